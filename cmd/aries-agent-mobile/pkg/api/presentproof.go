@@ -43,4 +43,20 @@ type PresentProofController interface {
 
 	// DeclinePresentation is used by the Verifier to decline a presentation.
 	DeclinePresentation(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// Records returns the records of the protocol instances matching the state, connection and thread ID.
+	Records(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// Record returns the record of the protocol instance.
+	Record(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// Presentations returns the presentations attached to the presentation of the protocol instance.
+	Presentations(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// Abandon moves a stale protocol instance to the abandoned state.
+	Abandon(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// CredentialSuggestions returns the stored credentials satisfying the presentation definition
+	// of the pending request presentation.
+	CredentialSuggestions(request *models.RequestEnvelope) *models.ResponseEnvelope
 }
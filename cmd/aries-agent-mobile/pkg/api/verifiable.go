@@ -16,6 +16,9 @@ type VerifiableController interface {
 	// ValidateCredential validates the verifiable credential.
 	ValidateCredential(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// VerifyCredential verifies the verifiable credential and returns the report with the outcome of each check.
+	VerifyCredential(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// SaveCredential saves the verifiable credential to the store.
 	SaveCredential(request *models.RequestEnvelope) *models.ResponseEnvelope
 
@@ -51,4 +54,35 @@ type VerifiableController interface {
 
 	// RemovePresentationByName will remove a VP that matches the specified name from the verifiable store.
 	RemovePresentationByName(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// RefreshCredential renews a VC that matches the specified name using its refresh service
+	// and replaces the stored VC.
+	RefreshCredential(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// UpdateCredentialName renames a VC that matches the specified name in the verifiable store.
+	UpdateCredentialName(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// AddCredentialTags adds tags to a VC that matches the specified name in the verifiable store.
+	AddCredentialTags(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// RemoveCredentialTags removes tags from a VC that matches the specified name in the verifiable store.
+	RemoveCredentialTags(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// CreateCollection creates a named collection to group the VCs of the verifiable store in.
+	CreateCollection(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// GetCollections retrieves the names of the VC collections.
+	GetCollections(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// RemoveCollection removes a VC collection, its VCs are kept in the verifiable store.
+	RemoveCollection(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// MoveCredential moves a VC that matches the specified name to a collection.
+	MoveCredential(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// GetCredentialsByCollection retrieves the records of the VCs of a collection.
+	GetCredentialsByCollection(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// RotateEncryptionKey creates a new encryption key for the verifiable store.
+	RotateEncryptionKey(request *models.RequestEnvelope) *models.ResponseEnvelope
 }
@@ -188,3 +188,84 @@ func (p *PresentProof) DeclinePresentation(request *models.RequestEnvelope) *mod
 
 	return &models.ResponseEnvelope{Payload: response}
 }
+
+// Records returns the records of the protocol instances matching the state, connection and thread ID.
+func (p *PresentProof) Records(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.RecordsArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.Records], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// Record returns the record of the protocol instance.
+func (p *PresentProof) Record(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.RecordArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.Record], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// Presentations returns the presentations attached to the presentation of the protocol instance.
+func (p *PresentProof) Presentations(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.PresentationsArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.Presentations], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// Abandon moves a stale protocol instance to the abandoned state.
+func (p *PresentProof) Abandon(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.AbandonArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.Abandon], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// CredentialSuggestions returns the stored credentials satisfying the presentation definition
+// of the pending request presentation.
+func (p *PresentProof) CredentialSuggestions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.CredentialSuggestionsArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.CredentialSuggestions], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
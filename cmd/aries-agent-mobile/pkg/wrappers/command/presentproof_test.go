@@ -249,3 +249,103 @@ func TestPresentProof_DeclinePresentation(t *testing.T) {
 			string(resp.Payload))
 	})
 }
+
+func TestPresentProof_Records(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.Records] = fakeHandler.exec
+
+		payload := mockPIID
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.Records(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestPresentProof_Record(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.Record] = fakeHandler.exec
+
+		payload := mockPIID
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.Record(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestPresentProof_Presentations(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.Presentations] = fakeHandler.exec
+
+		payload := mockPIID
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.Presentations(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestPresentProof_Abandon(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.Abandon] = fakeHandler.exec
+
+		payload := mockPIID
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.Abandon(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestPresentProof_CredentialSuggestions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.CredentialSuggestions] = fakeHandler.exec
+
+		payload := mockPIID
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.CredentialSuggestions(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
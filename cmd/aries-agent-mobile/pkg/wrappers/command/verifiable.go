@@ -35,6 +35,22 @@ func (v *Verifiable) ValidateCredential(request *models.RequestEnvelope) *models
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// VerifyCredential verifies the verifiable credential and returns the report with the outcome of each check.
+func (v *Verifiable) VerifyCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.Credential{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.VerifyCredentialCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // SaveCredential saves the verifiable credential to the store.
 func (v *Verifiable) SaveCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdverifiable.CredentialExt{}
@@ -214,3 +230,152 @@ func (v *Verifiable) RemovePresentationByName(request *models.RequestEnvelope) *
 
 	return &models.ResponseEnvelope{Payload: response}
 }
+
+// RefreshCredential renews a VC that matches the specified name using its refresh service
+// and replaces the stored VC.
+func (v *Verifiable) RefreshCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.NameArg{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.RefreshCredentialCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// UpdateCredentialName renames a VC that matches the specified name in the verifiable store.
+func (v *Verifiable) UpdateCredentialName(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.UpdateCredentialNameRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.UpdateCredentialNameCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// AddCredentialTags adds tags to a VC that matches the specified name in the verifiable store.
+func (v *Verifiable) AddCredentialTags(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.CredentialTagsRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.AddCredentialTagsCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RemoveCredentialTags removes tags from a VC that matches the specified name in the verifiable store.
+func (v *Verifiable) RemoveCredentialTags(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.CredentialTagsRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.RemoveCredentialTagsCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// CreateCollection creates a named collection to group the VCs of the verifiable store in.
+func (v *Verifiable) CreateCollection(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.CollectionRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.CreateCollectionCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// GetCollections retrieves the names of the VC collections.
+func (v *Verifiable) GetCollections(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	response, cmdErr := exec(v.handlers[cmdverifiable.GetCollectionsCommandMethod], request.Payload)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RemoveCollection removes a VC collection, its VCs are kept in the verifiable store.
+func (v *Verifiable) RemoveCollection(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.CollectionRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.RemoveCollectionCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// MoveCredential moves a VC that matches the specified name to a collection.
+func (v *Verifiable) MoveCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.MoveCredentialRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.MoveCredentialCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// GetCredentialsByCollection retrieves the records of the VCs of a collection.
+func (v *Verifiable) GetCredentialsByCollection(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.CollectionRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.GetCredentialsByCollectionCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RotateEncryptionKey creates a new encryption key for the verifiable store.
+func (v *Verifiable) RotateEncryptionKey(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	response, cmdErr := exec(v.handlers[cmdverifiable.RotateEncryptionKeyCommandMethod], request.Payload)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
@@ -109,6 +109,10 @@ func getVerifiableEndpoints() map[string]*endpoint {
 			Path:   opverifiable.ValidateCredentialPath,
 			Method: http.MethodPost,
 		},
+		cmdverifiable.VerifyCredentialCommandMethod: {
+			Path:   opverifiable.VerifyCredentialPath,
+			Method: http.MethodPost,
+		},
 		cmdverifiable.SaveCredentialCommandMethod: {
 			Path:   opverifiable.SaveCredentialPath,
 			Method: http.MethodPost,
@@ -157,6 +161,46 @@ func getVerifiableEndpoints() map[string]*endpoint {
 			Path:   opverifiable.RemovePresentationByNamePath,
 			Method: http.MethodPost,
 		},
+		cmdverifiable.RefreshCredentialCommandMethod: {
+			Path:   opverifiable.RefreshCredentialPath,
+			Method: http.MethodPost,
+		},
+		cmdverifiable.UpdateCredentialNameCommandMethod: {
+			Path:   opverifiable.UpdateCredentialNamePath,
+			Method: http.MethodPost,
+		},
+		cmdverifiable.AddCredentialTagsCommandMethod: {
+			Path:   opverifiable.AddCredentialTagsPath,
+			Method: http.MethodPost,
+		},
+		cmdverifiable.RemoveCredentialTagsCommandMethod: {
+			Path:   opverifiable.RemoveCredentialTagsPath,
+			Method: http.MethodPost,
+		},
+		cmdverifiable.CreateCollectionCommandMethod: {
+			Path:   opverifiable.CreateCollectionPath,
+			Method: http.MethodPost,
+		},
+		cmdverifiable.GetCollectionsCommandMethod: {
+			Path:   opverifiable.GetCollectionsPath,
+			Method: http.MethodGet,
+		},
+		cmdverifiable.RemoveCollectionCommandMethod: {
+			Path:   opverifiable.RemoveCollectionPath,
+			Method: http.MethodPost,
+		},
+		cmdverifiable.MoveCredentialCommandMethod: {
+			Path:   opverifiable.MoveCredentialPath,
+			Method: http.MethodPost,
+		},
+		cmdverifiable.GetCredentialsByCollectionCommandMethod: {
+			Path:   opverifiable.GetCredentialsByCollectionPath,
+			Method: http.MethodGet,
+		},
+		cmdverifiable.RotateEncryptionKeyCommandMethod: {
+			Path:   opverifiable.RotateEncryptionKeyPath,
+			Method: http.MethodPost,
+		},
 	}
 }
 
@@ -308,6 +352,26 @@ func getPresentProofEndpoints() map[string]*endpoint {
 			Path:   oppresproof.DeclinePresentation,
 			Method: http.MethodPost,
 		},
+		cmdpresproof.Records: {
+			Path:   oppresproof.Records,
+			Method: http.MethodGet,
+		},
+		cmdpresproof.Record: {
+			Path:   oppresproof.Record,
+			Method: http.MethodGet,
+		},
+		cmdpresproof.Presentations: {
+			Path:   oppresproof.Presentations,
+			Method: http.MethodGet,
+		},
+		cmdpresproof.Abandon: {
+			Path:   oppresproof.Abandon,
+			Method: http.MethodPost,
+		},
+		cmdpresproof.CredentialSuggestions: {
+			Path:   oppresproof.CredentialSuggestions,
+			Method: http.MethodGet,
+		},
 	}
 }
 
@@ -75,6 +75,32 @@ func (p *PresentProof) DeclinePresentation(request *models.RequestEnvelope) *mod
 	return p.createRespEnvelope(request, cmdpresproof.DeclinePresentation)
 }
 
+// Records returns the records of the protocol instances matching the state, connection and thread ID.
+func (p *PresentProof) Records(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.Records)
+}
+
+// Record returns the record of the protocol instance.
+func (p *PresentProof) Record(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.Record)
+}
+
+// Presentations returns the presentations attached to the presentation of the protocol instance.
+func (p *PresentProof) Presentations(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.Presentations)
+}
+
+// Abandon moves a stale protocol instance to the abandoned state.
+func (p *PresentProof) Abandon(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.Abandon)
+}
+
+// CredentialSuggestions returns the stored credentials satisfying the presentation definition
+// of the pending request presentation.
+func (p *PresentProof) CredentialSuggestions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.CredentialSuggestions)
+}
+
 func (p *PresentProof) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
 	return exec(&restOperation{
 		url:        p.URL,
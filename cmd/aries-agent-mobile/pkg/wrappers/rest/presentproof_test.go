@@ -278,3 +278,118 @@ func TestPresentProof_DeclinePresentation(t *testing.T) {
 		require.Equal(t, mockResponse, string(resp.Payload))
 	})
 }
+
+func TestPresentProof_Records(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		reqData := `{"state": "done"}`
+		mockURL, err := parseURL(mockAgentURL, oppresproof.Records, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodGet, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.Records(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestPresentProof_Record(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		reqData := fmt.Sprintf(`{"piid": "%s"}`, mockPIID)
+		mockURL, err := parseURL(mockAgentURL, oppresproof.Record, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodGet, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.Record(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestPresentProof_Presentations(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		reqData := fmt.Sprintf(`{"piid": "%s"}`, mockPIID)
+		mockURL, err := parseURL(mockAgentURL, oppresproof.Presentations, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodGet, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.Presentations(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestPresentProof_Abandon(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		reqData := fmt.Sprintf(`{"piid": "%s"}`, mockPIID)
+		mockURL, err := parseURL(mockAgentURL, oppresproof.Abandon, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.Abandon(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestPresentProof_CredentialSuggestions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		reqData := fmt.Sprintf(`{"piid": "%s"}`, mockPIID)
+		mockURL, err := parseURL(mockAgentURL, oppresproof.CredentialSuggestions, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodGet, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.CredentialSuggestions(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
@@ -25,6 +25,11 @@ func (vr *Verifiable) ValidateCredential(request *models.RequestEnvelope) *model
 	return vr.createRespEnvelope(request, cmdverifiable.ValidateCredentialCommandMethod)
 }
 
+// VerifyCredential verifies the verifiable credential and returns the report with the outcome of each check.
+func (vr *Verifiable) VerifyCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.VerifyCredentialCommandMethod)
+}
+
 // SaveCredential saves the verifiable credential to the store.
 func (vr *Verifiable) SaveCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return vr.createRespEnvelope(request, cmdverifiable.SaveCredentialCommandMethod)
@@ -85,6 +90,57 @@ func (vr *Verifiable) RemovePresentationByName(request *models.RequestEnvelope)
 	return vr.createRespEnvelope(request, cmdverifiable.RemovePresentationByNameCommandMethod)
 }
 
+// RefreshCredential renews a VC that matches the specified name using its refresh service
+// and replaces the stored VC.
+func (vr *Verifiable) RefreshCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.RefreshCredentialCommandMethod)
+}
+
+// UpdateCredentialName renames a VC that matches the specified name in the verifiable store.
+func (vr *Verifiable) UpdateCredentialName(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.UpdateCredentialNameCommandMethod)
+}
+
+// AddCredentialTags adds tags to a VC that matches the specified name in the verifiable store.
+func (vr *Verifiable) AddCredentialTags(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.AddCredentialTagsCommandMethod)
+}
+
+// RemoveCredentialTags removes tags from a VC that matches the specified name in the verifiable store.
+func (vr *Verifiable) RemoveCredentialTags(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.RemoveCredentialTagsCommandMethod)
+}
+
+// CreateCollection creates a named collection to group the VCs of the verifiable store in.
+func (vr *Verifiable) CreateCollection(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.CreateCollectionCommandMethod)
+}
+
+// GetCollections retrieves the names of the VC collections.
+func (vr *Verifiable) GetCollections(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.GetCollectionsCommandMethod)
+}
+
+// RemoveCollection removes a VC collection, its VCs are kept in the verifiable store.
+func (vr *Verifiable) RemoveCollection(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.RemoveCollectionCommandMethod)
+}
+
+// MoveCredential moves a VC that matches the specified name to a collection.
+func (vr *Verifiable) MoveCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.MoveCredentialCommandMethod)
+}
+
+// GetCredentialsByCollection retrieves the records of the VCs of a collection.
+func (vr *Verifiable) GetCredentialsByCollection(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.GetCredentialsByCollectionCommandMethod)
+}
+
+// RotateEncryptionKey creates a new encryption key for the verifiable store.
+func (vr *Verifiable) RotateEncryptionKey(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.RotateEncryptionKeyCommandMethod)
+}
+
 func (vr *Verifiable) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
 	return exec(&restOperation{
 		url:        vr.URL,
@@ -13,26 +13,19 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 	"github.com/spf13/cobra"
 
 	"github.com/hyperledger/aries-framework-go/component/storage/leveldb"
-	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
-	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
-	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
-	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/ws"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
-	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/config"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
-	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -156,10 +149,17 @@ const (
 		" Refer https://github.com/hyperledger/aries-framework-go/blob/8449c727c7c44f47ed7c9f10f35f0cd051dcb4e9/pkg/framework/aries/framework.go#L165-L168." + // nolint: lll
 		" Alternatively, this can be set with the following environment variable: " + agentTransportReturnRouteEnvKey
 
-	httpProtocol      = "http"
-	websocketProtocol = "ws"
+	// config file flag.
+	agentConfigFileFlagName  = "config-file"
+	agentConfigFileEnvKey    = "ARIESD_CONFIG_FILE"
+	agentConfigFileFlagUsage = "Path of a YAML or JSON file configuring the agent (optional)." +
+		" The flags and the environment variables override the values of the file." +
+		" Alternatively, this can be set with the following environment variable: " + agentConfigFileEnvKey
 
-	databaseTypeMemOption     = "mem"
+	httpProtocol      = config.HTTPScheme
+	websocketProtocol = config.WebsocketScheme
+
+	databaseTypeMemOption     = config.StorageTypeMem
 	databaseTypeLevelDBOption = "leveldb"
 )
 
@@ -178,6 +178,8 @@ type agentParameters struct {
 	autoAccept                                     bool
 	msgHandler                                     command.MessageHandler
 	dbParam                                        *dbParam
+	// config is the configuration read from the config file, if any.
+	config *config.Config
 }
 
 type dbParam struct {
@@ -186,14 +188,12 @@ type dbParam struct {
 	timeout uint64
 }
 
+// storageProviders are the storage types supported in addition to the in-memory storage.
 // nolint:gochecknoglobals
-var supportedStorageProviders = map[string]func(prefix string) (storage.Provider, error){
-	databaseTypeMemOption: func(_ string) (storage.Provider, error) { // nolint:unparam
-		return mem.NewProvider(), nil
-	},
-	databaseTypeLevelDBOption: func(path string) (storage.Provider, error) { // nolint:unparam
+var storageProviders = []config.Option{
+	config.WithStorageProvider(databaseTypeLevelDBOption, func(path string) (storage.Provider, error) {
 		return leveldb.NewProvider(path), nil
-	},
+	}),
 }
 
 type server interface {
@@ -227,12 +227,21 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen, gocyclo
 		Short: "Start an agent",
 		Long:  `Start an Aries agent controller`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			fileConfig, err := loadConfigFile(cmd)
+			if err != nil {
+				return err
+			}
+
 			// log level
 			logLevel, err := getUserSetVar(cmd, agentLogLevelFlagName, agentLogLevelEnvKey, true)
 			if err != nil {
 				return err
 			}
 
+			if logLevel == "" {
+				logLevel = fileConfig.LogLevel
+			}
+
 			err = setLogLevel(logLevel)
 			if err != nil {
 				return err
@@ -259,7 +268,7 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen, gocyclo
 				return err
 			}
 
-			dbParam, err := getDBParam(cmd)
+			dbParam, err := getDBParam(cmd, fileConfig.Storage.Type != "")
 			if err != nil {
 				return err
 			}
@@ -269,16 +278,25 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen, gocyclo
 				return err
 			}
 
-			autoAccept, err := getAutoAcceptValue(cmd)
+			if defaultLabel == "" {
+				defaultLabel = fileConfig.DefaultLabel
+			}
+
+			autoAccept, err := getAutoAcceptValue(cmd, fileConfig.AutoAccept)
 			if err != nil {
 				return err
 			}
 
-			webhookURLs, err := getUserSetVars(cmd, agentWebhookFlagName, agentWebhookEnvKey, autoAccept)
+			webhookURLs, err := getUserSetVars(cmd, agentWebhookFlagName, agentWebhookEnvKey,
+				autoAccept || len(fileConfig.WebhookURLs) > 0)
 			if err != nil {
 				return err
 			}
 
+			if len(webhookURLs) == 0 {
+				webhookURLs = fileConfig.WebhookURLs
+			}
+
 			httpResolvers, err := getUserSetVars(cmd, agentHTTPResolverFlagName, agentHTTPResolverEnvKey, true)
 			if err != nil {
 				return err
@@ -321,6 +339,7 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen, gocyclo
 				transportReturnRoute: transportReturnRoute,
 				tlsCertFile:          tlsCertFile,
 				tlsKeyFile:           tlsKeyFile,
+				config:               fileConfig,
 			}
 
 			return startAgent(parameters)
@@ -328,12 +347,31 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen, gocyclo
 	}
 }
 
-func getDBParam(cmd *cobra.Command) (*dbParam, error) {
+// loadConfigFile reads the config file, if set, otherwise it returns an empty configuration.
+func loadConfigFile(cmd *cobra.Command) (*config.Config, error) {
+	path, err := getUserSetVar(cmd, agentConfigFileFlagName, agentConfigFileEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		return &config.Config{}, nil
+	}
+
+	cfg, err := config.Load(path, storageProviders...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file %s : %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+func getDBParam(cmd *cobra.Command, isOptional bool) (*dbParam, error) {
 	dbParam := &dbParam{}
 
 	var err error
 
-	dbParam.dbType, err = getUserSetVar(cmd, databaseTypeFlagName, databaseTypeEnvKey, false)
+	dbParam.dbType, err = getUserSetVar(cmd, databaseTypeFlagName, databaseTypeEnvKey, isOptional)
 	if err != nil {
 		return nil, err
 	}
@@ -348,8 +386,9 @@ func getDBParam(cmd *cobra.Command) (*dbParam, error) {
 		return nil, err
 	}
 
-	if dbTimeout == "" || dbTimeout == "0" {
-		dbTimeout = databaseTimeoutDefault
+	// the config defaults the timeout to databaseTimeoutDefault
+	if dbTimeout == "" {
+		return dbParam, nil
 	}
 
 	t, err := strconv.Atoi(dbTimeout)
@@ -362,14 +401,14 @@ func getDBParam(cmd *cobra.Command) (*dbParam, error) {
 	return dbParam, nil
 }
 
-func getAutoAcceptValue(cmd *cobra.Command) (bool, error) {
+func getAutoAcceptValue(cmd *cobra.Command, defaultValue bool) (bool, error) {
 	v, err := getUserSetVar(cmd, agentAutoAcceptFlagName, agentAutoAcceptEnvKey, true)
 	if err != nil {
 		return false, err
 	}
 
 	if v == "" {
-		return false, nil
+		return defaultValue, nil
 	}
 
 	return strconv.ParseBool(v)
@@ -430,6 +469,9 @@ func createFlags(startCmd *cobra.Command) {
 
 	// db timeout
 	startCmd.Flags().StringP(databaseTimeoutFlagName, "", "", databaseTimeoutFlagUsage)
+
+	// config file
+	startCmd.Flags().StringP(agentConfigFileFlagName, "", "", agentConfigFileFlagUsage)
 }
 
 func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool) (string, error) {
@@ -478,113 +520,13 @@ func getUserSetVars(cmd *cobra.Command, flagName, envKey string, isOptional bool
 		"It must be set via either command line or environment variable", flagName)
 }
 
-func getResolverOpts(httpResolvers []string) ([]aries.Option, error) {
-	var opts []aries.Option
-
-	const numPartsResolverOption = 2
-
-	if len(httpResolvers) > 0 {
-		for _, httpResolver := range httpResolvers {
-			r := strings.Split(httpResolver, "@")
-			if len(r) != numPartsResolverOption {
-				return nil, fmt.Errorf("invalid http resolver options found")
-			}
-
-			httpVDR, err := httpbinding.New(r[1],
-				httpbinding.WithAccept(func(method string) bool { return method == r[0] }))
-			if err != nil {
-				return nil, fmt.Errorf("failed to setup http resolver :  %w", err)
-			}
-
-			opts = append(opts, aries.WithVDR(httpVDR))
-		}
-	}
-
-	return opts, nil
-}
-
-func getOutboundTransportOpts(outboundTransports []string) ([]aries.Option, error) {
-	var opts []aries.Option
-
-	var transports []transport.OutboundTransport
-
-	for _, outboundTransport := range outboundTransports {
-		switch outboundTransport {
-		case httpProtocol:
-			outbound, err := arieshttp.NewOutbound(arieshttp.WithOutboundHTTPClient(&http.Client{}))
-			if err != nil {
-				return nil, fmt.Errorf("http outbound transport initialization failed: %w", err)
-			}
-
-			transports = append(transports, outbound)
-		case websocketProtocol:
-			transports = append(transports, ws.NewOutbound())
-		default:
-			return nil, fmt.Errorf("outbound transport [%s] not supported", outboundTransport)
-		}
-	}
-
-	if len(transports) > 0 {
-		opts = append(opts, aries.WithOutboundTransports(transports...))
-	}
-
-	return opts, nil
-}
-
-func getInboundTransportOpts(inboundHostInternals, inboundHostExternals []string, certFile,
-	keyFile string) ([]aries.Option, error) {
-	internalHost, err := getInboundSchemeToURLMap(inboundHostInternals)
-	if err != nil {
-		return nil, fmt.Errorf("inbound internal host : %w", err)
-	}
-
-	externalHost, err := getInboundSchemeToURLMap(inboundHostExternals)
-	if err != nil {
-		return nil, fmt.Errorf("inbound external host : %w", err)
-	}
-
-	var opts []aries.Option
-
-	for scheme, host := range internalHost {
-		switch scheme {
-		case httpProtocol:
-			opts = append(opts, defaults.WithInboundHTTPAddr(host, externalHost[scheme], certFile, keyFile))
-		case websocketProtocol:
-			opts = append(opts, defaults.WithInboundWSAddr(host, externalHost[scheme], certFile, keyFile))
-		default:
-			return nil, fmt.Errorf("inbound transport [%s] not supported", scheme)
-		}
-	}
-
-	return opts, nil
-}
-
-func getInboundSchemeToURLMap(schemeHostStr []string) (map[string]string, error) {
-	const validSliceLen = 2
-
-	schemeHostMap := make(map[string]string)
-
-	for _, schemeHost := range schemeHostStr {
-		schemeHostSlice := strings.Split(schemeHost, "@")
-		if len(schemeHostSlice) != validSliceLen {
-			return nil, fmt.Errorf("invalid inbound host option: Use scheme@url to pass the option")
-		}
-
-		schemeHostMap[schemeHostSlice[0]] = schemeHostSlice[1]
-	}
-
-	return schemeHostMap, nil
-}
-
 func setLogLevel(logLevel string) error {
 	if logLevel != "" {
-		level, err := log.ParseLevel(logLevel)
+		err := (&config.Config{LogLevel: logLevel}).SetLogLevel()
 		if err != nil {
-			return fmt.Errorf("failed to parse log level '%s' : %w", logLevel, err)
+			return err
 		}
 
-		log.SetLevel("", level)
-
 		logger.Infof("logger level set to %s", logLevel)
 	}
 
@@ -667,43 +609,18 @@ func startAgent(parameters *agentParameters) error {
 }
 
 func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
-	var opts []aries.Option
-
-	storePro, err := createStoreProviders(parameters)
+	cfg, err := parameters.frameworkConfig()
 	if err != nil {
-		return nil, err
-	}
-
-	opts = append(opts, aries.WithStoreProvider(storePro))
-
-	if parameters.transportReturnRoute != "" {
-		opts = append(opts, aries.WithTransportReturnRoute(parameters.transportReturnRoute))
-	}
-
-	inboundTransportOpt, err := getInboundTransportOpts(parameters.inboundHostInternals,
-		parameters.inboundHostExternals, parameters.tlsCertFile, parameters.tlsKeyFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to inbound tranpsort opt : %w",
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], invalid configuration : %w",
 			parameters.host, err)
 	}
 
-	opts = append(opts, inboundTransportOpt...)
-
-	resolverOpts, err := getResolverOpts(parameters.httpResolvers)
+	opts, err := cfg.FrameworkOptions(storageProviders...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to resolver opts : %w",
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to create framework opts : %w",
 			parameters.host, err)
 	}
 
-	opts = append(opts, resolverOpts...)
-
-	outboundTransportOpts, err := getOutboundTransportOpts(parameters.outboundTransports)
-	if err != nil {
-		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to outbound transport opts : %w",
-			parameters.host, err)
-	}
-
-	opts = append(opts, outboundTransportOpts...)
 	opts = append(opts, aries.WithMessageServiceProvider(parameters.msgHandler))
 
 	framework, err := aries.New(opts...)
@@ -721,31 +638,58 @@ func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
 	return ctx, nil
 }
 
-func createStoreProviders(parameters *agentParameters) (storage.Provider, error) {
-	provider, supported := supportedStorageProviders[parameters.dbParam.dbType]
-	if !supported {
-		return nil, fmt.Errorf("key database type not set to a valid type." +
-			" run start --help to see the available options")
+// frameworkConfig returns the configuration of the config file overridden by the flags and environment variables.
+func (p *agentParameters) frameworkConfig() (*config.Config, error) { // nolint:gocyclo
+	cfg := &config.Config{}
+	if p.config != nil {
+		*cfg = *p.config
 	}
 
-	var store storage.Provider
+	if p.transportReturnRoute != "" {
+		cfg.TransportReturnRoute = p.transportReturnRoute
+	}
 
-	err := backoff.RetryNotify(
-		func() error {
-			var openErr error
-			store, openErr = provider(parameters.dbParam.prefix)
-			return openErr
-		},
-		backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), parameters.dbParam.timeout),
-		func(retryErr error, t time.Duration) {
-			logger.Warnf(
-				"failed to connect to storage, will sleep for %s before trying again : %s\n",
-				t, retryErr)
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to storage at %s : %w", parameters.dbParam.prefix, err)
+	if p.dbParam != nil {
+		if p.dbParam.dbType != "" {
+			cfg.Storage.Type = p.dbParam.dbType
+		}
+
+		if p.dbParam.prefix != "" {
+			cfg.Storage.Prefix = p.dbParam.prefix
+		}
+
+		if p.dbParam.timeout != 0 {
+			cfg.Storage.Timeout = p.dbParam.timeout
+		}
+	}
+
+	if len(p.inboundHostInternals) > 0 {
+		inbound, err := config.ParseInbound(p.inboundHostInternals, p.inboundHostExternals)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.Inbound = inbound
 	}
 
-	return store, nil
+	if len(p.outboundTransports) > 0 {
+		cfg.Outbound = p.outboundTransports
+	}
+
+	if len(p.httpResolvers) > 0 {
+		vdrs, err := config.ParseVDRs(p.httpResolvers)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.VDRs = vdrs
+	}
+
+	if p.tlsCertFile != "" || p.tlsKeyFile != "" {
+		cfg.TLS = config.TLSConfig{CertFile: p.tlsCertFile, KeyFile: p.tlsKeyFile}
+	}
+
+	cfg.SetDefaults()
+
+	return cfg, cfg.Validate(storageProviders...)
 }
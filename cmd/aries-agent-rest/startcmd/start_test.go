@@ -499,6 +499,102 @@ func TestStartCmdValidArgsEnvVar(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestStartCmdWithConfigFile(t *testing.T) {
+	// the environment variables override the config file
+	for _, key := range []string{agentInboundHostEnvKey, databaseTypeEnvKey, agentWebhookEnvKey, agentDefaultLabelEnvKey} {
+		require.NoError(t, os.Unsetenv(key))
+	}
+
+	t.Run("start with config file", func(t *testing.T) {
+		path := writeConfigFile(t, ".yaml", fmt.Sprintf(`
+defaultLabel: agent
+autoAccept: true
+storage:
+  type: %s
+inbound:
+  - scheme: %s
+    host: %s
+`, databaseTypeMemOption, httpProtocol, randomURL()))
+
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + agentConfigFileFlagName, path,
+		})
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	t.Run("flags override config file", func(t *testing.T) {
+		path := writeConfigFile(t, ".json", `{"storage": {"type": "mem"}, "outbound": ["ws"]}`)
+
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + agentConfigFileFlagName, path,
+			"--" + agentOutboundTransportFlagName, httpProtocol,
+			"--" + agentWebhookFlagName, "",
+		})
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	t.Run("invalid config file", func(t *testing.T) {
+		path := writeConfigFile(t, ".yaml", `
+storage:
+  type: mem
+outbound:
+  - wss
+`)
+
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + agentConfigFileFlagName, path,
+		})
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to load config file")
+		require.Contains(t, err.Error(), "outbound transport [wss] not supported")
+	})
+
+	t.Run("missing config file", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + agentConfigFileFlagName, "invalid.yaml",
+		})
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to load config file invalid.yaml")
+	})
+}
+
+func writeConfigFile(t *testing.T, ext, content string) string {
+	t.Helper()
+
+	file, err := ioutil.TempFile("", "agent-*"+ext)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { require.NoError(t, os.Remove(file.Name())) })
+
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	return file.Name()
+}
+
 func TestStartMultipleAgentsWithSameHost(t *testing.T) {
 	host := "localhost:8095"
 	inboundHost := "localhost:8096"
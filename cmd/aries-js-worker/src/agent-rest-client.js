@@ -383,6 +383,32 @@ const pkgs = {
             method: "POST",
             pathParam:"piid"
         },
+        Records: {
+            path: "/presentproof/records?state={state}&my_did={my_did}&their_did={their_did}&thread_id={thread_id}",
+            method: "GET",
+            queryStrings: ["state", "my_did", "their_did", "thread_id"]
+        },
+        Record: {
+            path: "/presentproof/{piid}/record",
+            method: "GET",
+            pathParam:"piid"
+        },
+        Presentations: {
+            path: "/presentproof/{piid}/presentations",
+            method: "GET",
+            pathParam:"piid"
+        },
+        Abandon: {
+            path: "/presentproof/{piid}/abandon?reason={reason}",
+            method: "POST",
+            pathParam:"piid",
+            queryStrings: ["reason"]
+        },
+        CredentialSuggestions: {
+            path: "/presentproof/{piid}/credential-suggestions",
+            method: "GET",
+            pathParam:"piid"
+        },
     },
     kms: {
         CreateKeySet: {
@@ -614,6 +614,51 @@ const Aries = function (opts) {
             declinePresentation: function (req) {
                 return invoke(aw, pending, this.pkgname, "DeclinePresentation", req, "timeout while declining a presentation")
             },
+            /**
+             * Returns the records of the protocol instances matching the state, connection and thread ID.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            records: function (req) {
+                return invoke(aw, pending, this.pkgname, "Records", req, "timeout while retrieving records")
+            },
+            /**
+             * Returns the record of the protocol instance.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            record: function (req) {
+                return invoke(aw, pending, this.pkgname, "Record", req, "timeout while retrieving a record")
+            },
+            /**
+             * Returns the presentations attached to the presentation of the protocol instance.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            presentations: function (req) {
+                return invoke(aw, pending, this.pkgname, "Presentations", req, "timeout while retrieving presentations")
+            },
+            /**
+             * Abandons a stale protocol instance.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            abandon: function (req) {
+                return invoke(aw, pending, this.pkgname, "Abandon", req, "timeout while abandoning a protocol instance")
+            },
+            /**
+             * Returns the stored credentials satisfying the presentation definition of the pending request presentation.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            credentialSuggestions: function (req) {
+                return invoke(aw, pending, this.pkgname, "CredentialSuggestions", req, "timeout while suggesting credentials")
+            },
         },
 
         /**
@@ -0,0 +1,14 @@
+// Copyright SecureKey Technologies Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+module github.com/hyperledger/aries-framework-go/component/eventbus/kafka
+
+go 1.16
+
+require (
+	github.com/google/uuid v1.1.2
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261015211733-ddc402c84753
+	github.com/segmentio/kafka-go v0.4.17
+	github.com/stretchr/testify v1.7.0
+)
@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package kafka implements the eventbus.Bus interface on top of Apache Kafka.
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+
+	"github.com/hyperledger/aries-framework-go/spi/eventbus"
+)
+
+const (
+	idHeader           = "aries-message-id"
+	defaultDialTimeout = 10 * time.Second
+)
+
+// Bus is the Kafka implementation of the eventbus.Bus interface. The messages with the same key are written to the
+// same partition, so they are delivered in order.
+type Bus struct {
+	brokers []string
+	writer  *kafka.Writer
+	dialer  *kafka.Dialer
+}
+
+// Option configures the Bus.
+type Option func(b *Bus)
+
+// WithTLS sets the TLS configuration of the connections to the brokers.
+func WithTLS(config *tls.Config) Option {
+	return func(b *Bus) {
+		b.dialer.TLS = config
+		b.writer.Transport = &kafka.Transport{TLS: config}
+	}
+}
+
+// New returns a new Bus connected to the given brokers.
+func New(brokers []string, opts ...Option) *Bus {
+	b := &Bus{
+		brokers: brokers,
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+		dialer: &kafka.Dialer{Timeout: defaultDialTimeout, DualStack: true},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Publish writes the message to its topic, keyed by the message key.
+func (b *Bus) Publish(ctx context.Context, msg *eventbus.Message) error {
+	err := b.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   msg.Topic,
+		Key:     []byte(msg.Key),
+		Value:   msg.Payload,
+		Headers: []kafka.Header{{Key: idHeader, Value: []byte(msg.ID)}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish message on %s: %w", msg.Topic, err)
+	}
+
+	return nil
+}
+
+// Subscribe returns the messages of the topic read by a consumer group. The subscriptions without group use a new
+// group, receiving all the messages of the topic. The offset of a message is committed once it has been received
+// from the channel.
+func (b *Bus) Subscribe(ctx context.Context, topic string,
+	options ...eventbus.SubscribeOption) (<-chan *eventbus.Message, error) {
+	opts := &eventbus.SubscribeOptions{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	group := opts.Group
+	if group == "" {
+		group = "aries-" + uuid.New().String()
+	}
+
+	startOffset := kafka.LastOffset
+	if opts.Replay {
+		startOffset = kafka.FirstOffset
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     b.brokers,
+		GroupID:     group,
+		Topic:       topic,
+		StartOffset: startOffset,
+		Dialer:      b.dialer,
+	})
+
+	messages := make(chan *eventbus.Message)
+
+	go func() {
+		defer close(messages)
+		defer closeReader(reader)
+
+		for {
+			m, err := reader.FetchMessage(ctx)
+			if err != nil {
+				if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+					log.Printf("failed to fetch message of %s: %s", topic, err)
+				}
+
+				return
+			}
+
+			select {
+			case messages <- toMessage(m):
+			case <-ctx.Done():
+				return
+			}
+
+			if err = reader.CommitMessages(context.Background(), m); err != nil {
+				log.Printf("failed to commit message of %s: %s", topic, err)
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+// Close closes the writer of the Bus, the subscriptions are closed with their context.
+func (b *Bus) Close() error {
+	if err := b.writer.Close(); err != nil {
+		return fmt.Errorf("failed to close kafka writer: %w", err)
+	}
+
+	return nil
+}
+
+func toMessage(m kafka.Message) *eventbus.Message {
+	msg := &eventbus.Message{
+		Topic:    m.Topic,
+		Key:      string(m.Key),
+		Payload:  m.Value,
+		Sequence: uint64(m.Offset),
+	}
+
+	for _, header := range m.Headers {
+		if header.Key == idHeader {
+			msg.ID = string(header.Value)
+		}
+	}
+
+	return msg
+}
+
+func closeReader(reader *kafka.Reader) {
+	if err := reader.Close(); err != nil {
+		log.Printf("failed to close kafka reader: %s", err)
+	}
+}
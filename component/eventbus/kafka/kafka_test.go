@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kafka_test
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+
+	kafkabus "github.com/hyperledger/aries-framework-go/component/eventbus/kafka"
+	"github.com/hyperledger/aries-framework-go/spi/eventbus"
+)
+
+// testBroker is started by scripts/start_eventbus_test_docker_images.sh.
+const testBroker = "localhost:9092"
+
+func createTopic(t *testing.T) string {
+	t.Helper()
+
+	topic := "aries-test-" + uuid.New().String()
+
+	conn, err := kafka.Dial("tcp", testBroker)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+
+	controller, err := conn.Controller()
+	require.NoError(t, err)
+
+	controllerConn, err := kafka.Dial("tcp", net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, controllerConn.Close())
+	}()
+
+	require.NoError(t, controllerConn.CreateTopics(kafka.TopicConfig{
+		Topic:             topic,
+		NumPartitions:     2,
+		ReplicationFactor: 1,
+	}))
+
+	return topic
+}
+
+func receive(t *testing.T, messages <-chan *eventbus.Message) *eventbus.Message {
+	t.Helper()
+
+	select {
+	case msg, ok := <-messages:
+		require.True(t, ok)
+
+		return msg
+	case <-time.After(30 * time.Second):
+		require.Fail(t, "timeout")
+	}
+
+	return nil
+}
+
+// waitClosed discards the messages until the subscription is closed.
+func waitClosed(messages <-chan *eventbus.Message) {
+	for range messages { // nolint:revive // discarded
+	}
+}
+
+func TestBus(t *testing.T) {
+	bus := kafkabus.New([]string{testBroker})
+
+	defer func() {
+		require.NoError(t, bus.Close())
+	}()
+
+	t.Run("replay and ordering by key", func(t *testing.T) {
+		topic := createTopic(t)
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, bus.Publish(context.Background(), &eventbus.Message{
+				ID:      strconv.Itoa(i),
+				Topic:   topic,
+				Key:     "piid",
+				Payload: []byte("event " + strconv.Itoa(i)),
+			}))
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		messages, err := bus.Subscribe(ctx, topic, eventbus.WithReplay())
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			msg := receive(t, messages)
+			require.Equal(t, strconv.Itoa(i), msg.ID)
+			require.Equal(t, topic, msg.Topic)
+			require.Equal(t, "piid", msg.Key)
+			require.Equal(t, "event "+strconv.Itoa(i), string(msg.Payload))
+		}
+
+		cancel()
+		waitClosed(messages)
+	})
+
+	t.Run("group resumes from its position", func(t *testing.T) {
+		topic := createTopic(t)
+		group := "group-" + uuid.New().String()
+
+		require.NoError(t, bus.Publish(context.Background(), &eventbus.Message{
+			ID: "1", Topic: topic, Key: "piid", Payload: []byte("first"),
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		messages, err := bus.Subscribe(ctx, topic, eventbus.WithGroup(group), eventbus.WithReplay())
+		require.NoError(t, err)
+
+		require.Equal(t, "1", receive(t, messages).ID)
+
+		cancel()
+		waitClosed(messages)
+
+		require.NoError(t, bus.Publish(context.Background(), &eventbus.Message{
+			ID: "2", Topic: topic, Key: "piid", Payload: []byte("second"),
+		}))
+
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+
+		messages, err = bus.Subscribe(ctx, topic, eventbus.WithGroup(group), eventbus.WithReplay())
+		require.NoError(t, err)
+
+		require.Equal(t, "2", receive(t, messages).ID)
+	})
+
+	t.Run("publish error", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		err := kafkabus.New([]string{"localhost:1"}).Publish(ctx, &eventbus.Message{Topic: "topic"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to publish message on topic")
+	})
+}
@@ -0,0 +1,14 @@
+// Copyright SecureKey Technologies Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+module github.com/hyperledger/aries-framework-go/component/eventbus/nats
+
+go 1.16
+
+require (
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261015211733-ddc402c84753
+	github.com/nats-io/nats-server/v2 v2.4.0
+	github.com/nats-io/nats.go v1.12.0
+	github.com/stretchr/testify v1.7.0
+)
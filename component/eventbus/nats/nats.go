@@ -0,0 +1,197 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package nats implements the eventbus.Bus interface on top of NATS JetStream.
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/hyperledger/aries-framework-go/spi/eventbus"
+)
+
+const (
+	defaultStreamName    = "ARIES"
+	defaultSubjectPrefix = "eventbus."
+	keyHeader            = "Aries-Message-Key"
+)
+
+// Bus is the NATS JetStream implementation of the eventbus.Bus interface. The topics are the subjects of a stream,
+// retaining the messages for the replays.
+type Bus struct {
+	js            nats.JetStreamContext
+	streamName    string
+	subjectPrefix string
+}
+
+// Option configures the Bus.
+type Option func(b *Bus)
+
+// WithStreamName sets the name of the JetStream stream of the messages ("ARIES" by default).
+func WithStreamName(name string) Option {
+	return func(b *Bus) {
+		b.streamName = name
+	}
+}
+
+// WithSubjectPrefix sets the prefix of the subjects of the topics ("eventbus." by default), the stream is bound to
+// the subjects starting with it.
+func WithSubjectPrefix(prefix string) Option {
+	return func(b *Bus) {
+		b.subjectPrefix = prefix
+	}
+}
+
+// New returns a new Bus using the given connection, the stream is created if it does not exist yet. The connection is
+// owned by the caller.
+func New(conn *nats.Conn, opts ...Option) (*Bus, error) {
+	b := &Bus{streamName: defaultStreamName, subjectPrefix: defaultSubjectPrefix}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jetstream context: %w", err)
+	}
+
+	_, err = js.StreamInfo(b.streamName)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = js.AddStream(&nats.StreamConfig{
+			Name:     b.streamName,
+			Subjects: []string{b.subjectPrefix + ">"},
+			Storage:  nats.FileStorage,
+		})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stream %s: %w", b.streamName, err)
+	}
+
+	b.js = js
+
+	return b, nil
+}
+
+// Publish publishes the message on the subject of its topic. The message ID is the JetStream message ID, the messages
+// published twice are dropped by the stream.
+func (b *Bus) Publish(ctx context.Context, msg *eventbus.Message) error {
+	m := nats.NewMsg(b.subjectPrefix + msg.Topic)
+	m.Data = msg.Payload
+	m.Header.Set(keyHeader, msg.Key)
+
+	if msg.ID != "" {
+		m.Header.Set(nats.MsgIdHdr, msg.ID)
+	}
+
+	if _, err := b.js.PublishMsg(m, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("failed to publish message on %s: %w", msg.Topic, err)
+	}
+
+	return nil
+}
+
+// Subscribe returns the messages of the topic. The group is the name of a durable consumer shared by the
+// subscribers of the group, it must not contain '.', '*' or '>'. A message is acknowledged once it has been received
+// from the channel.
+func (b *Bus) Subscribe(ctx context.Context, topic string,
+	options ...eventbus.SubscribeOption) (<-chan *eventbus.Message, error) {
+	opts := &eventbus.SubscribeOptions{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	subOpts := []nats.SubOpt{nats.ManualAck(), nats.DeliverNew()}
+	if opts.Replay {
+		subOpts[1] = nats.DeliverAll()
+	}
+
+	var (
+		messages = make(chan *eventbus.Message)
+		mu       sync.Mutex
+		closed   bool
+	)
+
+	handler := func(m *nats.Msg) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if closed {
+			return
+		}
+
+		select {
+		case messages <- b.toMessage(topic, m):
+		case <-ctx.Done():
+			return
+		}
+
+		if err := m.Ack(); err != nil {
+			log.Printf("failed to ack message of %s: %s", topic, err)
+		}
+	}
+
+	var (
+		sub *nats.Subscription
+		err error
+	)
+
+	if opts.Group == "" {
+		sub, err = b.js.Subscribe(b.subjectPrefix+topic, handler, subOpts...)
+	} else {
+		subOpts = append(subOpts, nats.Durable(opts.Group))
+		sub, err = b.js.QueueSubscribe(b.subjectPrefix+topic, opts.Group, handler, subOpts...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		if err := sub.Unsubscribe(); err != nil {
+			log.Printf("failed to unsubscribe from %s: %s", topic, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		closed = true
+
+		close(messages)
+	}()
+
+	return messages, nil
+}
+
+// Close does nothing, the connection is owned by the caller and the subscriptions are closed with their context.
+func (b *Bus) Close() error {
+	return nil
+}
+
+func (b *Bus) toMessage(topic string, m *nats.Msg) *eventbus.Message {
+	msg := &eventbus.Message{
+		ID:      m.Header.Get(nats.MsgIdHdr),
+		Topic:   topic,
+		Key:     m.Header.Get(keyHeader),
+		Payload: m.Data,
+	}
+
+	if meta, err := m.Metadata(); err == nil {
+		msg.Sequence = meta.Sequence.Stream
+	}
+
+	return msg
+}
@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nats_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	natsbus "github.com/hyperledger/aries-framework-go/component/eventbus/nats"
+	"github.com/hyperledger/aries-framework-go/spi/eventbus"
+)
+
+func newConn(t *testing.T) *nats.Conn {
+	t.Helper()
+
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	go srv.Start()
+
+	require.True(t, srv.ReadyForConnections(10*time.Second))
+
+	conn, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Shutdown()
+	})
+
+	return conn
+}
+
+func receive(t *testing.T, messages <-chan *eventbus.Message) *eventbus.Message {
+	t.Helper()
+
+	select {
+	case msg, ok := <-messages:
+		require.True(t, ok)
+
+		return msg
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout")
+	}
+
+	return nil
+}
+
+func publish(t *testing.T, bus *natsbus.Bus, id, topic string) {
+	t.Helper()
+
+	require.NoError(t, bus.Publish(context.Background(), &eventbus.Message{
+		ID:      id,
+		Topic:   topic,
+		Key:     "piid",
+		Payload: []byte("event " + id),
+	}))
+}
+
+func TestBus(t *testing.T) {
+	conn := newConn(t)
+
+	bus, err := natsbus.New(conn)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, bus.Close())
+	}()
+
+	t.Run("new messages", func(t *testing.T) {
+		publish(t, bus, "old", "new-messages")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		messages, err := bus.Subscribe(ctx, "new-messages")
+		require.NoError(t, err)
+
+		publish(t, bus, "new", "new-messages")
+
+		msg := receive(t, messages)
+		require.Equal(t, "new", msg.ID)
+		require.Equal(t, "new-messages", msg.Topic)
+		require.Equal(t, "piid", msg.Key)
+		require.Equal(t, "event new", string(msg.Payload))
+		require.Equal(t, uint64(2), msg.Sequence)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			publish(t, bus, strconv.Itoa(i), "replay")
+		}
+
+		// published twice, dropped by the stream
+		publish(t, bus, "2", "replay")
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		messages, err := bus.Subscribe(ctx, "replay", eventbus.WithReplay())
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			require.Equal(t, strconv.Itoa(i), receive(t, messages).ID)
+		}
+
+		publish(t, bus, "3", "replay")
+		require.Equal(t, "3", receive(t, messages).ID)
+
+		cancel()
+
+		select {
+		case _, ok := <-messages:
+			require.False(t, ok)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "subscription not closed")
+		}
+	})
+
+	t.Run("group", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		messages, err := bus.Subscribe(ctx, "group", eventbus.WithGroup("backend"))
+		require.NoError(t, err)
+
+		// the message IDs are deduplicated across the stream
+		publish(t, bus, "group-1", "group")
+		require.Equal(t, "group-1", receive(t, messages).ID)
+	})
+
+	t.Run("other stream", func(t *testing.T) {
+		other, err := natsbus.New(conn, natsbus.WithStreamName("OTHER"), natsbus.WithSubjectPrefix("other."))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		messages, err := other.Subscribe(ctx, "topic", eventbus.WithReplay())
+		require.NoError(t, err)
+
+		publish(t, bus, "1", "topic")
+		publish(t, other, "2", "topic")
+
+		msg := receive(t, messages)
+		require.Equal(t, "2", msg.ID)
+		require.Equal(t, uint64(1), msg.Sequence)
+	})
+}
+
+func TestNew_Error(t *testing.T) {
+	conn := newConn(t)
+	conn.Close()
+
+	_, err := natsbus.New(conn)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to get stream ARIES")
+}
@@ -0,0 +1,139 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package etcd implements the lock.Locker interface on top of etcd.
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+const (
+	defaultKeyPrefix = "/aries/lock/"
+	defaultTTL       = 30 // seconds
+)
+
+// Locker is the etcd implementation of the lock.Locker interface. The locks are attached to an etcd session lease:
+// they are released when the agent instance holding them stops refreshing the lease.
+type Locker struct {
+	client    *clientv3.Client
+	keyPrefix string
+	ttl       int
+
+	session *concurrency.Session
+	mu      sync.Mutex
+}
+
+// Option configures the Locker.
+type Option func(l *Locker)
+
+// WithKeyPrefix sets the prefix of the etcd keys of the locks ("/aries/lock/" by default).
+func WithKeyPrefix(prefix string) Option {
+	return func(l *Locker) {
+		l.keyPrefix = prefix
+	}
+}
+
+// WithTTL sets the TTL in seconds of the session lease (30 seconds by default): the locks of a crashed agent instance
+// are released after it.
+func WithTTL(ttl int) Option {
+	return func(l *Locker) {
+		l.ttl = ttl
+	}
+}
+
+// New returns a new Locker using the given client. The client is owned by the caller.
+func New(client *clientv3.Client, opts ...Option) *Locker {
+	l := &Locker{client: client, keyPrefix: defaultKeyPrefix, ttl: defaultTTL}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Lock acquires the lock with the given name, waiting until it is released by its current holder.
+func (l *Locker) Lock(ctx context.Context, name string) (lock.Unlocker, error) {
+	session, err := l.getSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+
+	mutex := concurrency.NewMutex(session, l.keyPrefix+name)
+
+	if err = mutex.Lock(ctx); err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+
+	return &etcdLock{session: session, mutex: mutex, name: name}, nil
+}
+
+// Close closes the session of the Locker, the locks still held are released.
+func (l *Locker) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.session == nil {
+		return nil
+	}
+
+	err := l.session.Close()
+	l.session = nil
+
+	return err
+}
+
+// getSession returns the session of the Locker, a new session is created if the lease of the previous one expired.
+func (l *Locker) getSession() (*concurrency.Session, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.session != nil {
+		select {
+		case <-l.session.Done():
+		default:
+			return l.session, nil
+		}
+	}
+
+	session, err := concurrency.NewSession(l.client, concurrency.WithTTL(l.ttl))
+	if err != nil {
+		return nil, fmt.Errorf("new session: %w", err)
+	}
+
+	l.session = session
+
+	return session, nil
+}
+
+type etcdLock struct {
+	session *concurrency.Session
+	mutex   *concurrency.Mutex
+	name    string
+}
+
+// Unlock releases the lock.
+func (l *etcdLock) Unlock() error {
+	select {
+	case <-l.session.Done():
+		return fmt.Errorf("release lock %s: %w", l.name, lock.ErrLockNotHeld)
+	default:
+	}
+
+	if err := l.mutex.Unlock(context.Background()); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", l.name, err)
+	}
+
+	return nil
+}
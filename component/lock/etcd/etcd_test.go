@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcd_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"github.com/hyperledger/aries-framework-go/component/lock/etcd"
+)
+
+func newClient(t *testing.T) *clientv3.Client {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "etcd")
+	require.NoError(t, err)
+
+	cfg := embed.NewConfig()
+	cfg.Dir = dir
+	cfg.LogLevel = "error"
+
+	clientURL, err := url.Parse("http://localhost:0")
+	require.NoError(t, err)
+
+	peerURL, err := url.Parse("http://localhost:0")
+	require.NoError(t, err)
+
+	cfg.LCUrls, cfg.ACUrls = []url.URL{*clientURL}, []url.URL{*clientURL}
+	cfg.LPUrls, cfg.APUrls = []url.URL{*peerURL}, []url.URL{*peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	server, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+
+	select {
+	case <-server.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "etcd server did not start")
+	}
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{server.Clients[0].Addr().String()},
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+		server.Close()
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	return client
+}
+
+func TestLocker(t *testing.T) {
+	client := newClient(t)
+
+	replica1 := etcd.New(client, etcd.WithTTL(5))
+	replica2 := etcd.New(client, etcd.WithKeyPrefix("/aries/lock/"))
+
+	defer func() {
+		require.NoError(t, replica1.Close())
+		require.NoError(t, replica2.Close())
+	}()
+
+	unlocker, err := replica1.Lock(context.Background(), "piid")
+	require.NoError(t, err)
+
+	t.Run("lock is exclusive", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		_, err = replica2.Lock(ctx, "piid")
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("released lock is acquired", func(t *testing.T) {
+		acquired := make(chan error)
+
+		go func() {
+			unlocker2, errLock := replica2.Lock(context.Background(), "piid")
+			if errLock == nil {
+				errLock = unlocker2.Unlock()
+			}
+
+			acquired <- errLock
+		}()
+
+		require.NoError(t, unlocker.Unlock())
+
+		select {
+		case err = <-acquired:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "released lock was not acquired")
+		}
+	})
+
+	t.Run("locks of closed session are released", func(t *testing.T) {
+		_, err = replica1.Lock(context.Background(), "other")
+		require.NoError(t, err)
+
+		require.NoError(t, replica1.Close())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		unlocker, err = replica2.Lock(ctx, "other")
+		require.NoError(t, err)
+		require.NoError(t, unlocker.Unlock())
+
+		// a new session is created
+		unlocker, err = replica1.Lock(context.Background(), "other")
+		require.NoError(t, err)
+		require.NoError(t, unlocker.Unlock())
+	})
+}
@@ -0,0 +1,14 @@
+// Copyright SecureKey Technologies Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+module github.com/hyperledger/aries-framework-go/component/lock/etcd
+
+go 1.16
+
+require (
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261015211733-ddc402c84753
+	github.com/stretchr/testify v1.7.0
+	go.etcd.io/etcd/client/v3 v3.5.0
+	go.etcd.io/etcd/server/v3 v3.5.0
+)
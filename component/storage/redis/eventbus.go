@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Notifier sends the notifications of a topic, e.g. the webnotifier of the agent controller.
+type Notifier interface {
+	Notify(topic string, message []byte) error
+}
+
+// Event is a message published on the event bus.
+type Event struct {
+	Topic   string
+	Message []byte
+}
+
+// EventBus distributes the notifications of the agent replicas through Redis pub/sub.
+//
+// The replicas notify the events (e.g. the actions and state changes of the protocols) to the EventBus instead of
+// their controller notifier, the replica holding the controller subscription forwards them to its notifier.
+type EventBus struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewEventBus returns a new EventBus using the given client. The key prefix ("aries" by default) is the prefix of
+// the Redis channels.
+func NewEventBus(client redis.UniversalClient, opts ...Option) *EventBus {
+	p := &Provider{keyPrefix: defaultKeyPrefix}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return &EventBus{client: client, keyPrefix: p.keyPrefix}
+}
+
+// Notify publishes the message of the topic to the subscribers of all replicas.
+func (b *EventBus) Notify(topic string, message []byte) error {
+	err := b.client.Publish(context.Background(), b.channel(topic), message).Err()
+	if err != nil {
+		return fmt.Errorf("failed to publish event of topic %s: %w", topic, err)
+	}
+
+	return nil
+}
+
+// Subscribe returns the events published on the topics (all topics if none is given).
+// The channel is closed when ctx is done.
+func (b *EventBus) Subscribe(ctx context.Context, topics ...string) (<-chan *Event, error) {
+	var pubSub *redis.PubSub
+
+	if len(topics) == 0 {
+		pubSub = b.client.PSubscribe(ctx, b.channel("*"))
+	} else {
+		channels := make([]string, len(topics))
+
+		for i, topic := range topics {
+			channels[i] = b.channel(topic)
+		}
+
+		pubSub = b.client.Subscribe(ctx, channels...)
+	}
+
+	// wait for the subscription to be confirmed, the events published before are not received
+	if _, err := pubSub.Receive(ctx); err != nil {
+		closePubSub(pubSub)
+
+		return nil, fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+
+	events := make(chan *Event)
+
+	go func() {
+		defer close(events)
+		defer closePubSub(pubSub)
+
+		messages := pubSub.Channel()
+
+		for {
+			select {
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				event := &Event{
+					Topic:   strings.TrimPrefix(msg.Channel, b.channel("")),
+					Message: []byte(msg.Payload),
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// Forward forwards the events published on the topics (all topics if none is given) to the notifier until ctx is
+// done. It is used by the replica holding the controller subscription.
+func (b *EventBus) Forward(ctx context.Context, notifier Notifier, topics ...string) error {
+	events, err := b.Subscribe(ctx, topics...)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for event := range events {
+			if err := notifier.Notify(event.Topic, event.Message); err != nil {
+				log.Printf("failed to forward event of topic %s: %s", event.Topic, err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (b *EventBus) channel(topic string) string {
+	return b.keyPrefix + ":events:" + topic
+}
+
+func closePubSub(pubSub *redis.PubSub) {
+	if err := pubSub.Close(); err != nil {
+		log.Printf("failed to close redis subscription: %s", err)
+	}
+}
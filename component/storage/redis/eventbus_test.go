@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	redisstorage "github.com/hyperledger/aries-framework-go/component/storage/redis"
+)
+
+func TestEventBus(t *testing.T) {
+	_, client := newClient(t)
+
+	replica1 := redisstorage.NewEventBus(client)
+	replica2 := redisstorage.NewEventBus(client)
+
+	t.Run("subscribe to topics", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := replica2.Subscribe(ctx, "didexchange_actions")
+		require.NoError(t, err)
+
+		require.NoError(t, replica1.Notify("other_topic", []byte("ignored")))
+		require.NoError(t, replica1.Notify("didexchange_actions", []byte("action")))
+
+		select {
+		case event := <-events:
+			require.Equal(t, "didexchange_actions", event.Topic)
+			require.Equal(t, "action", string(event.Message))
+		case <-time.After(time.Second):
+			require.Fail(t, "event was not received")
+		}
+
+		cancel()
+
+		select {
+		case _, ok := <-events:
+			require.False(t, ok)
+		case <-time.After(time.Second):
+			require.Fail(t, "events channel was not closed")
+		}
+	})
+
+	t.Run("forward all topics", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		notifier := &mockNotifier{notified: make(chan [2]string, 1)}
+
+		require.NoError(t, replica2.Forward(ctx, notifier))
+		require.NoError(t, replica1.Notify("issuecredential_states", []byte("state")))
+
+		select {
+		case notified := <-notifier.notified:
+			require.Equal(t, [2]string{"issuecredential_states", "state"}, notified)
+		case <-time.After(time.Second):
+			require.Fail(t, "event was not forwarded")
+		}
+	})
+
+	t.Run("other prefix", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := redisstorage.NewEventBus(client, redisstorage.WithKeyPrefix("other")).Subscribe(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, replica1.Notify("topic", []byte("message")))
+
+		select {
+		case <-events:
+			require.Fail(t, "unexpected event of another prefix")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
+type mockNotifier struct {
+	notified chan [2]string
+}
+
+func (n *mockNotifier) Notify(topic string, message []byte) error {
+	n.notified <- [2]string{topic, string(message)}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+// Copyright SecureKey Technologies Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+module github.com/hyperledger/aries-framework-go/component/storage/redis
+
+go 1.16
+
+require (
+	github.com/alicebob/miniredis/v2 v2.14.3
+	github.com/go-redis/redis/v8 v8.8.2
+	github.com/google/uuid v1.1.2
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261015211733-ddc402c84753
+	github.com/hyperledger/aries-framework-go/test/component v0.0.0-20210409151411-eeeb8508bd87
+	github.com/stretchr/testify v1.7.0
+)
+
+replace github.com/hyperledger/aries-framework-go/test/component => ../../../test/component
@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+const (
+	defaultLockTTL        = 30 * time.Second
+	defaultLockRetryDelay = 50 * time.Millisecond
+)
+
+// unlockScript deletes the lock only if it is still held by the token of the caller.
+// nolint:gochecknoglobals
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Locker is the Redis implementation of the lock.Locker interface. A lock is a Redis key set if not already set,
+// the key expires after the lock TTL so the locks of crashed agent instances are released.
+type Locker struct {
+	client     redis.UniversalClient
+	keyPrefix  string
+	ttl        time.Duration
+	retryDelay time.Duration
+}
+
+// LockerOption configures the Locker.
+type LockerOption func(l *Locker)
+
+// WithLockKeyPrefix sets the prefix of the Redis keys of the locks ("aries" by default).
+func WithLockKeyPrefix(prefix string) LockerOption {
+	return func(l *Locker) {
+		l.keyPrefix = prefix
+	}
+}
+
+// WithLockTTL sets the time after which a lock is released if its holder did not release it (30 seconds by default).
+// It must be longer than the processing of a protocol message.
+func WithLockTTL(ttl time.Duration) LockerOption {
+	return func(l *Locker) {
+		l.ttl = ttl
+	}
+}
+
+// WithLockRetryDelay sets the delay between the attempts to acquire a held lock (50 milliseconds by default).
+func WithLockRetryDelay(delay time.Duration) LockerOption {
+	return func(l *Locker) {
+		l.retryDelay = delay
+	}
+}
+
+// NewLocker returns a new Locker using the given client.
+func NewLocker(client redis.UniversalClient, opts ...LockerOption) *Locker {
+	l := &Locker{
+		client:     client,
+		keyPrefix:  defaultKeyPrefix,
+		ttl:        defaultLockTTL,
+		retryDelay: defaultLockRetryDelay,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Lock acquires the lock with the given name, waiting until it is released or expires.
+func (l *Locker) Lock(ctx context.Context, name string) (lock.Unlocker, error) {
+	key := l.keyPrefix + ":lock:" + name
+	token := uuid.New().String()
+
+	for {
+		acquired, err := l.client.SetNX(ctx, key, token, l.ttl).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+		}
+
+		if acquired {
+			return &redisLock{client: l.client, key: key, token: token}, nil
+		}
+
+		select {
+		case <-time.After(l.retryDelay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to acquire lock %s: %w", name, ctx.Err())
+		}
+	}
+}
+
+type redisLock struct {
+	client redis.UniversalClient
+	key    string
+	token  string
+}
+
+// Unlock releases the lock if it was not acquired by another holder since it expired.
+func (l *redisLock) Unlock() error {
+	deleted, err := unlockScript.Run(context.Background(), l.client, []string{l.key}, l.token).Int()
+	if err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", l.key, err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("release lock %s: %w", l.key, lock.ErrLockNotHeld)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	redisstorage "github.com/hyperledger/aries-framework-go/component/storage/redis"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+func TestLocker(t *testing.T) {
+	server, client := newClient(t)
+
+	t.Run("lock is exclusive", func(t *testing.T) {
+		replica1 := redisstorage.NewLocker(client, redisstorage.WithLockRetryDelay(time.Millisecond))
+		replica2 := redisstorage.NewLocker(client, redisstorage.WithLockRetryDelay(time.Millisecond))
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			holders int
+		)
+
+		for _, locker := range []*redisstorage.Locker{replica1, replica2, replica1, replica2} {
+			wg.Add(1)
+
+			go func(locker *redisstorage.Locker) {
+				defer wg.Done()
+
+				unlocker, err := locker.Lock(context.Background(), "piid")
+				require.NoError(t, err)
+
+				mu.Lock()
+				holders++
+				require.Equal(t, 1, holders)
+				mu.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				mu.Lock()
+				holders--
+				mu.Unlock()
+
+				require.NoError(t, unlocker.Unlock())
+			}(locker)
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("context done while waiting", func(t *testing.T) {
+		locker := redisstorage.NewLocker(client)
+
+		unlocker, err := locker.Lock(context.Background(), "held")
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, unlocker.Unlock())
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err = locker.Lock(ctx, "held")
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("expired lock", func(t *testing.T) {
+		locker := redisstorage.NewLocker(client, redisstorage.WithLockTTL(time.Second),
+			redisstorage.WithLockKeyPrefix("other"))
+
+		unlocker, err := locker.Lock(context.Background(), "expiring")
+		require.NoError(t, err)
+
+		server.FastForward(2 * time.Second)
+
+		unlocker2, err := locker.Lock(context.Background(), "expiring")
+		require.NoError(t, err)
+
+		err = unlocker.Unlock()
+		require.True(t, errors.Is(err, lock.ErrLockNotHeld))
+
+		require.NoError(t, unlocker2.Unlock())
+	})
+
+	t.Run("server unavailable", func(t *testing.T) {
+		server.Close()
+
+		_, err := redisstorage.NewLocker(client).Lock(context.Background(), "piid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to acquire lock piid")
+	})
+}
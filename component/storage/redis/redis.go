@@ -0,0 +1,599 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package redis implements the storage.Provider interface on top of Redis. Multiple agent replicas using the same
+// Redis server (or cluster) share their stores, so they can run behind a load balancer without sticky sessions.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	defaultKeyPrefix = "aries"
+	defaultPageSize  = 25
+	// maxTxRetries is the number of attempts of the write transactions conflicting with concurrent writes.
+	maxTxRetries = 100
+
+	expressionTagNameOnlyLength     = 1
+	expressionTagNameAndValueLength = 2
+
+	invalidTagName               = `"%s" is an invalid tag name since it contains one or more ':' characters`
+	invalidTagValue              = `"%s" is an invalid tag value since it contains one or more ':' characters`
+	invalidQueryExpressionFormat = `"%s" is not in a valid expression format. ` +
+		"it must be in the following format: TagName:TagValue"
+)
+
+var (
+	errEmptyKey   = errors.New("key cannot be blank")
+	errTxConflict = errors.New("transaction aborted after too many conflicts with concurrent writes")
+)
+
+// Provider is the Redis implementation of the storage.Provider interface.
+// The data of a store are kept under Redis keys starting with the key prefix and the store name, the tags are indexed
+// with Redis sets. The keys of a store share the same hash tag, so the provider can be used with a Redis cluster.
+type Provider struct {
+	client    redis.UniversalClient
+	keyPrefix string
+	dbs       map[string]*store
+	lock      sync.RWMutex
+}
+
+// Option configures the Redis storage Provider.
+type Option func(p *Provider)
+
+// WithKeyPrefix sets the prefix of the Redis keys used by the provider ("aries" by default), e.g. to share a Redis
+// server between several agents.
+func WithKeyPrefix(prefix string) Option {
+	return func(p *Provider) {
+		p.keyPrefix = prefix
+	}
+}
+
+type closer func(storeName string)
+
+type dbEntry struct {
+	Value []byte        `json:"value"`
+	Tags  []storage.Tag `json:"tags,omitempty"`
+}
+
+// NewProvider instantiates a new Redis storage Provider using the given client.
+// The client is owned by the caller: it is not closed by Close.
+func NewProvider(client redis.UniversalClient, opts ...Option) *Provider {
+	p := &Provider{client: client, keyPrefix: defaultKeyPrefix, dbs: make(map[string]*store)}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// OpenStore opens a store with the given name and returns a handle.
+// If the store has never been opened before, then it is created.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	if name == "" {
+		return nil, errors.New("store name cannot be blank")
+	}
+
+	name = strings.ToLower(name)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if openStore, ok := p.dbs[name]; ok {
+		return openStore, nil
+	}
+
+	err := p.client.SAdd(context.Background(), p.storesKey(), name).Err()
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create store "%s": %w`, name, err)
+	}
+
+	newStore := &store{
+		client:    p.client,
+		name:      name,
+		keyPrefix: fmt.Sprintf("%s{%s}", p.keyPrefix, name),
+		close:     p.removeStore,
+	}
+	p.dbs[name] = newStore
+
+	return newStore, nil
+}
+
+// SetStoreConfig sets the configuration on a store.
+// The store must be created prior to calling this method, by this provider or another one sharing the Redis server.
+// If the store cannot be found, then an error wrapping storage.ErrStoreNotFound will be returned.
+func (p *Provider) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	for _, tagName := range config.TagNames {
+		if strings.Contains(tagName, ":") {
+			return fmt.Errorf(invalidTagName, tagName)
+		}
+	}
+
+	name = strings.ToLower(name)
+
+	if err := p.checkStoreExists(name); err != nil {
+		return err
+	}
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal store configuration: %w", err)
+	}
+
+	err = p.client.Set(context.Background(), p.configKey(name), configBytes, 0).Err()
+	if err != nil {
+		return fmt.Errorf("failed to put store configuration: %w", err)
+	}
+
+	return nil
+}
+
+// GetStoreConfig gets the current store configuration.
+// The store must be created prior to calling this method, by this provider or another one sharing the Redis server.
+// If the store cannot be found, then an error wrapping storage.ErrStoreNotFound will be returned.
+func (p *Provider) GetStoreConfig(name string) (storage.StoreConfiguration, error) {
+	name = strings.ToLower(name)
+
+	if err := p.checkStoreExists(name); err != nil {
+		return storage.StoreConfiguration{}, err
+	}
+
+	configBytes, err := p.client.Get(context.Background(), p.configKey(name)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return storage.StoreConfiguration{}, nil
+	}
+
+	if err != nil {
+		return storage.StoreConfiguration{},
+			fmt.Errorf(`failed to get store configuration for "%s": %w`, name, err)
+	}
+
+	var config storage.StoreConfiguration
+
+	err = json.Unmarshal(configBytes, &config)
+	if err != nil {
+		return storage.StoreConfiguration{}, fmt.Errorf("failed to unmarshal store configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// GetOpenStores returns all stores currently open in this provider.
+func (p *Provider) GetOpenStores() []storage.Store {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	openStores := make([]storage.Store, 0, len(p.dbs))
+
+	for _, openStore := range p.dbs {
+		openStores = append(openStores, openStore)
+	}
+
+	return openStores
+}
+
+// Close closes all stores opened in this provider. The data remain in Redis.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.dbs = make(map[string]*store)
+
+	return nil
+}
+
+func (p *Provider) checkStoreExists(name string) error {
+	exists, err := p.client.SIsMember(context.Background(), p.storesKey(), name).Result()
+	if err != nil {
+		return fmt.Errorf(`failed to check store "%s": %w`, name, err)
+	}
+
+	if !exists {
+		return storage.ErrStoreNotFound
+	}
+
+	return nil
+}
+
+func (p *Provider) storesKey() string {
+	return p.keyPrefix + ":stores"
+}
+
+func (p *Provider) configKey(name string) string {
+	return fmt.Sprintf("%s{%s}:config", p.keyPrefix, name)
+}
+
+func (p *Provider) removeStore(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.dbs, name)
+}
+
+type store struct {
+	client    redis.UniversalClient
+	name      string
+	keyPrefix string
+	close     closer
+}
+
+// Put stores the key + value pair along with the (optional) tags.
+func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
+	if value == nil {
+		return errors.New("value cannot be nil")
+	}
+
+	return s.Batch([]storage.Operation{{Key: key, Value: value, Tags: tags}})
+}
+
+// Get fetches the value associated with the given key.
+// If key cannot be found, then an error wrapping storage.ErrDataNotFound will be returned.
+func (s *store) Get(key string) ([]byte, error) {
+	entry, err := s.getDBEntry(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DB entry: %w", err)
+	}
+
+	return entry.Value, nil
+}
+
+// GetTags fetches all tags associated with the given key.
+// If key cannot be found, then an error wrapping storage.ErrDataNotFound will be returned.
+func (s *store) GetTags(key string) ([]storage.Tag, error) {
+	entry, err := s.getDBEntry(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DB entry: %w", err)
+	}
+
+	return entry.Tags, nil
+}
+
+// GetBulk fetches the values associated with the given keys.
+// If no data exists under a given key, then a nil []byte is returned for that value.
+func (s *store) GetBulk(keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keys slice must contain at least one key")
+	}
+
+	for _, key := range keys {
+		if key == "" {
+			return nil, errEmptyKey
+		}
+	}
+
+	entries, err := s.getDBEntries(s.client, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DB entries: %w", err)
+	}
+
+	values := make([][]byte, len(keys))
+
+	for i, entry := range entries {
+		if entry != nil {
+			values[i] = entry.Value
+		}
+	}
+
+	return values, nil
+}
+
+// Query returns all data that satisfies the expression. Expression format: TagName:TagValue.
+// If TagValue is not provided, then all data associated with the TagName will be returned.
+// The page size option sets the number of entries fetched at once by the iterator.
+func (s *store) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	var indexKey string
+
+	expressionSplit := strings.Split(expression, ":")
+
+	switch {
+	case expression == "":
+		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	case len(expressionSplit) == expressionTagNameOnlyLength:
+		indexKey = s.tagNameIndexKey(expressionSplit[0])
+	case len(expressionSplit) == expressionTagNameAndValueLength:
+		indexKey = s.tagValueIndexKey(storage.Tag{Name: expressionSplit[0], Value: expressionSplit[1]})
+	default:
+		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	}
+
+	keys, err := s.client.SMembers(context.Background(), indexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keys matching %s: %w", expression, err)
+	}
+
+	queryOptions := storage.QueryOptions{PageSize: defaultPageSize}
+
+	for _, option := range options {
+		option(&queryOptions)
+	}
+
+	if queryOptions.PageSize <= 0 {
+		queryOptions.PageSize = defaultPageSize
+	}
+
+	return &iterator{store: s, keys: keys, pageSize: queryOptions.PageSize}, nil
+}
+
+// Delete deletes the key + value pair (and all tags) associated with key.
+func (s *store) Delete(key string) error {
+	return s.Batch([]storage.Operation{{Key: key}})
+}
+
+// Batch performs the Put and/or Delete operations in order, within a Redis transaction: either all operations are
+// performed or none.
+func (s *store) Batch(operations []storage.Operation) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(operations))
+	dataKeys := make([]string, len(operations))
+
+	for i, operation := range operations {
+		if operation.Key == "" {
+			return errEmptyKey
+		}
+
+		if err := validateTags(operation.Tags); err != nil {
+			return err
+		}
+
+		keys[i] = operation.Key
+		dataKeys[i] = s.dataKey(operation.Key)
+	}
+
+	for i := 0; i < maxTxRetries; i++ {
+		err := s.client.Watch(context.Background(), func(tx *redis.Tx) error {
+			return s.batch(tx, keys, operations)
+		}, dataKeys...)
+
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+
+	return errTxConflict
+}
+
+// Flush is a no-op since the store doesn't queue values.
+func (s *store) Flush() error {
+	return nil
+}
+
+// Close closes this store handle. The data remain in Redis.
+func (s *store) Close() error {
+	s.close(s.name)
+
+	return nil
+}
+
+// batch applies the operations in a transaction, the current entries of keys are watched by tx.
+func (s *store) batch(tx *redis.Tx, keys []string, operations []storage.Operation) error {
+	ctx := context.Background()
+
+	entries, err := s.getDBEntries(tx, keys)
+	if err != nil {
+		return fmt.Errorf("failed to get DB entries: %w", err)
+	}
+
+	current := make(map[string]*dbEntry, len(keys))
+
+	for i, key := range keys {
+		current[key] = entries[i]
+	}
+
+	_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, operation := range operations {
+			if previous := current[operation.Key]; previous != nil {
+				for _, tag := range previous.Tags {
+					pipe.SRem(ctx, s.tagNameIndexKey(tag.Name), operation.Key)
+					pipe.SRem(ctx, s.tagValueIndexKey(tag), operation.Key)
+				}
+			}
+
+			if operation.Value == nil {
+				pipe.Del(ctx, s.dataKey(operation.Key))
+
+				current[operation.Key] = nil
+
+				continue
+			}
+
+			entry := &dbEntry{Value: operation.Value, Tags: operation.Tags}
+
+			entryBytes, errMarshal := json.Marshal(entry)
+			if errMarshal != nil {
+				return fmt.Errorf("failed to marshal DB entry: %w", errMarshal)
+			}
+
+			pipe.Set(ctx, s.dataKey(operation.Key), entryBytes, 0)
+
+			for _, tag := range operation.Tags {
+				pipe.SAdd(ctx, s.tagNameIndexKey(tag.Name), operation.Key)
+				pipe.SAdd(ctx, s.tagValueIndexKey(tag), operation.Key)
+			}
+
+			current[operation.Key] = entry
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *store) getDBEntry(key string) (*dbEntry, error) {
+	if key == "" {
+		return nil, errEmptyKey
+	}
+
+	entries, err := s.getDBEntries(s.client, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	if entries[0] == nil {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return entries[0], nil
+}
+
+// getDBEntries returns the entries of keys, the entries not found are nil.
+func (s *store) getDBEntries(client redis.Cmdable, keys []string) ([]*dbEntry, error) {
+	dataKeys := make([]string, len(keys))
+
+	for i, key := range keys {
+		dataKeys[i] = s.dataKey(key)
+	}
+
+	values, err := client.MGet(context.Background(), dataKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*dbEntry, len(values))
+
+	for i, value := range values {
+		entryString, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		entry := &dbEntry{}
+
+		err = json.Unmarshal([]byte(entryString), entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal DB entry of %s: %w", keys[i], err)
+		}
+
+		entries[i] = entry
+	}
+
+	return entries, nil
+}
+
+func (s *store) dataKey(key string) string {
+	return s.keyPrefix + ":data:" + key
+}
+
+// tagNameIndexKey returns the key of the set of keys having a tag named name.
+func (s *store) tagNameIndexKey(name string) string {
+	return s.keyPrefix + ":tag:" + name
+}
+
+// tagValueIndexKey returns the key of the set of keys having the tag, an empty tag value matches any value.
+func (s *store) tagValueIndexKey(tag storage.Tag) string {
+	if tag.Value == "" {
+		return s.tagNameIndexKey(tag.Name)
+	}
+
+	return s.keyPrefix + ":tagvalue:" + tag.Name + ":" + tag.Value
+}
+
+func validateTags(tags []storage.Tag) error {
+	for _, tag := range tags {
+		if strings.Contains(tag.Name, ":") {
+			return fmt.Errorf(invalidTagName, tag.Name)
+		}
+
+		if strings.Contains(tag.Value, ":") {
+			return fmt.Errorf(invalidTagValue, tag.Value)
+		}
+	}
+
+	return nil
+}
+
+type iterator struct {
+	store    *store
+	keys     []string
+	pageSize int
+
+	page         []*dbEntry
+	pageKeys     []string
+	currentKey   string
+	currentEntry *dbEntry
+}
+
+// Next moves the pointer to the next entry in the iterator. It returns false if the iterator is exhausted.
+// The entries deleted since the query are skipped.
+func (i *iterator) Next() (bool, error) {
+	for len(i.page) == 0 {
+		if len(i.keys) == 0 {
+			i.currentEntry = nil
+
+			return false, nil
+		}
+
+		size := i.pageSize
+		if size > len(i.keys) {
+			size = len(i.keys)
+		}
+
+		entries, err := i.store.getDBEntries(i.store.client, i.keys[:size])
+		if err != nil {
+			return false, fmt.Errorf("failed to get DB entries: %w", err)
+		}
+
+		for j, entry := range entries {
+			if entry != nil {
+				i.page = append(i.page, entry)
+				i.pageKeys = append(i.pageKeys, i.keys[j])
+			}
+		}
+
+		i.keys = i.keys[size:]
+	}
+
+	i.currentKey, i.currentEntry = i.pageKeys[0], i.page[0]
+	i.pageKeys, i.page = i.pageKeys[1:], i.page[1:]
+
+	return true, nil
+}
+
+// Key returns the key of the current entry.
+func (i *iterator) Key() (string, error) {
+	if i.currentEntry == nil {
+		return "", errors.New("iterator is exhausted")
+	}
+
+	return i.currentKey, nil
+}
+
+// Value returns the value of the current entry.
+func (i *iterator) Value() ([]byte, error) {
+	if i.currentEntry == nil {
+		return nil, errors.New("iterator is exhausted")
+	}
+
+	return i.currentEntry.Value, nil
+}
+
+// Tags returns the tags associated with the key of the current entry.
+func (i *iterator) Tags() ([]storage.Tag, error) {
+	if i.currentEntry == nil {
+		return nil, errors.New("iterator is exhausted")
+	}
+
+	return i.currentEntry.Tags, nil
+}
+
+// Close is a no-op, the entries are fetched page by page.
+func (i *iterator) Close() error {
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	redisstorage "github.com/hyperledger/aries-framework-go/component/storage/redis"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	commontest "github.com/hyperledger/aries-framework-go/test/component/storage"
+)
+
+func newClient(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+		server.Close()
+	})
+
+	return server, client
+}
+
+func TestCommon(t *testing.T) {
+	_, client := newClient(t)
+
+	commontest.TestAll(t, redisstorage.NewProvider(client))
+}
+
+func TestBatchAtomicity(t *testing.T) {
+	_, client := newClient(t)
+
+	commontest.TestStoreBatchAtomicity(t, redisstorage.NewProvider(client))
+}
+
+func TestProvider_SharedServer(t *testing.T) {
+	_, client := newClient(t)
+
+	provider1 := redisstorage.NewProvider(client)
+	provider2 := redisstorage.NewProvider(client)
+	otherAgent := redisstorage.NewProvider(client, redisstorage.WithKeyPrefix("other"))
+
+	store1, err := provider1.OpenStore("connections")
+	require.NoError(t, err)
+
+	require.NoError(t, provider1.SetStoreConfig("connections", storage.StoreConfiguration{TagNames: []string{"tag"}}))
+	require.NoError(t, store1.Put("key", []byte("value"), storage.Tag{Name: "tag", Value: "value"}))
+
+	t.Run("config and data are shared by the providers using the same prefix", func(t *testing.T) {
+		config, err := provider2.GetStoreConfig("connections")
+		require.NoError(t, err)
+		require.Equal(t, []string{"tag"}, config.TagNames)
+
+		store2, err := provider2.OpenStore("Connections")
+		require.NoError(t, err)
+
+		value, err := store2.Get("key")
+		require.NoError(t, err)
+		require.Equal(t, "value", string(value))
+
+		iterator, err := store2.Query("tag:value")
+		require.NoError(t, err)
+
+		more, err := iterator.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+	})
+
+	t.Run("stores of another prefix are separated", func(t *testing.T) {
+		_, err := otherAgent.GetStoreConfig("connections")
+		require.True(t, errors.Is(err, storage.ErrStoreNotFound))
+
+		store, err := otherAgent.OpenStore("connections")
+		require.NoError(t, err)
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
+
+func TestStore_Errors(t *testing.T) {
+	server, client := newClient(t)
+
+	provider := redisstorage.NewProvider(client)
+
+	store, err := provider.OpenStore("store")
+	require.NoError(t, err)
+
+	t.Run("invalid tags", func(t *testing.T) {
+		err = store.Put("key", []byte("value"), storage.Tag{Name: "tag:name"})
+		require.EqualError(t, err, `"tag:name" is an invalid tag name since it contains one or more ':' characters`)
+
+		err = store.Put("key", []byte("value"), storage.Tag{Name: "tag", Value: "tag:value"})
+		require.EqualError(t, err, `"tag:value" is an invalid tag value since it contains one or more ':' characters`)
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		server.Set("aries{store}:data:invalid", "not json")
+
+		_, err = store.Get("invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal DB entry of invalid")
+	})
+
+	t.Run("server unavailable", func(t *testing.T) {
+		server.Close()
+
+		err = store.Put("key", []byte("value"))
+		require.Error(t, err)
+
+		_, err = store.Get("key")
+		require.Error(t, err)
+
+		_, err = store.Query("tag")
+		require.Error(t, err)
+
+		_, err = provider.OpenStore("newstore")
+		require.Error(t, err)
+
+		_, err = provider.GetStoreConfig("store")
+		require.Error(t, err)
+	})
+}
@@ -9,6 +9,7 @@ package mem
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -32,15 +33,33 @@ var (
 
 // Provider represents an in-memory implementation of the spi.Provider interface.
 type Provider struct {
-	dbs  map[string]*memStore
-	lock sync.RWMutex
+	dbs        map[string]*memStore
+	sortedKeys bool
+	lock       sync.RWMutex
 }
 
 type closer func(storeName string)
 
+// Option configures the in-memory storage Provider.
+type Option func(p *Provider)
+
+// WithSortedKeys makes the iterators returned by Query and GetOpenStores order their results by key and store name
+// respectively, instead of the random map iteration order. This is intended for deterministic tests.
+func WithSortedKeys() Option {
+	return func(p *Provider) {
+		p.sortedKeys = true
+	}
+}
+
 // NewProvider instantiates a new in-memory storage Provider.
-func NewProvider() *Provider {
-	return &Provider{dbs: make(map[string]*memStore)}
+func NewProvider(opts ...Option) *Provider {
+	p := &Provider{dbs: make(map[string]*memStore)}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
 }
 
 // OpenStore opens a store with the given name and returns a handle.
@@ -57,7 +76,12 @@ func (p *Provider) OpenStore(name string) (spi.Store, error) {
 
 	store := p.dbs[storeName]
 	if store == nil {
-		newStore := &memStore{name: storeName, db: make(map[string]dbEntry), close: p.removeStore}
+		newStore := &memStore{
+			name:       storeName,
+			db:         make(map[string]dbEntry),
+			close:      p.removeStore,
+			sortedKeys: p.sortedKeys,
+		}
 		p.dbs[storeName] = newStore
 
 		return newStore, nil
@@ -119,6 +143,12 @@ func (p *Provider) GetOpenStores() []spi.Store {
 		counter++
 	}
 
+	if p.sortedKeys {
+		sort.Slice(openStores, func(i, j int) bool {
+			return openStores[i].(*memStore).name < openStores[j].(*memStore).name
+		})
+	}
+
 	return openStores
 }
 
@@ -148,10 +178,11 @@ type dbEntry struct {
 }
 
 type memStore struct {
-	name   string
-	db     map[string]dbEntry
-	config spi.StoreConfiguration
-	close  closer
+	name       string
+	db         map[string]dbEntry
+	config     spi.StoreConfiguration
+	close      closer
+	sortedKeys bool
 	sync.RWMutex
 }
 
@@ -345,19 +376,26 @@ func (m *memStore) getMatchingKeysAndDBEntries(tagName, tagValue string) ([]stri
 
 	var keys []string
 
-	var dbEntries []dbEntry
-
 	for key, dbEntry := range m.db {
 		for _, tag := range dbEntry.tags {
 			if tag.Name == tagName && (matchAnyValue || tag.Value == tagValue) {
 				keys = append(keys, key)
-				dbEntries = append(dbEntries, dbEntry)
 
 				break
 			}
 		}
 	}
 
+	if m.sortedKeys {
+		sort.Strings(keys)
+	}
+
+	dbEntries := make([]dbEntry, len(keys))
+
+	for i, key := range keys {
+		dbEntries[i] = m.db[key]
+	}
+
 	return keys, dbEntries
 }
 
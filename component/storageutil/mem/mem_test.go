@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 	storagetest "github.com/hyperledger/aries-framework-go/test/component/storage"
 )
 
@@ -21,6 +22,10 @@ func TestCommon(t *testing.T) {
 	storagetest.TestAll(t, provider)
 }
 
+func TestBatchAtomicity(t *testing.T) {
+	storagetest.TestStoreBatchAtomicity(t, mem.NewProvider())
+}
+
 func TestMemIterator(t *testing.T) {
 	provider := mem.NewProvider()
 
@@ -42,3 +47,43 @@ func TestMemIterator(t *testing.T) {
 	require.EqualError(t, err, "iterator is exhausted")
 	require.Nil(t, tags)
 }
+
+func TestWithSortedKeys(t *testing.T) {
+	provider := mem.NewProvider(mem.WithSortedKeys())
+
+	_, err := provider.OpenStore("storeB")
+	require.NoError(t, err)
+
+	store, err := provider.OpenStore("storeA")
+	require.NoError(t, err)
+
+	keys := []string{"key5", "key3", "key1", "key4", "key2"}
+
+	for _, key := range keys {
+		require.NoError(t, store.Put(key, []byte("value"), spi.Tag{Name: "tagName"}))
+	}
+
+	iterator, err := store.Query("tagName")
+	require.NoError(t, err)
+
+	var received []string
+
+	more, err := iterator.Next()
+	require.NoError(t, err)
+
+	for more {
+		key, errKey := iterator.Key()
+		require.NoError(t, errKey)
+
+		received = append(received, key)
+
+		more, err = iterator.Next()
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []string{"key1", "key2", "key3", "key4", "key5"}, received)
+
+	openStores := provider.GetOpenStores()
+	require.Len(t, openStores, 2)
+	require.Equal(t, store, openStores[0])
+}
@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mem
+
+import (
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Snapshot is a copy of the data and configurations of the stores of a Provider, taken by Provider.Snapshot.
+type Snapshot struct {
+	stores map[string]storeSnapshot
+}
+
+type storeSnapshot struct {
+	db     map[string]dbEntry
+	config spi.StoreConfiguration
+}
+
+// Snapshot returns a copy of the current data and configurations of all open stores.
+// It is intended for test fixtures resetting the state between test cases with Restore.
+func (p *Provider) Snapshot() *Snapshot {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	snapshot := &Snapshot{stores: make(map[string]storeSnapshot, len(p.dbs))}
+
+	for name, store := range p.dbs {
+		store.RLock()
+		snapshot.stores[name] = storeSnapshot{db: copyDB(store.db), config: copyConfig(store.config)}
+		store.RUnlock()
+	}
+
+	return snapshot
+}
+
+// Restore sets the data and configurations of the stores back to the given snapshot.
+// The open store handles remain valid: the stores opened after the snapshot was taken are emptied and the stores
+// closed since are recreated.
+func (p *Provider) Restore(snapshot *Snapshot) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for name, store := range p.dbs {
+		if _, ok := snapshot.stores[name]; ok {
+			continue
+		}
+
+		store.Lock()
+		store.db = make(map[string]dbEntry)
+		store.config = spi.StoreConfiguration{}
+		store.Unlock()
+	}
+
+	for name, storeSnapshot := range snapshot.stores {
+		store, ok := p.dbs[name]
+		if !ok {
+			store = &memStore{name: name, close: p.removeStore, sortedKeys: p.sortedKeys}
+			p.dbs[name] = store
+		}
+
+		store.Lock()
+		store.db = copyDB(storeSnapshot.db)
+		store.config = copyConfig(storeSnapshot.config)
+		store.Unlock()
+	}
+}
+
+func copyDB(db map[string]dbEntry) map[string]dbEntry {
+	dbCopy := make(map[string]dbEntry, len(db))
+
+	for key, entry := range db {
+		var tags []spi.Tag
+
+		if entry.tags != nil {
+			tags = make([]spi.Tag, len(entry.tags))
+			copy(tags, entry.tags)
+		}
+
+		dbCopy[key] = dbEntry{value: append([]byte(nil), entry.value...), tags: tags}
+	}
+
+	return dbCopy
+}
+
+func copyConfig(config spi.StoreConfiguration) spi.StoreConfiguration {
+	if config.TagNames == nil {
+		return config
+	}
+
+	return spi.StoreConfiguration{TagNames: append([]string(nil), config.TagNames...)}
+}
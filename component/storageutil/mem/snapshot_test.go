@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mem_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestProvider_SnapshotRestore(t *testing.T) {
+	provider := mem.NewProvider()
+
+	store, err := provider.OpenStore("store1")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SetStoreConfig("store1", spi.StoreConfiguration{TagNames: []string{"tagName"}}))
+	require.NoError(t, store.Put("key1", []byte("value1"), spi.Tag{Name: "tagName", Value: "tagValue"}))
+
+	closedStore, err := provider.OpenStore("store2")
+	require.NoError(t, err)
+	require.NoError(t, closedStore.Put("key1", []byte("value1")))
+
+	snapshot := provider.Snapshot()
+
+	require.NoError(t, store.Put("key1", []byte("value1_new")))
+	require.NoError(t, store.Put("key2", []byte("value2")))
+	require.NoError(t, provider.SetStoreConfig("store1", spi.StoreConfiguration{}))
+	require.NoError(t, closedStore.Close())
+
+	newStore, err := provider.OpenStore("store3")
+	require.NoError(t, err)
+	require.NoError(t, newStore.Put("key1", []byte("value1")))
+
+	provider.Restore(snapshot)
+
+	t.Run("modified store is restored", func(t *testing.T) {
+		value, err := store.Get("key1")
+		require.NoError(t, err)
+		require.Equal(t, "value1", string(value))
+
+		tags, err := store.GetTags("key1")
+		require.NoError(t, err)
+		require.Equal(t, []spi.Tag{{Name: "tagName", Value: "tagValue"}}, tags)
+
+		_, err = store.Get("key2")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+		config, err := provider.GetStoreConfig("store1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"tagName"}, config.TagNames)
+	})
+
+	t.Run("closed store is recreated", func(t *testing.T) {
+		reopened, err := provider.OpenStore("store2")
+		require.NoError(t, err)
+
+		value, err := reopened.Get("key1")
+		require.NoError(t, err)
+		require.Equal(t, "value1", string(value))
+	})
+
+	t.Run("new store is emptied", func(t *testing.T) {
+		_, err := newStore.Get("key1")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+	})
+
+	t.Run("snapshot can be restored again", func(t *testing.T) {
+		require.NoError(t, store.Put("key1", []byte("value1_new")))
+
+		provider.Restore(snapshot)
+
+		value, err := store.Get("key1")
+		require.NoError(t, err)
+		require.Equal(t, "value1", string(value))
+	})
+}
@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// ErrMetadataNotFound is returned when the connection has no metadata.
+var ErrMetadataNotFound = errors.New("connection metadata not found")
+
+// Metadata is the application metadata of the connection.
+type Metadata connection.Metadata
+
+// Provider contains dependencies for the connection client and is typically created by using aries.Context().
+type Provider interface {
+	ProtocolStateStorageProvider() storage.Provider
+	StorageProvider() storage.Provider
+}
+
+// Client enables access to the connection metadata API.
+type Client struct {
+	connectionStore *connection.Recorder
+}
+
+// New returns a new instance of the connection client.
+func New(ctx Provider) (*Client, error) {
+	connectionStore, err := connection.NewRecorder(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection store: %w", err)
+	}
+
+	return &Client{connectionStore: connectionStore}, nil
+}
+
+// SetMetadata sets the metadata of the connection, the previous metadata is replaced.
+func (c *Client) SetMetadata(connectionID string, metadata *Metadata) error {
+	if metadata == nil {
+		return errors.New("metadata is required")
+	}
+
+	err := c.connectionStore.SaveMetadata(connectionID, (*connection.Metadata)(metadata))
+	if err != nil {
+		return fmt.Errorf("set metadata: %w", err)
+	}
+
+	return nil
+}
+
+// GetMetadata returns the metadata of the connection (ErrMetadataNotFound if none was set).
+func (c *Client) GetMetadata(connectionID string) (*Metadata, error) {
+	metadata, err := c.connectionStore.GetMetadata(connectionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrMetadataNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get metadata: %w", err)
+	}
+
+	return (*Metadata)(metadata), nil
+}
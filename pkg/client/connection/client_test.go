@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, err := New(newProvider(mockstorage.NewMockStoreProvider()))
+		require.NoError(t, err)
+		require.NotNil(t, c)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(newProvider(&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")}))
+		require.Contains(t, err.Error(), "failed to create connection store")
+	})
+}
+
+func TestClient_Metadata(t *testing.T) {
+	prov := newProvider(mockstorage.NewMockStoreProvider())
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: "conn1",
+		State:        connection.StateNameCompleted,
+		ThreadID:     "thid",
+	}))
+
+	c, err := New(prov)
+	require.NoError(t, err)
+
+	t.Run("set and get metadata", func(t *testing.T) {
+		_, err = c.GetMetadata("conn1")
+		require.True(t, errors.Is(err, ErrMetadataNotFound))
+
+		metadata := &Metadata{
+			DisplayName: "Alice",
+			AvatarURL:   "https://example.com/alice.png",
+			TrustLevel:  "high",
+			Tags:        []string{"friend"},
+			Properties:  map[string]interface{}{"note": "met at the conference"},
+		}
+
+		require.NoError(t, c.SetMetadata("conn1", metadata))
+
+		result, err := c.GetMetadata("conn1")
+		require.NoError(t, err)
+		require.Equal(t, metadata, result)
+
+		// the metadata is returned with the connection record
+		record, err := recorder.GetConnectionRecord("conn1")
+		require.NoError(t, err)
+		require.Equal(t, (*connection.Metadata)(metadata), record.Metadata)
+	})
+
+	t.Run("set metadata errors", func(t *testing.T) {
+		require.EqualError(t, c.SetMetadata("conn1", nil), "metadata is required")
+
+		err = c.SetMetadata("unknown", &Metadata{})
+		require.Contains(t, err.Error(), "set metadata")
+	})
+}
+
+func TestClient_GetMetadataError(t *testing.T) {
+	prov := newProvider(&mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+		Store:  make(map[string]mockstorage.DBEntry),
+		ErrGet: errors.New("get error"),
+	}})
+
+	c, err := New(prov)
+	require.NoError(t, err)
+
+	_, err = c.GetMetadata("conn1")
+	require.EqualError(t, err, "get metadata: get connection metadata: get error")
+}
+
+func newProvider(storageProvider *mockstorage.MockStoreProvider) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              storageProvider,
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+	}
+}
@@ -0,0 +1,10 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package connection enables the agent to manage the application metadata of its connections (display name,
+// avatar, trust level, tags). The metadata is local to the agent, it is returned with the connection records
+// and the DID Exchange events.
+package connection
@@ -38,6 +38,7 @@ var ErrConnectionNotFound = errors.New("connection not found")
 type options struct {
 	routerConnections  []string
 	routerConnectionID string
+	alias              string
 }
 
 func applyOptions(args ...Opt) *options {
@@ -63,6 +64,13 @@ func WithRouterConnectionID(conn string) InvOpt {
 	}
 }
 
+// WithAlias allows you to specify the alias of the connections created for the invitation.
+func WithAlias(alias string) InvOpt {
+	return func(opts *options) {
+		opts.alias = alias
+	}
+}
+
 // WithRouterConnections allows you to specify the router connections.
 func WithRouterConnections(conns ...string) Opt {
 	return func(opts *options) {
@@ -161,8 +169,6 @@ func (c *Client) CreateInvitation(label string, args ...InvOpt) (*Invitation, er
 		args[i](opts)
 	}
 
-	// TODO https://github.com/hyperledger/aries-framework-go/issues/623 'alias' should be passed as arg and persisted
-	//  with connection record
 	_, sigPubKey, err := c.kms.CreateAndExportPubKeyBytes(kms.ED25519Type)
 	if err != nil {
 		return nil, fmt.Errorf("createInvitation: failed to extract public SigningKey bytes from handle:%w", err)
@@ -202,12 +208,24 @@ func (c *Client) CreateInvitation(label string, args ...InvOpt) (*Invitation, er
 		return nil, fmt.Errorf("createInvitation: failed to save invitation: %w", err)
 	}
 
+	if opts.alias != "" {
+		if err = c.connectionStore.SaveInvitationAlias(invitation.ID, opts.alias); err != nil {
+			return nil, fmt.Errorf("createInvitation: failed to save invitation alias: %w", err)
+		}
+	}
+
 	return &Invitation{invitation}, nil
 }
 
 // CreateInvitationWithDID creates an invitation with specified public DID. This invitation will be stored
 // so client can cross reference this invitation during did exchange protocol.
-func (c *Client) CreateInvitationWithDID(label, publicDID string) (*Invitation, error) {
+func (c *Client) CreateInvitationWithDID(label, publicDID string, args ...InvOpt) (*Invitation, error) {
+	opts := &options{}
+
+	for i := range args {
+		args[i](opts)
+	}
+
 	invitation := &didexchange.Invitation{
 		ID:    uuid.New().String(),
 		Label: label,
@@ -220,13 +238,25 @@ func (c *Client) CreateInvitationWithDID(label, publicDID string) (*Invitation,
 		return nil, fmt.Errorf("createInvitationWithDID: failed to save invitation with DID: %w", err)
 	}
 
+	if opts.alias != "" {
+		if err = c.connectionStore.SaveInvitationAlias(invitation.ID, opts.alias); err != nil {
+			return nil, fmt.Errorf("createInvitationWithDID: failed to save invitation alias: %w", err)
+		}
+	}
+
 	return &Invitation{invitation}, nil
 }
 
 // HandleInvitation handle incoming invitation and returns the connectionID that can be used to query the state
 // of did exchange protocol. Upon successful completion of did exchange protocol connection details will be used
-// for securing communication between agents.
-func (c *Client) HandleInvitation(invitation *Invitation) (string, error) {
+// for securing communication between agents. The alias given with WithAlias is set on the connection record.
+func (c *Client) HandleInvitation(invitation *Invitation, args ...InvOpt) (string, error) {
+	opts := &options{}
+
+	for i := range args {
+		args[i](opts)
+	}
+
 	payload, err := json.Marshal(invitation)
 	if err != nil {
 		return "", fmt.Errorf("handleInvitation: failed marshal invitation: %w", err)
@@ -237,6 +267,10 @@ func (c *Client) HandleInvitation(invitation *Invitation) (string, error) {
 		return "", fmt.Errorf("handleInvitation: failed to create DIDCommMsg: %w", err)
 	}
 
+	if opts.alias != "" {
+		msg.Metadata()[didexchange.AliasMetadataKey] = opts.alias
+	}
+
 	connectionID, err := c.didexchangeSvc.HandleInbound(msg, service.EmptyDIDCommContext())
 	if err != nil {
 		return "", fmt.Errorf("handleInvitation: failed from didexchange service handle: %w", err)
@@ -287,7 +321,7 @@ func (c *Client) CreateImplicitInvitationWithDID(inviter, invitee *DIDInfo) (str
 }
 
 // QueryConnections queries connections matching given criteria(parameters).
-func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) { //nolint: gocyclo
+func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) {
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - query all connections from all criteria and
 	//  also results needs to be paged.
 	records, err := c.connectionStore.QueryConnectionRecords()
@@ -298,27 +332,9 @@ func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connectio
 	var result []*Connection
 
 	for _, record := range records {
-		if request.State != "" && request.State != record.State {
-			continue
-		}
-
-		if request.InvitationID != "" && request.InvitationID != record.InvitationID {
-			continue
-		}
-
-		if request.ParentThreadID != "" && request.ParentThreadID != record.ParentThreadID {
-			continue
+		if request.matches(record) {
+			result = append(result, &Connection{Record: record})
 		}
-
-		if request.MyDID != "" && request.MyDID != record.MyDID {
-			continue
-		}
-
-		if request.TheirDID != "" && request.TheirDID != record.TheirDID {
-			continue
-		}
-
-		result = append(result, &Connection{Record: record})
 	}
 
 	return result, nil
@@ -435,6 +451,13 @@ func WithInvitationDID(didID string) ConnectionOption {
 	}
 }
 
+// WithConnectionAlias sets Alias on the connection record.
+func WithConnectionAlias(alias string) ConnectionOption {
+	return func(c *Connection) {
+		c.Alias = alias
+	}
+}
+
 // WithImplicit sets Implicit on the connection record.
 func WithImplicit(i bool) ConnectionOption {
 	return func(c *Connection) {
@@ -172,6 +172,51 @@ func TestClient_CreateInvitation(t *testing.T) {
 		require.Equal(t, "endpoint", inviteReq.ServiceEndpoint)
 	})
 
+	t.Run("test success with alias", func(t *testing.T) {
+		svc, err := didexchange.New(&mockprotocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		ed25519KH, err := mockkms.CreateMockED25519KeyHandle()
+		require.NoError(t, err)
+
+		storageProvider := mem.NewProvider()
+
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+			StorageProviderValue:              storageProvider,
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: svc,
+				mediator.Coordination:   &mockroute.MockMediatorSvc{},
+			},
+			KMSValue: &mockkms.KeyManager{CreateKeyValue: ed25519KH},
+		})
+		require.NoError(t, err)
+
+		inviteReq, err := c.CreateInvitation("agent", WithAlias("Bob"))
+		require.NoError(t, err)
+
+		lookup, err := connection.NewLookup(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+			StorageProviderValue:              storageProvider,
+		})
+		require.NoError(t, err)
+
+		alias, err := lookup.GetInvitationAlias(inviteReq.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Bob", alias)
+
+		inviteReq, err = c.CreateInvitationWithDID("agent", "did:example:123", WithAlias("Carol"))
+		require.NoError(t, err)
+
+		alias, err = lookup.GetInvitationAlias(inviteReq.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Carol", alias)
+	})
+
 	t.Run("test error from createSigningKey", func(t *testing.T) {
 		svc, err := didexchange.New(&mockprotocol.MockProvider{
 			ServiceMap: map[string]interface{}{
@@ -601,7 +646,8 @@ func TestClient_CreateConnection(t *testing.T) {
 
 		id, err := c.CreateConnection(myDID.ID, theirDID,
 			WithTheirLabel(label), WithThreadID(threadID), WithParentThreadID(parentThreadID),
-			WithInvitationID(invitationID), WithInvitationDID(invitationDID), WithImplicit(implicit))
+			WithInvitationID(invitationID), WithInvitationDID(invitationDID), WithImplicit(implicit),
+			WithConnectionAlias("Alice"))
 		require.NoError(t, err)
 
 		conn, err := c.GetConnection(id)
@@ -616,6 +662,7 @@ func TestClient_CreateConnection(t *testing.T) {
 		require.Equal(t, invitationDID, conn.InvitationDID)
 		require.Equal(t, theirDID.Service[0].ServiceEndpoint, conn.ServiceEndPoint)
 		require.Equal(t, implicit, conn.Implicit)
+		require.Equal(t, "Alice", conn.Alias)
 	})
 
 	t.Run("test create connection - error", func(t *testing.T) {
@@ -1069,6 +1116,74 @@ func TestClient_QueryConnectionsByParams(t *testing.T) { // nolint: gocyclo
 		}
 	})
 
+	t.Run("test get connections with invitation metadata params", func(t *testing.T) {
+		svc, err := didexchange.New(&mockprotocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		storageProvider := mem.NewProvider()
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+			StorageProviderValue:              storageProvider,
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: svc,
+				mediator.Coordination:   &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		didExchangeStore, err := storageProvider.OpenStore("didexchange")
+		require.NoError(t, err)
+
+		created := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+
+		for i := 0; i < 3; i++ {
+			val, e := json.Marshal(&connection.Record{
+				ConnectionID:   fmt.Sprint(i),
+				Alias:          fmt.Sprintf("alias-%d", i),
+				TheirLabel:     fmt.Sprintf("label-%d", i),
+				GoalCode:       fmt.Sprintf("goal-%d", i%2),
+				InvitationKeys: []string{fmt.Sprintf("key-%d", i), "shared-key"},
+				CreatedAt:      created.Add(time.Duration(i) * time.Hour),
+			})
+			require.NoError(t, e)
+			require.NoError(t, didExchangeStore.Put(fmt.Sprintf("conn_abc%d", i), val, spi.Tag{Name: "conn_"}))
+		}
+
+		results, err := c.QueryConnections(&QueryConnectionsParams{Alias: "alias-1"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "1", results[0].ConnectionID)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{TheirLabel: "label-2"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "2", results[0].ConnectionID)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{GoalCode: "goal-0"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{InvitationKey: "shared-key"})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{InvitationKey: "key-0"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "0", results[0].ConnectionID)
+
+		after, before := created, created.Add(2*time.Hour)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{CreatedAfter: &after, CreatedBefore: &before})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "1", results[0].ConnectionID)
+	})
+
 	t.Run("test get connections error", func(t *testing.T) {
 		svc, err := didexchange.New(&mockprotocol.MockProvider{
 			ServiceMap: map[string]interface{}{
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package didexchange
 
 import (
+	"time"
+
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
@@ -43,6 +45,72 @@ type QueryConnectionsParams struct {
 
 	// TheirRole is other party's role
 	TheirRole string `json:"their_role,omitempty"`
+
+	// TheirLabel is other party's label
+	TheirLabel string `json:"their_label,omitempty"`
+
+	// GoalCode of the connection invitation
+	GoalCode string `json:"goal_code,omitempty"`
+
+	// CreatedAfter selects the connections created after the time (RFC 3339)
+	CreatedAfter *time.Time `json:"created_after,omitempty"`
+
+	// CreatedBefore selects the connections created before the time (RFC 3339)
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+}
+
+func (p *QueryConnectionsParams) matches(record *connection.Record) bool { //nolint: gocyclo
+	if p.State != "" && p.State != record.State {
+		return false
+	}
+
+	if p.InvitationID != "" && p.InvitationID != record.InvitationID {
+		return false
+	}
+
+	if p.ParentThreadID != "" && p.ParentThreadID != record.ParentThreadID {
+		return false
+	}
+
+	if p.MyDID != "" && p.MyDID != record.MyDID {
+		return false
+	}
+
+	if p.TheirDID != "" && p.TheirDID != record.TheirDID {
+		return false
+	}
+
+	if p.Alias != "" && p.Alias != record.Alias {
+		return false
+	}
+
+	if p.TheirLabel != "" && p.TheirLabel != record.TheirLabel {
+		return false
+	}
+
+	if p.GoalCode != "" && p.GoalCode != record.GoalCode {
+		return false
+	}
+
+	if p.InvitationKey != "" && !contains(record.InvitationKeys, p.InvitationKey) {
+		return false
+	}
+
+	if p.CreatedAfter != nil && !record.CreatedAt.After(*p.CreatedAfter) {
+		return false
+	}
+
+	return p.CreatedBefore == nil || record.CreatedAt.Before(*p.CreatedBefore)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Connection model
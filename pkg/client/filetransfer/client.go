@@ -0,0 +1,157 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filetransfer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/filetransfer"
+)
+
+type (
+	// File describes the transferred file.
+	File = filetransfer.File
+	// Transfer keeps the progress of a file transfer.
+	Transfer = filetransfer.Transfer
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	// DIDComm service
+	service.DIDComm
+
+	NewWriter(connectionID string, file filetransfer.File) (*filetransfer.Writer, error)
+
+	Send(connectionID string, file filetransfer.File, r io.Reader) (string, error)
+
+	Resume(transferID string) error
+
+	AcceptTransfer(transferID string) error
+
+	DeclineTransfer(transferID string) error
+
+	Open(transferID string) (io.Reader, error)
+
+	Transfer(transferID string) (*filetransfer.Transfer, error)
+
+	Transfers() ([]*filetransfer.Transfer, error)
+}
+
+// Client enable access to file transfer api.
+type Client struct {
+	service.Event
+	fileTransferSvc protocolService
+}
+
+// New return new instance of file transfer client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(filetransfer.FileTransfer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file transfer service: %w", err)
+	}
+
+	fileTransferSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to file transfer service failed")
+	}
+
+	return &Client{
+		Event:           fileTransferSvc,
+		fileTransferSvc: fileTransferSvc,
+	}, nil
+}
+
+// Send streams the contents of r to the other party of the connection and returns the transfer ID.
+func (c *Client) Send(connectionID string, file File, r io.Reader) (string, error) {
+	transferID, err := c.fileTransferSvc.Send(connectionID, file, r)
+	if err != nil {
+		return "", fmt.Errorf("file transfer client - send: %w", err)
+	}
+
+	return transferID, nil
+}
+
+// NewWriter returns a writer that sends the file written to it to the other party of the connection.
+// The transfer is ended by closing the writer.
+func (c *Client) NewWriter(connectionID string, file File) (*filetransfer.Writer, error) {
+	w, err := c.fileTransferSvc.NewWriter(connectionID, file)
+	if err != nil {
+		return nil, fmt.Errorf("file transfer client - new writer: %w", err)
+	}
+
+	return w, nil
+}
+
+// Receive writes the file of a completed incoming transfer to w.
+func (c *Client) Receive(transferID string, w io.Writer) (int64, error) {
+	r, err := c.Open(transferID)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := io.Copy(w, r)
+	if err != nil {
+		return n, fmt.Errorf("file transfer client - receive: %w", err)
+	}
+
+	return n, nil
+}
+
+// Open returns a reader over the file of a completed incoming transfer.
+// The integrity of the file is verified when the reader reaches EOF.
+func (c *Client) Open(transferID string) (io.Reader, error) {
+	r, err := c.fileTransferSvc.Open(transferID)
+	if err != nil {
+		return nil, fmt.Errorf("file transfer client - open: %w", err)
+	}
+
+	return r, nil
+}
+
+// Resume asks the sender of an incoming transfer to send again the chunks that were not received.
+func (c *Client) Resume(transferID string) error {
+	if err := c.fileTransferSvc.Resume(transferID); err != nil {
+		return fmt.Errorf("file transfer client - resume: %w", err)
+	}
+
+	return nil
+}
+
+// AcceptTransfer accepts an offered incoming transfer, the file is received once the sender sent it again.
+// The offered transfers are also announced as action events: Continue accepts them and Stop declines them.
+func (c *Client) AcceptTransfer(transferID string) error {
+	if err := c.fileTransferSvc.AcceptTransfer(transferID); err != nil {
+		return fmt.Errorf("file transfer client - accept transfer: %w", err)
+	}
+
+	return nil
+}
+
+// DeclineTransfer declines an offered incoming transfer or aborts an incoming transfer in progress.
+func (c *Client) DeclineTransfer(transferID string) error {
+	if err := c.fileTransferSvc.DeclineTransfer(transferID); err != nil {
+		return fmt.Errorf("file transfer client - decline transfer: %w", err)
+	}
+
+	return nil
+}
+
+// Transfer returns the transfer with the given ID.
+func (c *Client) Transfer(transferID string) (*Transfer, error) {
+	return c.fileTransferSvc.Transfer(transferID)
+}
+
+// Transfers returns all the incoming and outgoing transfers.
+func (c *Client) Transfers() ([]*Transfer, error) {
+	return c.fileTransferSvc.Transfers()
+}
@@ -0,0 +1,168 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filetransfer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/filetransfer"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const connID = "connection-id"
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		svc, err := filetransfer.New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		client, err := New(&mockprovider.Provider{ServiceValue: svc})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to file transfer service failed")
+	})
+}
+
+func TestClient_SendReceive(t *testing.T) {
+	var receiver *filetransfer.Service
+
+	sender, err := filetransfer.New(newProvider(t, &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			_, e := receiver.HandleInbound(service.NewDIDCommMsgMap(msg),
+				service.NewDIDCommContext(theirDID, myDID, nil))
+
+			return e
+		},
+	}), filetransfer.WithChunkSize(10))
+	require.NoError(t, err)
+
+	receiver, err = filetransfer.New(newProvider(t, &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			_, e := sender.HandleInbound(service.NewDIDCommMsgMap(msg),
+				service.NewDIDCommContext(theirDID, myDID, nil))
+
+			return e
+		},
+	}))
+	require.NoError(t, err)
+
+	alice, err := New(&mockprovider.Provider{ServiceValue: sender})
+	require.NoError(t, err)
+
+	bob, err := New(&mockprovider.Provider{ServiceValue: receiver})
+	require.NoError(t, err)
+
+	content := []byte("the quick brown fox jumps over the lazy dog")
+
+	t.Run("send and receive", func(t *testing.T) {
+		transferID, err := alice.Send(connID, File{Name: "fox.txt"}, bytes.NewReader(content))
+		require.NoError(t, err)
+
+		_, err = bob.Receive(transferID, &bytes.Buffer{})
+		require.Error(t, err)
+
+		require.NoError(t, bob.AcceptTransfer(transferID))
+
+		var buf bytes.Buffer
+
+		n, err := bob.Receive(transferID, &buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(content)), n)
+		require.Equal(t, content, buf.Bytes())
+
+		transfer, err := bob.Transfer(transferID)
+		require.NoError(t, err)
+		require.Equal(t, "fox.txt", transfer.File.Name)
+
+		transfers, err := bob.Transfers()
+		require.NoError(t, err)
+		require.Len(t, transfers, 1)
+	})
+
+	t.Run("writer", func(t *testing.T) {
+		w, err := alice.NewWriter(connID, File{Name: "fox.txt"})
+		require.NoError(t, err)
+
+		_, err = w.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.NoError(t, bob.AcceptTransfer(w.TransferID()))
+
+		var buf bytes.Buffer
+
+		_, err = bob.Receive(w.TransferID(), &buf)
+		require.NoError(t, err)
+		require.Equal(t, content, buf.Bytes())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := alice.Send("unknown", File{}, bytes.NewReader(content))
+		require.Error(t, err)
+
+		_, err = alice.NewWriter("unknown", File{})
+		require.Error(t, err)
+
+		_, err = bob.Receive("unknown", &bytes.Buffer{})
+		require.Error(t, err)
+
+		require.Error(t, bob.Resume("unknown"))
+		require.Error(t, bob.AcceptTransfer("unknown"))
+		require.Error(t, bob.DeclineTransfer("unknown"))
+	})
+
+	t.Run("decline", func(t *testing.T) {
+		transferID, err := alice.Send(connID, File{Name: "fox.txt"}, bytes.NewReader(content))
+		require.NoError(t, err)
+
+		require.NoError(t, bob.DeclineTransfer(transferID))
+
+		transfer, err := bob.Transfer(transferID)
+		require.NoError(t, err)
+		require.Equal(t, filetransfer.StateDeclined, transfer.State)
+	})
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+		OutboundDispatcherValue:           outbound,
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        "completed",
+		MyDID:        "did:example:alice",
+		TheirDID:     "did:example:bob",
+	}))
+
+	return prov
+}
@@ -7,16 +7,26 @@ SPDX-License-Identifier: Apache-2.0
 package issuecredential
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
 
+const ackOnReceipt = "RECEIPT"
+
 var (
 	errEmptyOffer    = errors.New("received an empty offer")
 	errEmptyProposal = errors.New("received an empty proposal")
 	errEmptyRequest  = errors.New("received an empty request")
+	errNoCredentials = errors.New("no credentials to issue")
+	errNoTemplate    = errors.New("no credential template")
 )
 
 type (
@@ -38,8 +48,22 @@ type (
 	IssueCredential issuecredential.IssueCredential
 	// Action contains helpful information about action.
 	Action issuecredential.Action
+	// CredentialAcceptance is the decision of the Holder about a credential attached to IssueCredential message.
+	CredentialAcceptance issuecredential.CredentialAcceptance
 )
 
+// CredentialTemplate describes the credential the Holder proposes the Issuer to issue.
+type CredentialTemplate struct {
+	// Credential is the credential to be issued (without proof).
+	Credential *verifiable.Credential
+	// ProofType is the type of the proof to be added to the credential, e.g. "Ed25519Signature2018".
+	ProofType string
+	// Preview is the preview of the credential. If nil, it has the attributes of the credential subject.
+	Preview *PreviewBuilder
+	// Comment is the human readable comment of the proposal.
+	Comment string
+}
+
 // Provider contains dependencies for the issuecredential protocol and is typically created by using aries.Context().
 type Provider interface {
 	Service(id string) (interface{}, error)
@@ -53,6 +77,21 @@ type ProtocolService interface {
 	ActionStop(piID string, err error) error
 }
 
+// formatRegistrar is implemented by the services supporting credential attachment formats.
+type formatRegistrar interface {
+	RegisterFormat(format string, handler issuecredential.FormatHandler)
+}
+
+// autoIssuer is implemented by the services issuing the requested credentials without controller involvement.
+type autoIssuer interface {
+	SetAutoIssuePolicy(policy issuecredential.AutoIssuePolicy)
+}
+
+// quotaSetter is implemented by the services limiting the issued credentials.
+type quotaSetter interface {
+	SetIssuanceQuota(quota *issuecredential.IssuanceQuota)
+}
+
 // Client enable access to issuecredential API.
 type Client struct {
 	service.Event
@@ -114,6 +153,21 @@ func (c *Client) SendProposal(proposal *ProposeCredential, myDID, theirDID strin
 	return c.service.HandleOutbound(service.NewDIDCommMsgMap(proposal), myDID, theirDID)
 }
 
+// ProposeCredential is used by the Holder to propose the Issuer to issue the credential of the template.
+// The template is attached to the proposal in issuecredential.LDProofVCDetailFormat.
+func (c *Client) ProposeCredential(template *CredentialTemplate, myDID, theirDID string) (string, error) {
+	if template == nil || template.Credential == nil {
+		return "", errNoTemplate
+	}
+
+	proposal, err := newProposeCredential(template)
+	if err != nil {
+		return "", err
+	}
+
+	return c.SendProposal(proposal, myDID, theirDID)
+}
+
 // SendRequest is used by the Holder to send a request.
 func (c *Client) SendRequest(request *RequestCredential, myDID, theirDID string) (string, error) {
 	if request == nil {
@@ -125,12 +179,68 @@ func (c *Client) SendRequest(request *RequestCredential, myDID, theirDID string)
 	return c.service.HandleOutbound(service.NewDIDCommMsgMap(request), myDID, theirDID)
 }
 
+// RegisterFormat registers the handler of the credential attachment format, e.g. issuecredential.LDProofVCFormat.
+// The handler builds the offer when the proposal is accepted without the offer, builds the credentials
+// when the request is accepted without the credentials, and validates the received credentials.
+func (c *Client) RegisterFormat(format string, handler issuecredential.FormatHandler) error {
+	registrar, ok := c.service.(formatRegistrar)
+	if !ok {
+		return errors.New("issuecredential service does not support credential formats")
+	}
+
+	registrar.RegisterFormat(format, handler)
+
+	return nil
+}
+
+// SetAutoIssuePolicy sets the policy deciding whether the credentials requested by the Holder are issued
+// by the handlers of registered formats (e.g. issuecredential.LDProofVCDetailFormat) without the action event.
+// A nil policy disables auto-issuance.
+func (c *Client) SetAutoIssuePolicy(policy issuecredential.AutoIssuePolicy) error {
+	issuer, ok := c.service.(autoIssuer)
+	if !ok {
+		return errors.New("issuecredential service does not support auto-issuance")
+	}
+
+	issuer.SetAutoIssuePolicy(policy)
+
+	return nil
+}
+
+// SetIssuanceQuota sets the quota of the credentials issued per connection and of the requests processed
+// concurrently. The requests exceeding the quota are declined with a problem report. A nil quota removes the limits.
+func (c *Client) SetIssuanceQuota(quota *issuecredential.IssuanceQuota) error {
+	issuer, ok := c.service.(quotaSetter)
+	if !ok {
+		return errors.New("issuecredential service does not support issuance quota")
+	}
+
+	issuer.SetIssuanceQuota(quota)
+
+	return nil
+}
+
 // AcceptProposal is used when the Issuer is willing to accept the proposal.
+// If msg is nil, the offer is built from the proposal by the handlers of registered credential formats.
 // NOTE: For async usage.
 func (c *Client) AcceptProposal(piID string, msg *OfferCredential) error {
+	if msg == nil {
+		return c.service.ActionContinue(piID, nil)
+	}
+
 	return c.service.ActionContinue(piID, WithOfferCredential(msg))
 }
 
+// AcceptProposalWithPreview is used when the Issuer is willing to offer the credential of the preview
+// in response to the proposal, e.g. to counter the proposal the Holder sent to negotiate the offer.
+// The offer is built for the preview in the formats of the proposal by the handlers of registered credential formats.
+// NOTE: For async usage.
+func (c *Client) AcceptProposalWithPreview(piID string, preview *PreviewBuilder) error {
+	p := preview.Preview()
+
+	return c.service.ActionContinue(piID, issuecredential.WithOfferPreview(&p, preview.Attachments()...))
+}
+
 // DeclineProposal is used when the Issuer does not want to accept the proposal.
 // NOTE: For async usage.
 func (c *Client) DeclineProposal(piID, reason string) error {
@@ -155,8 +265,26 @@ func (c *Client) NegotiateProposal(piID string, msg *ProposeCredential) error {
 }
 
 // AcceptRequest is used when the Issuer is willing to accept the request.
+// If msg is nil, the credentials are issued by the handlers of registered credential formats.
 // NOTE: For async usage.
 func (c *Client) AcceptRequest(piID string, msg *IssueCredential) error {
+	if msg == nil {
+		return c.service.ActionContinue(piID, nil)
+	}
+
+	return c.service.ActionContinue(piID, WithIssueCredential(msg))
+}
+
+// AcceptRequestWithCredentials is used when the Issuer is willing to accept the request and issue
+// several related credentials within the same protocol instance. Each credential is attached
+// to IssueCredential message in LD proof format and the Holder is requested to acknowledge their receipt.
+// NOTE: For async usage.
+func (c *Client) AcceptRequestWithCredentials(piID string, credentials []*verifiable.Credential) error {
+	msg, err := newIssueCredential(credentials)
+	if err != nil {
+		return err
+	}
+
 	return c.service.ActionContinue(piID, WithIssueCredential(msg))
 }
 
@@ -172,6 +300,14 @@ func (c *Client) AcceptCredential(piID string, names ...string) error {
 	return c.service.ActionContinue(piID, WithFriendlyNames(names...))
 }
 
+// AcceptCredentials is used when the Holder is willing to accept the IssueCredential with several credentials
+// and decides about each of them. The credentials without a decision are accepted. The outcome of each credential
+// is reported to the Issuer and provided by the issuecredential.CredentialResultsPropKey event property.
+// NOTE: For async usage.
+func (c *Client) AcceptCredentials(piID string, acceptance ...CredentialAcceptance) error {
+	return c.service.ActionContinue(piID, WithCredentialAcceptance(acceptance...))
+}
+
 // DeclineCredential is used when the Holder does not want to accept the IssueCredential.
 // NOTE: For async usage.
 func (c *Client) DeclineCredential(piID, reason string) error {
@@ -220,3 +356,96 @@ func WithIssueCredential(msg *IssueCredential) issuecredential.Opt {
 func WithFriendlyNames(names ...string) issuecredential.Opt {
 	return issuecredential.WithFriendlyNames(names...)
 }
+
+// WithCredentialAcceptance allows accepting or rejecting each credential attached to IssueCredential message.
+// USAGE: This function should be used when the Holder receives IssueCredential message.
+func WithCredentialAcceptance(acceptance ...CredentialAcceptance) issuecredential.Opt {
+	origin := make([]issuecredential.CredentialAcceptance, len(acceptance))
+	for i := range acceptance {
+		origin[i] = issuecredential.CredentialAcceptance(acceptance[i])
+	}
+
+	return issuecredential.WithCredentialAcceptance(origin...)
+}
+
+func newIssueCredential(credentials []*verifiable.Credential) (*IssueCredential, error) {
+	if len(credentials) == 0 {
+		return nil, errNoCredentials
+	}
+
+	msg := &IssueCredential{
+		Type:      issuecredential.IssueCredentialMsgType,
+		PleaseAck: &decorator.PleaseAck{On: []string{ackOnReceipt}},
+	}
+
+	for _, vc := range credentials {
+		raw, err := vc.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("marshal credential: %w", err)
+		}
+
+		var data map[string]interface{}
+
+		if err = json.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("unmarshal credential: %w", err)
+		}
+
+		attachID := uuid.New().String()
+
+		msg.Formats = append(msg.Formats, issuecredential.Format{
+			AttachID: attachID,
+			Format:   issuecredential.LDProofVCFormat,
+		})
+		msg.CredentialsAttach = append(msg.CredentialsAttach, decorator.Attachment{
+			ID:       attachID,
+			MimeType: "application/json",
+			Data:     decorator.AttachmentData{JSON: data},
+		})
+	}
+
+	return msg, nil
+}
+
+func newProposeCredential(template *CredentialTemplate) (*ProposeCredential, error) {
+	preview := template.Preview
+	if preview == nil {
+		var err error
+
+		preview, err = previewFromCredential(template.Credential)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	raw, err := template.Credential.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential: %w", err)
+	}
+
+	var credential map[string]interface{}
+
+	if err = json.Unmarshal(raw, &credential); err != nil {
+		return nil, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	attachID := uuid.New().String()
+
+	return &ProposeCredential{
+		Type:               issuecredential.ProposeCredentialMsgType,
+		Comment:            template.Comment,
+		CredentialProposal: preview.Preview(),
+		Formats: []issuecredential.Format{{
+			AttachID: attachID,
+			Format:   issuecredential.LDProofVCDetailFormat,
+		}},
+		FiltersAttach: []decorator.Attachment{{
+			ID:       attachID,
+			MimeType: "application/json",
+			Data: decorator.AttachmentData{JSON: map[string]interface{}{
+				"credential": credential,
+				"options":    map[string]interface{}{"proofType": template.ProofType},
+			}},
+		}},
+		Attachments: preview.Attachments(),
+	}, nil
+}
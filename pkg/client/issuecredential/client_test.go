@@ -8,13 +8,17 @@ package issuecredential
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/issuecredential"
 )
 
@@ -157,6 +161,66 @@ func TestClient_SendRequest(t *testing.T) {
 	})
 }
 
+func TestClient_ProposeCredential(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+		DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+			proposal := &issuecredential.ProposeCredential{}
+			require.NoError(t, msg.Decode(proposal))
+			require.Equal(t, issuecredential.ProposeCredentialMsgType, proposal.Type)
+			require.Equal(t, "degree", proposal.Comment)
+			require.Equal(t, "Alice", proposal.CredentialProposal.Attribute("name").Value)
+			require.Len(t, proposal.Attachments, 1)
+			require.Len(t, proposal.FiltersAttach, 1)
+			require.Equal(t, issuecredential.LDProofVCDetailFormat, proposal.Formats[0].Format)
+			require.Equal(t, proposal.FiltersAttach[0].ID, proposal.Formats[0].AttachID)
+
+			detail, ok := proposal.FiltersAttach[0].Data.JSON.(map[string]interface{})
+			require.True(t, ok)
+			require.Equal(t, map[string]interface{}{"proofType": "Ed25519Signature2018"}, detail["options"])
+			require.NotNil(t, detail["credential"])
+
+			return expectedPiid, nil
+		})
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	piid, err := client.ProposeCredential(&CredentialTemplate{
+		Credential: newCredential("http://example.edu/credentials/1"),
+		ProofType:  "Ed25519Signature2018",
+		Preview:    NewPreviewBuilder().Attribute("name", "Alice").Attachment("photo", "image/png", []byte("png")),
+		Comment:    "degree",
+	}, Alice, Bob)
+	require.NoError(t, err)
+	require.Equal(t, expectedPiid, piid)
+
+	_, err = client.ProposeCredential(&CredentialTemplate{}, Alice, Bob)
+	require.EqualError(t, err, errNoTemplate.Error())
+}
+
+func TestClient_AcceptProposalWithPreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Not(gomock.Nil())).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.AcceptProposalWithPreview("PIID", NewPreviewBuilder().Attribute("name", "Alice")))
+}
+
 func TestClient_AcceptProposal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -164,13 +228,15 @@ func TestClient_AcceptProposal(t *testing.T) {
 	provider := mocks.NewMockProvider(ctrl)
 
 	svc := mocks.NewMockProtocolService(ctrl)
-	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+	svc.EXPECT().ActionContinue("PIID", gomock.Not(gomock.Nil())).Return(nil)
+	svc.EXPECT().ActionContinue("PIID", gomock.Nil()).Return(nil)
 
 	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
 	client, err := New(provider)
 	require.NoError(t, err)
 
 	require.NoError(t, client.AcceptProposal("PIID", &OfferCredential{}))
+	require.NoError(t, client.AcceptProposal("PIID", nil))
 }
 
 func TestClient_DeclineProposal(t *testing.T) {
@@ -228,13 +294,54 @@ func TestClient_AcceptRequest(t *testing.T) {
 	provider := mocks.NewMockProvider(ctrl)
 
 	svc := mocks.NewMockProtocolService(ctrl)
-	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+	svc.EXPECT().ActionContinue("PIID", gomock.Not(gomock.Nil())).Return(nil)
+	svc.EXPECT().ActionContinue("PIID", gomock.Nil()).Return(nil)
 
 	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
 	client, err := New(provider)
 	require.NoError(t, err)
 
 	require.NoError(t, client.AcceptRequest("PIID", &IssueCredential{}))
+	require.NoError(t, client.AcceptRequest("PIID", nil))
+}
+
+func TestClient_AcceptRequestWithCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.AcceptRequestWithCredentials("PIID", []*verifiable.Credential{
+		newCredential("http://example.edu/credentials/1"),
+		newCredential("http://example.edu/credentials/2"),
+	}))
+	require.EqualError(t, client.AcceptRequestWithCredentials("PIID", nil), errNoCredentials.Error())
+}
+
+func Test_newIssueCredential(t *testing.T) {
+	msg, err := newIssueCredential([]*verifiable.Credential{
+		newCredential("http://example.edu/credentials/1"),
+		newCredential("http://example.edu/credentials/2"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, issuecredential.IssueCredentialMsgType, msg.Type)
+	require.Equal(t, []string{ackOnReceipt}, msg.PleaseAck.On)
+	require.Len(t, msg.CredentialsAttach, 2)
+	require.Len(t, msg.Formats, 2)
+
+	for i, f := range msg.Formats {
+		require.Equal(t, issuecredential.LDProofVCFormat, f.Format)
+		require.Equal(t, msg.CredentialsAttach[i].ID, f.AttachID)
+		require.Equal(t, fmt.Sprintf("http://example.edu/credentials/%d", i+1),
+			msg.CredentialsAttach[i].Data.JSON.(map[string]interface{})["id"])
+	}
 }
 
 func TestClient_DeclineRequest(t *testing.T) {
@@ -301,6 +408,25 @@ func TestClient_AcceptCredential(t *testing.T) {
 	require.NoError(t, client.AcceptCredential("PIID"))
 }
 
+func TestClient_AcceptCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.AcceptCredentials("PIID",
+		CredentialAcceptance{AttachID: "1", Name: "degree", Accept: true},
+		CredentialAcceptance{AttachID: "2", Accept: false},
+	))
+}
+
 func TestClient_DeclineCredential(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -316,3 +442,127 @@ func TestClient_DeclineCredential(t *testing.T) {
 
 	require.NoError(t, client.DeclineCredential("PIID", "the reason"))
 }
+
+type formatService struct {
+	*mocks.MockProtocolService
+	formats map[string]issuecredential.FormatHandler
+	policy  issuecredential.AutoIssuePolicy
+	quota   *issuecredential.IssuanceQuota
+}
+
+func (s *formatService) SetAutoIssuePolicy(policy issuecredential.AutoIssuePolicy) {
+	s.policy = policy
+}
+
+func (s *formatService) SetIssuanceQuota(quota *issuecredential.IssuanceQuota) {
+	s.quota = quota
+}
+
+func (s *formatService) RegisterFormat(format string, handler issuecredential.FormatHandler) {
+	s.formats[format] = handler
+}
+
+func TestClient_RegisterFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		svc := &formatService{
+			MockProtocolService: mocks.NewMockProtocolService(ctrl),
+			formats:             map[string]issuecredential.FormatHandler{},
+		}
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		require.NoError(t, client.RegisterFormat(issuecredential.LDProofVCFormat, nil))
+		require.Contains(t, svc.formats, issuecredential.LDProofVCFormat)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		err = client.RegisterFormat(issuecredential.LDProofVCFormat, nil)
+		require.EqualError(t, err, "issuecredential service does not support credential formats")
+	})
+}
+
+func TestClient_SetAutoIssuePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		svc := &formatService{MockProtocolService: mocks.NewMockProtocolService(ctrl)}
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		require.NoError(t, client.SetAutoIssuePolicy(func(_ *issuecredential.RequestCredential, _, _ string) bool {
+			return true
+		}))
+		require.NotNil(t, svc.policy)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		err = client.SetAutoIssuePolicy(nil)
+		require.EqualError(t, err, "issuecredential service does not support auto-issuance")
+	})
+}
+
+func TestClient_SetIssuanceQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		svc := &formatService{MockProtocolService: mocks.NewMockProtocolService(ctrl)}
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		quota := &issuecredential.IssuanceQuota{PerConnection: 10, Period: time.Hour, MaxConcurrent: 5}
+
+		require.NoError(t, client.SetIssuanceQuota(quota))
+		require.Equal(t, quota, svc.quota)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		err = client.SetIssuanceQuota(nil)
+		require.EqualError(t, err, "issuecredential service does not support issuance quota")
+	})
+}
+
+func newCredential(id string) *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      id,
+		Types:   []string{"VerifiableCredential"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  util.NewTime(time.Now()),
+	}
+}
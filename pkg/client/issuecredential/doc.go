@@ -32,6 +32,8 @@ SPDX-License-Identifier: Apache-2.0
 //        if event.Message.Type() == presentproof.ProposeCredentialMsgType {
 //          // If Issuer is willing to accept the proposal.
 //          client.AcceptProposal(piid, &OfferCredential{})
+//          // If Issuer wants to offer another credential preview, e.g. to counter the Holder's negotiation.
+//          client.AcceptProposalWithPreview(piid, NewPreviewBuilder().Attribute("name", "Alice"))
 //          // If Issuer is not willing to accept the proposal.
 //          client.DeclineProposal(piid, reason)
 //        }
@@ -74,6 +76,8 @@ SPDX-License-Identifier: Apache-2.0
 //
 // 1. The Holder can begin with a proposal.
 //  client.SendProposal(&ProposeCredential{}, myDID, theirDID)
+//  or with the template of the credential to be issued
+//  client.ProposeCredential(&CredentialTemplate{Credential: vc, ProofType: "Ed25519Signature2018"}, myDID, theirDID)
 // 2. Holder can begin with a request.
 //  client.SendRequest(&RequestCredential{}, myDID, theirDID)
 //
@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// PreviewBuilder builds the credential preview of ProposeCredential and OfferCredential messages
+// with the attachments referenced by its attributes.
+//
+//	builder := NewPreviewBuilder().
+//		Attribute("name", "Alice").
+//		AttributeWithMimeType("birthdate", "1990-01-01", "text/plain").
+//		Attachment("photo", "image/png", photo)
+type PreviewBuilder struct {
+	attributes  []issuecredential.Attribute
+	attachments []decorator.Attachment
+}
+
+// NewPreviewBuilder returns new instance of PreviewBuilder.
+func NewPreviewBuilder() *PreviewBuilder {
+	return &PreviewBuilder{}
+}
+
+// Attribute adds the attribute with the value.
+func (b *PreviewBuilder) Attribute(name, value string) *PreviewBuilder {
+	return b.AttributeWithMimeType(name, value, "")
+}
+
+// AttributeWithMimeType adds the attribute with the value of the MIME type.
+func (b *PreviewBuilder) AttributeWithMimeType(name, value, mimeType string) *PreviewBuilder {
+	b.attributes = append(b.attributes, issuecredential.Attribute{
+		Name:     name,
+		MimeType: mimeType,
+		Value:    value,
+	})
+
+	return b
+}
+
+// Attachment adds the data of the MIME type as an attachment and the attribute referring to it
+// (see issuecredential.Attribute.AttachmentRef).
+func (b *PreviewBuilder) Attachment(name, mimeType string, data []byte) *PreviewBuilder {
+	id := uuid.New().String()
+
+	b.attachments = append(b.attachments, decorator.Attachment{
+		ID:       id,
+		MimeType: mimeType,
+		Data:     decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(data)},
+	})
+
+	return b.AttributeWithMimeType(name, issuecredential.AttachmentRefPrefix+id, mimeType)
+}
+
+// Preview returns the credential preview.
+func (b *PreviewBuilder) Preview() issuecredential.PreviewCredential {
+	return *issuecredential.NewCredentialPreview(b.attributes...)
+}
+
+// Attachments returns the attachments referenced by the attributes of the preview.
+func (b *PreviewBuilder) Attachments() []decorator.Attachment {
+	return b.attachments
+}
+
+// previewFromCredential returns the builder of the preview with the attributes of the credential subject.
+func previewFromCredential(vc *verifiable.Credential) (*PreviewBuilder, error) {
+	raw, err := vc.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential: %w", err)
+	}
+
+	doc := struct {
+		Subject map[string]interface{} `json:"credentialSubject"`
+	}{}
+
+	// the credential with many subjects has no preview
+	if json.Unmarshal(raw, &doc) != nil {
+		return NewPreviewBuilder(), nil
+	}
+
+	names := make([]string, 0, len(doc.Subject))
+
+	for name := range doc.Subject {
+		if name != "id" {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	builder := NewPreviewBuilder()
+
+	for _, name := range names {
+		value, ok := doc.Subject[name].(string)
+		if !ok {
+			src, err := json.Marshal(doc.Subject[name])
+			if err != nil {
+				return nil, fmt.Errorf("marshal attribute %s: %w", name, err)
+			}
+
+			value = string(src)
+		}
+
+		builder.Attribute(name, value)
+	}
+
+	return builder, nil
+}
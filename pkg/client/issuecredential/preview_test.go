@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+func TestPreviewBuilder(t *testing.T) {
+	builder := NewPreviewBuilder().
+		Attribute("name", "Alice").
+		AttributeWithMimeType("birthdate", "1990-01-01", "text/plain").
+		Attachment("photo", "image/png", []byte("png"))
+
+	preview := builder.Preview()
+	require.Equal(t, issuecredential.CredentialPreviewMsgType, preview.Type)
+	require.Len(t, preview.Attributes, 3)
+	require.Equal(t, "Alice", preview.Attribute("name").Value)
+	require.Equal(t, "text/plain", preview.Attribute("birthdate").MimeType)
+
+	photo := preview.Attribute("photo")
+	require.Equal(t, "image/png", photo.MimeType)
+
+	id, ok := photo.AttachmentRef()
+	require.True(t, ok)
+
+	attachments := builder.Attachments()
+	require.Len(t, attachments, 1)
+	require.Equal(t, id, attachments[0].ID)
+	require.Equal(t, "image/png", attachments[0].MimeType)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("png")), attachments[0].Data.Base64)
+}
+
+func Test_previewFromCredential(t *testing.T) {
+	vc := newCredential("http://example.edu/credentials/1")
+	vc.Subject = verifiable.Subject{
+		ID:           "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		CustomFields: verifiable.CustomFields{"name": "Alice", "age": 30},
+	}
+
+	builder, err := previewFromCredential(vc)
+	require.NoError(t, err)
+
+	preview := builder.Preview()
+	require.Equal(t, []issuecredential.Attribute{
+		{Name: "age", Value: "30"},
+		{Name: "name", Value: "Alice"},
+	}, preview.Attributes)
+
+	builder, err = previewFromCredential(newCredential("http://example.edu/credentials/2"))
+	require.NoError(t, err)
+	require.Empty(t, builder.Preview().Attributes)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyescrow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keyescrow"
+)
+
+type (
+	// Escrow is the backup of a secret by its owner.
+	Escrow = keyescrow.Escrow
+	// Holding is the shard of an escrow held by this agent as a trustee.
+	Holding = keyescrow.Holding
+	// ReleaseRequest is a recovery request received by this agent as a trustee.
+	ReleaseRequest = keyescrow.ReleaseRequest
+	// Recovery gathers the shards released by the trustees of an escrow.
+	Recovery = keyescrow.Recovery
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	// DIDComm service
+	service.DIDComm
+
+	Backup(label string, secret []byte, threshold int, connectionIDs []string) (string, error)
+
+	Recover(escrowID, comment string, connectionIDs []string) (string, error)
+
+	Recovered(recoveryID string) ([]byte, error)
+
+	Approve(requestID string) error
+
+	Decline(requestID, reason string) error
+
+	Escrow(escrowID string) (*keyescrow.Escrow, error)
+
+	Escrows() ([]*keyescrow.Escrow, error)
+
+	Holdings() ([]*keyescrow.Holding, error)
+
+	ReleaseRequest(requestID string) (*keyescrow.ReleaseRequest, error)
+
+	Recovery(recoveryID string) (*keyescrow.Recovery, error)
+}
+
+// Client enable access to key escrow api.
+type Client struct {
+	service.Event
+	keyEscrowSvc protocolService
+}
+
+// New return new instance of key escrow client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(keyescrow.KeyEscrow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key escrow service: %w", err)
+	}
+
+	keyEscrowSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to key escrow service failed")
+	}
+
+	return &Client{
+		Event:        keyEscrowSvc,
+		keyEscrowSvc: keyEscrowSvc,
+	}, nil
+}
+
+// Backup backs up the secret to the trustees of the given connections, any threshold of which can release
+// their shards to recover it. It returns the escrow ID, which must be kept to recover the secret.
+func (c *Client) Backup(label string, secret []byte, threshold int, connectionIDs ...string) (string, error) {
+	escrowID, err := c.keyEscrowSvc.Backup(label, secret, threshold, connectionIDs)
+	if err != nil {
+		return "", fmt.Errorf("key escrow client - backup: %w", err)
+	}
+
+	return escrowID, nil
+}
+
+// Recover asks the trustees of the given connections to release their shards of the escrow.
+// It returns the recovery ID, see Recovered.
+func (c *Client) Recover(escrowID, comment string, connectionIDs ...string) (string, error) {
+	recoveryID, err := c.keyEscrowSvc.Recover(escrowID, comment, connectionIDs)
+	if err != nil {
+		return "", fmt.Errorf("key escrow client - recover: %w", err)
+	}
+
+	return recoveryID, nil
+}
+
+// Recovered returns the secret once the threshold of shards were released by the trustees.
+func (c *Client) Recovered(recoveryID string) ([]byte, error) {
+	secret, err := c.keyEscrowSvc.Recovered(recoveryID)
+	if err != nil {
+		return nil, fmt.Errorf("key escrow client - recovered: %w", err)
+	}
+
+	return secret, nil
+}
+
+// ApproveRelease releases the shard held by this agent to the owner of the escrow who requested it.
+func (c *Client) ApproveRelease(requestID string) error {
+	if err := c.keyEscrowSvc.Approve(requestID); err != nil {
+		return fmt.Errorf("key escrow client - approve release: %w", err)
+	}
+
+	return nil
+}
+
+// DeclineRelease declines the release of the shard held by this agent.
+func (c *Client) DeclineRelease(requestID, reason string) error {
+	if err := c.keyEscrowSvc.Decline(requestID, reason); err != nil {
+		return fmt.Errorf("key escrow client - decline release: %w", err)
+	}
+
+	return nil
+}
+
+// Escrow returns the escrow with the given ID.
+func (c *Client) Escrow(escrowID string) (*Escrow, error) {
+	return c.keyEscrowSvc.Escrow(escrowID)
+}
+
+// Escrows returns the escrows of the secrets backed up by this agent.
+func (c *Client) Escrows() ([]*Escrow, error) {
+	return c.keyEscrowSvc.Escrows()
+}
+
+// Holdings returns the shards held by this agent as a trustee.
+func (c *Client) Holdings() ([]*Holding, error) {
+	return c.keyEscrowSvc.Holdings()
+}
+
+// ReleaseRequest returns the release request with the given ID.
+func (c *Client) ReleaseRequest(requestID string) (*ReleaseRequest, error) {
+	return c.keyEscrowSvc.ReleaseRequest(requestID)
+}
+
+// Recovery returns the recovery with the given ID.
+func (c *Client) Recovery(recoveryID string) (*Recovery, error) {
+	return c.keyEscrowSvc.Recovery(recoveryID)
+}
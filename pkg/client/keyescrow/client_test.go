@@ -0,0 +1,168 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyescrow
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keyescrow"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	ownerDID = "did:example:owner"
+	bobDID   = "did:example:bob"
+	carolDID = "did:example:carol"
+)
+
+// connectionIDs are the IDs of the connections to the agents, by DID of the agent.
+var connectionIDs = map[string]string{
+	ownerDID: uuid.New().String(),
+	bobDID:   uuid.New().String(),
+	carolDID: uuid.New().String(),
+}
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		svc, err := keyescrow.New(newProvider(t, ownerDID, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		client, err := New(&mockprovider.Provider{ServiceValue: svc})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to key escrow service failed")
+	})
+}
+
+func TestClient_BackupRecover(t *testing.T) {
+	services := map[string]*keyescrow.Service{}
+	clients := map[string]*Client{}
+
+	for _, did := range []string{ownerDID, bobDID, carolDID} {
+		svc, err := keyescrow.New(newProvider(t, did, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				_, e := services[theirDID].HandleInbound(service.NewDIDCommMsgMap(msg),
+					service.NewDIDCommContext(theirDID, myDID, nil))
+
+				return e
+			},
+		}, ownerDID, bobDID, carolDID))
+		require.NoError(t, err)
+
+		services[did] = svc
+
+		clients[did], err = New(&mockprovider.Provider{ServiceValue: svc})
+		require.NoError(t, err)
+	}
+
+	secret := []byte("wallet secret")
+
+	escrowID, err := clients[ownerDID].Backup("wallet", secret, 2, connectionIDs[bobDID], connectionIDs[carolDID])
+	require.NoError(t, err)
+
+	escrow, err := clients[ownerDID].Escrow(escrowID)
+	require.NoError(t, err)
+	require.Equal(t, keyescrow.StateDeposited, escrow.State)
+
+	escrows, err := clients[ownerDID].Escrows()
+	require.NoError(t, err)
+	require.Len(t, escrows, 1)
+
+	holdings, err := clients[bobDID].Holdings()
+	require.NoError(t, err)
+	require.Len(t, holdings, 1)
+
+	for _, did := range []string{bobDID, carolDID} {
+		require.NoError(t, clients[did].RegisterActionEvent(make(chan service.DIDCommAction, 1)))
+	}
+
+	recoveryID, err := clients[ownerDID].Recover(escrowID, "new phone", connectionIDs[bobDID], connectionIDs[carolDID])
+	require.NoError(t, err)
+
+	request, err := clients[bobDID].ReleaseRequest(recoveryID)
+	require.NoError(t, err)
+	require.Equal(t, "new phone", request.Comment)
+
+	require.NoError(t, clients[bobDID].ApproveRelease(recoveryID))
+	require.NoError(t, clients[carolDID].ApproveRelease(recoveryID))
+
+	recovery, err := clients[ownerDID].Recovery(recoveryID)
+	require.NoError(t, err)
+	require.Equal(t, keyescrow.StateRecovered, recovery.State)
+
+	recovered, err := clients[ownerDID].Recovered(recoveryID)
+	require.NoError(t, err)
+	require.Equal(t, secret, recovered)
+
+	t.Run("errors", func(t *testing.T) {
+		_, err = clients[ownerDID].Backup("wallet", secret, 2)
+		require.EqualError(t, err, "key escrow client - backup: no trustee connections")
+
+		_, err = clients[ownerDID].Recover("", "", connectionIDs[bobDID])
+		require.EqualError(t, err, "key escrow client - recover: escrow ID is mandatory")
+
+		_, err = clients[ownerDID].Recovered("unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key escrow client - recovered")
+
+		err = clients[bobDID].ApproveRelease(recoveryID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key escrow client - approve release")
+
+		err = clients[bobDID].DeclineRelease(recoveryID, "no")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key escrow client - decline release")
+	})
+}
+
+func newProvider(t *testing.T, myDID string, outbound *mockdispatcher.MockOutbound,
+	theirDIDs ...string) *mockprovider.Provider {
+	t.Helper()
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+		OutboundDispatcherValue:           outbound,
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	for _, theirDID := range theirDIDs {
+		if theirDID == myDID {
+			continue
+		}
+
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: connectionIDs[theirDID],
+			State:        "completed",
+			MyDID:        myDID,
+			TheirDID:     theirDID,
+		}))
+	}
+
+	return prov
+}
@@ -112,6 +112,11 @@ type OobService interface {
 	ActionStop(string, error) error
 }
 
+// goalCodeRegistrar is implemented by the services supporting the goal code handlers.
+type goalCodeRegistrar interface {
+	RegisterGoalCode(goalCode string, handler outofband.GoalCodeHandler)
+}
+
 // Provider provides the dependencies for the client.
 type Provider interface {
 	ServiceEndpoint() string
@@ -125,6 +130,8 @@ type Client struct {
 	service.Event
 	didDocSvcFunc func(routerConnID string) (*did.Service, error)
 	oobService    OobService
+	// publicDID is the public DID of the agent the invitations target by default (see aries.WithPublicDID).
+	publicDID string
 }
 
 // New returns a new Client for the Out-Of-Band protocol.
@@ -139,10 +146,16 @@ func New(p Provider) (*Client, error) {
 		return nil, fmt.Errorf("failed to cast service %s as a dependency", outofband.Name)
 	}
 
+	var publicDID string
+	if pp, ok := p.(interface{ PublicDID() string }); ok {
+		publicDID = pp.PublicDID()
+	}
+
 	return &Client{
 		Event:         oobSvc,
 		didDocSvcFunc: didServiceBlockFunc(p),
 		oobService:    oobSvc,
+		publicDID:     publicDID,
 	}, nil
 }
 
@@ -169,14 +182,17 @@ func (c *Client) CreateInvitation(services []interface{}, opts ...MessageOption)
 		Requests:  msg.Attachments,
 	}
 
-	if len(inv.Services) == 0 {
+	switch {
+	case len(inv.Services) == 0 && c.publicDID != "" && msg.RouterConnection() == "":
+		inv.Services = []interface{}{c.publicDID}
+	case len(inv.Services) == 0:
 		svc, err := c.didDocSvcFunc(msg.RouterConnection())
 		if err != nil {
 			return nil, fmt.Errorf("failed to create a new inlined did doc service block : %w", err)
 		}
 
 		inv.Services = []interface{}{svc}
-	} else {
+	default:
 		err := validateServices(inv.Services...)
 		if err != nil {
 			return nil, fmt.Errorf("invalid service: %w", err)
@@ -261,6 +277,20 @@ func (c *Client) AcceptInvitation(i *Invitation, myLabel string, opts ...Message
 	return connID, err
 }
 
+// RegisterGoalCode registers the handler of the accepted invitations with the goal code. The handler is called
+// once the connection for the invitation is established, instead of dispatching the message attached
+// to the invitation (e.g. a present-proof request) to the service of its protocol.
+func (c *Client) RegisterGoalCode(goalCode string, handler outofband.GoalCodeHandler) error {
+	registrar, ok := c.oobService.(goalCodeRegistrar)
+	if !ok {
+		return errors.New("outofband service does not support goal codes")
+	}
+
+	registrar.RegisterGoalCode(goalCode, handler)
+
+	return nil
+}
+
 // WithLabel allows you to specify the label on the message.
 func WithLabel(l string) MessageOption {
 	return func(m *message) {
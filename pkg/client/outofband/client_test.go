@@ -70,6 +70,22 @@ func TestCreateInvitation(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, []string{didexchange.PIURI}, inv.Protocols)
 	})
+	t.Run("targets the public DID of the agent", func(t *testing.T) {
+		c, err := New(&publicDIDProvider{Provider: withTestProvider(), did: "did:web:agent.example.com"})
+		require.NoError(t, err)
+		inv, err := c.CreateInvitation(nil)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"did:web:agent.example.com"}, inv.Services)
+
+		// the invitations routed through a router use the inlined service block
+		c.didDocSvcFunc = func(conn string) (*did.Service, error) {
+			return &did.Service{ServiceEndpoint: conn}, nil
+		}
+
+		inv, err = c.CreateInvitation(nil, WithRouterConnections("xyz"))
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{&did.Service{ServiceEndpoint: "xyz"}}, inv.Services)
+	})
 	t.Run("includes the diddoc Service block returned by provider", func(t *testing.T) {
 		expected := &did.Service{
 			ID:              uuid.New().String(),
@@ -339,6 +355,47 @@ type didcommMsg struct {
 	Type string
 }
 
+type goalCodeOOBService struct {
+	stubOOBService
+	goalCodes map[string]outofband.GoalCodeHandler
+}
+
+func (s *goalCodeOOBService) RegisterGoalCode(goalCode string, handler outofband.GoalCodeHandler) {
+	s.goalCodes[goalCode] = handler
+}
+
+func TestRegisterGoalCode(t *testing.T) {
+	t.Run("registers the handler", func(t *testing.T) {
+		svc := &goalCodeOOBService{goalCodes: map[string]outofband.GoalCodeHandler{}}
+
+		provider := withTestProvider()
+		provider.ServiceMap[outofband.Name] = svc
+
+		c, err := New(provider)
+		require.NoError(t, err)
+
+		require.NoError(t, c.RegisterGoalCode("issue-vc", func(*outofband.GoalContext) error { return nil }))
+		require.NotNil(t, svc.goalCodes["issue-vc"])
+	})
+
+	t.Run("service does not support goal codes", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+
+		err = c.RegisterGoalCode("issue-vc", func(*outofband.GoalContext) error { return nil })
+		require.EqualError(t, err, "outofband service does not support goal codes")
+	})
+}
+
+type publicDIDProvider struct {
+	*mockprovider.Provider
+	did string
+}
+
+func (p *publicDIDProvider) PublicDID() string {
+	return p.did
+}
+
 func withTestProvider() *mockprovider.Provider {
 	mockKey, err := mockkms.CreateMockED25519KeyHandle()
 	if err != nil {
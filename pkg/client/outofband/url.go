@@ -0,0 +1,159 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// InvitationURLParam is the query parameter of the invitation URL containing the encoded invitation.
+	InvitationURLParam = "oob"
+
+	// ShortURLStoreName is the name of the store used by StoreShortener.
+	ShortURLStoreName = "oob_short_url"
+
+	shortCodeLength = 8
+)
+
+// ErrShortCodeNotFound is returned when the short code is not known to the StoreShortener.
+var ErrShortCodeNotFound = errors.New("short code not found")
+
+// InvitationURL encodes the invitation into the `oob` query parameter of the base URL (RFC 0434),
+// e.g. https://example.com/path?oob=eyJAdHlwZSI6Li4ufQ.
+func InvitationURL(baseURL string, inv *Invitation) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parse base URL: %w", err)
+	}
+
+	raw, err := json.Marshal(inv)
+	if err != nil {
+		return "", fmt.Errorf("marshal invitation: %w", err)
+	}
+
+	query := u.Query()
+	query.Set(InvitationURLParam, base64.RawURLEncoding.EncodeToString(raw))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// ParseInvitationURL decodes the invitation from the `oob` query parameter of the invitation URL.
+func ParseInvitationURL(invitationURL string) (*Invitation, error) {
+	u, err := url.Parse(invitationURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse invitation URL: %w", err)
+	}
+
+	encoded := u.Query().Get(InvitationURLParam)
+	if encoded == "" {
+		return nil, fmt.Errorf("invitation URL has no %q query parameter", InvitationURLParam)
+	}
+
+	// the senders may pad the value, the padding is not significant for the decoding
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode invitation: %w", err)
+	}
+
+	inv := &Invitation{}
+
+	err = json.Unmarshal(raw, inv)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal invitation: %w", err)
+	}
+
+	return inv, nil
+}
+
+// URLShortener shortens the invitation URLs, e.g. to make them small enough for QR codes.
+type URLShortener interface {
+	Shorten(invitationURL string) (string, error)
+}
+
+// ShortInvitationURL encodes the invitation into the `oob` URL and shortens it with the URLShortener.
+func ShortInvitationURL(s URLShortener, baseURL string, inv *Invitation) (string, error) {
+	invitationURL, err := InvitationURL(baseURL, inv)
+	if err != nil {
+		return "", err
+	}
+
+	shortURL, err := s.Shorten(invitationURL)
+	if err != nil {
+		return "", fmt.Errorf("shorten invitation URL: %w", err)
+	}
+
+	return shortURL, nil
+}
+
+// StoreShortener is the built-in URLShortener. It keeps the invitation URLs in the store under random short codes
+// and returns the short URLs made of the base URL and the code, e.g. https://agent.example.com/oob/5nC7ZZvgnwE.
+// The base URL is expected to be served by a redirect handler resolving the codes with Resolve
+// (see the redirect handler of the outofband REST controller).
+type StoreShortener struct {
+	store   storage.Store
+	baseURL string
+}
+
+// NewStoreShortener returns new StoreShortener.
+func NewStoreShortener(p storage.Provider, baseURL string) (*StoreShortener, error) {
+	store, err := p.OpenStore(ShortURLStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	return &StoreShortener{store: store, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+// Shorten saves the invitation URL under a new short code and returns the short URL.
+func (s *StoreShortener) Shorten(invitationURL string) (string, error) {
+	code, err := newShortCode()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.store.Put(code, []byte(invitationURL))
+	if err != nil {
+		return "", fmt.Errorf("save invitation URL: %w", err)
+	}
+
+	return s.baseURL + "/" + code, nil
+}
+
+// Resolve returns the invitation URL saved under the short code.
+func (s *StoreShortener) Resolve(code string) (string, error) {
+	invitationURL, err := s.store.Get(code)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return "", ErrShortCodeNotFound
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("get invitation URL: %w", err)
+	}
+
+	return string(invitationURL), nil
+}
+
+func newShortCode() (string, error) {
+	b := make([]byte, shortCodeLength)
+
+	_, err := random.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("generate short code: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
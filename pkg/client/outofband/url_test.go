@@ -0,0 +1,154 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestInvitationURL(t *testing.T) {
+	inv := &Invitation{
+		ID:        "1234",
+		Type:      InvitationMsgType,
+		Label:     "Faber College",
+		Services:  []interface{}{"did:example:123"},
+		Protocols: []string{"https://didcomm.org/didexchange/1.0"},
+	}
+
+	t.Run("encodes and decodes the invitation", func(t *testing.T) {
+		invitationURL, err := InvitationURL("https://example.com/path?a=b", inv)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(invitationURL, "https://example.com/path?"))
+
+		u, err := url.Parse(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, "b", u.Query().Get("a"))
+		require.NotEmpty(t, u.Query().Get(InvitationURLParam))
+
+		result, err := ParseInvitationURL(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, inv, result)
+	})
+
+	t.Run("decodes padded invitation", func(t *testing.T) {
+		invitationURL, err := InvitationURL("https://example.com", inv)
+		require.NoError(t, err)
+
+		u, err := url.Parse(invitationURL)
+		require.NoError(t, err)
+
+		query := u.Query()
+		query.Set(InvitationURLParam, query.Get(InvitationURLParam)+"==")
+		u.RawQuery = query.Encode()
+
+		result, err := ParseInvitationURL(u.String())
+		require.NoError(t, err)
+		require.Equal(t, inv, result)
+	})
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		_, err := InvitationURL("%", inv)
+		require.Contains(t, err.Error(), "parse base URL")
+	})
+
+	t.Run("invalid invitation URL", func(t *testing.T) {
+		_, err := ParseInvitationURL("%")
+		require.Contains(t, err.Error(), "parse invitation URL")
+
+		_, err = ParseInvitationURL("https://example.com")
+		require.Contains(t, err.Error(), `has no "oob" query parameter`)
+
+		_, err = ParseInvitationURL("https://example.com?oob=!")
+		require.Contains(t, err.Error(), "decode invitation")
+
+		_, err = ParseInvitationURL("https://example.com?oob=e30x")
+		require.Contains(t, err.Error(), "unmarshal invitation")
+	})
+}
+
+type stubShortener struct {
+	shortURL string
+	err      error
+}
+
+func (s *stubShortener) Shorten(string) (string, error) {
+	return s.shortURL, s.err
+}
+
+func TestShortInvitationURL(t *testing.T) {
+	inv := &Invitation{ID: "1234", Type: InvitationMsgType}
+
+	t.Run("success", func(t *testing.T) {
+		shortURL, err := ShortInvitationURL(&stubShortener{shortURL: "https://s.example.com/1"}, "https://example.com", inv)
+		require.NoError(t, err)
+		require.Equal(t, "https://s.example.com/1", shortURL)
+	})
+
+	t.Run("shortener error", func(t *testing.T) {
+		_, err := ShortInvitationURL(&stubShortener{err: errors.New("test")}, "https://example.com", inv)
+		require.EqualError(t, err, "shorten invitation URL: test")
+	})
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		_, err := ShortInvitationURL(&stubShortener{}, "%", inv)
+		require.Contains(t, err.Error(), "parse base URL")
+	})
+}
+
+func TestStoreShortener(t *testing.T) {
+	t.Run("shortens and resolves the URL", func(t *testing.T) {
+		s, err := NewStoreShortener(mockstore.NewMockStoreProvider(), "https://agent.example.com/oob/")
+		require.NoError(t, err)
+
+		invitationURL, err := InvitationURL("https://example.com", &Invitation{ID: "1234", Type: InvitationMsgType})
+		require.NoError(t, err)
+
+		shortURL, err := s.Shorten(invitationURL)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(shortURL, "https://agent.example.com/oob/"))
+
+		code := strings.TrimPrefix(shortURL, "https://agent.example.com/oob/")
+		require.NotEmpty(t, code)
+
+		result, err := s.Resolve(code)
+		require.NoError(t, err)
+		require.Equal(t, invitationURL, result)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		s, err := NewStoreShortener(mockstore.NewMockStoreProvider(), "https://agent.example.com/oob")
+		require.NoError(t, err)
+
+		_, err = s.Resolve("unknown")
+		require.True(t, errors.Is(err, ErrShortCodeNotFound))
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		_, err := NewStoreShortener(&mockstore.MockStoreProvider{FailNamespace: ShortURLStoreName}, "")
+		require.Contains(t, err.Error(), "open store")
+
+		provider := mockstore.NewMockStoreProvider()
+		provider.Store.ErrPut = errors.New("put error")
+		provider.Store.ErrGet = errors.New("get error")
+
+		s, err := NewStoreShortener(provider, "https://agent.example.com/oob")
+		require.NoError(t, err)
+
+		_, err = s.Shorten("https://example.com?oob=e30")
+		require.EqualError(t, err, "save invitation URL: put error")
+
+		_, err = s.Resolve("code")
+		require.EqualError(t, err, "get invitation URL: get error")
+	})
+}
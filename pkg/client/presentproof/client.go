@@ -8,10 +8,21 @@ package presentproof
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	jsonThread   = "~thread"
+	jsonThreadID = "thid"
 )
 
 type (
@@ -25,11 +36,16 @@ type (
 	ProposePresentation presentproof.ProposePresentation
 	// Action contains helpful information about action.
 	Action presentproof.Action
+	// Record is the record of a protocol instance.
+	Record presentproof.Record
+	// RecordFilter selects the records, its empty fields match all the records.
+	RecordFilter presentproof.RecordFilter
 )
 
 var (
-	errEmptyRequestPresentation = errors.New("request presentation message is empty")
-	errEmptyProposePresentation = errors.New("propose presentation message is empty")
+	errEmptyRequestPresentation   = errors.New("request presentation message is empty")
+	errEmptyProposePresentation   = errors.New("propose presentation message is empty")
+	errConnectionlessNotSupported = errors.New("connection-less requests are not supported by the provider")
 )
 
 // Provider contains dependencies for the protocol and is typically created by using aries.Context().
@@ -37,19 +53,30 @@ type Provider interface {
 	Service(id string) (interface{}, error)
 }
 
+// connectionlessProvider is implemented by the providers supporting connection-less requests
+// (e.g. aries.Context()).
+type connectionlessProvider interface {
+	KMS() kms.KeyManager
+	ServiceEndpoint() string
+}
+
 // ProtocolService defines the presentproof service.
 type ProtocolService interface {
 	service.DIDComm
 	Actions() ([]presentproof.Action, error)
 	ActionContinue(piID string, opt presentproof.Opt) error
 	ActionStop(piID string, err error) error
+	Records(filter *presentproof.RecordFilter) ([]presentproof.Record, error)
+	Record(piID string) (*presentproof.Record, error)
+	Abandon(piID, reason string) error
 }
 
 // Client enable access to presentproof API
 // https://github.com/hyperledger/aries-rfcs/tree/master/features/0037-present-proof
 type Client struct {
 	service.Event
-	service ProtocolService
+	service        ProtocolService
+	connectionless connectionlessProvider
 }
 
 // New returns new instance of the presentproof client.
@@ -64,10 +91,16 @@ func New(ctx Provider) (*Client, error) {
 		return nil, errors.New("cast service to presentproof service failed")
 	}
 
-	return &Client{
+	client := &Client{
 		Event:   svc,
 		service: svc,
-	}, nil
+	}
+
+	if cp, ok := ctx.(connectionlessProvider); ok {
+		client.connectionless = cp
+	}
+
+	return client, nil
 }
 
 // Actions returns pending actions that have yet to be executed or cancelled.
@@ -85,6 +118,36 @@ func (c *Client) Actions() ([]Action, error) {
 	return result, nil
 }
 
+// Records returns the records of the protocol instances matching the filter, all the records if the filter is nil.
+func (c *Client) Records(filter *RecordFilter) ([]Record, error) {
+	records, err := c.service.Records((*presentproof.RecordFilter)(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Record, len(records))
+	for i, record := range records {
+		result[i] = Record(record)
+	}
+
+	return result, nil
+}
+
+// Record returns the record of the protocol instance.
+func (c *Client) Record(piID string) (*Record, error) {
+	record, err := c.service.Record(piID)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*Record)(record), nil
+}
+
+// Abandon moves a stale protocol instance to the abandoned state, its pending action (if any) is dropped.
+func (c *Client) Abandon(piID, reason string) error {
+	return c.service.Abandon(piID, reason)
+}
+
 // SendRequestPresentation is used by the Verifier to send a request presentation.
 // It returns the threadID of the new instance of the protocol.
 func (c *Client) SendRequestPresentation(msg *RequestPresentation, myDID, theirDID string) (string, error) {
@@ -97,6 +160,84 @@ func (c *Client) SendRequestPresentation(msg *RequestPresentation, myDID, theirD
 	return c.service.HandleInbound(service.NewDIDCommMsgMap(msg), service.NewDIDCommContext(myDID, theirDID, nil))
 }
 
+// CreateConnectionlessRequestPresentation is used by the Verifier to request a presentation without a connection.
+// The request gets the ~service decorator with a new recipient key and the service endpoint of the agent,
+// the Prover sends the presentation there. It returns the request to be delivered to the Prover by the caller
+// (e.g. attached to an out-of-band invitation), its ID is the threadID of the new instance of the protocol.
+func (c *Client) CreateConnectionlessRequestPresentation(msg *RequestPresentation) (service.DIDCommMsgMap, error) {
+	if msg == nil {
+		return nil, errEmptyRequestPresentation
+	}
+
+	if c.connectionless == nil {
+		return nil, errConnectionlessNotSupported
+	}
+
+	_, pubKey, err := c.connectionless.KMS().CreateAndExportPubKeyBytes(kms.ED25519Type)
+	if err != nil {
+		return nil, fmt.Errorf("create recipient key: %w", err)
+	}
+
+	didKey, _ := fingerprint.CreateDIDKey(pubKey)
+
+	msg.Type = presentproof.RequestPresentationMsgType
+	msg.Service = &decorator.Service{
+		RecipientKeys:   []string{didKey},
+		ServiceEndpoint: c.connectionless.ServiceEndpoint(),
+	}
+
+	request := service.NewDIDCommMsgMap(msg)
+	if err = request.SetID(uuid.New().String()); err != nil {
+		return nil, err
+	}
+
+	_, err = c.service.HandleInbound(request.Clone(), service.NewDIDCommContext("", "", nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// ReceiveConnectionlessRequestPresentation is used by the Prover to handle the connection-less request
+// (see CreateConnectionlessRequestPresentation) received out of band. The request is processed the same way as
+// the requests received through the connection, the presentation is sent to the service endpoint of the request.
+// It returns the threadID of the instance of the protocol.
+func (c *Client) ReceiveConnectionlessRequestPresentation(msg service.DIDCommMsgMap) (string, error) {
+	if msg == nil {
+		return "", errEmptyRequestPresentation
+	}
+
+	if msg.Type() != presentproof.RequestPresentationMsgType {
+		return "", fmt.Errorf("unexpected message type: %s", msg.Type())
+	}
+
+	var req RequestPresentation
+	if err := msg.Decode(&req); err != nil {
+		return "", fmt.Errorf("decode request: %w", err)
+	}
+
+	if req.Service == nil {
+		return "", errors.New("request has no ~service decorator")
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("threadID: %w", err)
+	}
+
+	request := msg.Clone()
+	// the request did not go through the messenger of the Verifier, the thread starts with it
+	request[jsonThread] = map[string]interface{}{jsonThreadID: thID}
+
+	_, err = c.service.HandleInbound(request, service.NewDIDCommContext("", "", nil))
+	if err != nil {
+		return "", err
+	}
+
+	return thID, nil
+}
+
 type addProof func(presentation *verifiable.Presentation) error
 
 // AcceptRequestPresentation is used by the Prover is to accept a presentation request.
@@ -8,6 +8,7 @@ package presentproof
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -15,8 +16,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 )
 
 const (
@@ -82,6 +86,137 @@ func TestClient_SendRequestPresentation(t *testing.T) {
 	})
 }
 
+type kmsProvider struct {
+	Provider
+	kms kms.KeyManager
+}
+
+func (p *kmsProvider) KMS() kms.KeyManager {
+	return p.kms
+}
+
+func (p *kmsProvider) ServiceEndpoint() string {
+	return "http://verifier.example.com"
+}
+
+func TestClient_CreateConnectionlessRequestPresentation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), service.NewDIDCommContext("", "", nil)).
+			DoAndReturn(func(msg service.DIDCommMsg, _ service.DIDCommContext) (string, error) {
+				require.Equal(t, presentproof.RequestPresentationMsgType, msg.Type())
+				require.NotEmpty(t, msg.ID())
+
+				return msg.ID(), nil
+			})
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+
+		client, err := New(&kmsProvider{
+			Provider: provider,
+			kms:      &mockkms.KeyManager{CrAndExportPubKeyValue: []byte("pub key")},
+		})
+		require.NoError(t, err)
+
+		request, err := client.CreateConnectionlessRequestPresentation(&RequestPresentation{})
+		require.NoError(t, err)
+		require.NotEmpty(t, request.ID())
+
+		var req RequestPresentation
+		require.NoError(t, request.Decode(&req))
+		require.Equal(t, "http://verifier.example.com", req.Service.ServiceEndpoint)
+		require.Len(t, req.Service.RecipientKeys, 1)
+		require.True(t, strings.HasPrefix(req.Service.RecipientKeys[0], "did:key:"))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil).Times(2)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.CreateConnectionlessRequestPresentation(nil)
+		require.EqualError(t, err, errEmptyRequestPresentation.Error())
+
+		_, err = client.CreateConnectionlessRequestPresentation(&RequestPresentation{})
+		require.EqualError(t, err, errConnectionlessNotSupported.Error())
+
+		client, err = New(&kmsProvider{
+			Provider: provider,
+			kms:      &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("test")},
+		})
+		require.NoError(t, err)
+
+		_, err = client.CreateConnectionlessRequestPresentation(&RequestPresentation{})
+		require.EqualError(t, err, "create recipient key: test")
+	})
+}
+
+func TestClient_ReceiveConnectionlessRequestPresentation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	request := service.NewDIDCommMsgMap(&RequestPresentation{
+		Type:    presentproof.RequestPresentationMsgType,
+		Service: &decorator.Service{ServiceEndpoint: "http://verifier.example.com"},
+	})
+	require.NoError(t, request.SetID(uuid.New().String()))
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), service.NewDIDCommContext("", "", nil)).
+			DoAndReturn(func(msg service.DIDCommMsg, _ service.DIDCommContext) (string, error) {
+				thID, err := msg.ThreadID()
+				require.NoError(t, err)
+				require.Equal(t, request.ID(), thID)
+				require.Contains(t, msg.(service.DIDCommMsgMap), jsonThread)
+
+				return "", nil
+			})
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		thID, err := client.ReceiveConnectionlessRequestPresentation(request)
+		require.NoError(t, err)
+		require.Equal(t, request.ID(), thID)
+		require.NotContains(t, request, jsonThread)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.ReceiveConnectionlessRequestPresentation(nil)
+		require.EqualError(t, err, errEmptyRequestPresentation.Error())
+
+		_, err = client.ReceiveConnectionlessRequestPresentation(service.DIDCommMsgMap{"@type": "test"})
+		require.EqualError(t, err, "unexpected message type: test")
+
+		_, err = client.ReceiveConnectionlessRequestPresentation(service.NewDIDCommMsgMap(&RequestPresentation{
+			Type: presentproof.RequestPresentationMsgType,
+		}))
+		require.EqualError(t, err, "request has no ~service decorator")
+
+		_, err = client.ReceiveConnectionlessRequestPresentation(service.NewDIDCommMsgMap(&RequestPresentation{
+			Type:    presentproof.RequestPresentationMsgType,
+			Service: &decorator.Service{},
+		}))
+		require.Contains(t, err.Error(), "threadID")
+	})
+}
+
 func TestClient_SendProposePresentation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -246,3 +381,88 @@ func TestClient_NegotiateProposePresentation(t *testing.T) {
 
 	require.NoError(t, client.NegotiateRequestPresentation("PIID", &ProposePresentation{}))
 }
+
+func TestClient_Records(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Records(&presentproof.RecordFilter{State: "done"}).
+			Return([]presentproof.Record{{PIID: "PIID", State: "done"}}, nil)
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		records, err := client.Records(&RecordFilter{State: "done"})
+		require.NoError(t, err)
+		require.Equal(t, []Record{{PIID: "PIID", State: "done"}}, records)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Records(nil).Return(nil, errors.New("test err"))
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.Records(nil)
+		require.EqualError(t, err, "test err")
+	})
+}
+
+func TestClient_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Record("PIID").Return(&presentproof.Record{PIID: "PIID"}, nil)
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		record, err := client.Record("PIID")
+		require.NoError(t, err)
+		require.Equal(t, "PIID", record.PIID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Record("PIID").Return(nil, errors.New("test err"))
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.Record("PIID")
+		require.EqualError(t, err, "test err")
+	})
+}
+
+func TestClient_Abandon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().Abandon("PIID", "reason").Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Abandon("PIID", "reason"))
+}
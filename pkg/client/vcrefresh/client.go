@@ -0,0 +1,302 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcrefresh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/client/vcrefresh")
+
+const (
+	// DefaultTimeout is the default timeout of a credential refresh.
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultRefreshInterval is the default interval of checks for credentials expiring soon.
+	DefaultRefreshInterval = time.Hour
+
+	// DefaultRefreshWindow is the default time before expiration within which credentials are refreshed.
+	DefaultRefreshWindow = 24 * time.Hour
+)
+
+var (
+	// ErrNoRefreshService is returned when the credential does not define supported refresh service.
+	ErrNoRefreshService = errors.New("credential has no supported refresh service")
+
+	// ErrIssuerMismatch is returned when the renewed credential is issued by another issuer.
+	ErrIssuerMismatch = errors.New("renewed credential issuer does not match")
+)
+
+// provider contains dependencies for the credential refresh client and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdr.Registry
+}
+
+// Client refreshes stored credentials using the refresh services defined in them.
+type Client struct {
+	store          verifiablestore.Store
+	handlers       map[string]Handler
+	credentialOpts []verifiable.CredentialOpt
+	timeout        time.Duration
+	interval       time.Duration
+	window         time.Duration
+	currentTime    func() time.Time
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Opt configures the Client.
+type Opt func(c *Client)
+
+// WithHandler registers the handler of refresh services of the given type.
+func WithHandler(serviceType string, handler Handler) Opt {
+	return func(c *Client) {
+		c.handlers[serviceType] = handler
+	}
+}
+
+// WithHTTPClient sets the HTTP client of the default RefreshService 2021 handler.
+func WithHTTPClient(client HTTPClient) Opt {
+	return func(c *Client) {
+		c.handlers[HTTPRefreshServiceType] = NewHTTPHandler(client)
+	}
+}
+
+// WithJSONLDDocumentLoader sets the JSON-LD document loader used when parsing renewed credentials.
+func WithJSONLDDocumentLoader(loader ld.DocumentLoader) Opt {
+	return func(c *Client) {
+		c.credentialOpts = append(c.credentialOpts, verifiable.WithJSONLDDocumentLoader(loader))
+	}
+}
+
+// WithCredentialOpts sets additional options used when parsing renewed credentials.
+func WithCredentialOpts(opts ...verifiable.CredentialOpt) Opt {
+	return func(c *Client) {
+		c.credentialOpts = append(c.credentialOpts, opts...)
+	}
+}
+
+// WithTimeout sets the timeout of a single credential refresh.
+func WithTimeout(timeout time.Duration) Opt {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRefreshInterval sets the interval of checks for credentials expiring soon when auto refresh is started.
+func WithRefreshInterval(interval time.Duration) Opt {
+	return func(c *Client) {
+		c.interval = interval
+	}
+}
+
+// WithRefreshWindow sets the time before expiration within which credentials are refreshed automatically.
+func WithRefreshWindow(window time.Duration) Opt {
+	return func(c *Client) {
+		c.window = window
+	}
+}
+
+// WithCurrentTime sets the clock used to find credentials expiring soon.
+func WithCurrentTime(currentTime func() time.Time) Opt {
+	return func(c *Client) {
+		c.currentTime = currentTime
+	}
+}
+
+// New returns new credential refresh client.
+func New(ctx provider, opts ...Opt) (*Client, error) {
+	store, err := verifiablestore.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new vc store : %w", err)
+	}
+
+	c := &Client{
+		store: store,
+		handlers: map[string]Handler{
+			HTTPRefreshServiceType: NewHTTPHandler(&http.Client{}),
+		},
+		credentialOpts: []verifiable.CredentialOpt{
+			verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(ctx.VDRegistry()).PublicKeyFetcher()),
+		},
+		timeout:     DefaultTimeout,
+		interval:    DefaultRefreshInterval,
+		window:      DefaultRefreshWindow,
+		currentTime: time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Refresh renews the credential stored under the given name using its refresh service
+// and replaces the stored credential by the renewed one.
+func (c *Client) Refresh(name string) (*verifiable.Credential, error) {
+	id, err := c.store.GetCredentialIDByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("get credential id using name : %w", err)
+	}
+
+	vc, err := c.store.GetCredential(id)
+	if err != nil {
+		return nil, fmt.Errorf("get credential : %w", err)
+	}
+
+	renewed, err := c.renew(vc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.store.ReplaceCredential(name, renewed); err != nil {
+		return nil, fmt.Errorf("replace credential : %w", err)
+	}
+
+	logger.Debugf("credential %s refreshed", name)
+
+	return renewed, nil
+}
+
+// RefreshExpiring refreshes stored credentials which have refresh service and expire within the refresh window.
+// It returns the names of refreshed credentials. Failures of individual credentials are logged.
+func (c *Client) RefreshExpiring() ([]string, error) {
+	records, err := c.store.GetCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("get credential records : %w", err)
+	}
+
+	var refreshed []string
+
+	deadline := c.currentTime().Add(c.window)
+
+	for _, record := range records {
+		vc, err := c.store.GetCredential(record.ID)
+		if err != nil {
+			logger.Warnf("failed to get credential %s: %s", record.Name, err)
+
+			continue
+		}
+
+		if vc.Expired == nil || vc.Expired.Time.After(deadline) || c.refreshService(vc) == nil {
+			continue
+		}
+
+		if _, err = c.Refresh(record.Name); err != nil {
+			logger.Warnf("failed to refresh credential %s: %s", record.Name, err)
+
+			continue
+		}
+
+		refreshed = append(refreshed, record.Name)
+	}
+
+	return refreshed, nil
+}
+
+// Start starts refreshing credentials expiring soon periodically. It does nothing if already started.
+func (c *Client) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stop != nil {
+		return
+	}
+
+	c.stop = make(chan struct{})
+	c.done = make(chan struct{})
+
+	go c.run(c.stop, c.done)
+}
+
+// Stop stops refreshing credentials started by Start and waits for the ongoing refresh to finish.
+func (c *Client) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stop == nil {
+		return
+	}
+
+	close(c.stop)
+	<-c.done
+
+	c.stop = nil
+	c.done = nil
+}
+
+func (c *Client) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := c.RefreshExpiring(); err != nil {
+				logger.Warnf("auto refresh of credentials: %s", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (c *Client) renew(vc *verifiable.Credential) (*verifiable.Credential, error) {
+	refreshService := c.refreshService(vc)
+	if refreshService == nil {
+		return nil, ErrNoRefreshService
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	vcBytes, err := c.handlers[refreshService.Type].Refresh(ctx, vc, refreshService)
+	if err != nil {
+		return nil, fmt.Errorf("refresh service %s: %w", refreshService.Type, err)
+	}
+
+	renewed, err := verifiable.ParseCredential(vcBytes, c.credentialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse renewed credential: %w", err)
+	}
+
+	if renewed.Issuer.ID != vc.Issuer.ID {
+		return nil, fmt.Errorf("%w: %s", ErrIssuerMismatch, renewed.Issuer.ID)
+	}
+
+	return renewed, nil
+}
+
+// refreshService returns the first refresh service of the credential having a handler.
+func (c *Client) refreshService(vc *verifiable.Credential) *verifiable.TypedID {
+	for i := range vc.RefreshService {
+		if _, ok := c.handlers[vc.RefreshService[i].Type]; ok {
+			return &vc.RefreshService[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcrefresh
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const (
+	vcName     = "degree"
+	issuerDID  = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+	serviceURL = "https://issuer.example.com/refresh"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, err := New(newMockProvider())
+		require.NoError(t, err)
+		require.NotNil(t, c)
+		require.Contains(t, c.handlers, HTTPRefreshServiceType)
+	})
+
+	t.Run("error opening store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+			VDRegistryValue:      &mockvdr.MockVDRegistry{},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open error")
+	})
+}
+
+func TestClient_Refresh(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		prov := newMockProvider()
+		store := newStore(t, prov)
+
+		vc := newCredential("http://example.edu/credentials/1", time.Now().Add(time.Hour))
+		require.NoError(t, store.SaveCredential(vcName, vc))
+
+		renewedVC := newCredential("http://example.edu/credentials/2", time.Now().AddDate(1, 0, 0))
+
+		c, err := New(prov, WithCredentialOpts(verifiable.WithDisabledProofCheck()),
+			WithHandler(HTTPRefreshServiceType, HandlerFunc(
+				func(_ context.Context, cred *verifiable.Credential, service *verifiable.TypedID) ([]byte, error) {
+					require.Equal(t, vc.ID, cred.ID)
+					require.Equal(t, serviceURL, service.ID)
+
+					return renewedVC.MarshalJSON()
+				})))
+		require.NoError(t, err)
+
+		renewed, err := c.Refresh(vcName)
+		require.NoError(t, err)
+		require.Equal(t, renewedVC.ID, renewed.ID)
+
+		id, err := store.GetCredentialIDByName(vcName)
+		require.NoError(t, err)
+		require.Equal(t, renewedVC.ID, id)
+	})
+
+	t.Run("credential not found", func(t *testing.T) {
+		c, err := New(newMockProvider())
+		require.NoError(t, err)
+
+		_, err = c.Refresh(vcName)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get credential id using name")
+	})
+
+	t.Run("no refresh service", func(t *testing.T) {
+		prov := newMockProvider()
+		store := newStore(t, prov)
+
+		vc := newCredential("http://example.edu/credentials/1", time.Now().Add(time.Hour))
+		vc.RefreshService = []verifiable.TypedID{{ID: serviceURL, Type: "ManualRefreshService2018"}}
+		require.NoError(t, store.SaveCredential(vcName, vc))
+
+		c, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = c.Refresh(vcName)
+		require.ErrorIs(t, err, ErrNoRefreshService)
+	})
+
+	t.Run("refresh service error", func(t *testing.T) {
+		prov := newMockProvider()
+		store := newStore(t, prov)
+
+		vc := newCredential("http://example.edu/credentials/1", time.Now().Add(time.Hour))
+		require.NoError(t, store.SaveCredential(vcName, vc))
+
+		c, err := New(prov, WithHandler(HTTPRefreshServiceType, HandlerFunc(
+			func(context.Context, *verifiable.Credential, *verifiable.TypedID) ([]byte, error) {
+				return nil, errors.New("service unavailable")
+			})))
+		require.NoError(t, err)
+
+		_, err = c.Refresh(vcName)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service unavailable")
+
+		id, err := store.GetCredentialIDByName(vcName)
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, id)
+	})
+
+	t.Run("invalid renewed credential", func(t *testing.T) {
+		prov := newMockProvider()
+		store := newStore(t, prov)
+
+		vc := newCredential("http://example.edu/credentials/1", time.Now().Add(time.Hour))
+		require.NoError(t, store.SaveCredential(vcName, vc))
+
+		c, err := New(prov, WithHandler(HTTPRefreshServiceType, HandlerFunc(
+			func(context.Context, *verifiable.Credential, *verifiable.TypedID) ([]byte, error) {
+				return []byte("{}"), nil
+			})))
+		require.NoError(t, err)
+
+		_, err = c.Refresh(vcName)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse renewed credential")
+	})
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		prov := newMockProvider()
+		store := newStore(t, prov)
+
+		vc := newCredential("http://example.edu/credentials/1", time.Now().Add(time.Hour))
+		require.NoError(t, store.SaveCredential(vcName, vc))
+
+		renewedVC := newCredential("http://example.edu/credentials/2", time.Now().AddDate(1, 0, 0))
+		renewedVC.Issuer.ID = "did:example:other"
+
+		c, err := New(prov, WithCredentialOpts(verifiable.WithDisabledProofCheck()),
+			WithHandler(HTTPRefreshServiceType, HandlerFunc(
+				func(context.Context, *verifiable.Credential, *verifiable.TypedID) ([]byte, error) {
+					return renewedVC.MarshalJSON()
+				})))
+		require.NoError(t, err)
+
+		_, err = c.Refresh(vcName)
+		require.ErrorIs(t, err, ErrIssuerMismatch)
+	})
+}
+
+func TestClient_RefreshExpiring(t *testing.T) {
+	now := time.Now()
+
+	prov := newMockProvider()
+	store := newStore(t, prov)
+
+	expiring := newCredential("http://example.edu/credentials/expiring", now.Add(time.Hour))
+	require.NoError(t, store.SaveCredential("expiring", expiring))
+
+	valid := newCredential("http://example.edu/credentials/valid", now.AddDate(1, 0, 0))
+	require.NoError(t, store.SaveCredential("valid", valid))
+
+	noExpiration := newCredential("http://example.edu/credentials/no-expiration", now)
+	noExpiration.Expired = nil
+	require.NoError(t, store.SaveCredential("no-expiration", noExpiration))
+
+	failing := newCredential("http://example.edu/credentials/failing", now.Add(time.Hour))
+	failing.RefreshService[0].ID = "https://failing.example.com"
+	require.NoError(t, store.SaveCredential("failing", failing))
+
+	c, err := New(prov, WithCredentialOpts(verifiable.WithDisabledProofCheck()),
+		WithCurrentTime(func() time.Time { return now }), WithRefreshWindow(24*time.Hour),
+		WithHandler(HTTPRefreshServiceType, HandlerFunc(
+			func(_ context.Context, vc *verifiable.Credential, service *verifiable.TypedID) ([]byte, error) {
+				if service.ID != serviceURL {
+					return nil, errors.New("refresh failed")
+				}
+
+				renewed := *vc
+				renewed.ID += "/renewed"
+				renewed.Expired = util.NewTime(now.AddDate(1, 0, 0))
+
+				return renewed.MarshalJSON()
+			})))
+	require.NoError(t, err)
+
+	refreshed, err := c.RefreshExpiring()
+	require.NoError(t, err)
+	require.Equal(t, []string{"expiring"}, refreshed)
+
+	id, err := store.GetCredentialIDByName("expiring")
+	require.NoError(t, err)
+	require.Equal(t, expiring.ID+"/renewed", id)
+
+	refreshed, err = c.RefreshExpiring()
+	require.NoError(t, err)
+	require.Empty(t, refreshed)
+}
+
+func TestClient_StartStop(t *testing.T) {
+	prov := newMockProvider()
+	store := newStore(t, prov)
+
+	vc := newCredential("http://example.edu/credentials/1", time.Now().Add(time.Hour))
+	require.NoError(t, store.SaveCredential(vcName, vc))
+
+	refreshed := make(chan struct{}, 1)
+
+	c, err := New(prov, WithCredentialOpts(verifiable.WithDisabledProofCheck()),
+		WithRefreshInterval(10*time.Millisecond),
+		WithHandler(HTTPRefreshServiceType, HandlerFunc(
+			func(_ context.Context, cred *verifiable.Credential, _ *verifiable.TypedID) ([]byte, error) {
+				renewed := *cred
+				renewed.Expired = util.NewTime(time.Now().AddDate(1, 0, 0))
+
+				select {
+				case refreshed <- struct{}{}:
+				default:
+				}
+
+				return renewed.MarshalJSON()
+			})))
+	require.NoError(t, err)
+
+	c.Start()
+	c.Start()
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		require.Fail(t, "credential was not refreshed")
+	}
+
+	c.Stop()
+	c.Stop()
+}
+
+func newMockProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		VDRegistryValue:      &mockvdr.MockVDRegistry{},
+	}
+}
+
+func newStore(t *testing.T, prov *mockprovider.Provider) *verifiablestore.StoreImplementation {
+	t.Helper()
+
+	store, err := verifiablestore.New(prov)
+	require.NoError(t, err)
+
+	return store
+}
+
+func newCredential(id string, expired time.Time) *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      id,
+		Types:   []string{"VerifiableCredential"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issuer:  verifiable.Issuer{ID: issuerDID},
+		Issued:  util.NewTime(time.Now().Add(-time.Hour)),
+		Expired: util.NewTime(expired),
+		RefreshService: []verifiable.TypedID{{
+			ID:   serviceURL,
+			Type: HTTPRefreshServiceType,
+		}},
+	}
+}
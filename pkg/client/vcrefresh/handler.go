@@ -0,0 +1,318 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcrefresh
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/messaging"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// HTTPRefreshServiceType is the type of refresh service (RefreshService 2021) renewing credentials
+	// over HTTP using the presentation request flow.
+	HTTPRefreshServiceType = "VerifiableCredentialRefreshService2021"
+
+	// DIDCommRefreshServiceType is the type of refresh service renewing credentials over DIDComm.
+	// The ID of the service is the DID of the party refreshing the credential.
+	DIDCommRefreshServiceType = "DIDCommRefreshService2021"
+
+	// RefreshRequestMsgType is the DIDComm message type of the credential refresh request.
+	RefreshRequestMsgType = "https://didcomm.org/vc-refresh/1.0/refresh-request"
+
+	// RefreshResponseMsgType is the DIDComm message type of the credential refresh response.
+	RefreshResponseMsgType = "https://didcomm.org/vc-refresh/1.0/refresh-response"
+
+	urlField = "url"
+
+	maxResponseSize = 1 << 20
+)
+
+// ErrNoCredential is returned when the refresh service response does not contain a credential.
+var ErrNoCredential = errors.New("refresh service response does not contain credential")
+
+// Handler obtains the renewed version of the credential from its refresh service.
+type Handler interface {
+	// Refresh calls the refresh service and returns the renewed credential in JSON or JWT form.
+	Refresh(ctx context.Context, vc *verifiable.Credential, refreshService *verifiable.TypedID) ([]byte, error)
+}
+
+// HandlerFunc is a function adapter of Handler.
+type HandlerFunc func(ctx context.Context, vc *verifiable.Credential, refreshService *verifiable.TypedID) ([]byte, error)
+
+// Refresh calls f(ctx, vc, refreshService).
+func (f HandlerFunc) Refresh(ctx context.Context, vc *verifiable.Credential,
+	refreshService *verifiable.TypedID) ([]byte, error) {
+	return f(ctx, vc, refreshService)
+}
+
+// HTTPClient performs HTTP requests to the refresh service.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// PresentationSigner adds proof to the presentation sent to the refresh service
+// using challenge and domain of the presentation request.
+type PresentationSigner func(vp *verifiable.Presentation, challenge, domain string) (interface{}, error)
+
+// HTTPHandler renews credentials using manifest based refresh service (RefreshService 2021).
+//
+// The handler posts to the URL of the service. If the service replies with a presentation request,
+// a presentation of the credential is posted to the interaction endpoint of the request (or to the service URL).
+// The renewed credential is taken from the presentation (or credential) in the response.
+type HTTPHandler struct {
+	client HTTPClient
+	signer PresentationSigner
+}
+
+// HTTPHandlerOpt configures HTTPHandler.
+type HTTPHandlerOpt func(h *HTTPHandler)
+
+// WithPresentationSigner sets the signer of presentations sent to the refresh service.
+// Presentations are sent unsigned if not defined.
+func WithPresentationSigner(signer PresentationSigner) HTTPHandlerOpt {
+	return func(h *HTTPHandler) {
+		h.signer = signer
+	}
+}
+
+// NewHTTPHandler returns new HTTP refresh service handler.
+func NewHTTPHandler(client HTTPClient, opts ...HTTPHandlerOpt) *HTTPHandler {
+	h := &HTTPHandler{client: client}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+type presentationRequest struct {
+	Challenge string `json:"challenge,omitempty"`
+	Domain    string `json:"domain,omitempty"`
+	Interact  *struct {
+		Service []struct {
+			Type            string `json:"type,omitempty"`
+			ServiceEndpoint string `json:"serviceEndpoint,omitempty"`
+		} `json:"service,omitempty"`
+	} `json:"interact,omitempty"`
+}
+
+// Refresh calls the refresh service and returns the renewed credential.
+func (h *HTTPHandler) Refresh(ctx context.Context, vc *verifiable.Credential,
+	refreshService *verifiable.TypedID) ([]byte, error) {
+	serviceURL := refreshService.ID
+
+	if u, ok := refreshService.CustomFields[urlField].(string); ok && u != "" {
+		serviceURL = u
+	}
+
+	if serviceURL == "" {
+		return nil, errors.New("refresh service URL is not defined")
+	}
+
+	respBytes, err := h.post(ctx, serviceURL, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Request *presentationRequest `json:"verifiablePresentationRequest,omitempty"`
+	}
+
+	if err = json.Unmarshal(respBytes, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal refresh service response: %w", err)
+	}
+
+	if resp.Request == nil {
+		return extractCredential(respBytes)
+	}
+
+	vp, err := h.presentation(vc, resp.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := serviceURL
+
+	if resp.Request.Interact != nil && len(resp.Request.Interact.Service) > 0 &&
+		resp.Request.Interact.Service[0].ServiceEndpoint != "" {
+		endpoint = resp.Request.Interact.Service[0].ServiceEndpoint
+	}
+
+	respBytes, err = h.post(ctx, endpoint, map[string]interface{}{"verifiablePresentation": vp})
+	if err != nil {
+		return nil, err
+	}
+
+	return extractCredential(respBytes)
+}
+
+func (h *HTTPHandler) presentation(vc *verifiable.Credential, req *presentationRequest) (interface{}, error) {
+	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+	if err != nil {
+		return nil, fmt.Errorf("create presentation: %w", err)
+	}
+
+	if h.signer == nil {
+		return vp, nil
+	}
+
+	signed, err := h.signer(vp, req.Challenge, req.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("sign presentation: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (h *HTTPHandler) post(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	reqBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal refresh service request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create refresh service request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("refresh service request: %w", err)
+	}
+
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			logger.Warnf("failed to close refresh service response body: %s", e)
+		}
+	}()
+
+	respBytes, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("read refresh service response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("refresh service responded with status %d: %s", resp.StatusCode, respBytes)
+	}
+
+	return respBytes, nil
+}
+
+// Messenger sends DIDComm messages and waits for the replies.
+type Messenger interface {
+	Send(msg json.RawMessage, opts ...messaging.SendMessageOpions) (json.RawMessage, error)
+}
+
+// DIDCommHandler renews credentials by sending refresh request over DIDComm to the DID of the refresh service
+// and waiting for refresh response.
+type DIDCommHandler struct {
+	messenger Messenger
+}
+
+// NewDIDCommHandler returns new DIDComm refresh service handler.
+func NewDIDCommHandler(messenger Messenger) *DIDCommHandler {
+	return &DIDCommHandler{messenger: messenger}
+}
+
+// Refresh sends refresh request with the credential and returns the renewed credential of the response.
+func (h *DIDCommHandler) Refresh(ctx context.Context, vc *verifiable.Credential,
+	refreshService *verifiable.TypedID) ([]byte, error) {
+	if refreshService.ID == "" {
+		return nil, errors.New("refresh service DID is not defined")
+	}
+
+	msg, err := json.Marshal(map[string]interface{}{
+		"@id":        uuid.New().String(),
+		"@type":      RefreshRequestMsgType,
+		"credential": vc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal refresh request: %w", err)
+	}
+
+	reply, err := h.messenger.Send(msg, messaging.SendByTheirDID(refreshService.ID),
+		messaging.WaitForResponse(ctx, RefreshResponseMsgType))
+	if err != nil {
+		return nil, fmt.Errorf("send refresh request: %w", err)
+	}
+
+	var payload struct {
+		Message struct {
+			Credential json.RawMessage `json:"credential,omitempty"`
+		} `json:"message"`
+	}
+
+	if err = json.Unmarshal(reply, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshal refresh response: %w", err)
+	}
+
+	if len(payload.Message.Credential) == 0 {
+		return nil, ErrNoCredential
+	}
+
+	return unquoteJWT(payload.Message.Credential), nil
+}
+
+// extractCredential takes the first credential from the response which is either
+// {"verifiablePresentation": VP}, VP, {"verifiableCredential": VC} or VC.
+func extractCredential(respBytes []byte) ([]byte, error) {
+	var resp map[string]json.RawMessage
+
+	if err := json.Unmarshal(respBytes, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal refresh service response: %w", err)
+	}
+
+	if vp, ok := resp["verifiablePresentation"]; ok {
+		return extractCredential(vp)
+	}
+
+	if _, ok := resp["credentialSubject"]; ok {
+		return respBytes, nil
+	}
+
+	vcs, ok := resp["verifiableCredential"]
+	if !ok {
+		return nil, ErrNoCredential
+	}
+
+	var list []json.RawMessage
+
+	if err := json.Unmarshal(vcs, &list); err != nil {
+		return unquoteJWT(vcs), nil
+	}
+
+	if len(list) == 0 {
+		return nil, ErrNoCredential
+	}
+
+	return unquoteJWT(list[0]), nil
+}
+
+// unquoteJWT returns the credential in JWT form if it is defined as JSON string.
+func unquoteJWT(vc json.RawMessage) []byte {
+	var jwt string
+
+	if err := json.Unmarshal(vc, &jwt); err == nil {
+		return []byte(jwt)
+	}
+
+	return vc
+}
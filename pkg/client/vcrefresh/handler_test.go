@@ -0,0 +1,209 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcrefresh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/messaging"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+func TestHTTPHandler_Refresh(t *testing.T) {
+	vc := newCredential("http://example.edu/credentials/1", time.Now().Add(time.Hour))
+
+	renewedBytes, err := newCredential("http://example.edu/credentials/2", time.Now().AddDate(1, 0, 0)).MarshalJSON()
+	require.NoError(t, err)
+
+	t.Run("credential returned directly", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+
+			writeJSON(t, w, map[string]interface{}{
+				"verifiablePresentation": map[string]interface{}{
+					"type":                 "VerifiablePresentation",
+					"verifiableCredential": []json.RawMessage{renewedBytes},
+				},
+			})
+		}))
+		defer srv.Close()
+
+		service := &verifiable.TypedID{
+			Type:         HTTPRefreshServiceType,
+			CustomFields: verifiable.CustomFields{"url": srv.URL},
+		}
+
+		result, err := NewHTTPHandler(srv.Client()).Refresh(context.Background(), vc, service)
+		require.NoError(t, err)
+		require.JSONEq(t, string(renewedBytes), string(result))
+	})
+
+	t.Run("presentation request flow", func(t *testing.T) {
+		mux := http.NewServeMux()
+
+		srv := httptest.NewServer(mux)
+		defer srv.Close()
+
+		mux.HandleFunc("/refresh", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(t, w, map[string]interface{}{
+				"verifiablePresentationRequest": map[string]interface{}{
+					"challenge": "c1",
+					"domain":    "issuer.example.com",
+					"interact": map[string]interface{}{
+						"service": []map[string]interface{}{{
+							"type":            "UnmediatedHttpPresentationService2021",
+							"serviceEndpoint": srv.URL + "/exchange",
+						}},
+					},
+				},
+			})
+		})
+
+		mux.HandleFunc("/exchange", func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				VP struct {
+					Proof json.RawMessage `json:"proof"`
+				} `json:"verifiablePresentation"`
+			}
+
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.JSONEq(t, `{"challenge":"c1","domain":"issuer.example.com"}`, string(req.VP.Proof))
+
+			writeJSON(t, w, map[string]interface{}{
+				"verifiablePresentation": map[string]interface{}{
+					"verifiableCredential": json.RawMessage(renewedBytes),
+				},
+			})
+		})
+
+		h := NewHTTPHandler(srv.Client(), WithPresentationSigner(
+			func(vp *verifiable.Presentation, challenge, domain string) (interface{}, error) {
+				require.Len(t, vp.Credentials(), 1)
+
+				vp.Proofs = []verifiable.Proof{{"challenge": challenge, "domain": domain}}
+
+				return vp, nil
+			}))
+
+		result, err := h.Refresh(context.Background(), vc, &verifiable.TypedID{ID: srv.URL + "/refresh"})
+		require.NoError(t, err)
+		require.JSONEq(t, string(renewedBytes), string(result))
+	})
+
+	t.Run("presentation signer error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(t, w, map[string]interface{}{"verifiablePresentationRequest": map[string]interface{}{}})
+		}))
+		defer srv.Close()
+
+		h := NewHTTPHandler(srv.Client(), WithPresentationSigner(
+			func(*verifiable.Presentation, string, string) (interface{}, error) {
+				return nil, errors.New("signer error")
+			}))
+
+		_, err := h.Refresh(context.Background(), vc, &verifiable.TypedID{ID: srv.URL})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signer error")
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer srv.Close()
+
+		_, err := NewHTTPHandler(srv.Client()).Refresh(context.Background(), vc, &verifiable.TypedID{ID: srv.URL})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status 403")
+	})
+
+	t.Run("no credential in response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(t, w, map[string]interface{}{"verifiableCredential": []interface{}{}})
+		}))
+		defer srv.Close()
+
+		_, err := NewHTTPHandler(srv.Client()).Refresh(context.Background(), vc, &verifiable.TypedID{ID: srv.URL})
+		require.ErrorIs(t, err, ErrNoCredential)
+	})
+
+	t.Run("no service URL", func(t *testing.T) {
+		_, err := NewHTTPHandler(http.DefaultClient).Refresh(context.Background(), vc, &verifiable.TypedID{})
+		require.EqualError(t, err, "refresh service URL is not defined")
+	})
+}
+
+func TestDIDCommHandler_Refresh(t *testing.T) {
+	vc := newCredential("http://example.edu/credentials/1", time.Now().Add(time.Hour))
+
+	t.Run("success", func(t *testing.T) {
+		h := NewDIDCommHandler(messengerFunc(
+			func(msg json.RawMessage, opts ...messaging.SendMessageOpions) (json.RawMessage, error) {
+				var request map[string]interface{}
+
+				require.NoError(t, json.Unmarshal(msg, &request))
+				require.Equal(t, RefreshRequestMsgType, request["@type"])
+				require.NotEmpty(t, request["@id"])
+				require.NotEmpty(t, request["credential"])
+				require.Len(t, opts, 2)
+
+				return json.RawMessage(`{"message":{"@type":"` + RefreshResponseMsgType +
+					`","credential":"eyJhbGciOiJub25lIn0.e30."}}`), nil
+			}))
+
+		result, err := h.Refresh(context.Background(), vc, &verifiable.TypedID{ID: issuerDID})
+		require.NoError(t, err)
+		require.Equal(t, "eyJhbGciOiJub25lIn0.e30.", string(result))
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		h := NewDIDCommHandler(messengerFunc(
+			func(json.RawMessage, ...messaging.SendMessageOpions) (json.RawMessage, error) {
+				return nil, errors.New("send error")
+			}))
+
+		_, err := h.Refresh(context.Background(), vc, &verifiable.TypedID{ID: issuerDID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send error")
+	})
+
+	t.Run("no credential in response", func(t *testing.T) {
+		h := NewDIDCommHandler(messengerFunc(
+			func(json.RawMessage, ...messaging.SendMessageOpions) (json.RawMessage, error) {
+				return json.RawMessage(`{"message":{}}`), nil
+			}))
+
+		_, err := h.Refresh(context.Background(), vc, &verifiable.TypedID{ID: issuerDID})
+		require.ErrorIs(t, err, ErrNoCredential)
+	})
+
+	t.Run("no service DID", func(t *testing.T) {
+		_, err := NewDIDCommHandler(nil).Refresh(context.Background(), vc, &verifiable.TypedID{})
+		require.EqualError(t, err, "refresh service DID is not defined")
+	})
+}
+
+type messengerFunc func(msg json.RawMessage, opts ...messaging.SendMessageOpions) (json.RawMessage, error)
+
+func (f messengerFunc) Send(msg json.RawMessage, opts ...messaging.SendMessageOpions) (json.RawMessage, error) {
+	return f(msg, opts...)
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
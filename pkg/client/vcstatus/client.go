@@ -0,0 +1,339 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcstatus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/client/vcstatus")
+
+const (
+	// DefaultCheckInterval is the default interval of the checks of the stored credentials.
+	DefaultCheckInterval = 24 * time.Hour
+
+	// Topic is the topic of the notifications sent when a stored credential becomes invalid.
+	Topic = "credential-status"
+)
+
+// Status of a stored credential.
+type Status string
+
+const (
+	// StatusExpired is the status of the credentials whose expiration date has passed.
+	StatusExpired Status = "expired"
+	// StatusRevoked is the status of the credentials revoked by their issuer.
+	StatusRevoked Status = "revoked"
+)
+
+// Result describes a stored credential found invalid. It is the message of the notifications sent on Topic.
+type Result struct {
+	Name   string `json:"name"`
+	ID     string `json:"id"`
+	Status Status `json:"status"`
+	// Purged is true if the credential was removed from the store by the purge policy.
+	Purged bool `json:"purged,omitempty"`
+}
+
+// Notifier sends the notifications about the stored credentials becoming invalid (e.g. webnotifier to send them
+// to the webhooks).
+type Notifier interface {
+	Notify(topic string, message []byte) error
+}
+
+// PurgePolicy decides whether an invalid credential is removed from the store.
+type PurgePolicy func(name string, vc *verifiable.Credential, status Status) bool
+
+// PurgeRevoked is a PurgePolicy removing the revoked credentials, the expired credentials are kept.
+func PurgeRevoked(_ string, _ *verifiable.Credential, status Status) bool {
+	return status == StatusRevoked
+}
+
+// historyProvider is optionally implemented by the provider to record the revocations in the credential history.
+type historyProvider interface {
+	CredentialHistory() *history.Store
+}
+
+// provider contains dependencies for the credential status client and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdr.Registry
+}
+
+// Client maintains the stored credentials: it flags the expired credentials, checks the revocation of the
+// credentials defining credentialStatus, notifies the credentials becoming invalid and purges them.
+type Client struct {
+	store       verifiablestore.Store
+	checker     verifiable.StatusChecker
+	notifier    Notifier
+	purge       PurgePolicy
+	history     *history.Store
+	interval    time.Duration
+	currentTime func() time.Time
+
+	// invalid keeps the names of the invalid credentials which were notified.
+	invalid   map[string]Status
+	invalidMu sync.Mutex
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Opt configures the Client.
+type Opt func(c *Client)
+
+// WithStatusChecker sets the checker of the credential statuses, NewStatusListChecker is used by default.
+func WithStatusChecker(checker verifiable.StatusChecker) Opt {
+	return func(c *Client) {
+		c.checker = checker
+	}
+}
+
+// WithNotifier sets the notifier of the stored credentials becoming invalid.
+func WithNotifier(notifier Notifier) Opt {
+	return func(c *Client) {
+		c.notifier = notifier
+	}
+}
+
+// WithPurgePolicy sets the policy removing the invalid credentials from the store. Without policy,
+// the invalid credentials are kept.
+func WithPurgePolicy(policy PurgePolicy) Opt {
+	return func(c *Client) {
+		c.purge = policy
+	}
+}
+
+// WithCheckInterval sets the interval of the checks when the periodic checks are started.
+func WithCheckInterval(interval time.Duration) Opt {
+	return func(c *Client) {
+		c.interval = interval
+	}
+}
+
+// WithCurrentTime sets the clock used to find the expired credentials.
+func WithCurrentTime(currentTime func() time.Time) Opt {
+	return func(c *Client) {
+		c.currentTime = currentTime
+	}
+}
+
+// New returns new credential status client.
+func New(ctx provider, opts ...Opt) (*Client, error) {
+	store, err := verifiablestore.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new vc store : %w", err)
+	}
+
+	c := &Client{
+		store: store,
+		checker: NewStatusListChecker(&http.Client{},
+			verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(ctx.VDRegistry()).PublicKeyFetcher())),
+		interval:    DefaultCheckInterval,
+		currentTime: time.Now,
+		invalid:     make(map[string]Status),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if hp, ok := ctx.(historyProvider); ok {
+		c.history = hp.CredentialHistory()
+	}
+
+	return c, nil
+}
+
+// Check checks the stored credentials and returns the invalid ones. The credentials becoming invalid since
+// the previous check are notified and the credentials selected by the purge policy are removed.
+// Failures of individual credentials are logged.
+func (c *Client) Check() ([]*Result, error) {
+	records, err := c.store.GetCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("get credential records : %w", err)
+	}
+
+	var results []*Result
+
+	for _, record := range records {
+		vc, err := c.store.GetCredential(record.ID)
+		if err != nil {
+			logger.Warnf("failed to get credential %s: %s", record.Name, err)
+
+			continue
+		}
+
+		status, ok := c.status(record.Name, vc)
+		if !ok {
+			c.forget(record.Name)
+
+			continue
+		}
+
+		result := &Result{Name: record.Name, ID: record.ID, Status: status}
+
+		if c.purge != nil && c.purge(record.Name, vc, status) {
+			if err = c.store.RemoveCredentialByName(record.Name); err != nil {
+				logger.Warnf("failed to purge credential %s: %s", record.Name, err)
+			} else {
+				result.Purged = true
+			}
+		}
+
+		if c.notify(result) && status == StatusRevoked {
+			c.recordRevocation(record)
+		}
+
+		if result.Purged {
+			c.forget(record.Name)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Start starts checking the stored credentials periodically. It does nothing if already started.
+func (c *Client) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stop != nil {
+		return
+	}
+
+	c.stop = make(chan struct{})
+	c.done = make(chan struct{})
+
+	go c.run(c.stop, c.done)
+}
+
+// Stop stops checking the stored credentials started by Start and waits for the ongoing check to finish.
+func (c *Client) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stop == nil {
+		return
+	}
+
+	close(c.stop)
+	<-c.done
+
+	c.stop = nil
+	c.done = nil
+}
+
+func (c *Client) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := c.Check(); err != nil {
+				logger.Warnf("credential status check: %s", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// status returns the status of the invalid credential, false is returned for the valid credentials
+// and the credentials whose revocation cannot be checked.
+func (c *Client) status(name string, vc *verifiable.Credential) (Status, bool) {
+	if vc.Expired != nil && vc.Expired.Time.Before(c.currentTime()) {
+		return StatusExpired, true
+	}
+
+	if vc.Status == nil || c.checker == nil {
+		return "", false
+	}
+
+	err := c.checker(vc.Status)
+	if errors.Is(err, verifiable.ErrCredentialRevoked) {
+		return StatusRevoked, true
+	}
+
+	if err != nil {
+		logger.Warnf("failed to check status of credential %s: %s", name, err)
+	}
+
+	return "", false
+}
+
+// notify notifies the result if the credential was not notified with the same status yet, and returns
+// true in that case.
+func (c *Client) notify(result *Result) bool {
+	c.invalidMu.Lock()
+	notified := c.invalid[result.Name] == result.Status
+	c.invalid[result.Name] = result.Status
+	c.invalidMu.Unlock()
+
+	if notified {
+		return false
+	}
+
+	if c.notifier == nil {
+		return true
+	}
+
+	msg, err := json.Marshal(result)
+	if err != nil {
+		logger.Warnf("failed to marshal credential status of %s: %s", result.Name, err)
+
+		return true
+	}
+
+	if err = c.notifier.Notify(Topic, msg); err != nil {
+		logger.Warnf("failed to notify credential status of %s: %s", result.Name, err)
+	}
+
+	return true
+}
+
+// recordRevocation records the revocation of the stored credential in the credential history, if enabled.
+func (c *Client) recordRevocation(record *verifiablestore.Record) {
+	if c.history == nil {
+		return
+	}
+
+	err := c.history.Record(&history.Event{
+		Type:          history.EventRevoked,
+		MyDID:         record.MyDID,
+		TheirDID:      record.TheirDID,
+		CredentialIDs: []string{record.ID},
+		Details:       map[string]string{"name": record.Name},
+	})
+	if err != nil {
+		logger.Warnf("failed to record revocation of credential %s in credential history: %s", record.Name, err)
+	}
+}
+
+func (c *Client) forget(name string) {
+	c.invalidMu.Lock()
+	delete(c.invalid, name)
+	c.invalidMu.Unlock()
+}
@@ -0,0 +1,249 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcstatus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const (
+	issuerDID     = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+	statusListURL = "https://issuer.example.com/status/1"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, err := New(newMockProvider())
+		require.NoError(t, err)
+		require.NotNil(t, c)
+		require.NotNil(t, c.checker)
+	})
+
+	t.Run("error opening store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+			VDRegistryValue:      &mockvdr.MockVDRegistry{},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open error")
+	})
+}
+
+func TestClient_Check(t *testing.T) {
+	prov := newMockProvider()
+	store := newStore(t, prov)
+
+	require.NoError(t, store.SaveCredential("valid", newCredential("http://example.edu/credentials/1",
+		time.Now().Add(time.Hour), 1)))
+	require.NoError(t, store.SaveCredential("expired", newCredential("http://example.edu/credentials/2",
+		time.Now().Add(-time.Hour), 2)))
+	require.NoError(t, store.SaveCredential("revoked", newCredential("http://example.edu/credentials/3",
+		time.Now().Add(time.Hour), 3)))
+	require.NoError(t, store.SaveCredential("unchecked", newCredential("http://example.edu/credentials/4",
+		time.Now().Add(time.Hour), 4)))
+
+	checker := func(status *verifiable.TypedID) error {
+		switch status.CustomFields["statusListIndex"] {
+		case "3":
+			return fmt.Errorf("%w: index 3", verifiable.ErrCredentialRevoked)
+		case "4":
+			return errors.New("status list unavailable")
+		default:
+			return nil
+		}
+	}
+
+	t.Run("invalid credentials are notified once", func(t *testing.T) {
+		notifier := &mockNotifier{}
+
+		c, err := New(prov, WithStatusChecker(checker), WithNotifier(notifier))
+		require.NoError(t, err)
+
+		results, err := c.Check()
+		require.NoError(t, err)
+		require.ElementsMatch(t, []*Result{
+			{Name: "expired", ID: "http://example.edu/credentials/2", Status: StatusExpired},
+			{Name: "revoked", ID: "http://example.edu/credentials/3", Status: StatusRevoked},
+		}, results)
+		require.Len(t, notifier.messages, 2)
+
+		results, err = c.Check()
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.Len(t, notifier.messages, 2)
+
+		result := &Result{}
+		require.NoError(t, json.Unmarshal(notifier.messages[0], result))
+		require.NotEmpty(t, result.Name)
+	})
+
+	t.Run("revocations are recorded in the credential history", func(t *testing.T) {
+		historyStore, err := history.New(&mockprovider.Provider{
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		c, err := New(&mockHistoryProvider{Provider: prov, history: historyStore}, WithStatusChecker(checker))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = c.Check()
+			require.NoError(t, err)
+		}
+
+		events, err := historyStore.Timeline(nil)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, history.EventRevoked, events[0].Type)
+		require.Equal(t, []string{"http://example.edu/credentials/3"}, events[0].CredentialIDs)
+		require.Equal(t, map[string]string{"name": "revoked"}, events[0].Details)
+	})
+
+	t.Run("revoked credentials are purged", func(t *testing.T) {
+		notifier := &mockNotifier{}
+
+		c, err := New(prov, WithStatusChecker(checker), WithNotifier(notifier), WithPurgePolicy(PurgeRevoked))
+		require.NoError(t, err)
+
+		results, err := c.Check()
+		require.NoError(t, err)
+		require.ElementsMatch(t, []*Result{
+			{Name: "expired", ID: "http://example.edu/credentials/2", Status: StatusExpired},
+			{Name: "revoked", ID: "http://example.edu/credentials/3", Status: StatusRevoked, Purged: true},
+		}, results)
+		require.Len(t, notifier.messages, 2)
+
+		_, err = store.GetCredentialIDByName("revoked")
+		require.Error(t, err)
+
+		_, err = store.GetCredentialIDByName("expired")
+		require.NoError(t, err)
+	})
+
+	t.Run("current time", func(t *testing.T) {
+		c, err := New(prov, WithStatusChecker(checker), WithCurrentTime(func() time.Time {
+			return time.Now().Add(-2 * time.Hour)
+		}))
+		require.NoError(t, err)
+
+		results, err := c.Check()
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+}
+
+func TestClient_StartStop(t *testing.T) {
+	prov := newMockProvider()
+	store := newStore(t, prov)
+
+	require.NoError(t, store.SaveCredential("expired", newCredential("http://example.edu/credentials/1",
+		time.Now().Add(-time.Hour), 1)))
+
+	notifier := &mockNotifier{notified: make(chan struct{}, 1)}
+
+	c, err := New(prov, WithNotifier(notifier), WithCheckInterval(10*time.Millisecond))
+	require.NoError(t, err)
+
+	c.Start()
+	c.Start()
+
+	select {
+	case <-notifier.notified:
+	case <-time.After(time.Second):
+		require.Fail(t, "expired credential was not notified")
+	}
+
+	c.Stop()
+	c.Stop()
+}
+
+type mockNotifier struct {
+	mu       sync.Mutex
+	messages [][]byte
+	notified chan struct{}
+}
+
+func (n *mockNotifier) Notify(topic string, message []byte) error {
+	if topic != Topic {
+		return fmt.Errorf("unexpected topic %s", topic)
+	}
+
+	n.mu.Lock()
+	n.messages = append(n.messages, message)
+	n.mu.Unlock()
+
+	if n.notified != nil {
+		select {
+		case n.notified <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+type mockHistoryProvider struct {
+	*mockprovider.Provider
+	history *history.Store
+}
+
+func (p *mockHistoryProvider) CredentialHistory() *history.Store {
+	return p.history
+}
+
+func newMockProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		VDRegistryValue:      &mockvdr.MockVDRegistry{},
+	}
+}
+
+func newStore(t *testing.T, prov *mockprovider.Provider) *verifiablestore.StoreImplementation {
+	t.Helper()
+
+	store, err := verifiablestore.New(prov)
+	require.NoError(t, err)
+
+	return store
+}
+
+func newCredential(id string, expired time.Time, statusIndex int) *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      id,
+		Types:   []string{"VerifiableCredential"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issuer:  verifiable.Issuer{ID: issuerDID},
+		Issued:  util.NewTime(time.Now().Add(-2 * time.Hour)),
+		Expired: util.NewTime(expired),
+		Status: &verifiable.TypedID{
+			ID:   fmt.Sprintf("%s#%d", statusListURL, statusIndex),
+			Type: StatusList2021EntryType,
+			CustomFields: verifiable.CustomFields{
+				"statusPurpose":        "revocation",
+				"statusListIndex":      fmt.Sprint(statusIndex),
+				"statusListCredential": statusListURL,
+			},
+		},
+	}
+}
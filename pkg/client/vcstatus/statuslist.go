@@ -0,0 +1,195 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcstatus
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// StatusList2021EntryType is the type of credentialStatus referring to a Status List 2021 credential.
+	StatusList2021EntryType = "StatusList2021Entry"
+
+	// RevocationList2020StatusType is the type of credentialStatus referring to a Revocation List 2020 credential.
+	RevocationList2020StatusType = "RevocationList2020Status"
+
+	statusPurposeRevocation = "revocation"
+
+	maxStatusListSize = 1 << 20
+)
+
+// HTTPClient fetches the status list credentials.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type statusEntry struct {
+	purpose        string
+	index          int
+	listCredential string
+}
+
+// NewStatusListChecker returns a verifiable.StatusChecker of the credentials revoked with the status list
+// (StatusList2021Entry and RevocationList2020Status statuses). The status list credential is fetched with client
+// and parsed with opts, e.g. verifiable.WithPublicKeyFetcher to check its proof.
+// Statuses of other purposes than revocation (e.g. suspension) are not checked.
+func NewStatusListChecker(client HTTPClient, opts ...verifiable.CredentialOpt) verifiable.StatusChecker {
+	return func(status *verifiable.TypedID) error {
+		entry, err := parseStatusEntry(status)
+		if err != nil {
+			return err
+		}
+
+		if entry.purpose != statusPurposeRevocation {
+			return nil
+		}
+
+		bitstring, err := fetchStatusList(client, entry.listCredential, opts)
+		if err != nil {
+			return fmt.Errorf("status list %s: %w", entry.listCredential, err)
+		}
+
+		if entry.index/8 >= len(bitstring) {
+			return fmt.Errorf("status list %s: index %d out of range", entry.listCredential, entry.index)
+		}
+
+		// the index 0 is the left-most bit of the bitstring
+		if bitstring[entry.index/8]&(1<<(7-uint(entry.index%8))) != 0 {
+			return fmt.Errorf("%w: index %d of %s", verifiable.ErrCredentialRevoked, entry.index,
+				entry.listCredential)
+		}
+
+		return nil
+	}
+}
+
+func parseStatusEntry(status *verifiable.TypedID) (*statusEntry, error) {
+	var indexField, credentialField string
+
+	entry := &statusEntry{purpose: statusPurposeRevocation}
+
+	switch status.Type {
+	case StatusList2021EntryType:
+		indexField, credentialField = "statusListIndex", "statusListCredential"
+
+		if purpose, ok := status.CustomFields["statusPurpose"].(string); ok {
+			entry.purpose = purpose
+		}
+	case RevocationList2020StatusType:
+		indexField, credentialField = "revocationListIndex", "revocationListCredential"
+	default:
+		return nil, fmt.Errorf("unsupported credential status type %s", status.Type)
+	}
+
+	index, err := stringOrNumber(status.CustomFields[indexField])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", indexField, err)
+	}
+
+	entry.index, err = strconv.Atoi(index)
+	if err != nil || entry.index < 0 {
+		return nil, fmt.Errorf("invalid %s: %s", indexField, index)
+	}
+
+	entry.listCredential, _ = status.CustomFields[credentialField].(string)
+	if entry.listCredential == "" {
+		return nil, fmt.Errorf("missing %s", credentialField)
+	}
+
+	return entry, nil
+}
+
+func stringOrNumber(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case string:
+		return value, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unexpected value %v", v)
+	}
+}
+
+func fetchStatusList(client HTTPClient, url string, opts []verifiable.CredentialOpt) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil) //nolint:noctx
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	defer func() {
+		if errClose := resp.Body.Close(); errClose != nil {
+			logger.Warnf("failed to close response body: %s", errClose)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get: unexpected status %d", resp.StatusCode)
+	}
+
+	vcBytes, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxStatusListSize))
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	vc, err := verifiable.ParseCredential(vcBytes, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+
+	subjects, ok := vc.Subject.([]verifiable.Subject)
+	if !ok || len(subjects) == 0 {
+		return nil, errors.New("missing credential subject")
+	}
+
+	encodedList, ok := subjects[0].CustomFields["encodedList"].(string)
+	if !ok {
+		return nil, errors.New("missing encodedList")
+	}
+
+	return decodeBitstring(encodedList)
+}
+
+// decodeBitstring decodes the base64 encoded GZIP-compressed bitstring of the status list.
+func decodeBitstring(encodedList string) ([]byte, error) {
+	encodedList = strings.TrimRight(encodedList, "=")
+
+	compressed, err := base64.RawStdEncoding.DecodeString(encodedList)
+	if err != nil {
+		compressed, err = base64.RawURLEncoding.DecodeString(encodedList)
+		if err != nil {
+			return nil, fmt.Errorf("decode encodedList: %w", err)
+		}
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress encodedList: %w", err)
+	}
+
+	bitstring, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("decompress encodedList: %w", err)
+	}
+
+	return bitstring, nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcstatus
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+func TestNewStatusListChecker(t *testing.T) {
+	// bits 3 and 10 are set
+	statusList := newStatusListCredential(t, []byte{0x10, 0x20})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status/1":
+			_, _ = w.Write(statusList)
+		case "/invalid":
+			_, _ = w.Write([]byte("not a credential"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := NewStatusListChecker(server.Client(), verifiable.WithDisabledProofCheck(),
+		verifiable.WithBaseContextValidation())
+
+	newStatus := func(index interface{}, url string) *verifiable.TypedID {
+		return &verifiable.TypedID{
+			Type: StatusList2021EntryType,
+			CustomFields: verifiable.CustomFields{
+				"statusPurpose":        "revocation",
+				"statusListIndex":      index,
+				"statusListCredential": url,
+			},
+		}
+	}
+
+	t.Run("revoked", func(t *testing.T) {
+		err := checker(newStatus("3", server.URL+"/status/1"))
+		require.True(t, errors.Is(err, verifiable.ErrCredentialRevoked))
+
+		err = checker(newStatus(float64(10), server.URL+"/status/1"))
+		require.True(t, errors.Is(err, verifiable.ErrCredentialRevoked))
+
+		err = checker(&verifiable.TypedID{
+			Type: RevocationList2020StatusType,
+			CustomFields: verifiable.CustomFields{
+				"revocationListIndex":      "3",
+				"revocationListCredential": server.URL + "/status/1",
+			},
+		})
+		require.True(t, errors.Is(err, verifiable.ErrCredentialRevoked))
+	})
+
+	t.Run("not revoked", func(t *testing.T) {
+		require.NoError(t, checker(newStatus("2", server.URL+"/status/1")))
+		require.NoError(t, checker(newStatus("15", server.URL+"/status/1")))
+
+		suspension := newStatus("3", server.URL+"/status/1")
+		suspension.CustomFields["statusPurpose"] = "suspension"
+		require.NoError(t, checker(suspension))
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		err := checker(&verifiable.TypedID{Type: "CredentialStatusList2017"})
+		require.EqualError(t, err, "unsupported credential status type CredentialStatusList2017")
+
+		err = checker(newStatus("-1", server.URL+"/status/1"))
+		require.EqualError(t, err, "invalid statusListIndex: -1")
+
+		err = checker(newStatus(nil, server.URL+"/status/1"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid statusListIndex")
+
+		err = checker(newStatus("1", ""))
+		require.EqualError(t, err, "missing statusListCredential")
+
+		err = checker(newStatus("16", server.URL+"/status/1"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "index 16 out of range")
+	})
+
+	t.Run("invalid status list", func(t *testing.T) {
+		err := checker(newStatus("1", server.URL+"/unknown"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected status 404")
+
+		err = checker(newStatus("1", server.URL+"/invalid"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse credential")
+	})
+}
+
+func TestDecodeBitstring(t *testing.T) {
+	_, err := decodeBitstring("!!!")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode encodedList")
+
+	_, err = decodeBitstring(base64.RawStdEncoding.EncodeToString([]byte("not gzip")))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decompress encodedList")
+}
+
+func newStatusListCredential(t *testing.T, bitstring []byte) []byte {
+	t.Helper()
+
+	var compressed bytes.Buffer
+
+	w := gzip.NewWriter(&compressed)
+	_, err := w.Write(bitstring)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	vc := &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      "https://issuer.example.com/status/1",
+		Types:   []string{"VerifiableCredential"},
+		Subject: []verifiable.Subject{{
+			ID: "https://issuer.example.com/status/1#list",
+			CustomFields: verifiable.CustomFields{
+				"type":          "StatusList2021",
+				"statusPurpose": "revocation",
+				"encodedList":   base64.StdEncoding.EncodeToString(compressed.Bytes()),
+			},
+		}},
+		Issuer: verifiable.Issuer{ID: issuerDID},
+		Issued: util.NewTime(time.Now()),
+	}
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	return vcBytes
+}
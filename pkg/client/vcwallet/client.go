@@ -169,14 +169,17 @@ func (c *Client) Import(auth string, contents json.RawMessage) error {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
+// Issuer branding of a credential can be stored along with the credential by using 'wallet.WithDisplayMetadata()'.
+//
 // TODO: (#2433) support for correlation between wallet contents (ex: credentials to a profile/collection).
-func (c *Client) Add(contentType wallet.ContentType, content json.RawMessage) error {
+func (c *Client) Add(contentType wallet.ContentType, content json.RawMessage,
+	options ...wallet.AddContentOptions) error {
 	auth, err := c.auth()
 	if err != nil {
 		return err
 	}
 
-	return c.wallet.Add(auth, contentType, content)
+	return c.wallet.Add(auth, contentType, content, options...)
 }
 
 // Remove removes wallet content by content ID.
@@ -218,6 +221,17 @@ func (c *Client) GetAll(contentType wallet.ContentType) (map[string]json.RawMess
 	return c.wallet.GetAll(contentType)
 }
 
+// ResolveDisplay resolves display data of the stored credential, ready to be rendered by wallet UIs.
+//
+//	Args:
+//		- ID of the stored credential.
+//		- resolve display options (ex: preferred locale).
+//
+func (c *Client) ResolveDisplay(credentialID string,
+	options ...wallet.ResolveDisplayOptions) (*wallet.ResolvedDisplay, error) {
+	return c.wallet.ResolveDisplay(credentialID, options...)
+}
+
 // Query runs query against wallet credential contents and returns presentation containing credential results.
 //
 // https://w3c-ccg.github.io/universal-wallet-interop-spec/#query
@@ -263,6 +277,25 @@ func (c *Client) Prove(opts *wallet.ProofOptions, creds ...wallet.ProveOptions)
 	return c.wallet.Prove(auth, opts, creds...)
 }
 
+// ConsentReceipts returns the consent receipts created when presenting credentials, oldest first.
+func (c *Client) ConsentReceipts() ([]json.RawMessage, error) {
+	return c.wallet.ConsentReceipts()
+}
+
+// ExportConsentReceipts exports the consent receipts in a presentation signed by the holder.
+//
+//	Args:
+//		- proof options of the presentation.
+//
+func (c *Client) ExportConsentReceipts(opts *wallet.ProofOptions) (*verifiable.Presentation, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.ExportConsentReceipts(auth, opts)
+}
+
 // Verify takes Takes a Verifiable Credential or Verifiable Presentation as input,.
 //
 //	Args:
@@ -829,6 +829,65 @@ func TestClient_GetAll(t *testing.T) {
 	require.Len(t, vcs, count)
 }
 
+func TestClient_ResolveDisplay(t *testing.T) {
+	const vcContent = `{
+      "@context": ["https://www.w3.org/2018/credentials/v1"],
+      "id": "http://example.edu/credentials/1872",
+      "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21", "name": "Jayden Doe"},
+      "issuanceDate": "2010-01-01T19:23:24Z",
+      "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+      "type": ["VerifiableCredential", "UniversityDegreeCredential"]
+    }`
+
+	mockctx := newMockProvider()
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+	require.NotEmpty(t, vcWalletClient)
+	require.NoError(t, err)
+
+	require.NoError(t, vcWalletClient.Add(wallet.Credential, []byte(vcContent),
+		wallet.WithDisplayMetadata(&wallet.DisplayMetadata{
+			Credential: []wallet.LocalizedDisplay{{Name: "University Degree", Locale: "en-US"}},
+			Claims:     map[string][]wallet.LocalizedDisplay{"name": {{Name: "Full Name", Locale: "en-US"}}},
+		})))
+
+	display, err := vcWalletClient.ResolveDisplay("http://example.edu/credentials/1872",
+		wallet.WithDisplayLocale("en-US"))
+	require.NoError(t, err)
+	require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", display.Issuer.Name)
+	require.Equal(t, "University Degree", display.Credential.Name)
+	require.Equal(t, []wallet.ResolvedClaim{{Name: "name", Label: "Full Name", Value: "Jayden Doe", Locale: "en-US"}},
+		display.Claims)
+
+	_, err = vcWalletClient.ResolveDisplay("http://example.edu/credentials/1873")
+	require.Error(t, err)
+}
+
+func TestClient_ConsentReceipts(t *testing.T) {
+	mockctx := newMockProvider()
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+	require.NotEmpty(t, vcWalletClient)
+	require.NoError(t, err)
+
+	receipts, err := vcWalletClient.ConsentReceipts()
+	require.NoError(t, err)
+	require.Empty(t, receipts)
+
+	_, err = vcWalletClient.ExportConsentReceipts(&wallet.ProofOptions{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to prepare proof")
+
+	require.True(t, vcWalletClient.Close())
+
+	_, err = vcWalletClient.ExportConsentReceipts(&wallet.ProofOptions{})
+	require.True(t, errors.Is(err, ErrWalletLocked))
+}
+
 func TestClient_Remove(t *testing.T) {
 	mockctx := newMockProvider()
 	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
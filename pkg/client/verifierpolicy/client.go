@@ -0,0 +1,498 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package verifierpolicy lets the verifiers define named proof request templates (a presentation definition
+// and the rules accepting the presented credentials) and run the whole present proof flow of a policy:
+// the request is sent over the connection, the presentation received is evaluated and accepted or declined.
+package verifierpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/client/vcstatus"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// StoreName is the name of the store of the policies.
+	StoreName = "verifierpolicy"
+
+	// DefaultTimeout is the default time waiting for the presentation once the request is sent.
+	DefaultTimeout = 2 * time.Minute
+
+	// DefaultPollInterval is the default interval of the checks of the pending presentations.
+	DefaultPollInterval = 500 * time.Millisecond
+
+	policyKeyPattern = "policy_%s"
+	policyTagName    = "policy"
+
+	mimeTypeApplicationJSON = "application/json"
+	peDefinitionFormat      = "dif/presentation-exchange/definitions@v1.0"
+)
+
+var logger = log.New("aries-framework/client/verifierpolicy")
+
+var (
+	errEmptyPolicyName             = errors.New("policy name is mandatory")
+	errEmptyPresentationDefinition = errors.New("presentation definition is mandatory")
+	errNoPresentation              = errors.New("presentations were not provided")
+)
+
+// Policy is a named proof request template: the presentation definition requested to the holders
+// and the rules accepting the credentials they present.
+type Policy struct {
+	// Name of the policy, unique on the agent.
+	Name string `json:"name"`
+	// PresentationDefinition is the definition of the credentials requested to the holders.
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentationDefinition"`
+	// TrustedIssuers are the IDs of the issuers whose credentials are accepted, all issuers if empty.
+	TrustedIssuers []string `json:"trustedIssuers,omitempty"`
+	// MaxCredentialAge is the maximum time elapsed since the issuance of the credentials, no maximum if zero.
+	MaxCredentialAge time.Duration `json:"maxCredentialAge,omitempty"`
+	// RequireStatusCheck requires the credentials to define a status which is checked (e.g. not revoked).
+	RequireStatusCheck bool `json:"requireStatusCheck,omitempty"`
+}
+
+// Decision is the outcome of the evaluation of the presentation against the policy.
+type Decision struct {
+	// PIID is the ID of the present proof protocol instance.
+	PIID string `json:"piid"`
+	// Policy is the name of the policy.
+	Policy string `json:"policy"`
+	// Accepted is true if the presentation satisfies the policy, the presentation is then accepted,
+	// otherwise it is declined.
+	Accepted bool `json:"accepted"`
+	// Reasons are the reasons the presentation was declined.
+	Reasons []string `json:"reasons,omitempty"`
+	// CredentialIDs are the IDs of the credentials of the accepted presentation.
+	CredentialIDs []string `json:"credentialIDs,omitempty"`
+}
+
+// Provider contains dependencies for the verifier policy client and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+}
+
+// Opt configures the Client.
+type Opt func(c *Client)
+
+// WithStatusChecker sets the checker of the credential statuses, vcstatus.NewStatusListChecker is used by default.
+func WithStatusChecker(checker verifiable.StatusChecker) Opt {
+	return func(c *Client) {
+		c.checker = checker
+	}
+}
+
+// WithTimeout sets the time waiting for the presentation once the request is sent.
+func WithTimeout(timeout time.Duration) Opt {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithPollInterval sets the interval of the checks of the pending presentations.
+func WithPollInterval(interval time.Duration) Opt {
+	return func(c *Client) {
+		c.pollInterval = interval
+	}
+}
+
+// WithCurrentTime sets the clock used to compute the age of the credentials.
+func WithCurrentTime(currentTime func() time.Time) Opt {
+	return func(c *Client) {
+		c.currentTime = currentTime
+	}
+}
+
+// Client manages the verifier policies and requests the proofs defined by them.
+type Client struct {
+	store        storage.Store
+	presentproof *presentproof.Client
+	connections  *connection.Lookup
+	keyFetcher   verifiable.PublicKeyFetcher
+	checker      verifiable.StatusChecker
+	timeout      time.Duration
+	pollInterval time.Duration
+	currentTime  func() time.Time
+}
+
+// New returns new verifier policy client.
+func New(ctx Provider, opts ...Opt) (*Client, error) {
+	store, err := ctx.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open verifier policy store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(StoreName, storage.StoreConfiguration{TagNames: []string{policyTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	presentProof, err := presentproof.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create presentproof client: %w", err)
+	}
+
+	connections, err := connection.NewLookup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection lookup: %w", err)
+	}
+
+	keyFetcher := verifiable.NewVDRKeyResolver(ctx.VDRegistry()).PublicKeyFetcher()
+
+	c := &Client{
+		store:        store,
+		presentproof: presentProof,
+		connections:  connections,
+		keyFetcher:   keyFetcher,
+		checker:      vcstatus.NewStatusListChecker(&http.Client{}, verifiable.WithPublicKeyFetcher(keyFetcher)),
+		timeout:      DefaultTimeout,
+		pollInterval: DefaultPollInterval,
+		currentTime:  time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// SavePolicy saves the policy, replacing the policy having the same name.
+func (c *Client) SavePolicy(policy *Policy) error {
+	if policy == nil || policy.Name == "" {
+		return errEmptyPolicyName
+	}
+
+	if policy.PresentationDefinition == nil {
+		return errEmptyPresentationDefinition
+	}
+
+	if err := policy.PresentationDefinition.ValidateSchema(); err != nil {
+		return fmt.Errorf("validate presentation definition: %w", err)
+	}
+
+	policyBytes, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("marshal policy: %w", err)
+	}
+
+	err = c.store.Put(fmt.Sprintf(policyKeyPattern, policy.Name), policyBytes, storage.Tag{Name: policyTagName})
+	if err != nil {
+		return fmt.Errorf("save policy: %w", err)
+	}
+
+	return nil
+}
+
+// Policy returns the policy with the given name.
+func (c *Client) Policy(name string) (*Policy, error) {
+	policyBytes, err := c.store.Get(fmt.Sprintf(policyKeyPattern, name))
+	if err != nil {
+		return nil, fmt.Errorf("get policy %s: %w", name, err)
+	}
+
+	var policy Policy
+
+	if err = json.Unmarshal(policyBytes, &policy); err != nil {
+		return nil, fmt.Errorf("unmarshal policy %s: %w", name, err)
+	}
+
+	return &policy, nil
+}
+
+// Policies returns the policies sorted by name.
+func (c *Client) Policies() ([]*Policy, error) {
+	iter, err := c.store.Query(policyTagName)
+	if err != nil {
+		return nil, fmt.Errorf("query policies: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var policies []*Policy
+
+	for {
+		more, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("iterate policies: %w", err)
+		}
+
+		if !more {
+			break
+		}
+
+		policyBytes, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("get policy: %w", err)
+		}
+
+		var policy Policy
+
+		if err = json.Unmarshal(policyBytes, &policy); err != nil {
+			return nil, fmt.Errorf("unmarshal policy: %w", err)
+		}
+
+		policies = append(policies, &policy)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	return policies, nil
+}
+
+// RemovePolicy removes the policy with the given name.
+func (c *Client) RemovePolicy(name string) error {
+	if err := c.store.Delete(fmt.Sprintf(policyKeyPattern, name)); err != nil {
+		return fmt.Errorf("remove policy %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// RequestProof requests the presentation defined by the policy over the connection, waits for the presentation
+// and evaluates it against the acceptance rules of the policy. The presentation is accepted or declined
+// according to the decision which is returned. An error is returned if the presentation is not received
+// before the timeout, the protocol instance is then left pending.
+func (c *Client) RequestProof(connectionID, policyName string) (*Decision, error) {
+	policy, err := c.Policy(policyName)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := c.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection %s: %w", connectionID, err)
+	}
+
+	request, err := requestPresentation(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	piid, err := c.presentproof.SendRequestPresentation(request, record.MyDID, record.TheirDID)
+	if err != nil {
+		return nil, fmt.Errorf("send request presentation: %w", err)
+	}
+
+	action, err := c.waitForPresentation(piid)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := c.evaluate(policy, action)
+	decision.PIID = piid
+
+	if decision.Accepted {
+		err = c.presentproof.AcceptPresentation(piid)
+	} else {
+		err = c.presentproof.DeclinePresentation(piid, strings.Join(decision.Reasons, "; "))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("complete presentation %s: %w", piid, err)
+	}
+
+	logger.Debugf("presentation %s evaluated with policy %s: accepted=%t", piid, policy.Name, decision.Accepted)
+
+	return decision, nil
+}
+
+// requestPresentation creates the request presentation message attaching the presentation definition.
+func requestPresentation(policy *Policy) (*presentproof.RequestPresentation, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"presentation_definition": policy.PresentationDefinition,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal presentation definition: %w", err)
+	}
+
+	attachID := uuid.New().String()
+
+	return &presentproof.RequestPresentation{
+		Formats: []protocol.Format{{AttachID: attachID, Format: peDefinitionFormat}},
+		RequestPresentationsAttach: []decorator.Attachment{{
+			ID:       attachID,
+			MimeType: mimeTypeApplicationJSON,
+			Data:     decorator.AttachmentData{JSON: json.RawMessage(payload)},
+		}},
+	}, nil
+}
+
+// waitForPresentation waits for the pending action of the protocol instance, i.e. the presentation received.
+func (c *Client) waitForPresentation(piid string) (*presentproof.Action, error) {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(c.timeout)
+	defer timeout.Stop()
+
+	for {
+		actions, err := c.presentproof.Actions()
+		if err != nil {
+			return nil, fmt.Errorf("get actions: %w", err)
+		}
+
+		for i := range actions {
+			if actions[i].PIID == piid {
+				return &actions[i], nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timeout.C:
+			return nil, fmt.Errorf("no presentation received for %s within %s", piid, c.timeout)
+		}
+	}
+}
+
+// evaluate evaluates the presentations received against the policy, the decision is accepted once
+// a presentation satisfies the policy.
+func (c *Client) evaluate(policy *Policy, action *presentproof.Action) *Decision {
+	decision := &Decision{Policy: policy.Name}
+
+	presentation := protocol.Presentation{}
+
+	if err := action.Msg.Decode(&presentation); err != nil {
+		decision.Reasons = []string{fmt.Sprintf("decode presentation: %s", err)}
+
+		return decision
+	}
+
+	if len(presentation.PresentationsAttach) == 0 {
+		decision.Reasons = []string{errNoPresentation.Error()}
+
+		return decision
+	}
+
+	for i := range presentation.PresentationsAttach {
+		credentials, reasons := c.evaluatePresentation(policy, &presentation.PresentationsAttach[i])
+		if len(reasons) == 0 {
+			decision.Accepted = true
+			decision.Reasons = nil
+			decision.CredentialIDs = credentials
+
+			return decision
+		}
+
+		decision.Reasons = append(decision.Reasons, reasons...)
+	}
+
+	return decision
+}
+
+// evaluatePresentation matches the presentation with the presentation definition and checks the matched
+// credentials with the acceptance rules of the policy. It returns the IDs of the credentials and the reasons
+// the presentation does not satisfy the policy (none if it does).
+func (c *Client) evaluatePresentation(policy *Policy, attachment *decorator.Attachment) ([]string, []string) {
+	raw, err := attachment.Data.Fetch()
+	if err != nil {
+		return nil, []string{fmt.Sprintf("fetch presentation: %s", err)}
+	}
+
+	loader := presexch.CachingJSONLDLoader()
+
+	vp, err := verifiable.ParsePresentation(raw,
+		verifiable.WithPresPublicKeyFetcher(c.keyFetcher),
+		verifiable.WithPresJSONLDDocumentLoader(loader),
+	)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("parse presentation: %s", err)}
+	}
+
+	matched, err := policy.PresentationDefinition.Match(vp, presexch.WithCredentialOptions(
+		verifiable.WithPublicKeyFetcher(c.keyFetcher),
+		verifiable.WithJSONLDDocumentLoader(loader),
+	))
+	if err != nil {
+		return nil, []string{fmt.Sprintf("match presentation definition: %s", err)}
+	}
+
+	descriptors := make([]string, 0, len(matched))
+	for id := range matched {
+		descriptors = append(descriptors, id)
+	}
+
+	sort.Strings(descriptors)
+
+	var (
+		credentials []string
+		reasons     []string
+	)
+
+	for _, id := range descriptors {
+		vc := matched[id]
+
+		if vc.ID != "" {
+			credentials = append(credentials, vc.ID)
+		}
+
+		reasons = append(reasons, c.checkRules(policy, id, vc)...)
+	}
+
+	return credentials, reasons
+}
+
+// checkRules checks the credential matched with the input descriptor against the acceptance rules of the policy.
+func (c *Client) checkRules(policy *Policy, descriptorID string, vc *verifiable.Credential) []string {
+	var reasons []string
+
+	if len(policy.TrustedIssuers) > 0 && !contains(policy.TrustedIssuers, vc.Issuer.ID) {
+		reasons = append(reasons, fmt.Sprintf("%s: issuer %s is not trusted", descriptorID, vc.Issuer.ID))
+	}
+
+	if policy.MaxCredentialAge > 0 {
+		switch {
+		case vc.Issued == nil:
+			reasons = append(reasons, fmt.Sprintf("%s: issuance date is not defined", descriptorID))
+		case c.currentTime().Sub(vc.Issued.Time) > policy.MaxCredentialAge:
+			reasons = append(reasons, fmt.Sprintf("%s: credential is older than %s", descriptorID,
+				policy.MaxCredentialAge))
+		}
+	}
+
+	if policy.RequireStatusCheck {
+		if vc.Status == nil {
+			reasons = append(reasons, fmt.Sprintf("%s: credential status is not defined", descriptorID))
+		} else if err := c.checker(vc.Status); err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s: status check: %s", descriptorID, err))
+		}
+	}
+
+	return reasons
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
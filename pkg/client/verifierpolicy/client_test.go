@@ -0,0 +1,389 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifierpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	connID      = "conn-1"
+	myDID       = "did:example:verifier"
+	theirDID    = "did:example:holder"
+	issuerDID   = "did:example:issuer"
+	piid        = "piid-1"
+	policyName  = "degree"
+	vcID        = "http://example.edu/credentials/1872"
+	descriptor  = "degree_input"
+	statusEntry = "https://example.edu/status/1#94567"
+)
+
+func newTestProvider(t *testing.T, svc interface{}) *mockprovider.Provider {
+	t.Helper()
+
+	prov := &mockprovider.Provider{
+		ServiceValue:                      svc,
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue:                   &mockvdr.MockVDRegistry{},
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        connection.StateNameCompleted,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+	}))
+
+	return prov
+}
+
+func newPolicy() *Policy {
+	return &Policy{
+		Name: policyName,
+		PresentationDefinition: &presexch.PresentationDefinition{
+			ID: "degree_definition",
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID:     descriptor,
+				Schema: []*presexch.Schema{{URI: verifiable.ContextURI}},
+			}},
+		},
+	}
+}
+
+func newCredential(issued time.Time) *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType},
+		ID:      vcID,
+		Issuer:  verifiable.Issuer{ID: issuerDID},
+		Issued:  util.NewTime(issued),
+		Status:  &verifiable.TypedID{ID: statusEntry, Type: "StatusList2021Entry"},
+		Subject: map[string]interface{}{"id": theirDID},
+	}
+}
+
+func newPresentationMsg(t *testing.T, vc *verifiable.Credential) service.DIDCommMsgMap {
+	t.Helper()
+
+	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+	require.NoError(t, err)
+
+	vp.Context = append(vp.Context, presexch.PresentationSubmissionJSONLDContextIRI)
+	vp.Type = append(vp.Type, presexch.PresentationSubmissionJSONLDType)
+	vp.CustomFields = map[string]interface{}{
+		"presentation_submission": map[string]interface{}{
+			"id":            "submission",
+			"definition_id": "degree_definition",
+			"descriptor_map": []interface{}{
+				map[string]interface{}{"id": descriptor, "path": "$.verifiableCredential[0]"},
+			},
+		},
+	}
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	return service.NewDIDCommMsgMap(protocol.Presentation{
+		Type: protocol.PresentationMsgType,
+		PresentationsAttach: []decorator.Attachment{{
+			MimeType: "application/ld+json",
+			Data:     decorator.AttachmentData{JSON: json.RawMessage(vpBytes)},
+		}},
+	})
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		client, err := New(newTestProvider(t, mocks.NewMockProtocolService(ctrl)))
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		})
+		require.EqualError(t, err, "failed to open verifier policy store: open error")
+	})
+
+	t.Run("presentproof service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			ServiceErr:           errors.New("service error"),
+		})
+		require.EqualError(t, err, "create presentproof client: service error")
+	})
+
+	t.Run("connection lookup error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			ServiceValue:                      mocks.NewMockProtocolService(ctrl),
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: &mockstore.MockStoreProvider{FailNamespace: connection.Namespace},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create connection lookup")
+	})
+}
+
+func TestClient_Policies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client, err := New(newTestProvider(t, mocks.NewMockProtocolService(ctrl)))
+	require.NoError(t, err)
+
+	t.Run("save, get, list and remove policies", func(t *testing.T) {
+		policy := newPolicy()
+		policy.TrustedIssuers = []string{issuerDID}
+		policy.MaxCredentialAge = 24 * time.Hour
+		require.NoError(t, client.SavePolicy(policy))
+
+		other := newPolicy()
+		other.Name = "age"
+		require.NoError(t, client.SavePolicy(other))
+
+		saved, err := client.Policy(policyName)
+		require.NoError(t, err)
+		require.Equal(t, policy, saved)
+
+		policies, err := client.Policies()
+		require.NoError(t, err)
+		require.Len(t, policies, 2)
+		require.Equal(t, "age", policies[0].Name)
+		require.Equal(t, policyName, policies[1].Name)
+
+		require.NoError(t, client.RemovePolicy("age"))
+
+		_, err = client.Policy("age")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get policy age")
+	})
+
+	t.Run("invalid policies", func(t *testing.T) {
+		require.EqualError(t, client.SavePolicy(nil), errEmptyPolicyName.Error())
+		require.EqualError(t, client.SavePolicy(&Policy{Name: policyName}), errEmptyPresentationDefinition.Error())
+
+		err := client.SavePolicy(&Policy{Name: policyName, PresentationDefinition: &presexch.PresentationDefinition{}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validate presentation definition")
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		prov := newTestProvider(t, mocks.NewMockProtocolService(ctrl))
+		prov.StorageProviderValue = storeProvider
+
+		errClient, err := New(prov)
+		require.NoError(t, err)
+
+		storeProvider.Store.ErrPut = errors.New("put error")
+		require.EqualError(t, errClient.SavePolicy(newPolicy()), "save policy: put error")
+
+		storeProvider.Store.ErrQuery = errors.New("query error")
+		_, err = errClient.Policies()
+		require.EqualError(t, err, "query policies: query error")
+	})
+}
+
+func TestClient_RequestProof(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+
+	newClient := func(t *testing.T, svc *mocks.MockProtocolService, policy *Policy, opts ...Opt) *Client {
+		t.Helper()
+
+		opts = append([]Opt{
+			WithPollInterval(time.Millisecond),
+			WithCurrentTime(func() time.Time { return now }),
+			WithStatusChecker(func(status *verifiable.TypedID) error {
+				require.Equal(t, statusEntry, status.ID)
+
+				return nil
+			}),
+		}, opts...)
+
+		client, err := New(newTestProvider(t, svc), opts...)
+		require.NoError(t, err)
+
+		if policy != nil {
+			require.NoError(t, client.SavePolicy(policy))
+		}
+
+		return client
+	}
+
+	t.Run("presentation accepted", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), service.NewDIDCommContext(myDID, theirDID, nil)).
+			DoAndReturn(func(msg service.DIDCommMsg, _ service.DIDCommContext) (string, error) {
+				request := protocol.RequestPresentation{}
+				require.NoError(t, msg.Decode(&request))
+				require.Equal(t, peDefinitionFormat, request.Formats[0].Format)
+				require.Equal(t, request.Formats[0].AttachID, request.RequestPresentationsAttach[0].ID)
+
+				return piid, nil
+			})
+		gomock.InOrder(
+			svc.EXPECT().Actions().Return(nil, nil),
+			svc.EXPECT().Actions().Return([]protocol.Action{
+				{PIID: "other"},
+				{PIID: piid, Msg: newPresentationMsg(t, newCredential(now.Add(-time.Hour)))},
+			}, nil),
+		)
+		svc.EXPECT().ActionContinue(piid, gomock.Any()).Return(nil)
+
+		policy := newPolicy()
+		policy.TrustedIssuers = []string{issuerDID}
+		policy.MaxCredentialAge = 24 * time.Hour
+		policy.RequireStatusCheck = true
+
+		decision, err := newClient(t, svc, policy).RequestProof(connID, policyName)
+		require.NoError(t, err)
+		require.Equal(t, &Decision{
+			PIID:          piid,
+			Policy:        policyName,
+			Accepted:      true,
+			CredentialIDs: []string{vcID},
+		}, decision)
+	})
+
+	t.Run("presentation declined", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(piid, nil)
+		svc.EXPECT().Actions().Return([]protocol.Action{
+			{PIID: piid, Msg: newPresentationMsg(t, newCredential(now.Add(-48*time.Hour)))},
+		}, nil)
+		svc.EXPECT().ActionStop(piid, gomock.Any()).DoAndReturn(func(_ string, err error) error {
+			require.Contains(t, err.Error(), "is not trusted")
+
+			return nil
+		})
+
+		policy := newPolicy()
+		policy.TrustedIssuers = []string{"did:example:other"}
+		policy.MaxCredentialAge = 24 * time.Hour
+		policy.RequireStatusCheck = true
+
+		client := newClient(t, svc, policy, WithStatusChecker(func(*verifiable.TypedID) error {
+			return verifiable.ErrCredentialRevoked
+		}))
+
+		decision, err := client.RequestProof(connID, policyName)
+		require.NoError(t, err)
+		require.False(t, decision.Accepted)
+		require.Equal(t, []string{
+			descriptor + ": issuer " + issuerDID + " is not trusted",
+			descriptor + ": credential is older than 24h0m0s",
+			descriptor + ": status check: " + verifiable.ErrCredentialRevoked.Error(),
+		}, decision.Reasons)
+	})
+
+	t.Run("presentation not matching the definition", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(piid, nil)
+		svc.EXPECT().Actions().Return([]protocol.Action{{PIID: piid, Msg: service.NewDIDCommMsgMap(
+			protocol.Presentation{
+				Type: protocol.PresentationMsgType,
+				PresentationsAttach: []decorator.Attachment{
+					{Data: decorator.AttachmentData{Base64: "invalid"}},
+					{Data: decorator.AttachmentData{JSON: map[string]interface{}{"type": "unknown"}}},
+				},
+			})}}, nil)
+		svc.EXPECT().ActionStop(piid, gomock.Any()).Return(nil)
+
+		decision, err := newClient(t, svc, newPolicy()).RequestProof(connID, policyName)
+		require.NoError(t, err)
+		require.False(t, decision.Accepted)
+		require.Len(t, decision.Reasons, 2)
+		require.Contains(t, decision.Reasons[0], "fetch presentation")
+		require.Contains(t, decision.Reasons[1], "parse presentation")
+	})
+
+	t.Run("no presentation", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(piid, nil)
+		svc.EXPECT().Actions().Return([]protocol.Action{{PIID: piid, Msg: service.NewDIDCommMsgMap(
+			protocol.Presentation{Type: protocol.PresentationMsgType},
+		)}}, nil)
+		svc.EXPECT().ActionStop(piid, gomock.Any()).Return(errors.New("stop error"))
+
+		_, err := newClient(t, svc, newPolicy()).RequestProof(connID, policyName)
+		require.EqualError(t, err, "complete presentation "+piid+": stop error")
+	})
+
+	t.Run("presentation not received", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(piid, nil)
+		svc.EXPECT().Actions().Return(nil, nil).AnyTimes()
+
+		_, err := newClient(t, svc, newPolicy(), WithTimeout(10*time.Millisecond)).RequestProof(connID, policyName)
+		require.EqualError(t, err, "no presentation received for "+piid+" within 10ms")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		client := newClient(t, svc, newPolicy())
+
+		_, err := client.RequestProof(connID, "unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get policy unknown")
+
+		_, err = client.RequestProof("unknown", policyName)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection unknown")
+
+		svc.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return("", errors.New("send error"))
+
+		_, err = client.RequestProof(connID, policyName)
+		require.EqualError(t, err, "send request presentation: send error")
+
+		svc.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(piid, nil)
+		svc.EXPECT().Actions().Return(nil, errors.New("actions error"))
+
+		_, err = client.RequestProof(connID, policyName)
+		require.EqualError(t, err, "get actions: actions error")
+	})
+}
+
+func TestClient_CheckRules(t *testing.T) {
+	client := &Client{currentTime: time.Now}
+
+	policy := &Policy{MaxCredentialAge: time.Hour, RequireStatusCheck: true}
+
+	require.Equal(t, []string{
+		descriptor + ": issuance date is not defined",
+		descriptor + ": credential status is not defined",
+	}, client.checkRules(policy, descriptor, &verifiable.Credential{}))
+}
@@ -0,0 +1,203 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmssigner"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/audit"
+)
+
+var logger = log.New("aries-framework/command/audit")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Audit)
+
+	// QueryErrorCode for query audit log error.
+	QueryErrorCode
+
+	// VerifyErrorCode for verify audit log error.
+	VerifyErrorCode
+
+	// ExportErrorCode for export audit log error.
+	ExportErrorCode
+)
+
+// constants for the audit controller's methods.
+const (
+	// command name.
+	CommandName = "audit"
+
+	// command methods.
+	QueryCommandMethod  = "Query"
+	VerifyCommandMethod = "Verify"
+	ExportCommandMethod = "Export"
+
+	// default signature algorithm of the log bundles.
+	defaultSignatureAlgorithm = "EdDSA"
+
+	// error messages.
+	errEmptyKID        = "kid is mandatory"
+	errAuditNotEnabled = "audit log is not enabled on this agent"
+
+	// log constants.
+	kidString = "kid"
+)
+
+// provider contains dependencies for the audit controller command operations
+// and is typically created by using aries.Context().
+type provider interface {
+	AuditLog() *audit.Log
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+}
+
+// Command contains command operations querying, verifying and exporting the audit log of the credential operations.
+type Command struct {
+	ctx provider
+}
+
+// New returns new audit controller command instance.
+func New(ctx provider) *Command {
+	return &Command{ctx: ctx}
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, QueryCommandMethod, c.Query),
+		cmdutil.NewCommandHandler(CommandName, VerifyCommandMethod, c.Verify),
+		cmdutil.NewCommandHandler(CommandName, ExportCommandMethod, c.Export),
+	}
+}
+
+// Query returns the audit log entries matching the criteria.
+func (c *Command) Query(rw io.Writer, req io.Reader) command.Error {
+	var request QueryArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, QueryCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	auditLog := c.ctx.AuditLog()
+	if auditLog == nil {
+		logutil.LogError(logger, CommandName, QueryCommandMethod, errAuditNotEnabled)
+		return command.NewExecuteError(QueryErrorCode, fmt.Errorf(errAuditNotEnabled))
+	}
+
+	entries, err := auditLog.Query(&audit.Filter{
+		Type:     audit.EventType(request.Type),
+		ActorDID: request.ActorDID,
+		ObjectID: request.ObjectID,
+		From:     request.From,
+		To:       request.To,
+	})
+	if err != nil {
+		logutil.LogError(logger, CommandName, QueryCommandMethod, err.Error())
+		return command.NewExecuteError(QueryErrorCode, err)
+	}
+
+	if entries == nil {
+		entries = []*audit.Entry{}
+	}
+
+	command.WriteNillableResponse(rw, &QueryResult{Entries: entries}, logger)
+
+	logutil.LogDebug(logger, CommandName, QueryCommandMethod, "success")
+
+	return nil
+}
+
+// Verify checks the integrity of the audit log. An altered or truncated log is reported in the result.
+func (c *Command) Verify(rw io.Writer, _ io.Reader) command.Error {
+	auditLog := c.ctx.AuditLog()
+	if auditLog == nil {
+		logutil.LogError(logger, CommandName, VerifyCommandMethod, errAuditNotEnabled)
+		return command.NewExecuteError(VerifyErrorCode, fmt.Errorf(errAuditNotEnabled))
+	}
+
+	result := &VerifyResult{Valid: true}
+
+	if err := auditLog.Verify(); err != nil {
+		logutil.LogError(logger, CommandName, VerifyCommandMethod, err.Error())
+
+		result = &VerifyResult{Error: err.Error()}
+	}
+
+	command.WriteNillableResponse(rw, result, logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyCommandMethod, "success")
+
+	return nil
+}
+
+// Export exports a range of audit log entries as a log bundle signed (JWS) by the KMS key.
+func (c *Command) Export(rw io.Writer, req io.Reader) command.Error {
+	var request ExportArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.KID == "" {
+		logutil.LogDebug(logger, CommandName, ExportCommandMethod, errEmptyKID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKID))
+	}
+
+	auditLog := c.ctx.AuditLog()
+	if auditLog == nil {
+		logutil.LogError(logger, CommandName, ExportCommandMethod, errAuditNotEnabled)
+		return command.NewExecuteError(ExportErrorCode, fmt.Errorf(errAuditNotEnabled))
+	}
+
+	signer, err := kmssigner.NewKMSSigner(c.ctx.KMS(), c.ctx.Crypto(), request.KID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ExportCommandMethod, "get signer: "+err.Error(),
+			logutil.CreateKeyValueString(kidString, request.KID))
+
+		return command.NewExecuteError(ExportErrorCode, fmt.Errorf("get signer: %w", err))
+	}
+
+	alg := request.SignatureAlgorithm
+	if alg == "" {
+		alg = defaultSignatureAlgorithm
+	}
+
+	bundle, err := auditLog.Export(jose.NewSigner(signer, jose.Headers{
+		jose.HeaderAlgorithm: alg,
+		jose.HeaderKeyID:     request.KID,
+	}), request.FromSequence, request.ToSequence)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ExportCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(kidString, request.KID))
+
+		return command.NewExecuteError(ExportErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportResult{Bundle: bundle}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportCommandMethod, "success",
+		logutil.CreateKeyValueString(kidString, request.KID))
+
+	return nil
+}
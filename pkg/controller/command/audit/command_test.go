@@ -0,0 +1,220 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/audit"
+)
+
+const actorDID = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+type mockProvider struct {
+	auditLog   *audit.Log
+	keyManager kms.KeyManager
+	crypto     crypto.Crypto
+}
+
+func (p *mockProvider) AuditLog() *audit.Log {
+	return p.auditLog
+}
+
+func (p *mockProvider) KMS() kms.KeyManager {
+	return p.keyManager
+}
+
+func (p *mockProvider) Crypto() crypto.Crypto {
+	return p.crypto
+}
+
+func newMockProvider(t *testing.T, storeProvider *mockstore.MockStoreProvider) *mockProvider {
+	t.Helper()
+
+	auditLog, err := audit.New(&mockprovider.Provider{StorageProviderValue: storeProvider})
+	require.NoError(t, err)
+
+	for _, event := range []*audit.Event{
+		{Type: audit.EventIssued, ActorDID: actorDID, ObjectID: "urn:uuid:vc-1"},
+		{Type: audit.EventVerified, ObjectID: "urn:uuid:vc-1"},
+		{Type: audit.EventDeleted, ActorDID: actorDID, ObjectID: "urn:uuid:vc-1"},
+	} {
+		_, err = auditLog.Append(event)
+		require.NoError(t, err)
+	}
+
+	keyManager, err := localkms.New("local-lock://test/key-uri/",
+		mockkms.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	return &mockProvider{auditLog: auditLog, keyManager: keyManager, crypto: cryptoSvc}
+}
+
+func TestNew(t *testing.T) {
+	cmd := New(&mockProvider{})
+	require.NotNil(t, cmd)
+	require.Len(t, cmd.GetHandlers(), 3)
+}
+
+func TestCommand_Query(t *testing.T) {
+	cmd := New(newMockProvider(t, mockstore.NewMockStoreProvider()))
+
+	t.Run("test query - success", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, cmd.Query(&b, bytes.NewBufferString(`{}`)))
+
+		var result QueryResult
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+		require.Len(t, result.Entries, 3)
+
+		b.Reset()
+		require.NoError(t, cmd.Query(&b, bytes.NewBufferString(`{"actorDID":"`+actorDID+`","type":"deleted"}`)))
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+		require.Len(t, result.Entries, 1)
+		require.Equal(t, uint64(3), result.Entries[0].Sequence)
+
+		b.Reset()
+		require.NoError(t, cmd.Query(&b, bytes.NewBufferString(`{"objectID":"urn:uuid:vc-2"}`)))
+		require.JSONEq(t, `{"entries":[]}`, b.String())
+	})
+
+	t.Run("test query - errors", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = New(&mockProvider{}).Query(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, QueryErrorCode, cmdErr.Code())
+		require.EqualError(t, cmdErr, errAuditNotEnabled)
+
+		storeProvider := mockstore.NewMockStoreProvider()
+		prov := newMockProvider(t, storeProvider)
+		storeProvider.Store.ErrQuery = errors.New("query error")
+
+		cmdErr = New(prov).Query(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, QueryErrorCode, cmdErr.Code())
+		require.EqualError(t, cmdErr, "query audit log: query error")
+	})
+}
+
+func TestCommand_Verify(t *testing.T) {
+	t.Run("test verify - valid and altered logs", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+		cmd := New(newMockProvider(t, storeProvider))
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Verify(&b, nil))
+		require.JSONEq(t, `{"valid":true}`, b.String())
+
+		for key, entry := range storeProvider.Store.Store {
+			if len(entry.Tags) > 0 {
+				entry.Value = bytes.Replace(entry.Value, []byte("vc-1"), []byte("vc-2"), 1)
+				storeProvider.Store.Store[key] = entry
+			}
+		}
+
+		b.Reset()
+		require.NoError(t, cmd.Verify(&b, nil))
+
+		var result VerifyResult
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+		require.False(t, result.Valid)
+		require.Equal(t, "audit entry 1 was altered", result.Error)
+	})
+
+	t.Run("test verify - audit not enabled", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := New(&mockProvider{}).Verify(&b, nil)
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
+
+func TestCommand_Export(t *testing.T) {
+	prov := newMockProvider(t, mockstore.NewMockStoreProvider())
+	cmd := New(prov)
+
+	kid, pubKey, err := prov.keyManager.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	t.Run("test export - success", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, cmd.Export(&b, bytes.NewBufferString(`{"kid":"`+kid+`","fromSequence":2}`)))
+
+		var result ExportResult
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+
+		bundle, err := audit.ParseBundle(result.Bundle, jose.SignatureVerifierFunc(
+			func(headers jose.Headers, _, signingInput, signature []byte) error {
+				if k, _ := headers.KeyID(); k != kid {
+					return errors.New("unexpected kid")
+				}
+
+				if alg, _ := headers.Algorithm(); alg != "EdDSA" {
+					return errors.New("unexpected alg")
+				}
+
+				if !ed25519.Verify(pubKey, signingInput, signature) {
+					return errors.New("signature doesn't match")
+				}
+
+				return nil
+			}))
+		require.NoError(t, err)
+		require.Len(t, bundle.Entries, 2)
+		require.Equal(t, uint64(2), bundle.Entries[0].Sequence)
+	})
+
+	t.Run("test export - errors", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.Export(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.EqualError(t, cmdErr, errEmptyKID)
+
+		cmdErr = New(&mockProvider{}).Export(&b, bytes.NewBufferString(`{"kid":"`+kid+`"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, ExportErrorCode, cmdErr.Code())
+		require.EqualError(t, cmdErr, errAuditNotEnabled)
+
+		cmdErr = cmd.Export(&b, bytes.NewBufferString(`{"kid":"unknown"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, ExportErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "get signer")
+
+		cmdErr = cmd.Export(&b, bytes.NewBufferString(`{"kid":"`+kid+`","fromSequence":3,"toSequence":1}`))
+		require.NoError(t, cmdErr)
+	})
+}
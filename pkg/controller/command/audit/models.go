@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/store/audit"
+)
+
+// QueryArgs model
+//
+// This is used for querying the audit log entries, the empty criteria select all entries.
+//
+type QueryArgs struct {
+	// Type of the audited operation, supported values [issued] [verified] [presented] [stored] [deleted]
+	Type string `json:"type,omitempty"`
+
+	// ActorDID is the DID of the party performing the operation
+	ActorDID string `json:"actorDID,omitempty"`
+
+	// ObjectID is the ID (or name) of the credential or presentation
+	ObjectID string `json:"objectID,omitempty"`
+
+	// From selects the entries recorded at or after this time
+	From time.Time `json:"from,omitempty"`
+
+	// To selects the entries recorded before this time
+	To time.Time `json:"to,omitempty"`
+}
+
+// QueryResult model
+//
+// This is used for returning the audit log entries.
+//
+type QueryResult struct {
+	// Entries of the audit log, in sequence order
+	Entries []*audit.Entry `json:"entries"`
+}
+
+// VerifyResult model
+//
+// This is used for returning the integrity check result of the audit log.
+//
+type VerifyResult struct {
+	// Valid is true if the audit log was neither altered nor truncated
+	Valid bool `json:"valid"`
+
+	// Error describes the integrity violation
+	Error string `json:"error,omitempty"`
+}
+
+// ExportArgs model
+//
+// This is used for exporting audit log entries as a signed log bundle.
+//
+type ExportArgs struct {
+	// KID is the KMS key ID signing the bundle, also used as the "kid" header
+	KID string `json:"kid"`
+
+	// SignatureAlgorithm is the JWS algorithm of the key, EdDSA by default
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+
+	// FromSequence is the sequence number of the first exported entry, the first entry by default
+	FromSequence uint64 `json:"fromSequence,omitempty"`
+
+	// ToSequence is the sequence number of the last exported entry, the last entry by default
+	ToSequence uint64 `json:"toSequence,omitempty"`
+}
+
+// ExportResult model
+//
+// This is used for returning the signed log bundle.
+//
+type ExportResult struct {
+	// Bundle is the JWS compact serialization of the log bundle
+	Bundle string `json:"bundle"`
+}
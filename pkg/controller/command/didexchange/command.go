@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -93,13 +94,37 @@ type provider interface {
 	ProtocolStateStorageProvider() storage.Provider
 }
 
+// Option configures the DID Exchange command.
+type Option func(c *Command)
+
+// WithSettings makes the command read the agent settings at runtime: the auto_accept setting overrides
+// the auto-accept flag of the command, and the mediator_connections setting gives the router connections used
+// when the request specifies none.
+func WithSettings(store *settings.Store) Option {
+	return func(c *Command) {
+		c.settings = store
+	}
+}
+
 // New returns new DID Exchange controller command instance.
-func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccept bool) (*Command, error) {
+func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccept bool,
+	opts ...Option) (*Command, error) {
 	didExchange, err := didexchange.New(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	cmd := &Command{
+		ctx:          ctx,
+		client:       didExchange,
+		msgCh:        make(chan service.StateMsg),
+		defaultLabel: defaultLabel,
+	}
+
+	for _, opt := range opts {
+		opt(cmd)
+	}
+
 	// creates action channel
 	actions := make(chan service.DIDCommAction)
 	// registers action channel to listen for events
@@ -118,7 +143,12 @@ func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccep
 		make(chan service.DIDCommAction),
 	}
 
-	if autoAccept {
+	switch {
+	case cmd.settings != nil:
+		subscribers = append(subscribers, make(chan service.DIDCommAction))
+
+		go cmd.autoExecuteActionEvent(subscribers[1], autoAccept)
+	case autoAccept:
 		subscribers = append(subscribers, make(chan service.DIDCommAction))
 
 		go service.AutoExecuteActionEvent(subscribers[1])
@@ -137,13 +167,6 @@ func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccep
 	obs.RegisterAction(protocol.DIDExchange+_actions, subscribers[0])
 	obs.RegisterStateMsg(protocol.DIDExchange+_states, states)
 
-	cmd := &Command{
-		ctx:          ctx,
-		client:       didExchange,
-		msgCh:        make(chan service.StateMsg),
-		defaultLabel: defaultLabel,
-	}
-
 	return cmd, nil
 }
 
@@ -153,6 +176,29 @@ type Command struct {
 	client       *didexchange.Client
 	msgCh        chan service.StateMsg
 	defaultLabel string
+	settings     *settings.Store
+}
+
+// autoExecuteActionEvent continues the actions while auto-accept is enabled by the auto_accept setting,
+// or by the auto-accept flag of the command if the setting is not set.
+func (c *Command) autoExecuteActionEvent(ch chan service.DIDCommAction, autoAccept bool) {
+	for action := range ch {
+		if c.settings.Bool(settings.AutoAccept, autoAccept) {
+			action.Continue(&service.Empty{})
+		}
+	}
+}
+
+// routerConnections returns the router connections of the request, or the mediator_connections setting
+// if the request specifies none.
+func (c *Command) routerConnections(requested string) []string {
+	if requested == "" && c.settings != nil {
+		if conns := c.settings.Strings(settings.MediatorConnections, nil); len(conns) > 0 {
+			return conns
+		}
+	}
+
+	return strings.Split(requested, ",")
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -184,10 +230,12 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 	var invitation *didexchange.Invitation
 	// call didexchange client
 	if request.Public != "" {
-		invitation, err = c.client.CreateInvitationWithDID(c.defaultLabel, request.Public)
+		invitation, err = c.client.CreateInvitationWithDID(c.defaultLabel, request.Public,
+			didexchange.WithAlias(request.Alias))
 	} else {
 		invitation, err = c.client.CreateInvitation(c.defaultLabel,
-			didexchange.WithRouterConnectionID(request.RouterConnectionID))
+			didexchange.WithRouterConnectionID(c.routerConnections(request.RouterConnectionID)[0]),
+			didexchange.WithAlias(request.Alias))
 	}
 
 	if err != nil {
@@ -210,16 +258,16 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 
 // ReceiveInvitation receives a new connection invitation.
 func (c *Command) ReceiveInvitation(rw io.Writer, req io.Reader) command.Error {
-	var request didexchange.Invitation
+	var request ReceiveInvitationArgs
 
-	err := json.NewDecoder(req).Decode(&request.Invitation)
+	err := json.NewDecoder(req).Decode(&request)
 	if err != nil {
 		logutil.LogInfo(logger, CommandName, ReceiveInvitationCommandMethod, err.Error())
 
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
-	connectionID, err := c.client.HandleInvitation(&request)
+	connectionID, err := c.client.HandleInvitation(&request.Invitation, didexchange.WithAlias(request.Alias))
 	if err != nil {
 		logutil.LogError(logger, CommandName, ReceiveInvitationCommandMethod, err.Error(),
 			logutil.CreateKeyValueString(invitationIDString, request.ID),
@@ -259,7 +307,7 @@ func (c *Command) AcceptInvitation(rw io.Writer, req io.Reader) command.Error {
 	}
 
 	err = c.client.AcceptInvitation(request.ID, request.Public, c.defaultLabel,
-		didexchange.WithRouterConnections(strings.Split(request.RouterConnections, ",")...))
+		didexchange.WithRouterConnections(c.routerConnections(request.RouterConnections)...))
 	if err != nil {
 		logutil.LogError(logger, CommandName, AcceptInvitationCommandMethod, err.Error(),
 			logutil.CreateKeyValueString(connectionIDString, request.ID))
@@ -306,7 +354,7 @@ func (c *Command) CreateImplicitInvitation(rw io.Writer, req io.Reader) command.
 		id, err = c.client.CreateImplicitInvitationWithDID(inviter, invitee)
 	} else {
 		id, err = c.client.CreateImplicitInvitation(inviter.Label, inviter.DID,
-			didexchange.WithRouterConnections(strings.Split(request.RouterConnections, ",")...))
+			didexchange.WithRouterConnections(c.routerConnections(request.RouterConnections)...))
 	}
 
 	if err != nil {
@@ -340,7 +388,7 @@ func (c *Command) AcceptExchangeRequest(rw io.Writer, req io.Reader) command.Err
 	}
 
 	err = c.client.AcceptExchangeRequest(request.ID, request.Public,
-		c.defaultLabel, didexchange.WithRouterConnections(strings.Split(request.RouterConnections, ",")...))
+		c.defaultLabel, didexchange.WithRouterConnections(c.routerConnections(request.RouterConnections)...))
 	if err != nil {
 		logutil.LogError(logger, CommandName, AcceptExchangeRequestCommandMethod, err.Error(),
 			logutil.CreateKeyValueString(connectionIDString, request.ID))
@@ -441,6 +489,7 @@ func (c *Command) CreateConnection(rw io.Writer, req io.Reader) command.Error {
 	id, err := c.client.CreateConnection(request.MyDID, theirDID,
 		didexchange.WithImplicit(request.Implicit),
 		didexchange.WithTheirLabel(request.TheirLabel),
+		didexchange.WithConnectionAlias(request.Alias),
 		didexchange.WithInvitationDID(request.InvitationDID),
 		didexchange.WithInvitationID(request.InvitationID),
 		didexchange.WithParentThreadID(request.ParentThreadID),
@@ -42,6 +42,7 @@ import (
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
 	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -102,6 +103,22 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestCommand_routerConnections(t *testing.T) {
+	cmd := &Command{}
+	require.Equal(t, []string{""}, cmd.routerConnections(""))
+	require.Equal(t, []string{"conn-1", "conn-2"}, cmd.routerConnections("conn-1,conn-2"))
+
+	store, err := settings.New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+	require.NoError(t, err)
+
+	WithSettings(store)(cmd)
+	require.Equal(t, []string{""}, cmd.routerConnections(""))
+
+	require.NoError(t, store.Set(settings.MediatorConnections, json.RawMessage(`["conn-3"]`)))
+	require.Equal(t, []string{"conn-3"}, cmd.routerConnections(""))
+	require.Equal(t, []string{"conn-1"}, cmd.routerConnections("conn-1"))
+}
+
 func TestCommand_CreateInvitation(t *testing.T) {
 	t.Run("Successful CreateInvitation with label", func(t *testing.T) {
 		cmd, err := New(mockProvider(), mockwebhook.NewMockWebhookNotifier(), "", false)
@@ -228,6 +245,34 @@ func TestCommand_ReceiveInvitation(t *testing.T) {
 		require.NotEmpty(t, response.ConnectionID)
 	})
 
+	t.Run("Successful ReceiveInvitation with alias", func(t *testing.T) {
+		jsonStr := `{
+		"serviceEndpoint":"http://alice.agent.example.com:8081",
+		"recipientKeys":["FDmegH8upiNquathbHZiGBZKwcudNfNWPeGQFBt8eNNi"],
+		"@id":"a35c0ac6-4fc3-46af-a072-c1036d036057",
+		"label":"agent",
+		"alias":"Alice",
+		"@type":"https://didcomm.org/didexchange/1.0/invitation"}`
+
+		prov := mockProvider()
+		prov.ServiceMap[didexsvc.DIDExchange] = &mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				require.Equal(t, "Alice", msg.Metadata()[didexsvc.AliasMetadataKey])
+				require.Equal(t, "a35c0ac6-4fc3-46af-a072-c1036d036057", msg.ID())
+
+				return uuid.New().String(), nil
+			},
+		}
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.ReceiveInvitation(&b, bytes.NewBufferString(jsonStr))
+		require.NoError(t, cmdErr)
+	})
+
 	t.Run("ReceiveInvitation failure", func(t *testing.T) {
 		jsonStr := `{
     	"@type": "https://didcomm.org/connections/1.0/invitation",
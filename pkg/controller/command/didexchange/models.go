@@ -19,7 +19,7 @@ import (
 //
 type CreateInvitationArgs struct {
 
-	// The Alias to be used in invitation to be created
+	// The Alias of the connections created for the invitation
 	Alias string `json:"alias"`
 
 	// Optional public DID to be used in invitation
@@ -41,6 +41,17 @@ type CreateInvitationResponse struct {
 	InvitationURL string `json:"invitation_url"`
 }
 
+// ReceiveInvitationArgs model
+//
+// This is used for receiving connection invitation, the alias is optional.
+//
+type ReceiveInvitationArgs struct {
+	didexchange.Invitation
+
+	// The Alias of the connection created for the invitation
+	Alias string `json:"alias,omitempty"`
+}
+
 // ReceiveInvitationResponse model
 //
 // This is used for returning a receive invitation response with a single receive invitation response as body.
@@ -320,6 +331,7 @@ type CreateConnectionRequest struct {
 	MyDID          string      `json:"myDID"`
 	TheirDID       DIDDocument `json:"theirDID"`
 	TheirLabel     string      `json:"theirLabel,omitempty"`
+	Alias          string      `json:"alias,omitempty"`
 	InvitationID   string      `json:"invitationID,omitempty"`
 	InvitationDID  string      `json:"invitationDID,omitempty"`
 	ParentThreadID string      `json:"parentThreadID,omitempty"`
@@ -64,6 +64,24 @@ const (
 
 	// Outofband error group for outofband command errors.
 	Outofband = 11000
+
+	// Tenant error group for multi-tenant routing errors.
+	Tenant = 12000
+
+	// Transport error group for transport command errors.
+	Transport = 13000
+
+	// Audit error group for audit log command errors.
+	Audit = 14000
+
+	// History error group for credential history command errors.
+	History = 15000
+
+	// VerifierPolicy error group for verifier policy command errors.
+	VerifierPolicy = 16000
+
+	// Settings error group for agent settings command errors.
+	Settings = 17000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+)
+
+var logger = log.New("aries-framework/command/history")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.History)
+
+	// TimelineErrorCode for credential history timeline error.
+	TimelineErrorCode
+)
+
+// constants for the credential history controller's methods.
+const (
+	// command name.
+	CommandName = "history"
+
+	// command methods.
+	TimelineCommandMethod = "Timeline"
+
+	// error messages.
+	errHistoryNotEnabled = "credential history is not enabled on this agent"
+)
+
+// provider contains dependencies for the credential history controller command operations
+// and is typically created by using aries.Context().
+type provider interface {
+	CredentialHistory() *history.Store
+}
+
+// Command contains command operations querying the history of the credential exchanges.
+type Command struct {
+	ctx provider
+}
+
+// New returns new credential history controller command instance.
+func New(ctx provider) *Command {
+	return &Command{ctx: ctx}
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, TimelineCommandMethod, c.Timeline),
+	}
+}
+
+// Timeline returns the events of the credential exchanges matching the criteria, in chronological order.
+func (c *Command) Timeline(rw io.Writer, req io.Reader) command.Error {
+	var request TimelineArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, TimelineCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	store := c.ctx.CredentialHistory()
+	if store == nil {
+		logutil.LogError(logger, CommandName, TimelineCommandMethod, errHistoryNotEnabled)
+		return command.NewExecuteError(TimelineErrorCode, fmt.Errorf(errHistoryNotEnabled))
+	}
+
+	events, err := store.Timeline(&history.Filter{
+		ConnectionID: request.ConnectionID,
+		CredentialID: request.CredentialID,
+		Type:         history.EventType(request.Type),
+	})
+	if err != nil {
+		logutil.LogError(logger, CommandName, TimelineCommandMethod, err.Error())
+		return command.NewExecuteError(TimelineErrorCode, err)
+	}
+
+	if events == nil {
+		events = []*history.Event{}
+	}
+
+	command.WriteNillableResponse(rw, &TimelineResult{Events: events}, logger)
+
+	logutil.LogDebug(logger, CommandName, TimelineCommandMethod, "success")
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+)
+
+const vcID = "http://example.edu/credentials/1872"
+
+type mockProvider struct {
+	credentialHistory *history.Store
+}
+
+func (p *mockProvider) CredentialHistory() *history.Store {
+	return p.credentialHistory
+}
+
+func newMockProvider(t *testing.T, storeProvider *mockstore.MockStoreProvider) *mockProvider {
+	t.Helper()
+
+	store, err := history.New(&mockprovider.Provider{
+		StorageProviderValue:              storeProvider,
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	for _, event := range []*history.Event{
+		{Type: history.EventOffered, PIID: "piid-1"},
+		{Type: history.EventIssued, PIID: "piid-1", CredentialIDs: []string{vcID}},
+		{Type: history.EventRevoked, CredentialIDs: []string{vcID}},
+		{Type: history.EventOffered, PIID: "piid-2"},
+	} {
+		require.NoError(t, store.Record(event))
+	}
+
+	return &mockProvider{credentialHistory: store}
+}
+
+func TestNew(t *testing.T) {
+	cmd := New(&mockProvider{})
+	require.NotNil(t, cmd)
+	require.Len(t, cmd.GetHandlers(), 1)
+}
+
+func TestCommand_Timeline(t *testing.T) {
+	cmd := New(newMockProvider(t, mockstore.NewMockStoreProvider()))
+
+	t.Run("test timeline - success", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, cmd.Timeline(&b, bytes.NewBufferString(`{}`)))
+
+		var result TimelineResult
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+		require.Len(t, result.Events, 4)
+
+		b.Reset()
+		require.NoError(t, cmd.Timeline(&b, bytes.NewBufferString(`{"credentialID":"`+vcID+`"}`)))
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+		require.Len(t, result.Events, 3)
+		require.Equal(t, history.EventOffered, result.Events[0].Type)
+		require.Equal(t, history.EventRevoked, result.Events[2].Type)
+
+		b.Reset()
+		require.NoError(t, cmd.Timeline(&b, bytes.NewBufferString(`{"connectionID":"conn-1"}`)))
+		require.JSONEq(t, `{"events":[]}`, b.String())
+	})
+
+	t.Run("test timeline - errors", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.Timeline(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = New(&mockProvider{}).Timeline(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, TimelineErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.EqualError(t, cmdErr, errHistoryNotEnabled)
+
+		storeProvider := mockstore.NewMockStoreProvider()
+		prov := newMockProvider(t, storeProvider)
+		storeProvider.Store.ErrQuery = errors.New("query error")
+
+		cmdErr = New(prov).Timeline(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, TimelineErrorCode, cmdErr.Code())
+		require.EqualError(t, cmdErr, "query credential history: query error")
+	})
+}
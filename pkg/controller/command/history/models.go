@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+)
+
+// TimelineArgs model
+//
+// This is used for querying the timeline of the credential exchanges, the empty criteria select all events.
+//
+type TimelineArgs struct {
+	// ConnectionID selects the events of the exchanges over the connection
+	ConnectionID string `json:"connectionID,omitempty"`
+
+	// CredentialID selects the events of the credential and of the exchanges it was part of
+	CredentialID string `json:"credentialID,omitempty"`
+
+	// Type of the events, supported values [offered] [requested] [issued] [presented] [verified] [revoked]
+	Type string `json:"type,omitempty"`
+}
+
+// TimelineResult model
+//
+// This is used for returning the timeline of the credential exchanges.
+//
+type TimelineResult struct {
+	// Events of the credential exchanges, in chronological order
+	Events []*history.Event `json:"events"`
+}
@@ -31,6 +31,8 @@ const (
 	CreateKeySetError
 	// ImportKeyError is for failures while importing key.
 	ImportKeyError
+	// JWKSError is for failures while publishing JWKS documents of agent public keys.
+	JWKSError
 )
 
 // constants for KMS commands.
@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ack"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/http"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -40,6 +41,7 @@ const (
 	errMsgDestSvcEndpointMissing     = "missing service endpoint in message destination"
 	errMsgDestSvcEndpointKeysMissing = "missing service endpoint recipient/routing keys in message destination"
 	errMsgIDEmpty                    = "empty message ID"
+	errMsgAckTrackingDisabled        = "acknowledgement tracking is not enabled"
 
 	// command methods.
 	RegisteredServicesCommandMethod         = "Services"
@@ -48,6 +50,7 @@ const (
 	RegisterHTTPMessageServiceCommandMethod = "RegisterHTTPService"
 	SendNewMessageCommandMethod             = "Send"
 	SendReplyMessageCommandMethod           = "Reply"
+	DeliveryStatusCommandMethod             = "DeliveryStatus"
 
 	// log constants.
 	replyTo       = "replyTo"
@@ -73,6 +76,9 @@ const (
 
 	// SendMsgReplyError is for failures while sending message replies.
 	SendMsgReplyError
+
+	// DeliveryStatusError is for failures while getting the delivery status of messages.
+	DeliveryStatusError
 )
 
 // provider contains dependencies for the messaging controller command operations
@@ -87,7 +93,8 @@ type provider interface {
 
 // Command contains basic command operations provided by messaging controller command.
 type Command struct {
-	msgClient *messaging.Client
+	msgClient  *messaging.Client
+	ackTracker *ack.Tracker
 }
 
 // New returns new command instance for messaging controller API.
@@ -97,7 +104,14 @@ func New(ctx provider, registrar command.MessageHandler, notifier command.Notifi
 		return nil, fmt.Errorf("failed to initialize message client : %w", err)
 	}
 
-	return &Command{msgClient}, nil
+	cmd := &Command{msgClient: msgClient}
+
+	// the delivery status is available when the acknowledgements are tracked (see aries.WithAckTracking)
+	if p, ok := ctx.(interface{ AckTracker() *ack.Tracker }); ok {
+		cmd.ackTracker = p.AckTracker()
+	}
+
+	return cmd, nil
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -109,6 +123,7 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, RegisterHTTPMessageServiceCommandMethod, o.RegisterHTTPService),
 		cmdutil.NewCommandHandler(CommandName, SendNewMessageCommandMethod, o.Send),
 		cmdutil.NewCommandHandler(CommandName, SendReplyMessageCommandMethod, o.Reply),
+		cmdutil.NewCommandHandler(CommandName, DeliveryStatusCommandMethod, o.DeliveryStatus),
 	}
 }
 
@@ -252,6 +267,47 @@ func (o *Command) Reply(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// DeliveryStatus returns the delivery status of a message requesting an acknowledgement with `~please_ack`.
+func (o *Command) DeliveryStatus(rw io.Writer, req io.Reader) command.Error {
+	var request DeliveryStatusArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DeliveryStatusCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.MessageID == "" {
+		logutil.LogDebug(logger, CommandName, DeliveryStatusCommandMethod, errMsgIDEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgIDEmpty))
+	}
+
+	if o.ackTracker == nil {
+		logutil.LogDebug(logger, CommandName, DeliveryStatusCommandMethod, errMsgAckTrackingDisabled)
+		return command.NewExecuteError(DeliveryStatusError, fmt.Errorf(errMsgAckTrackingDisabled))
+	}
+
+	rec, err := o.ackTracker.Status(request.MessageID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeliveryStatusCommandMethod, err.Error())
+		return command.NewExecuteError(DeliveryStatusError, err)
+	}
+
+	command.WriteNillableResponse(rw, &DeliveryStatusResponse{
+		MessageID: rec.MsgID,
+		ThreadID:  rec.ThreadID,
+		Status:    rec.Status,
+		Attempts:  rec.Attempts,
+		SentAt:    rec.SentAt,
+		UpdatedAt: rec.UpdatedAt,
+		AckID:     rec.AckID,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, DeliveryStatusCommandMethod, successString)
+
+	return nil
+}
+
 // RegisterHTTPService registers new http over didcomm service to message handler registrar.
 func (o *Command) RegisterHTTPService(rw io.Writer, req io.Reader) command.Error {
 	var request RegisterHTTPMsgSvcArgs
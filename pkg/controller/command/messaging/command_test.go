@@ -17,6 +17,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ack"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/http"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
@@ -719,3 +721,106 @@ func TestCommand_Reply(t *testing.T) {
 		require.NoError(t, cmdErr)
 	})
 }
+
+type ackTrackerProvider struct {
+	*protocol.MockProvider
+	tracker *ack.Tracker
+}
+
+func (p *ackTrackerProvider) AckTracker() *ack.Tracker {
+	return p.tracker
+}
+
+func TestCommand_DeliveryStatus(t *testing.T) {
+	tracker, err := ack.New(&protocol.MockProvider{StoreProvider: mem.NewProvider()})
+	require.NoError(t, err)
+
+	send := tracker.OutboundInterceptor()(dispatcher.OutboundHandlerFunc(func(*dispatcher.OutboundMessage) error {
+		return nil
+	}))
+
+	require.NoError(t, send.HandleOutbound(&dispatcher.OutboundMessage{
+		Msg: service.DIDCommMsgMap{
+			"@id":         "1234",
+			"@type":       "https://didcomm.org/basicmessage/1.0/message",
+			"~please_ack": map[string]interface{}{"on": []interface{}{"RECEIPT"}},
+		},
+		Destination: &service.Destination{ServiceEndpoint: "http://example.com"},
+	}))
+
+	t.Run("Test delivery status validation and failures", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			requestJSON string
+			tracker     *ack.Tracker
+			errorCode   command.Code
+			errorMsg    string
+			errType     command.Type
+		}{
+			{
+				name:        "missing message id",
+				requestJSON: `{}`,
+				tracker:     tracker,
+				errorCode:   InvalidRequestErrorCode,
+				errorMsg:    errMsgIDEmpty,
+				errType:     command.ValidationError,
+			},
+			{
+				name:        "invalid request",
+				requestJSON: `----`,
+				tracker:     tracker,
+				errorCode:   InvalidRequestErrorCode,
+				errorMsg:    "invalid character",
+				errType:     command.ValidationError,
+			},
+			{
+				name:        "ack tracking disabled",
+				requestJSON: `{"message_ID": "1234"}`,
+				errorCode:   DeliveryStatusError,
+				errorMsg:    errMsgAckTrackingDisabled,
+				errType:     command.ExecuteError,
+			},
+			{
+				name:        "message not tracked",
+				requestJSON: `{"message_ID": "5678"}`,
+				tracker:     tracker,
+				errorCode:   DeliveryStatusError,
+				errorMsg:    ack.ErrNotTracked.Error(),
+				errType:     command.ExecuteError,
+			},
+		}
+
+		for _, test := range tests {
+			tc := test
+			t.Run(tc.name, func(t *testing.T) {
+				cmd, err := New(&ackTrackerProvider{MockProvider: &protocol.MockProvider{}, tracker: tc.tracker},
+					msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+				require.NoError(t, err)
+
+				var b bytes.Buffer
+				cmdErr := cmd.DeliveryStatus(&b, bytes.NewBufferString(tc.requestJSON))
+				require.Error(t, cmdErr)
+				require.Empty(t, b.String())
+				require.Equal(t, cmdErr.Type(), tc.errType)
+				require.Equal(t, cmdErr.Code(), tc.errorCode)
+				require.Contains(t, cmdErr.Error(), tc.errorMsg)
+			})
+		}
+	})
+
+	t.Run("Test delivery status", func(t *testing.T) {
+		cmd, err := New(&ackTrackerProvider{MockProvider: &protocol.MockProvider{}, tracker: tracker},
+			msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DeliveryStatus(&b, bytes.NewBufferString(`{"message_ID": "1234"}`))
+		require.NoError(t, cmdErr)
+
+		var response DeliveryStatusResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Equal(t, "1234", response.MessageID)
+		require.Equal(t, ack.StatusPending, response.Status)
+		require.Equal(t, 1, response.Attempts)
+	})
+}
@@ -110,6 +110,36 @@ type SendMessageResponse struct {
 	Response json.RawMessage `json:"response,omitempty"`
 }
 
+// DeliveryStatusArgs contains parameters for getting the delivery status of a message.
+type DeliveryStatusArgs struct {
+	// ID of the message requesting an acknowledgement
+	MessageID string `json:"message_ID"`
+}
+
+// DeliveryStatusResponse is response for delivery status feature.
+type DeliveryStatusResponse struct {
+	// ID of the message
+	MessageID string `json:"message_ID"`
+
+	// Thread ID of the message
+	ThreadID string `json:"thread_ID"`
+
+	// Status of the delivery: pending, acknowledged, failed or expired
+	Status string `json:"status"`
+
+	// Number of times the message was sent
+	Attempts int `json:"attempts"`
+
+	// Time the message was last sent at
+	SentAt time.Time `json:"sent_at"`
+
+	// Time the status was last updated at
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// ID of the acknowledgement or problem report received for the message
+	AckID string `json:"ack_ID,omitempty"`
+}
+
 // RegisterHTTPMsgSvcArgs contains parameters for registering an HTTP over DIDComm message service to message handler.
 type RegisterHTTPMsgSvcArgs struct {
 	// Name of the HTTP over DIDComm message service
@@ -37,6 +37,8 @@ const (
 	ActionsErrorCode
 	// ActionContinueErrorCode is for failures in action continue command.
 	ActionContinueErrorCode
+	// ResolveShortURLErrorCode is for failures in resolving the short invitation URL.
+	ResolveShortURLErrorCode
 )
 
 // constants for out-of-band.
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mdpresentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/presentproof"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 )
@@ -48,6 +49,16 @@ const (
 	AcceptPresentationErrorCode
 	// DeclinePresentationErrorCode is for failures in decline presentation command.
 	DeclinePresentationErrorCode
+	// RecordsErrorCode is for failures in records command.
+	RecordsErrorCode
+	// RecordErrorCode is for failures in record command.
+	RecordErrorCode
+	// PresentationsErrorCode is for failures in presentations command.
+	PresentationsErrorCode
+	// AbandonErrorCode is for failures in abandon command.
+	AbandonErrorCode
+	// CredentialSuggestionsErrorCode is for failures in credential suggestions command.
+	CredentialSuggestionsErrorCode
 )
 
 // constants for the PresentProof operations.
@@ -66,6 +77,11 @@ const (
 	DeclineProposePresentation   = "DeclineProposePresentation"
 	AcceptPresentation           = "AcceptPresentation"
 	DeclinePresentation          = "DeclinePresentation"
+	Records                      = "Records"
+	Record                       = "Record"
+	Presentations                = "Presentations"
+	Abandon                      = "Abandon"
+	CredentialSuggestions        = "CredentialSuggestions"
 )
 
 const (
@@ -76,6 +92,9 @@ const (
 	errEmptyPresentation        = "empty Presentation"
 	errEmptyProposePresentation = "empty ProposePresentation"
 	errEmptyRequestPresentation = "empty RequestPresentation"
+	errNoPresentation           = "no presentation"
+	errNoPendingRequest         = "no pending request presentation"
+	errSuggestionsNotSupported  = "credential suggestions are not supported by the provider"
 
 	// log constants.
 	successString = "success"
@@ -89,6 +108,8 @@ var logger = log.New("aries-framework/controller/presentproof")
 // Command is controller command for present proof.
 type Command struct {
 	client *presentproof.Client
+	// suggest is nil if the provider does not support the credential suggestions
+	suggest mdpresentproof.SuggestProvider
 }
 
 // New returns new present proof controller command instance.
@@ -116,7 +137,13 @@ func New(ctx presentproof.Provider, notifier command.Notifier) (*Command, error)
 	obs.RegisterAction(protocol.Name+_actions, actions)
 	obs.RegisterStateMsg(protocol.Name+_states, states)
 
-	return &Command{client: client}, nil
+	cmd := &Command{client: client}
+
+	if sp, ok := ctx.(mdpresentproof.SuggestProvider); ok {
+		cmd.suggest = sp
+	}
+
+	return cmd, nil
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -133,6 +160,11 @@ func (c *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, AcceptPresentation, c.AcceptPresentation),
 		cmdutil.NewCommandHandler(CommandName, DeclinePresentation, c.DeclinePresentation),
 		cmdutil.NewCommandHandler(CommandName, AcceptProblemReport, c.AcceptProblemReport),
+		cmdutil.NewCommandHandler(CommandName, Records, c.Records),
+		cmdutil.NewCommandHandler(CommandName, Record, c.Record),
+		cmdutil.NewCommandHandler(CommandName, Presentations, c.Presentations),
+		cmdutil.NewCommandHandler(CommandName, Abandon, c.Abandon),
+		cmdutil.NewCommandHandler(CommandName, CredentialSuggestions, c.CredentialSuggestions),
 	}
 }
 
@@ -453,3 +485,209 @@ func (c *Command) DeclinePresentation(rw io.Writer, req io.Reader) command.Error
 
 	return nil
 }
+
+// Records returns the records of the protocol instances matching the filter (state, connection and thread ID).
+func (c *Command) Records(rw io.Writer, req io.Reader) command.Error {
+	var args RecordsArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Records, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	result, err := c.client.Records(&presentproof.RecordFilter{
+		State:    args.State,
+		MyDID:    args.MyDID,
+		TheirDID: args.TheirDID,
+		ThreadID: args.ThreadID,
+	})
+	if err != nil {
+		logutil.LogError(logger, CommandName, Records, err.Error())
+		return command.NewExecuteError(RecordsErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RecordsResponse{
+		Records: result,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, Records, successString)
+
+	return nil
+}
+
+// Record returns the record of the protocol instance.
+func (c *Command) Record(rw io.Writer, req io.Reader) command.Error {
+	var args RecordArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Record, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, Record, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	result, err := c.client.Record(args.PIID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, Record, err.Error())
+		return command.NewExecuteError(RecordErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RecordResponse{
+		Record: result,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, Record, successString)
+
+	return nil
+}
+
+// Presentations returns the presentations attached to the presentation message of the protocol instance.
+func (c *Command) Presentations(rw io.Writer, req io.Reader) command.Error {
+	var args PresentationsArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Presentations, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, Presentations, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	record, err := c.client.Record(args.PIID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, Presentations, err.Error())
+		return command.NewExecuteError(PresentationsErrorCode, err)
+	}
+
+	if record.Presentation == nil {
+		logutil.LogDebug(logger, CommandName, Presentations, errNoPresentation)
+		return command.NewExecuteError(PresentationsErrorCode, errors.New(errNoPresentation))
+	}
+
+	presentations := make([]json.RawMessage, len(record.Presentation.PresentationsAttach))
+
+	for i := range record.Presentation.PresentationsAttach {
+		src, err := record.Presentation.PresentationsAttach[i].Data.Fetch()
+		if err != nil {
+			logutil.LogError(logger, CommandName, Presentations, err.Error())
+			return command.NewExecuteError(PresentationsErrorCode, fmt.Errorf("fetch attachment: %w", err))
+		}
+
+		presentations[i] = toRawJSON(src)
+	}
+
+	command.WriteNillableResponse(rw, &PresentationsResponse{
+		Presentations: presentations,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, Presentations, successString)
+
+	return nil
+}
+
+// Abandon moves a stale protocol instance to the abandoned state, e.g. when the other party stopped responding.
+func (c *Command) Abandon(rw io.Writer, req io.Reader) command.Error {
+	var args AbandonArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Abandon, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, Abandon, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if err := c.client.Abandon(args.PIID, args.Reason); err != nil {
+		logutil.LogError(logger, CommandName, Abandon, err.Error())
+		return command.NewExecuteError(AbandonErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &AbandonResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, Abandon, successString)
+
+	return nil
+}
+
+// CredentialSuggestions is used by the Prover to get the stored credentials satisfying the presentation definition
+// of the pending request presentation, with the claims each candidate credential would disclose.
+func (c *Command) CredentialSuggestions(rw io.Writer, req io.Reader) command.Error {
+	var args CredentialSuggestionsArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, CredentialSuggestions, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, CredentialSuggestions, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if c.suggest == nil {
+		logutil.LogDebug(logger, CommandName, CredentialSuggestions, errSuggestionsNotSupported)
+		return command.NewExecuteError(CredentialSuggestionsErrorCode, errors.New(errSuggestionsNotSupported))
+	}
+
+	request, err := c.pendingRequest(args.PIID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, CredentialSuggestions, err.Error())
+		return command.NewExecuteError(CredentialSuggestionsErrorCode, err)
+	}
+
+	suggestions, err := mdpresentproof.Suggest(c.suggest, request)
+	if err != nil {
+		logutil.LogError(logger, CommandName, CredentialSuggestions, err.Error())
+		return command.NewExecuteError(CredentialSuggestionsErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &CredentialSuggestionsResponse{
+		Suggestions: suggestions,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, CredentialSuggestions, successString)
+
+	return nil
+}
+
+// pendingRequest returns the request presentation of the protocol instance pending an action of the Prover.
+func (c *Command) pendingRequest(piID string) (*protocol.RequestPresentation, error) {
+	actions, err := c.client.Actions()
+	if err != nil {
+		return nil, fmt.Errorf("get actions: %w", err)
+	}
+
+	for _, action := range actions {
+		if action.PIID != piID || action.Msg.Type() != protocol.RequestPresentationMsgType {
+			continue
+		}
+
+		request := &protocol.RequestPresentation{}
+
+		if err := action.Msg.Decode(request); err != nil {
+			return nil, fmt.Errorf("decode request presentation: %w", err)
+		}
+
+		return request, nil
+	}
+
+	return nil, errors.New(errNoPendingRequest)
+}
+
+// toRawJSON returns the JSON content as is and any other content (e.g. a JWT) as a JSON string.
+func toRawJSON(src []byte) json.RawMessage {
+	if json.Valid(src) {
+		return src
+	}
+
+	raw, _ := json.Marshal(string(src)) // nolint:errcheck // a string always marshals
+
+	return raw
+}
@@ -8,6 +8,7 @@ package presentproof
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -17,9 +18,15 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
 	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+	mocksstore "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
 const jsonPayload = `{"piid":"id"}`
@@ -1018,6 +1025,267 @@ func TestCommand_DeclinePresentation(t *testing.T) {
 	})
 }
 
+func TestCommand_Records(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Records(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service.EXPECT().Records(&protocol.RecordFilter{State: "done", TheirDID: "theirDID"}).
+			Return([]protocol.Record{{PIID: "ID1", State: "done", TheirDID: "theirDID"}}, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Records(&b, bytes.NewBufferString(`{"state":"done","their_did":"theirDID"}`)))
+
+		response := RecordsResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Len(t, response.Records, 1)
+		require.Equal(t, "ID1", response.Records[0].PIID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		service.EXPECT().Records(gomock.Any()).Return(nil, errors.New("some error message"))
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Records(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, RecordsErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
+
+func TestCommand_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Record(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty PIID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Record(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyPIID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Record (error)", func(t *testing.T) {
+		service.EXPECT().Record("id").Return(nil, errors.New("some error message"))
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Record(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, RecordErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service.EXPECT().Record("id").Return(&protocol.Record{PIID: "id", State: "abandoned"}, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Record(&b, bytes.NewBufferString(jsonPayload)))
+
+		response := RecordResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, "abandoned", response.Record.State)
+	})
+}
+
+func TestCommand_Presentations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Empty PIID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Presentations(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyPIID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("No presentation", func(t *testing.T) {
+		service.EXPECT().Record("id").Return(&protocol.Record{PIID: "id"}, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Presentations(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errNoPresentation)
+		require.Equal(t, PresentationsErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Fetch attachment (error)", func(t *testing.T) {
+		service.EXPECT().Record("id").Return(&protocol.Record{PIID: "id", Presentation: &protocol.Presentation{
+			PresentationsAttach: []decorator.Attachment{{}},
+		}}, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Presentations(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "fetch attachment")
+		require.Equal(t, PresentationsErrorCode, cmdErr.Code())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service.EXPECT().Record("id").Return(&protocol.Record{PIID: "id", Presentation: &protocol.Presentation{
+			PresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{JSON: map[string]interface{}{"type": "VerifiablePresentation"}}},
+				{Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte("jwt"))}},
+			},
+		}}, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Presentations(&b, bytes.NewBufferString(jsonPayload)))
+
+		response := PresentationsResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Len(t, response.Presentations, 2)
+		require.JSONEq(t, `{"type":"VerifiablePresentation"}`, string(response.Presentations[0]))
+		require.Equal(t, `"jwt"`, string(response.Presentations[1]))
+	})
+}
+
+func TestCommand_Abandon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Empty PIID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Abandon(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyPIID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Abandon (error)", func(t *testing.T) {
+		service.EXPECT().Abandon("id", "").Return(errors.New("some error message"))
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Abandon(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, AbandonErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service.EXPECT().Abandon("id", "stale").Return(nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Abandon(&b, bytes.NewBufferString(`{"piid":"id","reason":"stale"}`)))
+	})
+}
+
 func toProtocolActions(actions []presentproof.Action) []protocol.Action {
 	res := make([]protocol.Action, len(actions))
 	for i, action := range actions {
@@ -1026,3 +1294,146 @@ func toProtocolActions(actions []presentproof.Action) []protocol.Action {
 
 	return res
 }
+
+type suggestProvider struct {
+	*mocks.MockProvider
+	store storeverifiable.Store
+}
+
+func (p *suggestProvider) VerifiableStore() storeverifiable.Store {
+	return p.store
+}
+
+func (p *suggestProvider) VDRegistry() vdrapi.Registry {
+	return nil
+}
+
+func TestCommand_CredentialSuggestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	svc.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	store := mocksstore.NewMockStore(ctrl)
+
+	provider := &suggestProvider{MockProvider: mocks.NewMockProvider(ctrl), store: store}
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil).AnyTimes()
+
+	cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	newAction := func(request *protocol.RequestPresentation) protocol.Action {
+		request.Type = protocol.RequestPresentationMsgType
+
+		return protocol.Action{PIID: "id", Msg: service.NewDIDCommMsgMap(request)}
+	}
+
+	t.Run("Empty PIID", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.CredentialSuggestions(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyPIID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Decode request (error)", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.CredentialSuggestions(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("Not supported by the provider", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+
+		noSuggestCmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := noSuggestCmd.CredentialSuggestions(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.EqualError(t, cmdErr, errSuggestionsNotSupported)
+		require.Equal(t, CredentialSuggestionsErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Actions (error)", func(t *testing.T) {
+		svc.EXPECT().Actions().Return(nil, errors.New("some error"))
+
+		var b bytes.Buffer
+		cmdErr := cmd.CredentialSuggestions(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "get actions: some error")
+		require.Equal(t, CredentialSuggestionsErrorCode, cmdErr.Code())
+	})
+
+	t.Run("No pending request", func(t *testing.T) {
+		svc.EXPECT().Actions().Return([]protocol.Action{
+			{PIID: "other", Msg: newAction(&protocol.RequestPresentation{}).Msg},
+			{PIID: "id", Msg: service.NewDIDCommMsgMap(protocol.Presentation{Type: protocol.PresentationMsgType})},
+		}, nil)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CredentialSuggestions(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.EqualError(t, cmdErr, errNoPendingRequest)
+		require.Equal(t, CredentialSuggestionsErrorCode, cmdErr.Code())
+	})
+
+	t.Run("Suggest (error)", func(t *testing.T) {
+		svc.EXPECT().Actions().Return([]protocol.Action{newAction(&protocol.RequestPresentation{
+			Formats: []protocol.Format{{AttachID: "pd", Format: "dif/presentation-exchange/definitions@v1.0"}},
+		})}, nil)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CredentialSuggestions(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "get attachment by format")
+		require.Equal(t, CredentialSuggestionsErrorCode, cmdErr.Code())
+	})
+
+	t.Run("Success (no presentation definition)", func(t *testing.T) {
+		svc.EXPECT().Actions().Return([]protocol.Action{newAction(&protocol.RequestPresentation{})}, nil)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.CredentialSuggestions(&b, bytes.NewBufferString(jsonPayload)))
+		require.JSONEq(t, `{"suggestions":null}`, b.String())
+	})
+
+	t.Run("Success (no matching credentials)", func(t *testing.T) {
+		svc.EXPECT().Actions().Return([]protocol.Action{newAction(&protocol.RequestPresentation{
+			Formats: []protocol.Format{{AttachID: "pd", Format: "dif/presentation-exchange/definitions@v1.0"}},
+			RequestPresentationsAttach: []decorator.Attachment{{
+				ID: "pd",
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{
+					"presentation_definition": &presexch.PresentationDefinition{
+						ID: "pd",
+						InputDescriptors: []*presexch.InputDescriptor{{
+							ID:     "degree",
+							Schema: []*presexch.Schema{{URI: "http://example.edu/schema"}},
+						}},
+					},
+				}},
+			}},
+		})}, nil)
+		store.EXPECT().GetCredentials().Return(nil, nil)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.CredentialSuggestions(&b, bytes.NewBufferString(jsonPayload)))
+
+		var res CredentialSuggestionsResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.NotNil(t, res.Suggestions)
+		require.Empty(t, res.Suggestions)
+	})
+}
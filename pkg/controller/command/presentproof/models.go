@@ -6,7 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 
 package presentproof
 
-import "github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+)
 
 // DeclinePresentationArgs model
 //
@@ -195,3 +200,97 @@ type AcceptProblemReportArgs struct {
 // Represents a AcceptProblemReport response message.
 //
 type AcceptProblemReportResponse struct{}
+
+// RecordsArgs model
+//
+// This is used for querying the records of the protocol instances, the empty fields match all the records.
+//
+type RecordsArgs struct {
+	// State of the protocol instances (e.g. "request-sent", "done", "abandoned")
+	State string `json:"state"`
+	// MyDID is the DID of the agent in the connection
+	MyDID string `json:"my_did"`
+	// TheirDID is the DID of the other party in the connection
+	TheirDID string `json:"their_did"`
+	// ThreadID of the protocol instances
+	ThreadID string `json:"thread_id"`
+}
+
+// RecordsResponse model
+//
+// Represents Records response message.
+//
+type RecordsResponse struct {
+	Records []presentproof.Record `json:"records"`
+}
+
+// RecordArgs model
+//
+// This is used for getting the record of a protocol instance.
+//
+type RecordArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+}
+
+// RecordResponse model
+//
+// Represents Record response message.
+//
+type RecordResponse struct {
+	Record *presentproof.Record `json:"record"`
+}
+
+// PresentationsArgs model
+//
+// This is used for getting the presentations of a protocol instance.
+//
+type PresentationsArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+}
+
+// PresentationsResponse model
+//
+// Represents Presentations response message.
+//
+type PresentationsResponse struct {
+	// Presentations are the contents of the presentation attachments
+	Presentations []json.RawMessage `json:"presentations"`
+}
+
+// AbandonArgs model
+//
+// This is used for abandoning a stale protocol instance.
+//
+type AbandonArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// Reason why the protocol instance is abandoned
+	Reason string `json:"reason"`
+}
+
+// AbandonResponse model
+//
+// Represents a Abandon response message.
+//
+type AbandonResponse struct{}
+
+// CredentialSuggestionsArgs model
+//
+// This is used for getting the credentials suggested for the pending request presentation.
+//
+type CredentialSuggestionsArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+}
+
+// CredentialSuggestionsResponse model
+//
+// Represents CredentialSuggestions response message.
+//
+type CredentialSuggestionsResponse struct {
+	// Suggestions are the candidate credentials of each input descriptor of the presentation definition,
+	// nil if the request has no presentation definition
+	Suggestions []*presexch.Suggestion `json:"suggestions"`
+}
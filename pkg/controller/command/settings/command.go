@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
+)
+
+var logger = log.New("aries-framework/command/settings")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Settings)
+
+	// GetSettingsErrorCode for get settings error.
+	GetSettingsErrorCode
+
+	// GetSettingErrorCode for get setting error.
+	GetSettingErrorCode
+
+	// SetSettingErrorCode for set setting error.
+	SetSettingErrorCode
+
+	// RemoveSettingErrorCode for remove setting error.
+	RemoveSettingErrorCode
+)
+
+// constants for the settings controller's methods.
+const (
+	// command name.
+	CommandName = "settings"
+
+	// command methods.
+	GetSettingsCommandMethod   = "GetSettings"
+	GetSettingCommandMethod    = "GetSetting"
+	SetSettingCommandMethod    = "SetSetting"
+	RemoveSettingCommandMethod = "RemoveSetting"
+
+	// error messages.
+	errEmptySettingName     = "setting name is mandatory"
+	errEmptySettingValue    = "setting value is mandatory"
+	errSettingsNotSupported = "settings are not supported by this agent"
+	successString           = "success"
+	settingString           = "setting"
+)
+
+// provider contains dependencies for the settings controller command operations
+// and is typically created by using aries.Context().
+type provider interface {
+	Settings() *settings.Store
+}
+
+// Command contains command operations managing the runtime-tunable parameters of the agent.
+type Command struct {
+	ctx provider
+}
+
+// New returns new settings controller command instance.
+func New(ctx provider) *Command {
+	return &Command{ctx: ctx}
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, GetSettingsCommandMethod, c.GetSettings),
+		cmdutil.NewCommandHandler(CommandName, GetSettingCommandMethod, c.GetSetting),
+		cmdutil.NewCommandHandler(CommandName, SetSettingCommandMethod, c.SetSetting),
+		cmdutil.NewCommandHandler(CommandName, RemoveSettingCommandMethod, c.RemoveSetting),
+	}
+}
+
+// GetSettings returns the settings of the agent sorted by name.
+func (c *Command) GetSettings(rw io.Writer, _ io.Reader) command.Error {
+	store := c.ctx.Settings()
+	if store == nil {
+		logutil.LogError(logger, CommandName, GetSettingsCommandMethod, errSettingsNotSupported)
+		return command.NewExecuteError(GetSettingsErrorCode, errors.New(errSettingsNotSupported))
+	}
+
+	all, err := store.All()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetSettingsCommandMethod, err.Error())
+		return command.NewExecuteError(GetSettingsErrorCode, err)
+	}
+
+	if all == nil {
+		all = []*settings.Setting{}
+	}
+
+	command.WriteNillableResponse(rw, &SettingsResult{Settings: all}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetSettingsCommandMethod, successString)
+
+	return nil
+}
+
+// GetSetting returns the setting with the given name.
+func (c *Command) GetSetting(rw io.Writer, req io.Reader) command.Error {
+	var request SettingNameArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetSettingCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, GetSettingCommandMethod, errEmptySettingName)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptySettingName))
+	}
+
+	store := c.ctx.Settings()
+	if store == nil {
+		logutil.LogError(logger, CommandName, GetSettingCommandMethod, errSettingsNotSupported)
+		return command.NewExecuteError(GetSettingErrorCode, errors.New(errSettingsNotSupported))
+	}
+
+	setting, err := store.Get(request.Name)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetSettingCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(settingString, request.Name))
+		return command.NewExecuteError(GetSettingErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &SettingResult{Setting: setting}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetSettingCommandMethod, successString,
+		logutil.CreateKeyValueString(settingString, request.Name))
+
+	return nil
+}
+
+// SetSetting sets the value of the setting, the components consuming it are notified of the change.
+func (c *Command) SetSetting(rw io.Writer, req io.Reader) command.Error {
+	var request SetSettingArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SetSettingCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, SetSettingCommandMethod, errEmptySettingName)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptySettingName))
+	}
+
+	if len(request.Value) == 0 {
+		logutil.LogDebug(logger, CommandName, SetSettingCommandMethod, errEmptySettingValue)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptySettingValue))
+	}
+
+	store := c.ctx.Settings()
+	if store == nil {
+		logutil.LogError(logger, CommandName, SetSettingCommandMethod, errSettingsNotSupported)
+		return command.NewExecuteError(SetSettingErrorCode, errors.New(errSettingsNotSupported))
+	}
+
+	if err = store.Set(request.Name, request.Value); err != nil {
+		logutil.LogError(logger, CommandName, SetSettingCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(settingString, request.Name))
+		return command.NewExecuteError(SetSettingErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, SetSettingCommandMethod, successString,
+		logutil.CreateKeyValueString(settingString, request.Name))
+
+	return nil
+}
+
+// RemoveSetting removes the setting, the static startup option applies again.
+func (c *Command) RemoveSetting(rw io.Writer, req io.Reader) command.Error {
+	var request SettingNameArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RemoveSettingCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, RemoveSettingCommandMethod, errEmptySettingName)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptySettingName))
+	}
+
+	store := c.ctx.Settings()
+	if store == nil {
+		logutil.LogError(logger, CommandName, RemoveSettingCommandMethod, errSettingsNotSupported)
+		return command.NewExecuteError(RemoveSettingErrorCode, errors.New(errSettingsNotSupported))
+	}
+
+	if err = store.Remove(request.Name); err != nil {
+		logutil.LogError(logger, CommandName, RemoveSettingCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(settingString, request.Name))
+		return command.NewExecuteError(RemoveSettingErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RemoveSettingCommandMethod, successString,
+		logutil.CreateKeyValueString(settingString, request.Name))
+
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
+)
+
+type mockProvider struct {
+	settings *settings.Store
+}
+
+func (p *mockProvider) Settings() *settings.Store {
+	return p.settings
+}
+
+func newMockProvider(t *testing.T, storeProvider *mockstore.MockStoreProvider) *mockProvider {
+	t.Helper()
+
+	store, err := settings.New(&mockprovider.Provider{StorageProviderValue: storeProvider})
+	require.NoError(t, err)
+
+	return &mockProvider{settings: store}
+}
+
+func TestNew(t *testing.T) {
+	cmd := New(&mockProvider{})
+	require.NotNil(t, cmd)
+	require.Len(t, cmd.GetHandlers(), 4)
+}
+
+func TestCommand(t *testing.T) {
+	t.Run("test settings - success", func(t *testing.T) {
+		prov := newMockProvider(t, mockstore.NewMockStoreProvider())
+		cmd := New(prov)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GetSettings(&b, nil))
+		require.JSONEq(t, `{"settings":[]}`, b.String())
+
+		b.Reset()
+		require.NoError(t, cmd.SetSetting(&b, bytes.NewBufferString(`{"name":"auto_accept","value":true}`)))
+		require.NoError(t, cmd.SetSetting(&b, bytes.NewBufferString(`{"name":"log_level","value":"debug"}`)))
+		require.True(t, prov.settings.Bool(settings.AutoAccept, false))
+
+		b.Reset()
+		require.NoError(t, cmd.GetSettings(&b, nil))
+
+		var all SettingsResult
+		require.NoError(t, json.Unmarshal(b.Bytes(), &all))
+		require.Len(t, all.Settings, 2)
+		require.Equal(t, settings.AutoAccept, all.Settings[0].Name)
+		require.Equal(t, settings.LogLevel, all.Settings[1].Name)
+
+		b.Reset()
+		require.NoError(t, cmd.GetSetting(&b, bytes.NewBufferString(`{"name":"log_level"}`)))
+
+		var result SettingResult
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+		require.JSONEq(t, `"debug"`, string(result.Setting.Value))
+
+		b.Reset()
+		require.NoError(t, cmd.RemoveSetting(&b, bytes.NewBufferString(`{"name":"log_level"}`)))
+
+		cmdErr := cmd.GetSetting(&b, bytes.NewBufferString(`{"name":"log_level"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetSettingErrorCode, cmdErr.Code())
+		require.EqualError(t, cmdErr, "log_level: "+settings.ErrNotFound.Error())
+	})
+
+	t.Run("test settings - invalid requests", func(t *testing.T) {
+		cmd := New(newMockProvider(t, mockstore.NewMockStoreProvider()))
+
+		var b bytes.Buffer
+
+		for _, cmdErr := range []command.Error{
+			cmd.GetSetting(&b, bytes.NewBufferString("--")),
+			cmd.GetSetting(&b, bytes.NewBufferString(`{}`)),
+			cmd.SetSetting(&b, bytes.NewBufferString("--")),
+			cmd.SetSetting(&b, bytes.NewBufferString(`{"value":true}`)),
+			cmd.SetSetting(&b, bytes.NewBufferString(`{"name":"auto_accept"}`)),
+			cmd.RemoveSetting(&b, bytes.NewBufferString("--")),
+			cmd.RemoveSetting(&b, bytes.NewBufferString(`{}`)),
+		} {
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+		}
+
+		cmdErr := cmd.SetSetting(&b, bytes.NewBufferString(`{"name":"auto_accept","value":"yes"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, SetSettingErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "invalid value of setting auto_accept")
+
+		cmdErr = cmd.RemoveSetting(&b, bytes.NewBufferString(`{"name":"auto_accept"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RemoveSettingErrorCode, cmdErr.Code())
+	})
+
+	t.Run("test settings - not supported", func(t *testing.T) {
+		cmd := New(&mockProvider{})
+
+		var b bytes.Buffer
+
+		for code, cmdErr := range map[command.Code]command.Error{
+			GetSettingsErrorCode:   cmd.GetSettings(&b, nil),
+			GetSettingErrorCode:    cmd.GetSetting(&b, bytes.NewBufferString(`{"name":"auto_accept"}`)),
+			SetSettingErrorCode:    cmd.SetSetting(&b, bytes.NewBufferString(`{"name":"auto_accept","value":true}`)),
+			RemoveSettingErrorCode: cmd.RemoveSetting(&b, bytes.NewBufferString(`{"name":"auto_accept"}`)),
+		} {
+			require.Error(t, cmdErr)
+			require.Equal(t, code, cmdErr.Code())
+			require.Equal(t, command.ExecuteError, cmdErr.Type())
+			require.EqualError(t, cmdErr, errSettingsNotSupported)
+		}
+	})
+
+	t.Run("test settings - store error", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+		cmd := New(newMockProvider(t, storeProvider))
+
+		storeProvider.Store.ErrQuery = errors.New("query error")
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetSettings(&b, nil)
+		require.Error(t, cmdErr)
+		require.Equal(t, GetSettingsErrorCode, cmdErr.Code())
+		require.EqualError(t, cmdErr, "query settings: query error")
+	})
+}
@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package settings
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
+)
+
+// SettingNameArgs model
+//
+// This is used for getting or removing a setting.
+//
+type SettingNameArgs struct {
+	// Name of the setting
+	Name string `json:"name"`
+}
+
+// SetSettingArgs model
+//
+// This is used for setting a runtime-tunable parameter of the agent, e.g. auto_accept (boolean),
+// webhook_urls (array of URLs), log_level (string) or mediator_connections (array of connection IDs).
+//
+type SetSettingArgs struct {
+	// Name of the setting
+	Name string `json:"name"`
+
+	// Value of the setting (JSON)
+	Value json.RawMessage `json:"value"`
+}
+
+// SettingResult model
+//
+// This is used for returning a setting.
+//
+type SettingResult struct {
+	// Setting of the agent
+	Setting *settings.Setting `json:"setting"`
+}
+
+// SettingsResult model
+//
+// This is used for returning the settings of the agent.
+//
+type SettingsResult struct {
+	// Settings of the agent, sorted by name
+	Settings []*settings.Setting `json:"settings"`
+}
@@ -0,0 +1,238 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/command/status")
+
+// constants for the status commands.
+const (
+	// command name.
+	CommandName = "status"
+
+	// command methods.
+	HealthCommandMethod = "Health"
+	StatusCommandMethod = "Status"
+
+	// HealthStatusUp is the status of a live agent.
+	HealthStatusUp = "up"
+
+	// transport states.
+	TransportStateListening = "listening"
+	TransportStateStopped   = "stopped"
+
+	// storage probed by the status command.
+	storageName              = "storage"
+	protocolStateStorageName = "protocolStateStorage"
+	kmsName                  = "kms"
+	probeStoreName           = "status"
+	probeKey                 = "probe"
+)
+
+// provider contains dependencies for the status command and is typically created by using aries.Context().
+type provider interface {
+	InboundTransports() []transport.InboundTransport
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	KMS() kms.KeyManager
+	Service(id string) (interface{}, error)
+}
+
+type mediatorConnections interface {
+	GetConnections() ([]string, error)
+}
+
+type messageQueue interface {
+	QueuedMessageCount() (int, error)
+}
+
+// Command reports the health and the readiness of the agent.
+type Command struct {
+	ctx provider
+}
+
+// New returns new status command instance.
+func New(p provider) *Command {
+	return &Command{ctx: p}
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, HealthCommandMethod, c.Health),
+		cmdutil.NewCommandHandler(CommandName, StatusCommandMethod, c.Status),
+	}
+}
+
+// Health reports the agent is live.
+func (c *Command) Health(rw io.Writer, _ io.Reader) command.Error {
+	command.WriteNillableResponse(rw, &HealthResponse{Status: HealthStatusUp}, logger)
+
+	logutil.LogDebug(logger, CommandName, HealthCommandMethod, "success")
+
+	return nil
+}
+
+// Status reports the state of the transports, storage, KMS, mediator registrations and queued messages.
+func (c *Command) Status(rw io.Writer, _ io.Reader) command.Error {
+	command.WriteNillableResponse(rw, c.Report(), logger)
+
+	logutil.LogDebug(logger, CommandName, StatusCommandMethod, "success")
+
+	return nil
+}
+
+// Report returns the state of the agent components.
+func (c *Command) Report() *StatusResponse {
+	report := &StatusResponse{
+		Transports: c.transports(),
+		Storage: []ComponentStatus{
+			probeStorage(storageName, c.ctx.StorageProvider()),
+			probeStorage(protocolStateStorageName, c.ctx.ProtocolStateStorageProvider()),
+		},
+		KMS:            c.kms(),
+		Mediator:       c.mediator(),
+		QueuedMessages: c.queuedMessages(),
+	}
+
+	report.Ready = report.KMS.Available
+
+	for _, t := range report.Transports {
+		report.Ready = report.Ready && t.State == TransportStateListening
+	}
+
+	for _, s := range report.Storage {
+		report.Ready = report.Ready && s.Available
+	}
+
+	return report
+}
+
+func (c *Command) transports() []TransportStatus {
+	var states []TransportStatus
+
+	for _, inbound := range c.ctx.InboundTransports() {
+		state := TransportStateListening
+
+		// transports not reporting their state are considered listening once the framework is started
+		if l, ok := inbound.(transport.Listener); ok && !l.Listening() {
+			state = TransportStateStopped
+		}
+
+		states = append(states, TransportStatus{Endpoint: inbound.Endpoint(), State: state})
+	}
+
+	return states
+}
+
+func probeStorage(name string, p storage.Provider) ComponentStatus {
+	status := ComponentStatus{Name: name}
+
+	if p == nil {
+		status.Error = "storage provider not configured"
+
+		return status
+	}
+
+	store, err := p.OpenStore(probeStoreName)
+	if err != nil {
+		status.Error = fmt.Sprintf("open store: %s", err)
+
+		return status
+	}
+
+	_, err = store.Get(probeKey)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		status.Error = fmt.Sprintf("get: %s", err)
+
+		return status
+	}
+
+	status.Available = true
+
+	return status
+}
+
+func (c *Command) kms() ComponentStatus {
+	status := ComponentStatus{Name: kmsName}
+
+	if c.ctx.KMS() == nil {
+		status.Error = "kms not configured"
+
+		return status
+	}
+
+	status.Available = true
+
+	return status
+}
+
+func (c *Command) mediator() MediatorStatus {
+	status := MediatorStatus{}
+
+	svc, err := c.ctx.Service(mediator.Coordination)
+	if err != nil {
+		return status
+	}
+
+	m, ok := svc.(mediatorConnections)
+	if !ok {
+		return status
+	}
+
+	connections, err := m.GetConnections()
+	if err != nil {
+		status.Error = fmt.Sprintf("get mediator connections: %s", err)
+
+		return status
+	}
+
+	status.Registered = len(connections) > 0
+	status.ConnectionIDs = connections
+
+	return status
+}
+
+func (c *Command) queuedMessages() QueueStatus {
+	status := QueueStatus{}
+
+	svc, err := c.ctx.Service(messagepickup.MessagePickup)
+	if err != nil {
+		return status
+	}
+
+	q, ok := svc.(messageQueue)
+	if !ok {
+		return status
+	}
+
+	count, err := q.QueuedMessageCount()
+	if err != nil {
+		status.Error = fmt.Sprintf("count queued messages: %s", err)
+
+		return status
+	}
+
+	status.Count = count
+
+	return status
+}
@@ -0,0 +1,173 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNew(t *testing.T) {
+	cmd := New(&mockprovider.Provider{})
+	require.NotNil(t, cmd)
+	require.Len(t, cmd.GetHandlers(), 2)
+}
+
+func TestCommand_Health(t *testing.T) {
+	cmd := New(&mockprovider.Provider{})
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.Health(&b, nil))
+
+	res := HealthResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+	require.Equal(t, HealthStatusUp, res.Status)
+}
+
+func TestCommand_Status(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			InboundTransportsValue: []transport.InboundTransport{
+				&mockInbound{endpoint: "http://localhost:8080", listening: true},
+				&mockInbound{endpoint: "ws://localhost:8081", listening: true},
+			},
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination:       &mockMediator{connections: []string{"conn-1"}},
+				messagepickup.MessagePickup: &mockQueue{count: 3},
+			},
+		})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Status(&b, nil))
+
+		res := StatusResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.True(t, res.Ready)
+		require.Equal(t, []TransportStatus{
+			{Endpoint: "http://localhost:8080", State: TransportStateListening},
+			{Endpoint: "ws://localhost:8081", State: TransportStateListening},
+		}, res.Transports)
+		require.Equal(t, []ComponentStatus{
+			{Name: storageName, Available: true},
+			{Name: protocolStateStorageName, Available: true},
+		}, res.Storage)
+		require.True(t, res.KMS.Available)
+		require.True(t, res.Mediator.Registered)
+		require.Equal(t, []string{"conn-1"}, res.Mediator.ConnectionIDs)
+		require.Equal(t, 3, res.QueuedMessages.Count)
+	})
+
+	t.Run("not ready - transport stopped", func(t *testing.T) {
+		report := New(&mockprovider.Provider{
+			InboundTransportsValue:            []transport.InboundTransport{&mockInbound{endpoint: "http://localhost"}},
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			ServiceErr:                        errors.New("service not found"),
+		}).Report()
+
+		require.False(t, report.Ready)
+		require.Equal(t, TransportStateStopped, report.Transports[0].State)
+		require.False(t, report.Mediator.Registered)
+		require.Zero(t, report.QueuedMessages.Count)
+	})
+
+	t.Run("not ready - storage unavailable", func(t *testing.T) {
+		store := mockstorage.NewMockStoreProvider()
+		store.Store.ErrGet = errors.New("connection refused")
+
+		report := New(&mockprovider.Provider{
+			StorageProviderValue: store,
+			ProtocolStateStorageProviderValue: &mockstorage.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("open error"),
+			},
+			KMSValue: &mockkms.KeyManager{},
+		}).Report()
+
+		require.False(t, report.Ready)
+		require.Contains(t, report.Storage[0].Error, "connection refused")
+		require.Contains(t, report.Storage[1].Error, "open error")
+	})
+
+	t.Run("not ready - missing providers", func(t *testing.T) {
+		report := New(&mockprovider.Provider{}).Report()
+
+		require.False(t, report.Ready)
+		require.False(t, report.KMS.Available)
+		require.Equal(t, "kms not configured", report.KMS.Error)
+		require.Equal(t, "storage provider not configured", report.Storage[0].Error)
+	})
+
+	t.Run("mediator and queue errors", func(t *testing.T) {
+		report := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination:       &mockMediator{err: errors.New("mediator error")},
+				messagepickup.MessagePickup: &mockQueue{err: errors.New("queue error")},
+			},
+		}).Report()
+
+		require.True(t, report.Ready)
+		require.Contains(t, report.Mediator.Error, "mediator error")
+		require.Contains(t, report.QueuedMessages.Error, "queue error")
+	})
+}
+
+type mockInbound struct {
+	endpoint  string
+	listening bool
+}
+
+func (m *mockInbound) Start(transport.Provider) error {
+	return nil
+}
+
+func (m *mockInbound) Stop() error {
+	return nil
+}
+
+func (m *mockInbound) Endpoint() string {
+	return m.endpoint
+}
+
+func (m *mockInbound) Listening() bool {
+	return m.listening
+}
+
+type mockMediator struct {
+	connections []string
+	err         error
+}
+
+func (m *mockMediator) GetConnections() ([]string, error) {
+	return m.connections, m.err
+}
+
+type mockQueue struct {
+	count int
+	err   error
+}
+
+func (m *mockQueue) QueuedMessageCount() (int, error) {
+	return m.count, m.err
+}
@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+// HealthResponse model
+//
+// Represents the liveness of the agent.
+//
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// StatusResponse model
+//
+// Represents the state of the agent components, the agent is ready when its inbound transports are listening
+// and its storage and KMS are available.
+//
+type StatusResponse struct {
+	Ready          bool              `json:"ready"`
+	Transports     []TransportStatus `json:"transports"`
+	Storage        []ComponentStatus `json:"storage"`
+	KMS            ComponentStatus   `json:"kms"`
+	Mediator       MediatorStatus    `json:"mediator"`
+	QueuedMessages QueueStatus       `json:"queuedMessages"`
+}
+
+// TransportStatus model
+//
+// Represents the state of an inbound transport listener.
+//
+type TransportStatus struct {
+	Endpoint string `json:"endpoint"`
+	State    string `json:"state"`
+}
+
+// ComponentStatus model
+//
+// Represents the availability of a component of the agent.
+//
+type ComponentStatus struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+	Error     string `json:"error,omitempty"`
+}
+
+// MediatorStatus model
+//
+// Represents the registrations of the agent with mediators.
+//
+type MediatorStatus struct {
+	Registered    bool     `json:"registered"`
+	ConnectionIDs []string `json:"connectionIDs,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// QueueStatus model
+//
+// Represents the messages queued by the agent for pickup.
+//
+type QueueStatus struct {
+	Count int    `json:"count"`
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,195 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	didcommtransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/ws"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/transport")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Transport)
+
+	// RegisterOutboundErrorCode for register outbound transport error.
+	RegisterOutboundErrorCode
+
+	// UnregisterOutboundErrorCode for unregister outbound transport error.
+	UnregisterOutboundErrorCode
+)
+
+// constants for the transport controller's methods.
+const (
+	// command name.
+	CommandName = "transport"
+
+	// command methods.
+	RegisterOutboundCommandMethod   = "RegisterOutbound"
+	UnregisterOutboundCommandMethod = "UnregisterOutbound"
+
+	// outbound transport schemes.
+	HTTPScheme      = "http"
+	WebsocketScheme = "ws"
+
+	// error messages.
+	errEmptyScheme       = "scheme is mandatory"
+	errTransportReadOnly = "outbound transports cannot be changed on this agent"
+
+	// log constants.
+	schemeString = "scheme"
+)
+
+// provider contains dependencies for the transport controller command operations
+// and is typically created by using aries.Context().
+type provider interface {
+	InboundMessageHandler() didcommtransport.InboundMessageHandler
+	Packager() didcommtransport.Packager
+	AriesFrameworkID() string
+	OutboundTransportRegistry() *didcommtransport.OutboundRegistry
+}
+
+// Command contains command operations adding and removing the transports of the running agent.
+type Command struct {
+	ctx provider
+}
+
+// New returns new transport controller command instance.
+func New(ctx provider) *Command {
+	return &Command{ctx: ctx}
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, RegisterOutboundCommandMethod, c.RegisterOutbound),
+		cmdutil.NewCommandHandler(CommandName, UnregisterOutboundCommandMethod, c.UnregisterOutbound),
+	}
+}
+
+// RegisterOutbound starts an outbound transport of the given scheme and registers it on the running agent.
+func (c *Command) RegisterOutbound(rw io.Writer, req io.Reader) command.Error {
+	var request OutboundArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RegisterOutboundCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Scheme == "" {
+		logutil.LogDebug(logger, CommandName, RegisterOutboundCommandMethod, errEmptyScheme)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyScheme))
+	}
+
+	registry := c.ctx.OutboundTransportRegistry()
+	if registry == nil {
+		logutil.LogError(logger, CommandName, RegisterOutboundCommandMethod, errTransportReadOnly)
+		return command.NewExecuteError(RegisterOutboundErrorCode, fmt.Errorf(errTransportReadOnly))
+	}
+
+	outbound, err := newOutbound(request.Scheme)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RegisterOutboundCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	err = outbound.Start(c.ctx)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RegisterOutboundCommandMethod, "start transport: "+err.Error(),
+			logutil.CreateKeyValueString(schemeString, request.Scheme))
+
+		return command.NewExecuteError(RegisterOutboundErrorCode, fmt.Errorf("start transport: %w", err))
+	}
+
+	registry.Add(outbound)
+
+	logutil.LogDebug(logger, CommandName, RegisterOutboundCommandMethod, "success",
+		logutil.CreateKeyValueString(schemeString, request.Scheme))
+
+	return nil
+}
+
+// UnregisterOutbound removes the outbound transports of the given scheme from the running agent and closes their
+// connections.
+func (c *Command) UnregisterOutbound(rw io.Writer, req io.Reader) command.Error {
+	var request OutboundArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UnregisterOutboundCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Scheme == "" {
+		logutil.LogDebug(logger, CommandName, UnregisterOutboundCommandMethod, errEmptyScheme)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyScheme))
+	}
+
+	registry := c.ctx.OutboundTransportRegistry()
+	if registry == nil {
+		logutil.LogError(logger, CommandName, UnregisterOutboundCommandMethod, errTransportReadOnly)
+		return command.NewExecuteError(UnregisterOutboundErrorCode, fmt.Errorf(errTransportReadOnly))
+	}
+
+	removed := registry.Remove(request.Scheme)
+	if len(removed) == 0 {
+		err = fmt.Errorf("no outbound transport registered for scheme [%s]", request.Scheme)
+
+		logutil.LogInfo(logger, CommandName, UnregisterOutboundCommandMethod, err.Error())
+
+		return command.NewExecuteError(UnregisterOutboundErrorCode, err)
+	}
+
+	for _, outbound := range removed {
+		s, ok := outbound.(didcommtransport.Shutdowner)
+		if !ok {
+			continue
+		}
+
+		if err = s.Shutdown(context.Background()); err != nil {
+			logutil.LogError(logger, CommandName, UnregisterOutboundCommandMethod, "shutdown transport: "+err.Error(),
+				logutil.CreateKeyValueString(schemeString, request.Scheme))
+
+			return command.NewExecuteError(UnregisterOutboundErrorCode, fmt.Errorf("shutdown transport: %w", err))
+		}
+	}
+
+	logutil.LogDebug(logger, CommandName, UnregisterOutboundCommandMethod, "success",
+		logutil.CreateKeyValueString(schemeString, request.Scheme))
+
+	return nil
+}
+
+func newOutbound(scheme string) (didcommtransport.OutboundTransport, error) {
+	switch scheme {
+	case HTTPScheme:
+		outbound, err := arieshttp.NewOutbound(arieshttp.WithOutboundHTTPClient(&http.Client{}))
+		if err != nil {
+			return nil, fmt.Errorf("http outbound transport initialization failed: %w", err)
+		}
+
+		return outbound, nil
+	case WebsocketScheme:
+		return ws.NewOutbound(), nil
+	default:
+		return nil, fmt.Errorf("outbound transport [%s] not supported", scheme)
+	}
+}
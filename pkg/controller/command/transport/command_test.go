@@ -0,0 +1,134 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	didcommtransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
+)
+
+type mockProvider struct {
+	registry *didcommtransport.OutboundRegistry
+}
+
+func (p *mockProvider) InboundMessageHandler() didcommtransport.InboundMessageHandler {
+	return func(*didcommtransport.Envelope) error { return nil }
+}
+
+func (p *mockProvider) Packager() didcommtransport.Packager {
+	return nil
+}
+
+func (p *mockProvider) AriesFrameworkID() string {
+	return "aries"
+}
+
+func (p *mockProvider) OutboundTransportRegistry() *didcommtransport.OutboundRegistry {
+	return p.registry
+}
+
+func TestNew(t *testing.T) {
+	cmd := New(&mockProvider{})
+	require.NotNil(t, cmd)
+	require.Len(t, cmd.GetHandlers(), 2)
+}
+
+func TestCommand_RegisterOutbound(t *testing.T) {
+	t.Run("test register and unregister outbound transports - success", func(t *testing.T) {
+		registry := didcommtransport.NewOutboundRegistry()
+		cmd := New(&mockProvider{registry: registry})
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterOutbound(&b, bytes.NewBufferString(`{"scheme":"http"}`))
+		require.NoError(t, cmdErr)
+
+		cmdErr = cmd.RegisterOutbound(&b, bytes.NewBufferString(`{"scheme":"ws"}`))
+		require.NoError(t, cmdErr)
+
+		require.Len(t, registry.Transports(), 2)
+		require.True(t, registry.Transports()[0].Accept("http://localhost:8080"))
+		require.True(t, registry.Transports()[1].Accept("ws://localhost:8080"))
+
+		cmdErr = cmd.UnregisterOutbound(&b, bytes.NewBufferString(`{"scheme":"ws"}`))
+		require.NoError(t, cmdErr)
+
+		require.Len(t, registry.Transports(), 1)
+		require.True(t, registry.Transports()[0].Accept("http://localhost:8080"))
+
+		cmdErr = cmd.UnregisterOutbound(&b, bytes.NewBufferString(`{"scheme":"ws"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, UnregisterOutboundErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "no outbound transport registered for scheme [ws]")
+	})
+
+	t.Run("test register outbound transport - invalid requests", func(t *testing.T) {
+		cmd := New(&mockProvider{registry: didcommtransport.NewOutboundRegistry()})
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterOutbound(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "request decode")
+
+		cmdErr = cmd.RegisterOutbound(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyScheme)
+
+		cmdErr = cmd.RegisterOutbound(&b, bytes.NewBufferString(`{"scheme":"wss"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "outbound transport [wss] not supported")
+
+		cmdErr = cmd.UnregisterOutbound(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "request decode")
+
+		cmdErr = cmd.UnregisterOutbound(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyScheme)
+	})
+
+	t.Run("test register outbound transport - no registry", func(t *testing.T) {
+		cmd := New(&mockProvider{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterOutbound(&b, bytes.NewBufferString(`{"scheme":"http"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RegisterOutboundErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errTransportReadOnly)
+
+		cmdErr = cmd.UnregisterOutbound(&b, bytes.NewBufferString(`{"scheme":"http"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, UnregisterOutboundErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errTransportReadOnly)
+	})
+
+	t.Run("test unregister outbound transport - transports without shutdown", func(t *testing.T) {
+		registry := didcommtransport.NewOutboundRegistry(&mockdidcomm.MockOutboundTransport{AcceptValue: true})
+		cmd := New(&mockProvider{registry: registry})
+
+		var b bytes.Buffer
+		cmdErr := cmd.UnregisterOutbound(&b, bytes.NewBufferString(`{"scheme":"http"}`))
+		require.NoError(t, cmdErr)
+		require.Empty(t, registry.Transports())
+	})
+}
+
+func TestNewOutbound(t *testing.T) {
+	for _, scheme := range []string{HTTPScheme, WebsocketScheme} {
+		outbound, err := newOutbound(scheme)
+		require.NoError(t, err, fmt.Sprintf("scheme %s", scheme))
+		require.True(t, outbound.Accept(scheme+"://localhost:8080"))
+	}
+}
@@ -0,0 +1,16 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+// OutboundArgs model
+//
+// This is used for registering and unregistering the outbound transports of a scheme.
+//
+type OutboundArgs struct {
+	// Scheme of the outbound transport, supported values [http] [ws]
+	Scheme string `json:"scheme"`
+}
@@ -18,6 +18,7 @@ import (
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 	storage "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -39,6 +40,12 @@ const (
 
 	// CreateDIDErrorCode for create did error.
 	CreateDIDErrorCode
+
+	// RegisterResolverErrorCode for register resolver error.
+	RegisterResolverErrorCode
+
+	// UnregisterResolverErrorCode for unregister resolver error.
+	UnregisterResolverErrorCode
 )
 
 // constants for the VDR controller's methods.
@@ -53,13 +60,19 @@ const (
 	ResolveDIDCommandMethod = "ResolveDID"
 	CreateDIDCommandMethod  = "CreateDID"
 
+	RegisterResolverCommandMethod   = "RegisterResolver"
+	UnregisterResolverCommandMethod = "UnregisterResolver"
+
 	// error messages.
-	errEmptyDIDName   = "name is mandatory"
-	errEmptyDIDID     = "did is mandatory"
-	errEmptyDIDMETHOD = "did method is mandatory"
+	errEmptyDIDName      = "name is mandatory"
+	errEmptyDIDID        = "did is mandatory"
+	errEmptyDIDMETHOD    = "did method is mandatory"
+	errEmptyResolverURL  = "resolver url is mandatory"
+	errResolversReadOnly = "vdr registry does not support the registration of resolvers"
 
 	// log constants.
-	didID = "did"
+	didID     = "did"
+	didMethod = "method"
 )
 
 // provider contains dependencies for the vdr controller command operations
@@ -69,6 +82,12 @@ type provider interface {
 	StorageProvider() storage.Provider
 }
 
+// resolverRegistry is implemented by the VDR registries supporting the registration of resolvers at runtime.
+type resolverRegistry interface {
+	AddVDR(method vdrapi.VDR)
+	RemoveVDR(didMethod string) error
+}
+
 // Command contains command operations provided by vdr controller.
 type Command struct {
 	ctx      provider
@@ -96,6 +115,8 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetDIDsCommandMethod, o.GetDIDRecords),
 		cmdutil.NewCommandHandler(CommandName, ResolveDIDCommandMethod, o.ResolveDID),
 		cmdutil.NewCommandHandler(CommandName, CreateDIDCommandMethod, o.CreateDID),
+		cmdutil.NewCommandHandler(CommandName, RegisterResolverCommandMethod, o.RegisterResolver),
+		cmdutil.NewCommandHandler(CommandName, UnregisterResolverCommandMethod, o.UnregisterResolver),
 	}
 }
 
@@ -284,3 +305,82 @@ func (o *Command) GetDIDRecords(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// RegisterResolver registers an HTTP binding resolver for a DID method on the running agent, the resolver takes
+// precedence over the resolvers already registered for the DID method.
+func (o *Command) RegisterResolver(rw io.Writer, req io.Reader) command.Error {
+	var request ResolverArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RegisterResolverCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Method == "" {
+		logutil.LogDebug(logger, CommandName, RegisterResolverCommandMethod, errEmptyDIDMETHOD)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDMETHOD))
+	}
+
+	if request.URL == "" {
+		logutil.LogDebug(logger, CommandName, RegisterResolverCommandMethod, errEmptyResolverURL)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyResolverURL))
+	}
+
+	registry, ok := o.ctx.VDRegistry().(resolverRegistry)
+	if !ok {
+		logutil.LogError(logger, CommandName, RegisterResolverCommandMethod, errResolversReadOnly)
+		return command.NewExecuteError(RegisterResolverErrorCode, fmt.Errorf(errResolversReadOnly))
+	}
+
+	method := request.Method
+
+	resolver, err := httpbinding.New(request.URL,
+		httpbinding.WithAccept(func(m string) bool { return m == method }))
+	if err != nil {
+		logutil.LogError(logger, CommandName, RegisterResolverCommandMethod, "create resolver: "+err.Error())
+		return command.NewValidationError(RegisterResolverErrorCode, fmt.Errorf("create resolver: %w", err))
+	}
+
+	registry.AddVDR(resolver)
+
+	logutil.LogDebug(logger, CommandName, RegisterResolverCommandMethod, "success",
+		logutil.CreateKeyValueString(didMethod, method))
+
+	return nil
+}
+
+// UnregisterResolver removes the resolvers of a DID method from the running agent.
+func (o *Command) UnregisterResolver(rw io.Writer, req io.Reader) command.Error {
+	var request ResolverMethodArg
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UnregisterResolverCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Method == "" {
+		logutil.LogDebug(logger, CommandName, UnregisterResolverCommandMethod, errEmptyDIDMETHOD)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDMETHOD))
+	}
+
+	registry, ok := o.ctx.VDRegistry().(resolverRegistry)
+	if !ok {
+		logutil.LogError(logger, CommandName, UnregisterResolverCommandMethod, errResolversReadOnly)
+		return command.NewExecuteError(UnregisterResolverErrorCode, fmt.Errorf(errResolversReadOnly))
+	}
+
+	err = registry.RemoveVDR(request.Method)
+	if err != nil {
+		logutil.LogError(logger, CommandName, UnregisterResolverCommandMethod, "remove resolver: "+err.Error(),
+			logutil.CreateKeyValueString(didMethod, request.Method))
+
+		return command.NewExecuteError(UnregisterResolverErrorCode, fmt.Errorf("remove resolver: %w", err))
+	}
+
+	logutil.LogDebug(logger, CommandName, UnregisterResolverCommandMethod, "success",
+		logutil.CreateKeyValueString(didMethod, request.Method))
+
+	return nil
+}
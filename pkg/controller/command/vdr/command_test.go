@@ -19,6 +19,7 @@ import (
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	vdrregistry "github.com/hyperledger/aries-framework-go/pkg/vdr"
 )
 
 const sampleDIDName = "sampleDIDName"
@@ -52,7 +53,7 @@ func TestNew(t *testing.T) {
 		require.NoError(t, err)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 5, len(handlers))
+		require.Equal(t, 7, len(handlers))
 	})
 
 	t.Run("test new command - did store error", func(t *testing.T) {
@@ -439,3 +440,84 @@ func TestGetDIDRecords(t *testing.T) {
 		require.Equal(t, 1, len(response.Result))
 	})
 }
+
+func TestRegisterResolver(t *testing.T) {
+	t.Run("test register and unregister resolver - success", func(t *testing.T) {
+		registry := vdrregistry.New()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue:      registry,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterResolver(&b, bytes.NewBufferString(`{"method":"example","url":"http://localhost:8080"}`))
+		require.NoError(t, cmdErr)
+
+		cmdErr = cmd.UnregisterResolver(&b, bytes.NewBufferString(`{"method":"other"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, UnregisterResolverErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "did method other not supported for vdr")
+
+		cmdErr = cmd.UnregisterResolver(&b, bytes.NewBufferString(`{"method":"example"}`))
+		require.NoError(t, cmdErr)
+
+		_, err = registry.Resolve("did:example:123")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did method example not supported for vdr")
+	})
+
+	t.Run("test register resolver - invalid requests", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue:      vdrregistry.New(),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterResolver(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "request decode")
+
+		cmdErr = cmd.RegisterResolver(&b, bytes.NewBufferString(`{"url":"http://localhost:8080"}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "did method is mandatory")
+
+		cmdErr = cmd.RegisterResolver(&b, bytes.NewBufferString(`{"method":"example"}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "resolver url is mandatory")
+
+		cmdErr = cmd.RegisterResolver(&b, bytes.NewBufferString(`{"method":"example","url":"@h"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RegisterResolverErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "create resolver")
+
+		cmdErr = cmd.UnregisterResolver(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "request decode")
+
+		cmdErr = cmd.UnregisterResolver(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "did method is mandatory")
+	})
+
+	t.Run("test register resolver - registry not supporting resolvers", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue:      &mockvdr.MockVDRegistry{},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterResolver(&b, bytes.NewBufferString(`{"method":"example","url":"http://localhost:8080"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RegisterResolverErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errResolversReadOnly)
+
+		cmdErr = cmd.UnregisterResolver(&b, bytes.NewBufferString(`{"method":"example"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, UnregisterResolverErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errResolversReadOnly)
+	})
+}
@@ -53,3 +53,24 @@ type CreateDIDRequest struct {
 	DID    json.RawMessage        `json:"did,omitempty"`
 	Opts   map[string]interface{} `json:"opts,omitempty"`
 }
+
+// ResolverArgs model
+//
+// This is used for registering an HTTP binding resolver for a DID method.
+//
+type ResolverArgs struct {
+	// DID method resolved
+	Method string `json:"method"`
+
+	// URL of the resolver
+	URL string `json:"url"`
+}
+
+// ResolverMethodArg model
+//
+// This is used for unregistering the resolvers of a DID method.
+//
+type ResolverMethodArg struct {
+	// DID method
+	Method string `json:"method"`
+}
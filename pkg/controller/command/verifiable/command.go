@@ -11,16 +11,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/client/vcrefresh"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	ariescrypto "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	jld "github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	verifiablesigner "github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
@@ -28,11 +31,14 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmssigner"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/audit"
 	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/keylink"
 	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -85,6 +91,24 @@ const (
 
 	// DeriveCredentialErrorCode for derive credential error.
 	DeriveCredentialErrorCode
+
+	// RefreshCredentialErrorCode for refresh credential error.
+	RefreshCredentialErrorCode
+
+	// VerifyCredentialErrorCode for verify credential error.
+	VerifyCredentialErrorCode
+
+	// UpdateCredentialNameErrorCode for rename vc error.
+	UpdateCredentialNameErrorCode
+
+	// UpdateCredentialTagsErrorCode for add or remove vc tags error.
+	UpdateCredentialTagsErrorCode
+
+	// CredentialCollectionErrorCode for vc collection errors.
+	CredentialCollectionErrorCode
+
+	// RotateEncryptionKeyErrorCode for verifiable store encryption key rotation error.
+	RotateEncryptionKeyErrorCode
 )
 
 // constants for the Verifiable protocol.
@@ -106,6 +130,19 @@ const (
 	GeneratePresentationByIDCommandMethod = "GeneratePresentationByID"
 	RemoveCredentialByNameCommandMethod   = "RemoveCredentialByName"
 	RemovePresentationByNameCommandMethod = "RemovePresentationByName"
+	RefreshCredentialCommandMethod        = "RefreshCredential"
+	VerifyCredentialCommandMethod         = "VerifyCredential"
+	UpdateCredentialNameCommandMethod     = "UpdateCredentialName"
+	AddCredentialTagsCommandMethod        = "AddCredentialTags"
+	RemoveCredentialTagsCommandMethod     = "RemoveCredentialTags"
+
+	CreateCollectionCommandMethod           = "CreateCollection"
+	GetCollectionsCommandMethod             = "GetCollections"
+	RemoveCollectionCommandMethod           = "RemoveCollection"
+	MoveCredentialCommandMethod             = "MoveCredential"
+	GetCredentialsByCollectionCommandMethod = "GetCredentialsByCollection"
+
+	RotateEncryptionKeyCommandMethod = "RotateEncryptionKey"
 
 	// error messages.
 	errEmptyCredentialName   = "credential name is mandatory"
@@ -115,13 +152,27 @@ const (
 	errEmptyDID              = "did is mandatory"
 	errEmptyCredential       = "credential is mandatory is mandatory"
 	errEmptyFrame            = "frame is mandatory is mandatory"
+	errEmptyNewName          = "new credential name is mandatory"
+	errEmptyTags             = "tags are mandatory"
+	errEmptyCollection       = "collection name is mandatory"
 
 	// log constants.
-	vcID   = "vcID"
-	vcName = "vcName"
-	vpID   = "vpID"
-
-	creatorParts = 2
+	vcID         = "vcID"
+	vcName       = "vcName"
+	vpID         = "vpID"
+	vcCollection = "vcCollection"
+	keyID        = "keyID"
+
+	// audit event details.
+	auditVerified    = "verified"
+	auditError       = "error"
+	auditIssuer      = "issuer"
+	auditName        = "name"
+	auditCredentials = "credentials"
+	auditNewName     = "newName"
+	auditTagsAdded   = "tagsAdded"
+	auditTagsRemoved = "tagsRemoved"
+	auditCollection  = "collection"
 
 	// Ed25519Signature2018 ed25519 signature suite.
 	Ed25519Signature2018 = "Ed25519Signature2018"
@@ -151,58 +202,25 @@ type keyResolver interface {
 	PublicKeyFetcher() verifiable.PublicKeyFetcher
 }
 
-type kmsSigner struct {
-	keyHandle interface{}
-	crypto    ariescrypto.Crypto
-	bbs       bool
+// keyLinkProvider is optionally implemented by the provider to look up KMS keys of the agent's verification methods.
+type keyLinkProvider interface {
+	KeyLinkStore() *keylink.Store
 }
 
-func getKID(opts *ProofOptions) string {
-	if opts.KID != "" {
-		return opts.KID
-	}
-
-	idSplit := strings.Split(opts.VerificationMethod, "#")
-	if len(idSplit) == creatorParts {
-		return idSplit[1]
-	}
-
-	return ""
-}
-
-func newKMSSigner(keyManager kms.KeyManager, c ariescrypto.Crypto, kid string) (*kmsSigner, error) {
-	keyHandler, err := keyManager.Get(kid)
-	if err != nil {
-		return nil, err
-	}
-
-	return &kmsSigner{keyHandle: keyHandler, crypto: c}, nil
+// verifiableStoreProvider is optionally implemented by the provider to share its verifiable store, which may be
+// encrypted at rest.
+type verifiableStoreProvider interface {
+	VerifiableStore() verifiablestore.Store
 }
 
-func (s *kmsSigner) textToLines(txt string) [][]byte {
-	lines := strings.Split(txt, "\n")
-	linesBytes := make([][]byte, 0, len(lines))
-
-	for i := range lines {
-		if strings.TrimSpace(lines[i]) != "" {
-			linesBytes = append(linesBytes, []byte(lines[i]))
-		}
-	}
-
-	return linesBytes
+// encryptionKeyRotator is implemented by the verifiable stores encrypted at rest.
+type encryptionKeyRotator interface {
+	RotateEncryptionKey() (string, error)
 }
 
-func (s *kmsSigner) Sign(data []byte) ([]byte, error) {
-	if s.bbs {
-		return s.crypto.SignMulti(s.textToLines(string(data)), s.keyHandle)
-	}
-
-	v, err := s.crypto.Sign(data, s.keyHandle)
-	if err != nil {
-		return nil, err
-	}
-
-	return v, nil
+// auditLogProvider is optionally implemented by the provider to record the credential operations in an audit log.
+type auditLogProvider interface {
+	AuditLog() *audit.Log
 }
 
 // provider contains dependencies for the verifiable command and is typically created by using aries.Context().
@@ -220,11 +238,13 @@ type Command struct {
 	resolver        keyResolver
 	ctx             provider
 	docLoader       ld.DocumentLoader
+	refresher       *vcrefresh.Client
+	auditLog        *audit.Log
 }
 
 // New returns new verifiable credential controller command instance.
 func New(p provider) (*Command, error) {
-	verifiableStore, err := verifiablestore.New(p)
+	verifiableStore, err := newVerifiableStore(p)
 	if err != nil {
 		return nil, fmt.Errorf("new vc store : %w", err)
 	}
@@ -245,19 +265,41 @@ func New(p provider) (*Command, error) {
 		presExchDoc,
 	)
 
-	return &Command{
+	refresher, err := vcrefresh.New(p, vcrefresh.WithJSONLDDocumentLoader(docLoader))
+	if err != nil {
+		return nil, fmt.Errorf("new vc refresh client : %w", err)
+	}
+
+	cmd := &Command{
 		verifiableStore: verifiableStore,
 		didStore:        didStore,
 		resolver:        verifiable.NewVDRKeyResolver(p.VDRegistry()),
 		ctx:             p,
 		docLoader:       docLoader,
-	}, nil
+		refresher:       refresher,
+	}
+
+	if ap, ok := p.(auditLogProvider); ok {
+		cmd.auditLog = ap.AuditLog()
+	}
+
+	return cmd, nil
+}
+
+// newVerifiableStore returns the verifiable store of the provider if any, a new unencrypted store otherwise.
+func newVerifiableStore(p provider) (verifiablestore.Store, error) {
+	if sp, ok := p.(verifiableStoreProvider); ok && sp.VerifiableStore() != nil {
+		return sp.VerifiableStore(), nil
+	}
+
+	return verifiablestore.New(p)
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
 func (o *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, ValidateCredentialCommandMethod, o.ValidateCredential),
+		cmdutil.NewCommandHandler(CommandName, VerifyCredentialCommandMethod, o.VerifyCredential),
 		cmdutil.NewCommandHandler(CommandName, SaveCredentialCommandMethod, o.SaveCredential),
 		cmdutil.NewCommandHandler(CommandName, GetCredentialCommandMethod, o.GetCredential),
 		cmdutil.NewCommandHandler(CommandName, GetCredentialByNameCommandMethod, o.GetCredentialByName),
@@ -271,6 +313,16 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetPresentationsCommandMethod, o.GetPresentations),
 		cmdutil.NewCommandHandler(CommandName, RemoveCredentialByNameCommandMethod, o.RemoveCredentialByName),
 		cmdutil.NewCommandHandler(CommandName, RemovePresentationByNameCommandMethod, o.RemovePresentationByName),
+		cmdutil.NewCommandHandler(CommandName, RefreshCredentialCommandMethod, o.RefreshCredential),
+		cmdutil.NewCommandHandler(CommandName, UpdateCredentialNameCommandMethod, o.UpdateCredentialName),
+		cmdutil.NewCommandHandler(CommandName, AddCredentialTagsCommandMethod, o.AddCredentialTags),
+		cmdutil.NewCommandHandler(CommandName, RemoveCredentialTagsCommandMethod, o.RemoveCredentialTags),
+		cmdutil.NewCommandHandler(CommandName, CreateCollectionCommandMethod, o.CreateCollection),
+		cmdutil.NewCommandHandler(CommandName, GetCollectionsCommandMethod, o.GetCollections),
+		cmdutil.NewCommandHandler(CommandName, RemoveCollectionCommandMethod, o.RemoveCollection),
+		cmdutil.NewCommandHandler(CommandName, MoveCredentialCommandMethod, o.MoveCredential),
+		cmdutil.NewCommandHandler(CommandName, GetCredentialsByCollectionCommandMethod, o.GetCredentialsByCollection),
+		cmdutil.NewCommandHandler(CommandName, RotateEncryptionKeyCommandMethod, o.RotateEncryptionKey),
 	}
 }
 
@@ -285,16 +337,34 @@ func (o *Command) ValidateCredential(rw io.Writer, req io.Reader) command.Error
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
 	}
 
+	var opts []verifiable.CredentialOpt
+
+	// the proof of a VC-JWT is its JWS, which can't be left unchecked
+	if jwt.IsJWS(request.VerifiableCredential) {
+		opts = append(opts, verifiable.WithPublicKeyFetcher(o.resolver.PublicKeyFetcher()))
+	}
+
 	// we are only validating the VerifiableCredential here, hence ignoring other return values
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/1316 VC Validate Command - Add keys for proof
 	//  verification as options to the function.
-	_, err = verifiable.ParseCredential([]byte(request.VerifiableCredential))
+	vc, err := verifiable.ParseCredential([]byte(request.VerifiableCredential), opts...)
 	if err != nil {
 		logutil.LogInfo(logger, CommandName, ValidateCredentialCommandMethod, "validate vc : "+err.Error())
 
+		o.recordEvent(&audit.Event{
+			Type:    audit.EventVerified,
+			Details: map[string]string{auditVerified: "false", auditError: err.Error()},
+		})
+
 		return command.NewValidationError(ValidateCredentialErrorCode, fmt.Errorf("validate vc : %w", err))
 	}
 
+	o.recordEvent(&audit.Event{
+		Type:     audit.EventVerified,
+		ObjectID: vc.ID,
+		Details:  map[string]string{auditVerified: "true", auditIssuer: vc.Issuer.ID},
+	})
+
 	command.WriteNillableResponse(rw, nil, logger)
 
 	logutil.LogDebug(logger, CommandName, ValidateCredentialCommandMethod, "success")
@@ -302,6 +372,54 @@ func (o *Command) ValidateCredential(rw io.Writer, req io.Reader) command.Error
 	return nil
 }
 
+// VerifyCredential verifies the verifiable credential and returns the report with the outcome of each check
+// (signature, issuance window, status, schema and issuer trust).
+func (o *Command) VerifyCredential(rw io.Writer, req io.Reader) command.Error {
+	request := &Credential{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	result, err := verifiable.VerifyCredential([]byte(request.VerifiableCredential),
+		verifiable.WithPublicKeyFetcher(o.resolver.PublicKeyFetcher()),
+		verifiable.WithJSONLDDocumentLoader(o.docLoader))
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "verify vc : "+err.Error())
+
+		o.recordEvent(&audit.Event{
+			Type:    audit.EventVerified,
+			Details: map[string]string{auditVerified: "false", auditError: err.Error()},
+		})
+
+		return command.NewValidationError(VerifyCredentialErrorCode, fmt.Errorf("verify vc : %w", err))
+	}
+
+	event := &audit.Event{
+		Type:    audit.EventVerified,
+		Details: map[string]string{auditVerified: strconv.FormatBool(result.Verified)},
+	}
+
+	if result.Credential != nil {
+		event.ObjectID = result.Credential.ID
+		event.Details[auditIssuer] = result.Credential.Issuer.ID
+	}
+
+	o.recordEvent(event)
+
+	command.WriteNillableResponse(rw, &VerifyCredentialResponse{
+		Verified: result.Verified,
+		Checks:   result.Checks,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyCredentialCommandMethod, "success")
+
+	return nil
+}
+
 // SaveCredential saves the verifiable credential to the store.
 func (o *Command) SaveCredential(rw io.Writer, req io.Reader) command.Error {
 	request := &CredentialExt{}
@@ -318,7 +436,8 @@ func (o *Command) SaveCredential(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(SaveCredentialErrorCode, fmt.Errorf(errEmptyCredentialName))
 	}
 
-	vc, err := verifiable.ParseCredential([]byte(request.VerifiableCredential), verifiable.WithDisabledProofCheck())
+	vc, err := verifiable.ParseCredential([]byte(request.VerifiableCredential), verifiable.WithDisabledProofCheck(),
+		verifiable.WithOriginalJWT())
 	if err != nil {
 		logutil.LogError(logger, CommandName, SaveCredentialCommandMethod, "parse vc : "+err.Error())
 
@@ -332,6 +451,12 @@ func (o *Command) SaveCredential(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(SaveCredentialErrorCode, fmt.Errorf("save vc : %w", err))
 	}
 
+	o.recordEvent(&audit.Event{
+		Type:     audit.EventStored,
+		ObjectID: vc.ID,
+		Details:  map[string]string{auditName: request.Name, auditIssuer: vc.Issuer.ID},
+	})
+
 	command.WriteNillableResponse(rw, nil, logger)
 
 	logutil.LogDebug(logger, CommandName, SaveCredentialCommandMethod, "success")
@@ -370,6 +495,13 @@ func (o *Command) SavePresentation(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(SavePresentationErrorCode, fmt.Errorf("save vp : %w", err))
 	}
 
+	o.recordEvent(&audit.Event{
+		Type:     audit.EventStored,
+		ActorDID: vp.Holder,
+		ObjectID: vp.ID,
+		Details:  map[string]string{auditName: request.Name},
+	})
+
 	command.WriteNillableResponse(rw, nil, logger)
 
 	logutil.LogDebug(logger, CommandName, SavePresentationCommandMethod, "success")
@@ -400,7 +532,7 @@ func (o *Command) GetCredential(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(GetCredentialErrorCode, fmt.Errorf("get vc : %w", err))
 	}
 
-	vcBytes, err := vc.MarshalJSON()
+	vcBytes, err := vc.MarshalOriginal()
 	if err != nil {
 		logutil.LogError(logger, CommandName, GetCredentialCommandMethod, "marshal vc : "+err.Error(),
 			logutil.CreateKeyValueString(vcID, request.ID))
@@ -467,6 +599,8 @@ func (o *Command) SignCredential(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(SignCredentialErrorCode, fmt.Errorf("marshal credential : %w", err))
 	}
 
+	o.recordEvent(&audit.Event{Type: audit.EventIssued, ActorDID: didDoc.ID, ObjectID: vc.ID})
+
 	command.WriteNillableResponse(rw, &SignCredentialResponse{
 		VerifiableCredential: vcBytes,
 	}, logger)
@@ -653,21 +787,13 @@ func (o *Command) GeneratePresentation(rw io.Writer, req io.Reader) command.Erro
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
 	}
 
-	var didDoc *did.Doc
-
-	doc, err := o.ctx.VDRegistry().Resolve(request.DID)
-	//  if did not found in VDR, look through in local storage
+	didDoc, err := o.resolveHolderDID(request.DID)
 	if err != nil {
-		didDoc, err = o.didStore.GetDID(request.DID)
-		if err != nil {
-			logutil.LogError(logger, CommandName, GeneratePresentationCommandMethod,
-				"failed to get did doc from store or vdr: "+err.Error())
+		logutil.LogError(logger, CommandName, GeneratePresentationCommandMethod,
+			"failed to get did doc from store or vdr: "+err.Error())
 
-			return command.NewValidationError(GeneratePresentationErrorCode,
-				fmt.Errorf("generate vp - failed to get did doc from store or vdr : %w", err))
-		}
-	} else {
-		didDoc = doc.DIDDocument
+		return command.NewValidationError(GeneratePresentationErrorCode,
+			fmt.Errorf("generate vp - failed to get did doc from store or vdr : %w", err))
 	}
 
 	credentials, presentation, opts, err := o.parsePresentationRequest(request, didDoc)
@@ -679,7 +805,56 @@ func (o *Command) GeneratePresentation(rw io.Writer, req io.Reader) command.Erro
 			fmt.Errorf("generate vp - parse presentation request: %w", err))
 	}
 
-	return o.generatePresentation(rw, credentials, presentation, didDoc.ID, opts)
+	holderOpts, err := o.prepareAdditionalHolderOpts(request.AdditionalHolders, opts)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GeneratePresentationCommandMethod,
+			"prepare additional holder proofs: "+err.Error())
+
+		return command.NewValidationError(GeneratePresentationErrorCode,
+			fmt.Errorf("generate vp - prepare additional holder proofs: %w", err))
+	}
+
+	return o.generatePresentation(rw, credentials, presentation, didDoc.ID, append([]*ProofOptions{opts}, holderOpts...))
+}
+
+// resolveHolderDID resolves the DID by the VDR, or gets it from the DID store if it is not found.
+func (o *Command) resolveHolderDID(didID string) (*did.Doc, error) {
+	doc, err := o.ctx.VDRegistry().Resolve(didID)
+	//  if did not found in VDR, look through in local storage
+	if err != nil {
+		return o.didStore.GetDID(didID)
+	}
+
+	return doc.DIDDocument, nil
+}
+
+// prepareAdditionalHolderOpts returns the options of the proofs of the additional holders: the proof options of the
+// request with the verification method of each holder, validated to be an authentication method of the holder.
+func (o *Command) prepareAdditionalHolderOpts(holders []*HolderProofOptions,
+	opts *ProofOptions) ([]*ProofOptions, error) {
+	holderOpts := make([]*ProofOptions, len(holders))
+
+	for i, holder := range holders {
+		if holder == nil || holder.DID == "" {
+			return nil, errors.New(errEmptyDID)
+		}
+
+		didDoc, err := o.resolveHolderDID(holder.DID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get did doc of holder %s from store or vdr : %w", holder.DID, err)
+		}
+
+		holderOpt := *opts
+		holderOpt.KID = holder.KID
+		holderOpt.VerificationMethod = holder.VerificationMethod
+
+		holderOpts[i], err = prepareOpts(&holderOpt, didDoc, did.Authentication)
+		if err != nil {
+			return nil, fmt.Errorf("holder %s: %w", holder.DID, err)
+		}
+	}
+
+	return holderOpts, nil
 }
 
 // GeneratePresentationByID generates verifiable presentation from a stored verifiable credential.
@@ -751,6 +926,10 @@ func (o *Command) RemoveCredentialByName(rw io.Writer, req io.Reader) command.Er
 		return command.NewValidationError(RemoveCredentialByNameErrorCode, fmt.Errorf("remove vc by name : %w", err))
 	}
 
+	o.recordEvent(&audit.Event{Type: audit.EventDeleted, ObjectID: request.Name, Details: map[string]string{
+		auditName: request.Name,
+	}})
+
 	command.WriteNillableResponse(rw, &RemoveCredentialByNameResponse{}, logger)
 
 	logutil.LogDebug(logger, CommandName, RemoveCredentialByNameCommandMethod, "success",
@@ -759,6 +938,320 @@ func (o *Command) RemoveCredentialByName(rw io.Writer, req io.Reader) command.Er
 	return nil
 }
 
+// UpdateCredentialName renames the VC that matches the specified name in the verifiable store. The VC and the other
+// fields of its record are kept.
+func (o *Command) UpdateCredentialName(rw io.Writer, req io.Reader) command.Error {
+	var request UpdateCredentialNameRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UpdateCredentialNameCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, UpdateCredentialNameCommandMethod, errEmptyCredentialName)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCredentialName))
+	}
+
+	if request.NewName == "" {
+		logutil.LogDebug(logger, CommandName, UpdateCredentialNameCommandMethod, errEmptyNewName)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyNewName))
+	}
+
+	if err := o.verifiableStore.UpdateCredentialName(request.Name, request.NewName); err != nil {
+		logutil.LogError(logger, CommandName, UpdateCredentialNameCommandMethod, "rename vc : "+err.Error(),
+			logutil.CreateKeyValueString(vcName, request.Name))
+
+		return command.NewValidationError(UpdateCredentialNameErrorCode, fmt.Errorf("rename vc : %w", err))
+	}
+
+	o.recordEvent(&audit.Event{Type: audit.EventUpdated, ObjectID: request.NewName, Details: map[string]string{
+		auditName:    request.Name,
+		auditNewName: request.NewName,
+	}})
+
+	command.WriteNillableResponse(rw, &UpdateCredentialNameResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, UpdateCredentialNameCommandMethod, "success",
+		logutil.CreateKeyValueString(vcName, request.NewName))
+
+	return nil
+}
+
+// AddCredentialTags adds tags to the record of the VC that matches the specified name in the verifiable store.
+func (o *Command) AddCredentialTags(rw io.Writer, req io.Reader) command.Error {
+	return o.updateCredentialTags(rw, req, AddCredentialTagsCommandMethod, auditTagsAdded,
+		o.verifiableStore.AddCredentialTags)
+}
+
+// RemoveCredentialTags removes tags from the record of the VC that matches the specified name in the verifiable
+// store.
+func (o *Command) RemoveCredentialTags(rw io.Writer, req io.Reader) command.Error {
+	return o.updateCredentialTags(rw, req, RemoveCredentialTagsCommandMethod, auditTagsRemoved,
+		o.verifiableStore.RemoveCredentialTags)
+}
+
+func (o *Command) updateCredentialTags(rw io.Writer, req io.Reader, method, auditDetail string,
+	update func(name string, tags ...string) error) command.Error {
+	var request CredentialTagsRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, method, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, method, errEmptyCredentialName)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCredentialName))
+	}
+
+	if len(request.Tags) == 0 {
+		logutil.LogDebug(logger, CommandName, method, errEmptyTags)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyTags))
+	}
+
+	if err := update(request.Name, request.Tags...); err != nil {
+		logutil.LogError(logger, CommandName, method, "update vc tags : "+err.Error(),
+			logutil.CreateKeyValueString(vcName, request.Name))
+
+		return command.NewValidationError(UpdateCredentialTagsErrorCode, fmt.Errorf("update vc tags : %w", err))
+	}
+
+	o.recordEvent(&audit.Event{Type: audit.EventUpdated, ObjectID: request.Name, Details: map[string]string{
+		auditName:   request.Name,
+		auditDetail: strings.Join(request.Tags, ","),
+	}})
+
+	command.WriteNillableResponse(rw, &CredentialTagsResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, method, "success",
+		logutil.CreateKeyValueString(vcName, request.Name))
+
+	return nil
+}
+
+// CreateCollection creates a named collection to group the VCs of the verifiable store in.
+func (o *Command) CreateCollection(rw io.Writer, req io.Reader) command.Error {
+	var request CollectionRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateCollectionCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, CreateCollectionCommandMethod, errEmptyCollection)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCollection))
+	}
+
+	if err := o.verifiableStore.CreateCollection(request.Name); err != nil {
+		logutil.LogError(logger, CommandName, CreateCollectionCommandMethod, "create collection : "+err.Error(),
+			logutil.CreateKeyValueString(vcCollection, request.Name))
+
+		return command.NewValidationError(CredentialCollectionErrorCode, fmt.Errorf("create collection : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &CollectionResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreateCollectionCommandMethod, "success",
+		logutil.CreateKeyValueString(vcCollection, request.Name))
+
+	return nil
+}
+
+// GetCollections retrieves the names of the VC collections.
+func (o *Command) GetCollections(rw io.Writer, req io.Reader) command.Error {
+	collections, err := o.verifiableStore.GetCollections()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetCollectionsCommandMethod, "get collections : "+err.Error())
+
+		return command.NewValidationError(CredentialCollectionErrorCode, fmt.Errorf("get collections : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &CollectionsResponse{
+		Collections: collections,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetCollectionsCommandMethod, "success")
+
+	return nil
+}
+
+// RemoveCollection removes the VC collection that matches the specified name, its VCs are kept in the verifiable
+// store out of any collection.
+func (o *Command) RemoveCollection(rw io.Writer, req io.Reader) command.Error {
+	var request CollectionRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RemoveCollectionCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, RemoveCollectionCommandMethod, errEmptyCollection)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCollection))
+	}
+
+	if err := o.verifiableStore.RemoveCollection(request.Name); err != nil {
+		logutil.LogError(logger, CommandName, RemoveCollectionCommandMethod, "remove collection : "+err.Error(),
+			logutil.CreateKeyValueString(vcCollection, request.Name))
+
+		return command.NewValidationError(CredentialCollectionErrorCode, fmt.Errorf("remove collection : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &CollectionResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, RemoveCollectionCommandMethod, "success",
+		logutil.CreateKeyValueString(vcCollection, request.Name))
+
+	return nil
+}
+
+// MoveCredential moves the VC that matches the specified name to the collection, or out of any collection if no
+// collection is specified.
+func (o *Command) MoveCredential(rw io.Writer, req io.Reader) command.Error {
+	var request MoveCredentialRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, MoveCredentialCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, MoveCredentialCommandMethod, errEmptyCredentialName)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCredentialName))
+	}
+
+	if err := o.verifiableStore.MoveCredential(request.Name, request.Collection); err != nil {
+		logutil.LogError(logger, CommandName, MoveCredentialCommandMethod, "move vc : "+err.Error(),
+			logutil.CreateKeyValueString(vcName, request.Name),
+			logutil.CreateKeyValueString(vcCollection, request.Collection))
+
+		return command.NewValidationError(CredentialCollectionErrorCode, fmt.Errorf("move vc : %w", err))
+	}
+
+	o.recordEvent(&audit.Event{Type: audit.EventUpdated, ObjectID: request.Name, Details: map[string]string{
+		auditName:       request.Name,
+		auditCollection: request.Collection,
+	}})
+
+	command.WriteNillableResponse(rw, &MoveCredentialResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, MoveCredentialCommandMethod, "success",
+		logutil.CreateKeyValueString(vcName, request.Name),
+		logutil.CreateKeyValueString(vcCollection, request.Collection))
+
+	return nil
+}
+
+// GetCredentialsByCollection retrieves the records of the VCs of the collection that matches the specified name.
+func (o *Command) GetCredentialsByCollection(rw io.Writer, req io.Reader) command.Error {
+	var request CollectionRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetCredentialsByCollectionCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, GetCredentialsByCollectionCommandMethod, errEmptyCollection)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCollection))
+	}
+
+	vcRecords, err := o.verifiableStore.GetCredentialsByCollection(request.Name)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetCredentialsByCollectionCommandMethod,
+			"get credential records : "+err.Error(), logutil.CreateKeyValueString(vcCollection, request.Name))
+
+		return command.NewValidationError(CredentialCollectionErrorCode, fmt.Errorf("get credential records : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &RecordResult{
+		Result: vcRecords,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetCredentialsByCollectionCommandMethod, "success",
+		logutil.CreateKeyValueString(vcCollection, request.Name))
+
+	return nil
+}
+
+// RotateEncryptionKey creates a new encryption key for the verifiable store, the stored VCs and VPs are
+// re-encrypted with it when read.
+func (o *Command) RotateEncryptionKey(rw io.Writer, req io.Reader) command.Error {
+	rotator, ok := o.verifiableStore.(encryptionKeyRotator)
+	if !ok {
+		logutil.LogError(logger, CommandName, RotateEncryptionKeyCommandMethod, "store encryption is not supported")
+
+		return command.NewExecuteError(RotateEncryptionKeyErrorCode,
+			fmt.Errorf("rotate encryption key : store encryption is not supported"))
+	}
+
+	kid, err := rotator.RotateEncryptionKey()
+	if err != nil {
+		logutil.LogError(logger, CommandName, RotateEncryptionKeyCommandMethod, "rotate encryption key : "+err.Error())
+
+		return command.NewExecuteError(RotateEncryptionKeyErrorCode, fmt.Errorf("rotate encryption key : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &RotateEncryptionKeyResponse{
+		KeyID: kid,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, RotateEncryptionKeyCommandMethod, "success",
+		logutil.CreateKeyValueString(keyID, kid))
+
+	return nil
+}
+
+// RefreshCredential renews the VC that matches the specified name using the refresh service defined in the VC
+// and replaces the stored VC by the renewed one.
+func (o *Command) RefreshCredential(rw io.Writer, req io.Reader) command.Error {
+	var request NameArg
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RefreshCredentialCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, RefreshCredentialCommandMethod, errEmptyCredentialName)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCredentialName))
+	}
+
+	vc, err := o.refresher.Refresh(request.Name)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RefreshCredentialCommandMethod, "refresh vc : "+err.Error(),
+			logutil.CreateKeyValueString(vcName, request.Name))
+
+		return command.NewExecuteError(RefreshCredentialErrorCode, fmt.Errorf("refresh vc : %w", err))
+	}
+
+	vcBytes, err := vc.MarshalOriginal()
+	if err != nil {
+		logutil.LogError(logger, CommandName, RefreshCredentialCommandMethod, "marshal vc : "+err.Error(),
+			logutil.CreateKeyValueString(vcName, request.Name))
+
+		return command.NewExecuteError(RefreshCredentialErrorCode, fmt.Errorf("marshal vc : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &Credential{
+		VerifiableCredential: string(vcBytes),
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, RefreshCredentialCommandMethod, "success",
+		logutil.CreateKeyValueString(vcName, request.Name))
+
+	return nil
+}
+
 // RemovePresentationByName will remove a VP that matches the specified name from the verifiable store.
 func (o *Command) RemovePresentationByName(rw io.Writer, req io.Reader) command.Error {
 	var request NameArg
@@ -781,6 +1274,10 @@ func (o *Command) RemovePresentationByName(rw io.Writer, req io.Reader) command.
 		return command.NewValidationError(RemovePresentationByNameErrorCode, fmt.Errorf("remove vp by name : %w", err))
 	}
 
+	o.recordEvent(&audit.Event{Type: audit.EventDeleted, ObjectID: request.Name, Details: map[string]string{
+		auditName: request.Name,
+	}})
+
 	command.WriteNillableResponse(rw, &RemovePresentationByNameResponse{}, logger)
 
 	logutil.LogDebug(logger, CommandName, RemovePresentationByNameCommandMethod, "success",
@@ -789,8 +1286,29 @@ func (o *Command) RemovePresentationByName(rw io.Writer, req io.Reader) command.
 	return nil
 }
 
+// recordEvent records the credential operation in the audit log, if auditing is enabled.
+func (o *Command) recordEvent(event *audit.Event) {
+	if o.auditLog == nil {
+		return
+	}
+
+	if _, err := o.auditLog.Append(event); err != nil {
+		logger.Warnf("failed to record %s event in audit log: %s", event.Type, err.Error())
+	}
+}
+
+func credentialIDs(vcs ...*verifiable.Credential) string {
+	ids := make([]string, len(vcs))
+
+	for i, vc := range vcs {
+		ids[i] = vc.ID
+	}
+
+	return strings.Join(ids, " ")
+}
+
 func (o *Command) generatePresentation(rw io.Writer, vcs []*verifiable.Credential, p *verifiable.Presentation,
-	holder string, opts *ProofOptions) command.Error {
+	holder string, opts []*ProofOptions) command.Error {
 	// prepare vp
 	vp, err := o.createAndSignPresentation(vcs, p, holder, opts)
 	if err != nil {
@@ -799,6 +1317,10 @@ func (o *Command) generatePresentation(rw io.Writer, vcs []*verifiable.Credentia
 		return command.NewValidationError(GeneratePresentationByIDErrorCode, fmt.Errorf("prepare vp: %w", err))
 	}
 
+	o.recordEvent(&audit.Event{Type: audit.EventPresented, ActorDID: holder, Details: map[string]string{
+		auditCredentials: credentialIDs(vcs...),
+	}})
+
 	command.WriteNillableResponse(rw, &Presentation{
 		VerifiablePresentation: vp,
 	}, logger)
@@ -818,6 +1340,10 @@ func (o *Command) generatePresentationByID(rw io.Writer, vc *verifiable.Credenti
 		return command.NewValidationError(GeneratePresentationByIDErrorCode, fmt.Errorf("prepare vp by id: %w", err))
 	}
 
+	o.recordEvent(&audit.Event{Type: audit.EventPresented, ActorDID: didDoc.ID, Details: map[string]string{
+		auditCredentials: credentialIDs(vc),
+	}})
+
 	//  TODO : VP is already implementing marshall json. Revisit #1643
 	command.WriteNillableResponse(rw, &Presentation{
 		VerifiablePresentation: vp,
@@ -828,11 +1354,13 @@ func (o *Command) generatePresentationByID(rw io.Writer, vc *verifiable.Credenti
 	return nil
 }
 
+// createAndSignPresentation signs the presentation with every proof options, giving it a proof set when there are
+// several holders.
 func (o *Command) createAndSignPresentation(credentials []*verifiable.Credential, vp *verifiable.Presentation,
-	holder string, opts *ProofOptions) ([]byte, error) {
+	holder string, opts []*ProofOptions) ([]byte, error) {
 	var err error
 	if vp == nil {
-		vp, err = verifiable.NewPresentation(verifiable.WithCredentials(credentials...))
+		vp, err = newPresentation(credentials...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to set credentials: %w", err)
 		}
@@ -842,9 +1370,11 @@ func (o *Command) createAndSignPresentation(credentials []*verifiable.Credential
 	vp.Holder = holder
 
 	// Add proofs to vp - sign presentation
-	err = o.addLinkedDataProof(vp, opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign vp: %w", err)
+	for _, opt := range opts {
+		err = o.addLinkedDataProof(vp, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign vp: %w", err)
+		}
 	}
 
 	return vp.MarshalJSON()
@@ -858,7 +1388,7 @@ func (o *Command) createAndSignPresentationByID(vc *verifiable.Credential,
 		return nil, err
 	}
 
-	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+	vp, err := newPresentation(vc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create vp by ID: %w", err)
 	}
@@ -871,8 +1401,29 @@ func (o *Command) createAndSignPresentationByID(vc *verifiable.Credential,
 	return vp.MarshalJSON()
 }
 
+func (o *Command) newSigner(opts *ProofOptions, signerOpts ...kmssigner.Opt) (*kmssigner.KMSSigner, error) {
+	if opts.KID != "" {
+		return kmssigner.NewKMSSigner(o.ctx.KMS(), o.ctx.Crypto(), opts.KID, signerOpts...)
+	}
+
+	var resolverOpts []kmssigner.ResolverOpt
+
+	if p, ok := o.ctx.(keyLinkProvider); ok && p.KeyLinkStore() != nil {
+		resolverOpts = append(resolverOpts, kmssigner.WithSigningKeyLookup(p.KeyLinkStore()))
+	}
+
+	return kmssigner.NewResolver(o.ctx.VDRegistry(), o.ctx.KMS(), o.ctx.Crypto(), resolverOpts...).
+		Resolve(opts.VerificationMethod, signerOpts...)
+}
+
 func (o *Command) addLinkedDataProof(p provable, opts *ProofOptions) error {
-	s, err := newKMSSigner(o.ctx.KMS(), o.ctx.Crypto(), getKID(opts))
+	var signerOpts []kmssigner.Opt
+
+	if opts.SignatureType == BbsBlsSignature2020 {
+		signerOpts = append(signerOpts, kmssigner.WithMultiMessage())
+	}
+
+	s, err := o.newSigner(opts, signerOpts...)
 	if err != nil {
 		return err
 	}
@@ -885,7 +1436,6 @@ func (o *Command) addLinkedDataProof(p provable, opts *ProofOptions) error {
 	case JSONWebSignature2020:
 		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
 	case BbsBlsSignature2020:
-		s.bbs = true
 		signatureSuite = bbsblssignature2020.New(suite.WithSigner(s))
 	default:
 		return fmt.Errorf("signature type unsupported %s", opts.SignatureType)
@@ -926,7 +1476,8 @@ func (o *Command) parseVerifiableCredentials(request *PresentationRequest,
 	var vcs []*verifiable.Credential
 
 	for _, vcRaw := range request.VerifiableCredentials {
-		vc, e := verifiable.ParseCredential(vcRaw, o.getCredentialOpts(request.SkipVerify)...)
+		vc, e := verifiable.ParseCredential(credentialBytes(vcRaw),
+			append(o.getCredentialOpts(request.SkipVerify), verifiable.WithOriginalJWT())...)
 
 		if e != nil {
 			logutil.LogError(logger, CommandName, GeneratePresentationCommandMethod,
@@ -987,6 +1538,39 @@ func (o *Command) parsePresentationRequest(request *PresentationRequest,
 	return nil, nil, nil, fmt.Errorf("invalid request, no valid credentials/presentation found")
 }
 
+// credentialBytes returns the credential of the request, either a JSON-LD credential or a VC-JWT given as a JSON
+// string.
+func credentialBytes(vcRaw json.RawMessage) []byte {
+	var vcJWT string
+
+	if err := json.Unmarshal(vcRaw, &vcJWT); err == nil {
+		return []byte(vcJWT)
+	}
+
+	return vcRaw
+}
+
+// newPresentation returns a presentation of the credentials, the credentials decoded from a VC-JWT are enveloped
+// in the presentation as their original JWT.
+func newPresentation(credentials ...*verifiable.Credential) (*verifiable.Presentation, error) {
+	opts := make([]verifiable.CreatePresentationOpt, len(credentials))
+
+	for i, vc := range credentials {
+		original, err := vc.MarshalOriginal()
+		if err != nil {
+			return nil, err
+		}
+
+		if jwt.IsJWS(string(original)) {
+			opts[i] = verifiable.WithJWTCredentials(string(original))
+		} else {
+			opts[i] = verifiable.WithCredentials(vc)
+		}
+	}
+
+	return verifiable.NewPresentation(opts...)
+}
+
 func (o *Command) getCredentialOpts(disableProofCheck bool) []verifiable.CredentialOpt {
 	if disableProofCheck {
 		return []verifiable.CredentialOpt{verifiable.WithDisabledProofCheck()}
@@ -14,6 +14,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
@@ -23,18 +25,24 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/client/vcrefresh"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	cryptomock "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	kmsmock "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/audit"
 	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
@@ -419,7 +427,7 @@ func TestNew(t *testing.T) {
 		require.NoError(t, err)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 14, len(handlers))
+		require.Equal(t, 25, len(handlers))
 	})
 
 	t.Run("test new command - vc store error", func(t *testing.T) {
@@ -435,6 +443,64 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestVerifyCredential(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NotNil(t, cmd)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		vcReqBytes, err := json.Marshal(Credential{VerifiableCredential: `{
+			"@context": ["https://www.w3.org/2018/credentials/v1"],
+			"id": "http://example.edu/credentials/1989",
+			"type": "VerifiableCredential",
+			"credentialSubject": {"id": "did:example:iuajk1f712ebc6f1c276e12ec21"},
+			"issuer": "did:example:09s12ec712ebc6f1c671ebfeb1f",
+			"issuanceDate": "2020-01-01T10:54:01Z"
+		}`})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.VerifyCredential(&b, bytes.NewBuffer(vcReqBytes))
+		require.NoError(t, err)
+
+		var response VerifyCredentialResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+
+		// the credential is not signed
+		require.False(t, response.Verified)
+		require.Len(t, response.Checks, 5)
+		require.Equal(t, verifiable.CheckSignature, response.Checks[0].Check)
+		require.Equal(t, verifiable.CodeProofMissing, response.Checks[0].Code)
+
+		for _, c := range response.Checks[1:] {
+			require.NotEqual(t, verifiable.CheckFailed, c.Status, c.Check)
+		}
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.VerifyCredential(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "request decode")
+	})
+
+	t.Run("malformed credential", func(t *testing.T) {
+		vcReqBytes, err := json.Marshal(Credential{VerifiableCredential: "--"})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.VerifyCredential(&b, bytes.NewBuffer(vcReqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "verify vc")
+	})
+}
+
 func TestValidateVC(t *testing.T) {
 	t.Run("test register - success", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
@@ -505,6 +571,37 @@ func TestSaveVC(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test save vc - JWT", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		jws := vcJWT(t)
+
+		vcReqBytes, err := json.Marshal(&CredentialExt{
+			Credential: Credential{VerifiableCredential: jws},
+			Name:       sampleCredentialName,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.SaveCredential(&b, bytes.NewBuffer(vcReqBytes))
+		require.NoError(t, err)
+
+		// the original JWT is kept
+		jsoStr := fmt.Sprintf(`{"id":"%s"}`, "http://example.edu/credentials/1989")
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.GetCredential(&getRW, bytes.NewBufferString(jsoStr))
+		require.NoError(t, cmdErr)
+
+		var response Credential
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Equal(t, jws, response.VerifiableCredential)
+	})
+
 	t.Run("test save vc - invalid request", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
 			StorageProviderValue: mockstore.NewMockStoreProvider(),
@@ -1123,6 +1220,107 @@ func TestGeneratePresentation(t *testing.T) {
 		require.Contains(t, vp.Proofs[0]["created"], strconv.Itoa(presReq.Created.Year()))
 	})
 
+	t.Run("test generate presentation - JWT credential", func(t *testing.T) {
+		jws := vcJWT(t)
+
+		presReq := PresentationRequest{
+			VerifiableCredentials: []json.RawMessage{[]byte(vc), stringToJSONRaw(`"` + jws + `"`)},
+			DID:                   "did:peer:123456789abcdefghi#inbox",
+			ProofOptions:          &ProofOptions{SignatureType: Ed25519Signature2018},
+			SkipVerify:            true,
+		}
+		presReqBytes, err := json.Marshal(presReq)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.GeneratePresentation(&b, bytes.NewBuffer(presReqBytes))
+		require.NoError(t, err)
+
+		var response Presentation
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+
+		var vp struct {
+			Credentials []interface{} `json:"verifiableCredential"`
+		}
+
+		require.NoError(t, json.Unmarshal(response.VerifiablePresentation, &vp))
+
+		// the JWT credential is enveloped in the presentation as is
+		require.Len(t, vp.Credentials, 2)
+		require.Equal(t, jws, vp.Credentials[1])
+	})
+
+	t.Run("test generate presentation with additional holders - success", func(t *testing.T) {
+		presReq := PresentationRequest{
+			VerifiableCredentials: []json.RawMessage{[]byte(vc)},
+			DID:                   "did:peer:123456789abcdefghi#inbox",
+			ProofOptions: &ProofOptions{
+				Challenge:     "sample-random-test-value",
+				SignatureType: Ed25519Signature2018,
+			},
+			AdditionalHolders: []*HolderProofOptions{{DID: jwsDID}},
+		}
+
+		presReqBytes, err := json.Marshal(presReq)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.GeneratePresentation(&b, bytes.NewBuffer(presReqBytes))
+		require.NoError(t, err)
+
+		var response Presentation
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+
+		vp, err := verifiable.ParsePresentation(response.VerifiablePresentation,
+			verifiable.WithPresDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, presReq.DID, vp.Holder)
+
+		// one proof per holder, with the common proof options
+		require.Len(t, vp.Proofs, 2)
+		require.Equal(t, jwsDID+"#key-7777", vp.Proofs[1]["verificationMethod"])
+		require.NotEqual(t, vp.Proofs[0]["verificationMethod"], vp.Proofs[1]["verificationMethod"])
+
+		for _, proof := range vp.Proofs {
+			require.Equal(t, presReq.Challenge, proof["challenge"])
+			require.Equal(t, "authentication", proof["proofPurpose"])
+		}
+	})
+
+	t.Run("test generate presentation with additional holders - failure", func(t *testing.T) {
+		presReq := PresentationRequest{
+			VerifiableCredentials: []json.RawMessage{[]byte(vc)},
+			DID:                   "did:peer:123456789abcdefghi#inbox",
+			ProofOptions:          &ProofOptions{SignatureType: Ed25519Signature2018},
+		}
+
+		for _, tc := range []struct {
+			holder *HolderProofOptions
+			err    string
+		}{
+			{holder: &HolderProofOptions{}, err: "did is mandatory"},
+			{holder: &HolderProofOptions{DID: invalidDID}, err: "failed to get did doc of holder did:error:123"},
+			{
+				holder: &HolderProofOptions{DID: jwsDID, VerificationMethod: "did:peer:123456789abcdefghi#keys-1"},
+				err:    "unable to find matching 'authentication' key IDs for given verification method",
+			},
+		} {
+			presReq.AdditionalHolders = []*HolderProofOptions{tc.holder}
+
+			presReqBytes, err := json.Marshal(presReq)
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+
+			err = cmd.GeneratePresentation(&b, bytes.NewBuffer(presReqBytes))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "generate vp - prepare additional holder proofs")
+			require.Contains(t, err.Error(), tc.err)
+		}
+	})
+
 	t.Run("test generate presentation - invalid request", func(t *testing.T) {
 		var b bytes.Buffer
 
@@ -2251,6 +2449,28 @@ func stringToJSONRaw(jsonStr string) json.RawMessage {
 	return []byte(jsonStr)
 }
 
+type stubJWTSigner struct{}
+
+func (s *stubJWTSigner) Sign([]byte) ([]byte, error) {
+	return []byte("signature"), nil
+}
+
+// vcJWT returns the test credential as a VC-JWT (with an invalid signature).
+func vcJWT(t *testing.T) string {
+	t.Helper()
+
+	credential, err := verifiable.ParseCredential([]byte(vc))
+	require.NoError(t, err)
+
+	claims, err := credential.JWTClaims(false)
+	require.NoError(t, err)
+
+	jws, err := claims.MarshalJWS(verifiable.EdDSA, &stubJWTSigner{}, "did:example:09s12ec712ebc6f1c671ebfeb1f#key-1")
+	require.NoError(t, err)
+
+	return jws
+}
+
 func TestCommand_RemoveVCByName(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
@@ -2350,6 +2570,451 @@ func TestCommand_RemoveVCByName(t *testing.T) {
 	})
 }
 
+func TestCommand_UpdateCredentialName(t *testing.T) {
+	newCommand := func(t *testing.T) *Command {
+		t.Helper()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		vcReqBytes, err := json.Marshal(CredentialExt{
+			Credential: Credential{VerifiableCredential: vc},
+			Name:       sampleCredentialName,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.SaveCredential(&b, bytes.NewBuffer(vcReqBytes)))
+
+		return cmd
+	}
+
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		var b bytes.Buffer
+		cmdErr := cmd.UpdateCredentialName(&b,
+			bytes.NewBufferString(fmt.Sprintf(`{"name":"%s","newName":"newName"}`, sampleCredentialName)))
+		require.NoError(t, cmdErr)
+
+		var getRW bytes.Buffer
+		cmdErr = cmd.GetCredentialByName(&getRW, bytes.NewBufferString(`{"name":"newName"}`))
+		require.NoError(t, cmdErr)
+
+		var record verifiablestore.Record
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&record))
+		require.Equal(t, "newName", record.Name)
+		require.Equal(t, "http://example.edu/credentials/1872", record.ID)
+
+		cmdErr = cmd.GetCredentialByName(&getRW,
+			bytes.NewBufferString(fmt.Sprintf(`{"name":"%s"}`, sampleCredentialName)))
+		require.Error(t, cmdErr)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := newCommand(t).UpdateCredentialName(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "request decode")
+	})
+
+	t.Run("no name", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := newCommand(t).UpdateCredentialName(&b, bytes.NewBufferString(`{"newName":"newName"}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyCredentialName)
+
+		cmdErr = newCommand(t).UpdateCredentialName(&b,
+			bytes.NewBufferString(fmt.Sprintf(`{"name":"%s"}`, sampleCredentialName)))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyNewName)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := newCommand(t).UpdateCredentialName(&b, bytes.NewBufferString(`{"name":"unknown","newName":"newName"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, UpdateCredentialNameErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "rename vc")
+	})
+}
+
+func TestCommand_CredentialTags(t *testing.T) {
+	newCommand := func(t *testing.T) *Command {
+		t.Helper()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		vcReqBytes, err := json.Marshal(CredentialExt{
+			Credential: Credential{VerifiableCredential: vc},
+			Name:       sampleCredentialName,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.SaveCredential(&b, bytes.NewBuffer(vcReqBytes)))
+
+		return cmd
+	}
+
+	getTags := func(t *testing.T, cmd *Command) []string {
+		t.Helper()
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GetCredentials(&b, nil))
+
+		var result RecordResult
+		require.NoError(t, json.NewDecoder(&b).Decode(&result))
+		require.Len(t, result.Result, 1)
+
+		return result.Result[0].Tags
+	}
+
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		var b bytes.Buffer
+		cmdErr := cmd.AddCredentialTags(&b, bytes.NewBufferString(
+			fmt.Sprintf(`{"name":"%s","tags":["work","travel"]}`, sampleCredentialName)))
+		require.NoError(t, cmdErr)
+		require.Equal(t, []string{"work", "travel"}, getTags(t, cmd))
+
+		cmdErr = cmd.RemoveCredentialTags(&b, bytes.NewBufferString(
+			fmt.Sprintf(`{"name":"%s","tags":["work"]}`, sampleCredentialName)))
+		require.NoError(t, cmdErr)
+		require.Equal(t, []string{"travel"}, getTags(t, cmd))
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := newCommand(t).AddCredentialTags(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "request decode")
+	})
+
+	t.Run("no name or tags", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := newCommand(t).AddCredentialTags(&b, bytes.NewBufferString(`{"tags":["work"]}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyCredentialName)
+
+		cmdErr = newCommand(t).RemoveCredentialTags(&b,
+			bytes.NewBufferString(fmt.Sprintf(`{"name":"%s"}`, sampleCredentialName)))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyTags)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := newCommand(t).RemoveCredentialTags(&b, bytes.NewBufferString(`{"name":"unknown","tags":["work"]}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, UpdateCredentialTagsErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "update vc tags")
+	})
+}
+
+func TestCommand_CredentialCollections(t *testing.T) {
+	newCommand := func(t *testing.T) *Command {
+		t.Helper()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		vcReqBytes, err := json.Marshal(CredentialExt{
+			Credential: Credential{VerifiableCredential: vc},
+			Name:       sampleCredentialName,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.SaveCredential(&b, bytes.NewBuffer(vcReqBytes)))
+
+		return cmd
+	}
+
+	getCredentials := func(t *testing.T, cmd *Command, collection string) []*verifiablestore.Record {
+		t.Helper()
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GetCredentialsByCollection(&b,
+			bytes.NewBufferString(fmt.Sprintf(`{"name":"%s"}`, collection))))
+
+		var result RecordResult
+		require.NoError(t, json.NewDecoder(&b).Decode(&result))
+
+		return result.Result
+	}
+
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.CreateCollection(&b, bytes.NewBufferString(`{"name":"work"}`)))
+		require.NoError(t, cmd.CreateCollection(&b, bytes.NewBufferString(`{"name":"travel"}`)))
+
+		var getRW bytes.Buffer
+		require.NoError(t, cmd.GetCollections(&getRW, nil))
+
+		var collections CollectionsResponse
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&collections))
+		require.Equal(t, []string{"travel", "work"}, collections.Collections)
+
+		require.NoError(t, cmd.MoveCredential(&b, bytes.NewBufferString(
+			fmt.Sprintf(`{"name":"%s","collection":"work"}`, sampleCredentialName))))
+
+		records := getCredentials(t, cmd, "work")
+		require.Len(t, records, 1)
+		require.Equal(t, sampleCredentialName, records[0].Name)
+		require.Equal(t, "work", records[0].Collection)
+		require.Empty(t, getCredentials(t, cmd, "travel"))
+
+		require.NoError(t, cmd.RemoveCollection(&b, bytes.NewBufferString(`{"name":"work"}`)))
+		require.Empty(t, getCredentials(t, cmd, "work"))
+
+		// the credentials of a removed collection are kept
+		var getVCRW bytes.Buffer
+		require.NoError(t, cmd.GetCredentialByName(&getVCRW,
+			bytes.NewBufferString(fmt.Sprintf(`{"name":"%s"}`, sampleCredentialName))))
+
+		var record verifiablestore.Record
+		require.NoError(t, json.NewDecoder(&getVCRW).Decode(&record))
+		require.Empty(t, record.Collection)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		for _, fn := range []command.Exec{
+			cmd.CreateCollection, cmd.RemoveCollection, cmd.MoveCredential, cmd.GetCredentialsByCollection,
+		} {
+			var b bytes.Buffer
+			cmdErr := fn(&b, bytes.NewBufferString("--"))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Contains(t, cmdErr.Error(), "request decode")
+		}
+	})
+
+	t.Run("no name", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		for _, fn := range []command.Exec{cmd.CreateCollection, cmd.RemoveCollection, cmd.GetCredentialsByCollection} {
+			var b bytes.Buffer
+			cmdErr := fn(&b, bytes.NewBufferString(`{}`))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Contains(t, cmdErr.Error(), errEmptyCollection)
+		}
+
+		var b bytes.Buffer
+		cmdErr := cmd.MoveCredential(&b, bytes.NewBufferString(`{"collection":"work"}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyCredentialName)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.CreateCollection(&b, bytes.NewBufferString(`{"name":"work"}`)))
+
+		cmdErr := cmd.CreateCollection(&b, bytes.NewBufferString(`{"name":"work"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, CredentialCollectionErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "create collection")
+
+		cmdErr = cmd.RemoveCollection(&b, bytes.NewBufferString(`{"name":"unknown"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, CredentialCollectionErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "remove collection")
+
+		cmdErr = cmd.MoveCredential(&b, bytes.NewBufferString(
+			fmt.Sprintf(`{"name":"%s","collection":"unknown"}`, sampleCredentialName)))
+		require.Error(t, cmdErr)
+		require.Equal(t, CredentialCollectionErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "move vc")
+
+		cmdErr = cmd.GetCredentialsByCollection(&b, bytes.NewBufferString(`{"name":"a:b"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, CredentialCollectionErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "get credential records")
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				Store: &mockstore.MockStore{
+					Store:    make(map[string]mockstore.DBEntry),
+					ErrQuery: fmt.Errorf("query error"),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		cmdErr = cmd.GetCollections(&b, nil)
+		require.Error(t, cmdErr)
+		require.Equal(t, CredentialCollectionErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "get collections")
+	})
+}
+
+func TestCommand_RefreshCredential(t *testing.T) {
+	newCredential := func(id, refreshURL string) *verifiable.Credential {
+		return &verifiable.Credential{
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			ID:      id,
+			Types:   []string{"VerifiableCredential"},
+			Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+			Issued:  util.NewTime(time.Now()),
+			RefreshService: []verifiable.TypedID{{
+				ID:   refreshURL,
+				Type: vcrefresh.HTTPRefreshServiceType,
+			}},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			vcBytes, err := newCredential("http://example.edu/credentials/renewed", "https://issuer.example.com/refresh").MarshalJSON()
+			require.NoError(t, err)
+
+			_, err = w.Write(vcBytes)
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue:      &mockvdr.MockVDRegistry{},
+		})
+		require.NoError(t, err)
+
+		err = cmd.verifiableStore.SaveCredential(sampleCredentialName,
+			newCredential("http://example.edu/credentials/1", srv.URL))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.RefreshCredential(&b, bytes.NewBufferString(fmt.Sprintf(`{"name":"%s"}`, sampleCredentialName)))
+		require.NoError(t, cmdErr)
+
+		var response Credential
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Contains(t, response.VerifiableCredential, "http://example.edu/credentials/renewed")
+
+		id, err := cmd.verifiableStore.GetCredentialIDByName(sampleCredentialName)
+		require.NoError(t, err)
+		require.Equal(t, "http://example.edu/credentials/renewed", id)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.RefreshCredential(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "request decode")
+	})
+
+	t.Run("no name", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.RefreshCredential(&b, bytes.NewBufferString(`{"name":""}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyCredentialName)
+	})
+
+	t.Run("refresh error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.RefreshCredential(&b, bytes.NewBufferString(fmt.Sprintf(`{"name":"%s"}`, sampleCredentialName)))
+		require.Error(t, cmdErr)
+		require.Equal(t, RefreshCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "refresh vc")
+	})
+}
+
+func TestCommand_RotateEncryptionKey(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		keyManager, err := localkms.New("local-lock://test/key-uri/",
+			kmsmock.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+		require.NoError(t, err)
+
+		cryptoSvc, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		store, err := verifiablestore.New(&mockprovider.Provider{StorageProviderValue: storeProvider},
+			verifiablestore.WithEncryption(keyManager, cryptoSvc))
+		require.NoError(t, err)
+
+		cmd, err := New(&vcStoreProvider{
+			Provider:        &mockprovider.Provider{StorageProviderValue: storeProvider},
+			verifiableStore: store,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, store.SaveCredential(sampleCredentialName, &verifiable.Credential{
+			Context: []string{verifiable.ContextURI},
+			Types:   []string{verifiable.VCType},
+			ID:      sampleVCID,
+			Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+			Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			Issued:  util.NewTime(time.Now()),
+		}))
+		require.NotContains(t, string(storeProvider.Store.Store[sampleVCID].Value), sampleVCID)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.RotateEncryptionKey(&b, bytes.NewBufferString("")))
+
+		var response RotateEncryptionKeyResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.NotEmpty(t, response.KeyID)
+
+		b.Reset()
+		require.NoError(t, cmd.GetCredential(&b, bytes.NewBufferString(fmt.Sprintf(`{"id":"%s"}`, sampleVCID))))
+		require.Contains(t, b.String(), sampleVCID)
+	})
+
+	t.Run("store encryption not enabled", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.RotateEncryptionKey(&b, bytes.NewBufferString(""))
+		require.Error(t, cmdErr)
+		require.Equal(t, RotateEncryptionKeyErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "store encryption is not enabled")
+	})
+}
+
 func TestCommand_RemoveVPByName(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		s := make(map[string]mockstore.DBEntry)
@@ -2679,3 +3344,105 @@ func (s *bbsSigner) textToLines(txt string) [][]byte {
 
 	return linesBytes
 }
+
+type vcStoreProvider struct {
+	*mockprovider.Provider
+	verifiableStore verifiablestore.Store
+}
+
+func (p *vcStoreProvider) VerifiableStore() verifiablestore.Store {
+	return p.verifiableStore
+}
+
+type auditProvider struct {
+	*mockprovider.Provider
+	auditLog *audit.Log
+}
+
+func (p *auditProvider) AuditLog() *audit.Log {
+	return p.auditLog
+}
+
+func TestCommand_AuditLog(t *testing.T) {
+	const auditedVC = `{
+	  "@context": ["https://www.w3.org/2018/credentials/v1"],
+	  "id": "urn:uuid:0b5b0b2c-1a62-4d3e-9b59-9ad4b4a1e1ad",
+	  "type": ["VerifiableCredential"],
+	  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+	  "issuanceDate": "2010-01-01T19:23:24Z",
+	  "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+	}`
+
+	newCommand := func(t *testing.T, auditStore *mockstore.MockStoreProvider) (*Command, *audit.Log) {
+		t.Helper()
+
+		auditLog, err := audit.New(&mockprovider.Provider{StorageProviderValue: auditStore})
+		require.NoError(t, err)
+
+		cmd, err := New(&auditProvider{
+			Provider: &mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()},
+			auditLog: auditLog,
+		})
+		require.NoError(t, err)
+
+		return cmd, auditLog
+	}
+
+	t.Run("test credential operations are audited", func(t *testing.T) {
+		cmd, auditLog := newCommand(t, mockstore.NewMockStoreProvider())
+
+		vcReqBytes, err := json.Marshal(CredentialExt{
+			Credential: Credential{VerifiableCredential: auditedVC},
+			Name:       sampleCredentialName,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.SaveCredential(&b, bytes.NewBuffer(vcReqBytes)))
+		require.NoError(t, cmd.ValidateCredential(&b, bytes.NewBuffer(vcReqBytes)))
+		require.Error(t, cmd.ValidateCredential(&b, bytes.NewBufferString(`{"verifiableCredential":"{}"}`)))
+		require.NoError(t, cmd.RemoveCredentialByName(&b,
+			bytes.NewBufferString(fmt.Sprintf(`{"name":"%s"}`, sampleCredentialName))))
+
+		entries, err := auditLog.Query(nil)
+		require.NoError(t, err)
+		require.Len(t, entries, 4)
+
+		require.Equal(t, audit.EventStored, entries[0].Type)
+		require.Equal(t, "urn:uuid:0b5b0b2c-1a62-4d3e-9b59-9ad4b4a1e1ad", entries[0].ObjectID)
+		require.Equal(t, sampleCredentialName, entries[0].Details["name"])
+
+		require.Equal(t, audit.EventVerified, entries[1].Type)
+		require.Equal(t, "true", entries[1].Details["verified"])
+		require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", entries[1].Details["issuer"])
+
+		require.Equal(t, audit.EventVerified, entries[2].Type)
+		require.Equal(t, "false", entries[2].Details["verified"])
+		require.NotEmpty(t, entries[2].Details["error"])
+
+		require.Equal(t, audit.EventDeleted, entries[3].Type)
+		require.Equal(t, sampleCredentialName, entries[3].ObjectID)
+
+		require.NoError(t, auditLog.Verify())
+	})
+
+	t.Run("test audit failures don't fail the operations", func(t *testing.T) {
+		auditStore := mockstore.NewMockStoreProvider()
+		auditStore.Store.ErrBatch = errors.New("batch error")
+
+		cmd, auditLog := newCommand(t, auditStore)
+
+		vcReqBytes, err := json.Marshal(CredentialExt{
+			Credential: Credential{VerifiableCredential: auditedVC},
+			Name:       sampleCredentialName,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.SaveCredential(&b, bytes.NewBuffer(vcReqBytes)))
+
+		entries, err := auditLog.Query(nil)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
@@ -14,21 +14,38 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
-// Credential is model for verifiable credential.
+// Credential is model for verifiable credential, either a JSON-LD credential or a VC-JWT.
 type Credential struct {
 	VerifiableCredential string `json:"verifiableCredential,omitempty"`
 }
 
 // PresentationRequest is model for verifiable presentation request.
 type PresentationRequest struct {
+	// VerifiableCredentials are JSON-LD credentials or VC-JWTs given as JSON strings, the VC-JWTs are enveloped in
+	// the presentation as is.
 	VerifiableCredentials []json.RawMessage `json:"verifiableCredential,omitempty"`
 	Presentation          json.RawMessage   `json:"presentation,omitempty"`
 	DID                   string            `json:"did,omitempty"`
 	*ProofOptions
+	// AdditionalHolders sign the presentation along with the DID, e.g. when the presentation aggregates credentials
+	// bound to different holder DIDs. The presentation then gets a proof set, one proof per holder, made with the
+	// proof options but the verification method and the key ID of the holder.
+	AdditionalHolders []*HolderProofOptions `json:"additionalHolders,omitempty"`
 	// SkipVerify can be used to skip verification of `VerifiableCredentials` provided.
 	SkipVerify bool `json:"skipVerify,omitempty"`
 }
 
+// HolderProofOptions is model for the proof of a presentation by an additional holder.
+type HolderProofOptions struct {
+	// DID of the holder.
+	DID string `json:"did"`
+	// KID of the key signing the proof, resolved from the verification method if omitted.
+	KID string `json:"kid,omitempty"`
+	// VerificationMethod is the URI of the verificationMethod used for the proof, it must be an authentication
+	// method of the DID. If omitted the first authentication method of the DID will be used.
+	VerificationMethod string `json:"verificationMethod,omitempty"`
+}
+
 // IDArg model
 //
 // This is used for querying/removing by ID from input json.
@@ -123,6 +140,60 @@ type RemoveCredentialByNameResponse struct{}
 // from the verifiable store.
 type RemovePresentationByNameResponse struct{}
 
+// UpdateCredentialNameRequest is request model for renaming a vc in the verifiable store.
+type UpdateCredentialNameRequest struct {
+	// Name is the current name of the credential.
+	Name string `json:"name"`
+	// NewName is the name the credential is saved under.
+	NewName string `json:"newName"`
+}
+
+// UpdateCredentialNameResponse is a response model for renaming a vc in the verifiable store.
+type UpdateCredentialNameResponse struct{}
+
+// CredentialTagsRequest is request model for adding or removing tags of a vc in the verifiable store.
+type CredentialTagsRequest struct {
+	// Name of the credential.
+	Name string `json:"name"`
+	// Tags to be added or removed.
+	Tags []string `json:"tags"`
+}
+
+// CredentialTagsResponse is a response model for adding or removing tags of a vc in the verifiable store.
+type CredentialTagsResponse struct{}
+
+// CollectionRequest is request model for creating, removing or listing a collection of vcs in the verifiable store.
+type CollectionRequest struct {
+	// Name of the collection.
+	Name string `json:"name"`
+}
+
+// CollectionResponse is a response model for creating or removing a collection of vcs in the verifiable store.
+type CollectionResponse struct{}
+
+// CollectionsResponse is a response model for retrieving the collections of vcs in the verifiable store.
+type CollectionsResponse struct {
+	// Collections are the names of the collections.
+	Collections []string `json:"collections,omitempty"`
+}
+
+// MoveCredentialRequest is request model for moving a vc to a collection in the verifiable store.
+type MoveCredentialRequest struct {
+	// Name of the credential.
+	Name string `json:"name"`
+	// Collection the credential is moved to, the credential is moved out of any collection if empty.
+	Collection string `json:"collection,omitempty"`
+}
+
+// MoveCredentialResponse is a response model for moving a vc to a collection in the verifiable store.
+type MoveCredentialResponse struct{}
+
+// RotateEncryptionKeyResponse is a response model for rotating the encryption key of the verifiable store.
+type RotateEncryptionKeyResponse struct {
+	// KeyID is the ID of the new encryption key.
+	KeyID string `json:"keyID"`
+}
+
 // DeriveCredentialRequest is request for deriving credential.
 type DeriveCredentialRequest struct {
 	// Raw Credential from which a new credential will be derived
@@ -134,3 +205,11 @@ type DeriveCredentialRequest struct {
 	// SkipVerify can be used to skip verification of `Credential` provided.
 	SkipVerify bool `json:"skipVerify,omitempty"`
 }
+
+// VerifyCredentialResponse is the report of verifiable credential verification.
+type VerifyCredentialResponse struct {
+	// Verified is true if none of the checks failed.
+	Verified bool `json:"verified"`
+	// Checks are the outcomes of signature, issuance window, status, schema and issuer trust checks.
+	Checks []*docverifiable.CheckResult `json:"checks"`
+}
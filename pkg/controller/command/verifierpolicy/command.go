@@ -0,0 +1,234 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifierpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/verifierpolicy"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/verifierpolicy")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.VerifierPolicy)
+
+	// SavePolicyErrorCode for save policy error.
+	SavePolicyErrorCode
+
+	// GetPolicyErrorCode for get policy error.
+	GetPolicyErrorCode
+
+	// GetPoliciesErrorCode for get policies error.
+	GetPoliciesErrorCode
+
+	// RemovePolicyErrorCode for remove policy error.
+	RemovePolicyErrorCode
+
+	// RequestProofByPolicyErrorCode for request proof by policy error.
+	RequestProofByPolicyErrorCode
+)
+
+// constants for the verifier policy controller's methods.
+const (
+	// command name.
+	CommandName = "verifierpolicy"
+
+	// command methods.
+	SavePolicyCommandMethod           = "SavePolicy"
+	GetPolicyCommandMethod            = "GetPolicy"
+	GetPoliciesCommandMethod          = "GetPolicies"
+	RemovePolicyCommandMethod         = "RemovePolicy"
+	RequestProofByPolicyCommandMethod = "RequestProofByPolicy"
+
+	// error messages.
+	errEmptyPolicyName   = "policy name is mandatory"
+	errEmptyConnectionID = "connection ID is mandatory"
+
+	// log constants.
+	successString = "success"
+	policyString  = "policy"
+)
+
+// Command contains command operations managing the verifier policies and requesting the proofs defined by them.
+type Command struct {
+	client *verifierpolicy.Client
+}
+
+// New returns new verifier policy controller command instance.
+func New(ctx verifierpolicy.Provider, opts ...verifierpolicy.Opt) (*Command, error) {
+	client, err := verifierpolicy.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create verifier policy client: %w", err)
+	}
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, SavePolicyCommandMethod, c.SavePolicy),
+		cmdutil.NewCommandHandler(CommandName, GetPolicyCommandMethod, c.GetPolicy),
+		cmdutil.NewCommandHandler(CommandName, GetPoliciesCommandMethod, c.GetPolicies),
+		cmdutil.NewCommandHandler(CommandName, RemovePolicyCommandMethod, c.RemovePolicy),
+		cmdutil.NewCommandHandler(CommandName, RequestProofByPolicyCommandMethod, c.RequestProofByPolicy),
+	}
+}
+
+// SavePolicy saves the verifier policy, replacing the policy having the same name.
+func (c *Command) SavePolicy(rw io.Writer, req io.Reader) command.Error {
+	var request SavePolicyArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SavePolicyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, SavePolicyCommandMethod, errEmptyPolicyName)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPolicyName))
+	}
+
+	if err = c.client.SavePolicy(&request.Policy); err != nil {
+		logutil.LogError(logger, CommandName, SavePolicyCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(policyString, request.Name))
+		return command.NewExecuteError(SavePolicyErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, SavePolicyCommandMethod, successString,
+		logutil.CreateKeyValueString(policyString, request.Name))
+
+	return nil
+}
+
+// GetPolicy returns the verifier policy with the given name.
+func (c *Command) GetPolicy(rw io.Writer, req io.Reader) command.Error {
+	var request PolicyNameArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetPolicyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, GetPolicyCommandMethod, errEmptyPolicyName)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPolicyName))
+	}
+
+	policy, err := c.client.Policy(request.Name)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetPolicyCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(policyString, request.Name))
+		return command.NewExecuteError(GetPolicyErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &PolicyResult{Policy: policy}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetPolicyCommandMethod, successString,
+		logutil.CreateKeyValueString(policyString, request.Name))
+
+	return nil
+}
+
+// GetPolicies returns the verifier policies sorted by name.
+func (c *Command) GetPolicies(rw io.Writer, _ io.Reader) command.Error {
+	policies, err := c.client.Policies()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetPoliciesCommandMethod, err.Error())
+		return command.NewExecuteError(GetPoliciesErrorCode, err)
+	}
+
+	if policies == nil {
+		policies = []*verifierpolicy.Policy{}
+	}
+
+	command.WriteNillableResponse(rw, &PoliciesResult{Policies: policies}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetPoliciesCommandMethod, successString)
+
+	return nil
+}
+
+// RemovePolicy removes the verifier policy with the given name.
+func (c *Command) RemovePolicy(rw io.Writer, req io.Reader) command.Error {
+	var request PolicyNameArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RemovePolicyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name == "" {
+		logutil.LogDebug(logger, CommandName, RemovePolicyCommandMethod, errEmptyPolicyName)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPolicyName))
+	}
+
+	if err = c.client.RemovePolicy(request.Name); err != nil {
+		logutil.LogError(logger, CommandName, RemovePolicyCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(policyString, request.Name))
+		return command.NewExecuteError(RemovePolicyErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RemovePolicyCommandMethod, successString,
+		logutil.CreateKeyValueString(policyString, request.Name))
+
+	return nil
+}
+
+// RequestProofByPolicy requests the presentation defined by the policy over the connection, evaluates
+// the presentation received against the policy and returns the decision. The presentation is accepted or
+// declined according to the decision.
+func (c *Command) RequestProofByPolicy(rw io.Writer, req io.Reader) command.Error {
+	var request RequestProofByPolicyArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RequestProofByPolicyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, RequestProofByPolicyCommandMethod, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	if request.PolicyName == "" {
+		logutil.LogDebug(logger, CommandName, RequestProofByPolicyCommandMethod, errEmptyPolicyName)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPolicyName))
+	}
+
+	decision, err := c.client.RequestProof(request.ConnectionID, request.PolicyName)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RequestProofByPolicyCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(policyString, request.PolicyName))
+		return command.NewExecuteError(RequestProofByPolicyErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RequestProofByPolicyResult{Decision: decision}, logger)
+
+	logutil.LogDebug(logger, CommandName, RequestProofByPolicyCommandMethod, successString,
+		logutil.CreateKeyValueString(policyString, request.PolicyName))
+
+	return nil
+}
@@ -0,0 +1,214 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifierpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/verifierpolicy"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	connID = "conn-1"
+	piid   = "piid-1"
+	policy = `{
+		"name":"degree",
+		"presentationDefinition":{
+			"id":"degree_definition",
+			"input_descriptors":[{"id":"degree_input","schema":[{"uri":"https://www.w3.org/2018/credentials/v1"}]}]
+		},
+		"trustedIssuers":["did:example:issuer"]
+	}`
+)
+
+func newMockProvider(t *testing.T, svc interface{}) *mockprovider.Provider {
+	t.Helper()
+
+	prov := &mockprovider.Provider{
+		ServiceValue:                      svc,
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue:                   &mockvdr.MockVDRegistry{},
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        connection.StateNameCompleted,
+		MyDID:        "did:example:verifier",
+		TheirDID:     "did:example:holder",
+	}))
+
+	return prov
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd, err := New(newMockProvider(t, mocks.NewMockProtocolService(ctrl)))
+	require.NoError(t, err)
+	require.Len(t, cmd.GetHandlers(), 5)
+
+	_, err = New(&mockprovider.Provider{
+		StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "create verifier policy client")
+}
+
+func TestCommand_Policies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd, err := New(newMockProvider(t, mocks.NewMockProtocolService(ctrl)))
+	require.NoError(t, err)
+
+	t.Run("test save, get, list and remove policies - success", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, cmd.GetPolicies(&b, nil))
+		require.JSONEq(t, `{"policies":[]}`, b.String())
+
+		b.Reset()
+		require.NoError(t, cmd.SavePolicy(&b, bytes.NewBufferString(policy)))
+
+		b.Reset()
+		require.NoError(t, cmd.GetPolicy(&b, bytes.NewBufferString(`{"name":"degree"}`)))
+
+		var result PolicyResult
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+		require.Equal(t, "degree", result.Policy.Name)
+		require.Equal(t, []string{"did:example:issuer"}, result.Policy.TrustedIssuers)
+
+		b.Reset()
+		require.NoError(t, cmd.GetPolicies(&b, nil))
+
+		var policies PoliciesResult
+		require.NoError(t, json.Unmarshal(b.Bytes(), &policies))
+		require.Len(t, policies.Policies, 1)
+
+		b.Reset()
+		require.NoError(t, cmd.RemovePolicy(&b, bytes.NewBufferString(`{"name":"degree"}`)))
+
+		cmdErr := cmd.GetPolicy(&b, bytes.NewBufferString(`{"name":"degree"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetPolicyErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("test save, get and remove policies - validation errors", func(t *testing.T) {
+		for _, fn := range []command.Exec{cmd.SavePolicy, cmd.GetPolicy, cmd.RemovePolicy} {
+			var b bytes.Buffer
+			cmdErr := fn(&b, bytes.NewBufferString("--"))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+
+			cmdErr = fn(&b, bytes.NewBufferString(`{}`))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.EqualError(t, cmdErr, errEmptyPolicyName)
+		}
+	})
+
+	t.Run("test save policy - invalid presentation definition", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.SavePolicy(&b, bytes.NewBufferString(`{"name":"degree"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, SavePolicyErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("test get and remove policies - store errors", func(t *testing.T) {
+		prov := newMockProvider(t, mocks.NewMockProtocolService(ctrl))
+		storeProvider := mockstore.NewMockStoreProvider()
+		prov.StorageProviderValue = storeProvider
+
+		errCmd, err := New(prov)
+		require.NoError(t, err)
+
+		storeProvider.Store.ErrQuery = errors.New("query error")
+		storeProvider.Store.ErrDelete = errors.New("delete error")
+
+		var b bytes.Buffer
+		cmdErr := errCmd.GetPolicies(&b, nil)
+		require.Error(t, cmdErr)
+		require.Equal(t, GetPoliciesErrorCode, cmdErr.Code())
+
+		cmdErr = errCmd.RemovePolicy(&b, bytes.NewBufferString(`{"name":"degree"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RemovePolicyErrorCode, cmdErr.Code())
+	})
+}
+
+func TestCommand_RequestProofByPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockProtocolService(ctrl)
+
+	cmd, err := New(newMockProvider(t, svc), verifierpolicy.WithPollInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.SavePolicy(&b, bytes.NewBufferString(policy)))
+
+	t.Run("test request proof by policy - declined", func(t *testing.T) {
+		svc.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(piid, nil)
+		svc.EXPECT().Actions().Return([]protocol.Action{{PIID: piid, Msg: service.NewDIDCommMsgMap(
+			protocol.Presentation{Type: protocol.PresentationMsgType},
+		)}}, nil)
+		svc.EXPECT().ActionStop(piid, gomock.Any()).Return(nil)
+
+		b.Reset()
+		require.NoError(t, cmd.RequestProofByPolicy(&b,
+			bytes.NewBufferString(`{"connectionID":"`+connID+`","policyName":"degree"}`)))
+
+		var result RequestProofByPolicyResult
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+		require.Equal(t, piid, result.Decision.PIID)
+		require.Equal(t, "degree", result.Decision.Policy)
+		require.False(t, result.Decision.Accepted)
+		require.Equal(t, []string{"presentations were not provided"}, result.Decision.Reasons)
+	})
+
+	t.Run("test request proof by policy - errors", func(t *testing.T) {
+		cmdErr := cmd.RequestProofByPolicy(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.RequestProofByPolicy(&b, bytes.NewBufferString(`{"policyName":"degree"}`))
+		require.Error(t, cmdErr)
+		require.EqualError(t, cmdErr, errEmptyConnectionID)
+
+		cmdErr = cmd.RequestProofByPolicy(&b, bytes.NewBufferString(`{"connectionID":"`+connID+`"}`))
+		require.Error(t, cmdErr)
+		require.EqualError(t, cmdErr, errEmptyPolicyName)
+
+		cmdErr = cmd.RequestProofByPolicy(&b, bytes.NewBufferString(`{"connectionID":"unknown","policyName":"degree"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RequestProofByPolicyErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
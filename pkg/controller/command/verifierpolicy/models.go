@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifierpolicy
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/client/verifierpolicy"
+)
+
+// SavePolicyArgs model
+//
+// This is used for saving a verifier policy: the presentation definition requested to the holders
+// and the rules accepting the credentials they present.
+//
+type SavePolicyArgs struct {
+	verifierpolicy.Policy
+}
+
+// PolicyNameArgs model
+//
+// This is used for getting or removing a verifier policy.
+//
+type PolicyNameArgs struct {
+	// Name of the policy
+	Name string `json:"name"`
+}
+
+// PolicyResult model
+//
+// This is used for returning a verifier policy.
+//
+type PolicyResult struct {
+	// Policy is the verifier policy
+	Policy *verifierpolicy.Policy `json:"policy"`
+}
+
+// PoliciesResult model
+//
+// This is used for returning the verifier policies.
+//
+type PoliciesResult struct {
+	// Policies are the verifier policies, sorted by name
+	Policies []*verifierpolicy.Policy `json:"policies"`
+}
+
+// RequestProofByPolicyArgs model
+//
+// This is used for requesting the presentation defined by a verifier policy over a connection.
+//
+type RequestProofByPolicyArgs struct {
+	// ConnectionID is the ID of the connection with the holder
+	ConnectionID string `json:"connectionID"`
+
+	// PolicyName is the name of the verifier policy
+	PolicyName string `json:"policyName"`
+}
+
+// RequestProofByPolicyResult model
+//
+// This is used for returning the decision on the presentation received.
+//
+type RequestProofByPolicyResult struct {
+	// Decision is the outcome of the evaluation of the presentation against the policy
+	Decision *verifierpolicy.Decision `json:"decision"`
+}
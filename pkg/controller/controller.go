@@ -10,7 +10,9 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	auditcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/audit"
 	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	historycmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/history"
 	introducecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	issuecredentialcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
@@ -18,10 +20,16 @@ import (
 	messagingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	outofbandcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	presentproofcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	settingscmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/settings"
+	statuscmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/status"
+	transportcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/transport"
 	vdrcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	verifierpolicycmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/verifierpolicy"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	auditrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/audit"
 	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
+	historyrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/history"
 	introducerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	issuecredentialrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
 	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
@@ -29,18 +37,25 @@ import (
 	messagingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/messaging"
 	outofbandrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
 	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
+	settingsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/settings"
+	statusrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/status"
+	transportrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/transport"
 	vdrrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
+	verifierpolicyrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifierpolicy"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 )
 
 type allOpts struct {
-	webhookURLs  []string
-	defaultLabel string
-	autoAccept   bool
-	msgHandler   command.MessageHandler
-	notifier     command.Notifier
+	webhookURLs    []string
+	webhookTargets []webnotifier.WebhookTarget
+	defaultLabel   string
+	autoAccept     bool
+	msgHandler     command.MessageHandler
+	notifier       command.Notifier
+	shortURLs      outofbandrest.ShortURLResolver
+	kmsOpts        []kmsrest.Opt
 }
 
 const wsPath = "/ws"
@@ -55,6 +70,14 @@ func WithWebhookURLs(webhookURLs ...string) Opt {
 	}
 }
 
+// WithWebhookTargets is an option for setting up webhook targets notified of the topics matching their filters,
+// with the internal or the typed payloads of the topics.
+func WithWebhookTargets(targets ...webnotifier.WebhookTarget) Opt {
+	return func(opts *allOpts) {
+		opts.webhookTargets = append(opts.webhookTargets, targets...)
+	}
+}
+
 // WithNotifier is an option for setting up a notifier which will notify clients of events.
 func WithNotifier(notifier command.Notifier) Opt {
 	return func(opts *allOpts) {
@@ -83,6 +106,22 @@ func WithMessageHandler(handler command.MessageHandler) Opt {
 	}
 }
 
+// WithShortURLResolver is an option enabling the REST handler which redirects the short out-of-band invitation URLs
+// to the invitation URLs.
+func WithShortURLResolver(resolver outofbandrest.ShortURLResolver) Opt {
+	return func(opts *allOpts) {
+		opts.shortURLs = resolver
+	}
+}
+
+// WithJWKS is an option enabling the REST handler which publishes the agent public keys selected by set as JWKS
+// document named name (see kmsrest.WithJWKS).
+func WithJWKS(name string, set *kmsrest.JWKSet) Opt {
+	return func(opts *allOpts) {
+		opts.kmsOpts = append(opts.kmsOpts, kmsrest.WithJWKS(name, set))
+	}
+}
+
 // GetRESTHandlers returns all REST handlers provided by controller.
 func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error) { // nolint: funlen,gocyclo
 	restAPIOpts := &allOpts{}
@@ -91,14 +130,11 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		opt(restAPIOpts)
 	}
 
-	notifier := restAPIOpts.notifier
-	if notifier == nil {
-		notifier = webnotifier.New(wsPath, restAPIOpts.webhookURLs)
-	}
+	notifier := newNotifier(ctx, restAPIOpts)
 
 	// DID Exchange REST operation
 	exchangeOp, err := didexchangerest.New(ctx, notifier, restAPIOpts.defaultLabel,
-		restAPIOpts.autoAccept)
+		restAPIOpts.autoAccept, didexchangecmd.WithSettings(ctx.Settings()))
 	if err != nil {
 		return nil, err
 	}
@@ -146,13 +182,39 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	}
 
 	// outofband REST operation
-	outofbandOp, err := outofbandrest.New(ctx, notifier)
+	var outofbandOpts []outofbandrest.Opt
+	if restAPIOpts.shortURLs != nil {
+		outofbandOpts = append(outofbandOpts, outofbandrest.WithShortURLResolver(restAPIOpts.shortURLs))
+	}
+
+	outofbandOp, err := outofbandrest.New(ctx, notifier, outofbandOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("create outofband rest command : %w", err)
 	}
 
 	// kms command operation
-	kmscmd := kmsrest.New(ctx)
+	kmscmd := kmsrest.New(ctx, restAPIOpts.kmsOpts...)
+
+	// status REST operation
+	statusOp := statusrest.New(ctx)
+
+	// transport REST operation
+	transportOp := transportrest.New(ctx)
+
+	// audit REST operation
+	auditOp := auditrest.New(ctx)
+
+	// credential history REST operation
+	historyOp := historyrest.New(ctx)
+
+	// settings REST operation
+	settingsOp := settingsrest.New(ctx)
+
+	// verifier policy REST operation
+	verifierpolicyOp, err := verifierpolicyrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create verifier policy rest command : %w", err)
+	}
 
 	// creat handlers from all operations
 	var allHandlers []rest.Handler
@@ -166,6 +228,12 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, introduceOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, outofbandOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, kmscmd.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, statusOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, transportOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, auditOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, historyOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, settingsOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, verifierpolicyOp.GetRESTHandlers()...)
 
 	nhp, ok := notifier.(handlerProvider)
 	if ok {
@@ -187,14 +255,11 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		opt(cmdOpts)
 	}
 
-	notifier := cmdOpts.notifier
-	if notifier == nil {
-		notifier = webnotifier.New(wsPath, cmdOpts.webhookURLs)
-	}
+	notifier := newNotifier(ctx, cmdOpts)
 
 	// did exchange command operation
 	didexcmd, err := didexchangecmd.New(ctx, notifier, cmdOpts.defaultLabel,
-		cmdOpts.autoAccept)
+		cmdOpts.autoAccept, didexchangecmd.WithSettings(ctx.Settings()))
 	if err != nil {
 		return nil, fmt.Errorf("failed initialized didexchange command: %w", err)
 	}
@@ -250,6 +315,27 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	// kms command operation
 	kmscmd := kms.New(ctx)
 
+	// status command operation
+	statuscommand := statuscmd.New(ctx)
+
+	// transport command operation
+	transportcommand := transportcmd.New(ctx)
+
+	// audit command operation
+	auditcommand := auditcmd.New(ctx)
+
+	// credential history command operation
+	historycommand := historycmd.New(ctx)
+
+	// settings command operation
+	settingscommand := settingscmd.New(ctx)
+
+	// verifier policy command operation
+	verifierpolicy, err := verifierpolicycmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create verifier policy command : %w", err)
+	}
+
 	var allHandlers []command.Handler
 	allHandlers = append(allHandlers, didexcmd.GetHandlers()...)
 	allHandlers = append(allHandlers, vcmd.GetHandlers()...)
@@ -261,6 +347,12 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, presentproof.GetHandlers()...)
 	allHandlers = append(allHandlers, introduce.GetHandlers()...)
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
+	allHandlers = append(allHandlers, statuscommand.GetHandlers()...)
+	allHandlers = append(allHandlers, transportcommand.GetHandlers()...)
+	allHandlers = append(allHandlers, auditcommand.GetHandlers()...)
+	allHandlers = append(allHandlers, historycommand.GetHandlers()...)
+	allHandlers = append(allHandlers, settingscommand.GetHandlers()...)
+	allHandlers = append(allHandlers, verifierpolicy.GetHandlers()...)
 
 	return allHandlers, nil
 }
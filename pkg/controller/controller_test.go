@@ -12,7 +12,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/client/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	outofbandrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
@@ -102,11 +105,22 @@ func TestGetRESTHandlers_Success(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, ctx)
 
+		shortener, err := outofband.NewStoreShortener(ctx.StorageProvider(), "https://agent.example.com")
+		require.NoError(t, err)
+
 		handlers, err := GetRESTHandlers(ctx, WithMessageHandler(msghandler.NewMockMsgServiceProvider()),
 			WithAutoAccept(true), WithDefaultLabel("sample-label"),
-			WithWebhookURLs("sample-wh-url"))
+			WithWebhookURLs("sample-wh-url"), WithShortURLResolver(shortener))
 		require.NoError(t, err)
 		require.NotEmpty(t, handlers)
+
+		var shortURLHandler bool
+
+		for _, h := range handlers {
+			shortURLHandler = shortURLHandler || h.Path() == outofbandrest.ResolveShortURL
+		}
+
+		require.True(t, shortURLHandler)
 	})
 }
 
@@ -121,6 +135,19 @@ func TestWithWebhookNotifierOption(t *testing.T) {
 	require.Equal(t, webhookURLs, controllerOpts.webhookURLs)
 }
 
+func TestWithWebhookTargetsOption(t *testing.T) {
+	controllerOpts := &allOpts{}
+
+	targets := []webnotifier.WebhookTarget{
+		{URL: "localhost:8080", Include: []string{webnotifier.TopicIssueCredential}, Typed: true},
+		{URL: "localhost:8081", Exclude: []string{"didexchange_*"}},
+	}
+
+	WithWebhookTargets(targets...)(controllerOpts)
+
+	require.Equal(t, targets, controllerOpts.webhookTargets)
+}
+
 func TestWithDefaultLabelOption(t *testing.T) {
 	controllerOpts := &allOpts{}
 
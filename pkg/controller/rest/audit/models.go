@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/audit"
+)
+
+// queryAuditReq model
+//
+// This is used for querying the audit log entries
+//
+// swagger:parameters queryAuditReq
+type queryAuditReq struct { // nolint: unused,deadcode
+	// Params for querying the audit log
+	//
+	// in: body
+	Params audit.QueryArgs
+}
+
+// queryAuditRes model
+//
+// This is used for returning the audit log entries
+//
+// swagger:response queryAuditRes
+type queryAuditRes struct { // nolint: unused,deadcode
+
+	// in: body
+	audit.QueryResult
+}
+
+// verifyAuditRes model
+//
+// This is used for returning the integrity check result of the audit log
+//
+// swagger:response verifyAuditRes
+type verifyAuditRes struct { // nolint: unused,deadcode
+
+	// in: body
+	audit.VerifyResult
+}
+
+// exportAuditReq model
+//
+// This is used for exporting audit log entries as a signed log bundle
+//
+// swagger:parameters exportAuditReq
+type exportAuditReq struct { // nolint: unused,deadcode
+	// Params for exporting the audit log
+	//
+	// in: body
+	Params audit.ExportArgs
+}
+
+// exportAuditRes model
+//
+// This is used for returning the signed log bundle
+//
+// swagger:response exportAuditRes
+type exportAuditRes struct { // nolint: unused,deadcode
+
+	// in: body
+	audit.ExportResult
+}
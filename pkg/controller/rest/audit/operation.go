@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/audit"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	auditstore "github.com/hyperledger/aries-framework-go/pkg/store/audit"
+)
+
+// constants for the audit operations.
+const (
+	AuditOperationID = "/audit"
+	QueryPath        = AuditOperationID + "/query"
+	VerifyPath       = AuditOperationID + "/verify"
+	ExportPath       = AuditOperationID + "/export"
+)
+
+// provider contains dependencies for the audit controller operations
+// and is typically created by using aries.Context().
+type provider interface {
+	AuditLog() *auditstore.Log
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+}
+
+// Operation contains the operations querying, verifying and exporting the audit log of the credential operations.
+type Operation struct {
+	handlers []rest.Handler
+	command  *audit.Command
+}
+
+// New returns new audit operations rest client instance.
+func New(ctx provider) *Operation {
+	o := &Operation{command: audit.New(ctx)}
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(QueryPath, http.MethodPost, o.Query),
+		cmdutil.NewHTTPHandler(VerifyPath, http.MethodGet, o.Verify),
+		cmdutil.NewHTTPHandler(ExportPath, http.MethodPost, o.Export),
+	}
+}
+
+// Query swagger:route POST /audit/query audit queryAuditReq
+//
+// Returns the audit log entries matching the criteria.
+//
+// Responses:
+//    default: genericError
+//        200: queryAuditRes
+func (o *Operation) Query(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Query, rw, req.Body)
+}
+
+// Verify swagger:route GET /audit/verify audit verifyAudit
+//
+// Checks the integrity of the audit log.
+//
+// Responses:
+//    default: genericError
+//        200: verifyAuditRes
+func (o *Operation) Verify(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Verify, rw, req.Body)
+}
+
+// Export swagger:route POST /audit/export audit exportAuditReq
+//
+// Exports a range of audit log entries as a log bundle signed by a KMS key.
+//
+// Responses:
+//    default: genericError
+//        200: exportAuditRes
+func (o *Operation) Export(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Export, rw, req.Body)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/audit"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	auditstore "github.com/hyperledger/aries-framework-go/pkg/store/audit"
+)
+
+func TestNew(t *testing.T) {
+	op := New(&context.Provider{})
+	require.NotNil(t, op)
+	require.Len(t, op.GetRESTHandlers(), 3)
+}
+
+func TestOperation(t *testing.T) {
+	storeCtx, err := context.New(context.WithStorageProvider(mockstore.NewMockStoreProvider()))
+	require.NoError(t, err)
+
+	auditLog, err := auditstore.New(storeCtx)
+	require.NoError(t, err)
+
+	_, err = auditLog.Append(&auditstore.Event{Type: auditstore.EventIssued, ObjectID: "urn:uuid:vc-1"})
+	require.NoError(t, err)
+
+	ctx, err := context.New(context.WithAuditLog(auditLog))
+	require.NoError(t, err)
+
+	op := New(ctx)
+
+	t.Run("query and verify audit log", func(t *testing.T) {
+		rr := serve(t, op, QueryPath, http.MethodPost, bytes.NewBufferString(`{"type":"issued"}`))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result audit.QueryResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Len(t, result.Entries, 1)
+
+		rr = serve(t, op, VerifyPath, http.MethodGet, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"valid":true}`, rr.Body.String())
+	})
+
+	t.Run("export audit log - error", func(t *testing.T) {
+		rr := serve(t, op, ExportPath, http.MethodPost, bytes.NewBufferString(`{}`))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		errResponse := struct {
+			Code int `json:"code"`
+		}{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResponse))
+		require.EqualValues(t, audit.InvalidRequestErrorCode, errResponse.Code)
+	})
+}
+
+func serve(t *testing.T, op *Operation, path, method string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var handler rest.Handler
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == method {
+			handler = h
+		}
+	}
+
+	require.NotNil(t, handler)
+
+	req, err := http.NewRequest(method, path, body)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
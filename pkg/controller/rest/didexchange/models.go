@@ -49,6 +49,9 @@ type receiveInvitationRequest struct { // nolint: unused,deadcode
 	// in: body
 	Invitation struct {
 		*didexchangeSvc.Invitation
+
+		// Optional alias of the connection created for the invitation
+		Alias string `json:"alias,omitempty"`
 	}
 }
 
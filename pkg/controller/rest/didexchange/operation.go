@@ -47,8 +47,9 @@ type provider interface {
 }
 
 // New returns new DID Exchange rest client protocol instance.
-func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccept bool) (*Operation, error) {
-	dxcmd, err := didexchange.New(ctx, notifier, defaultLabel, autoAccept)
+func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccept bool,
+	opts ...didexchange.Option) (*Operation, error) {
+	dxcmd, err := didexchange.New(ctx, notifier, defaultLabel, autoAccept, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize did-exchange command : %w", err)
 	}
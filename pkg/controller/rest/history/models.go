@@ -0,0 +1,34 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/history"
+)
+
+// timelineReq model
+//
+// This is used for querying the timeline of the credential exchanges
+//
+// swagger:parameters timelineReq
+type timelineReq struct { // nolint: unused,deadcode
+	// Params for querying the credential history
+	//
+	// in: body
+	Params history.TimelineArgs
+}
+
+// timelineRes model
+//
+// This is used for returning the timeline of the credential exchanges
+//
+// swagger:response timelineRes
+type timelineRes struct { // nolint: unused,deadcode
+
+	// in: body
+	history.TimelineResult
+}
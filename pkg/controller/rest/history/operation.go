@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/history"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	historystore "github.com/hyperledger/aries-framework-go/pkg/store/history"
+)
+
+// constants for the credential history operations.
+const (
+	HistoryOperationID = "/history"
+	TimelinePath       = HistoryOperationID + "/timeline"
+)
+
+// provider contains dependencies for the credential history controller operations
+// and is typically created by using aries.Context().
+type provider interface {
+	CredentialHistory() *historystore.Store
+}
+
+// Operation contains the operations querying the history of the credential exchanges.
+type Operation struct {
+	handlers []rest.Handler
+	command  *history.Command
+}
+
+// New returns new credential history operations rest client instance.
+func New(ctx provider) *Operation {
+	o := &Operation{command: history.New(ctx)}
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(TimelinePath, http.MethodPost, o.Timeline),
+	}
+}
+
+// Timeline swagger:route POST /history/timeline history timelineReq
+//
+// Returns the events of the credential exchanges matching the criteria, in chronological order.
+//
+// Responses:
+//    default: genericError
+//        200: timelineRes
+func (o *Operation) Timeline(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Timeline, rw, req.Body)
+}
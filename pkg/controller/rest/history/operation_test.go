@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/history"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	historystore "github.com/hyperledger/aries-framework-go/pkg/store/history"
+)
+
+func TestNew(t *testing.T) {
+	op := New(&context.Provider{})
+	require.NotNil(t, op)
+	require.Len(t, op.GetRESTHandlers(), 1)
+}
+
+func TestOperation(t *testing.T) {
+	storeCtx, err := context.New(
+		context.WithStorageProvider(mockstore.NewMockStoreProvider()),
+		context.WithProtocolStateStorageProvider(mockstore.NewMockStoreProvider()),
+	)
+	require.NoError(t, err)
+
+	store, err := historystore.New(storeCtx)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Record(&historystore.Event{
+		Type: historystore.EventIssued, CredentialIDs: []string{"urn:uuid:vc-1"},
+	}))
+
+	ctx, err := context.New(context.WithCredentialHistory(store))
+	require.NoError(t, err)
+
+	op := New(ctx)
+
+	t.Run("timeline of the credential", func(t *testing.T) {
+		rr := serve(t, op, TimelinePath, http.MethodPost, bytes.NewBufferString(`{"credentialID":"urn:uuid:vc-1"}`))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result history.TimelineResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Len(t, result.Events, 1)
+		require.Equal(t, historystore.EventIssued, result.Events[0].Type)
+	})
+
+	t.Run("timeline - error", func(t *testing.T) {
+		rr := serve(t, op, TimelinePath, http.MethodPost, bytes.NewBufferString(`--`))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		errResponse := struct {
+			Code int `json:"code"`
+		}{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResponse))
+		require.EqualValues(t, history.InvalidRequestErrorCode, errResponse.Code)
+	})
+}
+
+func serve(t *testing.T, op *Operation, path, method string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var handler rest.Handler
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == method {
+			handler = h
+		}
+	}
+
+	require.NotNil(t, handler)
+
+	req, err := http.NewRequest(method, path, body)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
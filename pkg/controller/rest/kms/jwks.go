@@ -0,0 +1,195 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	cmdkms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	ed25519VerificationKey2018        = "Ed25519VerificationKey2018"
+	ecdsaSecp256k1VerificationKey2019 = "EcdsaSecp256k1VerificationKey2019"
+	jwkSetContentType                 = "application/jwk-set+json"
+)
+
+// JWKSet selects the agent public keys published in a JWKS document (RFC 7517), e.g. the keys of a DID or the keys
+// used for a purpose such as signing JWTs. The keys are read from the KMS and the VDR for every request, the document
+// follows key rotations without any republication.
+type JWKSet struct {
+	// DID whose verification methods are published (e.g. a DID of the agent stored in the VDR).
+	DID string
+	// Purposes of the published verification methods of the DID, all verification methods are published if empty.
+	Purposes []did.VerificationRelationship
+	// Keys are the KMS keys published, mapped by key ID to their key type. The key IDs are used as "kid".
+	Keys map[string]kms.KeyType
+}
+
+// Opt is an option of the kms REST operations.
+type Opt func(o *Operation)
+
+// WithJWKS enables the handler publishing the public keys selected by set as JWKS document at
+// <agent URL>/kms/jwks/<name>. The option can be repeated to publish several sets.
+func WithJWKS(name string, set *JWKSet) Opt {
+	return func(o *Operation) {
+		if o.jwks == nil {
+			o.jwks = make(map[string]*JWKSet)
+		}
+
+		o.jwks[name] = set
+	}
+}
+
+// JWKS swagger:route GET /kms/jwks/{name} kms jwks
+//
+// Publishes a set of agent public keys as JWKS document.
+//
+// Responses:
+//    default: genericError
+//        200: jwksRes
+func (o *Operation) JWKS(rw http.ResponseWriter, req *http.Request) {
+	name := mux.Vars(req)["name"]
+
+	set, ok := o.jwks[name]
+	if !ok {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, cmdkms.JWKSError,
+			fmt.Errorf("JWKS '%s' not found", name))
+
+		return
+	}
+
+	keys, err := o.jwkSetKeys(set)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, cmdkms.JWKSError, err)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", jwkSetContentType)
+
+	err = json.NewEncoder(rw).Encode(&jwksDocument{Keys: keys})
+	if err != nil {
+		logger.Errorf("Unable to send JWKS '%s', %s", name, err)
+	}
+}
+
+type jwksDocument struct {
+	Keys []*jose.JWK `json:"keys"`
+}
+
+func (o *Operation) jwkSetKeys(set *JWKSet) ([]*jose.JWK, error) {
+	keys := make([]*jose.JWK, 0, len(set.Keys))
+
+	for keyID, kt := range set.Keys {
+		pubKeyBytes, err := o.kms.ExportPubKeyBytes(keyID)
+		if err != nil {
+			return nil, fmt.Errorf("export public key %s: %w", keyID, err)
+		}
+
+		jwk, err := pubKeyBytesToJWK(pubKeyBytes, kt)
+		if err != nil {
+			return nil, fmt.Errorf("public key %s: %w", keyID, err)
+		}
+
+		jwk.KeyID = keyID
+
+		keys = append(keys, jwk)
+	}
+
+	if set.DID == "" {
+		return keys, nil
+	}
+
+	didKeys, err := o.didKeys(set.DID, set.Purposes)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(keys, didKeys...), nil
+}
+
+func (o *Operation) didKeys(didID string, purposes []did.VerificationRelationship) ([]*jose.JWK, error) {
+	docResolution, err := o.vdr.Resolve(didID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve DID %s: %w", didID, err)
+	}
+
+	doc := docResolution.DIDDocument
+
+	var keys []*jose.JWK
+
+	published := make(map[string]bool)
+
+	for _, verifications := range doc.VerificationMethods(purposes...) {
+		for i := range verifications {
+			vm := verifications[i].VerificationMethod
+
+			kid := vm.ID
+			if strings.HasPrefix(kid, "#") {
+				kid = doc.ID + kid
+			}
+
+			if published[kid] {
+				continue
+			}
+
+			jwk, err := verificationMethodJWK(&vm)
+			if err != nil {
+				return nil, fmt.Errorf("verification method %s: %w", kid, err)
+			}
+
+			// verification methods which cannot be represented as JWK (e.g. BLS12-381 keys) are not published
+			if jwk == nil {
+				continue
+			}
+
+			jwk.KeyID = kid
+			published[kid] = true
+
+			keys = append(keys, jwk)
+		}
+	}
+
+	return keys, nil
+}
+
+func verificationMethodJWK(vm *did.VerificationMethod) (*jose.JWK, error) {
+	if jwk := vm.JSONWebKey(); jwk != nil {
+		copied := *jwk
+
+		return &copied, nil
+	}
+
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		return pubKeyBytesToJWK(vm.Value, kms.ED25519Type)
+	case ecdsaSecp256k1VerificationKey2019:
+		return pubKeyBytesToJWK(vm.Value, kms.ECDSASecp256k1TypeIEEEP1363)
+	default:
+		return nil, nil
+	}
+}
+
+func pubKeyBytesToJWK(pubKeyBytes []byte, kt kms.KeyType) (*jose.JWK, error) {
+	if kt == kms.ED25519Type {
+		return jose.JWKFromKey(ed25519.PublicKey(pubKeyBytes))
+	}
+
+	return jwkkid.BuildJWK(pubKeyBytes, kt)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cmdkms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+func TestJWKS(t *testing.T) {
+	kmsPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	didPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	doc := &did.Doc{
+		ID: "did:example:123",
+		VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, "did:example:123", didPubKey),
+			*did.NewVerificationMethodFromBytes("#key-2", "Bls12381G2Key2020", "did:example:123", []byte("bls")),
+		},
+	}
+	doc.AssertionMethod = []did.Verification{{VerificationMethod: doc.VerificationMethod[0]}}
+
+	keyManager := &mockkms.KeyManager{ExportPubKeyBytesValue: kmsPubKey}
+
+	op := New(&mockprovider.Provider{
+		KMSValue:        keyManager,
+		VDRegistryValue: &mockvdr.MockVDRegistry{ResolveValue: doc},
+	},
+		WithJWKS("signing", &JWKSet{Keys: map[string]kms.KeyType{"kid-1": kms.ED25519Type}}),
+		WithJWKS("did", &JWKSet{DID: doc.ID, Purposes: []did.VerificationRelationship{did.AssertionMethod}}),
+		WithJWKS("all", &JWKSet{DID: doc.ID, Keys: map[string]kms.KeyType{"kid-1": kms.ED25519Type}}),
+	)
+	require.Len(t, op.GetRESTHandlers(), 3)
+
+	handler := lookupGetHandler(t, op, JWKSPath)
+
+	t.Run("KMS keys", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, strings.Replace(JWKSPath, "{name}", "signing", 1))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		keys := parseJWKS(t, buf.Bytes())
+		require.Len(t, keys, 1)
+		require.Equal(t, "kid-1", keys[0].KeyID)
+		require.Equal(t, kmsPubKey, keys[0].Key)
+	})
+
+	t.Run("DID verification methods by purpose", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, strings.Replace(JWKSPath, "{name}", "did", 1))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		keys := parseJWKS(t, buf.Bytes())
+		require.Len(t, keys, 1)
+		require.Equal(t, "did:example:123#key-1", keys[0].KeyID)
+		require.Equal(t, didPubKey, keys[0].Key)
+	})
+
+	t.Run("KMS keys and all DID verification methods", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, strings.Replace(JWKSPath, "{name}", "all", 1))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		// the BLS12-381 key is not published
+		require.Len(t, parseJWKS(t, buf.Bytes()), 2)
+	})
+
+	t.Run("unknown JWKS", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, strings.Replace(JWKSPath, "{name}", "unknown", 1))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, code)
+		verifyError(t, cmdkms.JWKSError, "JWKS 'unknown' not found", buf.Bytes())
+	})
+
+	t.Run("KMS export error", func(t *testing.T) {
+		keyManager.ExportPubKeyBytesErr = errors.New("key not found")
+		defer func() { keyManager.ExportPubKeyBytesErr = nil }()
+
+		buf, code, err := sendRequestToHandler(handler, nil, strings.Replace(JWKSPath, "{name}", "signing", 1))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, cmdkms.JWKSError, "export public key kid-1: key not found", buf.Bytes())
+	})
+
+	t.Run("DID resolution error", func(t *testing.T) {
+		op := New(&mockprovider.Provider{
+			KMSValue:        keyManager,
+			VDRegistryValue: &mockvdr.MockVDRegistry{ResolveErr: errors.New("DID not found")},
+		}, WithJWKS("did", &JWKSet{DID: doc.ID}))
+
+		buf, code, err := sendRequestToHandler(lookupGetHandler(t, op, JWKSPath), nil,
+			strings.Replace(JWKSPath, "{name}", "did", 1))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, cmdkms.JWKSError, "resolve DID did:example:123: DID not found", buf.Bytes())
+	})
+}
+
+func lookupGetHandler(t *testing.T, op *Operation, path string) rest.Handler {
+	t.Helper()
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == http.MethodGet {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+func parseJWKS(t *testing.T, data []byte) []*jose.JWK {
+	t.Helper()
+
+	keys := struct {
+		Keys []json.RawMessage `json:"keys"`
+	}{}
+
+	require.NoError(t, json.Unmarshal(data, &keys))
+
+	jwks := make([]*jose.JWK, len(keys.Keys))
+
+	for i, k := range keys.Keys {
+		jwks[i] = &jose.JWK{}
+		require.NoError(t, jwks[i].UnmarshalJSON(k))
+	}
+
+	return jwks
+}
@@ -43,3 +43,27 @@ type importKeyReq struct {
 	// in: body
 	kms.JSONWebKey
 }
+
+// jwksRes model
+//
+// This is used for returning the JWKS document of agent public keys
+//
+// swagger:response jwksRes
+type jwksRes struct { // nolint: unused,deadcode
+
+	// in: body
+	Keys []kms.JSONWebKey `json:"keys"`
+}
+
+// jwksReq model
+//
+// This is used for the JWKS request
+//
+// swagger:parameters jwks
+type jwksReq struct { // nolint: unused,deadcode
+	// Name of the JWKS document
+	//
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
@@ -10,23 +10,29 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	cmdkms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
+var logger = log.New("aries-framework/rest/kms")
+
 // constants for KMS operations.
 const (
 	KmsOperationID   = "/kms"
 	CreateKeySetPath = KmsOperationID + "/keyset"
 	ImportKeyPath    = KmsOperationID + "/import"
+	JWKSPath         = KmsOperationID + "/jwks/{name}"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
 type provider interface {
 	KMS() kms.KeyManager
+	VDRegistry() vdrapi.Registry
 }
 
 type kmsCommand interface {
@@ -38,13 +44,25 @@ type kmsCommand interface {
 type Operation struct {
 	handlers []rest.Handler
 	command  kmsCommand
+	kms      kms.KeyManager
+	vdr      vdrapi.Registry
+	jwks     map[string]*JWKSet
 }
 
 // New returns new kms operations rest client instance.
-func New(p provider) *Operation {
+func New(p provider, opts ...Opt) *Operation {
 	cmd := cmdkms.New(p)
 
-	o := &Operation{command: cmd}
+	o := &Operation{
+		command: cmd,
+		kms:     p.KMS(),
+		vdr:     p.VDRegistry(),
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	o.registerHandler()
 
 	return o
@@ -61,6 +79,10 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(CreateKeySetPath, http.MethodPost, o.CreateKeySet),
 		cmdutil.NewHTTPHandler(ImportKeyPath, http.MethodPost, o.ImportKey),
 	}
+
+	if len(o.jwks) > 0 {
+		o.handlers = append(o.handlers, cmdutil.NewHTTPHandler(JWKSPath, http.MethodGet, o.JWKS))
+	}
 }
 
 // CreateKeySet swagger:route POST /kms/keyset kms createKeySet
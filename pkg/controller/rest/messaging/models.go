@@ -92,3 +92,26 @@ type sendMessageResponse struct { // nolint: unused,deadcode
 	// in: body
 	Response json.RawMessage `json:"response,omitempty"`
 }
+
+// deliveryStatusRequest model
+//
+// This is used for operation to get the delivery status of a message
+//
+// swagger:parameters deliveryStatus
+type deliveryStatusRequest struct { // nolint: unused,deadcode
+	// Message ID
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// deliveryStatusResponse model
+//
+// Response of delivery status feature.
+//
+// swagger:response deliveryStatusResponse
+type deliveryStatusResponse struct { // nolint: unused,deadcode
+	// in: body
+	messaging.DeliveryStatusResponse
+}
@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package messaging
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
@@ -33,6 +36,7 @@ const (
 	MsgServiceList        = MsgServiceOperationID + "/services"
 	SendNewMsg            = MsgServiceOperationID + "/send"
 	SendReplyMsg          = MsgServiceOperationID + "/reply"
+	MsgDeliveryStatus     = MsgServiceOperationID + "/{id}/delivery-status"
 )
 
 // provider contains dependencies for the common controller operations
@@ -78,6 +82,7 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(MsgServiceList, http.MethodGet, o.Services),
 		cmdutil.NewHTTPHandler(SendNewMsg, http.MethodPost, o.Send),
 		cmdutil.NewHTTPHandler(SendReplyMsg, http.MethodPost, o.Reply),
+		cmdutil.NewHTTPHandler(MsgDeliveryStatus, http.MethodGet, o.DeliveryStatus),
 		cmdutil.NewHTTPHandler(RegisterHTTPOverDIDCommService, http.MethodPost, o.RegisterHTTPService),
 	}
 }
@@ -135,6 +140,19 @@ func (o *Operation) Reply(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Reply, rw, req.Body)
 }
 
+// DeliveryStatus swagger:route GET /message/{id}/delivery-status message deliveryStatus
+//
+// returns the delivery status of a message requesting an acknowledgement
+//
+// Responses:
+//    default: genericError
+//    200: deliveryStatusResponse
+func (o *Operation) DeliveryStatus(rw http.ResponseWriter, req *http.Request) {
+	request := fmt.Sprintf(`{"message_ID":%q}`, mux.Vars(req)["id"])
+
+	rest.Execute(o.command.DeliveryStatus, rw, bytes.NewBufferString(request))
+}
+
 // RegisterHTTPService swagger:route POST /http-over-didcomm/register http-over-didcomm registerHttpMsgSvc
 //
 // registers new http over didcomm service to message handler registrar
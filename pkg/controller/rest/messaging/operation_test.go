@@ -13,6 +13,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -718,6 +719,18 @@ func TestOperation_Reply(t *testing.T) {
 	})
 }
 
+func TestOperation_DeliveryStatus(t *testing.T) {
+	svc, err := New(&protocol.MockProvider{}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+	require.NotNil(t, svc)
+
+	handler := lookupCreatePublicDIDHandler(t, svc, MsgDeliveryStatus)
+	buf, code, err := sendRequestToHandler(handler, nil, strings.Replace(MsgDeliveryStatus, "{id}", "1234", 1))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusInternalServerError, code)
+	verifyError(t, messaging.DeliveryStatusError, "acknowledgement tracking is not enabled", buf.Bytes())
+}
+
 func lookupCreatePublicDIDHandler(t *testing.T, op *Operation, path string) rest.Handler {
 	t.Helper()
 
@@ -141,3 +141,26 @@ type outofbandActionStopResponse struct { // nolint: unused,deadcode
 	// in: body
 	Body struct{}
 }
+
+// outofbandResolveShortURLRequest model
+//
+// This is used for operation to resolve the short invitation URL.
+//
+// swagger:parameters outofbandResolveShortURL
+type outofbandResolveShortURLRequest struct { // nolint: unused,deadcode
+	// The code of the short invitation URL
+	//
+	// in: path
+	// required: true
+	Code string `json:"code"`
+}
+
+// outofbandResolveShortURLResponse model
+//
+// Redirects to the invitation URL.
+//
+// swagger:response outofbandResolveShortURLResponse
+type outofbandResolveShortURLResponse struct { // nolint: unused,deadcode
+	// The invitation URL
+	Location string `json:"Location"`
+}
@@ -8,6 +8,7 @@ package outofband
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -29,22 +30,46 @@ const (
 	Actions          = OperationID + "/actions"
 	ActionContinue   = OperationID + "/{piid}/action-continue"
 	ActionStop       = OperationID + "/{piid}/action-stop"
+	ResolveShortURL  = OperationID + "/url/{code}"
 )
 
+// ShortURLResolver resolves the codes of short invitation URLs back to the invitation URLs
+// (e.g. client.StoreShortener).
+type ShortURLResolver interface {
+	Resolve(code string) (string, error)
+}
+
+// Opt configures the outofband REST operation.
+type Opt func(o *Operation)
+
+// WithShortURLResolver enables the redirect handler resolving the codes of short invitation URLs.
+// The short URLs are expected to have the form <agent URL>/outofband/url/<code>.
+func WithShortURLResolver(r ShortURLResolver) Opt {
+	return func(o *Operation) {
+		o.shortURLResolver = r
+	}
+}
+
 // Operation is controller REST service controller for outofband.
 type Operation struct {
-	command  *outofband.Command
-	handlers []rest.Handler
+	command          *outofband.Command
+	handlers         []rest.Handler
+	shortURLResolver ShortURLResolver
 }
 
 // New returns new outofband rest client protocol instance.
-func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+func New(ctx client.Provider, notifier command.Notifier, opts ...Opt) (*Operation, error) {
 	cmd, err := outofband.New(ctx, notifier)
 	if err != nil {
 		return nil, fmt.Errorf("outofband command : %w", err)
 	}
 
 	o := &Operation{command: cmd}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	o.registerHandler()
 
 	return o, nil
@@ -65,6 +90,10 @@ func (c *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(ActionContinue, http.MethodPost, c.ActionContinue),
 		cmdutil.NewHTTPHandler(ActionStop, http.MethodPost, c.ActionStop),
 	}
+
+	if c.shortURLResolver != nil {
+		c.handlers = append(c.handlers, cmdutil.NewHTTPHandler(ResolveShortURL, http.MethodGet, c.ResolveShortURL))
+	}
 }
 
 // Actions swagger:route GET /outofband/actions outofband outofbandActions
@@ -128,3 +157,27 @@ func (c *Operation) CreateInvitation(rw http.ResponseWriter, req *http.Request)
 func (c *Operation) AcceptInvitation(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(c.command.AcceptInvitation, rw, req.Body)
 }
+
+// ResolveShortURL swagger:route GET /outofband/url/{code} outofband outofbandResolveShortURL
+//
+// Redirects to the invitation URL of the short invitation URL.
+//
+// Responses:
+//    default: genericError
+//        302: outofbandResolveShortURLResponse
+func (c *Operation) ResolveShortURL(rw http.ResponseWriter, req *http.Request) {
+	invitationURL, err := c.shortURLResolver.Resolve(mux.Vars(req)["code"])
+	if errors.Is(err, client.ErrShortCodeNotFound) {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, outofband.ResolveShortURLErrorCode, err)
+
+		return
+	}
+
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, outofband.ResolveShortURLErrorCode, err)
+
+		return
+	}
+
+	http.Redirect(rw, req, invitationURL, http.StatusFound)
+}
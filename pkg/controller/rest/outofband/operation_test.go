@@ -159,6 +159,90 @@ func TestOperation_ActionStop(t *testing.T) {
 	require.Equal(t, http.StatusOK, code)
 }
 
+type stubResolver struct {
+	urls map[string]string
+	err  error
+}
+
+func (r *stubResolver) Resolve(code string) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+
+	u, ok := r.urls[code]
+	if !ok {
+		return "", client.ErrShortCodeNotFound
+	}
+
+	return u, nil
+}
+
+func TestOperation_ResolveShortURL(t *testing.T) {
+	const invitationURL = "https://example.com?oob=e30"
+
+	resolve := func(op *Operation, code string) *httptest.ResponseRecorder {
+		handler := handlerLookup(t, op, ResolveShortURL)
+
+		req, err := http.NewRequest(handler.Method(), strings.Replace(ResolveShortURL, "{code}", code, 1), nil)
+		require.NoError(t, err)
+
+		router := mux.NewRouter()
+		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		for _, h := range operation.GetRESTHandlers() {
+			require.NotEqual(t, ResolveShortURL, h.Path())
+		}
+	})
+
+	t.Run("redirects to the invitation URL", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil),
+			WithShortURLResolver(&stubResolver{urls: map[string]string{"abc": invitationURL}}))
+		require.NoError(t, err)
+
+		rr := resolve(operation, "abc")
+		require.Equal(t, http.StatusFound, rr.Code)
+		require.Equal(t, invitationURL, rr.Header().Get("Location"))
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil),
+			WithShortURLResolver(&stubResolver{}))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusNotFound, resolve(operation, "abc").Code)
+	})
+
+	t.Run("resolver error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil),
+			WithShortURLResolver(&stubResolver{err: errors.New("test")}))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusInternalServerError, resolve(operation, "abc").Code)
+	})
+}
+
 func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
 	t.Helper()
 
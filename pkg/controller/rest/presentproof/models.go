@@ -6,7 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 
 package presentproof
 
-import protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+import (
+	"encoding/json"
+
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+)
 
 // presentProofActionsRequest model
 //
@@ -316,3 +321,145 @@ type presentProofAcceptProblemReportResponse struct { // nolint: unused,deadcode
 	// in: body
 	Body struct{}
 }
+
+// presentProofRecordsRequest model
+//
+// This is used for operation to query the records of the protocol instances.
+//
+// swagger:parameters presentProofRecords
+type presentProofRecordsRequest struct { // nolint: unused,deadcode
+	// State of the protocol instances (e.g. "request-sent", "done", "abandoned")
+	//
+	// in: query
+	State string `json:"state"`
+
+	// MyDID is the DID of the agent in the connection
+	//
+	// in: query
+	MyDID string `json:"my_did"`
+
+	// TheirDID is the DID of the other party in the connection
+	//
+	// in: query
+	TheirDID string `json:"their_did"`
+
+	// ThreadID of the protocol instances
+	//
+	// in: query
+	ThreadID string `json:"thread_id"`
+}
+
+// presentProofRecordsResponse model
+//
+// Represents a Records response message.
+//
+// swagger:response presentProofRecordsResponse
+type presentProofRecordsResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Records []struct{ *protocol.Record } `json:"records"`
+	}
+}
+
+// presentProofRecordRequest model
+//
+// This is used for operation to get the record of a protocol instance.
+//
+// swagger:parameters presentProofRecord
+type presentProofRecordRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+}
+
+// presentProofRecordResponse model
+//
+// Represents a Record response message.
+//
+// swagger:response presentProofRecordResponse
+type presentProofRecordResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Record struct{ *protocol.Record } `json:"record"`
+	}
+}
+
+// presentProofPresentationsRequest model
+//
+// This is used for operation to get the presentations of a protocol instance.
+//
+// swagger:parameters presentProofPresentations
+type presentProofPresentationsRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+}
+
+// presentProofPresentationsResponse model
+//
+// Represents a Presentations response message.
+//
+// swagger:response presentProofPresentationsResponse
+type presentProofPresentationsResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// Presentations are the contents of the presentation attachments
+		Presentations []json.RawMessage `json:"presentations"`
+	}
+}
+
+// presentProofAbandonRequest model
+//
+// This is used for operation to abandon a stale protocol instance.
+//
+// swagger:parameters presentProofAbandon
+type presentProofAbandonRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// Reason why the protocol instance is abandoned
+	Reason string `json:"reason"`
+}
+
+// presentProofAbandonResponse model
+//
+// Represents a Abandon response message.
+//
+// swagger:response presentProofAbandonResponse
+type presentProofAbandonResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
+
+// presentProofCredentialSuggestionsRequest model
+//
+// This is used for operation to get the credentials suggested for the pending request presentation.
+//
+// swagger:parameters presentProofCredentialSuggestions
+type presentProofCredentialSuggestionsRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+}
+
+// presentProofCredentialSuggestionsResponse model
+//
+// Represents a CredentialSuggestions response message.
+//
+// swagger:response presentProofCredentialSuggestionsResponse
+type presentProofCredentialSuggestionsResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// Suggestions are the candidate credentials of each input descriptor of the presentation definition
+		Suggestions []*presexch.Suggestion `json:"suggestions"`
+	}
+}
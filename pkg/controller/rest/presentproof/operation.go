@@ -38,6 +38,11 @@ const (
 	AcceptPresentation           = OperationID + "/{piid}/accept-presentation"
 	DeclinePresentation          = OperationID + "/{piid}/decline-presentation"
 	AcceptProblemReport          = OperationID + "/{piid}/accept-problem-report"
+	Records                      = OperationID + "/records"
+	Record                       = OperationID + "/{piid}/record"
+	Presentations                = OperationID + "/{piid}/presentations"
+	Abandon                      = OperationID + "/{piid}/abandon"
+	CredentialSuggestions        = OperationID + "/{piid}/credential-suggestions"
 )
 
 // Operation is controller REST service controller for present proof.
@@ -79,6 +84,11 @@ func (c *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(AcceptPresentation, http.MethodPost, c.AcceptPresentation),
 		cmdutil.NewHTTPHandler(DeclinePresentation, http.MethodPost, c.DeclinePresentation),
 		cmdutil.NewHTTPHandler(AcceptProblemReport, http.MethodPost, c.AcceptProblemReport),
+		cmdutil.NewHTTPHandler(Records, http.MethodGet, c.Records),
+		cmdutil.NewHTTPHandler(Record, http.MethodGet, c.Record),
+		cmdutil.NewHTTPHandler(Presentations, http.MethodGet, c.Presentations),
+		cmdutil.NewHTTPHandler(Abandon, http.MethodPost, c.Abandon),
+		cmdutil.NewHTTPHandler(CredentialSuggestions, http.MethodGet, c.CredentialSuggestions),
 	}
 }
 
@@ -222,6 +232,77 @@ func (c *Operation) DeclinePresentation(rw http.ResponseWriter, req *http.Reques
 	}`, mux.Vars(req)["piid"], req.URL.Query().Get("reason"))))
 }
 
+// Records swagger:route GET /presentproof/records present-proof presentProofRecords
+//
+// Returns the records of the protocol instances matching the state, connection and thread ID.
+//
+// Responses:
+//    default: genericError
+//        200: presentProofRecordsResponse
+func (c *Operation) Records(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	rest.Execute(c.command.Records, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"state":%q,
+		"my_did":%q,
+		"their_did":%q,
+		"thread_id":%q
+	}`, query.Get("state"), query.Get("my_did"), query.Get("their_did"), query.Get("thread_id"))))
+}
+
+// Record swagger:route GET /presentproof/{piid}/record present-proof presentProofRecord
+//
+// Returns the record of the protocol instance.
+//
+// Responses:
+//    default: genericError
+//        200: presentProofRecordResponse
+func (c *Operation) Record(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.Record, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"piid":%q
+	}`, mux.Vars(req)["piid"])))
+}
+
+// Presentations swagger:route GET /presentproof/{piid}/presentations present-proof presentProofPresentations
+//
+// Returns the presentations attached to the presentation of the protocol instance.
+//
+// Responses:
+//    default: genericError
+//        200: presentProofPresentationsResponse
+func (c *Operation) Presentations(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.Presentations, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"piid":%q
+	}`, mux.Vars(req)["piid"])))
+}
+
+// Abandon swagger:route POST /presentproof/{piid}/abandon present-proof presentProofAbandon
+//
+// Abandons a stale protocol instance.
+//
+// Responses:
+//    default: genericError
+//        200: presentProofAbandonResponse
+func (c *Operation) Abandon(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.Abandon, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"piid":%q,
+		"reason":%q
+	}`, mux.Vars(req)["piid"], req.URL.Query().Get("reason"))))
+}
+
+// CredentialSuggestions swagger:route GET /presentproof/{piid}/credential-suggestions present-proof presentProofCredentialSuggestions
+//
+// Returns the stored credentials satisfying the presentation definition of the pending request presentation.
+//
+// Responses:
+//    default: genericError
+//        200: presentProofCredentialSuggestionsResponse
+func (c *Operation) CredentialSuggestions(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.CredentialSuggestions, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"piid":%q
+	}`, mux.Vars(req)["piid"])))
+}
+
 func toCommandRequest(rw http.ResponseWriter, req *http.Request) (bool, io.Reader) {
 	var buf bytes.Buffer
 
@@ -20,6 +20,8 @@ import (
 
 	client "github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
 	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
 )
@@ -30,6 +32,12 @@ func provider(ctrl *gomock.Controller) client.Provider {
 	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
 	service.EXPECT().ActionContinue(gomock.Any(), gomock.Any()).AnyTimes()
 	service.EXPECT().ActionStop(gomock.Any(), gomock.Any()).AnyTimes()
+	service.EXPECT().Records(&protocol.RecordFilter{State: "done", ThreadID: "thid"}).
+		Return([]protocol.Record{{PIID: "1234", State: "done", ThreadID: "thid"}}, nil).AnyTimes()
+	service.EXPECT().Record("1234").Return(&protocol.Record{PIID: "1234", Presentation: &protocol.Presentation{
+		PresentationsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{JSON: map[string]interface{}{}}}},
+	}}, nil).AnyTimes()
+	service.EXPECT().Abandon("1234", "stale").AnyTimes()
 
 	provider := mocks.NewMockProvider(ctrl)
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil)
@@ -260,6 +268,105 @@ func TestOperation_NegotiateRequestPresentation(t *testing.T) {
 	})
 }
 
+func TestOperation_Records(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, Records),
+			nil,
+			Records+"?state=done&thread_id=thid",
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), `"piid":"1234"`)
+	})
+}
+
+func TestOperation_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, Record),
+			nil,
+			strings.Replace(Record, `{piid}`, "1234", 1),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), `"piid":"1234"`)
+	})
+}
+
+func TestOperation_Presentations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, Presentations),
+			nil,
+			strings.Replace(Presentations, `{piid}`, "1234", 1),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), `"presentations":[{}]`)
+	})
+}
+
+func TestOperation_Abandon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		_, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, Abandon),
+			nil,
+			strings.Replace(Abandon, `{piid}`, "1234", 1)+"?reason=stale",
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+	})
+}
+
+func TestOperation_CredentialSuggestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Not supported by the provider", func(t *testing.T) {
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, CredentialSuggestions),
+			nil,
+			strings.Replace(CredentialSuggestions, `{piid}`, "1234", 1),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Contains(t, buf.String(), "credential suggestions are not supported")
+	})
+}
+
 func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
 	t.Helper()
 
@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package settings
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/settings"
+)
+
+// setSettingReq model
+//
+// This is used for setting a runtime-tunable parameter of the agent
+//
+// swagger:parameters setSettingReq
+type setSettingReq struct { // nolint: unused,deadcode
+	// Params for setting the parameter
+	//
+	// in: body
+	Params settings.SetSettingArgs
+}
+
+// settingNameReq model
+//
+// This is used for getting or removing a setting
+//
+// swagger:parameters getSettingReq removeSettingReq
+type settingNameReq struct { // nolint: unused,deadcode
+	// Name of the setting
+	//
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
+// settingRes model
+//
+// This is used for returning a setting
+//
+// swagger:response settingRes
+type settingRes struct { // nolint: unused,deadcode
+
+	// in: body
+	settings.SettingResult
+}
+
+// settingsRes model
+//
+// This is used for returning the settings of the agent
+//
+// swagger:response settingsRes
+type settingsRes struct { // nolint: unused,deadcode
+
+	// in: body
+	settings.SettingsResult
+}
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package settings
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/settings"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	settingsstore "github.com/hyperledger/aries-framework-go/pkg/store/settings"
+)
+
+// constants for the settings operations.
+const (
+	SettingsOperationID = "/settings"
+	SettingPath         = SettingsOperationID + "/{name}"
+)
+
+// provider contains dependencies for the settings controller operations
+// and is typically created by using aries.Context().
+type provider interface {
+	Settings() *settingsstore.Store
+}
+
+// Operation contains the operations managing the runtime-tunable parameters of the agent.
+type Operation struct {
+	handlers []rest.Handler
+	command  *settings.Command
+}
+
+// New returns new settings operations rest client instance.
+func New(ctx provider) *Operation {
+	o := &Operation{command: settings.New(ctx)}
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(SettingsOperationID, http.MethodGet, o.GetSettings),
+		cmdutil.NewHTTPHandler(SettingsOperationID, http.MethodPost, o.SetSetting),
+		cmdutil.NewHTTPHandler(SettingPath, http.MethodGet, o.GetSetting),
+		cmdutil.NewHTTPHandler(SettingPath, http.MethodDelete, o.RemoveSetting),
+	}
+}
+
+// GetSettings swagger:route GET /settings settings getSettingsReq
+//
+// Returns the settings of the agent sorted by name.
+//
+// Responses:
+//    default: genericError
+//        200: settingsRes
+func (o *Operation) GetSettings(rw http.ResponseWriter, _ *http.Request) {
+	rest.Execute(o.command.GetSettings, rw, nil)
+}
+
+// SetSetting swagger:route POST /settings settings setSettingReq
+//
+// Sets the value of a setting, the components consuming it are notified of the change.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) SetSetting(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.SetSetting, rw, req.Body)
+}
+
+// GetSetting swagger:route GET /settings/{name} settings getSettingReq
+//
+// Returns the setting with the given name.
+//
+// Responses:
+//    default: genericError
+//        200: settingRes
+func (o *Operation) GetSetting(rw http.ResponseWriter, req *http.Request) {
+	request := fmt.Sprintf(`{"name":%q}`, mux.Vars(req)["name"])
+
+	rest.Execute(o.command.GetSetting, rw, bytes.NewBufferString(request))
+}
+
+// RemoveSetting swagger:route DELETE /settings/{name} settings removeSettingReq
+//
+// Removes the setting with the given name, the static startup option applies again.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RemoveSetting(rw http.ResponseWriter, req *http.Request) {
+	request := fmt.Sprintf(`{"name":%q}`, mux.Vars(req)["name"])
+
+	rest.Execute(o.command.RemoveSetting, rw, bytes.NewBufferString(request))
+}
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/settings"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	settingsstore "github.com/hyperledger/aries-framework-go/pkg/store/settings"
+)
+
+func TestNew(t *testing.T) {
+	op := New(&context.Provider{})
+	require.NotNil(t, op)
+	require.Len(t, op.GetRESTHandlers(), 4)
+}
+
+func TestOperation(t *testing.T) {
+	storeCtx, err := context.New(context.WithStorageProvider(mockstore.NewMockStoreProvider()))
+	require.NoError(t, err)
+
+	store, err := settingsstore.New(storeCtx)
+	require.NoError(t, err)
+
+	ctx, err := context.New(context.WithSettings(store))
+	require.NoError(t, err)
+
+	op := New(ctx)
+
+	t.Run("set, get and remove a setting", func(t *testing.T) {
+		rr := serve(t, op, SettingsOperationID, http.MethodPost, SettingsOperationID,
+			bytes.NewBufferString(`{"name":"webhook_urls","value":["http://example.com/webhook"]}`))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = serve(t, op, SettingsOperationID, http.MethodGet, SettingsOperationID, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var all settings.SettingsResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &all))
+		require.Len(t, all.Settings, 1)
+
+		rr = serve(t, op, SettingPath, http.MethodGet, SettingsOperationID+"/webhook_urls", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result settings.SettingResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Equal(t, settingsstore.WebhookURLs, result.Setting.Name)
+		require.JSONEq(t, `["http://example.com/webhook"]`, string(result.Setting.Value))
+
+		rr = serve(t, op, SettingPath, http.MethodDelete, SettingsOperationID+"/webhook_urls", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = serve(t, op, SettingPath, http.MethodGet, SettingsOperationID+"/webhook_urls", nil)
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("set a setting - error", func(t *testing.T) {
+		rr := serve(t, op, SettingsOperationID, http.MethodPost, SettingsOperationID, bytes.NewBufferString(`--`))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		errResponse := struct {
+			Code int `json:"code"`
+		}{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResponse))
+		require.EqualValues(t, settings.InvalidRequestErrorCode, errResponse.Code)
+	})
+}
+
+func serve(t *testing.T, op *Operation, path, method, url string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var handler rest.Handler
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == method {
+			handler = h
+		}
+	}
+
+	require.NotNil(t, handler)
+
+	req, err := http.NewRequest(method, url, body)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/status"
+)
+
+// healthRes model
+//
+// This is used for returning the liveness of the agent
+//
+// swagger:response healthRes
+type healthRes struct { // nolint: unused,deadcode
+
+	// in: body
+	status.HealthResponse
+}
+
+// statusRes model
+//
+// This is used for returning the state of the agent components
+//
+// swagger:response statusRes
+type statusRes struct { // nolint: unused,deadcode
+
+	// in: body
+	status.StatusResponse
+}
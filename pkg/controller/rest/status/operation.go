@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdstatus "github.com/hyperledger/aries-framework-go/pkg/controller/command/status"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/rest/status")
+
+// constants for the status operations.
+const (
+	HealthPath    = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+// provider contains dependencies for the status command and is typically created by using aries.Context().
+type provider interface {
+	InboundTransports() []transport.InboundTransport
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	KMS() kms.KeyManager
+	Service(id string) (interface{}, error)
+}
+
+// Operation contains the health and readiness probes of the agent.
+type Operation struct {
+	handlers []rest.Handler
+	command  *cmdstatus.Command
+}
+
+// New returns new status operations rest client instance.
+func New(p provider) *Operation {
+	o := &Operation{command: cmdstatus.New(p)}
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(HealthPath, http.MethodGet, o.Health),
+		cmdutil.NewHTTPHandler(ReadinessPath, http.MethodGet, o.Readiness),
+	}
+}
+
+// Health swagger:route GET /healthz status health
+//
+// Liveness probe of the agent.
+//
+// Responses:
+//    default: genericError
+//        200: healthRes
+func (o *Operation) Health(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Health, rw, req.Body)
+}
+
+// Readiness swagger:route GET /readyz status readiness
+//
+// Readiness probe of the agent, responds with status 503 while the agent is not ready.
+//
+// Responses:
+//    default: statusRes
+//        200: statusRes
+func (o *Operation) Readiness(rw http.ResponseWriter, _ *http.Request) {
+	report := o.command.Report()
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	if !report.Ready {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	command.WriteNillableResponse(rw, report, logger)
+}
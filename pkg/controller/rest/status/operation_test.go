@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/status"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNew(t *testing.T) {
+	op := New(&mockprovider.Provider{})
+	require.NotNil(t, op)
+	require.Len(t, op.GetRESTHandlers(), 2)
+}
+
+func TestOperation_Health(t *testing.T) {
+	op := New(&mockprovider.Provider{})
+
+	rr := serve(t, op, HealthPath)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	res := status.HealthResponse{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	require.Equal(t, status.HealthStatusUp, res.Status)
+}
+
+func TestOperation_Readiness(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		op := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+		})
+
+		rr := serve(t, op, ReadinessPath)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		res := status.StatusResponse{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		require.True(t, res.Ready)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		op := New(&mockprovider.Provider{})
+
+		rr := serve(t, op, ReadinessPath)
+		require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+		res := status.StatusResponse{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		require.False(t, res.Ready)
+	})
+}
+
+func serve(t *testing.T, op *Operation, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var handler rest.Handler
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path {
+			handler = h
+		}
+	}
+
+	require.NotNil(t, handler)
+
+	req, err := http.NewRequest(handler.Method(), path, nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.Handle().ServeHTTP(rr, req)
+
+	return rr
+}
@@ -0,0 +1,36 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/transport"
+)
+
+// registerOutboundReq model
+//
+// This is used for starting an outbound transport
+//
+// swagger:parameters registerOutboundReq
+type registerOutboundReq struct { // nolint: unused,deadcode
+	// Params for registering the outbound transport
+	//
+	// in: body
+	Params transport.OutboundArgs
+}
+
+// unregisterOutboundReq model
+//
+// This is used for removing the outbound transports of a scheme
+//
+// swagger:parameters unregisterOutboundReq
+type unregisterOutboundReq struct { // nolint: unused,deadcode
+	// Scheme of the outbound transports
+	//
+	// in: path
+	// required: true
+	Scheme string `json:"scheme"`
+}
@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	didcommtransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+// constants for the transport operations.
+const (
+	TransportOperationID   = "/transport"
+	outboundPath           = TransportOperationID + "/outbound"
+	RegisterOutboundPath   = outboundPath
+	UnregisterOutboundPath = outboundPath + "/{scheme}"
+)
+
+// provider contains dependencies for the transport controller operations
+// and is typically created by using aries.Context().
+type provider interface {
+	InboundMessageHandler() didcommtransport.InboundMessageHandler
+	Packager() didcommtransport.Packager
+	AriesFrameworkID() string
+	OutboundTransportRegistry() *didcommtransport.OutboundRegistry
+}
+
+// Operation contains the operations adding and removing the transports of the running agent.
+type Operation struct {
+	handlers []rest.Handler
+	command  *transport.Command
+}
+
+// New returns new transport operations rest client instance.
+func New(ctx provider) *Operation {
+	o := &Operation{command: transport.New(ctx)}
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(RegisterOutboundPath, http.MethodPost, o.RegisterOutbound),
+		cmdutil.NewHTTPHandler(UnregisterOutboundPath, http.MethodDelete, o.UnregisterOutbound),
+	}
+}
+
+// RegisterOutbound swagger:route POST /transport/outbound transport registerOutboundReq
+//
+// Starts an outbound transport of the given scheme on the running agent.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RegisterOutbound(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RegisterOutbound, rw, req.Body)
+}
+
+// UnregisterOutbound swagger:route DELETE /transport/outbound/{scheme} transport unregisterOutboundReq
+//
+// Removes the outbound transports of the given scheme from the running agent.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) UnregisterOutbound(rw http.ResponseWriter, req *http.Request) {
+	request := fmt.Sprintf(`{"scheme":%q}`, mux.Vars(req)["scheme"])
+
+	rest.Execute(o.command.UnregisterOutbound, rw, bytes.NewBufferString(request))
+}
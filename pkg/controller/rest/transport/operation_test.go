@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	didcommtransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+)
+
+func TestNew(t *testing.T) {
+	op := New(&context.Provider{})
+	require.NotNil(t, op)
+	require.Len(t, op.GetRESTHandlers(), 2)
+}
+
+func TestOperation_RegisterOutbound(t *testing.T) {
+	registry := didcommtransport.NewOutboundRegistry()
+
+	ctx, err := context.New(context.WithOutboundTransportRegistry(registry))
+	require.NoError(t, err)
+
+	op := New(ctx)
+
+	t.Run("register and unregister outbound transport", func(t *testing.T) {
+		rr := serve(t, op, RegisterOutboundPath, http.MethodPost, RegisterOutboundPath,
+			bytes.NewBufferString(`{"scheme":"ws"}`))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Len(t, registry.Transports(), 1)
+
+		rr = serve(t, op, UnregisterOutboundPath, http.MethodDelete, outboundPath+"/ws", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Empty(t, registry.Transports())
+	})
+
+	t.Run("unregister outbound transport - error", func(t *testing.T) {
+		rr := serve(t, op, UnregisterOutboundPath, http.MethodDelete, outboundPath+"/ws", nil)
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+
+		errResponse := struct {
+			Code int `json:"code"`
+		}{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResponse))
+		require.EqualValues(t, transport.UnregisterOutboundErrorCode, errResponse.Code)
+	})
+}
+
+func serve(t *testing.T, op *Operation, path, method, url string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var handler rest.Handler
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == method {
+			handler = h
+		}
+	}
+
+	require.NotNil(t, handler)
+
+	req, err := http.NewRequest(method, url, body)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
@@ -83,3 +83,28 @@ type didRecordResult struct {
 	// in: body
 	Result []*didstore.Record `json:"result,omitempty"`
 }
+
+// registerResolverReq model
+//
+// This is used for registering an HTTP binding resolver for a DID method
+//
+// swagger:parameters registerResolverReq
+type registerResolverReq struct { // nolint: unused,deadcode
+	// Params for registering the resolver
+	//
+	// in: body
+	Params vdrcommand.ResolverArgs
+}
+
+// unregisterResolverReq model
+//
+// This is used for removing the resolvers of a DID method
+//
+// swagger:parameters unregisterResolverReq
+type unregisterResolverReq struct { // nolint: unused,deadcode
+	// DID method
+	//
+	// in: path
+	// required: true
+	Method string `json:"method"`
+}
@@ -30,6 +30,10 @@ const (
 	ResolveDIDPath    = vdrDIDPath + "/resolve/{id}"
 	CreateDIDPath     = vdrDIDPath + "/create"
 	GetDIDRecordsPath = vdrDIDPath + "/records"
+
+	vdrResolverPath        = VDROperationID + "/resolver"
+	RegisterResolverPath   = vdrResolverPath
+	UnregisterResolverPath = vdrResolverPath + "/{method}"
 )
 
 // provider contains dependencies for the common controller operations
@@ -72,6 +76,8 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(CreateDIDPath, http.MethodPost, o.CreateDID),
 		cmdutil.NewHTTPHandler(GetDIDRecordsPath, http.MethodGet, o.GetDIDRecords),
 		cmdutil.NewHTTPHandler(GetDIDPath, http.MethodGet, o.GetDID),
+		cmdutil.NewHTTPHandler(RegisterResolverPath, http.MethodPost, o.RegisterResolver),
+		cmdutil.NewHTTPHandler(UnregisterResolverPath, http.MethodDelete, o.UnregisterResolver),
 	}
 }
 
@@ -148,3 +154,25 @@ func (o *Operation) ResolveDID(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) GetDIDRecords(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.GetDIDRecords, rw, req.Body)
 }
+
+// RegisterResolver swagger:route POST /vdr/resolver vdr registerResolverReq
+//
+// Registers an HTTP binding resolver for a DID method on the running agent.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RegisterResolver(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RegisterResolver, rw, req.Body)
+}
+
+// UnregisterResolver swagger:route DELETE /vdr/resolver/{method} vdr unregisterResolverReq
+//
+// Removes the resolvers of a DID method from the running agent.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) UnregisterResolver(rw http.ResponseWriter, req *http.Request) {
+	request := fmt.Sprintf(`{"method":%q}`, mux.Vars(req)["method"])
+
+	rest.Execute(o.command.UnregisterResolver, rw, bytes.NewBufferString(request))
+}
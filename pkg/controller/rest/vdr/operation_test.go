@@ -27,6 +27,7 @@ import (
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	vdrregistry "github.com/hyperledger/aries-framework-go/pkg/vdr"
 )
 
 const sampleDIDName = "sampleDIDName"
@@ -58,7 +59,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 5, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 7, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -292,6 +293,35 @@ func TestGetDIDRecords(t *testing.T) {
 	})
 }
 
+func TestRegisterResolver(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue:      vdrregistry.New(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	t.Run("test register and unregister resolver - success", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, RegisterResolverPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler,
+			bytes.NewBufferString(`{"method":"example","url":"http://localhost:8080"}`), RegisterResolverPath)
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, cmd, UnregisterResolverPath, http.MethodDelete)
+		_, err = getSuccessResponseFromHandler(handler, nil, vdrResolverPath+"/example")
+		require.NoError(t, err)
+	})
+
+	t.Run("test unregister resolver - error", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, UnregisterResolverPath, http.MethodDelete)
+		buf, code, err := sendRequestToHandler(handler, nil, vdrResolverPath+"/other")
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, vdr.UnregisterResolverErrorCode, "did method other not supported for vdr", buf.Bytes())
+	})
+}
+
 func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
 	t.Helper()
 
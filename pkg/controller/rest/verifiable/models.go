@@ -25,6 +25,29 @@ type validateCredentialReq struct { // nolint: unused,deadcode
 	Params verifiable.Credential
 }
 
+// verifyCredentialReq model
+//
+// This is used to verify the verifiable credential.
+//
+// swagger:parameters verifyCredentialReq
+type verifyCredentialReq struct { // nolint: unused,deadcode
+	// Params for verifying the verifiable credential (pass the vc document as a string)
+	//
+	// in: body
+	Params verifiable.Credential
+}
+
+// verifyCredentialRes model
+//
+// This is used for returning the verification report of the verifiable credential.
+//
+// swagger:response verifyCredentialRes
+type verifyCredentialRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifiable.VerifyCredentialResponse
+}
+
 // emptyRes model
 //
 // swagger:response emptyRes
@@ -247,3 +270,108 @@ type deriveCredentialRes struct {
 	// in: body
 	verifiable.Credential
 }
+
+// refreshCredentialReq model
+//
+// This is used to refresh the verifiable credential by name.
+//
+// swagger:parameters refreshCredentialReq
+type refreshCredentialReq struct { // nolint: unused,deadcode
+	// VC Name
+	//
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
+// refreshCredentialRes model
+//
+// This is used for returning the renewed verifiable credential.
+//
+// swagger:response refreshCredentialRes
+type refreshCredentialRes struct {
+
+	// in: body
+	verifiable.Credential
+}
+
+// updateCredentialNameReq model
+//
+// This is used to rename the verifiable credential.
+//
+// swagger:parameters updateCredentialNameReq
+type updateCredentialNameReq struct { // nolint: unused,deadcode
+	// Params for renaming the verifiable credential
+	//
+	// in: body
+	Params verifiable.UpdateCredentialNameRequest
+}
+
+// credentialTagsReq model
+//
+// This is used to add or remove tags of the verifiable credential.
+//
+// swagger:parameters credentialTagsReq
+type credentialTagsReq struct { // nolint: unused,deadcode
+	// Params for adding or removing tags of the verifiable credential
+	//
+	// in: body
+	Params verifiable.CredentialTagsRequest
+}
+
+// collectionReq model
+//
+// This is used to create or remove a verifiable credential collection.
+//
+// swagger:parameters collectionReq
+type collectionReq struct { // nolint: unused,deadcode
+	// Params for creating or removing the verifiable credential collection
+	//
+	// in: body
+	Params verifiable.CollectionRequest
+}
+
+// collectionsRes model
+//
+// This is used to return the names of the verifiable credential collections.
+//
+// swagger:response collectionsRes
+type collectionsRes struct { // nolint: unused,deadcode
+	// in: body
+	verifiable.CollectionsResponse
+}
+
+// moveCredentialReq model
+//
+// This is used to move the verifiable credential to a collection.
+//
+// swagger:parameters moveCredentialReq
+type moveCredentialReq struct { // nolint: unused,deadcode
+	// Params for moving the verifiable credential
+	//
+	// in: body
+	Params verifiable.MoveCredentialRequest
+}
+
+// getCredentialsByCollectionReq model
+//
+// This is used to retrieve the verifiable credentials of a collection.
+//
+// swagger:parameters getCredentialsByCollectionReq
+type getCredentialsByCollectionReq struct { // nolint: unused,deadcode
+	// Collection Name
+	//
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
+// rotateEncryptionKeyRes model
+//
+// This is used to return the ID of the new encryption key of the verifiable store.
+//
+// swagger:response rotateEncryptionKeyRes
+type rotateEncryptionKeyRes struct { // nolint: unused,deadcode
+	// in: body
+	verifiable.RotateEncryptionKeyResponse
+}
@@ -29,9 +29,11 @@ const (
 	VerifiableOperationID      = "/verifiable"
 	verifiableCredentialPath   = VerifiableOperationID + "/credential"
 	verifiablePresentationPath = VerifiableOperationID + "/presentation"
+	verifiableCollectionPath   = VerifiableOperationID + "/collection"
 
 	// credential paths.
 	ValidateCredentialPath     = verifiableCredentialPath + "/validate"
+	VerifyCredentialPath       = verifiableCredentialPath + "/verify"
 	SaveCredentialPath         = verifiableCredentialPath
 	GetCredentialPath          = verifiableCredentialPath + "/{id}"
 	GetCredentialByNamePath    = verifiableCredentialPath + "/name" + "/{name}"
@@ -39,6 +41,10 @@ const (
 	SignCredentialsPath        = VerifiableOperationID + "/signcredential"
 	DeriveCredentialPath       = VerifiableOperationID + "/derivecredential"
 	RemoveCredentialByNamePath = verifiableCredentialPath + "/remove/name" + "/{name}"
+	RefreshCredentialPath      = verifiableCredentialPath + "/refresh/name" + "/{name}"
+	UpdateCredentialNamePath   = verifiableCredentialPath + "/rename"
+	AddCredentialTagsPath      = verifiableCredentialPath + "/tags/add"
+	RemoveCredentialTagsPath   = verifiableCredentialPath + "/tags/remove"
 
 	// presentation paths.
 	GeneratePresentationPath     = verifiablePresentationPath + "/generate"
@@ -47,6 +53,16 @@ const (
 	GetPresentationPath          = verifiablePresentationPath + "/{id}"
 	GetPresentationsPath         = VerifiableOperationID + "/presentations"
 	RemovePresentationByNamePath = verifiablePresentationPath + "/remove/name" + "/{name}"
+
+	// collection paths.
+	CreateCollectionPath           = verifiableCollectionPath
+	GetCollectionsPath             = VerifiableOperationID + "/collections"
+	RemoveCollectionPath           = verifiableCollectionPath + "/remove"
+	MoveCredentialPath             = verifiableCredentialPath + "/move"
+	GetCredentialsByCollectionPath = verifiableCollectionPath + "/{name}" + "/credentials"
+
+	// store paths.
+	RotateEncryptionKeyPath = VerifiableOperationID + "/store/encryption-key/rotate"
 )
 
 // provider contains dependencies for the verifiable command and is typically created by using aries.Context().
@@ -85,6 +101,7 @@ func (o *Operation) GetRESTHandlers() []rest.Handler {
 func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(ValidateCredentialPath, http.MethodPost, o.ValidateCredential),
+		cmdutil.NewHTTPHandler(VerifyCredentialPath, http.MethodPost, o.VerifyCredential),
 		cmdutil.NewHTTPHandler(SaveCredentialPath, http.MethodPost, o.SaveCredential),
 		cmdutil.NewHTTPHandler(GetCredentialPath, http.MethodGet, o.GetCredential),
 		cmdutil.NewHTTPHandler(GetCredentialByNamePath, http.MethodGet, o.GetCredentialByName),
@@ -98,6 +115,16 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(GetPresentationsPath, http.MethodGet, o.GetPresentations),
 		cmdutil.NewHTTPHandler(RemoveCredentialByNamePath, http.MethodPost, o.RemoveCredentialByName),
 		cmdutil.NewHTTPHandler(RemovePresentationByNamePath, http.MethodPost, o.RemovePresentationByName),
+		cmdutil.NewHTTPHandler(RefreshCredentialPath, http.MethodPost, o.RefreshCredential),
+		cmdutil.NewHTTPHandler(UpdateCredentialNamePath, http.MethodPost, o.UpdateCredentialName),
+		cmdutil.NewHTTPHandler(AddCredentialTagsPath, http.MethodPost, o.AddCredentialTags),
+		cmdutil.NewHTTPHandler(RemoveCredentialTagsPath, http.MethodPost, o.RemoveCredentialTags),
+		cmdutil.NewHTTPHandler(CreateCollectionPath, http.MethodPost, o.CreateCollection),
+		cmdutil.NewHTTPHandler(GetCollectionsPath, http.MethodGet, o.GetCollections),
+		cmdutil.NewHTTPHandler(RemoveCollectionPath, http.MethodPost, o.RemoveCollection),
+		cmdutil.NewHTTPHandler(MoveCredentialPath, http.MethodPost, o.MoveCredential),
+		cmdutil.NewHTTPHandler(GetCredentialsByCollectionPath, http.MethodGet, o.GetCredentialsByCollection),
+		cmdutil.NewHTTPHandler(RotateEncryptionKeyPath, http.MethodPost, o.RotateEncryptionKey),
 	}
 }
 
@@ -112,6 +139,18 @@ func (o *Operation) ValidateCredential(rw http.ResponseWriter, req *http.Request
 	rest.Execute(o.command.ValidateCredential, rw, req.Body)
 }
 
+// VerifyCredential swagger:route POST /verifiable/credential/verify verifiable verifyCredentialReq
+//
+// Verifies the verifiable credential and reports the outcome of signature, issuance window, status, schema
+// and issuer trust checks.
+//
+// Responses:
+//    default: genericError
+//        200: verifyCredentialRes
+func (o *Operation) VerifyCredential(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.VerifyCredential, rw, req.Body)
+}
+
 // SaveCredential swagger:route POST /verifiable/credential verifiable saveCredentialReq
 //
 // Saves the verifiable credential.
@@ -286,3 +325,122 @@ func (o *Operation) RemovePresentationByName(rw http.ResponseWriter, req *http.R
 
 	rest.Execute(o.command.RemovePresentationByName, rw, bytes.NewBufferString(request))
 }
+
+// RefreshCredential swagger:route POST /verifiable/credential/refresh/name/{name} verifiable refreshCredentialReq
+//
+// Renews a stored verifiable credential by name using its refresh service and replaces the stored one.
+//
+// Responses:
+//    default: genericError
+//        200: refreshCredentialRes
+func (o *Operation) RefreshCredential(rw http.ResponseWriter, req *http.Request) {
+	name := mux.Vars(req)["name"]
+
+	request := fmt.Sprintf(`{"name":"%s"}`, name)
+
+	rest.Execute(o.command.RefreshCredential, rw, bytes.NewBufferString(request))
+}
+
+// UpdateCredentialName swagger:route POST /verifiable/credential/rename verifiable updateCredentialNameReq
+//
+// Renames a stored verifiable credential.
+//
+// Responses:
+//    default: genericError
+//        200: emptyResponse
+func (o *Operation) UpdateCredentialName(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.UpdateCredentialName, rw, req.Body)
+}
+
+// AddCredentialTags swagger:route POST /verifiable/credential/tags/add verifiable credentialTagsReq
+//
+// Adds tags to a stored verifiable credential.
+//
+// Responses:
+//    default: genericError
+//        200: emptyResponse
+func (o *Operation) AddCredentialTags(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.AddCredentialTags, rw, req.Body)
+}
+
+// RemoveCredentialTags swagger:route POST /verifiable/credential/tags/remove verifiable credentialTagsReq
+//
+// Removes tags from a stored verifiable credential.
+//
+// Responses:
+//    default: genericError
+//        200: emptyResponse
+func (o *Operation) RemoveCredentialTags(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RemoveCredentialTags, rw, req.Body)
+}
+
+// CreateCollection swagger:route POST /verifiable/collection verifiable collectionReq
+//
+// Creates a named collection to group the stored verifiable credentials in.
+//
+// Responses:
+//    default: genericError
+//        200: emptyResponse
+func (o *Operation) CreateCollection(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreateCollection, rw, req.Body)
+}
+
+// GetCollections swagger:route GET /verifiable/collections verifiable getCollections
+//
+// Retrieves the names of the verifiable credential collections.
+//
+// Responses:
+//    default: genericError
+//        200: collectionsRes
+func (o *Operation) GetCollections(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.GetCollections, rw, req.Body)
+}
+
+// RemoveCollection swagger:route POST /verifiable/collection/remove verifiable collectionReq
+//
+// Removes a verifiable credential collection, its verifiable credentials are kept out of any collection.
+//
+// Responses:
+//    default: genericError
+//        200: emptyResponse
+func (o *Operation) RemoveCollection(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RemoveCollection, rw, req.Body)
+}
+
+// MoveCredential swagger:route POST /verifiable/credential/move verifiable moveCredentialReq
+//
+// Moves a stored verifiable credential to a collection, or out of any collection.
+//
+// Responses:
+//    default: genericError
+//        200: emptyResponse
+func (o *Operation) MoveCredential(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.MoveCredential, rw, req.Body)
+}
+
+// GetCredentialsByCollection swagger:route GET /verifiable/collection/{name}/credentials verifiable getCredentialsByCollectionReq
+//
+// Retrieves the verifiable credentials of a collection.
+//
+// Responses:
+//    default: genericError
+//        200: credentialRecordResult
+func (o *Operation) GetCredentialsByCollection(rw http.ResponseWriter, req *http.Request) {
+	name := mux.Vars(req)["name"]
+
+	request := fmt.Sprintf(`{"name":"%s"}`, name)
+
+	rest.Execute(o.command.GetCredentialsByCollection, rw, bytes.NewBufferString(request))
+}
+
+// RotateEncryptionKey swagger:route POST /verifiable/store/encryption-key/rotate verifiable rotateEncryptionKey
+//
+// Creates a new encryption key for the verifiable store, the stored credentials and presentations are re-encrypted
+// with it when read.
+//
+// Responses:
+//    default: genericError
+//        200: rotateEncryptionKeyRes
+func (o *Operation) RotateEncryptionKey(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RotateEncryptionKey, rw, req.Body)
+}
@@ -277,7 +277,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 14, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 25, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -336,6 +336,47 @@ func TestValidateVC(t *testing.T) {
 	})
 }
 
+func TestVerifyVC(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	handler := lookupHandler(t, cmd, VerifyCredentialPath, http.MethodPost)
+
+	t.Run("test verify vc - success", func(t *testing.T) {
+		vcReq := verifiable.Credential{VerifiableCredential: `{
+			"@context": ["https://www.w3.org/2018/credentials/v1"],
+			"type": "VerifiableCredential",
+			"credentialSubject": {"id": "did:example:iuajk1f712ebc6f1c276e12ec21"},
+			"issuer": "did:example:09s12ec712ebc6f1c671ebfeb1f",
+			"issuanceDate": "2020-01-01T10:54:01Z"
+		}`}
+		jsonStr, err := json.Marshal(vcReq)
+		require.NoError(t, err)
+
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		response := verifyCredentialRes{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		require.False(t, response.Verified)
+		require.Len(t, response.Checks, 5)
+	})
+
+	t.Run("test verify vc - error", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.VerifyCredentialErrorCode, "verify vc : unmarshal new credential", buf.Bytes())
+	})
+}
+
 func TestSaveVC(t *testing.T) {
 	t.Run("test save vc - success", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
@@ -1259,6 +1300,182 @@ func TestRemoveVCByName(t *testing.T) {
 	})
 }
 
+func TestRefreshCredential(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	handler := lookupHandler(t, cmd, RefreshCredentialPath, http.MethodPost)
+	buf, code, err := sendRequestToHandler(handler, nil, fmt.Sprintf(`%s/refresh/name/%s`,
+		verifiableCredentialPath, sampleCredentialName))
+	require.NoError(t, err)
+	require.NotEmpty(t, buf)
+
+	require.Equal(t, http.StatusInternalServerError, code)
+	verifyError(t, verifiable.RefreshCredentialErrorCode, "refresh vc", buf.Bytes())
+}
+
+func TestRotateEncryptionKey(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	handler := lookupHandler(t, cmd, RotateEncryptionKeyPath, http.MethodPost)
+	buf, code, err := sendRequestToHandler(handler, nil, RotateEncryptionKeyPath)
+	require.NoError(t, err)
+	require.NotEmpty(t, buf)
+
+	require.Equal(t, http.StatusInternalServerError, code)
+	verifyError(t, verifiable.RotateEncryptionKeyErrorCode, "store encryption is not enabled", buf.Bytes())
+}
+
+func TestUpdateCredentialName(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	t.Run("success", func(t *testing.T) {
+		jsonStr, err := json.Marshal(verifiable.CredentialExt{
+			Credential: verifiable.Credential{VerifiableCredential: vc},
+			Name:       sampleCredentialName,
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, SaveCredentialPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		jsonStr, err = json.Marshal(verifiable.UpdateCredentialNameRequest{Name: sampleCredentialName, NewName: "newName"})
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, cmd, UpdateCredentialNamePath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, UpdateCredentialNamePath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"name":"unknown","newName":"name"}`),
+			handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.UpdateCredentialNameErrorCode, "rename vc", buf.Bytes())
+	})
+}
+
+func TestCredentialTags(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	t.Run("success", func(t *testing.T) {
+		jsonStr, err := json.Marshal(verifiable.CredentialExt{
+			Credential: verifiable.Credential{VerifiableCredential: vc},
+			Name:       sampleCredentialName,
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, SaveCredentialPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		jsonStr, err = json.Marshal(verifiable.CredentialTagsRequest{Name: sampleCredentialName, Tags: []string{"work"}})
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, cmd, AddCredentialTagsPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, cmd, RemoveCredentialTagsPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, AddCredentialTagsPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"name":"unknown","tags":["work"]}`),
+			handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.UpdateCredentialTagsErrorCode, "update vc tags", buf.Bytes())
+	})
+}
+
+func TestCredentialCollections(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	t.Run("success", func(t *testing.T) {
+		jsonStr, err := json.Marshal(verifiable.CredentialExt{
+			Credential: verifiable.Credential{VerifiableCredential: vc},
+			Name:       sampleCredentialName,
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, SaveCredentialPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, cmd, CreateCollectionPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"name":"work"}`), handler.Path())
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, cmd, GetCollectionsPath, http.MethodGet)
+		buf, err := getSuccessResponseFromHandler(handler, nil, handler.Path())
+		require.NoError(t, err)
+
+		collections := collectionsRes{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &collections))
+		require.Equal(t, []string{"work"}, collections.Collections)
+
+		jsonStr, err = json.Marshal(verifiable.MoveCredentialRequest{Name: sampleCredentialName, Collection: "work"})
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, cmd, MoveCredentialPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, cmd, GetCredentialsByCollectionPath, http.MethodGet)
+		buf, err = getSuccessResponseFromHandler(handler, nil, verifiableCollectionPath+"/work/credentials")
+		require.NoError(t, err)
+
+		records := credentialRecordResult{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &records))
+		require.Len(t, records.Result, 1)
+		require.Equal(t, sampleCredentialName, records.Result[0].Name)
+
+		handler = lookupHandler(t, cmd, RemoveCollectionPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"name":"work"}`), handler.Path())
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, MoveCredentialPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler,
+			bytes.NewBufferString(`{"name":"unknown","collection":"unknown"}`), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.CredentialCollectionErrorCode, "move vc", buf.Bytes())
+	})
+}
+
 func TestRemoveVPByName(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		s := make(map[string]mockstore.DBEntry)
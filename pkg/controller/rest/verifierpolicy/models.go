@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifierpolicy
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifierpolicy"
+)
+
+// savePolicyReq model
+//
+// This is used for saving a verifier policy
+//
+// swagger:parameters savePolicyReq
+type savePolicyReq struct { // nolint: unused,deadcode
+	// Params for saving the verifier policy
+	//
+	// in: body
+	Params verifierpolicy.SavePolicyArgs
+}
+
+// policyNameReq model
+//
+// This is used for getting or removing a verifier policy
+//
+// swagger:parameters getPolicyReq removePolicyReq
+type policyNameReq struct { // nolint: unused,deadcode
+	// Name of the policy
+	//
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
+// policyRes model
+//
+// This is used for returning a verifier policy
+//
+// swagger:response policyRes
+type policyRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifierpolicy.PolicyResult
+}
+
+// policiesRes model
+//
+// This is used for returning the verifier policies
+//
+// swagger:response policiesRes
+type policiesRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifierpolicy.PoliciesResult
+}
+
+// requestProofByPolicyReq model
+//
+// This is used for requesting the presentation defined by a verifier policy over a connection
+//
+// swagger:parameters requestProofByPolicyReq
+type requestProofByPolicyReq struct { // nolint: unused,deadcode
+	// Params for requesting the proof
+	//
+	// in: body
+	Params verifierpolicy.RequestProofByPolicyArgs
+}
+
+// requestProofByPolicyRes model
+//
+// This is used for returning the decision on the presentation received
+//
+// swagger:response requestProofByPolicyRes
+type requestProofByPolicyRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifierpolicy.RequestProofByPolicyResult
+}
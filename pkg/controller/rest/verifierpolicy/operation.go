@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifierpolicy
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/verifierpolicy"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifierpolicy"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the verifier policy operations.
+const (
+	VerifierPolicyOperationID = "/verifierpolicy"
+	PoliciesPath              = VerifierPolicyOperationID + "/policies"
+	PolicyPath                = PoliciesPath + "/{name}"
+	RequestProofByPolicyPath  = VerifierPolicyOperationID + "/request-proof"
+)
+
+// Operation contains the operations managing the verifier policies and requesting the proofs defined by them.
+type Operation struct {
+	handlers []rest.Handler
+	command  *verifierpolicy.Command
+}
+
+// New returns new verifier policy operations rest client instance.
+func New(ctx client.Provider) (*Operation, error) {
+	cmd, err := verifierpolicy.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("verifier policy command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(PoliciesPath, http.MethodPost, o.SavePolicy),
+		cmdutil.NewHTTPHandler(PoliciesPath, http.MethodGet, o.GetPolicies),
+		cmdutil.NewHTTPHandler(PolicyPath, http.MethodGet, o.GetPolicy),
+		cmdutil.NewHTTPHandler(PolicyPath, http.MethodDelete, o.RemovePolicy),
+		cmdutil.NewHTTPHandler(RequestProofByPolicyPath, http.MethodPost, o.RequestProofByPolicy),
+	}
+}
+
+// SavePolicy swagger:route POST /verifierpolicy/policies verifierpolicy savePolicyReq
+//
+// Saves a verifier policy, replacing the policy having the same name.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) SavePolicy(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.SavePolicy, rw, req.Body)
+}
+
+// GetPolicies swagger:route GET /verifierpolicy/policies verifierpolicy getPoliciesReq
+//
+// Returns the verifier policies sorted by name.
+//
+// Responses:
+//    default: genericError
+//        200: policiesRes
+func (o *Operation) GetPolicies(rw http.ResponseWriter, _ *http.Request) {
+	rest.Execute(o.command.GetPolicies, rw, nil)
+}
+
+// GetPolicy swagger:route GET /verifierpolicy/policies/{name} verifierpolicy getPolicyReq
+//
+// Returns the verifier policy with the given name.
+//
+// Responses:
+//    default: genericError
+//        200: policyRes
+func (o *Operation) GetPolicy(rw http.ResponseWriter, req *http.Request) {
+	request := fmt.Sprintf(`{"name":%q}`, mux.Vars(req)["name"])
+
+	rest.Execute(o.command.GetPolicy, rw, bytes.NewBufferString(request))
+}
+
+// RemovePolicy swagger:route DELETE /verifierpolicy/policies/{name} verifierpolicy removePolicyReq
+//
+// Removes the verifier policy with the given name.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RemovePolicy(rw http.ResponseWriter, req *http.Request) {
+	request := fmt.Sprintf(`{"name":%q}`, mux.Vars(req)["name"])
+
+	rest.Execute(o.command.RemovePolicy, rw, bytes.NewBufferString(request))
+}
+
+// RequestProofByPolicy swagger:route POST /verifierpolicy/request-proof verifierpolicy requestProofByPolicyReq
+//
+// Requests the presentation defined by the policy over the connection and returns the decision on the
+// presentation received, which is accepted or declined accordingly.
+//
+// Responses:
+//    default: genericError
+//        200: requestProofByPolicyRes
+func (o *Operation) RequestProofByPolicy(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RequestProofByPolicy, rw, req.Body)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifierpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifierpolicy"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const policy = `{
+	"name":"degree",
+	"presentationDefinition":{
+		"id":"degree_definition",
+		"input_descriptors":[{"id":"degree_input","schema":[{"uri":"https://www.w3.org/2018/credentials/v1"}]}]
+	}
+}`
+
+func newMockProvider(ctrl *gomock.Controller) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		ServiceValue:                      mocks.NewMockProtocolService(ctrl),
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue:                   &mockvdr.MockVDRegistry{},
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	op, err := New(newMockProvider(ctrl))
+	require.NoError(t, err)
+	require.Len(t, op.GetRESTHandlers(), 5)
+
+	_, err = New(&mockprovider.Provider{
+		StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "verifier policy command")
+}
+
+func TestOperation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	op, err := New(newMockProvider(ctrl))
+	require.NoError(t, err)
+
+	t.Run("save, get, list and remove policies", func(t *testing.T) {
+		rr := serve(t, op, PoliciesPath, PoliciesPath, http.MethodPost, bytes.NewBufferString(policy))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = serve(t, op, PolicyPath, PoliciesPath+"/degree", http.MethodGet, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result verifierpolicy.PolicyResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Equal(t, "degree", result.Policy.Name)
+
+		rr = serve(t, op, PoliciesPath, PoliciesPath, http.MethodGet, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var policies verifierpolicy.PoliciesResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &policies))
+		require.Len(t, policies.Policies, 1)
+
+		rr = serve(t, op, PolicyPath, PoliciesPath+"/degree", http.MethodDelete, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = serve(t, op, PolicyPath, PoliciesPath+"/degree", http.MethodGet, nil)
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("request proof by policy - error", func(t *testing.T) {
+		rr := serve(t, op, RequestProofByPolicyPath, RequestProofByPolicyPath, http.MethodPost,
+			bytes.NewBufferString(`{"policyName":"degree"}`))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		errResponse := struct {
+			Code int `json:"code"`
+		}{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResponse))
+		require.EqualValues(t, verifierpolicy.InvalidRequestErrorCode, errResponse.Code)
+	})
+}
+
+func serve(t *testing.T, op *Operation, path, url, method string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var handler rest.Handler
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == method {
+			handler = h
+		}
+	}
+
+	require.NotNil(t, handler)
+
+	req, err := http.NewRequest(method, url, body)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
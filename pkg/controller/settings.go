@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
+)
+
+var logger = log.New("aries-framework/controller")
+
+// newNotifier returns the notifier of the options, or else a web notifier. The webhook_urls setting of the agent,
+// if any, overrides the webhook URLs of the options and the web notifier follows its changes.
+func newNotifier(ctx *context.Provider, opts *allOpts) command.Notifier {
+	if opts.notifier != nil {
+		return opts.notifier
+	}
+
+	store := ctx.Settings()
+	if store == nil {
+		return webnotifier.New(wsPath, opts.webhookURLs, opts.webhookTargets...)
+	}
+
+	webhookURLs := store.Strings(settings.WebhookURLs, opts.webhookURLs)
+	notifier := webnotifier.New(wsPath, webhookURLs, opts.webhookTargets...)
+
+	events := make(chan settings.Event)
+	store.RegisterEvent(events)
+
+	go followWebhookURLs(events, notifier.Webhooks(), webhookURLs, opts.webhookURLs)
+
+	return notifier
+}
+
+// followWebhookURLs replaces the webhook URLs of the notifier on each change of the webhook_urls setting,
+// the startup webhook URLs are restored when the setting is removed.
+func followWebhookURLs(events <-chan settings.Event, webhooks *webnotifier.HTTPNotifier, current, startup []string) {
+	for event := range events {
+		if event.Name != settings.WebhookURLs {
+			continue
+		}
+
+		webhookURLs := startup
+
+		if event.Value != nil {
+			var changed []string
+
+			if err := json.Unmarshal(event.Value, &changed); err != nil {
+				logger.Warnf("webhook URLs are not changed: %s", err)
+
+				continue
+			}
+
+			webhookURLs = changed
+		}
+
+		for _, webhookURL := range current {
+			webhooks.UnregisterTarget(webhookURL)
+		}
+
+		for _, webhookURL := range webhookURLs {
+			if err := webhooks.RegisterTarget(webnotifier.WebhookTarget{URL: webhookURL}); err != nil {
+				logger.Warnf("webhook URL %s is not registered: %s", webhookURL, err)
+			}
+		}
+
+		current = webhookURLs
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
+)
+
+func TestNewNotifier(t *testing.T) {
+	t.Run("without settings", func(t *testing.T) {
+		notifier := newNotifier(&context.Provider{}, &allOpts{webhookURLs: []string{"http://localhost:8081"}})
+
+		webNotifier, ok := notifier.(*webnotifier.WebNotifier)
+		require.True(t, ok)
+		require.Len(t, webNotifier.Webhooks().Targets(), 1)
+	})
+
+	t.Run("follows the webhook URLs setting", func(t *testing.T) {
+		storeCtx, err := context.New(context.WithStorageProvider(mockstore.NewMockStoreProvider()))
+		require.NoError(t, err)
+
+		store, err := settings.New(storeCtx)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Set(settings.WebhookURLs, json.RawMessage(`["http://localhost:8082"]`)))
+
+		ctx, err := context.New(context.WithSettings(store))
+		require.NoError(t, err)
+
+		notifier := newNotifier(ctx, &allOpts{webhookURLs: []string{"http://localhost:8081"}})
+
+		webhooks := notifier.(*webnotifier.WebNotifier).Webhooks()
+		require.Equal(t, []string{"http://localhost:8082"}, targetURLs(webhooks))
+
+		require.NoError(t, store.Set(settings.WebhookURLs,
+			json.RawMessage(`["http://localhost:8083","http://localhost:8084"]`)))
+		require.Eventually(t, func() bool {
+			urls := targetURLs(webhooks)
+
+			return len(urls) == 2 && urls[0] == "http://localhost:8083"
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, store.Remove(settings.WebhookURLs))
+		require.Eventually(t, func() bool {
+			urls := targetURLs(webhooks)
+
+			return len(urls) == 1 && urls[0] == "http://localhost:8081"
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func targetURLs(webhooks *webnotifier.HTTPNotifier) []string {
+	var urls []string
+
+	for _, target := range webhooks.Targets() {
+		urls = append(urls, target.URL)
+	}
+
+	return urls
+}
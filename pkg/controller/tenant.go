@@ -0,0 +1,259 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/tenant"
+)
+
+const (
+	// TenantIDHeader is the HTTP header selecting the tenant of a REST API call.
+	TenantIDHeader = "X-Tenant-ID"
+
+	// tenantPathPrefix selects the tenant of a REST API call when the header is not set,
+	// e.g. /tenants/alice/connections.
+	tenantPathPrefix = "/tenants/"
+)
+
+// Error codes of the tenant REST handler.
+const (
+	// MissingTenantIDErrorCode is for requests not selecting a tenant.
+	MissingTenantIDErrorCode = command.Code(iota + command.Tenant)
+	// TenantNotFoundErrorCode is for requests selecting an unknown tenant.
+	TenantNotFoundErrorCode
+	// TenantSuspendedErrorCode is for requests selecting a suspended tenant.
+	TenantSuspendedErrorCode
+	// TenantAgentErrorCode is for failures to start the agent of the tenant.
+	TenantAgentErrorCode
+)
+
+type tenantRouter struct {
+	router        *mux.Router
+	ctx           *context.Provider
+	subscriptions []*subscription
+}
+
+// subscription is an event channel registered on the protocol services of an agent.
+type subscription struct {
+	unregister []func() error
+	close      func()
+}
+
+// eventService is implemented by the protocol services the REST handlers subscribe to.
+type eventService interface {
+	service.Event
+	ActionEvent() chan<- service.DIDCommAction
+	MsgEvents() []chan<- service.StateMsg
+}
+
+// eventServices are the names of the protocol services the REST handlers subscribe to.
+var eventServices = []string{ // nolint:gochecknoglobals
+	didexchange.DIDExchange,
+	issuecredential.Name,
+	presentproof.Name,
+	introduce.Introduce,
+	outofband.Name,
+	mediator.Coordination,
+}
+
+// tenantHandler routes the REST API calls to the agent of the tenant they select.
+type tenantHandler struct {
+	manager *tenant.Manager
+	opts    func(tenantID string) []Opt
+
+	mu      sync.Mutex
+	routers map[string]*tenantRouter
+}
+
+// GetTenantRESTHandler returns the http handler of the REST API of all the tenants of the manager. The tenant of
+// a call is selected by the X-Tenant-ID header or else by the /tenants/{tenantID} path prefix (removed before
+// routing the call). The opts function returns the REST options of a tenant (e.g. its webhooks), it may be nil.
+func GetTenantRESTHandler(manager *tenant.Manager, opts func(tenantID string) []Opt) http.Handler {
+	if opts == nil {
+		opts = func(string) []Opt { return nil }
+	}
+
+	return &tenantHandler{
+		manager: manager,
+		opts:    opts,
+		routers: make(map[string]*tenantRouter),
+	}
+}
+
+func (h *tenantHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	tenantID, path := tenantOf(req)
+	if tenantID == "" {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, MissingTenantIDErrorCode,
+			fmt.Errorf("tenant is not selected: set the %s header or the %s{tenantID} path prefix",
+				TenantIDHeader, tenantPathPrefix))
+
+		return
+	}
+
+	router, err := h.router(tenantID)
+	if err != nil {
+		switch {
+		case errors.Is(err, tenant.ErrTenantNotFound):
+			rest.SendHTTPStatusError(rw, http.StatusNotFound, TenantNotFoundErrorCode, err)
+		case errors.Is(err, tenant.ErrTenantSuspended):
+			rest.SendHTTPStatusError(rw, http.StatusForbidden, TenantSuspendedErrorCode, err)
+		default:
+			rest.SendHTTPStatusError(rw, http.StatusInternalServerError, TenantAgentErrorCode, err)
+		}
+
+		return
+	}
+
+	if path != req.URL.Path {
+		req = req.Clone(req.Context())
+		req.URL.Path = path
+		req.URL.RawPath = ""
+	}
+
+	router.ServeHTTP(rw, req)
+}
+
+// router returns the router of the tenant, it is created again when the agent of the tenant was restarted.
+func (h *tenantHandler) router(tenantID string) (*mux.Router, error) {
+	ctx, err := h.manager.Context(tenantID)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r, ok := h.routers[tenantID]
+	if ok && err == nil && r.ctx == ctx {
+		return r.router, nil
+	}
+
+	// the agent of the tenant was stopped, the REST handlers of its previous agent are released
+	if ok {
+		release(r.subscriptions)
+		delete(h.routers, tenantID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	registered := subscriptions(ctx)
+
+	handlers, err := GetRESTHandlers(ctx, h.opts(tenantID)...)
+
+	// the event channels registered by the REST handlers
+	var subs []*subscription
+
+	for ch, sub := range subscriptions(ctx) {
+		if _, ok = registered[ch]; !ok {
+			subs = append(subs, sub)
+		}
+	}
+
+	if err != nil {
+		release(subs)
+
+		return nil, fmt.Errorf("failed to get REST handlers of tenant %s: %w", tenantID, err)
+	}
+
+	router := mux.NewRouter()
+
+	for _, handler := range handlers {
+		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+	}
+
+	h.routers[tenantID] = &tenantRouter{router: router, ctx: ctx, subscriptions: subs}
+
+	return router, nil
+}
+
+// subscriptions returns the event channels registered on the protocol services of the agent.
+func subscriptions(ctx *context.Provider) map[interface{}]*subscription {
+	subs := make(map[interface{}]*subscription)
+
+	add := func(ch interface{}, unregister func() error, closeCh func()) {
+		sub, ok := subs[ch]
+		if !ok {
+			sub = &subscription{close: closeCh}
+			subs[ch] = sub
+		}
+
+		sub.unregister = append(sub.unregister, unregister)
+	}
+
+	for _, name := range eventServices {
+		s, err := ctx.Service(name)
+		if err != nil {
+			continue
+		}
+
+		svc, ok := s.(eventService)
+		if !ok {
+			continue
+		}
+
+		if ch := svc.ActionEvent(); ch != nil {
+			add(ch, func() error { return svc.UnregisterActionEvent(ch) }, func() { close(ch) })
+		}
+
+		for _, ch := range svc.MsgEvents() {
+			ch := ch
+			add(ch, func() error { return svc.UnregisterMsgEvent(ch) }, func() { close(ch) })
+		}
+	}
+
+	return subs
+}
+
+// release unregisters the event channels from the protocol services and closes them, so their listeners stop.
+func release(subs []*subscription) {
+	for _, sub := range subs {
+		for _, unregister := range sub.unregister {
+			if err := unregister(); err != nil {
+				logger.Warnf("failed to unregister event channel: %s", err)
+			}
+		}
+
+		sub.close()
+	}
+}
+
+// tenantOf returns the tenant selected by the request and the path of the request in the tenant API.
+func tenantOf(req *http.Request) (string, string) {
+	if tenantID := req.Header.Get(TenantIDHeader); tenantID != "" {
+		return tenantID, req.URL.Path
+	}
+
+	if !strings.HasPrefix(req.URL.Path, tenantPathPrefix) {
+		return "", req.URL.Path
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, tenantPathPrefix)
+
+	i := strings.Index(path, "/")
+	if i < 0 {
+		return path, "/"
+	}
+
+	return path[:i], path[i:]
+}
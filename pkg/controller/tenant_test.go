@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/tenant"
+)
+
+func TestGetTenantRESTHandler(t *testing.T) {
+	manager, err := tenant.New(mem.NewProvider())
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, manager.Close()) }()
+
+	_, err = manager.Create("alice")
+	require.NoError(t, err)
+
+	var optsTenants []string
+
+	handler := GetTenantRESTHandler(manager, func(tenantID string) []Opt {
+		optsTenants = append(optsTenants, tenantID)
+
+		return []Opt{WithDefaultLabel(tenantID)}
+	})
+
+	serve := func(path, tenantID string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if tenantID != "" {
+			req.Header.Set(TenantIDHeader, tenantID)
+		}
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		var body map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+		return rr.Code, body
+	}
+
+	t.Run("tenant selected by the header", func(t *testing.T) {
+		code, body := serve("/connections", "alice")
+		require.Equal(t, http.StatusOK, code)
+		require.NotNil(t, body)
+	})
+
+	t.Run("tenant selected by the path", func(t *testing.T) {
+		code, body := serve("/tenants/alice/connections", "")
+		require.Equal(t, http.StatusOK, code)
+		require.NotNil(t, body)
+
+		// the REST handlers of the tenant are created once
+		require.Equal(t, []string{"alice"}, optsTenants)
+	})
+
+	t.Run("tenant not selected", func(t *testing.T) {
+		code, body := serve("/connections", "")
+		require.Equal(t, http.StatusBadRequest, code)
+		require.EqualValues(t, MissingTenantIDErrorCode, body["code"])
+	})
+
+	t.Run("unknown tenant", func(t *testing.T) {
+		code, body := serve("/tenants/bob", "")
+		require.Equal(t, http.StatusNotFound, code)
+		require.EqualValues(t, TenantNotFoundErrorCode, body["code"])
+	})
+
+	t.Run("suspended tenant", func(t *testing.T) {
+		ctx, err := manager.Context("alice")
+		require.NoError(t, err)
+
+		svc, err := ctx.Service(issuecredential.Name)
+		require.NoError(t, err)
+
+		actions := svc.(eventService)
+		require.NotNil(t, actions.ActionEvent())
+		require.NotEmpty(t, actions.MsgEvents())
+
+		require.NoError(t, manager.Suspend("alice"))
+
+		code, body := serve("/connections", "alice")
+		require.Equal(t, http.StatusForbidden, code)
+		require.EqualValues(t, TenantSuspendedErrorCode, body["code"])
+
+		// the event channels of the REST handlers of the stopped agent are released
+		require.Nil(t, actions.ActionEvent())
+		require.Empty(t, actions.MsgEvents())
+
+		// the REST handlers are created again for the restarted agent
+		require.NoError(t, manager.Resume("alice"))
+
+		code, _ = serve("/connections", "alice")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, []string{"alice", "alice"}, optsTenants)
+	})
+}
+
+func TestGetTenantRESTHandler_AgentError(t *testing.T) {
+	manager, err := tenant.New(mem.NewProvider(), tenant.WithFrameworkOptions(func(string) []aries.Option {
+		return []aries.Option{func(*aries.Aries) error { return errors.New("option error") }}
+	}))
+	require.NoError(t, err)
+
+	_, err = manager.Create("alice")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/connections", nil)
+	req.Header.Set(TenantIDHeader, "alice")
+
+	rr := httptest.NewRecorder()
+	GetTenantRESTHandler(manager, nil).ServeHTTP(rr, req)
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+	require.Contains(t, rr.Body.String(), "failed to start the agent of tenant alice")
+}
@@ -0,0 +1,243 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/basic"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+)
+
+// SchemaVersion is the version of the typed payloads. It changes only when a payload changes in a way breaking its
+// consumers, the fields added to a payload do not change it.
+const SchemaVersion = "1.0"
+
+// Stable topic names of the typed payloads.
+const (
+	TopicConnections     = "connections"
+	TopicIssueCredential = "issue_credential"
+	TopicPresentProof    = "present_proof"
+	TopicBasicMessages   = "basicmessages"
+)
+
+// Event types of the typed payloads.
+const (
+	EventTypeAction    = "action"
+	EventTypePreState  = preState
+	EventTypePostState = postState
+)
+
+const actionsTopicSuffix = "_actions"
+
+// TypedTopicMessage is the body of the notifications posted to the typed webhook targets.
+type TypedTopicMessage struct {
+	ID            string      `json:"id"`
+	Topic         string      `json:"topic"`
+	SchemaVersion string      `json:"schema_version"`
+	Message       interface{} `json:"message"`
+}
+
+// ConnectionEvent is the typed payload of the connections topic.
+type ConnectionEvent struct {
+	EventType    string `json:"event_type"`
+	State        string `json:"state,omitempty"`
+	ConnectionID string `json:"connection_id,omitempty"`
+	InvitationID string `json:"invitation_id,omitempty"`
+	MessageID    string `json:"message_id,omitempty"`
+	MessageType  string `json:"message_type,omitempty"`
+	ThreadID     string `json:"thread_id,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// IssueCredentialEvent is the typed payload of the issue_credential topic.
+type IssueCredentialEvent struct {
+	EventType   string `json:"event_type"`
+	State       string `json:"state,omitempty"`
+	PIID        string `json:"piid,omitempty"`
+	MyDID       string `json:"my_did,omitempty"`
+	TheirDID    string `json:"their_did,omitempty"`
+	MessageID   string `json:"message_id,omitempty"`
+	MessageType string `json:"message_type,omitempty"`
+	ThreadID    string `json:"thread_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// PresentProofEvent is the typed payload of the present_proof topic.
+type PresentProofEvent struct {
+	EventType   string `json:"event_type"`
+	State       string `json:"state,omitempty"`
+	PIID        string `json:"piid,omitempty"`
+	MyDID       string `json:"my_did,omitempty"`
+	TheirDID    string `json:"their_did,omitempty"`
+	MessageID   string `json:"message_id,omitempty"`
+	MessageType string `json:"message_type,omitempty"`
+	ThreadID    string `json:"thread_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// BasicMessageEvent is the typed payload of the basicmessages topic.
+type BasicMessageEvent struct {
+	MessageID string    `json:"message_id"`
+	Content   string    `json:"content"`
+	SentTime  time.Time `json:"sent_time"`
+	Locale    string    `json:"locale,omitempty"`
+	MyDID     string    `json:"my_did,omitempty"`
+	TheirDID  string    `json:"their_did,omitempty"`
+}
+
+// protocolEvent is the notification of the Observer, Action or StateMsg.
+type protocolEvent struct {
+	Type       string
+	StateID    string
+	Message    service.DIDCommMsgMap
+	Properties map[string]interface{}
+}
+
+// messageServiceEvent is the notification of the generic message services.
+type messageServiceEvent struct {
+	Message  json.RawMessage `json:"message"`
+	MyDID    string          `json:"mydid"`
+	TheirDID string          `json:"theirdid"`
+}
+
+// StableTopic returns the stable topic name of the notification, false if the topic has no typed payload.
+func StableTopic(topic string, message []byte) (string, bool) {
+	switch {
+	case strings.HasPrefix(topic, didexchange.DIDExchange+"_"):
+		return TopicConnections, true
+	case strings.HasPrefix(topic, issuecredential.Name+"_"):
+		return TopicIssueCredential, true
+	case strings.HasPrefix(topic, presentproof.Name+"_"):
+		return TopicPresentProof, true
+	}
+
+	if _, ok := toBasicMessageEvent(message); ok {
+		return TopicBasicMessages, true
+	}
+
+	return "", false
+}
+
+// PrepareTypedTopicMessage prepares the typed topic message of the notification. The notifications of the topics
+// without typed payload are prepared with PrepareTopicMessage.
+func PrepareTypedTopicMessage(topic string, message []byte) ([]byte, error) {
+	stable, ok := StableTopic(topic, message)
+	if !ok {
+		return PrepareTopicMessage(topic, message)
+	}
+
+	var payload interface{}
+
+	if stable == TopicBasicMessages {
+		payload, _ = toBasicMessageEvent(message)
+	} else {
+		event := &protocolEvent{}
+
+		if err := json.Unmarshal(message, event); err != nil {
+			return nil, err
+		}
+
+		payload = toTypedEvent(stable, topic, event)
+	}
+
+	return json.Marshal(&TypedTopicMessage{
+		ID:            uuid.New().String(),
+		Topic:         stable,
+		SchemaVersion: SchemaVersion,
+		Message:       payload,
+	})
+}
+
+func toTypedEvent(stable, topic string, event *protocolEvent) interface{} {
+	eventType := event.Type
+	if strings.HasSuffix(topic, actionsTopicSuffix) {
+		eventType = EventTypeAction
+	}
+
+	var msgID, msgType, thID string
+
+	if event.Message != nil {
+		msgID = event.Message.ID()
+		msgType = event.Message.Type()
+		thID, _ = event.Message.ThreadID() //nolint:errcheck // the thread ID is optional
+	}
+
+	switch stable {
+	case TopicConnections:
+		return &ConnectionEvent{
+			EventType:    eventType,
+			State:        event.StateID,
+			ConnectionID: stringProperty(event, "connectionID"),
+			InvitationID: stringProperty(event, "invitationID"),
+			MessageID:    msgID,
+			MessageType:  msgType,
+			ThreadID:     thID,
+			Error:        stringProperty(event, "error"),
+		}
+	case TopicIssueCredential:
+		return &IssueCredentialEvent{
+			EventType:   eventType,
+			State:       event.StateID,
+			PIID:        stringProperty(event, "piid"),
+			MyDID:       stringProperty(event, "myDID"),
+			TheirDID:    stringProperty(event, "theirDID"),
+			MessageID:   msgID,
+			MessageType: msgType,
+			ThreadID:    thID,
+			Error:       stringProperty(event, "error"),
+		}
+	default:
+		return &PresentProofEvent{
+			EventType:   eventType,
+			State:       event.StateID,
+			PIID:        stringProperty(event, "piid"),
+			MyDID:       stringProperty(event, "myDID"),
+			TheirDID:    stringProperty(event, "theirDID"),
+			MessageID:   msgID,
+			MessageType: msgType,
+			ThreadID:    thID,
+			Error:       stringProperty(event, "error"),
+		}
+	}
+}
+
+func toBasicMessageEvent(message []byte) (*BasicMessageEvent, bool) {
+	event := &messageServiceEvent{}
+
+	if err := json.Unmarshal(message, event); err != nil || len(event.Message) == 0 {
+		return nil, false
+	}
+
+	msg := &basic.Message{}
+
+	if err := json.Unmarshal(event.Message, msg); err != nil || msg.Type != basic.MessageRequestType {
+		return nil, false
+	}
+
+	return &BasicMessageEvent{
+		MessageID: msg.ID,
+		Content:   msg.Content,
+		SentTime:  msg.SentTime,
+		Locale:    msg.I10n.Locale,
+		MyDID:     event.MyDID,
+		TheirDID:  event.TheirDID,
+	}, true
+}
+
+func stringProperty(event *protocolEvent, name string) string {
+	v, _ := event.Properties[name].(string) //nolint:errcheck // missing properties are empty
+
+	return v
+}
@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/basic"
+)
+
+func stateMsgJSON(t *testing.T, props properties) []byte {
+	t.Helper()
+
+	src, err := json.Marshal(toStateMsg(service.StateMsg{
+		ProtocolName: "protocol",
+		Type:         service.PostState,
+		StateID:      "offer-received",
+		Msg: service.DIDCommMsgMap{
+			"@id":     "msgID",
+			"@type":   "msgType",
+			"~thread": map[string]interface{}{"thid": "thID"},
+		},
+		Properties: props,
+	}))
+	require.NoError(t, err)
+
+	return src
+}
+
+func basicMessageJSON(t *testing.T) []byte {
+	t.Helper()
+
+	src, err := json.Marshal(map[string]interface{}{
+		"message": map[string]interface{}{
+			"@id":       "msgID",
+			"@type":     basic.MessageRequestType,
+			"~l10n":     map[string]interface{}{"locale": "en"},
+			"sent_time": time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC),
+			"content":   "hello",
+		},
+		"mydid":    "myDID",
+		"theirdid": "theirDID",
+	})
+	require.NoError(t, err)
+
+	return src
+}
+
+func prepareTyped(t *testing.T, topic string, message []byte, payload interface{}) *TypedTopicMessage {
+	t.Helper()
+
+	src, err := PrepareTypedTopicMessage(topic, message)
+	require.NoError(t, err)
+
+	topicMsg := &TypedTopicMessage{Message: payload}
+	require.NoError(t, json.Unmarshal(src, topicMsg))
+	require.NotEmpty(t, topicMsg.ID)
+	require.Equal(t, SchemaVersion, topicMsg.SchemaVersion)
+
+	return topicMsg
+}
+
+func TestStableTopic(t *testing.T) {
+	tests := []struct {
+		topic   string
+		message []byte
+		stable  string
+	}{
+		{topic: "didexchange_actions", stable: TopicConnections},
+		{topic: "didexchange_states", stable: TopicConnections},
+		{topic: "issue-credential_actions", stable: TopicIssueCredential},
+		{topic: "present-proof_states", stable: TopicPresentProof},
+		{topic: "generic", message: basicMessageJSON(t), stable: TopicBasicMessages},
+		{topic: "generic", message: []byte(`{"message":{"@type":"other"}}`)},
+		{topic: "introduce_states", message: []byte(`{}`)},
+	}
+
+	for _, test := range tests {
+		stable, ok := StableTopic(test.topic, test.message)
+		require.Equal(t, test.stable != "", ok, test.topic)
+		require.Equal(t, test.stable, stable, test.topic)
+	}
+}
+
+func TestPrepareTypedTopicMessage(t *testing.T) {
+	t.Run("connections", func(t *testing.T) {
+		event := &ConnectionEvent{}
+
+		topicMsg := prepareTyped(t, "didexchange_states",
+			stateMsgJSON(t, properties{"connectionID": "connID", "invitationID": "invID"}), event)
+		require.Equal(t, TopicConnections, topicMsg.Topic)
+		require.Equal(t, &ConnectionEvent{
+			EventType:    EventTypePostState,
+			State:        "offer-received",
+			ConnectionID: "connID",
+			InvitationID: "invID",
+			MessageID:    "msgID",
+			MessageType:  "msgType",
+			ThreadID:     "thID",
+		}, event)
+	})
+
+	t.Run("issue credential action", func(t *testing.T) {
+		src, err := json.Marshal(toAction(service.DIDCommAction{
+			Message:    service.DIDCommMsgMap{"@id": "msgID", "@type": "msgType"},
+			Properties: properties{"piid": "piid", "myDID": "myDID", "theirDID": "theirDID"},
+		}))
+		require.NoError(t, err)
+
+		event := &IssueCredentialEvent{}
+
+		topicMsg := prepareTyped(t, "issue-credential_actions", src, event)
+		require.Equal(t, TopicIssueCredential, topicMsg.Topic)
+		require.Equal(t, &IssueCredentialEvent{
+			EventType:   EventTypeAction,
+			PIID:        "piid",
+			MyDID:       "myDID",
+			TheirDID:    "theirDID",
+			MessageID:   "msgID",
+			MessageType: "msgType",
+			ThreadID:    "msgID",
+		}, event)
+	})
+
+	t.Run("present proof", func(t *testing.T) {
+		event := &PresentProofEvent{}
+
+		topicMsg := prepareTyped(t, "present-proof_states",
+			stateMsgJSON(t, properties{"piid": "piid", "error": "failed"}), event)
+		require.Equal(t, TopicPresentProof, topicMsg.Topic)
+		require.Equal(t, "piid", event.PIID)
+		require.Equal(t, "failed", event.Error)
+		require.Equal(t, EventTypePostState, event.EventType)
+	})
+
+	t.Run("basic messages", func(t *testing.T) {
+		event := &BasicMessageEvent{}
+
+		topicMsg := prepareTyped(t, "generic", basicMessageJSON(t), event)
+		require.Equal(t, TopicBasicMessages, topicMsg.Topic)
+		require.Equal(t, &BasicMessageEvent{
+			MessageID: "msgID",
+			Content:   "hello",
+			SentTime:  time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC),
+			Locale:    "en",
+			MyDID:     "myDID",
+			TheirDID:  "theirDID",
+		}, event)
+	})
+
+	t.Run("topic without typed payload", func(t *testing.T) {
+		src, err := PrepareTypedTopicMessage("introduce_states", []byte(`{"StateID":"done"}`))
+		require.NoError(t, err)
+
+		topicMsg := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(src, &topicMsg))
+		require.Equal(t, "introduce_states", topicMsg["topic"])
+		require.NotContains(t, topicMsg, "schema_version")
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		_, err := PrepareTypedTopicMessage("issue-credential_states", []byte(`[]`))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"path"
+)
+
+// WebhookTarget is a webhook URL notified of the topics matching its filters.
+//
+// The filters are path.Match patterns (e.g. "issue-credential_*") matched against the topic of the notification and
+// its stable topic name (e.g. "issue_credential", see StableTopic).
+type WebhookTarget struct {
+	URL string `json:"url"`
+	// Include lists the topics the target is notified of, all the topics if empty.
+	Include []string `json:"include,omitempty"`
+	// Exclude lists the topics the target is not notified of, it takes precedence over Include.
+	Exclude []string `json:"exclude,omitempty"`
+	// Typed posts the stable typed payloads of the topics instead of the internal structures, see SchemaVersion.
+	Typed bool `json:"typed,omitempty"`
+}
+
+// Accepts returns true if the target is notified of the message of the topic.
+func (t *WebhookTarget) Accepts(topic string, message []byte) bool {
+	names := []string{topic}
+
+	if stable, ok := StableTopic(topic, message); ok {
+		names = append(names, stable)
+	}
+
+	if matchAny(t.Exclude, names) {
+		return false
+	}
+
+	return len(t.Include) == 0 || matchAny(t.Include, names)
+}
+
+func matchAny(patterns, names []string) bool {
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if matched, err := path.Match(pattern, name); err == nil && matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookTarget_Accepts(t *testing.T) {
+	message := stateMsgJSON(t, nil)
+
+	all := &WebhookTarget{URL: "http://localhost:8080"}
+	require.True(t, all.Accepts("issue-credential_states", message))
+	require.True(t, all.Accepts("introduce_states", message))
+
+	stable := &WebhookTarget{Include: []string{TopicIssueCredential, TopicPresentProof}}
+	require.True(t, stable.Accepts("issue-credential_states", message))
+	require.True(t, stable.Accepts("present-proof_actions", message))
+	require.False(t, stable.Accepts("didexchange_states", message))
+
+	patterns := &WebhookTarget{Include: []string{"*_states"}, Exclude: []string{"didexchange_*"}}
+	require.True(t, patterns.Accepts("issue-credential_states", message))
+	require.False(t, patterns.Accepts("issue-credential_actions", message))
+	require.False(t, patterns.Accepts("didexchange_states", message))
+
+	basicMessages := &WebhookTarget{Include: []string{TopicBasicMessages}}
+	require.True(t, basicMessages.Accepts("generic", basicMessageJSON(t)))
+	require.False(t, basicMessages.Accepts("generic", message))
+}
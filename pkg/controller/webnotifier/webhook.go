@@ -12,20 +12,75 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 )
 
 // HTTPNotifier is a webhook dispatcher capable of notifying multiple subscribers via HTTP.
 type HTTPNotifier struct {
-	urls []string
+	mu      sync.RWMutex
+	targets []WebhookTarget
 }
 
-// NewHTTPNotifier returns a new instance of an HTTPNotifier.
-func NewHTTPNotifier(webhookURLs []string) *HTTPNotifier {
-	return &HTTPNotifier{urls: webhookURLs}
+// NewHTTPNotifier returns a new instance of an HTTPNotifier notifying the webhook URLs of all the topics, and the
+// targets of the topics matching their filters.
+func NewHTTPNotifier(webhookURLs []string, targets ...WebhookTarget) *HTTPNotifier {
+	n := &HTTPNotifier{}
+
+	for _, webhookURL := range webhookURLs {
+		n.targets = append(n.targets, WebhookTarget{URL: webhookURL})
+	}
+
+	n.targets = append(n.targets, targets...)
+
+	return n
+}
+
+// RegisterTarget registers the webhook target, it replaces the target already registered with the same URL.
+func (n *HTTPNotifier) RegisterTarget(target WebhookTarget) error {
+	if target.URL == "" {
+		return fmt.Errorf(emptyTargetURLErrMsg)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for i := range n.targets {
+		if n.targets[i].URL == target.URL {
+			n.targets[i] = target
+
+			return nil
+		}
+	}
+
+	n.targets = append(n.targets, target)
+
+	return nil
+}
+
+// UnregisterTarget unregisters the webhook target with the given URL.
+func (n *HTTPNotifier) UnregisterTarget(webhookURL string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for i := range n.targets {
+		if n.targets[i].URL == webhookURL {
+			n.targets = append(n.targets[:i], n.targets[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Targets returns the registered webhook targets.
+func (n *HTTPNotifier) Targets() []WebhookTarget {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return append([]WebhookTarget(nil), n.targets...)
 }
 
-// Notify sends the given message to all of the urls.
-// Topic is appended to the end of the webhook (subscriber) URL. E.g. localhost:8080/topic
+// Notify sends the given message to all of the targets accepting the topic.
+// The typed targets receive the typed payload of the topic, see PrepareTypedTopicMessage.
 // If multiple errors are encountered, then the first one is returned.
 func (n *HTTPNotifier) Notify(topic string, message []byte) error {
 	if topic == "" {
@@ -36,16 +91,34 @@ func (n *HTTPNotifier) Notify(topic string, message []byte) error {
 		return fmt.Errorf(emptyMessageErrMsg)
 	}
 
-	topicMsg, err := PrepareTopicMessage(topic, message)
-	if err != nil {
-		return fmt.Errorf(failedToCreateErrMsg, err)
-	}
-
 	var allErrs error
 
-	for _, webhookURL := range n.urls {
-		err := notifyWH(webhookURL, topicMsg)
-		allErrs = appendError(allErrs, err)
+	// the topic messages by typed flag of the targets
+	topicMsgs := make(map[bool][]byte)
+
+	for _, target := range n.Targets() {
+		if !target.Accepts(topic, message) {
+			continue
+		}
+
+		topicMsg, ok := topicMsgs[target.Typed]
+		if !ok {
+			var err error
+
+			if target.Typed {
+				topicMsg, err = PrepareTypedTopicMessage(topic, message)
+			} else {
+				topicMsg, err = PrepareTopicMessage(topic, message)
+			}
+
+			if err != nil {
+				return fmt.Errorf(failedToCreateErrMsg, err)
+			}
+
+			topicMsgs[target.Typed] = topicMsg
+		}
+
+		allErrs = appendError(allErrs, notifyWH(target.URL, topicMsg))
 	}
 
 	return allErrs
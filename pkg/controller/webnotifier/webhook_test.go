@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -118,6 +119,73 @@ func TestNotifyMultipleWebhooks(t *testing.T) {
 	}
 }
 
+func TestNotifyTargets(t *testing.T) {
+	received := make(chan map[string]interface{}, 10)
+
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			body := map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+
+			body["target"] = name
+			received <- body
+		}))
+	}
+
+	all, credentials, excluded := newServer("all"), newServer("credentials"), newServer("excluded")
+	defer all.Close()
+	defer credentials.Close()
+	defer excluded.Close()
+
+	testNotifier := NewHTTPNotifier([]string{all.URL},
+		WebhookTarget{URL: credentials.URL, Include: []string{TopicIssueCredential}, Typed: true},
+		WebhookTarget{URL: excluded.URL, Exclude: []string{"issue-credential_*"}},
+	)
+	require.Len(t, testNotifier.Targets(), 3)
+
+	require.NoError(t, testNotifier.Notify("issue-credential_states", stateMsgJSON(t, properties{"piid": "piid"})))
+
+	bodies := map[string]map[string]interface{}{}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case body := <-received:
+			bodies[body["target"].(string)] = body
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "notification not received")
+		}
+	}
+
+	require.Equal(t, "issue-credential_states", bodies["all"]["topic"])
+	require.NotContains(t, bodies["all"], "schema_version")
+
+	require.Equal(t, TopicIssueCredential, bodies["credentials"]["topic"])
+	require.Equal(t, SchemaVersion, bodies["credentials"]["schema_version"])
+	require.Equal(t, "piid", bodies["credentials"]["message"].(map[string]interface{})["piid"])
+
+	t.Run("register and unregister targets", func(t *testing.T) {
+		require.EqualError(t, testNotifier.RegisterTarget(WebhookTarget{}), emptyTargetURLErrMsg)
+
+		require.NoError(t, testNotifier.RegisterTarget(WebhookTarget{URL: credentials.URL}))
+		require.Len(t, testNotifier.Targets(), 3)
+		require.False(t, testNotifier.Targets()[1].Typed)
+
+		testNotifier.UnregisterTarget(all.URL)
+		testNotifier.UnregisterTarget(credentials.URL)
+		testNotifier.UnregisterTarget("unknown")
+		require.Equal(t, []WebhookTarget{{URL: excluded.URL, Exclude: []string{"issue-credential_*"}}},
+			testNotifier.Targets())
+
+		require.NoError(t, testNotifier.Notify("issue-credential_states", stateMsgJSON(t, nil)))
+
+		select {
+		case body := <-received:
+			require.FailNow(t, "unexpected notification", body)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
+
 func TestNotifyUnsupportedProtocol(t *testing.T) {
 	testNotifier := NewHTTPNotifier([]string{"badURL"})
 
@@ -23,22 +23,26 @@ const (
 	emptyTopicErrMsg        = "cannot notify with an empty topic"
 	emptyMessageErrMsg      = "cannot notify with an empty message"
 	failedToCreateErrMsg    = "failed to create topic message : %w"
+	emptyTargetURLErrMsg    = "webhook target URL is mandatory"
 )
 
 var logger = log.New("aries-framework/webnotifier")
 
 // WebNotifier is a dispatcher capable of notifying multiple subscribers via HTTP Webhooks and WebSockets.
 type WebNotifier struct {
+	webhooks  *HTTPNotifier
 	notifiers []command.Notifier
 	handlers  []rest.Handler
 }
 
-// New returns a new instance of a WebNotifier.
-func New(wsPath string, webhookURLs []string) *WebNotifier {
-	webhook := NewHTTPNotifier(webhookURLs)
+// New returns a new instance of a WebNotifier. The webhook URLs are notified of all the topics, the targets of the
+// topics matching their filters.
+func New(wsPath string, webhookURLs []string, targets ...WebhookTarget) *WebNotifier {
+	webhook := NewHTTPNotifier(webhookURLs, targets...)
 	ws := NewWSNotifier(wsPath)
 
 	n := WebNotifier{
+		webhooks:  webhook,
 		notifiers: []command.Notifier{webhook, ws},
 		handlers:  ws.GetRESTHandlers(),
 	}
@@ -59,6 +63,11 @@ func (n *WebNotifier) Notify(topic string, message []byte) error {
 	return allErrs
 }
 
+// Webhooks returns the notifier of the webhook targets, the targets may be changed at runtime.
+func (n *WebNotifier) Webhooks() *HTTPNotifier {
+	return n.webhooks
+}
+
 // GetRESTHandlers returns all REST handlers provided by notifier.
 func (n *WebNotifier) GetRESTHandlers() []rest.Handler {
 	return n.handlers
@@ -13,7 +13,9 @@ package bbs12381g2pub
 import (
 	"errors"
 	"fmt"
+	"runtime"
 	"sort"
+	"sync"
 
 	bls12381 "github.com/kilic/bls12-381"
 )
@@ -54,6 +56,9 @@ const (
 
 	// Number of bytes in scalar uncompressed form.
 	frUncompressedSize = 48
+
+	// Minimal number of products summed in parallel, below it the goroutines cost more than they save.
+	parallelProductsThreshold = 16
 )
 
 // Verify makes BLS BBS12-381 signature verification.
@@ -87,6 +92,8 @@ func (bbs *BBSG2Pub) Sign(messages [][]byte, privKeyBytes []byte) ([]byte, error
 		return nil, fmt.Errorf("unmarshal private key: %w", err)
 	}
 
+	defer privKey.Destroy()
+
 	if len(messages) == 0 {
 		return nil, errors.New("messages are not defined")
 	}
@@ -263,17 +270,61 @@ func (cb *commitmentBuilder) build() *bls12381.PointG1 {
 	return sumOfG1Products(cb.bases, cb.scalars)
 }
 
+// sumOfG1Products computes the multi-scalar multiplication of the bases, spread across the cores for the large
+// number of bases (i.e. the credentials with many statements).
 func sumOfG1Products(bases []*bls12381.PointG1, scalars []*bls12381.Fr) *bls12381.PointG1 {
-	res := g1.Zero()
+	workers := runtime.NumCPU()
+
+	if len(bases) < parallelProductsThreshold || workers < 2 {
+		return sumOfG1ProductsRange(bls12381.NewG1(), bases, scalars)
+	}
+
+	chunkSize := (len(bases) + workers - 1) / workers
+	partials := make([]*bls12381.PointG1, (len(bases)+chunkSize-1)/chunkSize)
+
+	var wg sync.WaitGroup
+
+	for i := range partials {
+		start := i * chunkSize
+
+		end := start + chunkSize
+		if end > len(bases) {
+			end = len(bases)
+		}
+
+		wg.Add(1)
+
+		go func(i, start, end int) {
+			defer wg.Done()
+
+			// the G1 groups hold temporary values, each goroutine needs its own
+			partials[i] = sumOfG1ProductsRange(bls12381.NewG1(), bases[start:end], scalars[start:end])
+		}(i, start, end)
+	}
+
+	wg.Wait()
+
+	group := bls12381.NewG1()
+	res := group.Zero()
+
+	for _, partial := range partials {
+		group.Add(res, res, partial)
+	}
+
+	return res
+}
+
+func sumOfG1ProductsRange(group *bls12381.G1, bases []*bls12381.PointG1, scalars []*bls12381.Fr) *bls12381.PointG1 {
+	res := group.Zero()
 
 	for i := 0; i < len(bases); i++ {
 		b := bases[i]
 		s := scalars[i]
 
-		g := g1.New()
+		g := group.New()
 
-		g1.MulScalar(g, b, frToRepr(s))
-		g1.Add(res, res, g)
+		group.MulScalar(g, b, frToRepr(s))
+		group.Add(res, res, g)
 	}
 
 	return res
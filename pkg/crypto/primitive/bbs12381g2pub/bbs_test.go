@@ -9,11 +9,13 @@ package bbs12381g2pub_test
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 )
 
 //nolint:lll
@@ -263,3 +265,111 @@ func TestBBSG2Pub_DeriveProof(t *testing.T) {
 			"larger than 4 messages")
 	})
 }
+
+func TestBBSG2Pub_DeterministicRandomSource(t *testing.T) {
+	pubKey, privKey, err := generateKeyPairRandom()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	messagesBytes := [][]byte{[]byte("message1"), []byte("message2"), []byte("message3")}
+	nonce := []byte("nonce")
+	bls := bbs12381g2pub.New()
+
+	signAndDerive := func() ([]byte, []byte) {
+		restore, e := random.SetSource(random.NewDeterministic([]byte("seed")))
+		require.NoError(t, e)
+
+		defer restore()
+
+		signatureBytes, e := bls.Sign(messagesBytes, privKeyBytes)
+		require.NoError(t, e)
+
+		proofBytes, e := bls.DeriveProof(messagesBytes, signatureBytes, nonce, pubKeyBytes, []int{0, 2})
+		require.NoError(t, e)
+
+		return signatureBytes, proofBytes
+	}
+
+	signature1, proof1 := signAndDerive()
+	signature2, proof2 := signAndDerive()
+
+	require.Equal(t, signature1, signature2)
+	require.Equal(t, proof1, proof2)
+
+	require.NoError(t, bls.Verify(messagesBytes, signature1, pubKeyBytes))
+	require.NoError(t, bls.VerifyProof([][]byte{messagesBytes[0], messagesBytes[2]}, proof1, nonce, pubKeyBytes))
+}
+
+func TestBBSG2Pub_DeriveProof_ManyMessages(t *testing.T) {
+	pubKey, privKey, err := generateKeyPairRandom()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	// enough messages for the generators and the proofs to be computed in parallel
+	messagesBytes := manyMessages(60)
+	bls := bbs12381g2pub.New()
+
+	signatureBytes, err := bls.Sign(messagesBytes, privKeyBytes)
+	require.NoError(t, err)
+
+	require.NoError(t, bls.Verify(messagesBytes, signatureBytes, pubKeyBytes))
+
+	nonce := []byte("nonce")
+	revealedIndexes := []int{0, 17, 18, 42, 59}
+	proofBytes, err := bls.DeriveProof(messagesBytes, signatureBytes, nonce, pubKeyBytes, revealedIndexes)
+	require.NoError(t, err)
+
+	revealedMessages := make([][]byte, len(revealedIndexes))
+	for i, ind := range revealedIndexes {
+		revealedMessages[i] = messagesBytes[ind]
+	}
+
+	require.NoError(t, bls.VerifyProof(revealedMessages, proofBytes, nonce, pubKeyBytes))
+
+	revealedMessages[1] = []byte("tampered message")
+	require.Error(t, bls.VerifyProof(revealedMessages, proofBytes, nonce, pubKeyBytes))
+}
+
+func BenchmarkBBSG2Pub_DeriveProof(b *testing.B) {
+	pubKey, privKey, err := generateKeyPairRandom()
+	require.NoError(b, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(b, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(b, err)
+
+	messagesBytes := manyMessages(50)
+	bls := bbs12381g2pub.New()
+
+	signatureBytes, err := bls.Sign(messagesBytes, privKeyBytes)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err = bls.DeriveProof(messagesBytes, signatureBytes, []byte("nonce"), pubKeyBytes, []int{0, 10, 20})
+		require.NoError(b, err)
+	}
+}
+
+func manyMessages(count int) [][]byte {
+	messages := make([][]byte, count)
+
+	for i := range messages {
+		messages[i] = []byte(fmt.Sprintf("message%d", i))
+	}
+
+	return messages
+}
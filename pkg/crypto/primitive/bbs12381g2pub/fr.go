@@ -7,10 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package bbs12381g2pub
 
 import (
-	"crypto/rand"
-
 	bls12381 "github.com/kilic/bls12-381"
 	"golang.org/x/crypto/blake2b"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 )
 
 func parseFr(data []byte) *bls12381.Fr {
@@ -62,7 +62,7 @@ func messagesToFr(messages [][]byte) []*SignatureMessage {
 }
 
 func createRandSignatureFr() *bls12381.Fr {
-	fr, _ := bls12381.NewFr().Rand(rand.Reader) //nolint:errcheck
+	fr, _ := bls12381.NewFr().Rand(random.Reader()) //nolint:errcheck
 
 	return frToRepr(fr)
 }
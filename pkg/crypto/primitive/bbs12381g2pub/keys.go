@@ -7,24 +7,35 @@ SPDX-License-Identifier: Apache-2.0
 package bbs12381g2pub
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"hash"
 	"io"
+	"runtime"
+	"sync"
 
+	"github.com/bluele/gcache"
 	bls12381 "github.com/kilic/bls12-381"
 	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/hkdf"
 
 	bls12381intern "github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub/internal/kilic/bls12-381"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 )
 
 const (
 	seedSize        = frCompressedSize
 	generateKeySalt = "BBS-SIG-KEYGEN-SALT-"
+
+	// generatorsCacheSize is the maximum number of generators cached, per public key and messages count.
+	generatorsCacheSize = 100
 )
 
+// generatorsCache caches the generators of the public keys: they are derived by hashing to the curve once per
+// message, which dominates the signing and proving time of the credentials with many statements.
+// nolint:gochecknoglobals
+var generatorsCache = gcache.New(generatorsCacheSize).LRU().Build()
+
 // PublicKey defines BLS Public Key.
 type PublicKey struct {
 	PointG2 *bls12381.PointG2
@@ -47,32 +58,40 @@ type PublicKeyWithGenerators struct {
 }
 
 // ToPublicKeyWithGenerators creates PublicKeyWithGenerators from the PublicKey.
+// The generators are cached per public key and messages count, the returned value must not be modified.
 func (pk *PublicKey) ToPublicKeyWithGenerators(messagesCount int) (*PublicKeyWithGenerators, error) {
-	offset := g2UncompressedSize + 1
+	cacheKey := generatorsCacheKey(pk, messagesCount)
 
-	data := calcData(pk, messagesCount)
+	if cached, err := generatorsCache.Get(cacheKey); err == nil {
+		return cached.(*PublicKeyWithGenerators), nil
+	}
 
-	h0, err := hashToG1(data)
+	pkWithGenerators, err := pk.newPublicKeyWithGenerators(messagesCount)
 	if err != nil {
-		return nil, fmt.Errorf("create G1 point from hash")
+		return nil, err
 	}
 
-	h := make([]*bls12381.PointG1, messagesCount)
+	// nolint:errcheck // gcache.Set fails for nil keys only
+	generatorsCache.Set(cacheKey, pkWithGenerators)
 
-	for i := 1; i <= messagesCount; i++ {
-		dataCopy := make([]byte, len(data))
-		copy(dataCopy, data)
+	return pkWithGenerators, nil
+}
 
-		iBytes := uint32ToBytes(uint32(i))
+func generatorsCacheKey(pk *PublicKey, messagesCount int) string {
+	return string(append(g2.ToCompressed(pk.PointG2), uint32ToBytes(uint32(messagesCount))...))
+}
 
-		for j := 0; j < len(iBytes); j++ {
-			dataCopy[j+offset] = iBytes[j]
-		}
+func (pk *PublicKey) newPublicKeyWithGenerators(messagesCount int) (*PublicKeyWithGenerators, error) {
+	data := calcData(pk, messagesCount)
 
-		h[i-1], err = hashToG1(dataCopy)
-		if err != nil {
-			return nil, fmt.Errorf("create G1 point from hash: %w", err)
-		}
+	h0, err := hashToG1(g1, data)
+	if err != nil {
+		return nil, fmt.Errorf("create G1 point from hash")
+	}
+
+	h, err := messageGenerators(data, messagesCount)
+	if err != nil {
+		return nil, err
 	}
 
 	return &PublicKeyWithGenerators{
@@ -83,6 +102,62 @@ func (pk *PublicKey) ToPublicKeyWithGenerators(messagesCount int) (*PublicKeyWit
 	}, nil
 }
 
+// messageGenerators derives the generator of each message, spread across the cores.
+func messageGenerators(data []byte, messagesCount int) ([]*bls12381.PointG1, error) {
+	offset := g2UncompressedSize + 1
+
+	h := make([]*bls12381.PointG1, messagesCount)
+	errs := make([]error, messagesCount)
+	indexes := make(chan int, messagesCount)
+
+	for i := 0; i < messagesCount; i++ {
+		indexes <- i
+	}
+
+	close(indexes)
+
+	workers := runtime.NumCPU()
+	if workers > messagesCount {
+		workers = messagesCount
+	}
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// the G1 groups hold temporary values, each goroutine needs its own
+			group := bls12381.NewG1()
+
+			for i := range indexes {
+				dataCopy := make([]byte, len(data))
+				copy(dataCopy, data)
+
+				iBytes := uint32ToBytes(uint32(i + 1))
+
+				for j := 0; j < len(iBytes); j++ {
+					dataCopy[j+offset] = iBytes[j]
+				}
+
+				h[i], errs[i] = hashToG1(group, dataCopy)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("create G1 point from hash: %w", err)
+		}
+	}
+
+	return h, nil
+}
+
 func calcData(key *PublicKey, messagesCount int) []byte {
 	data := g2.ToUncompressed(key.PointG2)
 
@@ -95,7 +170,7 @@ func calcData(key *PublicKey, messagesCount int) []byte {
 	return data
 }
 
-func hashToG1(data []byte) (*bls12381.PointG1, error) {
+func hashToG1(group *bls12381.G1, data []byte) (*bls12381.PointG1, error) {
 	dstG1 := []byte("BLS12381G1_XMD:BLAKE2B_SSWU_RO_BBS+_SIGNATURES:1_0_0")
 
 	hashFunc := func() hash.Hash {
@@ -109,7 +184,7 @@ func hashToG1(data []byte) (*bls12381.PointG1, error) {
 		return nil, err
 	}
 
-	return g1.FromBytes(g1Bytes)
+	return group.FromBytes(g1Bytes)
 }
 
 // UnmarshalPrivateKey unmarshals PrivateKey.
@@ -131,6 +206,19 @@ func (k *PrivateKey) Marshal() ([]byte, error) {
 	return bytes, nil
 }
 
+// Destroy wipes the private key. The key must not be used afterwards.
+func (k *PrivateKey) Destroy() {
+	if k.FR == nil {
+		return
+	}
+
+	for i := range k.FR {
+		k.FR[i] = 0
+	}
+
+	runtime.KeepAlive(k.FR)
+}
+
 // PublicKey returns a Public Key as G2 point generated from the Private Key.
 func (k *PrivateKey) PublicKey() *PublicKey {
 	pointG2 := g2.One()
@@ -190,7 +278,7 @@ func generateOKM(ikm []byte, h func() hash.Hash) ([]byte, error) {
 	} else {
 		ikm = make([]byte, seedSize+1)
 
-		_, err := rand.Read(ikm)
+		_, err := random.Read(ikm)
 		if err != nil {
 			return nil, err
 		}
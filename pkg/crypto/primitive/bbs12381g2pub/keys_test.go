@@ -55,6 +55,17 @@ func TestPrivateKey_Marshal(t *testing.T) {
 	require.Equal(t, privKey, privKeyUnmarshalled)
 }
 
+func TestPrivateKey_Destroy(t *testing.T) {
+	_, privKey, err := generateKeyPairRandom()
+	require.NoError(t, err)
+
+	privKey.Destroy()
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, len(privKeyBytes)), privKeyBytes)
+}
+
 func TestPrivateKey_PublicKey(t *testing.T) {
 	pubKey, privKey, err := generateKeyPairRandom()
 	require.NoError(t, err)
@@ -76,6 +87,23 @@ func TestPublicKey_Marshal(t *testing.T) {
 	require.Equal(t, pubKey, pubKeyUnmarshalled)
 }
 
+func TestPublicKey_ToPublicKeyWithGenerators(t *testing.T) {
+	pubKey, _, err := generateKeyPairRandom()
+	require.NoError(t, err)
+
+	pkWithGenerators, err := pubKey.ToPublicKeyWithGenerators(20)
+	require.NoError(t, err)
+
+	// the generators are cached per public key and messages count
+	cached, err := pubKey.ToPublicKeyWithGenerators(20)
+	require.NoError(t, err)
+	require.True(t, pkWithGenerators == cached)
+
+	other, err := pubKey.ToPublicKeyWithGenerators(21)
+	require.NoError(t, err)
+	require.False(t, pkWithGenerators == other)
+}
+
 func TestParseMattrKeys(t *testing.T) {
 	privKeyB58 := "5D6Pa8dSwApdnfg7EZR8WnGfvLDCZPZGsZ5Y1ELL9VDj"
 	privKeyBytes := base58.Decode(privKeyB58)
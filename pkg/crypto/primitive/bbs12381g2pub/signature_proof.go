@@ -108,16 +108,7 @@ func (sp *PoKOfSignatureProof) verifyVC2Proof(challenge *bls12381.Fr, pubKey *Pu
 		}
 	}
 
-	pr := g1.Zero()
-
-	for i := 0; i < len(basesDisclosed); i++ {
-		b := basesDisclosed[i]
-		s := exponents[i]
-
-		g := g1.New()
-		g1.MulScalar(g, b, frToRepr(s))
-		g1.Add(pr, pr, g)
-	}
+	pr := sumOfG1Products(basesDisclosed, exponents)
 
 	g1.Neg(pr, pr)
 
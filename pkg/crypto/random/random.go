@@ -0,0 +1,138 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package random provides the source of randomness of the framework's key generation and nonce creation.
+//
+// The source is crypto/rand by default. It can be replaced (e.g. with aries.WithRandomSource) by a deterministic
+// source for the tests which compare their output with golden values. The source is process-wide: the keys and
+// nonces created with a deterministic source are predictable, it must never be used outside of tests. A single
+// source can be installed at a time, installing another one before restoring the first fails with ErrSourceInstalled.
+//
+// The keys created by Tink (e.g. the KMS keysets) use crypto/rand directly and are not affected by the source.
+package random
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrSourceInstalled is returned by SetSource when a source other than crypto/rand is already installed.
+var ErrSourceInstalled = errors.New("a source of randomness is already installed")
+
+// nolint:gochecknoglobals // the process-wide source of randomness
+var (
+	mu     sync.RWMutex
+	source io.Reader = rand.Reader
+	// installed identifies the installed source, zero when the source is crypto/rand.
+	installed uint64
+	lastID    uint64
+)
+
+// Reader returns a reader reading from the current source of randomness, including the sources set after the call.
+func Reader() io.Reader {
+	return reader{}
+}
+
+// Read fills b with random bytes read from the current source.
+func Read(b []byte) (int, error) {
+	return io.ReadFull(current(), b)
+}
+
+// SetSource installs r as the source of randomness and returns the function restoring crypto/rand. It fails with
+// ErrSourceInstalled when another source is installed. The restore function only restores crypto/rand while r is
+// still installed, it can be called several times.
+//
+// A nil source restores crypto/rand whatever the installed source.
+func SetSource(r io.Reader) (restore func(), err error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if r == nil {
+		source, installed = rand.Reader, 0
+
+		return func() {}, nil
+	}
+
+	if installed != 0 {
+		return nil, ErrSourceInstalled
+	}
+
+	lastID++
+	id := lastID
+	source, installed = r, id
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if installed == id {
+			source, installed = rand.Reader, 0
+		}
+	}, nil
+}
+
+func current() io.Reader {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return source
+}
+
+type reader struct{}
+
+func (reader) Read(b []byte) (int, error) {
+	return Read(b)
+}
+
+// Deterministic is a source of randomness returning the same bytes for the same seed, for tests only.
+// The bytes are the SHA-256 digests of the seed followed by a block counter.
+type Deterministic struct {
+	mu      sync.Mutex
+	seed    []byte
+	counter uint64
+	block   []byte
+}
+
+// NewDeterministic returns a deterministic source of randomness seeded with seed.
+func NewDeterministic(seed []byte) *Deterministic {
+	return &Deterministic{seed: append([]byte(nil), seed...)}
+}
+
+// Read fills b with the next bytes of the source, it never fails.
+func (d *Deterministic) Read(b []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	n := 0
+
+	for n < len(b) {
+		if len(d.block) == 0 {
+			d.block = d.nextBlock()
+		}
+
+		c := copy(b[n:], d.block)
+		d.block = d.block[c:]
+		n += c
+	}
+
+	return n, nil
+}
+
+func (d *Deterministic) nextBlock() []byte {
+	counter := make([]byte, 8) // nolint:gomnd // uint64 size
+	binary.BigEndian.PutUint64(counter, d.counter)
+	d.counter++
+
+	h := sha256.New()
+	_, _ = h.Write(d.seed)
+	_, _ = h.Write(counter)
+
+	return h.Sum(nil)
+}
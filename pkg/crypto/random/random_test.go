@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package random
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeterministic(t *testing.T) {
+	t.Run("same seed, same bytes", func(t *testing.T) {
+		b1 := make([]byte, 100)
+		b2 := make([]byte, 100)
+
+		n, err := NewDeterministic([]byte("seed")).Read(b1)
+		require.NoError(t, err)
+		require.Equal(t, 100, n)
+
+		// reading in chunks returns the same stream
+		d := NewDeterministic([]byte("seed"))
+
+		for i := 0; i < len(b2); i += 7 {
+			end := i + 7
+			if end > len(b2) {
+				end = len(b2)
+			}
+
+			_, err = d.Read(b2[i:end])
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, b1, b2)
+	})
+
+	t.Run("different seeds, different bytes", func(t *testing.T) {
+		b1 := make([]byte, 32)
+		b2 := make([]byte, 32)
+
+		_, err := NewDeterministic([]byte("seed 1")).Read(b1)
+		require.NoError(t, err)
+
+		_, err = NewDeterministic([]byte("seed 2")).Read(b2)
+		require.NoError(t, err)
+
+		require.NotEqual(t, b1, b2)
+	})
+}
+
+func TestSetSource(t *testing.T) {
+	r := Reader()
+
+	restore, err := SetSource(NewDeterministic([]byte("seed")))
+	require.NoError(t, err)
+
+	_, err = SetSource(NewDeterministic([]byte("other")))
+	require.ErrorIs(t, err, ErrSourceInstalled)
+
+	b1 := make([]byte, 48)
+	_, err = r.Read(b1)
+	require.NoError(t, err)
+
+	expected := make([]byte, 48)
+	_, err = NewDeterministic([]byte("seed")).Read(expected)
+	require.NoError(t, err)
+
+	require.Equal(t, expected, b1)
+
+	restore()
+
+	// restoring again leaves the source installed after the first restore
+	restoreOther, err := SetSource(NewDeterministic([]byte("other")))
+	require.NoError(t, err)
+	restore()
+
+	b3 := make([]byte, 48)
+	_, err = Read(b3)
+	require.NoError(t, err)
+
+	expected = make([]byte, 48)
+	_, err = NewDeterministic([]byte("other")).Read(expected)
+	require.NoError(t, err)
+	require.Equal(t, expected, b3)
+
+	restoreOther()
+
+	b2 := make([]byte, 48)
+	_, err = Read(b2)
+	require.NoError(t, err)
+	require.False(t, bytes.Equal(b1, b2))
+
+	// nil restores crypto/rand whatever the installed source
+	_, err = SetSource(NewDeterministic([]byte("seed")))
+	require.NoError(t, err)
+
+	restore, err = SetSource(nil)
+	require.NoError(t, err)
+	restore()
+
+	restore, err = SetSource(NewDeterministic([]byte("seed")))
+	require.NoError(t, err)
+	restore()
+}
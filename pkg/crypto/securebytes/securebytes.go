@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package securebytes provides helpers for the handling of secret key material in memory: the secrets are wiped
+// once used, so that they cannot be scraped from the memory of shared hosts, and compared in constant time, so that
+// the MACs and signatures do not leak through timing side channels.
+//
+// The Go runtime may copy the values (e.g. when growing a slice), the wiping is a best effort which reduces the
+// exposure of the secrets, it does not prevent it.
+package securebytes
+
+import (
+	"crypto/subtle"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// Destroyer is implemented by the wrappers of secret key material that can wipe it from memory.
+type Destroyer interface {
+	// Destroy wipes the key material. The wrapper must not be used afterwards.
+	Destroy()
+}
+
+// Zero overwrites b with zeros.
+func Zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+
+	// prevents the compiler from eliminating the writes to memory no longer read
+	runtime.KeepAlive(b)
+}
+
+// ZeroInt overwrites the words of the big integer (e.g. the D of an ECDSA private key) with zeros.
+func ZeroInt(i *big.Int) {
+	if i == nil {
+		return
+	}
+
+	words := i.Bits()
+
+	for j := range words {
+		words[j] = 0
+	}
+
+	runtime.KeepAlive(words)
+
+	i.SetInt64(0)
+}
+
+// Equal reports whether a and b are equal, in a time independent of their content. The time depends on the length
+// of the values, which is public for MACs and signatures.
+func Equal(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// Bytes wraps secret key material and wipes it on Destroy.
+type Bytes struct {
+	mu        sync.RWMutex
+	value     []byte
+	destroyed bool
+}
+
+// New wraps the secret b. Bytes takes ownership of b, which is wiped on Destroy: the caller must not keep b.
+func New(b []byte) *Bytes {
+	return &Bytes{value: b}
+}
+
+// Bytes returns the secret, nil once destroyed. The returned slice is wiped on Destroy.
+func (s *Bytes) Bytes() []byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.value
+}
+
+// Equal reports whether the secret equals b, in constant time.
+func (s *Bytes) Equal(b []byte) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return !s.destroyed && Equal(s.value, b)
+}
+
+// Destroy wipes the secret. It is safe to call Destroy several times.
+func (s *Bytes) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	Zero(s.value)
+
+	s.value = nil
+	s.destroyed = true
+}
+
+// Destroyed reports whether the secret was destroyed.
+func (s *Bytes) Destroyed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.destroyed
+}
@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package securebytes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZero(t *testing.T) {
+	b := []byte("secret")
+
+	Zero(b)
+	require.Equal(t, make([]byte, 6), b)
+
+	Zero(nil)
+}
+
+func TestZeroInt(t *testing.T) {
+	i := new(big.Int).SetBytes([]byte("a private key scalar"))
+	words := i.Bits()
+
+	ZeroInt(i)
+	require.Zero(t, i.Sign())
+
+	for _, w := range words {
+		require.Zero(t, w)
+	}
+
+	ZeroInt(nil)
+}
+
+func TestEqual(t *testing.T) {
+	require.True(t, Equal([]byte("mac"), []byte("mac")))
+	require.False(t, Equal([]byte("mac"), []byte("mad")))
+	require.False(t, Equal([]byte("mac"), []byte("mac1")))
+	require.True(t, Equal(nil, []byte{}))
+}
+
+func TestBytes(t *testing.T) {
+	secret := []byte("secret")
+
+	s := New(secret)
+	require.Equal(t, []byte("secret"), s.Bytes())
+	require.True(t, s.Equal([]byte("secret")))
+	require.False(t, s.Destroyed())
+
+	var d Destroyer = s
+
+	d.Destroy()
+	require.True(t, s.Destroyed())
+	require.Nil(t, s.Bytes())
+	require.False(t, s.Equal([]byte("secret")))
+	require.Equal(t, make([]byte, 6), secret)
+
+	s.Destroy()
+	require.True(t, s.Destroyed())
+}
@@ -0,0 +1,152 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package shamir implements Shamir's secret sharing over GF(2^8): a secret is split into n shares so that any
+// threshold of them recovers it, while fewer shares reveal nothing about it.
+//
+// Each byte of the secret is the constant term of a random polynomial of degree threshold-1. A share holds the
+// evaluations of the polynomials at the x coordinate of the share, followed by the x coordinate (one byte).
+package shamir
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/securebytes"
+)
+
+const (
+	// MaxShares is the maximum number of shares of a secret, the number of non zero elements of GF(2^8).
+	MaxShares = 255
+	// MinThreshold is the minimum number of shares required to recover a secret.
+	MinThreshold = 2
+)
+
+// Split splits the secret into n shares, any threshold of which recover the secret with Combine.
+// The coefficients of the polynomials are read from the source of randomness of the framework.
+func Split(secret []byte, n, threshold int) ([][]byte, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("cannot split an empty secret")
+	}
+
+	if threshold < MinThreshold || threshold > n || n > MaxShares {
+		return nil, fmt.Errorf("invalid number of shares %d with threshold %d", n, threshold)
+	}
+
+	shares := make([][]byte, n)
+
+	for i := range shares {
+		shares[i] = make([]byte, len(secret)+1)
+		shares[i][len(secret)] = byte(i + 1)
+	}
+
+	coefficients := make([]byte, threshold)
+	defer securebytes.Zero(coefficients)
+
+	for b, s := range secret {
+		coefficients[0] = s
+
+		if _, err := random.Read(coefficients[1:]); err != nil {
+			return nil, fmt.Errorf("read polynomial coefficients: %w", err)
+		}
+
+		for _, share := range shares {
+			share[b] = evaluate(coefficients, share[len(secret)])
+		}
+	}
+
+	return shares, nil
+}
+
+// Combine recovers the secret from at least threshold of its shares. The shares are not authenticated:
+// combining too few shares, or shares of different secrets, returns a wrong secret rather than an error.
+func Combine(shares [][]byte) ([]byte, error) {
+	if len(shares) < MinThreshold {
+		return nil, fmt.Errorf("at least %d shares are required", MinThreshold)
+	}
+
+	size := len(shares[0])
+	if size < 2 { // nolint: gomnd
+		return nil, errors.New("share is too short")
+	}
+
+	xs := make([]byte, len(shares))
+	seen := map[byte]bool{}
+
+	for i, share := range shares {
+		if len(share) != size {
+			return nil, errors.New("shares have different lengths")
+		}
+
+		xs[i] = share[size-1]
+
+		if xs[i] == 0 || seen[xs[i]] {
+			return nil, fmt.Errorf("invalid or duplicate share x coordinate %d", xs[i])
+		}
+
+		seen[xs[i]] = true
+	}
+
+	// the Lagrange basis polynomials evaluated at x = 0 only depend on the x coordinates of the shares
+	basis := make([]byte, len(shares))
+
+	for i := range xs {
+		basis[i] = 1
+
+		for j := range xs {
+			if i != j {
+				basis[i] = mul(basis[i], div(xs[j], xs[i]^xs[j]))
+			}
+		}
+	}
+
+	secret := make([]byte, size-1)
+
+	for b := range secret {
+		for i, share := range shares {
+			secret[b] ^= mul(share[b], basis[i])
+		}
+	}
+
+	return secret, nil
+}
+
+// evaluate evaluates the polynomial with the given coefficients (constant term first) at x, with Horner's method.
+func evaluate(coefficients []byte, x byte) byte {
+	var y byte
+
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = mul(y, x) ^ coefficients[i]
+	}
+
+	return y
+}
+
+// mul multiplies a and b in GF(2^8) modulo the AES polynomial x^8 + x^4 + x^3 + x + 1, without data dependent
+// branches.
+func mul(a, b byte) byte {
+	var p byte
+
+	for i := 0; i < 8; i++ {
+		p ^= -(b & 1) & a
+		a = (a << 1) ^ (-(a >> 7) & 0x1b) // nolint: gomnd
+		b >>= 1
+	}
+
+	return p
+}
+
+// div divides a by b (non zero) in GF(2^8): the inverse of b is b^254.
+func div(a, b byte) byte {
+	inverse := b
+
+	for i := 0; i < 6; i++ { // b^127 after the loop, squared below
+		inverse = mul(mul(inverse, inverse), b)
+	}
+
+	return mul(a, mul(inverse, inverse))
+}
@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shamir
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
+)
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("32 bytes of secret key material!")
+
+	t.Run("any threshold of shares recover the secret", func(t *testing.T) {
+		shares, err := Split(secret, 5, 3)
+		require.NoError(t, err)
+		require.Len(t, shares, 5)
+
+		for _, share := range shares {
+			require.Len(t, share, len(secret)+1)
+		}
+
+		for _, subset := range [][][]byte{
+			{shares[0], shares[1], shares[2]},
+			{shares[4], shares[2], shares[0]},
+			{shares[1], shares[3], shares[4]},
+			{shares[0], shares[1], shares[2], shares[3]},
+			shares,
+		} {
+			recovered, err := Combine(subset)
+			require.NoError(t, err)
+			require.Equal(t, secret, recovered)
+		}
+
+		recovered, err := Combine(shares[:2])
+		require.NoError(t, err)
+		require.NotEqual(t, secret, recovered)
+	})
+
+	t.Run("maximum number of shares", func(t *testing.T) {
+		shares, err := Split([]byte{0x42}, MaxShares, MaxShares)
+		require.NoError(t, err)
+
+		recovered, err := Combine(shares)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x42}, recovered)
+	})
+
+	t.Run("deterministic source", func(t *testing.T) {
+		restore, err := random.SetSource(random.NewDeterministic([]byte("seed")))
+		require.NoError(t, err)
+
+		first, err := Split(secret, 3, 2)
+		restore()
+		require.NoError(t, err)
+
+		restore, err = random.SetSource(random.NewDeterministic([]byte("seed")))
+		require.NoError(t, err)
+
+		second, err := Split(secret, 3, 2)
+		restore()
+		require.NoError(t, err)
+
+		require.Equal(t, first, second)
+	})
+}
+
+func TestSplit_Errors(t *testing.T) {
+	_, err := Split(nil, 3, 2)
+	require.EqualError(t, err, "cannot split an empty secret")
+
+	for _, args := range [][2]int{{3, 1}, {3, 4}, {MaxShares + 1, 2}} {
+		_, err = Split([]byte("secret"), args[0], args[1])
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid number of shares")
+	}
+
+	restore, err := random.SetSource(&failingReader{})
+	require.NoError(t, err)
+
+	defer restore()
+
+	_, err = Split([]byte("secret"), 3, 2)
+	require.EqualError(t, err, "read polynomial coefficients: read error")
+}
+
+func TestCombine_Errors(t *testing.T) {
+	shares, err := Split([]byte("secret"), 3, 2)
+	require.NoError(t, err)
+
+	_, err = Combine(shares[:1])
+	require.EqualError(t, err, "at least 2 shares are required")
+
+	_, err = Combine([][]byte{{1}, {2}})
+	require.EqualError(t, err, "share is too short")
+
+	_, err = Combine([][]byte{shares[0], shares[1][1:]})
+	require.EqualError(t, err, "shares have different lengths")
+
+	_, err = Combine([][]byte{shares[0], shares[0]})
+	require.EqualError(t, err, "invalid or duplicate share x coordinate 1")
+
+	zero := append([]byte(nil), shares[1]...)
+	zero[len(zero)-1] = 0
+
+	_, err = Combine([][]byte{shares[0], zero})
+	require.EqualError(t, err, "invalid or duplicate share x coordinate 0")
+}
+
+func TestGF256(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		require.Equal(t, byte(1), mul(byte(a), div(1, byte(a))), a)
+		require.Equal(t, byte(a), div(mul(byte(a), 0x53), 0x53), a) // nolint: gomnd
+	}
+
+	require.Equal(t, byte(0xc1), mul(0x57, 0x83)) // FIPS-197 section 4.2
+}
+
+type failingReader struct{}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read error")
+}
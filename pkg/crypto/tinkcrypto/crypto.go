@@ -24,6 +24,8 @@ import (
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
+	// registers the secp256k1 key managers, Sign and Verify support secp256k1 keys like the other ECDSA keys.
+	_ "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 )
 
 const (
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 )
 
 const testMessage = "test message"
@@ -187,6 +188,27 @@ func TestCrypto_SignVerify(t *testing.T) {
 		err = c.Verify(s, msg, badKH)
 		require.Error(t, err)
 	})
+
+	t.Run("test with secp256k1 signature", func(t *testing.T) {
+		for _, kt := range []*tinkpb.KeyTemplate{secp256k1.DERKeyTemplate(), secp256k1.IEEEP1363KeyTemplate()} {
+			kh, err := keyset.NewHandle(kt)
+			require.NoError(t, err)
+
+			c := Crypto{}
+			msg := []byte(testMessage)
+			s, err := c.Sign(msg, kh)
+			require.NoError(t, err)
+
+			pubKH, err := kh.Public()
+			require.NoError(t, err)
+
+			err = c.Verify(s, msg, pubKH)
+			require.NoError(t, err)
+
+			err = c.Verify(s, []byte("other message"), pubKH)
+			require.Error(t, err)
+		}
+	})
 }
 
 func TestCrypto_ComputeMAC(t *testing.T) {
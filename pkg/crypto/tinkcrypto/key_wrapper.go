@@ -21,6 +21,7 @@ import (
 	"golang.org/x/crypto/curve25519"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/securebytes"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
@@ -107,6 +108,8 @@ func (t *Crypto) deriveKEKAndUnwrap(alg string, encCEK, apu, apv []byte, epk *cr
 		return nil, fmt.Errorf("deriveKEKAndUnwrap: %w", err)
 	}
 
+	defer destroyPrivKey(recipientPrivateKey)
+
 	switch alg {
 	case ECDH1PUA256KWAlg, ECDH1PUXC20PKWAlg:
 		kek, err = t.derive1PUKEKForUnwrap(alg, apu, apv, epk, senderKH, recipientPrivateKey)
@@ -279,6 +282,8 @@ func (t *Crypto) derive1PUWithECKey(apu, apv []byte, senderKH interface{}, recPu
 		return "", nil, nil, nil, fmt.Errorf("derive1PUWithECKey: failed to retrieve sender key: %w", err)
 	}
 
+	defer destroyPrivKey(senderPrivKey)
+
 	pubKey, ephemeralPrivKey, err := t.convertRecKeyAndGenEPKEC(recPubKey)
 	if err != nil {
 		return "", nil, nil, nil, err
@@ -421,6 +426,8 @@ func (t *Crypto) derive1PUWithOKPKey(apu, apv []byte, senderKH interface{}, recP
 		return "", nil, nil, nil, fmt.Errorf("derive1PUWithOKPKey: failed to retrieve sender key: %w", err)
 	}
 
+	defer destroyPrivKey(senderPrivKey)
+
 	ephemeralPubKey, ephemeralPrivKey, err := t.generateEphemeralOKPKey()
 	if err != nil {
 		return "", nil, nil, nil, fmt.Errorf("derive1PUWithOKPKey: failed to generate ephemeral key: %w", err)
@@ -481,6 +488,8 @@ func (t *Crypto) deriveESWithOKPKey(apu, apv []byte, recPubKey *cryptoapi.Public
 	ephemeralPrivChacha := new([chacha20poly1305.KeySize]byte)
 	copy(ephemeralPrivChacha[:], ephemeralPrivKey)
 
+	defer securebytes.Zero(ephemeralPrivChacha[:])
+
 	recPubKeyChacha := new([chacha20poly1305.KeySize]byte)
 	copy(recPubKeyChacha[:], recPubKey.X)
 
@@ -513,6 +522,8 @@ func (t *Crypto) deriveESWithOKPKeyForUnwrap(alg string, apu, apv []byte, epk *c
 	recPrivKeyChacha := new([chacha20poly1305.KeySize]byte)
 	copy(recPrivKeyChacha[:], recPrivOKPKey)
 
+	defer securebytes.Zero(recPrivKeyChacha[:])
+
 	epkChacha := new([chacha20poly1305.KeySize]byte)
 	copy(epkChacha[:], epk.X)
 
@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package bbs
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
 
@@ -18,6 +17,7 @@ import (
 	"github.com/google/tink/go/subtle"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 	bbssubtle "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs/subtle"
 	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
 )
@@ -91,7 +91,7 @@ func (km *bbsSignerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message
 	if keyFormat.Params.Group == bbspb.GroupField_G2 && keyFormat.Params.Curve == bbspb.BBSCurveType_BLS12_381 {
 		seed := make([]byte, 32)
 
-		_, err = rand.Read(seed)
+		_, err = random.Read(seed)
 		if err != nil {
 			return nil, err
 		}
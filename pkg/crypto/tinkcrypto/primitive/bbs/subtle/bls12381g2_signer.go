@@ -8,6 +8,7 @@ package subtle
 
 import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/securebytes"
 )
 
 // BLS12381G2Signer is the BBS+ signer for BLS12-381 curve for keys on a G2 group.
@@ -34,3 +35,8 @@ func NewBLS12381G2Signer(privateKey []byte) *BLS12381G2Signer {
 func (s *BLS12381G2Signer) Sign(messages [][]byte) ([]byte, error) {
 	return s.bbsPrimitive.Sign(messages, s.privateKeyBytes)
 }
+
+// Destroy wipes the private key of the signer. The signer must not be used afterwards.
+func (s *BLS12381G2Signer) Destroy() {
+	securebytes.Zero(s.privateKeyBytes)
+}
@@ -43,6 +43,9 @@ func TestBBSG2_Sign(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, "messages are not defined")
 	require.Nil(t, signatureBytes)
+
+	blsSigner.Destroy()
+	require.Equal(t, make([]byte, len(privKeyBytes)), privKeyBytes)
 }
 
 func TestBBSG2_DeriveProof(t *testing.T) {
@@ -0,0 +1,46 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package secp256k1 provides the Tink key managers of ECDSA keys on the secp256k1 curve, which Tink does not
+// support natively. The keys are serialized as Tink's ECDSA key protos under their own type URLs, the curve of
+// their parameters is left unknown as the type URL implies it.
+//
+// The primitives of the keys are the standard Tink signature primitives, the keysets are used like the other
+// signature keysets:
+//
+//  kh, err := keyset.NewHandle(secp256k1.IEEEP1363KeyTemplate())
+//  if err != nil {
+//      // handle error
+//  }
+//
+//  s, err := signature.NewSigner(kh)
+//  if err != nil {
+//      // handle error
+//  }
+//
+//  sig, err := s.Sign(msg)
+package secp256k1
+
+import (
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+)
+
+// TODO - find a better way to setup tink than init.
+// nolint: gochecknoinits
+func init() {
+	// TODO - avoid the tink registry singleton.
+	err := registry.RegisterKeyManager(newSecp256k1SignerKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("secp256k1.init() failed: %v", err))
+	}
+
+	err = registry.RegisterKeyManager(newSecp256k1VerifierKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("secp256k1.init() failed: %v", err))
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecp256k1KeyManagers(t *testing.T) {
+	for _, kt := range []*tinkpb.KeyTemplate{DERKeyTemplate(), IEEEP1363KeyTemplate()} {
+		kh, err := keyset.NewHandle(kt)
+		require.NoError(t, err)
+
+		s, err := signature.NewSigner(kh)
+		require.NoError(t, err)
+
+		pubKH, err := kh.Public()
+		require.NoError(t, err)
+
+		v, err := signature.NewVerifier(pubKH)
+		require.NoError(t, err)
+
+		msg := []byte("message")
+
+		sig, err := s.Sign(msg)
+		require.NoError(t, err)
+		require.NoError(t, v.Verify(sig, msg))
+		require.Error(t, v.Verify(sig, []byte("other message")))
+	}
+}
+
+func TestSecp256k1SignerKeyManager(t *testing.T) {
+	km := newSecp256k1SignerKeyManager()
+
+	require.True(t, km.DoesSupport(secp256k1SignerKeyTypeURL))
+	require.Equal(t, secp256k1SignerKeyTypeURL, km.TypeURL())
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.EqualError(t, err, errInvalidSecp256k1SignerKey.Error())
+
+		_, err = km.Primitive([]byte("bad.data"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid proto")
+
+		_, err = km.NewKey(nil)
+		require.EqualError(t, err, errInvalidSecp256k1SignerKeyFormat.Error())
+
+		_, err = km.PublicKeyData([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidSecp256k1SignerKey.Error())
+	})
+
+	t.Run("invalid key format params", func(t *testing.T) {
+		format, err := proto.Marshal(&ecdsapb.EcdsaKeyFormat{
+			Params: &ecdsapb.EcdsaParams{
+				HashType: commonpb.HashType_SHA512,
+				Encoding: ecdsapb.EcdsaSignatureEncoding_DER,
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = km.NewKeyData(format)
+		require.EqualError(t, err, errInvalidSecp256k1SignerKeyFormat.Error()+": invalid hash type 'SHA512'")
+
+		format, err = proto.Marshal(&ecdsapb.EcdsaKeyFormat{
+			Params: &ecdsapb.EcdsaParams{HashType: commonpb.HashType_SHA256},
+		})
+		require.NoError(t, err)
+
+		_, err = km.NewKeyData(format)
+		require.EqualError(t, err, errInvalidSecp256k1SignerKeyFormat.Error()+
+			": invalid signature encoding 'UNKNOWN_ENCODING'")
+	})
+
+	t.Run("public key data", func(t *testing.T) {
+		kd, err := km.NewKeyData(DERKeyTemplate().Value)
+		require.NoError(t, err)
+		require.Equal(t, secp256k1SignerKeyTypeURL, kd.TypeUrl)
+
+		pubKD, err := km.PublicKeyData(kd.Value)
+		require.NoError(t, err)
+		require.Equal(t, secp256k1VerifierKeyTypeURL, pubKD.TypeUrl)
+		require.Equal(t, tinkpb.KeyData_ASYMMETRIC_PUBLIC, pubKD.KeyMaterialType)
+	})
+}
+
+func TestSecp256k1VerifierKeyManager(t *testing.T) {
+	km := newSecp256k1VerifierKeyManager()
+
+	require.True(t, km.DoesSupport(secp256k1VerifierKeyTypeURL))
+	require.Equal(t, secp256k1VerifierKeyTypeURL, km.TypeURL())
+
+	_, err := km.Primitive(nil)
+	require.EqualError(t, err, errInvalidSecp256k1VerifierKey.Error())
+
+	_, err = km.Primitive([]byte("bad.data"))
+	require.EqualError(t, err, errInvalidSecp256k1VerifierKey.Error())
+
+	_, err = km.NewKey(nil)
+	require.EqualError(t, err, "secp256k1_verifier_key_manager: NewKey not implemented")
+
+	_, err = km.NewKeyData(nil)
+	require.EqualError(t, err, "secp256k1_verifier_key_manager: NewKeyData not implemented")
+}
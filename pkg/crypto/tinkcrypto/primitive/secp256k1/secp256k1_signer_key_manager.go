@@ -0,0 +1,183 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1/subtle"
+)
+
+const (
+	secp256k1SignerKeyVersion = 0
+	secp256k1SignerKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PrivateKey"
+)
+
+// common errors.
+var (
+	errInvalidSecp256k1SignerKey       = errors.New("secp256k1_signer_key_manager: invalid key")
+	errInvalidSecp256k1SignerKeyFormat = errors.New("secp256k1_signer_key_manager: invalid key format")
+)
+
+// secp256k1SignerKeyManager is an implementation of KeyManager interface for secp256k1 signatures.
+// It generates new secp256k1 EcdsaPrivateKeys and produces new instances of Secp256k1Signer subtle.
+type secp256k1SignerKeyManager struct{}
+
+// newSecp256k1SignerKeyManager creates a new secp256k1SignerKeyManager.
+func newSecp256k1SignerKeyManager() *secp256k1SignerKeyManager {
+	return new(secp256k1SignerKeyManager)
+}
+
+// Primitive creates a Secp256k1Signer subtle for the given serialized EcdsaPrivateKey proto.
+func (km *secp256k1SignerKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidSecp256k1SignerKey
+	}
+
+	key := new(ecdsapb.EcdsaPrivateKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSecp256k1SignerKey.Error()+": invalid proto: %w", err)
+	}
+
+	err = km.validateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSecp256k1SignerKey.Error()+": %w", err)
+	}
+
+	return subtle.NewSecp256k1Signer(key.KeyValue, key.PublicKey.Params.Encoding.String())
+}
+
+// NewKey creates a new key according to the specification of EcdsaKeyFormat.
+func (km *secp256k1SignerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if len(serializedKeyFormat) == 0 {
+		return nil, errInvalidSecp256k1SignerKeyFormat
+	}
+
+	keyFormat := new(ecdsapb.EcdsaKeyFormat)
+
+	err := proto.Unmarshal(serializedKeyFormat, keyFormat)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSecp256k1SignerKeyFormat.Error()+": invalid proto: %w", err)
+	}
+
+	err = validateKeyParams(keyFormat.Params)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSecp256k1SignerKeyFormat.Error()+": %w", err)
+	}
+
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), random.Reader())
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer_key_manager: GenerateKey failed: %w", err)
+	}
+
+	return &ecdsapb.EcdsaPrivateKey{
+		Version:  secp256k1SignerKeyVersion,
+		KeyValue: privKey.D.Bytes(),
+		PublicKey: &ecdsapb.EcdsaPublicKey{
+			Version: secp256k1SignerKeyVersion,
+			Params:  keyFormat.Params,
+			X:       privKey.X.Bytes(),
+			Y:       privKey.Y.Bytes(),
+		},
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to the specification of EcdsaKeyFormat.
+// It should be used solely by the key management API.
+func (km *secp256k1SignerKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer_key_manager: Proto.Marshal failed: %w", err)
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         secp256k1SignerKeyTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *secp256k1SignerKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(ecdsapb.EcdsaPrivateKey)
+
+	err := proto.Unmarshal(serializedPrivKey, privKey)
+	if err != nil {
+		return nil, errInvalidSecp256k1SignerKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidSecp256k1SignerKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         secp256k1VerifierKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *secp256k1SignerKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == secp256k1SignerKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *secp256k1SignerKeyManager) TypeURL() string {
+	return secp256k1SignerKeyTypeURL
+}
+
+// validateKey validates the given EcdsaPrivateKey.
+func (km *secp256k1SignerKeyManager) validateKey(key *ecdsapb.EcdsaPrivateKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, secp256k1SignerKeyVersion)
+	if err != nil {
+		return fmt.Errorf("secp256k1_signer_key_manager: invalid key: %w", err)
+	}
+
+	if key.PublicKey == nil {
+		return errors.New("missing public key")
+	}
+
+	return validateKeyParams(key.PublicKey.Params)
+}
+
+// validateKeyParams validates the hash and encoding of the secp256k1 keys, the curve is implied by the type URL.
+func validateKeyParams(params *ecdsapb.EcdsaParams) error {
+	if params == nil {
+		return errors.New("missing params")
+	}
+
+	if params.HashType != commonpb.HashType_SHA256 {
+		return fmt.Errorf("invalid hash type '%s'", params.HashType)
+	}
+
+	switch params.Encoding {
+	case ecdsapb.EcdsaSignatureEncoding_DER, ecdsapb.EcdsaSignatureEncoding_IEEE_P1363:
+	default:
+		return fmt.Errorf("invalid signature encoding '%s'", params.Encoding)
+	}
+
+	return nil
+}
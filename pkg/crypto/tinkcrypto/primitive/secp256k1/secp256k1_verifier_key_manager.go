@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1/subtle"
+)
+
+const (
+	secp256k1VerifierKeyVersion = 0
+	secp256k1VerifierKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PublicKey"
+)
+
+// common errors.
+var errInvalidSecp256k1VerifierKey = errors.New("secp256k1_verifier_key_manager: invalid key")
+
+// secp256k1VerifierKeyManager is an implementation of KeyManager interface for secp256k1 signature verification.
+// It doesn't support key generation.
+type secp256k1VerifierKeyManager struct{}
+
+// newSecp256k1VerifierKeyManager creates a new secp256k1VerifierKeyManager.
+func newSecp256k1VerifierKeyManager() *secp256k1VerifierKeyManager {
+	return new(secp256k1VerifierKeyManager)
+}
+
+// Primitive creates a Secp256k1Verifier subtle for the given serialized EcdsaPublicKey proto.
+func (km *secp256k1VerifierKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidSecp256k1VerifierKey
+	}
+
+	key := new(ecdsapb.EcdsaPublicKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidSecp256k1VerifierKey
+	}
+
+	err = km.validateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSecp256k1VerifierKey.Error()+": %w", err)
+	}
+
+	return subtle.NewSecp256k1Verifier(key.X, key.Y, key.Params.Encoding.String())
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *secp256k1VerifierKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == secp256k1VerifierKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *secp256k1VerifierKeyManager) TypeURL() string {
+	return secp256k1VerifierKeyTypeURL
+}
+
+// NewKey is not implemented for public key manager.
+func (km *secp256k1VerifierKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errors.New("secp256k1_verifier_key_manager: NewKey not implemented")
+}
+
+// NewKeyData is not implemented for public key manager.
+func (km *secp256k1VerifierKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errors.New("secp256k1_verifier_key_manager: NewKeyData not implemented")
+}
+
+// validateKey validates the given EcdsaPublicKey.
+func (km *secp256k1VerifierKeyManager) validateKey(key *ecdsapb.EcdsaPublicKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, secp256k1VerifierKeyVersion)
+	if err != nil {
+		return err
+	}
+
+	return validateKeyParams(key.Params)
+}
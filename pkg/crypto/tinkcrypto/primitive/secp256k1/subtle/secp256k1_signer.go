@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
+)
+
+const (
+	// DEREncoding is the DER (ASN.1) encoding of secp256k1 signatures.
+	DEREncoding = "DER"
+	// IEEEP1363Encoding is the IEEE P1363 (r||s) encoding of secp256k1 signatures, as used by ES256K JWS.
+	IEEEP1363Encoding = "IEEE_P1363"
+
+	coordinateSize = 32
+)
+
+// Secp256k1Signer is the ECDSA signer for keys on the secp256k1 curve, the messages are hashed with SHA-256.
+type Secp256k1Signer struct {
+	privateKey *ecdsa.PrivateKey
+	encoding   string
+}
+
+// NewSecp256k1Signer creates a new instance of Secp256k1Signer with the provided private key value and signature
+// encoding (DEREncoding or IEEEP1363Encoding).
+func NewSecp256k1Signer(keyValue []byte, encoding string) (*Secp256k1Signer, error) {
+	if err := validateEncoding(encoding); err != nil {
+		return nil, err
+	}
+
+	curve := btcec.S256()
+	d := new(big.Int).SetBytes(keyValue)
+
+	if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("secp256k1_signer: invalid private key")
+	}
+
+	privKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve},
+		D:         d,
+	}
+	privKey.X, privKey.Y = curve.ScalarBaseMult(keyValue)
+
+	return &Secp256k1Signer{privateKey: privKey, encoding: encoding}, nil
+}
+
+// Sign computes a signature of the SHA-256 digest of data. The signatures are low-S normalized as required by
+// Bitcoin and Ethereum verifiers.
+func (s *Secp256k1Signer) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	r, sv, err := ecdsa.Sign(random.Reader(), s.privateKey, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer: failed to sign: %w", err)
+	}
+
+	n := s.privateKey.Curve.Params().N
+	if sv.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		sv = new(big.Int).Sub(n, sv)
+	}
+
+	return encodeSignature(r, sv, s.encoding)
+}
+
+type derSignature struct {
+	R, S *big.Int
+}
+
+func encodeSignature(r, s *big.Int, encoding string) ([]byte, error) {
+	if encoding == DEREncoding {
+		return asn1.Marshal(derSignature{R: r, S: s})
+	}
+
+	sig := make([]byte, 2*coordinateSize)
+	r.FillBytes(sig[:coordinateSize])
+	s.FillBytes(sig[coordinateSize:])
+
+	return sig, nil
+}
+
+func validateEncoding(encoding string) error {
+	switch encoding {
+	case DEREncoding, IEEEP1363Encoding:
+		return nil
+	default:
+		return fmt.Errorf("secp256k1: unsupported signature encoding '%s'", encoding)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecp256k1_SignVerify(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	msg := []byte("message")
+
+	for _, encoding := range []string{DEREncoding, IEEEP1363Encoding} {
+		encoding := encoding
+
+		t.Run(encoding, func(t *testing.T) {
+			signer, err := NewSecp256k1Signer(privKey.D.Bytes(), encoding)
+			require.NoError(t, err)
+
+			verifier, err := NewSecp256k1Verifier(privKey.X.Bytes(), privKey.Y.Bytes(), encoding)
+			require.NoError(t, err)
+
+			sig, err := signer.Sign(msg)
+			require.NoError(t, err)
+
+			if encoding == IEEEP1363Encoding {
+				require.Len(t, sig, 64)
+			}
+
+			r, s, err := decodeSignature(sig, encoding)
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, s.Cmp(new(big.Int).Rsh(btcec.S256().N, 1)) <= 0, "signature must be low-S")
+
+			require.NoError(t, verifier.Verify(sig, msg))
+			require.EqualError(t, verifier.Verify(sig, []byte("other message")), errInvalidSignature.Error())
+			require.EqualError(t, verifier.Verify([]byte("bad signature"), msg), errInvalidSignature.Error())
+		})
+	}
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		_, err := NewSecp256k1Signer(privKey.D.Bytes(), "BAD")
+		require.EqualError(t, err, "secp256k1: unsupported signature encoding 'BAD'")
+
+		_, err = NewSecp256k1Signer(nil, DEREncoding)
+		require.EqualError(t, err, "secp256k1_signer: invalid private key")
+
+		_, err = NewSecp256k1Verifier(privKey.X.Bytes(), privKey.X.Bytes(), DEREncoding)
+		require.EqualError(t, err, "secp256k1_verifier: invalid public key: point not on curve")
+	})
+}
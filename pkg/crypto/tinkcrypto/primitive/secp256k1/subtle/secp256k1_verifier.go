@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+var errInvalidSignature = errors.New("secp256k1_verifier: invalid signature")
+
+// Secp256k1Verifier is the ECDSA verifier for keys on the secp256k1 curve, the messages are hashed with SHA-256.
+type Secp256k1Verifier struct {
+	publicKey *ecdsa.PublicKey
+	encoding  string
+}
+
+// NewSecp256k1Verifier creates a new instance of Secp256k1Verifier with the provided public key coordinates and
+// signature encoding (DEREncoding or IEEEP1363Encoding).
+func NewSecp256k1Verifier(x, y []byte, encoding string) (*Secp256k1Verifier, error) {
+	if err := validateEncoding(encoding); err != nil {
+		return nil, err
+	}
+
+	curve := btcec.S256()
+	pubKey := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+
+	if !curve.IsOnCurve(pubKey.X, pubKey.Y) {
+		return nil, errors.New("secp256k1_verifier: invalid public key: point not on curve")
+	}
+
+	return &Secp256k1Verifier{publicKey: pubKey, encoding: encoding}, nil
+}
+
+// Verify verifies signature is a signature of the SHA-256 digest of data.
+// returns:
+// 		error in case of errors or nil if signature verification was successful
+func (v *Secp256k1Verifier) Verify(signature, data []byte) error {
+	r, s, err := decodeSignature(signature, v.encoding)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(data)
+
+	if !ecdsa.Verify(v.publicKey, digest[:], r, s) {
+		return errInvalidSignature
+	}
+
+	return nil
+}
+
+func decodeSignature(signature []byte, encoding string) (*big.Int, *big.Int, error) {
+	if encoding == DEREncoding {
+		sig := derSignature{}
+
+		rest, err := asn1.Unmarshal(signature, &sig)
+		if err != nil || len(rest) != 0 || sig.R == nil || sig.S == nil {
+			return nil, nil, errInvalidSignature
+		}
+
+		return sig.R, sig.S, nil
+	}
+
+	if len(signature) != 2*coordinateSize {
+		return nil, nil, errInvalidSignature
+	}
+
+	return new(big.Int).SetBytes(signature[:coordinateSize]), new(big.Int).SetBytes(signature[coordinateSize:]), nil
+}
@@ -20,6 +20,7 @@ import (
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/securebytes"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
 )
 
@@ -37,6 +38,9 @@ func extractPrivKey(kh *keyset.Handle) (interface{}, error) {
 		return nil, fmt.Errorf("extractPrivKey: retrieving private key failed: %w", err)
 	}
 
+	// the keyset is written in clear text by the noop AEAD
+	defer securebytes.Zero(buf.Bytes())
+
 	ks := new(tinkpb.Keyset)
 
 	err = proto.Unmarshal(buf.Bytes(), ks)
@@ -62,7 +66,11 @@ func extractPrivKey(kh *keyset.Handle) (interface{}, error) {
 			return nil, fmt.Errorf("extractPrivKey: invalid key: %w", err)
 		}
 
-		return hybrid.GetECPrivateKey(c, pbKey.KeyValue), nil
+		privKey := hybrid.GetECPrivateKey(c, pbKey.KeyValue)
+
+		securebytes.Zero(pbKey.KeyValue)
+
+		return privKey, nil
 	case x25519ECDHKWPrivateKeyTypeURL:
 		pbKey := new(ecdhpb.EcdhAeadPrivateKey)
 
@@ -81,6 +89,18 @@ func extractPrivKey(kh *keyset.Handle) (interface{}, error) {
 	return nil, fmt.Errorf("extractPrivKey: can't extract unsupported private key '%s'", primaryKey.KeyData.TypeUrl)
 }
 
+// destroyPrivKey wipes the private key extracted from a key handle by extractPrivKey, once used.
+func destroyPrivKey(privKey interface{}) {
+	switch k := privKey.(type) {
+	case *hybrid.ECPrivateKey:
+		securebytes.ZeroInt(k.D)
+	case *ecdsa.PrivateKey:
+		securebytes.ZeroInt(k.D)
+	case []byte:
+		securebytes.Zero(k)
+	}
+}
+
 func hybridECPrivToECDSAKey(hybridEcPriv *hybrid.ECPrivateKey) *ecdsa.PrivateKey {
 	return &ecdsa.PrivateKey{
 		PublicKey: ecdsa.PublicKey{
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package tinkcrypto
 
 import (
+	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -171,6 +172,27 @@ func ecdhesAEADPrivateKey(t *testing.T, p *ecdhpb.EcdhAeadPublicKey, d []byte) *
 	}
 }
 
+func Test_DestroyPrivKey(t *testing.T) {
+	okpKey := []byte("x25519 private key value")
+
+	destroyPrivKey(okpKey)
+	require.Equal(t, make([]byte, len(okpKey)), okpKey)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	destroyPrivKey(ecKey)
+	require.Zero(t, ecKey.D.Sign())
+
+	hybridKey := hybrid.GetECPrivateKey(elliptic.P256(), random.GetRandomBytes(32))
+
+	destroyPrivKey(hybridKey)
+	require.Zero(t, hybridKey.D.Sign())
+
+	// unsupported keys are ignored
+	destroyPrivKey("key")
+}
+
 func TestNoopAEAD_Decrypt(t *testing.T) {
 	n := noopAEAD{}
 
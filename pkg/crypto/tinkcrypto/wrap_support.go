@@ -12,7 +12,6 @@ import (
 	"crypto/cipher"
 	"crypto/ecdsa"
 	"crypto/elliptic"
-	"crypto/rand"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -21,6 +20,7 @@ import (
 	josecipher "github.com/square/go-jose/v3/cipher"
 	"golang.org/x/crypto/chacha20poly1305"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 )
 
@@ -43,7 +43,7 @@ func (w *ecKWSupport) getCurve(curve string) (elliptic.Curve, error) {
 }
 
 func (w *ecKWSupport) generateKey(curve elliptic.Curve) (interface{}, error) {
-	return ecdsa.GenerateKey(curve, rand.Reader)
+	return ecdsa.GenerateKey(curve, random.Reader())
 }
 
 func (w *ecKWSupport) createPrimitive(kek []byte) (interface{}, error) {
@@ -132,7 +132,7 @@ func (o *okpKWSupport) getCurve(curve string) (elliptic.Curve, error) {
 func (o *okpKWSupport) generateKey(_ elliptic.Curve) (interface{}, error) {
 	newKey := make([]byte, cryptoutil.Curve25519KeySize)
 
-	_, err := rand.Read(newKey)
+	_, err := random.Read(newKey)
 	if err != nil {
 		return nil, fmt.Errorf("generateKey: failed to create X25519 random key: %w", err)
 	}
@@ -158,7 +158,7 @@ func (o *okpKWSupport) wrap(aead interface{}, cek []byte) ([]byte, error) {
 	nonceSize := aeadPrimitive.NonceSize()
 	nonce := make([]byte, nonceSize)
 
-	_, err := rand.Read(nonce)
+	_, err := random.Read(nonce)
 	if err != nil {
 		return nil, fmt.Errorf("wrap support: failed to generate random nonce: %w", err)
 	}
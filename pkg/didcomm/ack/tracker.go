@@ -0,0 +1,478 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ack tracks the acknowledgements of the outbound messages requesting one with the `~please_ack`
+// decorator (Aries RFC 0317). The acknowledgements (Aries RFC 0015) and the problem reports received on the thread
+// of a tracked message update its delivery status, the messages not acknowledged in time are sent again
+// and expire once the retries are exhausted.
+package ack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for the delivery status store.
+	NameSpace = "ack"
+
+	// DefaultTimeout is the default period an acknowledgement is waited for before the message is sent again.
+	DefaultTimeout = 2 * time.Minute
+
+	// DefaultMaxRetries is the default number of times a message not acknowledged is sent again.
+	DefaultMaxRetries = 3
+
+	// StatusPending is the status of a message waiting for its acknowledgement.
+	StatusPending = "pending"
+	// StatusAcknowledged is the status of a message acknowledged by the recipient.
+	StatusAcknowledged = "acknowledged"
+	// StatusFailed is the status of a message whose processing failed on the recipient side: the acknowledgement
+	// has the FAIL status or a problem report was received instead.
+	StatusFailed = "failed"
+	// StatusExpired is the status of a message still not acknowledged once the retries are exhausted.
+	StatusExpired = "expired"
+
+	keyPrefix     = "ack_"
+	thidTagName   = "thid"
+	statusTagName = "status"
+
+	// acknowledgement statuses (Aries RFC 0015).
+	ackStatusPending = "PENDING"
+	ackStatusFail    = "FAIL"
+
+	ackMsgName           = "/ack"
+	problemReportMsgName = "/problem-report"
+)
+
+var logger = log.New("aries-framework/didcomm/ack")
+
+// ErrNotTracked is returned when the delivery of the message is not tracked.
+var ErrNotTracked = errors.New("message not tracked")
+
+// Record is the delivery status of a message requesting an acknowledgement.
+type Record struct {
+	MsgID    string   `json:"msg_id"`
+	ThreadID string   `json:"thid"`
+	MsgType  string   `json:"msg_type"`
+	On       []string `json:"on,omitempty"`
+	Status   string   `json:"status"`
+	// Attempts is the number of times the message was sent.
+	Attempts  int       `json:"attempts"`
+	SentAt    time.Time `json:"sent_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// AckID is the ID of the acknowledgement or problem report received for the message.
+	AckID string `json:"ack_id,omitempty"`
+	// Msg, SenderKey and Destination are kept while the message is pending, to send it again.
+	Msg         service.DIDCommMsgMap `json:"msg,omitempty"`
+	SenderKey   string                `json:"sender_key,omitempty"`
+	Destination *service.Destination  `json:"destination,omitempty"`
+}
+
+// Event is sent when a tracked message is acknowledged, fails or expires.
+type Event struct {
+	MsgID    string
+	ThreadID string
+	Status   string
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Option configures the Tracker.
+type Option func(t *Tracker)
+
+// WithTimeout sets the period an acknowledgement is waited for before the message is sent again,
+// DefaultTimeout by default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(t *Tracker) {
+		t.timeout = timeout
+	}
+}
+
+// WithMaxRetries sets the number of times a message not acknowledged is sent again before it expires,
+// DefaultMaxRetries by default. Zero disables the retries.
+func WithMaxRetries(retries int) Option {
+	return func(t *Tracker) {
+		t.maxRetries = retries
+	}
+}
+
+// Tracker records the delivery status of the outbound messages requesting an acknowledgement.
+type Tracker struct {
+	store      storage.Store
+	timeout    time.Duration
+	maxRetries int
+	now        func() time.Time
+
+	mu     sync.RWMutex
+	events []chan<- Event
+
+	runMu sync.Mutex
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// New returns a new acknowledgement tracker.
+func New(ctx provider, opts ...Option) (*Tracker, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ack store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace,
+		storage.StoreConfiguration{TagNames: []string{thidTagName, statusTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	t := &Tracker{
+		store:      store,
+		timeout:    DefaultTimeout,
+		maxRetries: DefaultMaxRetries,
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t, nil
+}
+
+// RegisterEvent registers a channel to receive the delivery status events.
+func (t *Tracker) RegisterEvent(ch chan<- Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, ch)
+}
+
+// UnregisterEvent unregisters the channel from the delivery status events.
+func (t *Tracker) UnregisterEvent(ch chan<- Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range t.events {
+		if t.events[i] == ch {
+			t.events = append(t.events[:i], t.events[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Status returns the delivery status of the message.
+func (t *Tracker) Status(msgID string) (*Record, error) {
+	src, err := t.store.Get(keyPrefix + msgID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotTracked, msgID)
+		}
+
+		return nil, fmt.Errorf("get ack record: %w", err)
+	}
+
+	rec := &Record{}
+	if err = json.Unmarshal(src, rec); err != nil {
+		return nil, fmt.Errorf("unmarshal ack record: %w", err)
+	}
+
+	return rec, nil
+}
+
+// OutboundInterceptor returns the outbound dispatcher interceptor tracking the messages with the `~please_ack`
+// decorator. A message sent again is counted as a new attempt.
+func (t *Tracker) OutboundInterceptor() dispatcher.OutboundInterceptor {
+	return func(next dispatcher.OutboundHandler) dispatcher.OutboundHandler {
+		return dispatcher.OutboundHandlerFunc(func(msg *dispatcher.OutboundMessage) error {
+			if err := t.trackOutbound(msg); err != nil {
+				logger.Warnf("failed to track the acknowledgement of message %s: %s", msg.Msg.ID(), err)
+			}
+
+			return next.HandleOutbound(msg)
+		})
+	}
+}
+
+func (t *Tracker) trackOutbound(msg *dispatcher.OutboundMessage) error {
+	pleaseAck := struct {
+		PleaseAck *struct {
+			On []string `json:"on"`
+		} `json:"~please_ack"`
+	}{}
+
+	if err := msg.Msg.Decode(&pleaseAck); err != nil || pleaseAck.PleaseAck == nil || msg.Msg.ID() == "" {
+		return nil // nolint: nilerr
+	}
+
+	rec, err := t.Status(msg.Msg.ID())
+	if err != nil && !errors.Is(err, ErrNotTracked) {
+		return err
+	}
+
+	if rec == nil {
+		thID, errThread := msg.Msg.ThreadID()
+		if errThread != nil {
+			return fmt.Errorf("thread ID: %w", errThread)
+		}
+
+		// the message is kept as sent, the interceptors may still modify it
+		src, errMarshal := json.Marshal(msg.Msg)
+		if errMarshal != nil {
+			return fmt.Errorf("marshal message: %w", errMarshal)
+		}
+
+		rec = &Record{MsgID: msg.Msg.ID(), ThreadID: thID, MsgType: msg.Msg.Type(), On: pleaseAck.PleaseAck.On}
+
+		if err = json.Unmarshal(src, &rec.Msg); err != nil {
+			return fmt.Errorf("unmarshal message: %w", err)
+		}
+	}
+
+	if rec.Status != "" && rec.Status != StatusPending {
+		return nil
+	}
+
+	rec.Status = StatusPending
+	rec.Attempts++
+	rec.SentAt = t.now()
+	rec.UpdatedAt = rec.SentAt
+	rec.SenderKey = msg.SenderKey
+	rec.Destination = msg.Destination
+
+	return t.save(rec)
+}
+
+// HandleInbound updates the delivery status of the tracked messages on the thread of the inbound acknowledgement
+// or problem report. It returns true if the message acknowledged a tracked message. Errors are logged.
+func (t *Tracker) HandleInbound(msg service.DIDCommMsgMap) bool {
+	isAck := strings.HasSuffix(msg.Type(), ackMsgName)
+	if !isAck && !strings.HasSuffix(msg.Type(), problemReportMsgName) {
+		return false
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil || thID == msg.ID() {
+		return false
+	}
+
+	ackStatus := struct {
+		Status string `json:"status"`
+	}{}
+
+	if err = msg.Decode(&ackStatus); err != nil {
+		logger.Debugf("inbound message %s is not an acknowledgement: %s", msg.ID(), err)
+
+		return false
+	}
+
+	records, err := t.pending(thidTagName + ":" + thID)
+	if err != nil {
+		logger.Warnf("failed to get the messages acknowledged by %s: %s", msg.ID(), err)
+
+		return false
+	}
+
+	for _, rec := range records {
+		switch {
+		case isAck && ackStatus.Status == ackStatusPending:
+			// the recipient is processing the message, the acknowledgement is waited for again
+			rec.SentAt = t.now()
+			rec.UpdatedAt = rec.SentAt
+
+			err = t.save(rec)
+		case isAck && ackStatus.Status != ackStatusFail:
+			err = t.complete(rec, StatusAcknowledged, msg.ID())
+		default:
+			err = t.complete(rec, StatusFailed, msg.ID())
+		}
+
+		if err != nil {
+			logger.Warnf("failed to update the delivery status of message %s: %s", rec.MsgID, err)
+		}
+	}
+
+	return len(records) > 0
+}
+
+// Check sends again the pending messages not acknowledged within the timeout, the messages whose retries are
+// exhausted expire. It returns the number of messages sent again.
+func (t *Tracker) Check(outbound dispatcher.Outbound) (int, error) {
+	records, err := t.pending(statusTagName + ":" + StatusPending)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+
+	for _, rec := range records {
+		if t.now().Sub(rec.SentAt) < t.timeout {
+			continue
+		}
+
+		if rec.Attempts > t.maxRetries || rec.Destination == nil {
+			if err = t.complete(rec, StatusExpired, ""); err != nil {
+				return sent, err
+			}
+
+			continue
+		}
+
+		// the outbound interceptor records the new attempt
+		if err = outbound.Send(rec.Msg, rec.SenderKey, rec.Destination); err != nil {
+			logger.Warnf("failed to send message %s again, it will be retried: %s", rec.MsgID, err)
+
+			continue
+		}
+
+		sent++
+	}
+
+	return sent, nil
+}
+
+// Start checks the pending messages at every timeout until Stop is called, the messages are sent again
+// with the outbound dispatcher.
+func (t *Tracker) Start(outbound dispatcher.Outbound) {
+	t.runMu.Lock()
+	defer t.runMu.Unlock()
+
+	if t.stop != nil {
+		return
+	}
+
+	t.stop = make(chan struct{})
+	t.done = make(chan struct{})
+
+	go t.run(outbound, t.stop, t.done)
+}
+
+// Stop stops the periodic check and waits for the check in progress to complete.
+func (t *Tracker) Stop() {
+	t.runMu.Lock()
+	defer t.runMu.Unlock()
+
+	if t.stop == nil {
+		return
+	}
+
+	close(t.stop)
+	<-t.done
+
+	t.stop = nil
+	t.done = nil
+}
+
+func (t *Tracker) run(outbound dispatcher.Outbound, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(t.timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			sent, err := t.Check(outbound)
+			if err != nil {
+				logger.Errorf("acknowledgement check failed: %v", err)
+			}
+
+			if sent > 0 {
+				logger.Infof("sent again %d messages not acknowledged", sent)
+			}
+		}
+	}
+}
+
+// complete sets the final status of the message and sends the event.
+func (t *Tracker) complete(rec *Record, status, ackID string) error {
+	rec.Status = status
+	rec.AckID = ackID
+	rec.UpdatedAt = t.now()
+	rec.Msg = nil
+	rec.SenderKey = ""
+	rec.Destination = nil
+
+	if err := t.save(rec); err != nil {
+		return err
+	}
+
+	event := Event{MsgID: rec.MsgID, ThreadID: rec.ThreadID, Status: status}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, ch := range t.events {
+		ch <- event
+	}
+
+	return nil
+}
+
+func (t *Tracker) save(rec *Record) error {
+	src, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal ack record: %w", err)
+	}
+
+	err = t.store.Put(keyPrefix+rec.MsgID, src,
+		storage.Tag{Name: thidTagName, Value: rec.ThreadID},
+		storage.Tag{Name: statusTagName, Value: rec.Status},
+	)
+	if err != nil {
+		return fmt.Errorf("save ack record: %w", err)
+	}
+
+	return nil
+}
+
+// pending returns the pending records matching the query.
+func (t *Tracker) pending(query string) ([]*Record, error) {
+	iter, err := t.store.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("query ack records: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var records []*Record
+
+	more, err := iter.Next()
+
+	for ; err == nil && more; more, err = iter.Next() {
+		src, errValue := iter.Value()
+		if errValue != nil {
+			return nil, fmt.Errorf("get ack record: %w", errValue)
+		}
+
+		rec := &Record{}
+		if errValue = json.Unmarshal(src, rec); errValue != nil {
+			return nil, fmt.Errorf("unmarshal ack record: %w", errValue)
+		}
+
+		if rec.Status == StatusPending {
+			records = append(records, rec)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("iterate ack records: %w", err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,276 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ack
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+// outbound sends the messages through the interceptor of the tracker.
+type outbound struct {
+	dispatcher.Outbound
+	handler dispatcher.OutboundHandler
+	sent    []service.DIDCommMsgMap
+	err     error
+}
+
+func newOutbound(tracker *Tracker) *outbound {
+	o := &outbound{}
+	o.handler = tracker.OutboundInterceptor()(dispatcher.OutboundHandlerFunc(func(msg *dispatcher.OutboundMessage) error {
+		o.sent = append(o.sent, msg.Msg)
+
+		return o.err
+	}))
+
+	return o
+}
+
+func (o *outbound) Send(msg interface{}, senderKey string, des *service.Destination) error {
+	return o.handler.HandleOutbound(&dispatcher.OutboundMessage{
+		Msg: msg.(service.DIDCommMsgMap), SenderKey: senderKey, Destination: des,
+	})
+}
+
+func newTracker(t *testing.T, opts ...Option) *Tracker {
+	t.Helper()
+
+	tracker, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, opts...)
+	require.NoError(t, err)
+
+	return tracker
+}
+
+func request(id, thid string) service.DIDCommMsgMap {
+	msg := service.DIDCommMsgMap{
+		"@id":         id,
+		"@type":       "https://didcomm.org/issue-credential/2.0/issue-credential",
+		"~please_ack": map[string]interface{}{"on": []interface{}{"OUTCOME"}},
+	}
+
+	if thid != "" {
+		msg["~thread"] = map[string]interface{}{"thid": thid}
+	}
+
+	return msg
+}
+
+func ackMsg(msgType, thid, status string) service.DIDCommMsgMap {
+	return service.DIDCommMsgMap{
+		"@id":     "ack-" + thid,
+		"@type":   msgType,
+		"status":  status,
+		"~thread": map[string]interface{}{"thid": thid},
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}})
+		require.EqualError(t, err, "failed to open ack store: open error")
+	})
+}
+
+func TestTracker_OutboundInterceptor(t *testing.T) {
+	dest := &service.Destination{ServiceEndpoint: "http://example.com"}
+
+	t.Run("tracks the messages requesting an ack", func(t *testing.T) {
+		tracker := newTracker(t)
+		o := newOutbound(tracker)
+
+		require.NoError(t, o.Send(request("msg-1", "thread-1"), "key", dest))
+		require.NoError(t, o.Send(service.DIDCommMsgMap{"@id": "msg-2", "@type": "type"}, "key", dest))
+		require.Len(t, o.sent, 2)
+
+		rec, err := tracker.Status("msg-1")
+		require.NoError(t, err)
+		require.Equal(t, StatusPending, rec.Status)
+		require.Equal(t, "thread-1", rec.ThreadID)
+		require.Equal(t, []string{"OUTCOME"}, rec.On)
+		require.Equal(t, 1, rec.Attempts)
+		require.Equal(t, dest, rec.Destination)
+
+		_, err = tracker.Status("msg-2")
+		require.True(t, errors.Is(err, ErrNotTracked))
+	})
+
+	t.Run("the message is tracked even if it fails to be sent", func(t *testing.T) {
+		tracker := newTracker(t)
+		o := newOutbound(tracker)
+		o.err = errors.New("send error")
+
+		require.EqualError(t, o.Send(request("msg-1", ""), "key", dest), "send error")
+
+		rec, err := tracker.Status("msg-1")
+		require.NoError(t, err)
+		require.Equal(t, "msg-1", rec.ThreadID)
+	})
+}
+
+func TestTracker_HandleInbound(t *testing.T) {
+	dest := &service.Destination{ServiceEndpoint: "http://example.com"}
+
+	tests := []struct {
+		name    string
+		ack     service.DIDCommMsgMap
+		handled bool
+		status  string
+	}{
+		{
+			name:    "ack",
+			ack:     ackMsg("https://didcomm.org/notification/1.0/ack", "thread-1", "OK"),
+			handled: true,
+			status:  StatusAcknowledged,
+		},
+		{
+			name:    "protocol ack",
+			ack:     ackMsg("https://didcomm.org/issue-credential/2.0/ack", "thread-1", "OK"),
+			handled: true,
+			status:  StatusAcknowledged,
+		},
+		{
+			name:    "failure ack",
+			ack:     ackMsg("https://didcomm.org/notification/1.0/ack", "thread-1", "FAIL"),
+			handled: true,
+			status:  StatusFailed,
+		},
+		{
+			name:    "problem report",
+			ack:     ackMsg("https://didcomm.org/issue-credential/2.0/problem-report", "thread-1", ""),
+			handled: true,
+			status:  StatusFailed,
+		},
+		{
+			name:    "pending ack",
+			ack:     ackMsg("https://didcomm.org/notification/1.0/ack", "thread-1", "PENDING"),
+			handled: true,
+			status:  StatusPending,
+		},
+		{
+			name:   "ack of another thread",
+			ack:    ackMsg("https://didcomm.org/notification/1.0/ack", "thread-2", "OK"),
+			status: StatusPending,
+		},
+		{
+			name:   "not an ack",
+			ack:    ackMsg("https://didcomm.org/issue-credential/2.0/request-credential", "thread-1", ""),
+			status: StatusPending,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newTracker(t)
+
+			events := make(chan Event, 1)
+			tracker.RegisterEvent(events)
+
+			require.NoError(t, newOutbound(tracker).Send(request("msg-1", "thread-1"), "key", dest))
+			require.Equal(t, tc.handled, tracker.HandleInbound(tc.ack))
+
+			rec, err := tracker.Status("msg-1")
+			require.NoError(t, err)
+			require.Equal(t, tc.status, rec.Status)
+
+			if tc.status == StatusPending {
+				require.Empty(t, events)
+				require.NotNil(t, rec.Msg)
+
+				return
+			}
+
+			require.Equal(t, Event{MsgID: "msg-1", ThreadID: "thread-1", Status: tc.status}, <-events)
+			require.Equal(t, tc.ack.ID(), rec.AckID)
+			require.Nil(t, rec.Msg)
+			require.Nil(t, rec.Destination)
+
+			// the message is not tracked anymore
+			require.False(t, tracker.HandleInbound(tc.ack))
+		})
+	}
+}
+
+func TestTracker_Check(t *testing.T) {
+	dest := &service.Destination{ServiceEndpoint: "http://example.com"}
+	now := time.Now()
+
+	tracker := newTracker(t, WithTimeout(time.Minute), WithMaxRetries(1))
+	tracker.now = func() time.Time { return now }
+
+	events := make(chan Event, 1)
+	tracker.RegisterEvent(events)
+
+	o := newOutbound(tracker)
+	require.NoError(t, o.Send(request("msg-1", "thread-1"), "key", dest))
+
+	// the ack is still waited for
+	sent, err := tracker.Check(o)
+	require.NoError(t, err)
+	require.Zero(t, sent)
+
+	now = now.Add(time.Minute)
+
+	sent, err = tracker.Check(o)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+	require.Len(t, o.sent, 2)
+	require.Equal(t, "msg-1", o.sent[1].ID())
+
+	rec, err := tracker.Status("msg-1")
+	require.NoError(t, err)
+	require.Equal(t, StatusPending, rec.Status)
+	require.Equal(t, 2, rec.Attempts)
+
+	now = now.Add(time.Minute)
+
+	sent, err = tracker.Check(o)
+	require.NoError(t, err)
+	require.Zero(t, sent)
+	require.Equal(t, Event{MsgID: "msg-1", ThreadID: "thread-1", Status: StatusExpired}, <-events)
+
+	rec, err = tracker.Status("msg-1")
+	require.NoError(t, err)
+	require.Equal(t, StatusExpired, rec.Status)
+
+	tracker.UnregisterEvent(events)
+}
+
+func TestTracker_StartStop(t *testing.T) {
+	tracker := newTracker(t, WithTimeout(time.Millisecond), WithMaxRetries(0))
+
+	events := make(chan Event, 1)
+	tracker.RegisterEvent(events)
+
+	o := newOutbound(tracker)
+	require.NoError(t, o.Send(request("msg-1", ""), "key", &service.Destination{}))
+
+	tracker.Start(o)
+	tracker.Start(o)
+
+	select {
+	case e := <-events:
+		require.Equal(t, StatusExpired, e.Status)
+	case <-time.After(time.Second):
+		require.Fail(t, "tracked message did not expire")
+	}
+
+	tracker.Stop()
+	tracker.Stop()
+}
@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interop_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// fixtures are the messages sent by ACA-Py.
+func readFixture(t *testing.T, name string, v interface{}) {
+	t.Helper()
+
+	src, err := ioutil.ReadFile(filepath.Clean(filepath.Join("testdata", "acapy", name)))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(src, v))
+}
+
+type anoncredsFormat struct{}
+
+func (anoncredsFormat) Offer(*issuecredential.PreviewCredential) (*decorator.AttachmentData, error) {
+	return &decorator.AttachmentData{}, nil
+}
+
+func (anoncredsFormat) Issue(*decorator.AttachmentData) (*decorator.AttachmentData, error) {
+	return &decorator.AttachmentData{}, nil
+}
+
+func (anoncredsFormat) Validate(*decorator.AttachmentData) error {
+	return nil
+}
+
+func TestACAPy_DIDExchangeRequest(t *testing.T) {
+	request := &didexchange.Request{}
+	readFixture(t, "didexchange_request.json", request)
+
+	require.False(t, strings.HasPrefix(request.DID, "did:"))
+
+	mode := interop.ACAPy()
+
+	theirDID := mode.QualifyDID(request.DID)
+	require.Equal(t, "did:sov:"+request.DID, theirDID)
+
+	id, err := did.Parse(theirDID)
+	require.NoError(t, err)
+	require.Equal(t, interop.SovMethod, id.Method)
+
+	src, err := base64.StdEncoding.DecodeString(request.DocAttach.Data.Base64)
+	require.NoError(t, err)
+
+	doc, err := did.ParseDocument(src)
+	require.NoError(t, err)
+	require.Equal(t, request.DID, doc.ID)
+
+	svc, ok := did.LookupService(doc, interop.LegacyDIDCommServiceType)
+	require.True(t, ok)
+	require.Equal(t, "http://acapy.example.com:8020", svc.ServiceEndpoint)
+
+	destination, err := service.CreateDestination(doc)
+	require.NoError(t, err)
+	require.Len(t, destination.RecipientKeys, 1)
+	require.True(t, strings.HasPrefix(destination.RecipientKeys[0], "did:key:z6Mk"))
+}
+
+func TestACAPy_IssueCredentialOffer(t *testing.T) {
+	offer := &issuecredential.OfferCredential{}
+	readFixture(t, "issuecredential_offer.json", offer)
+
+	require.Len(t, offer.Formats, 1)
+	require.Equal(t, issuecredential.AnoncredsFormat, interop.ACAPy().AttachmentFormat(offer.Formats[0].Format))
+
+	registry := issuecredential.NewFormatRegistry()
+	registry.Register(issuecredential.AnoncredsFormat, anoncredsFormat{})
+
+	require.False(t, registry.Supports(offer.Formats))
+
+	registry.SetInteropMode(interop.ACAPy())
+
+	require.True(t, registry.Supports(offer.Formats))
+	require.Equal(t, "Alice", offer.CredentialPreview.Attributes[0].Value)
+}
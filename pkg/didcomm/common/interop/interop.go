@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package interop provides the compatibility quirks of the agents which do not follow the latest Aries RFCs,
+// e.g. ACA-Py.
+package interop
+
+import "strings"
+
+const (
+	// LegacyDIDCommServiceType is the did:sov type of DIDComm service used by ACA-Py and the Indy agents.
+	LegacyDIDCommServiceType = "IndyAgent"
+	// SovMethod is the DID method of the unqualified DIDs.
+	SovMethod = "sov"
+
+	didPrefix    = "did:"
+	sovDIDPrefix = didPrefix + SovMethod + ":"
+)
+
+// legacyFormats maps the legacy attachment format strings to the formats of the current RFCs.
+// nolint:gochecknoglobals
+var legacyFormats = map[string]string{
+	// RFC 0453 before the format strings were versioned
+	"hlindy-zkp-v1.0": "hlindy/cred@v2.0",
+	// ACA-Py names the Indy attachments after the message they are attached to
+	"hlindy/cred-filter@v2.0":   "hlindy/cred@v2.0",
+	"hlindy/cred-abstract@v2.0": "hlindy/cred@v2.0",
+	"hlindy/cred-req@v2.0":      "hlindy/cred@v2.0",
+	// ACA-Py before the RFC 0593 formats were versioned
+	"aries/ld-proof-vc-detail@1.0": "aries/ld-proof-vc-detail@v1.0",
+	"aries/ld-proof-vc@1.0":        "aries/ld-proof-vc@v1.0",
+}
+
+// Mode is the set of compatibility quirks enabled on the agent. The zero Mode enables none of them.
+type Mode struct {
+	// LegacyServiceType advertises the DIDComm service of the DID docs created for the connections
+	// with the did:sov service type (LegacyDIDCommServiceType) as well.
+	LegacyServiceType bool
+	// UnqualifiedDIDs accepts the unqualified DIDs of the connection protocols (e.g. "AyRHrP7u6rF1dKViGf5shA")
+	// as did:sov DIDs, whose documents are stored by the peer VDR.
+	UnqualifiedDIDs bool
+	// LegacyAttachmentFormats accepts the legacy attachment format strings in place of the current ones.
+	LegacyAttachmentFormats bool
+	// ConnectionsProtocol enables the RFC 0160 connections protocol, for the agents not supporting DID exchange.
+	ConnectionsProtocol bool
+}
+
+// ACAPy returns the Mode enabling all the quirks needed to interoperate with ACA-Py.
+func ACAPy() *Mode {
+	return &Mode{
+		LegacyServiceType:       true,
+		UnqualifiedDIDs:         true,
+		LegacyAttachmentFormats: true,
+		ConnectionsProtocol:     true,
+	}
+}
+
+// QualifyDID returns the did:sov DID of the unqualified DID if the mode accepts the unqualified DIDs,
+// the DID unchanged otherwise.
+func (m *Mode) QualifyDID(id string) string {
+	if m == nil || !m.UnqualifiedDIDs {
+		return id
+	}
+
+	return SovDID(id)
+}
+
+// SovDID returns the did:sov DID of the unqualified DID, the qualified DIDs unchanged.
+func SovDID(id string) string {
+	if id == "" || strings.HasPrefix(id, didPrefix) {
+		return id
+	}
+
+	return sovDIDPrefix + id
+}
+
+// UnqualifiedDID returns the unqualified DID of the did:sov DID, the other DIDs unchanged.
+func UnqualifiedDID(id string) string {
+	return strings.TrimPrefix(id, sovDIDPrefix)
+}
+
+// AttachmentFormat returns the current attachment format of the legacy format string if the mode accepts
+// the legacy formats, the format unchanged otherwise.
+func (m *Mode) AttachmentFormat(format string) string {
+	if m == nil || !m.LegacyAttachmentFormats {
+		return format
+	}
+
+	if current, ok := legacyFormats[format]; ok {
+		return current
+	}
+
+	return format
+}
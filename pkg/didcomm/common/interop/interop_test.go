@@ -0,0 +1,46 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMode_QualifyDID(t *testing.T) {
+	const unqualifiedDID = "AyRHrP7u6rF1dKViGf5shA"
+
+	require.Equal(t, "did:sov:"+unqualifiedDID, ACAPy().QualifyDID(unqualifiedDID))
+	require.Equal(t, "did:peer:123", ACAPy().QualifyDID("did:peer:123"))
+	require.Empty(t, ACAPy().QualifyDID(""))
+
+	require.Equal(t, unqualifiedDID, (&Mode{}).QualifyDID(unqualifiedDID))
+
+	var mode *Mode
+	require.Equal(t, unqualifiedDID, mode.QualifyDID(unqualifiedDID))
+}
+
+func TestSovDID(t *testing.T) {
+	require.Equal(t, "did:sov:AyRHrP7u6rF1dKViGf5shA", SovDID("AyRHrP7u6rF1dKViGf5shA"))
+	require.Equal(t, "did:peer:123", SovDID("did:peer:123"))
+
+	require.Equal(t, "AyRHrP7u6rF1dKViGf5shA", UnqualifiedDID("did:sov:AyRHrP7u6rF1dKViGf5shA"))
+	require.Equal(t, "did:peer:123", UnqualifiedDID("did:peer:123"))
+}
+
+func TestMode_AttachmentFormat(t *testing.T) {
+	require.Equal(t, "hlindy/cred@v2.0", ACAPy().AttachmentFormat("hlindy-zkp-v1.0"))
+	require.Equal(t, "hlindy/cred@v2.0", ACAPy().AttachmentFormat("hlindy/cred-abstract@v2.0"))
+	require.Equal(t, "aries/ld-proof-vc-detail@v1.0", ACAPy().AttachmentFormat("aries/ld-proof-vc-detail@1.0"))
+	require.Equal(t, "jwt-vc", ACAPy().AttachmentFormat("jwt-vc"))
+
+	require.Equal(t, "hlindy-zkp-v1.0", (&Mode{}).AttachmentFormat("hlindy-zkp-v1.0"))
+
+	var mode *Mode
+	require.Equal(t, "hlindy-zkp-v1.0", mode.AttachmentFormat("hlindy-zkp-v1.0"))
+}
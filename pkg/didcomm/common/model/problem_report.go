@@ -6,15 +6,73 @@ SPDX-License-Identifier: Apache-2.0
 
 package model
 
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const codeKey = "code"
+
 // ProblemReport problem report definition
 // TODO: need to provide full ProblemReport structure https://github.com/hyperledger/aries-framework-go/issues/912
 type ProblemReport struct {
 	Type        string `json:"@type"`
 	ID          string `json:"@id"`
 	Description Code   `json:"description"`
+	// L10n references the message catalogs the localized texts of the description come from.
+	L10n *decorator.L10n `json:"~l10n,omitempty"`
 }
 
 // Code represents a problem report code.
 type Code struct {
 	Code string `json:"code"`
+	// Texts are the localized descriptions of the code by locale (e.g. "en"). They are serialized next to the code,
+	// e.g. {"code": "rejected", "en": "The request was rejected"}.
+	Texts map[string]string `json:"-"`
+}
+
+// MarshalJSON marshals the code with its localized texts.
+func (c Code) MarshalJSON() ([]byte, error) {
+	raw := map[string]string{}
+
+	for locale, text := range c.Texts {
+		raw[locale] = text
+	}
+
+	raw[codeKey] = c.Code
+
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON unmarshals the code with its localized texts.
+func (c *Code) UnmarshalJSON(data []byte) error {
+	raw := map[string]interface{}{}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*c = Code{}
+
+	for key, value := range raw {
+		text, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		if key == codeKey {
+			c.Code = text
+
+			continue
+		}
+
+		if c.Texts == nil {
+			c.Texts = map[string]string{}
+		}
+
+		c.Texts[key] = text
+	}
+
+	return nil
 }
@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode_JSON(t *testing.T) {
+	t.Run("code only", func(t *testing.T) {
+		src, err := json.Marshal(Code{Code: "rejected"})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"code":"rejected"}`, string(src))
+
+		code := Code{}
+		require.NoError(t, json.Unmarshal(src, &code))
+		require.Equal(t, Code{Code: "rejected"}, code)
+	})
+
+	t.Run("localized texts", func(t *testing.T) {
+		src, err := json.Marshal(Code{
+			Code:  "rejected",
+			Texts: map[string]string{"en": "The request was rejected", "es": "La solicitud fue rechazada"},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"code":"rejected","en":"The request was rejected","es":"La solicitud fue rechazada"}`,
+			string(src))
+
+		report := ProblemReport{}
+		require.NoError(t, json.Unmarshal([]byte(`{"description":`+string(src)+`}`), &report))
+		require.Equal(t, "rejected", report.Description.Code)
+		require.Equal(t, "The request was rejected", report.Description.Texts["en"])
+		require.Equal(t, "La solicitud fue rechazada", report.Description.Texts["es"])
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		code := Code{}
+		require.Error(t, code.UnmarshalJSON([]byte("[]")))
+	})
+}
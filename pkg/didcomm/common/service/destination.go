@@ -51,7 +51,7 @@ func CreateDestination(didDoc *diddoc.Doc) (*Destination, error) {
 		return createDestinationFromIndy(didDoc)
 	}
 
-	if didCommService.ServiceEndpoint == "" {
+	if didCommService.EndpointURI() == "" {
 		return nil, fmt.Errorf("create destination: no service endpoint on didcomm service block in diddoc: %+v", didDoc)
 	}
 
@@ -61,9 +61,9 @@ func CreateDestination(didDoc *diddoc.Doc) (*Destination, error) {
 
 	return &Destination{
 		RecipientKeys:   didCommService.RecipientKeys,
-		ServiceEndpoint: didCommService.ServiceEndpoint,
-		RoutingKeys:     didCommService.RoutingKeys,
-		MediaTypes:      didCommService.Accept,
+		ServiceEndpoint: didCommService.EndpointURI(),
+		RoutingKeys:     didCommService.EndpointRoutingKeys(),
+		MediaTypes:      didCommService.EndpointAccept(),
 	}, nil
 }
 
@@ -73,7 +73,7 @@ func createDestinationFromIndy(didDoc *diddoc.Doc) (*Destination, error) {
 		return nil, fmt.Errorf("create destination: missing DID doc service")
 	}
 
-	if didCommService.ServiceEndpoint == "" {
+	if didCommService.EndpointURI() == "" {
 		return nil, fmt.Errorf("create destination: no service endpoint on didcomm service block in diddoc: %+v", didDoc)
 	}
 
@@ -86,15 +86,35 @@ func createDestinationFromIndy(didDoc *diddoc.Doc) (*Destination, error) {
 
 	// convert plain base58 keys to did:key
 	recKeys := lookupIndyRecipientKeys(didDoc, didCommService.RecipientKeys)
-	routeKeys := lookupIndyRecipientKeys(didDoc, didCommService.RoutingKeys)
+	routeKeys := indyRoutingKeys(didCommService.EndpointRoutingKeys())
 
 	return &Destination{
 		RecipientKeys:   recKeys,
-		ServiceEndpoint: didCommService.ServiceEndpoint,
+		ServiceEndpoint: didCommService.EndpointURI(),
 		RoutingKeys:     routeKeys,
 	}, nil
 }
 
+// indyRoutingKeys converts the routing keys to did:key, they are the keys of the mediators so unlike the recipient
+// keys they are not found among the verification methods of the doc.
+func indyRoutingKeys(routingKeys []string) []string {
+	var didKeys []string
+
+	for _, key := range routingKeys {
+		if _, err := fingerprint.PubKeyFromDIDKey(key); err == nil {
+			didKeys = append(didKeys, key)
+
+			continue
+		}
+
+		didKey, _ := fingerprint.CreateDIDKey(base58.Decode(key))
+
+		didKeys = append(didKeys, didKey)
+	}
+
+	return didKeys
+}
+
 func lookupIndyRecipientKeys(didDoc *diddoc.Doc, recipientKeys []string) []string {
 	b58VMkeys := map[string]int{}
 
@@ -91,6 +91,22 @@ func TestPrepareDestination(t *testing.T) {
 		require.Equal(t, doc.Service[0].RoutingKeys, dest.RoutingKeys)
 	})
 
+	t.Run("successfully prepared destination from DIDComm v2 endpoint objects", func(t *testing.T) {
+		doc := mockdiddoc.GetMockDIDDoc(t)
+		doc.Service[0].ServiceEndpoint = ""
+		doc.Service[0].Endpoints = []did.Endpoint{{
+			URI:         "https://agent.example.com",
+			Accept:      []string{"didcomm/v2"},
+			RoutingKeys: []string{"did:example:mediator#key-1"},
+		}}
+
+		dest, err := CreateDestination(doc)
+		require.NoError(t, err)
+		require.Equal(t, "https://agent.example.com", dest.ServiceEndpoint)
+		require.Equal(t, []string{"didcomm/v2"}, dest.MediaTypes)
+		require.Equal(t, []string{"did:example:mediator#key-1"}, dest.RoutingKeys)
+	})
+
 	t.Run("error while getting service", func(t *testing.T) {
 		didDoc := mockdiddoc.GetMockDIDDoc(t)
 		didDoc.Service = nil
@@ -121,6 +137,20 @@ func TestCreateDestinationFromLegacyDoc(t *testing.T) {
 		require.Equal(t, doc.Service[0].RoutingKeys, dest.RoutingKeys)
 	})
 
+	t.Run("routing keys of the mediator", func(t *testing.T) {
+		routingKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		routingDIDKey, _ := fingerprint.CreateDIDKey(routingKey)
+
+		doc := mockdiddoc.GetMockIndyDoc(t)
+		doc.Service[0].RoutingKeys = []string{base58.Encode(routingKey), routingDIDKey}
+
+		dest, err := CreateDestination(doc)
+		require.NoError(t, err)
+		require.Equal(t, []string{routingDIDKey, routingDIDKey}, dest.RoutingKeys)
+	})
+
 	t.Run("error while getting service", func(t *testing.T) {
 		didDoc := mockdiddoc.GetMockIndyDoc(t)
 		didDoc.Service = nil
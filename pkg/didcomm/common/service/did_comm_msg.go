@@ -30,6 +30,11 @@ const (
 	oldPIURI  = "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/"
 )
 
+// AttachmentSignersMetadataKey is the metadata key of the inbound messages holding the did:key of the signers of
+// their verified attachments by decorator name (e.g. did_doc~attach), as a map[string][]string. The protocols
+// bind the signers to the DIDs they expect.
+const AttachmentSignersMetadataKey = "attachmentSigners"
+
 // Metadata may contain additional payload for the protocol. It might be populated by the client/protocol
 // for outbound messages. If metadata were populated, the messenger will automatically add it to the incoming
 // messages by the threadID. If Metadata is <nil> in the outbound message the previous payload
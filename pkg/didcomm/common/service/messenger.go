@@ -6,8 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 
 package service
 
-// DIDCommContextEnvelopeMediaTypeKey is DIDCommContext property key holding the DIDComm envelope's media type.
-const DIDCommContextEnvelopeMediaTypeKey = "DIDCommContextEnvelopeMediaType"
+const (
+	// DIDCommContextEnvelopeMediaTypeKey is DIDCommContext property key holding the DIDComm envelope's media type.
+	DIDCommContextEnvelopeMediaTypeKey = "DIDCommContextEnvelopeMediaType"
+	// DIDCommContextRecipientKeyKey is DIDCommContext property key holding the raw public key the DIDComm envelope
+	// was decrypted with (e.g. the key of the invitation the message responds to).
+	DIDCommContextRecipientKeyKey = "DIDCommContextRecipientKey"
+)
 
 // DIDCommMsg describes message interface.
 type DIDCommMsg interface {
@@ -88,6 +93,10 @@ type Messenger interface {
 	// SendToDestination sends the message to given destination by starting a new thread.
 	SendToDestination(msg DIDCommMsgMap, sender string, destination *Destination) error
 
+	// ReplyToMsgDestination replies to the given message at given destination (e.g. connection-less reply
+	// to the message with ~service decorator). Keeps threadID in the *decorator.Thread.
+	ReplyToMsgDestination(in, out DIDCommMsgMap, sender string, destination *Destination) error
+
 	// ReplyToNested sends the message by starting a new thread.
 	// Keeps parent threadID in the *decorator.Thread
 	ReplyToNested(msg DIDCommMsgMap, opts *NestedReplyOpts) error
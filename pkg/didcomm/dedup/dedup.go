@@ -0,0 +1,251 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package dedup detects the inbound DIDComm messages received more than once (e.g. redelivered by the mediator
+// or retried by the transport), so that protocols do not process the same message twice.
+package dedup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for deduplication store.
+	NameSpace = "inbound_dedup"
+
+	// DefaultWindow is the default period a message is remembered for.
+	DefaultWindow = 24 * time.Hour
+
+	receivedKeyPrefix = "received_"
+	receivedTagName   = "received"
+	anoncryptPrefix   = "anoncrypt_"
+)
+
+var logger = log.New("aries-framework/didcomm/dedup")
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Deduplicator remembers the messages received within the window, by message ID and sender (by message ID
+// and recipient for the anoncrypt messages).
+type Deduplicator struct {
+	store  storage.Store
+	window time.Duration
+	now    func() time.Time
+
+	// mutex makes the check and the recording of a received message atomic.
+	mutex     sync.Mutex
+	lastPurge time.Time
+	purging   bool
+	purged    chan struct{}
+}
+
+type received struct {
+	Time time.Time `json:"time"`
+}
+
+// New returns a new deduplicator remembering the messages for the given window (DefaultWindow if not positive).
+func New(ctx provider, window time.Duration) (*Deduplicator, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open deduplication store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace,
+		storage.StoreConfiguration{TagNames: []string{receivedTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set deduplication store configuration: %w", err)
+	}
+
+	if window <= 0 {
+		window = DefaultWindow
+	}
+
+	return &Deduplicator{store: store, window: window, now: time.Now}, nil
+}
+
+// Receive records the message as received and returns true if the message with the same ID was already received
+// from the same sender within the window. The anoncrypt messages (without sender) are told apart by their
+// recipient. Messages without ID are never considered duplicates. The messages received before the window are
+// purged in the background once per window.
+func (d *Deduplicator) Receive(msgID, sender, recipient string) (bool, error) {
+	if msgID == "" {
+		return false, nil
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	now := d.now()
+
+	d.startPurge(now)
+
+	k := key(msgID, sender, recipient)
+
+	src, err := d.store.Get(k)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return false, fmt.Errorf("get received message: %w", err)
+	}
+
+	if err == nil {
+		var rec received
+		if err = json.Unmarshal(src, &rec); err != nil {
+			return false, fmt.Errorf("unmarshal received message: %w", err)
+		}
+
+		if now.Sub(rec.Time) < d.window {
+			return true, nil
+		}
+	}
+
+	src, err = json.Marshal(&received{Time: now})
+	if err != nil {
+		return false, fmt.Errorf("marshal received message: %w", err)
+	}
+
+	if err = d.store.Put(k, src, storage.Tag{Name: receivedTagName}); err != nil {
+		return false, fmt.Errorf("save received message: %w", err)
+	}
+
+	return false, nil
+}
+
+// Forget removes the message from the received ones. It is called when the message handling failed,
+// so that the message redelivered after a failure is handled again.
+func (d *Deduplicator) Forget(msgID, sender, recipient string) error {
+	if msgID == "" {
+		return nil
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.store.Delete(key(msgID, sender, recipient)); err != nil {
+		return fmt.Errorf("delete received message: %w", err)
+	}
+
+	return nil
+}
+
+// startPurge starts the purge of the messages received before the window, unless one is running or ran within
+// the window.
+func (d *Deduplicator) startPurge(now time.Time) {
+	if d.purging || now.Sub(d.lastPurge) < d.window {
+		return
+	}
+
+	d.purging = true
+	d.purged = make(chan struct{})
+
+	go func() {
+		err := d.purge(now)
+
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		if err != nil {
+			logger.Warnf("failed to purge received messages: %s", err)
+		} else {
+			d.lastPurge = now
+		}
+
+		d.purging = false
+		close(d.purged)
+	}()
+}
+
+// purge deletes the records of the messages received before the window. The expired records are checked again
+// under the mutex before they are deleted, a message received again meanwhile is kept.
+func (d *Deduplicator) purge(now time.Time) error {
+	itr, err := d.store.Query(receivedTagName)
+	if err != nil {
+		return fmt.Errorf("query received messages: %w", err)
+	}
+
+	defer func() {
+		if errClose := itr.Close(); errClose != nil {
+			logger.Errorf("failed to close iterator: %s", errClose.Error())
+		}
+	}()
+
+	var expired []string
+
+	more, err := itr.Next()
+	if err != nil {
+		return fmt.Errorf("iterate received messages: %w", err)
+	}
+
+	for more {
+		src, err := itr.Value()
+		if err != nil {
+			return fmt.Errorf("get received message value: %w", err)
+		}
+
+		if d.expired(src, now) {
+			k, errKey := itr.Key()
+			if errKey != nil {
+				return fmt.Errorf("get received message key: %w", errKey)
+			}
+
+			expired = append(expired, k)
+		}
+
+		more, err = itr.Next()
+		if err != nil {
+			return fmt.Errorf("iterate received messages: %w", err)
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, k := range expired {
+		src, errGet := d.store.Get(k)
+		if errors.Is(errGet, storage.ErrDataNotFound) {
+			continue
+		}
+
+		if errGet != nil {
+			return fmt.Errorf("get received message: %w", errGet)
+		}
+
+		if !d.expired(src, now) {
+			continue
+		}
+
+		if err = d.store.Delete(k); err != nil {
+			return fmt.Errorf("delete received message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// expired returns true if the message was received before the window or its record is invalid.
+func (d *Deduplicator) expired(src []byte, now time.Time) bool {
+	var rec received
+	if err := json.Unmarshal(src, &rec); err != nil {
+		return true
+	}
+
+	return now.Sub(rec.Time) >= d.window
+}
+
+func key(msgID, sender, recipient string) string {
+	if sender == "" {
+		return receivedKeyPrefix + anoncryptPrefix + recipient + "_" + msgID
+	}
+
+	return receivedKeyPrefix + sender + "_" + msgID
+}
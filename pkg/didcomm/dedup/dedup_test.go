@@ -0,0 +1,216 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dedup
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		d, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, 0)
+		require.NoError(t, err)
+		require.Equal(t, DefaultWindow, d.window)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}}, time.Minute)
+		require.EqualError(t, err, "failed to open deduplication store: open error")
+	})
+}
+
+func TestDeduplicator(t *testing.T) {
+	t.Run("duplicates within the window", func(t *testing.T) {
+		d, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		now := time.Now()
+		d.now = func() time.Time { return now }
+
+		duplicate, err := d.Receive("1", "alice", "")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+
+		duplicate, err = d.Receive("1", "alice", "")
+		require.NoError(t, err)
+		require.True(t, duplicate)
+
+		// same message ID from another sender
+		duplicate, err = d.Receive("1", "bob", "")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+
+		// the window has elapsed
+		now = now.Add(time.Minute)
+
+		duplicate, err = d.Receive("1", "alice", "")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+	})
+
+	t.Run("anoncrypt messages are told apart by recipient", func(t *testing.T) {
+		d, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		duplicate, err := d.Receive("1", "", "alice")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+
+		duplicate, err = d.Receive("1", "", "bob")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+
+		duplicate, err = d.Receive("1", "", "alice")
+		require.NoError(t, err)
+		require.True(t, duplicate)
+	})
+
+	t.Run("forgotten messages are received again", func(t *testing.T) {
+		d, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		duplicate, err := d.Receive("1", "alice", "")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+
+		require.NoError(t, d.Forget("1", "alice", ""))
+
+		duplicate, err = d.Receive("1", "alice", "")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+	})
+
+	t.Run("concurrent duplicates", func(t *testing.T) {
+		d, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		const receivers = 10
+
+		var (
+			wg       sync.WaitGroup
+			received int32
+		)
+
+		wg.Add(receivers)
+
+		for i := 0; i < receivers; i++ {
+			go func() {
+				defer wg.Done()
+
+				duplicate, e := d.Receive("1", "alice", "")
+				require.NoError(t, e)
+
+				if !duplicate {
+					atomic.AddInt32(&received, 1)
+				}
+			}()
+		}
+
+		wg.Wait()
+		require.Equal(t, int32(1), received)
+	})
+
+	t.Run("messages received before the window are purged", func(t *testing.T) {
+		storeProvider := mockstorage.NewMockStoreProvider()
+
+		d, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider}, time.Minute)
+		require.NoError(t, err)
+
+		now := time.Now()
+		d.now = func() time.Time { return now }
+
+		_, err = d.Receive("1", "alice", "")
+		require.NoError(t, err)
+		<-d.purged
+
+		now = now.Add(30 * time.Second)
+
+		_, err = d.Receive("2", "alice", "")
+		require.NoError(t, err)
+		require.Len(t, storeProvider.Store.Store, 2)
+
+		// purged once per window
+		now = now.Add(30 * time.Second)
+
+		_, err = d.Receive("3", "alice", "")
+		require.NoError(t, err)
+		<-d.purged
+
+		require.Len(t, storeProvider.Store.Store, 2)
+		require.NotContains(t, storeProvider.Store.Store, key("1", "alice", ""))
+
+		// a message received again while the purge runs is kept
+		now = now.Add(2 * time.Minute)
+
+		duplicate, err := d.Receive("2", "alice", "")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+		<-d.purged
+
+		require.Contains(t, storeProvider.Store.Store, key("2", "alice", ""))
+	})
+
+	t.Run("messages without ID", func(t *testing.T) {
+		d, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		duplicate, err := d.Receive("", "alice", "")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+
+		duplicate, err = d.Receive("", "alice", "")
+		require.NoError(t, err)
+		require.False(t, duplicate)
+
+		require.NoError(t, d.Forget("", "alice", ""))
+	})
+
+	t.Run("storage errors", func(t *testing.T) {
+		store := &mockstorage.MockStore{
+			Store:     map[string]mockstorage.DBEntry{},
+			ErrGet:    errors.New("get error"),
+			ErrPut:    errors.New("put error"),
+			ErrDelete: errors.New("delete error"),
+			ErrQuery:  errors.New("query error"),
+		}
+
+		d, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{Store: store},
+		}, time.Minute)
+		require.NoError(t, err)
+
+		// the purge errors are logged
+		_, err = d.Receive("1", "alice", "")
+		require.EqualError(t, err, "get received message: get error")
+		<-d.purged
+
+		require.True(t, d.lastPurge.IsZero())
+
+		store.ErrGet = nil
+
+		_, err = d.Receive("1", "alice", "")
+		require.EqualError(t, err, "save received message: put error")
+
+		require.EqualError(t, d.Forget("1", "alice", ""), "delete received message: delete error")
+
+		store.Store[key("1", "alice", "")] = mockstorage.DBEntry{Value: []byte("{")}
+
+		_, err = d.Receive("1", "alice", "")
+		require.Contains(t, err.Error(), "unmarshal received message")
+	})
+}
@@ -0,0 +1,256 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+// DIDComm v1 headers and decorators (Aries RFCs) and their DIDComm v2 counterparts (DIF DIDComm spec).
+const (
+	v1ID          = "@id"
+	v1Type        = "@type"
+	v1Thread      = "~thread"
+	v1Timing      = "~timing"
+	v1PleaseAck   = "~please_ack"
+	v1Transport   = "~transport"
+	v1ReturnRoute = "~return_route"
+	v1Attach      = "~attach"
+	v1MimeType    = "mime-type"
+	v1OutTime     = "out_time"
+
+	v2ID          = "id"
+	v2Type        = "type"
+	v2Body        = "body"
+	v2CreatedTime = "created_time"
+	v2PleaseAck   = "please_ack"
+	v2ReturnRoute = "return_route"
+	v2Attachments = "attachments"
+	v2MediaType   = "media_type"
+
+	threadID       = "thid"
+	parentThreadID = "pthid"
+	expiresTime    = "expires_time"
+	pleaseAckOn    = "on"
+	metadata       = "_internal_metadata"
+)
+
+// IsDIDCommV2 returns true if the message is a DIDComm v2 plaintext message, i.e. its type is not in the @type header.
+func IsDIDCommV2(msg service.DIDCommMsgMap) bool {
+	_, v1 := msg[v1Type]
+	_, v2 := msg[v2Type]
+
+	return v2 && !v1
+}
+
+// ToDIDCommV2 converts the DIDComm v1 message to a DIDComm v2 message: the headers and the decorators with a v2
+// counterpart (thread, timing, please-ack, return route and attachments) become v2 headers, the other attributes
+// (including the other decorators and the attachments of the protocols, e.g. offers~attach) go to the body.
+// ToDIDCommV1 converts the message back.
+func ToDIDCommV2(msg service.DIDCommMsgMap) service.DIDCommMsgMap {
+	v2 := service.DIDCommMsgMap{}
+	body := map[string]interface{}{}
+
+	for k, v := range msg {
+		switch k {
+		case v1ID:
+			v2[v2ID] = v
+		case v1Type:
+			v2[v2Type] = v
+		case v1Thread:
+			thread, _ := v.(map[string]interface{}) // nolint: errcheck
+			copyValues(v2, thread, threadID, parentThreadID)
+		case v1Timing:
+			timing, _ := v.(map[string]interface{}) // nolint: errcheck
+			setUnixTime(v2, v2CreatedTime, timing[v1OutTime])
+			setUnixTime(v2, expiresTime, timing[expiresTime])
+		case v1PleaseAck:
+			pleaseAck, _ := v.(map[string]interface{}) // nolint: errcheck
+			v2[v2PleaseAck] = pleaseAck[pleaseAckOn]
+		case v1Transport:
+			returnRoute, _ := v.(map[string]interface{}) // nolint: errcheck
+			copyValue(v2, v2ReturnRoute, returnRoute[v1ReturnRoute])
+		case v1Attach:
+			v2[v2Attachments] = renameAttachmentFields(v, v1ID, v2ID, v1MimeType, v2MediaType)
+		case metadata:
+			v2[k] = v
+		default:
+			body[k] = v
+		}
+	}
+
+	v2[v2Body] = body
+
+	return v2
+}
+
+// ToDIDCommV1 converts the DIDComm v2 message to a DIDComm v1 message: the v2 headers with a v1 counterpart become
+// v1 headers and decorators, the attributes of the body become attributes of the message. The other v2 headers
+// (e.g. from and to) are kept as they are.
+func ToDIDCommV1(msg service.DIDCommMsgMap) service.DIDCommMsgMap {
+	v1 := service.DIDCommMsgMap{}
+	thread := map[string]interface{}{}
+	timing := map[string]interface{}{}
+
+	body, _ := msg[v2Body].(map[string]interface{}) // nolint: errcheck
+	for k, v := range body {
+		v1[k] = v
+	}
+
+	for k, v := range msg {
+		switch k {
+		case v2ID:
+			v1[v1ID] = v
+		case v2Type:
+			v1[v1Type] = v
+		case threadID, parentThreadID:
+			thread[k] = v
+		case v2CreatedTime:
+			setRFC3339Time(timing, v1OutTime, v)
+		case expiresTime:
+			setRFC3339Time(timing, expiresTime, v)
+		case v2PleaseAck:
+			v1[v1PleaseAck] = map[string]interface{}{pleaseAckOn: v}
+		case v2ReturnRoute:
+			v1[v1Transport] = map[string]interface{}{v1ReturnRoute: v}
+		case v2Attachments:
+			v1[v1Attach] = renameAttachmentFields(v, v2ID, v1ID, v2MediaType, v1MimeType)
+		case v2Body:
+		default:
+			v1[k] = v
+		}
+	}
+
+	if len(thread) > 0 {
+		v1[v1Thread] = thread
+	}
+
+	if len(timing) > 0 {
+		v1[v1Timing] = timing
+	}
+
+	return v1
+}
+
+// isV2Plaintext returns true if the messages packed with the media type are DIDComm v2 plaintext messages,
+// the v2 envelopes of v1 plaintext payloads (transport.MediaTypeV2EncryptedEnvelopeV1PlaintextPayload) are not.
+func isV2Plaintext(mediaType string) bool {
+	return mediaType == transport.MediaTypeV2EncryptedEnvelope || mediaType == transport.MediaTypeV2SignedMessage
+}
+
+// bridge converts the message to the DIDComm version of the plaintext the media type packs, the payloads which are
+// not DIDComm messages are returned as they are.
+func bridge(req []byte, mediaType string) ([]byte, error) {
+	msg, err := service.ParseDIDCommMsgMap(req)
+	if err != nil {
+		return req, nil // nolint: nilerr
+	}
+
+	v2 := isV2Plaintext(mediaType)
+
+	switch {
+	case v2 && !IsDIDCommV2(msg):
+		msg = ToDIDCommV2(msg)
+	case !v2 && IsDIDCommV2(msg):
+		msg = ToDIDCommV1(msg)
+	default:
+		return req, nil
+	}
+
+	req, err = json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal bridged message: %w", err)
+	}
+
+	return req, nil
+}
+
+func copyValues(dst, src map[string]interface{}, keys ...string) {
+	for _, k := range keys {
+		copyValue(dst, k, src[k])
+	}
+}
+
+func copyValue(dst map[string]interface{}, key string, v interface{}) {
+	if v != nil && v != "" {
+		dst[key] = v
+	}
+}
+
+// setUnixTime sets the v2 time (seconds since the epoch) of the v1 time (RFC 3339).
+func setUnixTime(dst map[string]interface{}, key string, v interface{}) {
+	s, ok := v.(string)
+	if !ok {
+		return
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil || t.IsZero() {
+		return
+	}
+
+	dst[key] = t.Unix()
+}
+
+// setRFC3339Time sets the v1 time (RFC 3339) of the v2 time (seconds since the epoch).
+func setRFC3339Time(dst map[string]interface{}, key string, v interface{}) {
+	var seconds int64
+
+	switch n := v.(type) {
+	case float64:
+		seconds = int64(n)
+	case int64:
+		seconds = n
+	case int:
+		seconds = int64(n)
+	default:
+		return
+	}
+
+	dst[key] = time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+}
+
+// renameAttachmentFields returns the copies of the attachments with the id and media type fields renamed.
+func renameAttachmentFields(v interface{}, fromID, toID, fromMediaType, toMediaType string) interface{} {
+	attachments, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+
+	renamed := make([]interface{}, len(attachments))
+
+	for i, a := range attachments {
+		attachment, ok := a.(map[string]interface{})
+		if !ok {
+			renamed[i] = a
+
+			continue
+		}
+
+		r := make(map[string]interface{}, len(attachment))
+
+		for k, v := range attachment {
+			switch k {
+			case fromID:
+				r[toID] = v
+			case fromMediaType:
+				r[toMediaType] = v
+			default:
+				r[k] = v
+			}
+		}
+
+		renamed[i] = r
+	}
+
+	return renamed
+}
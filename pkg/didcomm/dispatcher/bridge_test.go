@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+)
+
+const v1Message = `{
+	"@id": "msg-1",
+	"@type": "https://didcomm.org/issue-credential/2.0/offer-credential",
+	"~thread": {"thid": "thread-1", "pthid": "parent-1", "sender_order": 1},
+	"~timing": {"out_time": "2021-06-01T10:00:00Z", "expires_time": "2021-06-02T10:00:00Z"},
+	"~please_ack": {"on": ["RECEIPT"]},
+	"~transport": {"~return_route": "all"},
+	"~attach": [{"@id": "a-1", "mime-type": "application/json", "data": {"json": {"k": "v"}}}],
+	"comment": "offer",
+	"offers~attach": [{"@id": "offer-1", "data": {"base64": "e30="}}]
+}`
+
+func parse(t *testing.T, raw string) service.DIDCommMsgMap {
+	t.Helper()
+
+	msg, err := service.ParseDIDCommMsgMap([]byte(raw))
+	require.NoError(t, err)
+
+	return msg
+}
+
+func TestToDIDCommV2(t *testing.T) {
+	v1 := parse(t, v1Message)
+	require.False(t, IsDIDCommV2(v1))
+
+	v2 := ToDIDCommV2(v1)
+	require.True(t, IsDIDCommV2(v2))
+
+	require.Equal(t, "msg-1", v2["id"])
+	require.Equal(t, v1.Type(), v2["type"])
+	require.Equal(t, "thread-1", v2["thid"])
+	require.Equal(t, "parent-1", v2["pthid"])
+	require.EqualValues(t, 1622541600, v2["created_time"])
+	require.EqualValues(t, 1622628000, v2["expires_time"])
+	require.Equal(t, []interface{}{"RECEIPT"}, v2["please_ack"])
+	require.Equal(t, "all", v2["return_route"])
+	require.Equal(t, []interface{}{map[string]interface{}{
+		"id":         "a-1",
+		"media_type": "application/json",
+		"data":       map[string]interface{}{"json": map[string]interface{}{"k": "v"}},
+	}}, v2["attachments"])
+
+	body, ok := v2["body"].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "offer", body["comment"])
+	require.Equal(t, v1["offers~attach"], body["offers~attach"])
+	require.NotContains(t, body, "~thread")
+}
+
+func TestToDIDCommV1(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		v1 := parse(t, v1Message)
+
+		raw, err := json.Marshal(ToDIDCommV2(v1))
+		require.NoError(t, err)
+
+		// the message is sent and received
+		back := ToDIDCommV1(parse(t, string(raw)))
+
+		require.Equal(t, v1.ID(), back.ID())
+		require.Equal(t, v1.Type(), back.Type())
+		require.Equal(t, "parent-1", back.ParentThreadID())
+
+		thID, err := back.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, "thread-1", thID)
+
+		for _, k := range []string{"~timing", "~please_ack", "~transport", "~attach", "comment", "offers~attach"} {
+			require.Equal(t, v1[k], back[k], k)
+		}
+	})
+
+	t.Run("v2 message", func(t *testing.T) {
+		v1 := ToDIDCommV1(parse(t, `{
+			"id": "msg-2",
+			"type": "https://didcomm.org/basicmessage/2.0/message",
+			"from": "did:example:alice",
+			"body": {"content": "hello"}
+		}`))
+
+		require.Equal(t, "msg-2", v1.ID())
+		require.Equal(t, "https://didcomm.org/basicmessage/2.0/message", v1.Type())
+		require.Equal(t, "hello", v1["content"])
+		require.Equal(t, "did:example:alice", v1["from"])
+		require.NotContains(t, v1, "body")
+		require.NotContains(t, v1, "~thread")
+	})
+}
+
+func TestBridge(t *testing.T) {
+	v1, err := json.Marshal(parse(t, v1Message))
+	require.NoError(t, err)
+
+	t.Run("v1 message to v2 destination", func(t *testing.T) {
+		req, err := bridge(v1, transport.MediaTypeV2EncryptedEnvelope)
+		require.NoError(t, err)
+		require.True(t, IsDIDCommV2(parse(t, string(req))))
+	})
+
+	t.Run("v1 message to v2 envelope of v1 payload", func(t *testing.T) {
+		req, err := bridge(v1, transport.MediaTypeV2EncryptedEnvelopeV1PlaintextPayload)
+		require.NoError(t, err)
+		require.Equal(t, v1, req)
+	})
+
+	t.Run("v2 message to v1 destination", func(t *testing.T) {
+		req, err := bridge([]byte(`{"id":"1","type":"https://didcomm.org/trust-ping/2.0/ping","body":{}}`), "")
+		require.NoError(t, err)
+		require.Equal(t, "https://didcomm.org/trust-ping/2.0/ping", parse(t, string(req)).Type())
+	})
+
+	t.Run("not a DIDComm message", func(t *testing.T) {
+		req, err := bridge([]byte(`"data"`), transport.MediaTypeV2EncryptedEnvelope)
+		require.NoError(t, err)
+		require.Equal(t, []byte(`"data"`), req)
+	})
+}
+
+type bridgeProvider struct {
+	*mockProvider
+}
+
+func (p *bridgeProvider) DIDCommBridge() bool {
+	return true
+}
+
+type capturePackager struct {
+	mockPackager
+	envelope *transport.Envelope
+}
+
+func (m *capturePackager) PackMessage(e *transport.Envelope) ([]byte, error) {
+	m.envelope = e
+
+	return e.Message, nil
+}
+
+func TestOutboundDispatcher_Bridge(t *testing.T) {
+	packager := &capturePackager{}
+
+	o := NewOutbound(&bridgeProvider{&mockProvider{
+		packagerValue:           packager,
+		outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
+		transportReturnRoute:    "all",
+	}})
+
+	msg := service.NewDIDCommMsgMap(struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	}{ID: "msg-1", Type: "https://didcomm.org/trust-ping/1.0/ping"})
+
+	require.NoError(t, o.Send(msg, mockdiddoc.MockDIDKey(t), &service.Destination{
+		ServiceEndpoint: "url",
+		MediaTypes:      []string{transport.MediaTypeV2EncryptedEnvelope},
+	}))
+
+	sent := parse(t, string(packager.envelope.Message))
+	require.True(t, IsDIDCommV2(sent))
+	require.Equal(t, "msg-1", sent["id"])
+	require.Equal(t, "all", sent["return_route"])
+
+	require.NoError(t, o.Send(msg, mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"}))
+	require.False(t, IsDIDCommV2(parse(t, string(packager.envelope.Message))))
+}
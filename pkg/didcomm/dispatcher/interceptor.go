@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+// OutboundMessage is the message sent by the OutboundDispatcher, as seen by the outbound interceptors.
+type OutboundMessage struct {
+	// Msg is the message before packing, interceptors may modify it (e.g. add decorators).
+	Msg         service.DIDCommMsgMap
+	SenderKey   string
+	Destination *service.Destination
+}
+
+// OutboundHandler handles the outbound message.
+type OutboundHandler interface {
+	HandleOutbound(msg *OutboundMessage) error
+}
+
+// OutboundHandlerFunc is a function wrapper for the OutboundHandler.
+type OutboundHandlerFunc func(msg *OutboundMessage) error
+
+// HandleOutbound handles the outbound message.
+func (f OutboundHandlerFunc) HandleOutbound(msg *OutboundMessage) error {
+	return f(msg)
+}
+
+// OutboundInterceptor is the outbound counterpart of the protocol middleware. It wraps the next handler of the chain,
+// the last one packs the message and sends it to the destination. The interceptor may modify the message, return an
+// error to reject it or divert it by not calling the next handler.
+type OutboundInterceptor func(next OutboundHandler) OutboundHandler
+
+// RegisterOutboundInterceptor adds the interceptors to the chain the messages sent by the Send and SendToDID functions
+// go through. The interceptors registered first are executed first. Forwarded messages and payloads that are not
+// JSON objects are not intercepted.
+func (o *OutboundDispatcher) RegisterOutboundInterceptor(interceptors ...OutboundInterceptor) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.interceptors = append(o.interceptors, interceptors...)
+	if len(o.interceptors) == 0 {
+		return
+	}
+
+	var handler OutboundHandler = OutboundHandlerFunc(func(msg *OutboundMessage) error {
+		return o.send(msg.Msg, msg.SenderKey, msg.Destination)
+	})
+
+	for i := len(o.interceptors) - 1; i >= 0; i-- {
+		handler = o.interceptors[i](handler)
+	}
+
+	o.outboundHandler = handler
+}
+
+func (o *OutboundDispatcher) getOutboundHandler() OutboundHandler {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.outboundHandler
+}
+
+// toDIDCommMsgMap converts the outbound message to DIDCommMsgMap, so the interceptors can handle it regardless
+// of the type the message was sent with.
+func toDIDCommMsgMap(msg interface{}) (service.DIDCommMsgMap, error) {
+	if msgMap, ok := msg.(service.DIDCommMsgMap); ok {
+		return msgMap, nil
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal message: %w", err)
+	}
+
+	msgMap, err := service.ParseDIDCommMsgMap(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse message: %w", err)
+	}
+
+	return msgMap, nil
+}
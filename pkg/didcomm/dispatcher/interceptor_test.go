@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+)
+
+func TestOutboundDispatcher_RegisterOutboundInterceptor(t *testing.T) {
+	dest := &service.Destination{ServiceEndpoint: "url"}
+
+	t.Run("interceptors are executed in order", func(t *testing.T) {
+		o := NewOutbound(&mockProvider{
+			packagerValue: &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{
+				&mockOutboundTransport{expectedRequest: `{"@id":"1","order":"ab","~timing":{"expires_time":"0001-01-01T00:00:00Z"}}`},
+			},
+		})
+
+		var calls []string
+
+		addOrder := func(name string) OutboundInterceptor {
+			return func(next OutboundHandler) OutboundHandler {
+				return OutboundHandlerFunc(func(msg *OutboundMessage) error {
+					calls = append(calls, name)
+
+					order, _ := msg.Msg["order"].(string) // nolint: errcheck
+					msg.Msg["order"] = order + name
+
+					return next.HandleOutbound(msg)
+				})
+			}
+		}
+
+		o.RegisterOutboundInterceptor(addOrder("a"))
+		o.RegisterOutboundInterceptor(addOrder("b"), func(next OutboundHandler) OutboundHandler {
+			return OutboundHandlerFunc(func(msg *OutboundMessage) error {
+				require.Equal(t, dest, msg.Destination)
+				require.NotEmpty(t, msg.SenderKey)
+
+				msg.Msg["~timing"] = &decorator.Timing{ExpiresTime: time.Time{}}
+
+				return next.HandleOutbound(msg)
+			})
+		})
+
+		require.NoError(t, o.Send(struct {
+			ID string `json:"@id"`
+		}{ID: "1"}, mockdiddoc.MockDIDKey(t), dest))
+		require.Equal(t, []string{"a", "b"}, calls)
+	})
+
+	t.Run("interceptor diverts the message", func(t *testing.T) {
+		o := NewOutbound(&mockProvider{
+			packagerValue: &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{
+				&mockOutboundTransport{expectedRequest: "unexpected"},
+			},
+		})
+
+		var diverted *OutboundMessage
+
+		o.RegisterOutboundInterceptor(func(next OutboundHandler) OutboundHandler {
+			return OutboundHandlerFunc(func(msg *OutboundMessage) error {
+				diverted = msg
+
+				return nil
+			})
+		})
+
+		msg := service.DIDCommMsgMap{"@id": "1"}
+
+		require.NoError(t, o.Send(msg, mockdiddoc.MockDIDKey(t), dest))
+		require.Equal(t, msg, diverted.Msg)
+	})
+
+	t.Run("interceptor rejects the message", func(t *testing.T) {
+		o := NewOutbound(&mockProvider{packagerValue: &mockPackager{}})
+
+		o.RegisterOutboundInterceptor(func(next OutboundHandler) OutboundHandler {
+			return OutboundHandlerFunc(func(msg *OutboundMessage) error {
+				return errors.New("policy violation")
+			})
+		})
+
+		require.EqualError(t, o.Send(service.DIDCommMsgMap{}, mockdiddoc.MockDIDKey(t), dest), "policy violation")
+	})
+
+	t.Run("payload that is not a JSON object is not intercepted", func(t *testing.T) {
+		o := NewOutbound(&mockProvider{
+			packagerValue: &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{
+				&mockOutboundTransport{expectedRequest: `"data"`},
+			},
+		})
+		o.RegisterOutboundInterceptor(func(next OutboundHandler) OutboundHandler {
+			return OutboundHandlerFunc(func(msg *OutboundMessage) error {
+				return errors.New("unexpected call")
+			})
+		})
+
+		require.NoError(t, o.Send("data", mockdiddoc.MockDIDKey(t), dest))
+
+		err := o.Send(make(chan int), mockdiddoc.MockDIDKey(t), dest)
+		require.Contains(t, err.Error(), "outboundDispatcher.Send: failed marshal to bytes")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 
@@ -39,22 +40,40 @@ type provider interface {
 
 // OutboundDispatcher dispatch msgs to destination.
 type OutboundDispatcher struct {
-	outboundTransports   []transport.OutboundTransport
+	outboundTransports   func() []transport.OutboundTransport
 	packager             transport.Packager
 	transportReturnRoute string
 	vdRegistry           vdr.Registry
 	kms                  kms.KeyManager
+	mu                   sync.RWMutex
+	interceptors         []OutboundInterceptor
+	outboundHandler      OutboundHandler
+	bridgeVersions       bool
+	mediaTypeProfiles    []string
 }
 
-// NewOutbound return new dispatcher outbound instance.
+// NewOutbound return new dispatcher outbound instance. The messages are converted to the DIDComm version of the
+// media type of their destination if the provider enables the bridging of the versions (see ToDIDCommV2).
+// The media type of the messages is negotiated from the accept list of their destination and the media type
+// profiles of the provider (see transport.NegotiateMediaTypeProfile).
 func NewOutbound(prov provider) *OutboundDispatcher {
-	return &OutboundDispatcher{
-		outboundTransports:   prov.OutboundTransports(),
+	o := &OutboundDispatcher{
+		outboundTransports:   prov.OutboundTransports,
 		packager:             prov.Packager(),
 		transportReturnRoute: prov.TransportReturnRoute(),
 		vdRegistry:           prov.VDRegistry(),
 		kms:                  prov.KMS(),
 	}
+
+	if p, ok := prov.(interface{ DIDCommBridge() bool }); ok {
+		o.bridgeVersions = p.DIDCommBridge()
+	}
+
+	if p, ok := prov.(interface{ MediaTypeProfiles() []string }); ok {
+		o.mediaTypeProfiles = p.MediaTypeProfiles()
+	}
+
+	return o
 }
 
 // SendToDID sends a message from myDID to the agent who owns theirDID.
@@ -79,9 +98,25 @@ func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string)
 }
 
 // Send sends the message after packing with the sender key and recipient keys.
-// nolint:gocyclo
+// The message goes through the registered outbound interceptors (see RegisterOutboundInterceptor) first.
 func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *service.Destination) error {
-	for _, v := range o.outboundTransports {
+	handler := o.getOutboundHandler()
+	if handler == nil {
+		return o.send(msg, senderVerKey, des)
+	}
+
+	msgMap, err := toDIDCommMsgMap(msg)
+	if err != nil {
+		// the payload is not a DIDComm message (JSON object), it is sent as is
+		return o.send(msg, senderVerKey, des)
+	}
+
+	return handler.HandleOutbound(&OutboundMessage{Msg: msgMap, SenderKey: senderVerKey, Destination: des})
+}
+
+// nolint:gocyclo
+func (o *OutboundDispatcher) send(msg interface{}, senderVerKey string, des *service.Destination) error {
+	for _, v := range o.outboundTransports() {
 		// check if outbound accepts routing keys, else use recipient keys
 		keys := des.RecipientKeys
 		if len(des.RoutingKeys) != 0 {
@@ -105,13 +140,22 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 			return fmt.Errorf("outboundDispatcher.Send: failed to add transport route options : %w", err)
 		}
 
+		mediaType := o.mediaType(des)
+
+		if o.bridgeVersions {
+			req, err = bridge(req, mediaType)
+			if err != nil {
+				return fmt.Errorf("outboundDispatcher.Send: %w", err)
+			}
+		}
+
 		sender, err := fingerprint.PubKeyFromDIDKey(senderVerKey)
 		if err != nil {
 			return fmt.Errorf("outboundDispatcher.Send: failed to extract pubKeyBytes from senderVerKey: %w", err)
 		}
 
 		packedMsg, err := o.packager.PackMessage(&transport.Envelope{
-			MediaType: mediaType(des),
+			MediaType: mediaType,
 			Message:   req,
 			FromKey:   sender,
 			ToKeys:    des.RecipientKeys,
@@ -141,7 +185,7 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 
 // Forward forwards the message without packing to the destination.
 func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination) error {
-	for _, v := range o.outboundTransports {
+	for _, v := range o.outboundTransports() {
 		if !v.AcceptRecipient(des.RecipientKeys) {
 			if !v.Accept(des.ServiceEndpoint) {
 				continue
@@ -200,7 +244,7 @@ func (o *OutboundDispatcher) createForwardMessage(msg []byte, des *service.Desti
 	//  algorithm(auth/anon crypt) for Forward(router) message
 
 	packedMsg, err := o.packager.PackMessage(&transport.Envelope{
-		MediaType: mediaType(des),
+		MediaType: o.mediaType(des),
 		Message:   req,
 		FromKey:   senderVerKey,
 		ToKeys:    des.RoutingKeys,
@@ -239,12 +283,8 @@ func (o *OutboundDispatcher) addTransportRouteOptions(req []byte, des *service.D
 	return req, nil
 }
 
-// TODO - inject MediaType selection strategy.
-func mediaType(des *service.Destination) string {
-	mt := ""
-	if len(des.MediaTypes) > 0 {
-		mt = des.MediaTypes[0]
-	}
-
-	return mt
+// mediaType returns the envelope media type of the profile negotiated from the accept list of the destination
+// (see transport.SelectMediaTypeProfile), an empty string makes the packager use its default one.
+func (o *OutboundDispatcher) mediaType(des *service.Destination) string {
+	return transport.MediaTypeForProfile(transport.SelectMediaTypeProfile(des.MediaTypes, o.mediaTypeProfiles))
 }
@@ -373,3 +373,61 @@ func (m *mockPackager) PackMessage(e *transport.Envelope) ([]byte, error) {
 func (m *mockPackager) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
 	return nil, nil
 }
+
+type mediaTypeProfilesProvider struct {
+	*mockProvider
+	profiles []string
+}
+
+func (p *mediaTypeProfilesProvider) MediaTypeProfiles() []string {
+	return p.profiles
+}
+
+func TestOutboundDispatcher_MediaType(t *testing.T) {
+	tests := []struct {
+		name      string
+		profiles  []string
+		accept    []string
+		mediaType string
+	}{
+		{
+			name: "default media type of the packager",
+		},
+		{
+			name:      "first accepted profile",
+			accept:    []string{"didcomm/v3", transport.MediaTypeProfileDIDCommV2, transport.MediaTypeProfileAIP1},
+			mediaType: transport.MediaTypeV2EncryptedEnvelope,
+		},
+		{
+			name:      "first accepted profile supported by the agent",
+			profiles:  []string{transport.MediaTypeProfileAIP2RFC19, transport.MediaTypeProfileAIP2RFC587},
+			accept:    []string{transport.MediaTypeProfileDIDCommV2, transport.MediaTypeProfileAIP2RFC587},
+			mediaType: transport.MediaTypeV2EncryptedEnvelopeV1PlaintextPayload,
+		},
+		{
+			name:      "first profile of the agent",
+			profiles:  []string{transport.MediaTypeProfileAIP2RFC19},
+			accept:    []string{transport.MediaTypeProfileDIDCommV2},
+			mediaType: transport.MediaTypeV1EncryptedEnvelope,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			packager := &capturePackager{}
+
+			o := NewOutbound(&mediaTypeProfilesProvider{mockProvider: &mockProvider{
+				packagerValue:           packager,
+				outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
+			}, profiles: tc.profiles})
+
+			require.NoError(t, o.Send("data", mockdiddoc.MockDIDKey(t), &service.Destination{
+				ServiceEndpoint: "url",
+				MediaTypes:      tc.accept,
+			}))
+			require.Equal(t, tc.mediaType, packager.envelope.MediaType)
+		})
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eventbridge publishes the events of the protocol services on an event bus (e.g. Kafka or NATS), so
+// that backend systems without a controller consume them at scale and replay them.
+//
+// The events of a protocol are published on the topics "<prefix><protocol name>.actions" and
+// "<prefix><protocol name>.states", with the Event JSON as payload and the protocol instance ID as ordering key.
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/spi/eventbus"
+)
+
+const (
+	// DefaultTopicPrefix is the default prefix of the topics.
+	DefaultTopicPrefix = "aries."
+
+	// ActionsTopicSuffix is the suffix of the topics of the action events.
+	ActionsTopicSuffix = ".actions"
+	// StatesTopicSuffix is the suffix of the topics of the state events.
+	StatesTopicSuffix = ".states"
+
+	// EventTypeAction is the type of the action events.
+	EventTypeAction = "action"
+	// EventTypePreState is the type of the events sent before a state change.
+	EventTypePreState = "pre_state"
+	// EventTypePostState is the type of the events sent after a state change.
+	EventTypePostState = "post_state"
+
+	defaultPublishTimeout = 10 * time.Second
+	piidPropKey           = "piid"
+)
+
+var logger = log.New("aries-framework/didcomm/eventbridge")
+
+// Event is the payload of the messages published by the Bridge.
+type Event struct {
+	ID           string                 `json:"id"`
+	ProtocolName string                 `json:"protocol_name"`
+	Type         string                 `json:"type"`
+	StateID      string                 `json:"state_id,omitempty"`
+	PIID         string                 `json:"piid,omitempty"`
+	Message      service.DIDCommMsgMap  `json:"message,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// Option configures the Bridge.
+type Option func(b *Bridge)
+
+// WithTopicPrefix sets the prefix of the topics, DefaultTopicPrefix by default.
+func WithTopicPrefix(prefix string) Option {
+	return func(b *Bridge) {
+		b.topicPrefix = prefix
+	}
+}
+
+// WithPublishTimeout sets the timeout of the publication of an event (10 seconds by default).
+func WithPublishTimeout(timeout time.Duration) Option {
+	return func(b *Bridge) {
+		b.publishTimeout = timeout
+	}
+}
+
+// Bridge publishes the action and state events of the protocol services on an event bus.
+type Bridge struct {
+	bus            eventbus.Bus
+	topicPrefix    string
+	publishTimeout time.Duration
+
+	mu          sync.Mutex
+	unregisters []func()
+	wg          sync.WaitGroup
+}
+
+// New returns a new Bridge publishing the events on bus.
+func New(bus eventbus.Bus, opts ...Option) *Bridge {
+	b := &Bridge{
+		bus:            bus,
+		topicPrefix:    DefaultTopicPrefix,
+		publishTimeout: defaultPublishTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Register publishes the action and state events of the service.
+// Only one consumer of the action events can be registered on a service: the Bridge is meant for the services keeping
+// their pending actions (e.g. issue credential and present proof), which the backend system continues through the
+// protocol clients with the PIID of the event.
+func (b *Bridge) Register(svc service.Event) error {
+	actions := make(chan service.DIDCommAction)
+
+	if err := svc.RegisterActionEvent(actions); err != nil {
+		return fmt.Errorf("register action event: %w", err)
+	}
+
+	b.track(func() {
+		if err := svc.UnregisterActionEvent(actions); err != nil {
+			logger.Warnf("unregister action event: %s", err)
+		}
+
+		close(actions)
+	})
+
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+
+		for action := range actions {
+			b.publish(toActionEvent(action))
+		}
+	}()
+
+	return b.RegisterStates(svc)
+}
+
+// RegisterStates publishes the state events of the service, the action events being consumed in-process.
+func (b *Bridge) RegisterStates(svc service.Event) error {
+	states := make(chan service.StateMsg)
+
+	if err := svc.RegisterMsgEvent(states); err != nil {
+		return fmt.Errorf("register msg event: %w", err)
+	}
+
+	b.track(func() {
+		if err := svc.UnregisterMsgEvent(states); err != nil {
+			logger.Warnf("unregister msg event: %s", err)
+		}
+
+		close(states)
+	})
+
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+
+		for state := range states {
+			b.publish(toStateEvent(state))
+		}
+	}()
+
+	return nil
+}
+
+// Close unregisters the Bridge from the services and waits for the events being published. The bus is owned by the
+// caller.
+func (b *Bridge) Close() {
+	b.mu.Lock()
+	unregisters := b.unregisters
+	b.unregisters = nil
+	b.mu.Unlock()
+
+	for _, unregister := range unregisters {
+		unregister()
+	}
+
+	b.wg.Wait()
+}
+
+func (b *Bridge) track(unregister func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.unregisters = append(b.unregisters, unregister)
+}
+
+func (b *Bridge) publish(event *Event) {
+	topic := b.topicPrefix + event.ProtocolName + StatesTopicSuffix
+	if event.Type == EventTypeAction {
+		topic = b.topicPrefix + event.ProtocolName + ActionsTopicSuffix
+	}
+
+	src, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorf("marshal event of %s: %s", event.ProtocolName, err)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.publishTimeout)
+	defer cancel()
+
+	err = b.bus.Publish(ctx, &eventbus.Message{ID: event.ID, Topic: topic, Key: event.PIID, Payload: src})
+	if err != nil {
+		logger.Errorf("publish event %s on %s: %s", event.ID, topic, err)
+	}
+}
+
+func toActionEvent(action service.DIDCommAction) *Event {
+	event := newEvent(action.ProtocolName, action.Message, action.Properties)
+	event.Type = EventTypeAction
+
+	return event
+}
+
+func toStateEvent(msg service.StateMsg) *Event {
+	event := newEvent(msg.ProtocolName, msg.Msg, msg.Properties)
+	event.StateID = msg.StateID
+
+	event.Type = EventTypePreState
+	if msg.Type == service.PostState {
+		event.Type = EventTypePostState
+	}
+
+	return event
+}
+
+func newEvent(protocolName string, msg service.DIDCommMsg, props service.EventProperties) *Event {
+	event := &Event{
+		ID:           uuid.New().String(),
+		ProtocolName: protocolName,
+		CreatedAt:    time.Now(),
+	}
+
+	if msg != nil {
+		event.Message = msg.Clone()
+	}
+
+	if props != nil {
+		event.Properties = props.All()
+	}
+
+	// the protocol instance orders the events, the thread ID is used by the protocols without PIID
+	if piid, ok := event.Properties[piidPropKey].(string); ok {
+		event.PIID = piid
+	} else if msg != nil {
+		event.PIID, _ = msg.ThreadID() //nolint:errcheck // the events without thread are not ordered
+	}
+
+	return event
+}
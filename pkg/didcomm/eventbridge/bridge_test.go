@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockeventbus "github.com/hyperledger/aries-framework-go/pkg/mock/eventbus"
+	"github.com/hyperledger/aries-framework-go/spi/eventbus"
+)
+
+type protocolService struct {
+	service.Action
+	service.Message
+}
+
+type props map[string]interface{}
+
+func (p props) All() map[string]interface{} {
+	return p
+}
+
+func TestBridge_Register(t *testing.T) {
+	bus := &mockeventbus.MockBus{}
+	svc := &protocolService{}
+
+	bridge := New(bus, WithTopicPrefix("agent."), WithPublishTimeout(time.Second))
+	require.NoError(t, bridge.Register(svc))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	actions, err := bus.Subscribe(ctx, "agent.issue-credential.actions")
+	require.NoError(t, err)
+
+	states, err := bus.Subscribe(ctx, "agent.issue-credential.states")
+	require.NoError(t, err)
+
+	msg := service.DIDCommMsgMap{"@id": "msgID", "@type": "offer", "~thread": map[string]interface{}{"thid": "thid"}}
+
+	svc.ActionEvent() <- service.DIDCommAction{
+		ProtocolName: "issue-credential",
+		Message:      msg,
+		Properties:   props{"piid": "piid"},
+	}
+
+	event := receive(t, actions)
+	require.Equal(t, EventTypeAction, event.Type)
+	require.Equal(t, "issue-credential", event.ProtocolName)
+	require.Equal(t, "piid", event.PIID)
+	require.Equal(t, "msgID", event.Message.ID())
+	require.Equal(t, "piid", event.Properties["piid"])
+
+	for _, ch := range svc.MsgEvents() {
+		ch <- service.StateMsg{
+			ProtocolName: "issue-credential",
+			Type:         service.PostState,
+			StateID:      "offer-received",
+			Msg:          msg,
+		}
+	}
+
+	event = receive(t, states)
+	require.Equal(t, EventTypePostState, event.Type)
+	require.Equal(t, "offer-received", event.StateID)
+	require.Equal(t, "thid", event.PIID)
+
+	bridge.Close()
+
+	require.Nil(t, svc.ActionEvent())
+	require.Empty(t, svc.MsgEvents())
+	require.Len(t, bus.Published, 2)
+	require.Equal(t, "piid", bus.Published[0].Key)
+}
+
+func TestBridge_RegisterStates(t *testing.T) {
+	bus := &mockeventbus.MockBus{}
+	svc := &protocolService{}
+
+	bridge := New(bus)
+	require.NoError(t, bridge.RegisterStates(svc))
+	require.Nil(t, svc.ActionEvent())
+
+	for _, ch := range svc.MsgEvents() {
+		ch <- service.StateMsg{ProtocolName: "present-proof", Type: service.PreState, StateID: "request-sent"}
+	}
+
+	bridge.Close()
+
+	require.Len(t, bus.Published, 1)
+	require.Equal(t, DefaultTopicPrefix+"present-proof"+StatesTopicSuffix, bus.Published[0].Topic)
+
+	event := &Event{}
+	require.NoError(t, json.Unmarshal(bus.Published[0].Payload, event))
+	require.Equal(t, EventTypePreState, event.Type)
+	require.Empty(t, event.PIID)
+}
+
+func TestBridge_Errors(t *testing.T) {
+	t.Run("action channel already registered", func(t *testing.T) {
+		svc := &protocolService{}
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		err := New(&mockeventbus.MockBus{}).Register(svc)
+		require.True(t, errors.Is(err, service.ErrChannelRegistered))
+	})
+
+	t.Run("publish error", func(t *testing.T) {
+		svc := &protocolService{}
+
+		bridge := New(&mockeventbus.MockBus{PublishErr: errors.New("unavailable")})
+		require.NoError(t, bridge.Register(svc))
+
+		svc.ActionEvent() <- service.DIDCommAction{ProtocolName: "issue-credential"}
+
+		bridge.Close()
+	})
+}
+
+func receive(t *testing.T, ch <-chan *eventbus.Message) *Event {
+	t.Helper()
+
+	select {
+	case msg := <-ch:
+		event := &Event{}
+		require.NoError(t, json.Unmarshal(msg.Payload, event))
+		require.Equal(t, event.ID, msg.ID)
+
+		return event
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout")
+	}
+
+	return nil
+}
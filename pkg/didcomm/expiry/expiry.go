@@ -0,0 +1,236 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expiry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// TagName is the tag of the expiry entries, it must be part of the configuration of the store of the tracker.
+	TagName = "expiry"
+
+	// DefaultCheckInterval is the default interval between two checks of the expired protocol instances.
+	DefaultCheckInterval = time.Minute
+
+	keyPrefix = TagName + "_"
+	timingKey = "~timing"
+)
+
+var logger = log.New("aries-framework/didcomm/expiry")
+
+// ErrExpired is returned when a message is handled after its expiration time.
+var ErrExpired = errors.New("message expired")
+
+// Entry is a protocol instance waiting for the reply to a message carrying an expiration time.
+type Entry struct {
+	// Protocol instance ID
+	PIID string `json:"piid"`
+	// StateName is the state the protocol instance moved to when the message was handled.
+	StateName   string                `json:"state_name"`
+	ExpiresTime time.Time             `json:"expires_time"`
+	Msg         service.DIDCommMsgMap `json:"msg"`
+}
+
+// ExpireFunc abandons the protocol instance of the expired entry. The entry is removed once it returns no error,
+// so it must do nothing if the protocol instance has moved on since the entry was tracked.
+type ExpireFunc func(entry *Entry) error
+
+// Option configures the Tracker.
+type Option func(t *Tracker)
+
+// WithCheckInterval sets the interval between two checks of the expired protocol instances,
+// DefaultCheckInterval by default.
+func WithCheckInterval(interval time.Duration) Option {
+	return func(t *Tracker) {
+		t.interval = interval
+	}
+}
+
+// Tracker keeps the protocol instances waiting for the reply to a message with the `~timing.expires_time` decorator
+// and expires them once the time passes. The expired instances are checked by the worker started by Start.
+type Tracker struct {
+	store    storage.Store
+	expire   ExpireFunc
+	interval time.Duration
+	now      func() time.Time
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New returns the tracker of the protocol instances persisted in store, expired by expire. The store is the store
+// of the protocol state, its configuration must include TagName.
+func New(store storage.Store, expire ExpireFunc, opts ...Option) *Tracker {
+	t := &Tracker{
+		store:    store,
+		expire:   expire,
+		interval: DefaultCheckInterval,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// ExpiresTime returns the expiration time of the message (`~timing.expires_time`), the zero time if it has none.
+func ExpiresTime(msg service.DIDCommMsgMap) time.Time {
+	timing := struct {
+		Timing *decorator.Timing `json:"~timing,omitempty"`
+	}{}
+
+	if _, ok := msg[timingKey]; !ok || msg.Decode(&timing) != nil || timing.Timing == nil {
+		return time.Time{}
+	}
+
+	return timing.Timing.ExpiresTime
+}
+
+// Track saves the entry of the protocol instance if the message handled carries an expiration time.
+// ErrExpired is returned if the expiration time has already passed.
+func (t *Tracker) Track(piID, stateName string, msg service.DIDCommMsgMap) error {
+	expires := ExpiresTime(msg)
+	if expires.IsZero() {
+		return nil
+	}
+
+	if !expires.After(t.now()) {
+		return fmt.Errorf("%w at %s", ErrExpired, expires.UTC().Format(time.RFC3339))
+	}
+
+	src, err := json.Marshal(&Entry{PIID: piID, StateName: stateName, ExpiresTime: expires, Msg: msg})
+	if err != nil {
+		return fmt.Errorf("marshal expiry entry: %w", err)
+	}
+
+	if err = t.store.Put(keyPrefix+piID, src, storage.Tag{Name: TagName}); err != nil {
+		return fmt.Errorf("save expiry entry: %w", err)
+	}
+
+	return nil
+}
+
+// Check expires the protocol instances whose expiration time has passed and returns the number of entries removed.
+func (t *Tracker) Check() (int, error) {
+	iter, err := t.store.Query(TagName)
+	if err != nil {
+		return 0, fmt.Errorf("query expiry entries: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var entries []*Entry
+
+	more, err := iter.Next()
+
+	for ; err == nil && more; more, err = iter.Next() {
+		src, errValue := iter.Value()
+		if errValue != nil {
+			return 0, fmt.Errorf("get expiry entry: %w", errValue)
+		}
+
+		entry := &Entry{}
+		if errValue = json.Unmarshal(src, entry); errValue != nil {
+			return 0, fmt.Errorf("unmarshal expiry entry: %w", errValue)
+		}
+
+		if !entry.ExpiresTime.After(t.now()) {
+			entries = append(entries, entry)
+		}
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("iterate expiry entries: %w", err)
+	}
+
+	removed := 0
+
+	for _, entry := range entries {
+		if err = t.expire(entry); err != nil {
+			logger.Warnf("failed to expire protocol instance %s, it will be retried: %s", entry.PIID, err)
+
+			continue
+		}
+
+		if err = t.store.Delete(keyPrefix + entry.PIID); err != nil {
+			logger.Warnf("failed to remove expiry entry %s: %s", entry.PIID, err)
+
+			continue
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+// Start checks the expired protocol instances at every check interval until Stop is called.
+func (t *Tracker) Start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stop != nil {
+		return
+	}
+
+	t.stop = make(chan struct{})
+	t.done = make(chan struct{})
+
+	go t.run(t.stop, t.done)
+}
+
+// Stop stops the periodic check and waits for the check in progress to complete.
+func (t *Tracker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stop == nil {
+		return
+	}
+
+	close(t.stop)
+	<-t.done
+
+	t.stop = nil
+	t.done = nil
+}
+
+func (t *Tracker) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			removed, err := t.Check()
+			if err != nil {
+				logger.Errorf("expiry check failed: %v", err)
+			}
+
+			if removed > 0 {
+				logger.Infof("removed %d expiry entries", removed)
+			}
+		}
+	}
+}
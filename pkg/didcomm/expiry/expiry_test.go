@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expiry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func newStore(t *testing.T) storage.Store {
+	t.Helper()
+
+	provider := mem.NewProvider()
+
+	store, err := provider.OpenStore("test")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SetStoreConfig("test", storage.StoreConfiguration{TagNames: []string{TagName}}))
+
+	return store
+}
+
+func newMsg(expires time.Time) service.DIDCommMsgMap {
+	return service.NewDIDCommMsgMap(struct {
+		ID     string            `json:"@id"`
+		Type   string            `json:"@type"`
+		Timing *decorator.Timing `json:"~timing,omitempty"`
+	}{
+		ID:     "msgID",
+		Type:   "https://didcomm.org/present-proof/2.0/request-presentation",
+		Timing: &decorator.Timing{ExpiresTime: expires},
+	})
+}
+
+func TestExpiresTime(t *testing.T) {
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	require.True(t, expires.Equal(ExpiresTime(newMsg(expires))))
+	require.True(t, ExpiresTime(service.DIDCommMsgMap{"@id": "msgID"}).IsZero())
+	require.True(t, ExpiresTime(service.DIDCommMsgMap{timingKey: "invalid"}).IsZero())
+}
+
+func TestTracker_Track(t *testing.T) {
+	t.Run("tracks the message with an expiration time", func(t *testing.T) {
+		store := newStore(t)
+		tracker := New(store, nil)
+
+		require.NoError(t, tracker.Track("piID", "request-sent", newMsg(time.Now().Add(time.Hour))))
+
+		_, err := store.Get(keyPrefix + "piID")
+		require.NoError(t, err)
+	})
+
+	t.Run("ignores the message without expiration time", func(t *testing.T) {
+		store := newStore(t)
+		tracker := New(store, nil)
+
+		require.NoError(t, tracker.Track("piID", "request-sent", service.DIDCommMsgMap{"@id": "msgID"}))
+
+		_, err := store.Get(keyPrefix + "piID")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("expired message", func(t *testing.T) {
+		tracker := New(newStore(t), nil)
+
+		err := tracker.Track("piID", "request-sent", newMsg(time.Now().Add(-time.Hour)))
+		require.True(t, errors.Is(err, ErrExpired))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		tracker := New(&mockstorage.MockStore{
+			Store:  make(map[string]mockstorage.DBEntry),
+			ErrPut: errors.New("put error"),
+		}, nil)
+
+		err := tracker.Track("piID", "request-sent", newMsg(time.Now().Add(time.Hour)))
+		require.EqualError(t, err, "save expiry entry: put error")
+	})
+}
+
+func TestTracker_Check(t *testing.T) {
+	t.Run("expires the protocol instances whose expiration time passed", func(t *testing.T) {
+		store := newStore(t)
+
+		var expired []*Entry
+
+		tracker := New(store, func(entry *Entry) error {
+			expired = append(expired, entry)
+
+			return nil
+		})
+
+		require.NoError(t, tracker.Track("piID-1", "request-sent", newMsg(time.Now().Add(time.Hour))))
+		require.NoError(t, tracker.Track("piID-2", "request-sent", newMsg(time.Now().Add(2*time.Hour))))
+
+		removed, err := tracker.Check()
+		require.NoError(t, err)
+		require.Zero(t, removed)
+
+		tracker.now = func() time.Time { return time.Now().Add(90 * time.Minute) }
+
+		removed, err = tracker.Check()
+		require.NoError(t, err)
+		require.Equal(t, 1, removed)
+		require.Len(t, expired, 1)
+		require.Equal(t, "piID-1", expired[0].PIID)
+		require.Equal(t, "request-sent", expired[0].StateName)
+		require.Equal(t, "msgID", expired[0].Msg.ID())
+
+		_, err = store.Get(keyPrefix + "piID-1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = store.Get(keyPrefix + "piID-2")
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps the entry if the protocol instance fails to expire", func(t *testing.T) {
+		store := newStore(t)
+
+		tracker := New(store, func(entry *Entry) error {
+			return errors.New("expire error")
+		})
+
+		require.NoError(t, tracker.Track("piID", "request-sent", newMsg(time.Now().Add(time.Hour))))
+
+		tracker.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+		removed, err := tracker.Check()
+		require.NoError(t, err)
+		require.Zero(t, removed)
+
+		_, err = store.Get(keyPrefix + "piID")
+		require.NoError(t, err)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		tracker := New(&mockstorage.MockStore{
+			Store:    make(map[string]mockstorage.DBEntry),
+			ErrQuery: errors.New("query error"),
+		}, nil)
+
+		_, err := tracker.Check()
+		require.EqualError(t, err, "query expiry entries: query error")
+	})
+}
+
+func TestTracker_StartStop(t *testing.T) {
+	expired := make(chan *Entry, 1)
+
+	tracker := New(newStore(t), func(entry *Entry) error {
+		expired <- entry
+
+		return nil
+	}, WithCheckInterval(time.Millisecond))
+
+	require.NoError(t, tracker.Track("piID", "request-sent", newMsg(time.Now().Add(10*time.Millisecond))))
+
+	tracker.Start()
+	tracker.Start()
+
+	select {
+	case entry := <-expired:
+		require.Equal(t, "piID", entry.PIID)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	tracker.Stop()
+	tracker.Stop()
+}
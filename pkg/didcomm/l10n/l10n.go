@@ -0,0 +1,129 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package l10n
+
+import (
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const (
+	descriptionKey = "description"
+	codeKey        = "code"
+)
+
+// Catalog is a message catalog: the texts of the codes used by the messages (e.g. the codes of the problem reports),
+// in each locale.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0043-l10n#message-codes-and-catalogs
+type Catalog struct {
+	// URI identifies the catalog, it is referenced by the `~l10n.catalogs` decorator of the messages.
+	URI string `json:"uri"`
+	// Texts are the texts of the codes by locale, e.g. {"rejected": {"en": "Rejected", "es": "Rechazado"}}.
+	Texts map[string]map[string]string `json:"texts"`
+}
+
+// Registry keeps the message catalogs, the codes are resolved against the catalogs in their registration order.
+type Registry struct {
+	mu       sync.RWMutex
+	catalogs []*Catalog
+}
+
+// NewRegistry returns the registry of the given catalogs.
+func NewRegistry(catalogs ...*Catalog) *Registry {
+	r := &Registry{}
+
+	for _, c := range catalogs {
+		r.Register(c)
+	}
+
+	return r
+}
+
+// Register registers the catalog, replacing the catalog registered with the same URI.
+func (r *Registry) Register(catalog *Catalog) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, c := range r.catalogs {
+		if c.URI == catalog.URI {
+			r.catalogs[i] = catalog
+
+			return
+		}
+	}
+
+	r.catalogs = append(r.catalogs, catalog)
+}
+
+// Describe returns the texts of the code by locale and the URIs of the catalogs they come from. A locale defined by
+// several catalogs is resolved against the first one registered.
+func (r *Registry) Describe(code string) (map[string]string, []string) {
+	if r == nil {
+		return nil, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		texts map[string]string
+		uris  []string
+	)
+
+	for _, c := range r.catalogs {
+		localized, ok := c.Texts[code]
+		if !ok || len(localized) == 0 {
+			continue
+		}
+
+		if texts == nil {
+			texts = map[string]string{}
+		}
+
+		for locale, text := range localized {
+			if _, exists := texts[locale]; !exists {
+				texts[locale] = text
+			}
+		}
+
+		uris = append(uris, c.URI)
+	}
+
+	return texts, uris
+}
+
+// Localize sets the localized texts of the code of the problem report and references their catalogs.
+// Nothing is changed if the code is not defined by the catalogs, or if the registry is nil.
+func (r *Registry) Localize(report *model.ProblemReport) {
+	texts, uris := r.Describe(report.Description.Code)
+	if len(texts) == 0 {
+		return
+	}
+
+	report.Description.Texts = texts
+	report.L10n = &decorator.L10n{Catalogs: uris}
+}
+
+// ProblemReportMsg converts the problem report to a DIDComm message, keeping the localized texts of the description
+// next to its code (service.NewDIDCommMsgMap alone would drop them).
+func ProblemReportMsg(report *model.ProblemReport) service.DIDCommMsgMap {
+	msg := service.NewDIDCommMsgMap(report)
+
+	description := map[string]interface{}{}
+
+	for locale, text := range report.Description.Texts {
+		description[locale] = text
+	}
+
+	description[codeKey] = report.Description.Code
+	msg[descriptionKey] = description
+
+	return msg
+}
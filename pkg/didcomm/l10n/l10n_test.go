@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package l10n
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+)
+
+const (
+	coreCatalog   = "https://example.com/catalogs/core"
+	customCatalog = "https://example.com/catalogs/custom"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(&Catalog{
+		URI: coreCatalog,
+		Texts: map[string]map[string]string{
+			"rejected": {"en": "Rejected", "es": "Rechazado"},
+		},
+	}, &Catalog{
+		URI: customCatalog,
+		Texts: map[string]map[string]string{
+			"rejected": {"en": "Declined", "fr": "Refusé"},
+			"internal": {"en": "Internal error"},
+		},
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		texts, uris := registry.Describe("rejected")
+		require.Equal(t, map[string]string{"en": "Rejected", "es": "Rechazado", "fr": "Refusé"}, texts)
+		require.Equal(t, []string{coreCatalog, customCatalog}, uris)
+
+		texts, uris = registry.Describe("unknown")
+		require.Empty(t, texts)
+		require.Empty(t, uris)
+
+		texts, uris = (*Registry)(nil).Describe("rejected")
+		require.Empty(t, texts)
+		require.Empty(t, uris)
+	})
+
+	t.Run("register replaces the catalog with the same URI", func(t *testing.T) {
+		r := NewRegistry(&Catalog{URI: coreCatalog, Texts: map[string]map[string]string{"rejected": {"en": "Rejected"}}})
+		r.Register(&Catalog{URI: coreCatalog, Texts: map[string]map[string]string{"rejected": {"en": "Refused"}}})
+
+		texts, uris := r.Describe("rejected")
+		require.Equal(t, map[string]string{"en": "Refused"}, texts)
+		require.Equal(t, []string{coreCatalog}, uris)
+	})
+
+	t.Run("localize problem report", func(t *testing.T) {
+		report := &model.ProblemReport{Description: model.Code{Code: "internal"}}
+		registry.Localize(report)
+
+		require.Equal(t, map[string]string{"en": "Internal error"}, report.Description.Texts)
+		require.NotNil(t, report.L10n)
+		require.Equal(t, []string{customCatalog}, report.L10n.Catalogs)
+
+		src, err := json.Marshal(report)
+		require.NoError(t, err)
+		require.Contains(t, string(src), `"description":{"code":"internal","en":"Internal error"}`)
+
+		report = &model.ProblemReport{Description: model.Code{Code: "unknown"}}
+		registry.Localize(report)
+		require.Empty(t, report.Description.Texts)
+		require.Nil(t, report.L10n)
+	})
+
+	t.Run("problem report message", func(t *testing.T) {
+		report := &model.ProblemReport{Type: "problem-report", Description: model.Code{Code: "internal"}}
+		registry.Localize(report)
+
+		msg := ProblemReportMsg(report)
+		require.Equal(t, "problem-report", msg.Type())
+		require.Equal(t, map[string]interface{}{"code": "internal", "en": "Internal error"}, msg["description"])
+
+		decoded := &model.ProblemReport{}
+		require.NoError(t, msg.Decode(decoded))
+		require.Equal(t, "internal", decoded.Description.Code)
+		require.Equal(t, []string{customCatalog}, decoded.L10n.Catalogs)
+	})
+}
@@ -169,6 +169,34 @@ func (m *Messenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID st
 	return m.dispatcher.SendToDID(out, myDID, theirDID)
 }
 
+// ReplyToMsgDestination replies to the given message at given destination.
+// The function adds ~thread decorator to the message according to the given message.
+// Do not provide a message with ~thread decorator. It will be rewritten.
+func (m *Messenger) ReplyToMsgDestination(in, out service.DIDCommMsgMap, sender string,
+	destination *service.Destination) error {
+	// fills missing fields
+	fillIfMissing(out)
+
+	thID, err := in.ThreadID()
+	if err != nil {
+		return fmt.Errorf("get threadID: %w", err)
+	}
+
+	// sets threadID
+	thread := map[string]interface{}{
+		jsonThreadID: thID,
+	}
+
+	// sets parent threadID
+	if in.ParentThreadID() != "" {
+		thread[jsonParentThreadID] = in.ParentThreadID()
+	}
+
+	out[jsonThread] = thread
+
+	return m.dispatcher.Send(out, sender, destination)
+}
+
 // ReplyToNested sends the message by starting a new thread.
 // Do not provide a message with ~thread decorator. It will be rewritten.
 // The function adds ~thread decorator to the message according to the given threadID.
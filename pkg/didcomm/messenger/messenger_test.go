@@ -410,3 +410,58 @@ func TestMessenger_ReplyToMsg(t *testing.T) {
 		}, service.DIDCommMsgMap{}, "", ""), "get threadID: invalid message")
 	})
 }
+
+func TestMessenger_ReplyToMsgDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		dest := &service.Destination{ServiceEndpoint: "http://example.com"}
+
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().Send(gomock.Any(), "sender", dest).
+			Do(func(msg service.DIDCommMsgMap, _ string, _ *service.Destination) error {
+				require.NotEmpty(t, msg.ID())
+				thID, err := msg.ThreadID()
+				require.NoError(t, err)
+				require.Equal(t, "thID", thID)
+				require.Equal(t, "pthID", msg.ParentThreadID())
+
+				return nil
+			})
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+		provider.EXPECT().OutboundDispatcher().Return(outbound)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+		require.NotNil(t, msgr)
+		require.NoError(t, msgr.ReplyToMsgDestination(service.DIDCommMsgMap{
+			jsonID:     "id",
+			jsonThread: map[string]interface{}{jsonThreadID: "thID", jsonParentThreadID: "pthID"},
+		}, service.DIDCommMsgMap{}, "sender", dest))
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+		provider.EXPECT().OutboundDispatcher().Return(outbound)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+		require.NotNil(t, msgr)
+
+		require.EqualError(t, msgr.ReplyToMsgDestination(service.DIDCommMsgMap{
+			jsonThread: map[string]interface{}{jsonThreadID: "thID"},
+		}, service.DIDCommMsgMap{}, "", &service.Destination{}), "get threadID: invalid message")
+	})
+}
@@ -0,0 +1,338 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// TagName is the tag of the outbox records, it must be part of the configuration of the store of the outbox.
+	TagName = "outbox"
+
+	// DefaultRetryInterval is the default interval between two dispatches of the pending messages.
+	DefaultRetryInterval = 30 * time.Second
+
+	keyPrefix = TagName + "_"
+)
+
+var logger = log.New("aries-framework/didcomm/outbox")
+
+const (
+	kindReplyTo               = "reply_to"
+	kindReplyToMsg            = "reply_to_msg"
+	kindSend                  = "send"
+	kindSendToDestination     = "send_to_destination"
+	kindReplyToMsgDestination = "reply_to_msg_destination"
+	kindReplyToNested         = "reply_to_nested"
+)
+
+// record is an outbound message waiting to be sent, with the arguments of the messenger function sending it.
+type record struct {
+	ID          string                   `json:"id"`
+	Kind        string                   `json:"kind"`
+	MsgID       string                   `json:"msg_id,omitempty"`
+	In          service.DIDCommMsgMap    `json:"in,omitempty"`
+	Out         service.DIDCommMsgMap    `json:"out"`
+	MyDID       string                   `json:"my_did,omitempty"`
+	TheirDID    string                   `json:"their_did,omitempty"`
+	Sender      string                   `json:"sender,omitempty"`
+	Destination *service.Destination     `json:"destination,omitempty"`
+	NestedOpts  *service.NestedReplyOpts `json:"nested_opts,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+}
+
+// Option configures the Outbox.
+type Option func(o *Outbox)
+
+// WithRetryInterval sets the interval between two dispatches of the pending messages, DefaultRetryInterval by
+// default. The messages which failed to be sent are retried after it.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(o *Outbox) {
+		o.interval = interval
+	}
+}
+
+// Outbox persists the outbound messages of a protocol service in the same batch as its state, and dispatches them.
+// The messages which could not be sent (e.g. the agent crashed after saving the state) are sent again by the worker
+// started by Start, so the messages may be sent more than once but are never lost.
+type Outbox struct {
+	store     storage.Store
+	messenger service.Messenger
+	interval  time.Duration
+	now       func() time.Time
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New returns the outbox of the messages sent with messenger, persisted in store. The store is the store of the
+// protocol state, its configuration must include TagName.
+func New(store storage.Store, messenger service.Messenger, opts ...Option) *Outbox {
+	o := &Outbox{
+		store:     store,
+		messenger: messenger,
+		interval:  DefaultRetryInterval,
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// NewRecorder returns a messenger recording the messages instead of sending them.
+func (o *Outbox) NewRecorder() *Recorder {
+	return &Recorder{now: o.now}
+}
+
+// Commit saves the recorded messages with the given operations (e.g. the state change) in one batch, then sends
+// them. The messages failing to be sent are logged and retried by the worker.
+func (o *Outbox) Commit(r *Recorder, operations ...storage.Operation) error {
+	for _, rec := range r.records {
+		src, err := json.Marshal(rec)
+		if err != nil {
+			return fmt.Errorf("marshal outbox record: %w", err)
+		}
+
+		operations = append(operations, storage.Operation{
+			Key:   keyPrefix + rec.ID,
+			Value: src,
+			Tags:  []storage.Tag{{Name: TagName}},
+		})
+	}
+
+	if err := o.store.Batch(operations); err != nil {
+		return fmt.Errorf("save outbox batch: %w", err)
+	}
+
+	for _, rec := range r.records {
+		if err := o.dispatch(rec); err != nil {
+			logger.Warnf("failed to send message %s, it will be retried: %s", rec.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Dispatch sends the pending messages recorded before the retry interval and returns the number of messages sent.
+// The more recent messages are being sent by Commit.
+func (o *Outbox) Dispatch() (int, error) {
+	iter, err := o.store.Query(TagName)
+	if err != nil {
+		return 0, fmt.Errorf("query outbox records: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var records []*record
+
+	more, err := iter.Next()
+
+	for ; err == nil && more; more, err = iter.Next() {
+		src, errValue := iter.Value()
+		if errValue != nil {
+			return 0, fmt.Errorf("get outbox record: %w", errValue)
+		}
+
+		rec := &record{}
+		if errValue = json.Unmarshal(src, rec); errValue != nil {
+			return 0, fmt.Errorf("unmarshal outbox record: %w", errValue)
+		}
+
+		if o.now().Sub(rec.CreatedAt) >= o.interval {
+			records = append(records, rec)
+		}
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("iterate outbox records: %w", err)
+	}
+
+	sent := 0
+
+	for _, rec := range records {
+		if err = o.dispatch(rec); err != nil {
+			logger.Warnf("failed to send message %s, it will be retried: %s", rec.ID, err)
+
+			continue
+		}
+
+		sent++
+	}
+
+	return sent, nil
+}
+
+// Start dispatches the pending messages at every retry interval until Stop is called.
+func (o *Outbox) Start() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.stop != nil {
+		return
+	}
+
+	o.stop = make(chan struct{})
+	o.done = make(chan struct{})
+
+	go o.run(o.stop, o.done)
+}
+
+// Stop stops the periodic dispatch and waits for the dispatch in progress to complete.
+func (o *Outbox) Stop() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.stop == nil {
+		return
+	}
+
+	close(o.stop)
+	<-o.done
+
+	o.stop = nil
+	o.done = nil
+}
+
+func (o *Outbox) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			sent, err := o.Dispatch()
+			if err != nil {
+				logger.Errorf("outbox dispatch failed: %v", err)
+			}
+
+			if sent > 0 {
+				logger.Infof("sent %d pending messages", sent)
+			}
+		}
+	}
+}
+
+// dispatch sends the message and removes its record.
+func (o *Outbox) dispatch(rec *record) error {
+	var err error
+
+	switch rec.Kind {
+	case kindReplyTo:
+		err = o.messenger.ReplyTo(rec.MsgID, rec.Out) // nolint:staticcheck // recorded from the deprecated function
+	case kindReplyToMsg:
+		err = o.messenger.ReplyToMsg(rec.In, rec.Out, rec.MyDID, rec.TheirDID)
+	case kindSend:
+		err = o.messenger.Send(rec.Out, rec.MyDID, rec.TheirDID)
+	case kindSendToDestination:
+		err = o.messenger.SendToDestination(rec.Out, rec.Sender, rec.Destination)
+	case kindReplyToMsgDestination:
+		err = o.messenger.ReplyToMsgDestination(rec.In, rec.Out, rec.Sender, rec.Destination)
+	case kindReplyToNested:
+		err = o.messenger.ReplyToNested(rec.Out, rec.NestedOpts)
+	default:
+		err = fmt.Errorf("unknown outbox record kind %s", rec.Kind)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = o.store.Delete(keyPrefix + rec.ID); err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("delete outbox record: %w", err)
+	}
+
+	return nil
+}
+
+// Recorder is a service.Messenger recording the messages to be committed with Outbox.Commit.
+type Recorder struct {
+	records []*record
+	now     func() time.Time
+}
+
+// ReplyTo records the reply to the message by given msgID.
+//
+// Deprecated: Please do not use it anymore. The function can be removed in future release.
+func (r *Recorder) ReplyTo(msgID string, msg service.DIDCommMsgMap) error {
+	return r.record(&record{Kind: kindReplyTo, MsgID: msgID, Out: msg})
+}
+
+// ReplyToMsg records the reply to the given message.
+func (r *Recorder) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID string) error {
+	return r.record(&record{Kind: kindReplyToMsg, In: in, Out: out, MyDID: myDID, TheirDID: theirDID})
+}
+
+// Send records the message starting a new thread.
+func (r *Recorder) Send(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	return r.record(&record{Kind: kindSend, Out: msg, MyDID: myDID, TheirDID: theirDID})
+}
+
+// SendToDestination records the message sent to given destination.
+func (r *Recorder) SendToDestination(msg service.DIDCommMsgMap, sender string, destination *service.Destination) error {
+	return r.record(&record{Kind: kindSendToDestination, Out: msg, Sender: sender, Destination: destination})
+}
+
+// ReplyToMsgDestination records the reply to the given message at given destination.
+func (r *Recorder) ReplyToMsgDestination(in, out service.DIDCommMsgMap, sender string,
+	destination *service.Destination) error {
+	return r.record(&record{
+		Kind: kindReplyToMsgDestination, In: in, Out: out, Sender: sender, Destination: destination,
+	})
+}
+
+// ReplyToNested records the message starting a new thread with the parent thread ID.
+func (r *Recorder) ReplyToNested(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+	return r.record(&record{Kind: kindReplyToNested, Out: msg, NestedOpts: opts})
+}
+
+// record keeps a copy of the messages, as they are sent after the recording.
+func (r *Recorder) record(rec *record) error {
+	rec.ID = uuid.New().String()
+	rec.CreatedAt = r.now()
+
+	for _, msg := range []*service.DIDCommMsgMap{&rec.In, &rec.Out} {
+		if *msg == nil {
+			continue
+		}
+
+		src, err := json.Marshal(*msg)
+		if err != nil {
+			return fmt.Errorf("marshal message: %w", err)
+		}
+
+		var msgCopy service.DIDCommMsgMap
+
+		if err = json.Unmarshal(src, &msgCopy); err != nil {
+			return fmt.Errorf("unmarshal message: %w", err)
+		}
+
+		*msg = msgCopy
+	}
+
+	r.records = append(r.records, rec)
+
+	return nil
+}
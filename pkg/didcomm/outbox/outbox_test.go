@@ -0,0 +1,254 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func newStore(t *testing.T) storage.Store {
+	t.Helper()
+
+	provider := mem.NewProvider()
+
+	store, err := provider.OpenStore("test")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SetStoreConfig("test", storage.StoreConfiguration{TagNames: []string{TagName}}))
+
+	return store
+}
+
+func pending(t *testing.T, store storage.Store) int {
+	t.Helper()
+
+	iter, err := store.Query(TagName)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, iter.Close())
+	}()
+
+	n := 0
+
+	more, err := iter.Next()
+	for ; err == nil && more; more, err = iter.Next() {
+		n++
+	}
+
+	require.NoError(t, err)
+
+	return n
+}
+
+func TestOutbox_Commit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	in := service.DIDCommMsgMap{"@id": "in"}
+	msg := service.DIDCommMsgMap{"@id": "out"}
+	dest := &service.Destination{ServiceEndpoint: "http://example.com"}
+	nested := &service.NestedReplyOpts{ThreadID: "thid"}
+
+	t.Run("saves the state and sends the messages", func(t *testing.T) {
+		store := newStore(t)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo("msgID", gomock.Any()).Return(nil)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), "myDID", "theirDID").Return(nil)
+		messenger.EXPECT().Send(gomock.Any(), "myDID", "theirDID").Return(nil)
+		messenger.EXPECT().SendToDestination(gomock.Any(), "sender", dest).Return(nil)
+		messenger.EXPECT().ReplyToMsgDestination(gomock.Any(), gomock.Any(), "sender", dest).Return(nil)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), nested).Return(nil)
+
+		o := New(store, messenger)
+
+		recorder := o.NewRecorder()
+		require.NoError(t, recorder.ReplyTo("msgID", msg)) // nolint:staticcheck // recorded too
+		require.NoError(t, recorder.ReplyToMsg(in, msg, "myDID", "theirDID"))
+		require.NoError(t, recorder.Send(msg, "myDID", "theirDID"))
+		require.NoError(t, recorder.SendToDestination(msg, "sender", dest))
+		require.NoError(t, recorder.ReplyToMsgDestination(in, msg, "sender", dest))
+		require.NoError(t, recorder.ReplyToNested(msg, nested))
+
+		require.NoError(t, o.Commit(recorder, storage.Operation{Key: "state", Value: []byte("done")}))
+
+		state, err := store.Get("state")
+		require.NoError(t, err)
+		require.Equal(t, "done", string(state))
+		require.Equal(t, 0, pending(t, store))
+	})
+
+	t.Run("keeps the message failing to be sent", func(t *testing.T) {
+		store := newStore(t)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), "myDID", "theirDID").Return(errors.New("unreachable"))
+
+		o := New(store, messenger)
+
+		recorder := o.NewRecorder()
+		require.NoError(t, recorder.Send(msg, "myDID", "theirDID"))
+
+		require.NoError(t, o.Commit(recorder, storage.Operation{Key: "state", Value: []byte("done")}))
+		require.Equal(t, 1, pending(t, store))
+	})
+
+	t.Run("records a copy of the message", func(t *testing.T) {
+		store := newStore(t)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), "myDID", "theirDID").DoAndReturn(
+			func(msg service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, "out", msg.ID())
+
+				return nil
+			})
+
+		o := New(store, messenger)
+
+		out := service.DIDCommMsgMap{"@id": "out"}
+
+		recorder := o.NewRecorder()
+		require.NoError(t, recorder.Send(out, "myDID", "theirDID"))
+
+		out["@id"] = "changed"
+
+		require.NoError(t, o.Commit(recorder))
+	})
+
+	t.Run("batch error", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry), ErrBatch: errors.New("batch")}
+
+		o := New(store, serviceMocks.NewMockMessenger(ctrl))
+
+		recorder := o.NewRecorder()
+		require.NoError(t, recorder.Send(msg, "myDID", "theirDID"))
+
+		err := o.Commit(recorder)
+		require.EqualError(t, err, "save outbox batch: batch")
+	})
+}
+
+func TestOutbox_Dispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msg := service.DIDCommMsgMap{"@id": "out"}
+
+	t.Run("resends the messages older than the retry interval", func(t *testing.T) {
+		store := newStore(t)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), "myDID", "theirDID").Return(errors.New("unreachable"))
+
+		o := New(store, messenger, WithRetryInterval(time.Minute))
+
+		now := time.Now()
+		o.now = func() time.Time { return now }
+
+		recorder := o.NewRecorder()
+		require.NoError(t, recorder.Send(msg, "myDID", "theirDID"))
+		require.NoError(t, o.Commit(recorder))
+
+		sent, err := o.Dispatch()
+		require.NoError(t, err)
+		require.Equal(t, 0, sent)
+
+		now = now.Add(time.Minute)
+
+		messenger.EXPECT().Send(gomock.Any(), "myDID", "theirDID").Return(errors.New("unreachable"))
+
+		sent, err = o.Dispatch()
+		require.NoError(t, err)
+		require.Equal(t, 0, sent)
+		require.Equal(t, 1, pending(t, store))
+
+		messenger.EXPECT().Send(gomock.Any(), "myDID", "theirDID").Return(nil)
+
+		sent, err = o.Dispatch()
+		require.NoError(t, err)
+		require.Equal(t, 1, sent)
+		require.Equal(t, 0, pending(t, store))
+	})
+
+	t.Run("invalid record", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, store.Put(keyPrefix+"id", []byte("{"), storage.Tag{Name: TagName}))
+
+		_, err := New(store, serviceMocks.NewMockMessenger(ctrl)).Dispatch()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal outbox record")
+	})
+
+	t.Run("unknown record kind", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, store.Put(keyPrefix+"id", []byte(`{"id":"id","kind":"unknown"}`),
+			storage.Tag{Name: TagName}))
+
+		sent, err := New(store, serviceMocks.NewMockMessenger(ctrl)).Dispatch()
+		require.NoError(t, err)
+		require.Equal(t, 0, sent)
+		require.Equal(t, 1, pending(t, store))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry), ErrQuery: errors.New("query")}
+
+		_, err := New(store, serviceMocks.NewMockMessenger(ctrl)).Dispatch()
+		require.EqualError(t, err, "query outbox records: query")
+	})
+}
+
+func TestOutbox_StartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := newStore(t)
+	msg := service.DIDCommMsgMap{"@id": "out"}
+	sent := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().Send(gomock.Any(), "myDID", "theirDID").Return(errors.New("unreachable"))
+	messenger.EXPECT().Send(gomock.Any(), "myDID", "theirDID").DoAndReturn(
+		func(service.DIDCommMsgMap, string, string) error {
+			close(sent)
+
+			return nil
+		})
+
+	o := New(store, messenger, WithRetryInterval(time.Millisecond))
+
+	recorder := o.NewRecorder()
+	require.NoError(t, recorder.Send(msg, "myDID", "theirDID"))
+	require.NoError(t, o.Commit(recorder))
+
+	o.Start()
+	o.Start()
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		require.Fail(t, "the pending message was not sent")
+	}
+
+	o.Stop()
+	o.Stop()
+
+	require.Equal(t, 0, pending(t, store))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/signed"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -393,6 +394,68 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 	})
 }
 
+func TestPackager_SignedMessage(t *testing.T) {
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	customKMS, err := localkms.New("local-lock://test/key-uri/",
+		newMockKMSProvider(mockstorage.NewMockStoreProvider()))
+	require.NoError(t, err)
+
+	mockedProviders := &mockProvider{
+		storage: mockstorage.NewMockStoreProvider(),
+		kms:     customKMS,
+		crypto:  cryptoSvc,
+	}
+
+	legacyPacker := legacy.New(mockedProviders)
+	mockedProviders.primaryPacker = legacyPacker
+
+	_, fromKey, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	t.Run("signed packer is not registered", func(t *testing.T) {
+		packager, err := New(mockedProviders)
+		require.NoError(t, err)
+
+		_, err = packager.PackMessage(&transport.Envelope{
+			MediaType: transport.MediaTypeV2SignedMessage,
+			Message:   []byte("msg"),
+			FromKey:   fromKey,
+		})
+		require.EqualError(t, err, "packMessage: signed packer is not registered")
+	})
+
+	t.Run("test Pack/Unpack success", func(t *testing.T) {
+		signedPacker, err := signed.New(mockedProviders)
+		require.NoError(t, err)
+
+		mockedProviders.packers = []packer.Packer{legacyPacker, signedPacker}
+
+		packager, err := New(mockedProviders)
+		require.NoError(t, err)
+
+		packMsg, err := packager.PackMessage(&transport.Envelope{
+			MediaType: transport.MediaTypeV2SignedMessage,
+			Message:   []byte(`{"msg":"signed"}`),
+			FromKey:   fromKey,
+		})
+		require.NoError(t, err)
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, transport.MediaTypeV2SignedMessage, unpackedMsg.MediaType)
+		require.Equal(t, []byte(`{"msg":"signed"}`), unpackedMsg.Message)
+		require.Equal(t, fromKey, unpackedMsg.FromKey)
+
+		_, err = packager.PackMessage(&transport.Envelope{
+			MediaType: transport.MediaTypeV2SignedMessage,
+			Message:   []byte(`{"msg":"signed"}`),
+		})
+		require.EqualError(t, err, "packMessage: failed to sign: signed Pack: empty senderKey")
+	})
+}
+
 func newMockKMSProvider(storagePvdr *mockstorage.MockStoreProvider) *mockProvider {
 	return &mockProvider{storagePvdr, nil, &noop.NoLock{}, nil, nil, nil, nil}
 }
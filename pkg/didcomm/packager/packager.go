@@ -105,6 +105,22 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 		recipients = append(recipients, verKeyBytes)
 	}
 
+	// signed envelopes are not addressed to the recipients, they are packed by the signed packer (if registered)
+	if messageEnvelope.MediaType == transport.MediaTypeV2SignedMessage {
+		signedPacker, ok := bp.packers[transport.MediaTypeV2SignedMessage]
+		if !ok {
+			return nil, errors.New("packMessage: signed packer is not registered")
+		}
+
+		bytes, err := signedPacker.Pack(transport.MediaTypeV1PlaintextPayload, messageEnvelope.Message,
+			messageEnvelope.FromKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("packMessage: failed to sign: %w", err)
+		}
+
+		return bytes, nil
+	}
+
 	// TODO since o.packager's primary packer is LegacyPacker, CTY Envelope field is ignored. When DIDComm V2 is
 	//  is used, make sure it's set here or passed in by the caller and remove below hard coded cty variable. The
 	//  JWE packers will add it to the Protected Headers of the envelope if it's set.
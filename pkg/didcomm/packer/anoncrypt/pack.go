@@ -8,6 +8,7 @@ package anoncrypt
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
@@ -23,6 +24,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did/keyagreement"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -39,6 +41,7 @@ type Packer struct {
 	kms           kms.KeyManager
 	encAlg        jose.EncAlg
 	cryptoService cryptoapi.Crypto
+	keyCache      *packer.KeyCache
 }
 
 // New will create an Packer instance to 'AnonCrypt' payloads for a given list of recipients.
@@ -58,6 +61,7 @@ func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
 		kms:           k,
 		encAlg:        encAlg,
 		cryptoService: c,
+		keyCache:      packer.NewKeyCache(packer.DefaultKeyCacheSize),
 	}, nil
 }
 
@@ -69,7 +73,7 @@ func (p *Packer) Pack(contentType string, payload, _ []byte, recipientsPubKeys [
 		return nil, fmt.Errorf("anoncrypt Pack: empty recipientsPubKeys")
 	}
 
-	recECKeys, aad, err := unmarshalRecipientKeys(recipientsPubKeys)
+	recECKeys, aad, err := p.unmarshalRecipientKeys(recipientsPubKeys)
 	if err != nil {
 		return nil, fmt.Errorf("anoncrypt Pack: failed to convert recipient keys: %w", err)
 	}
@@ -107,7 +111,7 @@ func (p *Packer) Pack(contentType string, payload, _ []byte, recipientsPubKeys [
 	return []byte(s), nil
 }
 
-func unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, []byte, error) {
+func (p *Packer) unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, []byte, error) {
 	var (
 		pubKeys []*cryptoapi.PublicKey
 		kids    []string
@@ -115,9 +119,7 @@ func unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, []byte, erro
 	)
 
 	for _, key := range keys {
-		var ecKey *cryptoapi.PublicKey
-
-		err := json.Unmarshal(key, &ecKey)
+		ecKey, err := p.recipientKey(key)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -141,6 +143,45 @@ func unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, []byte, erro
 	return pubKeys, aad, nil
 }
 
+// recipientKey returns the unmarshalled recipient key, from the cache if it was already unmarshalled.
+func (p *Packer) recipientKey(key []byte) (*cryptoapi.PublicKey, error) {
+	ecKey, err := p.keyCache.Get(key, func() (interface{}, error) {
+		return unmarshalRecipientKey(key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ecKey.(*cryptoapi.PublicKey), nil
+}
+
+// InvalidateRecipientKey removes the unmarshalled recipient key from the cache.
+func (p *Packer) InvalidateRecipientKey(recipientKey []byte) {
+	p.keyCache.Invalidate(recipientKey)
+}
+
+// PurgeRecipientKeys removes all the unmarshalled recipient keys from the cache.
+func (p *Packer) PurgeRecipientKeys() {
+	p.keyCache.Purge()
+}
+
+// unmarshalRecipientKey unmarshals the recipient key. Raw Ed25519 public keys of legacy DID docs lacking
+// keyAgreement entries are converted to their X25519 key agreement keys.
+func unmarshalRecipientKey(key []byte) (*cryptoapi.PublicKey, error) {
+	if len(key) == ed25519.PublicKeySize {
+		return keyagreement.RecipientKeyFromEd25519(key)
+	}
+
+	var ecKey *cryptoapi.PublicKey
+
+	err := json.Unmarshal(key, &ecKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return ecKey, nil
+}
+
 // Unpack will decode the envelope using a standard format.
 func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 	jwe, mediaType, err := getJWEAndMediaType(envelope)
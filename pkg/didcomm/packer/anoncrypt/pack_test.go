@@ -31,6 +31,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did/keyagreement"
 	afgjose "github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -272,6 +273,39 @@ func TestAnoncryptPackerSuccessWithDifferentCurvesSuccess(t *testing.T) {
 	}, msg)
 }
 
+func TestAnoncryptPackerEd25519RecipientSuccess(t *testing.T) {
+	k := createKMS(t)
+
+	edKID, edPubKey, err := k.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, _, err = k.ConvertEd25519ToX25519(edKID)
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	anonPacker, err := New(newMockProvider(k, cryptoSvc), afgjose.XC20P)
+	require.NoError(t, err)
+
+	origMsg := []byte("secret message")
+
+	// raw Ed25519 key of legacy DID doc is converted to X25519 key agreement key when packing
+	ct, err := anonPacker.Pack(transport.MediaTypeV1PlaintextPayload, origMsg, nil, [][]byte{edPubKey})
+	require.NoError(t, err)
+
+	msg, err := anonPacker.Unpack(ct)
+	require.NoError(t, err)
+	require.Equal(t, origMsg, msg.Message)
+
+	x25519PubKey, err := keyagreement.PublicKeyFromEd25519(edPubKey)
+	require.NoError(t, err)
+
+	recKey := &cryptoapi.PublicKey{}
+	require.NoError(t, json.Unmarshal(msg.ToKey, recKey))
+	require.Equal(t, x25519PubKey, recKey.X)
+}
+
 func TestAnoncryptPackerFail(t *testing.T) {
 	cty := transport.MediaTypeV1PlaintextPayload
 
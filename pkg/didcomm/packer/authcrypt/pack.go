@@ -47,6 +47,7 @@ type Packer struct {
 	encAlg        jose.EncAlg
 	thirdPartyKS  storage.Store
 	cryptoService cryptoapi.Crypto
+	keyCache      *packer.KeyCache
 }
 
 // New will create a Packer instance to 'AuthCrypt' payloads for a given sender and list of recipients keys using
@@ -86,6 +87,7 @@ func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
 		encAlg:        encAlg,
 		thirdPartyKS:  store,
 		cryptoService: c,
+		keyCache:      packer.NewKeyCache(packer.DefaultKeyCacheSize),
 	}, nil
 }
 
@@ -100,7 +102,7 @@ func (p *Packer) Pack(contentType string, payload, senderID []byte, recipientsPu
 		return nil, fmt.Errorf("authcrypt Pack: empty recipientsPubKeys")
 	}
 
-	recECKeys, aad, err := unmarshalRecipientKeys(recipientsPubKeys)
+	recECKeys, aad, err := p.unmarshalRecipientKeys(recipientsPubKeys)
 	if err != nil {
 		return nil, fmt.Errorf("authcrypt Pack: failed to convert recipient keys: %w", err)
 	}
@@ -143,7 +145,7 @@ func (p *Packer) Pack(contentType string, payload, senderID []byte, recipientsPu
 	return []byte(s), nil
 }
 
-func unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, []byte, error) {
+func (p *Packer) unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, []byte, error) {
 	var (
 		pubKeys []*cryptoapi.PublicKey
 		kids    []string
@@ -151,9 +153,7 @@ func unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, []byte, erro
 	)
 
 	for _, key := range keys {
-		var ecKey *cryptoapi.PublicKey
-
-		err := json.Unmarshal(key, &ecKey)
+		ecKey, err := p.recipientKey(key)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -177,6 +177,35 @@ func unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, []byte, erro
 	return pubKeys, aad, nil
 }
 
+// recipientKey returns the unmarshalled recipient key, from the cache if it was already unmarshalled.
+func (p *Packer) recipientKey(key []byte) (*cryptoapi.PublicKey, error) {
+	ecKey, err := p.keyCache.Get(key, func() (interface{}, error) {
+		var ecKey *cryptoapi.PublicKey
+
+		err := json.Unmarshal(key, &ecKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return ecKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ecKey.(*cryptoapi.PublicKey), nil
+}
+
+// InvalidateRecipientKey removes the unmarshalled recipient key from the cache.
+func (p *Packer) InvalidateRecipientKey(recipientKey []byte) {
+	p.keyCache.Invalidate(recipientKey)
+}
+
+// PurgeRecipientKeys removes all the unmarshalled recipient keys from the cache.
+func (p *Packer) PurgeRecipientKeys() {
+	p.keyCache.Purge()
+}
+
 // Unpack will decode the envelope using a standard format.
 func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 	jwe, mediaType, err := getJWEAndMediaType(envelope)
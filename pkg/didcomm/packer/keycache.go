@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packer
+
+import (
+	"github.com/bluele/gcache"
+)
+
+// DefaultKeyCacheSize is the default maximum number of recipients whose derived keys are cached by the packers.
+const DefaultKeyCacheSize = 1000
+
+// RecipientKeyCache is implemented by the packers caching the keys derived from the recipient keys (e.g. X25519
+// keys converted from Ed25519 keys), so that they are not derived again for every message packed.
+// The cached keys must be invalidated when the DID document of the recipient changes.
+type RecipientKeyCache interface {
+	// InvalidateRecipientKey removes the keys derived from the recipient key from the cache.
+	InvalidateRecipientKey(recipientKey []byte)
+	// PurgeRecipientKeys removes all the derived keys from the cache.
+	PurgeRecipientKeys()
+}
+
+// KeyCache caches the keys derived from the recipient keys, the least recently used are evicted first.
+// It is safe for concurrent use.
+type KeyCache struct {
+	cache gcache.Cache
+}
+
+// NewKeyCache returns a new KeyCache of the given size (DefaultKeyCacheSize if not positive).
+func NewKeyCache(size int) *KeyCache {
+	if size <= 0 {
+		size = DefaultKeyCacheSize
+	}
+
+	return &KeyCache{cache: gcache.New(size).LRU().Build()}
+}
+
+// Get returns the key derived from the recipient key, derive is called on a cache miss.
+// Derivation errors are not cached.
+func (c *KeyCache) Get(recipientKey []byte, derive func() (interface{}, error)) (interface{}, error) {
+	if derived, err := c.cache.Get(string(recipientKey)); err == nil {
+		return derived, nil
+	}
+
+	derived, err := derive()
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint:errcheck // gcache.Set fails for nil keys only
+	c.cache.Set(string(recipientKey), derived)
+
+	return derived, nil
+}
+
+// Invalidate removes the key derived from the recipient key.
+func (c *KeyCache) Invalidate(recipientKey []byte) {
+	c.cache.Remove(string(recipientKey))
+}
+
+// Purge removes all the derived keys.
+func (c *KeyCache) Purge() {
+	c.cache.Purge()
+}
@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyCache(t *testing.T) {
+	recKey := []byte("recipient key")
+
+	t.Run("derives the key once", func(t *testing.T) {
+		c := NewKeyCache(0)
+		calls := 0
+
+		derive := func() (interface{}, error) {
+			calls++
+
+			return []byte("derived key"), nil
+		}
+
+		for i := 0; i < 3; i++ {
+			derived, err := c.Get(recKey, derive)
+			require.NoError(t, err)
+			require.Equal(t, []byte("derived key"), derived)
+		}
+
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("does not cache derivation errors", func(t *testing.T) {
+		c := NewKeyCache(1)
+
+		_, err := c.Get(recKey, func() (interface{}, error) {
+			return nil, errors.New("derive error")
+		})
+		require.EqualError(t, err, "derive error")
+
+		derived, err := c.Get(recKey, func() (interface{}, error) {
+			return "derived key", nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "derived key", derived)
+	})
+
+	t.Run("invalidate and purge", func(t *testing.T) {
+		c := NewKeyCache(10)
+		calls := 0
+
+		derive := func() (interface{}, error) {
+			calls++
+
+			return calls, nil
+		}
+
+		_, err := c.Get(recKey, derive)
+		require.NoError(t, err)
+
+		c.Invalidate(recKey)
+
+		derived, err := c.Get(recKey, derive)
+		require.NoError(t, err)
+		require.Equal(t, 2, derived)
+
+		c.Purge()
+
+		derived, err = c.Get(recKey, derive)
+		require.NoError(t, err)
+		require.Equal(t, 3, derived)
+	})
+}
@@ -7,9 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package authcrypt
 
 import (
-	"crypto/rand"
 	"io"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
@@ -18,6 +18,7 @@ import (
 type Packer struct {
 	randSource io.Reader
 	kms        kms.KeyManager
+	keyCache   *packer.KeyCache
 }
 
 // encodingType is the `typ` string identifier in a message that identifies the format as being legacy.
@@ -29,8 +30,9 @@ func New(ctx packer.Provider) *Packer {
 	k := ctx.KMS()
 
 	return &Packer{
-		randSource: rand.Reader,
+		randSource: random.Reader(),
 		kms:        k,
+		keyCache:   packer.NewKeyCache(packer.DefaultKeyCacheSize),
 	}
 }
 
@@ -63,6 +65,16 @@ type recipientHeader struct {
 	IV     string `json:"iv,omitempty"`
 }
 
+// InvalidateRecipientKey removes the Curve25519 key converted from the recipient key from the cache.
+func (p *Packer) InvalidateRecipientKey(recipientKey []byte) {
+	p.keyCache.Invalidate(recipientKey)
+}
+
+// PurgeRecipientKeys removes all the Curve25519 keys converted from the recipient keys from the cache.
+func (p *Packer) PurgeRecipientKeys() {
+	p.keyCache.Purge()
+}
+
 // EncodingType returns the type of the encoding, as in the `Typ` field of the envelope header.
 func (p *Packer) EncodingType() string {
 	return encodingType
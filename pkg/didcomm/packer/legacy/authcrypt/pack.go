@@ -130,7 +130,7 @@ func (p *Packer) buildRecipient(cek *[chacha.KeySize]byte, senderKey, recKey []b
 		return nil, fmt.Errorf("buildRecipient: failed to create KID for public key: %w", err)
 	}
 
-	recEncKey, err := cryptoutil.PublicEd25519toCurve25519(recKey)
+	recEncKey, err := p.recipientEncKey(recKey)
 	if err != nil {
 		return nil, fmt.Errorf("buildRecipient: failed to convert public Ed25519 to Curve25519: %w", err)
 	}
@@ -161,6 +161,19 @@ func (p *Packer) buildRecipient(cek *[chacha.KeySize]byte, senderKey, recKey []b
 	}, nil
 }
 
+// recipientEncKey returns the Curve25519 key converted from the Ed25519 recipient key, from the cache if it was
+// already converted.
+func (p *Packer) recipientEncKey(recKey []byte) ([]byte, error) {
+	recEncKey, err := p.keyCache.Get(recKey, func() (interface{}, error) {
+		return cryptoutil.PublicEd25519toCurve25519(recKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recEncKey.([]byte), nil
+}
+
 func newCryptoBox(manager kms.KeyManager) (kms.CryptoBox, error) {
 	switch manager.(type) {
 	case *localkms.LocalKMS:
@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package signed
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"strings"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmssigner"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+// Package signed includes a Packer implementation to build and parse signed (but not encrypted) DIDComm messages
+// as per https://identity.foundation/didcomm-messaging/spec/#didcomm-signed-message. The payload is readable by
+// anyone, the signature authenticates the sender. It is meant for public, non-confidential messages (e.g. broadcast
+// out-of-band invitations).
+
+// algEdDSA is the JWS `alg` of the envelopes signed with Ed25519 keys.
+const algEdDSA = "EdDSA"
+
+// Packer represents a signed envelope Pack/Unpacker. The envelopes are JWS in compact serialization.
+type Packer struct {
+	kms           kms.KeyManager
+	cryptoService cryptoapi.Crypto
+}
+
+// New will create a Packer instance to sign payloads with the keys of the KMS.
+func New(ctx packer.Provider) (*Packer, error) {
+	k := ctx.KMS()
+	if k == nil {
+		return nil, errors.New("signed: failed to create packer because KMS is empty")
+	}
+
+	c := ctx.Crypto()
+	if c == nil {
+		return nil, errors.New("signed: failed to create packer because crypto service is empty")
+	}
+
+	return &Packer{
+		kms:           k,
+		cryptoService: c,
+	}, nil
+}
+
+// Pack will sign the payload with the sender key (raw Ed25519 public key of the KMS key). The recipients argument is
+// ignored since the signed envelope is not addressed to anyone, it's added to meet the Packer interface.
+// The `kid` protected header is the did:key URL of the sender key.
+func (p *Packer) Pack(contentType string, payload, senderKey []byte, _ [][]byte) ([]byte, error) {
+	if len(senderKey) == 0 {
+		return nil, errors.New("signed Pack: empty senderKey")
+	}
+
+	kid, err := localkms.CreateKID(senderKey, kms.ED25519Type)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to create KID for sender key: %w", err)
+	}
+
+	signer, err := kmssigner.NewKMSSigner(p.kms, p.cryptoService, kid)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to get sender key from kms: %w", err)
+	}
+
+	_, keyID := fingerprint.CreateDIDKey(senderKey)
+
+	headers := jose.Headers{
+		jose.HeaderType:  p.EncodingType(),
+		jose.HeaderKeyID: keyID,
+	}
+
+	if contentType != "" {
+		headers[jose.HeaderContentType] = contentType
+	}
+
+	jws, err := jose.NewJWS(headers, nil, payload,
+		jose.NewSigner(signer, jose.Headers{jose.HeaderAlgorithm: algEdDSA}))
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to sign payload: %w", err)
+	}
+
+	s, err := jws.SerializeCompact(false)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to serialize JWS: %w", err)
+	}
+
+	return []byte(s), nil
+}
+
+// Unpack will verify the signature of the envelope with the key referenced by its `kid` protected header.
+// The returned envelope has FromKey set to the raw public key of the sender, ToKey is empty.
+func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
+	var senderKey []byte
+
+	jws, err := jose.ParseJWS(string(envelope), jose.SignatureVerifierFunc(
+		func(headers jose.Headers, _, signingInput, signature []byte) error {
+			key, err := verify(headers, signingInput, signature)
+			if err != nil {
+				return err
+			}
+
+			senderKey = key
+
+			return nil
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("signed Unpack: %w", err)
+	}
+
+	typ, _ := jws.ProtectedHeaders.Type()
+	if typ != p.EncodingType() {
+		return nil, fmt.Errorf("signed Unpack: unsupported envelope type: %s", typ)
+	}
+
+	return &transport.Envelope{
+		MediaType: typ,
+		Message:   jws.Payload,
+		FromKey:   senderKey,
+	}, nil
+}
+
+// verify verifies the signature and returns the public key the envelope was signed with.
+func verify(headers jose.Headers, signingInput, signature []byte) ([]byte, error) {
+	alg, _ := headers.Algorithm()
+	if alg != algEdDSA {
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", alg)
+	}
+
+	kid, ok := headers.KeyID()
+	if !ok {
+		return nil, errors.New("missing 'kid' protected header")
+	}
+
+	// the key ID is the did:key URL, e.g. did:key:z6Mk...#z6Mk...
+	pubKey, err := fingerprint.PubKeyFromDIDKey(strings.Split(kid, "#")[0])
+	if err != nil {
+		return nil, fmt.Errorf("resolve sender key: %w", err)
+	}
+
+	if len(pubKey) != ed25519.PublicKeySize || !ed25519.Verify(pubKey, signingInput, signature) {
+		return nil, errors.New("invalid signature")
+	}
+
+	return pubKey, nil
+}
+
+// EncodingType for didcomm.
+func (p *Packer) EncodingType() string {
+	return transport.MediaTypeV2SignedMessage
+}
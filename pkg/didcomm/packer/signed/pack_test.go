@@ -0,0 +1,188 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package signed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(newMockProvider(nil, &tinkcrypto.Crypto{}))
+	require.EqualError(t, err, "signed: failed to create packer because KMS is empty")
+
+	_, err = New(newMockProvider(&mockkms.KeyManager{}, nil))
+	require.EqualError(t, err, "signed: failed to create packer because crypto service is empty")
+}
+
+func TestSignedPackerSuccess(t *testing.T) {
+	k := createKMS(t)
+
+	_, senderKey, err := k.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	sender, err := New(newMockProvider(k, cryptoSvc))
+	require.NoError(t, err)
+	require.Equal(t, transport.MediaTypeV2SignedMessage, sender.EncodingType())
+
+	payload := []byte(`{"@type":"https://didcomm.org/out-of-band/1.0/invitation"}`)
+
+	envelope, err := sender.Pack(transport.MediaTypeV1PlaintextPayload, payload, senderKey, nil)
+	require.NoError(t, err)
+	require.True(t, jose.IsCompactJWS(string(envelope)))
+
+	// any agent can unpack the envelope, the key of the sender is not needed
+	recipient, err := New(newMockProvider(createKMS(t), cryptoSvc))
+	require.NoError(t, err)
+
+	result, err := recipient.Unpack(envelope)
+	require.NoError(t, err)
+	require.Equal(t, transport.MediaTypeV2SignedMessage, result.MediaType)
+	require.Equal(t, payload, result.Message)
+	require.Equal(t, senderKey, result.FromKey)
+	require.Empty(t, result.ToKey)
+}
+
+func TestSignedPackerFail(t *testing.T) {
+	k := createKMS(t)
+
+	_, senderKey, err := k.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	p, err := New(newMockProvider(k, cryptoSvc))
+	require.NoError(t, err)
+
+	t.Run("pack errors", func(t *testing.T) {
+		_, err = p.Pack("", []byte("{}"), nil, nil)
+		require.EqualError(t, err, "signed Pack: empty senderKey")
+
+		_, err = p.Pack("", []byte("{}"), []byte("unknown key"), nil)
+		require.Contains(t, err.Error(), "signed Pack: failed to get sender key from kms")
+
+		failing, err := New(newMockProvider(k, &failingCrypto{Crypto: cryptoSvc}))
+		require.NoError(t, err)
+
+		_, err = failing.Pack("", []byte("{}"), senderKey, nil)
+		require.Contains(t, err.Error(), "signed Pack: failed to sign payload")
+	})
+
+	t.Run("unpack errors", func(t *testing.T) {
+		envelope, err := p.Pack("", []byte("{}"), senderKey, nil)
+		require.NoError(t, err)
+
+		parts := strings.Split(string(envelope), ".")
+
+		_, err = p.Unpack([]byte(parts[0] + "." + parts[1] + ".c2lnbmF0dXJl"))
+		require.EqualError(t, err, "signed Unpack: invalid signature")
+
+		_, err = p.Unpack([]byte("{}"))
+		require.Contains(t, err.Error(), "signed Unpack")
+
+		_, err = p.Unpack(signWith(t, jose.Headers{jose.HeaderAlgorithm: "ES256"}))
+		require.EqualError(t, err, "signed Unpack: unsupported signature algorithm: ES256")
+
+		_, err = p.Unpack(signWith(t, jose.Headers{jose.HeaderAlgorithm: algEdDSA}))
+		require.EqualError(t, err, "signed Unpack: missing 'kid' protected header")
+
+		_, err = p.Unpack(signWith(t, jose.Headers{jose.HeaderAlgorithm: algEdDSA, jose.HeaderKeyID: "did:example:1"}))
+		require.Contains(t, err.Error(), "signed Unpack: resolve sender key")
+
+		_, keyID := fingerprint.CreateDIDKey(senderKey)
+
+		_, err = p.Unpack(signWith(t, jose.Headers{
+			jose.HeaderAlgorithm: algEdDSA,
+			jose.HeaderKeyID:     keyID,
+			jose.HeaderType:      transport.MediaTypeV2EncryptedEnvelope,
+		}, func(data []byte) ([]byte, error) {
+			kh, err := k.Get(mustKID(t, senderKey))
+			require.NoError(t, err)
+
+			return cryptoSvc.Sign(data, kh)
+		}))
+		require.EqualError(t, err, "signed Unpack: unsupported envelope type: "+transport.MediaTypeV2EncryptedEnvelope)
+	})
+}
+
+type signerFunc func(data []byte) ([]byte, error)
+
+func (f signerFunc) Sign(data []byte) ([]byte, error) {
+	return f(data)
+}
+
+// signWith creates a compact JWS with the given headers, the signature is invalid unless the sign function is given.
+func signWith(t *testing.T, headers jose.Headers, sign ...signerFunc) []byte {
+	t.Helper()
+
+	var signer signerFunc = func([]byte) ([]byte, error) { return []byte("signature"), nil }
+	if len(sign) > 0 {
+		signer = sign[0]
+	}
+
+	jws, err := jose.NewJWS(headers, nil, []byte("{}"), jose.NewSigner(signer, nil))
+	require.NoError(t, err)
+
+	s, err := jws.SerializeCompact(false)
+	require.NoError(t, err)
+
+	return []byte(s)
+}
+
+func mustKID(t *testing.T, pubKey []byte) string {
+	t.Helper()
+
+	kid, err := localkms.CreateKID(pubKey, kms.ED25519Type)
+	require.NoError(t, err)
+
+	return kid
+}
+
+type failingCrypto struct {
+	cryptoapi.Crypto
+}
+
+func (c *failingCrypto) Sign([]byte, interface{}) ([]byte, error) {
+	return nil, errors.New("sign error")
+}
+
+func createKMS(t *testing.T) *localkms.LocalKMS {
+	t.Helper()
+
+	p := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+
+	k, err := localkms.New("local-lock://test/key/uri", p)
+	require.NoError(t, err)
+
+	return k
+}
+
+func newMockProvider(customKMS kms.KeyManager, customCrypto cryptoapi.Crypto) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		KMSValue:    customKMS,
+		CryptoValue: customCrypto,
+	}
+}
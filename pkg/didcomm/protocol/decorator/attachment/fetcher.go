@@ -0,0 +1,234 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// DefaultMaxSize is the default limit (10 MiB) on the size of attachment contents.
+const DefaultMaxSize = 10 << 20
+
+var logger = log.New("aries-framework/didcomm/decorator/attachment")
+
+var (
+	// ErrSizeLimitExceeded is returned when attachment contents exceed the configured size limit.
+	ErrSizeLimitExceeded = errors.New("attachment exceeds size limit")
+	// ErrSchemeNotAllowed is returned when an attachment link uses a scheme not permitted by the fetcher.
+	ErrSchemeNotAllowed = errors.New("attachment link scheme not allowed")
+	// ErrHostNotAllowed is returned when an attachment link points to a host not permitted by the fetcher.
+	ErrHostNotAllowed = errors.New("attachment link host not allowed")
+	// ErrNoContents is returned when the attachment data has neither inline contents nor links.
+	ErrNoContents = errors.New("no contents in this attachment")
+)
+
+// HTTPClient is the http client used to fetch attachment links.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Fetcher retrieves attachment contents, either inline or from the attachment links,
+// enforcing a size limit, an allowed scheme policy and sha256 integrity checks.
+type Fetcher struct {
+	client  HTTPClient
+	maxSize int64
+	schemes map[string]struct{}
+	hosts   map[string]struct{}
+}
+
+// Option configures the Fetcher.
+type Option func(f *Fetcher)
+
+// WithHTTPClient sets the http client used to fetch attachment links.
+func WithHTTPClient(client HTTPClient) Option {
+	return func(f *Fetcher) {
+		f.client = client
+	}
+}
+
+// WithMaxSize sets the maximum number of bytes accepted for attachment contents.
+func WithMaxSize(size int64) Option {
+	return func(f *Fetcher) {
+		f.maxSize = size
+	}
+}
+
+// WithAllowedSchemes sets the link schemes the fetcher is allowed to follow (defaults to "https").
+func WithAllowedSchemes(schemes ...string) Option {
+	return func(f *Fetcher) {
+		f.schemes = make(map[string]struct{}, len(schemes))
+
+		for _, s := range schemes {
+			f.schemes[strings.ToLower(s)] = struct{}{}
+		}
+	}
+}
+
+// WithAllowedHosts restricts the hosts the fetcher is allowed to follow links to (defaults to any host).
+func WithAllowedHosts(hosts ...string) Option {
+	return func(f *Fetcher) {
+		f.hosts = make(map[string]struct{}, len(hosts))
+
+		for _, h := range hosts {
+			f.hosts[strings.ToLower(h)] = struct{}{}
+		}
+	}
+}
+
+// NewFetcher returns a new attachment Fetcher.
+func NewFetcher(opts ...Option) *Fetcher {
+	f := &Fetcher{
+		client:  http.DefaultClient,
+		maxSize: DefaultMaxSize,
+		schemes: map[string]struct{}{"https": {}},
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Fetch returns the attachment contents held in memory.
+func (f *Fetcher) Fetch(ctx context.Context, d *decorator.AttachmentData) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if _, err := f.Stream(ctx, d, &buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Stream writes the attachment contents to w without holding them in memory and returns the number of bytes written.
+// Inline contents are preferred over links. Links are tried in order until one of them responds successfully.
+// If an error is returned after writing has started, w may hold partial contents.
+func (f *Fetcher) Stream(ctx context.Context, d *decorator.AttachmentData, w io.Writer) (int64, error) {
+	if d.JSON != nil {
+		bits, err := json.Marshal(d.JSON)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal json contents : %w", err)
+		}
+
+		return f.copy(w, bytes.NewReader(bits), d.Sha256)
+	}
+
+	if d.Base64 != "" {
+		return f.copy(w, base64.NewDecoder(base64.StdEncoding, strings.NewReader(d.Base64)), d.Sha256)
+	}
+
+	if len(d.Links) == 0 {
+		return 0, ErrNoContents
+	}
+
+	var errs []string
+
+	for _, link := range d.Links {
+		body, err := f.open(ctx, link)
+		if err != nil {
+			logger.Debugf("skipping attachment link %s: %s", link, err)
+
+			errs = append(errs, err.Error())
+
+			continue
+		}
+
+		n, err := f.copy(w, body, d.Sha256)
+
+		closeResponseBody(body)
+
+		return n, err
+	}
+
+	return 0, fmt.Errorf("failed to fetch attachment links: %s", strings.Join(errs, "; "))
+}
+
+func (f *Fetcher) open(ctx context.Context, link string) (io.ReadCloser, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid link %s: %w", link, err)
+	}
+
+	if _, ok := f.schemes[strings.ToLower(u.Scheme)]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSchemeNotAllowed, link)
+	}
+
+	if f.hosts != nil {
+		if _, ok := f.hosts[strings.ToLower(u.Hostname())]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrHostNotAllowed, link)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request for %s: %w", link, err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", link, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		closeResponseBody(resp.Body)
+
+		return nil, fmt.Errorf("get %s: unexpected status code %d", link, resp.StatusCode)
+	}
+
+	if resp.ContentLength > f.maxSize {
+		closeResponseBody(resp.Body)
+
+		return nil, fmt.Errorf("%w: %s has content length %d", ErrSizeLimitExceeded, link, resp.ContentLength)
+	}
+
+	return resp.Body, nil
+}
+
+// copy copies at most maxSize bytes from r to w and verifies the sha256 digest of the copied contents.
+func (f *Fetcher) copy(w io.Writer, r io.Reader, expectedDigest string) (int64, error) {
+	h := sha256.New()
+
+	n, err := io.Copy(io.MultiWriter(w, h), io.LimitReader(r, f.maxSize))
+	if err != nil {
+		return n, fmt.Errorf("copy attachment contents: %w", err)
+	}
+
+	if n == f.maxSize {
+		extra, readErr := r.Read(make([]byte, 1))
+		if extra > 0 {
+			return n, fmt.Errorf("%w of %d bytes", ErrSizeLimitExceeded, f.maxSize)
+		}
+
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return n, fmt.Errorf("copy attachment contents: %w", readErr)
+		}
+	}
+
+	return n, verifyDigest(expectedDigest, hex.EncodeToString(h.Sum(nil)))
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
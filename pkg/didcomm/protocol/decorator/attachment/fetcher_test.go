@@ -0,0 +1,168 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+func TestFetcher_Fetch(t *testing.T) {
+	content := []byte("the quick brown fox jumps over the lazy dog")
+
+	t.Run("json", func(t *testing.T) {
+		bits, err := NewFetcher().Fetch(context.Background(), &decorator.AttachmentData{
+			JSON: map[string]interface{}{"name": "John"},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name":"John"}`, string(bits))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := NewFetcher().Fetch(context.Background(), &decorator.AttachmentData{JSON: func() {}})
+		require.Error(t, err)
+	})
+
+	t.Run("base64 with sha256", func(t *testing.T) {
+		bits, err := NewFetcher().Fetch(context.Background(), &decorator.AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString(content),
+			Sha256: Sha256(content),
+		})
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+	})
+
+	t.Run("base64 with wrong sha256", func(t *testing.T) {
+		_, err := NewFetcher().Fetch(context.Background(), &decorator.AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString(content),
+			Sha256: Sha256([]byte("other")),
+		})
+		require.True(t, errors.Is(err, ErrChecksumMismatch))
+	})
+
+	t.Run("invalid base64", func(t *testing.T) {
+		_, err := NewFetcher().Fetch(context.Background(), &decorator.AttachmentData{Base64: "invalid"})
+		require.Error(t, err)
+	})
+
+	t.Run("size limit exceeded", func(t *testing.T) {
+		_, err := NewFetcher(WithMaxSize(10)).Fetch(context.Background(), &decorator.AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString(content),
+		})
+		require.True(t, errors.Is(err, ErrSizeLimitExceeded))
+
+		bits, err := NewFetcher(WithMaxSize(int64(len(content)))).Fetch(context.Background(),
+			&decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(content)})
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+	})
+
+	t.Run("no contents", func(t *testing.T) {
+		_, err := NewFetcher().Fetch(context.Background(), &decorator.AttachmentData{})
+		require.True(t, errors.Is(err, ErrNoContents))
+	})
+}
+
+func TestFetcher_Links(t *testing.T) {
+	content := []byte("the quick brown fox jumps over the lazy dog")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/content":
+			_, err := w.Write(content)
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Run("success", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		n, err := NewFetcher(WithAllowedSchemes("http")).Stream(context.Background(), &decorator.AttachmentData{
+			Links:  []string{srv.URL + "/content"},
+			Sha256: Sha256(content),
+		}, &buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(content)), n)
+		require.Equal(t, content, buf.Bytes())
+	})
+
+	t.Run("falls back to next link", func(t *testing.T) {
+		bits, err := NewFetcher(WithAllowedSchemes("http"), WithHTTPClient(srv.Client())).Fetch(context.Background(),
+			&decorator.AttachmentData{
+				Links: []string{"ftp://example.com/content", srv.URL + "/missing", srv.URL + "/content"},
+			})
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+	})
+
+	t.Run("scheme not allowed", func(t *testing.T) {
+		_, err := NewFetcher().Fetch(context.Background(), &decorator.AttachmentData{
+			Links: []string{srv.URL + "/content"},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), ErrSchemeNotAllowed.Error())
+	})
+
+	t.Run("host not allowed", func(t *testing.T) {
+		_, err := NewFetcher(WithAllowedSchemes("http"), WithAllowedHosts("example.com")).Fetch(context.Background(),
+			&decorator.AttachmentData{Links: []string{srv.URL + "/content"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), ErrHostNotAllowed.Error())
+
+		u, err := url.Parse(srv.URL)
+		require.NoError(t, err)
+
+		bits, err := NewFetcher(WithAllowedSchemes("http"), WithAllowedHosts(u.Hostname())).Fetch(context.Background(),
+			&decorator.AttachmentData{Links: []string{srv.URL + "/content"}})
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+	})
+
+	t.Run("invalid link", func(t *testing.T) {
+		_, err := NewFetcher().Fetch(context.Background(), &decorator.AttachmentData{
+			Links: []string{"%zz"},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid link")
+	})
+
+	t.Run("content length exceeds size limit", func(t *testing.T) {
+		_, err := NewFetcher(WithAllowedSchemes("http"), WithMaxSize(10)).Fetch(context.Background(),
+			&decorator.AttachmentData{Links: []string{srv.URL + "/content"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), ErrSizeLimitExceeded.Error())
+	})
+
+	t.Run("http client error", func(t *testing.T) {
+		_, err := NewFetcher(WithAllowedSchemes("http"), WithHTTPClient(&mockHTTPClient{err: fmt.Errorf("failed")})).
+			Fetch(context.Background(), &decorator.AttachmentData{Links: []string{srv.URL + "/content"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed")
+	})
+}
+
+type mockHTTPClient struct {
+	err error
+}
+
+func (m *mockHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, m.err
+}
@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package attachment
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// ErrChecksumMismatch is returned when attachment contents do not match the sha256 in the attachment data.
+var ErrChecksumMismatch = errors.New("attachment sha256 checksum mismatch")
+
+// Sha256 returns the hex encoded sha256 digest of the given content, as expected in AttachmentData.Sha256.
+func Sha256(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// SetSha256 computes the sha256 digest of the inline contents of the attachment data and sets it.
+func SetSha256(d *decorator.AttachmentData) error {
+	content, err := d.Fetch()
+	if err != nil {
+		return fmt.Errorf("set sha256: %w", err)
+	}
+
+	d.Sha256 = Sha256(content)
+
+	return nil
+}
+
+// VerifySha256 checks the given content against the sha256 digest of the attachment data.
+// Verification is skipped if the attachment data does not carry a digest.
+func VerifySha256(d *decorator.AttachmentData, content []byte) error {
+	if d.Sha256 == "" {
+		return nil
+	}
+
+	return verifyDigest(d.Sha256, Sha256(content))
+}
+
+func verifyDigest(expected, actual string) error {
+	if expected == "" {
+		return nil
+	}
+
+	if !strings.EqualFold(expected, actual) {
+		return fmt.Errorf("%w: expected %s but got %s", ErrChecksumMismatch, expected, actual)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package attachment
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+func TestSha256(t *testing.T) {
+	t.Run("set and verify", func(t *testing.T) {
+		content := []byte("hello world")
+		data := &decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(content)}
+
+		require.NoError(t, SetSha256(data))
+		require.Equal(t, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", data.Sha256)
+		require.NoError(t, VerifySha256(data, content))
+
+		data.Sha256 = strings.ToUpper(data.Sha256)
+		require.NoError(t, VerifySha256(data, content))
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		data := &decorator.AttachmentData{Sha256: Sha256([]byte("other"))}
+
+		err := VerifySha256(data, []byte("hello world"))
+		require.True(t, errors.Is(err, ErrChecksumMismatch))
+	})
+
+	t.Run("no digest", func(t *testing.T) {
+		require.NoError(t, VerifySha256(&decorator.AttachmentData{}, []byte("hello world")))
+	})
+
+	t.Run("no contents", func(t *testing.T) {
+		require.Error(t, SetSha256(&decorator.AttachmentData{}))
+	})
+}
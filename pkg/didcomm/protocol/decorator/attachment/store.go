@@ -0,0 +1,292 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package attachment
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for attachment store.
+	NameSpace = "attachment"
+
+	// DefaultChunkSize is the default size (256 KiB) of the chunks attachment contents are stored in.
+	DefaultChunkSize = 256 << 10
+
+	infoKeyPattern  = "info_%s"
+	chunkKeyPattern = "chunk_%s_%d"
+)
+
+// ErrWriterClosed is returned when writing to a closed attachment Writer.
+var ErrWriterClosed = errors.New("attachment writer is closed")
+
+// Info describes attachment contents persisted in the Store.
+type Info struct {
+	ID     string `json:"id"`
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+	Chunks int    `json:"chunks"`
+}
+
+// Store persists attachment contents in fixed size chunks, so that large attachments
+// can be streamed to and from storage without being held in memory.
+type Store struct {
+	store     storage.Store
+	chunkSize int
+}
+
+// StoreOption configures the Store.
+type StoreOption func(s *Store)
+
+// WithChunkSize sets the size of the chunks the attachment contents are stored in.
+func WithChunkSize(size int) StoreOption {
+	return func(s *Store) {
+		s.chunkSize = size
+	}
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// NewStore returns a new attachment Store.
+func NewStore(ctx provider, opts ...StoreOption) (*Store, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachment store: %w", err)
+	}
+
+	s := &Store{store: store, chunkSize: DefaultChunkSize}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.chunkSize <= 0 {
+		return nil, fmt.Errorf("invalid chunk size: %d", s.chunkSize)
+	}
+
+	return s, nil
+}
+
+// Put reads r until EOF and persists its contents under the given id.
+func (s *Store) Put(id string, r io.Reader) (*Info, error) {
+	w, err := s.Create(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = io.Copy(w, r); err != nil {
+		return nil, fmt.Errorf("failed to store attachment %s: %w", id, err)
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	return w.Info(), nil
+}
+
+// Create returns a Writer that persists attachment contents under the given id.
+// Contents are only visible through Open after the Writer is closed.
+func (s *Store) Create(id string) (*Writer, error) {
+	if id == "" {
+		return nil, errors.New("attachment id is mandatory")
+	}
+
+	if err := s.Delete(id); err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		store: s,
+		id:    id,
+		buf:   make([]byte, 0, s.chunkSize),
+		hash:  sha256.New(),
+	}, nil
+}
+
+// Info returns the description of the attachment persisted under the given id.
+func (s *Store) Info(id string) (*Info, error) {
+	bits, err := s.store.Get(fmt.Sprintf(infoKeyPattern, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment info %s: %w", id, err)
+	}
+
+	info := &Info{}
+
+	if err = json.Unmarshal(bits, info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attachment info %s: %w", id, err)
+	}
+
+	return info, nil
+}
+
+// Open returns a reader over the attachment contents persisted under the given id.
+// The sha256 digest of the contents is verified once the reader reaches EOF.
+func (s *Store) Open(id string) (io.Reader, error) {
+	info, err := s.Info(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reader{store: s.store, info: info, hash: sha256.New()}, nil
+}
+
+// Delete removes the attachment contents persisted under the given id.
+func (s *Store) Delete(id string) error {
+	info, err := s.Info(id)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ops := make([]storage.Operation, 0, info.Chunks+1)
+	ops = append(ops, storage.Operation{Key: fmt.Sprintf(infoKeyPattern, id)})
+
+	for i := 0; i < info.Chunks; i++ {
+		ops = append(ops, storage.Operation{Key: fmt.Sprintf(chunkKeyPattern, id, i)})
+	}
+
+	if err = s.store.Batch(ops); err != nil {
+		return fmt.Errorf("failed to delete attachment %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// Writer persists attachment contents in chunks as they are written.
+type Writer struct {
+	store  *Store
+	id     string
+	buf    []byte
+	hash   hash.Hash
+	size   int64
+	chunks int
+	closed bool
+}
+
+// Write implements io.Writer.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+
+	written := 0
+
+	for len(p) > 0 {
+		n := copy(w.buf[len(w.buf):cap(w.buf)], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+
+		if len(w.buf) == cap(w.buf) {
+			if err := w.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// Close flushes the remaining contents and persists the attachment info.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	if len(w.buf) > 0 {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+
+	w.closed = true
+
+	bits, err := json.Marshal(w.Info())
+	if err != nil {
+		return fmt.Errorf("failed to marshal attachment info %s: %w", w.id, err)
+	}
+
+	if err = w.store.store.Put(fmt.Sprintf(infoKeyPattern, w.id), bits); err != nil {
+		return fmt.Errorf("failed to store attachment info %s: %w", w.id, err)
+	}
+
+	return nil
+}
+
+// Info returns the description of the contents written so far.
+func (w *Writer) Info() *Info {
+	return &Info{
+		ID:     w.id,
+		Size:   w.size,
+		Sha256: hex.EncodeToString(w.hash.Sum(nil)),
+		Chunks: w.chunks,
+	}
+}
+
+func (w *Writer) flush() error {
+	chunk := make([]byte, len(w.buf))
+	copy(chunk, w.buf)
+
+	if err := w.store.store.Put(fmt.Sprintf(chunkKeyPattern, w.id, w.chunks), chunk); err != nil {
+		return fmt.Errorf("failed to store attachment chunk %s/%d: %w", w.id, w.chunks, err)
+	}
+
+	w.hash.Write(chunk) // nolint: errcheck,gosec
+	w.size += int64(len(chunk))
+	w.chunks++
+	w.buf = w.buf[:0]
+
+	return nil
+}
+
+type reader struct {
+	store storage.Store
+	info  *Info
+	hash  hash.Hash
+	next  int
+	buf   []byte
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.next == r.info.Chunks {
+			if err := verifyDigest(r.info.Sha256, hex.EncodeToString(r.hash.Sum(nil))); err != nil {
+				return 0, err
+			}
+
+			return 0, io.EOF
+		}
+
+		chunk, err := r.store.Get(fmt.Sprintf(chunkKeyPattern, r.info.ID, r.next))
+		if err != nil {
+			return 0, fmt.Errorf("failed to get attachment chunk %s/%d: %w", r.info.ID, r.next, err)
+		}
+
+		r.hash.Write(chunk) // nolint: errcheck,gosec
+		r.buf = chunk
+		r.next++
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
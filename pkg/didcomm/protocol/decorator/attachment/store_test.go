@@ -0,0 +1,139 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestNewStore(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := NewStore(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			ErrOpenStoreHandle: fmt.Errorf("open error"),
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open error")
+	})
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		_, err := NewStore(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, WithChunkSize(0))
+		require.Error(t, err)
+	})
+}
+
+func TestStore(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+
+	t.Run("put, open and delete", func(t *testing.T) {
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, WithChunkSize(64))
+		require.NoError(t, err)
+
+		info, err := s.Put("file", bytes.NewReader(content))
+		require.NoError(t, err)
+		require.Equal(t, &Info{ID: "file", Size: int64(len(content)), Sha256: Sha256(content), Chunks: 16}, info)
+
+		r, err := s.Open("file")
+		require.NoError(t, err)
+
+		bits, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+
+		require.NoError(t, s.Delete("file"))
+
+		_, err = s.Open("file")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, s.Delete("file"))
+	})
+
+	t.Run("stream fetched attachment to store", func(t *testing.T) {
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, WithChunkSize(100))
+		require.NoError(t, err)
+
+		w, err := s.Create("file")
+		require.NoError(t, err)
+
+		_, err = NewFetcher().Stream(context.Background(), &decorator.AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString(content),
+			Sha256: Sha256(content),
+		}, w)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.NoError(t, w.Close())
+
+		_, err = w.Write([]byte("data"))
+		require.True(t, errors.Is(err, ErrWriterClosed))
+
+		info, err := s.Info("file")
+		require.NoError(t, err)
+		require.Equal(t, 10, info.Chunks)
+		require.Equal(t, Sha256(content), info.Sha256)
+	})
+
+	t.Run("corrupted chunk", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: provider}, WithChunkSize(100))
+		require.NoError(t, err)
+
+		_, err = s.Put("file", bytes.NewReader(content))
+		require.NoError(t, err)
+
+		store, err := provider.OpenStore(NameSpace)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(fmt.Sprintf(chunkKeyPattern, "file", 3), []byte("corrupted")))
+
+		r, err := s.Open("file")
+		require.NoError(t, err)
+
+		_, err = ioutil.ReadAll(r)
+		require.True(t, errors.Is(err, ErrChecksumMismatch))
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+		require.NoError(t, err)
+
+		_, err = s.Put("", bytes.NewReader(content))
+		require.Error(t, err)
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			Store: &mockstore.MockStore{
+				Store:  map[string]mockstore.DBEntry{},
+				ErrPut: fmt.Errorf("put error"),
+			},
+		}}, WithChunkSize(100))
+		require.NoError(t, err)
+
+		_, err = s.Put("file", bytes.NewReader(content))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "put error")
+	})
+}
@@ -7,11 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package decorator
 
 import (
+	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 const (
@@ -23,6 +29,15 @@ const (
 
 	// TransportReturnRouteThread return route option thread.
 	TransportReturnRouteThread = "thread"
+
+	// AckOnReceipt requests the acknowledgement of the message as soon as it is received.
+	AckOnReceipt = "RECEIPT"
+
+	// AckOnOutcome requests the acknowledgement of the message once it is processed.
+	AckOnOutcome = "OUTCOME"
+
+	// jwsAlgEdDSA is the algorithm of the attachment signatures.
+	jwsAlgEdDSA = "EdDSA"
 )
 
 // Thread thread data.
@@ -33,9 +48,56 @@ type Thread struct {
 	ReceivedOrders map[string]int `json:"received_orders,omitempty"`
 }
 
-// Timing keeps expiration time.
+// Timing keeps the timing information of the message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0032-message-timing
 type Timing struct {
-	ExpiresTime time.Time `json:"expires_time,omitempty"`
+	// InTime is the time the preceding message of the thread was received.
+	InTime *time.Time `json:"in_time,omitempty"`
+	// OutTime is the time the message was sent.
+	OutTime *time.Time `json:"out_time,omitempty"`
+	// StaleTime is the time after which the message should be considered stale.
+	StaleTime   *time.Time `json:"stale_time,omitempty"`
+	ExpiresTime time.Time  `json:"expires_time,omitempty"`
+	// DelayMilli is the delay in milliseconds the recipient should wait before handling the message.
+	DelayMilli int `json:"delay_milli,omitempty"`
+	// WaitUntilTime is the time the recipient should wait for before handling the message.
+	WaitUntilTime *time.Time `json:"wait_until_time,omitempty"`
+}
+
+// PleaseAck requests an acknowledgement of the message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0317-please-ack
+type PleaseAck struct {
+	// On contains the events the acknowledgement is requested on, e.g. RECEIPT or OUTCOME.
+	On []string `json:"on,omitempty"`
+}
+
+// L10n is the localization decorator of the message (~l10n).
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0043-l10n
+type L10n struct {
+	// Locale is the locale of the localizable fields of the message, e.g. "en".
+	Locale string `json:"locale,omitempty"`
+	// Localizable are the names of the fields of the message which may be localized.
+	Localizable []string `json:"localizable,omitempty"`
+	// Catalogs are the URIs of the message catalogs defining the localized texts of the codes of the message.
+	Catalogs []string `json:"catalogs,omitempty"`
+}
+
+// Localized is the field-level localization decorator (<field>~l10n): the locale of the field and its texts in the
+// other locales, e.g. {"locale": "en", "es": "Hola", "fr": "Bonjour"}. The messages localizing their comment have
+// a CommentL10n field (comment~l10n).
+type Localized map[string]string
+
+// Locale returns the locale of the field.
+func (l Localized) Locale() string {
+	return l["locale"]
+}
+
+// Service is the service decorator. It allows the recipient to reply to the message without a connection.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0056-service-decorator
+type Service struct {
+	RecipientKeys   []string `json:"recipientKeys"`
+	RoutingKeys     []string `json:"routingKeys,omitempty"`
+	ServiceEndpoint string   `json:"serviceEndpoint"`
 }
 
 // Transport transport decorator
@@ -92,6 +154,131 @@ type AttachmentData struct {
 	// JSON is a directly embedded JSON data, when representing content inline instead of via links,
 	// and when the content is natively conveyable as JSON. Optional.
 	JSON interface{} `json:"json,omitempty"`
+	// JWS is the detached signature of the Base64 content, see Sign. Optional.
+	JWS *AttachmentJWS `json:"jws,omitempty"`
+}
+
+// AttachmentJWS is a detached JSON web signature of the attachment content, signed with an Ed25519 key.
+// The key is identified by its did:key, so the signature can be bound to the DID of the signer.
+type AttachmentJWS struct {
+	Header    AttachmentJWSHeader `json:"header"`
+	Protected string              `json:"protected"`
+	Signature string              `json:"signature"`
+}
+
+// AttachmentJWSHeader is the unprotected header of the attachment JWS.
+type AttachmentJWSHeader struct {
+	KID string `json:"kid"`
+}
+
+// Sign signs the Base64 content with the Ed25519 key of the given handle and public key, and sets its JWS.
+func (d *AttachmentData) Sign(c crypto.Crypto, kh interface{}, pubKey []byte) error {
+	payload, err := d.jwsPayload()
+	if err != nil {
+		return err
+	}
+
+	if len(pubKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid Ed25519 public key: %d bytes", len(pubKey))
+	}
+
+	// the fingerprint can't fail, the second value is the key ID of the did:key
+	didKey, _ := fingerprint.CreateDIDKey(pubKey)
+
+	protected, err := json.Marshal(map[string]interface{}{
+		"alg": jwsAlgEdDSA,
+		"kid": didKey,
+		"jwk": map[string]string{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   base64.RawURLEncoding.EncodeToString(pubKey),
+			"kid": didKey,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("marshal jws protected headers: %w", err)
+	}
+
+	encodedProtected := base64.RawURLEncoding.EncodeToString(protected)
+
+	signature, err := c.Sign([]byte(encodedProtected+"."+payload), kh)
+	if err != nil {
+		return fmt.Errorf("sign attachment data: %w", err)
+	}
+
+	d.JWS = &AttachmentJWS{
+		Header:    AttachmentJWSHeader{KID: didKey},
+		Protected: encodedProtected,
+		Signature: base64.RawURLEncoding.EncodeToString(signature),
+	}
+
+	return nil
+}
+
+// Verify verifies the JWS of the Base64 content and returns the did:key of the signer.
+func (d *AttachmentData) Verify(c crypto.Crypto, keyManager kms.KeyManager) (string, error) {
+	if d.JWS == nil {
+		return "", errors.New("attachment data is not signed")
+	}
+
+	payload, err := d.jwsPayload()
+	if err != nil {
+		return "", err
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(d.JWS.Protected)
+	if err != nil {
+		return "", fmt.Errorf("decode jws protected headers: %w", err)
+	}
+
+	headers := struct {
+		Alg string `json:"alg"`
+		KID string `json:"kid"`
+	}{}
+
+	if err = json.Unmarshal(protected, &headers); err != nil {
+		return "", fmt.Errorf("unmarshal jws protected headers: %w", err)
+	}
+
+	if headers.Alg != jwsAlgEdDSA {
+		return "", fmt.Errorf("unsupported jws algorithm: %s", headers.Alg)
+	}
+
+	signer := headers.KID
+	if signer == "" {
+		signer = d.JWS.Header.KID
+	}
+
+	pubKey, err := fingerprint.PubKeyFromDIDKey(signer)
+	if err != nil {
+		return "", fmt.Errorf("jws signer %s: %w", signer, err)
+	}
+
+	kh, err := keyManager.PubKeyBytesToHandle(pubKey, kms.ED25519Type)
+	if err != nil {
+		return "", fmt.Errorf("get jws signer key handle: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(d.JWS.Signature)
+	if err != nil {
+		return "", fmt.Errorf("decode jws signature: %w", err)
+	}
+
+	if err = c.Verify(signature, []byte(d.JWS.Protected+"."+payload), kh); err != nil {
+		return "", fmt.Errorf("verify attachment data: %w", err)
+	}
+
+	return signer, nil
+}
+
+// jwsPayload returns the JWS payload of the content: its base64url encoding, as the signing agents
+// convert the base64 encoding of the content.
+func (d *AttachmentData) jwsPayload() (string, error) {
+	if d.Base64 == "" {
+		return "", errors.New("only base64 attachment data can be signed")
+	}
+
+	return strings.TrimRight(strings.NewReplacer("+", "-", "/", "_").Replace(d.Base64), "="), nil
 }
 
 // Fetch this attachment's contents.
@@ -118,7 +305,5 @@ func (d *AttachmentData) Fetch() ([]byte, error) {
 
 	// TODO add support to fetch links
 
-	// TODO add support for jws signatures
-
 	return nil, errors.New("no contents in this attachment")
 }
@@ -12,6 +12,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 func TestAttachmentData_Fetch(t *testing.T) {
@@ -56,6 +65,107 @@ func TestAttachmentData_Fetch(t *testing.T) {
 	})
 }
 
+type kmsProvider struct {
+	store storage.Provider
+}
+
+func (p *kmsProvider) StorageProvider() storage.Provider {
+	return p.store
+}
+
+func (p *kmsProvider) SecretLock() secretlock.Service {
+	return &noop.NoLock{}
+}
+
+func TestAttachmentData_Sign(t *testing.T) {
+	km, err := localkms.New("local-lock://primary/test/", &kmsProvider{store: mem.NewProvider()})
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	keyID, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	signer, _ := fingerprint.CreateDIDKey(pubKey)
+
+	kh, err := km.Get(keyID)
+	require.NoError(t, err)
+
+	sign := func(t *testing.T) *AttachmentData {
+		t.Helper()
+
+		data := &AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(`{"id":"did:example:123"}`))}
+		require.NoError(t, data.Sign(c, kh, pubKey))
+		require.Equal(t, signer, data.JWS.Header.KID)
+
+		return data
+	}
+
+	t.Run("sign and verify", func(t *testing.T) {
+		data := sign(t)
+
+		// the attachment is sent and received
+		src, err := json.Marshal(data)
+		require.NoError(t, err)
+
+		received := &AttachmentData{}
+		require.NoError(t, json.Unmarshal(src, received))
+
+		kid, err := received.Verify(c, km)
+		require.NoError(t, err)
+		require.Equal(t, signer, kid)
+	})
+
+	t.Run("tampered content", func(t *testing.T) {
+		data := sign(t)
+		data.Base64 = base64.StdEncoding.EncodeToString([]byte(`{"id":"did:example:456"}`))
+
+		_, err := data.Verify(c, km)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify attachment data")
+	})
+
+	t.Run("other signer", func(t *testing.T) {
+		_, otherKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		other, _ := fingerprint.CreateDIDKey(otherKey)
+
+		data := sign(t)
+		data.JWS.Protected = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA","kid":"` + other + `"}`))
+
+		_, err = data.Verify(c, km)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify attachment data")
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		data := sign(t)
+		data.JWS.Protected = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256"}`))
+
+		_, err := data.Verify(c, km)
+		require.EqualError(t, err, "unsupported jws algorithm: ES256")
+	})
+
+	t.Run("not signed", func(t *testing.T) {
+		_, err := (&AttachmentData{Base64: "e30="}).Verify(c, km)
+		require.EqualError(t, err, "attachment data is not signed")
+	})
+
+	t.Run("json content", func(t *testing.T) {
+		err := (&AttachmentData{JSON: map[string]interface{}{}}).Sign(c, kh, pubKey)
+		require.EqualError(t, err, "only base64 attachment data can be signed")
+	})
+
+	t.Run("invalid public key", func(t *testing.T) {
+		data := &AttachmentData{Base64: "e30="}
+
+		require.EqualError(t, data.Sign(c, kh, pubKey[1:]), "invalid Ed25519 public key: 31 bytes")
+		require.Nil(t, data.JWS)
+	})
+}
+
 type testStruct struct {
 	FirstName string
 	LastName  string
@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package decorator
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// LocalizedPropKey is the key of the event property holding the localized texts of the fields of the received
+	// message by locale (map[string]map[string]string), e.g. {"comment": {"en": "Hello", "es": "Hola"}}.
+	LocalizedPropKey = "localized"
+
+	// l10nKey is the key of the message-level decorator and the suffix of the field-level ones.
+	l10nKey   = "~l10n"
+	localeKey = "locale"
+)
+
+// LocalizedFields returns the localized texts of the fields of the message by locale: the texts of the field-level
+// decorators (<field>~l10n) and the text of the fields in the locale of the message-level decorator (~l10n), e.g.
+// {"comment": {"en": "Hello", "es": "Hola"}}.
+func LocalizedFields(msg map[string]interface{}) map[string]map[string]string {
+	fields := map[string]map[string]string{}
+
+	if messageL10n := messageL10nOf(msg); messageL10n != nil && messageL10n.Locale != "" {
+		for _, name := range messageL10n.Localizable {
+			if text, ok := msg[name].(string); ok {
+				addText(fields, name, messageL10n.Locale, text)
+			}
+		}
+	}
+
+	for key, value := range msg {
+		name := strings.TrimSuffix(key, l10nKey)
+		if name == key || name == "" {
+			continue
+		}
+
+		localized, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for locale, text := range localized {
+			if s, isString := text.(string); isString && locale != localeKey {
+				addText(fields, name, locale, s)
+			}
+		}
+
+		if locale, isString := localized[localeKey].(string); isString && locale != "" {
+			if text, isString := msg[name].(string); isString {
+				addText(fields, name, locale, text)
+			}
+		}
+	}
+
+	return fields
+}
+
+// AddLocalizedFields adds the localized texts of the fields of the message to the event properties
+// (LocalizedPropKey), if it has any.
+func AddLocalizedFields(properties, msg map[string]interface{}) {
+	if fields := LocalizedFields(msg); len(fields) > 0 {
+		properties[LocalizedPropKey] = fields
+	}
+}
+
+// messageL10nOf returns the message-level decorator of the message, nil if it has none or if it is invalid.
+func messageL10nOf(msg map[string]interface{}) *L10n {
+	value, ok := msg[l10nKey]
+	if !ok {
+		return nil
+	}
+
+	src, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	messageL10n := &L10n{}
+
+	if err = json.Unmarshal(src, messageL10n); err != nil {
+		return nil
+	}
+
+	return messageL10n
+}
+
+func addText(fields map[string]map[string]string, name, locale, text string) {
+	if fields[name] == nil {
+		fields[name] = map[string]string{}
+	}
+
+	fields[name][locale] = text
+}
@@ -0,0 +1,45 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package decorator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizedFields(t *testing.T) {
+	t.Run("field-level and message-level decorators", func(t *testing.T) {
+		msg := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"@type": "https://didcomm.org/present-proof/2.0/request-presentation",
+			"comment": "Hello",
+			"comment~l10n": {"locale": "en", "es": "Hola", "fr": "Bonjour"},
+			"goal": "Proof of age",
+			"~l10n": {"locale": "en", "localizable": ["goal", "missing"]}
+		}`), &msg))
+
+		require.Equal(t, map[string]map[string]string{
+			"comment": {"en": "Hello", "es": "Hola", "fr": "Bonjour"},
+			"goal":    {"en": "Proof of age"},
+		}, LocalizedFields(msg))
+
+		properties := map[string]interface{}{}
+		AddLocalizedFields(properties, msg)
+		require.Equal(t, LocalizedFields(msg), properties[LocalizedPropKey])
+	})
+
+	t.Run("no localized fields", func(t *testing.T) {
+		msg := map[string]interface{}{"comment": "Hello", "comment~l10n": "invalid", "~l10n": "invalid"}
+		require.Empty(t, LocalizedFields(msg))
+
+		properties := map[string]interface{}{}
+		AddLocalizedFields(properties, msg)
+		require.Empty(t, properties)
+	})
+}
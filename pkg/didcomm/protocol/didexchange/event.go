@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package didexchange
 
+import "github.com/hyperledger/aries-framework-go/pkg/store/connection"
+
 // Event properties related api. This can be used to cast Generic event properties to DID Exchange specific props.
 type Event interface {
 	// connection ID
@@ -19,6 +21,7 @@ type Event interface {
 type didExchangeEvent struct {
 	connectionID string
 	invitationID string
+	metadata     *connection.Metadata
 }
 
 // ConnectionID returns DIDExchange connectionID.
@@ -31,12 +34,23 @@ func (ex *didExchangeEvent) InvitationID() string {
 	return ex.invitationID
 }
 
+// Metadata returns the application metadata of the connection (nil if none).
+func (ex *didExchangeEvent) Metadata() *connection.Metadata {
+	return ex.metadata
+}
+
 // All implements EventProperties interface.
 func (ex *didExchangeEvent) All() map[string]interface{} {
-	return map[string]interface{}{
+	props := map[string]interface{}{
 		"connectionID": ex.ConnectionID(),
 		"invitationID": ex.InvitationID(),
 	}
+
+	if ex.metadata != nil {
+		props["metadata"] = ex.metadata
+	}
+
+	return props
 }
 
 // didExchangeEvent for sending events with processing error.
@@ -56,9 +70,8 @@ func (ex *didExchangeEventError) Error() string {
 
 // All implements EventProperties interface.
 func (ex *didExchangeEventError) All() map[string]interface{} {
-	return map[string]interface{}{
-		"connectionID": ex.ConnectionID(),
-		"invitationID": ex.InvitationID(),
-		"error":        ex.Error(),
-	}
+	props := ex.didExchangeEvent.All()
+	props["error"] = ex.Error()
+
+	return props
 }
@@ -11,6 +11,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 func TestDIDExchangeEvent(t *testing.T) {
@@ -28,3 +30,18 @@ func TestDIDExchangeEvent(t *testing.T) {
 	evErr = didExchangeEventError{}
 	require.Equal(t, "", evErr.Error())
 }
+
+func TestDIDExchangeEventMetadata(t *testing.T) {
+	metadata := &connection.Metadata{DisplayName: "Alice"}
+
+	ev := didExchangeEvent{connectionID: "abc", metadata: metadata}
+	require.Equal(t, metadata, ev.Metadata())
+	require.Equal(t, metadata, ev.All()["metadata"])
+
+	evErr := didExchangeEventError{didExchangeEvent: ev, err: errors.New("processing error")}
+	require.Equal(t, metadata, evErr.All()["metadata"])
+	require.Equal(t, "abc", evErr.All()["connectionID"])
+
+	_, ok := (&didExchangeEvent{}).All()["metadata"]
+	require.False(t, ok)
+}
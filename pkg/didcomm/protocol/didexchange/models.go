@@ -32,6 +32,12 @@ type OOBInvitation struct {
 	Target interface{}
 	// MediaTypes are the message formats supported by the sender of this invitation.
 	MediaTypes []string
+	// GoalCode and Goal are the goal of the invitation.
+	GoalCode string
+	Goal     string
+	// Protocols are the handshake protocols of the invitation, the request is a did-exchange 1.1 request if PIURIV11
+	// is one of them.
+	Protocols []string
 }
 
 // Invitation model
@@ -83,6 +89,9 @@ type Request struct {
 	DocAttach *decorator.Attachment `json:"did_doc~attach,omitempty"`
 	// Connection is used for backwards-compatibility with rfc 0160 connection protocol.
 	Connection *Connection `json:"connection,omitempty"`
+	// GoalCode and Goal are the goal of the request (did-exchange 1.1).
+	GoalCode string `json:"goal_code,omitempty"`
+	Goal     string `json:"goal,omitempty"`
 }
 
 // Response defines a2a DID exchange response
@@ -92,6 +101,13 @@ type Response struct {
 	ID                  string               `json:"@id,omitempty"`
 	ConnectionSignature *ConnectionSignature `json:"connection~sig,omitempty"`
 	Thread              *decorator.Thread    `json:"~thread,omitempty"`
+	// DID the did of the responder.
+	DID string `json:"did,omitempty"`
+	// DocAttach an attachment containing the did doc of the responder signed with the invitation key.
+	DocAttach *decorator.Attachment `json:"did_doc~attach,omitempty"`
+	// DIDRotateAttach an attachment containing the DID of the responder signed with the invitation key, present if
+	// the responder does not attach its did doc (e.g. it responds with a public DID).
+	DIDRotateAttach *decorator.Attachment `json:"did_rotate~attach,omitempty"`
 }
 
 // ConnectionSignature connection signature.
@@ -17,6 +17,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -46,9 +47,20 @@ const (
 	ResponseMsgType = PIURI + "/response"
 	// AckMsgType defines the did-exchange ack message type.
 	AckMsgType = PIURI + "/ack"
+	// PIURIV11 is the did-exchange 1.1 protocol identifier URI.
+	PIURIV11 = "https://didcomm.org/didexchange/1.1"
+	// RequestMsgTypeV11 defines the did-exchange 1.1 request message type.
+	RequestMsgTypeV11 = PIURIV11 + "/request"
+	// ResponseMsgTypeV11 defines the did-exchange 1.1 response message type.
+	ResponseMsgTypeV11 = PIURIV11 + "/response"
+	// CompleteMsgTypeV11 defines the did-exchange 1.1 complete message type (the 1.0 ack).
+	CompleteMsgTypeV11 = PIURIV11 + "/complete"
 	// oobMsgType is the internal message type for the oob invitation that the didexchange service receives.
 	oobMsgType             = "oob-invitation"
 	routerConnsMetadataKey = "routerConnections"
+	// AliasMetadataKey is the metadata key of the invitation messages holding the alias given by the user to the
+	// connection created for the invitation.
+	AliasMetadataKey = "alias"
 )
 
 const (
@@ -108,6 +120,13 @@ type context struct {
 	connectionStore    didstore.ConnectionStore
 	vdRegistry         vdrapi.Registry
 	routeSvc           mediator.ProtocolService
+	interop            *interop.Mode
+	// publicDID is the DID the requests are responded with when the inviter does not give one.
+	publicDID string
+	// profileDID is the public DID the agent uses for all its connections (requests and responses).
+	profileDID string
+	// mediaTypeProfiles are the media type profiles the agent supports.
+	mediaTypeProfiles []string
 }
 
 // opts are used to provide client properties to DID Exchange service.
@@ -141,6 +160,25 @@ func New(prov provider) (*Service, error) {
 
 	const callbackChannelSize = 10
 
+	var interopMode *interop.Mode
+	if p, ok := prov.(interface{ InteropMode() *interop.Mode }); ok {
+		interopMode = p.InteropMode()
+	}
+
+	var publicDID, profileDID string
+	if p, ok := prov.(interface{ DIDExchangePublicDID() string }); ok {
+		publicDID = p.DIDExchangePublicDID()
+	}
+
+	if p, ok := prov.(interface{ PublicDID() string }); ok {
+		profileDID = p.PublicDID()
+	}
+
+	var mediaTypeProfiles []string
+	if p, ok := prov.(interface{ MediaTypeProfiles() []string }); ok {
+		mediaTypeProfiles = p.MediaTypeProfiles()
+	}
+
 	svc := &Service{
 		ctx: &context{
 			outboundDispatcher: prov.OutboundDispatcher(),
@@ -150,6 +188,10 @@ func New(prov provider) (*Service, error) {
 			connectionRecorder: connRecorder,
 			connectionStore:    prov.DIDConnectionStore(),
 			routeSvc:           routeSvc,
+			interop:            interopMode,
+			publicDID:          publicDID,
+			profileDID:         profileDID,
+			mediaTypeProfiles:  mediaTypeProfiles,
 		},
 		// TODO channel size - https://github.com/hyperledger/aries-framework-go/issues/246
 		callbackChannel:    make(chan *message, callbackChannelSize),
@@ -177,6 +219,12 @@ func retrievingRouterConnections(msg service.DIDCommMsg) []string {
 	return connections
 }
 
+func retrievingAlias(msg service.DIDCommMsg) string {
+	alias, _ := msg.Metadata()[AliasMetadataKey].(string) //nolint:errcheck // the alias is optional
+
+	return alias
+}
+
 // HandleInbound handles inbound didexchange messages.
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
 	logger.Debugf("receive inbound message : %s", msg)
@@ -236,7 +284,28 @@ func (s *Service) Name() string {
 	return DIDExchange
 }
 
+// baseMsgType returns the did-exchange 1.0 message type of the did-exchange 1.1 message type, the message types
+// of both versions are handled by the same states.
+func baseMsgType(msgType string) string {
+	switch msgType {
+	case RequestMsgTypeV11:
+		return RequestMsgType
+	case ResponseMsgTypeV11:
+		return ResponseMsgType
+	case CompleteMsgTypeV11:
+		return AckMsgType
+	default:
+		return msgType
+	}
+}
+
+// isV11 returns true if the message type is a did-exchange 1.1 message type.
+func isV11(msgType string) bool {
+	return strings.HasPrefix(msgType, PIURIV11+"/")
+}
+
 func findNamespace(msgType string) string {
+	msgType = baseMsgType(msgType)
 	namespace := theirNSPrefix
 	if msgType == InvitationMsgType || msgType == ResponseMsgType || msgType == oobMsgType {
 		namespace = myNSPrefix
@@ -247,6 +316,8 @@ func findNamespace(msgType string) string {
 
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
+	msgType = baseMsgType(msgType)
+
 	return msgType == InvitationMsgType ||
 		msgType == RequestMsgType ||
 		msgType == ResponseMsgType ||
@@ -301,7 +372,7 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 			Type:         service.PreState,
 			Msg:          msg.Msg.Clone(),
 			StateID:      next.Name(),
-			Properties:   createEventProperties(msg.ConnRecord.ConnectionID, msg.ConnRecord.InvitationID),
+			Properties:   s.createEventProperties(msg.ConnRecord.ConnectionID, msg.ConnRecord.InvitationID),
 		})
 		logger.Debugf("sent pre event for state %s", next.Name())
 
@@ -367,7 +438,7 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 			Type:         service.PostState,
 			Msg:          msg.Msg.Clone(),
 			StateID:      prev.Name(),
-			Properties:   createEventProperties(connectionRecord.ConnectionID, connectionRecord.InvitationID),
+			Properties:   s.createEventProperties(connectionRecord.ConnectionID, connectionRecord.InvitationID),
 		})
 		logger.Debugf("sent post event for state %s", prev.Name())
 
@@ -385,15 +456,16 @@ func (s *Service) handleWithoutAction(msg *message) error {
 	return s.handle(msg, nil)
 }
 
-func createEventProperties(connectionID, invitationID string) *didExchangeEvent {
+func (s *Service) createEventProperties(connectionID, invitationID string) *didExchangeEvent {
 	return &didExchangeEvent{
 		connectionID: connectionID,
 		invitationID: invitationID,
+		metadata:     s.connectionMetadata(connectionID),
 	}
 }
 
-func createErrorEventProperties(connectionID, invitationID string, err error) *didExchangeEventError {
-	props := createEventProperties(connectionID, invitationID)
+func (s *Service) createErrorEventProperties(connectionID, invitationID string, err error) *didExchangeEventError {
+	props := s.createEventProperties(connectionID, invitationID)
 
 	return &didExchangeEventError{
 		err:              err,
@@ -401,6 +473,24 @@ func createErrorEventProperties(connectionID, invitationID string, err error) *d
 	}
 }
 
+// connectionMetadata returns the application metadata of the connection, nil if there is none.
+func (s *Service) connectionMetadata(connectionID string) *connection.Metadata {
+	if connectionID == "" {
+		return nil
+	}
+
+	metadata, err := s.connectionRecorder.GetMetadata(connectionID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			logger.Warnf("failed to get metadata of the connection %s: %s", connectionID, err)
+		}
+
+		return nil
+	}
+
+	return metadata
+}
+
 // sendActionEvent triggers the action event. This function stores the state of current processing and passes a callback
 // function in the event message.
 func (s *Service) sendActionEvent(internalMsg *message, aEvent chan<- service.DIDCommAction) error {
@@ -434,7 +524,8 @@ func (s *Service) sendActionEvent(internalMsg *message, aEvent chan<- service.DI
 				internalMsg.err = err
 				s.processCallback(internalMsg)
 			},
-			Properties: createEventProperties(internalMsg.ConnRecord.ConnectionID, internalMsg.ConnRecord.InvitationID),
+			Properties: s.createEventProperties(internalMsg.ConnRecord.ConnectionID,
+				internalMsg.ConnRecord.InvitationID),
 		}
 
 		logger.Debugf("dispatched action for msg: %+v", internalMsg.Msg)
@@ -581,7 +672,7 @@ func (s *Service) abandon(thID string, msg service.DIDCommMsg, processErr error)
 		Type:         service.PostState,
 		Msg:          msg,
 		StateID:      StateIDAbandoned,
-		Properties:   createErrorEventProperties(connRec.ConnectionID, "", processErr),
+		Properties:   s.createErrorEventProperties(connRec.ConnectionID, "", processErr),
 	})
 
 	return nil
@@ -612,6 +703,8 @@ func (s *Service) currentState(nsThID string) (state, error) {
 }
 
 func (s *Service) update(msgType string, record *connection.Record) error {
+	msgType = baseMsgType(msgType)
+
 	if (msgType == RequestMsgType && record.State == StateIDRequested) ||
 		(msgType == InvitationMsgType && record.State == StateIDInvited) ||
 		(msgType == oobMsgType && record.State == StateIDInvited) {
@@ -650,7 +743,7 @@ func (s *Service) CreateConnection(record *connection.Record, theirDID *did.Doc)
 }
 
 func (s *Service) connectionRecord(msg service.DIDCommMsg, ctx service.EventProperties) (*connection.Record, error) {
-	switch msg.Type() {
+	switch baseMsgType(msg.Type()) {
 	case oobMsgType:
 		return s.oobInvitationMsgRecord(msg)
 	case InvitationMsgType:
@@ -690,11 +783,15 @@ func (s *Service) oobInvitationMsgRecord(msg service.DIDCommMsg) (*connection.Re
 		ParentThreadID:  oobInvitation.ThreadID,
 		State:           stateNameNull,
 		InvitationID:    oobInvitation.ID,
-		ServiceEndPoint: svc.ServiceEndpoint,
+		ServiceEndPoint: svc.EndpointURI(),
 		RecipientKeys:   svc.RecipientKeys,
 		TheirLabel:      oobInvitation.TheirLabel,
 		Namespace:       findNamespace(msg.Type()),
 		MediaTypes:      oobInvitation.MediaTypes,
+		Alias:           retrievingAlias(msg),
+		GoalCode:        oobInvitation.GoalCode,
+		Goal:            oobInvitation.Goal,
+		InvitationKeys:  svc.RecipientKeys,
 	}
 
 	publicDID, ok := oobInvitation.Target.(string)
@@ -738,6 +835,12 @@ func (s *Service) invitationMsgRecord(msg service.DIDCommMsg) (*connection.Recor
 		RecipientKeys:   []string{recKey},
 		TheirLabel:      invitation.Label,
 		Namespace:       findNamespace(msg.Type()),
+		Alias:           retrievingAlias(msg),
+		InvitationKeys:  invitation.RecipientKeys,
+	}
+
+	if len(connRecord.InvitationKeys) == 0 {
+		connRecord.InvitationKeys = []string{recKey}
 	}
 
 	if err := s.connectionRecorder.SaveConnectionRecord(connRecord); err != nil {
@@ -767,7 +870,19 @@ func getRequestConnection(r *Request) (*Connection, error) {
 		return nil, fmt.Errorf("missing did_doc~attach from request")
 	}
 
-	docData, err := base64.StdEncoding.DecodeString(pad(r.DocAttach.Data.Base64))
+	doc, err := docFromAttachment(r.DocAttach)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connection{
+		DID:    r.DID,
+		DIDDoc: doc,
+	}, nil
+}
+
+func docFromAttachment(attachment *decorator.Attachment) (*did.Doc, error) {
+	docData, err := base64.StdEncoding.DecodeString(pad(attachment.Data.Base64))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse base64 attachment data: %w", err)
 	}
@@ -778,10 +893,7 @@ func getRequestConnection(r *Request) (*Connection, error) {
 		return nil, fmt.Errorf("failed to parse did document: %w", err)
 	}
 
-	return &Connection{
-		DID:    r.DID,
-		DIDDoc: doc,
-	}, nil
+	return doc, nil
 }
 
 func (s *Service) requestMsgRecord(msg service.DIDCommMsg, ctx service.EventProperties) (*connection.Record, error) {
@@ -821,6 +933,10 @@ func (s *Service) requestMsgRecord(msg service.DIDCommMsg, ctx service.EventProp
 		connRecord.TheirDID = request.DID
 	}
 
+	connRecord.TheirDID = s.ctx.interop.QualifyDID(connRecord.TheirDID)
+
+	s.addInvitationMetadata(connRecord)
+
 	if err := s.connectionRecorder.SaveConnectionRecord(connRecord); err != nil {
 		return nil, err
 	}
@@ -828,6 +944,52 @@ func (s *Service) requestMsgRecord(msg service.DIDCommMsg, ctx service.EventProp
 	return connRecord, nil
 }
 
+// addInvitationMetadata adds the alias, goal and keys of the invitation created by the agent to the connection
+// record of the request received for it. The metadata is optional, the failures to read it are only logged.
+func (s *Service) addInvitationMetadata(connRecord *connection.Record) {
+	if isDID(connRecord.InvitationID) {
+		return
+	}
+
+	alias, err := s.connectionRecorder.GetInvitationAlias(connRecord.InvitationID)
+	if err == nil {
+		connRecord.Alias = alias
+	} else if !errors.Is(err, storage.ErrDataNotFound) {
+		logger.Warnf("failed to get the alias of the invitation %s: %s", connRecord.InvitationID, err)
+	}
+
+	var oobInvitation OOBInvitation
+
+	err = s.connectionRecorder.GetInvitation(connRecord.InvitationID, &oobInvitation)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			logger.Warnf("failed to get the invitation %s: %s", connRecord.InvitationID, err)
+		}
+
+		return
+	}
+
+	if oobInvitation.Type != oobMsgType {
+		var invitation Invitation
+
+		if err = s.connectionRecorder.GetInvitation(connRecord.InvitationID, &invitation); err == nil {
+			connRecord.InvitationKeys = invitation.RecipientKeys
+		}
+
+		return
+	}
+
+	connRecord.GoalCode = oobInvitation.GoalCode
+	connRecord.Goal = oobInvitation.Goal
+
+	// the keys of the public DIDs are not resolved here
+	if _, ok := oobInvitation.Target.(string); !ok {
+		if svc, errSvc := s.ctx.getServiceBlock(&oobInvitation); errSvc == nil {
+			connRecord.InvitationKeys = svc.RecipientKeys
+		}
+	}
+}
+
 func (s *Service) responseMsgRecord(payload service.DIDCommMsg) (*connection.Record, error) {
 	return s.fetchConnectionRecord(myNSPrefix, payload)
 }
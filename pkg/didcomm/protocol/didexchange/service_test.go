@@ -536,6 +536,10 @@ func TestService_Accept(t *testing.T) {
 	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/request"))
 	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/response"))
 	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/ack"))
+	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.1/request"))
+	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.1/response"))
+	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.1/complete"))
+	require.Equal(t, false, s.Accept("https://didcomm.org/didexchange/1.1/invitation"))
 	require.Equal(t, false, s.Accept("unsupported msg type"))
 }
 
@@ -630,6 +634,12 @@ func TestService_Update(t *testing.T) {
 
 	require.NoError(t, svc.update(RequestMsgType, connRecord))
 
+	// the recorder stamps the record when saving it
+	require.False(t, connRecord.CreatedAt.IsZero())
+	require.Equal(t, connRecord.CreatedAt, connRecord.UpdatedAt)
+
+	connRecord.CreatedAt, connRecord.UpdatedAt = time.Time{}, time.Time{}
+
 	cr := &connection.Record{}
 	err = json.Unmarshal(bytes, cr)
 	require.NoError(t, err)
@@ -1192,9 +1202,14 @@ func TestInvitationRecord(t *testing.T) {
 	msg, err := service.ParseDIDCommMsgMap(invitationBytes)
 	require.NoError(t, err)
 
+	msg.Metadata()[AliasMetadataKey] = "Alice"
+
 	conn, err := svc.invitationMsgRecord(msg)
 	require.NoError(t, err)
 	require.NotNil(t, conn)
+	require.Equal(t, "Alice", conn.Alias)
+	require.Equal(t, []string{verPubKey}, conn.InvitationKeys)
+	require.False(t, conn.CreatedAt.IsZero())
 
 	// invalid thread id
 	invitationBytes, err = json.Marshal(&Invitation{
@@ -1368,6 +1383,48 @@ func TestRequestRecord(t *testing.T) {
 		require.Contains(t, err.Error(), "save connection record")
 	})
 
+	t.Run("adds the metadata of the invitation", func(t *testing.T) {
+		svc, err := New(&protocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		invitation := &Invitation{
+			Type:          InvitationMsgType,
+			ID:            uuid.New().String(),
+			RecipientKeys: []string{"did:key:1234567"},
+		}
+		require.NoError(t, svc.connectionRecorder.SaveInvitation(invitation.ID, invitation))
+		require.NoError(t, svc.connectionRecorder.SaveInvitationAlias(invitation.ID, "Bob"))
+
+		conn, err := svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{},
+			randomString(), invitation.ID), service.EmptyDIDCommContext())
+		require.NoError(t, err)
+		require.Equal(t, "Bob", conn.Alias)
+		require.Equal(t, invitation.RecipientKeys, conn.InvitationKeys)
+		require.Empty(t, conn.GoalCode)
+
+		oobInvitation := newOOBInvite(&did.Service{
+			ID:              uuid.New().String(),
+			Type:            "did-communication",
+			RecipientKeys:   []string{"did:key:7654321"},
+			ServiceEndpoint: "http://example.com",
+		})
+		oobInvitation.GoalCode = "issue-vc"
+		oobInvitation.Goal = "To issue a credential"
+		require.NoError(t, svc.SaveInvitation(oobInvitation))
+
+		conn, err = svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{},
+			randomString(), oobInvitation.ThreadID), service.EmptyDIDCommContext())
+		require.NoError(t, err)
+		require.Empty(t, conn.Alias)
+		require.Equal(t, "issue-vc", conn.GoalCode)
+		require.Equal(t, "To issue a credential", conn.Goal)
+		require.Equal(t, []string{"did:key:7654321"}, conn.InvitationKeys)
+	})
+
 	t.Run("fails if parent thread ID is missing", func(t *testing.T) {
 		svc, err := New(&protocol.MockProvider{
 			ServiceMap: map[string]interface{}{
@@ -2162,6 +2219,23 @@ func TestSave(t *testing.T) {
 	})
 }
 
+func TestEventPropertiesMetadata(t *testing.T) {
+	s, err := New(testProvider())
+	require.NoError(t, err)
+
+	connRec := &connection.Record{ConnectionID: "conn1", ThreadID: "thid1", State: StateIDCompleted}
+	require.NoError(t, s.connectionRecorder.SaveConnectionRecord(connRec))
+
+	require.Nil(t, s.createEventProperties("conn1", "").Metadata())
+	require.Nil(t, s.createEventProperties("", "").Metadata())
+
+	metadata := &connection.Metadata{DisplayName: "Alice", Tags: []string{"friend"}}
+	require.NoError(t, s.connectionRecorder.SaveMetadata("conn1", metadata))
+
+	require.Equal(t, metadata, s.createEventProperties("conn1", "").Metadata())
+	require.Equal(t, metadata, s.createErrorEventProperties("conn1", "", errors.New("error")).All()["metadata"])
+}
+
 func newInvitation(target interface{}) *OOBInvitation {
 	return &OOBInvitation{
 		ID:         uuid.New().String(),
@@ -18,13 +18,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
-	"github.com/mitchellh/mapstructure"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
@@ -57,6 +59,7 @@ const (
 	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
 	bls12381G2Key2020          = "Bls12381G2Key2020"
 	jsonWebKey2020             = "JsonWebKey2020"
+	ecdsaSecp256k1Key2019      = "EcdsaSecp256k1VerificationKey2019"
 )
 
 var errVerKeyNotFound = errors.New("verkey not found")
@@ -80,7 +83,7 @@ type state interface {
 
 // Returns the state towards which the protocol will transition to if the msgType is processed.
 func stateFromMsgType(msgType string) (state, error) {
-	switch msgType {
+	switch baseMsgType(msgType) {
 	case InvitationMsgType, oobMsgType:
 		return &invited{}, nil
 	case RequestMsgType:
@@ -180,7 +183,7 @@ func (s *requested) CanTransitionTo(next state) bool {
 
 func (s *requested) ExecuteInbound(msg *stateMachineMsg, thid string, ctx *context) (*connectionstore.Record,
 	state, stateAction, error) {
-	switch msg.Type() {
+	switch baseMsgType(msg.Type()) {
 	case oobMsgType:
 		action, record, err := ctx.handleInboundOOBInvitation(msg, thid, msg.options)
 		if err != nil {
@@ -222,7 +225,7 @@ func (s *responded) CanTransitionTo(next state) bool {
 
 func (s *responded) ExecuteInbound(msg *stateMachineMsg, thid string, ctx *context) (*connectionstore.Record,
 	state, stateAction, error) {
-	switch msg.Type() {
+	switch baseMsgType(msg.Type()) {
 	case RequestMsgType:
 		request := &Request{}
 
@@ -257,7 +260,7 @@ func (s *completed) CanTransitionTo(next state) bool {
 
 func (s *completed) ExecuteInbound(msg *stateMachineMsg, thid string, ctx *context) (*connectionstore.Record,
 	state, stateAction, error) {
-	switch msg.Type() {
+	switch baseMsgType(msg.Type()) {
 	case ResponseMsgType:
 		response := &Response{}
 
@@ -298,7 +301,7 @@ func (s *abandoned) ExecuteInbound(msg *stateMachineMsg, thid string, ctx *conte
 
 func (ctx *context) handleInboundOOBInvitation(
 	msg *stateMachineMsg, thid string, options *options) (stateAction, *connectionstore.Record, error) {
-	myDID, conn, err := ctx.getDIDDocAndConnection(getPublicDID(options), getRouterConnections(options))
+	myDID, conn, err := ctx.getDIDDocAndConnection(ctx.requesterDID(options), getRouterConnections(options))
 	if err != nil {
 		return nil, nil, fmt.Errorf("handleInboundOOBInvitation - failed to get diddoc and connection: %w", err)
 	}
@@ -324,6 +327,13 @@ func (ctx *context) handleInboundOOBInvitation(
 		},
 	}
 
+	if supportsV11(oobInvitation.Protocols) {
+		err = upgradeRequest(request, &oobInvitation)
+		if err != nil {
+			return nil, nil, fmt.Errorf("handleInboundOOBInvitation - failed to upgrade request: %w", err)
+		}
+	}
+
 	svc, err := ctx.getServiceBlock(&oobInvitation)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get service block: %w", err)
@@ -331,8 +341,8 @@ func (ctx *context) handleInboundOOBInvitation(
 
 	dest := &service.Destination{
 		RecipientKeys:   svc.RecipientKeys,
-		ServiceEndpoint: svc.ServiceEndpoint,
-		RoutingKeys:     svc.RoutingKeys,
+		ServiceEndpoint: svc.EndpointURI(),
+		RoutingKeys:     svc.EndpointRoutingKeys(),
 	}
 
 	recipientKey, err := recipientKey(myDID)
@@ -355,7 +365,7 @@ func (ctx *context) handleInboundInvitation(invitation *Invitation, thid string,
 	}
 
 	// get did document that will be used in exchange request
-	didDoc, conn, err := ctx.getDIDDocAndConnection(getPublicDID(options), getRouterConnections(options))
+	didDoc, conn, err := ctx.getDIDDocAndConnection(ctx.requesterDID(options), getRouterConnections(options))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -390,6 +400,11 @@ func (ctx *context) handleInboundRequest(request *Request, options *options,
 	connRec *connectionstore.Record) (stateAction, *connectionstore.Record, error) {
 	logger.Debugf("handling request: %+v", request)
 
+	err := ctx.verifyDocAttachment(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("handle inbound request: %w", err)
+	}
+
 	reqConn, err := getRequestConnection(request)
 	if err != nil {
 		return nil, nil, fmt.Errorf("extracting connection data from request: %w", err)
@@ -404,7 +419,8 @@ func (ctx *context) handleInboundRequest(request *Request, options *options,
 
 	// get did document that will be used in exchange response
 	// (my did doc)
-	responseDidDoc, connection, err := ctx.getDIDDocAndConnection(getPublicDID(options), getRouterConnections(options))
+	responseDidDoc, connection, err := ctx.getDIDDocAndConnection(ctx.responderDID(options),
+		getRouterConnections(options))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -423,6 +439,23 @@ func (ctx *context) handleInboundRequest(request *Request, options *options,
 			ID: request.ID,
 		},
 		ConnectionSignature: encodedConnectionSignature,
+		DID:                 connection.DID,
+	}
+
+	if isV11(request.Type) {
+		response.Type = ResponseMsgTypeV11
+	}
+
+	// the new DID doc (or the public DID) is attached signed with the invitation key as well,
+	// for the did-exchange 1.1 requesters
+	err = ctx.attachResponderDID(response, connection, encodedConnectionSignature.SignVerKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("handle inbound request: %w", err)
+	}
+
+	if connRec.GoalCode == "" {
+		connRec.GoalCode = request.GoalCode
+		connRec.Goal = request.Goal
 	}
 
 	connRec.TheirDID = reqConn.DID
@@ -434,6 +467,8 @@ func (ctx *context) handleInboundRequest(request *Request, options *options,
 		return nil, nil, err
 	}
 
+	connRec.MediaTypeProfile = ctx.mediaTypeProfile(destination, connRec)
+
 	senderVerKey, err := recipientKey(responseDidDoc)
 	if err != nil {
 		return nil, nil, fmt.Errorf("handle inbound request: %w", err)
@@ -453,6 +488,75 @@ func getPublicDID(options *options) string {
 	return options.publicDID
 }
 
+// responderDID returns the public DID given in the options, otherwise the public DID the requests are responded
+// with or the public DID of the agent (an empty string if a new peer DID is created for the connection).
+func (ctx *context) responderDID(options *options) string {
+	if pubDID := getPublicDID(options); pubDID != "" {
+		return pubDID
+	}
+
+	if ctx.publicDID != "" {
+		return ctx.publicDID
+	}
+
+	return ctx.profileDID
+}
+
+// requesterDID returns the public DID given in the options, otherwise the public DID of the agent (an empty string
+// if a new peer DID is created for the connection).
+func (ctx *context) requesterDID(options *options) string {
+	if pubDID := getPublicDID(options); pubDID != "" {
+		return pubDID
+	}
+
+	return ctx.profileDID
+}
+
+// mediaTypeProfile returns the media type profile negotiated from the accept list of the DIDComm service of the other
+// agent, or from the media types of the invitation (or of the request) of the connection if it has none.
+func (ctx *context) mediaTypeProfile(destination *service.Destination, connRec *connectionstore.Record) string {
+	accept := destination.MediaTypes
+	if len(accept) == 0 {
+		accept = connRec.MediaTypes
+	}
+
+	return transport.SelectMediaTypeProfile(accept, ctx.mediaTypeProfiles)
+}
+
+// supportsV11 returns true if did-exchange 1.1 is one of the handshake protocols of the invitation.
+func supportsV11(protocols []string) bool {
+	for _, p := range protocols {
+		if p == PIURIV11 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// upgradeRequest makes the request a did-exchange 1.1 request: its DID is given in the did attribute, its new
+// DID doc (if any) is attached and its goal is the goal of the invitation. The rfc 0160 connection attribute is
+// kept for the inviters still expecting it.
+func upgradeRequest(request *Request, invitation *OOBInvitation) error {
+	request.Type = RequestMsgTypeV11
+	request.DID = request.Connection.DID
+	request.GoalCode = invitation.GoalCode
+	request.Goal = invitation.Goal
+
+	if request.Connection.DIDDoc == nil {
+		return nil
+	}
+
+	docBytes, err := request.Connection.DIDDoc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("marshal did doc: %w", err)
+	}
+
+	request.DocAttach = newAttachment("application/json", docBytes)
+
+	return nil
+}
+
 func getRouterConnections(options *options) []string {
 	if options == nil {
 		return nil
@@ -525,6 +629,11 @@ func (ctx *context) getDIDDocAndConnection(pubDID string, routerConnections []st
 		return nil, nil, fmt.Errorf("failed to create and export public key: %w", err)
 	}
 
+	// Interop: advertise the services to the agents not supporting the did-communication service type
+	if ctx.interop != nil && ctx.interop.LegacyServiceType {
+		newDID.Service = append(newDID.Service, legacyServices(newDID)...)
+	}
+
 	// by default use peer did
 	docResolution, err := ctx.vdRegistry.Create(didMethod, newDID)
 	if err != nil {
@@ -559,6 +668,25 @@ func (ctx *context) getDIDDocAndConnection(pubDID string, routerConnections []st
 	return docResolution.DIDDocument, connection, nil
 }
 
+// legacyServices returns the copies of the services of the DID doc with the did:sov service type, whose recipient
+// key is the base58 key of the verification method.
+func legacyServices(didDoc *did.Doc) []did.Service {
+	recipientKey := base58.Encode(didDoc.VerificationMethod[0].Value)
+
+	services := make([]did.Service, len(didDoc.Service))
+
+	for i := range didDoc.Service {
+		services[i] = did.Service{
+			Type:            interop.LegacyDIDCommServiceType,
+			ServiceEndpoint: didDoc.Service[i].EndpointURI(),
+			RoutingKeys:     didDoc.Service[i].EndpointRoutingKeys(),
+			RecipientKeys:   []string{recipientKey},
+		}
+	}
+
+	return services
+}
+
 func createNewKeyAndVerificationMethod(didDoc *did.Doc, keyType kms.KeyType, keyManager kms.KeyManager) error {
 	vmType := getVerMethodType(keyType)
 
@@ -615,6 +743,9 @@ func convertPubKeyBytes(bytes []byte, keyType kms.KeyType) ([]byte, error) {
 	switch keyType {
 	case kms.ED25519Type, kms.BLS12381G2Type: // no conversion needed for non ECDSA keys.
 		return bytes, nil
+	case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363:
+		// secp256k1 keys are exported uncompressed for both types, did:key compresses them.
+		return bytes, nil
 	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363:
 		// truncate first byte to remove compression point.
 		return bytes[1:], nil
@@ -638,14 +769,16 @@ func convertPubKeyBytes(bytes []byte, keyType kms.KeyType) ([]byte, error) {
 
 // nolint:gochecknoglobals
 var vmType = map[kms.KeyType]string{
-	kms.ED25519Type:            ed25519VerificationKey2018,
-	kms.BLS12381G2Type:         bls12381G2Key2020,
-	kms.ECDSAP256TypeDER:       jsonWebKey2020,
-	kms.ECDSAP256TypeIEEEP1363: jsonWebKey2020,
-	kms.ECDSAP384TypeDER:       jsonWebKey2020,
-	kms.ECDSAP384TypeIEEEP1363: jsonWebKey2020,
-	kms.ECDSAP521TypeDER:       jsonWebKey2020,
-	kms.ECDSAP521TypeIEEEP1363: jsonWebKey2020,
+	kms.ED25519Type:                 ed25519VerificationKey2018,
+	kms.BLS12381G2Type:              bls12381G2Key2020,
+	kms.ECDSAP256TypeDER:            jsonWebKey2020,
+	kms.ECDSAP256TypeIEEEP1363:      jsonWebKey2020,
+	kms.ECDSAP384TypeDER:            jsonWebKey2020,
+	kms.ECDSAP384TypeIEEEP1363:      jsonWebKey2020,
+	kms.ECDSAP521TypeDER:            jsonWebKey2020,
+	kms.ECDSAP521TypeIEEEP1363:      jsonWebKey2020,
+	kms.ECDSASecp256k1TypeDER:       ecdsaSecp256k1Key2019,
+	kms.ECDSASecp256k1TypeIEEEP1363: ecdsaSecp256k1Key2019,
 }
 
 func getVerMethodType(kt kms.KeyType) string {
@@ -653,6 +786,9 @@ func getVerMethodType(kt kms.KeyType) string {
 }
 
 func (ctx *context) resolveDidDocFromConnection(conn *Connection) (*did.Doc, error) {
+	// Interop: the unqualified DIDs (e.g. of ACA-Py) are resolved and stored as did:sov DIDs
+	conn.DID = ctx.interop.QualifyDID(conn.DID)
+
 	didDoc := conn.DIDDoc
 	if didDoc == nil {
 		// did content was not provided; resolve
@@ -664,6 +800,8 @@ func (ctx *context) resolveDidDocFromConnection(conn *Connection) (*did.Doc, err
 		return docResolution.DIDDocument, err
 	}
 
+	didDoc.ID = ctx.interop.QualifyDID(didDoc.ID)
+
 	id, err := did.Parse(didDoc.ID)
 	if err != nil {
 		return nil, fmt.Errorf("resolveDidDocFromConnection: failed to parse DID [%s]: %w", didDoc.ID, err)
@@ -699,33 +837,145 @@ func (ctx *context) prepareConnectionSignature(connection *Connection,
 		return nil, fmt.Errorf("failed to get verkey: %w", err)
 	}
 
+	kh, _, err := ctx.signingKey(didKey)
+	if err != nil {
+		return nil, fmt.Errorf("prepareConnectionSignature: %w", err)
+	}
+
+	signature, err := ctx.crypto.Sign(concatenateSignData, kh)
+	if err != nil {
+		return nil, fmt.Errorf("sign response message: %w", err)
+	}
+
+	return &ConnectionSignature{
+		Type:       "https://didcomm.org/signature/1.0/ed25519Sha512_single",
+		SignedData: base64.URLEncoding.EncodeToString(concatenateSignData),
+		SignVerKey: didKey,
+		Signature:  base64.URLEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// signingKey returns the handle and the public key bytes of the ed25519 key of the did:key.
+func (ctx *context) signingKey(didKey string) (interface{}, []byte, error) {
 	pubKeyBytes, err := fingerprint.PubKeyFromDIDKey(didKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract pubKeyBytes from did:key [%s]: %w", didKey, err)
+		return nil, nil, fmt.Errorf("failed to extract pubKeyBytes from did:key [%s]: %w", didKey, err)
 	}
 
 	signingKID, err := localkms.CreateKID(pubKeyBytes, kms.ED25519Type)
 	if err != nil {
-		return nil, fmt.Errorf("prepareConnectionSignature: failed to generate KID from public key: %w", err)
+		return nil, nil, fmt.Errorf("failed to generate KID from public key: %w", err)
 	}
 
 	kh, err := ctx.kms.Get(signingKID)
 	if err != nil {
-		return nil, fmt.Errorf("prepareConnectionSignature: failed to get key handle: %w", err)
+		return nil, nil, fmt.Errorf("failed to get key handle: %w", err)
 	}
 
-	// TODO: Replace with signed attachments issue-626
-	signature, err := ctx.crypto.Sign(concatenateSignData, kh)
+	return kh, pubKeyBytes, nil
+}
+
+// newAttachment returns the attachment of the content encoded in base64.
+func newAttachment(mimeType string, content []byte) *decorator.Attachment {
+	return &decorator.Attachment{
+		ID:       uuid.New().String(),
+		MimeType: mimeType,
+		Data:     decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(content)},
+	}
+}
+
+// signedAttachment returns the attachment of the content signed with the key of the did:key.
+func (ctx *context) signedAttachment(mimeType string, content []byte, didKey string) (*decorator.Attachment, error) {
+	attachment := newAttachment(mimeType, content)
+
+	kh, pubKeyBytes, err := ctx.signingKey(didKey)
 	if err != nil {
-		return nil, fmt.Errorf("sign response message: %w", err)
+		return nil, err
 	}
 
-	return &ConnectionSignature{
-		Type:       "https://didcomm.org/signature/1.0/ed25519Sha512_single",
-		SignedData: base64.URLEncoding.EncodeToString(concatenateSignData),
-		SignVerKey: didKey,
-		Signature:  base64.URLEncoding.EncodeToString(signature),
-	}, nil
+	err = attachment.Data.Sign(ctx.crypto, kh, pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("sign attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// docAttachment returns the did_doc~attach of the DID doc signed with the key of the did:key.
+func (ctx *context) docAttachment(doc *did.Doc, didKey string) (*decorator.Attachment, error) {
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return nil, fmt.Errorf("marshal did doc: %w", err)
+	}
+
+	attachment, err := ctx.signedAttachment("application/json", docBytes, didKey)
+	if err != nil {
+		return nil, fmt.Errorf("did doc attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// attachResponderDID attaches the new DID doc of the responder to the response, or its DID (did_rotate~attach) if
+// it responds with a public DID, signed with the key of the did:key of the invitation.
+func (ctx *context) attachResponderDID(response *Response, connection *Connection, invitationKey string) error {
+	var err error
+
+	if connection.DIDDoc != nil {
+		response.DocAttach, err = ctx.docAttachment(connection.DIDDoc, invitationKey)
+
+		return err
+	}
+
+	response.DIDRotateAttach, err = ctx.signedAttachment("text/string", []byte(connection.DID), invitationKey)
+	if err != nil {
+		return fmt.Errorf("did rotate attachment: %w", err)
+	}
+
+	return nil
+}
+
+// verifyDocAttachment verifies the signature of the did_doc~attach of the request, if signed, and checks it is
+// signed by a key of the attached DID doc.
+func (ctx *context) verifyDocAttachment(request *Request) error {
+	if request.DocAttach == nil || request.DocAttach.Data.JWS == nil {
+		return nil
+	}
+
+	signer, err := request.DocAttach.Data.Verify(ctx.crypto, ctx.kms)
+	if err != nil {
+		return fmt.Errorf("verify did doc attachment: %w", err)
+	}
+
+	doc, err := docFromAttachment(request.DocAttach)
+	if err != nil {
+		return err
+	}
+
+	if !isDocKey(doc, signer) {
+		return fmt.Errorf("did doc attachment is not signed by a key of %s", doc.ID)
+	}
+
+	return nil
+}
+
+// isDocKey returns true if the did:key is a recipient key or the key of a verification method of the DID doc.
+func isDocKey(doc *did.Doc, didKey string) bool {
+	for i := range doc.Service {
+		for _, recKey := range doc.Service[i].RecipientKeys {
+			if recKey == didKey {
+				return true
+			}
+		}
+	}
+
+	for _, vm := range doc.VerificationMethod {
+		if k, _ := fingerprint.CreateDIDKey(vm.Value); k == didKey { // nolint: errcheck
+			return true
+		}
+	}
+
+	return false
 }
 
 func (ctx *context) handleInboundResponse(response *Response) (stateAction, *connectionstore.Record, error) {
@@ -738,6 +988,10 @@ func (ctx *context) handleInboundResponse(response *Response) (stateAction, *con
 		},
 	}
 
+	if isV11(response.Type) {
+		ack.Type = CompleteMsgTypeV11
+	}
+
 	nsThID, err := connectionstore.CreateNamespaceKey(myNSPrefix, ack.Thread.ID)
 	if err != nil {
 		return nil, nil, err
@@ -748,23 +1002,25 @@ func (ctx *context) handleInboundResponse(response *Response) (stateAction, *con
 		return nil, nil, fmt.Errorf("get connection record: %w", err)
 	}
 
-	conn, err := verifySignature(response.ConnectionSignature, connRecord.RecipientKeys[0])
+	conn, err := ctx.responseConnection(response, connRecord.RecipientKeys[0])
 	if err != nil {
 		return nil, nil, err
 	}
 
-	connRecord.TheirDID = conn.DID
-
 	responseDidDoc, err := ctx.resolveDidDocFromConnection(conn)
 	if err != nil {
 		return nil, nil, fmt.Errorf("resolve did doc from exchange response connection: %w", err)
 	}
 
+	connRecord.TheirDID = conn.DID
+
 	destination, err := service.CreateDestination(responseDidDoc)
 	if err != nil {
 		return nil, nil, fmt.Errorf("prepare destination from response did doc: %w", err)
 	}
 
+	connRecord.MediaTypeProfile = ctx.mediaTypeProfile(destination, connRecord)
+
 	docResolution, err := ctx.vdRegistry.Resolve(connRecord.MyDID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("fetching did document: %w", err)
@@ -780,6 +1036,65 @@ func (ctx *context) handleInboundResponse(response *Response) (stateAction, *con
 	}, connRecord, nil
 }
 
+// responseConnection returns the connection of the response signed with the invitation key: the connection~sig
+// (rfc 0160 connection protocol) if present, the did_doc~attach otherwise.
+func (ctx *context) responseConnection(response *Response, invitationKey string) (*Connection, error) {
+	if response.ConnectionSignature != nil {
+		return verifySignature(response.ConnectionSignature, invitationKey)
+	}
+
+	switch {
+	case response.DocAttach != nil:
+		err := ctx.verifyInvitationSignature(response.DocAttach, invitationKey)
+		if err != nil {
+			return nil, fmt.Errorf("did doc attachment: %w", err)
+		}
+
+		doc, err := docFromAttachment(response.DocAttach)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Connection{DID: response.DID, DIDDoc: doc}, nil
+	case response.DIDRotateAttach != nil:
+		err := ctx.verifyInvitationSignature(response.DIDRotateAttach, invitationKey)
+		if err != nil {
+			return nil, fmt.Errorf("did rotate attachment: %w", err)
+		}
+
+		rotated, err := base64.StdEncoding.DecodeString(pad(response.DIDRotateAttach.Data.Base64))
+		if err != nil {
+			return nil, fmt.Errorf("did rotate attachment: failed to parse base64 attachment data: %w", err)
+		}
+
+		if string(rotated) != response.DID {
+			return nil, fmt.Errorf("did rotate attachment: %s is not the DID of the response", rotated)
+		}
+
+		return &Connection{DID: response.DID}, nil
+	default:
+		return nil, fmt.Errorf("missing connection~sig, did_doc~attach or did_rotate~attach from response")
+	}
+}
+
+// verifyInvitationSignature verifies the attachment is signed with the key of the did:key of the invitation.
+func (ctx *context) verifyInvitationSignature(attachment *decorator.Attachment, invitationKey string) error {
+	if attachment.Data.JWS == nil {
+		return fmt.Errorf("not signed")
+	}
+
+	signer, err := attachment.Data.Verify(ctx.crypto, ctx.kms)
+	if err != nil {
+		return fmt.Errorf("verify signature: %w", err)
+	}
+
+	if signer != invitationKey {
+		return fmt.Errorf("not signed by the invitation key")
+	}
+
+	return nil
+}
+
 // verifySignature verifies connection signature and returns connection.
 func verifySignature(connSignature *ConnectionSignature, recipientKeys string) (*Connection, error) {
 	sigData, err := base64.URLEncoding.DecodeString(connSignature.SignedData)
@@ -933,19 +1248,12 @@ func (ctx *context) getServiceBlock(i *OOBInvitation) (*did.Service, error) {
 	case *did.Service:
 		block = svc
 	case map[string]interface{}:
-		var s did.Service
-
-		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &s})
-		if err != nil {
-			return nil, fmt.Errorf("failed to initialize decoder : %w", err)
-		}
-
-		err = decoder.Decode(svc)
+		s, err := did.ParseService(svc)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode service block : %w", err)
 		}
 
-		block = &s
+		block = s
 	default:
 		return nil, fmt.Errorf("unsupported target type: %+v", svc)
 	}
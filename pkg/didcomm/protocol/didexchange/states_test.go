@@ -20,10 +20,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -360,6 +362,37 @@ func TestRequestedState_Execute(t *testing.T) {
 		require.NoError(t, err)
 		require.True(t, dispatched)
 	})
+	t.Run("handle inbound oob invitations with did-exchange 1.1", func(t *testing.T) {
+		var request *Request
+
+		ctx := getContext(t, &prov)
+		ctx.outboundDispatcher = &mockdispatcher.MockOutbound{
+			ValidateSend: func(msg interface{}, _ string, _ *service.Destination) error {
+				var ok bool
+				request, ok = msg.(*Request)
+				require.True(t, ok)
+				return nil
+			},
+		}
+		inv := newOOBInvite(newServiceBlock())
+		inv.Protocols = []string{PIURIV11, PIURI}
+		inv.GoalCode = "issue-vc"
+		inv.Goal = "To issue a credential"
+		_, _, action, err := (&requested{}).ExecuteInbound(&stateMachineMsg{
+			DIDCommMsg: service.NewDIDCommMsgMap(inv),
+			connRecord: &connection.Record{},
+		}, "", ctx)
+		require.NoError(t, err)
+		require.NoError(t, action())
+		require.Equal(t, RequestMsgTypeV11, request.Type)
+		require.Equal(t, "issue-vc", request.GoalCode)
+		require.Equal(t, "To issue a credential", request.Goal)
+		require.Equal(t, request.Connection.DID, request.DID)
+
+		doc, err := docFromAttachment(request.DocAttach)
+		require.NoError(t, err)
+		require.Equal(t, request.DID, doc.ID)
+	})
 	t.Run("handle inbound oob invitations - register recipient keys in router", func(t *testing.T) {
 		expected := "my test key"
 		registered := false
@@ -970,6 +1003,26 @@ func TestNewRequestFromInvitation(t *testing.T) {
 		require.NotNil(t, connRecord.MyDID)
 		require.Equal(t, connRecord.MyDID, doc.ID)
 	})
+	t.Run("successful request to invitation with the public DID of the agent", func(t *testing.T) {
+		prov := getProvider(t)
+		doc := createDIDDoc(t, prov.CustomKMS)
+		ctx := getContext(t, &prov)
+		ctx.profileDID = doc.ID
+		ctx.vdRegistry = &mockvdr.MockVDRegistry{ResolveValue: doc}
+
+		_, connRecord, err := ctx.handleInboundInvitation(invitation, invitation.ID, &options{}, &connection.Record{})
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, connRecord.MyDID)
+
+		// the public DID given when the invitation is accepted is used instead
+		other := createDIDDoc(t, prov.CustomKMS)
+		ctx.vdRegistry = &mockvdr.MockVDRegistry{ResolveValue: other}
+
+		_, connRecord, err = ctx.handleInboundInvitation(invitation, invitation.ID, &options{publicDID: other.ID},
+			&connection.Record{})
+		require.NoError(t, err)
+		require.Equal(t, other.ID, connRecord.MyDID)
+	})
 	t.Run("unsuccessful new request from invitation ", func(t *testing.T) {
 		prov := protocol.MockProvider{}
 		customKMS := newKMS(t, prov.StoreProvider)
@@ -999,6 +1052,76 @@ func TestNewResponseFromRequest(t *testing.T) {
 		require.NotNil(t, connRec.TheirDID)
 	})
 
+	t.Run("new response with the did doc attached signed with the invitation key", func(t *testing.T) {
+		ctx := getContext(t, &prov)
+		request, err := createRequest(t, ctx)
+		require.NoError(t, err)
+
+		var response *Response
+
+		ctx.outboundDispatcher = &mockdispatcher.MockOutbound{
+			ValidateSend: func(msg interface{}, _ string, _ *service.Destination) error {
+				var ok bool
+				response, ok = msg.(*Response)
+				require.True(t, ok)
+				return nil
+			},
+		}
+
+		action, _, err := ctx.handleInboundRequest(request, &options{}, &connection.Record{})
+		require.NoError(t, err)
+		require.NoError(t, action())
+		require.NotNil(t, response.DocAttach)
+
+		signer, err := response.DocAttach.Data.Verify(ctx.crypto, ctx.kms)
+		require.NoError(t, err)
+		require.Equal(t, response.ConnectionSignature.SignVerKey, signer)
+
+		doc, err := docFromAttachment(response.DocAttach)
+		require.NoError(t, err)
+		require.Equal(t, response.DID, doc.ID)
+	})
+
+	t.Run("did-exchange 1.1 response with the configured public DID", func(t *testing.T) {
+		ctx := getContext(t, &prov)
+		request, err := createRequest(t, ctx)
+		require.NoError(t, err)
+
+		request.Type = RequestMsgTypeV11
+		request.GoalCode = "issue-vc"
+
+		publicDoc := createDIDDoc(t, prov.CustomKMS)
+		ctx.publicDID = publicDoc.ID
+		ctx.vdRegistry = &mockvdr.MockVDRegistry{ResolveValue: publicDoc}
+
+		var response *Response
+
+		ctx.outboundDispatcher = &mockdispatcher.MockOutbound{
+			ValidateSend: func(msg interface{}, _ string, _ *service.Destination) error {
+				var ok bool
+				response, ok = msg.(*Response)
+				require.True(t, ok)
+				return nil
+			},
+		}
+
+		action, connRec, err := ctx.handleInboundRequest(request, &options{}, &connection.Record{})
+		require.NoError(t, err)
+		require.NoError(t, action())
+		require.Equal(t, publicDoc.ID, connRec.MyDID)
+		require.Equal(t, "issue-vc", connRec.GoalCode)
+		require.Equal(t, ResponseMsgTypeV11, response.Type)
+		require.Equal(t, publicDoc.ID, response.DID)
+		require.Nil(t, response.DocAttach)
+
+		conn, err := ctx.responseConnection(&Response{
+			DID:             response.DID,
+			DIDRotateAttach: response.DIDRotateAttach,
+		}, response.ConnectionSignature.SignVerKey)
+		require.NoError(t, err)
+		require.Equal(t, publicDoc.ID, conn.DID)
+	})
+
 	t.Run("unsuccessful new response from request due to get connection error", func(t *testing.T) {
 		ctx := getContext(t, &prov)
 		request, err := createRequest(t, ctx)
@@ -1098,6 +1221,116 @@ func TestHandleInboundResponse(t *testing.T) {
 	})
 }
 
+func TestResponseConnection(t *testing.T) {
+	prov := getProvider(t)
+	ctx := getContext(t, &prov)
+
+	invitationKey, encKey := newED25519AndX25519DIDKey(t, ctx.kms)
+	otherKey, _ := newED25519AndX25519DIDKey(t, ctx.kms)
+	doc := createDIDDocWithKey(otherKey, encKey)
+
+	attachment, err := ctx.docAttachment(doc, invitationKey)
+	require.NoError(t, err)
+
+	t.Run("did doc attached signed with the invitation key", func(t *testing.T) {
+		conn, err := ctx.responseConnection(&Response{DID: doc.ID, DocAttach: attachment}, invitationKey)
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, conn.DID)
+		require.Equal(t, doc.ID, conn.DIDDoc.ID)
+	})
+
+	t.Run("did doc attached signed with another key", func(t *testing.T) {
+		_, err := ctx.responseConnection(&Response{DID: doc.ID, DocAttach: attachment}, otherKey)
+		require.EqualError(t, err, "did doc attachment: not signed by the invitation key")
+	})
+
+	t.Run("did doc attached not signed", func(t *testing.T) {
+		unsigned := *attachment
+		unsigned.Data.JWS = nil
+
+		_, err := ctx.responseConnection(&Response{DID: doc.ID, DocAttach: &unsigned}, invitationKey)
+		require.EqualError(t, err, "did doc attachment: not signed")
+	})
+
+	t.Run("did rotated to another DID", func(t *testing.T) {
+		rotate, err := ctx.signedAttachment("text/string", []byte("did:example:other"), invitationKey)
+		require.NoError(t, err)
+
+		_, err = ctx.responseConnection(&Response{DID: doc.ID, DIDRotateAttach: rotate}, invitationKey)
+		require.EqualError(t, err, "did rotate attachment: did:example:other is not the DID of the response")
+	})
+
+	t.Run("no connection signature nor did doc attached", func(t *testing.T) {
+		_, err := ctx.responseConnection(&Response{}, invitationKey)
+		require.EqualError(t, err, "missing connection~sig, did_doc~attach or did_rotate~attach from response")
+	})
+}
+
+func TestVerifyDocAttachment(t *testing.T) {
+	prov := getProvider(t)
+	ctx := getContext(t, &prov)
+
+	verKey, encKey := newED25519AndX25519DIDKey(t, ctx.kms)
+	otherKey, _ := newED25519AndX25519DIDKey(t, ctx.kms)
+	doc := createDIDDocWithKey(verKey, encKey)
+
+	t.Run("did doc attached signed with its key", func(t *testing.T) {
+		attachment, err := ctx.docAttachment(doc, verKey)
+		require.NoError(t, err)
+
+		require.NoError(t, ctx.verifyDocAttachment(&Request{DID: doc.ID, DocAttach: attachment}))
+	})
+
+	t.Run("did doc attached signed with another key", func(t *testing.T) {
+		attachment, err := ctx.docAttachment(doc, otherKey)
+		require.NoError(t, err)
+
+		err = ctx.verifyDocAttachment(&Request{DID: doc.ID, DocAttach: attachment})
+		require.EqualError(t, err, "did doc attachment is not signed by a key of "+doc.ID)
+	})
+
+	t.Run("did doc attached with a tampered signature", func(t *testing.T) {
+		attachment, err := ctx.docAttachment(doc, verKey)
+		require.NoError(t, err)
+
+		attachment.Data.JWS.Signature = attachment.Data.JWS.Protected
+
+		err = ctx.verifyDocAttachment(&Request{DID: doc.ID, DocAttach: attachment})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify did doc attachment")
+	})
+
+	t.Run("did doc not attached", func(t *testing.T) {
+		require.NoError(t, ctx.verifyDocAttachment(&Request{}))
+	})
+}
+
+func TestMediaTypeProfile(t *testing.T) {
+	ctx := &context{}
+
+	t.Run("negotiated from the accept list of the destination", func(t *testing.T) {
+		profile := ctx.mediaTypeProfile(&service.Destination{
+			MediaTypes: []string{"didcomm/v3", transport.MediaTypeProfileDIDCommV2},
+		}, &connection.Record{MediaTypes: []string{transport.MediaTypeV1EncryptedEnvelope}})
+		require.Equal(t, transport.MediaTypeProfileDIDCommV2, profile)
+	})
+
+	t.Run("negotiated from the media types of the connection", func(t *testing.T) {
+		profile := ctx.mediaTypeProfile(&service.Destination{},
+			&connection.Record{MediaTypes: []string{transport.MediaTypeV1EncryptedEnvelope}})
+		require.Equal(t, transport.MediaTypeV1EncryptedEnvelope, profile)
+	})
+
+	t.Run("first profile of the agent", func(t *testing.T) {
+		ctx := &context{mediaTypeProfiles: []string{transport.MediaTypeProfileAIP2RFC587}}
+
+		profile := ctx.mediaTypeProfile(&service.Destination{
+			MediaTypes: []string{transport.MediaTypeProfileDIDCommV2},
+		}, &connection.Record{})
+		require.Equal(t, transport.MediaTypeProfileAIP2RFC587, profile)
+	})
+}
+
 func TestGetInvitationRecipientKey(t *testing.T) {
 	prov := getProvider(t)
 	ctx := getContext(t, &prov)
@@ -1224,6 +1457,36 @@ func TestGetDIDDocAndConnection(t *testing.T) {
 		require.NotNil(t, conn)
 		require.Equal(t, didDoc.ID, conn.DID)
 	})
+	t.Run("peer did with legacy service type", func(t *testing.T) {
+		connRec, err := connection.NewRecorder(&protocol.MockProvider{})
+		require.NoError(t, err)
+		didConnStore, err := didstore.NewConnectionStore(&protocol.MockProvider{})
+		require.NoError(t, err)
+
+		var created *diddoc.Doc
+
+		ctx := context{
+			kms: newKMS(t, mockstorage.NewMockStoreProvider()),
+			vdRegistry: &mockvdr.MockVDRegistry{
+				CreateFunc: func(_ string, doc *diddoc.Doc, _ ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+					created = doc
+
+					return &diddoc.DocResolution{DIDDocument: mockdiddoc.GetMockDIDDoc(t)}, nil
+				},
+			},
+			connectionRecorder: connRec,
+			connectionStore:    didConnStore,
+			routeSvc:           &mockroute.MockMediatorSvc{},
+			interop:            interop.ACAPy(),
+		}
+		_, _, err = ctx.getDIDDocAndConnection("", nil)
+		require.NoError(t, err)
+
+		require.Len(t, created.Service, 2)
+		require.Empty(t, created.Service[0].Type)
+		require.Equal(t, interop.LegacyDIDCommServiceType, created.Service[1].Type)
+		require.Equal(t, []string{base58.Encode(created.VerificationMethod[0].Value)}, created.Service[1].RecipientKeys)
+	})
 	t.Run("test create did doc - router service config error", func(t *testing.T) {
 		connRec, err := connection.NewRecorder(&protocol.MockProvider{})
 		require.NoError(t, err)
@@ -1265,6 +1528,44 @@ func TestGetDIDDocAndConnection(t *testing.T) {
 	})
 }
 
+func TestResolveDIDDocFromConnection(t *testing.T) {
+	const unqualifiedDID = "AyRHrP7u6rF1dKViGf5shA"
+
+	t.Run("unqualified did with interop mode", func(t *testing.T) {
+		var method string
+
+		ctx := &context{
+			vdRegistry: &mockvdr.MockVDRegistry{
+				CreateFunc: func(m string, doc *diddoc.Doc, _ ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+					method = m
+
+					return &diddoc.DocResolution{DIDDocument: doc}, nil
+				},
+			},
+			interop: interop.ACAPy(),
+		}
+
+		conn := &Connection{DID: unqualifiedDID, DIDDoc: &diddoc.Doc{ID: unqualifiedDID}}
+
+		doc, err := ctx.resolveDidDocFromConnection(conn)
+		require.NoError(t, err)
+		require.Equal(t, "did:sov:"+unqualifiedDID, conn.DID)
+		require.Equal(t, "did:sov:"+unqualifiedDID, doc.ID)
+		require.Equal(t, interop.SovMethod, method)
+	})
+
+	t.Run("unqualified did without interop mode", func(t *testing.T) {
+		ctx := &context{vdRegistry: &mockvdr.MockVDRegistry{}}
+
+		_, err := ctx.resolveDidDocFromConnection(&Connection{
+			DID:    unqualifiedDID,
+			DIDDoc: &diddoc.Doc{ID: unqualifiedDID},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse DID")
+	})
+}
+
 func TestGetVerKey(t *testing.T) {
 	k := newKMS(t, mockstorage.NewMockStoreProvider())
 	t.Run("returns verkey from explicit oob invitation", func(t *testing.T) {
@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package engine
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+// customError is a wrapper to determine custom error against internal error.
+type customError struct{ error }
+
+// Action contains helpful information about action.
+type Action struct {
+	// Protocol instance ID
+	PIID     string
+	Msg      service.DIDCommMsgMap
+	MyDID    string
+	TheirDID string
+}
+
+// transitionalPayload keeps payload needed for Continue function to proceed with the action.
+type transitionalPayload struct {
+	Action
+	StateName string
+	Data      map[string]interface{} `json:",omitempty"`
+}
+
+// Metadata is the data of the protocol instance available to the states and middlewares.
+type Metadata struct {
+	transitionalPayload
+	state      State
+	msgClone   service.DIDCommMsg
+	properties map[string]interface{}
+	// err is used to determine whether callback was stopped
+	// e.g the user received an action event and executes Stop(err) function
+	// in that case `err` is equal to `err` which was passing to Stop function
+	err error
+}
+
+// Message contains the original inbound/outbound message.
+func (md *Metadata) Message() service.DIDCommMsg {
+	return md.msgClone
+}
+
+// StateName provides the state name.
+func (md *Metadata) StateName() string {
+	return md.state.Name()
+}
+
+// Properties provides the possibility to set properties of the events.
+func (md *Metadata) Properties() map[string]interface{} {
+	return md.properties
+}
+
+// Get returns the value of the protocol instance data. The data is persisted (as JSON) with the state
+// of the protocol instance, the values read after the instance was resumed are the JSON decoded ones.
+func (md *Metadata) Get(key string) (interface{}, bool) {
+	v, ok := md.Data[key]
+
+	return v, ok
+}
+
+// Set sets the value of the protocol instance data.
+func (md *Metadata) Set(key string, value interface{}) {
+	if md.Data == nil {
+		md.Data = map[string]interface{}{}
+	}
+
+	md.Data[key] = value
+}
+
+// Opt describes option signature for the Continue function.
+type Opt func(md *Metadata)
+
+// WithData allows providing the protocol instance data through the Continue function.
+func WithData(key string, value interface{}) Opt {
+	return func(md *Metadata) {
+		md.Set(key, value)
+	}
+}
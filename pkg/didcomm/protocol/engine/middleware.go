@@ -0,0 +1,23 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package engine
+
+// Handler describes middleware interface.
+type Handler interface {
+	Handle(metadata *Metadata) error
+}
+
+// Middleware function receives next handler and returns handler that needs to be executed.
+type Middleware func(next Handler) Handler
+
+// HandlerFunc is a helper type which implements the middleware Handler interface.
+type HandlerFunc func(metadata *Metadata) error
+
+// Handle implements function to satisfy the Handler interface.
+func (hf HandlerFunc) Handle(metadata *Metadata) error {
+	return hf(metadata)
+}
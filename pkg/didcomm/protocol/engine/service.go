@@ -0,0 +1,541 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package engine provides the state machine plumbing of the DIDComm protocol services (states, transitions,
+// persistence of the protocol instances, action and message events, problem reports) so that custom protocols
+// only need to define their messages and states.
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	internalDataKey        = "internal_data_"
+	transitionalPayloadKey = "transitionalPayload_%s"
+)
+
+// nolint:gochecknoglobals
+var (
+	logger         = log.New("aries-framework/protocol/engine")
+	initialHandler = HandlerFunc(func(_ *Metadata) error {
+		return nil
+	})
+	errProtocolStopped = errors.New("protocol was stopped")
+)
+
+// Protocol defines the custom protocol run by the engine.
+type Protocol struct {
+	// Name of the protocol, it is also the name of the service and of its store.
+	Name string
+	// MsgTypes are the types of the messages of the protocol.
+	MsgTypes []string
+	// States of the protocol, they are looked up by name when the protocol instance is resumed.
+	// The engine states (Start, Done, Abandoned and NoOp) are not listed.
+	States []State
+	// NextState returns the state the message leads to, inbound is false for the messages sent by the agent.
+	// The problem report leads to the Abandoned state and is not passed to the function.
+	NextState func(msg service.DIDCommMsgMap, inbound bool) (State, error)
+	// ActionRequired returns true if the inbound message triggers an action event, the protocol instance
+	// is resumed by the Continue (or Stop) function of the event. Optional, no action events if nil.
+	ActionRequired func(msg service.DIDCommMsgMap) bool
+	// ProblemReportMsgType is the type of the problem report sent when the protocol is abandoned.
+	// Optional, no problem reports are sent if empty.
+	ProblemReportMsgType string
+}
+
+// Provider contains dependencies for the protocol and is typically created by using aries.Context().
+type Provider interface {
+	Messenger() service.Messenger
+	StorageProvider() storage.Provider
+}
+
+// Service runs the protocol instances of the custom protocol.
+type Service struct {
+	service.Action
+	service.Message
+	protocol   *Protocol
+	states     map[string]State
+	store      storage.Store
+	callbacks  chan *Metadata
+	messenger  service.Messenger
+	middleware Handler
+}
+
+// New returns the service running the custom protocol.
+func New(p Provider, protocol *Protocol) (*Service, error) {
+	if protocol == nil || protocol.Name == "" || protocol.NextState == nil {
+		return nil, errors.New("protocol name and next state function are mandatory")
+	}
+
+	states := map[string]State{}
+
+	for _, st := range protocol.States {
+		if isEngineState(st.Name()) {
+			return nil, fmt.Errorf("state name is reserved by the engine: %s", st.Name())
+		}
+
+		states[st.Name()] = st
+	}
+
+	store, err := p.StorageProvider().OpenStore(protocol.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.StorageProvider().SetStoreConfig(protocol.Name,
+		storage.StoreConfiguration{TagNames: []string{transitionalPayloadKey}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	svc := &Service{
+		protocol:   protocol,
+		states:     states,
+		messenger:  p.Messenger(),
+		store:      store,
+		callbacks:  make(chan *Metadata),
+		middleware: initialHandler,
+	}
+
+	// start the listener
+	go svc.startInternalListener()
+
+	return svc, nil
+}
+
+// Use allows providing middlewares.
+func (s *Service) Use(items ...Middleware) {
+	var handler Handler = initialHandler
+	for i := len(items) - 1; i >= 0; i-- {
+		handler = items[i](handler)
+	}
+
+	s.middleware = handler
+}
+
+// HandleInbound handles inbound message (custom protocol).
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	logger.Debugf("service.HandleInbound() input: msg=%+v myDID=%s theirDID=%s", msg, ctx.MyDID(), ctx.TheirDID())
+
+	msgMap := msg.Clone()
+
+	md, err := s.doHandle(msgMap, true)
+	if err != nil {
+		return "", fmt.Errorf("doHandle: %w", err)
+	}
+
+	md.MyDID = ctx.MyDID()
+	md.TheirDID = ctx.TheirDID()
+
+	// trigger action event based on message type for inbound messages
+	if s.protocol.ActionRequired != nil && s.protocol.ActionRequired(msgMap) {
+		aEvent := s.ActionEvent()
+		if aEvent == nil {
+			// throw error if there is no action event registered for inbound messages
+			return "", errors.New("no clients are registered to handle the message")
+		}
+
+		err = s.saveTransitionalPayload(md.PIID, md.transitionalPayload)
+		if err != nil {
+			return "", fmt.Errorf("save transitional payload: %w", err)
+		}
+		aEvent <- s.newDIDCommActionMsg(md)
+
+		return md.PIID, nil
+	}
+
+	// if no action event is triggered, continue the execution
+	return md.PIID, s.handle(md)
+}
+
+// HandleOutbound handles outbound message (custom protocol), it returns the protocol instance ID.
+// The message starting a new protocol instance gets a new ID (if missing) which is also the protocol instance ID.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	msgMap := msg.Clone()
+
+	if msgMap.ID() == "" {
+		if err := msgMap.SetID(uuid.New().String()); err != nil {
+			return "", fmt.Errorf("set message ID: %w", err)
+		}
+	}
+
+	md, err := s.doHandle(msgMap, false)
+	if err != nil {
+		return "", fmt.Errorf("doHandle: %w", err)
+	}
+
+	md.MyDID = myDID
+	md.TheirDID = theirDID
+
+	return md.PIID, s.handle(md)
+}
+
+func (s *Service) doHandle(msg service.DIDCommMsgMap, inbound bool) (*Metadata, error) {
+	piID, err := getPIID(msg)
+	if err != nil {
+		return nil, fmt.Errorf("piID: %w", err)
+	}
+
+	data, err := s.currentInternalData(piID)
+	if err != nil {
+		return nil, fmt.Errorf("current internal data: %w", err)
+	}
+
+	current := s.stateFromName(data.StateName)
+
+	var next State = &Abandoned{problemReportMsgType: s.protocol.ProblemReportMsgType}
+
+	if msg.Type() != s.protocol.ProblemReportMsgType {
+		next, err = s.protocol.NextState(msg, inbound)
+		if err != nil {
+			return nil, fmt.Errorf("nextState: %w", err)
+		}
+	}
+
+	if !canTransition(current, next) {
+		return nil, fmt.Errorf("invalid state transition: %s -> %s", current.Name(), next.Name())
+	}
+
+	return &Metadata{
+		transitionalPayload: transitionalPayload{
+			StateName: next.Name(),
+			Data:      data.Data,
+			Action: Action{
+				Msg:  msg,
+				PIID: piID,
+			},
+		},
+		properties: map[string]interface{}{},
+		state:      next,
+		msgClone:   msg.Clone(),
+	}, nil
+}
+
+// startInternalListener listens to messages in go channel for callback messages from clients.
+func (s *Service) startInternalListener() {
+	for md := range s.callbacks {
+		// if no error do handle
+		if md.err == nil {
+			md.err = s.handle(md)
+		}
+
+		// no error - continue
+		if md.err == nil {
+			continue
+		}
+
+		logger.Errorf("failed to handle msgID=%s : %s", md.Msg.ID(), md.err)
+
+		md.state = &Abandoned{Code: CodeInternalError, problemReportMsgType: s.protocol.ProblemReportMsgType}
+
+		if err := s.handle(md); err != nil {
+			logger.Errorf("listener handle: %s", err)
+		}
+	}
+}
+
+func (s *Service) handle(md *Metadata) error {
+	current := md.state
+
+	for !isNoOp(current) {
+		next, action, err := s.execute(current, md)
+		if err != nil {
+			return fmt.Errorf("execute: %w", err)
+		}
+
+		if !isNoOp(next) && !canTransition(current, next) {
+			return fmt.Errorf("invalid state transition: %s --> %s", current.Name(), next.Name())
+		}
+
+		data := &internalData{StateName: current.Name(), Data: md.Data}
+		if err := s.saveInternalData(md.PIID, data); err != nil {
+			return fmt.Errorf("failed to persist state %s: %w", current.Name(), err)
+		}
+
+		if err := action(s.messenger); err != nil {
+			return fmt.Errorf("action %s: %w", md.state.Name(), err)
+		}
+
+		current = next
+	}
+
+	return nil
+}
+
+func (s *Service) execute(next State, md *Metadata) (State, StateAction, error) {
+	md.state = next
+	s.sendMsgEvents(md, next.Name(), service.PreState)
+
+	defer s.sendMsgEvents(md, next.Name(), service.PostState)
+
+	md.properties = newEventProps(md).All()
+
+	if err := s.middleware.Handle(md); err != nil {
+		return nil, nil, fmt.Errorf("middleware: %w", err)
+	}
+
+	return next.Execute(md)
+}
+
+func getPIID(msg service.DIDCommMsg) (string, error) {
+	if pthID := msg.ParentThreadID(); pthID != "" {
+		return pthID, nil
+	}
+
+	return msg.ThreadID()
+}
+
+type internalData struct {
+	StateName string
+	Data      map[string]interface{} `json:",omitempty"`
+}
+
+func (s *Service) saveInternalData(piID string, data *internalData) error {
+	src, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Put(internalDataKey+piID, src)
+}
+
+func (s *Service) currentInternalData(piID string) (*internalData, error) {
+	src, err := s.store.Get(internalDataKey + piID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &internalData{StateName: StateNameStart}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var data *internalData
+	if err := json.Unmarshal(src, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// stateFromName returns the state by given name.
+func (s *Service) stateFromName(name string) State {
+	switch name {
+	case StateNameStart:
+		return &Start{}
+	case StateNameAbandoned:
+		return &Abandoned{problemReportMsgType: s.protocol.ProblemReportMsgType}
+	case StateNameDone:
+		return &Done{}
+	}
+
+	if st, ok := s.states[name]; ok {
+		return st
+	}
+
+	return &NoOp{}
+}
+
+func (s *Service) saveTransitionalPayload(id string, data transitionalPayload) error {
+	src, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal transitional payload: %w", err)
+	}
+
+	return s.store.Put(fmt.Sprintf(transitionalPayloadKey, id), src, storage.Tag{Name: transitionalPayloadKey})
+}
+
+func (s *Service) getTransitionalPayload(id string) (*transitionalPayload, error) {
+	src, err := s.store.Get(fmt.Sprintf(transitionalPayloadKey, id))
+	if err != nil {
+		return nil, fmt.Errorf("store get: %w", err)
+	}
+
+	t := &transitionalPayload{}
+
+	err = json.Unmarshal(src, t)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal transitional payload: %w", err)
+	}
+
+	return t, err
+}
+
+func (s *Service) deleteTransitionalPayload(id string) error {
+	return s.store.Delete(fmt.Sprintf(transitionalPayloadKey, id))
+}
+
+// Actions returns actions for the async usage.
+func (s *Service) Actions() ([]Action, error) {
+	records, err := s.store.Query(transitionalPayloadKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query store: %w", err)
+	}
+
+	defer storage.Close(records, logger)
+
+	var actions []Action
+
+	more, err := records.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next set of data from records: %w", err)
+	}
+
+	for more {
+		value, err := records.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value from records: %w", err)
+		}
+
+		var action Action
+		if errUnmarshal := json.Unmarshal(value, &action); errUnmarshal != nil {
+			return nil, fmt.Errorf("unmarshal: %w", errUnmarshal)
+		}
+
+		actions = append(actions, action)
+
+		more, err = records.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next set of data from records: %w", err)
+		}
+	}
+
+	return actions, nil
+}
+
+// ActionContinue allows proceeding with the action by the piID.
+func (s *Service) ActionContinue(piID string, opt Opt) error {
+	md, err := s.resume(piID)
+	if err != nil {
+		return err
+	}
+
+	if opt != nil {
+		opt(md)
+	}
+
+	s.processCallback(md)
+
+	return nil
+}
+
+// ActionStop allows stopping the action by the piID.
+func (s *Service) ActionStop(piID string, cErr error) error {
+	md, err := s.resume(piID)
+	if err != nil {
+		return err
+	}
+
+	if cErr == nil {
+		cErr = errProtocolStopped
+	}
+
+	md.err = customError{error: cErr}
+	s.processCallback(md)
+
+	return nil
+}
+
+// resume restores the metadata of the action waiting for the user.
+func (s *Service) resume(piID string) (*Metadata, error) {
+	tPayload, err := s.getTransitionalPayload(piID)
+	if err != nil {
+		return nil, fmt.Errorf("get transitional payload: %w", err)
+	}
+
+	if err := s.deleteTransitionalPayload(piID); err != nil {
+		return nil, fmt.Errorf("delete transitional payload: %w", err)
+	}
+
+	return &Metadata{
+		transitionalPayload: *tPayload,
+		state:               s.stateFromName(tPayload.StateName),
+		msgClone:            tPayload.Msg.Clone(),
+		properties:          map[string]interface{}{},
+	}, nil
+}
+
+func (s *Service) processCallback(md *Metadata) {
+	// pass the callback data to internal channel. This is created to unblock consumer go routine and wrap the callback
+	// channel internally.
+	s.callbacks <- md
+}
+
+// newDIDCommActionMsg creates new DIDCommAction message.
+func (s *Service) newDIDCommActionMsg(md *Metadata) service.DIDCommAction {
+	// create the message for the channel
+	// trigger the registered action event
+	return service.DIDCommAction{
+		ProtocolName: s.protocol.Name,
+		Message:      md.msgClone,
+		Continue: func(opt interface{}) {
+			if fn, ok := opt.(Opt); ok {
+				fn(md)
+			}
+
+			if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+				logger.Errorf("continue: delete transitional payload: %v", err)
+			}
+
+			s.processCallback(md)
+		},
+		Stop: func(cErr error) {
+			if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+				logger.Errorf("stop: delete transitional payload: %v", err)
+			}
+
+			if cErr == nil {
+				cErr = errProtocolStopped
+			}
+
+			md.err = customError{error: cErr}
+			s.processCallback(md)
+		},
+		Properties: newEventProps(md),
+	}
+}
+
+// sendMsgEvents triggers the message events.
+func (s *Service) sendMsgEvents(md *Metadata, stateID string, stateType service.StateMsgType) {
+	// trigger the message events
+	for _, handler := range s.MsgEvents() {
+		handler <- service.StateMsg{
+			ProtocolName: s.protocol.Name,
+			Type:         stateType,
+			Msg:          md.msgClone,
+			StateID:      stateID,
+			Properties:   newEventProps(md),
+		}
+	}
+}
+
+// Name returns service name.
+func (s *Service) Name() string {
+	return s.protocol.Name
+}
+
+// Accept msg checks the msg type.
+func (s *Service) Accept(msgType string) bool {
+	if msgType == s.protocol.ProblemReportMsgType && msgType != "" {
+		return true
+	}
+
+	for _, t := range s.protocol.MsgTypes {
+		if t == msgType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,412 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+// The question-answer protocol is the example of a custom protocol: the asker sends a question,
+// the user of the responder provides the answer through the Continue function of the action event.
+const (
+	qaName              = "question-answer"
+	qaSpec              = "https://example.com/question-answer/1.0/"
+	qaQuestionMsgType   = qaSpec + "question"
+	qaAnswerMsgType     = qaSpec + "answer"
+	qaProblemReportType = qaSpec + "problem-report"
+
+	answerKey = "answer"
+)
+
+type questionSent struct{}
+
+func (s *questionSent) Name() string { return "question-sent" }
+
+func (s *questionSent) CanTransitionTo(next State) bool { return next.Name() == "answer-received" }
+
+func (s *questionSent) Execute(md *Metadata) (State, StateAction, error) {
+	return &NoOp{}, func(messenger service.Messenger) error {
+		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
+	}, nil
+}
+
+type questionReceived struct{}
+
+func (s *questionReceived) Name() string { return "question-received" }
+
+func (s *questionReceived) CanTransitionTo(next State) bool { return next.Name() == "answer-sent" }
+
+func (s *questionReceived) Execute(md *Metadata) (State, StateAction, error) {
+	if _, ok := md.Get(answerKey); !ok {
+		return nil, nil, errors.New("answer was not provided")
+	}
+
+	return &answerSent{}, ZeroAction, nil
+}
+
+type answerSent struct{}
+
+func (s *answerSent) Name() string { return "answer-sent" }
+
+func (s *answerSent) CanTransitionTo(next State) bool { return next.Name() == StateNameDone }
+
+func (s *answerSent) Execute(md *Metadata) (State, StateAction, error) {
+	answer, _ := md.Get(answerKey)
+
+	return &Done{}, func(messenger service.Messenger) error {
+		return messenger.ReplyToMsg(md.Msg, service.DIDCommMsgMap{
+			"@type":   qaAnswerMsgType,
+			answerKey: answer,
+		}, md.MyDID, md.TheirDID)
+	}, nil
+}
+
+type answerReceived struct{}
+
+func (s *answerReceived) Name() string { return "answer-received" }
+
+func (s *answerReceived) CanTransitionTo(next State) bool { return next.Name() == StateNameDone }
+
+func (s *answerReceived) Execute(_ *Metadata) (State, StateAction, error) {
+	return &Done{}, ZeroAction, nil
+}
+
+func questionAnswer() *Protocol {
+	return &Protocol{
+		Name:     qaName,
+		MsgTypes: []string{qaQuestionMsgType, qaAnswerMsgType},
+		States:   []State{&questionSent{}, &questionReceived{}, &answerSent{}, &answerReceived{}},
+		NextState: func(msg service.DIDCommMsgMap, inbound bool) (State, error) {
+			switch {
+			case msg.Type() == qaQuestionMsgType && inbound:
+				return &questionReceived{}, nil
+			case msg.Type() == qaQuestionMsgType:
+				return &questionSent{}, nil
+			case msg.Type() == qaAnswerMsgType && inbound:
+				return &answerReceived{}, nil
+			}
+
+			return nil, fmt.Errorf("unrecognized msgType: %s", msg.Type())
+		},
+		ActionRequired: func(msg service.DIDCommMsgMap) bool {
+			return msg.Type() == qaQuestionMsgType
+		},
+		ProblemReportMsgType: qaProblemReportType,
+	}
+}
+
+type provider struct {
+	messenger       service.Messenger
+	storageProvider storage.Provider
+}
+
+func (p *provider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+func (p *provider) StorageProvider() storage.Provider {
+	return p.storageProvider
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		svc, err := New(&provider{storageProvider: mem.NewProvider()}, questionAnswer())
+		require.NoError(t, err)
+		require.Equal(t, qaName, svc.Name())
+		require.True(t, svc.Accept(qaQuestionMsgType))
+		require.True(t, svc.Accept(qaProblemReportType))
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("Invalid protocol", func(t *testing.T) {
+		_, err := New(&provider{storageProvider: mem.NewProvider()}, &Protocol{Name: qaName})
+		require.EqualError(t, err, "protocol name and next state function are mandatory")
+
+		protocol := questionAnswer()
+		protocol.States = append(protocol.States, &Done{})
+
+		_, err = New(&provider{storageProvider: mem.NewProvider()}, protocol)
+		require.EqualError(t, err, "state name is reserved by the engine: done")
+	})
+
+	t.Run("Error open store", func(t *testing.T) {
+		_, err := New(&provider{storageProvider: &mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}}, questionAnswer())
+		require.EqualError(t, err, "open error")
+	})
+}
+
+func TestService_QuestionAnswer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Alice asks
+	aliceMessenger := serviceMocks.NewMockMessenger(ctrl)
+	alice, err := New(&provider{messenger: aliceMessenger, storageProvider: mem.NewProvider()}, questionAnswer())
+	require.NoError(t, err)
+
+	// Bob answers
+	bobMessenger := serviceMocks.NewMockMessenger(ctrl)
+	bob, err := New(&provider{messenger: bobMessenger, storageProvider: mem.NewProvider()}, questionAnswer())
+	require.NoError(t, err)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, bob.RegisterActionEvent(actions))
+
+	bobEvents := make(chan service.StateMsg, 10)
+	require.NoError(t, bob.RegisterMsgEvent(bobEvents))
+
+	var question service.DIDCommMsgMap
+
+	aliceMessenger.EXPECT().Send(gomock.Any(), Alice, Bob).
+		Do(func(msg service.DIDCommMsgMap, _, _ string) error {
+			// the messenger sets the thread
+			question = msg.Clone()
+			question["~thread"] = map[string]interface{}{"thid": msg.ID()}
+
+			return nil
+		})
+
+	piid, err := alice.HandleOutbound(service.DIDCommMsgMap{"@type": qaQuestionMsgType}, Alice, Bob)
+	require.NoError(t, err)
+	require.NotEmpty(t, piid)
+	require.Equal(t, piid, question.ID())
+
+	bobPIID, err := bob.HandleInbound(question, service.NewDIDCommContext(Bob, Alice, nil))
+	require.NoError(t, err)
+	require.Equal(t, piid, bobPIID)
+
+	var answer service.DIDCommMsgMap
+
+	bobMessenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Bob, Alice).
+		Do(func(in, out service.DIDCommMsgMap, _, _ string) error {
+			answer = out.Clone()
+			answer["@id"] = "answer-id"
+			answer["~thread"] = map[string]interface{}{"thid": piid}
+
+			return nil
+		})
+
+	select {
+	case action := <-actions:
+		require.Equal(t, qaName, action.ProtocolName)
+		require.Equal(t, piid, action.Properties.All()["piid"])
+		action.Continue(WithData(answerKey, "42"))
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	waitForState(t, bobEvents, StateNameDone)
+	require.Equal(t, "42", answer[answerKey])
+
+	_, err = alice.HandleInbound(answer, service.NewDIDCommContext(Alice, Bob, nil))
+	require.NoError(t, err)
+
+	data, err := alice.currentInternalData(piid)
+	require.NoError(t, err)
+	require.Equal(t, StateNameDone, data.StateName)
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("No clients are registered", func(t *testing.T) {
+		svc, err := New(&provider{storageProvider: mem.NewProvider()}, questionAnswer())
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@id": "1", "@type": qaQuestionMsgType},
+			service.EmptyDIDCommContext())
+		require.EqualError(t, err, "no clients are registered to handle the message")
+	})
+
+	t.Run("Unrecognized message type", func(t *testing.T) {
+		svc, err := New(&provider{storageProvider: mem.NewProvider()}, questionAnswer())
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@id": "1", "@type": "unknown"},
+			service.EmptyDIDCommContext())
+		require.EqualError(t, err, "doHandle: nextState: unrecognized msgType: unknown")
+	})
+
+	t.Run("Invalid state transition", func(t *testing.T) {
+		svc, err := New(&provider{storageProvider: mem.NewProvider()}, questionAnswer())
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@id": "1", "@type": qaAnswerMsgType},
+			service.EmptyDIDCommContext())
+		require.NoError(t, err)
+
+		// the protocol instance is done
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@id": "1", "@type": qaAnswerMsgType},
+			service.EmptyDIDCommContext())
+		require.EqualError(t, err, "doHandle: invalid state transition: done -> answer-received")
+	})
+
+	t.Run("Problem report abandons the protocol instance", func(t *testing.T) {
+		svc, err := New(&provider{storageProvider: mem.NewProvider()}, questionAnswer())
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		// no messenger calls are expected, the problem report is not answered
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@id": "1", "@type": qaProblemReportType},
+			service.EmptyDIDCommContext())
+		require.NoError(t, err)
+
+		waitForState(t, events, StateNameAbandoned)
+	})
+
+	t.Run("Error save internal data", func(t *testing.T) {
+		svc, err := New(&provider{storageProvider: &mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+			Store:  map[string]mockstorage.DBEntry{},
+			ErrPut: errors.New("put error"),
+		}}}, questionAnswer())
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@id": "1", "@type": qaAnswerMsgType},
+			service.EmptyDIDCommContext())
+		require.EqualError(t, err, "failed to persist state answer-received: put error")
+	})
+}
+
+func TestService_ActionStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	svc, err := New(&provider{messenger: messenger, storageProvider: mem.NewProvider()}, questionAnswer())
+	require.NoError(t, err)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	events := make(chan service.StateMsg, 10)
+	require.NoError(t, svc.RegisterMsgEvent(events))
+
+	_, err = svc.HandleInbound(service.DIDCommMsgMap{"@id": "1", "@type": qaQuestionMsgType},
+		service.NewDIDCommContext(Bob, Alice, nil))
+	require.NoError(t, err)
+
+	list, err := svc.Actions()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "1", list[0].PIID)
+
+	messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Bob, Alice).
+		Do(func(_, out service.DIDCommMsgMap, _, _ string) error {
+			var report model.ProblemReport
+			require.NoError(t, out.Decode(&report))
+			require.Equal(t, qaProblemReportType, report.Type)
+			require.Equal(t, CodeRejectedError, report.Description.Code)
+
+			return nil
+		})
+
+	require.NoError(t, svc.ActionStop("1", nil))
+	waitForState(t, events, StateNameAbandoned)
+
+	list, err = svc.Actions()
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	err = svc.ActionStop("1", nil)
+	require.Contains(t, err.Error(), "get transitional payload")
+}
+
+func TestService_ActionContinue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	svc, err := New(&provider{messenger: messenger, storageProvider: mem.NewProvider()}, questionAnswer())
+	require.NoError(t, err)
+
+	require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction, 2)))
+
+	events := make(chan service.StateMsg, 10)
+	require.NoError(t, svc.RegisterMsgEvent(events))
+
+	var middlewareCalls []string
+
+	svc.Use(func(next Handler) Handler {
+		return HandlerFunc(func(md *Metadata) error {
+			middlewareCalls = append(middlewareCalls, md.StateName())
+			md.Properties()["custom"] = true
+
+			return next.Handle(md)
+		})
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@id": "1", "@type": qaQuestionMsgType},
+			service.NewDIDCommContext(Bob, Alice, nil))
+		require.NoError(t, err)
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Bob, Alice).Return(nil)
+
+		require.NoError(t, svc.ActionContinue("1", WithData(answerKey, "42")))
+		waitForState(t, events, StateNameDone)
+		require.Equal(t, []string{"question-received", "answer-sent", "done"}, middlewareCalls)
+	})
+
+	t.Run("Internal error sends the problem report", func(t *testing.T) {
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@id": "2", "@type": qaQuestionMsgType},
+			service.NewDIDCommContext(Bob, Alice, nil))
+		require.NoError(t, err)
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Bob, Alice).
+			Do(func(_, out service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, qaProblemReportType, out.Type())
+
+				return nil
+			})
+
+		// the answer is not provided
+		require.NoError(t, svc.ActionContinue("2", nil))
+		waitForState(t, events, StateNameAbandoned)
+	})
+
+	t.Run("Error get transitional payload", func(t *testing.T) {
+		err = svc.ActionContinue("unknown", nil)
+		require.Contains(t, err.Error(), "get transitional payload: store get")
+	})
+}
+
+func waitForState(t *testing.T, events chan service.StateMsg, stateID string) {
+	t.Helper()
+
+	for {
+		select {
+		case e := <-events:
+			if e.Type == service.PostState && e.StateID == stateID {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for state %s", stateID)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+const (
+	// StateNameStart is the name of the state of a protocol instance before the first message.
+	StateNameStart = "start"
+	// StateNameAbandoned is the name of the final state of a failed or stopped protocol instance.
+	StateNameAbandoned = "abandoned"
+	// StateNameDone is the name of the final state of a completed protocol instance.
+	StateNameDone = "done"
+	// StateNameNoop is the name of the state ending the execution of the states.
+	StateNameNoop = "noop"
+)
+
+const (
+	// CodeInternalError is the problem report code sent when the protocol failed.
+	CodeInternalError = "internal"
+	// CodeRejectedError is the problem report code sent when the protocol was stopped by the user.
+	CodeRejectedError = "rejected"
+)
+
+// StateAction is the network call of the state (e.g. sending the next message).
+type StateAction func(messenger service.Messenger) error
+
+// State is the state of the protocol.
+type State interface {
+	// Name of this state.
+	Name() string
+	// Whether this state allows transitioning into the next state.
+	// The transition to the abandoned state is always allowed.
+	CanTransitionTo(next State) bool
+	// Executes this state, returning a followup state to be immediately executed as well.
+	// The NoOp state should be returned if the state has no followup.
+	Execute(md *Metadata) (State, StateAction, error)
+}
+
+// ZeroAction is the action of the states without network call.
+func ZeroAction(service.Messenger) error { return nil }
+
+// Start is the state of a protocol instance before the first message, it can transition to any state of the protocol.
+type Start struct{}
+
+// Name of the state.
+func (s *Start) Name() string {
+	return StateNameStart
+}
+
+// CanTransitionTo returns true unless the next state is one of the engine states.
+func (s *Start) CanTransitionTo(next State) bool {
+	return !isEngineState(next.Name())
+}
+
+// Execute is not supported, the start state is never executed.
+func (s *Start) Execute(_ *Metadata) (State, StateAction, error) {
+	return nil, nil, fmt.Errorf("%s: is not implemented yet", s.Name())
+}
+
+// Abandoned is the final state of a failed or stopped protocol instance. If Code is set, the problem report
+// is sent to the other agent.
+type Abandoned struct {
+	Code string
+	// problemReportMsgType is the type of the problem report of the protocol.
+	problemReportMsgType string
+}
+
+// Name of the state.
+func (s *Abandoned) Name() string {
+	return StateNameAbandoned
+}
+
+// CanTransitionTo returns false, the state is final.
+func (s *Abandoned) CanTransitionTo(_ State) bool {
+	return false
+}
+
+// Execute sends the problem report if needed.
+func (s *Abandoned) Execute(md *Metadata) (State, StateAction, error) {
+	// if code is not provided it means we do not need to notify the another agent.
+	// if we received ProblemReport message no need to answer.
+	if s.Code == "" || s.problemReportMsgType == "" || md.Msg.Type() == s.problemReportMsgType {
+		return &NoOp{}, ZeroAction, nil
+	}
+
+	code := model.Code{Code: s.Code}
+
+	// if the protocol was stopped by the user we will set the rejected error code
+	if errors.As(md.err, &customError{}) {
+		code = model.Code{Code: CodeRejectedError}
+	}
+
+	return &NoOp{}, func(messenger service.Messenger) error {
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(&model.ProblemReport{
+			Type:        s.problemReportMsgType,
+			Description: code,
+		}), md.MyDID, md.TheirDID)
+	}, nil
+}
+
+// Done is the final state of a completed protocol instance.
+type Done struct{}
+
+// Name of the state.
+func (s *Done) Name() string {
+	return StateNameDone
+}
+
+// CanTransitionTo returns false, the state is final.
+func (s *Done) CanTransitionTo(_ State) bool {
+	return false
+}
+
+// Execute ends the execution.
+func (s *Done) Execute(_ *Metadata) (State, StateAction, error) {
+	return &NoOp{}, ZeroAction, nil
+}
+
+// NoOp ends the execution of the states, the protocol instance stays in the last executed state.
+type NoOp struct{}
+
+// Name of the state.
+func (s *NoOp) Name() string {
+	return StateNameNoop
+}
+
+// CanTransitionTo returns false.
+func (s *NoOp) CanTransitionTo(_ State) bool {
+	return false
+}
+
+// Execute is not supported.
+func (s *NoOp) Execute(_ *Metadata) (State, StateAction, error) {
+	return nil, nil, errors.New("cannot execute no-op")
+}
+
+func isNoOp(s State) bool {
+	_, ok := s.(*NoOp)
+	return ok
+}
+
+func isEngineState(name string) bool {
+	switch name {
+	case StateNameStart, StateNameAbandoned, StateNameDone, StateNameNoop:
+		return true
+	}
+
+	return false
+}
+
+// canTransition checks the transition, the abandoned state can be reached from any state but the final ones.
+func canTransition(current, next State) bool {
+	if next.Name() == StateNameAbandoned {
+		return current.Name() != StateNameAbandoned && current.Name() != StateNameDone
+	}
+
+	return current.CanTransitionTo(next)
+}
@@ -0,0 +1,42 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStart(t *testing.T) {
+	st := &Start{}
+	require.Equal(t, StateNameStart, st.Name())
+	require.True(t, st.CanTransitionTo(&questionSent{}))
+	require.False(t, st.CanTransitionTo(&Done{}))
+	require.False(t, st.CanTransitionTo(&NoOp{}))
+
+	_, _, err := st.Execute(&Metadata{})
+	require.EqualError(t, err, "start: is not implemented yet")
+}
+
+func TestNoOp(t *testing.T) {
+	st := &NoOp{}
+	require.Equal(t, StateNameNoop, st.Name())
+	require.False(t, st.CanTransitionTo(&Done{}))
+
+	_, _, err := st.Execute(&Metadata{})
+	require.EqualError(t, err, "cannot execute no-op")
+}
+
+func TestCanTransition(t *testing.T) {
+	require.True(t, canTransition(&Start{}, &Abandoned{}))
+	require.True(t, canTransition(&questionSent{}, &Abandoned{}))
+	require.False(t, canTransition(&Done{}, &Abandoned{}))
+	require.False(t, canTransition(&Abandoned{}, &Abandoned{}))
+	require.True(t, canTransition(&questionSent{}, &answerReceived{}))
+	require.False(t, canTransition(&questionSent{}, &answerSent{}))
+}
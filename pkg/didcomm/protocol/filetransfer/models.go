@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filetransfer
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Chunk carries one piece of the transferred file. The thread ID of the chunk identifies the transfer.
+type Chunk struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	// Index is the zero based position of the chunk in the file.
+	Index int `json:"index"`
+	// File describes the transferred file.
+	File *File `json:"file,omitempty"`
+	// Data holds the chunk contents along with their sha256 digest.
+	Data decorator.Attachment `json:"data~attach"`
+}
+
+// End is sent once all chunks of the file were sent and allows the receiver to verify the integrity of the file.
+type End struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	// Chunks is the total number of chunks of the file.
+	Chunks int `json:"chunks"`
+	// Size is the total size of the file in bytes.
+	Size int64 `json:"size"`
+	// Sha256 is the hex encoded sha256 digest of the whole file.
+	Sha256 string `json:"sha256"`
+}
+
+// Resume is sent by the receiver to ask the sender to send again chunks that were not received.
+type Resume struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	// Missing lists the indexes of the chunks that are missing.
+	Missing []int `json:"missing,omitempty"`
+	// From requests all chunks starting from the given index, followed by the end message.
+	From int `json:"from"`
+}
+
+// Ack is sent by the receiver once the whole file was received and verified.
+type Ack struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	Status string            `json:"status,omitempty"`
+}
+
+// File describes the transferred file.
+type File struct {
+	Name        string `json:"name,omitempty"`
+	MimeType    string `json:"mime-type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Transfer keeps the progress of a file transfer.
+type Transfer struct {
+	ID       string `json:"id"`
+	Role     string `json:"role"`
+	State    string `json:"state"`
+	MyDID    string `json:"my_did"`
+	TheirDID string `json:"their_did"`
+	File     File   `json:"file"`
+	// Chunks is the number of chunks of the file, known by the receiver once the end message was received.
+	Chunks int `json:"chunks"`
+	// Received holds the indexes of the chunks received so far (receiver only).
+	Received map[int]bool `json:"received,omitempty"`
+	// ReceivedSize is the number of bytes of the chunks received so far (receiver only).
+	ReceivedSize int64  `json:"received_size,omitempty"`
+	Size         int64  `json:"size"`
+	Sha256       string `json:"sha256,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
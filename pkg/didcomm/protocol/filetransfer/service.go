@@ -0,0 +1,999 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filetransfer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator/attachment"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// FileTransfer defines the protocol name.
+	FileTransfer = "filetransfer"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/file-transfer/1.0/"
+	// ChunkMsgType defines the protocol chunk message type.
+	ChunkMsgType = Spec + "chunk"
+	// EndMsgType defines the protocol end message type.
+	EndMsgType = Spec + "end"
+	// ResumeMsgType defines the protocol resume message type.
+	ResumeMsgType = Spec + "resume"
+	// AckMsgType defines the protocol ack message type.
+	AckMsgType = Spec + "ack"
+)
+
+const (
+	// RoleSender is the role of the agent sending the file.
+	RoleSender = "sender"
+	// RoleReceiver is the role of the agent receiving the file.
+	RoleReceiver = "receiver"
+
+	// StateSending the sender is streaming chunks.
+	StateSending = "sending"
+	// StateSent the sender has sent all chunks and waits for the ack.
+	StateSent = "sent"
+	// StateOffered the receiver got the first message of the transfer, the chunks are dropped until it is accepted.
+	StateOffered = "offered"
+	// StateDeclined the receiver declined the transfer.
+	StateDeclined = "declined"
+	// StateReceiving the receiver is collecting chunks.
+	StateReceiving = "receiving"
+	// StateCompleted the file was received and verified.
+	StateCompleted = "completed"
+	// StateFailed the received file failed the integrity check or exceeded the limits of the receiver.
+	StateFailed = "failed"
+)
+
+const (
+	// Namespace is namespace of file transfer store name.
+	Namespace = "filetransfer"
+
+	// DefaultChunkSize is the default size (64 KiB) of the file chunks sent in a single message.
+	DefaultChunkSize = 64 << 10
+	// DefaultMaxTransferSize is the default limit (1 GiB) on the size of the received files.
+	DefaultMaxTransferSize = 1 << 30
+	// DefaultMaxChunks is the default limit on the number of chunks of the received files.
+	DefaultMaxChunks = 1 << 14
+
+	transferTag        = "transfer"
+	transferKeyPattern = transferTag + "_%s"
+	chunkKeyPattern    = "chunk_%s_%d"
+
+	ackStatusOK       = "OK"
+	transferIDPropKey = "transferID"
+)
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrTransferNotFound transfer not found error.
+	ErrTransferNotFound = errors.New("transfer not found")
+	// ErrLimitExceeded is returned when an incoming transfer exceeds the size or chunk limits of the receiver.
+	ErrLimitExceeded = errors.New("file transfer limit exceeded")
+
+	logger = log.New("aries-framework/filetransfer")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+// Service for the file transfer protocol.
+type Service struct {
+	service.Action
+	service.Message
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	store            storage.Store
+	files            *attachment.Store
+	fetcher          *attachment.Fetcher
+	chunkSize        int
+	maxTransferSize  int64
+	maxChunks        int
+	linkHosts        []string
+	lock             sync.Mutex
+}
+
+// Option configures the file transfer service.
+type Option func(s *Service)
+
+// WithChunkSize sets the size of the file chunks sent in a single message.
+func WithChunkSize(size int) Option {
+	return func(s *Service) {
+		s.chunkSize = size
+	}
+}
+
+// WithMaxTransferSize sets the maximum size in bytes of the received files (defaults to DefaultMaxTransferSize).
+func WithMaxTransferSize(size int64) Option {
+	return func(s *Service) {
+		s.maxTransferSize = size
+	}
+}
+
+// WithMaxChunks sets the maximum number of chunks of the received files (defaults to DefaultMaxChunks).
+func WithMaxChunks(chunks int) Option {
+	return func(s *Service) {
+		s.maxChunks = chunks
+	}
+}
+
+// WithLinkHosts allows the chunks of the received files to be fetched from https links to the given hosts.
+// By default only the chunks with inline contents are accepted, the links supplied by the sender are not followed.
+func WithLinkHosts(hosts ...string) Option {
+	return func(s *Service) {
+		s.linkHosts = hosts
+	}
+}
+
+// New returns the file transfer service.
+func New(prov provider, opts ...Option) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("open file transfer store: %w", err)
+	}
+
+	err = prov.StorageProvider().SetStoreConfig(Namespace, storage.StoreConfiguration{TagNames: []string{transferTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set file transfer store configuration: %w", err)
+	}
+
+	files, err := attachment.NewStore(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := &Service{
+		outbound:         prov.OutboundDispatcher(),
+		connectionLookup: connectionLookup,
+		store:            store,
+		files:            files,
+		chunkSize:        DefaultChunkSize,
+		maxTransferSize:  DefaultMaxTransferSize,
+		maxChunks:        DefaultMaxChunks,
+	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	if svc.chunkSize <= 0 {
+		return nil, fmt.Errorf("invalid chunk size: %d", svc.chunkSize)
+	}
+
+	if svc.maxTransferSize <= 0 || svc.maxChunks <= 0 {
+		return nil, fmt.Errorf("invalid transfer limits: %d bytes, %d chunks", svc.maxTransferSize, svc.maxChunks)
+	}
+
+	// a chunk is never expected to be larger than what this agent would send
+	fetcherOpts := []attachment.Option{
+		attachment.WithMaxSize(int64(svc.chunkSize) * 2), // nolint: gomnd
+		attachment.WithAllowedSchemes(),
+	}
+
+	if len(svc.linkHosts) > 0 {
+		fetcherOpts = append(fetcherOpts, attachment.WithAllowedSchemes("https"),
+			attachment.WithAllowedHosts(svc.linkHosts...))
+	}
+
+	svc.fetcher = attachment.NewFetcher(fetcherOpts...)
+
+	return svc, nil
+}
+
+// HandleInbound handles inbound file transfer messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	var err error
+
+	switch msg.Type() {
+	case ChunkMsgType:
+		err = s.handleChunk(msg, ctx.MyDID(), ctx.TheirDID())
+	case EndMsgType:
+		err = s.handleEnd(msg, ctx.MyDID(), ctx.TheirDID())
+	case ResumeMsgType:
+		err = s.handleResume(msg)
+	case AckMsgType:
+		err = s.handleAck(msg)
+	default:
+		err = fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case ChunkMsgType, EndMsgType, ResumeMsgType, AckMsgType:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return FileTransfer
+}
+
+// NewWriter starts a file transfer over the given connection. The file is split into chunks that are sent
+// as they are written; closing the writer sends the end message that lets the receiver verify the file.
+func (s *Service) NewWriter(connectionID string, file File) (*Writer, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Transfer{
+		ID:       uuid.New().String(),
+		Role:     RoleSender,
+		State:    StateSending,
+		MyDID:    conn.MyDID,
+		TheirDID: conn.TheirDID,
+		File:     file,
+	}
+
+	if err = s.saveTransfer(t); err != nil {
+		return nil, err
+	}
+
+	return newWriter(s, t), nil
+}
+
+// Send streams the contents of r over the given connection and returns the transfer ID.
+func (s *Service) Send(connectionID string, file File, r io.Reader) (string, error) {
+	w, err := s.NewWriter(connectionID, file)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = io.Copy(w, r); err != nil {
+		return "", fmt.Errorf("send file: %w", err)
+	}
+
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+
+	return w.TransferID(), nil
+}
+
+// Resume asks the sender to send again the chunks of an incoming transfer that were not received.
+func (s *Service) Resume(transferID string) error {
+	s.lock.Lock()
+	t, err := s.Transfer(transferID)
+	s.lock.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if t.Role != RoleReceiver || t.State != StateReceiving {
+		return fmt.Errorf("transfer %s in role %s and state %s cannot be resumed", t.ID, t.Role, t.State)
+	}
+
+	// until the end message is received, the number of chunks is only known up to the last chunk received
+	last := t.Chunks - 1
+	if t.Sha256 == "" {
+		for i := range t.Received {
+			if i > last {
+				last = i
+			}
+		}
+	}
+
+	var missing []int
+
+	for i := 0; i <= last; i++ {
+		if !t.Received[i] {
+			missing = append(missing, i)
+		}
+	}
+
+	return s.outbound.SendToDID(&Resume{
+		Type:    ResumeMsgType,
+		ID:      uuid.New().String(),
+		Thread:  &decorator.Thread{ID: t.ID},
+		Missing: missing,
+		From:    last + 1,
+	}, t.MyDID, t.TheirDID)
+}
+
+// AcceptTransfer accepts an offered incoming transfer and asks the sender to send the file from its first chunk.
+func (s *Service) AcceptTransfer(transferID string) error {
+	t, err := s.acceptTransfer(transferID)
+	if err != nil {
+		return err
+	}
+
+	return s.outbound.SendToDID(&Resume{
+		Type:   ResumeMsgType,
+		ID:     uuid.New().String(),
+		Thread: &decorator.Thread{ID: t.ID},
+	}, t.MyDID, t.TheirDID)
+}
+
+func (s *Service) acceptTransfer(transferID string) (*Transfer, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	t, err := s.Transfer(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Role != RoleReceiver || t.State != StateOffered {
+		return nil, fmt.Errorf("transfer %s in role %s and state %s cannot be accepted", t.ID, t.Role, t.State)
+	}
+
+	t.State = StateReceiving
+
+	if err = s.saveTransfer(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// DeclineTransfer declines an offered incoming transfer or aborts an incoming transfer in progress,
+// the chunks received so far are deleted.
+func (s *Service) DeclineTransfer(transferID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	t, err := s.Transfer(transferID)
+	if err != nil {
+		return err
+	}
+
+	if t.Role != RoleReceiver || (t.State != StateOffered && t.State != StateReceiving) {
+		return fmt.Errorf("transfer %s in role %s and state %s cannot be declined", t.ID, t.Role, t.State)
+	}
+
+	if err = s.deleteReceived(t); err != nil {
+		return err
+	}
+
+	t.State = StateDeclined
+
+	return s.saveTransfer(t)
+}
+
+// Open returns a reader over the file of a completed incoming transfer.
+func (s *Service) Open(transferID string) (io.Reader, error) {
+	t, err := s.Transfer(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Role != RoleReceiver || t.State != StateCompleted {
+		return nil, fmt.Errorf("transfer %s in role %s and state %s has no file", t.ID, t.Role, t.State)
+	}
+
+	return s.files.Open(t.ID)
+}
+
+// Transfer returns the transfer with the given ID.
+func (s *Service) Transfer(transferID string) (*Transfer, error) {
+	bits, err := s.store.Get(fmt.Sprintf(transferKeyPattern, transferID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrTransferNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get transfer %s: %w", transferID, err)
+	}
+
+	t := &Transfer{}
+
+	if err = json.Unmarshal(bits, t); err != nil {
+		return nil, fmt.Errorf("unmarshal transfer %s: %w", transferID, err)
+	}
+
+	return t, nil
+}
+
+// Transfers returns all the transfers known by this agent.
+func (s *Service) Transfers() ([]*Transfer, error) {
+	iter, err := s.store.Query(transferTag)
+	if err != nil {
+		return nil, fmt.Errorf("query transfers: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var transfers []*Transfer
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("next transfer: %w", err)
+	}
+
+	for more {
+		bits, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("transfer value: %w", err)
+		}
+
+		t := &Transfer{}
+
+		if err = json.Unmarshal(bits, t); err != nil {
+			return nil, fmt.Errorf("unmarshal transfer: %w", err)
+		}
+
+		transfers = append(transfers, t)
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("next transfer: %w", err)
+		}
+	}
+
+	return transfers, nil
+}
+
+func (s *Service) handleChunk(msg service.DIDCommMsg, myDID, theirDID string) error {
+	chunk := &Chunk{}
+
+	if err := msg.Decode(chunk); err != nil {
+		return fmt.Errorf("chunk message unmarshal: %w", err)
+	}
+
+	if chunk.Thread == nil || chunk.Thread.ID == "" || chunk.Index < 0 {
+		return errors.New("chunk message: missing thread ID or invalid index")
+	}
+
+	data, err := s.fetcher.Fetch(context.Background(), &chunk.Data.Data)
+	if err != nil {
+		return fmt.Errorf("chunk %d: %w", chunk.Index, err)
+	}
+
+	offer, err := s.receiveChunk(chunk, data, msg, myDID, theirDID)
+	if err != nil {
+		return err
+	}
+
+	// the action event is sent once the lock is released, its handler accepts or declines the transfer
+	if offer != nil {
+		s.sendActionEvent(offer, msg)
+	}
+
+	return nil
+}
+
+// receiveChunk stores the chunk of an accepted transfer. It returns the transfer when the chunk offers a new one.
+func (s *Service) receiveChunk(chunk *Chunk, data []byte, msg service.DIDCommMsg,
+	myDID, theirDID string) (*Transfer, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	t, isNew, err := s.incomingTransfer(chunk.Thread.ID, myDID, theirDID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chunk.Index >= s.maxChunks || (t.Sha256 != "" && chunk.Index >= t.Chunks) {
+		return nil, fmt.Errorf("chunk %d of transfer %s is out of range", chunk.Index, t.ID)
+	}
+
+	if chunk.File != nil {
+		t.File = *chunk.File
+	}
+
+	switch {
+	case t.State == StateOffered:
+		// the chunks are sent again by the sender once the transfer is accepted
+		if !isNew {
+			return nil, nil
+		}
+
+		if err = s.saveTransfer(t); err != nil {
+			return nil, err
+		}
+
+		return t, nil
+	case t.State != StateReceiving || t.Received[chunk.Index]:
+		return nil, nil
+	}
+
+	if t.ReceivedSize+int64(len(data)) > s.maxTransferSize {
+		return nil, s.fail(t, msg, fmt.Errorf("%w: more than %d bytes received", ErrLimitExceeded, s.maxTransferSize))
+	}
+
+	if err = s.store.Put(fmt.Sprintf(chunkKeyPattern, t.ID, chunk.Index), data); err != nil {
+		return nil, fmt.Errorf("store chunk %d: %w", chunk.Index, err)
+	}
+
+	t.Received[chunk.Index] = true
+	t.ReceivedSize += int64(len(data))
+
+	return nil, s.complete(t, msg)
+}
+
+func (s *Service) handleEnd(msg service.DIDCommMsg, myDID, theirDID string) error {
+	end := &End{}
+
+	if err := msg.Decode(end); err != nil {
+		return fmt.Errorf("end message unmarshal: %w", err)
+	}
+
+	if end.Thread == nil || end.Thread.ID == "" {
+		return errors.New("end message: missing thread ID")
+	}
+
+	offer, err := s.receiveEnd(end, msg, myDID, theirDID)
+	if err != nil {
+		return err
+	}
+
+	if offer != nil {
+		s.sendActionEvent(offer, msg)
+	}
+
+	return nil
+}
+
+// receiveEnd records the end message, it returns the transfer when the message offers a new one (e.g. empty file).
+func (s *Service) receiveEnd(end *End, msg service.DIDCommMsg, myDID, theirDID string) (*Transfer, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	t, isNew, err := s.incomingTransfer(end.Thread.ID, myDID, theirDID)
+	if err != nil || (t.State != StateOffered && t.State != StateReceiving) {
+		return nil, err
+	}
+
+	if end.Chunks < 0 || end.Chunks > s.maxChunks || end.Size < 0 || end.Size > s.maxTransferSize {
+		return nil, s.fail(t, msg,
+			fmt.Errorf("%w: %d bytes in %d chunks announced", ErrLimitExceeded, end.Size, end.Chunks))
+	}
+
+	for i := range t.Received {
+		if i >= end.Chunks {
+			return nil, s.fail(t, msg, fmt.Errorf("chunk %d received but %d chunks announced", i, end.Chunks))
+		}
+	}
+
+	t.Chunks = end.Chunks
+	t.Size = end.Size
+	t.Sha256 = end.Sha256
+
+	if t.State == StateReceiving {
+		return nil, s.complete(t, msg)
+	}
+
+	if err = s.saveTransfer(t); err != nil || !isNew {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *Service) handleResume(msg service.DIDCommMsg) error {
+	resume := &Resume{}
+
+	if err := msg.Decode(resume); err != nil {
+		return fmt.Errorf("resume message unmarshal: %w", err)
+	}
+
+	if resume.Thread == nil {
+		return errors.New("resume message: missing thread ID")
+	}
+
+	t, err := s.Transfer(resume.Thread.ID)
+	if err != nil {
+		return err
+	}
+
+	if t.Role != RoleSender || t.State == StateCompleted {
+		logger.Debugf("ignoring resume for transfer %s in role %s and state %s", t.ID, t.Role, t.State)
+
+		return nil
+	}
+
+	indexes := map[int]bool{}
+
+	for _, i := range resume.Missing {
+		indexes[i] = true
+	}
+
+	for i := resume.From; i < t.Chunks; i++ {
+		indexes[i] = true
+	}
+
+	sorted := make([]int, 0, len(indexes))
+
+	for i := range indexes {
+		sorted = append(sorted, i)
+	}
+
+	sort.Ints(sorted)
+
+	for _, i := range sorted {
+		data, err := s.store.Get(fmt.Sprintf(chunkKeyPattern, t.ID, i))
+		if errors.Is(err, storage.ErrDataNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("get chunk %d: %w", i, err)
+		}
+
+		if err = s.sendChunk(t, i, data); err != nil {
+			return err
+		}
+	}
+
+	if t.State != StateSent {
+		return nil
+	}
+
+	return s.sendEnd(t)
+}
+
+func (s *Service) handleAck(msg service.DIDCommMsg) error {
+	ack := &Ack{}
+
+	if err := msg.Decode(ack); err != nil {
+		return fmt.Errorf("ack message unmarshal: %w", err)
+	}
+
+	if ack.Thread == nil {
+		return errors.New("ack message: missing thread ID")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	t, err := s.Transfer(ack.Thread.ID)
+	if err != nil {
+		return err
+	}
+
+	if t.Role != RoleSender || t.State == StateCompleted {
+		return nil
+	}
+
+	t.State = StateCompleted
+
+	if err = s.deleteChunks(t.ID, t.Chunks); err != nil {
+		return err
+	}
+
+	if err = s.saveTransfer(t); err != nil {
+		return err
+	}
+
+	s.sendMsgEvents(t, msg)
+
+	return nil
+}
+
+// incomingTransfer returns the incoming transfer with the given ID, an unknown transfer is offered by the sender.
+func (s *Service) incomingTransfer(transferID, myDID, theirDID string) (*Transfer, bool, error) {
+	t, err := s.Transfer(transferID)
+	if errors.Is(err, ErrTransferNotFound) {
+		return &Transfer{
+			ID:       transferID,
+			Role:     RoleReceiver,
+			State:    StateOffered,
+			MyDID:    myDID,
+			TheirDID: theirDID,
+			Received: map[int]bool{},
+		}, true, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if t.Role != RoleReceiver || t.TheirDID != theirDID {
+		return nil, false, fmt.Errorf("transfer %s does not belong to %s", transferID, theirDID)
+	}
+
+	if t.Received == nil {
+		t.Received = map[int]bool{}
+	}
+
+	return t, false, nil
+}
+
+// fail marks the incoming transfer as failed, deletes the chunks received so far and returns the failure.
+func (s *Service) fail(t *Transfer, msg service.DIDCommMsg, failure error) error {
+	if err := s.deleteReceived(t); err != nil {
+		return err
+	}
+
+	t.State = StateFailed
+	t.Error = failure.Error()
+
+	if err := s.saveTransfer(t); err != nil {
+		return err
+	}
+
+	s.sendMsgEvents(t, msg)
+
+	return fmt.Errorf("transfer %s: %w", t.ID, failure)
+}
+
+// complete saves the transfer and, once all chunks and the end message were received,
+// assembles the chunks in order into the file and verifies its integrity.
+func (s *Service) complete(t *Transfer, msg service.DIDCommMsg) error {
+	if !receivedAll(t) {
+		return s.saveTransfer(t)
+	}
+
+	w, err := s.files.Create(t.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < t.Chunks; i++ {
+		data, e := s.store.Get(fmt.Sprintf(chunkKeyPattern, t.ID, i))
+		if e != nil {
+			return fmt.Errorf("get chunk %d: %w", i, e)
+		}
+
+		if _, e = w.Write(data); e != nil {
+			return fmt.Errorf("write chunk %d: %w", i, e)
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	t.State = StateCompleted
+
+	if info := w.Info(); info.Sha256 != t.Sha256 || info.Size != t.Size {
+		t.State = StateFailed
+		t.Error = fmt.Sprintf("integrity check failed: expected %d bytes with sha256 %s but got %d bytes with sha256 %s",
+			t.Size, t.Sha256, info.Size, info.Sha256)
+
+		if err = s.files.Delete(t.ID); err != nil {
+			return err
+		}
+	}
+
+	if err = s.deleteChunks(t.ID, t.Chunks); err != nil {
+		return err
+	}
+
+	if err = s.saveTransfer(t); err != nil {
+		return err
+	}
+
+	if t.State == StateCompleted {
+		err = s.outbound.SendToDID(&Ack{
+			Type:   AckMsgType,
+			ID:     uuid.New().String(),
+			Thread: &decorator.Thread{ID: t.ID},
+			Status: ackStatusOK,
+		}, t.MyDID, t.TheirDID)
+		if err != nil {
+			return fmt.Errorf("send ack: %w", err)
+		}
+	}
+
+	s.sendMsgEvents(t, msg)
+
+	return nil
+}
+
+func receivedAll(t *Transfer) bool {
+	if t.Sha256 == "" {
+		return false
+	}
+
+	for i := 0; i < t.Chunks; i++ {
+		if !t.Received[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Service) sendChunk(t *Transfer, index int, data []byte) error {
+	chunk := &Chunk{
+		Type:   ChunkMsgType,
+		ID:     uuid.New().String(),
+		Thread: &decorator.Thread{ID: t.ID, SenderOrder: index},
+		Index:  index,
+		Data: decorator.Attachment{
+			ID:        uuid.New().String(),
+			MimeType:  "application/octet-stream",
+			ByteCount: int64(len(data)),
+			Data: decorator.AttachmentData{
+				Base64: base64.StdEncoding.EncodeToString(data),
+				Sha256: attachment.Sha256(data),
+			},
+		},
+	}
+
+	if index == 0 {
+		file := t.File
+		chunk.File = &file
+	}
+
+	if err := s.outbound.SendToDID(chunk, t.MyDID, t.TheirDID); err != nil {
+		return fmt.Errorf("send chunk %d: %w", index, err)
+	}
+
+	return nil
+}
+
+func (s *Service) sendEnd(t *Transfer) error {
+	err := s.outbound.SendToDID(&End{
+		Type:   EndMsgType,
+		ID:     uuid.New().String(),
+		Thread: &decorator.Thread{ID: t.ID},
+		Chunks: t.Chunks,
+		Size:   t.Size,
+		Sha256: t.Sha256,
+	}, t.MyDID, t.TheirDID)
+	if err != nil {
+		return fmt.Errorf("send end: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) saveTransfer(t *Transfer) error {
+	bits, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("marshal transfer %s: %w", t.ID, err)
+	}
+
+	if err = s.store.Put(fmt.Sprintf(transferKeyPattern, t.ID), bits, storage.Tag{Name: transferTag}); err != nil {
+		return fmt.Errorf("save transfer %s: %w", t.ID, err)
+	}
+
+	return nil
+}
+
+// deleteReceived deletes the chunks of an incoming transfer received so far.
+func (s *Service) deleteReceived(t *Transfer) error {
+	ops := make([]storage.Operation, 0, len(t.Received))
+
+	for i := range t.Received {
+		ops = append(ops, storage.Operation{Key: fmt.Sprintf(chunkKeyPattern, t.ID, i)})
+	}
+
+	t.Received = map[int]bool{}
+	t.ReceivedSize = 0
+
+	if len(ops) == 0 {
+		return nil
+	}
+
+	if err := s.store.Batch(ops); err != nil {
+		return fmt.Errorf("delete chunks of transfer %s: %w", t.ID, err)
+	}
+
+	return nil
+}
+
+func (s *Service) deleteChunks(transferID string, chunks int) error {
+	if chunks == 0 {
+		return nil
+	}
+
+	ops := make([]storage.Operation, chunks)
+
+	for i := range ops {
+		ops[i] = storage.Operation{Key: fmt.Sprintf(chunkKeyPattern, transferID, i)}
+	}
+
+	if err := s.store.Batch(ops); err != nil {
+		return fmt.Errorf("delete chunks of transfer %s: %w", transferID, err)
+	}
+
+	return nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+// sendMsgEvents triggers the message events.
+func (s *Service) sendMsgEvents(t *Transfer, msg service.DIDCommMsg) {
+	for _, handler := range s.MsgEvents() {
+		handler <- service.StateMsg{
+			ProtocolName: FileTransfer,
+			Type:         service.PostState,
+			StateID:      t.State,
+			Msg:          msg,
+			Properties:   &eventProps{transferID: t.ID},
+		}
+	}
+}
+
+// sendActionEvent lets the action event handler accept (Continue) or decline (Stop) an offered transfer.
+func (s *Service) sendActionEvent(t *Transfer, msg service.DIDCommMsg) {
+	handler := s.ActionEvent()
+	if handler == nil {
+		logger.Debugf("no action event handler, transfer %s waits to be accepted", t.ID)
+
+		return
+	}
+
+	handler <- service.DIDCommAction{
+		ProtocolName: FileTransfer,
+		Message:      msg,
+		Continue: func(interface{}) {
+			if err := s.AcceptTransfer(t.ID); err != nil {
+				logger.Errorf("accept transfer %s: %s", t.ID, err)
+			}
+		},
+		Stop: func(error) {
+			if err := s.DeclineTransfer(t.ID); err != nil {
+				logger.Errorf("decline transfer %s: %s", t.ID, err)
+			}
+		},
+		Properties: &eventProps{transferID: t.ID},
+	}
+}
+
+type eventProps struct {
+	transferID string
+}
+
+// TransferID returns the ID of the transfer the event relates to.
+func (e *eventProps) TransferID() string {
+	return e.transferID
+}
+
+// All implements EventProperties interface.
+func (e *eventProps) All() map[string]interface{} {
+	return map[string]interface{}{transferIDPropKey: e.transferID}
+}
@@ -0,0 +1,588 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filetransfer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator/attachment"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	aliceDID = "did:example:alice"
+	bobDID   = "did:example:bob"
+	connID   = "connection-id"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, err := New(newProvider(t, nil))
+		require.NoError(t, err)
+		require.Equal(t, FileTransfer, svc.Name())
+		require.True(t, svc.Accept(ChunkMsgType))
+		require.True(t, svc.Accept(EndMsgType))
+		require.True(t, svc.Accept(ResumeMsgType))
+		require.True(t, svc.Accept(AckMsgType))
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			ErrOpenStoreHandle: fmt.Errorf("open error"),
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open file transfer store")
+	})
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		_, err := New(newProvider(t, nil), WithChunkSize(-1))
+		require.Error(t, err)
+	})
+
+	t.Run("invalid transfer limits", func(t *testing.T) {
+		_, err := New(newProvider(t, nil), WithMaxTransferSize(0))
+		require.Error(t, err)
+
+		_, err = New(newProvider(t, nil), WithMaxChunks(-1))
+		require.Error(t, err)
+	})
+}
+
+func TestOffer(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 100)
+
+	t.Run("chunks are dropped until the transfer is accepted", func(t *testing.T) {
+		alice, bob := newServicePair(t, WithChunkSize(100))
+
+		alice.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				_, err := bob.HandleInbound(service.NewDIDCommMsgMap(msg), service.NewDIDCommContext(bobDID, aliceDID, nil))
+
+				return err
+			},
+		}
+
+		transferID, err := alice.Send(connID, File{Name: "file.txt"}, bytes.NewReader(content))
+		require.NoError(t, err)
+
+		offered, err := bob.Transfer(transferID)
+		require.NoError(t, err)
+		require.Equal(t, StateOffered, offered.State)
+		require.Equal(t, "file.txt", offered.File.Name)
+		require.Equal(t, 16, offered.Chunks)
+		require.Empty(t, offered.Received)
+
+		_, err = bob.Open(transferID)
+		require.Error(t, err)
+
+		require.NoError(t, bob.AcceptTransfer(transferID))
+		require.Error(t, bob.AcceptTransfer(transferID))
+
+		r, err := bob.Open(transferID)
+		require.NoError(t, err)
+
+		bits, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+	})
+
+	t.Run("action event", func(t *testing.T) {
+		svc, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		actions := make(chan service.DIDCommAction, 2)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+
+		ctx := service.NewDIDCommContext(bobDID, aliceDID, nil)
+
+		for _, id := range []string{"accepted", "declined"} {
+			_, err = svc.HandleInbound(service.NewDIDCommMsgMap(newChunk(id, 0, []byte("data"))), ctx)
+			require.NoError(t, err)
+
+			// a chunk of a transfer already offered does not raise another event
+			_, err = svc.HandleInbound(service.NewDIDCommMsgMap(newChunk(id, 1, []byte("data"))), ctx)
+			require.NoError(t, err)
+		}
+
+		accept, decline := <-actions, <-actions
+		require.Empty(t, actions)
+		require.Equal(t, "accepted", accept.Properties.All()[transferIDPropKey])
+
+		accept.Continue(nil)
+		decline.Stop(nil)
+
+		accepted, err := svc.Transfer("accepted")
+		require.NoError(t, err)
+		require.Equal(t, StateReceiving, accepted.State)
+
+		declined, err := svc.Transfer("declined")
+		require.NoError(t, err)
+		require.Equal(t, StateDeclined, declined.State)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(newChunk("declined", 0, []byte("data"))), ctx)
+		require.NoError(t, err)
+
+		declined, err = svc.Transfer("declined")
+		require.NoError(t, err)
+		require.Empty(t, declined.Received)
+	})
+
+	t.Run("decline a transfer in progress", func(t *testing.T) {
+		svc, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		ctx := service.NewDIDCommContext(bobDID, aliceDID, nil)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(newChunk("transfer-id", 0, []byte("data"))), ctx)
+		require.NoError(t, err)
+		require.NoError(t, svc.AcceptTransfer("transfer-id"))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(newChunk("transfer-id", 0, []byte("data"))), ctx)
+		require.NoError(t, err)
+
+		_, err = svc.store.Get(fmt.Sprintf(chunkKeyPattern, "transfer-id", 0))
+		require.NoError(t, err)
+
+		require.NoError(t, svc.DeclineTransfer("transfer-id"))
+		require.Error(t, svc.DeclineTransfer("transfer-id"))
+
+		_, err = svc.store.Get(fmt.Sprintf(chunkKeyPattern, "transfer-id", 0))
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
+
+func TestLimits(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 100)
+
+	t.Run("transfer size", func(t *testing.T) {
+		alice, bob := newServicePair(t, WithChunkSize(100))
+		bob.maxTransferSize = 1000
+
+		_, err := alice.Send(connID, File{Name: "file.txt"}, bytes.NewReader(content))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrLimitExceeded))
+	})
+
+	t.Run("announced chunks", func(t *testing.T) {
+		alice, bob := newServicePair(t, WithChunkSize(100))
+		bob.maxChunks = 10
+
+		_, err := alice.Send(connID, File{Name: "file.txt"}, bytes.NewReader(content[:900]))
+		require.NoError(t, err)
+
+		_, err = alice.Send(connID, File{Name: "file.txt"}, bytes.NewReader(content))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "out of range")
+	})
+
+	t.Run("chunks received beyond the announced range", func(t *testing.T) {
+		svc, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		ctx := service.NewDIDCommContext(bobDID, aliceDID, nil)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(newChunk("transfer-id", 5, []byte("data"))), ctx)
+		require.NoError(t, err)
+		require.NoError(t, svc.AcceptTransfer("transfer-id"))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(newChunk("transfer-id", 5, []byte("data"))), ctx)
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&End{
+			Type: EndMsgType, Thread: &decorator.Thread{ID: "transfer-id"}, Chunks: 2, Size: 8, Sha256: "digest",
+		}), ctx)
+		require.Error(t, err)
+
+		failed, err := svc.Transfer("transfer-id")
+		require.NoError(t, err)
+		require.Equal(t, StateFailed, failed.State)
+		require.Contains(t, failed.Error, "2 chunks announced")
+
+		_, err = svc.store.Get(fmt.Sprintf(chunkKeyPattern, "transfer-id", 5))
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&End{
+			Type: EndMsgType, Thread: &decorator.Thread{ID: "other"}, Chunks: DefaultMaxChunks + 1,
+		}), ctx)
+		require.True(t, errors.Is(err, ErrLimitExceeded))
+	})
+}
+
+func TestTransfer(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 100)
+
+	t.Run("send and receive", func(t *testing.T) {
+		alice, bob := newServicePair(t, WithChunkSize(100))
+
+		events := make(chan service.StateMsg, 2)
+		require.NoError(t, bob.RegisterMsgEvent(events))
+
+		transferID, err := alice.Send(connID, File{Name: "file.txt", MimeType: "text/plain"}, bytes.NewReader(content))
+		require.NoError(t, err)
+
+		select {
+		case e := <-events:
+			require.Equal(t, StateCompleted, e.StateID)
+			require.Equal(t, transferID, e.Properties.All()[transferIDPropKey])
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout waiting for completed event")
+		}
+
+		received, err := bob.Transfer(transferID)
+		require.NoError(t, err)
+		require.Equal(t, RoleReceiver, received.Role)
+		require.Equal(t, StateCompleted, received.State)
+		require.Equal(t, "file.txt", received.File.Name)
+		require.Equal(t, 16, received.Chunks)
+		require.Equal(t, int64(len(content)), received.Size)
+
+		r, err := bob.Open(transferID)
+		require.NoError(t, err)
+
+		bits, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+
+		sent, err := alice.Transfer(transferID)
+		require.NoError(t, err)
+		require.Equal(t, RoleSender, sent.Role)
+		require.Equal(t, StateCompleted, sent.State)
+
+		transfers, err := alice.Transfers()
+		require.NoError(t, err)
+		require.Len(t, transfers, 1)
+
+		_, err = alice.Open(transferID)
+		require.Error(t, err)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		alice, bob := newServicePair(t)
+
+		transferID, err := alice.Send(connID, File{Name: "empty"}, bytes.NewReader(nil))
+		require.NoError(t, err)
+
+		r, err := bob.Open(transferID)
+		require.NoError(t, err)
+
+		bits, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Empty(t, bits)
+	})
+
+	t.Run("resume after lost messages", func(t *testing.T) {
+		alice, bob := newServicePair(t, WithChunkSize(100))
+
+		// drop chunks 3 and 4 and everything after chunk 10, including the end message
+		dropped := 0
+		alice.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				if c, ok := msg.(*Chunk); ok && (c.Index == 3 || c.Index == 4 || c.Index > 10) {
+					dropped++
+
+					return nil
+				}
+
+				if _, ok := msg.(*End); ok {
+					return nil
+				}
+
+				return deliver(bob, msg, bobDID, aliceDID)
+			},
+		}
+
+		transferID, err := alice.Send(connID, File{Name: "file.txt"}, bytes.NewReader(content))
+		require.NoError(t, err)
+		require.Equal(t, 7, dropped)
+
+		received, err := bob.Transfer(transferID)
+		require.NoError(t, err)
+		require.Equal(t, StateReceiving, received.State)
+
+		_, err = bob.Open(transferID)
+		require.Error(t, err)
+
+		var resume *Resume
+
+		bob.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				if r, ok := msg.(*Resume); ok {
+					resume = r
+				}
+
+				return deliver(alice, msg, aliceDID, bobDID)
+			},
+		}
+		alice.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				return deliver(bob, msg, bobDID, aliceDID)
+			},
+		}
+
+		require.NoError(t, bob.Resume(transferID))
+		require.Equal(t, []int{3, 4}, resume.Missing)
+		require.Equal(t, 11, resume.From)
+
+		r, err := bob.Open(transferID)
+		require.NoError(t, err)
+
+		bits, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+
+		require.Error(t, bob.Resume(transferID))
+	})
+
+	t.Run("out of order and duplicate chunks", func(t *testing.T) {
+		alice, bob := newServicePair(t, WithChunkSize(100))
+
+		var queued []interface{}
+
+		alice.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				queued = append(queued, msg)
+
+				return nil
+			},
+		}
+
+		transferID, err := alice.Send(connID, File{Name: "file.txt"}, bytes.NewReader(content))
+		require.NoError(t, err)
+
+		for i := len(queued) - 1; i >= 0; i-- {
+			require.NoError(t, deliver(bob, queued[i], bobDID, aliceDID))
+		}
+
+		require.NoError(t, deliver(bob, queued[0], bobDID, aliceDID))
+
+		r, err := bob.Open(transferID)
+		require.NoError(t, err)
+
+		bits, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+	})
+
+	t.Run("integrity check failure", func(t *testing.T) {
+		alice, bob := newServicePair(t, WithChunkSize(100))
+
+		alice.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				if end, ok := msg.(*End); ok {
+					end.Sha256 = "invalid"
+				}
+
+				return deliver(bob, msg, bobDID, aliceDID)
+			},
+		}
+
+		transferID, err := alice.Send(connID, File{Name: "file.txt"}, bytes.NewReader(content))
+		require.NoError(t, err)
+
+		received, err := bob.Transfer(transferID)
+		require.NoError(t, err)
+		require.Equal(t, StateFailed, received.State)
+		require.Contains(t, received.Error, "integrity check failed")
+
+		sent, err := alice.Transfer(transferID)
+		require.NoError(t, err)
+		require.Equal(t, StateSent, sent.State)
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, err := New(newProvider(t, nil))
+		require.NoError(t, err)
+
+		_, err = svc.Send("unknown", File{}, bytes.NewReader(content))
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc, err := New(newProvider(t, &mockdispatcher.MockOutbound{SendErr: fmt.Errorf("send error")}))
+		require.NoError(t, err)
+
+		_, err = svc.Send(connID, File{}, bytes.NewReader(content))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send error")
+	})
+
+	t.Run("writer closed", func(t *testing.T) {
+		svc, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		w, err := svc.NewWriter(connID, File{})
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.NoError(t, w.Close())
+
+		_, err = w.Write(content)
+		require.True(t, errors.Is(err, ErrWriterClosed))
+	})
+
+	t.Run("transfer not found", func(t *testing.T) {
+		svc, err := New(newProvider(t, nil))
+		require.NoError(t, err)
+
+		_, err = svc.Transfer("unknown")
+		require.True(t, errors.Is(err, ErrTransferNotFound))
+		require.True(t, errors.Is(svc.Resume("unknown"), ErrTransferNotFound))
+	})
+}
+
+func TestHandleInbound(t *testing.T) {
+	svc, err := New(newProvider(t, nil))
+	require.NoError(t, err)
+
+	ctx := service.NewDIDCommContext(bobDID, aliceDID, nil)
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(struct {
+			Type string `json:"@type"`
+		}{Type: "unknown"}), ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("missing thread", func(t *testing.T) {
+		for _, msg := range []interface{}{
+			&Chunk{Type: ChunkMsgType}, &End{Type: EndMsgType}, &Resume{Type: ResumeMsgType}, &Ack{Type: AckMsgType},
+		} {
+			_, err = svc.HandleInbound(service.NewDIDCommMsgMap(msg), ctx)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "missing thread ID")
+		}
+	})
+
+	t.Run("invalid chunk data", func(t *testing.T) {
+		chunk := &Chunk{Type: ChunkMsgType}
+		chunk.Thread = &decorator.Thread{ID: "transfer-id"}
+		chunk.Data.Data.Base64 = "invalid"
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(chunk), ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("chunk index out of range", func(t *testing.T) {
+		chunk := newChunk("transfer-range", DefaultMaxChunks, []byte("data"))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(chunk), ctx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "out of range")
+
+		_, err = svc.Transfer("transfer-range")
+		require.True(t, errors.Is(err, ErrTransferNotFound))
+	})
+
+	t.Run("links are not followed by default", func(t *testing.T) {
+		chunk := newChunk("transfer-link", 0, nil)
+		chunk.Data.Data = decorator.AttachmentData{Links: []string{"https://example.com/chunk"}}
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(chunk), ctx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), attachment.ErrSchemeNotAllowed.Error())
+	})
+
+	t.Run("outbound is not implemented", func(t *testing.T) {
+		_, err = svc.HandleOutbound(nil, "", "")
+		require.Error(t, err)
+	})
+}
+
+func newServicePair(t *testing.T, opts ...Option) (*Service, *Service) {
+	t.Helper()
+
+	alice, err := New(newProvider(t, nil), opts...)
+	require.NoError(t, err)
+
+	bob, err := New(newProvider(t, nil), opts...)
+	require.NoError(t, err)
+
+	alice.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			return deliver(bob, msg, bobDID, aliceDID)
+		},
+	}
+	bob.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			return deliver(alice, msg, aliceDID, bobDID)
+		},
+	}
+
+	return alice, bob
+}
+
+// deliver handles the message and accepts the transfer it offers.
+func deliver(to *Service, msg interface{}, myDID, theirDID string) error {
+	didCommMsg := service.NewDIDCommMsgMap(msg)
+
+	_, err := to.HandleInbound(didCommMsg, service.NewDIDCommContext(myDID, theirDID, nil))
+	if err != nil {
+		return err
+	}
+
+	thID, err := didCommMsg.ThreadID()
+	if err != nil {
+		return err
+	}
+
+	t, err := to.Transfer(thID)
+	if err != nil || t.State != StateOffered {
+		return nil
+	}
+
+	return to.AcceptTransfer(t.ID)
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+		OutboundDispatcherValue:           outbound,
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        "completed",
+		MyDID:        aliceDID,
+		TheirDID:     bobDID,
+	}))
+
+	return prov
+}
+
+func newChunk(transferID string, index int, data []byte) *Chunk {
+	chunk := &Chunk{
+		Type:   ChunkMsgType,
+		ID:     uuid.New().String(),
+		Thread: &decorator.Thread{ID: transferID},
+		Index:  index,
+	}
+	chunk.Data.Data.Base64 = base64.StdEncoding.EncodeToString(data)
+
+	return chunk
+}
@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filetransfer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+// ErrWriterClosed is returned when writing to a closed transfer Writer.
+var ErrWriterClosed = errors.New("file transfer writer is closed")
+
+// Writer sends the file written to it as a sequence of chunk messages.
+// Sent chunks are kept until the receiver acknowledges the file, so that the transfer can be resumed.
+type Writer struct {
+	svc      *Service
+	transfer *Transfer
+	buf      []byte
+	hash     hash.Hash
+	closed   bool
+}
+
+func newWriter(svc *Service, t *Transfer) *Writer {
+	return &Writer{
+		svc:      svc,
+		transfer: t,
+		buf:      make([]byte, 0, svc.chunkSize),
+		hash:     sha256.New(),
+	}
+}
+
+// TransferID returns the ID of the transfer.
+func (w *Writer) TransferID() string {
+	return w.transfer.ID
+}
+
+// Write implements io.Writer.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+
+	written := 0
+
+	for len(p) > 0 {
+		n := copy(w.buf[len(w.buf):cap(w.buf)], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+
+		if len(w.buf) == cap(w.buf) {
+			if err := w.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// Close sends the remaining contents followed by the end message.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	if len(w.buf) > 0 {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+
+	w.closed = true
+
+	w.transfer.State = StateSent
+	w.transfer.Sha256 = hex.EncodeToString(w.hash.Sum(nil))
+
+	w.svc.lock.Lock()
+	err := w.svc.saveTransfer(w.transfer)
+	w.svc.lock.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return w.svc.sendEnd(w.transfer)
+}
+
+func (w *Writer) flush() error {
+	chunk := make([]byte, len(w.buf))
+	copy(chunk, w.buf)
+
+	index := w.transfer.Chunks
+
+	if err := w.svc.store.Put(fmt.Sprintf(chunkKeyPattern, w.transfer.ID, index), chunk); err != nil {
+		return fmt.Errorf("store chunk %d: %w", index, err)
+	}
+
+	w.hash.Write(chunk) // nolint: errcheck,gosec
+	w.transfer.Chunks++
+	w.transfer.Size += int64(len(chunk))
+	w.buf = w.buf[:0]
+
+	if err := w.svc.saveTransfer(w.transfer); err != nil {
+		return err
+	}
+
+	return w.svc.sendChunk(w.transfer, index, chunk)
+}
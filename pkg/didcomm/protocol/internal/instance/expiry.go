@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package instance
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// ExpiredEvent is the state ID of the message event triggered when a protocol instance is abandoned because
+// the message it waited a reply to expired (`~timing.expires_time`). The event message is the expired message.
+const ExpiredEvent = "expired"
+
+// ErrExpired is the error of the message event triggered when a protocol instance expired.
+var ErrExpired = errors.New("protocol instance expired")
+
+// PendingAction is the message of a protocol instance pending an action, with the DIDs of its connection.
+type PendingAction struct {
+	Msg      service.DIDCommMsgMap
+	MyDID    string
+	TheirDID string
+}
+
+// Expirer abandons the protocol instances of a protocol service once the message they wait a reply to expired.
+type Expirer struct {
+	Locker *Locker
+	// PendingAction returns the message of the protocol instance pending an action, storage.ErrDataNotFound
+	// if no action is pending.
+	PendingAction func(piID string) (*PendingAction, error)
+	// StateName returns the name of the current state of the protocol instance.
+	StateName func(piID string) (string, error)
+	// Abandon removes the state of the protocol instance and triggers ExpiredEvent. The pending action is nil
+	// if the protocol instance waits for a message of the other agent.
+	Abandon func(entry *expiry.Entry, action *PendingAction) error
+}
+
+// Expire abandons the protocol instance of the entry if it is still waiting for the reply to the expired message:
+// either the message is pending an action or the protocol instance is in the state the message moved it to.
+func (e *Expirer) Expire(entry *expiry.Entry) error {
+	unlock, err := e.Locker.Lock(entry.PIID)
+	if err != nil {
+		return fmt.Errorf("lock: %w", err)
+	}
+
+	defer unlock()
+
+	action, err := e.PendingAction(entry.PIID)
+
+	switch {
+	case err == nil:
+		if action.Msg.ID() != entry.Msg.ID() {
+			return nil
+		}
+	case !errors.Is(err, storage.ErrDataNotFound):
+		return fmt.Errorf("get pending action: %w", err)
+	default:
+		action = nil
+
+		stateName, errState := e.StateName(entry.PIID)
+		if errState != nil {
+			return fmt.Errorf("current state name: %w", errState)
+		}
+
+		if stateName != entry.StateName {
+			return nil
+		}
+	}
+
+	if err = e.Abandon(entry, action); err != nil {
+		return err
+	}
+
+	logger.Infof("protocol instance %s expired at %s", entry.PIID, entry.ExpiresTime)
+
+	return nil
+}
+
+// DeleteState deletes the state of a protocol instance stored by the given keys, the missing ones are ignored.
+func DeleteState(store storage.Store, keys ...string) error {
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+			return fmt.Errorf("delete state: %w", err)
+		}
+	}
+
+	return nil
+}
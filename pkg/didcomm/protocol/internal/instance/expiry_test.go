@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package instance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	mocklocker "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestExpirer_Expire(t *testing.T) {
+	entry := &expiry.Entry{
+		PIID:      "piid",
+		StateName: "offer-sent",
+		Msg:       service.DIDCommMsgMap{"@id": "offer"},
+	}
+
+	newExpirer := func(action *PendingAction, actionErr error, stateName string) (*Expirer, *[]*PendingAction) {
+		var abandoned []*PendingAction
+
+		return &Expirer{
+			Locker: NewLocker(struct{}{}, "protocol"),
+			PendingAction: func(string) (*PendingAction, error) {
+				return action, actionErr
+			},
+			StateName: func(string) (string, error) {
+				return stateName, nil
+			},
+			Abandon: func(_ *expiry.Entry, action *PendingAction) error {
+				abandoned = append(abandoned, action)
+
+				return nil
+			},
+		}, &abandoned
+	}
+
+	t.Run("pending action", func(t *testing.T) {
+		action := &PendingAction{Msg: entry.Msg, MyDID: "did:example:me", TheirDID: "did:example:them"}
+
+		expirer, abandoned := newExpirer(action, nil, "")
+		require.NoError(t, expirer.Expire(entry))
+		require.Equal(t, []*PendingAction{action}, *abandoned)
+
+		// another message is pending an action
+		expirer, abandoned = newExpirer(&PendingAction{Msg: service.DIDCommMsgMap{"@id": "request"}}, nil, "")
+		require.NoError(t, expirer.Expire(entry))
+		require.Empty(t, *abandoned)
+	})
+
+	t.Run("waiting for a reply", func(t *testing.T) {
+		expirer, abandoned := newExpirer(nil, storage.ErrDataNotFound, entry.StateName)
+		require.NoError(t, expirer.Expire(entry))
+		require.Equal(t, []*PendingAction{nil}, *abandoned)
+
+		// the protocol instance moved on
+		expirer, abandoned = newExpirer(nil, storage.ErrDataNotFound, "request-received")
+		require.NoError(t, expirer.Expire(entry))
+		require.Empty(t, *abandoned)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		expirer, _ := newExpirer(nil, errors.New("get error"), "")
+		require.EqualError(t, expirer.Expire(entry), "get pending action: get error")
+
+		expirer, _ = newExpirer(nil, storage.ErrDataNotFound, "")
+		expirer.StateName = func(string) (string, error) {
+			return "", errors.New("state error")
+		}
+		require.EqualError(t, expirer.Expire(entry), "current state name: state error")
+
+		expirer, _ = newExpirer(nil, storage.ErrDataNotFound, entry.StateName)
+		expirer.Abandon = func(*expiry.Entry, *PendingAction) error {
+			return errors.New("abandon error")
+		}
+		require.EqualError(t, expirer.Expire(entry), "abandon error")
+
+		expirer, _ = newExpirer(nil, storage.ErrDataNotFound, entry.StateName)
+		expirer.Locker = NewLocker(&lockerProvider{locker: &mocklocker.MockLocker{LockErr: errors.New("lock error")}},
+			"protocol")
+		require.EqualError(t, expirer.Expire(entry), "lock: lock error")
+	})
+}
+
+func TestDeleteState(t *testing.T) {
+	store := &mockstore.MockStore{Store: map[string]mockstore.DBEntry{
+		"state": {Value: []byte("offer-sent")},
+	}}
+
+	require.NoError(t, DeleteState(store, "state", "missing"))
+	require.Empty(t, store.Store)
+
+	store.ErrDelete = errors.New("delete error")
+	require.EqualError(t, DeleteState(store, "state"), "delete state: delete error")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package instance provides the helpers shared by the protocol services managing the state of their protocol
+// instances (e.g. issue-credential and present-proof).
+package instance
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+// lockTimeout is the maximum wait for the lock of a protocol instance held by another agent instance.
+const lockTimeout = time.Minute
+
+var logger = log.New("aries-framework/didcomm/protocol/instance")
+
+// LockerProvider is implemented by the providers of the agents sharing their protocol state with other instances
+// (e.g. aries.Context() with aries.WithLocker).
+type LockerProvider interface {
+	Locker() lock.Locker
+}
+
+// Locker locks the protocol instances of a protocol, so the agent instances sharing the protocol state don't
+// advance them concurrently (e.g. when they receive the retransmitted copies of a message).
+type Locker struct {
+	locker   lock.Locker
+	protocol string
+}
+
+// NewLocker returns the Locker of the protocol instances of the given protocol. The locker of the provider is used
+// if it implements LockerProvider, otherwise nothing is locked.
+func NewLocker(p interface{}, protocol string) *Locker {
+	l := &Locker{protocol: protocol}
+
+	if lp, ok := p.(LockerProvider); ok {
+		l.locker = lp.Locker()
+	}
+
+	return l
+}
+
+// Enabled returns true if the protocol instances are locked.
+func (l *Locker) Enabled() bool {
+	return l.locker != nil
+}
+
+// LockMsg acquires the lock of the protocol instance of the message, the returned function releases it.
+// Nothing is locked for the messages starting a new protocol instance.
+func (l *Locker) LockMsg(msg service.DIDCommMsg) (func(), error) {
+	piID := msg.ParentThreadID()
+	if piID == "" {
+		var err error
+
+		piID, err = msg.ThreadID()
+		if err != nil {
+			return func() {}, nil // nolint:nilerr // new protocol instance
+		}
+	}
+
+	return l.Lock(piID)
+}
+
+// Lock acquires the lock of the protocol instance, the returned function releases it.
+func (l *Locker) Lock(piID string) (func(), error) {
+	if l.locker == nil {
+		return func() {}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+
+	unlocker, err := l.locker.Lock(ctx, l.protocol+":"+piID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		if err := unlocker.Unlock(); err != nil {
+			logger.Warnf("failed to release lock of %s: %s", piID, err)
+		}
+	}, nil
+}
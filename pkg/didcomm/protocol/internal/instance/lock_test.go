@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package instance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mocklocker "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+type lockerProvider struct {
+	locker lock.Locker
+}
+
+func (p *lockerProvider) Locker() lock.Locker {
+	return p.locker
+}
+
+func TestLocker(t *testing.T) {
+	t.Run("without locker", func(t *testing.T) {
+		l := NewLocker(struct{}{}, "protocol")
+		require.False(t, l.Enabled())
+
+		unlock, err := l.Lock("piid")
+		require.NoError(t, err)
+		unlock()
+	})
+
+	t.Run("lock the protocol instance", func(t *testing.T) {
+		locker := &mocklocker.MockLocker{}
+
+		l := NewLocker(&lockerProvider{locker: locker}, "protocol")
+		require.True(t, l.Enabled())
+
+		unlock, err := l.Lock("piid")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = locker.Lock(ctx, "protocol:piid")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		unlock()
+
+		unlocker, err := locker.Lock(context.Background(), "protocol:piid")
+		require.NoError(t, err)
+		require.NoError(t, unlocker.Unlock())
+	})
+
+	t.Run("lock the protocol instance of the message", func(t *testing.T) {
+		locker := &mocklocker.MockLocker{}
+		l := NewLocker(&lockerProvider{locker: locker}, "protocol")
+
+		unlock, err := l.LockMsg(service.NewDIDCommMsgMap(struct {
+			ID string `json:"@id"`
+		}{ID: "thid"}))
+		require.NoError(t, err)
+		unlock()
+
+		unlock, err = l.LockMsg(service.NewDIDCommMsgMap(struct {
+			ID     string `json:"@id"`
+			Thread struct {
+				PID string `json:"pthid"`
+			} `json:"~thread"`
+		}{ID: "thid", Thread: struct {
+			PID string `json:"pthid"`
+		}{PID: "pthid"}}))
+		require.NoError(t, err)
+		unlock()
+
+		require.Equal(t, []string{"protocol:thid", "protocol:pthid"}, locker.Acquired)
+	})
+
+	t.Run("new protocol instance is not locked", func(t *testing.T) {
+		locker := &mocklocker.MockLocker{}
+		l := NewLocker(&lockerProvider{locker: locker}, "protocol")
+
+		unlock, err := l.LockMsg(service.NewDIDCommMsgMap(struct{}{}))
+		require.NoError(t, err)
+		unlock()
+
+		require.Empty(t, locker.Acquired)
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		l := NewLocker(&lockerProvider{locker: &mocklocker.MockLocker{LockErr: errors.New("lock error")}}, "protocol")
+
+		_, err := l.Lock("piid")
+		require.EqualError(t, err, "lock error")
+	})
+}
@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/internal/instance"
+)
+
+// ExpiredEvent is the state ID of the message event triggered when the protocol instance is abandoned because
+// the offer or the request it waited a reply to expired (`~timing.expires_time`). The event message is
+// the expired message.
+const ExpiredEvent = instance.ExpiredEvent
+
+// EnableExpiry abandons the protocol instances once the expiration time of the offer-credential or
+// request-credential message (`~timing.expires_time`) passes without a reply, and removes their state.
+// The worker of the returned tracker checking the expired protocol instances must be started by the caller.
+func (s *Service) EnableExpiry(opts ...expiry.Option) *expiry.Tracker {
+	s.expiry = expiry.New(s.store, s.expire, opts...)
+
+	return s.expiry
+}
+
+// trackExpiry tracks the protocol instance if the message handled carries an expiration time.
+func (s *Service) trackExpiry(md *metaData) error {
+	if s.expiry == nil {
+		return nil
+	}
+
+	return s.expiry.Track(md.PIID, md.StateName(), md.Msg)
+}
+
+// expire abandons the protocol instance of the entry if it is still waiting for the reply to the expired message.
+func (s *Service) expire(entry *expiry.Entry) error {
+	expirer := &instance.Expirer{
+		Locker:        s.locker,
+		PendingAction: s.pendingAction,
+		StateName:     s.currentStateName,
+		Abandon:       s.abandon,
+	}
+
+	return expirer.Expire(entry)
+}
+
+func (s *Service) pendingAction(piID string) (*instance.PendingAction, error) {
+	tPayload, err := s.getTransitionalPayload(piID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance.PendingAction{Msg: tPayload.Msg, MyDID: tPayload.MyDID, TheirDID: tPayload.TheirDID}, nil
+}
+
+// abandon removes the state of the expired protocol instance and triggers ExpiredEvent.
+func (s *Service) abandon(entry *expiry.Entry, action *instance.PendingAction) error {
+	md := &metaData{
+		transitionalPayload: transitionalPayload{Action: Action{PIID: entry.PIID, Msg: entry.Msg}},
+		msgClone:            entry.Msg.Clone(),
+		properties:          map[string]interface{}{},
+		err:                 instance.ErrExpired,
+	}
+
+	if action != nil {
+		md.MyDID, md.TheirDID = action.MyDID, action.TheirDID
+	}
+
+	err := instance.DeleteState(s.store, fmt.Sprintf(transitionalPayloadKey, entry.PIID), stateNameKey+entry.PIID)
+	if err != nil {
+		return err
+	}
+
+	s.sendMsgEvents(md, ExpiredEvent, service.PostState)
+
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/internal/instance"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	issuecredentialMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func newTestService(t *testing.T, ctrl *gomock.Controller) (*Service, *serviceMocks.MockMessenger) {
+	t.Helper()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	return svc, messenger
+}
+
+func expiringOffer(expires time.Time) service.DIDCommMsgMap {
+	return service.NewDIDCommMsgMap(struct {
+		ID     string            `json:"@id"`
+		Thread decorator.Thread  `json:"~thread"`
+		Type   string            `json:"@type"`
+		Timing *decorator.Timing `json:"~timing"`
+	}{
+		ID:     uuid.New().String(),
+		Thread: decorator.Thread{ID: uuid.New().String()},
+		Type:   OfferCredentialMsgType,
+		Timing: &decorator.Timing{ExpiresTime: expires},
+	})
+}
+
+func expire(t *testing.T, svc *Service, piID string) {
+	t.Helper()
+
+	src, err := svc.store.Get("expiry_" + piID)
+	require.NoError(t, err)
+
+	entry := &expiry.Entry{}
+	require.NoError(t, json.Unmarshal(src, entry))
+
+	require.NoError(t, svc.expire(entry))
+}
+
+func TestService_Expiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("issuer", func(t *testing.T) {
+		svc, messenger := newTestService(t, ctrl)
+		svc.EnableExpiry()
+
+		events := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		piID, err := svc.HandleOutbound(service.NewDIDCommMsgMap(OfferCredential{
+			Type:   OfferCredentialMsgType,
+			Timing: &decorator.Timing{ExpiresTime: time.Now().Add(time.Hour)},
+		}), Alice, Bob)
+		require.NoError(t, err)
+
+		stateName, err := svc.currentStateName(piID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameOfferSent, stateName)
+
+		expire(t, svc, piID)
+
+		_, err = svc.store.Get(stateNameKey + piID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		var expired *service.StateMsg
+
+		for len(events) > 0 {
+			if event := <-events; event.StateID == ExpiredEvent {
+				expired = &event
+			}
+		}
+
+		require.NotNil(t, expired)
+		require.Equal(t, OfferCredentialMsgType, expired.Msg.Type())
+		require.Equal(t, piID, expired.Properties.All()[piidPropKey])
+		require.Equal(t, instance.ErrExpired, expired.Properties.All()[errorPropKey])
+	})
+
+	t.Run("holder with pending action", func(t *testing.T) {
+		svc, _ := newTestService(t, ctrl)
+		svc.EnableExpiry()
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := expiringOffer(time.Now().Add(time.Hour))
+
+		_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		thID, err := msg.ThreadID()
+		require.NoError(t, err)
+
+		expire(t, svc, thID)
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+
+	t.Run("replied before the expiration", func(t *testing.T) {
+		svc, messenger := newTestService(t, ctrl)
+		svc.EnableExpiry()
+
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		piID, err := svc.HandleOutbound(service.NewDIDCommMsgMap(OfferCredential{
+			Type:   OfferCredentialMsgType,
+			Timing: &decorator.Timing{ExpiresTime: time.Now().Add(time.Hour)},
+		}), Alice, Bob)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.saveStateName(piID, stateNameRequestReceived))
+
+		expire(t, svc, piID)
+
+		stateName, err := svc.currentStateName(piID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameRequestReceived, stateName)
+	})
+
+	t.Run("expired offer", func(t *testing.T) {
+		svc, _ := newTestService(t, ctrl)
+		svc.EnableExpiry()
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		_, err := svc.HandleInbound(expiringOffer(time.Now().Add(-time.Hour)), service.NewDIDCommContext(Alice, Bob, nil))
+		require.True(t, errors.Is(err, expiry.ErrExpired))
+		require.Empty(t, ch)
+	})
+}
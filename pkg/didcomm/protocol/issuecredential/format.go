@@ -0,0 +1,308 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Attachment formats of the credentials.
+const (
+	// LDProofVCFormat is the format of W3C Verifiable Credential secured by Linked Data proof.
+	LDProofVCFormat = "aries/ld-proof-vc@v1.0"
+	// LDProofVCDetailFormat is the format of the details of W3C Verifiable Credential with Linked Data proof
+	// to be issued (the credential without proof and the proof options), as defined by RFC 0593.
+	LDProofVCDetailFormat = "aries/ld-proof-vc-detail@v1.0"
+	// JWTVCFormat is the format of W3C Verifiable Credential secured by JWT.
+	JWTVCFormat = "jwt-vc"
+	// AnoncredsFormat is the format of Hyperledger Indy (AnonCreds) credential.
+	AnoncredsFormat = "hlindy/cred@v2.0"
+)
+
+const jsonMimeType = "application/json"
+
+// ErrFormatNotSupported is returned when no handler is registered for the attachment format.
+var ErrFormatNotSupported = errors.New("credential format is not supported")
+
+// FormatHandler builds and validates the attachments of issue credential messages for a credential format.
+type FormatHandler interface {
+	// Offer builds the data of offers~attach attachment describing the credential preview.
+	// It returns an error if the preview can not be issued in this format.
+	Offer(preview *PreviewCredential) (*decorator.AttachmentData, error)
+	// Issue builds the data of credentials~attach attachment (issued credential) in response to
+	// the data of requests~attach attachment.
+	Issue(request *decorator.AttachmentData) (*decorator.AttachmentData, error)
+	// Validate validates the data of credentials~attach attachment (received credential).
+	Validate(credential *decorator.AttachmentData) error
+}
+
+// issuedFormatter is implemented by the handlers issuing credentials in a format other than the requested one,
+// e.g. the handler of LDProofVCDetailFormat issues the credentials in LDProofVCFormat.
+type issuedFormatter interface {
+	IssuedFormat() string
+}
+
+// FormatRegistry maps the attachment formats to the handlers building and validating the attachments.
+type FormatRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]FormatHandler
+	interop  *interop.Mode
+}
+
+// NewFormatRegistry returns new instance of FormatRegistry.
+func NewFormatRegistry() *FormatRegistry {
+	return &FormatRegistry{handlers: map[string]FormatHandler{}}
+}
+
+// Register registers the handler of the attachment format. It replaces the handler registered before (if any).
+func (r *FormatRegistry) Register(format string, handler FormatHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers[format] = handler
+}
+
+// SetInteropMode sets the compatibility quirks of the registry, the handlers of the current formats handle
+// the legacy format strings too if the mode accepts them (e.g. "hlindy/cred-abstract@v2.0" of ACA-Py).
+func (r *FormatRegistry) SetInteropMode(mode *interop.Mode) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interop = mode
+}
+
+// Handler returns the handler of the attachment format or ErrFormatNotSupported.
+func (r *FormatRegistry) Handler(format string) (FormatHandler, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	handler, ok := r.handlers[format]
+	if !ok {
+		handler, ok = r.handlers[r.interop.AttachmentFormat(format)]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFormatNotSupported, format)
+	}
+
+	return handler, nil
+}
+
+// Formats returns the registered attachment formats.
+func (r *FormatRegistry) Formats() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	formats := make([]string, 0, len(r.handlers))
+	for format := range r.handlers {
+		formats = append(formats, format)
+	}
+
+	sort.Strings(formats)
+
+	return formats
+}
+
+// Supports checks whether handlers are registered for all the formats.
+// It returns false if no formats are given.
+func (r *FormatRegistry) Supports(formats []Format) bool {
+	if len(formats) == 0 {
+		return false
+	}
+
+	for _, f := range formats {
+		if _, err := r.Handler(f.Format); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OfferCredential builds OfferCredential message for the credential preview with an attachment
+// in each of the given formats.
+func (r *FormatRegistry) OfferCredential(preview *PreviewCredential, formats ...string) (*OfferCredential, error) {
+	if len(formats) == 0 {
+		return nil, errors.New("no credential formats")
+	}
+
+	offer := &OfferCredential{
+		Type:              OfferCredentialMsgType,
+		CredentialPreview: *preview,
+	}
+
+	for _, format := range formats {
+		handler, err := r.Handler(format)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := handler.Offer(preview)
+		if err != nil {
+			return nil, fmt.Errorf("offer %s: %w", format, err)
+		}
+
+		attachID := uuid.New().String()
+
+		offer.Formats = append(offer.Formats, Format{AttachID: attachID, Format: format})
+		offer.OffersAttach = append(offer.OffersAttach, newAttachment(attachID, data))
+	}
+
+	return offer, nil
+}
+
+// IssueCredential builds IssueCredential message with the credential issued for each attachment
+// of RequestCredential message.
+func (r *FormatRegistry) IssueCredential(request *RequestCredential) (*IssueCredential, error) {
+	issue := &IssueCredential{Type: IssueCredentialMsgType}
+
+	err := forEachAttachment(request.Formats, request.RequestsAttach,
+		func(format string, attachment *decorator.Attachment) error {
+			handler, err := r.Handler(format)
+			if err != nil {
+				return err
+			}
+
+			data, err := handler.Issue(&attachment.Data)
+			if err != nil {
+				return fmt.Errorf("issue %s: %w", format, err)
+			}
+
+			attachID := uuid.New().String()
+
+			if f, ok := handler.(issuedFormatter); ok {
+				format = f.IssuedFormat()
+			}
+
+			issue.Formats = append(issue.Formats, Format{AttachID: attachID, Format: format})
+			issue.CredentialsAttach = append(issue.CredentialsAttach, newAttachment(attachID, data))
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
+
+// ValidateCredentials validates the credentials attached to IssueCredential message.
+// The attachments in formats without registered handlers are not validated.
+func (r *FormatRegistry) ValidateCredentials(msg *IssueCredential) error {
+	return forEachAttachment(msg.Formats, msg.CredentialsAttach,
+		func(format string, attachment *decorator.Attachment) error {
+			handler, err := r.Handler(format)
+			if errors.Is(err, ErrFormatNotSupported) {
+				return nil
+			}
+
+			if err = handler.Validate(&attachment.Data); err != nil {
+				return fmt.Errorf("validate %s: %w", format, err)
+			}
+
+			return nil
+		})
+}
+
+// forEachAttachment calls fn for each format entry with the attachment the entry refers to.
+func forEachAttachment(formats []Format, attachments []decorator.Attachment,
+	fn func(format string, attachment *decorator.Attachment) error) error {
+	for _, f := range formats {
+		attachment := findAttachment(attachments, f.AttachID)
+		if attachment == nil {
+			return fmt.Errorf("attachment %q of format %s not found", f.AttachID, f.Format)
+		}
+
+		if err := fn(f.Format, attachment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func findAttachment(attachments []decorator.Attachment, id string) *decorator.Attachment {
+	for i := range attachments {
+		if attachments[i].ID == id {
+			return &attachments[i]
+		}
+	}
+
+	return nil
+}
+
+func newAttachment(id string, data *decorator.AttachmentData) decorator.Attachment {
+	attachment := decorator.Attachment{ID: id, Data: *data}
+
+	if data.JSON != nil {
+		attachment.MimeType = jsonMimeType
+	}
+
+	return attachment
+}
+
+// NewCredentialPreview returns the credential preview with the given attributes.
+func NewCredentialPreview(attributes ...Attribute) *PreviewCredential {
+	return &PreviewCredential{
+		Type:       CredentialPreviewMsgType,
+		Attributes: attributes,
+	}
+}
+
+// Attribute returns the attribute of the preview by name, or nil if there is no such attribute.
+func (p *PreviewCredential) Attribute(name string) *Attribute {
+	for i := range p.Attributes {
+		if p.Attributes[i].Name == name {
+			return &p.Attributes[i]
+		}
+	}
+
+	return nil
+}
+
+// Diff returns the names of attributes which are missing in the other preview or whose values differ,
+// followed by the names of attributes which are present only in the other preview.
+// It is used to decide whether a counter proposal (or offer) matches the preview.
+func (p *PreviewCredential) Diff(other *PreviewCredential) []string {
+	var names []string
+
+	for i := range p.Attributes {
+		a := other.Attribute(p.Attributes[i].Name)
+		if a == nil || *a != p.Attributes[i] {
+			names = append(names, p.Attributes[i].Name)
+		}
+	}
+
+	for i := range other.Attributes {
+		if p.Attribute(other.Attributes[i].Name) == nil {
+			names = append(names, other.Attributes[i].Name)
+		}
+	}
+
+	return names
+}
+
+// AttachmentRefPrefix prefixes the @id of the attachment in the value of the attribute referring to it.
+const AttachmentRefPrefix = "#"
+
+// AttachmentRef returns the @id of the attachment the attribute refers to, false if the value is not a reference.
+func (a *Attribute) AttachmentRef() (string, bool) {
+	if !strings.HasPrefix(a.Value, AttachmentRefPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(a.Value, AttachmentRefPrefix), true
+}
@@ -0,0 +1,205 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// testFormat offers the preview attributes and issues the requested attributes as the credential.
+type testFormat struct {
+	err error
+}
+
+func (f *testFormat) Offer(preview *PreviewCredential) (*decorator.AttachmentData, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	attributes := map[string]interface{}{}
+	for _, a := range preview.Attributes {
+		attributes[a.Name] = a.Value
+	}
+
+	return &decorator.AttachmentData{JSON: attributes}, nil
+}
+
+func (f *testFormat) Issue(request *decorator.AttachmentData) (*decorator.AttachmentData, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &decorator.AttachmentData{JSON: map[string]interface{}{"credentialSubject": request.JSON}}, nil
+}
+
+func (f *testFormat) Validate(credential *decorator.AttachmentData) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	vc, ok := credential.JSON.(map[string]interface{})
+	if !ok {
+		return errors.New("credential is not JSON object")
+	}
+
+	if _, ok := vc["credentialSubject"]; !ok {
+		return errors.New("credentialSubject is missing")
+	}
+
+	return nil
+}
+
+func TestFormatRegistry(t *testing.T) {
+	registry := NewFormatRegistry()
+	registry.Register(LDProofVCFormat, &testFormat{})
+	registry.Register(JWTVCFormat, &testFormat{err: errors.New("test error")})
+
+	require.Equal(t, []string{LDProofVCFormat, JWTVCFormat}, registry.Formats())
+
+	_, err := registry.Handler(AnoncredsFormat)
+	require.ErrorIs(t, err, ErrFormatNotSupported)
+
+	require.True(t, registry.Supports([]Format{{Format: LDProofVCFormat}}))
+	require.False(t, registry.Supports([]Format{{Format: LDProofVCFormat}, {Format: AnoncredsFormat}}))
+	require.False(t, registry.Supports(nil))
+
+	preview := NewCredentialPreview(Attribute{Name: "name", Value: "Alice"})
+
+	t.Run("offer, issue and validate", func(t *testing.T) {
+		offer, err := registry.OfferCredential(preview, LDProofVCFormat)
+		require.NoError(t, err)
+		require.Equal(t, OfferCredentialMsgType, offer.Type)
+		require.Equal(t, *preview, offer.CredentialPreview)
+		require.Len(t, offer.Formats, 1)
+		require.Equal(t, LDProofVCFormat, offer.Formats[0].Format)
+		require.Equal(t, offer.Formats[0].AttachID, offer.OffersAttach[0].ID)
+		require.Equal(t, jsonMimeType, offer.OffersAttach[0].MimeType)
+
+		issue, err := registry.IssueCredential(&RequestCredential{
+			Formats:        offer.Formats,
+			RequestsAttach: offer.OffersAttach,
+		})
+		require.NoError(t, err)
+		require.Equal(t, IssueCredentialMsgType, issue.Type)
+		require.Len(t, issue.CredentialsAttach, 1)
+		require.Equal(t, map[string]interface{}{
+			"credentialSubject": map[string]interface{}{"name": "Alice"},
+		}, issue.CredentialsAttach[0].Data.JSON)
+
+		require.NoError(t, registry.ValidateCredentials(issue))
+	})
+
+	t.Run("offer errors", func(t *testing.T) {
+		_, err := registry.OfferCredential(preview)
+		require.EqualError(t, err, "no credential formats")
+
+		_, err = registry.OfferCredential(preview, AnoncredsFormat)
+		require.ErrorIs(t, err, ErrFormatNotSupported)
+
+		_, err = registry.OfferCredential(preview, JWTVCFormat)
+		require.EqualError(t, err, "offer jwt-vc: test error")
+	})
+
+	t.Run("issue errors", func(t *testing.T) {
+		_, err := registry.IssueCredential(&RequestCredential{
+			Formats: []Format{{AttachID: "1", Format: LDProofVCFormat}},
+		})
+		require.EqualError(t, err, `attachment "1" of format aries/ld-proof-vc@v1.0 not found`)
+
+		attach := []decorator.Attachment{{ID: "1"}}
+
+		_, err = registry.IssueCredential(&RequestCredential{
+			Formats:        []Format{{AttachID: "1", Format: AnoncredsFormat}},
+			RequestsAttach: attach,
+		})
+		require.ErrorIs(t, err, ErrFormatNotSupported)
+
+		_, err = registry.IssueCredential(&RequestCredential{
+			Formats:        []Format{{AttachID: "1", Format: JWTVCFormat}},
+			RequestsAttach: attach,
+		})
+		require.EqualError(t, err, "issue jwt-vc: test error")
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		err := registry.ValidateCredentials(&IssueCredential{
+			Formats: []Format{{AttachID: "1", Format: LDProofVCFormat}},
+			CredentialsAttach: []decorator.Attachment{{
+				ID:   "1",
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{}},
+			}},
+		})
+		require.EqualError(t, err, "validate aries/ld-proof-vc@v1.0: credentialSubject is missing")
+
+		// attachments of unsupported formats are not validated
+		err = registry.ValidateCredentials(&IssueCredential{
+			Formats:           []Format{{AttachID: "1", Format: AnoncredsFormat}},
+			CredentialsAttach: []decorator.Attachment{{ID: "1"}},
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestFormatRegistry_SetInteropMode(t *testing.T) {
+	registry := NewFormatRegistry()
+	registry.Register(LDProofVCDetailFormat, &testFormat{})
+
+	_, err := registry.Handler("aries/ld-proof-vc-detail@1.0")
+	require.ErrorIs(t, err, ErrFormatNotSupported)
+
+	registry.SetInteropMode(interop.ACAPy())
+
+	_, err = registry.Handler("aries/ld-proof-vc-detail@1.0")
+	require.NoError(t, err)
+
+	// the credentials are issued in the format string of the request
+	issue, err := registry.IssueCredential(&RequestCredential{
+		Formats:        []Format{{AttachID: "1", Format: "aries/ld-proof-vc-detail@1.0"}},
+		RequestsAttach: []decorator.Attachment{{ID: "1"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "aries/ld-proof-vc-detail@1.0", issue.Formats[0].Format)
+
+	_, err = registry.Handler("hlindy/cred-abstract@v2.0")
+	require.ErrorIs(t, err, ErrFormatNotSupported)
+}
+
+func TestPreviewCredential_Diff(t *testing.T) {
+	preview := NewCredentialPreview(
+		Attribute{Name: "name", Value: "Alice"},
+		Attribute{Name: "age", Value: "30"},
+	)
+
+	require.Equal(t, CredentialPreviewMsgType, preview.Type)
+	require.Equal(t, "30", preview.Attribute("age").Value)
+	require.Nil(t, preview.Attribute("degree"))
+	require.Empty(t, preview.Diff(preview))
+
+	other := NewCredentialPreview(
+		Attribute{Name: "name", Value: "Alice"},
+		Attribute{Name: "age", Value: "31"},
+		Attribute{Name: "degree", Value: "BSc"},
+	)
+
+	require.Equal(t, []string{"age", "degree"}, preview.Diff(other))
+	require.Equal(t, []string{"age", "degree"}, other.Diff(preview))
+}
+
+func TestAttribute_AttachmentRef(t *testing.T) {
+	id, ok := (&Attribute{Name: "photo", MimeType: "image/png", Value: "#attachID"}).AttachmentRef()
+	require.True(t, ok)
+	require.Equal(t, "attachID", id)
+
+	_, ok = (&Attribute{Name: "name", Value: "Alice"}).AttachmentRef()
+	require.False(t, ok)
+}
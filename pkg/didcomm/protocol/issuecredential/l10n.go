@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/l10n"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// LocalizedPropKey is the key of the event property holding the localized texts of the fields of the received
+// message (see decorator.LocalizedFields).
+const LocalizedPropKey = decorator.LocalizedPropKey
+
+// SetMessageCatalogs sets the message catalogs the problem reports sent by the service are localized with:
+// the texts of their codes are added to their description. A nil registry disables the localization.
+func (s *Service) SetMessageCatalogs(catalogs *l10n.Registry) {
+	s.catalogs = catalogs
+}
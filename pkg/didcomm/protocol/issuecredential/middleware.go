@@ -38,6 +38,9 @@ type Metadata interface {
 	RequestCredential() *RequestCredential
 	// CredentialNames is a slice which contains credential names provided by the user through the Continue function.
 	CredentialNames() []string
+	// CredentialAcceptance contains the decisions about the received credentials provided by the user
+	// through the Continue function.
+	CredentialAcceptance() []CredentialAcceptance
 	// StateName provides the state name
 	StateName() string
 	// Properties provides the possibility to set properties
@@ -15,8 +15,8 @@ type ProposeCredential struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is an optional field that provides human readable information about this Credential Offer,
 	// so the offer can be evaluated by human judgment.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
-	Comment string `json:"comment,omitempty"`
+	Comment     string              `json:"comment,omitempty"`
+	CommentL10n decorator.Localized `json:"comment~l10n,omitempty"`
 	// CredentialProposal is an optional JSON-LD object that represents
 	// the credential data that the Prover wants to receive.
 	CredentialProposal PreviewCredential `json:"credential_proposal,omitempty"`
@@ -26,6 +26,8 @@ type ProposeCredential struct {
 	// FiltersAttach is an array of attachments that further define the credential being proposed.
 	// This might be used to clarify which formats or format versions are wanted.
 	FiltersAttach []decorator.Attachment `json:"filters~attach,omitempty"`
+	// Attachments are the attachments referenced by the attributes of CredentialProposal.
+	Attachments []decorator.Attachment `json:"~attach,omitempty"`
 }
 
 // Format contains the the value of the attachment @id and the verifiable credential format of the attachment.
@@ -36,13 +38,13 @@ type Format struct {
 
 // OfferCredential is a message sent by the Issuer to the potential Holder,
 // describing the credential they intend to offer and possibly the price they expect to be paid.
-// TODO: Need to add ~payment_request and ~timing.expires_time decorators [Issue #1297].
+// TODO: Need to add ~payment_request decorator [Issue #1297].
 type OfferCredential struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is an optional field that provides human readable information about this Credential Offer,
 	// so the offer can be evaluated by human judgment.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300].
-	Comment string `json:"comment,omitempty"`
+	Comment     string              `json:"comment,omitempty"`
+	CommentL10n decorator.Localized `json:"comment~l10n,omitempty"`
 	// CredentialPreview is a JSON-LD object that represents the credential data that Issuer is willing to issue.
 	CredentialPreview PreviewCredential `json:"credential_preview,omitempty"`
 	// Formats contains an entry for each offers~attach array entry, providing the the value
@@ -51,6 +53,11 @@ type OfferCredential struct {
 	// OffersAttach is a slice of attachments that further define the credential being offered.
 	// This might be used to clarify which formats or format versions will be issued.
 	OffersAttach []decorator.Attachment `json:"offers~attach,omitempty"`
+	// Attachments are the attachments referenced by the attributes of CredentialPreview.
+	Attachments []decorator.Attachment `json:"~attach,omitempty"`
+	// Timing is the expiration time of the offer, the Issuer abandons the protocol instance once it passes
+	// without a request (see Service.EnableExpiry).
+	Timing *decorator.Timing `json:"~timing,omitempty"`
 }
 
 // RequestCredential is a message sent by the potential Holder to the Issuer,
@@ -63,29 +70,63 @@ type RequestCredential struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is an optional field that provides human readable information about this Credential Offer,
 	// so the offer can be evaluated by human judgment.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300].
-	Comment string `json:"comment,omitempty"`
+	Comment     string              `json:"comment,omitempty"`
+	CommentL10n decorator.Localized `json:"comment~l10n,omitempty"`
 	// Formats contains an entry for each requests~attach array entry, providing the the value
 	// of the attachment @id and the verifiable credential format and version of the attachment.
 	Formats []Format `json:"formats,omitempty"`
 	// RequestsAttach is a slice of attachments defining the requested formats for the credential
 	RequestsAttach []decorator.Attachment `json:"requests~attach,omitempty"`
+	// Timing is the expiration time of the request, the Holder abandons the protocol instance once it passes
+	// without the credentials (see Service.EnableExpiry).
+	Timing *decorator.Timing `json:"~timing,omitempty"`
 }
 
 // IssueCredential contains as attached payload the credentials being issued and is
 // sent in response to a valid Invitation Credential message.
-// TODO: Need to add ~please-ack decorator [Issue #1299].
 type IssueCredential struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is an optional field that provides human readable information about this Credential Offer,
 	// so the offer can be evaluated by human judgment.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300].
-	Comment string `json:"comment,omitempty"`
+	Comment     string              `json:"comment,omitempty"`
+	CommentL10n decorator.Localized `json:"comment~l10n,omitempty"`
 	// Formats contains an entry for each credentials~attach array entry, providing the the value
 	// of the attachment @id and the verifiable credential format and version of the attachment.
 	Formats []Format `json:"formats,omitempty"`
 	// CredentialsAttach is a slice of attachments containing the issued credentials.
 	CredentialsAttach []decorator.Attachment `json:"credentials~attach,omitempty"`
+	// PleaseAck requests the Holder to acknowledge the receipt of the credentials.
+	PleaseAck *decorator.PleaseAck `json:"~please_ack,omitempty"`
+}
+
+// Ack is a message sent by the Holder to acknowledge the receipt of the credentials.
+type Ack struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Status string            `json:"status,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	// CredentialResults contain the outcome of each credential attached to IssueCredential message.
+	CredentialResults []CredentialResult `json:"credentials~results,omitempty"`
+}
+
+// CredentialResult is the outcome of a credential attached to IssueCredential message.
+type CredentialResult struct {
+	// AttachID is the ID of the attachment containing the credential.
+	AttachID string `json:"attach_id"`
+	// Name is the name the accepted credential was saved under.
+	Name string `json:"name,omitempty"`
+	// Accepted is true if the Holder accepted the credential.
+	Accepted bool `json:"accepted"`
+}
+
+// CredentialAcceptance is the decision of the Holder about a credential attached to IssueCredential message.
+type CredentialAcceptance struct {
+	// AttachID is the ID of the attachment containing the credential.
+	AttachID string
+	// Name is the name the credential is saved under (optional).
+	Name string
+	// Accept is false if the Holder rejects the credential.
+	Accept bool
 }
 
 // PreviewCredential is used to construct a preview of the data for the credential that is to be issued.
@@ -95,6 +136,7 @@ type PreviewCredential struct {
 }
 
 // Attribute describes an attribute for a Preview Credential.
+// The attribute whose value is in an attachment of the message refers to it by its @id (see AttachmentRef).
 type Attribute struct {
 	Name     string `json:"name,omitempty"`
 	MimeType string `json:"mime-type,omitempty"`
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"sync"
+	"time"
+)
+
+// codeQuotaExceeded is the code of the problem report sent when a request exceeds the IssuanceQuota.
+const codeQuotaExceeded = "quota-exceeded"
+
+// IssuanceQuota limits the credentials issued by the Issuer, the requests exceeding the quota are abandoned
+// with a problem report (code "quota-exceeded") without triggering the action event.
+// The usage is kept in memory: it is not shared between the instances of the agent and is reset on restart.
+type IssuanceQuota struct {
+	// PerConnection is the maximum number of credentials issued to a connection (their DID) within Period.
+	// Zero means no limit.
+	PerConnection int
+	// Period is the sliding window of PerConnection.
+	Period time.Duration
+	// MaxConcurrent is the maximum number of requests processed at the same time, from their receipt
+	// until the credentials are issued or the request is declined. Zero means no limit.
+	MaxConcurrent int
+}
+
+type issuance struct {
+	time  time.Time
+	count int
+}
+
+// issuanceLimiter enforces IssuanceQuota.
+type issuanceLimiter struct {
+	mu       sync.Mutex
+	quota    IssuanceQuota
+	now      func() time.Time
+	inFlight map[string]struct{}
+	issued   map[string][]issuance
+}
+
+func newIssuanceLimiter(quota IssuanceQuota) *issuanceLimiter {
+	return &issuanceLimiter{
+		quota:    quota,
+		now:      time.Now,
+		inFlight: make(map[string]struct{}),
+		issued:   make(map[string][]issuance),
+	}
+}
+
+// acquire reserves the processing of the request of protocol instance piID from theirDID.
+// Returns false if the request exceeds the quota.
+func (l *issuanceLimiter) acquire(piID, theirDID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.quota.MaxConcurrent > 0 && len(l.inFlight) >= l.quota.MaxConcurrent {
+		return false
+	}
+
+	if l.quota.PerConnection > 0 && l.issuedCount(theirDID) >= l.quota.PerConnection {
+		return false
+	}
+
+	l.inFlight[piID] = struct{}{}
+
+	return true
+}
+
+// release ends the processing of the request of md, the credentials are counted if they were issued.
+func (l *issuanceLimiter) release(md *metaData) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.inFlight[md.PIID]; !ok {
+		return
+	}
+
+	delete(l.inFlight, md.PIID)
+
+	if md.err != nil || md.state.Name() != stateNameCredentialIssued || md.issueCredential == nil {
+		return
+	}
+
+	l.issued[md.TheirDID] = append(l.issued[md.TheirDID], issuance{
+		time:  l.now(),
+		count: len(md.issueCredential.CredentialsAttach),
+	})
+}
+
+// issuedCount returns the number of credentials issued to theirDID within the period, older issuances are dropped.
+func (l *issuanceLimiter) issuedCount(theirDID string) int {
+	since := l.now().Add(-l.quota.Period)
+
+	var (
+		count  int
+		recent []issuance
+	)
+
+	for _, i := range l.issued[theirDID] {
+		if i.time.After(since) {
+			recent = append(recent, i)
+			count += i.count
+		}
+	}
+
+	if len(recent) == 0 {
+		delete(l.issued, theirDID)
+	} else {
+		l.issued[theirDID] = recent
+	}
+
+	return count
+}
@@ -0,0 +1,163 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	issuecredentialMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/issuecredential"
+)
+
+func TestIssuanceLimiter(t *testing.T) {
+	issued := func(piID, theirDID string, credentials int) *metaData {
+		return &metaData{
+			transitionalPayload: transitionalPayload{Action: Action{PIID: piID, TheirDID: theirDID}},
+			state:               &credentialIssued{},
+			issueCredential:     &IssueCredential{CredentialsAttach: make([]decorator.Attachment, credentials)},
+		}
+	}
+
+	t.Run("per connection", func(t *testing.T) {
+		now := time.Now()
+
+		l := newIssuanceLimiter(IssuanceQuota{PerConnection: 3, Period: time.Hour})
+		l.now = func() time.Time { return now }
+
+		require.True(t, l.acquire("1", Bob))
+		l.release(issued("1", Bob, 2))
+
+		require.True(t, l.acquire("2", Bob))
+		l.release(issued("2", Bob, 1))
+
+		require.False(t, l.acquire("3", Bob))
+		require.True(t, l.acquire("4", Alice))
+
+		// the issuances are out of the period
+		now = now.Add(time.Hour)
+
+		require.True(t, l.acquire("5", Bob))
+	})
+
+	t.Run("declined requests are not counted", func(t *testing.T) {
+		l := newIssuanceLimiter(IssuanceQuota{PerConnection: 1, Period: time.Hour})
+
+		require.True(t, l.acquire("1", Bob))
+
+		md := issued("1", Bob, 1)
+		md.state = &done{}
+		md.err = errProtocolStopped
+		l.release(md)
+
+		require.True(t, l.acquire("2", Bob))
+	})
+
+	t.Run("concurrency", func(t *testing.T) {
+		l := newIssuanceLimiter(IssuanceQuota{MaxConcurrent: 2})
+
+		require.True(t, l.acquire("1", Bob))
+		require.True(t, l.acquire("2", Alice))
+		require.False(t, l.acquire("3", Bob))
+
+		l.release(issued("1", Bob, 1))
+		// released twice (e.g. abandoning after a failure)
+		l.release(issued("1", Bob, 1))
+
+		require.True(t, l.acquire("3", Bob))
+		require.False(t, l.acquire("4", Bob))
+	})
+}
+
+func TestService_IssuanceQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	svc.RegisterFormat(LDProofVCDetailFormat, &detailTestFormat{})
+	svc.SetAutoIssuePolicy(func(_ *RequestCredential, _, _ string) bool { return true })
+	svc.SetIssuanceQuota(&IssuanceQuota{PerConnection: 1, Period: time.Hour})
+
+	ch := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(ch))
+
+	newRequest := func() service.DIDCommMsgMap {
+		msg := service.NewDIDCommMsgMap(RequestCredential{
+			Type:    RequestCredentialMsgType,
+			Formats: []Format{{AttachID: "1", Format: LDProofVCDetailFormat}},
+			RequestsAttach: []decorator.Attachment{{
+				ID:   "1",
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{"name": "Alice"}},
+			}},
+		})
+
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		return msg
+	}
+
+	issued := make(chan struct{})
+
+	messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Bob).
+		Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+			defer close(issued)
+
+			require.Equal(t, IssueCredentialMsgType, msg.Type())
+
+			return nil
+		})
+
+	_, err = svc.HandleInbound(newRequest(), service.NewDIDCommContext(Alice, Bob, nil))
+	require.NoError(t, err)
+
+	select {
+	case <-issued:
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	// the credential is counted once the callback is processed
+	require.Eventually(t, func() bool {
+		svc.limiter.mu.Lock()
+		defer svc.limiter.mu.Unlock()
+
+		return len(svc.limiter.inFlight) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+		Do(func(msg service.DIDCommMsgMap, _ *service.NestedReplyOpts) error {
+			r := &model.ProblemReport{}
+			require.NoError(t, msg.Decode(r))
+			require.Equal(t, ProblemReportMsgType, r.Type)
+			require.Equal(t, codeQuotaExceeded, r.Description.Code)
+
+			return nil
+		})
+
+	_, err = svc.HandleInbound(newRequest(), service.NewDIDCommContext(Alice, Bob, nil))
+	require.NoError(t, err)
+	require.Empty(t, ch)
+
+	svc.SetIssuanceQuota(nil)
+	require.Nil(t, svc.limiter)
+}
@@ -15,6 +15,11 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/l10n"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/outbox"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/internal/instance"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -39,6 +44,11 @@ const (
 	CredentialPreviewMsgType = Spec + "credential-preview"
 )
 
+// CredentialResultsPropKey is the key of the event property containing the outcome of each credential
+// attached to IssueCredential message ([]CredentialResult). The property is set on the Holder's side
+// by the middleware saving the credentials and on the Issuer's side when the Holder's Ack is received.
+const CredentialResultsPropKey = "credentialResults"
+
 const (
 	stateNameKey           = "state_name_"
 	transitionalPayloadKey = "transitionalPayload_%s"
@@ -70,12 +80,22 @@ type metaData struct {
 	inbound         bool
 	properties      map[string]interface{}
 	credentialNames []string
+	// acceptance keeps the Holder's decision about each received credential.
+	acceptance []CredentialAcceptance
 	// keeps offer credential payload,
 	// allows filling the message by providing an option function.
 	offerCredential   *OfferCredential
 	proposeCredential *ProposeCredential
 	requestCredential *RequestCredential
 	issueCredential   *IssueCredential
+	// offerPreview replaces the preview of the proposal the offer is built from.
+	offerPreview     *PreviewCredential
+	offerAttachments []decorator.Attachment
+	// formats builds the messages which were not provided by the user
+	// and validates the received credentials.
+	formats *FormatRegistry
+	// catalogs localize the problem reports.
+	catalogs *l10n.Registry
 	// err is used to determine whether callback was stopped
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function.
@@ -106,6 +126,10 @@ func (md *metaData) CredentialNames() []string {
 	return md.credentialNames
 }
 
+func (md *metaData) CredentialAcceptance() []CredentialAcceptance {
+	return md.acceptance
+}
+
 func (md *metaData) StateName() string {
 	return md.state.Name()
 }
@@ -150,6 +174,17 @@ func WithOfferCredential(msg *OfferCredential) Opt {
 	}
 }
 
+// WithOfferPreview allows providing the preview of the offer built by the handlers of the registered credential
+// formats, instead of the preview of the proposal. The attachments are the ones referenced by the preview attributes.
+// USAGE: This option should be provided after receiving a ProposeCredential message, e.g. to counter
+// the Holder's proposal negotiating an offer.
+func WithOfferPreview(preview *PreviewCredential, attachments ...decorator.Attachment) Opt {
+	return func(md *metaData) {
+		md.offerPreview = preview
+		md.offerAttachments = attachments
+	}
+}
+
 // WithIssueCredential allows providing IssueCredential message
 // USAGE: This message should be provided after receiving a RequestCredential message.
 func WithIssueCredential(msg *IssueCredential) Opt {
@@ -166,6 +201,15 @@ func WithFriendlyNames(names ...string) Opt {
 	}
 }
 
+// WithCredentialAcceptance allows accepting or rejecting each credential attached to IssueCredential message.
+// The credentials without a decision are accepted.
+// USAGE: This function should be used when the Holder receives IssueCredential message.
+func WithCredentialAcceptance(acceptance ...CredentialAcceptance) Opt {
+	return func(md *metaData) {
+		md.acceptance = acceptance
+	}
+}
+
 // Provider contains dependencies for the protocol and is typically created by using aries.Context().
 type Provider interface {
 	Messenger() service.Messenger
@@ -180,8 +224,20 @@ type Service struct {
 	callbacks  chan *metaData
 	messenger  service.Messenger
 	middleware Handler
+	formats    *FormatRegistry
+	autoIssue  AutoIssuePolicy
+	limiter    *issuanceLimiter
+	locker     *instance.Locker
+	outbox     *outbox.Outbox
+	expiry     *expiry.Tracker
+	catalogs   *l10n.Registry
 }
 
+// AutoIssuePolicy decides whether the credentials requested by the Holder are issued automatically,
+// without triggering the action event. Only the requests whose formats are all registered
+// (e.g. LDProofVCDetailFormat) are issued automatically.
+type AutoIssuePolicy func(request *RequestCredential, myDID, theirDID string) bool
+
 // New returns the issuecredential service.
 func New(p Provider) (*Service, error) {
 	store, err := p.StorageProvider().OpenStore(Name)
@@ -189,7 +245,9 @@ func New(p Provider) (*Service, error) {
 		return nil, err
 	}
 
-	err = p.StorageProvider().SetStoreConfig(Name, storage.StoreConfiguration{TagNames: []string{transitionalPayloadKey}})
+	err = p.StorageProvider().SetStoreConfig(Name, storage.StoreConfiguration{
+		TagNames: []string{transitionalPayloadKey, outbox.TagName, expiry.TagName},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store config: %w", err)
 	}
@@ -199,6 +257,8 @@ func New(p Provider) (*Service, error) {
 		store:      store,
 		callbacks:  make(chan *metaData),
 		middleware: initialHandler,
+		formats:    NewFormatRegistry(),
+		locker:     instance.NewLocker(p, Name),
 	}
 
 	// start the listener
@@ -217,6 +277,45 @@ func (s *Service) Use(items ...Middleware) {
 	s.middleware = handler
 }
 
+// RegisterFormat registers the handler of the credential attachment format.
+// The handlers build the offer from the proposal and the credentials from the request if the user accepts
+// the proposal or the request without providing the message, and validate the received credentials.
+func (s *Service) RegisterFormat(format string, handler FormatHandler) {
+	s.formats.Register(format, handler)
+}
+
+// Formats returns the registry of credential attachment formats.
+func (s *Service) Formats() *FormatRegistry {
+	return s.formats
+}
+
+// SetAutoIssuePolicy sets the policy of automatic issuance of the requested credentials.
+// A nil policy disables automatic issuance.
+func (s *Service) SetAutoIssuePolicy(policy AutoIssuePolicy) {
+	s.autoIssue = policy
+}
+
+// SetIssuanceQuota sets the quota of the credentials issued by the Issuer, the credential requests exceeding it
+// are abandoned with a problem report. A nil quota removes the limits.
+func (s *Service) SetIssuanceQuota(quota *IssuanceQuota) {
+	if quota == nil {
+		s.limiter = nil
+
+		return
+	}
+
+	s.limiter = newIssuanceLimiter(*quota)
+}
+
+// EnableOutbox persists the outbound messages in the same batch as the protocol state, so they are not lost if
+// the agent stops before sending them. The worker of the returned outbox retrying the failed sends must be started
+// by the caller.
+func (s *Service) EnableOutbox(opts ...outbox.Option) *outbox.Outbox {
+	s.outbox = outbox.New(s.store, s.messenger, opts...)
+
+	return s.outbox
+}
+
 // HandleInbound handles inbound message (issuecredential protocol).
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
 	aEvent := s.ActionEvent()
@@ -226,6 +325,13 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		return "", errors.New("no clients are registered to handle the message")
 	}
 
+	unlock, err := s.locker.LockMsg(msg)
+	if err != nil {
+		return "", fmt.Errorf("lock: %w", err)
+	}
+
+	defer unlock()
+
 	md, err := s.doHandle(msg, false)
 	if err != nil {
 		return "", fmt.Errorf("doHandle: %w", err)
@@ -236,6 +342,28 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 	md.MyDID = ctx.MyDID()
 	md.TheirDID = ctx.TheirDID()
 
+	if err = s.trackExpiry(md); err != nil {
+		return "", fmt.Errorf("track expiry: %w", err)
+	}
+
+	// abandons the requests exceeding the issuance quota, the user is not involved
+	if s.exceedsQuota(md) {
+		md.state = &abandoning{Code: codeQuotaExceeded}
+
+		if err = s.handle(md); err != nil {
+			return "", fmt.Errorf("handle inbound: %w", err)
+		}
+
+		return msg.ThreadID()
+	}
+
+	// issues the credentials as if the user accepted the request (failures abandon the protocol)
+	if s.canAutoIssue(md) {
+		s.processCallback(md)
+
+		return "", nil
+	}
+
 	// trigger action event based on message type for inbound messages
 	if canTriggerActionEvents(msg) {
 		err = s.saveTransitionalPayload(md.PIID, md.transitionalPayload)
@@ -243,6 +371,8 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 			return "", fmt.Errorf("save transitional payload: %w", err)
 		}
 
+		decorator.AddLocalizedFields(md.properties, md.Msg)
+
 		aEvent <- s.newDIDCommActionMsg(md)
 
 		return "", nil
@@ -258,6 +388,13 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 
 // HandleOutbound handles outbound message (issuecredential protocol).
 func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	unlock, err := s.locker.LockMsg(msg)
+	if err != nil {
+		return "", fmt.Errorf("lock: %w", err)
+	}
+
+	defer unlock()
+
 	md, err := s.doHandle(msg, true)
 	if err != nil {
 		return "", fmt.Errorf("doHandle: %w", err)
@@ -267,6 +404,10 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 	md.MyDID = myDID
 	md.TheirDID = theirDID
 
+	if err = s.trackExpiry(md); err != nil {
+		return "", fmt.Errorf("track expiry: %w", err)
+	}
+
 	if err = s.handle(md); err != nil {
 		return "", fmt.Errorf("handle outbound: %w", err)
 	}
@@ -322,22 +463,44 @@ func (s *Service) doHandle(msg service.DIDCommMsg, outbound bool) (*metaData, er
 		properties: map[string]interface{}{},
 		state:      next,
 		msgClone:   msg.Clone(),
+		formats:    s.formats,
 	}, nil
 }
 
 // startInternalListener listens to messages in go channel for callback messages from clients.
 func (s *Service) startInternalListener() {
 	for msg := range s.callbacks {
-		// if no error do handle
-		if msg.err == nil {
-			msg.err = s.handle(msg)
-		}
+		s.handleCallback(msg)
 
-		// no error - continue
-		if msg.err == nil {
-			continue
+		if limiter := s.limiter; limiter != nil {
+			limiter.release(msg)
 		}
+	}
+}
+
+func (s *Service) handleCallback(msg *metaData) {
+	unlock, err := s.locker.Lock(msg.PIID)
+	if err != nil {
+		logger.Errorf("lock %s: %s", msg.PIID, err)
+
+		return
+	}
+
+	defer unlock()
+
+	// another agent instance may have advanced the protocol instance since the message was received
+	if err = s.checkTransition(msg); err != nil {
+		logger.Errorf("failed to handle msgID=%s : %s", msg.Msg.ID(), err)
+
+		return
+	}
 
+	// if no error do handle
+	if msg.err == nil {
+		msg.err = s.handle(msg)
+	}
+
+	if msg.err != nil {
 		logger.Errorf("abandoning: %s", msg.err)
 		msg.state = &abandoning{Code: codeInternalError}
 
@@ -347,6 +510,20 @@ func (s *Service) startInternalListener() {
 	}
 }
 
+// checkTransition checks the stored state of the protocol instance can still transition to the state of the message.
+func (s *Service) checkTransition(md *metaData) error {
+	stateName, err := s.currentStateName(md.PIID)
+	if err != nil {
+		return fmt.Errorf("current state name: %w", err)
+	}
+
+	if current := stateFromName(stateName); !current.CanTransitionTo(md.state) {
+		return fmt.Errorf("invalid state transition: %s -> %s", current.Name(), md.state.Name())
+	}
+
+	return nil
+}
+
 func isNoOp(s state) bool {
 	_, ok := s.(*noOp)
 	return ok
@@ -359,6 +536,8 @@ func (s *Service) handle(md *metaData) error {
 		stateName string
 	)
 
+	md.catalogs = s.catalogs
+
 	for !isNoOp(current) {
 		stateName = current.Name()
 
@@ -376,6 +555,10 @@ func (s *Service) handle(md *metaData) error {
 		current = next
 	}
 
+	if s.outbox != nil {
+		return s.commit(md.PIID, stateName, actions)
+	}
+
 	if err := s.saveStateName(md.PIID, stateName); err != nil {
 		return fmt.Errorf("failed to persist state %s: %w", stateName, err)
 	}
@@ -389,6 +572,24 @@ func (s *Service) handle(md *metaData) error {
 	return nil
 }
 
+// commit saves the state with the messages sent by the actions in one batch, then sends the messages.
+func (s *Service) commit(piID, stateName string, actions []stateAction) error {
+	recorder := s.outbox.NewRecorder()
+
+	for _, action := range actions {
+		if err := action(recorder); err != nil {
+			return fmt.Errorf("action %s: %w", stateName, err)
+		}
+	}
+
+	err := s.outbox.Commit(recorder, storage.Operation{Key: stateNameKey + piID, Value: []byte(stateName)})
+	if err != nil {
+		return fmt.Errorf("failed to persist state %s: %w", stateName, err)
+	}
+
+	return nil
+}
+
 func getPIID(msg service.DIDCommMsg) (string, error) {
 	if pthID := msg.ParentThreadID(); pthID != "" {
 		return pthID, nil
@@ -481,6 +682,31 @@ func (s *Service) saveTransitionalPayload(id string, data transitionalPayload) e
 	return s.store.Put(fmt.Sprintf(transitionalPayloadKey, id), src, storage.Tag{Name: transitionalPayloadKey})
 }
 
+// canAutoIssue checks if the credentials of the incoming request can be issued without the action event.
+func (s *Service) canAutoIssue(md *metaData) bool {
+	if s.autoIssue == nil || md.Msg.Type() != RequestCredentialMsgType {
+		return false
+	}
+
+	request := RequestCredential{}
+	if err := md.Msg.Decode(&request); err != nil {
+		return false
+	}
+
+	return s.formats.Supports(request.Formats) && s.autoIssue(&request, md.MyDID, md.TheirDID)
+}
+
+// exceedsQuota checks if the incoming request exceeds the issuance quota, the processing of the request
+// is reserved otherwise.
+func (s *Service) exceedsQuota(md *metaData) bool {
+	limiter := s.limiter
+	if limiter == nil || md.Msg.Type() != RequestCredentialMsgType {
+		return false
+	}
+
+	return !limiter.acquire(md.PIID, md.TheirDID)
+}
+
 // canTriggerActionEvents checks if the incoming message can trigger an action event.
 func canTriggerActionEvents(msg service.DIDCommMsg) bool {
 	return msg.Type() == ProposeCredentialMsgType ||
@@ -523,6 +749,7 @@ func (s *Service) ActionContinue(piID string, opt Opt) error {
 		msgClone:            tPayload.Msg.Clone(),
 		inbound:             true,
 		properties:          map[string]interface{}{},
+		formats:             s.formats,
 	}
 
 	if opt != nil {
@@ -551,6 +778,7 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 		msgClone:            tPayload.Msg.Clone(),
 		inbound:             true,
 		properties:          map[string]interface{}{},
+		formats:             s.formats,
 	}
 
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
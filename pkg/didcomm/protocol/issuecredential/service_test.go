@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/outbox"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -250,7 +251,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -308,7 +309,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -466,7 +467,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -522,7 +523,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -578,7 +579,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -636,7 +637,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -695,7 +696,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -751,7 +752,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -793,10 +794,67 @@ func TestService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Receive Request Credential (auto-issue)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &IssueCredential{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, IssueCredentialMsgType, r.Type)
+				require.Equal(t, LDProofVCFormat, r.Formats[0].Format)
+				require.Len(t, r.CredentialsAttach, 1)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+			require.Equal(t, "credential-issued", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		svc.RegisterFormat(LDProofVCDetailFormat, &detailTestFormat{})
+		svc.SetAutoIssuePolicy(func(request *RequestCredential, myDID, theirDID string) bool {
+			return myDID == Alice && theirDID == Bob
+		})
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(RequestCredential{
+			Type:    RequestCredentialMsgType,
+			Formats: []Format{{AttachID: "1", Format: LDProofVCDetailFormat}},
+			RequestsAttach: []decorator.Attachment{{
+				ID:   "1",
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{"name": "Alice"}},
+			}},
+		})
+
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		require.Empty(t, ch)
+	})
+
 	t.Run("Receive Problem Report (continue)", func(t *testing.T) {
 		done := make(chan struct{})
 
-		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
+		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -843,7 +901,7 @@ func TestService_HandleInbound(t *testing.T) {
 	t.Run("Receive Problem Report (stop)", func(t *testing.T) {
 		done := make(chan struct{})
 
-		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
+		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -901,7 +959,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
+		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -983,7 +1041,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
+		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
@@ -1494,3 +1552,57 @@ func TestService_canTriggerActionEvents(t *testing.T) {
 
 	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(struct{}{})))
 }
+
+// detailTestFormat issues the credentials in LDProofVCFormat.
+type detailTestFormat struct {
+	testFormat
+}
+
+func (f *detailTestFormat) IssuedFormat() string {
+	return LDProofVCFormat
+}
+
+func TestService_EnableOutbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	o := svc.EnableOutbox(outbox.WithRetryInterval(0))
+
+	msg := service.NewDIDCommMsgMap(ProposeCredential{
+		Type: ProposeCredentialMsgType,
+	})
+
+	// the state is saved although the message could not be sent
+	messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(errors.New("unreachable"))
+
+	piid, err := svc.HandleOutbound(msg, Alice, Bob)
+	require.NoError(t, err)
+
+	stateName, err := svc.currentStateName(piid)
+	require.NoError(t, err)
+	require.Equal(t, stateNameProposalSent, stateName)
+
+	messenger.EXPECT().Send(gomock.Any(), Alice, Bob).
+		Do(func(msg service.DIDCommMsgMap, _, _ string) error {
+			require.Equal(t, piid, msg.ID())
+
+			return nil
+		})
+
+	sent, err := o.Dispatch()
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	sent, err = o.Dispatch()
+	require.NoError(t, err)
+	require.Equal(t, 0, sent)
+}
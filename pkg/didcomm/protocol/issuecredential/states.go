@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/l10n"
 )
 
 const (
@@ -137,10 +138,15 @@ func (s *abandoning) ExecuteInbound(md *metaData) (state, stateAction, error) {
 	}
 
 	return &done{}, func(messenger service.Messenger) error {
-		return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReport{
+		report := &model.ProblemReport{
 			Type:        ProblemReportMsgType,
 			Description: code,
-		}), &service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
+		}
+
+		md.catalogs.Localize(report)
+
+		return messenger.ReplyToNested(l10n.ProblemReportMsg(report),
+			&service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
 	}, nil
 }
 
@@ -159,7 +165,18 @@ func (s *done) CanTransitionTo(_ state) bool {
 	return false
 }
 
-func (s *done) ExecuteInbound(_ *metaData) (state, stateAction, error) {
+func (s *done) ExecuteInbound(md *metaData) (state, stateAction, error) {
+	if md.Msg.Type() == AckMsgType {
+		ack := Ack{}
+		if err := md.Msg.Decode(&ack); err != nil {
+			return nil, nil, fmt.Errorf("decode: %w", err)
+		}
+
+		if len(ack.CredentialResults) > 0 && md.properties != nil {
+			md.properties[CredentialResultsPropKey] = ack.CredentialResults
+		}
+	}
+
 	return &noOp{}, zeroAction, nil
 }
 
@@ -201,7 +218,12 @@ func (s *offerSent) CanTransitionTo(st state) bool {
 
 func (s *offerSent) ExecuteInbound(md *metaData) (state, stateAction, error) {
 	if md.offerCredential == nil {
-		return nil, nil, errors.New("offer credential was not provided")
+		offer, err := offerFromProposal(md)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		md.offerCredential = offer
 	}
 
 	// creates the state's action.
@@ -236,7 +258,12 @@ func (s *requestReceived) CanTransitionTo(st state) bool {
 
 func (s *requestReceived) ExecuteInbound(md *metaData) (state, stateAction, error) {
 	if md.issueCredential == nil {
-		return nil, nil, errors.New("issue credential was not provided")
+		issue, err := credentialsFromRequest(md)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		md.issueCredential = issue
 	}
 
 	// creates the state's action
@@ -335,6 +362,7 @@ func (s *offerReceived) ExecuteInbound(md *metaData) (state, stateAction, error)
 	action := func(messenger service.Messenger) error {
 		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(RequestCredential{
 			Type:           RequestCredentialMsgType,
+			Formats:        offer.Formats,
 			RequestsAttach: offer.OffersAttach,
 		}), md.MyDID, md.TheirDID)
 	}
@@ -382,10 +410,25 @@ func (s *credentialReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *credentialReceived) ExecuteInbound(md *metaData) (state, stateAction, error) {
+	if md.formats != nil {
+		credential := IssueCredential{}
+		if err := md.Msg.Decode(&credential); err != nil {
+			return nil, nil, fmt.Errorf("decode: %w", err)
+		}
+
+		if err := md.formats.ValidateCredentials(&credential); err != nil {
+			return nil, nil, fmt.Errorf("validate credentials: %w", err)
+		}
+	}
+
+	// nolint: errcheck
+	results, _ := md.properties[CredentialResultsPropKey].([]CredentialResult)
+
 	// creates the state's action
 	action := func(messenger service.Messenger) error {
-		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(model.Ack{
-			Type: AckMsgType,
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(Ack{
+			Type:              AckMsgType,
+			CredentialResults: results,
 		}), md.MyDID, md.TheirDID)
 	}
 
@@ -395,3 +438,53 @@ func (s *credentialReceived) ExecuteInbound(md *metaData) (state, stateAction, e
 func (s *credentialReceived) ExecuteOutbound(_ *metaData) (state, stateAction, error) {
 	return nil, nil, fmt.Errorf("%s: ExecuteOutbound is not implemented yet", s.Name())
 }
+
+// offerFromProposal builds the offer from the received proposal using the registered credential formats.
+func offerFromProposal(md *metaData) (*OfferCredential, error) {
+	proposal := ProposeCredential{}
+	if err := md.Msg.Decode(&proposal); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if md.formats == nil || !md.formats.Supports(proposal.Formats) {
+		return nil, errors.New("offer credential was not provided")
+	}
+
+	formats := make([]string, len(proposal.Formats))
+	for i, f := range proposal.Formats {
+		formats[i] = f.Format
+	}
+
+	preview, attachments := &proposal.CredentialProposal, proposal.Attachments
+	if md.offerPreview != nil {
+		preview, attachments = md.offerPreview, md.offerAttachments
+	}
+
+	offer, err := md.formats.OfferCredential(preview, formats...)
+	if err != nil {
+		return nil, fmt.Errorf("offer credential: %w", err)
+	}
+
+	offer.Attachments = attachments
+
+	return offer, nil
+}
+
+// credentialsFromRequest issues the credentials for the received request using the registered credential formats.
+func credentialsFromRequest(md *metaData) (*IssueCredential, error) {
+	request := RequestCredential{}
+	if err := md.Msg.Decode(&request); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if md.formats == nil || !md.formats.Supports(request.Formats) {
+		return nil, errors.New("issue credential was not provided")
+	}
+
+	issue, err := md.formats.IssueCredential(&request)
+	if err != nil {
+		return nil, fmt.Errorf("issue credential: %w", err)
+	}
+
+	return issue, nil
+}
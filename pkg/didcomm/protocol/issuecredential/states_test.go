@@ -17,6 +17,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 )
 
@@ -192,6 +193,21 @@ func TestDone_ExecuteInbound(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, &noOp{}, followup)
 	require.NoError(t, action(nil))
+
+	t.Run("Ack with credential results", func(t *testing.T) {
+		results := []CredentialResult{{AttachID: "1", Name: "name", Accepted: true}, {AttachID: "2"}}
+
+		md := &metaData{properties: map[string]interface{}{}}
+		md.Msg = service.NewDIDCommMsgMap(Ack{
+			Type:              AckMsgType,
+			CredentialResults: results,
+		})
+
+		followup, _, err := (&done{}).ExecuteInbound(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.Equal(t, results, md.properties[CredentialResultsPropKey])
+	})
 }
 
 func TestDone_ExecuteOutbound(t *testing.T) {
@@ -297,6 +313,53 @@ func TestOfferSent_ExecuteInbound(t *testing.T) {
 		require.Nil(t, followup)
 		require.Nil(t, action)
 	})
+
+	t.Run("OfferCredential is built by registered format", func(t *testing.T) {
+		formats := NewFormatRegistry()
+		formats.Register(LDProofVCFormat, &testFormat{})
+
+		md := &metaData{formats: formats}
+		md.Msg = service.NewDIDCommMsgMap(ProposeCredential{
+			Type:               ProposeCredentialMsgType,
+			CredentialProposal: *NewCredentialPreview(Attribute{Name: "name", Value: "Alice"}),
+			Formats:            []Format{{Format: LDProofVCFormat}},
+		})
+
+		followup, action, err := (&offerSent{}).ExecuteInbound(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NotNil(t, action)
+		require.Equal(t, LDProofVCFormat, md.offerCredential.Formats[0].Format)
+		require.Equal(t, "Alice", md.offerCredential.CredentialPreview.Attribute("name").Value)
+
+		formats.Register(LDProofVCFormat, &testFormat{err: errors.New("test error")})
+
+		md.offerCredential = nil
+		_, _, err = (&offerSent{}).ExecuteInbound(md)
+		require.EqualError(t, err, "offer credential: offer aries/ld-proof-vc@v1.0: test error")
+	})
+	t.Run("OfferCredential is built for the offer preview", func(t *testing.T) {
+		formats := NewFormatRegistry()
+		formats.Register(LDProofVCFormat, &testFormat{})
+
+		md := &metaData{formats: formats}
+		md.Msg = service.NewDIDCommMsgMap(ProposeCredential{
+			Type:               ProposeCredentialMsgType,
+			CredentialProposal: *NewCredentialPreview(Attribute{Name: "name", Value: "Alice"}),
+			Formats:            []Format{{Format: LDProofVCFormat}},
+		})
+
+		WithOfferPreview(NewCredentialPreview(
+			Attribute{Name: "name", Value: "Alice"},
+			Attribute{Name: "photo", MimeType: "image/png", Value: "#photo"},
+		), decorator.Attachment{ID: "photo", MimeType: "image/png"})(md)
+
+		_, _, err := (&offerSent{}).ExecuteInbound(md)
+		require.NoError(t, err)
+		require.Equal(t, "#photo", md.offerCredential.CredentialPreview.Attribute("photo").Value)
+		require.Len(t, md.offerCredential.Attachments, 1)
+		require.Equal(t, "photo", md.offerCredential.Attachments[0].ID)
+	})
 }
 
 func TestOfferSent_ExecuteOutbound(t *testing.T) {
@@ -350,6 +413,33 @@ func TestRequestReceived_ExecuteInbound(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("IssueCredential is built by registered format", func(t *testing.T) {
+		formats := NewFormatRegistry()
+		formats.Register(LDProofVCFormat, &testFormat{})
+
+		md := &metaData{formats: formats}
+		md.Msg = service.NewDIDCommMsgMap(RequestCredential{
+			Type:    RequestCredentialMsgType,
+			Formats: []Format{{AttachID: "1", Format: LDProofVCFormat}},
+			RequestsAttach: []decorator.Attachment{{
+				ID:   "1",
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{"name": "Alice"}},
+			}},
+		})
+
+		followup, action, err := (&requestReceived{}).ExecuteInbound(md)
+		require.NoError(t, err)
+		require.Equal(t, &credentialIssued{}, followup)
+		require.NotNil(t, action)
+		require.Len(t, md.issueCredential.CredentialsAttach, 1)
+
+		formats.Register(LDProofVCFormat, &testFormat{err: errors.New("test error")})
+
+		md.issueCredential = nil
+		_, _, err = (&requestReceived{}).ExecuteInbound(md)
+		require.EqualError(t, err, "issue credential: issue aries/ld-proof-vc@v1.0: test error")
+	})
+
 	t.Run("IssueCredential is absent", func(t *testing.T) {
 		followup, action, err := (&requestReceived{}).ExecuteInbound(&metaData{})
 		require.Contains(t, fmt.Sprintf("%v", err), "issue credential was not provided")
@@ -605,6 +695,50 @@ func TestCredentialReceived_ExecuteInbound(t *testing.T) {
 
 		require.NoError(t, action(messenger))
 	})
+
+	t.Run("Ack with credential results", func(t *testing.T) {
+		results := []CredentialResult{{AttachID: "1", Name: "name", Accepted: true}, {AttachID: "2"}}
+
+		followup, action, err := (&credentialReceived{}).ExecuteInbound(&metaData{
+			properties: map[string]interface{}{CredentialResultsPropKey: results},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				ack := Ack{}
+				require.NoError(t, msg.Decode(&ack))
+				require.Equal(t, AckMsgType, ack.Type)
+				require.Equal(t, results, ack.CredentialResults)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Invalid credential", func(t *testing.T) {
+		formats := NewFormatRegistry()
+		formats.Register(LDProofVCFormat, &testFormat{})
+
+		md := &metaData{formats: formats}
+		md.Msg = service.NewDIDCommMsgMap(IssueCredential{
+			Type:              IssueCredentialMsgType,
+			Formats:           []Format{{AttachID: "1", Format: LDProofVCFormat}},
+			CredentialsAttach: []decorator.Attachment{{ID: "1", Data: decorator.AttachmentData{JSON: "vc"}}},
+		})
+
+		followup, action, err := (&credentialReceived{}).ExecuteInbound(md)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validate credentials")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
 }
 
 func TestCredentialReceived_ExecuteOutbound(t *testing.T) {
@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyescrow
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Deposit is sent by the owner of an escrow to a trustee, it carries the shard held by the trustee.
+// The thread ID of the deposit is the escrow ID.
+type Deposit struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	// Label describes the backup to the trustee.
+	Label string `json:"label,omitempty"`
+	// Threshold is the number of shards required to recover the backup.
+	Threshold int `json:"threshold"`
+	// Shard is the share of the key of the backup held by the trustee.
+	Shard decorator.Attachment `json:"shard~attach"`
+	// Backup is the encrypted backup, held by every trustee.
+	Backup decorator.Attachment `json:"backup~attach"`
+}
+
+// Ack is sent by the trustee once the shard of the deposit was stored.
+type Ack struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	Status string            `json:"status,omitempty"`
+}
+
+// RecoveryRequest is sent by the owner of an escrow, possibly from a new agent, to ask a trustee for its shard.
+// The thread ID of the request is the recovery ID.
+type RecoveryRequest struct {
+	Type     string            `json:"@type,omitempty"`
+	ID       string            `json:"@id,omitempty"`
+	Thread   *decorator.Thread `json:"~thread,omitempty"`
+	EscrowID string            `json:"escrow_id"`
+	// Comment helps the trustee to approve the request, e.g. how the owner lost the secret.
+	Comment string `json:"comment,omitempty"`
+}
+
+// Release is sent by the trustee once the recovery request was approved, it carries the shard and the backup.
+type Release struct {
+	Type      string               `json:"@type,omitempty"`
+	ID        string               `json:"@id,omitempty"`
+	Thread    *decorator.Thread    `json:"~thread,omitempty"`
+	Threshold int                  `json:"threshold"`
+	Shard     decorator.Attachment `json:"shard~attach"`
+	Backup    decorator.Attachment `json:"backup~attach"`
+}
+
+// Decline is sent by the trustee when the recovery request was declined or the escrow is unknown.
+type Decline struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	Reason string            `json:"reason,omitempty"`
+}
+
+// Trustee is a trusted contact or a backup service holding a shard of an escrow.
+type Trustee struct {
+	ConnectionID string `json:"connection_id"`
+	MyDID        string `json:"my_did"`
+	TheirDID     string `json:"their_did"`
+	// State of the shard: pending, deposited (escrow), released or declined (recovery).
+	State  string `json:"state"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Escrow is the backup of a secret by its owner. The secret is encrypted with a random key,
+// the key is split into one shard per trustee and any threshold of the shards recover it.
+type Escrow struct {
+	ID        string     `json:"id"`
+	Label     string     `json:"label,omitempty"`
+	State     string     `json:"state"`
+	Threshold int        `json:"threshold"`
+	Trustees  []*Trustee `json:"trustees"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Holding is the shard of an escrow held by this agent as a trustee.
+type Holding struct {
+	EscrowID   string    `json:"escrow_id"`
+	Label      string    `json:"label,omitempty"`
+	MyDID      string    `json:"my_did"`
+	TheirDID   string    `json:"their_did"`
+	Threshold  int       `json:"threshold"`
+	Shard      []byte    `json:"shard"`
+	Backup     []byte    `json:"backup"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// ReleaseRequest is a recovery request received by this agent as a trustee, the shard is released once approved.
+type ReleaseRequest struct {
+	ID       string `json:"id"`
+	EscrowID string `json:"escrow_id"`
+	MyDID    string `json:"my_did"`
+	TheirDID string `json:"their_did"`
+	State    string `json:"state"`
+	Comment  string `json:"comment,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Recovery gathers the shards released by the trustees of an escrow until the secret is recovered.
+type Recovery struct {
+	ID        string     `json:"id"`
+	EscrowID  string     `json:"escrow_id"`
+	State     string     `json:"state"`
+	Threshold int        `json:"threshold,omitempty"`
+	Trustees  []*Trustee `json:"trustees"`
+	// Shards holds the released shards by DID of the trustee.
+	Shards map[string][]byte `json:"shards,omitempty"`
+	Backup []byte            `json:"backup,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
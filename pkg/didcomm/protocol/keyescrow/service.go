@@ -0,0 +1,991 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package keyescrow implements a key backup escrow protocol: the primary agent of a user backs up a secret
+// (e.g. an exported wallet) to trusted contacts or a backup service and recovers it with m-of-n approval.
+//
+// The secret is encrypted with a random key which is split with Shamir's secret sharing, one shard per trustee.
+// Every trustee holds its shard along with the encrypted backup, so that the owner can recover the secret from a
+// new agent. A trustee releases its shard once the recovery request was approved by its user, and the secret is
+// recovered as soon as the threshold of shards were released.
+package keyescrow
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/securebytes"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/shamir"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// KeyEscrow defines the protocol name.
+	KeyEscrow = "keyescrow"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/key-escrow/1.0/"
+	// DepositMsgType defines the protocol deposit message type.
+	DepositMsgType = Spec + "deposit"
+	// AckMsgType defines the protocol ack message type.
+	AckMsgType = Spec + "ack"
+	// RecoveryRequestMsgType defines the protocol recovery request message type.
+	RecoveryRequestMsgType = Spec + "recovery-request"
+	// ReleaseMsgType defines the protocol release message type.
+	ReleaseMsgType = Spec + "release"
+	// DeclineMsgType defines the protocol decline message type.
+	DeclineMsgType = Spec + "decline"
+)
+
+const (
+	// StatePending the shard was sent to the trustee, or requested from it, without response yet.
+	StatePending = "pending"
+	// StateDepositing the shards of the escrow were sent to the trustees.
+	StateDepositing = "depositing"
+	// StateDeposited the shards were stored by the trustees.
+	StateDeposited = "deposited"
+	// StateHeld the shard is held by this agent as a trustee.
+	StateHeld = "held"
+	// StateRequested the shards of the escrow were requested from the trustees.
+	StateRequested = "requested"
+	// StateReleased the shard was released by the trustee.
+	StateReleased = "released"
+	// StateDeclined the recovery request was declined by the trustee.
+	StateDeclined = "declined"
+	// StateRecovered the secret was recovered from the released shards.
+	StateRecovered = "recovered"
+	// StateFailed the secret cannot be recovered.
+	StateFailed = "failed"
+)
+
+const (
+	// Namespace is namespace of key escrow store name.
+	Namespace = "keyescrow"
+
+	escrowTag         = "escrow"
+	holdingTag        = "holding"
+	releaseRequestTag = "releaserequest"
+	recoveryTag       = "recovery"
+
+	keySize       = 32
+	ackStatusOK   = "OK"
+	unknownEscrow = "unknown escrow"
+
+	escrowIDPropKey   = "escrowID"
+	recoveryIDPropKey = "recoveryID"
+	requestIDPropKey  = "requestID"
+)
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrNotFound escrow, holding, release request or recovery not found error.
+	ErrNotFound = errors.New("not found")
+
+	logger = log.New("aries-framework/keyescrow")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+// Service for the key escrow protocol.
+type Service struct {
+	service.Action
+	service.Message
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	store            storage.Store
+	now              func() time.Time
+	lock             sync.Mutex
+}
+
+// New returns the key escrow service.
+func New(prov provider) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("open key escrow store: %w", err)
+	}
+
+	err = prov.StorageProvider().SetStoreConfig(Namespace, storage.StoreConfiguration{
+		TagNames: []string{escrowTag, holdingTag, releaseRequestTag, recoveryTag},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set key escrow store configuration: %w", err)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		outbound:         prov.OutboundDispatcher(),
+		connectionLookup: connectionLookup,
+		store:            store,
+		now:              time.Now,
+	}, nil
+}
+
+// HandleInbound handles inbound key escrow messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	var err error
+
+	switch msg.Type() {
+	case DepositMsgType:
+		err = s.handleDeposit(msg, ctx.MyDID(), ctx.TheirDID())
+	case AckMsgType:
+		err = s.handleAck(msg, ctx.TheirDID())
+	case RecoveryRequestMsgType:
+		err = s.handleRecoveryRequest(msg, ctx.MyDID(), ctx.TheirDID())
+	case ReleaseMsgType:
+		err = s.handleRelease(msg, ctx.TheirDID())
+	case DeclineMsgType:
+		err = s.handleDecline(msg, ctx.TheirDID())
+	default:
+		err = fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case DepositMsgType, AckMsgType, RecoveryRequestMsgType, ReleaseMsgType, DeclineMsgType:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return KeyEscrow
+}
+
+// Backup encrypts the secret and deposits one shard of its key to each trustee of the given connections,
+// any threshold of the shards recover the secret. It returns the escrow ID, which is needed for the recovery.
+func (s *Service) Backup(label string, secret []byte, threshold int, connectionIDs []string) (string, error) {
+	trustees, err := s.trustees(connectionIDs)
+	if err != nil {
+		return "", err
+	}
+
+	escrow := &Escrow{
+		ID:        uuid.New().String(),
+		Label:     label,
+		State:     StateDepositing,
+		Threshold: threshold,
+		Trustees:  trustees,
+		CreatedAt: s.now().UTC(),
+	}
+
+	key := make([]byte, keySize)
+	defer securebytes.Zero(key)
+
+	if _, err = random.Read(key); err != nil {
+		return "", fmt.Errorf("generate backup key: %w", err)
+	}
+
+	shards, err := shamir.Split(key, len(trustees), threshold)
+	if err != nil {
+		return "", fmt.Errorf("split backup key: %w", err)
+	}
+
+	backup, err := seal(key, secret, escrow.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.save(escrowTag, escrow.ID, escrow); err != nil {
+		return "", err
+	}
+
+	for i, trustee := range trustees {
+		err = s.outbound.SendToDID(&Deposit{
+			Type:      DepositMsgType,
+			ID:        uuid.New().String(),
+			Thread:    &decorator.Thread{ID: escrow.ID},
+			Label:     label,
+			Threshold: threshold,
+			Shard:     newAttachment(shards[i]),
+			Backup:    newAttachment(backup),
+		}, trustee.MyDID, trustee.TheirDID)
+		if err != nil {
+			return "", fmt.Errorf("send deposit to %s: %w", trustee.ConnectionID, err)
+		}
+	}
+
+	return escrow.ID, nil
+}
+
+// Recover requests the shards of the escrow from the trustees of the given connections, which may have been
+// established by a new agent of the owner. It returns the recovery ID, the secret is available with Recovered
+// once the threshold of shards were released.
+func (s *Service) Recover(escrowID, comment string, connectionIDs []string) (string, error) {
+	if escrowID == "" {
+		return "", errors.New("escrow ID is mandatory")
+	}
+
+	trustees, err := s.trustees(connectionIDs)
+	if err != nil {
+		return "", err
+	}
+
+	recovery := &Recovery{
+		ID:       uuid.New().String(),
+		EscrowID: escrowID,
+		State:    StateRequested,
+		Trustees: trustees,
+	}
+
+	if err = s.save(recoveryTag, recovery.ID, recovery); err != nil {
+		return "", err
+	}
+
+	for _, trustee := range trustees {
+		err = s.outbound.SendToDID(&RecoveryRequest{
+			Type:     RecoveryRequestMsgType,
+			ID:       uuid.New().String(),
+			Thread:   &decorator.Thread{ID: recovery.ID},
+			EscrowID: escrowID,
+			Comment:  comment,
+		}, trustee.MyDID, trustee.TheirDID)
+		if err != nil {
+			return "", fmt.Errorf("send recovery request to %s: %w", trustee.ConnectionID, err)
+		}
+	}
+
+	return recovery.ID, nil
+}
+
+// Recovered returns the secret of a recovery in the recovered state.
+func (s *Service) Recovered(recoveryID string) ([]byte, error) {
+	recovery, err := s.Recovery(recoveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if recovery.State != StateRecovered {
+		return nil, fmt.Errorf("recovery %s in state %s has no secret", recovery.ID, recovery.State)
+	}
+
+	return open(recovery)
+}
+
+// Approve releases the shard requested by the release request to the owner of the escrow.
+func (s *Service) Approve(requestID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	request, err := s.pendingReleaseRequest(requestID)
+	if err != nil {
+		return err
+	}
+
+	holding, err := s.Holding(request.EscrowID)
+	if err != nil {
+		return err
+	}
+
+	err = s.outbound.SendToDID(&Release{
+		Type:      ReleaseMsgType,
+		ID:        uuid.New().String(),
+		Thread:    &decorator.Thread{ID: request.ID},
+		Threshold: holding.Threshold,
+		Shard:     newAttachment(holding.Shard),
+		Backup:    newAttachment(holding.Backup),
+	}, request.MyDID, request.TheirDID)
+	if err != nil {
+		return fmt.Errorf("send release: %w", err)
+	}
+
+	request.State = StateReleased
+
+	return s.save(releaseRequestTag, request.ID, request)
+}
+
+// Decline declines the release request, the owner of the escrow is notified with the reason.
+func (s *Service) Decline(requestID, reason string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	request, err := s.pendingReleaseRequest(requestID)
+	if err != nil {
+		return err
+	}
+
+	return s.decline(request, reason)
+}
+
+// Escrow returns the escrow with the given ID.
+func (s *Service) Escrow(escrowID string) (*Escrow, error) {
+	escrow := &Escrow{}
+
+	if err := s.get(escrowTag, escrowID, escrow); err != nil {
+		return nil, err
+	}
+
+	return escrow, nil
+}
+
+// Escrows returns the escrows of the secrets backed up by this agent.
+func (s *Service) Escrows() ([]*Escrow, error) {
+	var escrows []*Escrow
+
+	err := s.query(escrowTag, func(bits []byte) error {
+		escrow := &Escrow{}
+		escrows = append(escrows, escrow)
+
+		return json.Unmarshal(bits, escrow)
+	})
+
+	return escrows, err
+}
+
+// Holding returns the shard of the escrow held by this agent as a trustee.
+func (s *Service) Holding(escrowID string) (*Holding, error) {
+	holding := &Holding{}
+
+	if err := s.get(holdingTag, escrowID, holding); err != nil {
+		return nil, err
+	}
+
+	return holding, nil
+}
+
+// Holdings returns the shards held by this agent as a trustee.
+func (s *Service) Holdings() ([]*Holding, error) {
+	var holdings []*Holding
+
+	err := s.query(holdingTag, func(bits []byte) error {
+		holding := &Holding{}
+		holdings = append(holdings, holding)
+
+		return json.Unmarshal(bits, holding)
+	})
+
+	return holdings, err
+}
+
+// ReleaseRequest returns the release request with the given ID.
+func (s *Service) ReleaseRequest(requestID string) (*ReleaseRequest, error) {
+	request := &ReleaseRequest{}
+
+	if err := s.get(releaseRequestTag, requestID, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// Recovery returns the recovery with the given ID.
+func (s *Service) Recovery(recoveryID string) (*Recovery, error) {
+	recovery := &Recovery{}
+
+	if err := s.get(recoveryTag, recoveryID, recovery); err != nil {
+		return nil, err
+	}
+
+	return recovery, nil
+}
+
+func (s *Service) handleDeposit(msg service.DIDCommMsg, myDID, theirDID string) error {
+	deposit := &Deposit{}
+
+	if err := msg.Decode(deposit); err != nil {
+		return fmt.Errorf("deposit message unmarshal: %w", err)
+	}
+
+	if deposit.Thread == nil || deposit.Thread.ID == "" {
+		return errors.New("deposit message: missing thread ID")
+	}
+
+	shard, err := deposit.Shard.Data.Fetch()
+	if err != nil {
+		return fmt.Errorf("deposit message: shard: %w", err)
+	}
+
+	backup, err := deposit.Backup.Data.Fetch()
+	if err != nil {
+		return fmt.Errorf("deposit message: backup: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	held, err := s.Holding(deposit.Thread.ID)
+	if err == nil && held.TheirDID != theirDID {
+		return fmt.Errorf("escrow %s does not belong to %s", deposit.Thread.ID, theirDID)
+	}
+
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	holding := &Holding{
+		EscrowID:   deposit.Thread.ID,
+		Label:      deposit.Label,
+		MyDID:      myDID,
+		TheirDID:   theirDID,
+		Threshold:  deposit.Threshold,
+		Shard:      shard,
+		Backup:     backup,
+		ReceivedAt: s.now().UTC(),
+	}
+
+	if err = s.save(holdingTag, holding.EscrowID, holding); err != nil {
+		return err
+	}
+
+	err = s.outbound.SendToDID(&Ack{
+		Type:   AckMsgType,
+		ID:     uuid.New().String(),
+		Thread: &decorator.Thread{ID: holding.EscrowID},
+		Status: ackStatusOK,
+	}, myDID, theirDID)
+	if err != nil {
+		return fmt.Errorf("send ack: %w", err)
+	}
+
+	s.sendMsgEvents(StateHeld, msg, &eventProps{escrowID: holding.EscrowID})
+
+	return nil
+}
+
+func (s *Service) handleAck(msg service.DIDCommMsg, theirDID string) error {
+	ack := &Ack{}
+
+	if err := msg.Decode(ack); err != nil {
+		return fmt.Errorf("ack message unmarshal: %w", err)
+	}
+
+	if ack.Thread == nil || ack.Thread.ID == "" {
+		return errors.New("ack message: missing thread ID")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	escrow, err := s.Escrow(ack.Thread.ID)
+	if err != nil {
+		return err
+	}
+
+	trustee := findTrustee(escrow.Trustees, theirDID)
+	if trustee == nil {
+		return fmt.Errorf("%s is not a trustee of escrow %s", theirDID, escrow.ID)
+	}
+
+	trustee.State = StateDeposited
+
+	if countTrustees(escrow.Trustees, StateDeposited) == len(escrow.Trustees) {
+		escrow.State = StateDeposited
+	}
+
+	if err = s.save(escrowTag, escrow.ID, escrow); err != nil {
+		return err
+	}
+
+	s.sendMsgEvents(escrow.State, msg, &eventProps{escrowID: escrow.ID})
+
+	return nil
+}
+
+func (s *Service) handleRecoveryRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
+	req := &RecoveryRequest{}
+
+	if err := msg.Decode(req); err != nil {
+		return fmt.Errorf("recovery request message unmarshal: %w", err)
+	}
+
+	if req.Thread == nil || req.Thread.ID == "" {
+		return errors.New("recovery request message: missing thread ID")
+	}
+
+	events := s.ActionEvent()
+	if events == nil {
+		return fmt.Errorf("no clients registered to handle action events for %s protocol", KeyEscrow)
+	}
+
+	request := &ReleaseRequest{
+		ID:       req.Thread.ID,
+		EscrowID: req.EscrowID,
+		MyDID:    myDID,
+		TheirDID: theirDID,
+		State:    StatePending,
+		Comment:  req.Comment,
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch _, err := s.ReleaseRequest(request.ID); {
+	case err == nil:
+		return fmt.Errorf("duplicate recovery request %s", request.ID)
+	case !errors.Is(err, ErrNotFound):
+		return err
+	}
+
+	_, err := s.Holding(request.EscrowID)
+	if errors.Is(err, ErrNotFound) {
+		return s.decline(request, unknownEscrow)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = s.save(releaseRequestTag, request.ID, request); err != nil {
+		return err
+	}
+
+	go s.requestApproval(events, msg, request.ID)
+
+	return nil
+}
+
+// requestApproval asks the user of the trustee to approve the release of the shard. The request can
+// also be approved or declined later with Approve and Decline.
+func (s *Service) requestApproval(events chan<- service.DIDCommAction, msg service.DIDCommMsg, requestID string) {
+	events <- service.DIDCommAction{
+		ProtocolName: KeyEscrow,
+		Message:      msg,
+		Continue: func(interface{}) {
+			if err := s.Approve(requestID); err != nil {
+				logger.Errorf("approve release request %s: %s", requestID, err)
+			}
+		},
+		Stop: func(cause error) {
+			reason := ""
+			if cause != nil {
+				reason = cause.Error()
+			}
+
+			if err := s.Decline(requestID, reason); err != nil {
+				logger.Errorf("decline release request %s: %s", requestID, err)
+			}
+		},
+		Properties: &eventProps{requestID: requestID},
+	}
+}
+
+func (s *Service) handleRelease(msg service.DIDCommMsg, theirDID string) error {
+	release := &Release{}
+
+	if err := msg.Decode(release); err != nil {
+		return fmt.Errorf("release message unmarshal: %w", err)
+	}
+
+	if release.Thread == nil || release.Thread.ID == "" {
+		return errors.New("release message: missing thread ID")
+	}
+
+	shard, err := release.Shard.Data.Fetch()
+	if err != nil {
+		return fmt.Errorf("release message: shard: %w", err)
+	}
+
+	backup, err := release.Backup.Data.Fetch()
+	if err != nil {
+		return fmt.Errorf("release message: backup: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	recovery, trustee, err := s.pendingTrustee(release.Thread.ID, theirDID)
+	if err != nil || trustee == nil {
+		return err
+	}
+
+	if recovery.Backup != nil && (!bytes.Equal(recovery.Backup, backup) || recovery.Threshold != release.Threshold) {
+		return fmt.Errorf("release of %s does not match the backup of recovery %s", theirDID, recovery.ID)
+	}
+
+	trustee.State = StateReleased
+	recovery.Threshold = release.Threshold
+	recovery.Backup = backup
+
+	if recovery.Shards == nil {
+		recovery.Shards = map[string][]byte{}
+	}
+
+	recovery.Shards[theirDID] = shard
+
+	return s.progress(recovery, msg)
+}
+
+func (s *Service) handleDecline(msg service.DIDCommMsg, theirDID string) error {
+	decline := &Decline{}
+
+	if err := msg.Decode(decline); err != nil {
+		return fmt.Errorf("decline message unmarshal: %w", err)
+	}
+
+	if decline.Thread == nil || decline.Thread.ID == "" {
+		return errors.New("decline message: missing thread ID")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	recovery, trustee, err := s.pendingTrustee(decline.Thread.ID, theirDID)
+	if err != nil || trustee == nil {
+		return err
+	}
+
+	trustee.State = StateDeclined
+	trustee.Reason = decline.Reason
+
+	return s.progress(recovery, msg)
+}
+
+// pendingTrustee returns the recovery in the requested state and its trustee with the given DID
+// waiting for a response, a nil trustee if the recovery or the trustee already completed.
+func (s *Service) pendingTrustee(recoveryID, theirDID string) (*Recovery, *Trustee, error) {
+	recovery, err := s.Recovery(recoveryID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trustee := findTrustee(recovery.Trustees, theirDID)
+	if trustee == nil {
+		return nil, nil, fmt.Errorf("%s is not a trustee of recovery %s", theirDID, recovery.ID)
+	}
+
+	if recovery.State != StateRequested || trustee.State != StatePending {
+		logger.Debugf("ignoring response of %s to recovery %s in state %s", theirDID, recovery.ID, recovery.State)
+
+		return recovery, nil, nil
+	}
+
+	return recovery, trustee, nil
+}
+
+// progress recovers the secret once the threshold of shards were released, or fails the recovery once
+// the trustees which did not respond yet cannot release enough shards anymore.
+func (s *Service) progress(recovery *Recovery, msg service.DIDCommMsg) error {
+	released := countTrustees(recovery.Trustees, StateReleased)
+	pending := countTrustees(recovery.Trustees, StatePending)
+
+	switch {
+	case recovery.Threshold > 0 && released >= recovery.Threshold:
+		recovery.State = StateRecovered
+
+		if secret, err := open(recovery); err != nil {
+			recovery.State = StateFailed
+			recovery.Error = err.Error()
+		} else {
+			securebytes.Zero(secret)
+		}
+	case pending == 0 || (recovery.Threshold > 0 && released+pending < recovery.Threshold):
+		recovery.State = StateFailed
+		recovery.Error = "not enough shards were released"
+	}
+
+	if err := s.save(recoveryTag, recovery.ID, recovery); err != nil {
+		return err
+	}
+
+	s.sendMsgEvents(recovery.State, msg, &eventProps{escrowID: recovery.EscrowID, recoveryID: recovery.ID})
+
+	return nil
+}
+
+func (s *Service) pendingReleaseRequest(requestID string) (*ReleaseRequest, error) {
+	request, err := s.ReleaseRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.State != StatePending {
+		return nil, fmt.Errorf("release request %s in state %s cannot be approved or declined", request.ID, request.State)
+	}
+
+	return request, nil
+}
+
+func (s *Service) decline(request *ReleaseRequest, reason string) error {
+	err := s.outbound.SendToDID(&Decline{
+		Type:   DeclineMsgType,
+		ID:     uuid.New().String(),
+		Thread: &decorator.Thread{ID: request.ID},
+		Reason: reason,
+	}, request.MyDID, request.TheirDID)
+	if err != nil {
+		return fmt.Errorf("send decline: %w", err)
+	}
+
+	request.State = StateDeclined
+	request.Reason = reason
+
+	return s.save(releaseRequestTag, request.ID, request)
+}
+
+func (s *Service) trustees(connectionIDs []string) ([]*Trustee, error) {
+	if len(connectionIDs) == 0 {
+		return nil, errors.New("no trustee connections")
+	}
+
+	trustees := make([]*Trustee, len(connectionIDs))
+
+	for i, connectionID := range connectionIDs {
+		conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("%s: %w", connectionID, ErrConnectionNotFound)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("fetch connection record from store : %w", err)
+		}
+
+		if findTrustee(trustees[:i], conn.TheirDID) != nil {
+			return nil, fmt.Errorf("duplicate trustee %s", conn.TheirDID)
+		}
+
+		trustees[i] = &Trustee{
+			ConnectionID: connectionID,
+			MyDID:        conn.MyDID,
+			TheirDID:     conn.TheirDID,
+			State:        StatePending,
+		}
+	}
+
+	return trustees, nil
+}
+
+func (s *Service) save(tag, id string, record interface{}) error {
+	bits, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal %s %s: %w", tag, id, err)
+	}
+
+	if err = s.store.Put(tag+"_"+id, bits, storage.Tag{Name: tag}); err != nil {
+		return fmt.Errorf("save %s %s: %w", tag, id, err)
+	}
+
+	return nil
+}
+
+func (s *Service) get(tag, id string, record interface{}) error {
+	bits, err := s.store.Get(tag + "_" + id)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("%s %s: %w", tag, id, ErrNotFound)
+	}
+
+	if err != nil {
+		return fmt.Errorf("get %s %s: %w", tag, id, err)
+	}
+
+	if err = json.Unmarshal(bits, record); err != nil {
+		return fmt.Errorf("unmarshal %s %s: %w", tag, id, err)
+	}
+
+	return nil
+}
+
+func (s *Service) query(tag string, unmarshal func([]byte) error) error {
+	iter, err := s.store.Query(tag)
+	if err != nil {
+		return fmt.Errorf("query %s: %w", tag, err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	more, err := iter.Next()
+
+	for ; err == nil && more; more, err = iter.Next() {
+		bits, errValue := iter.Value()
+		if errValue != nil {
+			return fmt.Errorf("%s value: %w", tag, errValue)
+		}
+
+		if errValue = unmarshal(bits); errValue != nil {
+			return fmt.Errorf("unmarshal %s: %w", tag, errValue)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("next %s: %w", tag, err)
+	}
+
+	return nil
+}
+
+// sendMsgEvents triggers the message events.
+func (s *Service) sendMsgEvents(state string, msg service.DIDCommMsg, props *eventProps) {
+	for _, handler := range s.MsgEvents() {
+		handler <- service.StateMsg{
+			ProtocolName: KeyEscrow,
+			Type:         service.PostState,
+			StateID:      state,
+			Msg:          msg,
+			Properties:   props,
+		}
+	}
+}
+
+// seal encrypts the secret with AES-256-GCM, the escrow ID is authenticated along with the secret.
+// The nonce is prepended to the ciphertext.
+func seal(key, secret []byte, escrowID string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err = random.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate backup nonce: %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, secret, []byte(escrowID)), nil
+}
+
+// open combines the released shards of the recovery and decrypts the backup.
+func open(recovery *Recovery) ([]byte, error) {
+	shards := make([][]byte, 0, len(recovery.Shards))
+
+	for _, shard := range recovery.Shards {
+		shards = append(shards, shard)
+	}
+
+	key, err := shamir.Combine(shards)
+	if err != nil {
+		return nil, fmt.Errorf("combine shards: %w", err)
+	}
+
+	defer securebytes.Zero(key)
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(recovery.Backup) < aead.NonceSize() {
+		return nil, errors.New("backup is too short")
+	}
+
+	nonce, ciphertext := recovery.Backup[:aead.NonceSize()], recovery.Backup[aead.NonceSize():]
+
+	secret, err := aead.Open(nil, nonce, ciphertext, []byte(recovery.EscrowID))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt backup: %w", err)
+	}
+
+	return secret, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create backup cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create backup cipher: %w", err)
+	}
+
+	return aead, nil
+}
+
+func newAttachment(data []byte) decorator.Attachment {
+	return decorator.Attachment{
+		ID:       uuid.New().String(),
+		MimeType: "application/octet-stream",
+		Data:     decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(data)},
+	}
+}
+
+func findTrustee(trustees []*Trustee, theirDID string) *Trustee {
+	for _, trustee := range trustees {
+		if trustee.TheirDID == theirDID {
+			return trustee
+		}
+	}
+
+	return nil
+}
+
+func countTrustees(trustees []*Trustee, state string) int {
+	count := 0
+
+	for _, trustee := range trustees {
+		if trustee.State == state {
+			count++
+		}
+	}
+
+	return count
+}
+
+type eventProps struct {
+	escrowID   string
+	recoveryID string
+	requestID  string
+}
+
+// EscrowID returns the ID of the escrow the event relates to.
+func (e *eventProps) EscrowID() string {
+	return e.escrowID
+}
+
+// RecoveryID returns the ID of the recovery the event relates to, if any.
+func (e *eventProps) RecoveryID() string {
+	return e.recoveryID
+}
+
+// RequestID returns the ID of the release request the action event relates to.
+func (e *eventProps) RequestID() string {
+	return e.requestID
+}
+
+// All implements EventProperties interface.
+func (e *eventProps) All() map[string]interface{} {
+	all := map[string]interface{}{}
+
+	for key, value := range map[string]string{
+		escrowIDPropKey: e.escrowID, recoveryIDPropKey: e.recoveryID, requestIDPropKey: e.requestID,
+	} {
+		if value != "" {
+			all[key] = value
+		}
+	}
+
+	return all
+}
@@ -0,0 +1,399 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyescrow
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	aliceDID    = "did:example:alice"
+	newAliceDID = "did:example:alice-new-agent"
+	bobDID      = "did:example:bob"
+	carolDID    = "did:example:carol"
+	daveDID     = "did:example:dave"
+)
+
+var secret = []byte(`{"wallet":"exported wallet contents"}`)
+
+// connectionIDs are the IDs of the connections to the agents, by DID of the agent.
+var connectionIDs = map[string]string{
+	aliceDID:    uuid.New().String(),
+	newAliceDID: uuid.New().String(),
+	bobDID:      uuid.New().String(),
+	carolDID:    uuid.New().String(),
+	daveDID:     uuid.New().String(),
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, err := New(newProvider(t, aliceDID))
+		require.NoError(t, err)
+		require.Equal(t, KeyEscrow, svc.Name())
+
+		for _, msgType := range []string{
+			DepositMsgType, AckMsgType, RecoveryRequestMsgType, ReleaseMsgType, DeclineMsgType,
+		} {
+			require.True(t, svc.Accept(msgType))
+		}
+
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		})
+		require.EqualError(t, err, "open key escrow store: open error")
+	})
+}
+
+func TestBackupRecover(t *testing.T) {
+	t.Run("backup and recover 2-of-3 from a new agent", func(t *testing.T) {
+		agents := newAgents(t, aliceDID, bobDID, carolDID, daveDID)
+
+		escrowID, err := agents[aliceDID].Backup("wallet", secret, 2, connectionsTo(bobDID, carolDID, daveDID))
+		require.NoError(t, err)
+
+		escrow, err := agents[aliceDID].Escrow(escrowID)
+		require.NoError(t, err)
+		require.Equal(t, StateDeposited, escrow.State)
+		require.Equal(t, 2, escrow.Threshold)
+		require.Len(t, escrow.Trustees, 3)
+
+		for _, trustee := range []string{bobDID, carolDID, daveDID} {
+			holding, e := agents[trustee].Holding(escrowID)
+			require.NoError(t, e)
+			require.Equal(t, "wallet", holding.Label)
+			require.Equal(t, aliceDID, holding.TheirDID)
+			require.NotContains(t, string(holding.Backup), string(secret))
+		}
+
+		// the primary agent was lost, a new agent of alice asks the trustees for their shards
+		agents[newAliceDID] = newAgent(t, agents, newAliceDID, bobDID, carolDID, daveDID)
+
+		bobActions := registerActions(t, agents[bobDID])
+		carolActions := registerActions(t, agents[carolDID])
+		daveActions := registerActions(t, agents[daveDID])
+
+		events := make(chan service.StateMsg, 3)
+		require.NoError(t, agents[newAliceDID].RegisterMsgEvent(events))
+
+		recoveryID, err := agents[newAliceDID].Recover(escrowID, "lost my phone", connectionsTo(bobDID, carolDID, daveDID))
+		require.NoError(t, err)
+
+		_, err = agents[newAliceDID].Recovered(recoveryID)
+		require.EqualError(t, err, "recovery "+recoveryID+" in state requested has no secret")
+
+		action := nextAction(t, carolActions)
+		require.Equal(t, recoveryID, action.Properties.All()[requestIDPropKey])
+		action.Stop(errors.New("cannot reach alice by phone"))
+		require.Equal(t, StateRequested, nextEvent(t, events).StateID)
+
+		nextAction(t, bobActions).Continue(nil)
+		require.Equal(t, StateRequested, nextEvent(t, events).StateID)
+
+		nextAction(t, daveActions).Continue(nil)
+
+		event := nextEvent(t, events)
+		require.Equal(t, StateRecovered, event.StateID)
+		require.Equal(t, recoveryID, event.Properties.All()[recoveryIDPropKey])
+		require.Equal(t, escrowID, event.Properties.All()[escrowIDPropKey])
+
+		recovered, err := agents[newAliceDID].Recovered(recoveryID)
+		require.NoError(t, err)
+		require.Equal(t, secret, recovered)
+
+		recovery, err := agents[newAliceDID].Recovery(recoveryID)
+		require.NoError(t, err)
+		require.Equal(t, StateDeclined, recovery.Trustees[1].State)
+		require.Equal(t, "cannot reach alice by phone", recovery.Trustees[1].Reason)
+
+		request, err := agents[carolDID].ReleaseRequest(recoveryID)
+		require.NoError(t, err)
+		require.Equal(t, StateDeclined, request.State)
+		require.Equal(t, "lost my phone", request.Comment)
+
+		require.Error(t, agents[bobDID].Approve(recoveryID))
+	})
+
+	t.Run("recovery fails when too many trustees decline", func(t *testing.T) {
+		agents := newAgents(t, aliceDID, bobDID, carolDID, daveDID)
+
+		escrowID, err := agents[aliceDID].Backup("wallet", secret, 2, connectionsTo(bobDID, carolDID, daveDID))
+		require.NoError(t, err)
+
+		for _, trustee := range []string{bobDID, carolDID, daveDID} {
+			registerActions(t, agents[trustee])
+		}
+
+		recoveryID, err := agents[aliceDID].Recover(escrowID, "", connectionsTo(bobDID, carolDID, daveDID))
+		require.NoError(t, err)
+
+		require.NoError(t, agents[bobDID].Approve(recoveryID))
+		require.NoError(t, agents[carolDID].Decline(recoveryID, "no"))
+
+		recovery, err := agents[aliceDID].Recovery(recoveryID)
+		require.NoError(t, err)
+		require.Equal(t, StateRequested, recovery.State)
+
+		require.NoError(t, agents[daveDID].Decline(recoveryID, "no"))
+
+		recovery, err = agents[aliceDID].Recovery(recoveryID)
+		require.NoError(t, err)
+		require.Equal(t, StateFailed, recovery.State)
+		require.Equal(t, "not enough shards were released", recovery.Error)
+
+		_, err = agents[aliceDID].Recovered(recoveryID)
+		require.Error(t, err)
+	})
+
+	t.Run("unknown escrow is declined", func(t *testing.T) {
+		agents := newAgents(t, aliceDID, bobDID, carolDID)
+
+		registerActions(t, agents[bobDID])
+		registerActions(t, agents[carolDID])
+
+		recoveryID, err := agents[aliceDID].Recover("unknown-escrow", "", connectionsTo(bobDID, carolDID))
+		require.NoError(t, err)
+
+		recovery, err := agents[aliceDID].Recovery(recoveryID)
+		require.NoError(t, err)
+		require.Equal(t, StateFailed, recovery.State)
+		require.Equal(t, unknownEscrow, recovery.Trustees[0].Reason)
+	})
+
+	t.Run("escrows and holdings", func(t *testing.T) {
+		agents := newAgents(t, aliceDID, bobDID, carolDID)
+
+		for i := 0; i < 2; i++ {
+			_, err := agents[aliceDID].Backup("wallet", secret, 2, connectionsTo(bobDID, carolDID))
+			require.NoError(t, err)
+		}
+
+		escrows, err := agents[aliceDID].Escrows()
+		require.NoError(t, err)
+		require.Len(t, escrows, 2)
+
+		holdings, err := agents[bobDID].Holdings()
+		require.NoError(t, err)
+		require.Len(t, holdings, 2)
+
+		_, err = agents[bobDID].Escrow("unknown")
+		require.True(t, errors.Is(err, ErrNotFound))
+	})
+}
+
+func TestBackup_Errors(t *testing.T) {
+	agents := newAgents(t, aliceDID, bobDID, carolDID)
+	alice := agents[aliceDID]
+
+	_, err := alice.Backup("wallet", secret, 2, nil)
+	require.EqualError(t, err, "no trustee connections")
+
+	_, err = alice.Backup("wallet", secret, 2, []string{connectionIDs[bobDID], "unknown"})
+	require.True(t, errors.Is(err, ErrConnectionNotFound))
+
+	_, err = alice.Backup("wallet", secret, 2, connectionsTo(bobDID, bobDID))
+	require.EqualError(t, err, "duplicate trustee "+bobDID)
+
+	_, err = alice.Backup("wallet", secret, 3, connectionsTo(bobDID, carolDID))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "split backup key")
+
+	_, err = alice.Recover("", "", connectionsTo(bobDID, carolDID))
+	require.EqualError(t, err, "escrow ID is mandatory")
+
+	alice.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+	_, err = alice.Backup("wallet", secret, 2, connectionsTo(bobDID, carolDID))
+	require.EqualError(t, err, "send deposit to "+connectionIDs[bobDID]+": send error")
+
+	_, err = alice.Recover("escrow-id", "", connectionsTo(bobDID, carolDID))
+	require.EqualError(t, err, "send recovery request to "+connectionIDs[bobDID]+": send error")
+}
+
+func TestHandleInbound(t *testing.T) {
+	svc, err := New(newProvider(t, bobDID))
+	require.NoError(t, err)
+
+	ctx := service.NewDIDCommContext(bobDID, aliceDID, nil)
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(struct {
+			Type string `json:"@type"`
+		}{Type: "unknown"}), ctx)
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+
+	t.Run("missing thread", func(t *testing.T) {
+		for _, msg := range []interface{}{
+			&Deposit{Type: DepositMsgType}, &Ack{Type: AckMsgType}, &RecoveryRequest{Type: RecoveryRequestMsgType},
+			&Release{Type: ReleaseMsgType}, &Decline{Type: DeclineMsgType},
+		} {
+			_, err = svc.HandleInbound(service.NewDIDCommMsgMap(msg), ctx)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "missing thread ID")
+		}
+	})
+
+	t.Run("invalid attachments", func(t *testing.T) {
+		deposit := &Deposit{Type: DepositMsgType, Thread: &decorator.Thread{ID: "escrow-id"}}
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(deposit), ctx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "deposit message: shard")
+
+		release := &Release{Type: ReleaseMsgType, Thread: &decorator.Thread{ID: "recovery-id"}}
+		release.Shard = newAttachment([]byte("shard"))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(release), ctx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "release message: backup")
+	})
+
+	t.Run("no clients registered to handle action events", func(t *testing.T) {
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&RecoveryRequest{
+			Type:     RecoveryRequestMsgType,
+			Thread:   &decorator.Thread{ID: "recovery-id"},
+			EscrowID: "escrow-id",
+		}), ctx)
+		require.EqualError(t, err, "no clients registered to handle action events for keyescrow protocol")
+	})
+
+	t.Run("unknown escrow or recovery", func(t *testing.T) {
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Ack{
+			Type: AckMsgType, Thread: &decorator.Thread{ID: "escrow-id"},
+		}), ctx)
+		require.True(t, errors.Is(err, ErrNotFound))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Decline{
+			Type: DeclineMsgType, Thread: &decorator.Thread{ID: "recovery-id"},
+		}), ctx)
+		require.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("outbound is not implemented", func(t *testing.T) {
+		_, err = svc.HandleOutbound(nil, "", "")
+		require.Error(t, err)
+	})
+}
+
+// newAgents returns the services of the agents with the given DIDs, the first agent is connected to the others.
+// The ID of a connection is the one of the other party in connectionIDs.
+func newAgents(t *testing.T, owner string, trustees ...string) map[string]*Service {
+	t.Helper()
+
+	agents := map[string]*Service{}
+	agents[owner] = newAgent(t, agents, owner, trustees...)
+
+	for _, trustee := range trustees {
+		agents[trustee] = newAgent(t, agents, trustee, owner)
+	}
+
+	return agents
+}
+
+func newAgent(t *testing.T, agents map[string]*Service, myDID string, theirDIDs ...string) *Service {
+	t.Helper()
+
+	svc, err := New(newProvider(t, myDID, theirDIDs...))
+	require.NoError(t, err)
+
+	svc.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			_, err := agents[theirDID].HandleInbound(service.NewDIDCommMsgMap(msg),
+				service.NewDIDCommContext(theirDID, myDID, nil))
+
+			return err
+		},
+	}
+
+	return svc
+}
+
+func newProvider(t *testing.T, myDID string, theirDIDs ...string) *mockprovider.Provider {
+	t.Helper()
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	for _, theirDID := range theirDIDs {
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: connectionIDs[theirDID],
+			State:        "completed",
+			MyDID:        myDID,
+			TheirDID:     theirDID,
+		}))
+	}
+
+	return prov
+}
+
+// connectionsTo returns the IDs of the connections to the agents with the given DIDs.
+func connectionsTo(dids ...string) []string {
+	ids := make([]string, len(dids))
+
+	for i, did := range dids {
+		ids[i] = connectionIDs[did]
+	}
+
+	return ids
+}
+
+func registerActions(t *testing.T, svc *Service) chan service.DIDCommAction {
+	t.Helper()
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	return actions
+}
+
+func nextAction(t *testing.T, actions chan service.DIDCommAction) service.DIDCommAction {
+	t.Helper()
+
+	select {
+	case action := <-actions:
+		return action
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for action event")
+	}
+
+	return service.DIDCommAction{}
+}
+
+func nextEvent(t *testing.T, events chan service.StateMsg) service.StateMsg {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for state event")
+	}
+
+	return service.StateMsg{}
+}
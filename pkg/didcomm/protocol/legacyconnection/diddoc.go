@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package legacyconnection
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	ed25519VerificationKey2018       = "Ed25519VerificationKey2018"
+	ed25519SignatureAuthentication   = "Ed25519SignatureAuthentication2018"
+	legacyDIDLen                     = 16
+	legacyServiceIDSuffix            = ";indy"
+	legacyVerificationMethodFragment = "#1"
+)
+
+type rawLegacyKey struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	Controller      string `json:"controller"`
+	PublicKeyBase58 string `json:"publicKeyBase58"`
+}
+
+type rawLegacyAuthentication struct {
+	Type      string `json:"type"`
+	PublicKey string `json:"publicKey"`
+}
+
+type rawLegacyService struct {
+	ID              string   `json:"id"`
+	Type            string   `json:"type"`
+	Priority        uint     `json:"priority"`
+	RecipientKeys   []string `json:"recipientKeys"`
+	RoutingKeys     []string `json:"routingKeys,omitempty"`
+	ServiceEndpoint string   `json:"serviceEndpoint"`
+}
+
+type rawLegacyDoc struct {
+	Context        string                    `json:"@context"`
+	ID             string                    `json:"id"`
+	PublicKey      []rawLegacyKey            `json:"publicKey"`
+	Authentication []rawLegacyAuthentication `json:"authentication"`
+	Service        []rawLegacyService        `json:"service"`
+}
+
+// newLegacyDoc returns the did:sov DID doc of the Ed25519 public key with the IndyAgent service,
+// the DID is derived from the key as done by the Indy agents.
+func newLegacyDoc(pubKey []byte, endpoint string, routingKeys []string) *did.Doc {
+	id := interop.SovDID(base58.Encode(pubKey[:legacyDIDLen]))
+
+	vm := did.NewVerificationMethodFromBytes(id+legacyVerificationMethodFragment, ed25519VerificationKey2018,
+		id, pubKey)
+
+	return &did.Doc{
+		Context:            []string{did.Context},
+		ID:                 id,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+		Service: []did.Service{{
+			ID:              id + legacyServiceIDSuffix,
+			Type:            interop.LegacyDIDCommServiceType,
+			RecipientKeys:   []string{base58.Encode(pubKey)},
+			RoutingKeys:     routingKeys,
+			ServiceEndpoint: endpoint,
+		}},
+	}
+}
+
+// legacyDoc returns the DID doc in the format of the Indy agents: the unqualified DID, the publicKey array
+// and the services with base58 keys.
+func legacyDoc(doc *did.Doc) *rawLegacyDoc {
+	id := interop.UnqualifiedDID(doc.ID)
+
+	raw := &rawLegacyDoc{Context: did.Context, ID: id}
+
+	for i := range doc.VerificationMethod {
+		keyID := fmt.Sprintf("%s#%d", id, i+1)
+
+		raw.PublicKey = append(raw.PublicKey, rawLegacyKey{
+			ID:              keyID,
+			Type:            doc.VerificationMethod[i].Type,
+			Controller:      id,
+			PublicKeyBase58: base58.Encode(doc.VerificationMethod[i].Value),
+		})
+
+		raw.Authentication = append(raw.Authentication, rawLegacyAuthentication{
+			Type:      ed25519SignatureAuthentication,
+			PublicKey: keyID,
+		})
+	}
+
+	for i := range doc.Service {
+		raw.Service = append(raw.Service, rawLegacyService{
+			ID:              id + legacyServiceIDSuffix,
+			Type:            interop.LegacyDIDCommServiceType,
+			Priority:        doc.Service[i].Priority,
+			RecipientKeys:   base58Keys(doc.Service[i].RecipientKeys),
+			RoutingKeys:     base58Keys(doc.Service[i].EndpointRoutingKeys()),
+			ServiceEndpoint: doc.Service[i].EndpointURI(),
+		})
+	}
+
+	return raw
+}
+
+// base58Keys returns the base58 public keys of the keys given as did:key or base58.
+func base58Keys(keys []string) []string {
+	var b58Keys []string
+
+	for _, key := range keys {
+		if pubKey, err := fingerprint.PubKeyFromDIDKey(key); err == nil {
+			key = base58.Encode(pubKey)
+		}
+
+		b58Keys = append(b58Keys, key)
+	}
+
+	return b58Keys
+}
+
+// didKeys returns the did:key IDs of the keys given as base58 or did:key.
+func didKeys(keys []string) []string {
+	var ids []string
+
+	for _, key := range keys {
+		ids = append(ids, didKey(key))
+	}
+
+	return ids
+}
+
+func didKey(key string) string {
+	if _, err := fingerprint.PubKeyFromDIDKey(key); err == nil {
+		return key
+	}
+
+	id, _ := fingerprint.CreateDIDKey(base58.Decode(key))
+
+	return id
+}
@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package legacyconnection
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+func TestNewLegacyDoc(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	routingKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	routingDIDKey, _ := fingerprint.CreateDIDKey(routingKey)
+
+	doc := newLegacyDoc(pubKey, bobEndpoint, base58Keys([]string{routingDIDKey}))
+	require.Equal(t, interop.SovDID(base58.Encode(pubKey[:legacyDIDLen])), doc.ID)
+
+	raw, err := json.Marshal(&Connection{DID: interop.UnqualifiedDID(doc.ID), DIDDoc: doc})
+	require.NoError(t, err)
+
+	legacy := struct {
+		DID    string       `json:"DID"`
+		DIDDoc rawLegacyDoc `json:"DIDDoc"`
+	}{}
+
+	require.NoError(t, json.Unmarshal(raw, &legacy))
+	require.Equal(t, base58.Encode(pubKey[:legacyDIDLen]), legacy.DID)
+	require.Equal(t, legacy.DID, legacy.DIDDoc.ID)
+	require.Equal(t, base58.Encode(pubKey), legacy.DIDDoc.PublicKey[0].PublicKeyBase58)
+	require.Equal(t, legacy.DIDDoc.PublicKey[0].ID, legacy.DIDDoc.Authentication[0].PublicKey)
+	require.Equal(t, interop.LegacyDIDCommServiceType, legacy.DIDDoc.Service[0].Type)
+	require.Equal(t, []string{base58.Encode(pubKey)}, legacy.DIDDoc.Service[0].RecipientKeys)
+	require.Equal(t, []string{base58.Encode(routingKey)}, legacy.DIDDoc.Service[0].RoutingKeys)
+
+	conn := &Connection{}
+	require.NoError(t, json.Unmarshal(raw, conn))
+	require.Equal(t, []byte(pubKey), conn.DIDDoc.VerificationMethod[0].Value)
+
+	destination, err := service.CreateDestination(conn.DIDDoc)
+	require.NoError(t, err)
+	require.Equal(t, bobEndpoint, destination.ServiceEndpoint)
+	require.Equal(t, didKeys([]string{base58.Encode(pubKey)}), destination.RecipientKeys)
+	require.Equal(t, []string{routingDIDKey}, destination.RoutingKeys)
+}
+
+func TestKeys(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	id, _ := fingerprint.CreateDIDKey(pubKey)
+
+	require.Equal(t, []string{id, id}, didKeys([]string{id, base58.Encode(pubKey)}))
+	require.Equal(t, []string{base58.Encode(pubKey), base58.Encode(pubKey)},
+		base58Keys([]string{id, base58.Encode(pubKey)}))
+	require.Empty(t, didKeys(nil))
+}
@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package legacyconnection
+
+// Event properties related api. This can be used to cast Generic event properties to connection specific props.
+type Event interface {
+	// connection ID
+	ConnectionID() string
+
+	// invitation ID
+	InvitationID() string
+}
+
+// connectionEvent implements legacyconnection.Event interface.
+type connectionEvent struct {
+	connectionID string
+	invitationID string
+}
+
+// ConnectionID returns the connection ID.
+func (ev *connectionEvent) ConnectionID() string {
+	return ev.connectionID
+}
+
+// InvitationID returns the invitation ID.
+func (ev *connectionEvent) InvitationID() string {
+	return ev.invitationID
+}
+
+// All implements EventProperties interface.
+func (ev *connectionEvent) All() map[string]interface{} {
+	return map[string]interface{}{
+		"connectionID": ev.ConnectionID(),
+		"invitationID": ev.InvitationID(),
+	}
+}
+
+// connectionEventError for sending events with processing error.
+type connectionEventError struct {
+	connectionEvent
+	err error
+}
+
+// Error implements error interface.
+func (ev *connectionEventError) Error() string {
+	if ev.err != nil {
+		return ev.err.Error()
+	}
+
+	return ""
+}
+
+// All implements EventProperties interface.
+func (ev *connectionEventError) All() map[string]interface{} {
+	props := ev.connectionEvent.All()
+	props["error"] = ev.Error()
+
+	return props
+}
@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package legacyconnection
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// Invitation defines the connection invitation message
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0160-connection-protocol#0-invitation-to-connect
+type Invitation struct {
+	Type  string `json:"@type,omitempty"`
+	ID    string `json:"@id,omitempty"`
+	Label string `json:"label,omitempty"`
+	// DID is the public DID of the inviter, the invitations with a DID have no keys nor endpoint.
+	DID string `json:"did,omitempty"`
+	// RecipientKeys are the base58 public keys of the inviter.
+	RecipientKeys   []string `json:"recipientKeys,omitempty"`
+	ServiceEndpoint string   `json:"serviceEndpoint,omitempty"`
+	// RoutingKeys are the base58 public keys of the mediators of the inviter.
+	RoutingKeys []string `json:"routingKeys,omitempty"`
+	ImageURL    string   `json:"imageUrl,omitempty"`
+}
+
+// Request defines the connection request message
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0160-connection-protocol#1-connection-request
+type Request struct {
+	Type       string            `json:"@type,omitempty"`
+	ID         string            `json:"@id,omitempty"`
+	Label      string            `json:"label,omitempty"`
+	Thread     *decorator.Thread `json:"~thread,omitempty"`
+	Connection *Connection       `json:"connection,omitempty"`
+}
+
+// Response defines the connection response message
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0160-connection-protocol#2-connection-response
+type Response struct {
+	Type                string               `json:"@type,omitempty"`
+	ID                  string               `json:"@id,omitempty"`
+	Thread              *decorator.Thread    `json:"~thread,omitempty"`
+	ConnectionSignature *ConnectionSignature `json:"connection~sig,omitempty"`
+}
+
+// ConnectionSignature is the connection attribute of the response signed with the invitation key.
+type ConnectionSignature struct {
+	Type       string `json:"@type,omitempty"`
+	Signature  string `json:"signature,omitempty"`
+	SignedData string `json:"sig_data,omitempty"`
+	// SignVerKey is the base58 public key the connection is signed with.
+	SignVerKey string `json:"signer,omitempty"`
+}
+
+// Connection is the DID and the DID doc of the sender of the request or response.
+type Connection struct {
+	DID    string   `json:"DID,omitempty"`
+	DIDDoc *did.Doc `json:"DIDDoc,omitempty"`
+}
+
+// MarshalJSON marshals the connection with the DID doc in the format of the Indy agents
+// (see legacyDoc), the DID docs of all formats are accepted when unmarshalling it.
+func (c *Connection) MarshalJSON() ([]byte, error) {
+	raw := struct {
+		DID    string      `json:"DID,omitempty"`
+		DIDDoc interface{} `json:"DIDDoc,omitempty"`
+	}{DID: c.DID}
+
+	if c.DIDDoc != nil {
+		raw.DIDDoc = legacyDoc(c.DIDDoc)
+	}
+
+	return json.Marshal(raw)
+}
@@ -0,0 +1,409 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package legacyconnection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/legacy-connection/service")
+
+const (
+	// LegacyConnection is the name of the RFC 0160 connections protocol service.
+	LegacyConnection = "legacyconnection"
+	// PIURI is the connections protocol identifier URI.
+	PIURI = "https://didcomm.org/connections/1.0"
+	// InvitationMsgType defines the connections invitation message type.
+	InvitationMsgType = PIURI + "/invitation"
+	// RequestMsgType defines the connections request message type.
+	RequestMsgType = PIURI + "/request"
+	// ResponseMsgType defines the connections response message type.
+	ResponseMsgType = PIURI + "/response"
+	// AckMsgType defines the ack message type sent by the invitee once the response is verified.
+	AckMsgType = "https://didcomm.org/notification/1.0/ack"
+)
+
+const (
+	// StateIDInvited is the state of the invitee once the invitation is received.
+	StateIDInvited = "invited"
+	// StateIDRequested is the state of both parties once the request is sent or received.
+	StateIDRequested = "requested"
+	// StateIDResponded is the state of the inviter once the response is sent.
+	StateIDResponded = "responded"
+	// StateIDCompleted is the state of both parties once the connection is established.
+	StateIDCompleted = connection.StateNameCompleted
+	// StateIDAbandoned is the state of the connections stopped by the user or failed.
+	StateIDAbandoned = "abandoned"
+)
+
+// provider contains dependencies for the connections protocol and is typically created by using aries.Context().
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	DIDConnectionStore() didstore.ConnectionStore
+	Crypto() crypto.Crypto
+	KMS() kms.KeyManager
+	VDRegistry() vdrapi.Registry
+	Service(id string) (interface{}, error)
+	ServiceEndpoint() string
+}
+
+// opts are used to provide the label and the router connections when accepting an invitation or a request.
+type opts interface {
+	// Label allows for setting label
+	Label() string
+
+	// RouterConnections allows for setting router connections
+	RouterConnections() []string
+}
+
+// message is the inbound invitation or request waiting for the user to accept it.
+type message struct {
+	Msg          service.DIDCommMsgMap
+	ConnectionID string
+}
+
+// Service for the RFC 0160 connections protocol, to establish connections with the agents
+// which do not support DID exchange. The connections use did:sov DIDs stored by the peer VDR.
+type Service struct {
+	service.Action
+	service.Message
+	outboundDispatcher dispatcher.Outbound
+	crypto             crypto.Crypto
+	kms                kms.KeyManager
+	vdRegistry         vdrapi.Registry
+	connectionRecorder *connection.Recorder
+	connectionStore    didstore.ConnectionStore
+	routeSvc           mediator.ProtocolService
+	serviceEndpoint    string
+}
+
+// New returns the connections protocol service.
+func New(prov provider) (*Service, error) {
+	connRecorder, err := connection.NewRecorder(prov)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize connection recorder: %w", err)
+	}
+
+	s, err := prov.Service(mediator.Coordination)
+	if err != nil {
+		return nil, err
+	}
+
+	routeSvc, ok := s.(mediator.ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to Route Service failed")
+	}
+
+	return &Service{
+		outboundDispatcher: prov.OutboundDispatcher(),
+		crypto:             prov.Crypto(),
+		kms:                prov.KMS(),
+		vdRegistry:         prov.VDRegistry(),
+		connectionRecorder: connRecorder,
+		connectionStore:    prov.DIDConnectionStore(),
+		routeSvc:           routeSvc,
+		serviceEndpoint:    prov.ServiceEndpoint(),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return LegacyConnection
+}
+
+// Accept checks the msg type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == InvitationMsgType ||
+		msgType == RequestMsgType ||
+		msgType == ResponseMsgType ||
+		msgType == AckMsgType
+}
+
+// HandleOutbound handles outbound connections messages.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// HandleInbound handles inbound connections messages. The invitations and the requests trigger an action event,
+// the responses and the acks complete the connection.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	logger.Debugf("receive inbound message : %s", msg)
+
+	var (
+		record *connection.Record
+		err    error
+	)
+
+	switch msg.Type() {
+	case InvitationMsgType:
+		record, err = s.invitationMsgRecord(msg)
+	case RequestMsgType:
+		record, err = s.requestMsgRecord(msg, ctx)
+	case ResponseMsgType:
+		record, err = s.handleResponse(msg)
+	case AckMsgType:
+		record, err = s.handleAck(msg)
+	default:
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("handle inbound %s: %w", msg.Type(), err)
+	}
+
+	logutil.LogDebug(logger, LegacyConnection, "handleInbound", "success",
+		logutil.CreateKeyValueString("msgType", msg.Type()),
+		logutil.CreateKeyValueString("msgID", msg.ID()),
+		logutil.CreateKeyValueString("connectionID", record.ConnectionID))
+
+	return record.ConnectionID, nil
+}
+
+// AcceptInvitation accepts the connection invitation and sends the request to the inviter.
+func (s *Service) AcceptInvitation(connectionID, label string, routerConnections []string) error {
+	return s.accept(connectionID, StateIDInvited, label, routerConnections)
+}
+
+// AcceptConnectionRequest accepts the connection request and sends the response to the invitee.
+func (s *Service) AcceptConnectionRequest(connectionID, label string, routerConnections []string) error {
+	return s.accept(connectionID, StateIDRequested, label, routerConnections)
+}
+
+func (s *Service) accept(connectionID, stateID, label string, routerConnections []string) error {
+	msg, err := s.getPendingMessage(connectionID)
+	if err != nil {
+		return fmt.Errorf("accept connection %s: %w", connectionID, err)
+	}
+
+	record, err := s.connectionRecorder.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("accept connection %s: %w", connectionID, err)
+	}
+
+	if record.State != stateID {
+		return fmt.Errorf("current state (%s) is different from expected state (%s)", record.State, stateID)
+	}
+
+	if err = s.continueWith(msg.Msg, record, label, routerConnections); err != nil {
+		s.abandon(msg.Msg, record, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// continueWith sends the request or the response once the user accepted the invitation or the request.
+func (s *Service) continueWith(msg service.DIDCommMsg, record *connection.Record, label string,
+	routerConnections []string) error {
+	switch {
+	case record.State == StateIDInvited:
+		return s.sendRequest(msg, record, label, routerConnections)
+	case record.State == StateIDRequested && record.Namespace == connection.TheirNSPrefix:
+		return s.sendResponse(msg, record, routerConnections)
+	default:
+		return fmt.Errorf("no action for the connection in state %s", record.State)
+	}
+}
+
+// sendActionEvent saves the message waiting for the user and triggers the action event.
+func (s *Service) sendActionEvent(msg service.DIDCommMsg, record *connection.Record) error {
+	bytes, err := json.Marshal(&message{Msg: msg.Clone(), ConnectionID: record.ConnectionID})
+	if err != nil {
+		return fmt.Errorf("marshal pending message: %w", err)
+	}
+
+	// the pending message allows to accept the connection with AcceptInvitation and AcceptConnectionRequest
+	if err = s.connectionRecorder.SaveEvent(record.ConnectionID, bytes); err != nil {
+		return fmt.Errorf("save pending message: %w", err)
+	}
+
+	aEvent := s.ActionEvent()
+	if aEvent == nil {
+		return nil
+	}
+
+	stateID := record.State
+
+	go func() {
+		aEvent <- service.DIDCommAction{
+			ProtocolName: LegacyConnection,
+			Message:      msg.Clone(),
+			Continue: func(args interface{}) {
+				var (
+					label             string
+					routerConnections []string
+				)
+
+				if v, ok := args.(opts); ok {
+					label, routerConnections = v.Label(), v.RouterConnections()
+				}
+
+				if err := s.accept(record.ConnectionID, stateID, label, routerConnections); err != nil {
+					logger.Errorf("continue connection %s: %s", record.ConnectionID, err)
+				}
+			},
+			Stop: func(err error) {
+				s.abandon(msg, record, err)
+			},
+			Properties: createEventProperties(record),
+		}
+	}()
+
+	return nil
+}
+
+func (s *Service) getPendingMessage(connectionID string) (*message, error) {
+	bytes, err := s.connectionRecorder.GetEvent(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get pending message: %w", err)
+	}
+
+	msg := &message{}
+
+	if err = json.Unmarshal(bytes, msg); err != nil {
+		return nil, fmt.Errorf("unmarshal pending message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// abandon updates the state of the connection to abandoned and triggers the failure event.
+func (s *Service) abandon(msg service.DIDCommMsg, record *connection.Record, processErr error) {
+	record.State = StateIDAbandoned
+
+	if err := s.connectionRecorder.SaveConnectionRecord(record); err != nil {
+		logger.Errorf("unable to update the state to abandoned: %s", err)
+	}
+
+	s.sendMsgEvents(&service.StateMsg{
+		ProtocolName: LegacyConnection,
+		Type:         service.PostState,
+		Msg:          msg,
+		StateID:      StateIDAbandoned,
+		Properties: &connectionEventError{
+			connectionEvent: *createEventProperties(record),
+			err:             processErr,
+		},
+	})
+}
+
+// transition saves the connection in the next state and triggers the state events.
+func (s *Service) transition(msg service.DIDCommMsg, record *connection.Record, next string) error {
+	s.sendMsgEvents(&service.StateMsg{
+		ProtocolName: LegacyConnection,
+		Type:         service.PreState,
+		Msg:          msg.Clone(),
+		StateID:      next,
+		Properties:   createEventProperties(record),
+	})
+
+	if !canTransition(record.State, next) {
+		return fmt.Errorf("invalid state transition: %s -> %s", record.State, next)
+	}
+
+	record.State = next
+
+	save := s.connectionRecorder.SaveConnectionRecord
+	if next == StateIDInvited || (next == StateIDRequested && record.Namespace == connection.TheirNSPrefix) {
+		save = s.connectionRecorder.SaveConnectionRecordWithMappings
+	}
+
+	if err := save(record); err != nil {
+		return fmt.Errorf("save connection in state %s: %w", next, err)
+	}
+
+	s.sendMsgEvents(&service.StateMsg{
+		ProtocolName: LegacyConnection,
+		Type:         service.PostState,
+		Msg:          msg.Clone(),
+		StateID:      next,
+		Properties:   createEventProperties(record),
+	})
+
+	return nil
+}
+
+// canTransition checks the transitions of the invitee (invited, requested, completed)
+// and of the inviter (requested, responded, completed).
+func canTransition(current, next string) bool {
+	switch next {
+	case StateIDInvited:
+		return current == ""
+	case StateIDRequested:
+		return current == "" || current == StateIDInvited
+	case StateIDResponded:
+		return current == StateIDRequested
+	case StateIDCompleted:
+		return current == StateIDRequested || current == StateIDResponded
+	default:
+		return false
+	}
+}
+
+// sendMsgEvents triggers the message events.
+func (s *Service) sendMsgEvents(msg *service.StateMsg) {
+	for _, handler := range s.MsgEvents() {
+		handler <- *msg
+	}
+}
+
+// fetchConnectionRecord returns the connection of the thread of the message.
+func (s *Service) fetchConnectionRecord(nsPrefix string, msg service.DIDCommMsg) (*connection.Record, error) {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := connection.CreateNamespaceKey(nsPrefix, thID)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.connectionRecorder.GetConnectionRecordByNSThreadID(key)
+	if err != nil {
+		return nil, fmt.Errorf("get connection record: %w", err)
+	}
+
+	return record, nil
+}
+
+func createEventProperties(record *connection.Record) *connectionEvent {
+	return &connectionEvent{
+		connectionID: record.ConnectionID,
+		invitationID: record.InvitationID,
+	}
+}
+
+func generateRandomID() string {
+	return uuid.New().String()
+}
+
+// invitationKey returns the base58 key the invitation is saved by, to find the invitation
+// of the requests which have no parent thread.
+func invitationKey(recipientKey []byte) string {
+	return base58.Encode(recipientKey)
+}
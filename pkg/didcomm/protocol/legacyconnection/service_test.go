@@ -0,0 +1,406 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package legacyconnection
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+)
+
+const (
+	aliceEndpoint = "http://alice.agent.example.com:8081"
+	bobEndpoint   = "http://bob.agent.example.com:8082"
+)
+
+// testProvider signs with the real crypto, as the connection signatures are verified.
+type testProvider struct {
+	*protocol.MockProvider
+	crypto   crypto.Crypto
+	endpoint string
+}
+
+func (p *testProvider) Crypto() crypto.Crypto {
+	return p.crypto
+}
+
+func (p *testProvider) ServiceEndpoint() string {
+	return p.endpoint
+}
+
+func newTestProvider(t *testing.T, endpoint string) *testProvider {
+	t.Helper()
+
+	storeProv := mockstorage.NewMockStoreProvider()
+
+	k, err := localkms.New("local-lock://primary/test/", &protocol.MockProvider{
+		StoreProvider: storeProv,
+		CustomLock:    &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	peerVDR, err := peer.New(storeProv, peer.WithAcceptedMethods(interop.SovMethod))
+	require.NoError(t, err)
+
+	return &testProvider{
+		MockProvider: &protocol.MockProvider{
+			StoreProvider:  storeProv,
+			CustomKMS:      k,
+			CustomVDR:      vdr.New(vdr.WithVDR(peerVDR)),
+			CustomOutbound: &mockdispatcher.MockOutbound{},
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		},
+		crypto:   c,
+		endpoint: endpoint,
+	}
+}
+
+func newService(t *testing.T, prov *testProvider) *Service {
+	t.Helper()
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	return svc
+}
+
+// deliver sends the message to the service as the transport would.
+func deliver(t *testing.T, svc *Service, msg interface{}, ctx service.DIDCommContext) string {
+	t.Helper()
+
+	raw, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	didCommMsg, err := service.ParseDIDCommMsgMap(raw)
+	require.NoError(t, err)
+
+	connID, err := svc.HandleInbound(didCommMsg, ctx)
+	require.NoError(t, err)
+
+	return connID
+}
+
+func TestServiceNew(t *testing.T) {
+	t.Run("test error from open store", func(t *testing.T) {
+		_, err := New(&testProvider{MockProvider: &protocol.MockProvider{
+			StoreProvider: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("failed to open store")},
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open store")
+	})
+
+	t.Run("test error - no route service found", func(t *testing.T) {
+		_, err := New(&testProvider{MockProvider: &protocol.MockProvider{ServiceErr: errors.New("service not found")}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service not found")
+	})
+
+	t.Run("test error - casting to route service failed", func(t *testing.T) {
+		_, err := New(&testProvider{MockProvider: &protocol.MockProvider{}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to Route Service failed")
+	})
+
+	t.Run("test success", func(t *testing.T) {
+		svc := newService(t, newTestProvider(t, aliceEndpoint))
+		require.Equal(t, LegacyConnection, svc.Name())
+		require.True(t, svc.Accept(InvitationMsgType))
+		require.True(t, svc.Accept(RequestMsgType))
+		require.True(t, svc.Accept(ResponseMsgType))
+		require.True(t, svc.Accept(AckMsgType))
+		require.False(t, svc.Accept("https://didcomm.org/didexchange/1.0/request"))
+
+		_, err := svc.HandleOutbound(nil, "", "")
+		require.EqualError(t, err, "not implemented")
+	})
+}
+
+// connect establishes the connection of Bob (invitee) with Alice (inviter),
+// the requests of Bob have no parent thread if withoutPthID is set.
+func connect(t *testing.T, withoutPthID bool) (alice, bob *Service, aliceConnID, bobConnID string) {
+	t.Helper()
+
+	aliceProv, bobProv := newTestProvider(t, aliceEndpoint), newTestProvider(t, bobEndpoint)
+	alice, bob = newService(t, aliceProv), newService(t, bobProv)
+
+	invitation, err := alice.CreateInvitation("Alice")
+	require.NoError(t, err)
+	require.Equal(t, aliceEndpoint, invitation.ServiceEndpoint)
+	require.Len(t, invitation.RecipientKeys, 1)
+
+	bobProv.CustomOutbound.ValidateSend = func(msg interface{}, senderVerKey string, des *service.Destination) error {
+		require.True(t, strings.HasPrefix(senderVerKey, "did:key:"))
+
+		switch m := msg.(type) {
+		case *Request:
+			require.Equal(t, aliceEndpoint, des.ServiceEndpoint)
+			require.Equal(t, didKeys(invitation.RecipientKeys), des.RecipientKeys)
+			require.Equal(t, invitation.ID, m.Thread.PID)
+
+			props := map[string]interface{}{
+				service.DIDCommContextRecipientKeyKey: base58.Decode(invitation.RecipientKeys[0]),
+			}
+
+			if withoutPthID {
+				m.Thread = nil
+			}
+
+			aliceConnID = deliver(t, alice, m, service.NewDIDCommContext("", "", props))
+		default:
+			require.Equal(t, aliceEndpoint, des.ServiceEndpoint)
+			deliver(t, alice, m, service.EmptyDIDCommContext())
+		}
+
+		return nil
+	}
+
+	aliceProv.CustomOutbound.ValidateSend = func(msg interface{}, senderVerKey string, des *service.Destination) error {
+		require.True(t, strings.HasPrefix(senderVerKey, "did:key:"))
+		require.Equal(t, bobEndpoint, des.ServiceEndpoint)
+
+		deliver(t, bob, msg, service.EmptyDIDCommContext())
+
+		return nil
+	}
+
+	bobConnID = deliver(t, bob, invitation, service.EmptyDIDCommContext())
+
+	record, err := bob.connectionRecorder.GetConnectionRecord(bobConnID)
+	require.NoError(t, err)
+	require.Equal(t, StateIDInvited, record.State)
+	require.Equal(t, "Alice", record.TheirLabel)
+
+	require.NoError(t, bob.AcceptInvitation(bobConnID, "Bob", nil))
+	require.NotEmpty(t, aliceConnID)
+
+	record, err = alice.connectionRecorder.GetConnectionRecord(aliceConnID)
+	require.NoError(t, err)
+	require.Equal(t, StateIDRequested, record.State)
+	require.Equal(t, "Bob", record.TheirLabel)
+	require.Equal(t, invitation.ID, record.InvitationID)
+
+	require.NoError(t, alice.AcceptConnectionRequest(aliceConnID, "", nil))
+
+	return alice, bob, aliceConnID, bobConnID
+}
+
+func TestService_Connect(t *testing.T) {
+	for name, withoutPthID := range map[string]bool{
+		"request with the invitation as parent thread": false,
+		"request without parent thread":                true,
+	} {
+		withoutPthID := withoutPthID
+
+		t.Run(name, func(t *testing.T) {
+			alice, bob, aliceConnID, bobConnID := connect(t, withoutPthID)
+
+			aliceRecord, err := alice.connectionRecorder.GetConnectionRecord(aliceConnID)
+			require.NoError(t, err)
+			require.Equal(t, StateIDCompleted, aliceRecord.State)
+
+			bobRecord, err := bob.connectionRecorder.GetConnectionRecord(bobConnID)
+			require.NoError(t, err)
+			require.Equal(t, StateIDCompleted, bobRecord.State)
+
+			require.Equal(t, aliceRecord.MyDID, bobRecord.TheirDID)
+			require.Equal(t, bobRecord.MyDID, aliceRecord.TheirDID)
+			require.True(t, strings.HasPrefix(aliceRecord.MyDID, "did:sov:"))
+			require.True(t, strings.HasPrefix(bobRecord.MyDID, "did:sov:"))
+
+			// the DID docs of the other party are stored by the VDR
+			doc, err := alice.vdRegistry.Resolve(aliceRecord.TheirDID)
+			require.NoError(t, err)
+			require.Equal(t, bobEndpoint, doc.DIDDocument.Service[0].ServiceEndpoint)
+		})
+	}
+}
+
+func TestService_ActionEvents(t *testing.T) {
+	prov := newTestProvider(t, bobEndpoint)
+	bob := newService(t, prov)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, bob.RegisterActionEvent(actions))
+
+	states := make(chan service.StateMsg, 10)
+	require.NoError(t, bob.RegisterMsgEvent(states))
+
+	invitation := &Invitation{
+		Type:            InvitationMsgType,
+		ID:              generateRandomID(),
+		Label:           "Alice",
+		RecipientKeys:   []string{base58.Encode(make([]byte, 32))},
+		ServiceEndpoint: aliceEndpoint,
+	}
+
+	connID := deliver(t, bob, invitation, service.EmptyDIDCommContext())
+
+	action := <-actions
+	require.Equal(t, LegacyConnection, action.ProtocolName)
+	require.Equal(t, connID, action.Properties.(Event).ConnectionID())
+	require.Equal(t, invitation.ID, action.Properties.(Event).InvitationID())
+
+	action.Stop(errors.New("declined"))
+
+	for state := range states {
+		if state.StateID != StateIDAbandoned {
+			continue
+		}
+
+		require.Equal(t, service.PostState, state.Type)
+		require.Equal(t, "declined", state.Properties.All()["error"])
+
+		break
+	}
+
+	record, err := bob.connectionRecorder.GetConnectionRecord(connID)
+	require.NoError(t, err)
+	require.Equal(t, StateIDAbandoned, record.State)
+
+	err = bob.AcceptInvitation(connID, "Bob", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "current state (abandoned) is different from expected state (invited)")
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	svc := newService(t, newTestProvider(t, aliceEndpoint))
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Invitation{Type: PIURI + "/unknown"}),
+			service.EmptyDIDCommContext())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported message type")
+	})
+
+	t.Run("invitation with a public DID", func(t *testing.T) {
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Invitation{
+			Type: InvitationMsgType,
+			ID:   generateRandomID(),
+			DID:  "did:sov:QmWbsNYhMrjHiqZDTUTEJs",
+		}), service.EmptyDIDCommContext())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public DID are not supported")
+	})
+
+	t.Run("request of an unknown invitation", func(t *testing.T) {
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Request{
+			Type:   RequestMsgType,
+			ID:     generateRandomID(),
+			Thread: &decorator.Thread{PID: generateRandomID()},
+		}), service.EmptyDIDCommContext())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get invitation")
+	})
+
+	t.Run("response of an unknown connection", func(t *testing.T) {
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Response{
+			Type:   ResponseMsgType,
+			ID:     generateRandomID(),
+			Thread: &decorator.Thread{ID: generateRandomID()},
+		}), service.EmptyDIDCommContext())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+
+	t.Run("accept an unknown connection", func(t *testing.T) {
+		err := svc.AcceptConnectionRequest(generateRandomID(), "", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get pending message")
+	})
+}
+
+func TestVerifySignature(t *testing.T) {
+	prov := newTestProvider(t, aliceEndpoint)
+	svc := newService(t, prov)
+
+	invitation, err := svc.CreateInvitation("Alice")
+	require.NoError(t, err)
+
+	invitationKey := didKey(invitation.RecipientKeys[0])
+
+	conn, err := svc.newConnection(nil)
+	require.NoError(t, err)
+
+	signature, err := svc.signConnection(conn, invitationKey)
+	require.NoError(t, err)
+	require.Equal(t, invitation.RecipientKeys[0], signature.SignVerKey)
+
+	t.Run("success", func(t *testing.T) {
+		verified, err := verifySignature(signature, invitationKey)
+		require.NoError(t, err)
+		require.Equal(t, conn.DID, verified.DID)
+		require.Equal(t, conn.DIDDoc.VerificationMethod[0].Value, verified.DIDDoc.VerificationMethod[0].Value)
+	})
+
+	t.Run("success - unpadded base64", func(t *testing.T) {
+		unpadded := *signature
+		unpadded.SignedData = strings.TrimRight(unpadded.SignedData, "=")
+		unpadded.Signature = strings.TrimRight(unpadded.Signature, "=")
+
+		_, err := verifySignature(&unpadded, invitationKey)
+		require.NoError(t, err)
+	})
+
+	t.Run("signed with another key", func(t *testing.T) {
+		otherKey, _ := fingerprint.CreateDIDKey(make([]byte, 32))
+
+		_, err := verifySignature(signature, otherKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not signed with the invitation key")
+	})
+
+	t.Run("tampered connection", func(t *testing.T) {
+		tampered := *signature
+		tampered.SignedData = base64.URLEncoding.EncodeToString([]byte("12345678{}"))
+
+		_, err := verifySignature(&tampered, invitationKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify signature")
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		_, err := verifySignature(nil, invitationKey)
+		require.EqualError(t, err, "missing connection signature")
+	})
+}
+
+func TestCanTransition(t *testing.T) {
+	require.True(t, canTransition("", StateIDInvited))
+	require.True(t, canTransition(StateIDInvited, StateIDRequested))
+	require.True(t, canTransition(StateIDRequested, StateIDResponded))
+	require.True(t, canTransition(StateIDResponded, StateIDCompleted))
+	require.False(t, canTransition(StateIDCompleted, StateIDRequested))
+	require.False(t, canTransition(StateIDInvited, StateIDAbandoned))
+	require.False(t, canTransition(connection.StateNameCompleted, StateIDResponded))
+}
@@ -0,0 +1,491 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package legacyconnection
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	signatureType = "https://didcomm.org/signature/1.0/ed25519Sha512_single"
+	ackStatusOK   = "OK"
+	timestampLen  = 8
+)
+
+// CreateInvitation creates the invitation with a new key, whose endpoint is the one of the first router
+// connection if any, and saves it to find the invitation of the requests.
+func (s *Service) CreateInvitation(label string, routerConnections ...string) (*Invitation, error) {
+	_, pubKey, err := s.kms.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	if err != nil {
+		return nil, fmt.Errorf("create invitation key: %w", err)
+	}
+
+	endpoint, routingKeys, err := s.routerConfig(pubKey, routerConnections)
+	if err != nil {
+		return nil, fmt.Errorf("create invitation: %w", err)
+	}
+
+	invitation := &Invitation{
+		Type:            InvitationMsgType,
+		ID:              generateRandomID(),
+		Label:           label,
+		RecipientKeys:   []string{base58.Encode(pubKey)},
+		ServiceEndpoint: endpoint,
+		RoutingKeys:     routingKeys,
+	}
+
+	// the requests of the Indy agents have no parent thread, the invitation is found by the recipient key as well
+	for _, id := range []string{invitation.ID, invitationKey(pubKey)} {
+		if err = s.connectionRecorder.SaveInvitation(id, invitation); err != nil {
+			return nil, fmt.Errorf("save invitation: %w", err)
+		}
+	}
+
+	return invitation, nil
+}
+
+// routerConfig returns the endpoint and the base58 routing keys of the router connections
+// and registers the key with the routers.
+func (s *Service) routerConfig(pubKey []byte, routerConnections []string) (string, []string, error) {
+	endpoint := s.serviceEndpoint
+
+	var routingKeys []string
+
+	recKey, _ := fingerprint.CreateDIDKey(pubKey)
+
+	for i, connID := range routerConnections {
+		routerEndpoint, keys, err := mediator.GetRouterConfig(s.routeSvc, connID, endpoint)
+		if err != nil {
+			return "", nil, fmt.Errorf("fetch router config: %w", err)
+		}
+
+		if i == 0 {
+			endpoint, routingKeys = routerEndpoint, base58Keys(keys)
+		}
+
+		if err = mediator.AddKeyToRouter(s.routeSvc, connID, recKey); err != nil {
+			return "", nil, fmt.Errorf("add key to the router: %w", err)
+		}
+	}
+
+	return endpoint, routingKeys, nil
+}
+
+// newConnection creates the key and the did:sov DID doc of our side of the connection and stores them.
+func (s *Service) newConnection(routerConnections []string) (*Connection, error) {
+	_, pubKey, err := s.kms.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	if err != nil {
+		return nil, fmt.Errorf("create connection key: %w", err)
+	}
+
+	endpoint, routingKeys, err := s.routerConfig(pubKey, routerConnections)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := newLegacyDoc(pubKey, endpoint, routingKeys)
+
+	if _, err = s.vdRegistry.Create(interop.SovMethod, doc, vdrapi.WithOption("store", true)); err != nil {
+		return nil, fmt.Errorf("store did doc: %w", err)
+	}
+
+	if err = s.connectionStore.SaveDIDFromDoc(doc); err != nil {
+		return nil, fmt.Errorf("save did: %w", err)
+	}
+
+	return &Connection{DID: interop.UnqualifiedDID(doc.ID), DIDDoc: doc}, nil
+}
+
+// storeTheirDoc qualifies the DID of the connection of the other party and stores its DID doc.
+func (s *Service) storeTheirDoc(conn *Connection) (*did.Doc, error) {
+	if conn == nil || conn.DIDDoc == nil {
+		return nil, errors.New("missing DID doc of the connection")
+	}
+
+	doc := conn.DIDDoc
+	doc.ID = interop.SovDID(doc.ID)
+
+	if _, err := s.vdRegistry.Create(interop.SovMethod, doc, vdrapi.WithOption("store", true)); err != nil {
+		return nil, fmt.Errorf("store their did doc: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (s *Service) invitationMsgRecord(msg service.DIDCommMsg) (*connection.Record, error) {
+	invitation := &Invitation{}
+
+	if err := msg.Decode(invitation); err != nil {
+		return nil, fmt.Errorf("decode invitation: %w", err)
+	}
+
+	if invitation.DID != "" {
+		return nil, errors.New("the invitations with a public DID are not supported")
+	}
+
+	if len(invitation.RecipientKeys) == 0 || invitation.ServiceEndpoint == "" {
+		return nil, errors.New("missing recipient keys or service endpoint of the invitation")
+	}
+
+	// the thread of the connection is the one of the request
+	record := &connection.Record{
+		ConnectionID:    generateRandomID(),
+		ThreadID:        generateRandomID(),
+		ParentThreadID:  invitation.ID,
+		InvitationID:    invitation.ID,
+		TheirLabel:      invitation.Label,
+		RecipientKeys:   didKeys(invitation.RecipientKeys),
+		InvitationKeys:  didKeys(invitation.RecipientKeys),
+		RoutingKeys:     didKeys(invitation.RoutingKeys),
+		ServiceEndPoint: invitation.ServiceEndpoint,
+		Namespace:       connection.MyNSPrefix,
+	}
+
+	if err := s.transition(msg, record, StateIDInvited); err != nil {
+		return nil, err
+	}
+
+	return record, s.sendActionEvent(msg, record)
+}
+
+// sendRequest sends the request with our DID doc to the inviter.
+func (s *Service) sendRequest(msg service.DIDCommMsg, record *connection.Record, label string,
+	routerConnections []string) error {
+	conn, err := s.newConnection(routerConnections)
+	if err != nil {
+		return err
+	}
+
+	request := &Request{
+		Type:       RequestMsgType,
+		ID:         record.ThreadID,
+		Label:      label,
+		Thread:     &decorator.Thread{PID: record.InvitationID},
+		Connection: conn,
+	}
+
+	destination := &service.Destination{
+		RecipientKeys:   record.InvitationKeys,
+		ServiceEndpoint: record.ServiceEndPoint,
+		RoutingKeys:     record.RoutingKeys,
+	}
+
+	record.MyDID = conn.DIDDoc.ID
+
+	// the record is saved before sending, the response may be handled before Send returns
+	if err = s.transition(msg, record, StateIDRequested); err != nil {
+		return err
+	}
+
+	if err = s.outboundDispatcher.Send(request, didKey(conn.DIDDoc.Service[0].RecipientKeys[0]),
+		destination); err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) requestMsgRecord(msg service.DIDCommMsg, ctx service.DIDCommContext) (*connection.Record, error) {
+	request := &Request{}
+
+	if err := msg.Decode(request); err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+
+	invitation, err := s.findInvitation(msg.ParentThreadID(), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	theirDoc, err := s.storeTheirDoc(request.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &connection.Record{
+		ConnectionID:   generateRandomID(),
+		ThreadID:       request.ID,
+		ParentThreadID: invitation.ID,
+		InvitationID:   invitation.ID,
+		TheirLabel:     request.Label,
+		TheirDID:       theirDoc.ID,
+		InvitationKeys: didKeys(invitation.RecipientKeys),
+		Namespace:      connection.TheirNSPrefix,
+	}
+
+	if destination, e := service.CreateDestination(theirDoc); e == nil {
+		record.RecipientKeys = destination.RecipientKeys
+		record.RoutingKeys = destination.RoutingKeys
+		record.ServiceEndPoint = destination.ServiceEndpoint
+	}
+
+	if err = s.transition(msg, record, StateIDRequested); err != nil {
+		return nil, err
+	}
+
+	return record, s.sendActionEvent(msg, record)
+}
+
+// findInvitation returns the invitation of the parent thread of the request or, if the request has none,
+// the invitation of the key the request was encrypted with.
+func (s *Service) findInvitation(pthID string, ctx service.DIDCommContext) (*Invitation, error) {
+	id := pthID
+
+	if id == "" && ctx != nil {
+		if recKey, ok := ctx.All()[service.DIDCommContextRecipientKeyKey].([]byte); ok && len(recKey) > 0 {
+			id = invitationKey(recKey)
+		}
+	}
+
+	if id == "" {
+		return nil, errors.New("no invitation of the request")
+	}
+
+	invitation := &Invitation{}
+
+	if err := s.connectionRecorder.GetInvitation(id, invitation); err != nil {
+		return nil, fmt.Errorf("get invitation %s: %w", id, err)
+	}
+
+	return invitation, nil
+}
+
+// sendResponse sends the response with our DID doc, signed with the invitation key, to the invitee.
+func (s *Service) sendResponse(msg service.DIDCommMsg, record *connection.Record, routerConnections []string) error {
+	conn, err := s.newConnection(routerConnections)
+	if err != nil {
+		return err
+	}
+
+	signature, err := s.signConnection(conn, record.InvitationKeys[0])
+	if err != nil {
+		return err
+	}
+
+	theirDoc, err := s.vdRegistry.Resolve(record.TheirDID)
+	if err != nil {
+		return fmt.Errorf("resolve their did: %w", err)
+	}
+
+	destination, err := service.CreateDestination(theirDoc.DIDDocument)
+	if err != nil {
+		return fmt.Errorf("create destination: %w", err)
+	}
+
+	response := &Response{
+		Type:                ResponseMsgType,
+		ID:                  generateRandomID(),
+		Thread:              &decorator.Thread{ID: record.ThreadID},
+		ConnectionSignature: signature,
+	}
+
+	record.MyDID = conn.DIDDoc.ID
+
+	// the record is saved before sending, the ack may be handled before Send returns
+	if err = s.transition(msg, record, StateIDResponded); err != nil {
+		return err
+	}
+
+	if err = s.outboundDispatcher.Send(response, didKey(conn.DIDDoc.Service[0].RecipientKeys[0]),
+		destination); err != nil {
+		return fmt.Errorf("send response: %w", err)
+	}
+
+	return nil
+}
+
+// signConnection signs the timestamped connection with the invitation key as per RFC 0160.
+func (s *Service) signConnection(conn *Connection, invitationKey string) (*ConnectionSignature, error) {
+	connBytes, err := json.Marshal(conn)
+	if err != nil {
+		return nil, fmt.Errorf("marshal connection: %w", err)
+	}
+
+	sigData := make([]byte, timestampLen, timestampLen+len(connBytes))
+	binary.BigEndian.PutUint64(sigData, uint64(time.Now().Unix()))
+	sigData = append(sigData, connBytes...)
+
+	pubKey, err := fingerprint.PubKeyFromDIDKey(invitationKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse invitation key: %w", err)
+	}
+
+	kid, err := localkms.CreateKID(pubKey, kms.ED25519Type)
+	if err != nil {
+		return nil, fmt.Errorf("create kid of invitation key: %w", err)
+	}
+
+	kh, err := s.kms.Get(kid)
+	if err != nil {
+		return nil, fmt.Errorf("get invitation key handle: %w", err)
+	}
+
+	signature, err := s.crypto.Sign(sigData, kh)
+	if err != nil {
+		return nil, fmt.Errorf("sign connection: %w", err)
+	}
+
+	return &ConnectionSignature{
+		Type:       signatureType,
+		Signature:  base64.URLEncoding.EncodeToString(signature),
+		SignedData: base64.URLEncoding.EncodeToString(sigData),
+		SignVerKey: base58.Encode(pubKey),
+	}, nil
+}
+
+// handleResponse verifies the response, completes the connection and acknowledges it.
+func (s *Service) handleResponse(msg service.DIDCommMsg) (*connection.Record, error) {
+	response := &Response{}
+
+	if err := msg.Decode(response); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	record, err := s.fetchConnectionRecord(connection.MyNSPrefix, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := verifySignature(response.ConnectionSignature, record.InvitationKeys[0])
+	if err != nil {
+		return nil, err
+	}
+
+	theirDoc, err := s.storeTheirDoc(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, err := service.CreateDestination(theirDoc)
+	if err != nil {
+		return nil, fmt.Errorf("create destination: %w", err)
+	}
+
+	myDoc, err := s.vdRegistry.Resolve(record.MyDID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve my did: %w", err)
+	}
+
+	record.TheirDID = theirDoc.ID
+	record.RecipientKeys = destination.RecipientKeys
+	record.RoutingKeys = destination.RoutingKeys
+	record.ServiceEndPoint = destination.ServiceEndpoint
+
+	if err = s.transition(msg, record, StateIDCompleted); err != nil {
+		return nil, err
+	}
+
+	if err = s.connectionStore.SaveDIDByResolving(record.TheirDID, record.RecipientKeys...); err != nil {
+		return nil, fmt.Errorf("save their did: %w", err)
+	}
+
+	ack := &model.Ack{
+		Type:   AckMsgType,
+		ID:     generateRandomID(),
+		Status: ackStatusOK,
+		Thread: &decorator.Thread{ID: record.ThreadID},
+	}
+
+	if err = s.outboundDispatcher.Send(ack, didKey(myDoc.DIDDocument.Service[0].RecipientKeys[0]),
+		destination); err != nil {
+		return nil, fmt.Errorf("send ack: %w", err)
+	}
+
+	return record, nil
+}
+
+// handleAck completes the connection of the inviter.
+func (s *Service) handleAck(msg service.DIDCommMsg) (*connection.Record, error) {
+	record, err := s.fetchConnectionRecord(connection.TheirNSPrefix, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.transition(msg, record, StateIDCompleted); err != nil {
+		return nil, err
+	}
+
+	if err = s.connectionStore.SaveDIDByResolving(record.TheirDID, record.RecipientKeys...); err != nil {
+		return nil, fmt.Errorf("save their did: %w", err)
+	}
+
+	return record, nil
+}
+
+// verifySignature verifies the connection signature with the invitation key and returns the signed connection.
+func verifySignature(connSignature *ConnectionSignature, invitationKey string) (*Connection, error) {
+	if connSignature == nil {
+		return nil, errors.New("missing connection signature")
+	}
+
+	sigData, err := decodeBase64URL(connSignature.SignedData)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature data: %w", err)
+	}
+
+	signature, err := decodeBase64URL(connSignature.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature: %w", err)
+	}
+
+	pubKey, err := fingerprint.PubKeyFromDIDKey(invitationKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse invitation key: %w", err)
+	}
+
+	// the connection must be signed with the key of the invitation for continuity
+	if connSignature.SignVerKey != base58.Encode(pubKey) {
+		return nil, errors.New("the connection is not signed with the invitation key")
+	}
+
+	signatureSuite := ed25519signature2018.New(suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	if err = signatureSuite.Verify(&verifier.PublicKey{Type: kms.ED25519, Value: pubKey}, sigData,
+		signature); err != nil {
+		return nil, fmt.Errorf("verify signature: %w", err)
+	}
+
+	if len(sigData) <= timestampLen {
+		return nil, errors.New("missing connection attribute bytes")
+	}
+
+	conn := &Connection{}
+
+	if err = json.Unmarshal(sigData[timestampLen:], conn); err != nil {
+		return nil, fmt.Errorf("unmarshal connection: %w", err)
+	}
+
+	return conn, nil
+}
+
+// decodeBase64URL decodes the URL base64 with or without padding, the Indy agents pad it.
+func decodeBase64URL(data string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(data, "="))
+}
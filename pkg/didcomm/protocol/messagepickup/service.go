@@ -47,6 +47,9 @@ const (
 
 	// Namespace is namespace of messagepickup store name.
 	Namespace = "mailbox"
+
+	// inboxTag is the tag of the inbox records, used to query all of them.
+	inboxTag = "inbox"
 )
 
 // ErrConnectionNotFound connection not found error.
@@ -377,7 +380,7 @@ func (s *Service) createInbox(theirDID string) (*inbox, error) {
 			return nil, e
 		}
 
-		e = s.msgStore.Put(theirDID, msgBytes)
+		e = s.msgStore.Put(theirDID, msgBytes, storage.Tag{Name: inboxTag})
 		if e != nil {
 			return nil, e
 		}
@@ -410,7 +413,43 @@ func (s *Service) putInbox(theirDID string, o *inbox) error {
 		return err
 	}
 
-	return s.msgStore.Put(theirDID, b)
+	return s.msgStore.Put(theirDID, b, storage.Tag{Name: inboxTag})
+}
+
+// QueuedMessageCount returns the number of messages waiting in the inboxes to be picked up.
+func (s *Service) QueuedMessageCount() (int, error) {
+	iter, err := s.msgStore.Query(inboxTag)
+	if err != nil {
+		return 0, fmt.Errorf("query inboxes: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	count := 0
+
+	for {
+		ok, errNext := iter.Next()
+		if errNext != nil {
+			return 0, fmt.Errorf("next inbox: %w", errNext)
+		}
+
+		if !ok {
+			return count, nil
+		}
+
+		b, errValue := iter.Value()
+		if errValue != nil {
+			return 0, fmt.Errorf("inbox value: %w", errValue)
+		}
+
+		o := &inbox{}
+
+		if errUnmarshal := json.Unmarshal(b, o); errUnmarshal != nil {
+			return 0, fmt.Errorf("unmarshal inbox: %w", errUnmarshal)
+		}
+
+		count += o.MessageCount
+	}
 }
 
 // StatusRequest request a status message.
@@ -581,6 +581,64 @@ func TestAddMessage(t *testing.T) {
 	})
 }
 
+func TestQueuedMessageCount(t *testing.T) {
+	t.Run("test MessagePickupService.QueuedMessageCount() - success", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		}, &mockTransportProvider{
+			packagerValue: &mockPackager{},
+		})
+		require.NoError(t, err)
+
+		count, err := svc.QueuedMessageCount()
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+
+		require.NoError(t, svc.AddMessage(&model.Envelope{}, THEIRDID))
+		require.NoError(t, svc.AddMessage(&model.Envelope{}, THEIRDID))
+		require.NoError(t, svc.AddMessage(&model.Envelope{}, "other-did"))
+
+		count, err = svc.QueuedMessageCount()
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("test MessagePickupService.QueuedMessageCount() - query error", func(t *testing.T) {
+		mockStore := mockstore.NewMockStoreProvider()
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockStore,
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		}, &mockTransportProvider{
+			packagerValue: &mockPackager{},
+		})
+		require.NoError(t, err)
+
+		mockStore.Store.ErrQuery = errors.New("error query")
+
+		_, err = svc.QueuedMessageCount()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error query")
+	})
+
+	t.Run("test MessagePickupService.QueuedMessageCount() - invalid inbox", func(t *testing.T) {
+		mockStore := mockstore.NewMockStoreProvider()
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockStore,
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		}, &mockTransportProvider{
+			packagerValue: &mockPackager{},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.msgStore.Put(THEIRDID, []byte("invalid"), storage.Tag{Name: inboxTag}))
+
+		_, err = svc.QueuedMessageCount()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal inbox")
+	})
+}
+
 func TestStatusRequest(t *testing.T) {
 	t.Run("test MessagePickupService.StatusRequest() - success", func(t *testing.T) {
 		msgID := make(chan string)
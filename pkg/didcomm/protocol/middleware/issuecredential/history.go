@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+)
+
+const piidKey = "piid"
+
+var logger = log.New("aries-framework/issuecredential/middleware")
+
+// historyEvents are the credential history events of the states of the protocol.
+var historyEvents = map[string]history.EventType{ // nolint: gochecknoglobals
+	"offer-sent":          history.EventOffered,
+	"offer-received":      history.EventOffered,
+	"request-sent":        history.EventRequested,
+	"request-received":    history.EventRequested,
+	"credential-issued":   history.EventIssued,
+	"credential-received": history.EventIssued,
+}
+
+// RecordHistory the helper function for the issue credential protocol which records the offers, the requests
+// and the issuances of the credentials in the credential history. The events are recorded once the next
+// handlers succeeded, a failure to record them is logged and does not stop the protocol.
+func RecordHistory(store *history.Store) issuecredential.Middleware {
+	return func(next issuecredential.Handler) issuecredential.Handler {
+		return issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+			if err := next.Handle(metadata); err != nil {
+				return err
+			}
+
+			eventType, ok := historyEvents[metadata.StateName()]
+			if !ok {
+				return nil
+			}
+
+			properties := metadata.Properties()
+
+			// nolint: errcheck
+			myDID, _ := properties[myDIDKey].(string)
+			// nolint: errcheck
+			theirDID, _ := properties[theirDIDKey].(string)
+			// nolint: errcheck
+			piid, _ := properties[piidKey].(string)
+
+			event := &history.Event{
+				Type:     eventType,
+				Protocol: issuecredential.Name,
+				PIID:     piid,
+				MyDID:    myDID,
+				TheirDID: theirDID,
+			}
+
+			if eventType == history.EventIssued {
+				event.CredentialIDs = issuedCredentialIDs(metadata)
+
+				if names, ok := properties[namesKey].([]string); ok && len(names) > 0 {
+					event.Details = map[string]string{namesKey: strings.Join(names, " ")}
+				}
+			}
+
+			if err := store.Record(event); err != nil {
+				logger.Warnf("failed to record %s event of %s in credential history: %s", eventType, piid, err)
+			}
+
+			return nil
+		})
+	}
+}
+
+// issuedCredentialIDs returns the IDs of the credentials issued by the user (through the Continue function)
+// or received, the credentials without ID are ignored.
+func issuedCredentialIDs(metadata issuecredential.Metadata) []string {
+	credential := metadata.IssueCredential()
+	if credential == nil {
+		credential = &issuecredential.IssueCredential{}
+
+		if err := metadata.Message().Decode(credential); err != nil {
+			return nil
+		}
+	}
+
+	var ids []string
+
+	for i := range credential.CredentialsAttach {
+		raw, err := credential.CredentialsAttach[i].Data.Fetch()
+		if err != nil {
+			continue
+		}
+
+		vc := struct {
+			ID string `json:"id"`
+		}{}
+
+		if err = json.Unmarshal(raw, &vc); err == nil && vc.ID != "" {
+			ids = append(ids, vc.ID)
+		}
+	}
+
+	return ids
+}
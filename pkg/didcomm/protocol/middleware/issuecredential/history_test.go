@@ -0,0 +1,139 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+)
+
+func TestRecordHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeProvider := mockstore.NewMockStoreProvider()
+
+	store, err := history.New(&mockprovider.Provider{
+		StorageProviderValue:              storeProvider,
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	next := issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+		return nil
+	})
+
+	properties := map[string]interface{}{myDIDKey: "did:example:my", theirDIDKey: "did:example:their", piidKey: "piid"}
+
+	t.Run("Ignores the states without event", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("done")
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+
+		events, err := store.Timeline(nil)
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+
+	t.Run("Records the offer", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("offer-received")
+		metadata.EXPECT().Properties().Return(properties)
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+
+		events, err := store.Timeline(&history.Filter{Type: history.EventOffered})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, issuecredential.Name, events[0].Protocol)
+		require.Equal(t, "piid", events[0].PIID)
+		require.Equal(t, "did:example:my", events[0].MyDID)
+		require.Equal(t, "did:example:their", events[0].TheirDID)
+	})
+
+	t.Run("Records the received credentials", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().Properties().Return(map[string]interface{}{
+			piidKey: "piid", namesKey: []string{"degree"},
+		})
+		metadata.EXPECT().IssueCredential().Return(nil)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
+			Type: issuecredential.IssueCredentialMsgType,
+			CredentialsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{JSON: getCredential()}},
+				{Data: decorator.AttachmentData{JSON: map[string]interface{}{"type": "VerifiableCredential"}}},
+				{Data: decorator.AttachmentData{}},
+			},
+		}))
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+
+		events, err := store.Timeline(&history.Filter{CredentialID: getCredential().ID})
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		require.Equal(t, history.EventIssued, events[1].Type)
+		require.Equal(t, []string{getCredential().ID}, events[1].CredentialIDs)
+		require.Equal(t, map[string]string{namesKey: "degree"}, events[1].Details)
+	})
+
+	t.Run("Records the issued credentials", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("credential-issued")
+		metadata.EXPECT().Properties().Return(map[string]interface{}{piidKey: "piid-2"})
+		metadata.EXPECT().IssueCredential().Return(&issuecredential.IssueCredential{
+			CredentialsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{JSON: getCredential()}}},
+		})
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+
+		events, err := store.Timeline(&history.Filter{CredentialID: getCredential().ID})
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		require.Equal(t, "piid-2", events[2].PIID)
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().IssueCredential().Return(nil)
+		metadata.EXPECT().Message().Return(service.DIDCommMsgMap{"@type": map[int]int{}})
+
+		require.Empty(t, issuedCredentialIDs(metadata))
+	})
+
+	t.Run("Record error does not stop the protocol", func(t *testing.T) {
+		storeProvider.Store.ErrPut = errors.New("put error")
+		defer func() { storeProvider.Store.ErrPut = nil }()
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("request-sent")
+		metadata.EXPECT().Properties().Return(properties)
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+	})
+
+	t.Run("Next handler error", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+
+		err := RecordHistory(store)(issuecredential.HandlerFunc(func(issuecredential.Metadata) error {
+			return errors.New("next error")
+		})).Handle(metadata)
+		require.EqualError(t, err, "next error")
+	})
+}
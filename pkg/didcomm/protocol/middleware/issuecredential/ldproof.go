@@ -0,0 +1,335 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmssigner"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/keylink"
+)
+
+// Proof types supported by LDProofVCDetailHandler.
+const (
+	Ed25519Signature2018 = "Ed25519Signature2018"
+	JSONWebSignature2020 = "JsonWebSignature2020"
+	BbsBlsSignature2020  = "BbsBlsSignature2020"
+)
+
+const (
+	assertionMethodPurpose = "assertionMethod"
+	authenticationPurpose  = "authentication"
+	baseContext            = "https://www.w3.org/2018/credentials/v1"
+	vcType                 = "VerifiableCredential"
+)
+
+// LDProofVCDetail is the content of the attachment in issuecredential.LDProofVCDetailFormat (RFC 0593).
+type LDProofVCDetail struct {
+	// Credential is the credential to be issued (without proof).
+	Credential json.RawMessage `json:"credential"`
+	// Options are the options of the proof to be added to the credential.
+	Options *LDProofVCDetailOptions `json:"options"`
+}
+
+// LDProofVCDetailOptions are the options of the proof of credential to be issued.
+type LDProofVCDetailOptions struct {
+	// ProofType is the type of the proof, e.g. Ed25519Signature2018.
+	ProofType string `json:"proofType"`
+	// ProofPurpose is the purpose of the proof, assertionMethod (default) or authentication.
+	ProofPurpose string `json:"proofPurpose,omitempty"`
+	// Created is the date of the proof. If omitted, the time of issuance is used.
+	Created *time.Time `json:"created,omitempty"`
+	// Domain is the operational domain of the proof.
+	Domain string `json:"domain,omitempty"`
+	// Challenge is the challenge of the proof.
+	Challenge string `json:"challenge,omitempty"`
+}
+
+// LDProofProvider contains dependencies for LDProofVCDetailHandler.
+type LDProofProvider interface {
+	VDRegistry() vdrapi.Registry
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+}
+
+// keyLinkProvider is optionally implemented by the provider to look up KMS keys of the agent's verification methods.
+type keyLinkProvider interface {
+	KeyLinkStore() *keylink.Store
+}
+
+// LDProofVCDetailHandler is the handler of issuecredential.LDProofVCDetailFormat. It signs the requested
+// credentials using the KMS key of the issuer's DID verification method and issues them
+// in issuecredential.LDProofVCFormat.
+type LDProofVCDetailHandler struct {
+	vdr       vdrapi.Registry
+	resolver  *kmssigner.Resolver
+	docLoader ld.DocumentLoader
+	issuer    string
+	proofType string
+}
+
+// LDProofOpt configures LDProofVCDetailHandler.
+type LDProofOpt func(h *LDProofVCDetailHandler)
+
+// WithIssuer sets the DID of the issuer of the credentials offered from the credential preview.
+func WithIssuer(issuerDID string) LDProofOpt {
+	return func(h *LDProofVCDetailHandler) {
+		h.issuer = issuerDID
+	}
+}
+
+// WithProofType sets the proof type of the credentials offered from the credential preview
+// (Ed25519Signature2018 by default).
+func WithProofType(proofType string) LDProofOpt {
+	return func(h *LDProofVCDetailHandler) {
+		h.proofType = proofType
+	}
+}
+
+// WithJSONLDDocumentLoader sets the JSON-LD document loader used to sign and verify the credentials.
+func WithJSONLDDocumentLoader(loader ld.DocumentLoader) LDProofOpt {
+	return func(h *LDProofVCDetailHandler) {
+		h.docLoader = loader
+	}
+}
+
+// NewLDProofVCDetailHandler returns new handler of issuecredential.LDProofVCDetailFormat.
+func NewLDProofVCDetailHandler(p LDProofProvider, opts ...LDProofOpt) *LDProofVCDetailHandler {
+	var resolverOpts []kmssigner.ResolverOpt
+
+	if klp, ok := p.(keyLinkProvider); ok && klp.KeyLinkStore() != nil {
+		resolverOpts = append(resolverOpts, kmssigner.WithSigningKeyLookup(klp.KeyLinkStore()))
+	}
+
+	h := &LDProofVCDetailHandler{
+		vdr:       p.VDRegistry(),
+		resolver:  kmssigner.NewResolver(p.VDRegistry(), p.KMS(), p.Crypto(), resolverOpts...),
+		proofType: Ed25519Signature2018,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.docLoader == nil {
+		h.docLoader = verifiable.CachingJSONLDLoader()
+	}
+
+	return h
+}
+
+// IssuedFormat returns the format of the issued credentials.
+func (h *LDProofVCDetailHandler) IssuedFormat() string {
+	return issuecredential.LDProofVCFormat
+}
+
+// Offer builds the credential detail whose subject has the attributes of the preview.
+func (h *LDProofVCDetailHandler) Offer(preview *issuecredential.PreviewCredential) (*decorator.AttachmentData, error) {
+	if h.issuer == "" {
+		return nil, errors.New("issuer of the offered credential is not defined")
+	}
+
+	subject := map[string]interface{}{}
+	for _, a := range preview.Attributes {
+		subject[a.Name] = a.Value
+	}
+
+	credential, err := json.Marshal(map[string]interface{}{
+		"@context":          []string{baseContext},
+		"type":              []string{vcType},
+		"issuer":            h.issuer,
+		"issuanceDate":      util.NewTime(time.Now()),
+		"credentialSubject": subject,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential: %w", err)
+	}
+
+	return toAttachmentData(&LDProofVCDetail{
+		Credential: credential,
+		Options: &LDProofVCDetailOptions{
+			ProofType:    h.proofType,
+			ProofPurpose: assertionMethodPurpose,
+		},
+	})
+}
+
+// Issue signs the credential of the detail with the proof of requested type using the KMS key
+// of the issuer's DID verification method.
+func (h *LDProofVCDetailHandler) Issue(request *decorator.AttachmentData) (*decorator.AttachmentData, error) {
+	raw, err := request.Fetch()
+	if err != nil {
+		return nil, fmt.Errorf("fetch: %w", err)
+	}
+
+	detail := &LDProofVCDetail{}
+
+	err = json.Unmarshal(raw, detail)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal credential detail: %w", err)
+	}
+
+	if detail.Options == nil || detail.Options.ProofType == "" {
+		return nil, errors.New("proof type is not defined")
+	}
+
+	vc, err := verifiable.ParseCredential(detail.Credential, verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(h.docLoader))
+	if err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+
+	err = h.sign(vc, detail.Options)
+	if err != nil {
+		return nil, fmt.Errorf("sign credential: %w", err)
+	}
+
+	return toAttachmentData(vc)
+}
+
+// Validate verifies the proof of the issued credential.
+func (h *LDProofVCDetailHandler) Validate(credential *decorator.AttachmentData) error {
+	raw, err := credential.Fetch()
+	if err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+
+	_, err = verifiable.ParseCredential(raw,
+		verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(h.vdr).PublicKeyFetcher()),
+		verifiable.WithJSONLDDocumentLoader(h.docLoader))
+
+	return err
+}
+
+func (h *LDProofVCDetailHandler) sign(vc *verifiable.Credential, opts *LDProofVCDetailOptions) error {
+	if vc.Issuer.ID == "" {
+		return errors.New("issuer is not defined")
+	}
+
+	purpose := opts.ProofPurpose
+	if purpose == "" {
+		purpose = assertionMethodPurpose
+	}
+
+	vmID, err := h.verificationMethod(vc.Issuer.ID, purpose)
+	if err != nil {
+		return err
+	}
+
+	var signerOpts []kmssigner.Opt
+
+	if opts.ProofType == BbsBlsSignature2020 {
+		signerOpts = append(signerOpts, kmssigner.WithMultiMessage())
+	}
+
+	s, err := h.resolver.Resolve(vmID, signerOpts...)
+	if err != nil {
+		return err
+	}
+
+	var (
+		signatureSuite          signer.SignatureSuite
+		signatureRepresentation = verifiable.SignatureJWS
+	)
+
+	switch opts.ProofType {
+	case Ed25519Signature2018:
+		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
+	case JSONWebSignature2020:
+		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
+	case BbsBlsSignature2020:
+		signatureSuite = bbsblssignature2020.New(suite.WithSigner(s))
+		signatureRepresentation = verifiable.SignatureProofValue
+	default:
+		return fmt.Errorf("unsupported proof type: %s", opts.ProofType)
+	}
+
+	return vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           opts.ProofType,
+		Suite:                   signatureSuite,
+		SignatureRepresentation: signatureRepresentation,
+		Created:                 opts.Created,
+		VerificationMethod:      vmID,
+		Challenge:               opts.Challenge,
+		Domain:                  opts.Domain,
+		Purpose:                 purpose,
+	}, jsonld.WithDocumentLoader(h.docLoader))
+}
+
+// verificationMethod returns the ID of the first verification method of the issuer's DID for the proof purpose.
+// If there is no such method, the first verification method of the DID is returned.
+func (h *LDProofVCDetailHandler) verificationMethod(issuerDID, purpose string) (string, error) {
+	var relationship did.VerificationRelationship
+
+	switch purpose {
+	case assertionMethodPurpose:
+		relationship = did.AssertionMethod
+	case authenticationPurpose:
+		relationship = did.Authentication
+	default:
+		return "", fmt.Errorf("unsupported proof purpose: %s", purpose)
+	}
+
+	docResolution, err := h.vdr.Resolve(issuerDID)
+	if err != nil {
+		return "", fmt.Errorf("resolve issuer DID: %w", err)
+	}
+
+	doc := docResolution.DIDDocument
+
+	var vmID string
+
+	if vms := doc.VerificationMethods(relationship)[relationship]; len(vms) > 0 {
+		vmID = vms[0].VerificationMethod.ID
+	} else if len(doc.VerificationMethod) > 0 {
+		vmID = doc.VerificationMethod[0].ID
+	} else {
+		return "", fmt.Errorf("no verification method of issuer DID %s", issuerDID)
+	}
+
+	if strings.HasPrefix(vmID, "#") {
+		vmID = doc.ID + vmID
+	}
+
+	return vmID, nil
+}
+
+func toAttachmentData(v interface{}) (*decorator.AttachmentData, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+
+	var data map[string]interface{}
+
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return &decorator.AttachmentData{JSON: data}, nil
+}
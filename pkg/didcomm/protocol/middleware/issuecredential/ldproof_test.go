@@ -0,0 +1,158 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+const issuerDID = "did:example:issuer"
+
+type ldProofProvider struct {
+	vdr    vdrapi.Registry
+	km     kms.KeyManager
+	crypto crypto.Crypto
+}
+
+func (p *ldProofProvider) VDRegistry() vdrapi.Registry { return p.vdr }
+
+func (p *ldProofProvider) KMS() kms.KeyManager { return p.km }
+
+func (p *ldProofProvider) Crypto() crypto.Crypto { return p.crypto }
+
+func newLDProofProvider(t *testing.T) *ldProofProvider {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://custom/master/key/",
+		mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	_, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vm := did.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", issuerDID, pubKey)
+
+	doc := &did.Doc{
+		ID:                 issuerDID,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		AssertionMethod: []did.Verification{
+			*did.NewReferencedVerification(vm, did.AssertionMethod),
+		},
+	}
+
+	return &ldProofProvider{vdr: &mockvdr.MockVDRegistry{ResolveValue: doc}, km: km, crypto: c}
+}
+
+func TestLDProofVCDetailHandler(t *testing.T) {
+	provider := newLDProofProvider(t)
+	handler := NewLDProofVCDetailHandler(provider, WithIssuer(issuerDID))
+
+	require.Equal(t, issuecredential.LDProofVCFormat, handler.IssuedFormat())
+
+	t.Run("offer, issue and validate", func(t *testing.T) {
+		detail, err := handler.Offer(issuecredential.NewCredentialPreview(
+			issuecredential.Attribute{Name: "name", Value: "Alice"}))
+		require.NoError(t, err)
+
+		credential, err := handler.Issue(detail)
+		require.NoError(t, err)
+		require.NoError(t, handler.Validate(credential))
+
+		raw, err := credential.Fetch()
+		require.NoError(t, err)
+
+		vc, err := verifiable.ParseCredential(raw, verifiable.WithDisabledProofCheck(),
+			verifiable.WithJSONLDDocumentLoader(verifiable.CachingJSONLDLoader()))
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, Ed25519Signature2018, vc.Proofs[0]["type"])
+		require.Equal(t, issuerDID+"#key-1", vc.Proofs[0]["verificationMethod"])
+		require.Equal(t, "Alice", vc.Subject.([]verifiable.Subject)[0].CustomFields["name"])
+	})
+
+	t.Run("offer without issuer", func(t *testing.T) {
+		_, err := NewLDProofVCDetailHandler(provider).Offer(issuecredential.NewCredentialPreview())
+		require.EqualError(t, err, "issuer of the offered credential is not defined")
+	})
+
+	t.Run("issue errors", func(t *testing.T) {
+		detail, err := handler.Offer(issuecredential.NewCredentialPreview())
+		require.NoError(t, err)
+
+		withOptions := func(options map[string]interface{}) *decorator.AttachmentData {
+			data := map[string]interface{}{}
+			for k, v := range detail.JSON.(map[string]interface{}) {
+				data[k] = v
+			}
+
+			data["options"] = options
+
+			return &decorator.AttachmentData{JSON: data}
+		}
+
+		_, err = handler.Issue(withOptions(nil))
+		require.EqualError(t, err, "proof type is not defined")
+
+		_, err = handler.Issue(withOptions(map[string]interface{}{"proofType": "UnknownSignature"}))
+		require.EqualError(t, err, "sign credential: unsupported proof type: UnknownSignature")
+
+		_, err = handler.Issue(withOptions(map[string]interface{}{
+			"proofType":    Ed25519Signature2018,
+			"proofPurpose": "capabilityInvocation",
+		}))
+		require.EqualError(t, err, "sign credential: unsupported proof purpose: capabilityInvocation")
+
+		_, err = handler.Issue(&decorator.AttachmentData{JSON: map[string]interface{}{
+			"credential": map[string]interface{}{},
+			"options":    map[string]interface{}{"proofType": Ed25519Signature2018},
+		}})
+		require.Contains(t, err.Error(), "parse credential")
+
+		_, err = handler.Issue(&decorator.AttachmentData{})
+		require.Contains(t, err.Error(), "fetch")
+	})
+
+	t.Run("issuer DID not resolved", func(t *testing.T) {
+		detail, err := handler.Offer(issuecredential.NewCredentialPreview())
+		require.NoError(t, err)
+
+		_, err = NewLDProofVCDetailHandler(&ldProofProvider{
+			vdr:    &mockvdr.MockVDRegistry{ResolveErr: errors.New("not found")},
+			km:     provider.km,
+			crypto: provider.crypto,
+		}).Issue(detail)
+		require.EqualError(t, err, "sign credential: resolve issuer DID: not found")
+	})
+
+	t.Run("invalid credential", func(t *testing.T) {
+		raw, err := json.Marshal(map[string]interface{}{"credentialSubject": "invalid"})
+		require.NoError(t, err)
+
+		require.Error(t, handler.Validate(&decorator.AttachmentData{JSON: json.RawMessage(raw)}))
+	})
+}
@@ -53,13 +53,18 @@ func SaveCredentials(p Provider) issuecredential.Middleware {
 				return fmt.Errorf("decode: %w", err)
 			}
 
-			credentials, err := toVerifiableCredentials(vdr, credential.CredentialsAttach)
+			if len(credential.CredentialsAttach) == 0 {
+				return errors.New("credentials were not provided")
+			}
+
+			credentials, results, err := toVerifiableCredentials(vdr, credential.CredentialsAttach,
+				metadata.CredentialAcceptance())
 			if err != nil {
 				return fmt.Errorf("to verifiable credentials: %w", err)
 			}
 
 			if len(credentials) == 0 {
-				return errors.New("credentials were not provided")
+				return errors.New("none of the credentials was accepted")
 			}
 
 			var names []string
@@ -73,19 +78,32 @@ func SaveCredentials(p Provider) issuecredential.Middleware {
 				return errors.New("myDID or theirDID is absent")
 			}
 
-			for i, credential := range credentials {
-				names = append(names, getName(i, credential.ID, metadata))
+			for i := range results {
+				credential, ok := credentials[i]
+				if !ok {
+					continue
+				}
 
-				err := store.SaveCredential(names[i], credential,
+				name := results[i].Name
+				if name == "" {
+					name = getName(i, credential.ID, metadata)
+				}
+
+				err := store.SaveCredential(name, credential,
 					storeverifiable.WithMyDID(myDID),
 					storeverifiable.WithTheirDID(theirDID),
 				)
 				if err != nil {
 					return fmt.Errorf("save credential: %w", err)
 				}
+
+				names = append(names, name)
+				results[i].Name = name
+				results[i].Accepted = true
 			}
 
 			properties[namesKey] = names
+			properties[issuecredential.CredentialResultsPropKey] = results
 
 			return next.Handle(metadata)
 		})
@@ -105,24 +123,49 @@ func getName(idx int, id string, metadata issuecredential.Metadata) string {
 	return uuid.New().String()
 }
 
-func toVerifiableCredentials(v vdrapi.Registry, attachments []decorator.Attachment) ([]*verifiable.Credential, error) {
-	var credentials []*verifiable.Credential
+// getAcceptance returns the Holder's decision about the credential attachment.
+// The credentials without a decision are accepted.
+func getAcceptance(attachID string,
+	acceptance []issuecredential.CredentialAcceptance) issuecredential.CredentialAcceptance {
+	for _, a := range acceptance {
+		if a.AttachID == attachID {
+			return a
+		}
+	}
+
+	return issuecredential.CredentialAcceptance{AttachID: attachID, Accept: true}
+}
+
+// toVerifiableCredentials parses the credentials accepted by the Holder. The parsed credentials are keyed
+// by the index of their attachment.
+func toVerifiableCredentials(v vdrapi.Registry, attachments []decorator.Attachment,
+	acceptance []issuecredential.CredentialAcceptance) (map[int]*verifiable.Credential, []issuecredential.CredentialResult, error) {
+	credentials := map[int]*verifiable.Credential{}
+	results := make([]issuecredential.CredentialResult, len(attachments))
 
 	for i := range attachments {
+		a := getAcceptance(attachments[i].ID, acceptance)
+
+		results[i] = issuecredential.CredentialResult{AttachID: attachments[i].ID, Name: a.Name}
+
+		if !a.Accept {
+			continue
+		}
+
 		rawVC, err := attachments[i].Data.Fetch()
 		if err != nil {
-			return nil, fmt.Errorf("fetch: %w", err)
+			return nil, nil, fmt.Errorf("fetch: %w", err)
 		}
 
 		vc, err := verifiable.ParseCredential(rawVC, verifiable.WithPublicKeyFetcher(
 			verifiable.NewVDRKeyResolver(v).PublicKeyFetcher(),
 		))
 		if err != nil {
-			return nil, fmt.Errorf("new credential: %w", err)
+			return nil, nil, fmt.Errorf("new credential: %w", err)
 		}
 
-		credentials = append(credentials, vc)
+		credentials[i] = vc
 	}
 
-	return credentials, nil
+	return credentials, results, nil
 }
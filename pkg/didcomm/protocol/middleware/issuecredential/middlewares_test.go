@@ -85,6 +85,7 @@ func TestSaveCredentials(t *testing.T) {
 	t.Run("Marshal credentials error", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialAcceptance().Return(nil)
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
 			Type: issuecredential.IssueCredentialMsgType,
 			CredentialsAttach: []decorator.Attachment{
@@ -108,6 +109,7 @@ func TestSaveCredentials(t *testing.T) {
 	t.Run("Invalid credentials", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialAcceptance().Return(nil)
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
 			Type: issuecredential.IssueCredentialMsgType,
 			CredentialsAttach: []decorator.Attachment{
@@ -129,6 +131,7 @@ func TestSaveCredentials(t *testing.T) {
 
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialAcceptance().Return(nil)
 		metadata.EXPECT().CredentialNames().Return([]string{vcName}).Times(2)
 		metadata.EXPECT().Properties().Return(map[string]interface{}{
 			myDIDKey:    myDIDKey,
@@ -155,6 +158,7 @@ func TestSaveCredentials(t *testing.T) {
 	t.Run("No DIDs", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialAcceptance().Return(nil)
 		metadata.EXPECT().Properties().Return(map[string]interface{}{})
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
 			Type: issuecredential.IssueCredentialMsgType,
@@ -180,6 +184,7 @@ func TestSaveCredentials(t *testing.T) {
 
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialAcceptance().Return(nil)
 		metadata.EXPECT().CredentialNames().Return([]string{vcName}).Times(2)
 		metadata.EXPECT().Properties().Return(props)
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
@@ -212,6 +217,7 @@ func TestSaveCredentials(t *testing.T) {
 
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialAcceptance().Return(nil)
 		metadata.EXPECT().CredentialNames().Return([]string{})
 		metadata.EXPECT().Properties().Return(props)
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
@@ -281,6 +287,7 @@ func TestSaveCredentials(t *testing.T) {
 
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialAcceptance().Return(nil)
 		metadata.EXPECT().CredentialNames().Return([]string{vcName}).Times(2)
 		metadata.EXPECT().Properties().Return(props)
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
@@ -312,4 +319,71 @@ func TestSaveCredentials(t *testing.T) {
 		require.NoError(t, SaveCredentials(provider)(next).Handle(metadata))
 		require.Equal(t, props["names"], []string{vcName})
 	})
+
+	t.Run("Success (one of credentials rejected)", func(t *testing.T) {
+		const vcName = "vc-name"
+
+		props := map[string]interface{}{
+			myDIDKey:    myDIDKey,
+			theirDIDKey: theirDIDKey,
+		}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialAcceptance().Return([]issuecredential.CredentialAcceptance{
+			{AttachID: "1", Name: vcName, Accept: true},
+			{AttachID: "2", Accept: false},
+		})
+		metadata.EXPECT().Properties().Return(props)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
+			Type: issuecredential.IssueCredentialMsgType,
+			CredentialsAttach: []decorator.Attachment{
+				{ID: "1", Data: decorator.AttachmentData{JSON: getBaseCredential()}},
+				{ID: "2", Data: decorator.AttachmentData{JSON: map[string]interface{}{}}},
+			},
+		}))
+
+		verifiableStore := mockstore.NewMockStore(ctrl)
+		verifiableStore.EXPECT().SaveCredential(vcName, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().VDRegistry().Return(nil).AnyTimes()
+		provider.EXPECT().VerifiableStore().Return(verifiableStore)
+
+		require.NoError(t, SaveCredentials(provider)(next).Handle(metadata))
+		require.Equal(t, []string{vcName}, props["names"])
+		require.Equal(t, []issuecredential.CredentialResult{
+			{AttachID: "1", Name: vcName, Accepted: true},
+			{AttachID: "2"},
+		}, props[issuecredential.CredentialResultsPropKey])
+	})
+
+	t.Run("None of credentials accepted", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialAcceptance().Return([]issuecredential.CredentialAcceptance{
+			{AttachID: "1", Accept: false},
+		})
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
+			Type: issuecredential.IssueCredentialMsgType,
+			CredentialsAttach: []decorator.Attachment{
+				{ID: "1", Data: decorator.AttachmentData{JSON: getBaseCredential()}},
+			},
+		}))
+
+		err := SaveCredentials(provider)(next).Handle(metadata)
+		require.EqualError(t, err, "none of the credentials was accepted")
+	})
+}
+
+func getBaseCredential() *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      "http://example.edu/credentials/1873",
+		Types:   []string{"VerifiableCredential"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  util.NewTime(time.Date(2010, time.January, 1, 19, 23, 24, 0, time.UTC)),
+	}
 }
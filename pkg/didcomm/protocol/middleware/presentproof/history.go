@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+)
+
+const piidKey = "piid"
+
+var logger = log.New("aries-framework/presentproof/middleware")
+
+// historyEvents are the credential history events of the states of the protocol.
+var historyEvents = map[string]history.EventType{ // nolint: gochecknoglobals
+	"request-sent":          history.EventRequested,
+	"request-received":      history.EventRequested,
+	"presentation-sent":     history.EventPresented,
+	"presentation-received": history.EventVerified,
+}
+
+// RecordHistory the helper function for the present proof protocol which records the requests, the presentations
+// sent and the presentations received in the credential history. The events are recorded once the next handlers
+// succeeded (e.g. VerifyPresentation), a failure to record them is logged and does not stop the protocol.
+func RecordHistory(store *history.Store) presentproof.Middleware {
+	return func(next presentproof.Handler) presentproof.Handler {
+		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+			if err := next.Handle(metadata); err != nil {
+				return err
+			}
+
+			eventType, ok := historyEvents[metadata.StateName()]
+			if !ok {
+				return nil
+			}
+
+			properties := metadata.Properties()
+
+			// nolint: errcheck
+			myDID, _ := properties[myDIDKey].(string)
+			// nolint: errcheck
+			theirDID, _ := properties[theirDIDKey].(string)
+			// nolint: errcheck
+			piid, _ := properties[piidKey].(string)
+
+			event := &history.Event{
+				Type:     eventType,
+				Protocol: presentproof.Name,
+				PIID:     piid,
+				MyDID:    myDID,
+				TheirDID: theirDID,
+			}
+
+			if eventType == history.EventPresented || eventType == history.EventVerified {
+				event.CredentialIDs = presentedCredentialIDs(metadata)
+			}
+
+			if err := store.Record(event); err != nil {
+				logger.Warnf("failed to record %s event of %s in credential history: %s", eventType, piid, err)
+			}
+
+			return nil
+		})
+	}
+}
+
+// presentedCredentialIDs returns the IDs of the credentials of the presentations provided by the user
+// (through the Continue function) or received. The credentials without ID and the JWT credentials are ignored.
+func presentedCredentialIDs(metadata presentproof.Metadata) []string {
+	presentation := metadata.Presentation()
+	if presentation == nil {
+		presentation = &presentproof.Presentation{}
+
+		if err := metadata.Message().Decode(presentation); err != nil {
+			return nil
+		}
+	}
+
+	var ids []string
+
+	for i := range presentation.PresentationsAttach {
+		raw, err := presentation.PresentationsAttach[i].Data.Fetch()
+		if err != nil {
+			continue
+		}
+
+		vp := struct {
+			Credentials json.RawMessage `json:"verifiableCredential"`
+		}{}
+
+		if err = json.Unmarshal(raw, &vp); err != nil || len(vp.Credentials) == 0 {
+			continue
+		}
+
+		var credentials []json.RawMessage
+
+		// a single credential is not wrapped in an array
+		if err = json.Unmarshal(vp.Credentials, &credentials); err != nil {
+			credentials = []json.RawMessage{vp.Credentials}
+		}
+
+		for _, credential := range credentials {
+			vc := struct {
+				ID string `json:"id"`
+			}{}
+
+			if err = json.Unmarshal(credential, &vc); err == nil && vc.ID != "" {
+				ids = append(ids, vc.ID)
+			}
+		}
+	}
+
+	return ids
+}
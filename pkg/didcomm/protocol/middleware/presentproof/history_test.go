@@ -0,0 +1,147 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+)
+
+func TestRecordHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeProvider := mockstore.NewMockStoreProvider()
+
+	store, err := history.New(&mockprovider.Provider{
+		StorageProviderValue:              storeProvider,
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	next := presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+		return nil
+	})
+
+	const vcID = "http://example.edu/credentials/1872"
+
+	t.Run("Ignores the states without event", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("done")
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+
+		events, err := store.Timeline(nil)
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+
+	t.Run("Records the request", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().Properties().Return(map[string]interface{}{
+			myDIDKey: "did:example:my", theirDIDKey: "did:example:their", piidKey: "piid",
+		})
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+
+		events, err := store.Timeline(&history.Filter{Type: history.EventRequested})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, presentproof.Name, events[0].Protocol)
+		require.Equal(t, "piid", events[0].PIID)
+	})
+
+	t.Run("Records the verified presentation", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Properties().Return(map[string]interface{}{piidKey: "piid"})
+		metadata.EXPECT().Presentation().Return(nil)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.Presentation{
+			Type: presentproof.PresentationMsgType,
+			PresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{JSON: map[string]interface{}{
+					"type":                 "VerifiablePresentation",
+					"verifiableCredential": []interface{}{map[string]interface{}{"id": vcID}, "eyJhbGciOiJub25lIn0"},
+				}}},
+				{Data: decorator.AttachmentData{JSON: map[string]interface{}{
+					"verifiableCredential": map[string]interface{}{"id": "http://example.edu/credentials/58473"},
+				}}},
+				{Data: decorator.AttachmentData{JSON: map[string]interface{}{"type": "VerifiablePresentation"}}},
+				{Data: decorator.AttachmentData{Base64: "invalid"}},
+			},
+		}))
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+
+		events, err := store.Timeline(&history.Filter{Type: history.EventVerified})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, []string{vcID, "http://example.edu/credentials/58473"}, events[0].CredentialIDs)
+
+		events, err = store.Timeline(&history.Filter{CredentialID: vcID})
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+	})
+
+	t.Run("Records the presentation sent", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("presentation-sent")
+		metadata.EXPECT().Properties().Return(map[string]interface{}{piidKey: "piid-2"})
+		metadata.EXPECT().Presentation().Return(&presentproof.Presentation{
+			PresentationsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{JSON: map[string]interface{}{
+				"verifiableCredential": []interface{}{map[string]interface{}{"id": vcID}},
+			}}}},
+		})
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+
+		events, err := store.Timeline(&history.Filter{Type: history.EventPresented})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, []string{vcID}, events[0].CredentialIDs)
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().Presentation().Return(nil)
+		metadata.EXPECT().Message().Return(service.DIDCommMsgMap{"@type": map[int]int{}})
+
+		require.Empty(t, presentedCredentialIDs(metadata))
+	})
+
+	t.Run("Record error does not stop the protocol", func(t *testing.T) {
+		storeProvider.Store.ErrPut = errors.New("put error")
+		defer func() { storeProvider.Store.ErrPut = nil }()
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("request-sent")
+		metadata.EXPECT().Properties().Return(map[string]interface{}{})
+
+		require.NoError(t, RecordHistory(store)(next).Handle(metadata))
+	})
+
+	t.Run("Next handler error", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+
+		err := RecordHistory(store)(presentproof.HandlerFunc(func(presentproof.Metadata) error {
+			return errors.New("next error")
+		})).Handle(metadata)
+		require.EqualError(t, err, "next error")
+	})
+}
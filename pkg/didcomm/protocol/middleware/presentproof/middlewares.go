@@ -23,6 +23,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmssigner"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -54,11 +56,32 @@ type Provider interface {
 	Crypto() crypto.Crypto
 }
 
+// OptSP represents option function for the SavePresentation middleware.
+type OptSP func(o *spOptions)
+
+// WithTrustRegistry sets the trust registry consulted to check issuers of the credentials
+// of received presentations. Presentations having credentials of not accredited issuers are rejected.
+func WithTrustRegistry(registry trustregistry.Registry) OptSP {
+	return func(o *spOptions) {
+		o.trustRegistry = registry
+	}
+}
+
+type spOptions struct {
+	trustRegistry trustregistry.Registry
+}
+
 // SavePresentation the helper function for the present proof protocol which saves the presentations.
-func SavePresentation(p Provider) presentproof.Middleware {
+func SavePresentation(p Provider, opts ...OptSP) presentproof.Middleware {
 	vdr := p.VDRegistry()
 	store := p.VerifiableStore()
 
+	options := &spOptions{}
+
+	for i := range opts {
+		opts[i](options)
+	}
+
 	return func(next presentproof.Handler) presentproof.Handler {
 		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
 			if metadata.StateName() != stateNamePresentationReceived {
@@ -70,7 +93,7 @@ func SavePresentation(p Provider) presentproof.Middleware {
 				return fmt.Errorf("decode: %w", err)
 			}
 
-			presentations, err := toVerifiablePresentation(vdr, presentation.PresentationsAttach)
+			presentations, err := toVerifiablePresentation(vdr, presentation.PresentationsAttach, options)
 			if err != nil {
 				return fmt.Errorf("to verifiable presentation: %w", err)
 			}
@@ -86,17 +109,20 @@ func SavePresentation(p Provider) presentproof.Middleware {
 			myDID, _ := properties[myDIDKey].(string)
 			// nolint: errcheck
 			theirDID, _ := properties[theirDIDKey].(string)
-			if myDID == "" || theirDID == "" {
+
+			var opts []storeverifiable.Opt
+
+			switch {
+			case myDID != "" && theirDID != "":
+				opts = append(opts, storeverifiable.WithMyDID(myDID), storeverifiable.WithTheirDID(theirDID))
+			case !isConnectionless(metadata.RequestPresentation()):
 				return errors.New("myDID or theirDID is absent")
 			}
 
 			for i, presentation := range presentations {
 				names = append(names, getName(i, presentation.ID, metadata))
 
-				err := store.SavePresentation(names[i], presentation,
-					storeverifiable.WithMyDID(myDID),
-					storeverifiable.WithTheirDID(theirDID),
-				)
+				err := store.SavePresentation(names[i], presentation, opts...)
 				if err != nil {
 					return fmt.Errorf("save presentation: %w", err)
 				}
@@ -109,6 +135,11 @@ func SavePresentation(p Provider) presentproof.Middleware {
 	}
 }
 
+// isConnectionless returns true if the presentation was requested without a connection.
+func isConnectionless(req *presentproof.RequestPresentation) bool {
+	return req != nil && req.Service != nil
+}
+
 type presentationExchangePayload struct {
 	Challenge              string                           `json:"challenge"`
 	Domain                 string                           `json:"domain"`
@@ -152,6 +183,11 @@ func AddBBSProofFn(p Provider) func(presentation *verifiable.Presentation) error
 			return err
 		}
 
+		signer, err := kmssigner.NewKMSSigner(km, cr, kid, kmssigner.WithMultiMessage())
+		if err != nil {
+			return err
+		}
+
 		_, didKey := fingerprint.CreateDIDKeyByCode(fingerprint.BLS12381g2PubKeyMultiCodec, pubKey)
 
 		presentation.Context = append(presentation.Context, bbsContext)
@@ -159,7 +195,7 @@ func AddBBSProofFn(p Provider) func(presentation *verifiable.Presentation) error
 		return presentation.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
 			SignatureType:           "BbsBlsSignature2020",
 			SignatureRepresentation: verifiable.SignatureProofValue,
-			Suite:                   bbsblssignature2020.New(suite.WithSigner(newBBSSigner(km, cr, kid))),
+			Suite:                   bbsblssignature2020.New(suite.WithSigner(signer)),
 			VerificationMethod:      didKey,
 		}, jsonld.WithDocumentLoader(bbsLoader))
 	}
@@ -340,21 +376,28 @@ func getName(idx int, id string, metadata presentproof.Metadata) string {
 	return uuid.New().String()
 }
 
-func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.Attachment) ([]*verifiable.Presentation, error) {
+func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.Attachment,
+	options *spOptions) ([]*verifiable.Presentation, error) {
 	var presentations []*verifiable.Presentation
 
+	parseOpts := []verifiable.PresentationOpt{
+		verifiable.WithPresPublicKeyFetcher(
+			verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher(),
+		),
+		verifiable.WithPresJSONLDDocumentLoader(presexch.CachingJSONLDLoader()),
+	}
+
+	if options.trustRegistry != nil {
+		parseOpts = append(parseOpts, verifiable.WithPresTrustRegistry(options.trustRegistry))
+	}
+
 	for i := range data {
 		raw, err := data[i].Data.Fetch()
 		if err != nil {
 			return nil, fmt.Errorf("fetch: %w", err)
 		}
 
-		presentation, err := verifiable.ParsePresentation(raw,
-			verifiable.WithPresPublicKeyFetcher(
-				verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher(),
-			),
-			verifiable.WithPresJSONLDDocumentLoader(presexch.CachingJSONLDLoader()),
-		)
+		presentation, err := verifiable.ParsePresentation(raw, parseOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("parse presentation: %w", err)
 		}
@@ -365,38 +408,6 @@ func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.Attachment)
 	return presentations, nil
 }
 
-type bbsSigner struct {
-	km    kms.KeyManager
-	cr    crypto.Crypto
-	keyID string
-}
-
-func newBBSSigner(km kms.KeyManager, cr crypto.Crypto, keyID string) *bbsSigner {
-	return &bbsSigner{km: km, cr: cr, keyID: keyID}
-}
-
-func (s *bbsSigner) Sign(data []byte) ([]byte, error) {
-	kh, err := s.km.Get(s.keyID)
-	if err != nil {
-		return nil, err
-	}
-
-	return s.cr.SignMulti(s.textToLines(string(data)), kh)
-}
-
-func (s *bbsSigner) textToLines(txt string) [][]byte {
-	lines := strings.Split(txt, "\n")
-	linesBytes := make([][]byte, 0, len(lines))
-
-	for i := range lines {
-		if strings.TrimSpace(lines[i]) != "" {
-			linesBytes = append(linesBytes, []byte(lines[i]))
-		}
-	}
-
-	return linesBytes
-}
-
 // TODO: context should not be loaded here, the loader should be defined once for the whole system.
 func bbsJSONLDDocumentLoader() (*jld.CachingDocumentLoader, error) {
 	loader := presexch.CachingJSONLDLoader()
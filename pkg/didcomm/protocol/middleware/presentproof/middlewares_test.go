@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
 	mocksvdr "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/framework/aries/api/vdr"
@@ -174,6 +175,7 @@ func TestSavePresentation(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
 		metadata.EXPECT().Properties().Return(map[string]interface{}{})
+		metadata.EXPECT().RequestPresentation().Return(&presentproof.RequestPresentation{})
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.Presentation{
 			Type: presentproof.PresentationMsgType,
 			PresentationsAttach: []decorator.Attachment{
@@ -192,6 +194,63 @@ func TestSavePresentation(t *testing.T) {
 		require.EqualError(t, SavePresentation(provider)(next).Handle(metadata), "myDID or theirDID is absent")
 	})
 
+	t.Run("Success (connection-less)", func(t *testing.T) {
+		props := map[string]interface{}{}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().PresentationNames().Return(nil)
+		metadata.EXPECT().Properties().Return(props)
+		metadata.EXPECT().RequestPresentation().Return(&presentproof.RequestPresentation{
+			Service: &decorator.Service{ServiceEndpoint: "http://example.com"},
+		})
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.Presentation{
+			Type: presentproof.PresentationMsgType,
+			PresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{JSON: &verifiable.Presentation{
+					Context: []string{"https://www.w3.org/2018/credentials/v1"},
+					Type:    []string{"VerifiablePresentation"},
+				}}},
+			},
+		}))
+
+		verifiableStore := mocksstore.NewMockStore(ctrl)
+		verifiableStore.EXPECT().SavePresentation(gomock.Any(), gomock.Any()).Return(nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().VDRegistry().Return(mocksvdr.NewMockRegistry(ctrl)).AnyTimes()
+		provider.EXPECT().VerifiableStore().Return(verifiableStore)
+
+		require.NoError(t, SavePresentation(provider)(next).Handle(metadata))
+		require.Len(t, props["names"], 1)
+	})
+
+	t.Run("Issuer not accredited", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.Presentation{
+			Type: presentproof.PresentationMsgType,
+			PresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(vpJWS))}},
+			},
+		}))
+
+		registry := mocksvdr.NewMockRegistry(ctrl)
+		registry.EXPECT().Resolve("did:example:ebfeb1f712ebc6f1c276e12ec21").
+			Return(&did.DocResolution{DIDDocument: &did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}}}, nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
+		provider.EXPECT().VerifiableStore().Return(mocksstore.NewMockStore(ctrl))
+
+		trustRegistry := trustregistry.RegistryFunc(func(issuerID string) (*trustregistry.IssuerMetadata, error) {
+			return &trustregistry.IssuerMetadata{DID: issuerID, Status: trustregistry.StatusSuspended}, nil
+		})
+
+		err := SavePresentation(provider, WithTrustRegistry(trustRegistry))(next).Handle(metadata)
+		require.ErrorIs(t, err, trustregistry.ErrIssuerNotAccredited)
+	})
+
 	t.Run("Success (no ID)", func(t *testing.T) {
 		vpJWSNoID := "eyJhbGciOiJFZERTQSIsImtpZCI6IiIsInR5cCI6IkpXVCJ9.eyJhdWQiOiJkaWQ6ZXhhbXBsZTo0YTU3NTQ2OTczNDM2ZjZmNmM0YTRhNTc1NzMiLCJpc3MiOiJkaWQ6ZXhhbXBsZTplYmZlYjFmNzEyZWJjNmYxYzI3NmUxMmVjMjEiLCJ2cCI6eyJAY29udGV4dCI6WyJodHRwczovL3d3dy53My5vcmcvMjAxOC9jcmVkZW50aWFscy92MSIsImh0dHBzOi8vd3d3LnczLm9yZy8yMDE4L2NyZWRlbnRpYWxzL2V4YW1wbGVzL3YxIl0sInR5cGUiOlsiVmVyaWZpYWJsZVByZXNlbnRhdGlvbiIsIlVuaXZlcnNpdHlEZWdyZWVDcmVkZW50aWFsIl0sInZlcmlmaWFibGVDcmVkZW50aWFsIjpbeyJAY29udGV4dCI6WyJodHRwczovL3d3dy53My5vcmcvMjAxOC9jcmVkZW50aWFscy92MSIsImh0dHBzOi8vd3d3LnczLm9yZy8yMDE4L2NyZWRlbnRpYWxzL2V4YW1wbGVzL3YxIl0sImNyZWRlbnRpYWxTY2hlbWEiOltdLCJjcmVkZW50aWFsU3ViamVjdCI6eyJkZWdyZWUiOnsidHlwZSI6IkJhY2hlbG9yRGVncmVlIiwidW5pdmVyc2l0eSI6Ik1JVCJ9LCJpZCI6ImRpZDpleGFtcGxlOmViZmViMWY3MTJlYmM2ZjFjMjc2ZTEyZWMyMSIsIm5hbWUiOiJKYXlkZW4gRG9lIiwic3BvdXNlIjoiZGlkOmV4YW1wbGU6YzI3NmUxMmVjMjFlYmZlYjFmNzEyZWJjNmYxIn0sImV4cGlyYXRpb25EYXRlIjoiMjAyMC0wMS0wMVQxOToyMzoyNFoiLCJpZCI6Imh0dHA6Ly9leGFtcGxlLmVkdS9jcmVkZW50aWFscy8xODcyIiwiaXNzdWFuY2VEYXRlIjoiMjAxMC0wMS0wMVQxOToyMzoyNFoiLCJpc3N1ZXIiOnsiaWQiOiJkaWQ6ZXhhbXBsZTo3NmUxMmVjNzEyZWJjNmYxYzIyMWViZmViMWYiLCJuYW1lIjoiRXhhbXBsZSBVbml2ZXJzaXR5In0sInJlZmVyZW5jZU51bWJlciI6ODMyOTQ4NDcsInR5cGUiOlsiVmVyaWZpYWJsZUNyZWRlbnRpYWwiLCJVbml2ZXJzaXR5RGVncmVlQ3JlZGVudGlhbCJdfV19fQ.VaULMC_bFEI46jPLX7T8BW9liQ88JfCu0BeAxUkEIqjk-K2GFAbrP1WOJyJIXZZ-5J_nM7LNZX6mxbmhcj--Dw" //nolint:lll
 
@@ -564,3 +623,221 @@ func TestPresentationDefinition(t *testing.T) {
 		require.Nil(t, PresentationDefinition(provider, WithAddProofFn(AddBBSProofFn(provider)))(next).Handle(metadata))
 	})
 }
+
+func TestVerifyPresentation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registry := mocksvdr.NewMockRegistry(ctrl)
+	registry.EXPECT().Resolve("did:example:ebfeb1f712ebc6f1c276e12ec21").Return(
+		&did.DocResolution{DIDDocument: &did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}}}, nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
+
+	var nextCalled bool
+
+	next := presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+		nextCalled = true
+
+		return nil
+	})
+
+	presentationMsg := func(data decorator.AttachmentData) service.DIDCommMsgMap {
+		return service.NewDIDCommMsgMap(presentproof.Presentation{
+			Type:                presentproof.PresentationMsgType,
+			PresentationsAttach: []decorator.Attachment{{Data: data}},
+		})
+	}
+
+	unsignedVP := map[string]interface{}{
+		"@context": []string{"https://www.w3.org/2018/credentials/v1"},
+		"type":     []string{"VerifiablePresentation"},
+	}
+
+	t.Run("Ignores processing", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("state-name")
+		require.NoError(t, VerifyPresentation(provider)(next).Handle(metadata))
+	})
+
+	t.Run("Presentations not provided", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.Presentation{
+			Type: presentproof.PresentationMsgType,
+		}))
+
+		err := VerifyPresentation(provider)(next).Handle(metadata)
+		require.EqualError(t, err, "presentations were not provided")
+	})
+
+	t.Run("Invalid definition", func(t *testing.T) {
+		ID := uuid.New().String()
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(presentationMsg(decorator.AttachmentData{JSON: unsignedVP}))
+		metadata.EXPECT().RequestPresentation().Return(&presentproof.RequestPresentation{
+			Formats: []presentproof.Format{{AttachID: ID, Format: peDefinitionFormat}},
+			RequestPresentationsAttach: []decorator.Attachment{{
+				ID:   ID,
+				Data: decorator.AttachmentData{Base64: "ew=="},
+			}},
+		})
+
+		err := VerifyPresentation(provider)(next).Handle(metadata)
+		require.EqualError(t, err, "requested definition: unmarshal definition: unexpected end of JSON input")
+	})
+
+	t.Run("Verified (unsigned presentation)", func(t *testing.T) {
+		nextCalled = false
+		props := map[string]interface{}{}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(presentationMsg(decorator.AttachmentData{JSON: unsignedVP}))
+		metadata.EXPECT().RequestPresentation().Return(nil)
+		metadata.EXPECT().Properties().Return(props)
+
+		require.NoError(t, VerifyPresentation(provider)(next).Handle(metadata))
+		require.True(t, nextCalled)
+
+		result, ok := props[presentproof.VerificationResultPropKey].(*VerificationResult)
+		require.True(t, ok)
+		require.True(t, result.Verified)
+		require.Len(t, result.Presentations, 1)
+		require.Equal(t, []*verifiable.CheckResult{{
+			Check:  verifiable.CheckSignature,
+			Status: verifiable.CheckSkipped,
+			Code:   verifiable.CodeProofMissing,
+		}, {
+			Check:  CheckPresentationDefinition,
+			Status: verifiable.CheckSkipped,
+			Code:   CodeDefinitionNotRequested,
+		}}, result.Presentations[0].Checks)
+	})
+
+	t.Run("Definition not satisfied", func(t *testing.T) {
+		nextCalled = false
+		props := map[string]interface{}{}
+		ID := uuid.New().String()
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(presentationMsg(decorator.AttachmentData{JSON: unsignedVP}))
+		metadata.EXPECT().RequestPresentation().Return(&presentproof.RequestPresentation{
+			Formats: []presentproof.Format{{AttachID: ID, Format: peDefinitionFormat}},
+			RequestPresentationsAttach: []decorator.Attachment{{
+				ID: ID,
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{
+					"presentation_definition": &presexch.PresentationDefinition{
+						ID: uuid.New().String(),
+						InputDescriptors: []*presexch.InputDescriptor{{
+							ID:     uuid.New().String(),
+							Schema: []*presexch.Schema{{URI: schemaURI}},
+						}},
+					},
+				}},
+			}},
+		})
+		metadata.EXPECT().Properties().Return(props)
+
+		err := VerifyPresentation(provider)(next).Handle(metadata)
+		require.ErrorIs(t, err, ErrPresentationNotVerified)
+		require.False(t, nextCalled)
+
+		result, ok := props[presentproof.VerificationResultPropKey].(*VerificationResult)
+		require.True(t, ok)
+		require.False(t, result.Verified)
+
+		check := result.Presentations[0].Checks[1]
+		require.Equal(t, CheckPresentationDefinition, check.Check)
+		require.Equal(t, verifiable.CheckFailed, check.Status)
+		require.Equal(t, CodeDefinitionNotSatisfied, check.Code)
+	})
+
+	t.Run("Not verified credential", func(t *testing.T) {
+		props := map[string]interface{}{}
+
+		vp := map[string]interface{}{
+			"@context": []string{"https://www.w3.org/2018/credentials/v1"},
+			"type":     []string{"VerifiablePresentation"},
+			"id":       "http://example.edu/presentations/1",
+			"verifiableCredential": []interface{}{map[string]interface{}{
+				"@context":          []string{"https://www.w3.org/2018/credentials/v1"},
+				"id":                "http://example.edu/credentials/1872",
+				"type":              []string{"VerifiableCredential"},
+				"issuer":            "did:example:76e12ec712ebc6f1c221ebfeb1f",
+				"issuanceDate":      "2010-01-01T19:23:24Z",
+				"expirationDate":    "2020-01-01T19:23:24Z",
+				"credentialSubject": map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+			}},
+		}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(presentationMsg(decorator.AttachmentData{JSON: vp}))
+		metadata.EXPECT().RequestPresentation().Return(nil)
+		metadata.EXPECT().Properties().Return(props)
+
+		err := VerifyPresentation(provider)(next).Handle(metadata)
+		require.ErrorIs(t, err, ErrPresentationNotVerified)
+
+		result, ok := props[presentproof.VerificationResultPropKey].(*VerificationResult)
+		require.True(t, ok)
+		require.False(t, result.Verified)
+		require.Equal(t, "http://example.edu/presentations/1", result.Presentations[0].ID)
+		require.Len(t, result.Presentations[0].Credentials, 1)
+
+		credential := result.Presentations[0].Credentials[0]
+		require.False(t, credential.Verified)
+		require.Equal(t, "http://example.edu/credentials/1872", credential.ID)
+		require.Equal(t, verifiable.CodeProofMissing, credential.Checks[0].Code)
+		require.Equal(t, verifiable.CodeCredentialExpired, credential.Checks[1].Code)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		props := map[string]interface{}{}
+
+		vp := map[string]interface{}{}
+		for k, v := range unsignedVP {
+			vp[k] = v
+		}
+
+		vp["proof"] = map[string]interface{}{"type": "UnknownSignature2030"}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(presentationMsg(decorator.AttachmentData{JSON: vp}))
+		metadata.EXPECT().RequestPresentation().Return(nil)
+		metadata.EXPECT().Properties().Return(props)
+
+		err := VerifyPresentation(provider)(next).Handle(metadata)
+		require.ErrorIs(t, err, ErrPresentationNotVerified)
+
+		result, ok := props[presentproof.VerificationResultPropKey].(*VerificationResult)
+		require.True(t, ok)
+		require.Equal(t, verifiable.CheckSignature, result.Presentations[0].Checks[0].Check)
+		require.Equal(t, verifiable.CheckFailed, result.Presentations[0].Checks[0].Status)
+	})
+
+	t.Run("Invalid presentation", func(t *testing.T) {
+		props := map[string]interface{}{}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(presentationMsg(decorator.AttachmentData{JSON: map[string]interface{}{}}))
+		metadata.EXPECT().RequestPresentation().Return(nil)
+		metadata.EXPECT().Properties().Return(props)
+
+		err := VerifyPresentation(provider)(next).Handle(metadata)
+		require.ErrorIs(t, err, ErrPresentationNotVerified)
+
+		result, ok := props[presentproof.VerificationResultPropKey].(*VerificationResult)
+		require.True(t, ok)
+		require.Len(t, result.Presentations[0].Checks, 1)
+		require.Equal(t, CheckPresentationModel, result.Presentations[0].Checks[0].Check)
+		require.Equal(t, verifiable.CodeModelInvalid, result.Presentations[0].Checks[0].Code)
+	})
+}
@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+// SuggestProvider contains dependencies for the credential suggestions.
+type SuggestProvider interface {
+	VerifiableStore() storeverifiable.Store
+	VDRegistry() vdrapi.Registry
+}
+
+// Suggest returns the stored credentials satisfying the presentation definition of the request presentation,
+// grouped by input descriptor. It returns nil if the request has no presentation definition and an empty list
+// if the stored credentials do not satisfy the presentation definition.
+func Suggest(p SuggestProvider, request *presentproof.RequestPresentation) ([]*presexch.Suggestion, error) {
+	if !hasFormat(request.Formats, peDefinitionFormat) {
+		return nil, nil
+	}
+
+	src, err := getAttachmentByFormat(request.Formats, request.RequestPresentationsAttach, peDefinitionFormat)
+	if err != nil {
+		return nil, fmt.Errorf("get attachment by format: %w", err)
+	}
+
+	var payload *presentationExchangePayload
+
+	if err = json.Unmarshal(src, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshal definition: %w", err)
+	}
+
+	if payload == nil || payload.PresentationDefinition == nil {
+		return nil, errors.New("presentation definition is absent")
+	}
+
+	credentials, err := storedCredentials(p.VerifiableStore())
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions, err := payload.PresentationDefinition.Suggest(credentials,
+		verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(p.VDRegistry()).PublicKeyFetcher()),
+		verifiable.WithJSONLDDocumentLoader(presexch.CachingJSONLDLoader()))
+	if errors.Is(err, presexch.ErrNoCredentials) {
+		return []*presexch.Suggestion{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("suggest: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// SuggestFn returns the function suggesting the stored credentials for the received request presentations
+// (see presentproof.Service EnableSuggestions).
+func SuggestFn(p SuggestProvider) presentproof.SuggestFunc {
+	return func(request *presentproof.RequestPresentation) (interface{}, error) {
+		suggestions, err := Suggest(p, request)
+		if err != nil || suggestions == nil {
+			return nil, err
+		}
+
+		return suggestions, nil
+	}
+}
+
+func storedCredentials(store storeverifiable.Store) ([]*verifiable.Credential, error) {
+	records, err := store.GetCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("get credentials: %w", err)
+	}
+
+	credentials := make([]*verifiable.Credential, len(records))
+
+	for i, record := range records {
+		credentials[i], err = store.GetCredential(record.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get credential %s: %w", record.ID, err)
+		}
+	}
+
+	return credentials, nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
+	mocksstore "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+func TestSuggest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store, err := storeverifiable.New(&mockprovider.Provider{StorageProviderValue: storage.NewMockStoreProvider()})
+	require.NoError(t, err)
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().VDRegistry().Return(nil).AnyTimes()
+	provider.EXPECT().VerifiableStore().Return(store).AnyTimes()
+
+	require.NoError(t, store.SaveCredential("degree", &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType},
+		ID:      "http://example.edu/credentials/1872",
+		Schemas: []verifiable.TypedID{{
+			ID:   schemaURI,
+			Type: "JsonSchemaValidator2018",
+		}},
+		Subject: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		Issued: &util.TimeWithTrailingZeroMsec{
+			Time: time.Now(),
+		},
+		Issuer: verifiable.Issuer{
+			ID: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		},
+	}))
+
+	newRequest := func(schema string) *presentproof.RequestPresentation {
+		ID := uuid.New().String()
+
+		return &presentproof.RequestPresentation{
+			Type: presentproof.RequestPresentationMsgType,
+			Formats: []presentproof.Format{{
+				AttachID: ID,
+				Format:   peDefinitionFormat,
+			}},
+			RequestPresentationsAttach: []decorator.Attachment{{
+				ID: ID,
+				Data: decorator.AttachmentData{
+					JSON: map[string]interface{}{
+						"presentation_definition": &presexch.PresentationDefinition{
+							ID: uuid.New().String(),
+							InputDescriptors: []*presexch.InputDescriptor{{
+								ID:     "degree",
+								Schema: []*presexch.Schema{{URI: schema}},
+							}},
+						},
+					},
+				},
+			}},
+		}
+	}
+
+	t.Run("Suggests the stored credentials", func(t *testing.T) {
+		suggestions, err := Suggest(provider, newRequest(schemaURI))
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		require.Equal(t, "degree", suggestions[0].InputDescriptorID)
+		require.Len(t, suggestions[0].Candidates, 1)
+		require.Equal(t, "http://example.edu/credentials/1872", suggestions[0].Candidates[0].CredentialID)
+
+		result, err := SuggestFn(provider)(newRequest(schemaURI))
+		require.NoError(t, err)
+		require.Equal(t, suggestions, result)
+	})
+
+	t.Run("No matching credentials", func(t *testing.T) {
+		suggestions, err := Suggest(provider, newRequest("http://example.edu/schema"))
+		require.NoError(t, err)
+		require.NotNil(t, suggestions)
+		require.Empty(t, suggestions)
+	})
+
+	t.Run("No presentation definition", func(t *testing.T) {
+		suggestions, err := Suggest(provider, &presentproof.RequestPresentation{})
+		require.NoError(t, err)
+		require.Nil(t, suggestions)
+
+		result, err := SuggestFn(provider)(&presentproof.RequestPresentation{})
+		require.NoError(t, err)
+		require.Nil(t, result)
+	})
+
+	t.Run("Invalid presentation definition", func(t *testing.T) {
+		request := newRequest(schemaURI)
+		request.RequestPresentationsAttach[0].Data.JSON = map[string]interface{}{}
+
+		_, err := Suggest(provider, request)
+		require.EqualError(t, err, "presentation definition is absent")
+
+		request.RequestPresentationsAttach[0].Data.JSON = map[string]interface{}{
+			"presentation_definition": map[string]interface{}{},
+		}
+
+		_, err = Suggest(provider, request)
+		require.Contains(t, err.Error(), "suggest:")
+
+		request.RequestPresentationsAttach[0].Data = decorator.AttachmentData{Base64: "ew=="}
+
+		_, err = Suggest(provider, request)
+		require.Contains(t, err.Error(), "unmarshal definition")
+
+		request.RequestPresentationsAttach = nil
+
+		_, err = Suggest(provider, request)
+		require.EqualError(t, err, "get attachment by format: not found")
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		storeMock := mocksstore.NewMockStore(ctrl)
+		storeMock.EXPECT().GetCredentials().Return(nil, errors.New("store error"))
+
+		errProvider := mocks.NewMockProvider(ctrl)
+		errProvider.EXPECT().VerifiableStore().Return(storeMock)
+
+		_, err := Suggest(errProvider, newRequest(schemaURI))
+		require.EqualError(t, err, "get credentials: store error")
+
+		storeMock.EXPECT().GetCredentials().Return([]*storeverifiable.Record{{ID: "id"}}, nil)
+		storeMock.EXPECT().GetCredential("id").Return(nil, errors.New("store error"))
+		errProvider.EXPECT().VerifiableStore().Return(storeMock)
+
+		_, err = Suggest(errProvider, newRequest(schemaURI))
+		require.EqualError(t, err, "get credential id: store error")
+	})
+}
@@ -0,0 +1,303 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// CheckPresentationDefinition is the check of the presentation against the presentation definition
+	// of the request.
+	CheckPresentationDefinition = "presentationDefinition"
+	// CheckPresentationModel is the check of the presentation data model.
+	CheckPresentationModel = "model"
+
+	// CodeDefinitionNotSatisfied is the code of the failed CheckPresentationDefinition.
+	CodeDefinitionNotSatisfied = "definition_not_satisfied"
+	// CodeDefinitionNotRequested is the code of the skipped CheckPresentationDefinition
+	// (the request has no presentation definition).
+	CodeDefinitionNotRequested = "definition_not_requested"
+)
+
+// ErrPresentationNotVerified is returned by VerifyPresentation middleware when the received presentation
+// fails the verification.
+var ErrPresentationNotVerified = errors.New("presentation is not verified")
+
+// VerificationResult is the result of the verification of presentations attached to the presentation message.
+// It is provided by the presentproof.PresentationVerifiedEvent message event
+// (see presentproof.VerificationResultPropKey property).
+type VerificationResult struct {
+	// Verified is true if all the presentations are verified.
+	Verified bool `json:"verified"`
+	// Presentations are the results of the verification of each attached presentation.
+	Presentations []*PresentationResult `json:"presentations"`
+}
+
+// PresentationResult is the result of the verification of a presentation.
+type PresentationResult struct {
+	// ID is the ID of the presentation (if any).
+	ID string `json:"id,omitempty"`
+	// Verified is true if none of the checks of the presentation and its credentials failed.
+	Verified bool `json:"verified"`
+	// Checks are the results of the signature and presentation definition checks of the presentation.
+	Checks []*verifiable.CheckResult `json:"checks"`
+	// Credentials are the results of the verification of credentials enclosed into the presentation.
+	Credentials []*CredentialResult `json:"credentials,omitempty"`
+}
+
+// CredentialResult is the result of the verification of a credential enclosed into the presentation.
+type CredentialResult struct {
+	// ID is the ID of the credential (if any).
+	ID string `json:"id,omitempty"`
+	// Verified is true if none of the checks failed.
+	Verified bool `json:"verified"`
+	// Checks are the results of signature, issuance window, status, schema and issuer trust checks.
+	Checks []*verifiable.CheckResult `json:"checks"`
+}
+
+// OptVP represents option function for the VerifyPresentation middleware.
+type OptVP func(o *vpOptions)
+
+// WithCredentialOpts sets the options used to verify the credentials enclosed into the presentations,
+// e.g. verifiable.WithStatusChecker or verifiable.WithTrustRegistry.
+func WithCredentialOpts(opts ...verifiable.CredentialOpt) OptVP {
+	return func(o *vpOptions) {
+		o.credentialOpts = append(o.credentialOpts, opts...)
+	}
+}
+
+type vpOptions struct {
+	credentialOpts []verifiable.CredentialOpt
+}
+
+// VerifyPresentation the helper function for the present proof protocol which verifies the received presentations:
+// the proofs of the presentations and the enclosed credentials, the status and validity period of the credentials,
+// and the presentations against the presentation definition of the request (if any).
+// The result is provided by the presentproof.PresentationVerifiedEvent message event. The presentations failing
+// the verification are rejected.
+func VerifyPresentation(p Provider, opts ...OptVP) presentproof.Middleware {
+	vdr := p.VDRegistry()
+
+	options := &vpOptions{}
+
+	for i := range opts {
+		opts[i](options)
+	}
+
+	keyFetcher := verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher()
+	loader := presexch.CachingJSONLDLoader()
+
+	credentialOpts := append([]verifiable.CredentialOpt{
+		verifiable.WithPublicKeyFetcher(keyFetcher),
+		verifiable.WithJSONLDDocumentLoader(loader),
+	}, options.credentialOpts...)
+
+	v := &presentationVerifier{
+		presentationOpts: []verifiable.PresentationOpt{
+			verifiable.WithPresPublicKeyFetcher(keyFetcher),
+			verifiable.WithPresJSONLDDocumentLoader(loader),
+		},
+		credentialOpts: credentialOpts,
+	}
+
+	return func(next presentproof.Handler) presentproof.Handler {
+		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+			if metadata.StateName() != stateNamePresentationReceived {
+				return next.Handle(metadata)
+			}
+
+			presentation := presentproof.Presentation{}
+			if err := metadata.Message().Decode(&presentation); err != nil {
+				return fmt.Errorf("decode: %w", err)
+			}
+
+			if len(presentation.PresentationsAttach) == 0 {
+				return errors.New("presentations were not provided")
+			}
+
+			definition, err := requestedDefinition(metadata.RequestPresentation())
+			if err != nil {
+				return fmt.Errorf("requested definition: %w", err)
+			}
+
+			result := &VerificationResult{Verified: true}
+
+			for i := range presentation.PresentationsAttach {
+				raw, err := presentation.PresentationsAttach[i].Data.Fetch()
+				if err != nil {
+					return fmt.Errorf("fetch: %w", err)
+				}
+
+				r := v.verify(raw, definition)
+
+				result.Presentations = append(result.Presentations, r)
+				result.Verified = result.Verified && r.Verified
+			}
+
+			metadata.Properties()[presentproof.VerificationResultPropKey] = result
+
+			if !result.Verified {
+				return ErrPresentationNotVerified
+			}
+
+			return next.Handle(metadata)
+		})
+	}
+}
+
+// requestedDefinition returns the presentation definition of the request, or nil if it is not defined.
+func requestedDefinition(request *presentproof.RequestPresentation) (*presexch.PresentationDefinition, error) {
+	if request == nil || !hasFormat(request.Formats, peDefinitionFormat) {
+		return nil, nil // nolint: nilnil
+	}
+
+	src, err := getAttachmentByFormat(request.Formats, request.RequestPresentationsAttach, peDefinitionFormat)
+	if err != nil {
+		return nil, fmt.Errorf("get attachment by format: %w", err)
+	}
+
+	var payload *presentationExchangePayload
+
+	if err = json.Unmarshal(src, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshal definition: %w", err)
+	}
+
+	return payload.PresentationDefinition, nil
+}
+
+type presentationVerifier struct {
+	presentationOpts []verifiable.PresentationOpt
+	credentialOpts   []verifiable.CredentialOpt
+}
+
+func (v *presentationVerifier) verify(raw []byte, definition *presexch.PresentationDefinition) *PresentationResult {
+	result := &PresentationResult{}
+
+	vp, err := verifiable.ParsePresentation(raw,
+		append(v.presentationOpts, verifiable.WithPresDisabledProofCheck())...)
+	if err != nil {
+		result.Checks = append(result.Checks, failedCheck(CheckPresentationModel, verifiable.CodeModelInvalid, err))
+
+		return result
+	}
+
+	result.Checks = append(result.Checks, v.signatureCheck(raw, vp))
+	result.ID = vp.ID
+	result.Checks = append(result.Checks, v.definitionCheck(vp, definition))
+	result.Credentials = v.verifyCredentials(vp)
+
+	result.Verified = verified(result.Checks)
+
+	for _, c := range result.Credentials {
+		result.Verified = result.Verified && c.Verified
+	}
+
+	return result
+}
+
+func (v *presentationVerifier) signatureCheck(raw []byte, vp *verifiable.Presentation) *verifiable.CheckResult {
+	// the presentation is neither signed with Linked Data proof nor serialized as JWS
+	if len(vp.Proofs) == 0 && json.Valid(raw) {
+		return &verifiable.CheckResult{
+			Check:  verifiable.CheckSignature,
+			Status: verifiable.CheckSkipped,
+			Code:   verifiable.CodeProofMissing,
+		}
+	}
+
+	_, err := verifiable.ParsePresentation(raw, v.presentationOpts...)
+	if err != nil {
+		return failedCheck(verifiable.CheckSignature, proofErrorCode(err), err)
+	}
+
+	return passedCheck(verifiable.CheckSignature)
+}
+
+func (v *presentationVerifier) definitionCheck(vp *verifiable.Presentation,
+	definition *presexch.PresentationDefinition) *verifiable.CheckResult {
+	if definition == nil {
+		return &verifiable.CheckResult{
+			Check:  CheckPresentationDefinition,
+			Status: verifiable.CheckSkipped,
+			Code:   CodeDefinitionNotRequested,
+		}
+	}
+
+	_, err := definition.Match(vp, presexch.WithCredentialOptions(v.credentialOpts...))
+	if err != nil {
+		return failedCheck(CheckPresentationDefinition, CodeDefinitionNotSatisfied, err)
+	}
+
+	return passedCheck(CheckPresentationDefinition)
+}
+
+func (v *presentationVerifier) verifyCredentials(vp *verifiable.Presentation) []*CredentialResult {
+	credentials, err := vp.MarshalledCredentials()
+	if err != nil {
+		return []*CredentialResult{{
+			Checks: []*verifiable.CheckResult{failedCheck(verifiable.CheckSchema, verifiable.CodeModelInvalid, err)},
+		}}
+	}
+
+	results := make([]*CredentialResult, 0, len(credentials))
+
+	for _, raw := range credentials {
+		r, err := verifiable.VerifyCredential(raw, v.credentialOpts...)
+		if err != nil {
+			results = append(results, &CredentialResult{
+				Checks: []*verifiable.CheckResult{failedCheck(verifiable.CheckSchema, verifiable.CodeModelInvalid, err)},
+			})
+
+			continue
+		}
+
+		results = append(results, &CredentialResult{
+			ID:       r.Credential.ID,
+			Verified: r.Verified,
+			Checks:   r.Checks,
+		})
+	}
+
+	return results
+}
+
+// proofErrorCode maps the error of the proof check to the code of the failed verifiable.CheckSignature.
+func proofErrorCode(err error) string {
+	switch {
+	case errors.Is(err, verifiable.ErrProofInvalid):
+		return verifiable.CodeProofInvalid
+	case errors.Is(err, verifiable.ErrUnsupportedProofType):
+		return verifiable.CodeUnsupportedProofType
+	default:
+		return verifiable.CodeProofCheckError
+	}
+}
+
+func verified(checks []*verifiable.CheckResult) bool {
+	for _, c := range checks {
+		if c.Status == verifiable.CheckFailed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func passedCheck(name string) *verifiable.CheckResult {
+	return &verifiable.CheckResult{Check: name, Status: verifiable.CheckPassed, Code: verifiable.CodeOK}
+}
+
+func failedCheck(name, code string, err error) *verifiable.CheckResult {
+	return &verifiable.CheckResult{Check: name, Status: verifiable.CheckFailed, Code: code, Message: err.Error()}
+}
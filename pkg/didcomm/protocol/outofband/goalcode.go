@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+// ErrNoAttachedMessage is returned by GoalContext.Dispatch when the invitation has no request attachments.
+var ErrNoAttachedMessage = errors.New("invitation has no attached message")
+
+// GoalContext is provided to the GoalCodeHandler once the connection for the invitation is established.
+type GoalContext struct {
+	// Invitation is the accepted invitation.
+	Invitation *Invitation
+	// ConnectionID is the ID of the connection established (or reused) for the invitation.
+	ConnectionID string
+	MyDID        string
+	TheirDID     string
+	// Msg is the DIDComm message attached to the invitation (nil if the invitation has no request attachments).
+	Msg service.DIDCommMsg
+	// Dispatch hands Msg over to the service of its protocol, as done for the invitations
+	// without a registered goal code.
+	Dispatch func() error
+}
+
+// GoalCodeHandler handles the invitations with the goal code it is registered for
+// (see Service.RegisterGoalCode).
+type GoalCodeHandler func(ctx *GoalContext) error
+
+// goalCodeRegistry maps the goal codes of the invitations to their handlers.
+type goalCodeRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]GoalCodeHandler
+}
+
+func (r *goalCodeRegistry) register(goalCode string, handler GoalCodeHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.handlers == nil {
+		r.handlers = map[string]GoalCodeHandler{}
+	}
+
+	if handler == nil {
+		delete(r.handlers, goalCode)
+
+		return
+	}
+
+	r.handlers[goalCode] = handler
+}
+
+func (r *goalCodeRegistry) handler(goalCode string) (GoalCodeHandler, bool) {
+	if goalCode == "" {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	h, ok := r.handlers[goalCode]
+
+	return h, ok
+}
+
+// RegisterGoalCode registers the handler of the invitations with the goal code. Once the connection for such
+// an invitation is established, the handler is called instead of dispatching the message attached to the invitation
+// to the service of its protocol. A nil handler removes the registration.
+func (s *Service) RegisterGoalCode(goalCode string, handler GoalCodeHandler) {
+	s.goalCodes.register(goalCode, handler)
+}
+
+// hasFollowup returns true if the invitation requires processing after the connection is established.
+func hasFollowup(inv *Invitation) bool {
+	return len(inv.Requests) > 0 || inv.GoalCode != ""
+}
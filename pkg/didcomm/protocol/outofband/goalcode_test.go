@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+func TestService_RegisterGoalCode(t *testing.T) {
+	const goalCode = "issue-vc"
+
+	newState := func(inv *Invitation) *attachmentHandlingState {
+		inv.GoalCode = goalCode
+
+		return &attachmentHandlingState{ID: inv.ID, ConnectionID: uuid.New().String(), Invitation: inv}
+	}
+
+	t.Run("handles the invitation with the goal code", func(t *testing.T) {
+		dispatched := make(chan service.DIDCommMsg, 1)
+
+		provider := testProvider()
+		provider.InboundDIDCommMsgHandlerFunc = func() service.InboundHandler {
+			return &inboundMsgHandler{handleFunc: func(msg service.DIDCommMsg, _ service.DIDCommContext) (string, error) {
+				dispatched <- msg
+
+				return "", nil
+			}}
+		}
+
+		state := newState(newInvitation())
+		s := newAutoService(t, provider, withState(t, state))
+
+		var goalCtx *GoalContext
+
+		s.RegisterGoalCode(goalCode, func(ctx *GoalContext) error {
+			goalCtx = ctx
+
+			return ctx.Dispatch()
+		})
+
+		require.NoError(t, s.dispatchInvitationAttachment(state.ID, myDID, theirDID))
+		require.NotNil(t, goalCtx)
+		require.Equal(t, state.Invitation.ID, goalCtx.Invitation.ID)
+		require.Equal(t, state.ConnectionID, goalCtx.ConnectionID)
+		require.Equal(t, myDID, goalCtx.MyDID)
+		require.Equal(t, theirDID, goalCtx.TheirDID)
+		require.Equal(t, "test-type", goalCtx.Msg.Type())
+		require.Equal(t, "test-type", (<-dispatched).Type())
+
+		saved, err := s.fetchAttachmentHandlingState(state.ID)
+		require.NoError(t, err)
+		require.True(t, saved.Done)
+
+		err = s.dispatchInvitationAttachment(state.ID, myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "was already handled")
+	})
+
+	t.Run("handles the invitation without attachments", func(t *testing.T) {
+		inv := newInvitation()
+		inv.Requests = nil
+
+		state := newState(inv)
+		s := newAutoService(t, testProvider(), withState(t, state))
+
+		s.RegisterGoalCode(goalCode, func(ctx *GoalContext) error {
+			require.Nil(t, ctx.Msg)
+
+			return ctx.Dispatch()
+		})
+
+		err := s.dispatchInvitationAttachment(state.ID, myDID, theirDID)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrNoAttachedMessage))
+	})
+
+	t.Run("ignores the invitation without a handler and attachments", func(t *testing.T) {
+		inv := newInvitation()
+		inv.Requests = nil
+
+		state := newState(inv)
+		s := newAutoService(t, testProvider(), withState(t, state))
+
+		s.RegisterGoalCode(goalCode, func(ctx *GoalContext) error {
+			return errors.New("unexpected call")
+		})
+		s.RegisterGoalCode(goalCode, nil)
+
+		require.NoError(t, s.dispatchInvitationAttachment(state.ID, myDID, theirDID))
+	})
+
+	t.Run("wraps error returned by the handler", func(t *testing.T) {
+		state := newState(newInvitation())
+		s := newAutoService(t, testProvider(), withState(t, state))
+
+		s.RegisterGoalCode(goalCode, func(ctx *GoalContext) error {
+			return errors.New("test")
+		})
+
+		require.EqualError(t, s.dispatchInvitationAttachment(state.ID, myDID, theirDID), "goal code issue-vc: test")
+	})
+}
+
+func TestHasFollowup(t *testing.T) {
+	require.True(t, hasFollowup(newInvitation()))
+	require.True(t, hasFollowup(&Invitation{GoalCode: "test"}))
+	require.False(t, hasFollowup(&Invitation{}))
+}
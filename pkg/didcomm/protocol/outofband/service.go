@@ -12,7 +12,6 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/mitchellh/mapstructure"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -85,6 +84,7 @@ type Service struct {
 	extractDIDCommMsgBytesFunc func(*decorator.Attachment) ([]byte, error)
 	listenerFunc               func()
 	messenger                  service.Messenger
+	goalCodes                  goalCodeRegistry
 }
 
 type callback struct {
@@ -584,6 +584,9 @@ func (s *Service) SaveInvitation(i *Invitation) error {
 		TheirLabel: i.Label,
 		Target:     target,
 		MediaTypes: i.Accept,
+		GoalCode:   i.GoalCode,
+		Goal:       i.Goal,
+		Protocols:  i.Protocols,
 	})
 	if err != nil {
 		return fmt.Errorf("the didexchange service failed to save the oob invitation : %w", err)
@@ -698,6 +701,18 @@ func (s *Service) dispatchInvitationAttachment(invID, myDID, theirDID string) er
 		return fmt.Errorf("failed to load attachment handling state : %w", err)
 	}
 
+	handler, ok := s.goalCodes.handler(state.Invitation.GoalCode)
+	if ok {
+		return s.handleGoalCode(handler, state, myDID, theirDID)
+	}
+
+	if len(state.Invitation.Requests) == 0 {
+		logger.Debugf("no handler for goal code %q and no attachments in invitation %s",
+			state.Invitation.GoalCode, state.ID)
+
+		return nil
+	}
+
 	msg, err := s.extractDIDCommMsg(state)
 	if err != nil {
 		return fmt.Errorf("failed to extract DIDComm msg : %w", err)
@@ -712,9 +727,61 @@ func (s *Service) dispatchInvitationAttachment(invID, myDID, theirDID string) er
 		return fmt.Errorf("failed to update state : %w", err)
 	}
 
+	return s.dispatch(msg, myDID, theirDID)
+}
+
+func (s *Service) handleGoalCode(handler GoalCodeHandler, state *attachmentHandlingState,
+	myDID, theirDID string) error {
+	if state.Done {
+		return fmt.Errorf("invitation %s was already handled", state.ID)
+	}
+
+	var (
+		msg service.DIDCommMsg
+		err error
+	)
+
+	if len(state.Invitation.Requests) > 0 {
+		msg, err = s.extractDIDCommMsg(state)
+		if err != nil {
+			return fmt.Errorf("failed to extract DIDComm msg : %w", err)
+		}
+	}
+
+	state.Done = true
+
+	err = s.save(state)
+	if err != nil {
+		return fmt.Errorf("failed to update state : %w", err)
+	}
+
+	logger.Debugf("handling invitation %s with goal code: %s", state.ID, state.Invitation.GoalCode)
+
+	err = handler(&GoalContext{
+		Invitation:   state.Invitation,
+		ConnectionID: state.ConnectionID,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+		Msg:          msg,
+		Dispatch: func() error {
+			if msg == nil {
+				return ErrNoAttachedMessage
+			}
+
+			return s.dispatch(msg, myDID, theirDID)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("goal code %s: %w", state.Invitation.GoalCode, err)
+	}
+
+	return nil
+}
+
+func (s *Service) dispatch(msg service.DIDCommMsg, myDID, theirDID string) error {
 	logger.Debugf("dispatching inbound message of type: %s", msg.Type())
 
-	_, err = s.inboundHandler().HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+	_, err := s.inboundHandler().HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
 	if err != nil {
 		return fmt.Errorf("failed to dispatch message: %w", err)
 	}
@@ -852,6 +919,9 @@ func decodeDIDInvitationAndOOBInvitation(c *callback) (*didexchange.OOBInvitatio
 		Target:     target,
 		MyLabel:    c.ctx.MyLabel,
 		MediaTypes: oobInv.Accept,
+		GoalCode:   oobInv.GoalCode,
+		Goal:       oobInv.Goal,
+		Protocols:  oobInv.Protocols,
 	}
 
 	return didInv, oobInv, nil
@@ -863,19 +933,12 @@ func chooseTarget(svcs []interface{}) (interface{}, error) {
 		case string, *did.Service:
 			return svc, nil
 		case map[string]interface{}:
-			var s did.Service
-
-			decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &s})
-			if err != nil {
-				return nil, fmt.Errorf("failed to initialize decoder : %w", err)
-			}
-
-			err = decoder.Decode(svc)
+			s, err := did.ParseService(svc)
 			if err != nil {
 				return nil, fmt.Errorf("failed to decode service block : %w", err)
 			}
 
-			return &s, nil
+			return s, nil
 		}
 	}
 
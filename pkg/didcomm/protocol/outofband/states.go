@@ -152,7 +152,7 @@ func (s *stateAwaitResponse) handleHandshakeReuseAccepted(
 	ctx *context, deps *dependencies) (state, finisher, bool, error) {
 	logger.Debugf("handling %s with context: %+v", ctx.Msg.Type(), ctx)
 
-	if len(ctx.Invitation.Requests) > 0 {
+	if hasFollowup(ctx.Invitation) {
 		go func() {
 			logger.Debugf("dispatching invitation attachment...")
 
@@ -189,7 +189,7 @@ func (s *statePrepareResponse) Execute(ctx *context, deps *dependencies) (state,
 
 	ctx.ConnectionID = connID
 
-	if len(ctx.Invitation.Requests) > 0 {
+	if hasFollowup(ctx.Invitation) {
 		callbackState := &attachmentHandlingState{
 			ID:           ctx.Invitation.ID,
 			ConnectionID: connID,
@@ -242,7 +242,7 @@ func (s *statePrepareResponse) connectionReuse(ctx *context, deps *dependencies)
 	ctx.MyDID = record.MyDID
 	ctx.TheirDID = record.TheirDID
 
-	if len(ctx.Invitation.Requests) > 0 {
+	if hasFollowup(ctx.Invitation) {
 		callbackState := &attachmentHandlingState{
 			ID:           ctx.Invitation.ID,
 			ConnectionID: record.ConnectionID,
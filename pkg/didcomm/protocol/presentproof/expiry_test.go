@@ -0,0 +1,189 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/internal/instance"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func expiringRequest(expires time.Time) service.DIDCommMsgMap {
+	return service.NewDIDCommMsgMap(struct {
+		ID     string            `json:"@id"`
+		Thread decorator.Thread  `json:"~thread"`
+		Type   string            `json:"@type"`
+		Timing *decorator.Timing `json:"~timing"`
+	}{
+		ID:     uuid.New().String(),
+		Thread: decorator.Thread{ID: uuid.New().String()},
+		Type:   RequestPresentationMsgType,
+		Timing: &decorator.Timing{ExpiresTime: expires},
+	})
+}
+
+func expire(t *testing.T, svc *Service, piID string) {
+	t.Helper()
+
+	src, err := svc.store.Get("expiry_" + piID)
+	require.NoError(t, err)
+
+	entry := &expiry.Entry{}
+	require.NoError(t, json.Unmarshal(src, entry))
+
+	require.NoError(t, svc.expire(entry))
+}
+
+func TestService_Expiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("verifier", func(t *testing.T) {
+		svc, messenger := newRecordsService(t, ctrl)
+		svc.EnableExpiry()
+
+		events := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		piID, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestPresentation{
+			Type:   RequestPresentationMsgType,
+			Timing: &decorator.Timing{ExpiresTime: time.Now().Add(time.Hour)},
+		}), service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		expire(t, svc, piID)
+
+		record, err := svc.Record(piID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameAbandoned, record.State)
+		require.Equal(t, instance.ErrExpired.Error(), record.Error)
+
+		_, err = svc.store.Get(internalDataKey + piID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		var expired *service.StateMsg
+
+		for len(events) > 0 {
+			if event := <-events; event.StateID == ExpiredEvent {
+				expired = &event
+			}
+		}
+
+		require.NotNil(t, expired)
+		require.Equal(t, RequestPresentationMsgType, expired.Msg.Type())
+		require.Equal(t, piID, expired.Properties.All()[piidPropKey])
+		require.Equal(t, instance.ErrExpired, expired.Properties.All()[errorPropKey])
+	})
+
+	t.Run("prover with pending action", func(t *testing.T) {
+		svc, _ := newRecordsService(t, ctrl)
+		svc.EnableExpiry()
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := expiringRequest(time.Now().Add(time.Hour))
+
+		_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		thID, err := msg.ThreadID()
+		require.NoError(t, err)
+
+		expire(t, svc, thID)
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+
+		record, err := svc.Record(thID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameAbandoned, record.State)
+		require.Equal(t, Alice, record.MyDID)
+	})
+
+	t.Run("replied before the expiration", func(t *testing.T) {
+		svc, messenger := newRecordsService(t, ctrl)
+		svc.EnableExpiry()
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Bob).Return(nil)
+
+		msg := expiringRequest(time.Now().Add(time.Hour))
+
+		_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		thID, err := msg.ThreadID()
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Continue(WithPresentation(&Presentation{}))
+
+		require.Eventually(t, func() bool {
+			record, errRecord := svc.Record(thID)
+
+			return errRecord == nil && record.State == stateNameDone
+		}, time.Second, 10*time.Millisecond)
+
+		expire(t, svc, thID)
+
+		record, err := svc.Record(thID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameDone, record.State)
+	})
+
+	t.Run("expired request", func(t *testing.T) {
+		svc, _ := newRecordsService(t, ctrl)
+		svc.EnableExpiry()
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		_, err := svc.HandleInbound(expiringRequest(time.Now().Add(-time.Hour)),
+			service.NewDIDCommContext(Alice, Bob, nil))
+		require.True(t, errors.Is(err, expiry.ErrExpired))
+	})
+
+	t.Run("the tracker checks the expired protocol instances", func(t *testing.T) {
+		svc, messenger := newRecordsService(t, ctrl)
+
+		tracker := svc.EnableExpiry(expiry.WithCheckInterval(time.Millisecond))
+		tracker.Start()
+
+		defer tracker.Stop()
+
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		piID, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestPresentation{
+			Type:   RequestPresentationMsgType,
+			Timing: &decorator.Timing{ExpiresTime: time.Now().Add(50 * time.Millisecond)},
+		}), service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			record, errRecord := svc.Record(piID)
+
+			return errRecord == nil && record.State == stateNameAbandoned
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/l10n"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+)
+
+const catalogURI = "https://example.com/catalogs/present-proof"
+
+func TestService_SetMessageCatalogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, _ := newRecordsService(t, ctrl)
+
+	catalogs := l10n.NewRegistry()
+	svc.SetMessageCatalogs(catalogs)
+	require.Equal(t, catalogs, svc.catalogs)
+
+	svc.SetMessageCatalogs(nil)
+	require.Nil(t, svc.catalogs)
+}
+
+func TestAbandoned_LocalizedProblemReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	md := &metaData{catalogs: l10n.NewRegistry(&l10n.Catalog{
+		URI: catalogURI,
+		Texts: map[string]map[string]string{
+			codeInternalError: {"en": "Internal error", "es": "Error interno"},
+		},
+	})}
+	md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+	require.NoError(t, md.Msg.SetID(uuid.New().String()))
+
+	_, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+	require.NoError(t, err)
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().
+		ReplyToNested(gomock.Any(), gomock.Any()).
+		Do(func(msg service.DIDCommMsgMap, _ *service.NestedReplyOpts) error {
+			r := &model.ProblemReport{}
+			require.NoError(t, msg.Decode(r))
+			require.Equal(t, codeInternalError, r.Description.Code)
+			require.NotNil(t, r.L10n)
+			require.Equal(t, []string{catalogURI}, r.L10n.Catalogs)
+
+			description, ok := msg["description"].(map[string]interface{})
+			require.True(t, ok)
+			require.Equal(t, "Internal error", description["en"])
+			require.Equal(t, "Error interno", description["es"])
+
+			return nil
+		})
+
+	require.NoError(t, action(messenger))
+}
+
+func TestService_LocalizedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, _ := newRecordsService(t, ctrl)
+
+	ch := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(ch))
+
+	msg := randomInboundMessage(RequestPresentationMsgType)
+	msg["comment"] = "Please prove your age"
+	msg["comment~l10n"] = map[string]interface{}{"locale": "en", "es": "Por favor, demuestre su edad"}
+
+	_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+	require.NoError(t, err)
+
+	action := <-ch
+	require.Equal(t, map[string]map[string]string{
+		"comment": {"en": "Please prove your age", "es": "Por favor, demuestre su edad"},
+	}, action.Properties.All()[LocalizedPropKey])
+}
@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
+	mocklocker "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+type lockingProvider struct {
+	Provider
+	locker lock.Locker
+}
+
+func (p *lockingProvider) Locker() lock.Locker {
+	return p.locker
+}
+
+func TestService_Lock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := presentproofMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(nil).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+	t.Run("inbound message locks the protocol instance", func(t *testing.T) {
+		locker := &mocklocker.MockLocker{}
+
+		svc, err := New(&lockingProvider{Provider: provider, locker: locker})
+		require.NoError(t, err)
+		require.NoError(t, svc.RegisterActionEvent(make(chan<- service.DIDCommAction)))
+
+		msg := service.NewDIDCommMsgMap(struct {
+			ID   string `json:"@id"`
+			Type string `json:"@type"`
+		}{ID: "piid", Type: "unknown"})
+
+		_, err = svc.HandleInbound(msg, service.EmptyDIDCommContext())
+		require.Error(t, err)
+		require.Equal(t, []string{"present-proof:piid"}, locker.Acquired)
+
+		// the lock was released
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		unlocker, err := locker.Lock(ctx, "present-proof:piid")
+		require.NoError(t, err)
+		require.NoError(t, unlocker.Unlock())
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		svc, err := New(&lockingProvider{Provider: provider, locker: &mocklocker.MockLocker{
+			LockErr: errors.New("lock error"),
+		}})
+		require.NoError(t, err)
+		require.NoError(t, svc.RegisterActionEvent(make(chan<- service.DIDCommAction)))
+
+		msg := service.NewDIDCommMsgMap(struct {
+			ID string `json:"@id"`
+		}{ID: "piid"})
+
+		_, err = svc.HandleInbound(msg, service.EmptyDIDCommContext())
+		require.EqualError(t, err, "lock: lock error")
+	})
+
+	t.Run("callback of a protocol instance advanced by another agent instance is dropped", func(t *testing.T) {
+		locker := &mocklocker.MockLocker{}
+
+		svc, err := New(&lockingProvider{Provider: provider, locker: locker})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.saveInternalData("piid", &internalData{StateName: stateNameDone}))
+
+		msg := service.NewDIDCommMsgMap(struct {
+			ID string `json:"@id"`
+		}{ID: "msgid"})
+
+		svc.handleCallback(&metaData{
+			transitionalPayload: transitionalPayload{Action: Action{PIID: "piid", Msg: msg}},
+			state:               &presentationReceived{},
+			msgClone:            msg.Clone(),
+		})
+
+		require.Equal(t, []string{"present-proof:piid"}, locker.Acquired)
+
+		data, err := svc.currentInternalData("piid")
+		require.NoError(t, err)
+		require.Equal(t, stateNameDone, data.StateName)
+	})
+}
@@ -36,6 +36,7 @@ type Metadata interface {
 	// ProposePresentation is pointer to the message provided by the user through the Continue function.
 	ProposePresentation() *ProposePresentation
 	// RequestPresentation is pointer to the message provided by the user through the Continue function.
+	// When the Verifier receives the presentation, it is the request-presentation message sent by the Verifier.
 	RequestPresentation() *RequestPresentation
 	// PresentationNames is a slice which contains presentation names provided by the user through the Continue function.
 	PresentationNames() []string
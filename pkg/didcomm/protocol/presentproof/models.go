@@ -14,8 +14,8 @@ import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator
 type ProposePresentation struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
-	Comment string `json:"comment,omitempty"`
+	Comment     string              `json:"comment,omitempty"`
+	CommentL10n decorator.Localized `json:"comment~l10n,omitempty"`
 	// Formats contains an entry for each proposal~attach array entry, including an optional value of the
 	// attachment @id (if attachments are present) and the verifiable presentation format and version of the attachment.
 	Formats []Format `json:"formats,omitempty"`
@@ -28,8 +28,8 @@ type ProposePresentation struct {
 type RequestPresentation struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
-	Comment string `json:"comment,omitempty"`
+	Comment     string              `json:"comment,omitempty"`
+	CommentL10n decorator.Localized `json:"comment~l10n,omitempty"`
 	// WillConfirm is a field that defaults to "false" to indicate that the verifier will or will not
 	// send a post-presentation confirmation ack message.
 	WillConfirm bool `json:"will_confirm,omitempty"`
@@ -38,20 +38,27 @@ type RequestPresentation struct {
 	Formats []Format `json:"formats,omitempty"`
 	// RequestPresentationsAttach is an array of attachments containing the acceptable verifiable presentation requests.
 	RequestPresentationsAttach []decorator.Attachment `json:"request_presentations~attach,omitempty"`
+	// Service is the service decorator of the connection-less request. The presentation is sent
+	// to the service endpoint of the Verifier instead of the connection.
+	Service *decorator.Service `json:"~service,omitempty"`
+	// Timing is the expiration time of the request, the Verifier abandons the protocol instance once it passes
+	// without a presentation (see Service.EnableExpiry).
+	Timing *decorator.Timing `json:"~timing,omitempty"`
 }
 
 // Presentation is a response to a RequestPresentation message and contains signed presentations.
-// TODO: Add ~please_ack decorator support for the protocol [Issue #2047].
 type Presentation struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300].
-	Comment string `json:"comment,omitempty"`
+	Comment     string              `json:"comment,omitempty"`
+	CommentL10n decorator.Localized `json:"comment~l10n,omitempty"`
 	// Formats contains an entry for each presentations~attach array entry, providing the the value of the attachment
 	// @id and the verifiable presentation format and version of the attachment.
 	Formats []Format `json:"formats,omitempty"`
 	// PresentationsAttach an array of attachments containing the presentation in the requested format(s).
 	PresentationsAttach []decorator.Attachment `json:"presentations~attach,omitempty"`
+	// PleaseAck requests the acknowledgement of the presentation by the Verifier.
+	PleaseAck *decorator.PleaseAck `json:"~please_ack,omitempty"`
 }
 
 // Format contains the the value of the attachment @id and the verifiable credential format of the attachment.
@@ -0,0 +1,268 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const recordKey = "record_%s"
+
+var errRecordsNotEnabled = errors.New("records are not enabled")
+
+// Record is the record of a protocol instance, kept by the service once the records are enabled (see EnableRecords).
+type Record struct {
+	// Protocol instance ID
+	PIID     string `json:"piid"`
+	ThreadID string `json:"thread_id,omitempty"`
+	// State is the current state of the protocol instance (e.g. "request-sent", "done", "abandoned").
+	State    string `json:"state"`
+	MyDID    string `json:"my_did,omitempty"`
+	TheirDID string `json:"their_did,omitempty"`
+	// Presentation is the presentation received by the Verifier or sent by the Prover.
+	Presentation *Presentation `json:"presentation,omitempty"`
+	// Error is the reason the protocol instance was abandoned.
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RecordFilter selects the records, its empty fields match all the records.
+type RecordFilter struct {
+	State    string `json:"state,omitempty"`
+	MyDID    string `json:"my_did,omitempty"`
+	TheirDID string `json:"their_did,omitempty"`
+	ThreadID string `json:"thread_id,omitempty"`
+}
+
+func (f *RecordFilter) matches(r *Record) bool {
+	if f == nil {
+		return true
+	}
+
+	return (f.State == "" || f.State == r.State) &&
+		(f.MyDID == "" || f.MyDID == r.MyDID) &&
+		(f.TheirDID == "" || f.TheirDID == r.TheirDID) &&
+		(f.ThreadID == "" || f.ThreadID == r.ThreadID)
+}
+
+// EnableRecords keeps a record of each protocol instance with its state, connection and presentation, so they can
+// be queried by Records once the instance is no longer pending an action.
+func (s *Service) EnableRecords() {
+	s.recordsEnabled = true
+}
+
+// Records returns the records of the protocol instances matching the filter, all the records if the filter is nil.
+func (s *Service) Records(filter *RecordFilter) ([]Record, error) {
+	if !s.recordsEnabled {
+		return nil, errRecordsNotEnabled
+	}
+
+	iter, err := s.store.Query(recordKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query store: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var records []Record
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next set of data from records: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value from records: %w", err)
+		}
+
+		var record Record
+		if errUnmarshal := json.Unmarshal(value, &record); errUnmarshal != nil {
+			return nil, fmt.Errorf("unmarshal: %w", errUnmarshal)
+		}
+
+		if filter.matches(&record) {
+			records = append(records, record)
+		}
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next set of data from records: %w", err)
+		}
+	}
+
+	return records, nil
+}
+
+// Record returns the record of the protocol instance.
+func (s *Service) Record(piID string) (*Record, error) {
+	if !s.recordsEnabled {
+		return nil, errRecordsNotEnabled
+	}
+
+	return s.getRecord(piID)
+}
+
+// Abandon moves the protocol instance to the abandoned state, e.g. when the other party stopped responding.
+// Its pending action, if any, is dropped and the other party is not notified.
+func (s *Service) Abandon(piID, reason string) error {
+	unlock, err := s.locker.Lock(piID)
+	if err != nil {
+		return fmt.Errorf("lock: %w", err)
+	}
+
+	defer unlock()
+
+	data, err := s.currentInternalData(piID)
+	if err != nil {
+		return fmt.Errorf("current internal data: %w", err)
+	}
+
+	if data.StateName == stateNameDone || data.StateName == stateNameAbandoned {
+		return fmt.Errorf("protocol instance %s is already %s", piID, data.StateName)
+	}
+
+	md := &metaData{transitionalPayload: transitionalPayload{Action: Action{PIID: piID}}}
+
+	tPayload, err := s.getTransitionalPayload(piID)
+
+	switch {
+	case err == nil:
+		md.transitionalPayload = *tPayload
+
+		if err = s.deleteTransitionalPayload(piID); err != nil {
+			return fmt.Errorf("delete transitional payload: %w", err)
+		}
+	case !errors.Is(err, storage.ErrDataNotFound):
+		return fmt.Errorf("get transitional payload: %w", err)
+	case data.StateName == stateNameStart:
+		return fmt.Errorf("protocol instance %s: %w", piID, storage.ErrDataNotFound)
+	}
+
+	if reason != "" {
+		md.err = customError{error: errors.New(reason)}
+	}
+
+	data.StateName = stateNameAbandoned
+
+	if err = s.saveInternalData(piID, data); err != nil {
+		return fmt.Errorf("failed to persist state %s: %w", data.StateName, err)
+	}
+
+	if err = s.updateRecord(md, stateNameAbandoned); err != nil {
+		return fmt.Errorf("failed to persist record of state %s: %w", data.StateName, err)
+	}
+
+	return nil
+}
+
+// recordOperation returns the operation saving the record of the protocol instance in the given state.
+func (s *Service) recordOperation(md *metaData, stateName string) (storage.Operation, error) {
+	record, err := s.getRecord(md.PIID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		record, err = &Record{PIID: md.PIID, CreatedAt: time.Now().UTC()}, nil
+	}
+
+	if err != nil {
+		return storage.Operation{}, err
+	}
+
+	record.State = stateName
+	record.UpdatedAt = time.Now().UTC()
+
+	if md.MyDID != "" {
+		record.MyDID = md.MyDID
+	}
+
+	if md.TheirDID != "" {
+		record.TheirDID = md.TheirDID
+	}
+
+	if record.ThreadID == "" && md.Msg != nil {
+		record.ThreadID, _ = md.Msg.ThreadID() // nolint:errcheck // the thread ID falls back to the message ID
+	}
+
+	switch stateName {
+	case stateNamePresentationReceived:
+		presentation := &Presentation{}
+		if md.Msg.Decode(presentation) == nil {
+			record.Presentation = presentation
+		}
+	case stateNamePresentationSent:
+		if md.presentation != nil {
+			record.Presentation = md.presentation
+		}
+	case stateNameAbandoned:
+		record.Error = abandonReason(md)
+	}
+
+	src, err := json.Marshal(record)
+	if err != nil {
+		return storage.Operation{}, fmt.Errorf("marshal record: %w", err)
+	}
+
+	return storage.Operation{
+		Key:   fmt.Sprintf(recordKey, md.PIID),
+		Value: src,
+		Tags:  []storage.Tag{{Name: recordKey}},
+	}, nil
+}
+
+// updateRecord saves the record of the protocol instance in the given state, if the records are enabled.
+func (s *Service) updateRecord(md *metaData, stateName string) error {
+	if !s.recordsEnabled {
+		return nil
+	}
+
+	op, err := s.recordOperation(md, stateName)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Put(op.Key, op.Value, op.Tags...)
+}
+
+func (s *Service) getRecord(piID string) (*Record, error) {
+	src, err := s.store.Get(fmt.Sprintf(recordKey, piID))
+	if err != nil {
+		return nil, fmt.Errorf("get record: %w", err)
+	}
+
+	record := &Record{}
+
+	if err = json.Unmarshal(src, record); err != nil {
+		return nil, fmt.Errorf("unmarshal record: %w", err)
+	}
+
+	return record, nil
+}
+
+// abandonReason returns the error the protocol instance was stopped with or the code of the received problem report.
+func abandonReason(md *metaData) string {
+	if md.err != nil {
+		return md.err.Error()
+	}
+
+	if md.Msg == nil || md.Msg.Type() != ProblemReportMsgType {
+		return ""
+	}
+
+	report := &model.ProblemReport{}
+	if md.Msg.Decode(report) != nil {
+		return ""
+	}
+
+	return report.Description.Code
+}
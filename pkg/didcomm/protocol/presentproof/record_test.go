@@ -0,0 +1,203 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func newRecordsService(t *testing.T, ctrl *gomock.Controller) (*Service, *serviceMocks.MockMessenger) {
+	t.Helper()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	provider := presentproofMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	svc.EnableRecords()
+
+	return svc, messenger
+}
+
+func sendRequestPresentation(t *testing.T, svc *Service, messenger *serviceMocks.MockMessenger) string {
+	t.Helper()
+
+	messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+	piID, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestPresentation{
+		Type: RequestPresentationMsgType,
+	}), service.NewDIDCommContext(Alice, Bob, nil))
+	require.NoError(t, err)
+
+	return piID
+}
+
+func TestService_Records(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("verifier", func(t *testing.T) {
+		svc, messenger := newRecordsService(t, ctrl)
+
+		piID := sendRequestPresentation(t, svc, messenger)
+
+		record, err := svc.Record(piID)
+		require.NoError(t, err)
+		require.Equal(t, piID, record.PIID)
+		require.Equal(t, piID, record.ThreadID)
+		require.Equal(t, stateNameRequestSent, record.State)
+		require.Equal(t, Alice, record.MyDID)
+		require.Equal(t, Bob, record.TheirDID)
+		require.Nil(t, record.Presentation)
+		require.False(t, record.CreatedAt.IsZero())
+
+		records, err := svc.Records(nil)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+	})
+
+	t.Run("prover", func(t *testing.T) {
+		svc, messenger := newRecordsService(t, ctrl)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Bob).Return(nil)
+
+		msg := randomInboundMessage(RequestPresentationMsgType)
+
+		_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		thID, err := msg.ThreadID()
+		require.NoError(t, err)
+
+		// the instance has no record while its first action is pending
+		_, err = svc.Record(thID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		action := <-ch
+		action.Continue(WithPresentation(&Presentation{Comment: "vp"}))
+
+		require.Eventually(t, func() bool {
+			record, errRecord := svc.Record(thID)
+
+			return errRecord == nil && record.State == stateNameDone
+		}, time.Second, 10*time.Millisecond)
+
+		record, err := svc.Record(thID)
+		require.NoError(t, err)
+		require.Equal(t, thID, record.ThreadID)
+		require.NotNil(t, record.Presentation)
+		require.Equal(t, "vp", record.Presentation.Comment)
+
+		sendRequestPresentation(t, svc, messenger)
+
+		records, err := svc.Records(&RecordFilter{State: stateNameDone})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, thID, records[0].PIID)
+
+		records, err = svc.Records(&RecordFilter{ThreadID: thID, State: stateNameRequestSent})
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		records, err = svc.Records(&RecordFilter{MyDID: Alice, TheirDID: Bob})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+	})
+
+	t.Run("records not enabled", func(t *testing.T) {
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(nil)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = svc.Records(nil)
+		require.True(t, errors.Is(err, errRecordsNotEnabled))
+
+		_, err = svc.Record("piid")
+		require.True(t, errors.Is(err, errRecordsNotEnabled))
+	})
+}
+
+func TestService_Abandon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		svc, messenger := newRecordsService(t, ctrl)
+
+		piID := sendRequestPresentation(t, svc, messenger)
+
+		require.NoError(t, svc.Abandon(piID, "no response"))
+
+		data, err := svc.currentInternalData(piID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameAbandoned, data.StateName)
+
+		record, err := svc.Record(piID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameAbandoned, record.State)
+		require.Equal(t, "no response", record.Error)
+
+		err = svc.Abandon(piID, "no response")
+		require.EqualError(t, err, "protocol instance "+piID+" is already abandoned")
+	})
+
+	t.Run("pending action", func(t *testing.T) {
+		svc, _ := newRecordsService(t, ctrl)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := randomInboundMessage(RequestPresentationMsgType)
+
+		_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		thID, err := msg.ThreadID()
+		require.NoError(t, err)
+
+		require.NoError(t, svc.Abandon(thID, ""))
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+
+		record, err := svc.Record(thID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameAbandoned, record.State)
+		require.Equal(t, thID, record.ThreadID)
+		require.Equal(t, Alice, record.MyDID)
+		require.Empty(t, record.Error)
+	})
+
+	t.Run("unknown protocol instance", func(t *testing.T) {
+		svc, _ := newRecordsService(t, ctrl)
+
+		err := svc.Abandon("piid", "")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
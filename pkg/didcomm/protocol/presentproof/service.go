@@ -15,7 +15,14 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/l10n"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/outbox"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/internal/instance"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -38,6 +45,15 @@ const (
 	PresentationPreviewMsgType = Spec + "presentation-preview"
 )
 
+const (
+	// PresentationVerifiedEvent is the state ID of the message event triggered when the received presentation
+	// was verified by the middleware (e.g. VerifyPresentation middleware). The result of the verification
+	// is provided by the event property VerificationResultPropKey.
+	PresentationVerifiedEvent = "presentation-verified"
+	// VerificationResultPropKey is the key of the event property holding the result of presentation verification.
+	VerificationResultPropKey = "verificationResult"
+)
+
 const (
 	internalDataKey        = "internal_data_"
 	transitionalPayloadKey = "transitionalPayload_%s"
@@ -60,6 +76,8 @@ type transitionalPayload struct {
 	Action
 	StateName   string
 	AckRequired bool
+	// SentRequest is the request-presentation message sent by the Verifier.
+	SentRequest *RequestPresentation `json:",omitempty"`
 }
 
 // metaData type to store data for internal usage.
@@ -73,6 +91,10 @@ type metaData struct {
 	proposePresentation *ProposePresentation
 	request             *RequestPresentation
 	addProofFn          func(presentation *verifiable.Presentation) error
+	// newSenderKey creates the key the connection-less reply is sent from
+	newSenderKey func() (string, error)
+	// catalogs localize the problem reports
+	catalogs *l10n.Registry
 	// err is used to determine whether callback was stopped
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function
@@ -92,6 +114,10 @@ func (md *metaData) ProposePresentation() *ProposePresentation {
 }
 
 func (md *metaData) RequestPresentation() *RequestPresentation {
+	if md.request == nil {
+		return md.SentRequest
+	}
+
 	return md.request
 }
 
@@ -177,6 +203,11 @@ type Provider interface {
 	StorageProvider() storage.Provider
 }
 
+// kmsProvider is implemented by the providers supporting connection-less present-proof (e.g. aries.Context()).
+type kmsProvider interface {
+	KMS() kms.KeyManager
+}
+
 // Service for the presentproof protocol.
 type Service struct {
 	service.Action
@@ -184,7 +215,18 @@ type Service struct {
 	store      storage.Store
 	callbacks  chan *metaData
 	messenger  service.Messenger
+	kms        kms.KeyManager
+	locker     *instance.Locker
+	outbox     *outbox.Outbox
 	middleware Handler
+	// expiry is set by EnableExpiry
+	expiry *expiry.Tracker
+	// recordsEnabled is set by EnableRecords
+	recordsEnabled bool
+	// suggest is set by EnableSuggestions
+	suggest SuggestFunc
+	// catalogs is set by SetMessageCatalogs
+	catalogs *l10n.Registry
 }
 
 // New returns the presentproof service.
@@ -194,7 +236,9 @@ func New(p Provider) (*Service, error) {
 		return nil, err
 	}
 
-	err = p.StorageProvider().SetStoreConfig(Name, storage.StoreConfiguration{TagNames: []string{transitionalPayloadKey}})
+	err = p.StorageProvider().SetStoreConfig(Name, storage.StoreConfiguration{
+		TagNames: []string{transitionalPayloadKey, recordKey, outbox.TagName, expiry.TagName},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store configuration: %w", err)
 	}
@@ -204,6 +248,11 @@ func New(p Provider) (*Service, error) {
 		store:      store,
 		callbacks:  make(chan *metaData),
 		middleware: initialHandler,
+		locker:     instance.NewLocker(p, Name),
+	}
+
+	if kp, ok := p.(kmsProvider); ok {
+		svc.kms = kp.KMS()
 	}
 
 	// start the listener
@@ -222,6 +271,15 @@ func (s *Service) Use(items ...Middleware) {
 	s.middleware = handler
 }
 
+// EnableOutbox persists the outbound messages in the same batch as the protocol state, so they are not lost if
+// the agent stops before sending them. The worker of the returned outbox retrying the failed sends must be started
+// by the caller.
+func (s *Service) EnableOutbox(opts ...outbox.Option) *outbox.Outbox {
+	s.outbox = outbox.New(s.store, s.messenger, opts...)
+
+	return s.outbox
+}
+
 // HandleInbound handles inbound message (presentproof protocol).
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
 	logger.Debugf("service.HandleInbound() input: msg=%+v myDID=%s theirDID=%s", msg, ctx.MyDID(), ctx.TheirDID())
@@ -237,6 +295,13 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		return "", errors.New("no clients are registered to handle the message")
 	}
 
+	unlock, err := s.locker.LockMsg(msgMap)
+	if err != nil {
+		return "", fmt.Errorf("lock: %w", err)
+	}
+
+	defer unlock()
+
 	md, err := s.doHandle(msgMap)
 	if err != nil {
 		return "", fmt.Errorf("doHandle: %w", err)
@@ -245,12 +310,20 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 	md.MyDID = ctx.MyDID()
 	md.TheirDID = ctx.TheirDID()
 
+	if err = s.trackExpiry(md); err != nil {
+		return "", fmt.Errorf("track expiry: %w", err)
+	}
+
 	// trigger action event based on message type for inbound messages
 	if canReply && canTriggerActionEvents(msgMap) {
 		err = s.saveTransitionalPayload(md.PIID, md.transitionalPayload)
 		if err != nil {
 			return "", fmt.Errorf("save transitional payload: %w", err)
 		}
+
+		decorator.AddLocalizedFields(md.properties, md.Msg)
+		s.addSuggestions(md)
+
 		aEvent <- s.newDIDCommActionMsg(md)
 
 		return "", nil
@@ -311,6 +384,7 @@ func (s *Service) doHandle(msg service.DIDCommMsgMap) (*metaData, error) {
 		transitionalPayload: transitionalPayload{
 			StateName:   next.Name(),
 			AckRequired: data.AckRequired,
+			SentRequest: data.SentRequest,
 			Action: Action{
 				Msg:  msg,
 				PIID: piID,
@@ -325,24 +399,58 @@ func (s *Service) doHandle(msg service.DIDCommMsgMap) (*metaData, error) {
 // startInternalListener listens to messages in go channel for callback messages from clients.
 func (s *Service) startInternalListener() {
 	for msg := range s.callbacks {
-		// if no error do handle
-		if msg.err == nil {
-			msg.err = s.handle(msg)
-		}
+		s.handleCallback(msg)
+	}
+}
 
-		// no error - continue
-		if msg.err == nil {
-			continue
-		}
+func (s *Service) handleCallback(msg *metaData) {
+	unlock, err := s.locker.Lock(msg.PIID)
+	if err != nil {
+		logger.Errorf("lock %s: %s", msg.PIID, err)
 
-		logger.Errorf("failed to handle msgID=%s : %s", msg.Msg.ID(), msg.err)
+		return
+	}
 
-		msg.state = &abandoned{Code: codeInternalError}
+	defer unlock()
 
-		if err := s.handle(msg); err != nil {
-			logger.Errorf("listener handle: %s", err)
-		}
+	// another agent instance may have advanced the protocol instance since the message was received
+	if err = s.checkTransition(msg); err != nil {
+		logger.Errorf("failed to handle msgID=%s : %s", msg.Msg.ID(), err)
+
+		return
+	}
+
+	// if no error do handle
+	if msg.err == nil {
+		msg.err = s.handle(msg)
+	}
+
+	// no error - continue
+	if msg.err == nil {
+		return
+	}
+
+	logger.Errorf("failed to handle msgID=%s : %s", msg.Msg.ID(), msg.err)
+
+	msg.state = &abandoned{Code: codeInternalError}
+
+	if err := s.handle(msg); err != nil {
+		logger.Errorf("listener handle: %s", err)
+	}
+}
+
+// checkTransition checks the stored state of the protocol instance can still transition to the state of the message.
+func (s *Service) checkTransition(md *metaData) error {
+	data, err := s.currentInternalData(md.PIID)
+	if err != nil {
+		return fmt.Errorf("current internal data: %w", err)
+	}
+
+	if current := stateFromName(data.StateName); !current.CanTransitionTo(md.state) {
+		return fmt.Errorf("invalid state transition: %s -> %s", current.Name(), md.state.Name())
 	}
+
+	return nil
 }
 
 func isNoOp(s state) bool {
@@ -353,6 +461,12 @@ func isNoOp(s state) bool {
 func (s *Service) handle(md *metaData) error {
 	current := md.state
 
+	if s.kms != nil {
+		md.newSenderKey = s.newSenderKey
+	}
+
+	md.catalogs = s.catalogs
+
 	for !isNoOp(current) {
 		next, action, err := s.execute(current, md)
 		if err != nil {
@@ -364,11 +478,26 @@ func (s *Service) handle(md *metaData) error {
 		}
 
 		// WARN: md.ackRequired is being modified by requestSent state
-		data := &internalData{StateName: current.Name(), AckRequired: md.AckRequired}
+		data := &internalData{StateName: current.Name(), AckRequired: md.AckRequired, SentRequest: md.SentRequest}
+
+		if s.outbox != nil {
+			if err := s.commit(md, data, action); err != nil {
+				return err
+			}
+
+			current = next
+
+			continue
+		}
+
 		if err := s.saveInternalData(md.PIID, data); err != nil {
 			return fmt.Errorf("failed to persist state %s: %w", current.Name(), err)
 		}
 
+		if err := s.updateRecord(md, current.Name()); err != nil {
+			return fmt.Errorf("failed to persist record of state %s: %w", current.Name(), err)
+		}
+
 		if err := action(s.messenger); err != nil {
 			return fmt.Errorf("action %s: %w", md.state.Name(), err)
 		}
@@ -379,6 +508,50 @@ func (s *Service) handle(md *metaData) error {
 	return nil
 }
 
+// commit saves the state with the messages sent by the action in one batch, then sends the messages.
+func (s *Service) commit(md *metaData, data *internalData, action stateAction) error {
+	recorder := s.outbox.NewRecorder()
+
+	if err := action(recorder); err != nil {
+		return fmt.Errorf("action %s: %w", md.state.Name(), err)
+	}
+
+	src, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to persist state %s: %w", data.StateName, err)
+	}
+
+	ops := []storage.Operation{{Key: internalDataKey + md.PIID, Value: src}}
+
+	if s.recordsEnabled {
+		op, errRecord := s.recordOperation(md, data.StateName)
+		if errRecord != nil {
+			return fmt.Errorf("failed to persist record of state %s: %w", data.StateName, errRecord)
+		}
+
+		ops = append(ops, op)
+	}
+
+	err = s.outbox.Commit(recorder, ops...)
+	if err != nil {
+		return fmt.Errorf("failed to persist state %s: %w", data.StateName, err)
+	}
+
+	return nil
+}
+
+// newSenderKey creates a new did:key for the connection-less reply.
+func (s *Service) newSenderKey() (string, error) {
+	_, pubKey, err := s.kms.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	if err != nil {
+		return "", err
+	}
+
+	didKey, _ := fingerprint.CreateDIDKey(pubKey)
+
+	return didKey, nil
+}
+
 func getPIID(msg service.DIDCommMsg) (string, error) {
 	if pthID := msg.ParentThreadID(); pthID != "" {
 		return pthID, nil
@@ -390,6 +563,7 @@ func getPIID(msg service.DIDCommMsg) (string, error) {
 type internalData struct {
 	AckRequired bool
 	StateName   string
+	SentRequest *RequestPresentation `json:",omitempty"`
 }
 
 func (s *Service) saveInternalData(piID string, data *internalData) error {
@@ -650,7 +824,13 @@ func (s *Service) execute(next state, md *metaData) (state, stateAction, error)
 
 	md.properties = newEventProps(md).All()
 
-	if err := s.middleware.Handle(md); err != nil {
+	err := s.middleware.Handle(md)
+
+	if _, ok := md.properties[VerificationResultPropKey]; ok && next.Name() == stateNamePresentationReceived {
+		s.sendMsgEvents(md, PresentationVerifiedEvent, service.PostState)
+	}
+
+	if err != nil {
 		return nil, nil, fmt.Errorf("middleware: %w", err)
 	}
 
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,10 +22,13 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/outbox"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -51,6 +55,31 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, svc)
 	})
 
+	t.Run("Success (with KMS)", func(t *testing.T) {
+		storeProvider := storageMocks.NewMockProvider(ctrl)
+		storeProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+		storeProvider.EXPECT().SetStoreConfig(Name, gomock.Any()).Return(nil)
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(nil)
+		provider.EXPECT().StorageProvider().Return(storeProvider).Times(2)
+
+		svc, err := New(&kmsProviderMock{Provider: provider, kms: &mockkms.KeyManager{
+			CrAndExportPubKeyValue: []byte("pub key"),
+		}})
+		require.NoError(t, err)
+		require.NotNil(t, svc.kms)
+
+		senderKey, err := svc.newSenderKey()
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(senderKey, "did:key:"))
+
+		svc.kms = &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("test")}
+
+		_, err = svc.newSenderKey()
+		require.EqualError(t, err, "test")
+	})
+
 	t.Run("Error open store", func(t *testing.T) {
 		const errMsg = "error"
 
@@ -66,6 +95,15 @@ func TestNew(t *testing.T) {
 	})
 }
 
+type kmsProviderMock struct {
+	Provider
+	kms kms.KeyManager
+}
+
+func (p *kmsProviderMock) KMS() kms.KeyManager {
+	return p.kms
+}
+
 func TestService_Use(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -366,7 +404,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
 			src, err := json.Marshal(&internalData{StateName: "abandoned"})
@@ -421,7 +459,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
 			src, err := json.Marshal(&internalData{StateName: "request-received"})
@@ -579,7 +617,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
 			src, err := json.Marshal(&internalData{StateName: "request-received"})
@@ -639,7 +677,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
@@ -651,7 +689,7 @@ func TestService_HandleInbound(t *testing.T) {
 		})
 
 		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
-			src, err := json.Marshal(&internalData{StateName: "request-sent"})
+			src, err := json.Marshal(&internalData{StateName: "request-sent", SentRequest: &RequestPresentation{}})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
 
@@ -693,7 +731,7 @@ func TestService_HandleInbound(t *testing.T) {
 		src, err := json.Marshal(&internalData{StateName: "request-sent"})
 		require.NoError(t, err)
 
-		store.EXPECT().Get(gomock.Any()).Return(src, nil)
+		store.EXPECT().Get(gomock.Any()).Return(src, nil).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
@@ -739,7 +777,7 @@ func TestService_HandleInbound(t *testing.T) {
 		src, err := json.Marshal(&internalData{StateName: "request-sent"})
 		require.NoError(t, err)
 
-		store.EXPECT().Get(gomock.Any()).Return(src, nil)
+		store.EXPECT().Get(gomock.Any()).Return(src, nil).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
@@ -795,7 +833,7 @@ func TestService_HandleInbound(t *testing.T) {
 				return nil
 			})
 
-		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
@@ -858,7 +896,7 @@ func TestService_HandleInbound(t *testing.T) {
 		src, err := json.Marshal(&internalData{AckRequired: true, StateName: "request-sent"})
 		require.NoError(t, err)
 
-		store.EXPECT().Get(gomock.Any()).Return(src, nil)
+		store.EXPECT().Get(gomock.Any()).Return(src, nil).Times(2)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
@@ -917,6 +955,82 @@ func TestService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Receive Presentation (verified event)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		sentRequest := &RequestPresentation{Type: RequestPresentationMsgType, Comment: "request"}
+
+		src, err := json.Marshal(&internalData{StateName: "request-sent", SentRequest: sentRequest})
+		require.NoError(t, err)
+
+		store.EXPECT().Get(gomock.Any()).Return(src, nil).Times(2)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			defer close(done)
+
+			src, err = json.Marshal(&internalData{StateName: "done", SentRequest: sentRequest})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		svc.Use(func(next Handler) Handler {
+			return HandlerFunc(func(metadata Metadata) error {
+				if metadata.StateName() == stateNamePresentationReceived {
+					require.Equal(t, sentRequest, metadata.RequestPresentation())
+
+					metadata.Properties()[VerificationResultPropKey] = "verified"
+				}
+
+				return next.Handle(metadata)
+			})
+		})
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		chState := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(chState))
+
+		msg := service.NewDIDCommMsgMap(Presentation{Type: PresentationMsgType})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+		msg["~thread"] = decorator.Thread{ID: uuid.New().String()}
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Continue(nil)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		for {
+			select {
+			case e := <-chState:
+				if e.StateID != PresentationVerifiedEvent {
+					continue
+				}
+
+				require.Equal(t, service.PostState, e.Type)
+				require.Equal(t, "verified", e.Properties.All()[VerificationResultPropKey])
+
+				return
+			case <-time.After(time.Second):
+				t.Fatal("presentation-verified event was not triggered")
+			}
+		}
+	})
+
 	t.Run("Receive Ack", func(t *testing.T) {
 		done := make(chan struct{})
 
@@ -955,7 +1069,10 @@ func TestService_HandleInbound(t *testing.T) {
 		done := make(chan struct{})
 
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
-			src, err := json.Marshal(&internalData{StateName: "request-sent"})
+			src, err := json.Marshal(&internalData{
+				StateName:   "request-sent",
+				SentRequest: &RequestPresentation{Type: RequestPresentationMsgType},
+			})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
 
@@ -1165,3 +1282,69 @@ func Test_nextState(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, next)
 }
+
+func TestService_EnableOutbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	provider := presentproofMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	o := svc.EnableOutbox(outbox.WithRetryInterval(0))
+
+	ch := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(ch))
+
+	failed := make(chan struct{})
+
+	// the state is saved although the message could not be sent
+	messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Bob).
+		DoAndReturn(func(_, _ service.DIDCommMsgMap, _, _ string) error {
+			defer close(failed)
+
+			return errors.New("unreachable")
+		})
+
+	_, err = svc.HandleInbound(randomInboundMessage(ProposePresentationMsgType),
+		service.NewDIDCommContext(Alice, Bob, nil))
+	require.NoError(t, err)
+
+	action := <-ch
+	action.Continue(WithRequestPresentation(&RequestPresentation{}))
+
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Error("timeout")
+	}
+
+	properties, ok := action.Properties.(*eventProps)
+	require.True(t, ok)
+
+	piid := properties.PIID()
+
+	require.Eventually(t, func() bool {
+		data, errData := svc.currentInternalData(piid)
+
+		return errData == nil && data.StateName == stateNameRequestSent
+	}, time.Second, 10*time.Millisecond)
+
+	messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Bob).
+		Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+			r := &RequestPresentation{}
+			require.NoError(t, msg.Decode(r))
+			require.Equal(t, RequestPresentationMsgType, r.Type)
+
+			return nil
+		})
+
+	sent, err := o.Dispatch()
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+}
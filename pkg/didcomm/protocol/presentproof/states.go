@@ -12,6 +12,8 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/l10n"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 )
 
 const (
@@ -114,10 +116,15 @@ func (s *abandoned) Execute(md *metaData) (state, stateAction, error) {
 	}
 
 	return &noOp{}, func(messenger service.Messenger) error {
-		return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReport{
+		report := &model.ProblemReport{
 			Type:        ProblemReportMsgType,
 			Description: code,
-		}), &service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
+		}
+
+		md.catalogs.Localize(report)
+
+		return messenger.ReplyToNested(l10n.ProblemReportMsg(report),
+			&service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
 	}, nil
 }
 
@@ -175,7 +182,7 @@ func (s *requestReceived) Execute(md *metaData) (state, stateAction, error) {
 		return nil, nil, err
 	}
 
-	return &presentationSent{WillConfirm: req.WillConfirm}, zeroAction, nil
+	return &presentationSent{WillConfirm: req.WillConfirm, ReplyTo: req.Service}, zeroAction, nil
 }
 
 // requestSent the Verifier's state.
@@ -206,6 +213,14 @@ func (s *requestSent) Execute(md *metaData) (state, stateAction, error) {
 		}
 
 		md.AckRequired = req.WillConfirm
+		md.SentRequest = req
+
+		// the connection-less request is delivered to the Prover by the caller (e.g. within the invitation)
+		if req.Service != nil {
+			md.AckRequired = false
+
+			return &noOp{}, zeroAction, nil
+		}
 
 		return &noOp{}, forwardInitial(md), nil
 	}
@@ -215,6 +230,7 @@ func (s *requestSent) Execute(md *metaData) (state, stateAction, error) {
 	}
 
 	md.AckRequired = md.request.WillConfirm
+	md.SentRequest = md.request
 
 	return &noOp{}, func(messenger service.Messenger) error {
 		md.request.Type = RequestPresentationMsgType
@@ -225,6 +241,8 @@ func (s *requestSent) Execute(md *metaData) (state, stateAction, error) {
 // presentationSent the Prover's state.
 type presentationSent struct {
 	WillConfirm bool
+	// ReplyTo is the service decorator of the connection-less request.
+	ReplyTo *decorator.Service
 }
 
 func (s *presentationSent) Name() string {
@@ -241,6 +259,10 @@ func (s *presentationSent) Execute(md *metaData) (state, stateAction, error) {
 		return nil, nil, errors.New("presentation was not provided")
 	}
 
+	if s.ReplyTo != nil {
+		return &done{}, replyToService(md, s.ReplyTo), nil
+	}
+
 	// creates the state's action
 	action := func(messenger service.Messenger) error {
 		// sets message type
@@ -255,6 +277,30 @@ func (s *presentationSent) Execute(md *metaData) (state, stateAction, error) {
 	return &noOp{}, action, nil
 }
 
+// replyToService sends the presentation to the service endpoint of the connection-less request.
+// The Verifier is not able to reply, thereby the confirmation is not expected.
+func replyToService(md *metaData, svc *decorator.Service) stateAction {
+	return func(messenger service.Messenger) error {
+		if md.newSenderKey == nil {
+			return errors.New("connection-less reply is not supported: no key manager")
+		}
+
+		senderKey, err := md.newSenderKey()
+		if err != nil {
+			return fmt.Errorf("create sender key: %w", err)
+		}
+
+		md.presentation.Type = PresentationMsgType
+
+		return messenger.ReplyToMsgDestination(md.Msg, service.NewDIDCommMsgMap(md.presentation), senderKey,
+			&service.Destination{
+				RecipientKeys:   svc.RecipientKeys,
+				RoutingKeys:     svc.RoutingKeys,
+				ServiceEndpoint: svc.ServiceEndpoint,
+			})
+	}
+}
+
 // presentationReceived the Verifier's state.
 type presentationReceived struct{}
 
@@ -217,6 +217,21 @@ func TestRequestReceived_Execute(t *testing.T) {
 		require.NoError(t, action(nil))
 	})
 
+	t.Run("With presentation - connection-less", func(t *testing.T) {
+		replyTo := &decorator.Service{ServiceEndpoint: "http://example.com"}
+
+		msg := randomInboundMessage(RequestPresentationMsgType)
+		msg["~service"] = replyTo
+
+		followup, action, err := (&requestReceived{}).Execute(&metaData{
+			presentation:        &Presentation{},
+			transitionalPayload: transitionalPayload{Action: Action{Msg: msg}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &presentationSent{ReplyTo: replyTo}, followup)
+		require.NoError(t, action(nil))
+	})
+
 	t.Run("Without presentation", func(t *testing.T) {
 		followup, action, err := (&requestReceived{}).Execute(&metaData{})
 		require.NoError(t, err)
@@ -314,6 +329,22 @@ func TestRequestSent_Execute(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("Success (connection-less)", func(t *testing.T) {
+		md := &metaData{transitionalPayload: transitionalPayload{
+			Action: Action{Msg: service.NewDIDCommMsgMap(RequestPresentation{
+				WillConfirm: true,
+				Service:     &decorator.Service{ServiceEndpoint: "http://example.com"},
+			})},
+		}}
+
+		followup, action, err := (&requestSent{}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.False(t, md.AckRequired)
+		require.NotNil(t, md.SentRequest.Service)
+		require.NoError(t, action(nil))
+	})
+
 	t.Run("Message decode error", func(t *testing.T) {
 		followup, action, err := (&requestSent{}).Execute(&metaData{transitionalPayload: transitionalPayload{
 			Action: Action{Msg: service.DIDCommMsgMap{"@type": []int{1}}},
@@ -375,6 +406,49 @@ func TestPresentationSent_Execute(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("Success (connection-less)", func(t *testing.T) {
+		replyTo := &decorator.Service{
+			RecipientKeys:   []string{"did:key:recipient"},
+			RoutingKeys:     []string{"did:key:routing"},
+			ServiceEndpoint: "http://example.com",
+		}
+
+		followup, action, err := (&presentationSent{WillConfirm: true, ReplyTo: replyTo}).Execute(&metaData{
+			presentation: &Presentation{},
+			newSenderKey: func() (string, error) { return "did:key:sender", nil },
+		})
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsgDestination(gomock.Any(), gomock.Any(), "did:key:sender", &service.Destination{
+			RecipientKeys:   replyTo.RecipientKeys,
+			RoutingKeys:     replyTo.RoutingKeys,
+			ServiceEndpoint: replyTo.ServiceEndpoint,
+		})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Connection-less reply errors", func(t *testing.T) {
+		replyTo := &decorator.Service{ServiceEndpoint: "http://example.com"}
+
+		_, action, err := (&presentationSent{ReplyTo: replyTo}).Execute(&metaData{presentation: &Presentation{}})
+		require.NoError(t, err)
+		require.EqualError(t, action(nil), "connection-less reply is not supported: no key manager")
+
+		_, action, err = (&presentationSent{ReplyTo: replyTo}).Execute(&metaData{
+			presentation: &Presentation{},
+			newSenderKey: func() (string, error) { return "", errors.New("test") },
+		})
+		require.NoError(t, err)
+		require.EqualError(t, action(nil), "create sender key: test")
+	})
+
 	t.Run("Presentation is absent", func(t *testing.T) {
 		followup, action, err := (&presentationSent{}).Execute(&metaData{})
 		require.EqualError(t, err, "presentation was not provided")
@@ -0,0 +1,48 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+// SuggestionsPropKey is the key of the action event property holding the credentials suggested to the Prover
+// for the received request presentation (see EnableSuggestions).
+const SuggestionsPropKey = "suggestions"
+
+// SuggestFunc returns the credentials suggested to the Prover for the request presentation,
+// nil if there is nothing to suggest.
+type SuggestFunc func(request *RequestPresentation) (interface{}, error)
+
+// EnableSuggestions computes the credentials suggested for each received request presentation, so wallets can
+// offer a ready-made chooser. The suggestions are provided by the action event property SuggestionsPropKey.
+func (s *Service) EnableSuggestions(suggest SuggestFunc) {
+	s.suggest = suggest
+}
+
+// addSuggestions sets the suggestions of the received request presentation to the properties of the action event.
+// The failures are logged only, the Prover still can choose the credentials by other means.
+func (s *Service) addSuggestions(md *metaData) {
+	if s.suggest == nil || md.Msg.Type() != RequestPresentationMsgType {
+		return
+	}
+
+	request := &RequestPresentation{}
+
+	if err := md.Msg.Decode(request); err != nil {
+		logger.Warnf("suggestions: decode request presentation %s: %s", md.PIID, err)
+
+		return
+	}
+
+	suggestions, err := s.suggest(request)
+	if err != nil {
+		logger.Warnf("suggestions: suggest credentials for %s: %s", md.PIID, err)
+
+		return
+	}
+
+	if suggestions != nil {
+		md.properties[SuggestionsPropKey] = suggestions
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+func TestService_EnableSuggestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	receiveRequest := func(t *testing.T, svc *Service, msg service.DIDCommMsgMap) service.DIDCommAction {
+		t.Helper()
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		return <-ch
+	}
+
+	t.Run("Provides the suggestions", func(t *testing.T) {
+		svc, _ := newRecordsService(t, ctrl)
+
+		svc.EnableSuggestions(func(request *RequestPresentation) (interface{}, error) {
+			require.Equal(t, "degree", request.Comment)
+
+			return []string{"http://example.edu/credentials/1872"}, nil
+		})
+
+		msg := randomInboundMessage(RequestPresentationMsgType)
+		msg["comment"] = "degree"
+
+		action := receiveRequest(t, svc, msg)
+		require.Equal(t, []string{"http://example.edu/credentials/1872"},
+			action.Properties.All()[SuggestionsPropKey])
+	})
+
+	t.Run("Ignores the other messages", func(t *testing.T) {
+		svc, _ := newRecordsService(t, ctrl)
+
+		svc.EnableSuggestions(func(*RequestPresentation) (interface{}, error) {
+			require.FailNow(t, "unexpected call")
+
+			return nil, nil
+		})
+
+		action := receiveRequest(t, svc, randomInboundMessage(ProposePresentationMsgType))
+		require.NotContains(t, action.Properties.All(), SuggestionsPropKey)
+	})
+
+	t.Run("Suggest error", func(t *testing.T) {
+		svc, _ := newRecordsService(t, ctrl)
+
+		svc.EnableSuggestions(func(*RequestPresentation) (interface{}, error) {
+			return nil, errors.New("suggest error")
+		})
+
+		action := receiveRequest(t, svc, randomInboundMessage(RequestPresentationMsgType))
+		require.NotContains(t, action.Properties.All(), SuggestionsPropKey)
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		svc, _ := newRecordsService(t, ctrl)
+
+		svc.EnableSuggestions(func(*RequestPresentation) (interface{}, error) {
+			require.FailNow(t, "unexpected call")
+
+			return nil, nil
+		})
+
+		md := &metaData{properties: map[string]interface{}{}}
+		md.Msg = service.DIDCommMsgMap{"@type": RequestPresentationMsgType, "comment": map[string]int{}}
+
+		svc.addSuggestions(md)
+		require.Empty(t, md.properties)
+	})
+}
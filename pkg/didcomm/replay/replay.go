@@ -0,0 +1,429 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package replay detects the replay of the inbound DIDComm protocol messages carrying single-use values
+// (the challenges of the presented proofs, the signatures of the did-exchange messages), e.g. resent with a new
+// message ID by a malicious mediator. Unlike the redelivered messages (see dedup package), the replayed messages
+// are rejected.
+package replay
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for seen nonce store.
+	NameSpace = "inbound_nonce"
+
+	// DefaultTTL is the default period a nonce is remembered for.
+	DefaultTTL = 24 * time.Hour
+
+	seenKeyPrefix = "nonce_"
+	seenTagName   = "nonce"
+)
+
+var logger = log.New("aries-framework/didcomm/replay")
+
+// ErrReplay is returned when the message carries a nonce already seen within the TTL.
+var ErrReplay = errors.New("message replay detected")
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// NonceExtractor returns the single-use values (nonces, challenges, signatures) carried by the message.
+type NonceExtractor func(msg service.DIDCommMsgMap) ([]string, error)
+
+// Guard remembers the nonces of the inbound messages handled within the TTL.
+type Guard struct {
+	store      storage.Store
+	ttl        time.Duration
+	now        func() time.Time
+	extractors map[string]NonceExtractor
+
+	// mutex makes the check and the reservation of the nonces of a message atomic.
+	mutex     sync.Mutex
+	lastPurge time.Time
+	purging   bool
+	purged    chan struct{}
+}
+
+// Option configures the replay guard.
+type Option func(g *Guard)
+
+// WithNonceExtractor sets the extractor of the nonces of the messages of the given type, replacing the default
+// one if any. By default, the challenges of the present-proof presentations, the signatures of the did_doc~attach
+// of the did-exchange requests and the signatures of the did-exchange responses (connection~sig, did_doc~attach
+// and did_rotate~attach) are guarded.
+func WithNonceExtractor(msgType string, extractor NonceExtractor) Option {
+	return func(g *Guard) {
+		g.extractors[msgType] = extractor
+	}
+}
+
+type seen struct {
+	Time time.Time `json:"time"`
+}
+
+// New returns a new replay guard remembering the nonces for the given TTL (DefaultTTL if not positive).
+func New(ctx provider, ttl time.Duration, opts ...Option) (*Guard, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open nonce store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace, storage.StoreConfiguration{TagNames: []string{seenTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set nonce store configuration: %w", err)
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	g := &Guard{
+		store: store,
+		ttl:   ttl,
+		now:   time.Now,
+		extractors: map[string]NonceExtractor{
+			presentproof.PresentationMsgType: PresentationChallenges,
+			didexchange.RequestMsgType:       RequestSignatures,
+			didexchange.RequestMsgTypeV11:    RequestSignatures,
+			didexchange.ResponseMsgType:      ResponseSignatures,
+			didexchange.ResponseMsgTypeV11:   ResponseSignatures,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g, nil
+}
+
+// Reserve returns ErrReplay if a nonce of the message was already seen within the TTL, otherwise it records
+// the nonces of the message as seen. The check and the record are atomic within the agent instance. The nonces
+// of the messages seen before the TTL are purged in the background once per TTL.
+func (g *Guard) Reserve(msg service.DIDCommMsgMap) error {
+	nonces, err := g.nonces(msg)
+	if err != nil || len(nonces) == 0 {
+		return err
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	now := g.now()
+
+	g.startPurge(now)
+
+	for _, nonce := range nonces {
+		seenAt, errGet := g.seenAt(key(nonce))
+		if errGet != nil {
+			return errGet
+		}
+
+		if seenAt != nil && now.Sub(*seenAt) < g.ttl {
+			return fmt.Errorf("%w: nonce of %s message was already seen", ErrReplay, msg.Type())
+		}
+	}
+
+	src, err := json.Marshal(&seen{Time: now})
+	if err != nil {
+		return fmt.Errorf("marshal seen nonce: %w", err)
+	}
+
+	for _, nonce := range nonces {
+		if err = g.store.Put(key(nonce), src, storage.Tag{Name: seenTagName}); err != nil {
+			return fmt.Errorf("save seen nonce: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Forget removes the nonces of the message reserved by Reserve. It is called when the message handling failed,
+// so that the message redelivered after a failure is handled again.
+func (g *Guard) Forget(msg service.DIDCommMsgMap) error {
+	nonces, err := g.nonces(msg)
+	if err != nil {
+		return err
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, nonce := range nonces {
+		if err = g.store.Delete(key(nonce)); err != nil {
+			return fmt.Errorf("delete seen nonce: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (g *Guard) seenAt(k string) (*time.Time, error) {
+	src, err := g.store.Get(k)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get seen nonce: %w", err)
+	}
+
+	var rec seen
+	if err = json.Unmarshal(src, &rec); err != nil {
+		return nil, fmt.Errorf("unmarshal seen nonce: %w", err)
+	}
+
+	return &rec.Time, nil
+}
+
+// startPurge starts the purge of the nonces seen before the TTL, unless one is running or ran within the TTL.
+func (g *Guard) startPurge(now time.Time) {
+	if g.purging || now.Sub(g.lastPurge) < g.ttl {
+		return
+	}
+
+	g.purging = true
+	g.purged = make(chan struct{})
+
+	go func() {
+		err := g.purge(now)
+
+		g.mutex.Lock()
+		defer g.mutex.Unlock()
+
+		if err != nil {
+			logger.Warnf("failed to purge seen nonces: %s", err)
+		} else {
+			g.lastPurge = now
+		}
+
+		g.purging = false
+		close(g.purged)
+	}()
+}
+
+// purge deletes the nonces seen before the TTL. The expired nonces are checked again under the mutex before they
+// are deleted, a nonce reserved again meanwhile is kept.
+func (g *Guard) purge(now time.Time) error {
+	itr, err := g.store.Query(seenTagName)
+	if err != nil {
+		return fmt.Errorf("query seen nonces: %w", err)
+	}
+
+	defer storage.Close(itr, logger)
+
+	var expired []string
+
+	more, err := itr.Next()
+	if err != nil {
+		return fmt.Errorf("iterate seen nonces: %w", err)
+	}
+
+	for more {
+		src, errValue := itr.Value()
+		if errValue != nil {
+			return fmt.Errorf("get seen nonce value: %w", errValue)
+		}
+
+		if g.expired(src, now) {
+			k, errKey := itr.Key()
+			if errKey != nil {
+				return fmt.Errorf("get seen nonce key: %w", errKey)
+			}
+
+			expired = append(expired, k)
+		}
+
+		more, err = itr.Next()
+		if err != nil {
+			return fmt.Errorf("iterate seen nonces: %w", err)
+		}
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, k := range expired {
+		src, errGet := g.store.Get(k)
+		if errors.Is(errGet, storage.ErrDataNotFound) {
+			continue
+		}
+
+		if errGet != nil {
+			return fmt.Errorf("get seen nonce: %w", errGet)
+		}
+
+		if !g.expired(src, now) {
+			continue
+		}
+
+		if err = g.store.Delete(k); err != nil {
+			return fmt.Errorf("delete seen nonce: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// expired returns true if the nonce record was seen before the TTL or is invalid.
+func (g *Guard) expired(src []byte, now time.Time) bool {
+	var rec seen
+	if err := json.Unmarshal(src, &rec); err != nil {
+		return true
+	}
+
+	return now.Sub(rec.Time) >= g.ttl
+}
+
+func (g *Guard) nonces(msg service.DIDCommMsgMap) ([]string, error) {
+	extract, ok := g.extractors[msg.Type()]
+	if !ok {
+		return nil, nil
+	}
+
+	nonces, err := extract(msg)
+	if err != nil {
+		return nil, fmt.Errorf("extract nonces of %s message: %w", msg.Type(), err)
+	}
+
+	return nonces, nil
+}
+
+// PresentationChallenges returns the challenges of the proofs of the presentations attached
+// to the present-proof presentation message.
+func PresentationChallenges(msg service.DIDCommMsgMap) ([]string, error) {
+	var presentation struct {
+		PresentationsAttach []decorator.Attachment `json:"presentations~attach,omitempty"`
+	}
+
+	if err := msg.Decode(&presentation); err != nil {
+		return nil, fmt.Errorf("decode presentation: %w", err)
+	}
+
+	var challenges []string
+
+	for _, attachment := range presentation.PresentationsAttach {
+		vp, err := attachedJSON(attachment.Data)
+		if err != nil || vp == nil {
+			// not a JSON-LD presentation (e.g. JWT), its proof is not embedded
+			continue
+		}
+
+		proofs, ok := vp["proof"].([]interface{})
+		if !ok {
+			proofs = []interface{}{vp["proof"]}
+		}
+
+		for _, p := range proofs {
+			proof, _ := p.(map[string]interface{})                            //nolint:errcheck
+			if challenge, _ := proof["challenge"].(string); challenge != "" { //nolint:errcheck
+				challenges = append(challenges, challenge)
+			}
+		}
+	}
+
+	return challenges, nil
+}
+
+// RequestSignatures returns the signature of the did_doc~attach of the did-exchange request message.
+func RequestSignatures(msg service.DIDCommMsgMap) ([]string, error) {
+	request := didexchange.Request{}
+
+	if err := msg.Decode(&request); err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+
+	return attachmentSignatures(request.DocAttach), nil
+}
+
+// ResponseSignatures returns the signatures of the did-exchange response message: its connection signature
+// (did-exchange 1.0) and the signatures of its did_doc~attach and did_rotate~attach. The signed did_rotate~attach
+// only holds the DID of the responder, the same for all the responses of a public DID, so the signatures are
+// bound to the thread of the response.
+func ResponseSignatures(msg service.DIDCommMsgMap) ([]string, error) {
+	response := didexchange.Response{}
+
+	if err := msg.Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	var signatures []string
+
+	if response.ConnectionSignature != nil && response.ConnectionSignature.Signature != "" {
+		signatures = append(signatures, response.ConnectionSignature.Signature)
+	}
+
+	signatures = append(signatures, attachmentSignatures(response.DocAttach, response.DIDRotateAttach)...)
+
+	if response.Thread == nil || response.Thread.ID == "" {
+		return signatures, nil
+	}
+
+	for i := range signatures {
+		signatures[i] = response.Thread.ID + "_" + signatures[i]
+	}
+
+	return signatures, nil
+}
+
+func attachmentSignatures(attachments ...*decorator.Attachment) []string {
+	var signatures []string
+
+	for _, a := range attachments {
+		if a != nil && a.Data.JWS != nil && a.Data.JWS.Signature != "" {
+			signatures = append(signatures, a.Data.JWS.Signature)
+		}
+	}
+
+	return signatures
+}
+
+func attachedJSON(data decorator.AttachmentData) (map[string]interface{}, error) {
+	if m, ok := data.JSON.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	if data.Base64 == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(data.Base64)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+
+	if err = json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func key(nonce string) string {
+	hash := sha256.Sum256([]byte(nonce))
+
+	return seenKeyPrefix + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,323 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replay
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func presentationMsg(id string, data map[string]interface{}) service.DIDCommMsgMap {
+	return service.DIDCommMsgMap{
+		"@id":   id,
+		"@type": presentproof.PresentationMsgType,
+		"presentations~attach": []interface{}{
+			map[string]interface{}{"data": data},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, 0)
+		require.NoError(t, err)
+		require.Equal(t, DefaultTTL, g.ttl)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}}, time.Minute)
+		require.EqualError(t, err, "failed to open nonce store: open error")
+	})
+}
+
+func TestGuard(t *testing.T) {
+	t.Run("replayed presentation within the TTL", func(t *testing.T) {
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		now := time.Now()
+		g.now = func() time.Time { return now }
+
+		msg := presentationMsg("msg-1", map[string]interface{}{
+			"json": map[string]interface{}{"proof": map[string]interface{}{"challenge": "challenge-1"}},
+		})
+
+		require.NoError(t, g.Reserve(msg))
+
+		// replayed with another message ID
+		err = g.Reserve(presentationMsg("msg-2", map[string]interface{}{
+			"base64": base64.StdEncoding.EncodeToString(
+				[]byte(`{"proof": [{"challenge": "challenge-2"}, {"challenge": "challenge-1"}]}`)),
+		}))
+		require.True(t, errors.Is(err, ErrReplay))
+		require.Contains(t, err.Error(), presentproof.PresentationMsgType)
+
+		// the nonces of a rejected message are not reserved
+		require.NoError(t, g.Reserve(presentationMsg("msg-3", map[string]interface{}{
+			"json": map[string]interface{}{"proof": map[string]interface{}{"challenge": "challenge-2"}},
+		})))
+
+		// the TTL has elapsed
+		now = now.Add(time.Minute)
+
+		require.NoError(t, g.Reserve(msg))
+		require.True(t, errors.Is(g.Reserve(msg), ErrReplay))
+	})
+
+	t.Run("forgotten nonces are reserved again", func(t *testing.T) {
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		msg := presentationMsg("msg-1", map[string]interface{}{
+			"json": map[string]interface{}{"proof": map[string]interface{}{"challenge": "challenge-1"}},
+		})
+
+		require.NoError(t, g.Reserve(msg))
+		require.NoError(t, g.Forget(msg))
+		require.NoError(t, g.Reserve(msg))
+	})
+
+	t.Run("concurrent replays", func(t *testing.T) {
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		const replays = 10
+
+		results := make(chan error, replays)
+
+		for i := 0; i < replays; i++ {
+			go func(i int) {
+				results <- g.Reserve(presentationMsg(fmt.Sprintf("msg-%d", i), map[string]interface{}{
+					"json": map[string]interface{}{"proof": map[string]interface{}{"challenge": "challenge-1"}},
+				}))
+			}(i)
+		}
+
+		reserved := 0
+
+		for i := 0; i < replays; i++ {
+			if err = <-results; err == nil {
+				reserved++
+			} else {
+				require.True(t, errors.Is(err, ErrReplay))
+			}
+		}
+
+		require.Equal(t, 1, reserved)
+	})
+
+	t.Run("replayed did-exchange response", func(t *testing.T) {
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&didexchange.Response{
+			ID:                  "msg-1",
+			Type:                didexchange.ResponseMsgType,
+			ConnectionSignature: &didexchange.ConnectionSignature{Signature: "signature"},
+		})
+
+		require.NoError(t, g.Reserve(msg))
+
+		msg.SetID("msg-2") // nolint: errcheck
+
+		require.True(t, errors.Is(g.Reserve(msg), ErrReplay))
+	})
+
+	t.Run("replayed did-exchange 1.1 messages with signed attachments", func(t *testing.T) {
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		signed := func(signature string) *decorator.Attachment {
+			return &decorator.Attachment{Data: decorator.AttachmentData{
+				Base64: "ZGF0YQ==",
+				JWS:    &decorator.AttachmentJWS{Signature: signature},
+			}}
+		}
+
+		request := service.NewDIDCommMsgMap(&didexchange.Request{
+			ID:        "msg-1",
+			Type:      didexchange.RequestMsgTypeV11,
+			DocAttach: signed("request-signature"),
+		})
+
+		require.NoError(t, g.Reserve(request))
+
+		request.SetID("msg-2") // nolint: errcheck
+
+		require.True(t, errors.Is(g.Reserve(request), ErrReplay))
+
+		response := func(id, thid string) service.DIDCommMsgMap {
+			return service.NewDIDCommMsgMap(&didexchange.Response{
+				ID:              id,
+				Type:            didexchange.ResponseMsgTypeV11,
+				Thread:          &decorator.Thread{ID: thid},
+				DIDRotateAttach: signed("rotate-signature"),
+			})
+		}
+
+		require.NoError(t, g.Reserve(response("msg-3", "thread-1")))
+		require.True(t, errors.Is(g.Reserve(response("msg-4", "thread-1")), ErrReplay))
+
+		// the same public DID responding on another thread
+		require.NoError(t, g.Reserve(response("msg-5", "thread-2")))
+	})
+
+	t.Run("messages without nonce", func(t *testing.T) {
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute)
+		require.NoError(t, err)
+
+		for _, msg := range []service.DIDCommMsgMap{
+			{"@id": "msg-1", "@type": "https://didcomm.org/basicmessage/1.0/message"},
+			{"@id": "msg-1", "@type": didexchange.ResponseMsgType},
+			{"@id": "msg-1", "@type": didexchange.RequestMsgType},
+			presentationMsg("msg-1", map[string]interface{}{"base64": "invalid"}),
+			presentationMsg("msg-1", map[string]interface{}{"json": map[string]interface{}{}}),
+		} {
+			require.NoError(t, g.Reserve(msg))
+			require.NoError(t, g.Reserve(msg))
+			require.NoError(t, g.Forget(msg))
+		}
+	})
+
+	t.Run("custom nonce extractor", func(t *testing.T) {
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, time.Minute,
+			WithNonceExtractor("custom-type", func(msg service.DIDCommMsgMap) ([]string, error) {
+				nonce, _ := msg["nonce"].(string) //nolint:errcheck
+
+				return []string{nonce}, nil
+			}),
+			WithNonceExtractor("invalid-type", func(msg service.DIDCommMsgMap) ([]string, error) {
+				return nil, errors.New("extract error")
+			}))
+		require.NoError(t, err)
+
+		msg := service.DIDCommMsgMap{"@id": "msg-1", "@type": "custom-type", "nonce": "nonce-1"}
+
+		require.NoError(t, g.Reserve(msg))
+		require.True(t, errors.Is(g.Reserve(msg), ErrReplay))
+
+		msg = service.DIDCommMsgMap{"@id": "msg-1", "@type": "invalid-type"}
+
+		require.EqualError(t, g.Reserve(msg), "extract nonces of invalid-type message: extract error")
+		require.EqualError(t, g.Forget(msg), "extract nonces of invalid-type message: extract error")
+	})
+
+	t.Run("storage errors", func(t *testing.T) {
+		msg := presentationMsg("msg-1", map[string]interface{}{
+			"json": map[string]interface{}{"proof": map[string]interface{}{"challenge": "challenge-1"}},
+		})
+
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewCustomMockStoreProvider(
+			&mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}, ErrGet: errors.New("get error")},
+		)}, time.Minute)
+		require.NoError(t, err)
+
+		require.EqualError(t, g.Reserve(msg), "get seen nonce: get error")
+
+		g, err = New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewCustomMockStoreProvider(
+			&mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}, ErrPut: errors.New("put error")},
+		)}, time.Minute)
+		require.NoError(t, err)
+
+		require.EqualError(t, g.Reserve(msg), "save seen nonce: put error")
+
+		g, err = New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewCustomMockStoreProvider(
+			&mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}, ErrDelete: errors.New("delete error")},
+		)}, time.Minute)
+		require.NoError(t, err)
+
+		require.EqualError(t, g.Forget(msg), "delete seen nonce: delete error")
+
+		store := &mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}}
+
+		g, err = New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewCustomMockStoreProvider(store)},
+			time.Minute)
+		require.NoError(t, err)
+
+		g.lastPurge = time.Now()
+		store.Store[key("challenge-1")] = mockstorage.DBEntry{Value: []byte("{")}
+
+		require.Contains(t, g.Reserve(msg).Error(), "unmarshal seen nonce")
+	})
+}
+
+func TestGuard_Purge(t *testing.T) {
+	store := &mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}}
+
+	g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewCustomMockStoreProvider(store)},
+		time.Minute)
+	require.NoError(t, err)
+
+	now := time.Now()
+	g.now = func() time.Time { return now }
+
+	reserve := func(challenge string) {
+		require.NoError(t, g.Reserve(presentationMsg("msg", map[string]interface{}{
+			"json": map[string]interface{}{"proof": map[string]interface{}{"challenge": challenge}},
+		})))
+	}
+
+	reserve("challenge-1")
+	<-g.purged
+
+	now = now.Add(30 * time.Second)
+
+	reserve("challenge-2")
+
+	store.Store[key("invalid")] = mockstorage.DBEntry{Value: []byte("{"), Tags: []storage.Tag{{Name: seenTagName}}}
+
+	// the purge runs once per TTL, challenge-1 has expired but challenge-2 has not
+	now = now.Add(45 * time.Second)
+
+	reserve("challenge-3")
+	<-g.purged
+
+	_, err = store.Get(key("challenge-1"))
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, err = store.Get(key("invalid"))
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, err = store.Get(key("challenge-2"))
+	require.NoError(t, err)
+
+	_, err = store.Get(key("challenge-3"))
+	require.NoError(t, err)
+
+	t.Run("query error", func(t *testing.T) {
+		g, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewCustomMockStoreProvider(
+			&mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}, ErrQuery: errors.New("query error")},
+		)}, time.Minute)
+		require.NoError(t, err)
+
+		require.EqualError(t, g.purge(time.Now()), "query seen nonces: query error")
+
+		reserve := presentationMsg("msg", map[string]interface{}{
+			"json": map[string]interface{}{"proof": map[string]interface{}{"challenge": "challenge"}},
+		})
+
+		require.NoError(t, g.Reserve(reserve))
+		<-g.purged
+
+		require.True(t, g.lastPurge.IsZero())
+	})
+}
@@ -0,0 +1,240 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package rotation handles the DID rotation of the other agents, as per
+// https://identity.foundation/didcomm-messaging/spec/#did-rotation. An agent that rotates its DID adds the
+// `from_prior` header to the messages it sends with the new DID: a JWT signed with a key of the prior DID whose
+// `iss` claim is the prior DID and `sub` claim is the new DID. Once the JWT is validated, the connections with
+// the prior DID are moved to the new DID so the messages sent by the new DID keep being routed to them.
+package rotation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/didcomm/rotation")
+
+const (
+	// FromPriorHeader is the DIDComm v2 message header carrying the DID rotation JWT.
+	FromPriorHeader = "from_prior"
+
+	fromHeader = "from"
+
+	// maxClockSkew is the tolerance of the `iat` claim issued in the future.
+	maxClockSkew = 5 * time.Minute
+)
+
+// FromPrior contains the claims of the `from_prior` JWT.
+type FromPrior struct {
+	// Sub is the new DID of the sender.
+	Sub string `json:"sub"`
+	// Iss is the prior DID of the sender.
+	Iss string `json:"iss"`
+	// Iat is the time the rotation was issued at (seconds since the epoch).
+	Iat int64 `json:"iat,omitempty"`
+}
+
+// Event is sent when the DID of the other agent of some connections was rotated.
+type Event struct {
+	PriorDID      string
+	NewDID        string
+	ConnectionIDs []string
+}
+
+// Provider contains dependencies for the Rotator.
+type Provider interface {
+	VDRegistry() vdrapi.Registry
+	DIDConnectionStore() didstore.ConnectionStore
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Rotator validates the `from_prior` header of the inbound messages and applies the DID rotation
+// to the connections.
+type Rotator struct {
+	vdr                vdrapi.Registry
+	didConnectionStore didstore.ConnectionStore
+	connectionRecorder *connection.Recorder
+	now                func() time.Time
+
+	mu     sync.RWMutex
+	events []chan<- Event
+}
+
+// New returns a new Rotator.
+func New(p Provider) (*Rotator, error) {
+	recorder, err := connection.NewRecorder(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection recorder: %w", err)
+	}
+
+	return &Rotator{
+		vdr:                p.VDRegistry(),
+		didConnectionStore: p.DIDConnectionStore(),
+		connectionRecorder: recorder,
+		now:                time.Now,
+	}, nil
+}
+
+// RegisterEvent registers a channel to receive the DID rotation events.
+func (r *Rotator) RegisterEvent(ch chan<- Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, ch)
+}
+
+// UnregisterEvent unregisters the channel from the DID rotation events.
+func (r *Rotator) UnregisterEvent(ch chan<- Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.events {
+		if r.events[i] == ch {
+			r.events = append(r.events[:i], r.events[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// HandleInboundMessage applies the DID rotation signaled by the `from_prior` header of the message, if any.
+// It must be called before the sender of the message is looked up: the sender key belongs to the new DID
+// which is unknown until the rotation is applied. An invalid `from_prior` header fails the message.
+func (r *Rotator) HandleInboundMessage(msg service.DIDCommMsgMap) error {
+	raw, ok := msg[FromPriorHeader]
+	if !ok {
+		return nil
+	}
+
+	token, ok := raw.(string)
+	if !ok {
+		return errors.New("from_prior: not a JWT")
+	}
+
+	fromPrior, err := r.parseFromPrior(token)
+	if err != nil {
+		return fmt.Errorf("from_prior: %w", err)
+	}
+
+	if from, ok := msg[fromHeader].(string); ok && from != fromPrior.Sub {
+		return fmt.Errorf("from_prior: sub %s does not match the sender %s", fromPrior.Sub, from)
+	}
+
+	return r.rotate(fromPrior.Iss, fromPrior.Sub)
+}
+
+func (r *Rotator) parseFromPrior(token string) (*FromPrior, error) {
+	keyResolver := jwt.KeyResolverFunc(verifiable.NewVDRKeyResolver(r.vdr).PublicKeyFetcher())
+
+	jsonWebToken, err := jwt.Parse(token, jwt.WithSignatureVerifier(jwt.NewVerifier(keyResolver)))
+	if err != nil {
+		return nil, fmt.Errorf("parse JWT: %w", err)
+	}
+
+	fromPrior := &FromPrior{}
+
+	if err = jsonWebToken.DecodeClaims(fromPrior); err != nil {
+		return nil, fmt.Errorf("decode claims: %w", err)
+	}
+
+	if fromPrior.Iss == "" || fromPrior.Sub == "" {
+		return nil, errors.New("iss and sub claims are required")
+	}
+
+	if fromPrior.Iss == fromPrior.Sub {
+		return nil, errors.New("iss and sub claims must be different DIDs")
+	}
+
+	if time.Unix(fromPrior.Iat, 0).After(r.now().Add(maxClockSkew)) {
+		return nil, errors.New("iat claim is in the future")
+	}
+
+	return fromPrior, nil
+}
+
+// rotate moves the connections with the prior DID to the new DID. A rotation is applied once, the following
+// messages carrying the same `from_prior` header find no connection with the prior DID.
+func (r *Rotator) rotate(priorDID, newDID string) error {
+	records, err := r.connectionRecorder.QueryConnectionRecords()
+	if err != nil {
+		return fmt.Errorf("query connection records: %w", err)
+	}
+
+	var rotated []*connection.Record
+
+	for _, record := range records {
+		if record.TheirDID == priorDID {
+			rotated = append(rotated, record)
+		}
+	}
+
+	if len(rotated) == 0 {
+		return nil
+	}
+
+	if err = r.didConnectionStore.SaveDIDByResolving(newDID); err != nil {
+		return fmt.Errorf("save rotated DID %s: %w", newDID, err)
+	}
+
+	event := Event{PriorDID: priorDID, NewDID: newDID}
+
+	for _, record := range rotated {
+		record.TheirDID = newDID
+
+		if err = r.connectionRecorder.SaveConnectionRecord(record); err != nil {
+			return fmt.Errorf("save connection record %s: %w", record.ConnectionID, err)
+		}
+
+		event.ConnectionIDs = append(event.ConnectionIDs, record.ConnectionID)
+	}
+
+	logger.Infof("DID %s rotated to %s, updated connections: %s", priorDID, newDID,
+		strings.Join(event.ConnectionIDs, ","))
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, ch := range r.events {
+		ch <- event
+	}
+
+	return nil
+}
+
+// CreateFromPrior creates the `from_prior` header signaling the rotation of the prior DID to the new DID.
+// The signer must sign with the key of the prior DID identified by kid (the DID URL of the verification method).
+func CreateFromPrior(priorDID, newDID, kid string, signer jose.Signer, issuedAt time.Time) (string, error) {
+	jsonWebToken, err := jwt.NewSigned(&FromPrior{
+		Sub: newDID,
+		Iss: priorDID,
+		Iat: issuedAt.Unix(),
+	}, jose.Headers{jose.HeaderKeyID: kid}, signer)
+	if err != nil {
+		return "", fmt.Errorf("create from_prior: %w", err)
+	}
+
+	token, err := jsonWebToken.Serialize(false)
+	if err != nil {
+		return "", fmt.Errorf("serialize from_prior: %w", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,247 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rotation
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+)
+
+const (
+	priorDID = "did:example:prior"
+	newDID   = "did:example:new"
+)
+
+func TestRotator_HandleInboundMessage(t *testing.T) {
+	t.Run("rotates the DID of the connections", func(t *testing.T) {
+		a := newAgent(t)
+
+		require.NoError(t, a.recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn1",
+			ThreadID:     "thid1",
+			State:        connection.StateNameCompleted,
+			MyDID:        "did:example:me",
+			TheirDID:     priorDID,
+		}))
+
+		events := make(chan Event, 1)
+		a.rotator.RegisterEvent(events)
+
+		msg := service.DIDCommMsgMap{
+			"id":            "1",
+			"from":          newDID,
+			FromPriorHeader: a.fromPrior(t, priorDID, newDID, time.Now()),
+		}
+
+		require.NoError(t, a.rotator.HandleInboundMessage(msg))
+
+		record, err := a.recorder.GetConnectionRecord("conn1")
+		require.NoError(t, err)
+		require.Equal(t, newDID, record.TheirDID)
+
+		// the keys of the new DID are known, the messages it sends are routed to the connection
+		theirDID, err := a.didStore.GetDID(base58.Encode(a.newKey))
+		require.NoError(t, err)
+		require.Equal(t, newDID, theirDID)
+
+		select {
+		case event := <-events:
+			require.Equal(t, Event{PriorDID: priorDID, NewDID: newDID, ConnectionIDs: []string{"conn1"}}, event)
+		case <-time.After(time.Second):
+			t.Fatal("rotation event timeout")
+		}
+
+		// the rotation is applied once
+		require.NoError(t, a.rotator.HandleInboundMessage(msg))
+
+		a.rotator.UnregisterEvent(events)
+		require.Empty(t, a.rotator.events)
+	})
+
+	t.Run("message without from_prior", func(t *testing.T) {
+		a := newAgent(t)
+
+		require.NoError(t, a.rotator.HandleInboundMessage(service.DIDCommMsgMap{"id": "1"}))
+	})
+
+	t.Run("invalid from_prior", func(t *testing.T) {
+		a := newAgent(t)
+
+		err := a.rotator.HandleInboundMessage(service.DIDCommMsgMap{FromPriorHeader: 1})
+		require.EqualError(t, err, "from_prior: not a JWT")
+
+		err = a.rotator.HandleInboundMessage(service.DIDCommMsgMap{FromPriorHeader: "invalid"})
+		require.Contains(t, err.Error(), "from_prior: parse JWT")
+
+		// signed with a key of the new DID instead of the prior DID
+		token, err := CreateFromPrior(priorDID, newDID, newDID+"#key1", a.signer(a.newPriv), time.Now())
+		require.NoError(t, err)
+
+		err = a.rotator.HandleInboundMessage(service.DIDCommMsgMap{FromPriorHeader: token})
+		require.Contains(t, err.Error(), "from_prior: parse JWT")
+
+		err = a.rotator.HandleInboundMessage(service.DIDCommMsgMap{
+			FromPriorHeader: a.fromPrior(t, priorDID, priorDID, time.Now()),
+		})
+		require.EqualError(t, err, "from_prior: iss and sub claims must be different DIDs")
+
+		err = a.rotator.HandleInboundMessage(service.DIDCommMsgMap{
+			FromPriorHeader: a.fromPrior(t, priorDID, "", time.Now()),
+		})
+		require.EqualError(t, err, "from_prior: iss and sub claims are required")
+
+		err = a.rotator.HandleInboundMessage(service.DIDCommMsgMap{
+			FromPriorHeader: a.fromPrior(t, priorDID, newDID, time.Now().Add(time.Hour)),
+		})
+		require.EqualError(t, err, "from_prior: iat claim is in the future")
+
+		err = a.rotator.HandleInboundMessage(service.DIDCommMsgMap{
+			"from":          "did:example:other",
+			FromPriorHeader: a.fromPrior(t, priorDID, newDID, time.Now()),
+		})
+		require.EqualError(t, err, "from_prior: sub did:example:new does not match the sender did:example:other")
+	})
+
+	t.Run("query connections error", func(t *testing.T) {
+		a := newAgent(t, &mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+			Store:    make(map[string]mockstorage.DBEntry),
+			ErrQuery: errors.New("query error"),
+		}})
+
+		err := a.rotator.HandleInboundMessage(service.DIDCommMsgMap{
+			FromPriorHeader: a.fromPrior(t, priorDID, newDID, time.Now()),
+		})
+		require.Contains(t, err.Error(), "query connection records")
+	})
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&provider{MockProvider: &protocol.MockProvider{
+		StoreProvider: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+	}})
+	require.Contains(t, err.Error(), "failed to create connection recorder")
+}
+
+type agent struct {
+	rotator   *Rotator
+	recorder  *connection.Recorder
+	didStore  didstore.ConnectionStore
+	priorPriv ed25519.PrivateKey
+	newPriv   ed25519.PrivateKey
+	newKey    ed25519.PublicKey
+}
+
+func newAgent(t *testing.T, storeProvider ...*mockstorage.MockStoreProvider) *agent {
+	t.Helper()
+
+	priorKey, priorPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	newKey, newPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	docs := map[string]*did.Doc{
+		priorDID: newDoc(priorDID, priorKey),
+		newDID:   newDoc(newDID, newKey),
+	}
+
+	p := &protocol.MockProvider{
+		StoreProvider: mockstorage.NewMockStoreProvider(),
+		CustomVDR: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				doc, ok := docs[didID]
+				if !ok {
+					return nil, errors.New("not found")
+				}
+
+				return &did.DocResolution{DIDDocument: doc}, nil
+			},
+		},
+	}
+
+	if len(storeProvider) > 0 {
+		p.StoreProvider = storeProvider[0]
+	}
+
+	didStore, err := didstore.NewConnectionStore(p)
+	require.NoError(t, err)
+
+	rotator, err := New(&provider{MockProvider: p, didStore: didStore})
+	require.NoError(t, err)
+
+	recorder, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+
+	return &agent{
+		rotator:   rotator,
+		recorder:  recorder,
+		didStore:  didStore,
+		priorPriv: priorPriv,
+		newPriv:   newPriv,
+		newKey:    newKey,
+	}
+}
+
+func (a *agent) fromPrior(t *testing.T, iss, sub string, issuedAt time.Time) string {
+	t.Helper()
+
+	token, err := CreateFromPrior(iss, sub, iss+"#key1", a.signer(a.priorPriv), issuedAt)
+	require.NoError(t, err)
+
+	return token
+}
+
+func (a *agent) signer(privKey ed25519.PrivateKey) jose.Signer {
+	return &ed25519Signer{privKey: privKey}
+}
+
+func newDoc(id string, pubKey ed25519.PublicKey) *did.Doc {
+	vm := did.NewVerificationMethodFromBytes(id+"#key1", "Ed25519VerificationKey2018", id, pubKey)
+
+	return &did.Doc{
+		ID:                 id,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+	}
+}
+
+type provider struct {
+	*protocol.MockProvider
+	didStore didstore.ConnectionStore
+}
+
+func (p *provider) DIDConnectionStore() didstore.ConnectionStore {
+	return p.didStore
+}
+
+type ed25519Signer struct {
+	privKey ed25519.PrivateKey
+}
+
+func (s *ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519Signer) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA"}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package thread provides utilities to manage DIDComm message threads (the ~thread decorator, see
+// https://github.com/hyperledger/aries-rfcs/tree/master/concepts/0008-message-id-and-threading) and a store
+// to correlate threads with the protocol instances they belong to.
+package thread
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+const (
+	jsonID             = "@id"
+	jsonThread         = "~thread"
+	jsonThreadID       = "thid"
+	jsonParentThreadID = "pthid"
+)
+
+// Start makes the message the first message of a new thread and returns the thread ID (equal to the message ID).
+// The message ID is generated if missing. The thread of a message that already belongs to a thread is left unchanged.
+func Start(msg service.DIDCommMsgMap) string {
+	if msg.ID() == "" {
+		msg[jsonID] = uuid.New().String()
+	}
+
+	// the message ID is set, the thread ID can't be invalid
+	thID, _ := msg.ThreadID() // nolint: errcheck
+	if thID == msg.ID() {
+		setThread(msg, thID, msg.ParentThreadID())
+	}
+
+	return thID
+}
+
+// Reply decorates the out message as a reply to the in message: it belongs to the same thread and
+// has the same parent thread.
+func Reply(in, out service.DIDCommMsgMap) error {
+	thID, err := in.ThreadID()
+	if err != nil {
+		return fmt.Errorf("reply thread: %w", err)
+	}
+
+	if out.ID() == "" {
+		out[jsonID] = uuid.New().String()
+	}
+
+	setThread(out, thID, in.ParentThreadID())
+
+	return nil
+}
+
+// StartChild makes the child message the first message of a new thread whose parent is the thread
+// of the parent message (e.g. an out-of-band invitation starting a DID exchange).
+func StartChild(parent, child service.DIDCommMsgMap) (string, error) {
+	pthID, err := parent.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("child thread: %w", err)
+	}
+
+	if child.ID() == "" {
+		child[jsonID] = uuid.New().String()
+	}
+
+	setThread(child, child.ID(), pthID)
+
+	return child.ID(), nil
+}
+
+func setThread(msg service.DIDCommMsgMap, thID, pthID string) {
+	thread := map[string]interface{}{
+		jsonThreadID: thID,
+	}
+
+	if pthID != "" {
+		thread[jsonParentThreadID] = pthID
+	}
+
+	msg[jsonThread] = thread
+}
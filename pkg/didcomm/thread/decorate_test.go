@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package thread
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+func TestStart(t *testing.T) {
+	t.Run("new thread", func(t *testing.T) {
+		msg := service.DIDCommMsgMap{"@type": "type"}
+
+		thID := Start(msg)
+		require.NotEmpty(t, thID)
+		require.Equal(t, msg.ID(), thID)
+		require.Equal(t, map[string]interface{}{"thid": thID}, msg["~thread"])
+	})
+
+	t.Run("message of an existing thread", func(t *testing.T) {
+		msg := service.DIDCommMsgMap{"@id": "2", "~thread": map[string]interface{}{"thid": "1"}}
+
+		thID := Start(msg)
+		require.Equal(t, "1", thID)
+		require.Equal(t, map[string]interface{}{"thid": "1"}, msg["~thread"])
+	})
+}
+
+func TestReply(t *testing.T) {
+	in := service.DIDCommMsgMap{"@id": "2", "~thread": map[string]interface{}{"thid": "1", "pthid": "0"}}
+	out := service.DIDCommMsgMap{}
+
+	require.NoError(t, Reply(in, out))
+	require.NotEmpty(t, out.ID())
+
+	thID, err := out.ThreadID()
+	require.NoError(t, err)
+	require.Equal(t, "1", thID)
+	require.Equal(t, "0", out.ParentThreadID())
+
+	err = Reply(service.DIDCommMsgMap{}, out)
+	require.Contains(t, err.Error(), "reply thread")
+}
+
+func TestStartChild(t *testing.T) {
+	parent := service.DIDCommMsgMap{"@id": "1"}
+	child := service.DIDCommMsgMap{"@id": "2"}
+
+	thID, err := StartChild(parent, child)
+	require.NoError(t, err)
+	require.Equal(t, "2", thID)
+	require.Equal(t, map[string]interface{}{"thid": "2", "pthid": "1"}, child["~thread"])
+
+	_, err = StartChild(service.DIDCommMsgMap{}, child)
+	require.Contains(t, err.Error(), "child thread")
+}
@@ -0,0 +1,230 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package thread
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for thread store.
+	NameSpace = "thread"
+
+	threadKeyPrefix = "thread_"
+	pthidTagName    = "pthid"
+)
+
+var logger = log.New("aries-framework/didcomm/thread")
+
+// ErrThreadNotFound is returned when the thread is not tracked.
+var ErrThreadNotFound = errors.New("thread not found")
+
+// Record is the tracked state of a thread.
+type Record struct {
+	ThreadID       string `json:"thid"`
+	ParentThreadID string `json:"pthid,omitempty"`
+	// Service is the name of the protocol service handling the thread.
+	Service string `json:"service,omitempty"`
+	// PIID is the ID of the protocol instance, as returned by the protocol service.
+	PIID     string `json:"piid,omitempty"`
+	MyDID    string `json:"myDID,omitempty"`
+	TheirDID string `json:"theirDID,omitempty"`
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Store tracks the threads of the messages exchanged by the agent, so that responses can be correlated
+// with the protocol instances of any service.
+type Store struct {
+	store storage.Store
+}
+
+// NewStore returns a new thread store.
+func NewStore(ctx provider) (*Store, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open thread store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace, storage.StoreConfiguration{TagNames: []string{pthidTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	return &Store{store: store}, nil
+}
+
+// Save saves the thread record. The empty fields of the record are filled with the values of
+// the record already tracked for the thread, if any.
+func (s *Store) Save(rec *Record) error {
+	if rec == nil || rec.ThreadID == "" {
+		return errors.New("thread ID is mandatory")
+	}
+
+	existing, err := s.Get(rec.ThreadID)
+	if err != nil && !errors.Is(err, ErrThreadNotFound) {
+		return err
+	}
+
+	merged := *rec
+
+	if existing != nil {
+		merge(&merged, existing)
+	}
+
+	src, err := json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("marshal thread record: %w", err)
+	}
+
+	var tags []storage.Tag
+	if merged.ParentThreadID != "" {
+		tags = append(tags, storage.Tag{Name: pthidTagName, Value: merged.ParentThreadID})
+	}
+
+	if err = s.store.Put(threadKeyPrefix+merged.ThreadID, src, tags...); err != nil {
+		return fmt.Errorf("save thread record: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the record of the thread.
+func (s *Store) Get(thID string) (*Record, error) {
+	src, err := s.store.Get(threadKeyPrefix + thID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrThreadNotFound, thID)
+		}
+
+		return nil, fmt.Errorf("get thread record: %w", err)
+	}
+
+	var rec Record
+	if err = json.Unmarshal(src, &rec); err != nil {
+		return nil, fmt.Errorf("unmarshal thread record: %w", err)
+	}
+
+	return &rec, nil
+}
+
+// Children returns the records of the threads whose parent is the given thread.
+func (s *Store) Children(pthID string) ([]*Record, error) {
+	itr, err := s.store.Query(pthidTagName + ":" + pthID)
+	if err != nil {
+		return nil, fmt.Errorf("query thread records: %w", err)
+	}
+
+	defer func() {
+		if errClose := itr.Close(); errClose != nil {
+			logger.Errorf("failed to close iterator: %s", errClose.Error())
+		}
+	}()
+
+	var records []*Record
+
+	more, err := itr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("iterate thread records: %w", err)
+	}
+
+	for more {
+		src, err := itr.Value()
+		if err != nil {
+			return nil, fmt.Errorf("get thread record value: %w", err)
+		}
+
+		var rec Record
+		if err = json.Unmarshal(src, &rec); err != nil {
+			return nil, fmt.Errorf("unmarshal thread record: %w", err)
+		}
+
+		records = append(records, &rec)
+
+		more, err = itr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("iterate thread records: %w", err)
+		}
+	}
+
+	return records, nil
+}
+
+// TrackInbound saves the thread of the message handled by the protocol service. Errors are logged.
+func (s *Store) TrackInbound(msg service.DIDCommMsgMap, svcName, piid, myDID, theirDID string) {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		logger.Debugf("inbound message %s is not tracked: %s", msg.ID(), err)
+
+		return
+	}
+
+	err = s.Save(&Record{
+		ThreadID:       thID,
+		ParentThreadID: msg.ParentThreadID(),
+		Service:        svcName,
+		PIID:           piid,
+		MyDID:          myDID,
+		TheirDID:       theirDID,
+	})
+	if err != nil {
+		logger.Warnf("failed to track thread %s: %s", thID, err)
+	}
+}
+
+// OutboundInterceptor returns the outbound dispatcher interceptor saving the threads of the messages sent
+// by the agent. The serviceName function returns the name of the service accepting the message type, if any.
+func (s *Store) OutboundInterceptor(serviceName func(msgType string) string) dispatcher.OutboundInterceptor {
+	return func(next dispatcher.OutboundHandler) dispatcher.OutboundHandler {
+		return dispatcher.OutboundHandlerFunc(func(msg *dispatcher.OutboundMessage) error {
+			if thID, err := msg.Msg.ThreadID(); err == nil {
+				rec := &Record{ThreadID: thID, ParentThreadID: msg.Msg.ParentThreadID()}
+
+				if serviceName != nil {
+					rec.Service = serviceName(msg.Msg.Type())
+				}
+
+				if err = s.Save(rec); err != nil {
+					logger.Warnf("failed to track thread %s: %s", thID, err)
+				}
+			}
+
+			return next.HandleOutbound(msg)
+		})
+	}
+}
+
+func merge(rec, existing *Record) {
+	if rec.ParentThreadID == "" {
+		rec.ParentThreadID = existing.ParentThreadID
+	}
+
+	if rec.Service == "" {
+		rec.Service = existing.Service
+	}
+
+	if rec.PIID == "" {
+		rec.PIID = existing.PIID
+	}
+
+	if rec.MyDID == "" {
+		rec.MyDID = existing.MyDID
+	}
+
+	if rec.TheirDID == "" {
+		rec.TheirDID = existing.TheirDID
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package thread
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNewStore(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		store, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+		require.NoError(t, err)
+		require.NotNil(t, store)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := NewStore(&mockprovider.Provider{StorageProviderValue: &mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}})
+		require.EqualError(t, err, "failed to open thread store: open error")
+	})
+}
+
+func TestStore(t *testing.T) {
+	t.Run("save and lookup", func(t *testing.T) {
+		store, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+		require.NoError(t, err)
+
+		require.NoError(t, store.Save(&Record{ThreadID: "1", Service: "svc"}))
+		require.NoError(t, store.Save(&Record{ThreadID: "1", PIID: "piid", MyDID: "did:example:alice"}))
+		require.NoError(t, store.Save(&Record{ThreadID: "2", ParentThreadID: "1"}))
+		require.NoError(t, store.Save(&Record{ThreadID: "3", ParentThreadID: "1"}))
+
+		rec, err := store.Get("1")
+		require.NoError(t, err)
+		require.Equal(t, &Record{ThreadID: "1", Service: "svc", PIID: "piid", MyDID: "did:example:alice"}, rec)
+
+		children, err := store.Children("1")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []*Record{
+			{ThreadID: "2", ParentThreadID: "1"},
+			{ThreadID: "3", ParentThreadID: "1"},
+		}, children)
+
+		children, err = store.Children("2")
+		require.NoError(t, err)
+		require.Empty(t, children)
+
+		_, err = store.Get("4")
+		require.True(t, errors.Is(err, ErrThreadNotFound))
+
+		require.EqualError(t, store.Save(&Record{}), "thread ID is mandatory")
+	})
+
+	t.Run("storage errors", func(t *testing.T) {
+		mockStore := &mockstorage.MockStore{
+			Store:    map[string]mockstorage.DBEntry{},
+			ErrGet:   errors.New("get error"),
+			ErrQuery: errors.New("query error"),
+		}
+
+		store, err := NewStore(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{Store: mockStore},
+		})
+		require.NoError(t, err)
+
+		_, err = store.Get("1")
+		require.EqualError(t, err, "get thread record: get error")
+
+		require.EqualError(t, store.Save(&Record{ThreadID: "1"}), "get thread record: get error")
+
+		_, err = store.Children("1")
+		require.EqualError(t, err, "query thread records: query error")
+
+		mockStore.ErrGet = nil
+		mockStore.ErrPut = errors.New("put error")
+
+		require.EqualError(t, store.Save(&Record{ThreadID: "1"}), "save thread record: put error")
+	})
+}
+
+func TestStore_TrackInbound(t *testing.T) {
+	store, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+	require.NoError(t, err)
+
+	store.TrackInbound(service.DIDCommMsgMap{"@id": "1"}, "svc", "piid", "did:example:alice", "did:example:bob")
+
+	rec, err := store.Get("1")
+	require.NoError(t, err)
+	require.Equal(t, &Record{
+		ThreadID: "1",
+		Service:  "svc",
+		PIID:     "piid",
+		MyDID:    "did:example:alice",
+		TheirDID: "did:example:bob",
+	}, rec)
+
+	// messages without ID are not tracked
+	store.TrackInbound(service.DIDCommMsgMap{}, "svc", "piid", "", "")
+}
+
+func TestStore_OutboundInterceptor(t *testing.T) {
+	store, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+	require.NoError(t, err)
+
+	var sent *dispatcher.OutboundMessage
+
+	handler := store.OutboundInterceptor(func(msgType string) string {
+		return "svc-" + msgType
+	})(dispatcher.OutboundHandlerFunc(func(msg *dispatcher.OutboundMessage) error {
+		sent = msg
+
+		return nil
+	}))
+
+	msg := &dispatcher.OutboundMessage{Msg: service.DIDCommMsgMap{
+		"@id":     "2",
+		"@type":   "type",
+		"~thread": map[string]interface{}{"thid": "1", "pthid": "0"},
+	}}
+
+	require.NoError(t, handler.HandleOutbound(msg))
+	require.Equal(t, msg, sent)
+
+	rec, err := store.Get("1")
+	require.NoError(t, err)
+	require.Equal(t, &Record{ThreadID: "1", ParentThreadID: "0", Service: "svc-type"}, rec)
+
+	// the message is sent even if it is not tracked
+	require.NoError(t, handler.HandleOutbound(&dispatcher.OutboundMessage{Msg: service.DIDCommMsgMap{}}))
+}
@@ -0,0 +1,232 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package timing implements the `~timing` decorator of the DIDComm messages (Aries RFC 0032): the inbound messages
+// are delayed and expired as requested by their sender, the outbound messages are stamped with the time they are
+// sent, and the latencies of the connections are measured from the stamped inbound messages.
+package timing
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const (
+	// DefaultMaxDelay is the default maximum time an inbound message is delayed for.
+	DefaultMaxDelay = time.Minute
+
+	timingKey  = "~timing"
+	outTimeKey = "out_time"
+)
+
+var logger = log.New("aries-framework/didcomm/timing")
+
+// Latency is the latency of the messages received from the other agent of a connection, measured from the time
+// they were sent (`~timing.out_time`) to the time they were received. The clocks of both agents are assumed to be
+// synchronized, a negative latency is counted as zero.
+type Latency struct {
+	TheirDID  string        `json:"their_did"`
+	Last      time.Duration `json:"last"`
+	Average   time.Duration `json:"average"`
+	Samples   int           `json:"samples"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// Option configures the Monitor.
+type Option func(m *Monitor)
+
+// WithMaxDelay sets the maximum time an inbound message is delayed for, DefaultMaxDelay by default.
+// The messages asking for a longer delay are handled once the maximum delay has passed.
+func WithMaxDelay(maxDelay time.Duration) Option {
+	return func(m *Monitor) {
+		m.maxDelay = maxDelay
+	}
+}
+
+// Monitor is the connection monitor handling the `~timing` decorator of the messages and keeping the latencies
+// of the connections in memory.
+type Monitor struct {
+	mu        sync.RWMutex
+	latencies map[string]*Latency
+	maxDelay  time.Duration
+	now       func() time.Time
+	sleep     func(d time.Duration)
+}
+
+// NewMonitor returns a new connection monitor.
+func NewMonitor(opts ...Option) *Monitor {
+	m := &Monitor{
+		latencies: map[string]*Latency{},
+		maxDelay:  DefaultMaxDelay,
+		now:       time.Now,
+		sleep:     time.Sleep,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Decode returns the `~timing` decorator of the message, nil if the message has no valid decorator.
+func Decode(msg service.DIDCommMsgMap) *decorator.Timing {
+	timing := struct {
+		Timing *decorator.Timing `json:"~timing,omitempty"`
+	}{}
+
+	if _, ok := msg[timingKey]; !ok || msg.Decode(&timing) != nil {
+		return nil
+	}
+
+	return timing.Timing
+}
+
+// HandleInbound waits for the delay requested by the inbound message (`~timing.delay_milli` and
+// `~timing.wait_until_time`, up to the maximum delay) and returns the time the message was received.
+// expiry.ErrExpired is returned if the message expires (`~timing.expires_time`) before it can be handled.
+func (m *Monitor) HandleInbound(msg service.DIDCommMsgMap) (time.Time, error) {
+	receivedAt := m.now()
+
+	timing := Decode(msg)
+	if timing == nil {
+		return receivedAt, nil
+	}
+
+	if !timing.ExpiresTime.IsZero() && !timing.ExpiresTime.After(receivedAt) {
+		return receivedAt, fmt.Errorf("message %s: %w", msg.ID(), expiry.ErrExpired)
+	}
+
+	delay := time.Duration(timing.DelayMilli) * time.Millisecond
+
+	if timing.WaitUntilTime != nil {
+		if wait := timing.WaitUntilTime.Sub(receivedAt); wait > delay {
+			delay = wait
+		}
+	}
+
+	if delay > m.maxDelay {
+		logger.Warnf("delay %s of message %s exceeds the maximum delay %s", delay, msg.ID(), m.maxDelay)
+
+		delay = m.maxDelay
+	}
+
+	if delay <= 0 {
+		return receivedAt, nil
+	}
+
+	if !timing.ExpiresTime.IsZero() && !timing.ExpiresTime.After(receivedAt.Add(delay)) {
+		return receivedAt, fmt.Errorf("message %s delayed for %s: %w", msg.ID(), delay, expiry.ErrExpired)
+	}
+
+	m.sleep(delay)
+
+	return receivedAt, nil
+}
+
+// TrackInbound updates the latency of the connection with the inbound message received at the given time,
+// nothing is done if the message has no sending time (`~timing.out_time`).
+func (m *Monitor) TrackInbound(msg service.DIDCommMsgMap, theirDID string, receivedAt time.Time) {
+	timing := Decode(msg)
+	if theirDID == "" || timing == nil || timing.OutTime == nil {
+		return
+	}
+
+	latency := receivedAt.Sub(*timing.OutTime)
+	if latency < 0 {
+		latency = 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, ok := m.latencies[theirDID]
+	if !ok {
+		rec = &Latency{TheirDID: theirDID}
+		m.latencies[theirDID] = rec
+	}
+
+	rec.Samples++
+	rec.Last = latency
+	rec.Average += (latency - rec.Average) / time.Duration(rec.Samples)
+	rec.UpdatedAt = receivedAt
+}
+
+// Latency returns the latency of the connection with the other agent (false if not measured yet).
+func (m *Monitor) Latency(theirDID string) (Latency, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rec, ok := m.latencies[theirDID]
+	if !ok {
+		return Latency{}, false
+	}
+
+	return *rec, true
+}
+
+// Latencies returns the latencies of the connections measured so far, sorted by DID.
+func (m *Monitor) Latencies() []Latency {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	latencies := make([]Latency, 0, len(m.latencies))
+
+	for _, rec := range m.latencies {
+		latencies = append(latencies, *rec)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i].TheirDID < latencies[j].TheirDID
+	})
+
+	return latencies
+}
+
+// OutboundInterceptor returns the outbound dispatcher interceptor stamping the messages with the time they are sent
+// (`~timing.out_time`). The other timing information set by the sender of the message is kept.
+func (m *Monitor) OutboundInterceptor() dispatcher.OutboundInterceptor {
+	return func(next dispatcher.OutboundHandler) dispatcher.OutboundHandler {
+		return dispatcher.OutboundHandlerFunc(func(msg *dispatcher.OutboundMessage) error {
+			m.stampOutbound(msg.Msg)
+
+			return next.HandleOutbound(msg)
+		})
+	}
+}
+
+func (m *Monitor) stampOutbound(msg service.DIDCommMsgMap) {
+	if msg == nil {
+		return
+	}
+
+	timing := map[string]interface{}{}
+
+	if v, ok := msg[timingKey]; ok {
+		existing, isMap := v.(map[string]interface{})
+		if !isMap {
+			logger.Warnf("message %s: unexpected %s decorator %T is not stamped", msg.ID(), timingKey, v)
+
+			return
+		}
+
+		// the decorator may be shared with the message of the caller
+		for k, val := range existing {
+			timing[k] = val
+		}
+	}
+
+	timing[outTimeKey] = m.now().UTC().Format(time.RFC3339Nano)
+	msg[timingKey] = timing
+}
@@ -0,0 +1,209 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timing
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const theirDID = "did:example:bob"
+
+func newMsg(t *testing.T, timing string) service.DIDCommMsgMap {
+	t.Helper()
+
+	msg, err := service.ParseDIDCommMsgMap([]byte(`{
+		"@id": "msgID",
+		"@type": "https://didcomm.org/basicmessage/1.0/message",
+		"~timing": ` + timing + `
+	}`))
+	require.NoError(t, err)
+
+	return msg
+}
+
+func newMonitor(now time.Time, opts ...Option) (*Monitor, *[]time.Duration) {
+	var delays []time.Duration
+
+	m := NewMonitor(opts...)
+	m.now = func() time.Time { return now }
+	m.sleep = func(d time.Duration) { delays = append(delays, d) }
+
+	return m, &delays
+}
+
+func TestDecode(t *testing.T) {
+	timing := Decode(newMsg(t, `{
+		"out_time": "2021-05-01T10:00:00Z",
+		"expires_time": "2021-05-02T10:00:00Z",
+		"delay_milli": 500
+	}`))
+	require.NotNil(t, timing)
+	require.Equal(t, time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC), timing.OutTime.UTC())
+	require.Equal(t, time.Date(2021, 5, 2, 10, 0, 0, 0, time.UTC), timing.ExpiresTime.UTC())
+	require.Equal(t, 500, timing.DelayMilli)
+	require.Nil(t, timing.WaitUntilTime)
+
+	require.Nil(t, Decode(service.DIDCommMsgMap{"@id": "msgID"}))
+	require.Nil(t, Decode(service.DIDCommMsgMap{timingKey: "invalid"}))
+}
+
+func TestMonitor_HandleInbound(t *testing.T) {
+	now := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("no timing", func(t *testing.T) {
+		m, delays := newMonitor(now)
+
+		receivedAt, err := m.HandleInbound(service.DIDCommMsgMap{"@id": "msgID"})
+		require.NoError(t, err)
+		require.Equal(t, now, receivedAt)
+		require.Empty(t, *delays)
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		m, delays := newMonitor(now)
+
+		_, err := m.HandleInbound(newMsg(t, `{"delay_milli": 1500}`))
+		require.NoError(t, err)
+		require.Equal(t, []time.Duration{1500 * time.Millisecond}, *delays)
+	})
+
+	t.Run("wait until", func(t *testing.T) {
+		m, delays := newMonitor(now)
+
+		_, err := m.HandleInbound(newMsg(t, `{"delay_milli": 1000, "wait_until_time": "2021-05-01T10:00:05Z"}`))
+		require.NoError(t, err)
+		require.Equal(t, []time.Duration{5 * time.Second}, *delays)
+
+		_, err = m.HandleInbound(newMsg(t, `{"wait_until_time": "2021-05-01T09:00:00Z"}`))
+		require.NoError(t, err)
+		require.Len(t, *delays, 1)
+	})
+
+	t.Run("maximum delay", func(t *testing.T) {
+		m, delays := newMonitor(now, WithMaxDelay(time.Second))
+
+		_, err := m.HandleInbound(newMsg(t, `{"delay_milli": 60000}`))
+		require.NoError(t, err)
+		require.Equal(t, []time.Duration{time.Second}, *delays)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		m, delays := newMonitor(now)
+
+		_, err := m.HandleInbound(newMsg(t, `{"expires_time": "2021-05-01T09:00:00Z"}`))
+		require.True(t, errors.Is(err, expiry.ErrExpired))
+
+		_, err = m.HandleInbound(newMsg(t, `{"expires_time": "2021-05-01T10:00:01Z", "delay_milli": 2000}`))
+		require.True(t, errors.Is(err, expiry.ErrExpired))
+		require.Empty(t, *delays)
+
+		_, err = m.HandleInbound(newMsg(t, `{"expires_time": "2021-05-01T11:00:00Z", "delay_milli": 2000}`))
+		require.NoError(t, err)
+		require.Len(t, *delays, 1)
+	})
+}
+
+func TestMonitor_TrackInbound(t *testing.T) {
+	outTime := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	m := NewMonitor()
+
+	_, ok := m.Latency(theirDID)
+	require.False(t, ok)
+
+	m.TrackInbound(newMsg(t, `{"out_time": "2021-05-01T10:00:00Z"}`), theirDID, outTime.Add(100*time.Millisecond))
+	m.TrackInbound(newMsg(t, `{"out_time": "2021-05-01T10:00:00Z"}`), theirDID, outTime.Add(300*time.Millisecond))
+	// clocks out of sync
+	m.TrackInbound(newMsg(t, `{"out_time": "2021-05-01T10:00:00Z"}`), "did:example:carol", outTime.Add(-time.Second))
+	// ignored
+	m.TrackInbound(newMsg(t, `{"delay_milli": 10}`), theirDID, outTime)
+	m.TrackInbound(newMsg(t, `{"out_time": "2021-05-01T10:00:00Z"}`), "", outTime)
+
+	latency, ok := m.Latency(theirDID)
+	require.True(t, ok)
+	require.Equal(t, Latency{
+		TheirDID:  theirDID,
+		Last:      300 * time.Millisecond,
+		Average:   200 * time.Millisecond,
+		Samples:   2,
+		UpdatedAt: outTime.Add(300 * time.Millisecond),
+	}, latency)
+
+	latencies := m.Latencies()
+	require.Len(t, latencies, 2)
+	require.Equal(t, theirDID, latencies[0].TheirDID)
+	require.Equal(t, "did:example:carol", latencies[1].TheirDID)
+	require.Zero(t, latencies[1].Last)
+}
+
+func TestMonitor_OutboundInterceptor(t *testing.T) {
+	now := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	m, _ := newMonitor(now)
+
+	var sent []service.DIDCommMsgMap
+
+	handler := m.OutboundInterceptor()(dispatcher.OutboundHandlerFunc(func(msg *dispatcher.OutboundMessage) error {
+		sent = append(sent, msg.Msg)
+
+		return nil
+	}))
+
+	t.Run("adds the timing decorator", func(t *testing.T) {
+		require.NoError(t, handler.HandleOutbound(&dispatcher.OutboundMessage{
+			Msg: service.DIDCommMsgMap{"@id": "msgID"},
+		}))
+
+		timing := Decode(sent[len(sent)-1])
+		require.NotNil(t, timing)
+		require.True(t, now.Equal(*timing.OutTime))
+	})
+
+	t.Run("keeps the timing information of the sender", func(t *testing.T) {
+		expires := now.Add(time.Hour)
+
+		msg := service.NewDIDCommMsgMap(struct {
+			ID     string            `json:"@id"`
+			Timing *decorator.Timing `json:"~timing"`
+		}{
+			ID:     "msgID",
+			Timing: &decorator.Timing{ExpiresTime: expires, DelayMilli: 10},
+		})
+		original := msg[timingKey].(map[string]interface{})
+
+		require.NoError(t, handler.HandleOutbound(&dispatcher.OutboundMessage{Msg: msg}))
+		require.NotContains(t, original, outTimeKey)
+
+		src, err := json.Marshal(sent[len(sent)-1])
+		require.NoError(t, err)
+
+		parsed, err := service.ParseDIDCommMsgMap(src)
+		require.NoError(t, err)
+
+		timing := Decode(parsed)
+		require.NotNil(t, timing)
+		require.True(t, now.Equal(*timing.OutTime))
+		require.True(t, expires.Equal(timing.ExpiresTime))
+		require.Equal(t, 10, timing.DelayMilli)
+	})
+
+	t.Run("unexpected decorator", func(t *testing.T) {
+		require.NoError(t, handler.HandleOutbound(&dispatcher.OutboundMessage{
+			Msg: service.DIDCommMsgMap{"@id": "msgID", timingKey: "invalid"},
+		}))
+
+		require.Equal(t, "invalid", sent[len(sent)-1][timingKey])
+	})
+}
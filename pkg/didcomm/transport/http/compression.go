@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	// GzipEncoding is the gzip content coding of the compressed envelopes.
+	GzipEncoding = "gzip"
+	// DeflateEncoding is the deflate (zlib) content coding of the compressed envelopes.
+	DeflateEncoding = "deflate"
+
+	identityEncoding = "identity"
+
+	// acceptedEncodings are advertised by the inbound transport in the Accept-Encoding header of its responses
+	// (RFC 7694).
+	acceptedEncodings = GzipEncoding + ", " + DeflateEncoding
+)
+
+// compress compresses the data with the content coding.
+func compress(data []byte, encoding string) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+
+	switch encoding {
+	case GzipEncoding:
+		w = gzip.NewWriter(&buf)
+	case DeflateEncoding:
+		w = zlib.NewWriter(&buf)
+	default:
+		return nil, fmt.Errorf("unsupported content coding: %s", encoding)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("compress: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("compress: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// supportedEncoding returns true if the inbound transport decompresses the content coding.
+func supportedEncoding(encoding string) bool {
+	switch encoding {
+	case "", identityEncoding, GzipEncoding, DeflateEncoding:
+		return true
+	default:
+		return false
+	}
+}
+
+// decompressor returns the reader of the data decompressed from the reader of the (supported) content coding.
+func decompressor(r io.Reader, encoding string) (io.ReadCloser, error) {
+	switch encoding {
+	case GzipEncoding:
+		return gzip.NewReader(r)
+	case DeflateEncoding:
+		return zlib.NewReader(r)
+	default:
+		return ioutil.NopCloser(r), nil
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package http
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+)
+
+// unpackRecorder records the envelopes unpacked by the inbound handler.
+type unpackRecorder struct {
+	mockpackager.Packager
+	mu       sync.Mutex
+	received [][]byte
+}
+
+func (p *unpackRecorder) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.received = append(p.received, encMessage)
+
+	return &transport.Envelope{Message: []byte("data")}, nil
+}
+
+func TestCompress(t *testing.T) {
+	data := []byte(strings.Repeat("envelope", 16))
+
+	for _, encoding := range []string{GzipEncoding, DeflateEncoding} {
+		compressed, err := compress(data, encoding)
+		require.NoError(t, err)
+		require.Less(t, len(compressed), len(data))
+
+		r, err := decompressor(bytes.NewReader(compressed), encoding)
+		require.NoError(t, err)
+
+		decompressed, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, data, decompressed)
+	}
+
+	_, err := compress(data, "br")
+	require.EqualError(t, err, "unsupported content coding: br")
+
+	require.True(t, supportedEncoding(""))
+	require.True(t, supportedEncoding(identityEncoding))
+	require.False(t, supportedEncoding("br"))
+}
+
+func TestInboundHandler_Compression(t *testing.T) {
+	const maxSize = 1024
+
+	packager := &unpackRecorder{}
+
+	inHandler, err := newInboundHandler(&mockProvider{packagerValue: packager}, maxSize)
+	require.NoError(t, err)
+
+	post := func(body []byte, encoding string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		r.Header.Set("Content-Type", commContentType)
+		r.Header.Set("Content-Encoding", encoding)
+
+		w := httptest.NewRecorder()
+		inHandler.ServeHTTP(w, r)
+
+		return w
+	}
+
+	t.Run("compressed envelopes are decompressed", func(t *testing.T) {
+		for _, encoding := range []string{GzipEncoding, DeflateEncoding} {
+			compressed, err := compress([]byte("envelope"), encoding)
+			require.NoError(t, err)
+
+			w := post(compressed, encoding)
+			require.Equal(t, http.StatusAccepted, w.Code)
+			require.Equal(t, acceptedEncodings, w.Header().Get("Accept-Encoding"))
+			require.Equal(t, []byte("envelope"), packager.received[len(packager.received)-1])
+		}
+	})
+
+	t.Run("unsupported content coding", func(t *testing.T) {
+		w := post([]byte("envelope"), "br")
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		require.Equal(t, acceptedEncodings, w.Header().Get("Accept-Encoding"))
+	})
+
+	t.Run("invalid compressed envelope", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, post([]byte("envelope"), GzipEncoding).Code)
+	})
+
+	t.Run("decompressed envelope too large", func(t *testing.T) {
+		// the envelope is only too large once decompressed
+		compressed, err := compress(bytes.Repeat([]byte("envelope"), 4*maxSize/len("envelope")), GzipEncoding)
+		require.NoError(t, err)
+		require.Less(t, len(compressed), maxSize)
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, post(compressed, GzipEncoding).Code)
+	})
+}
+
+func TestOutboundHTTPTransport_Compression(t *testing.T) {
+	t.Run("unsupported content coding", func(t *testing.T) {
+		_, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}), WithOutboundCompression("br"))
+		require.EqualError(t, err, "creation of outbound transport: unsupported content coding br")
+	})
+
+	t.Run("envelopes are sent compressed", func(t *testing.T) {
+		packager := &unpackRecorder{}
+
+		inHandler, err := NewInboundHandler(&mockProvider{packagerValue: packager})
+		require.NoError(t, err)
+
+		var encodings []string
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encodings = append(encodings, r.Header.Get("Content-Encoding"))
+			inHandler.ServeHTTP(w, r)
+		}))
+		defer srv.Close()
+
+		ot, err := NewOutbound(WithOutboundHTTPClient(srv.Client()), WithOutboundCompression(DeflateEncoding))
+		require.NoError(t, err)
+
+		_, err = ot.Send([]byte("envelope"), &service.Destination{ServiceEndpoint: srv.URL})
+		require.NoError(t, err)
+		require.Equal(t, []string{DeflateEncoding}, encodings)
+		require.Equal(t, [][]byte{[]byte("envelope")}, packager.received)
+	})
+
+	t.Run("envelopes are sent uncompressed to the agents rejecting the compressed ones", func(t *testing.T) {
+		var encodings []string
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encodings = append(encodings, r.Header.Get("Content-Encoding"))
+
+			if r.Header.Get("Content-Encoding") != "" {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer srv.Close()
+
+		ot, err := NewOutbound(WithOutboundHTTPClient(srv.Client()), WithOutboundCompression(GzipEncoding))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = ot.Send([]byte("envelope"), &service.Destination{ServiceEndpoint: srv.URL})
+			require.NoError(t, err)
+		}
+
+		// the second envelope is not compressed since the agent rejected the first compressed one
+		require.Equal(t, []string{GzipEncoding, "", ""}, encodings)
+	})
+}
@@ -8,10 +8,14 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync/atomic"
 
 	"github.com/rs/cors"
 
@@ -24,6 +28,8 @@ var logger = log.New("aries-framework/http")
 const (
 	// acceptInboundContentType additional content type to be accepted for inbound messages.
 	acceptInboundContentType = "application/ssi-agent-wire"
+	// problemContentType is the content type of the problem details of the rejected requests (RFC 7807).
+	problemContentType = "application/problem+json"
 )
 
 // TODO https://github.com/hyperledger/aries-framework-go/issues/891 Support for Transport Return Route (Duplex)
@@ -35,19 +41,28 @@ const (
 // * 'msgHandler' is the handler function that will be executed with the inbound request payload.
 //    Users of this library must manage the handling of all inbound payloads in this function.
 func NewInboundHandler(prov transport.Provider) (http.Handler, error) {
+	return newInboundHandler(prov, 0)
+}
+
+// newInboundHandler creates the inbound handler rejecting the payloads larger than maxMessageSize, the size of
+// the payloads is not limited if it is 0.
+func newInboundHandler(prov transport.Provider, maxMessageSize int64) (http.Handler, error) {
 	if prov == nil || prov.InboundMessageHandler() == nil {
 		logger.Errorf("Error creating a new inbound handler: message handler function is nil")
 		return nil, errors.New("creation of inbound handler failed")
 	}
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		processPOSTRequest(w, r, prov)
+		processPOSTRequest(w, r, prov, maxMessageSize)
 	})
 
 	return cors.Default().Handler(handler), nil
 }
 
-func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider) {
+func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider, maxMessageSize int64) {
+	// the content codings of the compressed payloads accepted are advertised as per RFC 7694
+	w.Header().Set("Accept-Encoding", acceptedEncodings)
+
 	if valid := validateHTTPMethod(w, r); !valid {
 		return
 	}
@@ -56,10 +71,20 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		return
 	}
 
-	body, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		logger.Errorf("Error reading request body: %s - returning Code: %d", err, http.StatusInternalServerError)
-		http.Error(w, "Failed to read payload", http.StatusInternalServerError)
+	// the payloads announced as too large are rejected before being read, the others are read up to the limit only
+	if maxMessageSize > 0 && r.ContentLength > maxMessageSize {
+		writeTooLarge(w, maxMessageSize)
+
+		return
+	}
+
+	body, ok := readPayload(w, r, maxMessageSize)
+	if !ok {
+		return
+	}
+
+	if maxMessageSize > 0 && int64(len(body)) > maxMessageSize {
+		writeTooLarge(w, maxMessageSize)
 
 		return
 	}
@@ -85,6 +110,73 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 	}
 }
 
+// readPayload reads the payload of the request, decompressed according to its content coding, up to one byte beyond
+// the maximum message size if any. The errors are written to the response, false is returned then.
+func readPayload(w http.ResponseWriter, r *http.Request, maxMessageSize int64) ([]byte, bool) {
+	encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+	if !supportedEncoding(encoding) {
+		http.Error(w, fmt.Sprintf("Unsupported Content-Encoding \"%s\"", encoding), http.StatusUnsupportedMediaType)
+
+		return nil, false
+	}
+
+	reader, err := decompressor(r.Body, encoding)
+	if err != nil {
+		logger.Errorf("Error decompressing request body: %s - returning Code: %d", err, http.StatusBadRequest)
+		http.Error(w, "Failed to decompress payload", http.StatusBadRequest)
+
+		return nil, false
+	}
+
+	// the decompressed payload is limited as well, so that small compressed payloads can't exhaust the memory
+	var payload io.Reader = reader
+
+	if maxMessageSize > 0 {
+		payload = io.LimitReader(reader, maxMessageSize+1)
+	}
+
+	body, err := ioutil.ReadAll(payload)
+	if err != nil {
+		logger.Errorf("Error reading request body: %s - returning Code: %d", err, http.StatusInternalServerError)
+		http.Error(w, "Failed to read payload", http.StatusInternalServerError)
+
+		return nil, false
+	}
+
+	return body, true
+}
+
+// writeTooLarge rejects the payload exceeding the maximum message size with problem details (RFC 7807).
+func writeTooLarge(w http.ResponseWriter, maxMessageSize int64) {
+	logger.Warnf("inbound message exceeds the maximum size of %d bytes - returning Code: %d",
+		maxMessageSize, http.StatusRequestEntityTooLarge)
+
+	problem, err := json.Marshal(&problemDetails{
+		Title:  http.StatusText(http.StatusRequestEntityTooLarge),
+		Status: http.StatusRequestEntityTooLarge,
+		Detail: fmt.Sprintf("the message exceeds the maximum size of %d bytes", maxMessageSize),
+	})
+	if err != nil {
+		http.Error(w, "Payload too large", http.StatusRequestEntityTooLarge)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+
+	if _, err = w.Write(problem); err != nil {
+		logger.Errorf("failed to write the problem details: %s", err)
+	}
+}
+
+// problemDetails are the problem details of the rejected requests (RFC 7807).
+type problemDetails struct {
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
 // validatePayload validate and get the payload from the request.
 func validatePayload(r *http.Request, w http.ResponseWriter) bool {
 	if r.ContentLength == 0 { // empty payload should not be accepted
@@ -105,7 +197,7 @@ func validateHTTPMethod(w http.ResponseWriter, r *http.Request) bool {
 	ct := r.Header.Get("Content-type")
 
 	// Interop: accept application/ssi-agent-wire legacy content type for inbound messages
-	if ct != commContentType && ct != acceptInboundContentType {
+	if ct != commContentType && ct != acceptInboundContentType && ct != transport.MediaTypeV2SignedMessage {
 		http.Error(w, fmt.Sprintf("Unsupported Content-type \"%s\"", ct), http.StatusUnsupportedMediaType)
 		return false
 	}
@@ -117,11 +209,24 @@ func validateHTTPMethod(w http.ResponseWriter, r *http.Request) bool {
 type Inbound struct {
 	externalAddr      string
 	server            *http.Server
+	listening         int32
 	certFile, keyFile string
+	maxMessageSize    int64
+}
+
+// InboundOpt is an inbound HTTP transport option.
+type InboundOpt func(i *Inbound)
+
+// WithInboundMaxMessageSize sets the maximum size (in bytes) of the inbound messages, the larger ones are rejected
+// with the 413 (Payload Too Large) status code. The size of the inbound messages is not limited by default (0).
+func WithInboundMaxMessageSize(size int64) InboundOpt {
+	return func(i *Inbound) {
+		i.maxMessageSize = size
+	}
 }
 
 // NewInbound creates a new HTTP inbound transport instance.
-func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound, error) {
+func NewInbound(internalAddr, externalAddr, certFile, keyFile string, opts ...InboundOpt) (*Inbound, error) {
 	if internalAddr == "" {
 		return nil, errors.New("http address is mandatory")
 	}
@@ -130,24 +235,38 @@ func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound,
 		externalAddr = internalAddr
 	}
 
-	return &Inbound{
-		certFile:     certFile,
-		keyFile:      keyFile,
-		externalAddr: externalAddr,
-		server:       &http.Server{Addr: internalAddr},
-	}, nil
+	i := &Inbound{
+		certFile:       certFile,
+		keyFile:        keyFile,
+		externalAddr:   externalAddr,
+		server:         &http.Server{Addr: internalAddr},
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	if i.maxMessageSize < 0 {
+		return nil, errors.New("http maximum message size must not be negative")
+	}
+
+	return i, nil
 }
 
 // Start the http server.
 func (i *Inbound) Start(prov transport.Provider) error {
-	handler, err := NewInboundHandler(prov)
+	handler, err := newInboundHandler(prov, i.maxMessageSize)
 	if err != nil {
 		return fmt.Errorf("HTTP server start failed: %w", err)
 	}
 
 	i.server.Handler = handler
 
+	atomic.StoreInt32(&i.listening, 1)
+
 	go func() {
+		defer atomic.StoreInt32(&i.listening, 0)
+
 		if err := i.listenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatalf("HTTP server start with address [%s] failed, cause:  %s", i.server.Addr, err)
 		}
@@ -166,13 +285,23 @@ func (i *Inbound) listenAndServe() error {
 
 // Stop the http server.
 func (i *Inbound) Stop() error {
-	if err := i.server.Shutdown(context.Background()); err != nil {
+	return i.Shutdown(context.Background())
+}
+
+// Shutdown stops the http server gracefully: the messages being received are handled, until the context is done.
+func (i *Inbound) Shutdown(ctx context.Context) error {
+	if err := i.server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("HTTP server shutdown failed: %w", err)
 	}
 
 	return nil
 }
 
+// Listening returns true if the http server is started and not stopped.
+func (i *Inbound) Listening() bool {
+	return atomic.LoadInt32(&i.listening) == 1
+}
+
 // Endpoint provides the http connection details.
 func (i *Inbound) Endpoint() string {
 	// return http prefix as framework only supports http
@@ -10,11 +10,15 @@ import (
 	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -117,6 +121,13 @@ func TestInboundHandler(t *testing.T) {
 	require.NotNil(t, resp)
 	require.Equal(t, http.StatusAccepted, resp.StatusCode)
 
+	// signed (not encrypted) messages are accepted too
+	resp, err = client.Post(serverURL+"/", transport.MediaTypeV2SignedMessage, bytes.NewBuffer([]byte(data)))
+	require.NoError(t, err)
+	err = resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
 	// test unpack error
 	mockPackager.UnpackValue = nil
 	mockPackager.UnpackErr = fmt.Errorf("unpack error")
@@ -130,6 +141,51 @@ func TestInboundHandler(t *testing.T) {
 	require.NoError(t, resp.Body.Close())
 }
 
+func TestInboundHandler_MaxMessageSize(t *testing.T) {
+	mockPackager := &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}}
+
+	inHandler, err := newInboundHandler(&mockProvider{packagerValue: mockPackager}, 8)
+	require.NoError(t, err)
+
+	post := func(body io.Reader) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/", body)
+		r.Header.Set("Content-Type", commContentType)
+
+		w := httptest.NewRecorder()
+		inHandler.ServeHTTP(w, r)
+
+		return w
+	}
+
+	t.Run("message of the maximum size", func(t *testing.T) {
+		require.Equal(t, http.StatusAccepted, post(strings.NewReader("12345678")).Code)
+	})
+
+	t.Run("message announced as too large", func(t *testing.T) {
+		w := post(strings.NewReader("123456789"))
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		require.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+
+		problem := &problemDetails{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), problem))
+		require.Equal(t, http.StatusRequestEntityTooLarge, problem.Status)
+		require.Equal(t, "the message exceeds the maximum size of 8 bytes", problem.Detail)
+	})
+
+	t.Run("message of unknown size too large", func(t *testing.T) {
+		// the content length of the request is unknown (-1) for the readers other than the buffers
+		w := post(io.MultiReader(strings.NewReader("12345"), strings.NewReader("6789")))
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("message size not limited by default", func(t *testing.T) {
+		inHandler, err = NewInboundHandler(&mockProvider{packagerValue: mockPackager})
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusAccepted, post(strings.NewReader(strings.Repeat("envelope", 1<<20))).Code)
+	})
+}
+
 func TestInboundTransport(t *testing.T) {
 	t.Run("test inbound transport - with host/port", func(t *testing.T) {
 		port := "26601"
@@ -167,6 +223,15 @@ func TestInboundTransport(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("test inbound transport - invalid maximum message size", func(t *testing.T) {
+		_, err := NewInbound(":26606", "", "", "", WithInboundMaxMessageSize(-1))
+		require.EqualError(t, err, "http maximum message size must not be negative")
+
+		inbound, err := NewInbound(":26606", "", "", "", WithInboundMaxMessageSize(1024))
+		require.NoError(t, err)
+		require.EqualValues(t, 1024, inbound.maxMessageSize)
+	})
+
 	t.Run("test inbound transport - invalid port number", func(t *testing.T) {
 		_, err := NewInbound("", "", "", "")
 		require.Error(t, err)
@@ -190,9 +255,11 @@ func TestInboundTransport(t *testing.T) {
 
 		// start server
 		mockPackager := &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}}
+		require.False(t, inbound.Listening())
 		err = inbound.Start(&mockProvider{packagerValue: mockPackager})
 		require.NoError(t, err)
 		require.NoError(t, listenFor("localhost:26605", time.Second))
+		require.True(t, inbound.Listening())
 		// invoke a endpoint
 		client := http.Client{}
 		resp, err := client.Post("http://localhost:26605", commContentType, bytes.NewBuffer([]byte("success")))
@@ -207,6 +274,8 @@ func TestInboundTransport(t *testing.T) {
 		err = inbound.Stop()
 		require.NoError(t, err)
 
+		require.Eventually(t, func() bool { return !inbound.Listening() }, time.Second, 10*time.Millisecond)
+
 		// try after server stop
 		_, err = client.Post("http://localhost:26605", commContentType, bytes.NewBuffer([]byte("success"))) // nolint
 		require.Error(t, err)
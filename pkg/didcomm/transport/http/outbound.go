@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -29,7 +30,8 @@ const (
 // outboundCommHTTPOpts holds options for the HTTP transport implementation of CommTransport
 // it has an http.Client instance.
 type outboundCommHTTPOpts struct {
-	client *http.Client
+	client      *http.Client
+	compression string
 }
 
 // OutboundHTTPOpt is an outbound HTTP transport option.
@@ -60,9 +62,21 @@ func WithOutboundTLSConfig(tlsConfig *tls.Config) OutboundHTTPOpt {
 	}
 }
 
+// WithOutboundCompression option is for creating an Outbound HTTP transport compressing the envelopes with
+// the content coding (GzipEncoding or DeflateEncoding). The envelopes are sent uncompressed to the agents
+// rejecting the compressed ones with the 415 (Unsupported Media Type) status code, as per RFC 7694.
+func WithOutboundCompression(encoding string) OutboundHTTPOpt {
+	return func(opts *outboundCommHTTPOpts) {
+		opts.compression = encoding
+	}
+}
+
 // OutboundHTTPClient represents the Outbound HTTP transport instance.
 type OutboundHTTPClient struct {
-	client *http.Client
+	client      *http.Client
+	compression string
+	// uncompressed are the service endpoints of the agents which do not accept the compressed envelopes.
+	uncompressed sync.Map
 }
 
 // NewOutbound creates a new instance of Outbound HTTP transport to Post requests to other Agents.
@@ -78,8 +92,13 @@ func NewOutbound(opts ...OutboundHTTPOpt) (*OutboundHTTPClient, error) {
 		return nil, errors.New("creation of outbound transport requires an HTTP client")
 	}
 
+	if clOpts.compression != "" && clOpts.compression != GzipEncoding && clOpts.compression != DeflateEncoding {
+		return nil, fmt.Errorf("creation of outbound transport: unsupported content coding %s", clOpts.compression)
+	}
+
 	cs := &OutboundHTTPClient{
-		client: clOpts.client,
+		client:      clOpts.client,
+		compression: clOpts.compression,
 	}
 
 	return cs, nil
@@ -92,40 +111,93 @@ func (cs *OutboundHTTPClient) Start(prov transport.Provider) error {
 
 // Send sends a2a exchange data via HTTP (client side).
 func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination) (string, error) {
-	resp, err := cs.client.Post(destination.ServiceEndpoint, commContentType, bytes.NewBuffer(data))
+	endpoint := destination.ServiceEndpoint
+
+	if cs.compressTo(endpoint) {
+		compressed, err := compress(data, cs.compression)
+		if err != nil {
+			return "", err
+		}
+
+		resp, respData, err := cs.post(endpoint, compressed, cs.compression)
+		if err != nil {
+			return "", err
+		}
+
+		if resp.StatusCode != http.StatusUnsupportedMediaType {
+			return checkResponse(endpoint, resp, respData)
+		}
+
+		logger.Infof("the agent of %s does not accept the %s envelopes, sending them uncompressed", endpoint,
+			cs.compression)
+
+		cs.uncompressed.Store(endpoint, true)
+	}
+
+	resp, respData, err := cs.post(endpoint, data, "")
 	if err != nil {
-		logger.Errorf("posting DID envelope to agent failed [%s, %v]", destination.ServiceEndpoint, err)
 		return "", err
 	}
 
-	var respData string
+	return checkResponse(endpoint, resp, respData)
+}
 
-	if resp != nil {
-		// handle response
-		defer func() {
-			e := resp.Body.Close()
-			if e != nil {
-				logger.Errorf("closing response body failed: %v", e)
-			}
-		}()
+// compressTo returns true if the envelopes are compressed for the agent of the endpoint.
+func (cs *OutboundHTTPClient) compressTo(endpoint string) bool {
+	if cs.compression == "" {
+		return false
+	}
 
-		buf := new(bytes.Buffer)
+	_, uncompressed := cs.uncompressed.Load(endpoint)
 
-		_, e := buf.ReadFrom(resp.Body)
+	return !uncompressed
+}
+
+// post posts the envelope, compressed with the content coding if it is not empty, and reads the response data.
+func (cs *OutboundHTTPClient) post(endpoint string, data []byte, encoding string) (*http.Response, string, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("new POST request to agent [%s]: %w", endpoint, err)
+	}
+
+	req.Header.Set("Content-Type", commContentType)
+
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
+	}
+
+	resp, err := cs.client.Do(req)
+	if err != nil {
+		logger.Errorf("posting DID envelope to agent failed [%s, %v]", endpoint, err)
+		return nil, "", err
+	}
+
+	// handle response
+	defer func() {
+		e := resp.Body.Close()
 		if e != nil {
-			return "", e
+			logger.Errorf("closing response body failed: %v", e)
 		}
+	}()
+
+	buf := new(bytes.Buffer)
+
+	_, err = buf.ReadFrom(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
 
-		respData = buf.String()
+	return resp, buf.String(), nil
+}
 
-		isStatusSuccess := resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusOK
-		if !isStatusSuccess {
-			logger.Errorf("didcomm failed : transport=http serviceEndpoint=%s status=%v errMsg=%s",
-				destination.ServiceEndpoint, resp.Status, respData)
+func checkResponse(endpoint string, resp *http.Response, respData string) (string, error) {
+	isStatusSuccess := resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusOK
+	if !isStatusSuccess {
+		logger.Errorf("didcomm failed : transport=http serviceEndpoint=%s status=%v errMsg=%s",
+			endpoint, resp.Status, respData)
 
-			return "", fmt.Errorf("received unsuccessful POST HTTP status from agent "+
-				"[%s, %v %s]", destination.ServiceEndpoint, resp.Status, respData)
-		}
+		return "", fmt.Errorf("received unsuccessful POST HTTP status from agent "+
+			"[%s, %v %s]", endpoint, resp.Status, respData)
 	}
 
 	return respData, nil
@@ -19,8 +19,90 @@ const (
 	// MediaTypeV2EncryptedEnvelopeV1PlaintextPayload is the media type for DIDComm V2 encrypted envelopes with a
 	// V1 plaintext payload as per Aries RFC 0587.
 	MediaTypeV2EncryptedEnvelopeV1PlaintextPayload = MediaTypeV2EncryptedEnvelope + ";cty=" + MediaTypeV1PlaintextPayload
+	// MediaTypeV2SignedMessage is the media type for DIDComm V2 signed (but not encrypted) messages as per the
+	// DIF DIDComm spec.
+	MediaTypeV2SignedMessage = "application/didcomm-signed+json"
+)
+
+// The media type profiles of the accept lists of the DIDComm services, as per Aries RFC 0044 and the DIF DIDComm spec.
+const (
+	// MediaTypeProfileAIP1 is the profile of the Aries Interop Profile 1.0 agents (DIDComm V1 envelopes).
+	MediaTypeProfileAIP1 = "didcomm/aip1"
+	// MediaTypeProfileAIP2RFC19 is the profile of the Aries Interop Profile 2.0 agents using DIDComm V1 envelopes.
+	MediaTypeProfileAIP2RFC19 = "didcomm/aip2;env=rfc19"
+	// MediaTypeProfileAIP2RFC587 is the profile of the Aries Interop Profile 2.0 agents using DIDComm V2 envelopes
+	// with V1 plaintext payloads.
+	MediaTypeProfileAIP2RFC587 = "didcomm/aip2;env=rfc587"
+	// MediaTypeProfileDIDCommV2 is the profile of the DIDComm V2 agents.
+	MediaTypeProfileDIDCommV2 = "didcomm/v2"
 )
 
+// DefaultMediaTypeProfiles returns the media type profiles the agents support by default.
+func DefaultMediaTypeProfiles() []string {
+	return []string{
+		MediaTypeProfileAIP1,
+		MediaTypeProfileAIP2RFC19,
+		MediaTypeProfileAIP2RFC587,
+		MediaTypeProfileDIDCommV2,
+	}
+}
+
+// MediaTypeForProfile returns the envelope media type of the media type profile. The envelope media types are
+// returned as is, an empty string is returned for the unknown profiles.
+func MediaTypeForProfile(profile string) string {
+	switch profile {
+	case MediaTypeProfileAIP1, MediaTypeProfileAIP2RFC19, MediaTypeV1EncryptedEnvelope:
+		return MediaTypeV1EncryptedEnvelope
+	case MediaTypeProfileAIP2RFC587, MediaTypeV2EncryptedEnvelopeV1PlaintextPayload:
+		return MediaTypeV2EncryptedEnvelopeV1PlaintextPayload
+	case MediaTypeProfileDIDCommV2, MediaTypeV2EncryptedEnvelope:
+		return MediaTypeV2EncryptedEnvelope
+	case MediaTypeV2SignedMessage:
+		return MediaTypeV2SignedMessage
+	default:
+		return ""
+	}
+}
+
+// NegotiateMediaTypeProfile returns the first media type profile (or envelope media type) of the accept list of the
+// other agent, in its order of preference, whose envelope media type is supported by one of the given profiles.
+func NegotiateMediaTypeProfile(accept, supported []string) (string, bool) {
+	for _, profile := range accept {
+		mediaType := MediaTypeForProfile(profile)
+		if mediaType == "" {
+			continue
+		}
+
+		for _, s := range supported {
+			if MediaTypeForProfile(s) == mediaType {
+				return profile, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// SelectMediaTypeProfile returns the media type profile negotiated from the accept list of the other agent and the
+// profiles of the agent (the default ones if it has none, see DefaultMediaTypeProfiles). The first profile of
+// the agent is returned if the other agent accepts none of them, an empty string if the agent has no profile either.
+func SelectMediaTypeProfile(accept, profiles []string) string {
+	supported := profiles
+	if len(supported) == 0 {
+		supported = DefaultMediaTypeProfiles()
+	}
+
+	if profile, ok := NegotiateMediaTypeProfile(accept, supported); ok {
+		return profile
+	}
+
+	if len(profiles) != 0 {
+		return profiles[0]
+	}
+
+	return ""
+}
+
 // EnvelopeMediaTypeFor returns the media type that corresponds with a DIDComm envelope given 'typ'
 // and optionally 'cty'.
 func EnvelopeMediaTypeFor(typ, cty string) (string, error) {
@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaTypeForProfile(t *testing.T) {
+	require.Equal(t, MediaTypeV1EncryptedEnvelope, MediaTypeForProfile(MediaTypeProfileAIP1))
+	require.Equal(t, MediaTypeV1EncryptedEnvelope, MediaTypeForProfile(MediaTypeProfileAIP2RFC19))
+	require.Equal(t, MediaTypeV2EncryptedEnvelopeV1PlaintextPayload, MediaTypeForProfile(MediaTypeProfileAIP2RFC587))
+	require.Equal(t, MediaTypeV2EncryptedEnvelope, MediaTypeForProfile(MediaTypeProfileDIDCommV2))
+	require.Equal(t, MediaTypeV2SignedMessage, MediaTypeForProfile(MediaTypeV2SignedMessage))
+	require.Empty(t, MediaTypeForProfile("didcomm/v3"))
+}
+
+func TestSelectMediaTypeProfile(t *testing.T) {
+	require.Equal(t, MediaTypeProfileDIDCommV2,
+		SelectMediaTypeProfile([]string{"didcomm/v3", MediaTypeProfileDIDCommV2, MediaTypeProfileAIP1}, nil))
+	require.Equal(t, MediaTypeV1EncryptedEnvelope,
+		SelectMediaTypeProfile([]string{MediaTypeV1EncryptedEnvelope}, []string{MediaTypeProfileAIP1}))
+	require.Equal(t, MediaTypeProfileAIP1,
+		SelectMediaTypeProfile([]string{MediaTypeProfileDIDCommV2}, []string{MediaTypeProfileAIP1}))
+	require.Empty(t, SelectMediaTypeProfile([]string{"didcomm/v3"}, nil))
+	require.Empty(t, SelectMediaTypeProfile(nil, nil))
+}
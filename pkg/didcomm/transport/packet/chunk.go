@@ -0,0 +1,194 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// chunkVersion is the version of the chunk header, the packets of other versions are dropped.
+	chunkVersion = 1
+	// headerSize is the size of the chunk header: the version, the message ID, the chunk index and the chunk count.
+	headerSize = 1 + 8 + 2 + 2
+	// maxChunks is the maximum number of chunks of a message.
+	maxChunks = math.MaxUint16
+)
+
+// chunk is a chunk of a message, carried by a packet.
+type chunk struct {
+	messageID string
+	index     int
+	count     int
+	payload   []byte
+}
+
+// messageID identifies a message by its digest, so that the message sent again after a failure is resumed.
+// The packed DIDComm messages are encrypted with random nonces, the same message is never sent twice.
+func messageID(data []byte) []byte {
+	digest := sha256.Sum256(data)
+
+	return digest[:8]
+}
+
+// split splits the message into the packets of its chunks.
+func split(data []byte, mtu int) ([][]byte, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty message")
+	}
+
+	chunkSize := mtu - headerSize
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("MTU %d is smaller than the chunk header", mtu)
+	}
+
+	count := (len(data) + chunkSize - 1) / chunkSize
+	if count > maxChunks {
+		return nil, fmt.Errorf("message of %d bytes exceeds %d chunks of %d bytes", len(data), maxChunks, chunkSize)
+	}
+
+	id := messageID(data)
+	packets := make([][]byte, count)
+
+	for i := range packets {
+		payload := data[i*chunkSize:]
+		if len(payload) > chunkSize {
+			payload = payload[:chunkSize]
+		}
+
+		packet := make([]byte, headerSize, headerSize+len(payload))
+		packet[0] = chunkVersion
+		copy(packet[1:9], id)
+		binary.BigEndian.PutUint16(packet[9:11], uint16(i))
+		binary.BigEndian.PutUint16(packet[11:13], uint16(count))
+
+		packets[i] = append(packet, payload...)
+	}
+
+	return packets, nil
+}
+
+// parseChunk parses the chunk carried by the packet.
+func parseChunk(packet []byte) (*chunk, error) {
+	if len(packet) <= headerSize {
+		return nil, errors.New("packet is too short")
+	}
+
+	if packet[0] != chunkVersion {
+		return nil, fmt.Errorf("unsupported chunk version %d", packet[0])
+	}
+
+	c := &chunk{
+		messageID: hex.EncodeToString(packet[1:9]),
+		index:     int(binary.BigEndian.Uint16(packet[9:11])),
+		count:     int(binary.BigEndian.Uint16(packet[11:13])),
+		payload:   packet[headerSize:],
+	}
+
+	if c.index >= c.count {
+		return nil, fmt.Errorf("invalid chunk %d of %d", c.index, c.count)
+	}
+
+	return c, nil
+}
+
+// partialMessage holds the chunks of a message received so far.
+type partialMessage struct {
+	chunks   [][]byte
+	received int
+	size     int64
+	updated  time.Time
+}
+
+// reassembler reassembles the messages from the chunks received from the peers. The partial messages are kept
+// until no chunk is received for the timeout, so that the messages interrupted by a link failure are resumed.
+type reassembler struct {
+	mu             sync.Mutex
+	timeout        time.Duration
+	maxMessageSize int64
+	messages       map[string]*partialMessage
+}
+
+func newReassembler(timeout time.Duration, maxMessageSize int64) *reassembler {
+	return &reassembler{
+		timeout:        timeout,
+		maxMessageSize: maxMessageSize,
+		messages:       map[string]*partialMessage{},
+	}
+}
+
+// add adds the chunk carried by the packet received from the peer, it returns the message once complete.
+func (r *reassembler) add(address string, packet []byte, now time.Time) ([]byte, error) {
+	c, err := parseChunk(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expire(now)
+
+	key := address + "/" + c.messageID
+
+	msg, ok := r.messages[key]
+	if !ok {
+		msg = &partialMessage{chunks: make([][]byte, c.count)}
+		r.messages[key] = msg
+	}
+
+	if len(msg.chunks) != c.count {
+		delete(r.messages, key)
+
+		return nil, fmt.Errorf("message %s: inconsistent chunk count", c.messageID)
+	}
+
+	msg.updated = now
+
+	if msg.chunks[c.index] != nil {
+		return nil, nil
+	}
+
+	msg.size += int64(len(c.payload))
+	if r.maxMessageSize > 0 && msg.size > r.maxMessageSize {
+		delete(r.messages, key)
+
+		return nil, fmt.Errorf("message %s exceeds the maximum size of %d bytes", c.messageID, r.maxMessageSize)
+	}
+
+	msg.chunks[c.index] = append([]byte(nil), c.payload...)
+	msg.received++
+
+	if msg.received < c.count {
+		return nil, nil
+	}
+
+	delete(r.messages, key)
+
+	data := make([]byte, 0, msg.size)
+	for _, payload := range msg.chunks {
+		data = append(data, payload...)
+	}
+
+	return data, nil
+}
+
+// expire drops the partial messages whose chunks stopped being received.
+func (r *reassembler) expire(now time.Time) {
+	for key, msg := range r.messages {
+		if now.Sub(msg.updated) > r.timeout {
+			delete(r.messages, key)
+		}
+	}
+}
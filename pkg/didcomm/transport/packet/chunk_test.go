@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10)
+
+	packets, err := split(data, headerSize+30)
+	require.NoError(t, err)
+	require.Len(t, packets, 4)
+
+	for i, packet := range packets {
+		require.LessOrEqual(t, len(packet), headerSize+30)
+
+		c, err := parseChunk(packet)
+		require.NoError(t, err)
+		require.Equal(t, i, c.index)
+		require.Equal(t, 4, c.count)
+	}
+
+	_, err = split(nil, 100)
+	require.EqualError(t, err, "empty message")
+
+	_, err = split(data, headerSize)
+	require.EqualError(t, err, "MTU 13 is smaller than the chunk header")
+
+	_, err = split(make([]byte, maxChunks+1), headerSize+1)
+	require.EqualError(t, err, "message of 65536 bytes exceeds 65535 chunks of 1 bytes")
+}
+
+func TestParseChunk(t *testing.T) {
+	packets, err := split([]byte("message"), 100)
+	require.NoError(t, err)
+
+	_, err = parseChunk(packets[0][:headerSize])
+	require.EqualError(t, err, "packet is too short")
+
+	packet := append([]byte(nil), packets[0]...)
+	packet[0] = 2
+
+	_, err = parseChunk(packet)
+	require.EqualError(t, err, "unsupported chunk version 2")
+
+	packet[0] = chunkVersion
+	packet[10] = 1
+
+	_, err = parseChunk(packet)
+	require.EqualError(t, err, "invalid chunk 1 of 1")
+}
+
+func TestReassembler(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10)
+	now := time.Now()
+
+	packets, err := split(data, headerSize+30)
+	require.NoError(t, err)
+
+	t.Run("chunks out of order and duplicated", func(t *testing.T) {
+		r := newReassembler(time.Minute, 1000)
+
+		for _, i := range []int{2, 0, 2, 3} {
+			msg, err := r.add("alice", packets[i], now)
+			require.NoError(t, err)
+			require.Nil(t, msg)
+		}
+
+		// the messages are reassembled by peer
+		msg, err := r.add("bob", packets[1], now)
+		require.NoError(t, err)
+		require.Nil(t, msg)
+
+		msg, err = r.add("alice", packets[1], now)
+		require.NoError(t, err)
+		require.Equal(t, data, msg)
+		require.Len(t, r.messages, 1)
+	})
+
+	t.Run("partial messages expire", func(t *testing.T) {
+		r := newReassembler(time.Minute, 1000)
+
+		for _, packet := range packets[:3] {
+			_, err := r.add("alice", packet, now)
+			require.NoError(t, err)
+		}
+
+		msg, err := r.add("alice", packets[3], now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, data, msg)
+
+		for _, packet := range packets[:3] {
+			_, err = r.add("alice", packet, now)
+			require.NoError(t, err)
+		}
+
+		msg, err = r.add("alice", packets[3], now.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.Nil(t, msg)
+		require.Len(t, r.messages, 1)
+	})
+
+	t.Run("message too large", func(t *testing.T) {
+		r := newReassembler(time.Minute, 70)
+
+		for _, packet := range packets[:2] {
+			_, err := r.add("alice", packet, now)
+			require.NoError(t, err)
+		}
+
+		_, err := r.add("alice", packets[2], now)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exceeds the maximum size of 70 bytes")
+		require.Empty(t, r.messages)
+	})
+
+	t.Run("inconsistent chunk count", func(t *testing.T) {
+		r := newReassembler(time.Minute, 1000)
+
+		_, err := r.add("alice", packets[0], now)
+		require.NoError(t, err)
+
+		packet := append([]byte(nil), packets[1]...)
+		packet[12] = 5
+
+		_, err = r.add("alice", packet, now)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "inconsistent chunk count")
+		require.Empty(t, r.messages)
+
+		_, err = r.add("alice", packets[0][:headerSize], now)
+		require.EqualError(t, err, "packet is too short")
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packet
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+// Inbound is the inbound transport receiving the messages over a packet link.
+type Inbound struct {
+	link              Link
+	maxMessageSize    int64
+	reassemblyTimeout time.Duration
+	reassembler       *reassembler
+	listening         int32
+}
+
+// InboundOpt is an inbound packet transport option.
+type InboundOpt func(i *Inbound)
+
+// WithInboundMaxMessageSize sets the maximum size (in bytes) of the inbound messages, the larger ones are dropped.
+// The size of the inbound messages is not limited by default (0).
+func WithInboundMaxMessageSize(size int64) InboundOpt {
+	return func(i *Inbound) {
+		i.maxMessageSize = size
+	}
+}
+
+// WithInboundReassemblyTimeout sets the time the partial messages are kept while no chunk is received, so that
+// the senders can resume them. It is one minute by default.
+func WithInboundReassemblyTimeout(timeout time.Duration) InboundOpt {
+	return func(i *Inbound) {
+		i.reassemblyTimeout = timeout
+	}
+}
+
+// NewInbound creates a new inbound transport over the packet link.
+func NewInbound(link Link, opts ...InboundOpt) (*Inbound, error) {
+	if link == nil {
+		return nil, errors.New("packet link is mandatory")
+	}
+
+	i := &Inbound{
+		link:              link,
+		reassemblyTimeout: defaultResumeTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	if i.maxMessageSize < 0 {
+		return nil, errors.New("packet maximum message size must not be negative")
+	}
+
+	return i, nil
+}
+
+// Start starts listening to the packets of the link.
+func (i *Inbound) Start(prov transport.Provider) error {
+	if prov == nil || prov.InboundMessageHandler() == nil {
+		return errors.New("creation of inbound handler failed")
+	}
+
+	i.reassembler = newReassembler(i.reassemblyTimeout, i.maxMessageSize)
+
+	err := i.link.Listen(func(address string, packet []byte) {
+		i.handlePacket(prov, address, packet)
+	})
+	if err != nil {
+		return fmt.Errorf("listen to packet link: %w", err)
+	}
+
+	atomic.StoreInt32(&i.listening, 1)
+
+	return nil
+}
+
+func (i *Inbound) handlePacket(prov transport.Provider, address string, packet []byte) {
+	if !i.Listening() {
+		return
+	}
+
+	data, err := i.reassembler.add(address, packet, time.Now())
+	if err != nil {
+		logger.Warnf("dropped packet from %s: %s", address, err)
+
+		return
+	}
+
+	if data == nil {
+		return
+	}
+
+	unpackMsg, err := prov.Packager().UnpackMessage(data)
+	if err != nil {
+		logger.Errorf("failed to unpack msg: %s", err)
+
+		return
+	}
+
+	err = prov.InboundMessageHandler()(unpackMsg)
+	if err != nil {
+		logger.Errorf("incoming msg processing failed: %s", err)
+	}
+}
+
+// Stop stops handling the packets of the link, the link itself is closed by its owner.
+func (i *Inbound) Stop() error {
+	atomic.StoreInt32(&i.listening, 0)
+
+	return nil
+}
+
+// Listening returns true if the transport is started and not stopped.
+func (i *Inbound) Listening() bool {
+	return atomic.LoadInt32(&i.listening) == 1
+}
+
+// Endpoint returns the endpoint of the agent on the link, e.g. ble://<address>.
+func (i *Inbound) Endpoint() string {
+	return i.link.Scheme() + "://" + i.link.Address()
+}
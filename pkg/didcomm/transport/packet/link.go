@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package packet implements the DIDComm transports over packet-oriented links limited by a small MTU, such as
+// Bluetooth LE (GATT characteristics) or NFC (APDUs). The mobile wrappers implement the Link SPI on top of the
+// platform radios, the Inbound and Outbound transports chunk the packed DIDComm messages into packets and
+// reassemble them, so the links never deal with the envelopes.
+package packet
+
+// Link is a packet-oriented link to the peers of the agent. The packets are written and delivered whole, they may
+// be delivered out of order or more than once. The lifecycle of the link (discovery, pairing, reconnection) is
+// managed by its implementation.
+type Link interface {
+	// Scheme returns the scheme of the endpoints reached through the link, e.g. ble or nfc.
+	Scheme() string
+
+	// Address returns the address of the agent on the link.
+	Address() string
+
+	// MTU returns the maximum size (in bytes) of the packets written to the link.
+	MTU() int
+
+	// WritePacket writes the packet to the peer of the given address. The errors are expected to be transient
+	// (e.g. the peer is out of range), the packet is written again until the retries of the transport are exhausted.
+	WritePacket(address string, packet []byte) error
+
+	// Listen sets the handler of the packets received from the peers.
+	Listen(handler Handler) error
+}
+
+// Handler handles the packet received from the peer of the given address.
+type Handler func(address string, packet []byte)
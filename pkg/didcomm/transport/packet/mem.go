@@ -0,0 +1,134 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packet
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MemScheme is the scheme of the endpoints of the in-memory links.
+const MemScheme = "mem"
+
+// ErrUnreachable is returned when writing a packet to a peer which is not connected to the in-memory network.
+var ErrUnreachable = errors.New("peer is unreachable")
+
+// MemNetwork is an in-memory packet network, it connects the in-memory links of the agents in the same process.
+// It is the reference implementation of the Link SPI, for tests and demos.
+type MemNetwork struct {
+	mu    sync.RWMutex
+	links map[string]*MemLink
+}
+
+// NewMemNetwork returns a new in-memory packet network.
+func NewMemNetwork() *MemNetwork {
+	return &MemNetwork{links: map[string]*MemLink{}}
+}
+
+// Link connects a new in-memory link of the given address and MTU to the network.
+func (n *MemNetwork) Link(address string, mtu int) (*MemLink, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.links[address]; ok {
+		return nil, fmt.Errorf("address %s is already linked", address)
+	}
+
+	link := &MemLink{network: n, address: address, mtu: mtu}
+	n.links[address] = link
+
+	return link, nil
+}
+
+// Disconnect disconnects the link of the given address from the network, e.g. when the peer is out of range.
+func (n *MemNetwork) Disconnect(address string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.links, address)
+}
+
+// Reconnect connects again the link disconnected from the network.
+func (n *MemNetwork) Reconnect(link *MemLink) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.links[link.address] = link
+}
+
+func (n *MemNetwork) link(address string) (*MemLink, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	link, ok := n.links[address]
+
+	return link, ok
+}
+
+// MemLink is an in-memory packet link, the packets are delivered synchronously to the handler of the peer.
+type MemLink struct {
+	network *MemNetwork
+	address string
+	mtu     int
+
+	mu      sync.RWMutex
+	handler Handler
+}
+
+// Scheme returns the scheme of the in-memory links.
+func (l *MemLink) Scheme() string {
+	return MemScheme
+}
+
+// Address returns the address of the link.
+func (l *MemLink) Address() string {
+	return l.address
+}
+
+// MTU returns the maximum size of the packets.
+func (l *MemLink) MTU() int {
+	return l.mtu
+}
+
+// WritePacket delivers the packet to the peer of the given address.
+func (l *MemLink) WritePacket(address string, packet []byte) error {
+	if len(packet) > l.mtu {
+		return fmt.Errorf("packet of %d bytes exceeds the MTU of %d bytes", len(packet), l.mtu)
+	}
+
+	if _, ok := l.network.link(l.address); !ok {
+		return ErrUnreachable
+	}
+
+	peer, ok := l.network.link(address)
+	if !ok {
+		return ErrUnreachable
+	}
+
+	peer.mu.RLock()
+	handler := peer.handler
+	peer.mu.RUnlock()
+
+	if handler == nil {
+		return fmt.Errorf("peer %s is not listening", address)
+	}
+
+	handler(l.address, append([]byte(nil), packet...))
+
+	return nil
+}
+
+// Listen sets the handler of the packets delivered to the link.
+func (l *MemLink) Listen(handler Handler) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.handler = handler
+
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+var logger = log.New("aries-framework/packet")
+
+const (
+	defaultRetries       = 3
+	defaultRetryInterval = 200 * time.Millisecond
+	// defaultResumeTimeout is the time the partial messages are kept to be resumed, by the senders and the receivers.
+	defaultResumeTimeout = time.Minute
+)
+
+// Outbound is the outbound transport sending the messages over a packet link.
+type Outbound struct {
+	link          Link
+	retries       int
+	retryInterval time.Duration
+	resumeTimeout time.Duration
+
+	mu sync.Mutex
+	// interrupted are the messages whose sending failed by destination and message ID.
+	interrupted map[string]*interruptedMessage
+}
+
+// interruptedMessage is a message whose sending failed, it is resumed from its first unsent chunk.
+type interruptedMessage struct {
+	sent int
+	at   time.Time
+}
+
+// OutboundOpt is an outbound packet transport option.
+type OutboundOpt func(o *Outbound)
+
+// WithOutboundRetries sets the number of times a packet is written again when the link fails to write it, and the
+// interval between the writes. A packet is written again 3 times every 200ms by default.
+func WithOutboundRetries(retries int, interval time.Duration) OutboundOpt {
+	return func(o *Outbound) {
+		o.retries = retries
+		o.retryInterval = interval
+	}
+}
+
+// WithOutboundResumeTimeout sets the time a message whose sending failed is resumed from its first unsent chunk
+// when it is sent again. It is one minute by default, the receivers should keep the partial messages as long.
+func WithOutboundResumeTimeout(timeout time.Duration) OutboundOpt {
+	return func(o *Outbound) {
+		o.resumeTimeout = timeout
+	}
+}
+
+// NewOutbound creates a new outbound transport over the packet link.
+func NewOutbound(link Link, opts ...OutboundOpt) (*Outbound, error) {
+	if link == nil {
+		return nil, errors.New("packet link is mandatory")
+	}
+
+	if link.MTU() <= headerSize {
+		return nil, fmt.Errorf("packet link MTU must be greater than %d", headerSize)
+	}
+
+	o := &Outbound{
+		link:          link,
+		retries:       defaultRetries,
+		retryInterval: defaultRetryInterval,
+		resumeTimeout: defaultResumeTimeout,
+		interrupted:   map[string]*interruptedMessage{},
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o, nil
+}
+
+// Start starts the outbound transport.
+func (o *Outbound) Start(transport.Provider) error {
+	return nil
+}
+
+// Send sends the message to the destination, chunked into packets. The message sent again after a failure
+// is resumed from its first unsent chunk.
+func (o *Outbound) Send(data []byte, destination *service.Destination) (string, error) {
+	address := strings.TrimPrefix(destination.ServiceEndpoint, o.link.Scheme()+"://")
+
+	packets, err := split(data, o.link.MTU())
+	if err != nil {
+		return "", fmt.Errorf("chunk message: %w", err)
+	}
+
+	key := address + "/" + hex.EncodeToString(messageID(data))
+
+	for i := o.resume(key); i < len(packets); i++ {
+		err = o.write(address, packets[i])
+		if err != nil {
+			o.interrupt(key, i)
+
+			logger.Errorf("didcomm failed : transport=%s serviceEndpoint=%s errMsg=%s",
+				o.link.Scheme(), destination.ServiceEndpoint, err.Error())
+
+			return "", fmt.Errorf("write packet %d of %d: %w", i+1, len(packets), err)
+		}
+	}
+
+	o.mu.Lock()
+	delete(o.interrupted, key)
+	o.mu.Unlock()
+
+	return "", nil
+}
+
+func (o *Outbound) write(address string, packet []byte) error {
+	err := o.link.WritePacket(address, packet)
+
+	for i := 0; err != nil && i < o.retries; i++ {
+		time.Sleep(o.retryInterval)
+
+		err = o.link.WritePacket(address, packet)
+	}
+
+	return err
+}
+
+// resume returns the first unsent chunk of the message.
+func (o *Outbound) resume(key string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+
+	for k, msg := range o.interrupted {
+		if now.Sub(msg.at) > o.resumeTimeout {
+			delete(o.interrupted, k)
+		}
+	}
+
+	if msg, ok := o.interrupted[key]; ok {
+		return msg.sent
+	}
+
+	return 0
+}
+
+func (o *Outbound) interrupt(key string, sent int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.interrupted[key] = &interruptedMessage{sent: sent, at: time.Now()}
+}
+
+// Accept checks for the url scheme of the link.
+func (o *Outbound) Accept(url string) bool {
+	return strings.HasPrefix(url, o.link.Scheme()+"://")
+}
+
+// AcceptRecipient returns false, the packet transport doesn't keep connections for the return routes.
+func (o *Outbound) AcceptRecipient([]string) bool {
+	return false
+}
@@ -0,0 +1,268 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+const testMTU = 20
+
+func TestTransport(t *testing.T) {
+	network := NewMemNetwork()
+
+	aliceLink, err := network.Link("alice", testMTU)
+	require.NoError(t, err)
+
+	bobLink, err := network.Link("bob", testMTU)
+	require.NoError(t, err)
+
+	bob, err := NewInbound(bobLink)
+	require.NoError(t, err)
+	require.False(t, bob.Listening())
+	require.Equal(t, "mem://bob", bob.Endpoint())
+
+	prov := newMockProvider()
+	require.NoError(t, bob.Start(prov))
+	require.True(t, bob.Listening())
+
+	alice, err := NewOutbound(aliceLink)
+	require.NoError(t, err)
+	require.NoError(t, alice.Start(prov))
+	require.True(t, alice.Accept("mem://bob"))
+	require.False(t, alice.Accept("ble://bob"))
+	require.False(t, alice.AcceptRecipient([]string{"key"}))
+
+	destination := &service.Destination{ServiceEndpoint: bob.Endpoint()}
+
+	t.Run("messages are chunked and reassembled", func(t *testing.T) {
+		data := bytes.Repeat([]byte("didcomm envelope "), 20)
+
+		_, err = alice.Send(data, destination)
+		require.NoError(t, err)
+		require.Equal(t, data, prov.received())
+
+		_, err = alice.Send([]byte("{}"), destination)
+		require.NoError(t, err)
+		require.Equal(t, []byte("{}"), prov.received())
+	})
+
+	t.Run("interrupted messages are resumed", func(t *testing.T) {
+		data := bytes.Repeat([]byte("didcomm envelope "), 10)
+		flaky := &flakyLink{Link: aliceLink, failAt: 5}
+
+		outbound, err := NewOutbound(flaky, WithOutboundRetries(1, time.Millisecond))
+		require.NoError(t, err)
+
+		_, err = outbound.Send(data, destination)
+		require.EqualError(t, err, "write packet 5 of 25: link error")
+		require.Equal(t, 6, flaky.writes)
+
+		_, err = outbound.Send(data, destination)
+		require.NoError(t, err)
+		require.Equal(t, data, prov.received())
+		// the 4 packets written before the failure were not written again
+		require.Equal(t, 27, flaky.writes)
+	})
+
+	t.Run("peer out of range", func(t *testing.T) {
+		network.Disconnect("bob")
+
+		_, err = alice.Send([]byte("{}"), destination)
+		require.True(t, errors.Is(err, ErrUnreachable))
+
+		network.Reconnect(bobLink)
+
+		_, err = alice.Send([]byte("{}"), destination)
+		require.NoError(t, err)
+		require.Equal(t, []byte("{}"), prov.received())
+	})
+
+	t.Run("invalid messages are dropped", func(t *testing.T) {
+		prov.unpackErr = errors.New("unpack error")
+
+		_, err = alice.Send([]byte("invalid"), destination)
+		require.NoError(t, err)
+
+		prov.unpackErr = nil
+
+		_, err = alice.Send([]byte("invalid-data"), destination)
+		require.NoError(t, err)
+		require.Equal(t, []byte("invalid-data"), prov.received())
+
+		require.NoError(t, aliceLink.WritePacket("bob", []byte("short")))
+		require.Empty(t, prov.messages)
+
+		_, err = alice.Send(nil, destination)
+		require.EqualError(t, err, "chunk message: empty message")
+	})
+
+	t.Run("stopped transport", func(t *testing.T) {
+		require.NoError(t, bob.Stop())
+		require.False(t, bob.Listening())
+
+		_, err = alice.Send([]byte("{}"), destination)
+		require.NoError(t, err)
+		require.Empty(t, prov.messages)
+	})
+}
+
+func TestNewTransport_Errors(t *testing.T) {
+	network := NewMemNetwork()
+
+	link, err := network.Link("alice", headerSize)
+	require.NoError(t, err)
+
+	_, err = network.Link("alice", testMTU)
+	require.EqualError(t, err, "address alice is already linked")
+
+	_, err = NewOutbound(nil)
+	require.EqualError(t, err, "packet link is mandatory")
+
+	_, err = NewOutbound(link)
+	require.EqualError(t, err, "packet link MTU must be greater than 13")
+
+	_, err = NewInbound(nil)
+	require.EqualError(t, err, "packet link is mandatory")
+
+	_, err = NewInbound(link, WithInboundMaxMessageSize(-1))
+	require.EqualError(t, err, "packet maximum message size must not be negative")
+
+	inbound, err := NewInbound(link, WithInboundReassemblyTimeout(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, time.Second, inbound.reassemblyTimeout)
+
+	require.EqualError(t, inbound.Start(nil), "creation of inbound handler failed")
+
+	inbound, err = NewInbound(&flakyLink{Link: link, listenErr: errors.New("listen error")})
+	require.NoError(t, err)
+
+	require.EqualError(t, inbound.Start(newMockProvider()), "listen to packet link: listen error")
+}
+
+func TestMemLink(t *testing.T) {
+	network := NewMemNetwork()
+
+	alice, err := network.Link("alice", testMTU)
+	require.NoError(t, err)
+	require.Equal(t, MemScheme, alice.Scheme())
+	require.Equal(t, "alice", alice.Address())
+	require.Equal(t, testMTU, alice.MTU())
+
+	bob, err := network.Link("bob", testMTU)
+	require.NoError(t, err)
+
+	err = alice.WritePacket("bob", []byte("packet"))
+	require.EqualError(t, err, "peer bob is not listening")
+
+	var received []byte
+
+	require.NoError(t, bob.Listen(func(address string, packet []byte) {
+		require.Equal(t, "alice", address)
+		received = packet
+	}))
+
+	require.NoError(t, alice.WritePacket("bob", []byte("packet")))
+	require.Equal(t, []byte("packet"), received)
+
+	err = alice.WritePacket("bob", make([]byte, testMTU+1))
+	require.EqualError(t, err, "packet of 21 bytes exceeds the MTU of 20 bytes")
+
+	require.True(t, errors.Is(alice.WritePacket("carol", []byte("packet")), ErrUnreachable))
+
+	network.Disconnect("alice")
+	require.True(t, errors.Is(alice.WritePacket("bob", []byte("packet")), ErrUnreachable))
+}
+
+type mockProvider struct {
+	messages  chan []byte
+	unpackErr error
+}
+
+func newMockProvider() *mockProvider {
+	return &mockProvider{messages: make(chan []byte, 10)}
+}
+
+func (p *mockProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	return func(envelope *transport.Envelope) error {
+		p.messages <- envelope.Message
+
+		if string(envelope.Message) == "invalid-data" {
+			return errors.New("error")
+		}
+
+		return nil
+	}
+}
+
+func (p *mockProvider) Packager() transport.Packager {
+	return p
+}
+
+func (p *mockProvider) AriesFrameworkID() string {
+	return "framework"
+}
+
+func (p *mockProvider) PackMessage(envelope *transport.Envelope) ([]byte, error) {
+	return envelope.Message, nil
+}
+
+func (p *mockProvider) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	if p.unpackErr != nil {
+		return nil, p.unpackErr
+	}
+
+	return &transport.Envelope{Message: encMessage}, nil
+}
+
+// received returns the message handled by the inbound transport, the in-memory links deliver them synchronously.
+func (p *mockProvider) received() []byte {
+	select {
+	case msg := <-p.messages:
+		return msg
+	default:
+		return nil
+	}
+}
+
+// flakyLink fails to write the packets from the failAt-th write, until the failure is reported to the sender.
+type flakyLink struct {
+	Link
+	failAt    int
+	writes    int
+	listenErr error
+}
+
+func (l *flakyLink) WritePacket(address string, packet []byte) error {
+	l.writes++
+
+	if l.failAt > 0 && l.writes >= l.failAt {
+		if l.writes == l.failAt+1 {
+			l.failAt = 0
+		}
+
+		return errors.New("link error")
+	}
+
+	return l.Link.WritePacket(address, packet)
+}
+
+func (l *flakyLink) Listen(handler Handler) error {
+	if l.listenErr != nil {
+		return l.listenErr
+	}
+
+	return l.Link.Listen(handler)
+}
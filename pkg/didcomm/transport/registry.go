@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"sync"
+)
+
+// OutboundRegistry holds the outbound transports of the framework, transports can be added and removed while the
+// framework is running. The list of transports is copied on write: the slices returned by Transports are never
+// modified, so messages being dispatched keep using the transports they started with.
+type OutboundRegistry struct {
+	mu         sync.RWMutex
+	transports []OutboundTransport
+}
+
+// NewOutboundRegistry returns a new outbound transport registry holding the given transports.
+func NewOutboundRegistry(transports ...OutboundTransport) *OutboundRegistry {
+	return &OutboundRegistry{transports: append([]OutboundTransport(nil), transports...)}
+}
+
+// Transports returns the registered outbound transports.
+func (r *OutboundRegistry) Transports() []OutboundTransport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.transports
+}
+
+// Add registers an outbound transport, the transport must be started by the caller.
+func (r *OutboundRegistry) Add(t OutboundTransport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transports := make([]OutboundTransport, 0, len(r.transports)+1)
+	transports = append(transports, r.transports...)
+
+	r.transports = append(transports, t)
+}
+
+// Remove unregisters the outbound transports accepting the endpoints of the given scheme (eg: http, ws) and returns
+// them, the caller is in charge of shutting them down.
+func (r *OutboundRegistry) Remove(scheme string) []OutboundTransport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		kept    []OutboundTransport
+		removed []OutboundTransport
+	)
+
+	for _, t := range r.transports {
+		if t.Accept(scheme + "://") {
+			removed = append(removed, t)
+
+			continue
+		}
+
+		kept = append(kept, t)
+	}
+
+	r.transports = kept
+
+	return removed
+}
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+type schemeTransport struct {
+	scheme string
+}
+
+func (t *schemeTransport) Start(Provider) error {
+	return nil
+}
+
+func (t *schemeTransport) Send([]byte, *service.Destination) (string, error) {
+	return "", nil
+}
+
+func (t *schemeTransport) AcceptRecipient([]string) bool {
+	return false
+}
+
+func (t *schemeTransport) Accept(url string) bool {
+	return strings.HasPrefix(url, t.scheme)
+}
+
+func TestOutboundRegistry(t *testing.T) {
+	httpTransport := &schemeTransport{scheme: "http"}
+	wsTransport := &schemeTransport{scheme: "ws"}
+
+	t.Run("add and remove transports", func(t *testing.T) {
+		registry := NewOutboundRegistry(httpTransport)
+		require.Equal(t, []OutboundTransport{httpTransport}, registry.Transports())
+
+		registry.Add(wsTransport)
+		require.Equal(t, []OutboundTransport{httpTransport, wsTransport}, registry.Transports())
+
+		require.Equal(t, []OutboundTransport{httpTransport}, registry.Remove("http"))
+		require.Equal(t, []OutboundTransport{wsTransport}, registry.Transports())
+
+		require.Empty(t, registry.Remove("http"))
+	})
+
+	t.Run("transports returned are not modified", func(t *testing.T) {
+		registry := NewOutboundRegistry(httpTransport, wsTransport)
+
+		transports := registry.Transports()
+
+		registry.Remove("http")
+		registry.Add(&schemeTransport{scheme: "http"})
+
+		require.Equal(t, []OutboundTransport{httpTransport, wsTransport}, transports)
+	})
+}
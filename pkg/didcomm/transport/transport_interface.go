@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package transport
 
 import (
+	"context"
+
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 )
 
@@ -62,6 +64,17 @@ type InboundTransport interface {
 	Endpoint() string
 }
 
+// Shutdowner is implemented by the inbound and outbound transports that can stop gracefully: they stop accepting
+// messages, drain the requests in progress and close their connections, until the context is done.
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// Listener is implemented by the inbound transports reporting whether their server accepts connections.
+type Listener interface {
+	Listening() bool
+}
+
 // Packager manages the handling, building and parsing of DIDComm raw messages in JSON envelopes.
 //
 // These envelopes are used as wire-level wrappers of messages sent in Aries agent-agent communication.
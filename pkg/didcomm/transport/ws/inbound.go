@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 
 	"nhooyr.io/websocket"
 
@@ -24,12 +25,35 @@ var logger = log.New("aries-framework/ws")
 type Inbound struct {
 	externalAddr      string
 	server            *http.Server
+	listening         int32
 	pool              *connPool
 	certFile, keyFile string
+	maxMessageSize    int64
+	compression       bool
+}
+
+// InboundOpt is an inbound WebSocket transport option.
+type InboundOpt func(i *Inbound)
+
+// WithInboundMaxMessageSize sets the maximum size (in bytes) of the inbound messages, the connections receiving
+// a larger one are closed with the 1009 (Message Too Big) status code. By default (0), the read limit of
+// the WebSocket library applies.
+func WithInboundMaxMessageSize(size int64) InboundOpt {
+	return func(i *Inbound) {
+		i.maxMessageSize = size
+	}
+}
+
+// WithInboundCompression enables the compression of the messages of the connections negotiating
+// the permessage-deflate extension (RFC 7692).
+func WithInboundCompression() InboundOpt {
+	return func(i *Inbound) {
+		i.compression = true
+	}
 }
 
 // NewInbound creates a new WebSocket inbound transport instance.
-func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound, error) {
+func NewInbound(internalAddr, externalAddr, certFile, keyFile string, opts ...InboundOpt) (*Inbound, error) {
 	if internalAddr == "" {
 		return nil, errors.New("websocket address is mandatory")
 	}
@@ -38,12 +62,22 @@ func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound,
 		externalAddr = internalAddr
 	}
 
-	return &Inbound{
-		certFile:     certFile,
-		keyFile:      keyFile,
-		externalAddr: externalAddr,
-		server:       &http.Server{Addr: internalAddr},
-	}, nil
+	i := &Inbound{
+		certFile:       certFile,
+		keyFile:        keyFile,
+		externalAddr:   externalAddr,
+		server:         &http.Server{Addr: internalAddr},
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	if i.maxMessageSize < 0 {
+		return nil, errors.New("websocket maximum message size must not be negative")
+	}
+
+	return i, nil
 }
 
 // Start the http(ws) server.
@@ -58,7 +92,11 @@ func (i *Inbound) Start(prov transport.Provider) error {
 
 	i.pool = getConnPool(prov)
 
+	atomic.StoreInt32(&i.listening, 1)
+
 	go func() {
+		defer atomic.StoreInt32(&i.listening, 0)
+
 		if err := i.listenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatalf("websocket server start with address [%s] failed, cause:  %s", i.server.Addr, err)
 		}
@@ -77,30 +115,56 @@ func (i *Inbound) listenAndServe() error {
 
 // Stop the http(ws) server.
 func (i *Inbound) Stop() error {
-	if err := i.server.Shutdown(context.Background()); err != nil {
+	return i.Shutdown(context.Background())
+}
+
+// Shutdown stops the http(ws) server gracefully: it stops accepting connections, then closes the inbound
+// websocket connections and waits for their messages to be handled, until the context is done.
+func (i *Inbound) Shutdown(ctx context.Context) error {
+	if err := i.server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("websocket server shutdown failed: %w", err)
 	}
 
+	if i.pool == nil {
+		return nil
+	}
+
+	if err := i.pool.shutdown(ctx, false); err != nil {
+		return err
+	}
+
+	releaseConnPool(i.pool.id)
+
 	return nil
 }
 
+// Listening returns true if the http(ws) server is started and not stopped.
+func (i *Inbound) Listening() bool {
+	return atomic.LoadInt32(&i.listening) == 1
+}
+
 // Endpoint provides the http(ws) connection details.
 func (i *Inbound) Endpoint() string {
 	return i.externalAddr
 }
 
 func (i *Inbound) processRequest(w http.ResponseWriter, r *http.Request) {
-	c, err := upgradeConnection(w, r)
+	c, err := upgradeConnection(w, r, i.compression)
 	if err != nil {
 		logger.Errorf("failed to upgrade the connection : %v", err)
 		return
 	}
 
+	// the messages are read up to the limit only, the connection is closed when it is exceeded
+	if i.maxMessageSize > 0 {
+		c.SetReadLimit(i.maxMessageSize)
+	}
+
 	i.pool.listener(c, false)
 }
 
-func upgradeConnection(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
-	c, err := Accept(w, r)
+func upgradeConnection(w http.ResponseWriter, r *http.Request, compression bool) (*websocket.Conn, error) {
+	c, err := accept(w, r, compression)
 	if err != nil {
 		logger.Errorf("failed to upgrade the connection : %v", err)
 		return nil, err
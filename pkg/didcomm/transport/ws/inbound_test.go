@@ -11,7 +11,9 @@ import (
 	"errors"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"nhooyr.io/websocket"
 
@@ -71,9 +73,68 @@ func TestInboundTransport(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "websocket address is mandatory")
 	})
+
+	t.Run("test inbound transport - invalid maximum message size", func(t *testing.T) {
+		_, err := NewInbound(":0", "", "", "", WithInboundMaxMessageSize(-1))
+		require.EqualError(t, err, "websocket maximum message size must not be negative")
+	})
 }
 
 func TestInboundDataProcessing(t *testing.T) {
+	t.Run("test inbound transport - message too big", func(t *testing.T) {
+		port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+		inbound, err := NewInbound(port, "", "", "", WithInboundMaxMessageSize(8))
+		require.NoError(t, err)
+
+		mockPackager := &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("valid-data")}}
+		require.NoError(t, inbound.Start(&mockProvider{packagerValue: mockPackager}))
+
+		defer func() {
+			require.NoError(t, inbound.Stop())
+		}()
+
+		// the connection is closed by the inbound transport
+		client, _ := websocketClient(t, port)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		require.NoError(t, client.Write(ctx, websocket.MessageText, []byte("0123456789abcdef")))
+
+		_, _, err = client.Read(ctx)
+		require.Equal(t, websocket.StatusMessageTooBig, websocket.CloseStatus(err))
+	})
+
+	t.Run("test inbound transport - compression", func(t *testing.T) {
+		port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+		inbound, err := NewInbound(port, "", "", "", WithInboundCompression())
+		require.NoError(t, err)
+
+		mockPackager := &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("valid-data")}}
+		require.NoError(t, inbound.Start(&mockProvider{packagerValue: mockPackager}))
+
+		defer func() {
+			require.NoError(t, inbound.Stop())
+		}()
+
+		require.NoError(t, transportutil.VerifyListener("localhost"+port, time.Second))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		client, resp, err := websocket.Dial(ctx, "ws://localhost"+port, dialOptions(true)) //nolint:bodyclose
+		require.NoError(t, err)
+		require.Contains(t, resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+
+		defer func() {
+			require.NoError(t, client.Close(websocket.StatusNormalClosure, "closing the connection"))
+		}()
+
+		require.NoError(t, client.Write(ctx, websocket.MessageText, []byte("valid-data")))
+	})
+
 	t.Run("test inbound transport - multiple invocation with same client", func(t *testing.T) {
 		port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
 
@@ -165,3 +226,57 @@ func TestInboundDataProcessing(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestInboundShutdown(t *testing.T) {
+	t.Run("shutdown closes the websocket connections", func(t *testing.T) {
+		port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+		inbound, err := NewInbound(port, "", "", "")
+		require.NoError(t, err)
+
+		received := make(chan struct{}, 1)
+		prov := &mockTransportProvider{
+			packagerValue: &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("{}")}},
+			frameworkID:   uuid.New().String(),
+			executeInbound: func(envelope *transport.Envelope) error {
+				received <- struct{}{}
+
+				return nil
+			},
+		}
+
+		require.NoError(t, inbound.Start(prov))
+
+		client, _ := websocketClient(t, port)
+
+		require.NoError(t, client.Write(context.Background(), websocket.MessageText, []byte("{}")))
+		<-received
+
+		// the client reads the close frame of the server
+		readErr := make(chan error, 1)
+
+		go func() {
+			_, _, errRead := client.Read(context.Background())
+			readErr <- errRead
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		require.NoError(t, inbound.Shutdown(ctx))
+		require.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(<-readErr))
+
+		require.Empty(t, inbound.pool.conns)
+
+		poolMu.Lock()
+		_, ok := pool[prov.frameworkID]
+		poolMu.Unlock()
+		require.False(t, ok)
+	})
+
+	t.Run("shutdown of a transport not started", func(t *testing.T) {
+		inbound, err := NewInbound(":"+strconv.Itoa(transportutil.GetRandomPort(5)), "", "", "")
+		require.NoError(t, err)
+		require.NoError(t, inbound.Shutdown(context.Background()))
+	})
+}
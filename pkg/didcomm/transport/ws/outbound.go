@@ -22,13 +22,31 @@ const webSocketScheme = "ws"
 
 // OutboundClient websocket outbound.
 type OutboundClient struct {
-	pool *connPool
-	prov transport.Provider
+	pool        *connPool
+	prov        transport.Provider
+	compression bool
+}
+
+// OutboundOpt is an outbound WebSocket transport option.
+type OutboundOpt func(cs *OutboundClient)
+
+// WithOutboundCompression enables the compression of the messages, the permessage-deflate extension (RFC 7692)
+// is offered to the servers and the messages are sent uncompressed to the ones not negotiating it.
+func WithOutboundCompression() OutboundOpt {
+	return func(cs *OutboundClient) {
+		cs.compression = true
+	}
 }
 
 // NewOutbound creates a client for Outbound WS transport.
-func NewOutbound() *OutboundClient {
-	return &OutboundClient{}
+func NewOutbound(opts ...OutboundOpt) *OutboundClient {
+	cs := &OutboundClient{}
+
+	for _, opt := range opts {
+		opt(cs)
+	}
+
+	return cs
 }
 
 // Start starts the outbound transport.
@@ -59,6 +77,22 @@ func (cs *OutboundClient) Send(data []byte, destination *service.Destination) (s
 	return "", nil
 }
 
+// Shutdown closes the outbound websocket connections kept open for the return route responses and waits for
+// their messages to be handled, until the context is done.
+func (cs *OutboundClient) Shutdown(ctx context.Context) error {
+	if cs.pool == nil {
+		return nil
+	}
+
+	if err := cs.pool.shutdown(ctx, true); err != nil {
+		return err
+	}
+
+	releaseConnPool(cs.pool.id)
+
+	return nil
+}
+
 // Accept checks for the url scheme.
 func (cs *OutboundClient) Accept(url string) bool {
 	return strings.HasPrefix(url, webSocketScheme)
@@ -94,7 +128,7 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 
 	var err error
 
-	conn, _, err = websocket.Dial(context.Background(), destination.ServiceEndpoint, nil)
+	conn, _, err = websocket.Dial(context.Background(), destination.ServiceEndpoint, dialOptions(cs.compression))
 	if err != nil {
 		return nil, cleanup, fmt.Errorf("websocket client : %w", err)
 	}
@@ -105,7 +139,8 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 			cs.pool.add(v, conn)
 		}
 
-		go cs.pool.listener(conn, true)
+		// track before listening so that a shutdown right after the send closes the connection
+		go cs.pool.listen(conn, cs.pool.track(conn, true))
 
 		return conn, cleanup, nil
 	}
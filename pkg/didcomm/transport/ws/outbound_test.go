@@ -59,6 +59,22 @@ func TestClient(t *testing.T) {
 		require.Contains(t, err.Error(), "websocket client")
 	})
 
+	t.Run("test outbound transport - compression", func(t *testing.T) {
+		outbound := NewOutbound(WithOutboundCompression())
+		require.NotNil(t, outbound)
+
+		extensions := make(chan string, 1)
+		addr := startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			extensions <- r.Header.Get("Sec-WebSocket-Extensions")
+
+			echo(t, w, r)
+		})
+
+		_, err := outbound.Send([]byte("ws-request"), prepareDestination("ws://"+addr))
+		require.NoError(t, err)
+		require.Contains(t, <-extensions, "permessage-deflate")
+	})
+
 	t.Run("test outbound transport pool success - no existing connections", func(t *testing.T) {
 		outbound := NewOutbound()
 		require.NotNil(t, outbound)
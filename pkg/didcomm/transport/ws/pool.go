@@ -8,6 +8,7 @@ package ws
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
 
@@ -24,21 +25,40 @@ const (
 )
 
 type connPool struct {
+	id      string
 	connMap map[string]*websocket.Conn
+	// conns are the connections with a listener.
+	conns map[*websocket.Conn]*trackedConn
 	sync.RWMutex
 	packager   transport.Packager
 	msgHandler transport.InboundMessageHandler
 }
 
+type trackedConn struct {
+	outbound bool
+	// closing is set when the connection is closed by the shutdown of the transport.
+	closing bool
+	// done is closed when the listener of the connection returns.
+	done chan struct{}
+}
+
 // nolint: gochecknoglobals
-var pool = make(map[string]*connPool)
+var (
+	pool   = make(map[string]*connPool)
+	poolMu sync.Mutex
+)
 
 func getConnPool(prov transport.Provider) *connPool {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+
 	id := prov.AriesFrameworkID()
 
 	if _, ok := pool[id]; !ok {
 		pool[id] = &connPool{
+			id:         id,
 			connMap:    make(map[string]*websocket.Conn),
+			conns:      make(map[*websocket.Conn]*trackedConn),
 			packager:   prov.Packager(),
 			msgHandler: prov.InboundMessageHandler(),
 		}
@@ -47,6 +67,14 @@ func getConnPool(prov transport.Provider) *connPool {
 	return pool[id]
 }
 
+// releaseConnPool removes the pool of the framework, the transports started afterwards get a new pool.
+func releaseConnPool(id string) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+
+	delete(pool, id)
+}
+
 func (d *connPool) add(verKey string, wsConn *websocket.Conn) {
 	d.Lock()
 	defer d.Unlock()
@@ -69,16 +97,24 @@ func (d *connPool) remove(verKey string) {
 }
 
 func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
-	verKeys := []string{}
+	d.listen(conn, d.track(conn, outbound))
+}
+
+// listen reads the messages of the tracked connection until it is closed.
+func (d *connPool) listen(conn *websocket.Conn, tracked *trackedConn) {
+	defer d.untrack(conn, tracked)
+
+	outbound := tracked.outbound
 
-	defer d.close(conn, verKeys)
+	stop := make(chan struct{})
+	defer close(stop)
 
-	go keepConnAlive(conn, outbound, pingFrequency)
+	go keepConnAlive(conn, outbound, pingFrequency, stop)
 
 	for {
 		_, message, err := conn.Read(context.Background())
 		if err != nil {
-			if websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+			if websocket.CloseStatus(err) != websocket.StatusNormalClosure && !d.isClosing(tracked) {
 				logger.Errorf("Error reading request message: %v", err)
 			}
 
@@ -114,13 +150,82 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 	}
 }
 
-func (d *connPool) close(conn *websocket.Conn, verKeys []string) {
+func (d *connPool) track(conn *websocket.Conn, outbound bool) *trackedConn {
+	d.Lock()
+	defer d.Unlock()
+
+	tracked := &trackedConn{outbound: outbound, done: make(chan struct{})}
+	d.conns[conn] = tracked
+
+	return tracked
+}
+
+// untrack closes the connection (unless it is closed by the shutdown) and removes it from the pool.
+func (d *connPool) untrack(conn *websocket.Conn, tracked *trackedConn) {
+	if !d.isClosing(tracked) {
+		d.close(conn)
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	delete(d.conns, conn)
+
+	for k, c := range d.connMap {
+		if c == conn {
+			delete(d.connMap, k)
+		}
+	}
+
+	close(tracked.done)
+}
+
+func (d *connPool) isClosing(tracked *trackedConn) bool {
+	d.RLock()
+	defer d.RUnlock()
+
+	return tracked.closing
+}
+
+// shutdown closes the inbound or outbound connections of the pool and waits for their listeners to return,
+// until the context is done.
+func (d *connPool) shutdown(ctx context.Context, outbound bool) error {
+	d.Lock()
+
+	closing := make(map[*websocket.Conn]*trackedConn)
+
+	for conn, tracked := range d.conns {
+		if tracked.outbound == outbound {
+			tracked.closing = true
+			closing[conn] = tracked
+		}
+	}
+
+	d.Unlock()
+
+	for conn := range closing {
+		go func(conn *websocket.Conn) {
+			err := conn.Close(websocket.StatusGoingAway, "shutting down")
+			if err != nil && websocket.CloseStatus(err) != websocket.StatusGoingAway {
+				logger.Debugf("close connection on shutdown: %v", err)
+			}
+		}(conn)
+	}
+
+	for _, tracked := range closing {
+		select {
+		case <-tracked.done:
+		case <-ctx.Done():
+			return fmt.Errorf("websocket connections shutdown: %w", ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+func (d *connPool) close(conn *websocket.Conn) {
 	if err := conn.Close(websocket.StatusNormalClosure,
 		"closing the connection"); websocket.CloseStatus(err) != websocket.StatusNormalClosure {
 		logger.Errorf("connection close error")
 	}
-
-	for _, v := range verKeys {
-		d.remove(v)
-	}
 }
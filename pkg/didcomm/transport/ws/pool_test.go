@@ -7,6 +7,7 @@ package ws
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
 	"time"
@@ -133,3 +134,50 @@ func TestConnectionStore(t *testing.T) {
 		}
 	})
 }
+
+func TestConnPoolShutdown(t *testing.T) {
+	addr := startWebSocketServer(t, echo)
+
+	t.Run("outbound connections are closed", func(t *testing.T) {
+		outbound := NewOutbound()
+		require.NoError(t, outbound.Start(&mockTransportProvider{
+			packagerValue: &mockPackager{verKey: "key"},
+			frameworkID:   uuid.New().String(),
+			executeInbound: func(envelope *transport.Envelope) error {
+				return nil
+			},
+		}))
+
+		_, err := outbound.Send([]byte("ping"), prepareDestinationWithTransport("ws://"+addr,
+			decorator.TransportReturnRouteAll, []string{"key"}))
+		require.NoError(t, err)
+		require.NotNil(t, outbound.pool.fetch("key"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		require.NoError(t, outbound.Shutdown(ctx))
+		require.Empty(t, outbound.pool.conns)
+		require.Nil(t, outbound.pool.fetch("key"))
+	})
+
+	t.Run("shutdown deadline", func(t *testing.T) {
+		conn, _, err := websocket.Dial(context.Background(), "ws://"+addr, nil) //nolint:bodyclose
+		require.NoError(t, err)
+
+		p := getConnPool(&mockTransportProvider{frameworkID: uuid.New().String()})
+
+		// the connection has no listener, it is never untracked
+		p.track(conn, true)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err = p.shutdown(ctx, true)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("shutdown of a transport not started", func(t *testing.T) {
+		require.NoError(t, NewOutbound().Shutdown(context.Background()))
+	})
+}
@@ -20,6 +20,15 @@ func Accept(_ http.ResponseWriter, _ *http.Request) (*websocket.Conn, error) {
 	return nil, errors.New("invalid operation with JS/WASM target")
 }
 
+func accept(w http.ResponseWriter, r *http.Request, _ bool) (*websocket.Conn, error) {
+	return Accept(w, r)
+}
+
+// dialOptions returns no options, the compression of the connections is negotiated by the browser.
+func dialOptions(_ bool) *websocket.DialOptions {
+	return nil
+}
+
 func acceptRecipient(pool *connPool, keys []string) bool {
 	for _, v := range keys {
 		// check if the connection exists for the key
@@ -32,6 +41,6 @@ func acceptRecipient(pool *connPool, keys []string) bool {
 	return false
 }
 
-func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) {
+func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration, stop <-chan struct{}) {
 	// TODO make sure connection is alive (conn.Ping() doesn't work with JS/WASM build)
 }
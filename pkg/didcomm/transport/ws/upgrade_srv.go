@@ -19,13 +19,34 @@ import (
 // Accept accepts a WebSocket handshake from a client and upgrades the
 // the connection to a WebSocket.
 func Accept(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
+	return accept(w, r, false)
+}
+
+// accept upgrades the connection, negotiating the permessage-deflate extension (RFC 7692) with the client
+// if the compression is enabled.
+func accept(w http.ResponseWriter, r *http.Request, compression bool) (*websocket.Conn, error) {
 	// TODO Allow user to enable InsecureSkipVerify https://github.com/hyperledger/aries-framework-go/issues/928
 	return websocket.Accept(w, r, &websocket.AcceptOptions{
 		InsecureSkipVerify: true,
-		CompressionMode:    websocket.CompressionDisabled,
+		CompressionMode:    compressionMode(compression),
 	})
 }
 
+// dialOptions returns the options of the outbound connections, offering the permessage-deflate extension
+// to the server if the compression is enabled.
+func dialOptions(compression bool) *websocket.DialOptions {
+	return &websocket.DialOptions{CompressionMode: compressionMode(compression)}
+}
+
+func compressionMode(compression bool) websocket.CompressionMode {
+	if compression {
+		// no context takeover keeps the memory of the pooled connections low
+		return websocket.CompressionNoContextTakeover
+	}
+
+	return websocket.CompressionDisabled
+}
+
 func acceptRecipient(pool *connPool, keys []string) bool {
 	for _, v := range keys {
 		// check if the connection exists for the key
@@ -49,23 +70,24 @@ func acceptRecipient(pool *connPool, keys []string) bool {
 
 // keepConnAlive sends the pings the server based on time frequency. The web server, load balancer, network routers
 // between the client and server closes the TCP keepalives connection. This function calls websocket ping request
-// directly to the server and keeps the connection active.
-func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) {
-	if outbound {
-		ticker := time.NewTicker(frequency)
-		done := make(chan struct{})
-
-		for {
-			select {
-			case <-done:
-				return
-			case <-ticker.C:
-				if err := conn.Ping(context.Background()); err != nil {
-					logger.Errorf("websocket ping error : %v", err)
+// directly to the server and keeps the connection active until the stop channel is closed.
+func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration, stop <-chan struct{}) {
+	if !outbound {
+		return
+	}
 
-					ticker.Stop()
-					done <- struct{}{}
-				}
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := conn.Ping(context.Background()); err != nil {
+				logger.Errorf("websocket ping error : %v", err)
+
+				return
 			}
 		}
 	}
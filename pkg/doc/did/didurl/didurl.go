@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package didurl parses DID URLs according to the DID URL syntax:
+// https://www.w3.org/TR/did-core/#did-url-syntax
+//
+//	did-url = did path-abempty [ "?" query ] [ "#" fragment ]
+//
+// Relative DID URLs (e.g. "#key-1", "/path?query") are supported as well.
+package didurl
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const didScheme = "did"
+
+var (
+	// ErrInvalidDIDURL is returned when the string is not a valid DID URL.
+	ErrInvalidDIDURL = errors.New("invalid DID URL")
+
+	didRegexp = regexp.MustCompile(`^did:[a-z0-9]+:([a-zA-Z0-9._%-]*:)*[a-zA-Z0-9._%-]+$`)
+)
+
+// DIDURL is a parsed DID URL.
+type DIDURL struct {
+	// DID is the DID part of the URL (empty for relative DID URL).
+	DID string
+	// Method is the DID method (empty for relative DID URL).
+	Method string
+	// MethodSpecificID is the method specific identifier (empty for relative DID URL).
+	MethodSpecificID string
+	// Path is the path of the URL, including the leading "/".
+	Path string
+	// RawQuery is the query of the URL without the leading "?".
+	RawQuery string
+	// Fragment is the fragment of the URL without the leading "#".
+	Fragment string
+}
+
+// Parse parses absolute or relative DID URL.
+func Parse(s string) (*DIDURL, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidDIDURL)
+	}
+
+	u := &DIDURL{}
+
+	rest := s
+
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		u.Fragment = rest[i+1:]
+		rest = rest[:i]
+	}
+
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		u.RawQuery = rest[i+1:]
+		rest = rest[:i]
+	}
+
+	if i := strings.IndexByte(rest, '/'); i >= 0 {
+		u.Path = rest[i:]
+		rest = rest[:i]
+	}
+
+	if rest == "" {
+		if u.Path == "" && u.RawQuery == "" && u.Fragment == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidDIDURL, s)
+		}
+
+		return u, nil
+	}
+
+	if !didRegexp.MatchString(rest) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDIDURL, s)
+	}
+
+	parts := strings.SplitN(rest, ":", 3) //nolint:gomnd
+
+	u.DID = rest
+	u.Method = parts[1]
+	u.MethodSpecificID = parts[2]
+
+	return u, nil
+}
+
+// IsRelative checks whether the DID URL is relative (does not contain DID).
+func (u *DIDURL) IsRelative() bool {
+	return u.DID == ""
+}
+
+// Query returns the parsed query of the URL.
+func (u *DIDURL) Query() (url.Values, error) {
+	return url.ParseQuery(u.RawQuery)
+}
+
+// ResolveReference resolves relative DID URL against the given DID. Absolute DID URL is returned as is.
+func (u *DIDURL) ResolveReference(did string) (*DIDURL, error) {
+	if !u.IsRelative() {
+		return u, nil
+	}
+
+	base, err := Parse(did)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *u
+	resolved.DID = base.DID
+	resolved.Method = base.Method
+	resolved.MethodSpecificID = base.MethodSpecificID
+
+	return &resolved, nil
+}
+
+// String returns string representation of the DID URL.
+func (u *DIDURL) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(u.DID)
+	sb.WriteString(u.Path)
+
+	if u.RawQuery != "" {
+		sb.WriteString("?")
+		sb.WriteString(u.RawQuery)
+	}
+
+	if u.Fragment != "" {
+		sb.WriteString("#")
+		sb.WriteString(u.Fragment)
+	}
+
+	return sb.String()
+}
+
+// IsDIDURL checks whether the string is an absolute DID URL (starts with "did:").
+func IsDIDURL(s string) bool {
+	return strings.HasPrefix(s, didScheme+":")
+}
@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didurl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("absolute DID URL", func(t *testing.T) {
+		u, err := Parse("did:example:123:456/path/to?service=agent&relativeRef=%2Fa#key-1")
+		require.NoError(t, err)
+		require.False(t, u.IsRelative())
+		require.Equal(t, "did:example:123:456", u.DID)
+		require.Equal(t, "example", u.Method)
+		require.Equal(t, "123:456", u.MethodSpecificID)
+		require.Equal(t, "/path/to", u.Path)
+		require.Equal(t, "service=agent&relativeRef=%2Fa", u.RawQuery)
+		require.Equal(t, "key-1", u.Fragment)
+		require.Equal(t, "did:example:123:456/path/to?service=agent&relativeRef=%2Fa#key-1", u.String())
+
+		query, err := u.Query()
+		require.NoError(t, err)
+		require.Equal(t, "agent", query.Get("service"))
+		require.Equal(t, "/a", query.Get("relativeRef"))
+	})
+
+	t.Run("DID only", func(t *testing.T) {
+		u, err := Parse("did:web:example.com%3A8080")
+		require.NoError(t, err)
+		require.Equal(t, "did:web:example.com%3A8080", u.DID)
+		require.Empty(t, u.Path)
+		require.Empty(t, u.Fragment)
+		require.Equal(t, "did:web:example.com%3A8080", u.String())
+	})
+
+	t.Run("relative DID URL", func(t *testing.T) {
+		u, err := Parse("#key-1")
+		require.NoError(t, err)
+		require.True(t, u.IsRelative())
+		require.Equal(t, "key-1", u.Fragment)
+
+		resolved, err := u.ResolveReference("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123#key-1", resolved.String())
+		require.Equal(t, "key-1", u.Fragment)
+		require.True(t, u.IsRelative())
+
+		same, err := resolved.ResolveReference("did:example:456")
+		require.NoError(t, err)
+		require.Equal(t, resolved, same)
+
+		_, err = u.ResolveReference("invalid")
+		require.ErrorIs(t, err, ErrInvalidDIDURL)
+	})
+
+	t.Run("invalid DID URL", func(t *testing.T) {
+		for _, s := range []string{"", "key-1", "did:example", "did:Example:123", "did:example:123:", "http://example.com#key"} {
+			_, err := Parse(s)
+			require.ErrorIs(t, err, ErrInvalidDIDURL, s)
+		}
+	})
+}
+
+func TestIsDIDURL(t *testing.T) {
+	require.True(t, IsDIDURL("did:example:123#key-1"))
+	require.False(t, IsDIDURL("#key-1"))
+}
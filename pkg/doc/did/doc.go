@@ -20,6 +20,7 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did/didurl"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	jld "github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
@@ -248,6 +249,8 @@ func (pk *VerificationMethod) JSONWebKey() *jose.JWK {
 }
 
 // Service DID doc service.
+// ServiceEndpoint is the URI of the service endpoint, Endpoints are its DIDComm v2 endpoint objects if the service
+// endpoint is given as an object or an array.
 type Service struct {
 	ID                       string                 `json:"id"`
 	Type                     string                 `json:"type"`
@@ -255,6 +258,7 @@ type Service struct {
 	RecipientKeys            []string               `json:"recipientKeys,omitempty"`
 	RoutingKeys              []string               `json:"routingKeys,omitempty"`
 	ServiceEndpoint          string                 `json:"serviceEndpoint"`
+	Endpoints                []Endpoint             `json:"-"`
 	Accept                   []string               `json:"accept,omitempty"`
 	Properties               map[string]interface{} `json:"properties,omitempty"`
 	recipientKeysRelativeURL map[string]bool
@@ -551,9 +555,11 @@ func populateServices(didID, baseURI string, rawServices []map[string]interface{
 			routingKeys, routingKeysRelativeURL = populateKeys(routingKeys, didID, baseURI)
 		}
 
+		serviceEndpoint, endpoints := populateServiceEndpoint(rawService[jsonldServicePoint])
+
 		service := Service{
 			ID: id, Type: stringEntry(rawService[jsonldType]), relativeURL: isRelative,
-			ServiceEndpoint: stringEntry(rawService[jsonldServicePoint]), RecipientKeys: recipientKeys,
+			ServiceEndpoint: serviceEndpoint, Endpoints: endpoints, RecipientKeys: recipientKeys,
 			RoutingKeys: routingKeys, Priority: uintEntry(rawService[jsonldPriority]),
 			recipientKeysRelativeURL: recipientKeysRelativeURL, routingKeysRelativeURL: routingKeysRelativeURL,
 		}
@@ -1110,6 +1116,97 @@ func (doc *Doc) VerificationMethods(customVerificationRelationships ...Verificat
 	return verificationMethods
 }
 
+// ErrVerificationMethodNotAuthorized is returned when the verification method exists
+// but is not authorized for the requested verification relationship.
+var ErrVerificationMethodNotAuthorized = errors.New("verification method is not authorized for verification relationship")
+
+// DereferenceVerificationMethod returns the verification method of DID Doc identified by absolute DID URL
+// (e.g. "did:example:123#key-1"), relative DID URL ("#key-1") or bare fragment ("key-1").
+// Verification methods embedded into verification relationships are looked up as well.
+func (doc *Doc) DereferenceVerificationMethod(idOrFragment string) (*VerificationMethod, error) {
+	id, err := doc.absoluteDIDURL(idOrFragment)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, verifications := range doc.VerificationMethods() {
+		for i := range verifications {
+			vm := &verifications[i].VerificationMethod
+
+			if doc.matchesDIDURL(vm.ID, id) {
+				return vm, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, idOrFragment)
+}
+
+// ResolveRelationship returns the verification method identified by id (as in DereferenceVerificationMethod)
+// if it is authorized for the given verification relationship (purpose). Any verification method
+// of DID Doc is authorized for VerificationRelationshipGeneral purpose.
+func (doc *Doc) ResolveRelationship(purpose VerificationRelationship, id string) (*VerificationMethod, error) {
+	vm, err := doc.DereferenceVerificationMethod(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if purpose == VerificationRelationshipGeneral {
+		return vm, nil
+	}
+
+	vmID, err := doc.absoluteDIDURL(vm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, verification := range doc.VerificationMethods(purpose)[purpose] {
+		if doc.matchesDIDURL(verification.VerificationMethod.ID, vmID) {
+			return vm, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrVerificationMethodNotAuthorized, id)
+}
+
+// absoluteDIDURL resolves DID URL, relative DID URL or bare fragment against the ID of DID Doc.
+func (doc *Doc) absoluteDIDURL(id string) (string, error) {
+	if !didurl.IsDIDURL(id) && !strings.ContainsAny(id, "#/?") {
+		id = "#" + id
+	}
+
+	u, err := didurl.Parse(id)
+	if err != nil {
+		return "", err
+	}
+
+	if !u.IsRelative() {
+		return u.String(), nil
+	}
+
+	if doc.ID == "" {
+		return u.String(), nil
+	}
+
+	resolved, err := u.ResolveReference(doc.ID)
+	if err != nil {
+		return "", err
+	}
+
+	return resolved.String(), nil
+}
+
+// matchesDIDURL checks whether the ID of verification method (possibly relative) matches the absolute DID URL.
+func (doc *Doc) matchesDIDURL(vmID, id string) bool {
+	if vmID == id {
+		return true
+	}
+
+	absolute, err := doc.absoluteDIDURL(vmID)
+
+	return err == nil && absolute == id
+}
+
 // ErrProofNotFound is returned when proof is not found.
 var ErrProofNotFound = errors.New("proof not found")
 
@@ -1173,7 +1270,7 @@ func populateRawServices(services []Service, didID, baseURI string) []map[string
 		}
 
 		rawService[jsonldType] = services[i].Type
-		rawService[jsonldServicePoint] = services[i].ServiceEndpoint
+		rawService[jsonldServicePoint] = services[i].rawServiceEndpoint()
 		rawService[jsonldRecipientKeys] = recipientKeys
 		rawService[jsonldRoutingKeys] = routingKeys
 		rawService[jsonldPriority] = services[i].Priority
@@ -1310,6 +1407,13 @@ func WithAssertion(assertion []Verification) DocOption {
 	}
 }
 
+// WithKeyAgreement sets the verification methods for key agreement: https://w3c.github.io/did-core/#key-agreement.
+func WithKeyAgreement(keyAgreement []Verification) DocOption {
+	return func(opts *Doc) {
+		opts.KeyAgreement = keyAgreement
+	}
+}
+
 // WithService DID doc services.
 func WithService(svc []Service) DocOption {
 	return func(opts *Doc) {
@@ -1980,3 +1980,71 @@ const docV011WithVerificationRelationships = `{
 		"publicKeyBase58": "B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"
 	}]
 }`
+
+func TestDoc_DereferenceVerificationMethod(t *testing.T) {
+	const didID = "did:example:123456789abcdefghi"
+
+	didDocStr := `{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "` + didID + `",
+  "verificationMethod": [
+    {
+      "id": "#keys-1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "` + didID + `",
+      "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    }
+  ],
+  "authentication": [
+    "#keys-1",
+    {
+      "id": "` + didID + `#keys-2",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "` + didID + `",
+      "publicKeyBase58": "atEBuHypSkQx7486xT5FUkoBLqvNcWyNK2Xz9EPjdMy"
+    }
+  ]
+}`
+
+	doc, err := ParseDocument([]byte(didDocStr))
+	require.NoError(t, err)
+
+	for _, id := range []string{didID + "#keys-1", "#keys-1", "keys-1"} {
+		vm, err := doc.DereferenceVerificationMethod(id)
+		require.NoError(t, err, id)
+		require.Equal(t, "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV", base58.Encode(vm.Value))
+	}
+
+	vm, err := doc.DereferenceVerificationMethod("keys-2")
+	require.NoError(t, err)
+	require.Equal(t, didID+"#keys-2", vm.ID)
+
+	_, err = doc.DereferenceVerificationMethod("#keys-3")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = doc.DereferenceVerificationMethod("did:other:123#keys-1")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = doc.DereferenceVerificationMethod("did:#keys-1")
+	require.Error(t, err)
+
+	t.Run("resolve relationship", func(t *testing.T) {
+		vm, err := doc.ResolveRelationship(Authentication, "keys-1")
+		require.NoError(t, err)
+		require.Equal(t, "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV", base58.Encode(vm.Value))
+
+		vm, err = doc.ResolveRelationship(Authentication, didID+"#keys-2")
+		require.NoError(t, err)
+		require.Equal(t, didID+"#keys-2", vm.ID)
+
+		vm, err = doc.ResolveRelationship(VerificationRelationshipGeneral, "#keys-1")
+		require.NoError(t, err)
+		require.NotNil(t, vm)
+
+		_, err = doc.ResolveRelationship(AssertionMethod, "#keys-1")
+		require.ErrorIs(t, err, ErrVerificationMethodNotAuthorized)
+
+		_, err = doc.ResolveRelationship(AssertionMethod, "#keys-3")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
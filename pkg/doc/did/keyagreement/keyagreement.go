@@ -0,0 +1,242 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package keyagreement builds the key agreement keys of DID documents.
+//
+// Legacy DID documents (e.g. DIDComm V1 peer DIDs) publish Ed25519 verification keys only and use them for both
+// signing and encryption. The functions of this package convert such keys to their X25519 counterparts to be used
+// as keyAgreement entries of DID documents and as recipient keys of anoncrypt/authcrypt envelopes.
+//
+// DIDComm V2 capable DID documents publish dedicated NIST P or X25519 key agreement keys, CreateKeyAgreement
+// creates them with the KMS and adds them to the keyAgreement entries of the document.
+package keyagreement
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	hybrid "github.com/google/tink/go/hybrid/subtle"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	jsonWebKey2020             = "JsonWebKey2020"
+
+	// X25519KeyAgreementKey2019 is the verification method type of derived raw X25519 keys.
+	X25519KeyAgreementKey2019 = "X25519KeyAgreementKey2019"
+
+	ed25519Crv = "Ed25519"
+	x25519Crv  = "X25519"
+	okpKty     = "OKP"
+	ecKty      = "EC"
+)
+
+// PublicKeyFromEd25519 converts the Ed25519 public key to X25519 public key.
+func PublicKeyFromEd25519(pub ed25519.PublicKey) ([]byte, error) {
+	return cryptoutil.PublicEd25519toCurve25519(pub)
+}
+
+// PrivateKeyFromEd25519 converts the Ed25519 private key to X25519 private key.
+func PrivateKeyFromEd25519(priv ed25519.PrivateKey) ([]byte, error) {
+	if len(priv) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("%d-byte private key size is invalid", len(priv))
+	}
+
+	return cryptoutil.SecretEd25519toCurve25519(priv)
+}
+
+// RecipientKeyFromEd25519 converts the Ed25519 public key to X25519 recipient key of JWE envelopes.
+// KID of the returned key is set to the JWK thumbprint of X25519 key, the same KID the KMS assigns to
+// the key when it is converted from the Ed25519 private key (see localkms.LocalKMS.ConvertEd25519ToX25519).
+func RecipientKeyFromEd25519(pub ed25519.PublicKey) (*cryptoapi.PublicKey, error) {
+	x25519Pub, err := PublicKeyFromEd25519(pub)
+	if err != nil {
+		return nil, fmt.Errorf("convert Ed25519 public key: %w", err)
+	}
+
+	recKey := &cryptoapi.PublicKey{
+		X:     x25519Pub,
+		Curve: x25519Crv,
+		Type:  okpKty,
+	}
+
+	mKey, err := json.Marshal(recKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshal X25519 key: %w", err)
+	}
+
+	kid, err := jwkkid.CreateKID(mKey, kms.X25519ECDHKWType)
+	if err != nil {
+		return nil, fmt.Errorf("create KID of X25519 key: %w", err)
+	}
+
+	recKey.KID = kid
+
+	return recKey, nil
+}
+
+// DeriveVerificationMethod derives X25519 key agreement verification method from the Ed25519 verification method.
+// Raw Ed25519 keys are converted to X25519KeyAgreementKey2019 and Ed25519 JWKs are converted to X25519 JWKs
+// (JsonWebKey2020). ID of the derived method is the DID of the source method with the X25519 key fingerprint
+// as fragment, similar to key agreement methods of did:key documents.
+func DeriveVerificationMethod(vm *did.VerificationMethod) (*did.VerificationMethod, error) {
+	if !IsEd25519(vm) {
+		return nil, fmt.Errorf("not supported verification method type: %s", vm.Type)
+	}
+
+	x25519Pub, err := PublicKeyFromEd25519(vm.Value)
+	if err != nil {
+		return nil, fmt.Errorf("convert Ed25519 public key of %s: %w", vm.ID, err)
+	}
+
+	id := derivedID(vm.ID, x25519Pub)
+
+	if vm.JSONWebKey() == nil {
+		return did.NewVerificationMethodFromBytes(id, X25519KeyAgreementKey2019, vm.Controller, x25519Pub), nil
+	}
+
+	jwk, err := jose.JWKFromX25519Key(x25519Pub)
+	if err != nil {
+		return nil, fmt.Errorf("create X25519 JWK: %w", err)
+	}
+
+	return did.NewVerificationMethodFromJWK(id, jsonWebKey2020, vm.Controller, jwk)
+}
+
+// DeriveKeyAgreement adds key agreement methods derived from Ed25519 authentication methods to the DID document
+// lacking explicit keyAgreement entries. Documents having keyAgreement entries are not modified.
+// Returns the number of added key agreement methods.
+func DeriveKeyAgreement(doc *did.Doc) (int, error) {
+	if len(doc.KeyAgreement) > 0 {
+		return 0, nil
+	}
+
+	var sources []did.VerificationMethod
+
+	for i := range doc.Authentication {
+		sources = append(sources, doc.Authentication[i].VerificationMethod)
+	}
+
+	if len(sources) == 0 {
+		sources = doc.VerificationMethod
+	}
+
+	for i := range sources {
+		if !IsEd25519(&sources[i]) {
+			continue
+		}
+
+		vm, err := DeriveVerificationMethod(&sources[i])
+		if err != nil {
+			return 0, err
+		}
+
+		doc.KeyAgreement = append(doc.KeyAgreement, *did.NewEmbeddedVerification(vm, did.KeyAgreement))
+	}
+
+	return len(doc.KeyAgreement), nil
+}
+
+// CreateKeyAgreement creates a key agreement key of type kt (NISTP256ECDHKWType, NISTP384ECDHKWType,
+// NISTP521ECDHKWType or X25519ECDHKWType) with the KMS and adds its verification method to the keyAgreement entries
+// of the DID document. The KMS key ID is the fragment of the method ID, so that the recipients of envelopes packed
+// for the method can find the key.
+// NIST P keys are published as JsonWebKey2020, X25519 keys as X25519KeyAgreementKey2019 unless asJWK is set.
+func CreateKeyAgreement(doc *did.Doc, km kms.KeyAgreementKeyCreator, kt kms.KeyType,
+	asJWK bool) (*did.VerificationMethod, error) {
+	_, pubKey, err := km.CreateKeyAgreementKey(kt)
+	if err != nil {
+		return nil, fmt.Errorf("create key agreement key: %w", err)
+	}
+
+	vm, err := VerificationMethodFromKey(doc.ID, pubKey, asJWK)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.KeyAgreement = append(doc.KeyAgreement, *did.NewEmbeddedVerification(vm, did.KeyAgreement))
+
+	return vm, nil
+}
+
+// VerificationMethodFromKey builds the key agreement verification method of the public key exported by the KMS,
+// its ID is the DID with the key ID as fragment.
+// NIST P keys are built as JsonWebKey2020, X25519 keys as X25519KeyAgreementKey2019 unless asJWK is set.
+func VerificationMethodFromKey(didID string, pubKey *cryptoapi.PublicKey,
+	asJWK bool) (*did.VerificationMethod, error) {
+	if pubKey.KID == "" {
+		return nil, fmt.Errorf("key agreement key has no KID")
+	}
+
+	id := didID + "#" + pubKey.KID
+
+	switch pubKey.Type {
+	case okpKty:
+		if !asJWK {
+			return did.NewVerificationMethodFromBytes(id, X25519KeyAgreementKey2019, didID, pubKey.X), nil
+		}
+
+		jwk, err := jose.JWKFromX25519Key(pubKey.X)
+		if err != nil {
+			return nil, fmt.Errorf("create X25519 JWK: %w", err)
+		}
+
+		jwk.KeyID = pubKey.KID
+
+		return did.NewVerificationMethodFromJWK(id, jsonWebKey2020, didID, jwk)
+	case ecKty:
+		curve, err := hybrid.GetCurve(pubKey.Curve)
+		if err != nil {
+			return nil, fmt.Errorf("get curve of key agreement key: %w", err)
+		}
+
+		jwk, err := jose.JWKFromKey(&ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(pubKey.X),
+			Y:     new(big.Int).SetBytes(pubKey.Y),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create EC JWK: %w", err)
+		}
+
+		jwk.KeyID = pubKey.KID
+
+		return did.NewVerificationMethodFromJWK(id, jsonWebKey2020, didID, jwk)
+	default:
+		return nil, fmt.Errorf("not supported key agreement key type: %s", pubKey.Type)
+	}
+}
+
+// IsEd25519 checks whether the verification method is Ed25519 public key.
+func IsEd25519(vm *did.VerificationMethod) bool {
+	if jwk := vm.JSONWebKey(); jwk != nil {
+		return jwk.Crv == ed25519Crv
+	}
+
+	return vm.Type == ed25519VerificationKey2018 || vm.Type == ed25519VerificationKey2020
+}
+
+func derivedID(sourceID string, x25519Pub []byte) string {
+	base := sourceID
+	if i := strings.IndexByte(base, '#'); i >= 0 {
+		base = base[:i]
+	}
+
+	return base + "#" + fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, x25519Pub)
+}
@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyagreement
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const docID = "did:example:123456789abcdefghi"
+
+func TestKeyConversion(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	x25519Pub, err := PublicKeyFromEd25519(pub)
+	require.NoError(t, err)
+
+	x25519Priv, err := PrivateKeyFromEd25519(priv)
+	require.NoError(t, err)
+
+	// the public key derived from the converted private key matches the converted public key
+	expectedPub, err := curve25519.X25519(x25519Priv, curve25519.Basepoint)
+	require.NoError(t, err)
+	require.Equal(t, expectedPub, x25519Pub)
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, err = PublicKeyFromEd25519(pub[:10])
+		require.Error(t, err)
+
+		_, err = PrivateKeyFromEd25519(priv[:10])
+		require.Error(t, err)
+	})
+
+	t.Run("recipient key", func(t *testing.T) {
+		recKey, err := RecipientKeyFromEd25519(pub)
+		require.NoError(t, err)
+		require.Equal(t, x25519Pub, recKey.X)
+		require.Equal(t, "X25519", recKey.Curve)
+		require.Equal(t, "OKP", recKey.Type)
+		require.NotEmpty(t, recKey.KID)
+
+		_, err = RecipientKeyFromEd25519(pub[:10])
+		require.Error(t, err)
+	})
+}
+
+func TestDeriveVerificationMethod(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	x25519Pub, err := PublicKeyFromEd25519(pub)
+	require.NoError(t, err)
+
+	expectedID := docID + "#" + fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, x25519Pub)
+
+	t.Run("raw key", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes(docID+"#key-1", ed25519VerificationKey2018, docID, pub)
+
+		ka, err := DeriveVerificationMethod(vm)
+		require.NoError(t, err)
+		require.Equal(t, expectedID, ka.ID)
+		require.Equal(t, X25519KeyAgreementKey2019, ka.Type)
+		require.Equal(t, docID, ka.Controller)
+		require.Equal(t, x25519Pub, ka.Value)
+	})
+
+	t.Run("JWK", func(t *testing.T) {
+		jwk, err := jose.JWKFromKey(pub)
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK(docID+"#key-1", jsonWebKey2020, docID, jwk)
+		require.NoError(t, err)
+
+		ka, err := DeriveVerificationMethod(vm)
+		require.NoError(t, err)
+		require.Equal(t, expectedID, ka.ID)
+		require.Equal(t, jsonWebKey2020, ka.Type)
+		require.Equal(t, "X25519", ka.JSONWebKey().Crv)
+		require.Equal(t, x25519Pub, ka.Value)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes(docID+"#key-1", "Bls12381G2Key2020", docID, pub)
+
+		_, err := DeriveVerificationMethod(vm)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not supported verification method type")
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes(docID+"#key-1", ed25519VerificationKey2018, docID, pub[:10])
+
+		_, err := DeriveVerificationMethod(vm)
+		require.Error(t, err)
+	})
+}
+
+func TestDeriveKeyAgreement(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("derived from authentication", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes(docID+"#key-1", ed25519VerificationKey2018, docID, pub)
+		other := did.NewVerificationMethodFromBytes(docID+"#key-2", "Bls12381G2Key2020", docID, []byte("key"))
+
+		doc := did.BuildDoc(
+			did.WithVerificationMethod([]did.VerificationMethod{*vm, *other}),
+			did.WithAuthentication([]did.Verification{
+				*did.NewReferencedVerification(vm, did.Authentication),
+				*did.NewReferencedVerification(other, did.Authentication),
+			}),
+		)
+		doc.ID = docID
+
+		n, err := DeriveKeyAgreement(doc)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, did.KeyAgreement, doc.KeyAgreement[0].Relationship)
+		require.Equal(t, X25519KeyAgreementKey2019, doc.KeyAgreement[0].VerificationMethod.Type)
+		require.True(t, strings.HasPrefix(doc.KeyAgreement[0].VerificationMethod.ID, docID+"#z"))
+
+		// document having keyAgreement is not modified
+		n, err = DeriveKeyAgreement(doc)
+		require.NoError(t, err)
+		require.Zero(t, n)
+		require.Len(t, doc.KeyAgreement, 1)
+	})
+
+	t.Run("derived from verification methods", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2020, docID, pub)
+
+		doc := did.BuildDoc(did.WithVerificationMethod([]did.VerificationMethod{*vm}))
+
+		n, err := DeriveKeyAgreement(doc)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.True(t, strings.HasPrefix(doc.KeyAgreement[0].VerificationMethod.ID, "#z"))
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, docID, pub[:10])
+
+		_, err := DeriveKeyAgreement(did.BuildDoc(did.WithVerificationMethod([]did.VerificationMethod{*vm})))
+		require.Error(t, err)
+	})
+}
+
+func TestCreateKeyAgreement(t *testing.T) {
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	x25519Pub := make([]byte, curve25519.PointSize)
+	_, err = rand.Read(x25519Pub)
+	require.NoError(t, err)
+
+	keys := map[kms.KeyType]*cryptoapi.PublicKey{
+		kms.NISTP256ECDHKWType: {
+			KID:   "p256-kid",
+			X:     ecPriv.X.Bytes(),
+			Y:     ecPriv.Y.Bytes(),
+			Curve: "NIST_P256",
+			Type:  ecKty,
+		},
+		kms.X25519ECDHKWType: {
+			KID:   "x25519-kid",
+			X:     x25519Pub,
+			Curve: "X25519",
+			Type:  okpKty,
+		},
+	}
+
+	km := &keyCreator{keys: keys}
+
+	t.Run("NIST P-256 key", func(t *testing.T) {
+		doc := &did.Doc{ID: docID}
+
+		vm, err := CreateKeyAgreement(doc, km, kms.NISTP256ECDHKWType, false)
+		require.NoError(t, err)
+		require.Equal(t, docID+"#p256-kid", vm.ID)
+		require.Equal(t, jsonWebKey2020, vm.Type)
+		require.Equal(t, docID, vm.Controller)
+		require.NotNil(t, vm.JSONWebKey())
+		require.Equal(t, "P-256", vm.JSONWebKey().Crv)
+		require.Equal(t, "p256-kid", vm.JSONWebKey().KeyID)
+
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, did.KeyAgreement, doc.KeyAgreement[0].Relationship)
+		require.Equal(t, vm.ID, doc.KeyAgreement[0].VerificationMethod.ID)
+	})
+
+	t.Run("X25519 key", func(t *testing.T) {
+		doc := &did.Doc{ID: docID}
+
+		vm, err := CreateKeyAgreement(doc, km, kms.X25519ECDHKWType, false)
+		require.NoError(t, err)
+		require.Equal(t, docID+"#x25519-kid", vm.ID)
+		require.Equal(t, X25519KeyAgreementKey2019, vm.Type)
+		require.Equal(t, x25519Pub, vm.Value)
+		require.Nil(t, vm.JSONWebKey())
+
+		vm, err = CreateKeyAgreement(doc, km, kms.X25519ECDHKWType, true)
+		require.NoError(t, err)
+		require.Equal(t, jsonWebKey2020, vm.Type)
+		require.Equal(t, x25519Crv, vm.JSONWebKey().Crv)
+		require.Len(t, doc.KeyAgreement, 2)
+	})
+
+	t.Run("KMS error", func(t *testing.T) {
+		doc := &did.Doc{ID: docID}
+
+		_, err := CreateKeyAgreement(doc, km, kms.ED25519Type, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create key agreement key")
+		require.Empty(t, doc.KeyAgreement)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, err := VerificationMethodFromKey(docID, &cryptoapi.PublicKey{Type: okpKty}, false)
+		require.EqualError(t, err, "key agreement key has no KID")
+
+		_, err = VerificationMethodFromKey(docID, &cryptoapi.PublicKey{KID: "kid", Type: "RSA"}, false)
+		require.EqualError(t, err, "not supported key agreement key type: RSA")
+
+		_, err = VerificationMethodFromKey(docID, &cryptoapi.PublicKey{KID: "kid", Type: ecKty, Curve: "P-0"}, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get curve of key agreement key")
+	})
+}
+
+type keyCreator struct {
+	keys map[kms.KeyType]*cryptoapi.PublicKey
+}
+
+func (k *keyCreator) CreateKeyAgreementKey(kt kms.KeyType) (string, *cryptoapi.PublicKey, error) {
+	pubKey, ok := k.keys[kt]
+	if !ok {
+		return "", nil, fmt.Errorf("key type is not supported: '%s'", kt)
+	}
+
+	return pubKey.KID, pubKey, nil
+}
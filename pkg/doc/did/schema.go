@@ -89,6 +89,18 @@ const (
     }
   },
   "definitions": {
+    "endpoint": {
+      "required": [
+        "uri"
+      ],
+      "type": "object",
+      "properties": {
+        "uri": {
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
     "proof": {
       "type": "object",
       "required": [ "type", "creator", "created", "proofValue"],
@@ -150,8 +162,29 @@ const (
           "type": "string"
         },
         "serviceEndpoint": {
-          "type": "string",
-          "format": "uri"
+          "oneOf": [
+            {
+              "type": "string",
+              "format": "uri"
+            },
+            {
+              "$ref": "#/definitions/endpoint"
+            },
+            {
+              "type": "array",
+              "items": {
+                "oneOf": [
+                  {
+                    "type": "string",
+                    "format": "uri"
+                  },
+                  {
+                    "$ref": "#/definitions/endpoint"
+                  }
+                ]
+              }
+            }
+          ]
         }
       }
     }
@@ -222,6 +255,18 @@ const (
     }
   },
   "definitions": {
+    "endpoint": {
+      "required": [
+        "uri"
+      ],
+      "type": "object",
+      "properties": {
+        "uri": {
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
 	"proof": {
       "type": "object",
       "required": [ "type", "creator", "created", "signatureValue"],
@@ -299,8 +344,29 @@ const (
           "type": "string"
         },
         "serviceEndpoint": {
-          "type": "string",
-          "format": "uri"
+          "oneOf": [
+            {
+              "type": "string",
+              "format": "uri"
+            },
+            {
+              "$ref": "#/definitions/endpoint"
+            },
+            {
+              "type": "array",
+              "items": {
+                "oneOf": [
+                  {
+                    "type": "string",
+                    "format": "uri"
+                  },
+                  {
+                    "$ref": "#/definitions/endpoint"
+                  }
+                ]
+              }
+            }
+          ]
         }
       }
     }
@@ -359,6 +425,18 @@ const (
     }
   },
   "definitions": {
+    "endpoint": {
+      "required": [
+        "uri"
+      ],
+      "type": "object",
+      "properties": {
+        "uri": {
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
 	"proof": {
       "type": "object",
       "required": [ "type", "creator", "created", "proofValue"],
@@ -418,8 +496,29 @@ const (
           "type": "string"
         },
         "serviceEndpoint": {
-          "type": "string",
-          "format": "uri"
+          "oneOf": [
+            {
+              "type": "string",
+              "format": "uri"
+            },
+            {
+              "$ref": "#/definitions/endpoint"
+            },
+            {
+              "type": "array",
+              "items": {
+                "oneOf": [
+                  {
+                    "type": "string",
+                    "format": "uri"
+                  },
+                  {
+                    "$ref": "#/definitions/endpoint"
+                  }
+                ]
+              }
+            }
+          ]
         }
       }
     }
@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	jsonldEndpointURI = "uri"
+	jsonldAccept      = "accept"
+)
+
+// Endpoint is a DIDComm v2 service endpoint object.
+// See https://identity.foundation/didcomm-messaging/spec/#did-document-service-endpoint.
+type Endpoint struct {
+	URI         string   `json:"uri"`
+	Accept      []string `json:"accept,omitempty"`
+	RoutingKeys []string `json:"routingKeys,omitempty"`
+}
+
+// rawService is the JSON representation of the service, whose serviceEndpoint is a string, an endpoint object or
+// an array of them.
+type rawService struct {
+	ID              string                 `json:"id"`
+	Type            string                 `json:"type"`
+	Priority        uint                   `json:"priority,omitempty"`
+	RecipientKeys   []string               `json:"recipientKeys,omitempty"`
+	RoutingKeys     []string               `json:"routingKeys,omitempty"`
+	ServiceEndpoint interface{}            `json:"serviceEndpoint"`
+	Accept          []string               `json:"accept,omitempty"`
+	Properties      map[string]interface{} `json:"properties,omitempty"`
+}
+
+// EndpointURI returns the URI of the service endpoint, the one of the first endpoint object if the service endpoint
+// is given as DIDComm v2 endpoint objects.
+func (s *Service) EndpointURI() string {
+	if s.ServiceEndpoint != "" || len(s.Endpoints) == 0 {
+		return s.ServiceEndpoint
+	}
+
+	return s.Endpoints[0].URI
+}
+
+// EndpointAccept returns the media types accepted by the service endpoint.
+func (s *Service) EndpointAccept() []string {
+	if len(s.Endpoints) != 0 && len(s.Endpoints[0].Accept) != 0 {
+		return s.Endpoints[0].Accept
+	}
+
+	return s.Accept
+}
+
+// EndpointRoutingKeys returns the routing keys of the service endpoint.
+func (s *Service) EndpointRoutingKeys() []string {
+	if len(s.Endpoints) != 0 && len(s.Endpoints[0].RoutingKeys) != 0 {
+		return s.Endpoints[0].RoutingKeys
+	}
+
+	return s.RoutingKeys
+}
+
+// MarshalJSON marshals the service, its service endpoint is marshalled as the DIDComm v2 endpoint objects if it has
+// some.
+func (s Service) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&rawService{
+		ID:              s.ID,
+		Type:            s.Type,
+		Priority:        s.Priority,
+		RecipientKeys:   s.RecipientKeys,
+		RoutingKeys:     s.RoutingKeys,
+		ServiceEndpoint: s.rawServiceEndpoint(),
+		Accept:          s.Accept,
+		Properties:      s.Properties,
+	})
+}
+
+// UnmarshalJSON unmarshals the service, whose service endpoint may be a string, an endpoint object or an array.
+func (s *Service) UnmarshalJSON(data []byte) error {
+	raw := &rawService{}
+
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return fmt.Errorf("unmarshal service: %w", err)
+	}
+
+	*s = Service{
+		ID:            raw.ID,
+		Type:          raw.Type,
+		Priority:      raw.Priority,
+		RecipientKeys: raw.RecipientKeys,
+		RoutingKeys:   raw.RoutingKeys,
+		Accept:        raw.Accept,
+		Properties:    raw.Properties,
+	}
+
+	s.ServiceEndpoint, s.Endpoints = populateServiceEndpoint(raw.ServiceEndpoint)
+
+	return nil
+}
+
+// ParseService parses the service from its JSON object, e.g. a service block of an out-of-band invitation.
+func ParseService(raw map[string]interface{}) (*Service, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("marshal service: %w", err)
+	}
+
+	svc := &Service{}
+
+	err = json.Unmarshal(data, svc)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// rawServiceEndpoint returns the service endpoint as a string if the service has no endpoint objects, otherwise as
+// the endpoint object or the array of endpoint objects.
+func (s *Service) rawServiceEndpoint() interface{} {
+	if len(s.Endpoints) == 0 {
+		return s.ServiceEndpoint
+	}
+
+	endpoints := make([]interface{}, len(s.Endpoints))
+
+	for i, endpoint := range s.Endpoints {
+		endpoints[i] = rawEndpoint(endpoint)
+	}
+
+	if len(endpoints) == 1 {
+		return endpoints[0]
+	}
+
+	return endpoints
+}
+
+func rawEndpoint(endpoint Endpoint) interface{} {
+	if len(endpoint.Accept) == 0 && len(endpoint.RoutingKeys) == 0 {
+		return endpoint.URI
+	}
+
+	raw := map[string]interface{}{jsonldEndpointURI: endpoint.URI}
+
+	if len(endpoint.Accept) != 0 {
+		raw[jsonldAccept] = endpoint.Accept
+	}
+
+	if len(endpoint.RoutingKeys) != 0 {
+		raw[jsonldRoutingKeys] = endpoint.RoutingKeys
+	}
+
+	return raw
+}
+
+// populateServiceEndpoint returns the URI of the service endpoint and, if it is not a plain string, its endpoint
+// objects.
+func populateServiceEndpoint(entry interface{}) (string, []Endpoint) {
+	switch e := entry.(type) {
+	case string:
+		return e, nil
+	case map[string]interface{}:
+		endpoint := endpointEntry(e)
+
+		return endpoint.URI, []Endpoint{endpoint}
+	case []interface{}:
+		var endpoints []Endpoint
+
+		for _, v := range e {
+			switch endpoint := v.(type) {
+			case string:
+				endpoints = append(endpoints, Endpoint{URI: endpoint})
+			case map[string]interface{}:
+				endpoints = append(endpoints, endpointEntry(endpoint))
+			}
+		}
+
+		if len(endpoints) == 0 {
+			return "", nil
+		}
+
+		return endpoints[0].URI, endpoints
+	default:
+		return "", nil
+	}
+}
+
+func endpointEntry(entry map[string]interface{}) Endpoint {
+	uri, _ := entry[jsonldEndpointURI].(string)
+
+	return Endpoint{
+		URI:         uri,
+		Accept:      stringArray(entry[jsonldAccept]),
+		RoutingKeys: stringArray(entry[jsonldRoutingKeys]),
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const docWithEndpointObjects = `{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:example:123456789abcdefghi",
+  "service": [
+    {
+      "id": "did:example:123456789abcdefghi#didcomm-1",
+      "type": "DIDCommMessaging",
+      "serviceEndpoint": {
+        "uri": "https://agent.example.com",
+        "accept": ["didcomm/v2"],
+        "routingKeys": ["did:example:mediator#key-1"]
+      }
+    },
+    {
+      "id": "did:example:123456789abcdefghi#didcomm-2",
+      "type": "DIDCommMessaging",
+      "serviceEndpoint": [
+        "https://agent.example.com",
+        {
+          "uri": "wss://agent.example.com/ws",
+          "accept": ["didcomm/v2"]
+        }
+      ]
+    }
+  ]
+}`
+
+func TestServiceEndpoint(t *testing.T) {
+	t.Run("parse the DIDComm v2 endpoint objects of the document", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(docWithEndpointObjects))
+		require.NoError(t, err)
+		require.Len(t, doc.Service, 2)
+
+		svc := doc.Service[0]
+		require.Equal(t, "https://agent.example.com", svc.ServiceEndpoint)
+		require.Equal(t, "https://agent.example.com", svc.EndpointURI())
+		require.Equal(t, []string{"didcomm/v2"}, svc.EndpointAccept())
+		require.Equal(t, []string{"did:example:mediator#key-1"}, svc.EndpointRoutingKeys())
+
+		require.Equal(t, []Endpoint{
+			{URI: "https://agent.example.com"},
+			{URI: "wss://agent.example.com/ws", Accept: []string{"didcomm/v2"}},
+		}, doc.Service[1].Endpoints)
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		parsed, err := ParseDocument(docBytes)
+		require.NoError(t, err)
+		require.Equal(t, doc.Service, parsed.Service)
+	})
+
+	t.Run("invalid endpoint object", func(t *testing.T) {
+		_, err := ParseDocument([]byte(`{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:example:123456789abcdefghi",
+  "service": [{"id": "did:example:123456789abcdefghi#didcomm", "type": "DIDCommMessaging", "serviceEndpoint": {}}]
+}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "serviceEndpoint")
+	})
+
+	t.Run("service endpoint accessors", func(t *testing.T) {
+		svc := &Service{
+			ServiceEndpoint: "https://agent.example.com",
+			RoutingKeys:     []string{"routing"},
+			Accept:          []string{"didcomm/aip2;env=rfc19"},
+		}
+
+		require.Equal(t, "https://agent.example.com", svc.EndpointURI())
+		require.Equal(t, []string{"routing"}, svc.EndpointRoutingKeys())
+		require.Equal(t, []string{"didcomm/aip2;env=rfc19"}, svc.EndpointAccept())
+
+		svc = &Service{Endpoints: []Endpoint{{URI: "https://agent.example.com"}}, RoutingKeys: []string{"routing"}}
+
+		require.Equal(t, "https://agent.example.com", svc.EndpointURI())
+		require.Equal(t, []string{"routing"}, svc.EndpointRoutingKeys())
+		require.Empty(t, svc.EndpointAccept())
+	})
+}
+
+func TestService_JSON(t *testing.T) {
+	t.Run("string service endpoint", func(t *testing.T) {
+		svc := &Service{
+			ID:              "did:example:123#didcomm",
+			Type:            "did-communication",
+			RecipientKeys:   []string{"did:key:recipient"},
+			ServiceEndpoint: "https://agent.example.com",
+		}
+
+		svcBytes, err := json.Marshal(svc)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"did:example:123#didcomm","type":"did-communication",
+"recipientKeys":["did:key:recipient"],"serviceEndpoint":"https://agent.example.com"}`, string(svcBytes))
+
+		parsed := &Service{}
+		require.NoError(t, json.Unmarshal(svcBytes, parsed))
+		require.Equal(t, svc, parsed)
+	})
+
+	t.Run("endpoint objects", func(t *testing.T) {
+		svc := Service{
+			ID:   "did:example:123#didcomm",
+			Type: "DIDCommMessaging",
+			Endpoints: []Endpoint{
+				{URI: "https://agent.example.com", RoutingKeys: []string{"did:example:mediator#key-1"}},
+				{URI: "wss://agent.example.com/ws"},
+			},
+		}
+
+		svcBytes, err := json.Marshal(svc)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"did:example:123#didcomm","type":"DIDCommMessaging","serviceEndpoint":[
+{"uri":"https://agent.example.com","routingKeys":["did:example:mediator#key-1"]},"wss://agent.example.com/ws"]}`,
+			string(svcBytes))
+
+		parsed := &Service{}
+		require.NoError(t, json.Unmarshal(svcBytes, parsed))
+		require.Equal(t, "https://agent.example.com", parsed.ServiceEndpoint)
+		require.Equal(t, svc.Endpoints, parsed.Endpoints)
+	})
+
+	t.Run("unmarshal error", func(t *testing.T) {
+		require.Error(t, json.Unmarshal([]byte(`{"id":1}`), &Service{}))
+	})
+}
+
+func TestParseService(t *testing.T) {
+	svc, err := ParseService(map[string]interface{}{
+		"id":            "did:example:123#didcomm",
+		"type":          "did-communication",
+		"recipientKeys": []interface{}{"did:key:recipient"},
+		"serviceEndpoint": map[string]interface{}{
+			"uri":         "https://agent.example.com",
+			"routingKeys": []interface{}{"did:example:mediator#key-1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"did:key:recipient"}, svc.RecipientKeys)
+	require.Equal(t, "https://agent.example.com", svc.EndpointURI())
+	require.Equal(t, []string{"did:example:mediator#key-1"}, svc.EndpointRoutingKeys())
+
+	_, err = ParseService(map[string]interface{}{"id": make(chan int)})
+	require.Error(t, err)
+
+	_, err = ParseService(map[string]interface{}{"id": 1})
+	require.Error(t, err)
+}
@@ -96,6 +96,32 @@ type Signer interface {
 	Headers() Headers
 }
 
+// RawSigner signs the data, e.g. using KMS key (see kmssigner.KMSSigner).
+type RawSigner interface {
+	Sign(data []byte) ([]byte, error)
+}
+
+// headersSigner implements Signer using RawSigner and preset JWS headers.
+type headersSigner struct {
+	signer  RawSigner
+	headers Headers
+}
+
+// NewSigner creates JWS Signer using the raw signer and JWS headers. "alg" header must be set.
+func NewSigner(signer RawSigner, headers Headers) Signer {
+	return &headersSigner{signer: signer, headers: headers}
+}
+
+// Sign signs.
+func (s *headersSigner) Sign(data []byte) ([]byte, error) {
+	return s.signer.Sign(data)
+}
+
+// Headers provides JWS headers.
+func (s *headersSigner) Headers() Headers {
+	return s.headers
+}
+
 // NewJWS creates JSON Web Signature.
 func NewJWS(protectedHeaders, unprotectedHeaders Headers, payload []byte, signer Signer) (*JSONWebSignature, error) {
 	headers := mergeHeaders(protectedHeaders, signer.Headers())
@@ -116,6 +142,47 @@ func NewJWS(protectedHeaders, unprotectedHeaders Headers, payload []byte, signer
 	return jws, nil
 }
 
+// NewUnencodedPayloadJWS creates JSON Web Signature with unencoded payload (https://tools.ietf.org/html/rfc7797).
+// "b64" header is set to false and is added to "crit" header.
+func NewUnencodedPayloadJWS(protectedHeaders, unprotectedHeaders Headers, payload []byte,
+	signer Signer) (*JSONWebSignature, error) {
+	headers := mergeHeaders(protectedHeaders, Headers{})
+	headers[HeaderB64Payload] = false
+	headers[HeaderCritical] = appendCritical(headers[HeaderCritical], HeaderB64Payload)
+
+	return NewJWS(headers, unprotectedHeaders, payload, signer)
+}
+
+// NewDetachedJWS signs the external content and returns JWS Compact Serialization with detached content
+// (https://tools.ietf.org/html/rfc7515#appendix-F). Set "b64" protected header to false (see NewUnencodedPayloadJWS)
+// to sign the content itself instead of its base64url encoding.
+func NewDetachedJWS(protectedHeaders Headers, payload []byte, signer Signer) (string, error) {
+	jws, err := NewJWS(protectedHeaders, nil, payload, signer)
+	if err != nil {
+		return "", err
+	}
+
+	return jws.SerializeCompact(true)
+}
+
+// VerifyDetachedJWS verifies JWS Compact Serialization with detached content against the external payload.
+func VerifyDetachedJWS(jws string, payload []byte, verifier SignatureVerifier) (*JSONWebSignature, error) {
+	parts := strings.Split(jws, ".")
+	if len(parts) != jwsPartsCount {
+		return nil, errors.New("invalid JWS compact format")
+	}
+
+	if parts[jwsPayloadPart] != "" {
+		return nil, errors.New("JWS payload is not detached")
+	}
+
+	if len(payload) == 0 {
+		return nil, errors.New("detached payload is empty")
+	}
+
+	return ParseJWS(jws, verifier, WithJWSDetachedPayload(payload))
+}
+
 // SerializeCompact makes JWS Compact Serialization (https://tools.ietf.org/html/rfc7515#section-7.1)
 func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
 	byteHeaders, err := json.Marshal(s.joseHeaders)
@@ -127,7 +194,10 @@ func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
 
 	b64Payload := ""
 	if !detached {
-		b64Payload = base64.RawURLEncoding.EncodeToString(s.Payload)
+		b64Payload, err = compactPayload(s.joseHeaders, s.Payload)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	b64Signature := base64.RawURLEncoding.EncodeToString(s.signature)
@@ -150,6 +220,62 @@ func (s JSONWebSignature) Signature() []byte {
 	return sCopy
 }
 
+// compactPayload returns the payload part of JWS Compact Serialization. Unencoded payload must not contain
+// the period character (https://tools.ietf.org/html/rfc7797#section-5.2).
+func compactPayload(headers Headers, payload []byte) (string, error) {
+	b64, err := isB64Payload(headers)
+	if err != nil {
+		return "", err
+	}
+
+	if b64 {
+		return base64.RawURLEncoding.EncodeToString(payload), nil
+	}
+
+	if strings.Contains(string(payload), ".") {
+		return "", errors.New("unencoded payload contains period character, use detached serialization")
+	}
+
+	return string(payload), nil
+}
+
+func isB64Payload(headers Headers) (bool, error) {
+	b64, ok := headers[HeaderB64Payload]
+	if !ok {
+		return true, nil
+	}
+
+	hBase64, ok := b64.(bool)
+	if !ok {
+		return false, errors.New("invalid b64 header")
+	}
+
+	return hBase64, nil
+}
+
+func appendCritical(crit interface{}, header string) []string {
+	var headers []string
+
+	switch c := crit.(type) {
+	case []string:
+		headers = append(headers, c...)
+	case []interface{}:
+		for _, h := range c {
+			if hStr, ok := h.(string); ok {
+				headers = append(headers, hStr)
+			}
+		}
+	}
+
+	for _, h := range headers {
+		if h == header {
+			return headers
+		}
+	}
+
+	return append(headers, header)
+}
+
 func mergeHeaders(h1, h2 Headers) Headers {
 	h := make(Headers, len(h1)+len(h2))
 
@@ -233,7 +359,7 @@ func parseCompacted(jwsCompact string, verifier SignatureVerifier, opts *jwsPars
 		return nil, err
 	}
 
-	payload, err := parseCompactedPayload(parts[jwsPayloadPart], opts)
+	payload, err := parseCompactedPayload(joseHeaders, parts[jwsPayloadPart], opts)
 	if err != nil {
 		return nil, err
 	}
@@ -261,11 +387,20 @@ func parseCompacted(jwsCompact string, verifier SignatureVerifier, opts *jwsPars
 	}, nil
 }
 
-func parseCompactedPayload(jwsPayload string, opts *jwsParseOpts) ([]byte, error) {
+func parseCompactedPayload(headers Headers, jwsPayload string, opts *jwsParseOpts) ([]byte, error) {
 	if len(opts.detachedPayload) > 0 {
 		return opts.detachedPayload, nil
 	}
 
+	b64, err := isB64Payload(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if !b64 {
+		return []byte(jwsPayload), nil
+	}
+
 	payload, err := base64.RawURLEncoding.DecodeString(jwsPayload)
 	if err != nil {
 		return nil, fmt.Errorf("decode base64 payload: %w", err)
@@ -301,12 +436,9 @@ func signingInput(headers Headers, payload []byte) ([]byte, error) {
 		return nil, fmt.Errorf("serialize JWS headers: %w", err)
 	}
 
-	hBase64 := true
-
-	if b64, ok := headers[HeaderB64Payload]; ok {
-		if hBase64, ok = b64.(bool); !ok {
-			return nil, errors.New("invalid b64 header")
-		}
+	hBase64, err := isB64Payload(headers)
+	if err != nil {
+		return nil, err
 	}
 
 	headersStr := base64.RawURLEncoding.EncodeToString(headersBytes)
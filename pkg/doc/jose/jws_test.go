@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package jose
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -230,6 +232,90 @@ func TestParseJWS(t *testing.T) {
 	require.Nil(t, parsedJWS)
 }
 
+func TestDetachedAndUnencodedPayloadJWS(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := NewSigner(rawSignerFunc(func(data []byte) ([]byte, error) {
+		return ed25519.Sign(privKey, data), nil
+	}), Headers{HeaderAlgorithm: "EdDSA", HeaderKeyID: "key-1"})
+
+	verifier := SignatureVerifierFunc(func(_ Headers, _, signingInput, signature []byte) error {
+		if !ed25519.Verify(pubKey, signingInput, signature) {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	})
+
+	content := []byte(`{"some":"external content."}`)
+
+	t.Run("detached JWS", func(t *testing.T) {
+		jws, err := NewDetachedJWS(Headers{HeaderType: "JWT"}, content, signer)
+		require.NoError(t, err)
+		require.Empty(t, strings.Split(jws, ".")[jwsPayloadPart])
+
+		parsed, err := VerifyDetachedJWS(jws, content, verifier)
+		require.NoError(t, err)
+		require.Equal(t, content, parsed.Payload)
+
+		kid, ok := parsed.ProtectedHeaders.KeyID()
+		require.True(t, ok)
+		require.Equal(t, "key-1", kid)
+
+		_, err = VerifyDetachedJWS(jws, []byte("other content"), verifier)
+		require.EqualError(t, err, "invalid signature")
+
+		_, err = VerifyDetachedJWS(jws, nil, verifier)
+		require.EqualError(t, err, "detached payload is empty")
+	})
+
+	t.Run("detached JWS with unencoded payload", func(t *testing.T) {
+		jws, err := NewUnencodedPayloadJWS(Headers{HeaderCritical: []interface{}{"exp"}}, nil, content, signer)
+		require.NoError(t, err)
+		require.Equal(t, false, jws.ProtectedHeaders[HeaderB64Payload])
+		require.Equal(t, []string{"exp", HeaderB64Payload}, jws.ProtectedHeaders[HeaderCritical])
+
+		_, err = jws.SerializeCompact(false)
+		require.EqualError(t, err, "unencoded payload contains period character, use detached serialization")
+
+		jwsCompact, err := jws.SerializeCompact(true)
+		require.NoError(t, err)
+
+		parsed, err := VerifyDetachedJWS(jwsCompact, content, verifier)
+		require.NoError(t, err)
+		require.Equal(t, content, parsed.Payload)
+	})
+
+	t.Run("attached unencoded payload", func(t *testing.T) {
+		payload := []byte("02")
+
+		jws, err := NewUnencodedPayloadJWS(nil, nil, payload, signer)
+		require.NoError(t, err)
+		require.Equal(t, []string{HeaderB64Payload}, jws.ProtectedHeaders[HeaderCritical])
+
+		jwsCompact, err := jws.SerializeCompact(false)
+		require.NoError(t, err)
+		require.Equal(t, "02", strings.Split(jwsCompact, ".")[jwsPayloadPart])
+
+		parsed, err := ParseJWS(jwsCompact, verifier)
+		require.NoError(t, err)
+		require.Equal(t, payload, parsed.Payload)
+
+		_, err = VerifyDetachedJWS(jwsCompact, payload, verifier)
+		require.EqualError(t, err, "JWS payload is not detached")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewDetachedJWS(nil, content, &testSigner{headers: Headers{}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "alg JWS header is not defined")
+
+		_, err = VerifyDetachedJWS("invalid", content, verifier)
+		require.EqualError(t, err, "invalid JWS compact format")
+	})
+}
+
 func TestIsCompactJWS(t *testing.T) {
 	require.True(t, IsCompactJWS("a.b.c"))
 	require.False(t, IsCompactJWS("a.b"))
@@ -251,6 +337,12 @@ func (s testSigner) Headers() Headers {
 	return s.headers
 }
 
+type rawSignerFunc func(data []byte) ([]byte, error)
+
+func (f rawSignerFunc) Sign(data []byte) ([]byte, error) {
+	return f(data)
+}
+
 type testVerifier struct {
 	err error
 }
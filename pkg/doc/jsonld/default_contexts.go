@@ -36,4 +36,9 @@ var DefaultContexts = []ContextDocument{ //nolint:gochecknoglobals
 		DocumentURL: "https://w3c-ccg.github.io/ldp-bbs2020/contexts/v1/",
 		Path:        "contexts/bbs2020.jsonld",
 	},
+	{
+		URL:         "https://trustbloc.github.io/context/vc/timestamp-evidence-v1.jsonld",
+		DocumentURL: "https://trustbloc.github.io/context/vc/timestamp-evidence-v1.jsonld",
+		Path:        "contexts/timestamp_evidence_v1.jsonld",
+	},
 }
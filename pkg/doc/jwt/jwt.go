@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/square/go-jose/v3/json"
 	"github.com/square/go-jose/v3/jwt"
@@ -31,6 +32,53 @@ const (
 // Claims defines JSON Web Token Claims (https://tools.ietf.org/html/rfc7519#section-4)
 type Claims jwt.Claims
 
+var (
+	// ErrExpired is returned when the "exp" claim is in the past.
+	ErrExpired = jwt.ErrExpired
+
+	// ErrNotValidYet is returned when the "nbf" claim is in the future.
+	ErrNotValidYet = jwt.ErrNotValidYet
+
+	// ErrIssuedInTheFuture is returned when the "iat" claim is in the future.
+	ErrIssuedInTheFuture = jwt.ErrIssuedInTheFuture
+)
+
+// claimsCheckOpts holds options for the check of time-based JWT claims.
+type claimsCheckOpts struct {
+	currentTime func() time.Time
+	leeway      time.Duration
+}
+
+// ClaimsCheckOpt is the option of time-based JWT claims check.
+type ClaimsCheckOpt func(opts *claimsCheckOpts)
+
+// WithCurrentTime option defines the clock the time-based claims are checked against.
+// If not defined, time.Now is used.
+func WithCurrentTime(currentTime func() time.Time) ClaimsCheckOpt {
+	return func(opts *claimsCheckOpts) {
+		opts.currentTime = currentTime
+	}
+}
+
+// WithLeeway option defines the tolerated clock skew when checking time-based claims.
+// No leeway is applied by default.
+func WithLeeway(leeway time.Duration) ClaimsCheckOpt {
+	return func(opts *claimsCheckOpts) {
+		opts.leeway = leeway
+	}
+}
+
+// CheckTime checks "exp", "nbf" and "iat" claims against the current time.
+func (c *Claims) CheckTime(opts ...ClaimsCheckOpt) error {
+	cOpts := &claimsCheckOpts{currentTime: time.Now}
+
+	for _, opt := range opts {
+		opt(cOpts)
+	}
+
+	return jwt.Claims(*c).ValidateWithLeeway(jwt.Expected{Time: cOpts.currentTime()}, cOpts.leeway)
+}
+
 // jwtParseOpts holds options for the JWT parsing.
 type parseOpts struct {
 	detachedPayload []byte
@@ -251,13 +299,21 @@ func isValidJSON(s string) bool {
 	return err == nil
 }
 
+// isJWTType checks that the type is JWT or an explicit JWT type, e.g. "vc+jwt"
+// (https://tools.ietf.org/html/rfc8725#section-3.11).
+func isJWTType(typ interface{}) bool {
+	typStr, ok := typ.(string)
+
+	return ok && (typStr == TypeJWT || strings.HasSuffix(strings.ToLower(typStr), "+jwt"))
+}
+
 func checkHeaders(headers map[string]interface{}) error {
 	if _, ok := headers[jose.HeaderAlgorithm]; !ok {
 		return errors.New("alg header is not defined")
 	}
 
 	typ, ok := headers[jose.HeaderType]
-	if ok && typ != TypeJWT {
+	if ok && !isJWTType(typ) {
 		return errors.New("typ is not JWT")
 	}
 
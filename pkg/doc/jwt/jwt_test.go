@@ -179,6 +179,14 @@ func TestParse(t *testing.T) {
 	r.Contains(err.Error(), "typ is not JWT")
 	r.Nil(token)
 
+	// explicit JWT type
+	signer.headers = map[string]interface{}{"alg": "EdDSA", "typ": "vc+jwt"}
+	jws, err = buildJWS(signer, map[string]interface{}{"iss": "Albert"})
+	r.NoError(err)
+	token, err = Parse(jws, WithSignatureVerifier(verifier))
+	r.NoError(err)
+	r.Equal("vc+jwt", token.Headers[jose.HeaderType])
+
 	// content type is not empty (equals to JWT)
 	signer.headers = map[string]interface{}{"alg": "EdDSA", "typ": "JWT", "cty": "JWT"}
 	jws, err = buildJWS(signer, map[string]interface{}{"iss": "Albert"})
@@ -238,6 +246,42 @@ func TestJSONWebToken_DecodeClaims(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestClaims_CheckTime(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	currentTime := WithCurrentTime(func() time.Time { return now })
+
+	claims := &Claims{
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Hour)),
+		NotBefore: jwt.NewNumericDate(now.Add(-time.Hour)),
+		Expiry:    jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, claims.CheckTime(currentTime))
+		require.NoError(t, (&Claims{}).CheckTime())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		later := WithCurrentTime(func() time.Time { return now.Add(2 * time.Hour) })
+
+		err := claims.CheckTime(later)
+		require.ErrorIs(t, err, ErrExpired)
+
+		require.NoError(t, claims.CheckTime(later, WithLeeway(2*time.Hour)))
+	})
+
+	t.Run("not valid yet", func(t *testing.T) {
+		earlier := WithCurrentTime(func() time.Time { return now.Add(-90 * time.Minute) })
+
+		notBefore := &Claims{NotBefore: claims.NotBefore}
+		require.ErrorIs(t, notBefore.CheckTime(earlier), ErrNotValidYet)
+		require.NoError(t, notBefore.CheckTime(earlier, WithLeeway(time.Hour)))
+
+		issuedAt := &Claims{IssuedAt: claims.IssuedAt}
+		require.ErrorIs(t, issuedAt.CheckTime(earlier), ErrIssuedInTheFuture)
+	})
+}
+
 func TestJSONWebToken_LookupStringHeader(t *testing.T) {
 	token, err := getValidJSONWebToken()
 	require.NoError(t, err)
@@ -8,11 +8,13 @@ package jwt
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
 
+	secp256k1subtle "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1/subtle"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 )
 
@@ -167,6 +169,31 @@ func verifyRS256(jws string, pubKey *rsa.PublicKey) error {
 	return nil
 }
 
+type es256kSigner struct {
+	signer  *secp256k1subtle.Secp256k1Signer
+	headers map[string]interface{}
+}
+
+func newES256KSigner(privKey *ecdsa.PrivateKey) (*es256kSigner, error) {
+	signer, err := secp256k1subtle.NewSecp256k1Signer(privKey.D.Bytes(), secp256k1subtle.IEEEP1363Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return &es256kSigner{
+		signer:  signer,
+		headers: prepareJWSHeaders(nil, signatureES256K),
+	}, nil
+}
+
+func (s es256kSigner) Sign(data []byte) ([]byte, error) {
+	return s.signer.Sign(data)
+}
+
+func (s es256kSigner) Headers() jose.Headers {
+	return s.headers
+}
+
 func prepareJWSHeaders(headers map[string]interface{}, alg string) map[string]interface{} {
 	newHeaders := make(map[string]interface{})
 
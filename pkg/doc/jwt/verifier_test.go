@@ -8,13 +8,16 @@ package jwt
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"errors"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/square/go-jose/v3/json"
 	"github.com/stretchr/testify/require"
 
@@ -73,6 +76,27 @@ func TestNewVerifier(t *testing.T) {
 		_, err = jose.ParseJWS(jws, v)
 		r.NoError(err)
 	})
+
+	t.Run("Verify JWT signed by ES256K", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+		r.NoError(err)
+
+		signer, err := newES256KSigner(privKey)
+		r.NoError(err)
+
+		token, err := NewSigned(&Claims{Issuer: "Mike"}, nil, signer)
+		r.NoError(err)
+		jws, err := token.Serialize(false)
+		r.NoError(err)
+
+		v := NewVerifier(getTestKeyResolver(
+			&verifier.PublicKey{
+				Type:  kms.ECDSASecp256k1IEEEP1363,
+				Value: elliptic.Marshal(btcec.S256(), privKey.X, privKey.Y),
+			}, nil))
+		_, err = jose.ParseJWS(jws, v)
+		r.NoError(err)
+	})
 }
 
 func TestBasicVerifier_Verify(t *testing.T) { // error corner cases
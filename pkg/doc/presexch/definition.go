@@ -585,15 +585,47 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 	return result, nil
 }
 
+// toSubject returns the subject of the limited credential, which keeps only the identifiers of the subjects.
+// The claims constrained by the fields are then copied from the original credential, so the limited credential
+// (or the BBS+ reveal frame) discloses only them.
 func toSubject(subject interface{}) interface{} {
-	sub, ok := subject.([]verifiable.Subject)
-	if ok && len(sub) == 1 {
-		return sub[0]
+	switch s := subject.(type) {
+	case verifiable.Subject:
+		return subjectID(s.ID)
+	case []verifiable.Subject:
+		if len(s) == 1 {
+			return subjectID(s[0].ID)
+		}
+
+		subjects := make([]map[string]interface{}, len(s))
+		for i := range s {
+			subjects[i] = subjectID(s[i].ID)
+		}
+
+		return subjects
+	case map[string]interface{}:
+		return subjectID(s["id"])
+	case []map[string]interface{}:
+		subjects := make([]map[string]interface{}, len(s))
+		for i := range s {
+			subjects[i] = subjectID(s[i]["id"])
+		}
+
+		return subjects
 	}
 
 	return subject
 }
 
+// subjectID keeps the subject as a JSON object, so the constrained claims could be set into it.
+func subjectID(id interface{}) map[string]interface{} {
+	if id == nil || id == "" {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{"id": id}
+}
+
 func tmpID(id string) string {
 	return id + tmpEnding + uuid.New().String()
 }
@@ -427,6 +427,71 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 		checkVP(t, vp)
 	})
 
+	t.Run("Limit disclosure (subject claims)", func(t *testing.T) {
+		required := Required
+
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Schema: []*Schema{{
+					URI: schemaURI,
+				}},
+				Constraints: &Constraints{
+					LimitDisclosure: &required,
+					Fields: []*Field{{
+						Path:   []string{"$.credentialSubject.givenName"},
+						Filter: &Filter{Type: &strFilterType},
+					}},
+				},
+			}},
+		}
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{
+			{
+				Context: []string{verifiable.ContextURI},
+				Types:   []string{verifiable.VCType},
+				ID:      "http://example.edu/credentials/1872",
+				Schemas: []verifiable.TypedID{{
+					ID:   schemaURI,
+					Type: "JsonSchemaValidator2018",
+				}},
+				Subject: verifiable.Subject{
+					ID: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+					CustomFields: map[string]interface{}{
+						"givenName": "JOHN",
+						"gender":    "Male",
+						"birthDate": "1958-07-17",
+					},
+				},
+				Issued: &util.TimeWithTrailingZeroMsec{
+					Time: time.Now(),
+				},
+				Issuer: verifiable.Issuer{
+					ID: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+		require.Equal(t, 1, len(vp.Credentials()))
+
+		vcBytes, err := json.Marshal(vp.Credentials()[0])
+		require.NoError(t, err)
+
+		var vc map[string]interface{}
+		require.NoError(t, json.Unmarshal(vcBytes, &vc))
+
+		require.Equal(t, map[string]interface{}{
+			"id":        "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			"givenName": "JOHN",
+		}, vc["credentialSubject"])
+
+		checkSubmission(t, vp, pd)
+		checkVP(t, vp)
+	})
+
 	t.Run("Limit disclosure BBS+", func(t *testing.T) {
 		required := Required
 
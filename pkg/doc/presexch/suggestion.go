@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const credentialSubjectProperty = "credentialSubject"
+
+// Suggestion is the set of the candidate credentials satisfying an input descriptor of the presentation definition,
+// the holder chooses one of them to submit.
+type Suggestion struct {
+	InputDescriptorID string       `json:"input_descriptor_id"`
+	Name              string       `json:"name,omitempty"`
+	Purpose           string       `json:"purpose,omitempty"`
+	Candidates        []*Candidate `json:"candidates"`
+}
+
+// Candidate is a credential satisfying an input descriptor.
+type Candidate struct {
+	CredentialID string `json:"credential_id"`
+	// Disclosed are the JSON paths of the claims disclosed to the verifier if the candidate is submitted.
+	Disclosed []string `json:"disclosed"`
+	// LimitDisclosure is true if only the claims required by the input descriptor are disclosed.
+	LimitDisclosure bool `json:"limit_disclosure,omitempty"`
+}
+
+// Suggest returns the candidate credentials satisfying the input descriptors of the presentation definition,
+// in the order of the input descriptors. ErrNoCredentials is returned if the credentials do not satisfy the
+// submission requirements.
+func (pd *PresentationDefinition) Suggest(credentials []*verifiable.Credential,
+	opts ...verifiable.CredentialOpt) ([]*Suggestion, error) {
+	if err := pd.ValidateSchema(); err != nil {
+		return nil, err
+	}
+
+	req, err := makeRequirement(pd.SubmissionRequirements, pd.InputDescriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := applyRequirement(req, credentials, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions []*Suggestion
+
+	for _, descriptor := range pd.InputDescriptors {
+		matched, ok := result[descriptor.ID]
+		if !ok {
+			continue
+		}
+
+		suggestion := &Suggestion{
+			InputDescriptorID: descriptor.ID,
+			Name:              descriptor.Name,
+			Purpose:           descriptor.Purpose,
+		}
+
+		for _, credential := range matched {
+			disclosed, err := disclosedClaims(credential)
+			if err != nil {
+				return nil, fmt.Errorf("disclosed claims of %s: %w", trimTmpID(credential.ID), err)
+			}
+
+			suggestion.Candidates = append(suggestion.Candidates, &Candidate{
+				CredentialID:    trimTmpID(credential.ID),
+				Disclosed:       disclosed,
+				LimitDisclosure: descriptor.Constraints != nil && descriptor.Constraints.LimitDisclosure.isRequired(),
+			})
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
+
+// disclosedClaims returns the sorted JSON paths of the subject and custom claims of the credential.
+func disclosedClaims(credential *verifiable.Credential) ([]string, error) {
+	src, err := json.Marshal(credential)
+	if err != nil {
+		return nil, err
+	}
+
+	var credentialMap map[string]interface{}
+
+	if err = json.Unmarshal(src, &credentialMap); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+
+	for key, value := range credentialMap {
+		if _, ok := value.(string); ok && key == credentialSubjectProperty {
+			// a subject reduced to its identifier is serialized as the identifier
+			paths = append(paths, "$."+key+".id")
+
+			continue
+		}
+
+		if _, ok := credential.CustomFields[key]; ok || key == credentialSubjectProperty {
+			paths = appendLeafPaths(paths, "$."+key, value)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func appendLeafPaths(paths []string, path string, value interface{}) []string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			paths = appendLeafPaths(paths, path+"."+key, val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			paths = appendLeafPaths(paths, fmt.Sprintf("%s[%d]", path, i), val)
+		}
+	default:
+		paths = append(paths, path)
+	}
+
+	return paths
+}
@@ -0,0 +1,140 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+func TestPresentationDefinition_Suggest(t *testing.T) {
+	newCredential := func(id string, schemas ...string) *verifiable.Credential {
+		vc := &verifiable.Credential{
+			Context: []string{verifiable.ContextURI},
+			Types:   []string{verifiable.VCType},
+			ID:      id,
+			Subject: verifiable.Subject{
+				ID: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+				CustomFields: map[string]interface{}{
+					"degree": map[string]interface{}{"type": "BachelorDegree", "school": "MIT"},
+				},
+			},
+			Issued: &util.TimeWithTrailingZeroMsec{
+				Time: time.Now(),
+			},
+			Issuer: verifiable.Issuer{
+				ID: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			},
+			CustomFields: map[string]interface{}{
+				"first_name": "First name",
+				"last_name":  "Last name",
+			},
+		}
+
+		for _, schema := range schemas {
+			vc.Schemas = append(vc.Schemas, verifiable.TypedID{ID: schema, Type: "JsonSchemaValidator2018"})
+		}
+
+		return vc
+	}
+
+	t.Run("Checks schema", func(t *testing.T) {
+		pd := &PresentationDefinition{ID: uuid.New().String()}
+
+		suggestions, err := pd.Suggest(nil)
+		require.EqualError(t, err, "presentation_definition: input_descriptors is required")
+		require.Nil(t, suggestions)
+	})
+
+	t.Run("Suggests the matching credentials", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:      uuid.New().String(),
+				Name:    "Degree",
+				Purpose: "We need your degree",
+				Schema:  []*Schema{{URI: schemaURI}},
+			}},
+		}
+
+		suggestions, err := pd.Suggest([]*verifiable.Credential{
+			newCredential("http://example.edu/credentials/1", schemaURI),
+			newCredential("http://example.edu/credentials/2", "http://example.edu/schema"),
+			newCredential("http://example.edu/credentials/3", schemaURI),
+		})
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		require.Equal(t, pd.InputDescriptors[0].ID, suggestions[0].InputDescriptorID)
+		require.Equal(t, "Degree", suggestions[0].Name)
+		require.Equal(t, "We need your degree", suggestions[0].Purpose)
+		require.Len(t, suggestions[0].Candidates, 2)
+		require.Equal(t, "http://example.edu/credentials/1", suggestions[0].Candidates[0].CredentialID)
+		require.Equal(t, "http://example.edu/credentials/3", suggestions[0].Candidates[1].CredentialID)
+		require.False(t, suggestions[0].Candidates[0].LimitDisclosure)
+		require.Equal(t, []string{
+			"$.credentialSubject.degree.school",
+			"$.credentialSubject.degree.type",
+			"$.credentialSubject.id",
+			"$.first_name",
+			"$.last_name",
+		}, suggestions[0].Candidates[0].Disclosed)
+	})
+
+	t.Run("Suggests the limited credentials", func(t *testing.T) {
+		required := Required
+
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:     uuid.New().String(),
+				Schema: []*Schema{{URI: schemaURI}},
+				Constraints: &Constraints{
+					LimitDisclosure: &required,
+					Fields: []*Field{{
+						Path:      []string{"$.first_name"},
+						Predicate: &required,
+						Filter:    &Filter{Type: &strFilterType},
+					}},
+				},
+			}},
+		}
+
+		suggestions, err := pd.Suggest([]*verifiable.Credential{
+			newCredential("http://example.edu/credentials/1", schemaURI),
+		})
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		require.Len(t, suggestions[0].Candidates, 1)
+		require.Equal(t, "http://example.edu/credentials/1", suggestions[0].Candidates[0].CredentialID)
+		require.True(t, suggestions[0].Candidates[0].LimitDisclosure)
+		require.Equal(t, []string{"$.credentialSubject.id", "$.first_name"}, suggestions[0].Candidates[0].Disclosed)
+	})
+
+	t.Run("No matching credentials", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:     uuid.New().String(),
+				Schema: []*Schema{{URI: schemaURI}},
+			}},
+		}
+
+		suggestions, err := pd.Suggest([]*verifiable.Credential{
+			newCredential("http://example.edu/credentials/2", "http://example.edu/schema"),
+		})
+		require.True(t, errors.Is(err, ErrNoCredentials))
+		require.Nil(t, suggestions)
+	})
+}
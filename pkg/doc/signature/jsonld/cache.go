@@ -0,0 +1,113 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// CanonicalizationCache caches the results of JSON-LD canonicalization (RDF dataset normalization).
+// Implementations must be safe for concurrent use.
+type CanonicalizationCache interface {
+	// Get returns the canonical document stored under the given key.
+	Get(key string) ([]byte, bool)
+	// Set stores the canonical document under the given key.
+	Set(key string, canonicalDoc []byte)
+}
+
+// MemCanonicalizationCache is an in-memory CanonicalizationCache which keeps at most a fixed
+// number of entries, evicting the least recently used entry first.
+type MemCanonicalizationCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	entries    map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key   string
+	value []byte
+}
+
+// NewMemCanonicalizationCache returns a new in-memory canonicalization cache holding up to maxEntries documents.
+func NewMemCanonicalizationCache(maxEntries int) *MemCanonicalizationCache {
+	return &MemCanonicalizationCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the canonical document stored under the given key.
+func (c *MemCanonicalizationCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.ll.MoveToFront(e)
+
+	return e.Value.(*cacheEntry).value, true // nolint: errcheck
+}
+
+// Set stores the canonical document under the given key.
+func (c *MemCanonicalizationCache) Set(key string, canonicalDoc []byte) {
+	if c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(e)
+		e.Value.(*cacheEntry).value = canonicalDoc // nolint: errcheck
+
+		return
+	}
+
+	c.entries[key] = c.ll.PushFront(&cacheEntry{key: key, value: canonicalDoc})
+
+	for c.ll.Len() > c.maxEntries {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key) // nolint: errcheck
+	}
+}
+
+// Len returns the number of cached documents.
+func (c *MemCanonicalizationCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+// canonicalizationCacheKey computes the key of a document in the canonicalization cache.
+// The key covers the document (including its context set), the RDF dataset algorithm and
+// the options altering the canonical form.
+func canonicalizationCacheKey(algorithm string, doc map[string]interface{}, opts *processorOpts) (string, error) {
+	docBytes, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("marshal document for canonicalization cache: %w", err)
+	}
+
+	h := sha256.New()
+
+	fmt.Fprintf(h, "%s|%t|%t|", algorithm, opts.removeInvalidRDF, opts.validateRDF)
+	h.Write(docBytes) // nolint: errcheck,gosec
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
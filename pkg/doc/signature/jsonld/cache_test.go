@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemCanonicalizationCache(t *testing.T) {
+	t.Run("get and set", func(t *testing.T) {
+		cache := NewMemCanonicalizationCache(2)
+
+		_, ok := cache.Get("a")
+		require.False(t, ok)
+
+		cache.Set("a", []byte("1"))
+		cache.Set("b", []byte("2"))
+		cache.Set("a", []byte("3"))
+
+		v, ok := cache.Get("a")
+		require.True(t, ok)
+		require.Equal(t, []byte("3"), v)
+		require.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("evicts least recently used entry", func(t *testing.T) {
+		cache := NewMemCanonicalizationCache(2)
+
+		cache.Set("a", []byte("1"))
+		cache.Set("b", []byte("2"))
+
+		_, ok := cache.Get("a")
+		require.True(t, ok)
+
+		cache.Set("c", []byte("3"))
+
+		_, ok = cache.Get("b")
+		require.False(t, ok)
+
+		_, ok = cache.Get("a")
+		require.True(t, ok)
+
+		_, ok = cache.Get("c")
+		require.True(t, ok)
+	})
+
+	t.Run("zero size cache stores nothing", func(t *testing.T) {
+		cache := NewMemCanonicalizationCache(0)
+
+		cache.Set("a", []byte("1"))
+
+		_, ok := cache.Get("a")
+		require.False(t, ok)
+	})
+}
+
+func TestGetCanonicalDocument_CanonicalizationCache(t *testing.T) {
+	cache := NewMemCanonicalizationCache(10)
+
+	canonize := func(opts ...ProcessorOpts) string {
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(jsonLdWithIncorrectRDF), &doc))
+
+		result, err := Default().GetCanonicalDocument(doc,
+			append([]ProcessorOpts{jsonldCache, WithCanonicalizationCache(cache)}, opts...)...)
+		require.NoError(t, err)
+
+		return string(result)
+	}
+
+	require.Equal(t, canonizedIncorrectRDF_Filtered, canonize(WithRemoveAllInvalidRDF()))
+	require.Equal(t, 1, cache.Len())
+
+	require.Equal(t, canonizedIncorrectRDF_Filtered, canonize(WithRemoveAllInvalidRDF()))
+	require.Equal(t, 1, cache.Len())
+
+	// options altering the canonical form are part of the cache key
+	require.Equal(t, canonizedIncorrectRDF, canonize())
+	require.Equal(t, 2, cache.Len())
+
+	t.Run("cached result is not shared", func(t *testing.T) {
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(jsonLdWithIncorrectRDF), &doc))
+
+		result, err := Default().GetCanonicalDocument(doc, jsonldCache, WithCanonicalizationCache(cache))
+		require.NoError(t, err)
+
+		result[0] = '!'
+
+		require.Equal(t, canonizedIncorrectRDF, canonize())
+	})
+
+	t.Run("document which can't be marshalled", func(t *testing.T) {
+		_, err := Default().GetCanonicalDocument(map[string]interface{}{"invalid": make(chan int)},
+			WithCanonicalizationCache(cache))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "canonicalization cache")
+	})
+}
+
+func BenchmarkGetCanonicalDocument_CanonicalizationCache(b *testing.B) {
+	var doc map[string]interface{}
+
+	require.NoError(b, json.Unmarshal([]byte(jsonLdWithIncorrectRDF), &doc))
+
+	b.Run("without cache", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			_, err := Default().GetCanonicalDocument(doc, jsonldCache, WithRemoveAllInvalidRDF())
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("with cache", func(b *testing.B) {
+		cache := NewMemCanonicalizationCache(10)
+
+		for n := 0; n < b.N; n++ {
+			_, err := Default().GetCanonicalDocument(doc, jsonldCache, WithRemoveAllInvalidRDF(),
+				WithCanonicalizationCache(cache))
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("with cache and distinct documents", func(b *testing.B) {
+		cache := NewMemCanonicalizationCache(10)
+
+		for n := 0; n < b.N; n++ {
+			doc["id"] = "http://example.com/" + strconv.Itoa(n)
+
+			_, err := Default().GetCanonicalDocument(doc, jsonldCache, WithRemoveAllInvalidRDF(),
+				WithCanonicalizationCache(cache))
+			require.NoError(b, err)
+		}
+	})
+}
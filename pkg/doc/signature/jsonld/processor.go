@@ -20,9 +20,19 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
 )
 
+const (
+	// AlgorithmURDNA2015 is the URDNA2015 RDF dataset normalization algorithm used by the Linked Data Signatures
+	// suites.
+	AlgorithmURDNA2015 = "URDNA2015"
+
+	// AlgorithmRDFC10 is the RDF Dataset Canonicalization algorithm (RDFC-1.0) required by the Data Integrity
+	// suites. RDFC-1.0 is the W3C standardized URDNA2015 and produces the same canonical form.
+	AlgorithmRDFC10 = "RDFC-1.0"
+)
+
 const (
 	format             = "application/n-quads"
-	defaultAlgorithm   = "URDNA2015"
+	defaultAlgorithm   = AlgorithmURDNA2015
 	handleNormalizeErr = "error while parsing N-Quads; invalid quad. line:"
 )
 
@@ -39,6 +49,7 @@ type processorOpts struct {
 	documentLoader      ld.DocumentLoader
 	externalContexts    []string
 	documentLoaderCache map[string]interface{}
+	canonicalCache      CanonicalizationCache
 }
 
 // ProcessorOpts are the options for JSON LD operations on docs (like canonicalization or compacting).
@@ -99,13 +110,23 @@ func WithValidateRDF() ProcessorOpts {
 	}
 }
 
+// WithCanonicalizationCache option is for passing a cache of canonical documents. Canonicalization of a document
+// already processed with the same context set and options is then skipped, which speeds up repeated
+// verification of identical documents.
+func WithCanonicalizationCache(cache CanonicalizationCache) ProcessorOpts {
+	return func(opts *processorOpts) {
+		opts.canonicalCache = cache
+	}
+}
+
 // Processor is JSON-LD processor for aries.
 // processing mode JSON-LD 1.0 {RFC: https://www.w3.org/TR/2014/REC-json-ld-20140116}
 type Processor struct {
 	algorithm string
 }
 
-// NewProcessor returns new JSON-LD processor for aries.
+// NewProcessor returns new JSON-LD processor for aries canonicalizing documents with the given RDF dataset
+// canonicalization algorithm (e.g. AlgorithmURDNA2015 or AlgorithmRDFC10).
 func NewProcessor(algorithm string) *Processor {
 	if algorithm == "" {
 		return Default()
@@ -119,6 +140,20 @@ func Default() *Processor {
 	return &Processor{defaultAlgorithm}
 }
 
+// Algorithm returns the RDF dataset canonicalization algorithm of the processor.
+func (p *Processor) Algorithm() string {
+	return p.algorithm
+}
+
+// ldAlgorithm returns the name of the canonicalization algorithm known to the JSON-LD library.
+func (p *Processor) ldAlgorithm() string {
+	if p.algorithm == AlgorithmRDFC10 {
+		return AlgorithmURDNA2015
+	}
+
+	return p.algorithm
+}
+
 // GetCanonicalDocument returns canonized document of given json ld.
 func (p *Processor) GetCanonicalDocument(doc map[string]interface{}, opts ...ProcessorOpts) ([]byte, error) {
 	procOptions := prepareOpts(opts)
@@ -126,7 +161,7 @@ func (p *Processor) GetCanonicalDocument(doc map[string]interface{}, opts ...Pro
 	proc := ld.NewJsonLdProcessor()
 	ldOptions := ld.NewJsonLdOptions("")
 	ldOptions.ProcessingMode = ld.JsonLd_1_1
-	ldOptions.Algorithm = p.algorithm
+	ldOptions.Algorithm = p.ldAlgorithm()
 	ldOptions.Format = format
 	ldOptions.ProduceGeneralizedRdf = true
 	useDocumentLoader(ldOptions, procOptions.documentLoader, procOptions.documentLoaderCache)
@@ -135,6 +170,21 @@ func (p *Processor) GetCanonicalDocument(doc map[string]interface{}, opts ...Pro
 		doc["@context"] = AppendExternalContexts(doc["@context"], procOptions.externalContexts...)
 	}
 
+	var cacheKey string
+
+	if procOptions.canonicalCache != nil {
+		var err error
+
+		cacheKey, err = canonicalizationCacheKey(p.algorithm, doc, procOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		if cached, ok := procOptions.canonicalCache.Get(cacheKey); ok {
+			return append([]byte(nil), cached...), nil
+		}
+	}
+
 	view, err := proc.Normalize(doc, ldOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to normalize JSON-LD document: %w", err)
@@ -150,6 +200,10 @@ func (p *Processor) GetCanonicalDocument(doc map[string]interface{}, opts ...Pro
 		return nil, err
 	}
 
+	if procOptions.canonicalCache != nil {
+		procOptions.canonicalCache.Set(cacheKey, []byte(result))
+	}
+
 	return []byte(result), nil
 }
 
@@ -492,7 +546,7 @@ func (p *Processor) normalizeFilteredDataset(view string) (string, error) {
 	proc := ld.NewJsonLdProcessor()
 	options := ld.NewJsonLdOptions("")
 	options.ProcessingMode = ld.JsonLd_1_1
-	options.Algorithm = p.algorithm
+	options.Algorithm = p.ldAlgorithm()
 	options.Format = format
 
 	filteredJSONLd, err := proc.FromRDF(view, options)
@@ -250,6 +250,24 @@ func TestGetCanonicalDocument(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Test canonicalization algorithms", func(t *testing.T) {
+		var jsonldDoc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(jsonLDProofSample), &jsonldDoc))
+
+		require.Equal(t, AlgorithmURDNA2015, Default().Algorithm())
+		require.Equal(t, AlgorithmURDNA2015, NewProcessor("").Algorithm())
+
+		processor := NewProcessor(AlgorithmRDFC10)
+		require.Equal(t, AlgorithmRDFC10, processor.Algorithm())
+
+		response, err := processor.GetCanonicalDocument(jsonldDoc, jsonldCache)
+		require.NoError(t, err)
+		require.EqualValues(t, canonizedJsonLDProof, string(response))
+
+		_, err = NewProcessor("unknown").GetCanonicalDocument(jsonldDoc, jsonldCache)
+		require.Error(t, err)
+	})
 }
 
 func TestCompact(t *testing.T) {
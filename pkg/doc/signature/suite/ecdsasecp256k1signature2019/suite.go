@@ -27,15 +27,17 @@ type Suite struct {
 const (
 	signatureType = "EcdsaSecp256k1Signature2019"
 	jwkType       = "EcdsaSecp256k1VerificationKey2019"
-	rdfDataSetAlg = "URDNA2015"
+	rdfDataSetAlg = jsonld.AlgorithmURDNA2015
 )
 
 // New an instance of Linked Data Signatures for JWS suite.
 func New(opts ...suite.Opt) *Suite {
-	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+	s := &Suite{}
 
 	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
 
+	s.jsonldProcessor = s.JSONLDProcessor(rdfDataSetAlg)
+
 	return s
 }
 
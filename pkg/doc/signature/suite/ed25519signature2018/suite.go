@@ -27,15 +27,17 @@ type Suite struct {
 const (
 	// SignatureType is the signature type for ed25519 keys.
 	SignatureType = "Ed25519Signature2018"
-	rdfDataSetAlg = "URDNA2015"
+	rdfDataSetAlg = jsonld.AlgorithmURDNA2015
 )
 
 // New an instance of ed25519 signature suite.
 func New(opts ...suite.Opt) *Suite {
-	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+	s := &Suite{}
 
 	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
 
+	s.jsonldProcessor = s.JSONLDProcessor(rdfDataSetAlg)
+
 	return s
 }
 
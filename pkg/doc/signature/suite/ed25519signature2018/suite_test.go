@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 )
 
 func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
@@ -16,6 +19,10 @@ func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, doc)
 	require.Equal(t, test28Result, string(doc))
+
+	doc, err = New(suite.WithCanonicalizationAlgorithm(jsonld.AlgorithmRDFC10)).GetCanonicalDocument(getDefaultDoc())
+	require.NoError(t, err)
+	require.Equal(t, test28Result, string(doc))
 }
 
 func TestSignatureSuite_GetDigest(t *testing.T) {
@@ -35,15 +35,17 @@ type Suite struct {
 const (
 	signatureType = "JsonWebSignature2020"
 	jwkType       = "JwsVerificationKey2020"
-	rdfDataSetAlg = "URDNA2015"
+	rdfDataSetAlg = jsonld.AlgorithmURDNA2015
 )
 
 // New an instance of Linked Data Signatures for JWS suite.
 func New(opts ...suite.Opt) *Suite {
-	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+	s := &Suite{}
 
 	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
 
+	s.jsonldProcessor = s.JSONLDProcessor(rdfDataSetAlg)
+
 	return s
 }
 
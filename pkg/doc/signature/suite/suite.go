@@ -8,6 +8,7 @@ package suite
 import (
 	"errors"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
@@ -16,6 +17,8 @@ type SignatureSuite struct {
 	Signer         signer
 	Verifier       verifier
 	CompactedProof bool
+	// CanonicalizationAlgorithm is the RDF dataset canonicalization algorithm, the suite version default if empty.
+	CanonicalizationAlgorithm string
 }
 
 type signer interface {
@@ -52,6 +55,14 @@ func WithCompactProof() Opt {
 	}
 }
 
+// WithCanonicalizationAlgorithm defines the RDF dataset canonicalization algorithm of the Signature Suite
+// (e.g. jsonld.AlgorithmRDFC10), overriding the default algorithm of the suite version.
+func WithCanonicalizationAlgorithm(algorithm string) Opt {
+	return func(opts *SignatureSuite) {
+		opts.CanonicalizationAlgorithm = algorithm
+	}
+}
+
 // InitSuiteOptions initializes signature suite with options.
 func InitSuiteOptions(suite *SignatureSuite, opts ...Opt) *SignatureSuite {
 	for _, opt := range opts {
@@ -61,6 +72,16 @@ func InitSuiteOptions(suite *SignatureSuite, opts ...Opt) *SignatureSuite {
 	return suite
 }
 
+// JSONLDProcessor returns the JSON-LD processor canonicalizing documents with the canonicalization algorithm
+// of the Signature Suite, or with the given default algorithm of the suite version if it is not defined.
+func (s *SignatureSuite) JSONLDProcessor(defaultAlgorithm string) *jsonld.Processor {
+	if s.CanonicalizationAlgorithm != "" {
+		return jsonld.NewProcessor(s.CanonicalizationAlgorithm)
+	}
+
+	return jsonld.NewProcessor(defaultAlgorithm)
+}
+
 // Verify will verify a signature.
 func (s *SignatureSuite) Verify(pubKeyValue *sigverifier.PublicKey, doc, signature []byte) error {
 	if s.Verifier == nil {
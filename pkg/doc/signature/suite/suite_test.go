@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
@@ -70,6 +71,15 @@ func TestWithCompactProof(t *testing.T) {
 	require.True(t, ss.CompactProof())
 }
 
+func TestWithCanonicalizationAlgorithm(t *testing.T) {
+	ss := InitSuiteOptions(&SignatureSuite{})
+	require.Equal(t, jsonld.AlgorithmURDNA2015, ss.JSONLDProcessor(jsonld.AlgorithmURDNA2015).Algorithm())
+
+	ss = InitSuiteOptions(&SignatureSuite{}, WithCanonicalizationAlgorithm(jsonld.AlgorithmRDFC10))
+	require.Equal(t, jsonld.AlgorithmRDFC10, ss.CanonicalizationAlgorithm)
+	require.Equal(t, jsonld.AlgorithmRDFC10, ss.JSONLDProcessor(jsonld.AlgorithmURDNA2015).Algorithm())
+}
+
 func TestWithSigner(t *testing.T) {
 	suiteOpt := WithSigner(&mockSigner{})
 	require.NotNil(t, suiteOpt)
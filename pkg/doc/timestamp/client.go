@@ -0,0 +1,196 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timestamp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+var logger = log.New("aries-framework/doc/timestamp")
+
+const (
+	queryContentType = "application/timestamp-query"
+	replyContentType = "application/timestamp-reply"
+
+	// maxReplySize is the maximum size of the TSA replies read.
+	maxReplySize = 1 << 20
+
+	nonceBits = 64
+)
+
+// PKIStatus values of the timestamp replies granting the request.
+const (
+	statusGranted         = 0
+	statusGrantedWithMods = 1
+)
+
+type request struct {
+	Version        int
+	MessageImprint messageImprint
+	ReqPolicy      asn1.ObjectIdentifier `asn1:"optional"`
+	Nonce          *big.Int              `asn1:"optional"`
+	CertReq        bool                  `asn1:"optional"`
+	Extensions     []pkix.Extension      `asn1:"optional,tag:0"`
+}
+
+type pkiStatusInfo struct {
+	Status       int
+	StatusString []string       `asn1:"optional"`
+	FailInfo     asn1.BitString `asn1:"optional"`
+}
+
+type response struct {
+	Status         pkiStatusInfo
+	TimeStampToken asn1.RawValue `asn1:"optional"`
+}
+
+// Client requests the timestamp tokens from a TSA over HTTP (RFC 3161 section 3.4).
+type Client struct {
+	url        string
+	httpClient *http.Client
+	hash       crypto.Hash
+	policy     asn1.ObjectIdentifier
+}
+
+// ClientOpt configures the Client.
+type ClientOpt func(c *Client)
+
+// WithHTTPClient sets the HTTP client of the TSA requests, http.DefaultClient by default.
+func WithHTTPClient(httpClient *http.Client) ClientOpt {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHash sets the hash algorithm of the timestamped data: crypto.SHA256 (default), crypto.SHA384 or crypto.SHA512.
+func WithHash(hash crypto.Hash) ClientOpt {
+	return func(c *Client) {
+		c.hash = hash
+	}
+}
+
+// WithPolicy requests the tokens to be generated under the TSA policy.
+func WithPolicy(policy asn1.ObjectIdentifier) ClientOpt {
+	return func(c *Client) {
+		c.policy = policy
+	}
+}
+
+// NewClient creates a new client of the TSA at the given URL.
+func NewClient(url string, opts ...ClientOpt) *Client {
+	c := &Client{
+		url:        url,
+		httpClient: http.DefaultClient,
+		hash:       crypto.SHA256,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Timestamp requests a timestamp token over the hash of the data and returns the DER encoded token, which embeds the
+// TSA certificate. The token is checked to timestamp the data and to answer the request, its signature is verified
+// by Token.Verify.
+func (c *Client) Timestamp(data []byte) ([]byte, error) {
+	hashOID, ok := hashOIDs[c.hash]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %s", c.hash)
+	}
+
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), nonceBits))
+	if err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	req, err := asn1.Marshal(request{
+		Version: 1,
+		MessageImprint: messageImprint{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: hashOID, Parameters: asn1.NullRawValue},
+			HashedMessage: digest(c.hash, data),
+		},
+		ReqPolicy: c.policy,
+		Nonce:     nonce,
+		CertReq:   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal timestamp request: %w", err)
+	}
+
+	reply, err := c.post(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp response
+
+	if err = unmarshalDER(reply, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal timestamp reply: %w", err)
+	}
+
+	if resp.Status.Status != statusGranted && resp.Status.Status != statusGrantedWithMods {
+		return nil, fmt.Errorf("timestamp request rejected with status %d %v", resp.Status.Status,
+			resp.Status.StatusString)
+	}
+
+	token, err := Parse(resp.TimeStampToken.FullBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse timestamp token: %w", err)
+	}
+
+	if token.HashAlgorithm != c.hash || !bytes.Equal(token.HashedMessage, digest(c.hash, data)) {
+		return nil, errors.New("timestamp token does not timestamp the data")
+	}
+
+	if token.Nonce == nil || token.Nonce.Cmp(nonce) != 0 {
+		return nil, errors.New("timestamp token does not answer the request")
+	}
+
+	return token.Raw, nil
+}
+
+func (c *Client) post(req []byte) ([]byte, error) {
+	resp, err := c.httpClient.Post(c.url, queryContentType, bytes.NewReader(req)) //nolint:noctx
+	if err != nil {
+		return nil, fmt.Errorf("post timestamp request: %w", err)
+	}
+
+	defer func() {
+		if errClose := resp.Body.Close(); errClose != nil {
+			logger.Warnf("failed to close the timestamp reply: %s", errClose)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("TSA replied with status %s", resp.Status)
+	}
+
+	if ct := resp.Header.Get("Content-Type"); ct != replyContentType {
+		return nil, fmt.Errorf("unexpected timestamp reply content type %q", ct)
+	}
+
+	reply, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxReplySize))
+	if err != nil {
+		return nil, fmt.Errorf("read timestamp reply: %w", err)
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,407 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timestamp
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/x509chain"
+)
+
+func TestClient_Timestamp(t *testing.T) {
+	tsa := newTestTSA(t, x509.ExtKeyUsageTimeStamping)
+	data := []byte("credential")
+
+	t.Run("token is verified", func(t *testing.T) {
+		for _, hash := range []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+			der, err := NewClient(tsa.server.URL, WithHash(hash), WithHTTPClient(tsa.server.Client())).Timestamp(data)
+			require.NoError(t, err)
+
+			token, err := Parse(der)
+			require.NoError(t, err)
+			require.Equal(t, hash, token.HashAlgorithm)
+			require.Equal(t, tsa.genTime, token.GenTime)
+			require.Equal(t, int64(42), token.SerialNumber.Int64())
+			require.Len(t, token.Certificates, 1)
+
+			require.NoError(t, token.Verify(data, tsa.validator()))
+
+			err = token.Verify([]byte("other"), tsa.validator())
+			require.EqualError(t, err, "token does not timestamp the data")
+
+			err = token.Verify(data, x509chain.New())
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "validate signer certificate")
+		}
+	})
+
+	t.Run("requested policy", func(t *testing.T) {
+		policy := asn1.ObjectIdentifier{1, 2, 3, 4}
+
+		der, err := NewClient(tsa.server.URL, WithPolicy(policy)).Timestamp(data)
+		require.NoError(t, err)
+
+		token, err := Parse(der)
+		require.NoError(t, err)
+		require.Equal(t, policy, token.Policy)
+	})
+
+	t.Run("rejected requests", func(t *testing.T) {
+		tsa.status = 2
+
+		_, err := NewClient(tsa.server.URL).Timestamp(data)
+		require.EqualError(t, err, "timestamp request rejected with status 2 [bad request]")
+
+		tsa.status = 0
+		tsa.contentType = "text/plain"
+
+		_, err = NewClient(tsa.server.URL).Timestamp(data)
+		require.EqualError(t, err, `unexpected timestamp reply content type "text/plain"`)
+
+		tsa.contentType = replyContentType
+		tsa.httpStatus = http.StatusInternalServerError
+
+		_, err = NewClient(tsa.server.URL).Timestamp(data)
+		require.EqualError(t, err, "TSA replied with status 500 Internal Server Error")
+
+		tsa.httpStatus = http.StatusOK
+
+		_, err = NewClient("http://localhost:1").Timestamp(data)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "post timestamp request")
+
+		_, err = NewClient(tsa.server.URL, WithHash(crypto.MD5)).Timestamp(data)
+		require.EqualError(t, err, "unsupported hash algorithm MD5")
+	})
+
+	t.Run("tokens not answering the request", func(t *testing.T) {
+		tsa.nonce = big.NewInt(1)
+
+		_, err := NewClient(tsa.server.URL).Timestamp(data)
+		require.EqualError(t, err, "timestamp token does not answer the request")
+
+		tsa.nonce = nil
+		tsa.imprint = []byte("other")
+
+		_, err = NewClient(tsa.server.URL).Timestamp(data)
+		require.EqualError(t, err, "timestamp token does not timestamp the data")
+
+		tsa.imprint = nil
+		tsa.reply = []byte("invalid")
+
+		_, err = NewClient(tsa.server.URL).Timestamp(data)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal timestamp reply")
+
+		tsa.reply = nil
+	})
+}
+
+func TestToken_Verify(t *testing.T) {
+	data := []byte("credential")
+
+	t.Run("signer without time stamping usage", func(t *testing.T) {
+		tsa := newTestTSA(t, x509.ExtKeyUsageServerAuth)
+
+		token, err := Parse(tsa.token(t, crypto.SHA256, digest(crypto.SHA256, data), nil))
+		require.NoError(t, err)
+
+		err = token.Verify(data, tsa.validator())
+		require.EqualError(t, err, "signer certificate is not a time stamping certificate")
+	})
+
+	t.Run("invalid signatures", func(t *testing.T) {
+		tsa := newTestTSA(t, x509.ExtKeyUsageTimeStamping)
+
+		token, err := Parse(tsa.token(t, crypto.SHA256, digest(crypto.SHA256, data), nil))
+		require.NoError(t, err)
+
+		token.signerInfo.Signature[len(token.signerInfo.Signature)-1] ^= 1
+
+		err = token.Verify(data, tsa.validator())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check token signature")
+
+		token, err = Parse(tsa.token(t, crypto.SHA256, digest(crypto.SHA256, data), nil))
+		require.NoError(t, err)
+
+		token.eContent = append(token.eContent, 0)
+
+		err = token.Verify(data, tsa.validator())
+		require.EqualError(t, err, "signed message digest does not match TSTInfo")
+
+		token.signerInfo.SignedAttrs = asn1.RawValue{}
+
+		err = token.Verify(data, tsa.validator())
+		require.EqualError(t, err, "signer info has no signed attributes")
+
+		token.Certificates = nil
+
+		err = token.Verify(data, tsa.validator())
+		require.EqualError(t, err, "signer certificate is not embedded into the token")
+	})
+}
+
+func TestNewVerifier(t *testing.T) {
+	tsa := newTestTSA(t, x509.ExtKeyUsageTimeStamping)
+	data := []byte("credential")
+	verify := NewVerifier(tsa.validator())
+
+	token, err := NewClient(tsa.server.URL).Timestamp(data)
+	require.NoError(t, err)
+
+	genTime, err := verify(token, data)
+	require.NoError(t, err)
+	require.Equal(t, tsa.genTime, genTime)
+
+	_, err = verify(token, []byte("other"))
+	require.EqualError(t, err, "token does not timestamp the data")
+
+	_, err = verify([]byte("invalid"), data)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parse timestamp token")
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte("invalid"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unmarshal content info")
+
+	other, err := asn1.Marshal(contentInfo{
+		ContentType: asn1.ObjectIdentifier{1, 2, 3},
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: []byte{5, 0}},
+	})
+	require.NoError(t, err)
+
+	_, err = Parse(other)
+	require.EqualError(t, err, "unexpected content type 1.2.3")
+
+	tsa := newTestTSA(t, x509.ExtKeyUsageTimeStamping)
+
+	_, err = Parse(tsa.token(t, crypto.SHA1, make([]byte, 20), nil))
+	require.EqualError(t, err, "message imprint: unsupported hash algorithm 1.3.14.3.2.26")
+}
+
+type testTSA struct {
+	server  *httptest.Server
+	root    *x509.Certificate
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	genTime time.Time
+
+	// the replies are altered by the tests
+	status      int
+	httpStatus  int
+	contentType string
+	nonce       *big.Int
+	imprint     []byte
+	reply       []byte
+}
+
+func newTestTSA(t *testing.T, usage x509.ExtKeyUsage) *testTSA {
+	t.Helper()
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, &rootKey.PublicKey, rootKey)
+	require.NoError(t, err)
+
+	root, err := x509.ParseCertificate(rootDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	certDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test TSA"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}, root, &key.PublicKey, rootKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+
+	tsa := &testTSA{
+		root:        root,
+		cert:        cert,
+		key:         key,
+		genTime:     time.Date(2021, time.April, 1, 12, 0, 0, 0, time.UTC),
+		httpStatus:  http.StatusOK,
+		contentType: replyContentType,
+	}
+
+	tsa.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tsa.serve(t, w, r)
+	}))
+	t.Cleanup(tsa.server.Close)
+
+	return tsa
+}
+
+func (tsa *testTSA) validator() *x509chain.Validator {
+	return x509chain.New(x509chain.WithTrustAnchors(tsa.root))
+}
+
+func (tsa *testTSA) serve(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	require.NoError(t, err)
+	require.Equal(t, queryContentType, r.Header.Get("Content-Type"))
+
+	var req request
+
+	require.NoError(t, unmarshalDER(body, &req))
+	require.True(t, req.CertReq)
+
+	hash, err := hashOf(req.MessageImprint.HashAlgorithm.Algorithm)
+	require.NoError(t, err)
+
+	resp := response{Status: pkiStatusInfo{Status: tsa.status}}
+
+	if tsa.status == statusGranted {
+		imprint, nonce := req.MessageImprint.HashedMessage, req.Nonce
+
+		if tsa.imprint != nil {
+			imprint = tsa.imprint
+		}
+
+		if tsa.nonce != nil {
+			nonce = tsa.nonce
+		}
+
+		resp.TimeStampToken = asn1.RawValue{FullBytes: tsa.token(t, hash, imprint, nonce, req.ReqPolicy...)}
+	} else {
+		resp.Status.StatusString = []string{"bad request"}
+	}
+
+	reply, err := asn1.Marshal(resp)
+	require.NoError(t, err)
+
+	if tsa.reply != nil {
+		reply = tsa.reply
+	}
+
+	w.Header().Set("Content-Type", tsa.contentType)
+	w.WriteHeader(tsa.httpStatus)
+
+	_, err = w.Write(reply)
+	require.NoError(t, err)
+}
+
+// token creates a timestamp token signed by the TSA, the signer is identified by its issuer and serial number.
+func (tsa *testTSA) token(t *testing.T, hash crypto.Hash, imprint []byte, nonce *big.Int, policy ...int) []byte {
+	t.Helper()
+
+	hashOID, ok := hashOIDs[hash]
+	if !ok {
+		hashOID = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+		hash = crypto.SHA256
+	}
+
+	if len(policy) == 0 {
+		policy = []int{1, 2, 3}
+	}
+
+	eContent, err := asn1.Marshal(tstInfo{
+		Version: 1,
+		Policy:  policy,
+		MessageImprint: messageImprint{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: hashOID},
+			HashedMessage: imprint,
+		},
+		SerialNumber: big.NewInt(42),
+		GenTime:      tsa.genTime,
+		Nonce:        nonce,
+	})
+	require.NoError(t, err)
+
+	contentType, err := asn1.Marshal(attribute{Type: oidAttrContentType, Values: setOf(t, oidTSTInfo)})
+	require.NoError(t, err)
+
+	messageDigest, err := asn1.Marshal(attribute{
+		Type: oidAttrMessageDigest, Values: setOf(t, digest(hash, eContent)),
+	})
+	require.NoError(t, err)
+
+	attrs := append(contentType, messageDigest...)
+
+	signed, err := asn1.Marshal(asn1.RawValue{
+		Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: attrs,
+	})
+	require.NoError(t, err)
+
+	signature, err := tsa.key.Sign(rand.Reader, digest(hash, signed), hash)
+	require.NoError(t, err)
+
+	sid, err := asn1.Marshal(issuerAndSerialNumber{
+		Issuer: asn1.RawValue{FullBytes: tsa.cert.RawIssuer}, SerialNumber: tsa.cert.SerialNumber,
+	})
+	require.NoError(t, err)
+
+	sd, err := asn1.Marshal(signedData{
+		Version:          3, //nolint:gomnd
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{{Algorithm: hashOIDs[hash]}},
+		EncapContentInfo: encapsulatedContentInfo{EContentType: oidTSTInfo, EContent: eContent},
+		Certificates: asn1.RawValue{
+			Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: tsa.cert.Raw,
+		},
+		SignerInfos: []signerInfo{{
+			Version:            1,
+			SID:                asn1.RawValue{FullBytes: sid},
+			DigestAlgorithm:    pkix.AlgorithmIdentifier{Algorithm: hashOIDs[hash]},
+			SignedAttrs:        asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: attrs},
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+			Signature:          signature,
+		}},
+	})
+	require.NoError(t, err)
+
+	token, err := asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd},
+	})
+	require.NoError(t, err)
+
+	return token
+}
+
+func setOf(t *testing.T, value interface{}) asn1.RawValue {
+	t.Helper()
+
+	der, err := asn1.Marshal(value)
+	require.NoError(t, err)
+
+	return asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: der}
+}
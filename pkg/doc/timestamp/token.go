@@ -0,0 +1,396 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package timestamp obtains and verifies the RFC 3161 timestamp tokens issued by the Time Stamping Authorities
+// (TSA): a token proves that the data it was requested for existed before its time, independently of the clock of
+// the requester.
+package timestamp
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha256" // register the hash algorithms of the message imprints
+	_ "crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/x509chain"
+)
+
+//nolint:gochecknoglobals
+var (
+	oidSignedData        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+	oidAttrContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttrMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+
+	hashOIDs = map[crypto.Hash]asn1.ObjectIdentifier{
+		crypto.SHA256: {2, 16, 840, 1, 101, 3, 4, 2, 1},
+		crypto.SHA384: {2, 16, 840, 1, 101, 3, 4, 2, 2},
+		crypto.SHA512: {2, 16, 840, 1, 101, 3, 4, 2, 3},
+	}
+)
+
+// Token is a timestamp token (RFC 3161 TimeStampToken): the TSTInfo signed by the TSA in a CMS SignedData.
+type Token struct {
+	// Raw is the DER encoded token.
+	Raw []byte
+	// GenTime is the time the token was generated at.
+	GenTime time.Time
+	// SerialNumber is the serial number of the token, unique per TSA.
+	SerialNumber *big.Int
+	// Policy is the TSA policy the token was generated under.
+	Policy asn1.ObjectIdentifier
+	// Nonce is the nonce of the timestamp request, if any.
+	Nonce *big.Int
+	// HashAlgorithm is the hash algorithm of the timestamped data.
+	HashAlgorithm crypto.Hash
+	// HashedMessage is the hash of the timestamped data.
+	HashedMessage []byte
+	// Certificates are the certificates embedded into the token, including the TSA certificate if requested.
+	Certificates []*x509.Certificate
+
+	eContent   []byte
+	signerInfo signerInfo
+}
+
+type messageImprint struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	HashedMessage []byte
+}
+
+type accuracy struct {
+	Seconds int `asn1:"optional"`
+	Millis  int `asn1:"optional,tag:0"`
+	Micros  int `asn1:"optional,tag:1"`
+}
+
+type tstInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint messageImprint
+	SerialNumber   *big.Int
+	GenTime        time.Time        `asn1:"generalized"`
+	Accuracy       accuracy         `asn1:"optional"`
+	Ordering       bool             `asn1:"optional,default:false"`
+	Nonce          *big.Int         `asn1:"optional"`
+	TSA            asn1.RawValue    `asn1:"optional,tag:0"`
+	Extensions     []pkix.Extension `asn1:"optional,tag:1"`
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+type encapsulatedContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type signerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+// Parse parses the DER encoded timestamp token.
+func Parse(der []byte) (*Token, error) {
+	var info contentInfo
+
+	if err := unmarshalDER(der, &info); err != nil {
+		return nil, fmt.Errorf("unmarshal content info: %w", err)
+	}
+
+	if !info.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("unexpected content type %s", info.ContentType)
+	}
+
+	var sd signedData
+
+	if err := unmarshalDER(info.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("unmarshal signed data: %w", err)
+	}
+
+	if !sd.EncapContentInfo.EContentType.Equal(oidTSTInfo) {
+		return nil, fmt.Errorf("unexpected encapsulated content type %s", sd.EncapContentInfo.EContentType)
+	}
+
+	if len(sd.SignerInfos) != 1 {
+		return nil, fmt.Errorf("token has %d signers", len(sd.SignerInfos))
+	}
+
+	var tst tstInfo
+
+	if err := unmarshalDER(sd.EncapContentInfo.EContent, &tst); err != nil {
+		return nil, fmt.Errorf("unmarshal TSTInfo: %w", err)
+	}
+
+	hashAlg, err := hashOf(tst.MessageImprint.HashAlgorithm.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("message imprint: %w", err)
+	}
+
+	certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificates: %w", err)
+	}
+
+	return &Token{
+		Raw:           der,
+		GenTime:       tst.GenTime,
+		SerialNumber:  tst.SerialNumber,
+		Policy:        tst.Policy,
+		Nonce:         tst.Nonce,
+		HashAlgorithm: hashAlg,
+		HashedMessage: tst.MessageImprint.HashedMessage,
+		Certificates:  certs,
+		eContent:      sd.EncapContentInfo.EContent,
+		signerInfo:    sd.SignerInfos[0],
+	}, nil
+}
+
+// Verify verifies that the token timestamps the data and is signed by a TSA certificate, with the time stamping
+// extended key usage, whose chain is validated by the validator.
+func (t *Token) Verify(data []byte, validator *x509chain.Validator) error {
+	if !bytes.Equal(digest(t.HashAlgorithm, data), t.HashedMessage) {
+		return errors.New("token does not timestamp the data")
+	}
+
+	signer, err := t.signer()
+	if err != nil {
+		return err
+	}
+
+	if err = t.verifySignature(signer); err != nil {
+		return err
+	}
+
+	if !hasTimeStampingUsage(signer) {
+		return errors.New("signer certificate is not a time stamping certificate")
+	}
+
+	chain := []*x509.Certificate{signer}
+
+	for _, cert := range t.Certificates {
+		if cert != signer {
+			chain = append(chain, cert)
+		}
+	}
+
+	if _, err = validator.Validate(chain); err != nil {
+		return fmt.Errorf("validate signer certificate: %w", err)
+	}
+
+	return nil
+}
+
+// NewVerifier returns the verifier of the DER encoded timestamp tokens signed by the TSA certificates validated by
+// the validator, it returns the time of the verified tokens (see verifiable.WithTimestampEvidenceCheck).
+func NewVerifier(validator *x509chain.Validator) func(token, data []byte) (time.Time, error) {
+	return func(der, data []byte) (time.Time, error) {
+		token, err := Parse(der)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse timestamp token: %w", err)
+		}
+
+		if err = token.Verify(data, validator); err != nil {
+			return time.Time{}, err
+		}
+
+		return token.GenTime, nil
+	}
+}
+
+// signer returns the certificate identified by the signer info.
+func (t *Token) signer() (*x509.Certificate, error) {
+	sid := t.signerInfo.SID
+
+	for _, cert := range t.Certificates {
+		switch {
+		case sid.Class == asn1.ClassContextSpecific && sid.Tag == 0:
+			if bytes.Equal(cert.SubjectKeyId, sid.Bytes) {
+				return cert, nil
+			}
+		case sid.Class == asn1.ClassUniversal && sid.Tag == asn1.TagSequence:
+			var ias issuerAndSerialNumber
+
+			if err := unmarshalDER(sid.FullBytes, &ias); err != nil {
+				return nil, fmt.Errorf("unmarshal signer identifier: %w", err)
+			}
+
+			if bytes.Equal(cert.RawIssuer, ias.Issuer.FullBytes) && cert.SerialNumber.Cmp(ias.SerialNumber) == 0 {
+				return cert, nil
+			}
+		}
+	}
+
+	return nil, errors.New("signer certificate is not embedded into the token")
+}
+
+// verifySignature verifies the signature of the signed attributes, which bind the TSTInfo by its digest.
+func (t *Token) verifySignature(signer *x509.Certificate) error {
+	si := t.signerInfo
+
+	if len(si.SignedAttrs.Bytes) == 0 {
+		return errors.New("signer info has no signed attributes")
+	}
+
+	digestAlg, err := hashOf(si.DigestAlgorithm.Algorithm)
+	if err != nil {
+		return fmt.Errorf("signer digest: %w", err)
+	}
+
+	// the signed attributes are signed with their SET OF tag rather than their IMPLICIT [0] tag
+	signed, err := asn1.Marshal(asn1.RawValue{
+		Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: si.SignedAttrs.Bytes,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal signed attributes: %w", err)
+	}
+
+	var attrs []attribute
+
+	if _, err = asn1.UnmarshalWithParams(signed, &attrs, "set"); err != nil {
+		return fmt.Errorf("unmarshal signed attributes: %w", err)
+	}
+
+	if err = checkSignedAttributes(attrs, digest(digestAlg, t.eContent)); err != nil {
+		return err
+	}
+
+	sigAlg, err := signatureAlgorithm(signer, digestAlg)
+	if err != nil {
+		return err
+	}
+
+	if err = signer.CheckSignature(sigAlg, signed, si.Signature); err != nil {
+		return fmt.Errorf("check token signature: %w", err)
+	}
+
+	return nil
+}
+
+func checkSignedAttributes(attrs []attribute, contentDigest []byte) error {
+	var contentTypeOK, digestOK bool
+
+	for _, attr := range attrs {
+		switch {
+		case attr.Type.Equal(oidAttrContentType):
+			var contentType asn1.ObjectIdentifier
+
+			_, err := asn1.Unmarshal(attr.Values.Bytes, &contentType)
+			contentTypeOK = err == nil && contentType.Equal(oidTSTInfo)
+		case attr.Type.Equal(oidAttrMessageDigest):
+			var md []byte
+
+			_, err := asn1.Unmarshal(attr.Values.Bytes, &md)
+			digestOK = err == nil && bytes.Equal(md, contentDigest)
+		}
+	}
+
+	if !contentTypeOK {
+		return errors.New("signed content type is not TSTInfo")
+	}
+
+	if !digestOK {
+		return errors.New("signed message digest does not match TSTInfo")
+	}
+
+	return nil
+}
+
+// signatureAlgorithm returns the signature algorithm of the signer key with the digest algorithm, the CMS signature
+// algorithm identifiers are either the key algorithms (e.g. rsaEncryption) or the signature algorithms.
+func signatureAlgorithm(signer *x509.Certificate, digestAlg crypto.Hash) (x509.SignatureAlgorithm, error) {
+	algorithms := map[x509.PublicKeyAlgorithm]map[crypto.Hash]x509.SignatureAlgorithm{
+		x509.RSA: {
+			crypto.SHA256: x509.SHA256WithRSA, crypto.SHA384: x509.SHA384WithRSA, crypto.SHA512: x509.SHA512WithRSA,
+		},
+		x509.ECDSA: {
+			crypto.SHA256: x509.ECDSAWithSHA256, crypto.SHA384: x509.ECDSAWithSHA384, crypto.SHA512: x509.ECDSAWithSHA512,
+		},
+		x509.Ed25519: {
+			crypto.SHA256: x509.PureEd25519, crypto.SHA384: x509.PureEd25519, crypto.SHA512: x509.PureEd25519,
+		},
+	}
+
+	sigAlg, ok := algorithms[signer.PublicKeyAlgorithm][digestAlg]
+	if !ok {
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported signer key algorithm %s", signer.PublicKeyAlgorithm)
+	}
+
+	return sigAlg, nil
+}
+
+func hasTimeStampingUsage(cert *x509.Certificate) bool {
+	for _, usage := range cert.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageTimeStamping {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hashOf(oid asn1.ObjectIdentifier) (crypto.Hash, error) {
+	for h, hashOID := range hashOIDs {
+		if hashOID.Equal(oid) {
+			return h, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported hash algorithm %s", oid)
+}
+
+func digest(h crypto.Hash, data []byte) []byte {
+	hash := h.New()
+	hash.Write(data) //nolint:errcheck // hash.Hash never returns an error
+
+	return hash.Sum(nil)
+}
+
+// unmarshalDER unmarshals the DER encoded value, without trailing data.
+func unmarshalDER(der []byte, v interface{}) error {
+	rest, err := asn1.Unmarshal(der, v)
+	if err != nil {
+		return err
+	}
+
+	if len(rest) > 0 {
+		return errors.New("trailing data")
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustregistry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EBSITrustedIssuersRegistryURL is the default URL of the Trusted Issuers Registry of the European Blockchain
+// Services Infrastructure (pilot network).
+const EBSITrustedIssuersRegistryURL = "https://api-pilot.ebsi.eu/trusted-issuers-registry/v4"
+
+// Issuer types of the EBSI Trusted Issuers Registry.
+const (
+	// EBSIIssuerTypeRootTAO is the type of root Trusted Accreditation Organisation.
+	EBSIIssuerTypeRootTAO = "RootTAO"
+	// EBSIIssuerTypeTAO is the type of Trusted Accreditation Organisation.
+	EBSIIssuerTypeTAO = "TAO"
+	// EBSIIssuerTypeTI is the type of Trusted Issuer.
+	EBSIIssuerTypeTI = "TI"
+	// EBSIIssuerTypeRevoked is the type of issuer whose accreditation is revoked.
+	EBSIIssuerTypeRevoked = "Revoked"
+)
+
+const jwtParts = 3
+
+// ebsiIssuer is the issuer record of the EBSI Trusted Issuers Registry.
+type ebsiIssuer struct {
+	DID        string          `json:"did"`
+	Attributes []ebsiAttribute `json:"attributes"`
+}
+
+// ebsiAttribute is an accreditation of the issuer, the body is the verifiable accreditation in JWT format.
+type ebsiAttribute struct {
+	Hash       string `json:"hash,omitempty"`
+	Body       string `json:"body"`
+	IssuerType string `json:"issuerType"`
+	TAO        string `json:"tao,omitempty"`
+	RootTAO    string `json:"rootTao,omitempty"`
+}
+
+// ebsiAccreditation is the payload of a verifiable accreditation, restricted to the accredited credential types.
+type ebsiAccreditation struct {
+	VC *ebsiAccreditation `json:"vc,omitempty"`
+
+	CredentialSubject struct {
+		AccreditedFor []struct {
+			Types []string `json:"types"`
+		} `json:"accreditedFor"`
+	} `json:"credentialSubject"`
+}
+
+// EBSIRegistry resolves issuer metadata from the EBSI Trusted Issuers Registry exposing
+// GET {baseURL}/issuers/{did}. The issuer is accredited when one of its accreditations is of type RootTAO, TAO or TI,
+// and it is allowed to issue the credential types its accreditations are accredited for.
+//
+// The accreditations are read from the registry as they are: the registry is trusted to have validated them
+// when they were registered, their signatures are not verified.
+type EBSIRegistry struct {
+	registry *HTTPRegistry
+}
+
+// NewEBSIRegistry returns new EBSI Trusted Issuers Registry client, baseURL is typically
+// EBSITrustedIssuersRegistryURL.
+func NewEBSIRegistry(baseURL string, opts ...HTTPRegistryOpt) *EBSIRegistry {
+	return &EBSIRegistry{registry: NewHTTPRegistry(baseURL, opts...)}
+}
+
+// ResolveIssuer returns metadata of the issuer from the EBSI Trusted Issuers Registry.
+func (r *EBSIRegistry) ResolveIssuer(issuerID string) (*IssuerMetadata, error) {
+	respBytes, err := r.registry.getIssuer(issuerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var issuer ebsiIssuer
+
+	if err = json.Unmarshal(respBytes, &issuer); err != nil {
+		return nil, fmt.Errorf("unmarshal ebsi issuer: %w", err)
+	}
+
+	metadata := &IssuerMetadata{DID: issuer.DID, Status: StatusRevoked}
+	if metadata.DID == "" {
+		metadata.DID = issuerID
+	}
+
+	for _, attribute := range issuer.Attributes {
+		switch attribute.IssuerType {
+		case EBSIIssuerTypeRootTAO, EBSIIssuerTypeTAO, EBSIIssuerTypeTI:
+		default:
+			continue
+		}
+
+		metadata.Status = StatusAccredited
+
+		types, errTypes := accreditedTypes(attribute.Body)
+		if errTypes != nil {
+			logger.Warnf("ignoring the credential types of an accreditation of issuer %s: %s", issuerID, errTypes)
+
+			continue
+		}
+
+		metadata.CredentialTypes = append(metadata.CredentialTypes, types...)
+	}
+
+	return metadata, nil
+}
+
+// accreditedTypes returns the credential types the JWT verifiable accreditation is accredited for.
+func accreditedTypes(body string) ([]string, error) {
+	parts := strings.Split(body, ".")
+	if len(parts) != jwtParts {
+		return nil, fmt.Errorf("accreditation is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decode accreditation payload: %w", err)
+	}
+
+	var accreditation ebsiAccreditation
+
+	if err = json.Unmarshal(payload, &accreditation); err != nil {
+		return nil, fmt.Errorf("unmarshal accreditation payload: %w", err)
+	}
+
+	// the JWT claims hold the credential in the vc claim
+	if accreditation.VC != nil {
+		accreditation = *accreditation.VC
+	}
+
+	var types []string
+
+	for _, accreditedFor := range accreditation.CredentialSubject.AccreditedFor {
+		types = append(types, accreditedFor.Types...)
+	}
+
+	return types, nil
+}
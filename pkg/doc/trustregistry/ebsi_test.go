@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustregistry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const ebsiIssuerDID = "did:ebsi:zZeKyEJfUTGwajhNyNX928z"
+
+func TestEBSIRegistry_ResolveIssuer(t *testing.T) {
+	accreditation := jwtAccreditation(t, map[string]interface{}{
+		"vc": map[string]interface{}{
+			"credentialSubject": map[string]interface{}{
+				"id": ebsiIssuerDID,
+				"accreditedFor": []interface{}{
+					map[string]interface{}{
+						"schemaId": "https://api-pilot.ebsi.eu/trusted-schemas-registry/v2/schemas/z123",
+						"types":    []string{"VerifiableCredential", "VerifiableAttestation", "DiplomaCredential"},
+					},
+				},
+			},
+		},
+	})
+
+	issuers := map[string]*ebsiIssuer{
+		ebsiIssuerDID: {
+			DID: ebsiIssuerDID,
+			Attributes: []ebsiAttribute{
+				{Body: accreditation, IssuerType: EBSIIssuerTypeTI},
+				{Body: "invalid", IssuerType: EBSIIssuerTypeTAO},
+			},
+		},
+		"did:ebsi:revoked": {
+			Attributes: []ebsiAttribute{{Body: accreditation, IssuerType: EBSIIssuerTypeRevoked}},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/issuers/did:ebsi:invalid" {
+			_, err := w.Write([]byte("{"))
+			require.NoError(t, err)
+
+			return
+		}
+
+		for did, issuer := range issuers {
+			if r.URL.Path == "/issuers/"+did {
+				require.NoError(t, json.NewEncoder(w).Encode(issuer))
+
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	registry := NewEBSIRegistry(srv.URL, WithHTTPClient(srv.Client()))
+
+	t.Run("accredited issuer", func(t *testing.T) {
+		metadata, err := registry.ResolveIssuer(ebsiIssuerDID)
+		require.NoError(t, err)
+		require.Equal(t, ebsiIssuerDID, metadata.DID)
+		require.True(t, metadata.Accredited())
+		require.True(t, metadata.AllowsType("DiplomaCredential"))
+		require.False(t, metadata.AllowsType("DriverLicense"))
+
+		require.NoError(t, CheckIssuer(registry, ebsiIssuerDID, []string{"VerifiableCredential", "DiplomaCredential"}))
+	})
+
+	t.Run("revoked issuer", func(t *testing.T) {
+		metadata, err := registry.ResolveIssuer("did:ebsi:revoked")
+		require.NoError(t, err)
+		require.Equal(t, "did:ebsi:revoked", metadata.DID)
+		require.False(t, metadata.Accredited())
+
+		require.ErrorIs(t, CheckIssuer(registry, "did:ebsi:revoked", nil), ErrIssuerNotAccredited)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := registry.ResolveIssuer("did:ebsi:unknown")
+		require.ErrorIs(t, err, ErrIssuerNotFound)
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		_, err := registry.ResolveIssuer("did:ebsi:invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal ebsi issuer")
+	})
+}
+
+func TestAccreditedTypes(t *testing.T) {
+	t.Run("accreditation without vc claim", func(t *testing.T) {
+		types, err := accreditedTypes(jwtAccreditation(t, map[string]interface{}{
+			"credentialSubject": map[string]interface{}{
+				"accreditedFor": []interface{}{map[string]interface{}{"types": []string{"DiplomaCredential"}}},
+			},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"DiplomaCredential"}, types)
+	})
+
+	t.Run("invalid accreditations", func(t *testing.T) {
+		_, err := accreditedTypes("a.b")
+		require.EqualError(t, err, "accreditation is not a JWT")
+
+		_, err = accreditedTypes("a.#.c")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode accreditation payload")
+
+		_, err = accreditedTypes("a." + base64.RawURLEncoding.EncodeToString([]byte("{")) + ".c")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal accreditation payload")
+	})
+}
+
+func jwtAccreditation(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256K","typ":"JWT"}`))
+
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustregistry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+var logger = log.New("aries-framework/doc/trustregistry")
+
+const (
+	// DefaultTimeout is the default timeout of trust registry requests.
+	DefaultTimeout = 10 * time.Second
+
+	issuersPath     = "/issuers/"
+	maxResponseSize = 1 << 20
+)
+
+// HTTPClient performs HTTP requests to the trust registry.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HTTPRegistry resolves issuer metadata from trust registry exposing GET {baseURL}/issuers/{issuerID}.
+// The endpoint responds with IssuerMetadata in JSON or with status 404 if the issuer is not registered.
+type HTTPRegistry struct {
+	baseURL string
+	client  HTTPClient
+	timeout time.Duration
+	header  http.Header
+}
+
+// HTTPRegistryOpt configures HTTPRegistry.
+type HTTPRegistryOpt func(r *HTTPRegistry)
+
+// WithHTTPClient sets the HTTP client used for trust registry requests.
+func WithHTTPClient(client HTTPClient) HTTPRegistryOpt {
+	return func(r *HTTPRegistry) {
+		r.client = client
+	}
+}
+
+// WithTimeout sets the timeout of trust registry requests.
+func WithTimeout(timeout time.Duration) HTTPRegistryOpt {
+	return func(r *HTTPRegistry) {
+		r.timeout = timeout
+	}
+}
+
+// WithHeader adds the header (e.g. Authorization) to trust registry requests.
+func WithHeader(key, value string) HTTPRegistryOpt {
+	return func(r *HTTPRegistry) {
+		r.header.Add(key, value)
+	}
+}
+
+// NewHTTPRegistry returns new HTTP-backed trust registry.
+func NewHTTPRegistry(baseURL string, opts ...HTTPRegistryOpt) *HTTPRegistry {
+	r := &HTTPRegistry{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{},
+		timeout: DefaultTimeout,
+		header:  http.Header{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// ResolveIssuer returns metadata of the issuer from the trust registry.
+func (r *HTTPRegistry) ResolveIssuer(issuerID string) (*IssuerMetadata, error) {
+	respBytes, err := r.getIssuer(issuerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata IssuerMetadata
+
+	if err = json.Unmarshal(respBytes, &metadata); err != nil {
+		return nil, fmt.Errorf("unmarshal issuer metadata: %w", err)
+	}
+
+	if metadata.DID == "" {
+		metadata.DID = issuerID
+	}
+
+	return &metadata, nil
+}
+
+// getIssuer returns the issuer record of GET {baseURL}/issuers/{issuerID}, or ErrIssuerNotFound on status 404.
+func (r *HTTPRegistry) getIssuer(issuerID string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		r.baseURL+issuersPath+url.PathEscape(issuerID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create trust registry request: %w", err)
+	}
+
+	for k, v := range r.header {
+		req.Header[k] = v
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("trust registry request: %w", err)
+	}
+
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			logger.Warnf("failed to close trust registry response body: %s", e)
+		}
+	}()
+
+	respBytes, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("read trust registry response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrIssuerNotFound
+	default:
+		return nil, fmt.Errorf("trust registry responded with status %d: %s", resp.StatusCode, respBytes)
+	}
+
+	return respBytes, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package trustregistry defines the lookup of issuer metadata in a trust registry of an ecosystem
+// (accreditation status of the issuer and the credential types it is allowed to issue).
+// Verifiers consult the registry to enforce ecosystem governance.
+package trustregistry
+
+import (
+	"errors"
+	"fmt"
+)
+
+const baseCredentialType = "VerifiableCredential"
+
+// Accreditation statuses of issuers.
+const (
+	// StatusAccredited is the status of issuer accredited by the ecosystem.
+	StatusAccredited = "accredited"
+	// StatusSuspended is the status of issuer whose accreditation is suspended.
+	StatusSuspended = "suspended"
+	// StatusRevoked is the status of issuer whose accreditation is revoked.
+	StatusRevoked = "revoked"
+)
+
+var (
+	// ErrIssuerNotFound is returned when the issuer is not registered in the trust registry.
+	ErrIssuerNotFound = errors.New("issuer not found in trust registry")
+
+	// ErrIssuerNotAccredited is returned when the issuer is registered but is not accredited.
+	ErrIssuerNotAccredited = errors.New("issuer is not accredited")
+
+	// ErrCredentialTypeNotAllowed is returned when the issuer is not allowed to issue the credential type.
+	ErrCredentialTypeNotAllowed = errors.New("issuer is not allowed to issue credential type")
+)
+
+// IssuerMetadata is the metadata of issuer registered in the trust registry.
+type IssuerMetadata struct {
+	DID             string   `json:"did"`
+	Name            string   `json:"name,omitempty"`
+	Status          string   `json:"status"`
+	CredentialTypes []string `json:"credentialTypes,omitempty"`
+}
+
+// Accredited checks whether the issuer is accredited.
+func (m *IssuerMetadata) Accredited() bool {
+	return m.Status == StatusAccredited
+}
+
+// AllowsType checks whether the issuer is allowed to issue credentials of the given type.
+// The base VerifiableCredential type is always allowed.
+func (m *IssuerMetadata) AllowsType(credentialType string) bool {
+	if credentialType == baseCredentialType {
+		return true
+	}
+
+	for _, t := range m.CredentialTypes {
+		if t == credentialType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Registry resolves issuer metadata from the trust registry.
+type Registry interface {
+	// ResolveIssuer returns metadata of the issuer or ErrIssuerNotFound if the issuer is not registered.
+	ResolveIssuer(issuerID string) (*IssuerMetadata, error)
+}
+
+// RegistryFunc is a function adapter of Registry.
+type RegistryFunc func(issuerID string) (*IssuerMetadata, error)
+
+// ResolveIssuer calls f(issuerID).
+func (f RegistryFunc) ResolveIssuer(issuerID string) (*IssuerMetadata, error) {
+	return f(issuerID)
+}
+
+// CheckIssuer checks that the issuer is accredited in the registry and is allowed
+// to issue credentials of all the given types.
+func CheckIssuer(registry Registry, issuerID string, credentialTypes []string) error {
+	metadata, err := registry.ResolveIssuer(issuerID)
+	if err != nil {
+		return fmt.Errorf("resolve issuer %s: %w", issuerID, err)
+	}
+
+	if !metadata.Accredited() {
+		return fmt.Errorf("%w: %s has status %q", ErrIssuerNotAccredited, issuerID, metadata.Status)
+	}
+
+	for _, t := range credentialTypes {
+		if !metadata.AllowsType(t) {
+			return fmt.Errorf("%w: %s", ErrCredentialTypeNotAllowed, t)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const issuerDID = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+func TestCheckIssuer(t *testing.T) {
+	registry := RegistryFunc(func(issuerID string) (*IssuerMetadata, error) {
+		switch issuerID {
+		case issuerDID:
+			return &IssuerMetadata{DID: issuerID, Status: StatusAccredited, CredentialTypes: []string{"UniversityDegree"}}, nil
+		case "did:example:suspended":
+			return &IssuerMetadata{DID: issuerID, Status: StatusSuspended}, nil
+		default:
+			return nil, ErrIssuerNotFound
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, CheckIssuer(registry, issuerDID, []string{"VerifiableCredential", "UniversityDegree"}))
+	})
+
+	t.Run("type not allowed", func(t *testing.T) {
+		err := CheckIssuer(registry, issuerDID, []string{"VerifiableCredential", "DriverLicense"})
+		require.ErrorIs(t, err, ErrCredentialTypeNotAllowed)
+		require.Contains(t, err.Error(), "DriverLicense")
+	})
+
+	t.Run("not accredited", func(t *testing.T) {
+		err := CheckIssuer(registry, "did:example:suspended", nil)
+		require.ErrorIs(t, err, ErrIssuerNotAccredited)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := CheckIssuer(registry, "did:example:unknown", nil)
+		require.ErrorIs(t, err, ErrIssuerNotFound)
+	})
+}
+
+func TestHTTPRegistry_ResolveIssuer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/issuers/" + issuerDID:
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(&IssuerMetadata{
+				Name:            "Example University",
+				Status:          StatusAccredited,
+				CredentialTypes: []string{"UniversityDegree"},
+			}))
+		case "/issuers/did:example:invalid":
+			_, err := w.Write([]byte("{"))
+			require.NoError(t, err)
+		case "/issuers/did:example:error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	registry := NewHTTPRegistry(srv.URL+"/", WithHTTPClient(srv.Client()), WithHeader("Authorization", "Bearer token"))
+
+	t.Run("success", func(t *testing.T) {
+		metadata, err := registry.ResolveIssuer(issuerDID)
+		require.NoError(t, err)
+		require.Equal(t, issuerDID, metadata.DID)
+		require.Equal(t, "Example University", metadata.Name)
+		require.True(t, metadata.Accredited())
+		require.True(t, metadata.AllowsType("UniversityDegree"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := registry.ResolveIssuer("did:example:unknown")
+		require.ErrorIs(t, err, ErrIssuerNotFound)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		_, err := registry.ResolveIssuer("did:example:error")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status 500")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		_, err := registry.ResolveIssuer("did:example:invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal issuer metadata")
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		_, err := NewHTTPRegistry(srv.URL, WithHTTPClient(clientFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}))).ResolveIssuer(issuerDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "connection refused")
+	})
+}
+
+type clientFunc func(req *http.Request) (*http.Response, error)
+
+func (f clientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	hybrid "github.com/google/tink/go/hybrid/subtle"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
@@ -29,7 +30,7 @@ import (
 var errInvalidKeyType = errors.New("key type is not supported")
 
 // CreateKID creates a KID value based on the marshalled keyBytes of type kt. This function should be called for
-// asymmetric public keys only (ECDSA DER or IEEE-P1363, ED25519, X25519, BLS12381G2).
+// asymmetric public keys only (ECDSA DER or IEEE-P1363, secp256k1, ED25519, X25519, BLS12381G2).
 // returns:
 //  - base64 raw (no padding) URL encoded KID
 //  - error in case of error
@@ -60,6 +61,14 @@ func CreateKID(keyBytes []byte, kt kms.KeyType) (string, error) {
 		}
 
 		return bbsKID, nil
+	case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363: // secp256k1 JWK thumbprint is not supported by
+		// go jose, manually build it and build its resulting KID.
+		secp256k1KID, err := createSecp256k1KID(keyBytes)
+		if err != nil {
+			return "", fmt.Errorf("createKID: %w", err)
+		}
+
+		return secp256k1KID, nil
 	}
 
 	jwk, err := BuildJWK(keyBytes, kt)
@@ -109,6 +118,16 @@ func BuildJWK(keyBytes []byte, kt kms.KeyType) (*jose.JWK, error) {
 		if err != nil {
 			return nil, fmt.Errorf("buildJWK: failed to build JWK from ecdsa key in IEEE1363 format: %w", err)
 		}
+	case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363:
+		pubKey, e := unmarshalSecp256k1Key(keyBytes)
+		if e != nil {
+			return nil, fmt.Errorf("buildJWK: %w", e)
+		}
+
+		jwk, err = jose.JWKFromKey(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from secp256k1 key: %w", err)
+		}
 	case kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType:
 		jwk, err = generateJWKFromECDH(keyBytes)
 		if err != nil {
@@ -249,6 +268,42 @@ func createBLS12381G2KID(keyBytes []byte) (string, error) {
 	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
 }
 
+// unmarshalSecp256k1Key parses secp256k1 public keys in their uncompressed form, as exported by the KMS for both
+// DER and IEEE-P1363 key types (x509 does not support the secp256k1 curve).
+func unmarshalSecp256k1Key(keyBytes []byte) (*ecdsa.PublicKey, error) {
+	x, y := elliptic.Unmarshal(btcec.S256(), keyBytes)
+	if x == nil || y == nil {
+		return nil, errors.New("invalid secp256k1 key")
+	}
+
+	return &ecdsa.PublicKey{Curve: btcec.S256(), X: x, Y: y}, nil
+}
+
+func createSecp256k1KID(keyBytes []byte) (string, error) {
+	const (
+		secp256k1ThumbprintTemplate = `{"crv":"secp256k1","kty":"EC","x":"%s","y":"%s"}`
+		secp256k1CoordinateSize     = 32
+	)
+
+	pubKey, err := unmarshalSecp256k1Key(keyBytes)
+	if err != nil {
+		return "", fmt.Errorf("createSecp256k1KID: %w", err)
+	}
+
+	x := make([]byte, secp256k1CoordinateSize)
+	y := make([]byte, secp256k1CoordinateSize)
+
+	pubKey.X.FillBytes(x)
+	pubKey.Y.FillBytes(y)
+
+	jwk := fmt.Sprintf(secp256k1ThumbprintTemplate, base64.RawURLEncoding.EncodeToString(x),
+		base64.RawURLEncoding.EncodeToString(y))
+
+	thumbprint := sha256Sum(jwk)
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
 func sha256Sum(jwk string) []byte {
 	h := crypto.SHA256.New()
 	_, _ = h.Write([]byte(jwk)) // SHA256 digest returns empty error on Write()
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	"github.com/stretchr/testify/require"
 
@@ -226,3 +227,26 @@ func TestCreateBLS12381G2KID(t *testing.T) {
 	_, err = CreateKID(append(pubKeyBytes, []byte("larger key")...), kms.BLS12381G2Type)
 	require.EqualError(t, err, "createKID: invalid BBS+ key")
 }
+
+func TestCreateSecp256k1KID(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	pubKeyBytes := elliptic.Marshal(btcec.S256(), privKey.X, privKey.Y)
+
+	kid, err := CreateKID(pubKeyBytes, kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+	require.NotEmpty(t, kid)
+
+	derKID, err := CreateKID(pubKeyBytes, kms.ECDSASecp256k1TypeDER)
+	require.NoError(t, err)
+	require.Equal(t, kid, derKID)
+
+	jwk, err := BuildJWK(pubKeyBytes, kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+	require.Equal(t, "secp256k1", jwk.Crv)
+	require.Equal(t, "EC", jwk.Kty)
+
+	_, err = CreateKID([]byte("bad key"), kms.ECDSASecp256k1TypeDER)
+	require.EqualError(t, err, "createKID: createSecp256k1KID: invalid secp256k1 key")
+}
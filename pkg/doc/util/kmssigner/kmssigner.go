@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package kmssigner provides the signer of linked data proof suites backed by KMS key handles
+// and the resolution of KMS keys of DID verification methods.
+package kmssigner
+
+import (
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// KMSSigner signs data using the key handle of KMS. It implements the signer of signature suites.
+type KMSSigner struct {
+	keyHandle interface{}
+	crypto    crypto.Crypto
+	multiMsg  bool
+}
+
+// Opt configures KMSSigner.
+type Opt func(s *KMSSigner)
+
+// WithMultiMessage makes the signer sign the data as multiple messages (one per non-empty line)
+// as required by BBS+ signature suites.
+func WithMultiMessage() Opt {
+	return func(s *KMSSigner) {
+		s.multiMsg = true
+	}
+}
+
+// New returns new signer using the given key handle.
+func New(keyHandle interface{}, c crypto.Crypto, opts ...Opt) *KMSSigner {
+	s := &KMSSigner{keyHandle: keyHandle, crypto: c}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// NewKMSSigner returns new signer using the key of KMS with the given ID.
+func NewKMSSigner(keyManager kms.KeyManager, c crypto.Crypto, kid string, opts ...Opt) (*KMSSigner, error) {
+	keyHandle, err := keyManager.Get(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(keyHandle, c, opts...), nil
+}
+
+// Sign signs the data.
+func (s *KMSSigner) Sign(data []byte) ([]byte, error) {
+	if s.multiMsg {
+		return s.crypto.SignMulti(textToLines(string(data)), s.keyHandle)
+	}
+
+	return s.crypto.Sign(data, s.keyHandle)
+}
+
+func textToLines(txt string) [][]byte {
+	lines := strings.Split(txt, "\n")
+	linesBytes := make([][]byte, 0, len(lines))
+
+	for i := range lines {
+		if strings.TrimSpace(lines[i]) != "" {
+			linesBytes = append(linesBytes, []byte(lines[i]))
+		}
+	}
+
+	return linesBytes
+}
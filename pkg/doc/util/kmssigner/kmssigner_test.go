@@ -0,0 +1,193 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kmssigner
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"testing"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+const didID = "did:example:123456789abcdefghi"
+
+func TestKMSSigner_Sign(t *testing.T) {
+	localKMS := createKMS(t)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	t.Run("single message", func(t *testing.T) {
+		kid, pubKey, err := localKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		signer, err := NewKMSSigner(localKMS, tinkCrypto, kid)
+		require.NoError(t, err)
+
+		signature, err := signer.Sign([]byte("test message"))
+		require.NoError(t, err)
+		require.True(t, ed25519.Verify(pubKey, []byte("test message"), signature))
+	})
+
+	t.Run("multiple messages", func(t *testing.T) {
+		kid, _, err := localKMS.CreateAndExportPubKeyBytes(kms.BLS12381G2Type)
+		require.NoError(t, err)
+
+		signer, err := NewKMSSigner(localKMS, tinkCrypto, kid, WithMultiMessage())
+		require.NoError(t, err)
+
+		signature, err := signer.Sign([]byte("message 1\n\nmessage 2\n"))
+		require.NoError(t, err)
+
+		kh, err := localKMS.Get(kid)
+		require.NoError(t, err)
+
+		pubKH, err := kh.(*keyset.Handle).Public()
+		require.NoError(t, err)
+
+		err = tinkCrypto.VerifyMulti([][]byte{[]byte("message 1"), []byte("message 2")}, signature, pubKH)
+		require.NoError(t, err)
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		_, err := NewKMSSigner(localKMS, tinkCrypto, "unknown")
+		require.Error(t, err)
+	})
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	localKMS := createKMS(t)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	edKID, edPubKey, err := localKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ecKID, ecPubKey, err := localKMS.CreateAndExportPubKeyBytes(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	x, y := elliptic.Unmarshal(elliptic.P256(), ecPubKey)
+
+	ecJWK, err := jose.JWKFromKey(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+	require.NoError(t, err)
+
+	ecVM, err := did.NewVerificationMethodFromJWK(didID+"#jwk-key", "JsonWebKey2020", didID, ecJWK)
+	require.NoError(t, err)
+
+	doc := &did.Doc{
+		ID: didID,
+		VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes(didID+"#key-1", ed25519VerificationKey2018, didID, edPubKey),
+			*ecVM,
+		},
+	}
+
+	vdr := &mockvdr.MockVDRegistry{ResolveValue: doc}
+
+	t.Run("key ID derived from Ed25519 public key", func(t *testing.T) {
+		signer, err := NewResolver(vdr, localKMS, tinkCrypto).Resolve(didID + "#key-1")
+		require.NoError(t, err)
+
+		signature, err := signer.Sign([]byte("test message"))
+		require.NoError(t, err)
+		require.True(t, ed25519.Verify(edPubKey, []byte("test message"), signature))
+	})
+
+	t.Run("key ID derived from JWK thumbprint", func(t *testing.T) {
+		signer, err := NewResolver(vdr, localKMS, tinkCrypto).Resolve(didID + "#jwk-key")
+		require.NoError(t, err)
+		require.NotNil(t, signer)
+
+		kid, err := KID(ecVM)
+		require.NoError(t, err)
+		require.Equal(t, ecKID, kid)
+	})
+
+	t.Run("key ID mapping", func(t *testing.T) {
+		resolver := NewResolver(&mockvdr.MockVDRegistry{ResolveErr: errors.New("not found")}, localKMS, tinkCrypto,
+			WithKIDMapping(map[string]string{"did:example:other#mapped": edKID}))
+
+		signer, err := resolver.Resolve("did:example:other#mapped")
+		require.NoError(t, err)
+		require.NotNil(t, signer)
+	})
+
+	t.Run("signing key lookup", func(t *testing.T) {
+		resolver := NewResolver(nil, localKMS, tinkCrypto, WithSigningKeyLookup(lookupFunc(
+			func(vmID string) (string, error) {
+				if vmID == "did:example:other#lookup" {
+					return edKID, nil
+				}
+
+				return "", errors.New("not linked")
+			})))
+
+		signer, err := resolver.Resolve("did:example:other#lookup")
+		require.NoError(t, err)
+		require.NotNil(t, signer)
+
+		_, err = resolver.Resolve("did:example:other#unknown")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("key ID from fragment", func(t *testing.T) {
+		signer, err := NewResolver(nil, localKMS, tinkCrypto).Resolve("#" + edKID)
+		require.NoError(t, err)
+		require.NotNil(t, signer)
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		_, err := NewResolver(vdr, localKMS, tinkCrypto).Resolve(didID + "#unknown")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+
+		_, err = NewResolver(vdr, localKMS, tinkCrypto).Resolve(didID)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("invalid verification method", func(t *testing.T) {
+		_, err := NewResolver(vdr, localKMS, tinkCrypto).Resolve("invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse verification method")
+	})
+}
+
+func TestKID(t *testing.T) {
+	_, err := KID(&did.VerificationMethod{Type: "X25519KeyAgreementKey2019", Value: []byte{1}})
+	require.EqualError(t, err, "unsupported verification method type: X25519KeyAgreementKey2019")
+}
+
+func createKMS(t *testing.T) *localkms.LocalKMS {
+	t.Helper()
+
+	p := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+
+	k, err := localkms.New("local-lock://custom/master/key/", p)
+	require.NoError(t, err)
+
+	return k
+}
+
+type lookupFunc func(vmID string) (string, error)
+
+func (f lookupFunc) LookupSigningKey(vmID string) (string, error) {
+	return f(vmID)
+}
@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kmssigner
+
+import (
+	gocrypto "crypto"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did/didurl"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+var logger = log.New("aries-framework/doc/util/kmssigner")
+
+// ErrKeyNotFound is returned when no KMS key of the verification method is found.
+var ErrKeyNotFound = errors.New("KMS key of verification method not found")
+
+const (
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	bls12381G2Key2020          = "Bls12381G2Key2020"
+
+	ed25519Crv    = "Ed25519"
+	bls12381G2Crv = "BLS12381G2"
+)
+
+// Resolver finds the KMS key of DID verification method and creates the signer using it.
+//
+// The key ID is looked up in the following order:
+//   - explicit mapping of verification method ID to KMS key ID (see WithKIDMapping);
+//   - signing key lookup, e.g. the store of key links maintained when DIDs are created (see WithSigningKeyLookup);
+//   - KMS key ID derived from the public key of verification method resolved via VDR
+//     (JWK thumbprint, as created by localkms);
+//   - fragment of the verification method ID.
+type Resolver struct {
+	vdr        vdrapi.Registry
+	keyManager kms.KeyManager
+	crypto     crypto.Crypto
+	kidMapping map[string]string
+	lookup     SigningKeyLookup
+}
+
+// SigningKeyLookup finds the KMS key ID of the verification method.
+type SigningKeyLookup interface {
+	LookupSigningKey(verificationMethodID string) (string, error)
+}
+
+// ResolverOpt configures Resolver.
+type ResolverOpt func(r *Resolver)
+
+// WithKIDMapping sets the mapping of verification method IDs (DID URLs) to KMS key IDs.
+func WithKIDMapping(mapping map[string]string) ResolverOpt {
+	return func(r *Resolver) {
+		for vmID, kid := range mapping {
+			r.kidMapping[vmID] = kid
+		}
+	}
+}
+
+// WithSigningKeyLookup sets the lookup of KMS key IDs of verification methods.
+func WithSigningKeyLookup(lookup SigningKeyLookup) ResolverOpt {
+	return func(r *Resolver) {
+		r.lookup = lookup
+	}
+}
+
+// NewResolver returns new resolver of KMS signers.
+func NewResolver(vdr vdrapi.Registry, keyManager kms.KeyManager, c crypto.Crypto, opts ...ResolverOpt) *Resolver {
+	r := &Resolver{
+		vdr:        vdr,
+		keyManager: keyManager,
+		crypto:     c,
+		kidMapping: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Resolve returns the signer using the KMS key of the verification method.
+func (r *Resolver) Resolve(verificationMethod string, opts ...Opt) (*KMSSigner, error) {
+	kids, err := r.candidateKIDs(verificationMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+
+	for _, kid := range kids {
+		keyHandle, err := r.keyManager.Get(kid)
+		if err != nil {
+			lastErr = err
+
+			continue
+		}
+
+		return New(keyHandle, r.crypto, opts...), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s: %v", ErrKeyNotFound, verificationMethod, lastErr)
+}
+
+func (r *Resolver) candidateKIDs(verificationMethod string) ([]string, error) {
+	vmURL, err := didurl.Parse(verificationMethod)
+	if err != nil {
+		return nil, fmt.Errorf("parse verification method: %w", err)
+	}
+
+	var kids []string
+
+	add := func(kid string) {
+		if kid == "" {
+			return
+		}
+
+		for _, k := range kids {
+			if k == kid {
+				return
+			}
+		}
+
+		kids = append(kids, kid)
+	}
+
+	add(r.kidMapping[verificationMethod])
+
+	if r.lookup != nil {
+		kid, e := r.lookup.LookupSigningKey(verificationMethod)
+		if e != nil {
+			logger.Debugf("KMS key ID of verification method %s is not found: %s", verificationMethod, e)
+		}
+
+		add(kid)
+	}
+
+	if !vmURL.IsRelative() && r.vdr != nil {
+		kid, e := r.thumbprintKID(vmURL.DID, verificationMethod)
+		if e != nil {
+			logger.Debugf("KMS key ID of verification method %s is not derived: %s", verificationMethod, e)
+		}
+
+		add(kid)
+	}
+
+	add(vmURL.Fragment)
+
+	if len(kids) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, verificationMethod)
+	}
+
+	return kids, nil
+}
+
+func (r *Resolver) thumbprintKID(didID, verificationMethod string) (string, error) {
+	docResolution, err := r.vdr.Resolve(didID)
+	if err != nil {
+		return "", fmt.Errorf("resolve DID: %w", err)
+	}
+
+	vm, err := docResolution.DIDDocument.DereferenceVerificationMethod(verificationMethod)
+	if err != nil {
+		return "", err
+	}
+
+	return KID(vm)
+}
+
+// KID returns the KMS key ID of the verification method public key as created by localkms (JWK thumbprint).
+func KID(vm *did.VerificationMethod) (string, error) {
+	if jwk := vm.JSONWebKey(); jwk != nil {
+		return jwkKID(jwk)
+	}
+
+	switch vm.Type {
+	case ed25519VerificationKey2018, ed25519VerificationKey2020:
+		return jwkkid.CreateKID(vm.Value, kms.ED25519Type)
+	case bls12381G2Key2020:
+		return jwkkid.CreateKID(vm.Value, kms.BLS12381G2Type)
+	default:
+		return "", fmt.Errorf("unsupported verification method type: %s", vm.Type)
+	}
+}
+
+func jwkKID(jwk *jose.JWK) (string, error) {
+	switch jwk.Crv {
+	case ed25519Crv, bls12381G2Crv:
+		keyBytes, err := jwk.PublicKeyBytes()
+		if err != nil {
+			return "", fmt.Errorf("get public key bytes of JWK: %w", err)
+		}
+
+		kt := kms.ED25519Type
+		if jwk.Crv == bls12381G2Crv {
+			kt = kms.BLS12381G2Type
+		}
+
+		return jwkkid.CreateKID(keyBytes, kt)
+	default:
+		tp, err := jwk.Thumbprint(gocrypto.SHA256)
+		if err != nil {
+			return "", fmt.Errorf("get JWK thumbprint: %w", err)
+		}
+
+		return base64.RawURLEncoding.EncodeToString(tp), nil
+	}
+}
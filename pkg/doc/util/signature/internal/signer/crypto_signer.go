@@ -14,6 +14,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
+
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 )
@@ -116,6 +118,16 @@ func getPublicKey(keyType kmsapi.KeyType, pubKeyBytes []byte) (interface{}, erro
 			Y:     y,
 		}, nil
 
+	case kmsapi.ECDSASecp256k1TypeDER, kmsapi.ECDSASecp256k1TypeIEEEP1363:
+		// the KMS exports secp256k1 keys of both types in their uncompressed form
+		x, y := elliptic.Unmarshal(btcec.S256(), pubKeyBytes)
+
+		return &ecdsa.PublicKey{
+			Curve: btcec.S256(),
+			X:     x,
+			Y:     y,
+		}, nil
+
 	case kmsapi.ED25519Type:
 		return ed25519.PublicKey(pubKeyBytes), nil
 
@@ -43,6 +43,8 @@ func TestNewCryptoSigner(t *testing.T) {
 		{kmsapi.ECDSAP256TypeIEEEP1363, &ecdsa.PublicKey{}},
 		{kmsapi.ECDSAP384TypeIEEEP1363, &ecdsa.PublicKey{}},
 		{kmsapi.ECDSAP521TypeIEEEP1363, &ecdsa.PublicKey{}},
+		{kmsapi.ECDSASecp256k1TypeDER, &ecdsa.PublicKey{}},
+		{kmsapi.ECDSASecp256k1TypeIEEEP1363, &ecdsa.PublicKey{}},
 	}
 
 	for _, test := range tests {
@@ -10,15 +10,16 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
-	"crypto/rand"
 	"errors"
 
 	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 )
 
 // NewECDSAP256Signer creates a new ECDSA P256 signer with generated key.
 func NewECDSAP256Signer() (*ECDSASigner, error) {
-	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), random.Reader())
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +34,7 @@ func GetECDSAP256Signer(privKey *ecdsa.PrivateKey) *ECDSASigner {
 
 // NewECDSAP384Signer creates a new ECDSA P384 signer with generated key.
 func NewECDSAP384Signer() (*ECDSASigner, error) {
-	privKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	privKey, err := ecdsa.GenerateKey(elliptic.P384(), random.Reader())
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +49,7 @@ func GetECDSAP384Signer(privKey *ecdsa.PrivateKey) *ECDSASigner {
 
 // NewECDSAP521Signer creates a new ECDSA P521 signer with generated key.
 func NewECDSAP521Signer() (*ECDSASigner, error) {
-	privKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	privKey, err := ecdsa.GenerateKey(elliptic.P521(), random.Reader())
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +64,7 @@ func GetECDSAP521Signer(privKey *ecdsa.PrivateKey) *ECDSASigner {
 
 // NewECDSASecp256k1Signer creates a new ECDSA Secp256k1 signer with generated key.
 func NewECDSASecp256k1Signer() (*ECDSASigner, error) {
-	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), random.Reader())
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +135,7 @@ func signEcdsa(msg []byte, privateKey *ecdsa.PrivateKey, hash crypto.Hash) ([]by
 	_, _ = hasher.Write(msg)
 	hashed := hasher.Sum(nil)
 
-	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hashed)
+	r, s, err := ecdsa.Sign(random.Reader(), privateKey, hashed)
 	if err != nil {
 		return nil, err
 	}
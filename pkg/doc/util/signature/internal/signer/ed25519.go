@@ -8,13 +8,14 @@ package signer
 
 import (
 	"crypto/ed25519"
-	"crypto/rand"
 	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 )
 
 // NewEd25519Signer creates a new Ed25519 signer with generated key.
 func NewEd25519Signer() (*Ed25519Signer, error) {
-	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	pubKey, privKey, err := ed25519.GenerateKey(random.Reader())
 	if err != nil {
 		return nil, err
 	}
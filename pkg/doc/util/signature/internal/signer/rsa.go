@@ -8,14 +8,15 @@ package signer
 
 import (
 	"crypto"
-	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 )
 
 // NewRS256Signer creates a new RS256 signer with generated key.
 func NewRS256Signer() (*RS256Signer, error) {
-	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	privKey, err := rsa.GenerateKey(random.Reader(), 2048)
 	if err != nil {
 		return nil, err
 	}
@@ -43,12 +44,12 @@ func (s *RS256Signer) Sign(msg []byte) ([]byte, error) {
 	_, _ = hasher.Write(msg)
 	hashed := hasher.Sum(nil)
 
-	return rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, hashed)
+	return rsa.SignPKCS1v15(random.Reader(), s.privateKey, crypto.SHA256, hashed)
 }
 
 // NewPS256Signer creates a new PS256 signer with generated key.
 func NewPS256Signer() (*PS256Signer, error) {
-	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	privKey, err := rsa.GenerateKey(random.Reader(), 2048)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +79,7 @@ func (s *PS256Signer) Sign(msg []byte) ([]byte, error) {
 
 	hashed := hasher.Sum(nil)
 
-	return rsa.SignPSS(rand.Reader, s.privateKey, crypto.SHA256, hashed, &rsa.PSSOptions{
+	return rsa.SignPSS(random.Reader(), s.privateKey, crypto.SHA256, hashed, &rsa.PSSOptions{
 		SaltLength: rsa.PSSSaltLengthEqualsHash,
 	})
 }
@@ -21,13 +21,10 @@ func NewCryptoSigner(crypto cryptoapi.Crypto, kms kmsapi.KeyManager, keyType kms
 	case kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP256TypeIEEEP1363,
 		kmsapi.ECDSAP384TypeDER, kmsapi.ECDSAP384TypeIEEEP1363,
 		kmsapi.ECDSAP521TypeDER, kmsapi.ECDSAP521TypeIEEEP1363,
+		kmsapi.ECDSASecp256k1TypeDER, kmsapi.ECDSASecp256k1TypeIEEEP1363,
 		kmsapi.ED25519Type:
 		return signer.NewCryptoSigner(crypto, kms, keyType)
 
-	case kmsapi.ECDSASecp256k1TypeIEEEP1363:
-		// TODO use crypto signer when available (https://github.com/hyperledger/aries-framework-go/issues/1285)
-		return signer.NewECDSASecp256k1Signer()
-
 	case kmsapi.RSARS256Type:
 		return signer.NewRS256Signer()
 
@@ -54,7 +51,7 @@ func NewSigner(keyType kmsapi.KeyType) (Signer, error) {
 	case kmsapi.ECDSAP521TypeDER, kmsapi.ECDSAP521TypeIEEEP1363:
 		return signer.NewECDSAP521Signer()
 
-	case kmsapi.ECDSASecp256k1TypeIEEEP1363:
+	case kmsapi.ECDSASecp256k1TypeDER, kmsapi.ECDSASecp256k1TypeIEEEP1363:
 		return signer.NewECDSASecp256k1Signer()
 
 	case kmsapi.RSARS256Type:
@@ -33,7 +33,8 @@ func TestNewCryptoSigner(t *testing.T) {
 	for _, keyType := range [...]kmsapi.KeyType{
 		kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP384TypeDER, kmsapi.ECDSAP521TypeDER,
 		kmsapi.ECDSAP256TypeIEEEP1363, kmsapi.ECDSAP521TypeIEEEP1363, kmsapi.ED25519Type,
-		kmsapi.ECDSAP384TypeIEEEP1363, kmsapi.ECDSASecp256k1TypeIEEEP1363, kmsapi.RSARS256Type, kmsapi.RSAPS256Type,
+		kmsapi.ECDSAP384TypeIEEEP1363, kmsapi.ECDSASecp256k1TypeIEEEP1363, kmsapi.ECDSASecp256k1TypeDER,
+		kmsapi.RSARS256Type, kmsapi.RSAPS256Type,
 	} {
 		newSigner, signerErr := NewCryptoSigner(tinkCrypto, localKMS, keyType)
 		require.NoError(t, signerErr)
@@ -53,7 +54,8 @@ func TestNewSigner(t *testing.T) {
 	for _, keyType := range [...]kmsapi.KeyType{
 		kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP384TypeDER, kmsapi.ECDSAP521TypeDER,
 		kmsapi.ECDSAP256TypeIEEEP1363, kmsapi.ECDSAP521TypeIEEEP1363, kmsapi.ED25519Type,
-		kmsapi.ECDSAP384TypeIEEEP1363, kmsapi.ECDSASecp256k1TypeIEEEP1363, kmsapi.RSARS256Type, kmsapi.RSAPS256Type,
+		kmsapi.ECDSAP384TypeIEEEP1363, kmsapi.ECDSASecp256k1TypeIEEEP1363, kmsapi.ECDSASecp256k1TypeDER,
+		kmsapi.RSARS256Type, kmsapi.RSAPS256Type,
 	} {
 		newSigner, signerErr := NewSigner(keyType)
 		require.NoError(t, signerErr)
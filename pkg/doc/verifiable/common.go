@@ -18,11 +18,15 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
@@ -52,9 +56,75 @@ func (ja JWSAlgorithm) name() (string, error) {
 }
 
 type jsonldCredentialOpts struct {
-	jsonldDocumentLoader ld.DocumentLoader
-	externalContext      []string
-	jsonldOnlyValidRDF   bool
+	jsonldDocumentLoader  ld.DocumentLoader
+	externalContext       []string
+	jsonldOnlyValidRDF    bool
+	canonicalizationCache jsonld.CanonicalizationCache
+	undefinedTermsMode    UndefinedTermsMode
+}
+
+// validityPeriodOpts holds options for the check of validity period of VC or VP.
+// The validity period is checked only if the clock or leeway is defined explicitly.
+type validityPeriodOpts struct {
+	checkValidityPeriod bool
+	currentTime         func() time.Time
+	leeway              time.Duration
+	allowExpired        bool
+}
+
+func (o *validityPeriodOpts) setCurrentTime(currentTime func() time.Time) {
+	o.checkValidityPeriod = true
+	o.currentTime = currentTime
+}
+
+func (o *validityPeriodOpts) setLeeway(leeway time.Duration) {
+	o.checkValidityPeriod = true
+	o.leeway = leeway
+}
+
+func (o *validityPeriodOpts) now() time.Time {
+	if o.currentTime == nil {
+		return time.Now()
+	}
+
+	return o.currentTime()
+}
+
+func (o *validityPeriodOpts) jwtClaimsCheckOpts() []jwt.ClaimsCheckOpt {
+	return []jwt.ClaimsCheckOpt{
+		jwt.WithCurrentTime(o.now),
+		jwt.WithLeeway(o.leeway),
+	}
+}
+
+func checkValidityPeriod(issued, expired *util.TimeWithTrailingZeroMsec, opts *validityPeriodOpts) error {
+	if !opts.checkValidityPeriod {
+		return nil
+	}
+
+	now := opts.now()
+
+	if issued != nil && issued.Time.After(now.Add(opts.leeway)) {
+		return &ValidityPeriodError{Kind: ErrCredentialNotValidYet, Date: issued.Time, CurrentTime: now}
+	}
+
+	if !opts.allowExpired && expired != nil && now.After(expired.Time.Add(opts.leeway)) {
+		return &ValidityPeriodError{Kind: ErrCredentialExpired, Date: expired.Time, CurrentTime: now}
+	}
+
+	return nil
+}
+
+// jwtClaimsValidityError maps the error of time-based JWT claims check to ValidityPeriodError.
+func jwtClaimsValidityError(err error, now time.Time) error {
+	switch {
+	case errors.Is(err, jwt.ErrExpired):
+		return &ValidityPeriodError{Kind: ErrCredentialExpired, CurrentTime: now, Err: err}
+	case errors.Is(err, jwt.ErrNotValidYet), errors.Is(err, jwt.ErrIssuedInTheFuture):
+		return &ValidityPeriodError{Kind: ErrCredentialNotValidYet, CurrentTime: now, Err: err}
+	default:
+		return err
+	}
 }
 
 // PublicKeyFetcher fetches public key for JWT signing verification based on Issuer ID (possibly DID)
@@ -170,13 +240,13 @@ func newTypedID(v interface{}) (TypedID, error) {
 	return tid, err
 }
 
-func describeSchemaValidationError(result *gojsonschema.Result, what string) string {
-	errMsg := what + " is not valid:\n"
+func describeSchemaValidationError(result *gojsonschema.Result, what string) error {
+	violations := make([]string, 0, len(result.Errors()))
 	for _, desc := range result.Errors() {
-		errMsg += fmt.Sprintf("- %s\n", desc)
+		violations = append(violations, desc.String())
 	}
 
-	return errMsg
+	return &SchemaValidationError{Subject: what, Violations: violations}
 }
 
 func stringSlice(values []interface{}) ([]string, error) {
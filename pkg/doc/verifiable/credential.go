@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -13,6 +14,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/piprate/json-gold/ld"
@@ -20,8 +22,11 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/x509chain"
 )
 
 var logger = log.New("aries-framework/doc/verifiable")
@@ -298,6 +303,7 @@ type CredentialSchemaLoader struct {
 	schemaDownloadClient *http.Client
 	cache                SchemaCache
 	jsonLoader           gojsonschema.JSONLoader
+	didResourceLoader    func(didURL string) ([]byte, error)
 }
 
 // CredentialSchemaLoaderBuilder defines a builder of CredentialSchemaLoader.
@@ -330,6 +336,14 @@ func (b *CredentialSchemaLoaderBuilder) SetJSONLoader(loader gojsonschema.JSONLo
 	return b
 }
 
+// SetDIDResourceLoader defines the loader of the schemas identified by a DID URL (e.g. a DID-Linked Resource of
+// did:cheqd), such schemas are not downloaded with the HTTP client.
+func (b *CredentialSchemaLoaderBuilder) SetDIDResourceLoader(
+	loader func(didURL string) ([]byte, error)) *CredentialSchemaLoaderBuilder {
+	b.loader.didResourceLoader = loader
+	return b
+}
+
 // Build constructed CredentialSchemaLoader.
 // It creates default HTTP client and JSON schema loader if not defined.
 func (b *CredentialSchemaLoaderBuilder) Build() *CredentialSchemaLoader {
@@ -510,6 +524,15 @@ type Credential struct {
 	RefreshService []TypedID
 
 	CustomFields CustomFields
+
+	// UndefinedTerms are the JSON paths of the terms undefined in the JSON-LD context of the decoded credential,
+	// reported when decoding with WithUndefinedTerms option.
+	UndefinedTerms []string
+
+	// originalJWT is the JWS of the credential decoded from a VC-JWT with WithOriginalJWT option, originalJSON
+	// is the JSON of the decoded credential telling whether it was changed since.
+	originalJWT  string
+	originalJSON []byte
 }
 
 // rawCredential is a basic verifiable credential.
@@ -574,8 +597,15 @@ type credentialOpts struct {
 	disabledProofCheck    bool
 	strictValidation      bool
 	ldpSuites             []verifier.SignatureSuite
+	trustRegistry         trustregistry.Registry
+	statusChecker         StatusChecker
+	jwtTypes              []string
+	x509Validator         *x509chain.Validator
+	timestampVerifier     TimestampVerifier
+	originalJWT           bool
 
 	jsonldCredentialOpts
+	validityPeriodOpts
 }
 
 // CredentialOpt is the Verifiable Credential decoding option.
@@ -588,6 +618,32 @@ func WithDisabledProofCheck() CredentialOpt {
 	}
 }
 
+// WithOriginalJWT option keeps the JWS of the Verifiable Credential decoded from a VC-JWT, so that it can be
+// stored and presented as issued (see Credential.MarshalOriginal).
+func WithOriginalJWT() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.originalJWT = true
+	}
+}
+
+// WithExpectedJWTType option requires the Verifiable Credential decoded from JWS to have one of the given "typ"
+// headers (e.g. "vc+jwt").
+func WithExpectedJWTType(types ...string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.jwtTypes = types
+	}
+}
+
+// WithX509ChainValidator option verifies the JWS with an "x5c" header, e.g. of the did:x509 issuers, with the key of
+// the certificate chain once the chain is validated against the trust anchors of the validator and is bound to
+// the issuer (the did:x509 DID or a SAN URI of the certificate). The JWS without "x5c" header are verified with
+// the keys of the public key fetcher.
+func WithX509ChainValidator(validator *x509chain.Validator) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.x509Validator = validator
+	}
+}
+
 // WithNoCustomSchemaCheck option is for disabling of Credential Schemas download if defined
 // in Verifiable Credential. Instead, the Verifiable Credential is checked against default Schema.
 func WithNoCustomSchemaCheck() CredentialOpt {
@@ -669,6 +725,14 @@ func WithStrictValidation() CredentialOpt {
 	}
 }
 
+// WithUndefinedTerms defines how the terms undefined in the JSON-LD context of VC are handled (see
+// UndefinedTermsMode). The undefined terms are reported in Credential.UndefinedTerms.
+func WithUndefinedTerms(mode UndefinedTermsMode) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.undefinedTermsMode = mode
+	}
+}
+
 // WithExternalJSONLDContext defines external JSON-LD contexts to be used in JSON-LD validation and
 // Linked Data Signatures verification.
 func WithExternalJSONLDContext(context ...string) CredentialOpt {
@@ -685,6 +749,14 @@ func WithJSONLDOnlyValidRDF() CredentialOpt {
 	}
 }
 
+// WithJSONLDCanonicalizationCache defines a cache of canonical JSON-LD documents used when verifying
+// linked data signatures of verifiable credential. It avoids canonicalizing identical credentials again.
+func WithJSONLDCanonicalizationCache(cache jsonld.CanonicalizationCache) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.canonicalizationCache = cache
+	}
+}
+
 // WithEmbeddedSignatureSuites defines the suites which are used to check embedded linked data proof of VC.
 func WithEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) CredentialOpt {
 	return func(opts *credentialOpts) {
@@ -692,6 +764,49 @@ func WithEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) CredentialOp
 	}
 }
 
+// WithCurrentTime defines the clock the validity period of VC (issuance and expiration dates) is checked against.
+// The validity period is not checked unless this option or WithLeeway is defined.
+func WithCurrentTime(currentTime func() time.Time) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.setCurrentTime(currentTime)
+	}
+}
+
+// WithLeeway defines the tolerated clock skew when checking the validity period of VC.
+// The validity period is not checked unless this option or WithCurrentTime is defined.
+func WithLeeway(leeway time.Duration) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.setLeeway(leeway)
+	}
+}
+
+// WithExpiredCredentialsAllowed disables the rejection of expired VC when checking its validity period.
+// It is intended for audit tooling which has to process historical credentials.
+func WithExpiredCredentialsAllowed() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.allowExpired = true
+	}
+}
+
+// WithTrustRegistry sets the trust registry consulted to check that the issuer of VC is accredited
+// and is allowed to issue credentials of VC types.
+func WithTrustRegistry(registry trustregistry.Registry) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.trustRegistry = registry
+	}
+}
+
+// StatusChecker checks the status of VC defined by its credentialStatus (e.g. in the revocation list).
+// It returns an error wrapping ErrCredentialRevoked if VC is revoked.
+type StatusChecker func(status *TypedID) error
+
+// WithStatusChecker sets the checker of VC status. The status is checked only if VC defines credentialStatus.
+func WithStatusChecker(checker StatusChecker) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.statusChecker = checker
+	}
+}
+
 // parseIssuer parses raw issuer.
 //
 // Issuer can be defined by:
@@ -794,6 +909,15 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		return nil, fmt.Errorf("decode new credential: %w", err)
 	}
 
+	var undefinedTerms []string
+
+	if vcOpts.undefinedTermsMode != 0 {
+		undefinedTerms, vcDataDecoded, err = checkUndefinedTerms(vcDataDecoded, &vcOpts.jsonldCredentialOpts)
+		if err != nil {
+			return nil, fmt.Errorf("check undefined terms of new credential: %w", err)
+		}
+	}
+
 	// Unmarshal raw credential from JSON.
 	var raw rawCredential
 
@@ -808,14 +932,60 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		return nil, fmt.Errorf("build new credential: %w", err)
 	}
 
+	vc.UndefinedTerms = undefinedTerms
+
 	err = validateCredential(vc, vcDataDecoded, vcOpts)
 	if err != nil {
 		return nil, err
 	}
 
+	err = checkValidityPeriod(vc.Issued, vc.Expired, &vcOpts.validityPeriodOpts)
+	if err != nil {
+		return nil, fmt.Errorf("check validity period: %w", err)
+	}
+
+	if vcOpts.timestampVerifier != nil {
+		err = checkTimestampEvidence(vc, vcDataDecoded, vcOpts)
+		if err != nil {
+			return nil, fmt.Errorf("check timestamp evidence: %w", err)
+		}
+	}
+
+	if vcOpts.trustRegistry != nil {
+		err = trustregistry.CheckIssuer(vcOpts.trustRegistry, vc.Issuer.ID, vc.Types)
+		if err != nil {
+			return nil, fmt.Errorf("check issuer in trust registry: %w", err)
+		}
+	}
+
+	if vcOpts.statusChecker != nil && vc.Status != nil {
+		err = vcOpts.statusChecker(vc.Status)
+		if err != nil {
+			return nil, fmt.Errorf("check credential status: %w", err)
+		}
+	}
+
+	if vcOpts.originalJWT && jwt.IsJWS(string(vcData)) {
+		if err = vc.keepOriginalJWT(string(vcData)); err != nil {
+			return nil, err
+		}
+	}
+
 	return vc, nil
 }
 
+// keepOriginalJWT keeps the JWS the credential is decoded from, with the JSON of the decoded credential.
+func (vc *Credential) keepOriginalJWT(vcJWT string) error {
+	vcJSON, err := vc.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("keep original JWT: %w", err)
+	}
+
+	vc.originalJWT, vc.originalJSON = vcJWT, vcJSON
+
+	return nil
+}
+
 func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts) error {
 	// Credential and type constraint.
 	switch vcOpts.modelValidationMode {
@@ -1009,11 +1179,12 @@ func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, error) {
 	vcStr := string(vcData)
 
 	if jwt.IsJWS(vcStr) { // External proof, is checked by JWS.
-		if vcOpts.publicKeyFetcher == nil && !vcOpts.disabledProofCheck {
+		if vcOpts.publicKeyFetcher == nil && vcOpts.x509Validator == nil && !vcOpts.disabledProofCheck {
 			return nil, errors.New("public key fetcher is not defined")
 		}
 
-		vcDecodedBytes, err := decodeCredJWS(vcStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher)
+		vcDecodedBytes, err := decodeCredJWS(vcStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher,
+			vcOpts.x509Validator, vcOpts.jwtTypes)
 		if err != nil {
 			return nil, fmt.Errorf("JWS decoding: %w", err)
 		}
@@ -1175,8 +1346,7 @@ func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *cre
 	}
 
 	if !result.Valid() {
-		errMsg := describeSchemaValidationError(result, "verifiable credential")
-		return errors.New(errMsg)
+		return describeSchemaValidationError(result, "verifiable credential")
 	}
 
 	return nil
@@ -1214,7 +1384,7 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 	cache := loader.cache
 
 	if cache == nil {
-		return loadJSONSchema(url, loader.schemaDownloadClient)
+		return loader.load(url)
 	}
 
 	// Check the cache first.
@@ -1222,7 +1392,7 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 		return cachedBytes, nil
 	}
 
-	schemaBytes, err := loadJSONSchema(url, loader.schemaDownloadClient)
+	schemaBytes, err := loader.load(url)
 	if err != nil {
 		return nil, err
 	}
@@ -1233,6 +1403,19 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 	return schemaBytes, nil
 }
 
+func (l *CredentialSchemaLoader) load(url string) ([]byte, error) {
+	if l.didResourceLoader != nil && strings.HasPrefix(url, "did:") {
+		schemaBytes, err := l.didResourceLoader(url)
+		if err != nil {
+			return nil, fmt.Errorf("load credential schema: %w", err)
+		}
+
+		return schemaBytes, nil
+	}
+
+	return loadJSONSchema(url, l.schemaDownloadClient)
+}
+
 func loadJSONSchema(url string, client *http.Client) ([]byte, error) {
 	resp, err := client.Get(url)
 	if err != nil {
@@ -1414,6 +1597,21 @@ func typedIDsToRaw(typedIDs []TypedID) ([]byte, error) {
 	}
 }
 
+// MarshalOriginal returns the original JWT of the credential decoded from a VC-JWT with WithOriginalJWT option,
+// its JSON otherwise. The JSON is returned as well once the credential is changed, as the JWT no longer matches.
+func (vc *Credential) MarshalOriginal() ([]byte, error) {
+	vcJSON, err := vc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if vc.originalJWT != "" && bytes.Equal(vcJSON, vc.originalJSON) {
+		return []byte(vc.originalJWT), nil
+	}
+
+	return vcJSON, nil
+}
+
 // MarshalJSON converts Verifiable Credential to JSON bytes.
 func (vc *Credential) MarshalJSON() ([]byte, error) {
 	raw, err := vc.raw()
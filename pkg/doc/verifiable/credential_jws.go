@@ -5,15 +5,19 @@ SPDX-License-Identifier: Apache-2.0
 
 package verifiable
 
-// MarshalJWS serializes JWT into signed form (JWS).
-func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	return marshalJWS(jcc, signatureAlg, signer, keyID)
+import "github.com/hyperledger/aries-framework-go/pkg/doc/x509chain"
+
+// MarshalJWS serializes JWT into signed form (JWS), the options customize the JWS headers.
+func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	opts ...JWSOpt) (string, error) {
+	return marshalJWS(jcc, signatureAlg, signer, keyID, opts...)
 }
 
-func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher) (*JWTCredClaims, error) {
+func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher,
+	x509Validator *x509chain.Validator, expectedTypes []string) (*JWTCredClaims, error) {
 	var claims JWTCredClaims
 
-	err := unmarshalJWS(rawJwt, checkProof, fetcher, &claims)
+	err := unmarshalJWS(rawJwt, checkProof, fetcher, x509Validator, expectedTypes, &claims)
 	if err != nil {
 		return nil, err
 	}
@@ -21,8 +25,9 @@ func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher
 	return &claims, err
 }
 
-func decodeCredJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher) ([]byte, error) {
+func decodeCredJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher,
+	x509Validator *x509chain.Validator, expectedTypes []string) ([]byte, error) {
 	return decodeCredJWT(rawJwt, func(vcJWTBytes string) (*JWTCredClaims, error) {
-		return unmarshalJWSClaims(rawJwt, checkProof, fetcher)
+		return unmarshalJWSClaims(rawJwt, checkProof, fetcher, x509Validator, expectedTypes)
 	})
 }
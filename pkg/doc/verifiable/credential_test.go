@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -873,6 +875,99 @@ func TestWithEmbeddedSignatureSuites(t *testing.T) {
 	require.Equal(t, []verifier.SignatureSuite{ss}, opts.ldpSuites)
 }
 
+func TestParseCredential_ValidityPeriod(t *testing.T) {
+	// validCredential is issued on 2010-01-01T19:23:24Z and expires on 2020-01-01T19:23:24Z.
+	issued := time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC)
+	expired := time.Date(2020, 1, 1, 19, 23, 24, 0, time.UTC)
+
+	clock := func(t time.Time) func() time.Time {
+		return func() time.Time { return t }
+	}
+
+	t.Run("validity period is not checked by default", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("valid credential", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential), WithCurrentTime(clock(issued.AddDate(1, 0, 0))))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("expired credential", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential), WithCurrentTime(clock(expired.Add(time.Minute))))
+		require.ErrorIs(t, err, ErrCredentialExpired)
+		require.Nil(t, vc)
+
+		vc, err = parseTestCredential([]byte(validCredential), WithLeeway(0))
+		require.ErrorIs(t, err, ErrCredentialExpired)
+		require.Nil(t, vc)
+
+		vc, err = parseTestCredential([]byte(validCredential),
+			WithCurrentTime(clock(expired.Add(time.Minute))), WithLeeway(2*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+
+		vc, err = parseTestCredential([]byte(validCredential), WithLeeway(0), WithExpiredCredentialsAllowed())
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("credential not valid yet", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential),
+			WithCurrentTime(clock(issued.Add(-time.Minute))), WithExpiredCredentialsAllowed())
+		require.ErrorIs(t, err, ErrCredentialNotValidYet)
+		require.Nil(t, vc)
+
+		vc, err = parseTestCredential([]byte(validCredential),
+			WithCurrentTime(clock(issued.Add(-time.Minute))), WithLeeway(2*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+}
+
+func TestParseCredential_TrustRegistry(t *testing.T) {
+	registry := trustregistry.RegistryFunc(func(issuerID string) (*trustregistry.IssuerMetadata, error) {
+		switch issuerID {
+		case "did:example:76e12ec712ebc6f1c221ebfeb1f":
+			return &trustregistry.IssuerMetadata{DID: issuerID, Status: trustregistry.StatusAccredited}, nil
+		default:
+			return nil, trustregistry.ErrIssuerNotFound
+		}
+	})
+
+	t.Run("accredited issuer", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential), WithTrustRegistry(registry))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("credential type not allowed", func(t *testing.T) {
+		vcMap := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+		vcMap["type"] = []string{"VerifiableCredential", "UniversityDegreeCredential"}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(vcBytes, WithTrustRegistry(registry))
+		require.ErrorIs(t, err, trustregistry.ErrCredentialTypeNotAllowed)
+		require.Contains(t, err.Error(), "check issuer in trust registry")
+		require.Nil(t, vc)
+	})
+
+	t.Run("unknown issuer", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential), WithTrustRegistry(trustregistry.RegistryFunc(
+			func(string) (*trustregistry.IssuerMetadata, error) {
+				return nil, trustregistry.ErrIssuerNotFound
+			})))
+		require.ErrorIs(t, err, trustregistry.ErrIssuerNotFound)
+		require.Nil(t, vc)
+	})
+}
+
 func TestCustomCredentialJsonSchemaValidator2018(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 		rawMap := make(map[string]interface{})
@@ -1076,6 +1171,29 @@ func TestDownloadCustomSchema(t *testing.T) {
 		require.Contains(t, err.Error(), "credential schema endpoint HTTP failure")
 		require.Nil(t, customSchema)
 	})
+
+	t.Run("Load custom credentialSchema identified by DID URL", func(t *testing.T) {
+		const schemaDIDURL = "did:cheqd:mainnet:zF7rhDBfUt9d1gJPjx7s1JXfUY7oVWkY/resources/schema"
+
+		opts := &credentialOpts{schemaLoader: NewCredentialSchemaLoaderBuilder().
+			SetDIDResourceLoader(func(didURL string) ([]byte, error) {
+				if didURL != schemaDIDURL {
+					return nil, errors.New("resource not found")
+				}
+
+				return []byte("custom schema"), nil
+			}).
+			Build()}
+
+		customSchema, err := getJSONSchema(schemaDIDURL, opts)
+		require.NoError(t, err)
+		require.Equal(t, []byte("custom schema"), customSchema)
+
+		customSchema, err = getJSONSchema(schemaDIDURL+"2", opts)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "load credential schema: resource not found")
+		require.Nil(t, customSchema)
+	})
 }
 
 func Test_SubjectID(t *testing.T) {
@@ -1860,6 +1978,40 @@ func TestParseUnverifiedCredential(t *testing.T) {
 		require.Equal(t, vc, vcUnverified)
 	})
 
+	t.Run("ParseUnverifiedCredential() for JWS with the original JWT", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential))
+		require.NoError(t, err)
+
+		credClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		jws, err := credClaims.MarshalJWS(EdDSA, signer, "any")
+		require.NoError(t, err)
+
+		vcUnverified, err := ParseCredential([]byte(jws),
+			WithJSONLDDocumentLoader(createTestJSONLDDocumentLoader()),
+			WithDisabledProofCheck(),
+			WithOriginalJWT())
+		require.NoError(t, err)
+
+		original, err := vcUnverified.MarshalOriginal()
+		require.NoError(t, err)
+		require.Equal(t, jws, string(original))
+
+		// the credential decoded from JSON has no JWT
+		original, err = vc.MarshalOriginal()
+		require.NoError(t, err)
+		require.True(t, json.Valid(original))
+
+		// the changed credential no longer matches its JWT
+		vcUnverified.ID = "http://example.edu/credentials/changed"
+
+		original, err = vcUnverified.MarshalOriginal()
+		require.NoError(t, err)
+		require.True(t, json.Valid(original))
+		require.Contains(t, string(original), "http://example.edu/credentials/changed")
+	})
+
 	t.Run("ParseUnverifiedCredential() for Linked Data proof", func(t *testing.T) {
 		// Prepare JWS.
 		vc, err := parseTestCredential([]byte(validCredential))
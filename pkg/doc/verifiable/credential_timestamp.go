@@ -0,0 +1,208 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TimestampEvidenceType is the type of the evidence holding the timestamp token of the credential.
+	TimestampEvidenceType = "RFC3161TimestampEvidence"
+	// TimestampEvidenceContextURI is the JSON-LD context defining the timestamp evidence, it is added to
+	// the context of the timestamped credentials.
+	TimestampEvidenceContextURI = "https://trustbloc.github.io/context/vc/timestamp-evidence-v1.jsonld"
+)
+
+const timestampEvidenceJSONLD = `
+{
+  "@context": {
+    "@version": 1.1,
+    "@protected": true,
+    "RFC3161TimestampEvidence": {
+      "@id": "https://trustbloc.github.io/context/vc/timestamp-evidence#RFC3161TimestampEvidence",
+      "@context": {
+        "@version": 1.1,
+        "@protected": true,
+        "id": "@id",
+        "type": "@type",
+        "timestampToken": {
+          "@id": "https://trustbloc.github.io/context/vc/timestamp-evidence#timestampToken",
+          "@type": "http://www.w3.org/2001/XMLSchema#base64Binary"
+        }
+      }
+    }
+  }
+}
+`
+
+// Timestamper obtains a timestamp token over the data from a Time Stamping Authority, e.g. the RFC 3161
+// timestamp.Client.
+type Timestamper interface {
+	Timestamp(data []byte) ([]byte, error)
+}
+
+// TimestampVerifier verifies that the timestamp token timestamps the data and returns the time of the timestamp,
+// e.g. timestamp.NewVerifier.
+type TimestampVerifier func(token, data []byte) (time.Time, error)
+
+// WithTimestampEvidenceCheck requires VC to hold a timestamp evidence, verified by the verifier, proving that VC
+// existed before the time of the timestamp independently of the clock of the issuer. VC must not be issued after
+// the time of its timestamps (with the leeway of WithLeeway).
+func WithTimestampEvidenceCheck(verifier TimestampVerifier) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.timestampVerifier = verifier
+	}
+}
+
+// AddTimestampEvidence obtains a timestamp token over VC from the Time Stamping Authority and adds it to the evidence
+// of VC, whose context is extended with TimestampEvidenceContextURI. It is called before VC is signed: the token
+// covers VC but its proofs and its timestamp evidences.
+func (vc *Credential) AddTimestampEvidence(timestamper Timestamper) error {
+	if !containsContext(vc.Context, TimestampEvidenceContextURI) {
+		vc.Context = append(vc.Context, TimestampEvidenceContextURI)
+	}
+
+	vcBytes, err := vc.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("marshal credential: %w", err)
+	}
+
+	data, err := timestampedData(vcBytes)
+	if err != nil {
+		return err
+	}
+
+	token, err := timestamper.Timestamp(data)
+	if err != nil {
+		return fmt.Errorf("timestamp credential: %w", err)
+	}
+
+	vc.Evidence = append(evidences(vc.Evidence), map[string]interface{}{
+		"id":             "urn:uuid:" + uuid.New().String(),
+		"type":           TimestampEvidenceType,
+		"timestampToken": base64.StdEncoding.EncodeToString(token),
+	})
+
+	return nil
+}
+
+// checkTimestampEvidence verifies the timestamp evidences of VC.
+func checkTimestampEvidence(vc *Credential, vcBytes []byte, opts *credentialOpts) error {
+	data, err := timestampedData(vcBytes)
+	if err != nil {
+		return err
+	}
+
+	var timestamped bool
+
+	for _, evidence := range evidences(vc.Evidence) {
+		if !isTimestampEvidence(evidence) {
+			continue
+		}
+
+		encoded, _ := evidence.(map[string]interface{})["timestampToken"].(string) //nolint:errcheck
+
+		token, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("decode timestamp token: %w", err)
+		}
+
+		genTime, err := opts.timestampVerifier(token, data)
+		if err != nil {
+			return fmt.Errorf("verify timestamp token: %w", err)
+		}
+
+		if vc.Issued != nil && vc.Issued.Time.After(genTime.Add(opts.leeway)) {
+			return fmt.Errorf("credential issued at %s, after its timestamp at %s",
+				vc.Issued.Time.Format(time.RFC3339), genTime.Format(time.RFC3339))
+		}
+
+		timestamped = true
+	}
+
+	if !timestamped {
+		return errors.New("credential has no timestamp evidence")
+	}
+
+	return nil
+}
+
+// timestampedData returns the JSON of VC without its proofs and its timestamp evidences.
+func timestampedData(vcBytes []byte) ([]byte, error) {
+	var vcMap map[string]interface{}
+
+	if err := json.Unmarshal(vcBytes, &vcMap); err != nil {
+		return nil, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	delete(vcMap, "proof")
+
+	var kept []interface{}
+
+	for _, evidence := range evidences(vcMap["evidence"]) {
+		if !isTimestampEvidence(evidence) {
+			kept = append(kept, evidence)
+		}
+	}
+
+	delete(vcMap, "evidence")
+
+	if len(kept) > 0 {
+		vcMap["evidence"] = kept
+	}
+
+	return json.Marshal(vcMap)
+}
+
+// evidences returns the evidence of VC, which is either a single evidence or an array, as an array.
+func evidences(evidence Evidence) []interface{} {
+	switch e := evidence.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return e
+	default:
+		return []interface{}{e}
+	}
+}
+
+func containsContext(contexts []string, uri string) bool {
+	for _, c := range contexts {
+		if c == uri {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTimestampEvidence(evidence interface{}) bool {
+	evidenceMap, ok := evidence.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	switch types := evidenceMap["type"].(type) {
+	case string:
+		return types == TimestampEvidenceType
+	case []interface{}:
+		for _, t := range types {
+			if t == TimestampEvidenceType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCredential_TimestampEvidence(t *testing.T) {
+	genTime := time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// the mock tokens are the hashes of the timestamped data
+	verifier := func(token, data []byte) (time.Time, error) {
+		digest := sha256.Sum256(data)
+		if !bytes.Equal(token, digest[:]) {
+			return time.Time{}, errors.New("token does not timestamp the data")
+		}
+
+		return genTime, nil
+	}
+
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	issue := func(timestamper Timestamper) []byte {
+		vc, err := parseTestCredential([]byte(validCredential), WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		if timestamper != nil {
+			require.NoError(t, vc.AddTimestampEvidence(timestamper))
+		}
+
+		require.NoError(t, vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			SignatureRepresentation: SignatureJWS,
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+		}, jsonld.WithDocumentLoader(createTestJSONLDDocumentLoader())))
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	vcBytes := issue(&mockTimestamper{})
+
+	t.Run("timestamped credential", func(t *testing.T) {
+		vc, err := parseTestCredential(vcBytes,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kmsapi.ED25519)),
+			WithTimestampEvidenceCheck(verifier))
+		require.NoError(t, err)
+		require.Contains(t, vc.Context, TimestampEvidenceContextURI)
+
+		// the timestamp evidence is added to the evidence of the credential
+		evidence, ok := vc.Evidence.([]interface{})
+		require.True(t, ok)
+		require.Len(t, evidence, 3)
+		require.True(t, isTimestampEvidence(evidence[2]))
+	})
+
+	t.Run("credential issued after its timestamp", func(t *testing.T) {
+		genTime = time.Date(2005, time.January, 1, 0, 0, 0, 0, time.UTC)
+		defer func() { genTime = time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC) }()
+
+		_, err := parseTestCredential(vcBytes, WithDisabledProofCheck(), WithTimestampEvidenceCheck(verifier))
+		require.EqualError(t, err, "check timestamp evidence: credential issued at 2010-01-01T19:23:24Z, "+
+			"after its timestamp at 2005-01-01T00:00:00Z")
+	})
+
+	t.Run("altered credential", func(t *testing.T) {
+		altered := bytes.Replace(vcBytes, []byte("Example University"), []byte("Other University"), 1)
+
+		_, err := parseTestCredential(altered, WithDisabledProofCheck(), WithTimestampEvidenceCheck(verifier))
+		require.EqualError(t, err, "check timestamp evidence: verify timestamp token: "+
+			"token does not timestamp the data")
+
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+
+		vcMap["evidence"].([]interface{})[2].(map[string]interface{})["timestampToken"] = "!"
+
+		altered, err = json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(altered, WithDisabledProofCheck(), WithTimestampEvidenceCheck(verifier))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check timestamp evidence: decode timestamp token")
+	})
+
+	t.Run("credential without timestamp evidence", func(t *testing.T) {
+		_, err := parseTestCredential(issue(nil), WithDisabledProofCheck(), WithTimestampEvidenceCheck(verifier))
+		require.EqualError(t, err, "check timestamp evidence: credential has no timestamp evidence")
+	})
+
+	t.Run("timestamp failure", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential), WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		err = vc.AddTimestampEvidence(&mockTimestamper{err: errors.New("TSA error")})
+		require.EqualError(t, err, "timestamp credential: TSA error")
+	})
+}
+
+func TestTimestampedData(t *testing.T) {
+	data, err := timestampedData([]byte(`{"id":"urn:1","proof":{"type":"Ed25519Signature2018"},` +
+		`"evidence":{"id":"urn:2","type":"RFC3161TimestampEvidence"}}`))
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"urn:1"}`, string(data))
+
+	data, err = timestampedData([]byte(`{"id":"urn:1","evidence":[{"id":"urn:2","type":["Evidence"]},` +
+		`{"id":"urn:3","type":["RFC3161TimestampEvidence"]}]}`))
+	require.NoError(t, err)
+	require.Equal(t, `{"evidence":[{"id":"urn:2","type":["Evidence"]}],"id":"urn:1"}`, string(data))
+
+	_, err = timestampedData([]byte("[]"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unmarshal credential")
+}
+
+type mockTimestamper struct {
+	err error
+}
+
+func (m *mockTimestamper) Timestamp(data []byte) ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	digest := sha256.Sum256(data)
+
+	return digest[:], nil
+}
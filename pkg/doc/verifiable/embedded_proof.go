@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
@@ -32,7 +33,7 @@ const (
 func getProofType(proofMap map[string]interface{}) (string, error) {
 	proofType, ok := proofMap["type"]
 	if !ok {
-		return "", errors.New("proof type is missing")
+		return "", &ProofError{Err: errors.New("proof type is missing")}
 	}
 
 	proofTypeStr := safeStringValue(proofType)
@@ -41,7 +42,7 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 		bbsBlsSignature2020, bbsBlsSignatureProof2020:
 		return proofTypeStr, nil
 	default:
-		return "", fmt.Errorf("unsupported proof type: %s", proofType)
+		return "", &UnsupportedProofTypeError{ProofType: proofTypeStr}
 	}
 }
 
@@ -73,7 +74,7 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte,
 
 	proofs, err := getProofs(proofElement)
 	if err != nil {
-		return nil, fmt.Errorf("check embedded proof: %w", err)
+		return nil, &ProofError{Err: fmt.Errorf("check embedded proof: %w", err)}
 	}
 
 	ldpSuites, err := getSuites(proofs, opts)
@@ -95,7 +96,7 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte,
 
 	err = checkLinkedDataProof(checkedDoc, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts)
 	if err != nil {
-		return nil, fmt.Errorf("check embedded proof: %w", err)
+		return nil, &ProofError{ProofType: proofTypes(proofs), Err: fmt.Errorf("check embedded proof: %w", err)}
 	}
 
 	return docBytes, nil
@@ -139,6 +140,16 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 	return ldpSuites, nil
 }
 
+func proofTypes(proofs []map[string]interface{}) string {
+	types := make([]string, len(proofs))
+
+	for i := range proofs {
+		types[i] = safeStringValue(proofs[i]["type"])
+	}
+
+	return strings.Join(types, ",")
+}
+
 func getNonce(proof map[string]interface{}) ([]byte, error) {
 	if nonce, ok := proof["nonce"]; ok {
 		n, err := base64.StdEncoding.DecodeString(nonce.(string))
@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sentinel errors of Verifiable Credential and Presentation parsing.
+// Use errors.Is to branch on the failure cause and errors.As with the typed errors below to get the details.
+var (
+	// ErrProofInvalid is returned when the proof (embedded Linked Data proof or JWS) is not valid.
+	// The details are provided by ProofError.
+	ErrProofInvalid = errors.New("proof is invalid")
+
+	// ErrUnsupportedProofType is returned when no signature suite supports the type of embedded proof.
+	// The details are provided by UnsupportedProofTypeError.
+	ErrUnsupportedProofType = errors.New("unsupported proof type")
+
+	// ErrSchemaValidation is returned when the credential or presentation does not conform to its JSON schema.
+	// The details are provided by SchemaValidationError.
+	ErrSchemaValidation = errors.New("schema validation failed")
+
+	// ErrCredentialExpired is returned when the expiration date of Verifiable Credential
+	// (or "exp" claim of JWT Verifiable Presentation) is in the past. The details are provided by ValidityPeriodError.
+	ErrCredentialExpired = errors.New("verifiable credential is expired")
+
+	// ErrCredentialNotValidYet is returned when the issuance date of Verifiable Credential
+	// (or "nbf" claim of JWT Verifiable Presentation) is in the future. The details are provided by ValidityPeriodError.
+	ErrCredentialNotValidYet = errors.New("verifiable credential is not valid yet")
+
+	// ErrCredentialRevoked is returned by StatusChecker when Verifiable Credential is revoked.
+	ErrCredentialRevoked = errors.New("verifiable credential is revoked")
+)
+
+// jwtProofType is the proof type of ProofError for the external JWS proof.
+const jwtProofType = "JWT"
+
+// ProofError describes the invalid proof. It matches ErrProofInvalid.
+type ProofError struct {
+	// ProofType is the type of the proof, e.g. "Ed25519Signature2018", or "JWT" for the external JWS proof.
+	// It is empty when the type of embedded proof is unknown (e.g. malformed proof).
+	ProofType string
+	// Err is the cause of the failure.
+	Err error
+}
+
+// Error returns the error message.
+func (e *ProofError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *ProofError) Unwrap() error {
+	return e.Err
+}
+
+// Is checks whether the target is ErrProofInvalid.
+func (e *ProofError) Is(target error) bool {
+	return target == ErrProofInvalid
+}
+
+// UnsupportedProofTypeError describes the embedded proof of unsupported type. It matches ErrUnsupportedProofType.
+type UnsupportedProofTypeError struct {
+	// ProofType is the unsupported proof type.
+	ProofType string
+}
+
+// Error returns the error message.
+func (e *UnsupportedProofTypeError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnsupportedProofType, e.ProofType)
+}
+
+// Is checks whether the target is ErrUnsupportedProofType.
+func (e *UnsupportedProofTypeError) Is(target error) bool {
+	return target == ErrUnsupportedProofType
+}
+
+// SchemaValidationError describes the JSON schema violations. It matches ErrSchemaValidation.
+type SchemaValidationError struct {
+	// Subject is the validated document, "verifiable credential" or "verifiable presentation".
+	Subject string
+	// Violations are the descriptions of JSON schema violations.
+	Violations []string
+}
+
+// Error returns the error message.
+func (e *SchemaValidationError) Error() string {
+	errMsg := e.Subject + " is not valid:\n"
+	for _, v := range e.Violations {
+		errMsg += fmt.Sprintf("- %s\n", v)
+	}
+
+	return errMsg
+}
+
+// Is checks whether the target is ErrSchemaValidation.
+func (e *SchemaValidationError) Is(target error) bool {
+	return target == ErrSchemaValidation
+}
+
+// ValidityPeriodError describes the violated validity period.
+// It matches either ErrCredentialExpired or ErrCredentialNotValidYet.
+type ValidityPeriodError struct {
+	// Kind is either ErrCredentialExpired or ErrCredentialNotValidYet.
+	Kind error
+	// Date is the violated expiration or issuance date (zero if unknown).
+	Date time.Time
+	// CurrentTime is the time the validity period was checked at.
+	CurrentTime time.Time
+	// Err is the cause of the failure (e.g. the error of JWT claims check), optional.
+	Err error
+}
+
+// Error returns the error message.
+func (e *ValidityPeriodError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s", e.Kind, e.Err)
+	}
+
+	what := "expiration date"
+	if e.Kind == ErrCredentialNotValidYet {
+		what = "issuance date"
+	}
+
+	return fmt.Sprintf("%s %s: %s", what, e.Date.Format(time.RFC3339), e.Kind)
+}
+
+// Unwrap returns the cause of the failure.
+func (e *ValidityPeriodError) Unwrap() error {
+	return e.Err
+}
+
+// Is checks whether the target is the kind of the error.
+func (e *ValidityPeriodError) Is(target error) bool {
+	return target == e.Kind
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestParseCredential_TypedErrors(t *testing.T) {
+	t.Run("invalid linked data proof", func(t *testing.T) {
+		vc, _ := createVCWithLinkedDataProof()
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(vcBytes,
+			WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kms.ED25519)))
+		require.ErrorIs(t, err, ErrProofInvalid)
+
+		var proofErr *ProofError
+
+		require.True(t, errors.As(err, &proofErr))
+		require.Equal(t, "Ed25519Signature2018", proofErr.ProofType)
+		require.Contains(t, err.Error(), "check embedded proof")
+	})
+
+	t.Run("invalid JWS proof", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential([]byte(validCredential))
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		jws, err := jwtClaims.MarshalJWS(EdDSA, signer, "any")
+		require.NoError(t, err)
+
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential([]byte(jws),
+			WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kms.ED25519)))
+		require.ErrorIs(t, err, ErrProofInvalid)
+
+		var proofErr *ProofError
+
+		require.True(t, errors.As(err, &proofErr))
+		require.Equal(t, "JWT", proofErr.ProofType)
+	})
+
+	t.Run("unsupported proof type", func(t *testing.T) {
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		vcMap["proof"] = map[string]interface{}{"type": "UnknownSignature2030"}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(vcBytes, WithPublicKeyFetcher(func(_, _ string) (*verifier.PublicKey, error) {
+			return nil, errors.New("not expected")
+		}))
+		require.ErrorIs(t, err, ErrUnsupportedProofType)
+		require.False(t, errors.Is(err, ErrProofInvalid))
+
+		var typeErr *UnsupportedProofTypeError
+
+		require.True(t, errors.As(err, &typeErr))
+		require.Equal(t, "UnknownSignature2030", typeErr.ProofType)
+		require.EqualError(t, typeErr, "unsupported proof type: UnknownSignature2030")
+	})
+
+	t.Run("schema validation", func(t *testing.T) {
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		delete(vcMap, "issuanceDate")
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(vcBytes)
+		require.ErrorIs(t, err, ErrSchemaValidation)
+
+		var schemaErr *SchemaValidationError
+
+		require.True(t, errors.As(err, &schemaErr))
+		require.Equal(t, "verifiable credential", schemaErr.Subject)
+		require.Len(t, schemaErr.Violations, 1)
+		require.Contains(t, schemaErr.Violations[0], "issuanceDate is required")
+	})
+
+	t.Run("expired credential", func(t *testing.T) {
+		now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		_, err := parseTestCredential([]byte(validCredential), WithCurrentTime(func() time.Time { return now }))
+		require.ErrorIs(t, err, ErrCredentialExpired)
+
+		var validityErr *ValidityPeriodError
+
+		require.True(t, errors.As(err, &validityErr))
+		require.Equal(t, now, validityErr.CurrentTime)
+		require.Equal(t, time.Date(2020, 1, 1, 19, 23, 24, 0, time.UTC), validityErr.Date.UTC())
+		require.Contains(t, err.Error(), "expiration date 2020-01-01T19:23:24Z: verifiable credential is expired")
+	})
+
+	t.Run("revoked credential", func(t *testing.T) {
+		_, err := parseTestCredential([]byte(validCredential), WithStatusChecker(func(status *TypedID) error {
+			return fmt.Errorf("%s: %w", status.ID, ErrCredentialRevoked)
+		}))
+		require.ErrorIs(t, err, ErrCredentialRevoked)
+		require.Contains(t, err.Error(), "check credential status: https://example.edu/status/24")
+	})
+}
@@ -8,13 +8,13 @@ package verifiable_test
 
 import (
 	"crypto/ed25519"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
@@ -383,7 +383,7 @@ func ExampleCredential_AddLinkedDataProof() {
 }
 
 //nolint:govet
-func ExampleCredential_AddLinkedDataProofMultiProofs() {
+func ExampleCredential_AddLinkedDataProof_multiProofs() {
 	log.SetLevel("aries-framework/json-ld-processor", spi.ERROR)
 
 	vc, err := verifiable.ParseCredential([]byte(vcJSON),
@@ -535,6 +535,14 @@ func ExampleCredential_GenerateBBSSelectiveDisclosure() {
 		panic(err)
 	}
 
+	// BBS+ signatures and proofs are randomized, a deterministic source keeps the example output constant.
+	restoreRandom, err := random.SetSource(random.NewDeterministic([]byte("example")))
+	if err != nil {
+		panic(err)
+	}
+
+	defer restoreRandom()
+
 	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
 		Created:                 &issued,
 		SignatureType:           "BbsBlsSignature2020",
@@ -546,10 +554,6 @@ func ExampleCredential_GenerateBBSSelectiveDisclosure() {
 		panic(err)
 	}
 
-	// BBS+ signature is generated each time unique, that's why we substitute it with some constant value
-	// for a reason of keeping constant test output.
-	originalProofValue := hideProofValue(vc.Proofs[1], "dummy signature value")
-
 	vcJSONWithProof, err := json.MarshalIndent(vc, "", "\t")
 	if err != nil {
 		panic(fmt.Errorf("failed to marshal VC to JSON: %w", err))
@@ -557,8 +561,6 @@ func ExampleCredential_GenerateBBSSelectiveDisclosure() {
 
 	fmt.Println(string(vcJSONWithProof))
 
-	restoreProofValue(vc.Proofs[1], originalProofValue)
-
 	// Create BBS+ selective disclosure. We explicitly state the fields we want to reveal in the output document.
 	// For example, "credentialSubject.birthDate" is not mentioned and thus will be hidden.
 	// To hide top-level VC fields, "@explicit": true is used on top level of reveal doc.
@@ -606,8 +608,6 @@ func ExampleCredential_GenerateBBSSelectiveDisclosure() {
 	}
 
 	// Only BBS+ related proof left.
-	hideProofValue(vcWithSelectiveDisclosure.Proofs[0], "dummy signature proof value")
-
 	vcJSONWithProof, err = json.MarshalIndent(vcWithSelectiveDisclosure, "", "\t")
 	if err != nil {
 		panic(fmt.Errorf("failed to marshal VC to JSON: %w", err))
@@ -656,7 +656,7 @@ func ExampleCredential_GenerateBBSSelectiveDisclosure() {
 	//		{
 	//			"created": "2010-01-01T19:23:24Z",
 	//			"proofPurpose": "assertionMethod",
-	//			"proofValue": "ZHVtbXkgc2lnbmF0dXJlIHZhbHVl",
+	//			"proofValue": "rYteHGk5i4b3DnqN5Ai94dEyqfIKORCDiYUwhW67t5n0PSM8uyh4PJzgzJ8TkCkpFp0EHH6hf766z2EQUrPnEznF2vNVXuMnPpWdWV4m8TYazJ8h-YToH00OdVxAqyfLTIGFzvGV71CtzJN_dam8zg",
 	//			"type": "BbsBlsSignature2020",
 	//			"verificationMethod": "did:example:123456#key1"
 	//		}
@@ -691,7 +691,7 @@ func ExampleCredential_GenerateBBSSelectiveDisclosure() {
 	//		"created": "2010-01-01T19:23:24Z",
 	//		"nonce": "c29tZSBub25jZQ==",
 	//		"proofPurpose": "assertionMethod",
-	//		"proofValue": "ZHVtbXkgc2lnbmF0dXJlIHByb29mIHZhbHVl",
+	//		"proofValue": "ABkBugbvi/QA1mKMDwV90VS70nAkO5wytlHCXTq9DMujthfw7V5VmzVom99QuDDj/gP9mR9Xg53p+15ATFyK3yokEKnpkSguucS0WKb95Zjn3RzsJD+AANBkFv488QplI6Y0q3XcrR3AV2MvSEkfQuWI3leD5HWDjdzOaJXkG4OmT9SUZeXgH2IpSFP5a80Gk8+XOq7VAAAAdJXxz5ycw0rxmj1tTf6kLgk4vB7E5V6J4HJufHcAUvgGbAK89w/jwK2S0Dka49+OdwAAAAIXgPaUYDv3KVJf9CRvfHI+iL/3x7UNcuv29ZSqgUQhKwAk1VZ5UyzmK6ugH0Bd7acQXwEGamK8nNw9PF54WGoniRNIJhbrScRYaMsbQ+Q6pIy4iW/Csaed5Um9GZVH0SH5+qoGmIHNghzul566SOjEAAAADBqxLZ60X6ADFs5l4iQ7Y0u7h3ry4pmDtOleieJujExPD9I8X6oksxc58rw7TnwYxK7a9jKC8sfjerOC1ZCIckJuQZ/DOFzsbfx1mvz0fzbfncQGSmVGJLdE9ozENSGNxkqG6eKXSOC/mDoR4jbJAvI5lR5SYR7MLqryvMFlMqRmPdtMcp5L16RI6jSQgD6OxGzdrfkornPNgrQ+Anf1j9dvEYeJetpuGpH75GT94wmRpCLMx97MPS6+NilnQd7b+jYfY/lB7Ktl7u8zfE008iXlwo/mT2+P0IDZfqv16iGFYiS0y6oXaZl8OiyvBqmyQa1MH38NqwfJkWksMc7LVQcWJlb4aldKCpczFxs25BV/lW+jbzcZH6a+8RXX9xYlyDvaUmQv3ZXfHKCfV0UnmdaAfy3A8HVQRuwgKL//gYdpERBUW7I6nkBP8Ol5RE/MBhqutYxLcWBaV/o4kDTm2ghP/ix4vf5/fVYBlPrHtBamagdMqDR43Y3MP0JIUne75w==",
 	//		"type": "BbsBlsSignatureProof2020",
 	//		"verificationMethod": "did:example:123456#key1"
 	//	},
@@ -701,14 +701,3 @@ func ExampleCredential_GenerateBBSSelectiveDisclosure() {
 	//	]
 	//}
 }
-
-func hideProofValue(proof verifiable.Proof, dummyValue string) interface{} {
-	oldProofValue := proof["proofValue"]
-	proof["proofValue"] = base64.StdEncoding.EncodeToString([]byte(dummyValue))
-
-	return oldProofValue
-}
-
-func restoreProofValue(proof verifiable.Proof, proofValue interface{}) {
-	proof["proofValue"] = proofValue
-}
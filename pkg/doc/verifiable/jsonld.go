@@ -6,9 +6,11 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/piprate/json-gold/ld"
@@ -278,9 +280,132 @@ func CachingJSONLDLoader() *jld.CachingDocumentLoader {
 
 	loader.AddDocument(ContextURI, reader)
 
+	reader, err = ld.DocumentFromReader(strings.NewReader(timestampEvidenceJSONLD))
+	if err != nil {
+		panic(err)
+	}
+
+	loader.AddDocument(TimestampEvidenceContextURI, reader)
+
 	return loader
 }
 
+// UndefinedTermsMode defines how the terms undefined in the JSON-LD context of VC or VP are handled when decoding.
+// The undefined terms are dropped by the JSON-LD processing, and so are not covered by linked data proofs.
+type UndefinedTermsMode int
+
+const (
+	// UndefinedTermsDrop drops the undefined terms from the decoded VC or VP.
+	UndefinedTermsDrop UndefinedTermsMode = iota + 1
+
+	// UndefinedTermsError fails the decoding of VC or VP with undefined terms.
+	UndefinedTermsError
+
+	// UndefinedTermsPreserve keeps the undefined terms as custom fields of the decoded VC or VP.
+	UndefinedTermsPreserve
+)
+
+// checkUndefinedTerms finds the terms of the JSON-LD document undefined in its context, i.e. dropped by
+// the compaction of the document. It returns the JSON paths of the undefined terms (e.g. "credentialSubject.degree")
+// and the document, without the undefined terms in UndefinedTermsDrop mode.
+func checkUndefinedTerms(doc []byte, opts *jsonldCredentialOpts) ([]string, []byte, error) {
+	docMap, err := toMap(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("convert JSON-LD doc to map: %w", err)
+	}
+
+	inputMap, err := toMap(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("convert JSON-LD doc to map: %w", err)
+	}
+
+	docCompactedMap, err := jsonld.Default().Compact(inputMap,
+		nil, jsonld.WithDocumentLoader(opts.jsonldDocumentLoader),
+		jsonld.WithExternalContext(opts.externalContext...))
+	if err != nil {
+		return nil, nil, fmt.Errorf("compact JSON-LD document: %w", err)
+	}
+
+	terms := make(map[string]bool)
+	prune := opts.undefinedTermsMode == UndefinedTermsDrop
+
+	collectUndefinedTerms(docMap, docCompactedMap, "", terms, prune)
+
+	if len(terms) == 0 {
+		return nil, doc, nil
+	}
+
+	undefinedTerms := make([]string, 0, len(terms))
+	for term := range terms {
+		undefinedTerms = append(undefinedTerms, term)
+	}
+
+	sort.Strings(undefinedTerms)
+
+	if opts.undefinedTermsMode == UndefinedTermsError {
+		return nil, nil, fmt.Errorf("undefined JSON-LD terms: %s", strings.Join(undefinedTerms, ", "))
+	}
+
+	if !prune {
+		return undefinedTerms, doc, nil
+	}
+
+	prunedDoc, err := json.Marshal(docMap)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal JSON-LD doc without undefined terms: %w", err)
+	}
+
+	return undefinedTerms, prunedDoc, nil
+}
+
+// collectUndefinedTerms collects the terms of the original map missing from the compacted one, and deletes them
+// from the original map if pruning.
+func collectUndefinedTerms(original, compacted map[string]interface{}, path string, terms map[string]bool,
+	prune bool) {
+	for k, v := range original {
+		if strings.HasPrefix(k, "@") {
+			continue
+		}
+
+		compactedValue, ok := compacted[k]
+		if !ok {
+			terms[path+k] = true
+
+			if prune {
+				delete(original, k)
+			}
+
+			continue
+		}
+
+		collectUndefinedTermsOfValue(v, compactedValue, path+k+".", terms, prune)
+	}
+}
+
+func collectUndefinedTermsOfValue(original, compacted interface{}, path string, terms map[string]bool, prune bool) {
+	switch ov := original.(type) {
+	case map[string]interface{}:
+		if compactedMap, ok := compacted.(map[string]interface{}); ok {
+			collectUndefinedTerms(ov, compactedMap, path, terms, prune)
+		}
+
+	case []interface{}:
+		compactedSlice, ok := compacted.([]interface{})
+		if !ok {
+			// single element array is compacted to the element
+			compactedSlice = []interface{}{compacted}
+		}
+
+		if len(compactedSlice) != len(ov) {
+			return
+		}
+
+		for i := range ov {
+			collectUndefinedTermsOfValue(ov[i], compactedSlice[i], path, terms, prune)
+		}
+	}
+}
+
 func compactJSONLD(doc string, opts *jsonldCredentialOpts, strict bool) error {
 	docMap, err := toMap(doc)
 	if err != nil {
@@ -473,3 +473,131 @@ func Benchmark_compactJSONLD(b *testing.B) {
 		})
 	})
 }
+
+func TestParseCredential_UndefinedTerms(t *testing.T) {
+	vcJSON := `
+{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "http://127.0.0.1?context=5"
+  ],
+  "id": "http://example.com/credentials/4643",
+  "type": "VerifiableCredential",
+  "issuer": "https://example.com/issuers/14",
+  "issuanceDate": "2018-02-24T05:28:04Z",
+  "referenceNumber": 83294847,
+  "credentialSubject": [
+    {
+      "id": "did:example:abcdef1234567",
+      "name": "Jane Doe",
+      "nickname": "JD"
+    },
+    {
+      "id": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+      "favoriteFood": "Papaya"
+    }
+  ]
+}
+`
+
+	loader := CachingJSONLDLoader()
+	addJSONLDCachedContextFromFile(loader, "http://127.0.0.1?context=5", "context5.jsonld")
+
+	parse := func(opts ...CredentialOpt) (*Credential, error) {
+		return ParseCredential([]byte(vcJSON), append([]CredentialOpt{WithJSONLDDocumentLoader(loader)}, opts...)...)
+	}
+
+	t.Run("not checked by default", func(t *testing.T) {
+		vc, err := parse()
+		require.NoError(t, err)
+		require.Empty(t, vc.UndefinedTerms)
+		require.Contains(t, vc.CustomFields, "referenceNumber")
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		vc, err := parse(WithUndefinedTerms(UndefinedTermsDrop))
+		require.NoError(t, err)
+		require.Equal(t, []string{"credentialSubject.nickname", "referenceNumber"}, vc.UndefinedTerms)
+		require.NotContains(t, vc.CustomFields, "referenceNumber")
+
+		subjects, ok := vc.Subject.([]Subject)
+		require.True(t, ok)
+		require.Len(t, subjects, 2)
+		require.Equal(t, "Jane Doe", subjects[0].CustomFields["name"])
+		require.NotContains(t, subjects[0].CustomFields, "nickname")
+		require.Equal(t, "Papaya", subjects[1].CustomFields["favoriteFood"])
+
+		// no undefined terms after dropping them
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		vc, err = ParseCredential(vcBytes, WithJSONLDDocumentLoader(loader), WithStrictValidation(),
+			WithUndefinedTerms(UndefinedTermsError))
+		require.NoError(t, err)
+		require.Empty(t, vc.UndefinedTerms)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		vc, err := parse(WithUndefinedTerms(UndefinedTermsError))
+		require.EqualError(t, err, "check undefined terms of new credential: undefined JSON-LD terms: "+
+			"credentialSubject.nickname, referenceNumber")
+		require.Nil(t, vc)
+	})
+
+	t.Run("preserve as custom fields", func(t *testing.T) {
+		vc, err := parse(WithUndefinedTerms(UndefinedTermsPreserve))
+		require.NoError(t, err)
+		require.Equal(t, []string{"credentialSubject.nickname", "referenceNumber"}, vc.UndefinedTerms)
+		require.Equal(t, 83294847., vc.CustomFields["referenceNumber"])
+
+		subjects, ok := vc.Subject.([]Subject)
+		require.True(t, ok)
+		require.Equal(t, "JD", subjects[0].CustomFields["nickname"])
+	})
+
+	t.Run("invalid JSON-LD", func(t *testing.T) {
+		_, _, err := checkUndefinedTerms([]byte("[]"), &jsonldCredentialOpts{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "convert JSON-LD doc to map")
+	})
+}
+
+func TestParsePresentation_UndefinedTerms(t *testing.T) {
+	vpJSON := `
+{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "type": "VerifiablePresentation",
+  "holder": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+  "purpose": "loan application",
+  "verifiableCredential": [
+    {
+      "@context": ["https://www.w3.org/2018/credentials/v1"],
+      "id": "http://example.com/credentials/4643",
+      "type": "VerifiableCredential",
+      "issuer": "https://example.com/issuers/14",
+      "issuanceDate": "2018-02-24T05:28:04Z",
+      "referenceNumber": 83294847,
+      "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+    }
+  ]
+}
+`
+
+	vp, err := newTestPresentation([]byte(vpJSON), WithPresUndefinedTerms(UndefinedTermsDrop))
+	require.NoError(t, err)
+	require.Equal(t, []string{"purpose", "verifiableCredential.referenceNumber"}, vp.UndefinedTerms)
+	require.NotContains(t, vp.CustomFields, "purpose")
+
+	creds := vp.Credentials()
+	require.Len(t, creds, 1)
+	require.NotContains(t, creds[0], "referenceNumber")
+
+	vp, err = newTestPresentation([]byte(vpJSON), WithPresUndefinedTerms(UndefinedTermsPreserve))
+	require.NoError(t, err)
+	require.Equal(t, []string{"purpose", "verifiableCredential.referenceNumber"}, vp.UndefinedTerms)
+	require.Equal(t, "loan application", vp.CustomFields["purpose"])
+
+	_, err = newTestPresentation([]byte(vpJSON), WithPresUndefinedTerms(UndefinedTermsError))
+	require.EqualError(t, err, "check undefined terms of presentation: undefined JSON-LD terms: "+
+		"purpose, verifiableCredential.referenceNumber")
+}
@@ -6,10 +6,22 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/x509chain"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
 // Signer defines signer interface which is used to sign VC JWT.
@@ -48,17 +60,62 @@ func (v noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
 	return nil
 }
 
+// JWSOpt customizes the protected headers of the JWS.
+type JWSOpt func(headers jose.Headers)
+
+// WithJWSType sets the "typ" header (e.g. "vc+jwt" or "vp+jwt"), "JWT" by default.
+func WithJWSType(typ string) JWSOpt {
+	return func(headers jose.Headers) {
+		headers[jose.HeaderType] = typ
+	}
+}
+
+// WithJWSContentType sets the "cty" header.
+func WithJWSContentType(cty string) JWSOpt {
+	return func(headers jose.Headers) {
+		headers[jose.HeaderContentType] = cty
+	}
+}
+
+// WithJWSX509CertificateChain sets the "x5c" header to the certificate of the signing key, followed by the
+// certificates of its chain.
+func WithJWSX509CertificateChain(chain ...*x509.Certificate) JWSOpt {
+	return func(headers jose.Headers) {
+		x5c := make([]string, len(chain))
+
+		for i, cert := range chain {
+			x5c[i] = base64.StdEncoding.EncodeToString(cert.Raw)
+		}
+
+		headers[jose.HeaderX509CertificateChain] = x5c
+	}
+}
+
+// WithJWSHeader sets an arbitrary protected header. The "alg" and "kid" headers are set from the MarshalJWS
+// arguments and can not be overridden.
+func WithJWSHeader(name string, value interface{}) JWSOpt {
+	return func(headers jose.Headers) {
+		headers[name] = value
+	}
+}
+
 // MarshalJWS serializes JWT presentation claims into signed form (JWS).
-func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
+func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	opts ...JWSOpt) (string, error) {
 	algName, err := signatureAlg.name()
 	if err != nil {
 		return "", err
 	}
 
-	headers := map[string]interface{}{
-		jose.HeaderKeyID: keyID,
+	headers := jose.Headers{}
+
+	for _, opt := range opts {
+		opt(headers)
 	}
 
+	delete(headers, jose.HeaderAlgorithm)
+	headers[jose.HeaderKeyID] = keyID
+
 	token, err := jwt.NewSigned(jwtClaims, headers, getJWTSigner(signer, algName))
 	if err != nil {
 		return "", err
@@ -67,20 +124,30 @@ func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer,
 	return token.Serialize(false)
 }
 
-func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, claims interface{}) error {
-	var verifier jose.SignatureVerifier
+func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, x509Validator *x509chain.Validator,
+	expectedTypes []string, claims interface{}) error {
+	var sigVerifier jose.SignatureVerifier
 
 	if checkProof {
-		verifier = jwt.NewVerifier(jwt.KeyResolverFunc(fetcher))
+		sigVerifier = getJWSVerifier(fetcher, x509Validator)
 	} else {
-		verifier = &noVerifier{}
+		sigVerifier = &noVerifier{}
 	}
 
-	jsonWebToken, err := jwt.Parse(rawJwt, jwt.WithSignatureVerifier(verifier))
+	jsonWebToken, err := jwt.Parse(rawJwt, jwt.WithSignatureVerifier(sigVerifier))
 	if err != nil {
+		if checkProof {
+			return &ProofError{ProofType: jwtProofType, Err: fmt.Errorf("parse JWT: %w", err)}
+		}
+
 		return fmt.Errorf("parse JWT: %w", err)
 	}
 
+	err = checkJWSType(jsonWebToken.Headers, expectedTypes)
+	if err != nil {
+		return err
+	}
+
 	err = jsonWebToken.DecodeClaims(claims)
 	if err != nil {
 		return err
@@ -88,3 +155,155 @@ func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, clai
 
 	return nil
 }
+
+// checkJWSType checks that the "typ" header is one of the expected types, if any.
+func checkJWSType(headers jose.Headers, expectedTypes []string) error {
+	if len(expectedTypes) == 0 {
+		return nil
+	}
+
+	typ, _ := headers.Type()
+
+	for _, expected := range expectedTypes {
+		if typ == expected {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unexpected JWT type %q, expected one of %v", typ, expectedTypes)
+}
+
+// getJWSVerifier returns the verifier of the JWS signatures. If the X.509 validator is defined, the signatures of the
+// JWS with an "x5c" header are verified with the key of the first certificate of the chain, once the chain is
+// validated and is bound to the issuer: for did:x509 issuers the chain matches the issuer DID, for the other issuers
+// the certificate has a SAN URI equal to the issuer. The other signatures are verified with the keys fetched by
+// the public key fetcher.
+func getJWSVerifier(fetcher PublicKeyFetcher, x509Validator *x509chain.Validator) jose.SignatureVerifier {
+	fetcherVerifier := jwt.NewVerifier(jwt.KeyResolverFunc(fetcher))
+
+	if x509Validator == nil {
+		return fetcherVerifier
+	}
+
+	return jose.SignatureVerifierFunc(func(joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
+		x5c, ok := joseHeaders[jose.HeaderX509CertificateChain]
+		if !ok {
+			if fetcher == nil {
+				return errors.New("public key fetcher is not defined")
+			}
+
+			return fetcherVerifier.Verify(joseHeaders, payload, signingInput, signature)
+		}
+
+		pubKey, err := x509IssuerPublicKey(x509Validator, x5c, payload)
+		if err != nil {
+			return err
+		}
+
+		return newX509Verifier(pubKey).Verify(joseHeaders, payload, signingInput, signature)
+	})
+}
+
+// newX509Verifier returns the verifier of the signatures by the key of a certificate.
+func newX509Verifier(pubKey *verifier.PublicKey) jose.SignatureVerifier {
+	basicVerifier := jwt.NewVerifier(jwt.KeyResolverFunc(func(_, _ string) (*verifier.PublicKey, error) {
+		return pubKey, nil
+	}))
+
+	ecdsaVerifier := func(keyType string, sigVerifier *verifier.ECDSASignatureVerifier) jose.SignatureVerifier {
+		return jose.SignatureVerifierFunc(func(_ jose.Headers, _, signingInput, signature []byte) error {
+			if pubKey.Type != keyType {
+				return fmt.Errorf("certificate public key of type %s does not match the JWS algorithm", pubKey.Type)
+			}
+
+			return sigVerifier.Verify(pubKey, signingInput, signature)
+		})
+	}
+
+	return jose.NewCompositeAlgSigVerifier(
+		jose.AlgSignatureVerifier{Alg: "EdDSA", Verifier: basicVerifier},
+		jose.AlgSignatureVerifier{Alg: "RS256", Verifier: basicVerifier},
+		jose.AlgSignatureVerifier{
+			Alg:      "ES256",
+			Verifier: ecdsaVerifier(kms.ECDSAP256IEEEP1363, verifier.NewECDSAES256SignatureVerifier()),
+		},
+		jose.AlgSignatureVerifier{
+			Alg:      "ES384",
+			Verifier: ecdsaVerifier(kms.ECDSAP384IEEEP1363, verifier.NewECDSAES384SignatureVerifier()),
+		},
+	)
+}
+
+func x509IssuerPublicKey(x509Validator *x509chain.Validator, x5c interface{},
+	payload []byte) (*verifier.PublicKey, error) {
+	chain, err := x509chain.ParseX5C(x5c)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err = x509Validator.Validate(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("read claims from JSON Web Token: %w", err)
+	}
+
+	if strings.HasPrefix(claims.Issuer, x509chain.DIDPrefix) {
+		err = x509chain.VerifyDID(claims.Issuer, chain)
+	} else {
+		err = verifyIssuerSAN(claims.Issuer, chain[0])
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return certificatePublicKey(chain[0])
+}
+
+// verifyIssuerSAN verifies the certificate of the signing key has a SAN URI equal to the issuer, so the holders of
+// certificates issued under the trust anchors can't sign on behalf of other issuers.
+func verifyIssuerSAN(issuer string, cert *x509.Certificate) error {
+	if issuer != "" {
+		for _, uri := range cert.URIs {
+			if uri.String() == issuer {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("certificate %s is not bound to issuer %q: no SAN URI equal to the issuer", cert.Subject, issuer)
+}
+
+func certificatePublicKey(cert *x509.Certificate) (*verifier.PublicKey, error) {
+	switch key := cert.PublicKey.(type) {
+	case ed25519.PublicKey:
+		return &verifier.PublicKey{Type: kms.ED25519, Value: key}, nil
+	case *rsa.PublicKey:
+		return &verifier.PublicKey{Type: kms.RSARS256, Value: x509.MarshalPKCS1PublicKey(key)}, nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return &verifier.PublicKey{
+				Type:  kms.ECDSAP256IEEEP1363,
+				Value: elliptic.Marshal(key.Curve, key.X, key.Y),
+			}, nil
+		case elliptic.P384():
+			return &verifier.PublicKey{
+				Type:  kms.ECDSAP384IEEEP1363,
+				Value: elliptic.Marshal(key.Curve, key.X, key.Y),
+			}, nil
+		default:
+			return nil, fmt.Errorf("unsupported certificate public key curve %s", key.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported certificate public key type %T", key)
+	}
+}
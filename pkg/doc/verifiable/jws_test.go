@@ -0,0 +1,334 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/x509chain"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestMarshalJWSHeaders(t *testing.T) {
+	signer, err := newCryptoSigner(kms.RSARS256Type)
+	require.NoError(t, err)
+
+	pkFetcher := func(_, _ string) (*verifier.PublicKey, error) {
+		return &verifier.PublicKey{
+			Type:  kms.RSARS256,
+			Value: signer.PublicKeyBytes(),
+		}, nil
+	}
+
+	vc, err := parseTestCredential([]byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	t.Run("default headers", func(t *testing.T) {
+		vcJWS, err := jwtClaims.MarshalJWS(RS256, signer, "key-1")
+		require.NoError(t, err)
+
+		token, err := jwt.Parse(vcJWS, jwt.WithSignatureVerifier(&noVerifier{}))
+		require.NoError(t, err)
+		require.Equal(t, jose.Headers{
+			jose.HeaderAlgorithm: "RS256",
+			jose.HeaderType:      jwt.TypeJWT,
+			jose.HeaderKeyID:     "key-1",
+		}, token.Headers)
+
+		_, err = decodeCredJWS(vcJWS, true, pkFetcher, nil, []string{"vc+jwt"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `unexpected JWT type "JWT", expected one of [vc+jwt]`)
+	})
+
+	t.Run("custom headers", func(t *testing.T) {
+		cert := &x509.Certificate{Raw: []byte("certificate")}
+		caCert := &x509.Certificate{Raw: []byte("CA certificate")}
+
+		vcJWS, err := jwtClaims.MarshalJWS(RS256, signer, "key-1",
+			WithJWSType("vc+jwt"),
+			WithJWSContentType("vc+ld+json"),
+			WithJWSX509CertificateChain(cert, caCert),
+			WithJWSHeader("custom", "value"),
+			WithJWSHeader(jose.HeaderAlgorithm, "none"),
+			WithJWSHeader(jose.HeaderKeyID, "key-2"))
+		require.NoError(t, err)
+
+		token, err := jwt.Parse(vcJWS, jwt.WithSignatureVerifier(&noVerifier{}))
+		require.NoError(t, err)
+		require.Equal(t, jose.Headers{
+			jose.HeaderAlgorithm:   "RS256",
+			jose.HeaderType:        "vc+jwt",
+			jose.HeaderContentType: "vc+ld+json",
+			jose.HeaderKeyID:       "key-1",
+			jose.HeaderX509CertificateChain: []interface{}{
+				base64.StdEncoding.EncodeToString(cert.Raw),
+				base64.StdEncoding.EncodeToString(caCert.Raw),
+			},
+			"custom": "value",
+		}, token.Headers)
+
+		vcBytes, err := decodeCredJWS(vcJWS, true, pkFetcher, nil, []string{"JWT", "vc+jwt"})
+		require.NoError(t, err)
+		require.NotEmpty(t, vcBytes)
+
+		parsed, err := parseTestCredential([]byte(vcJWS), WithPublicKeyFetcher(pkFetcher),
+			WithExpectedJWTType("vc+jwt"))
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, parsed.ID)
+
+		_, err = parseTestCredential([]byte(vcJWS), WithPublicKeyFetcher(pkFetcher), WithExpectedJWTType("JWT"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `unexpected JWT type "vc+jwt"`)
+	})
+
+	t.Run("presentation type", func(t *testing.T) {
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		vp.Holder = "did:example:holder"
+
+		vpClaims, err := vp.JWTClaims([]string{}, false)
+		require.NoError(t, err)
+
+		vpJWS, err := vpClaims.MarshalJWS(RS256, signer, "key-1", WithJWSType("vp+jwt"))
+		require.NoError(t, err)
+
+		_, err = ParsePresentation([]byte(vpJWS), WithPresPublicKeyFetcher(pkFetcher),
+			WithPresExpectedJWTType("vp+jwt"), WithPresJSONLDDocumentLoader(testDocumentLoader))
+		require.NoError(t, err)
+
+		_, err = ParsePresentation([]byte(vpJWS), WithPresPublicKeyFetcher(pkFetcher),
+			WithPresExpectedJWTType("JWT"), WithPresJSONLDDocumentLoader(testDocumentLoader))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `unexpected JWT type "vp+jwt", expected one of [JWT]`)
+	})
+}
+
+type ed25519TestSigner ed25519.PrivateKey
+
+func (s ed25519TestSigner) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(ed25519.PrivateKey(s), data), nil
+}
+
+// ecdsaTestSigner signs ES256 JWS with IEEE P1363 encoded signatures.
+type ecdsaTestSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s ecdsaTestSigner) Sign(data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	const size = 32
+
+	return append(r.FillBytes(make([]byte, size)), sig.FillBytes(make([]byte, size))...), nil
+}
+
+func (s ecdsaTestSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "ES256"}
+}
+
+func newTestCertificate(t *testing.T, name string, ca bool, issuer *x509.Certificate,
+	issuerKey ed25519.PrivateKey, uris ...string) (*x509.Certificate, ed25519.PrivateKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	if issuer == nil {
+		issuerKey = priv
+	}
+
+	return createTestCertificate(t, name, ca, issuer, issuerKey, pub, uris...), priv
+}
+
+func createTestCertificate(t *testing.T, name string, ca bool, issuer *x509.Certificate,
+	issuerKey ed25519.PrivateKey, pub interface{}, uris ...string) *x509.Certificate {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  ca,
+		BasicConstraintsValid: ca,
+	}
+
+	for _, uri := range uris {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+
+		template.URIs = append(template.URIs, u)
+	}
+
+	if issuer == nil {
+		issuer = template
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, pub, issuerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestParseCredentialWithX509Chain(t *testing.T) {
+	caCert, caKey := newTestCertificate(t, "Root CA", true, nil, nil)
+	cert, key := newTestCertificate(t, "Issuer", false, caCert, caKey)
+
+	fingerprint := sha256.Sum256(caCert.Raw)
+	did := "did:x509:0:sha256:" + base64.RawURLEncoding.EncodeToString(fingerprint[:]) + "::subject:CN:Issuer"
+
+	newJWS := func(t *testing.T, issuer string, signKey ed25519.PrivateKey, certs ...*x509.Certificate) string {
+		t.Helper()
+
+		vc, err := parseTestCredential([]byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issuer.ID = issuer
+
+		jwtClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		var opts []JWSOpt
+		if len(certs) > 0 {
+			opts = append(opts, WithJWSX509CertificateChain(certs...))
+		}
+
+		vcJWS, err := jwtClaims.MarshalJWS(EdDSA, ed25519TestSigner(signKey), did+"#key-1", opts...)
+		require.NoError(t, err)
+
+		return vcJWS
+	}
+
+	validator := x509chain.New(x509chain.WithTrustAnchors(caCert))
+
+	t.Run("test did:x509 issuer", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(newJWS(t, did, key, cert, caCert)), WithX509ChainValidator(validator))
+		require.NoError(t, err)
+		require.Equal(t, did, vc.Issuer.ID)
+	})
+
+	t.Run("test x5c chain of issuer bound by SAN URI", func(t *testing.T) {
+		sanCert, sanKey := newTestCertificate(t, "Issuer", false, caCert, caKey, "did:example:issuer")
+
+		vc, err := parseTestCredential([]byte(newJWS(t, "did:example:issuer", sanKey, sanCert)),
+			WithX509ChainValidator(validator))
+		require.NoError(t, err)
+		require.Equal(t, "did:example:issuer", vc.Issuer.ID)
+	})
+
+	t.Run("test x5c chain not bound to the issuer", func(t *testing.T) {
+		_, err := parseTestCredential([]byte(newJWS(t, "did:example:issuer", key, cert)),
+			WithX509ChainValidator(validator))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `not bound to issuer "did:example:issuer"`)
+	})
+
+	t.Run("test ECDSA P-256 certificate", func(t *testing.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		ecCert := createTestCertificate(t, "Issuer", false, caCert, caKey, &ecKey.PublicKey, "did:example:issuer")
+
+		vc, err := parseTestCredential([]byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issuer.ID = "did:example:issuer"
+
+		jwtClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		headers := jose.Headers{jose.HeaderType: jwt.TypeJWT}
+		WithJWSX509CertificateChain(ecCert)(headers)
+
+		token, err := jwt.NewSigned(jwtClaims, headers, ecdsaTestSigner{key: ecKey})
+		require.NoError(t, err)
+
+		vcJWS, err := token.Serialize(false)
+		require.NoError(t, err)
+
+		vc, err = parseTestCredential([]byte(vcJWS), WithX509ChainValidator(validator))
+		require.NoError(t, err)
+		require.Equal(t, "did:example:issuer", vc.Issuer.ID)
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		token, err = jwt.NewSigned(jwtClaims, headers, ecdsaTestSigner{key: otherKey})
+		require.NoError(t, err)
+
+		vcJWS, err = token.Serialize(false)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential([]byte(vcJWS), WithX509ChainValidator(validator))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ecdsa: invalid signature")
+	})
+
+	t.Run("test untrusted chain", func(t *testing.T) {
+		otherCA, _ := newTestCertificate(t, "Other CA", true, nil, nil)
+
+		_, err := parseTestCredential([]byte(newJWS(t, did, key, cert, caCert)),
+			WithX509ChainValidator(x509chain.New(x509chain.WithTrustAnchors(otherCA))))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify certificate chain")
+	})
+
+	t.Run("test did:x509 policy mismatch", func(t *testing.T) {
+		otherCert, _ := newTestCertificate(t, "Other Issuer", false, caCert, caKey)
+
+		_, err := parseTestCredential([]byte(newJWS(t, did, key, otherCert, caCert)), WithX509ChainValidator(validator))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match CN=Issuer")
+	})
+
+	t.Run("test signature of other key", func(t *testing.T) {
+		otherCert, _ := newTestCertificate(t, "Issuer", false, caCert, caKey)
+
+		_, err := parseTestCredential([]byte(newJWS(t, did, key, otherCert, caCert)), WithX509ChainValidator(validator))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signature doesn't match")
+	})
+
+	t.Run("test JWS without x5c", func(t *testing.T) {
+		vcJWS := newJWS(t, "did:example:issuer", key)
+
+		_, err := parseTestCredential([]byte(vcJWS), WithX509ChainValidator(validator))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key fetcher is not defined")
+
+		_, err = parseTestCredential([]byte(vcJWS), WithX509ChainValidator(validator),
+			WithPublicKeyFetcher(SingleKey(key.Public().(ed25519.PublicKey), kms.ED25519)))
+		require.NoError(t, err)
+	})
+}
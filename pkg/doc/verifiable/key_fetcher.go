@@ -0,0 +1,170 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const jsonWebKey2020 = "JsonWebKey2020"
+
+// CompositeKeyFetcher returns a PublicKeyFetcher trying the fetchers in order until one of them finds the key,
+// e.g. the VDR resolution first, then a JWKS endpoint and finally a local trust store.
+// The returned error lists the errors of all the fetchers when none of them finds the key.
+func CompositeKeyFetcher(fetchers ...PublicKeyFetcher) PublicKeyFetcher {
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		if len(fetchers) == 0 {
+			return nil, errors.New("composite key fetcher: no public key fetcher defined")
+		}
+
+		errs := make([]string, 0, len(fetchers))
+
+		for _, fetcher := range fetchers {
+			pubKey, err := fetcher(issuerID, keyID)
+			if err == nil {
+				return pubKey, nil
+			}
+
+			errs = append(errs, err.Error())
+		}
+
+		return nil, fmt.Errorf("composite key fetcher: public key with KID %s is not found for issuer %s: [%s]",
+			keyID, issuerID, strings.Join(errs, "; "))
+	}
+}
+
+// DIDMethodKeyFetcher returns a PublicKeyFetcher routing the issuers to a fetcher by DID method (e.g. "key",
+// "web" or "orb"). The issuers which are not DIDs or whose method has no fetcher are routed to defaultFetcher
+// when it is set.
+func DIDMethodKeyFetcher(fetchers map[string]PublicKeyFetcher, defaultFetcher PublicKeyFetcher) PublicKeyFetcher {
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		if parsed, err := did.Parse(issuerID); err == nil {
+			if fetcher, ok := fetchers[parsed.Method]; ok {
+				return fetcher(issuerID, keyID)
+			}
+		}
+
+		if defaultFetcher == nil {
+			return nil, fmt.Errorf("did method key fetcher: no public key fetcher for issuer %s", issuerID)
+		}
+
+		return defaultFetcher(issuerID, keyID)
+	}
+}
+
+// TrustStoreKeyFetcher returns a PublicKeyFetcher looking up keys in a local trust store, the keys are mapped by
+// key ID. Key IDs are matched in full (e.g. "did:example:123#key-1") or by fragment ("key-1").
+func TrustStoreKeyFetcher(keys map[string]*verifier.PublicKey) PublicKeyFetcher {
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		if pubKey, ok := keys[keyID]; ok {
+			return pubKey, nil
+		}
+
+		for kid, pubKey := range keys {
+			if keyIDMatches(kid, keyID) {
+				return pubKey, nil
+			}
+		}
+
+		return nil, fmt.Errorf("trust store: public key with KID %s is not found for issuer %s", keyID, issuerID)
+	}
+}
+
+// JWKSKeyFetcher returns a PublicKeyFetcher looking up keys by "kid" in the JSON Web Key Set (RFC 7517) served at
+// jwksURL. The key set is fetched for every lookup, wrap the fetcher to cache the keys if needed.
+func JWKSKeyFetcher(jwksURL string, client *http.Client) PublicKeyFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		keys, err := fetchJWKS(client, jwksURL)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key fetcher: %w", err)
+		}
+
+		for _, jwk := range keys {
+			if !keyIDMatches(jwk.KeyID, keyID) {
+				continue
+			}
+
+			pubKeyBytes, err := jwk.PublicKeyBytes()
+			if err != nil {
+				return nil, fmt.Errorf("jwks key fetcher: public key with KID %s: %w", keyID, err)
+			}
+
+			return &verifier.PublicKey{
+				Type:  jsonWebKey2020,
+				Value: pubKeyBytes,
+				JWK:   jwk,
+			}, nil
+		}
+
+		return nil, fmt.Errorf("jwks key fetcher: public key with KID %s is not found in %s", keyID, jwksURL)
+	}
+}
+
+func fetchJWKS(client *http.Client, jwksURL string) ([]*jose.JWK, error) {
+	resp, err := client.Get(jwksURL) //nolint:noctx
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", jwksURL, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close() //nolint:errcheck
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s: unexpected status %d", jwksURL, resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", jwksURL, err)
+	}
+
+	jwks := struct {
+		Keys []*jose.JWK `json:"keys"`
+	}{}
+
+	err = json.Unmarshal(body, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal JWKS: %w", err)
+	}
+
+	return jwks.Keys, nil
+}
+
+// keyIDMatches checks if candidate identifies keyID, either in full or by their fragments (relative DID URLs,
+// plain key IDs).
+func keyIDMatches(candidate, keyID string) bool {
+	if candidate == "" || keyID == "" {
+		return false
+	}
+
+	if candidate == keyID {
+		return true
+	}
+
+	return keyIDFragment(candidate) == keyIDFragment(keyID)
+}
+
+func keyIDFragment(keyID string) string {
+	if i := strings.LastIndex(keyID, "#"); i >= 0 {
+		return keyID[i+1:]
+	}
+
+	return keyID
+}
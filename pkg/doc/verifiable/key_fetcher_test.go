@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gojose "github.com/square/go-jose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+func TestCompositeKeyFetcher(t *testing.T) {
+	pubKey := &verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: []byte("key")}
+
+	notFound := func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		return nil, fmt.Errorf("%s not found", keyID)
+	}
+
+	found := func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		return pubKey, nil
+	}
+
+	t.Run("first found key is returned", func(t *testing.T) {
+		calls := 0
+
+		counted := func(issuerID, keyID string) (*verifier.PublicKey, error) {
+			calls++
+
+			return found(issuerID, keyID)
+		}
+
+		key, err := CompositeKeyFetcher(notFound, counted, counted)("did:example:123", "#key-1")
+		require.NoError(t, err)
+		require.Equal(t, pubKey, key)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("key not found by any fetcher", func(t *testing.T) {
+		key, err := CompositeKeyFetcher(notFound, notFound)("did:example:123", "#key-1")
+		require.Error(t, err)
+		require.Nil(t, key)
+		require.Contains(t, err.Error(), "public key with KID #key-1 is not found for issuer did:example:123")
+		require.Contains(t, err.Error(), "[#key-1 not found; #key-1 not found]")
+	})
+
+	t.Run("no fetcher", func(t *testing.T) {
+		_, err := CompositeKeyFetcher()("did:example:123", "#key-1")
+		require.EqualError(t, err, "composite key fetcher: no public key fetcher defined")
+	})
+}
+
+func TestDIDMethodKeyFetcher(t *testing.T) {
+	named := func(name string) PublicKeyFetcher {
+		return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+			return &verifier.PublicKey{Type: name}, nil
+		}
+	}
+
+	fetchers := map[string]PublicKeyFetcher{
+		"key": named("key"),
+		"web": named("web"),
+	}
+
+	t.Run("routed by DID method", func(t *testing.T) {
+		fetcher := DIDMethodKeyFetcher(fetchers, named("default"))
+
+		key, err := fetcher("did:key:z6MkjRagNiMu91DduvCvgEsqLZDVzrJzFrwahc4tXLt9DoHd", "#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "key", key.Type)
+
+		key, err = fetcher("did:web:example.com", "#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "web", key.Type)
+
+		key, err = fetcher("did:example:123", "#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "default", key.Type)
+
+		key, err = fetcher("https://example.com/issuer", "#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "default", key.Type)
+	})
+
+	t.Run("no default fetcher", func(t *testing.T) {
+		_, err := DIDMethodKeyFetcher(fetchers, nil)("did:example:123", "#key-1")
+		require.EqualError(t, err, "did method key fetcher: no public key fetcher for issuer did:example:123")
+	})
+}
+
+func TestTrustStoreKeyFetcher(t *testing.T) {
+	pubKey := &verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: []byte("key")}
+
+	fetcher := TrustStoreKeyFetcher(map[string]*verifier.PublicKey{
+		"did:example:123#key-1": pubKey,
+	})
+
+	key, err := fetcher("did:example:123", "did:example:123#key-1")
+	require.NoError(t, err)
+	require.Equal(t, pubKey, key)
+
+	key, err = fetcher("did:example:123", "#key-1")
+	require.NoError(t, err)
+	require.Equal(t, pubKey, key)
+
+	_, err = fetcher("did:example:123", "#key-2")
+	require.EqualError(t, err, "trust store: public key with KID #key-2 is not found for issuer did:example:123")
+}
+
+func TestJWKSKeyFetcher(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwk := &jose.JWK{
+		JSONWebKey: gojose.JSONWebKey{
+			Key:   pub,
+			KeyID: "key-1",
+		},
+	}
+
+	jwkBytes, err := jwk.MarshalJSON()
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(map[string][]json.RawMessage{"keys": {jwkBytes}})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jwks":
+			_, _ = w.Write(jwks)
+		case "/invalid":
+			_, _ = w.Write([]byte("not JSON"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("key found by KID", func(t *testing.T) {
+		fetcher := JWKSKeyFetcher(server.URL+"/jwks", nil)
+
+		key, err := fetcher("did:example:123", "did:example:123#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "JsonWebKey2020", key.Type)
+		require.Equal(t, []byte(pub), key.Value)
+		require.Equal(t, "key-1", key.JWK.KeyID)
+
+		_, err = fetcher("did:example:123", "key-2")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key with KID key-2 is not found")
+	})
+
+	t.Run("invalid JWKS endpoint", func(t *testing.T) {
+		_, err := JWKSKeyFetcher(server.URL+"/unknown", server.Client())("did:example:123", "key-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected status 404")
+
+		_, err = JWKSKeyFetcher(server.URL+"/invalid", server.Client())("did:example:123", "key-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal JWKS")
+	})
+
+	t.Run("composed with a failing fetcher", func(t *testing.T) {
+		failing := func(issuerID, keyID string) (*verifier.PublicKey, error) {
+			return nil, errors.New("DID not resolved")
+		}
+
+		key, err := CompositeKeyFetcher(failing, JWKSKeyFetcher(server.URL+"/jwks", nil))("did:example:123", "#key-1")
+		require.NoError(t, err)
+		require.Equal(t, []byte(pub), key.Value)
+	})
+}
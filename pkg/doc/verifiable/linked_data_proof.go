@@ -96,6 +96,10 @@ func mapJSONLDProcessorOpts(jsonldOpts *jsonldCredentialOpts) []jsonld.Processor
 		processorOpts = append(processorOpts, jsonld.WithValidateRDF())
 	}
 
+	if jsonldOpts.canonicalizationCache != nil {
+		processorOpts = append(processorOpts, jsonld.WithCanonicalizationCache(jsonldOpts.canonicalizationCache))
+	}
+
 	return processorOpts
 }
 
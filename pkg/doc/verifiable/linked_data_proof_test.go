@@ -144,9 +144,56 @@ func TestLinkedDataProofSignerAndVerifier(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, vcDecoded)
 	})
+
+	t.Run("canonicalization cache", func(t *testing.T) {
+		cache := jsonld.NewMemCanonicalizationCache(10)
+
+		for i := 0; i < 2; i++ {
+			vcDecoded, err := parseTestCredential(vcWithEd25519ProofBytes,
+				WithJSONLDCanonicalizationCache(cache),
+				WithPublicKeyFetcher(SingleKey(ed25519Signer.PublicKeyBytes(), kms.ED25519)))
+			require.NoError(t, err)
+			require.Equal(t, vcWithEd25519Proof, vcDecoded)
+		}
+
+		// the credential and its proof are canonized once
+		require.Equal(t, 2, cache.Len())
+
+		_, err := parseTestCredential(vcWithEd25519ProofBytes,
+			WithJSONLDCanonicalizationCache(cache),
+			WithPublicKeyFetcher(SingleKey(ecdsaSigner.PublicKeyBytes(), kms.ED25519)))
+		require.Error(t, err)
+	})
+}
+
+func BenchmarkLinkedDataProofVerification_CanonicalizationCache(b *testing.B) {
+	ed25519Signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(b, err)
+
+	vcBytes, err := prepareVCWithEd25519LDP(b, validCredential, ed25519Signer).MarshalJSON()
+	require.NoError(b, err)
+
+	pubKeyFetcher := SingleKey(ed25519Signer.PublicKeyBytes(), kms.ED25519)
+
+	b.Run("without cache", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			_, err := parseTestCredential(vcBytes, WithPublicKeyFetcher(pubKeyFetcher))
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("with cache", func(b *testing.B) {
+		cache := jsonld.NewMemCanonicalizationCache(100)
+
+		for n := 0; n < b.N; n++ {
+			_, err := parseTestCredential(vcBytes, WithPublicKeyFetcher(pubKeyFetcher),
+				WithJSONLDCanonicalizationCache(cache))
+			require.NoError(b, err)
+		}
+	})
 }
 
-func prepareVCWithEd25519LDP(t *testing.T, vcJSON string, signer Signer) *Credential {
+func prepareVCWithEd25519LDP(t testing.TB, vcJSON string, signer Signer) *Credential {
 	vc, err := ParseCredential([]byte(vcJSON),
 		WithJSONLDDocumentLoader(createTestJSONLDDocumentLoader()),
 		WithDisabledProofCheck())
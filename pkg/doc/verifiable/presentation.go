@@ -9,13 +9,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
 )
 
 const basePresentationSchema = `
@@ -174,6 +178,10 @@ type Presentation struct {
 	Holder        string
 	Proofs        []Proof
 	CustomFields  CustomFields
+
+	// UndefinedTerms are the JSON paths of the terms undefined in the JSON-LD context of the decoded presentation,
+	// reported when decoding with WithPresUndefinedTerms option.
+	UndefinedTerms []string
 }
 
 // NewPresentation creates a new Presentation with default context and type with the provided credentials.
@@ -307,6 +315,14 @@ type rawPresentation struct {
 	Proof      json.RawMessage `json:"proof,omitempty"`
 	// All unmapped fields are put here.
 	CustomFields `json:"-"`
+
+	// jwtChallenge holds the "nonce" and "aud" claims of the presentation decoded from a JWS.
+	jwtChallenge *jwtChallenge
+}
+
+type jwtChallenge struct {
+	nonce    string
+	audience []string
 }
 
 // MarshalJSON defines custom marshalling of rawPresentation to JSON.
@@ -341,8 +357,16 @@ type presentationOpts struct {
 	strictValidation   bool
 	requireVC          bool
 	requireProof       bool
+	trustRegistry      trustregistry.Registry
+	jwtTypes           []string
+	holderBinding      bool
+	holderDelegates    []HolderDelegateChecker
+	challengeConsumer  ChallengeConsumer
+	caveatEvaluators   map[string]CaveatEvaluator
+	invocationCounter  InvocationCounter
 
 	jsonldCredentialOpts
+	validityPeriodOpts
 }
 
 // PresentationOpt is the Verifiable Presentation decoding option.
@@ -370,6 +394,14 @@ func WithPresDisabledProofCheck() PresentationOpt {
 	}
 }
 
+// WithPresExpectedJWTType option requires the Verifiable Presentation decoded from JWS to have one of the given
+// "typ" headers (e.g. "vp+jwt").
+func WithPresExpectedJWTType(types ...string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.jwtTypes = types
+	}
+}
+
 // WithPresStrictValidation enabled strict JSON-LD validation of VP.
 // In case of JSON-LD validation, the comparison of JSON-LD VP document after compaction with original VP one is made.
 // In case of mismatch a validation exception is raised.
@@ -379,6 +411,14 @@ func WithPresStrictValidation() PresentationOpt {
 	}
 }
 
+// WithPresUndefinedTerms defines how the terms undefined in the JSON-LD context of VP (including its embedded
+// credentials) are handled (see UndefinedTermsMode). The undefined terms are reported in Presentation.UndefinedTerms.
+func WithPresUndefinedTerms(mode UndefinedTermsMode) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.undefinedTermsMode = mode
+	}
+}
+
 // WithPresJSONLDDocumentLoader defines custom JSON-LD document loader. If not defined, when decoding VP
 // a new document loader will be created using CachingJSONLDLoader() if JSON-LD validation is made.
 func WithPresJSONLDDocumentLoader(documentLoader ld.DocumentLoader) PresentationOpt {
@@ -387,6 +427,76 @@ func WithPresJSONLDDocumentLoader(documentLoader ld.DocumentLoader) Presentation
 	}
 }
 
+// WithPresJSONLDCanonicalizationCache defines a cache of canonical JSON-LD documents used when verifying
+// linked data signatures of verifiable presentation.
+func WithPresJSONLDCanonicalizationCache(cache jsonld.CanonicalizationCache) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.canonicalizationCache = cache
+	}
+}
+
+// WithPresCurrentTime defines the clock the time-based JWT claims of VP ("exp", "nbf" and "iat") are checked against.
+// The claims are not checked unless this option or WithPresLeeway is defined.
+func WithPresCurrentTime(currentTime func() time.Time) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.setCurrentTime(currentTime)
+	}
+}
+
+// WithPresLeeway defines the tolerated clock skew when checking the time-based JWT claims of VP.
+// The claims are not checked unless this option or WithPresCurrentTime is defined.
+func WithPresLeeway(leeway time.Duration) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.setLeeway(leeway)
+	}
+}
+
+// WithPresTrustRegistry sets the trust registry consulted to check that the issuers of credentials
+// of Verifiable Presentation are accredited and are allowed to issue credentials of their types.
+func WithPresTrustRegistry(registry trustregistry.Registry) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.trustRegistry = registry
+	}
+}
+
+// HolderDelegateChecker checks whether the holder DID is approved to present the credentials of the subject DID
+// on its behalf.
+type HolderDelegateChecker func(subjectDID, holderDID string) bool
+
+// WithHolderBindingCheck requires the Verifiable Presentation to be signed by the subjects of its credentials:
+// the DID of the verification method of every embedded proof (or the "iss" claim of the JWT) must be the DID
+// of the credentialSubject.id of every credential, or a delegate approved by one of the delegate checkers
+// or by the delegation chain (see Capability) embedded into the proof. The signers are bound only once their
+// proofs are verified, the presentation is therefore rejected when combined with WithPresDisabledProofCheck.
+func WithHolderBindingCheck(delegateCheckers ...HolderDelegateChecker) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.holderBinding = true
+		opts.holderDelegates = delegateCheckers
+	}
+}
+
+// ChallengeConsumer validates the challenge issued by the verifier for the domain and consumes it,
+// so that it cannot be used again (e.g. challenge.Manager).
+type ChallengeConsumer interface {
+	Consume(challenge, domain string) error
+}
+
+// challengeChecker is optionally implemented by the challenge consumer to validate the challenges of all the proofs
+// before any of them is consumed (e.g. challenge.Manager).
+type challengeChecker interface {
+	Check(challenge, domain string) error
+}
+
+// WithPresChallengeConsumer requires the embedded proofs of Verifiable Presentation to be signed with a challenge
+// (and domain) issued by the verifier, or a JWT presentation to be signed with the challenge as "nonce" claim
+// (and the domain as "aud" claim). The challenges are consumed once the presentation is verified, so that
+// the presentation cannot be replayed. They are not consumed when the proof check is disabled.
+func WithPresChallengeConsumer(consumer ChallengeConsumer) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.challengeConsumer = consumer
+	}
+}
+
 // ParsePresentation creates an instance of Verifiable Presentation by reading a JSON document from bytes.
 // It also applies miscellaneous options like custom decoders or settings of schema validation.
 func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, error) {
@@ -397,6 +507,24 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 		return nil, err
 	}
 
+	jwtChallenge := vpRaw.jwtChallenge
+
+	var undefinedTerms []string
+
+	if vpOpts.undefinedTermsMode != 0 {
+		undefinedTerms, vpDataDecoded, err = checkUndefinedTerms(vpDataDecoded, &vpOpts.jsonldCredentialOpts)
+		if err != nil {
+			return nil, fmt.Errorf("check undefined terms of presentation: %w", err)
+		}
+	}
+
+	if vpOpts.undefinedTermsMode == UndefinedTermsDrop && len(undefinedTerms) > 0 {
+		_, vpRaw, err = decodeVPFromJSON(vpDataDecoded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = validateVP(vpDataDecoded, vpOpts)
 	if err != nil {
 		return nil, err
@@ -407,13 +535,238 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 		return nil, err
 	}
 
+	p.UndefinedTerms = undefinedTerms
+
 	if vpOpts.requireVC && len(p.credentials) == 0 {
 		return nil, fmt.Errorf("verifiableCredential is required")
 	}
 
+	if vpOpts.trustRegistry != nil {
+		err = checkCredentialIssuers(p.credentials, vpOpts.trustRegistry)
+		if err != nil {
+			return nil, fmt.Errorf("check issuer in trust registry: %w", err)
+		}
+	}
+
+	delegations, err := checkDelegations(p, vpOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if vpOpts.holderBinding {
+		if vpOpts.disabledProofCheck {
+			return nil, errors.New("check holder binding: the proofs of the presentation are not verified")
+		}
+
+		delegates := append([]HolderDelegateChecker{delegations.isDelegate}, vpOpts.holderDelegates...)
+
+		err = checkHolderBinding(p, jwt.IsJWS(string(vpData)), delegates)
+		if err != nil {
+			return nil, fmt.Errorf("check holder binding: %w", err)
+		}
+	}
+
+	if vpOpts.challengeConsumer != nil && !vpOpts.disabledProofCheck {
+		err = consumeChallenges(p.Proofs, jwtChallenge, vpOpts.challengeConsumer)
+		if err != nil {
+			return nil, fmt.Errorf("consume challenge: %w", err)
+		}
+	}
+
 	return p, nil
 }
 
+// consumeChallenges consumes the challenges of the presentation: the "nonce" and "aud" claims of a JWT
+// presentation, the challenges and domains of the embedded proofs otherwise. The challenges are all checked first
+// (if supported by the consumer), so that none is consumed when one of them is invalid.
+func consumeChallenges(proofs []Proof, fromJWT *jwtChallenge, consumer ChallengeConsumer) error {
+	var challenges, domains []string
+
+	switch {
+	case fromJWT != nil:
+		if fromJWT.nonce == "" {
+			return errors.New("presentation JWT has no nonce")
+		}
+
+		if len(fromJWT.audience) > 1 {
+			return errors.New("presentation JWT has several audiences")
+		}
+
+		domain := ""
+		if len(fromJWT.audience) == 1 {
+			domain = fromJWT.audience[0]
+		}
+
+		return consume([]string{fromJWT.nonce}, []string{domain}, consumer)
+	case len(proofs) == 0:
+		return errors.New("presentation has no embedded proof")
+	}
+
+	seen := make(map[string]bool)
+
+	for _, proof := range proofs {
+		challenge, _ := proof["challenge"].(string) //nolint:errcheck
+		if challenge == "" {
+			return errors.New("presentation proof has no challenge")
+		}
+
+		if seen[challenge] {
+			continue
+		}
+
+		domain, _ := proof["domain"].(string) //nolint:errcheck
+
+		challenges = append(challenges, challenge)
+		domains = append(domains, domain)
+		seen[challenge] = true
+	}
+
+	return consume(challenges, domains, consumer)
+}
+
+func consume(challenges, domains []string, consumer ChallengeConsumer) error {
+	if checker, ok := consumer.(challengeChecker); ok {
+		for i, challenge := range challenges {
+			if err := checker.Check(challenge, domains[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, challenge := range challenges {
+		if err := consumer.Consume(challenge, domains[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkHolderBinding checks that the presentation is signed by the subject of each of its credentials
+// or by an approved delegate of the subject.
+func checkHolderBinding(p *Presentation, fromJWS bool, delegates []HolderDelegateChecker) error {
+	var signers []string
+
+	if fromJWS {
+		signers = append(signers, p.Holder)
+	}
+
+	for _, proof := range p.Proofs {
+		if vm := proofVerificationMethod(proof); vm != "" {
+			signers = append(signers, didOf(vm))
+		}
+	}
+
+	if len(signers) == 0 {
+		return errors.New("presentation is not signed")
+	}
+
+	for _, cred := range p.credentials {
+		raw, err := rawCredentialOf(cred)
+		if err != nil {
+			return err
+		}
+
+		subjects, err := parseSubject(raw.Subject)
+		if err != nil {
+			return fmt.Errorf("parse subject of presentation credential: %w", err)
+		}
+
+		for _, signer := range signers {
+			if !boundToSubject(signer, subjects, delegates) {
+				return fmt.Errorf("presentation signer %s is not bound to the subject of credential %s",
+					signer, raw.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// boundToSubject checks that the signer DID is the DID of one of the subjects or its approved delegate.
+func boundToSubject(signer string, subjects []Subject, delegates []HolderDelegateChecker) bool {
+	for _, subject := range subjects {
+		if subject.ID == "" {
+			continue
+		}
+
+		subjectDID := didOf(subject.ID)
+		if subjectDID == signer {
+			return true
+		}
+
+		for _, isDelegate := range delegates {
+			if isDelegate(subjectDID, signer) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// didOf returns the DID of the DID URL (e.g. of the verification method).
+func didOf(didURL string) string {
+	return strings.Split(didURL, "#")[0]
+}
+
+// checkCredentialIssuers checks issuers of the presentation credentials (decoded from JWT
+// or defined in structured form) in the trust registry.
+func checkCredentialIssuers(creds []interface{}, registry trustregistry.Registry) error {
+	for _, cred := range creds {
+		raw, err := rawCredentialOf(cred)
+		if err != nil {
+			return err
+		}
+
+		issuer, err := parseIssuer(raw.Issuer)
+		if err != nil {
+			return fmt.Errorf("parse issuer of presentation credential: %w", err)
+		}
+
+		types, err := decodeType(raw.Type)
+		if err != nil {
+			return fmt.Errorf("decode type of presentation credential: %w", err)
+		}
+
+		err = trustregistry.CheckIssuer(registry, issuer.ID, types)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rawCredentialOf decodes the presentation credential (decoded from JWT or defined in structured form).
+func rawCredentialOf(cred interface{}) (*rawCredential, error) {
+	var (
+		credBytes []byte
+		err       error
+	)
+
+	switch c := cred.(type) {
+	case []byte:
+		credBytes = c
+	case *Credential:
+		credBytes, err = c.MarshalJSON()
+	default:
+		credBytes, err = json.Marshal(c)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential of presentation: %w", err)
+	}
+
+	var raw rawCredential
+
+	if err = json.Unmarshal(credBytes, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal credential of presentation: %w", err)
+	}
+
+	return &raw, nil
+}
+
 func getPresentationOpts(opts []PresentationOpt) *presentationOpts {
 	vpOpts := defaultPresentationOpts()
 
@@ -552,8 +905,7 @@ func validateVPJSONSchema(data []byte) error {
 	}
 
 	if !result.Valid() {
-		errMsg := describeSchemaValidationError(result, "verifiable presentation")
-		return errors.New(errMsg)
+		return describeSchemaValidationError(result, "verifiable presentation")
 	}
 
 	return nil
@@ -568,7 +920,8 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 			return nil, nil, errors.New("public key fetcher is not defined")
 		}
 
-		vcDataFromJwt, rawCred, err := decodeVPFromJWS(vpStr, !vpOpts.disabledProofCheck, vpOpts.publicKeyFetcher)
+		vcDataFromJwt, rawCred, err := decodeVPFromJWS(vpStr, !vpOpts.disabledProofCheck, vpOpts.publicKeyFetcher,
+			vpOpts.jwtTypes, &vpOpts.validityPeriodOpts)
 		if err != nil {
 			return nil, nil, fmt.Errorf("decoding of Verifiable Presentation from JWS: %w", err)
 		}
@@ -584,7 +937,7 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 	}
 
 	if jwt.IsJWTUnsecured(vpStr) {
-		rawBytes, rawPres, err := decodeVPFromUnsecuredJWT(vpStr)
+		rawBytes, rawPres, err := decodeVPFromUnsecuredJWT(vpStr, &vpOpts.validityPeriodOpts)
 		if err != nil {
 			return nil, nil, fmt.Errorf("decoding of Verifiable Presentation from unsecured JWT: %w", err)
 		}
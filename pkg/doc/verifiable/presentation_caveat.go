@@ -0,0 +1,233 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// CaveatTypeExpiry restricts the invocations of the capability to the time before its "expires" (RFC 3339).
+	CaveatTypeExpiry = "Expiry"
+	// CaveatTypeAllowedActions restricts the invocations of the capability to its "allowedAction" actions.
+	CaveatTypeAllowedActions = "AllowedActions"
+	// CaveatTypeInvocationLimit restricts the number of invocations of the capability to its "limit".
+	CaveatTypeInvocationLimit = "InvocationLimit"
+)
+
+// caveatContext maps the caveat types and the caveat terms undefined by the capability context into the security
+// vocabulary, so that the signature of the capability covers its caveats.
+var caveatContext = map[string]interface{}{ //nolint:gochecknoglobals
+	"@vocab": "https://w3id.org/security#",
+}
+
+// Caveat restricts the invocations of a delegated capability, it is evaluated by the CaveatEvaluator of its type.
+// The caveats of all the capabilities of a delegation chain are enforced when the chain is invoked, a delegate
+// cannot lift the caveats of its delegator.
+type Caveat map[string]interface{}
+
+// ExpiryCaveat returns the caveat restricting the invocations of the capability to the time before expires.
+func ExpiryCaveat(expires time.Time) Caveat {
+	return Caveat{"type": CaveatTypeExpiry, "expires": expires.UTC().Format(time.RFC3339)}
+}
+
+// AllowedActionsCaveat returns the caveat restricting the invocations of the capability to the given actions.
+func AllowedActionsCaveat(actions ...string) Caveat {
+	return Caveat{"type": CaveatTypeAllowedActions, "allowedAction": actions}
+}
+
+// InvocationLimitCaveat returns the caveat restricting the number of invocations of the capability.
+// The invocations are counted by the InvocationCounter of the verifier, see WithPresInvocationCounter.
+func InvocationLimitCaveat(limit int) Caveat {
+	return Caveat{"type": CaveatTypeInvocationLimit, "limit": limit}
+}
+
+// Type returns the type of the caveat.
+func (c Caveat) Type() string {
+	caveatType, _ := c["type"].(string) //nolint:errcheck
+
+	return caveatType
+}
+
+// CapabilityInvocation is the invocation of a delegated capability, its caveats are evaluated against it.
+type CapabilityInvocation struct {
+	// Capability invoked, or one of the capabilities it is delegated from.
+	Capability *Capability
+	// Invoker is the DID of the invoker of the delegation chain (e.g. the signer of the presentation).
+	Invoker string
+	// Action is the invoked action, e.g. CapabilityActionPresent.
+	Action string
+	// Time of the invocation.
+	Time time.Time
+}
+
+// CaveatEvaluator checks that the invocation satisfies the caveat, e.g. a spending limit or a biometric
+// confirmation of the invoker.
+type CaveatEvaluator func(caveat Caveat, invocation *CapabilityInvocation) error
+
+// InvocationCounter counts the invocations of the capabilities for their InvocationLimit caveats.
+type InvocationCounter interface {
+	// Increment increments the number of invocations of the capability and returns it.
+	Increment(capabilityID string) (int, error)
+}
+
+// NewInvocationCounter returns an in-memory counter of the invocations of the capabilities.
+func NewInvocationCounter() InvocationCounter {
+	return &memInvocationCounter{counts: map[string]int{}}
+}
+
+type memInvocationCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *memInvocationCounter) Increment(capabilityID string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counts[capabilityID]++
+
+	return c.counts[capabilityID], nil
+}
+
+// WithPresCaveatEvaluator sets the evaluator of the caveats of the given type of the capabilities delegating
+// the presentation, replacing the built-in evaluator if any. The capabilities with caveats of an unknown type
+// are rejected.
+func WithPresCaveatEvaluator(caveatType string, evaluator CaveatEvaluator) PresentationOpt {
+	return func(opts *presentationOpts) {
+		if opts.caveatEvaluators == nil {
+			opts.caveatEvaluators = map[string]CaveatEvaluator{}
+		}
+
+		opts.caveatEvaluators[caveatType] = evaluator
+	}
+}
+
+// WithPresInvocationCounter sets the counter of the invocations of the capabilities delegating the presentation,
+// which is required to enforce their InvocationLimit caveats.
+func WithPresInvocationCounter(counter InvocationCounter) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.invocationCounter = counter
+	}
+}
+
+// checkCaveats evaluates the caveats of the capabilities of the delegation chain invoked by the invoker.
+// The invocations are counted once all the other caveats are satisfied.
+func checkCaveats(chain []*Capability, invoker string, opts *presentationOpts) error {
+	evaluators := map[string]CaveatEvaluator{
+		CaveatTypeExpiry:          evaluateExpiry(opts.leeway),
+		CaveatTypeAllowedActions:  evaluateAllowedActions,
+		CaveatTypeInvocationLimit: evaluateInvocationLimit(opts.invocationCounter),
+	}
+
+	for caveatType, evaluator := range opts.caveatEvaluators {
+		evaluators[caveatType] = evaluator
+	}
+
+	now := opts.now()
+
+	for _, counting := range []bool{false, true} {
+		for _, capability := range chain {
+			invocation := &CapabilityInvocation{
+				Capability: capability,
+				Invoker:    invoker,
+				Action:     CapabilityActionPresent,
+				Time:       now,
+			}
+
+			for _, caveat := range capability.Caveats {
+				if (caveat.Type() == CaveatTypeInvocationLimit) != counting {
+					continue
+				}
+
+				evaluate, ok := evaluators[caveat.Type()]
+				if !ok {
+					return fmt.Errorf("capability %s has unsupported caveat %q", capability.ID, caveat.Type())
+				}
+
+				if err := evaluate(caveat, invocation); err != nil {
+					return fmt.Errorf("capability %s: %s caveat: %w", capability.ID, caveat.Type(), err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func evaluateExpiry(leeway time.Duration) CaveatEvaluator {
+	return func(caveat Caveat, invocation *CapabilityInvocation) error {
+		value, _ := caveat["expires"].(string) //nolint:errcheck
+
+		expires, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("invalid expiry: %w", err)
+		}
+
+		if invocation.Time.After(expires.Add(leeway)) {
+			return fmt.Errorf("capability expired at %s", value)
+		}
+
+		return nil
+	}
+}
+
+func evaluateAllowedActions(caveat Caveat, invocation *CapabilityInvocation) error {
+	var actions []string
+
+	switch value := caveat["allowedAction"].(type) {
+	case []string:
+		actions = value
+	case []interface{}:
+		for _, action := range value {
+			if s, ok := action.(string); ok {
+				actions = append(actions, s)
+			}
+		}
+	case string:
+		actions = []string{value}
+	}
+
+	if !containsAction(actions, invocation.Action) {
+		return fmt.Errorf("action %s is not allowed", invocation.Action)
+	}
+
+	return nil
+}
+
+func evaluateInvocationLimit(counter InvocationCounter) CaveatEvaluator {
+	return func(caveat Caveat, invocation *CapabilityInvocation) error {
+		var limit int
+
+		switch value := caveat["limit"].(type) {
+		case int:
+			limit = value
+		case float64:
+			limit = int(value)
+		default:
+			return errors.New("invalid invocation limit")
+		}
+
+		if counter == nil {
+			return errors.New("invocation counter is not defined")
+		}
+
+		count, err := counter.Increment(invocation.Capability.ID)
+		if err != nil {
+			return fmt.Errorf("count invocation: %w", err)
+		}
+
+		if count > limit {
+			return fmt.Errorf("invocation limit %d exceeded", limit)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+)
+
+const (
+	// CapabilityContext is the JSON-LD context of the authorization capabilities.
+	CapabilityContext = "https://w3id.org/security/v2"
+
+	// CapabilityActionPresent is the action of presenting the credentials of the delegator.
+	CapabilityActionPresent = "present"
+
+	capabilityDelegationPurpose = "capabilityDelegation"
+)
+
+// Capability is an authorization capability (ZCAP-LD) by which the delegator (the signer of the capability proof)
+// delegates the presentation of its credentials to the invoker. The capabilities delegating from the credential
+// subject to the holder are embedded, in order, into the "capabilityChain" of the presentation proof.
+type Capability struct {
+	Context          interface{} `json:"@context,omitempty"`
+	ID               string      `json:"id"`
+	ParentCapability string      `json:"parentCapability,omitempty"`
+	Invoker          string      `json:"invoker"`
+	AllowedAction    []string    `json:"allowedAction,omitempty"`
+	// Caveats restrict the invocations of the capability and of the capabilities delegated from it.
+	Caveats []Caveat `json:"caveat,omitempty"`
+	Proof   Proof    `json:"proof,omitempty"`
+}
+
+// AddLinkedDataProof signs the capability by the delegator.
+func (c *Capability) AddLinkedDataProof(context *LinkedDataProofContext, jsonldOpts ...jsonld.ProcessorOpts) error {
+	if c.Context == nil {
+		c.Context = CapabilityContext
+
+		if len(c.Caveats) > 0 {
+			c.Context = []interface{}{CapabilityContext, caveatContext}
+		}
+	}
+
+	c.Proof = nil
+
+	capBytes, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("add linked data proof to capability: %w", err)
+	}
+
+	delegationContext := *context
+	if delegationContext.Purpose == "" {
+		delegationContext.Purpose = capabilityDelegationPurpose
+	}
+
+	proofs, err := addLinkedDataProof(&delegationContext, capBytes, jsonldOpts...)
+	if err != nil {
+		return err
+	}
+
+	c.Proof = proofs[0]
+
+	return nil
+}
+
+// CapabilityChain converts the signed capabilities delegating from the credential subject to the holder into
+// the capability chain of the presentation proof (see LinkedDataProofContext).
+func CapabilityChain(capabilities ...*Capability) ([]interface{}, error) {
+	chain := make([]interface{}, len(capabilities))
+
+	for i, capability := range capabilities {
+		capMap, err := toMap(capability)
+		if err != nil {
+			return nil, fmt.Errorf("convert capability to map: %w", err)
+		}
+
+		chain[i] = capMap
+	}
+
+	return chain, nil
+}
+
+// delegations are the DIDs of the root delegators (the credential subjects) by the DIDs of the delegates.
+type delegations map[string][]string
+
+func (d delegations) isDelegate(subjectDID, holderDID string) bool {
+	for _, delegator := range d[holderDID] {
+		if delegator == subjectDID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkDelegations verifies the delegation chains embedded into the presentation proofs and returns the
+// delegations to the signers of the presentation.
+func checkDelegations(p *Presentation, opts *presentationOpts) (delegations, error) {
+	result := make(delegations)
+
+	for _, proof := range p.Proofs {
+		chain, ok := proof["capabilityChain"].([]interface{})
+		if !ok || !isDelegationChain(chain) {
+			continue
+		}
+
+		delegate := didOf(proofVerificationMethod(proof))
+
+		delegator, err := checkDelegationChain(chain, delegate, opts)
+		if err != nil {
+			return nil, fmt.Errorf("check delegation chain: %w", err)
+		}
+
+		result[delegate] = append(result[delegate], delegator)
+	}
+
+	return result, nil
+}
+
+// isDelegationChain checks whether the capability chain embeds capabilities (a chain of capability IDs
+// cannot be verified).
+func isDelegationChain(chain []interface{}) bool {
+	for _, element := range chain {
+		if _, ok := element.(map[string]interface{}); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkDelegationChain checks that the capabilities of the chain delegate, one to another, up to the delegate,
+// that the invocation by the delegate satisfies their caveats and returns the DID of the root delegator.
+func checkDelegationChain(chain []interface{}, delegate string, opts *presentationOpts) (string, error) {
+	var rootDelegator string
+
+	var parent *Capability
+
+	capabilities := make([]*Capability, 0, len(chain))
+
+	for _, element := range chain {
+		capMap, ok := element.(map[string]interface{})
+		if !ok {
+			return "", errors.New("capability is not embedded")
+		}
+
+		capability, err := checkCapability(capMap, opts)
+		if err != nil {
+			return "", err
+		}
+
+		delegator := didOf(proofVerificationMethod(capability.Proof))
+
+		if parent == nil {
+			rootDelegator = delegator
+		} else {
+			if capability.ParentCapability != parent.ID {
+				return "", fmt.Errorf("capability %s is not delegated from capability %s", capability.ID, parent.ID)
+			}
+
+			if delegator != didOf(parent.Invoker) {
+				return "", fmt.Errorf("capability %s is not delegated by the invoker of its parent capability",
+					capability.ID)
+			}
+		}
+
+		parent = capability
+		capabilities = append(capabilities, capability)
+	}
+
+	if didOf(parent.Invoker) != delegate {
+		return "", fmt.Errorf("presentation signer %s is not the invoker of capability %s", delegate, parent.ID)
+	}
+
+	if err := checkCaveats(capabilities, delegate, opts); err != nil {
+		return "", err
+	}
+
+	return rootDelegator, nil
+}
+
+// checkCapability checks the proof of the capability and that it delegates the presentation of the credentials.
+func checkCapability(capMap map[string]interface{}, opts *presentationOpts) (*Capability, error) {
+	capBytes, err := json.Marshal(capMap)
+	if err != nil {
+		return nil, fmt.Errorf("marshal capability: %w", err)
+	}
+
+	var capability Capability
+
+	err = json.Unmarshal(capBytes, &capability)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal capability: %w", err)
+	}
+
+	if capability.Invoker == "" || proofVerificationMethod(capability.Proof) == "" {
+		return nil, fmt.Errorf("capability %s must define invoker and be signed", capability.ID)
+	}
+
+	purpose, _ := capability.Proof["proofPurpose"].(string) //nolint:errcheck
+	if purpose != capabilityDelegationPurpose {
+		return nil, fmt.Errorf("capability %s has unexpected proof purpose %q", capability.ID, purpose)
+	}
+
+	if len(capability.AllowedAction) > 0 && !containsAction(capability.AllowedAction, CapabilityActionPresent) {
+		return nil, fmt.Errorf("capability %s does not allow presentation", capability.ID)
+	}
+
+	if opts.disabledProofCheck {
+		return &capability, nil
+	}
+
+	if opts.publicKeyFetcher == nil {
+		return nil, errors.New("public key fetcher is not defined")
+	}
+
+	err = checkLinkedDataProof(capBytes, opts.ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts)
+	if err != nil {
+		return nil, fmt.Errorf("capability %s: %w", capability.ID, err)
+	}
+
+	return &capability, nil
+}
+
+func containsAction(actions []string, action string) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// proofVerificationMethod returns the verification method (or the creator) of the linked data proof.
+func proofVerificationMethod(proof Proof) string {
+	vm, _ := proof["verificationMethod"].(string) //nolint:errcheck
+	if vm == "" {
+		vm, _ = proof["creator"].(string) //nolint:errcheck
+	}
+
+	return vm
+}
@@ -0,0 +1,281 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	delegationSubjectDID  = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+	delegationGuardianDID = "did:example:guardian"
+	delegationHolderDID   = "did:example:enterprise"
+)
+
+func TestParsePresentation_Delegation(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	ldpContext := func(verificationMethod string) *LinkedDataProofContext {
+		return &LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ss,
+			VerificationMethod:      verificationMethod + "#key1",
+		}
+	}
+
+	delegate := func(id, parent, delegator, invoker string, caveats ...Caveat) *Capability {
+		capability := &Capability{
+			ID:               id,
+			ParentCapability: parent,
+			Invoker:          invoker,
+			AllowedAction:    []string{CapabilityActionPresent},
+			Caveats:          caveats,
+		}
+
+		require.NoError(t, capability.AddLinkedDataProof(ldpContext(delegator),
+			jsonld.WithDocumentLoader(createTestJSONLDDocumentLoader())))
+
+		return capability
+	}
+
+	signedVP := func(signerDID string, capabilities ...*Capability) []byte {
+		vp, errParse := newTestPresentation([]byte(validPresentation))
+		require.NoError(t, errParse)
+
+		vp.Holder = signerDID
+
+		proofContext := ldpContext(signerDID)
+
+		proofContext.CapabilityChain, errParse = CapabilityChain(capabilities...)
+		require.NoError(t, errParse)
+
+		require.NoError(t, vp.AddLinkedDataProof(proofContext,
+			jsonld.WithDocumentLoader(createTestJSONLDDocumentLoader())))
+
+		vpBytes, errMarshal := json.Marshal(vp)
+		require.NoError(t, errMarshal)
+
+		return vpBytes
+	}
+
+	parse := func(vpBytes []byte, opts ...PresentationOpt) (*Presentation, error) {
+		return newTestPresentation(vpBytes, append([]PresentationOpt{
+			WithPresEmbeddedSignatureSuites(ss),
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithHolderBindingCheck(),
+		}, opts...)...)
+	}
+
+	guardianCap := delegate("urn:zcap:guardian", "", delegationSubjectDID, delegationGuardianDID)
+	holderCap := delegate("urn:zcap:enterprise", guardianCap.ID, delegationGuardianDID, delegationHolderDID)
+
+	t.Run("presentation by a delegate", func(t *testing.T) {
+		vp, err := parse(signedVP(delegationGuardianDID, guardianCap))
+		require.NoError(t, err)
+		require.Equal(t, delegationGuardianDID, vp.Holder)
+
+		vp, err = parse(signedVP(delegationHolderDID, guardianCap, holderCap))
+		require.NoError(t, err)
+		require.Equal(t, delegationHolderDID, vp.Holder)
+	})
+
+	t.Run("presentation by a delegate without delegation", func(t *testing.T) {
+		_, err := parse(signedVP(delegationHolderDID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "presentation signer did:example:enterprise is not bound")
+	})
+
+	t.Run("invalid delegation chains", func(t *testing.T) {
+		_, err := parse(signedVP(delegationHolderDID, guardianCap))
+		require.EqualError(t, err, "check delegation chain: presentation signer did:example:enterprise "+
+			"is not the invoker of capability urn:zcap:guardian")
+
+		_, err = parse(signedVP(delegationHolderDID, holderCap))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not bound")
+
+		otherCap := delegate("urn:zcap:other", "urn:zcap:unknown", delegationGuardianDID, delegationHolderDID)
+
+		_, err = parse(signedVP(delegationHolderDID, guardianCap, otherCap))
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:other is not delegated "+
+			"from capability urn:zcap:guardian")
+
+		otherCap = delegate("urn:zcap:other", guardianCap.ID, delegationSubjectDID, delegationHolderDID)
+
+		_, err = parse(signedVP(delegationHolderDID, guardianCap, otherCap))
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:other is not delegated "+
+			"by the invoker of its parent capability")
+
+		readCap := &Capability{ID: "urn:zcap:read", Invoker: delegationGuardianDID, AllowedAction: []string{"read"}}
+		require.NoError(t, readCap.AddLinkedDataProof(ldpContext(delegationSubjectDID),
+			jsonld.WithDocumentLoader(createTestJSONLDDocumentLoader())))
+
+		_, err = parse(signedVP(delegationGuardianDID, readCap))
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:read does not allow presentation")
+
+		tamperedCap := *guardianCap
+		tamperedCap.Invoker = delegationHolderDID
+
+		_, err = parse(signedVP(delegationHolderDID, &tamperedCap))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check delegation chain: capability urn:zcap:guardian: "+
+			"check linked data proof")
+
+		unsignedCap := &Capability{ID: "urn:zcap:unsigned", Invoker: delegationGuardianDID}
+
+		_, err = parse(signedVP(delegationGuardianDID, unsignedCap))
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:unsigned must define invoker "+
+			"and be signed")
+	})
+
+	t.Run("caveats", func(t *testing.T) {
+		expires := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+		expiringCap := delegate("urn:zcap:expiring", "", delegationSubjectDID, delegationGuardianDID,
+			ExpiryCaveat(expires), AllowedActionsCaveat(CapabilityActionPresent))
+
+		before := func() time.Time { return expires.Add(-time.Hour) }
+		after := func() time.Time { return expires.Add(time.Hour) }
+
+		_, err := parse(signedVP(delegationGuardianDID, expiringCap), WithPresCurrentTime(before))
+		require.NoError(t, err)
+
+		_, err = parse(signedVP(delegationGuardianDID, expiringCap), WithPresCurrentTime(after))
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:expiring: Expiry caveat: "+
+			"capability expired at 2030-01-01T00:00:00Z")
+
+		// the caveats of the delegator are enforced on the delegate
+		delegatedCap := delegate("urn:zcap:delegated", expiringCap.ID, delegationGuardianDID, delegationHolderDID)
+
+		_, err = parse(signedVP(delegationHolderDID, expiringCap, delegatedCap), WithPresCurrentTime(after))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "capability urn:zcap:expiring: Expiry caveat")
+
+		readCap := delegate("urn:zcap:read", "", delegationSubjectDID, delegationGuardianDID,
+			AllowedActionsCaveat("read"))
+
+		_, err = parse(signedVP(delegationGuardianDID, readCap))
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:read: AllowedActions caveat: "+
+			"action present is not allowed")
+
+		tamperedCap := *expiringCap
+		tamperedCap.Caveats = []Caveat{ExpiryCaveat(expires.AddDate(1, 0, 0))}
+
+		_, err = parse(signedVP(delegationGuardianDID, &tamperedCap))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "capability urn:zcap:expiring: check linked data proof")
+	})
+
+	t.Run("invocation limit caveat", func(t *testing.T) {
+		limitedCap := delegate("urn:zcap:limited", "", delegationSubjectDID, delegationGuardianDID,
+			InvocationLimitCaveat(2))
+		vpBytes := signedVP(delegationGuardianDID, limitedCap)
+
+		_, err := parse(vpBytes)
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:limited: InvocationLimit caveat: "+
+			"invocation counter is not defined")
+
+		counter := NewInvocationCounter()
+
+		for i := 0; i < 2; i++ {
+			_, err = parse(vpBytes, WithPresInvocationCounter(counter))
+			require.NoError(t, err)
+		}
+
+		_, err = parse(vpBytes, WithPresInvocationCounter(counter))
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:limited: InvocationLimit caveat: "+
+			"invocation limit 2 exceeded")
+
+		// the rejected invocations are not counted
+		expiringCap := delegate("urn:zcap:limited-expiring", "", delegationSubjectDID, delegationGuardianDID,
+			InvocationLimitCaveat(1), ExpiryCaveat(time.Now().Add(-time.Hour)))
+		vpBytes = signedVP(delegationGuardianDID, expiringCap)
+		counter = NewInvocationCounter()
+
+		_, err = parse(vpBytes, WithPresInvocationCounter(counter))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Expiry caveat")
+
+		count, err := counter.Increment(expiringCap.ID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		_, err = parse(vpBytes, WithPresInvocationCounter(&failingCounter{}), WithPresLeeway(2*time.Hour))
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:limited-expiring: "+
+			"InvocationLimit caveat: count invocation: counter error")
+	})
+
+	t.Run("custom caveat", func(t *testing.T) {
+		spendingCap := delegate("urn:zcap:spending", "", delegationSubjectDID, delegationGuardianDID,
+			Caveat{"type": "SpendingLimit", "amount": 100})
+		vpBytes := signedVP(delegationGuardianDID, spendingCap)
+
+		_, err := parse(vpBytes)
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:spending has unsupported "+
+			`caveat "SpendingLimit"`)
+
+		spent := 0.0
+
+		spendingLimit := func(spending float64) CaveatEvaluator {
+			return func(caveat Caveat, invocation *CapabilityInvocation) error {
+				require.Equal(t, delegationGuardianDID, invocation.Invoker)
+				require.Equal(t, CapabilityActionPresent, invocation.Action)
+				require.Equal(t, spendingCap.ID, invocation.Capability.ID)
+
+				if spent+spending > caveat["amount"].(float64) {
+					return fmt.Errorf("spending limit %v exceeded", caveat["amount"])
+				}
+
+				spent += spending
+
+				return nil
+			}
+		}
+
+		_, err = parse(vpBytes, WithPresCaveatEvaluator("SpendingLimit", spendingLimit(60)))
+		require.NoError(t, err)
+
+		_, err = parse(vpBytes, WithPresCaveatEvaluator("SpendingLimit", spendingLimit(60)))
+		require.EqualError(t, err, "check delegation chain: capability urn:zcap:spending: SpendingLimit caveat: "+
+			"spending limit 100 exceeded")
+	})
+}
+
+func TestInvocationCounter(t *testing.T) {
+	counter := NewInvocationCounter()
+
+	for i := 1; i <= 3; i++ {
+		count, err := counter.Increment("urn:zcap:1")
+		require.NoError(t, err)
+		require.Equal(t, i, count)
+	}
+
+	count, err := counter.Increment("urn:zcap:2")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+type failingCounter struct{}
+
+func (c *failingCounter) Increment(string) (int, error) {
+	return 0, errors.New("counter error")
+}
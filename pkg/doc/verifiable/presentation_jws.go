@@ -5,15 +5,17 @@ SPDX-License-Identifier: Apache-2.0
 
 package verifiable
 
-// MarshalJWS serializes JWT presentation claims into signed form (JWS).
-func (jpc *JWTPresClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	return marshalJWS(jpc, signatureAlg, signer, keyID)
+// MarshalJWS serializes JWT presentation claims into signed form (JWS), the options customize the JWS headers.
+func (jpc *JWTPresClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	opts ...JWSOpt) (string, error) {
+	return marshalJWS(jpc, signatureAlg, signer, keyID, opts...)
 }
 
-func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetcher) (*JWTPresClaims, error) {
+func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetcher,
+	expectedTypes []string) (*JWTPresClaims, error) {
 	var claims JWTPresClaims
 
-	err := unmarshalJWS(vpJWT, checkProof, fetcher, &claims)
+	err := unmarshalJWS(vpJWT, checkProof, fetcher, nil, expectedTypes, &claims)
 	if err != nil {
 		return nil, err
 	}
@@ -21,8 +23,25 @@ func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetc
 	return &claims, err
 }
 
-func decodeVPFromJWS(vpJWT string, checkProof bool, fetcher PublicKeyFetcher) ([]byte, *rawPresentation, error) {
+func decodeVPFromJWS(vpJWT string, checkProof bool, fetcher PublicKeyFetcher, expectedTypes []string,
+	validityOpts *validityPeriodOpts) ([]byte, *rawPresentation, error) {
 	return decodePresJWT(vpJWT, func(vpJWT string) (*JWTPresClaims, error) {
-		return unmarshalPresJWSClaims(vpJWT, checkProof, fetcher)
-	})
+		claims, err := unmarshalPresJWSClaims(vpJWT, checkProof, fetcher, expectedTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		// the challenge of a JWT presentation is signed into its claims rather than into an embedded proof
+		if claims.Presentation != nil {
+			challenge := &jwtChallenge{nonce: claims.Nonce}
+
+			if claims.Claims != nil {
+				challenge.audience = claims.Audience
+			}
+
+			claims.Presentation.jwtChallenge = challenge
+		}
+
+		return claims, nil
+	}, validityOpts)
 }
@@ -16,6 +16,10 @@ import (
 type JWTPresClaims struct {
 	*jwt.Claims
 
+	// Nonce is the challenge issued by the verifier, signed into the JWT by the holder (see
+	// WithPresChallengeConsumer).
+	Nonce string `json:"nonce,omitempty"`
+
 	Presentation *rawPresentation `json:"vp,omitempty"`
 }
 
@@ -72,13 +76,23 @@ func newJWTPresClaims(vp *Presentation, audience []string, minimizeVP bool) (*JW
 type JWTPresClaimsUnmarshaller func(vpJWT string) (*JWTPresClaims, error)
 
 // decodePresJWT parses JWT from the specified bytes array in compact format using the unmarshaller.
+// Time-based JWT Claims are checked if requested by the validity period options.
 // It returns decoded Verifiable Presentation refined by JWT Claims in raw byte array and rawPresentation form.
-func decodePresJWT(vpJWT string, unmarshaller JWTPresClaimsUnmarshaller) ([]byte, *rawPresentation, error) {
+func decodePresJWT(vpJWT string, unmarshaller JWTPresClaimsUnmarshaller,
+	validityOpts *validityPeriodOpts) ([]byte, *rawPresentation, error) {
 	presClaims, err := unmarshaller(vpJWT)
 	if err != nil {
 		return nil, nil, fmt.Errorf("decode Verifiable Presentation JWT claims: %w", err)
 	}
 
+	if validityOpts != nil && validityOpts.checkValidityPeriod && presClaims.Claims != nil {
+		err = presClaims.Claims.CheckTime(validityOpts.jwtClaimsCheckOpts()...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("check Verifiable Presentation JWT claims: %w",
+				jwtClaimsValidityError(err, validityOpts.now()))
+		}
+	}
+
 	// Apply VC-related claims from JWT.
 	presClaims.refineFromJWTClaims()
 
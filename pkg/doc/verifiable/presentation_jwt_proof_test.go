@@ -117,6 +117,62 @@ func TestParsePresentationFromJWS_EdDSA(t *testing.T) {
 	require.Equal(t, vp, vpFromJWS)
 }
 
+func TestParsePresentationFromJWS_ChallengeConsumer(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vp, err := newTestPresentation([]byte(validPresentation))
+	require.NoError(t, err)
+
+	signedVP := func(nonce string, audience ...string) []byte {
+		jwtClaims, errClaims := vp.JWTClaims(audience, false)
+		require.NoError(t, errClaims)
+
+		jwtClaims.Nonce = nonce
+
+		vpJWS, errJWS := jwtClaims.MarshalJWS(EdDSA, signer, vp.Holder+"#keys-"+keyID)
+		require.NoError(t, errJWS)
+
+		return []byte(vpJWS)
+	}
+
+	challenges := singleUseChallenges{
+		"challenge-1": "verifier.example.com",
+		"challenge-2": "verifier.example.com",
+	}
+
+	parse := func(vpBytes []byte) (*Presentation, error) {
+		return newTestPresentation(vpBytes,
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithPresChallengeConsumer(challenges))
+	}
+
+	vpBytes := signedVP("challenge-1", "verifier.example.com")
+
+	vpFromJWS, err := parse(vpBytes)
+	require.NoError(t, err)
+	require.Equal(t, vp, vpFromJWS)
+	require.NotContains(t, challenges, "challenge-1")
+
+	// replay
+	_, err = parse(vpBytes)
+	require.EqualError(t, err, "consume challenge: challenge is unknown or already used")
+
+	_, err = parse(signedVP("challenge-2", "other.example.com"))
+	require.EqualError(t, err, "consume challenge: challenge was issued for another domain")
+	require.Contains(t, challenges, "challenge-2")
+
+	_, err = parse(signedVP("challenge-2", "verifier.example.com", "other.example.com"))
+	require.EqualError(t, err, "consume challenge: presentation JWT has several audiences")
+
+	_, err = parse(signedVP("", "verifier.example.com"))
+	require.EqualError(t, err, "consume challenge: presentation JWT has no nonce")
+
+	_, err = parse(signedVP("challenge-2", "verifier.example.com"))
+	require.NoError(t, err)
+	require.NotContains(t, challenges, "challenge-2")
+}
+
 func TestParsePresentationFromUnsecuredJWT(t *testing.T) {
 	vpBytes := []byte(validPresentation)
 
@@ -25,6 +25,6 @@ func unmarshalUnsecuredJWTPresClaims(vpJWT string) (*JWTPresClaims, error) {
 	return &claims, nil
 }
 
-func decodeVPFromUnsecuredJWT(vpJWT string) ([]byte, *rawPresentation, error) {
-	return decodePresJWT(vpJWT, unmarshalUnsecuredJWTPresClaims)
+func decodeVPFromUnsecuredJWT(vpJWT string, validityOpts *validityPeriodOpts) ([]byte, *rawPresentation, error) {
+	return decodePresJWT(vpJWT, unmarshalUnsecuredJWTPresClaims, validityOpts)
 }
@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	"testing"
+	"time"
 
 	"github.com/square/go-jose/v3/jwt"
 	"github.com/stretchr/testify/require"
@@ -20,7 +21,7 @@ func TestJWTPresClaims_MarshalUnsecuredJWT(t *testing.T) {
 
 	jws := createCredUnsecuredJWT(t, vp)
 
-	_, rawVC, err := decodeVPFromUnsecuredJWT(jws)
+	_, rawVC, err := decodeVPFromUnsecuredJWT(jws, nil)
 
 	require.NoError(t, err)
 	require.Equal(t, vp.stringJSON(t), rawVC.stringJSON(t))
@@ -33,14 +34,53 @@ func TestDecodeVPFromUnsecuredJWT(t *testing.T) {
 
 		jws := createCredUnsecuredJWT(t, vp)
 
-		vpDecodedBytes, vpRaw, err := decodeVPFromUnsecuredJWT(jws)
+		vpDecodedBytes, vpRaw, err := decodeVPFromUnsecuredJWT(jws, nil)
 		require.NoError(t, err)
 		require.NotNil(t, vpDecodedBytes)
 		require.Equal(t, vp.stringJSON(t), vpRaw.stringJSON(t))
 	})
 
+	t.Run("Check of time-based JWT claims", func(t *testing.T) {
+		vp, err := newTestPresentation([]byte(validPresentation))
+		require.NoError(t, err)
+
+		now := time.Now()
+
+		claims, err := newJWTPresClaims(vp, []string{}, false)
+		require.NoError(t, err)
+
+		claims.Expiry = jwt.NewNumericDate(now)
+
+		rawJWT, err := claims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		_, vpRaw, err := decodeVPFromUnsecuredJWT(rawJWT, nil)
+		require.NoError(t, err)
+		require.NotNil(t, vpRaw)
+
+		validityOpts := &validityPeriodOpts{}
+		validityOpts.setCurrentTime(func() time.Time { return now.Add(-time.Minute) })
+
+		_, vpRaw, err = decodeVPFromUnsecuredJWT(rawJWT, validityOpts)
+		require.NoError(t, err)
+		require.NotNil(t, vpRaw)
+
+		validityOpts.setCurrentTime(func() time.Time { return now.Add(time.Minute) })
+
+		_, vpRaw, err = decodeVPFromUnsecuredJWT(rawJWT, validityOpts)
+		require.ErrorIs(t, err, jwt.ErrExpired)
+		require.Contains(t, err.Error(), "check Verifiable Presentation JWT claims")
+		require.Nil(t, vpRaw)
+
+		validityOpts.setLeeway(2 * time.Minute)
+
+		_, vpRaw, err = decodeVPFromUnsecuredJWT(rawJWT, validityOpts)
+		require.NoError(t, err)
+		require.NotNil(t, vpRaw)
+	})
+
 	t.Run("Invalid serialized unsecured JWT", func(t *testing.T) {
-		vpBytes, vpRaw, err := decodeVPFromUnsecuredJWT("invalid JWS")
+		vpBytes, vpRaw, err := decodeVPFromUnsecuredJWT("invalid JWS", nil)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "decode Verifiable Presentation JWT claims")
 		require.Nil(t, vpBytes)
@@ -56,7 +96,7 @@ func TestDecodeVPFromUnsecuredJWT(t *testing.T) {
 		rawJWT, err := marshalUnsecuredJWT(jose.Headers{}, claims)
 		require.NoError(t, err)
 
-		vpBytes, vpRaw, err := decodeVPFromUnsecuredJWT(rawJWT)
+		vpBytes, vpRaw, err := decodeVPFromUnsecuredJWT(rawJWT, nil)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "decode Verifiable Presentation JWT claims")
 		require.Nil(t, vpBytes)
@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -96,3 +97,212 @@ func TestPresentation_AddLinkedDataProof(t *testing.T) {
 		r.Equal("Ed25519Signature2018", newVPProof["type"])
 	})
 }
+
+func TestParsePresentation_HolderBinding(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	signedVP := func(verificationMethod string) []byte {
+		vp, errParse := newTestPresentation([]byte(validPresentation))
+		require.NoError(t, errParse)
+
+		require.NoError(t, vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ss,
+			VerificationMethod:      verificationMethod,
+		}, jsonld.WithDocumentLoader(createTestJSONLDDocumentLoader())))
+
+		vpBytes, errMarshal := json.Marshal(vp)
+		require.NoError(t, errMarshal)
+
+		return vpBytes
+	}
+
+	parse := func(vpBytes []byte, opts ...PresentationOpt) (*Presentation, error) {
+		return newTestPresentation(vpBytes, append([]PresentationOpt{
+			WithPresEmbeddedSignatureSuites(ss),
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		}, opts...)...)
+	}
+
+	t.Run("signed by the subject", func(t *testing.T) {
+		vp, err := parse(signedVP("did:example:ebfeb1f712ebc6f1c276e12ec21#key1"), WithHolderBindingCheck())
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+	})
+
+	t.Run("signed by another party", func(t *testing.T) {
+		vpBytes := signedVP("did:example:123456#key1")
+
+		vp, err := parse(vpBytes, WithHolderBindingCheck())
+		require.EqualError(t, err, "check holder binding: presentation signer did:example:123456 is not bound "+
+			"to the subject of credential http://example.edu/credentials/58473")
+		require.Nil(t, vp)
+
+		// no binding check by default
+		vp, err = parse(vpBytes)
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+	})
+
+	t.Run("proofs not verified", func(t *testing.T) {
+		vp, err := parse(signedVP("did:example:ebfeb1f712ebc6f1c276e12ec21#key1"), WithHolderBindingCheck(),
+			WithPresDisabledProofCheck())
+		require.EqualError(t, err, "check holder binding: the proofs of the presentation are not verified")
+		require.Nil(t, vp)
+	})
+
+	t.Run("signed by an approved delegate", func(t *testing.T) {
+		vpBytes := signedVP("did:example:123456#key1")
+
+		vp, err := parse(vpBytes, WithHolderBindingCheck(func(subjectDID, holderDID string) bool {
+			return subjectDID == "did:example:ebfeb1f712ebc6f1c276e12ec21" && holderDID == "did:example:123456"
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+
+		vp, err = parse(vpBytes, WithHolderBindingCheck(func(subjectDID, holderDID string) bool {
+			return false
+		}))
+		require.Error(t, err)
+		require.Nil(t, vp)
+	})
+
+	t.Run("unsigned presentation", func(t *testing.T) {
+		vp, err := newTestPresentation([]byte(validPresentation), WithHolderBindingCheck())
+		require.EqualError(t, err, "check holder binding: presentation is not signed")
+		require.Nil(t, vp)
+	})
+
+	t.Run("signed JWT", func(t *testing.T) {
+		rsaSigner, err := newCryptoSigner(kms.RSARS256Type)
+		require.NoError(t, err)
+
+		vp, err := newTestPresentation([]byte(validPresentation))
+		require.NoError(t, err)
+
+		fetcher := WithPresPublicKeyFetcher(holderPublicKeyFetcher(rsaSigner.PublicKeyBytes()))
+
+		vpJWT, err := newTestPresentation([]byte(createCredJWS(t, vp, rsaSigner)), fetcher, WithHolderBindingCheck())
+		require.NoError(t, err)
+		require.NotNil(t, vpJWT)
+
+		vp.Holder = "did:example:123456"
+
+		vpJWT, err = newTestPresentation([]byte(createCredJWS(t, vp, rsaSigner)), fetcher, WithHolderBindingCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "presentation signer did:example:123456 is not bound")
+		require.Nil(t, vpJWT)
+	})
+}
+
+type singleUseChallenges map[string]string
+
+func (c singleUseChallenges) Consume(challenge, domain string) error {
+	issuedDomain, ok := c[challenge]
+	if !ok {
+		return errors.New("challenge is unknown or already used")
+	}
+
+	delete(c, challenge)
+
+	if issuedDomain != domain {
+		return errors.New("challenge was issued for another domain")
+	}
+
+	return nil
+}
+
+func (c singleUseChallenges) Check(challenge, domain string) error {
+	issuedDomain, ok := c[challenge]
+	if !ok {
+		return errors.New("challenge is unknown or already used")
+	}
+
+	if issuedDomain != domain {
+		return errors.New("challenge was issued for another domain")
+	}
+
+	return nil
+}
+
+func TestParsePresentation_ChallengeConsumer(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	signedVP := func(challenge, domain string, otherChallenges ...string) []byte {
+		vp, errParse := newTestPresentation([]byte(validPresentation))
+		require.NoError(t, errParse)
+
+		for _, c := range append([]string{challenge}, otherChallenges...) {
+			require.NoError(t, vp.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				SignatureRepresentation: SignatureJWS,
+				Suite:                   ss,
+				VerificationMethod:      "did:example:ebfeb1f712ebc6f1c276e12ec21#key1",
+				Challenge:               c,
+				Domain:                  domain,
+			}, jsonld.WithDocumentLoader(createTestJSONLDDocumentLoader())))
+		}
+
+		vpBytes, errMarshal := json.Marshal(vp)
+		require.NoError(t, errMarshal)
+
+		return vpBytes
+	}
+
+	challenges := singleUseChallenges{
+		"challenge-1": "verifier.example.com",
+		"challenge-2": "verifier.example.com",
+		"challenge-3": "verifier.example.com",
+	}
+
+	parse := func(vpBytes []byte) (*Presentation, error) {
+		return newTestPresentation(vpBytes,
+			WithPresEmbeddedSignatureSuites(ss),
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithPresChallengeConsumer(challenges))
+	}
+
+	vpBytes := signedVP("challenge-1", "verifier.example.com")
+
+	// the challenges are not consumed when the proof check is disabled
+	vp, err := newTestPresentation(vpBytes, WithPresDisabledProofCheck(), WithPresChallengeConsumer(challenges))
+	require.NoError(t, err)
+	require.NotNil(t, vp)
+	require.Contains(t, challenges, "challenge-1")
+
+	vp, err = parse(vpBytes)
+	require.NoError(t, err)
+	require.NotNil(t, vp)
+
+	// replay
+	_, err = parse(vpBytes)
+	require.EqualError(t, err, "consume challenge: challenge is unknown or already used")
+
+	_, err = parse(signedVP("challenge-2", "other.example.com"))
+	require.EqualError(t, err, "consume challenge: challenge was issued for another domain")
+
+	// none of the challenges is consumed when one of them is invalid
+	_, err = parse(signedVP("challenge-3", "verifier.example.com", "challenge-1"))
+	require.EqualError(t, err, "consume challenge: challenge is unknown or already used")
+	require.Contains(t, challenges, "challenge-3")
+
+	vp, err = parse(signedVP("challenge-3", "verifier.example.com"))
+	require.NoError(t, err)
+	require.NotNil(t, vp)
+	require.NotContains(t, challenges, "challenge-3")
+
+	_, err = parse(signedVP("", ""))
+	require.EqualError(t, err, "consume challenge: presentation proof has no challenge")
+
+	_, err = newTestPresentation([]byte(validPresentation), WithPresChallengeConsumer(challenges))
+	require.EqualError(t, err, "consume challenge: presentation has no embedded proof")
+}
@@ -8,6 +8,7 @@ package verifiable
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
@@ -16,6 +17,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -507,6 +509,55 @@ func TestWithPresJSONLDDocumentLoader(t *testing.T) {
 	require.Equal(t, documentLoader, opts.jsonldDocumentLoader)
 }
 
+func TestWithPresCurrentTime(t *testing.T) {
+	now := time.Now()
+
+	vpOpt := WithPresCurrentTime(func() time.Time { return now })
+	require.NotNil(t, vpOpt)
+
+	opts := &presentationOpts{}
+	vpOpt(opts)
+	require.True(t, opts.checkValidityPeriod)
+	require.Equal(t, now, opts.now())
+}
+
+func TestWithPresLeeway(t *testing.T) {
+	vpOpt := WithPresLeeway(time.Minute)
+	require.NotNil(t, vpOpt)
+
+	opts := &presentationOpts{}
+	vpOpt(opts)
+	require.True(t, opts.checkValidityPeriod)
+	require.Equal(t, time.Minute, opts.leeway)
+}
+
+func TestParsePresentation_TrustRegistry(t *testing.T) {
+	var issuers []string
+
+	registry := trustregistry.RegistryFunc(func(issuerID string) (*trustregistry.IssuerMetadata, error) {
+		issuers = append(issuers, issuerID)
+
+		return &trustregistry.IssuerMetadata{
+			DID:             issuerID,
+			Status:          trustregistry.StatusAccredited,
+			CredentialTypes: []string{"UniversityDegreeCredential"},
+		}, nil
+	})
+
+	vp, err := newTestPresentation([]byte(validPresentation), WithPresTrustRegistry(registry))
+	require.NoError(t, err)
+	require.NotNil(t, vp)
+	require.Equal(t, []string{"https://example.edu/issuers/14"}, issuers)
+
+	vp, err = newTestPresentation([]byte(validPresentation), WithPresTrustRegistry(trustregistry.RegistryFunc(
+		func(issuerID string) (*trustregistry.IssuerMetadata, error) {
+			return &trustregistry.IssuerMetadata{DID: issuerID, Status: trustregistry.StatusRevoked}, nil
+		})))
+	require.ErrorIs(t, err, trustregistry.ErrIssuerNotAccredited)
+	require.Contains(t, err.Error(), "check issuer in trust registry")
+	require.Nil(t, vp)
+}
+
 func TestParseUnverifiedPresentation(t *testing.T) {
 	// happy path
 	vp, err := ParsePresentation([]byte(validPresentation), WithPresDisabledProofCheck())
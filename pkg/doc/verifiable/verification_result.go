@@ -0,0 +1,285 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
+)
+
+// Checks of Verifiable Credential reported by VerifyCredential.
+const (
+	// CheckSignature is the check of the proof (embedded Linked Data proof or JWS).
+	CheckSignature = "signature"
+	// CheckIssuanceWindow is the check of the validity period (issuance and expiration dates).
+	CheckIssuanceWindow = "issuanceWindow"
+	// CheckStatus is the check of the credential status (e.g. revocation).
+	CheckStatus = "status"
+	// CheckSchema is the check of the data model (JSON schema and JSON-LD validation).
+	CheckSchema = "schema"
+	// CheckIssuerTrust is the check of the issuer in the trust registry.
+	CheckIssuerTrust = "issuerTrust"
+)
+
+// Outcomes of the check.
+const (
+	// CheckPassed is the outcome of the successful check.
+	CheckPassed = "passed"
+	// CheckFailed is the outcome of the failed check.
+	CheckFailed = "failed"
+	// CheckSkipped is the outcome of the check which was not made (e.g. it is disabled or not configured).
+	CheckSkipped = "skipped"
+)
+
+// Machine-readable codes of the check outcome.
+const (
+	CodeOK                       = "ok"
+	CodeProofMissing             = "proof_missing"
+	CodeProofInvalid             = "proof_invalid"
+	CodeUnsupportedProofType     = "unsupported_proof_type"
+	CodeProofCheckError          = "proof_check_error"
+	CodeProofCheckDisabled       = "proof_check_disabled"
+	CodeCredentialExpired        = "credential_expired"
+	CodeCredentialNotValidYet    = "credential_not_valid_yet"
+	CodeStatusNotDefined         = "status_not_defined"
+	CodeStatusCheckerNotDefined  = "status_checker_not_defined"
+	CodeCredentialRevoked        = "credential_revoked"
+	CodeStatusCheckError         = "status_check_error"
+	CodeSchemaViolation          = "schema_violation"
+	CodeModelInvalid             = "model_invalid"
+	CodeTrustRegistryNotDefined  = "trust_registry_not_defined"
+	CodeIssuerNotFound           = "issuer_not_found"
+	CodeIssuerNotAccredited      = "issuer_not_accredited"
+	CodeCredentialTypeNotAllowed = "credential_type_not_allowed"
+	CodeTrustRegistryError       = "trust_registry_error"
+)
+
+// CheckResult is the outcome of a single check of Verifiable Credential.
+type CheckResult struct {
+	// Check is the name of the check, e.g. CheckSignature.
+	Check string `json:"check"`
+	// Status is the outcome of the check: CheckPassed, CheckFailed or CheckSkipped.
+	Status string `json:"status"`
+	// Code is the machine-readable code of the outcome, e.g. CodeCredentialExpired.
+	Code string `json:"code"`
+	// Message is the human-readable description of the outcome (e.g. the error message).
+	Message string `json:"message,omitempty"`
+}
+
+// VerificationResult is the report of Verifiable Credential verification with per-check breakdown.
+type VerificationResult struct {
+	// Verified is true if none of the checks failed.
+	Verified bool `json:"verified"`
+	// Checks are the results of signature, issuance window, status, schema and issuer trust checks (in this order).
+	Checks []*CheckResult `json:"checks"`
+	// Credential is the decoded credential.
+	Credential *Credential `json:"-"`
+}
+
+// Check returns the result of the check with the given name, or nil if the check is not reported.
+func (r *VerificationResult) Check(name string) *CheckResult {
+	for _, c := range r.Checks {
+		if c.Check == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (r *VerificationResult) add(c *CheckResult) {
+	r.Checks = append(r.Checks, c)
+}
+
+// VerifyCredential verifies Verifiable Credential from bytes which could be marshalled JSON or serialized JWT.
+// Unlike ParseCredential, it does not stop on the first failed check and reports the outcome
+// of every check: signature, issuance window, status, schema and issuer trust.
+// It accepts the same options as ParseCredential. The issuance window is checked against the current time
+// unless WithCurrentTime is defined. The status and issuer trust checks are skipped unless
+// WithStatusChecker and WithTrustRegistry are defined respectively.
+// An error is returned only if the credential cannot be decoded at all (e.g. it is malformed).
+func VerifyCredential(vcData []byte, opts ...CredentialOpt) (*VerificationResult, error) {
+	vcOpts := getCredentialOpts(opts)
+
+	if !vcOpts.checkValidityPeriod {
+		vcOpts.setCurrentTime(time.Now)
+	}
+
+	result := &VerificationResult{}
+
+	vcDataDecoded, signatureCheck, err := verifyCredentialProof(vcData, vcOpts)
+	if err != nil {
+		return nil, fmt.Errorf("decode new credential: %w", err)
+	}
+
+	var raw rawCredential
+
+	err = json.Unmarshal(vcDataDecoded, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal new credential: %w", err)
+	}
+
+	vc, err := newCredential(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("build new credential: %w", err)
+	}
+
+	result.Credential = vc
+
+	result.add(signatureCheck)
+	result.add(issuanceWindowCheck(checkValidityPeriod(vc.Issued, vc.Expired, &vcOpts.validityPeriodOpts)))
+	result.add(statusCheck(vc, vcOpts.statusChecker))
+	result.add(schemaCheck(validateCredential(vc, vcDataDecoded, vcOpts)))
+	result.add(issuerTrustCheck(vc, vcOpts.trustRegistry))
+
+	result.Verified = true
+
+	for _, c := range result.Checks {
+		if c.Status == CheckFailed {
+			result.Verified = false
+		}
+	}
+
+	return result, nil
+}
+
+// verifyCredentialProof decodes the credential checking its proof. If the proof is not valid, the credential
+// is decoded once again without the proof check to make the rest of checks.
+func verifyCredentialProof(vcData []byte, vcOpts *credentialOpts) ([]byte, *CheckResult, error) {
+	if vcOpts.disabledProofCheck {
+		vcDataDecoded, err := decodeRaw(vcData, vcOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return vcDataDecoded, skippedCheck(CheckSignature, CodeProofCheckDisabled, "proof check is disabled"), nil
+	}
+
+	vcDataDecoded, proofErr := decodeRaw(vcData, vcOpts)
+	if proofErr == nil {
+		if !jwt.IsJWS(string(vcData)) && !hasEmbeddedProof(vcDataDecoded) {
+			return vcDataDecoded, failedCheck(CheckSignature, CodeProofMissing, "proof is not defined"), nil
+		}
+
+		return vcDataDecoded, passedCheck(CheckSignature), nil
+	}
+
+	noProofCheckOpts := *vcOpts
+	noProofCheckOpts.disabledProofCheck = true
+
+	vcDataDecoded, err := decodeRaw(vcData, &noProofCheckOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	code := CodeProofCheckError
+
+	switch {
+	case errors.Is(proofErr, ErrProofInvalid):
+		code = CodeProofInvalid
+	case errors.Is(proofErr, ErrUnsupportedProofType):
+		code = CodeUnsupportedProofType
+	}
+
+	return vcDataDecoded, failedCheck(CheckSignature, code, proofErr.Error()), nil
+}
+
+func hasEmbeddedProof(vcBytes []byte) bool {
+	var doc map[string]interface{}
+
+	if err := json.Unmarshal(vcBytes, &doc); err != nil {
+		return false
+	}
+
+	return doc["proof"] != nil
+}
+
+func issuanceWindowCheck(err error) *CheckResult {
+	if err == nil {
+		return passedCheck(CheckIssuanceWindow)
+	}
+
+	if errors.Is(err, ErrCredentialNotValidYet) {
+		return failedCheck(CheckIssuanceWindow, CodeCredentialNotValidYet, err.Error())
+	}
+
+	return failedCheck(CheckIssuanceWindow, CodeCredentialExpired, err.Error())
+}
+
+func statusCheck(vc *Credential, checker StatusChecker) *CheckResult {
+	if vc.Status == nil {
+		return skippedCheck(CheckStatus, CodeStatusNotDefined, "credential status is not defined")
+	}
+
+	if checker == nil {
+		return skippedCheck(CheckStatus, CodeStatusCheckerNotDefined, "credential status checker is not defined")
+	}
+
+	err := checker(vc.Status)
+	if err == nil {
+		return passedCheck(CheckStatus)
+	}
+
+	if errors.Is(err, ErrCredentialRevoked) {
+		return failedCheck(CheckStatus, CodeCredentialRevoked, err.Error())
+	}
+
+	return failedCheck(CheckStatus, CodeStatusCheckError, err.Error())
+}
+
+func schemaCheck(err error) *CheckResult {
+	if err == nil {
+		return passedCheck(CheckSchema)
+	}
+
+	if errors.Is(err, ErrSchemaValidation) {
+		return failedCheck(CheckSchema, CodeSchemaViolation, err.Error())
+	}
+
+	return failedCheck(CheckSchema, CodeModelInvalid, err.Error())
+}
+
+func issuerTrustCheck(vc *Credential, registry trustregistry.Registry) *CheckResult {
+	if registry == nil {
+		return skippedCheck(CheckIssuerTrust, CodeTrustRegistryNotDefined, "trust registry is not defined")
+	}
+
+	err := trustregistry.CheckIssuer(registry, vc.Issuer.ID, vc.Types)
+	if err == nil {
+		return passedCheck(CheckIssuerTrust)
+	}
+
+	code := CodeTrustRegistryError
+
+	switch {
+	case errors.Is(err, trustregistry.ErrIssuerNotFound):
+		code = CodeIssuerNotFound
+	case errors.Is(err, trustregistry.ErrIssuerNotAccredited):
+		code = CodeIssuerNotAccredited
+	case errors.Is(err, trustregistry.ErrCredentialTypeNotAllowed):
+		code = CodeCredentialTypeNotAllowed
+	}
+
+	return failedCheck(CheckIssuerTrust, code, err.Error())
+}
+
+func passedCheck(check string) *CheckResult {
+	return &CheckResult{Check: check, Status: CheckPassed, Code: CodeOK}
+}
+
+func failedCheck(check, code, message string) *CheckResult {
+	return &CheckResult{Check: check, Status: CheckFailed, Code: code, Message: message}
+}
+
+func skippedCheck(check, code, message string) *CheckResult {
+	return &CheckResult{Check: check, Status: CheckSkipped, Code: code, Message: message}
+}
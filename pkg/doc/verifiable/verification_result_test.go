@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestVerifyCredential(t *testing.T) {
+	validTime := WithCurrentTime(func() time.Time {
+		return time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	vc, publicKeyFetcher := createVCWithLinkedDataProof()
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	t.Run("all checks passed", func(t *testing.T) {
+		registry := trustregistry.RegistryFunc(func(issuerID string) (*trustregistry.IssuerMetadata, error) {
+			return &trustregistry.IssuerMetadata{DID: issuerID, Status: trustregistry.StatusAccredited}, nil
+		})
+
+		result, err := verifyTestCredential(vcBytes, validTime,
+			WithPublicKeyFetcher(publicKeyFetcher),
+			WithTrustRegistry(registry),
+			WithStatusChecker(func(status *TypedID) error {
+				require.Equal(t, "https://example.edu/status/24", status.ID)
+
+				return nil
+			}))
+		require.NoError(t, err)
+		require.True(t, result.Verified)
+		require.Equal(t, vc.ID, result.Credential.ID)
+		require.Len(t, result.Checks, 5)
+
+		for _, c := range result.Checks {
+			require.Equal(t, CheckPassed, c.Status, c.Check)
+			require.Equal(t, CodeOK, c.Code)
+		}
+	})
+
+	t.Run("all checks are reported despite failures", func(t *testing.T) {
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+
+		vcMap["credentialSchema"] = map[string]interface{}{"id": "not a valid schema"}
+
+		invalidVCBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		registry := trustregistry.RegistryFunc(func(issuerID string) (*trustregistry.IssuerMetadata, error) {
+			return nil, trustregistry.ErrIssuerNotFound
+		})
+
+		result, err := verifyTestCredential(invalidVCBytes,
+			WithCurrentTime(func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) }),
+			WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kms.ED25519)),
+			WithTrustRegistry(registry),
+			WithStatusChecker(func(*TypedID) error {
+				return fmt.Errorf("status list: %w", ErrCredentialRevoked)
+			}))
+		require.NoError(t, err)
+		require.False(t, result.Verified)
+
+		requireCheck(t, result, CheckSignature, CheckFailed, CodeProofInvalid)
+		requireCheck(t, result, CheckIssuanceWindow, CheckFailed, CodeCredentialExpired)
+		requireCheck(t, result, CheckStatus, CheckFailed, CodeCredentialRevoked)
+		requireCheck(t, result, CheckSchema, CheckFailed, CodeSchemaViolation)
+		requireCheck(t, result, CheckIssuerTrust, CheckFailed, CodeIssuerNotFound)
+	})
+
+	t.Run("optional checks are skipped", func(t *testing.T) {
+		result, err := verifyTestCredential(vcBytes, validTime, WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.True(t, result.Verified)
+
+		requireCheck(t, result, CheckSignature, CheckSkipped, CodeProofCheckDisabled)
+		requireCheck(t, result, CheckStatus, CheckSkipped, CodeStatusCheckerNotDefined)
+		requireCheck(t, result, CheckIssuerTrust, CheckSkipped, CodeTrustRegistryNotDefined)
+		require.Nil(t, result.Check("unknown"))
+	})
+
+	t.Run("proof is missing", func(t *testing.T) {
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		delete(vcMap, "credentialStatus")
+
+		unsignedVCBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		result, err := verifyTestCredential(unsignedVCBytes, validTime)
+		require.NoError(t, err)
+		require.False(t, result.Verified)
+
+		requireCheck(t, result, CheckSignature, CheckFailed, CodeProofMissing)
+		requireCheck(t, result, CheckStatus, CheckSkipped, CodeStatusNotDefined)
+	})
+
+	t.Run("status check and trust registry errors", func(t *testing.T) {
+		registry := trustregistry.RegistryFunc(func(issuerID string) (*trustregistry.IssuerMetadata, error) {
+			return &trustregistry.IssuerMetadata{DID: issuerID, Status: trustregistry.StatusSuspended}, nil
+		})
+
+		result, err := verifyTestCredential(vcBytes, validTime,
+			WithPublicKeyFetcher(publicKeyFetcher),
+			WithTrustRegistry(registry),
+			WithStatusChecker(func(*TypedID) error {
+				return errors.New("status list is not available")
+			}))
+		require.NoError(t, err)
+		require.False(t, result.Verified)
+
+		requireCheck(t, result, CheckStatus, CheckFailed, CodeStatusCheckError)
+		requireCheck(t, result, CheckIssuerTrust, CheckFailed, CodeIssuerNotAccredited)
+		require.Contains(t, result.Check(CheckStatus).Message, "status list is not available")
+	})
+
+	t.Run("malformed credential", func(t *testing.T) {
+		result, err := verifyTestCredential([]byte("not a credential"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal new credential")
+		require.Nil(t, result)
+	})
+}
+
+func requireCheck(t *testing.T, result *VerificationResult, check, status, code string) {
+	t.Helper()
+
+	c := result.Check(check)
+	require.NotNil(t, c, check)
+	require.Equal(t, status, c.Status, check)
+	require.Equal(t, code, c.Code, check)
+}
+
+func verifyTestCredential(vcData []byte, opts ...CredentialOpt) (*VerificationResult, error) {
+	return VerifyCredential(vcData, append([]CredentialOpt{WithJSONLDDocumentLoader(testDocumentLoader)}, opts...)...)
+}
@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package x509chain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DIDPrefix is the prefix of the did:x509 DIDs.
+	DIDPrefix = "did:x509:"
+
+	didVersion      = "0"
+	policySeparator = "::"
+)
+
+// subject attributes of the did:x509 subject policy.
+//nolint:gochecknoglobals
+var subjectAttributes = map[string]asn1.ObjectIdentifier{
+	"CN":     {2, 5, 4, 3},
+	"C":      {2, 5, 4, 6},
+	"L":      {2, 5, 4, 7},
+	"ST":     {2, 5, 4, 8},
+	"STREET": {2, 5, 4, 9},
+	"O":      {2, 5, 4, 10},
+	"OU":     {2, 5, 4, 11},
+}
+
+// VerifyDID verifies that the certificate chain, the certificate of the signing key first, matches the did:x509
+// DID (did:x509:0:{alg}:{CA fingerprint}::{policy}:{value}...): one of the CA certificates of the chain has the
+// fingerprint of the DID and the certificate of the signing key satisfies all the policies of the DID (subject,
+// san and eku).
+func VerifyDID(did string, chain []*x509.Certificate) error {
+	if !strings.HasPrefix(did, DIDPrefix) {
+		return fmt.Errorf("%s is not a did:x509 DID", did)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(did, DIDPrefix), policySeparator)
+	if len(parts) < 2 { //nolint:gomnd
+		return fmt.Errorf("did:x509 %s has no policy", did)
+	}
+
+	if len(chain) < 2 { //nolint:gomnd
+		return errors.New("certificate chain has no CA certificate")
+	}
+
+	if err := verifyCAFingerprint(parts[0], chain[1:]); err != nil {
+		return fmt.Errorf("did:x509 %s: %w", did, err)
+	}
+
+	for _, policy := range parts[1:] {
+		if err := verifyPolicy(policy, chain[0]); err != nil {
+			return fmt.Errorf("did:x509 %s: %w", did, err)
+		}
+	}
+
+	return nil
+}
+
+func verifyCAFingerprint(fingerprint string, cas []*x509.Certificate) error {
+	parts := strings.Split(fingerprint, ":")
+	if len(parts) != 3 || parts[0] != didVersion { //nolint:gomnd
+		return fmt.Errorf("unsupported CA fingerprint %s", fingerprint)
+	}
+
+	var newHash func() hash.Hash
+
+	switch parts[1] {
+	case "sha256":
+		newHash = sha256.New
+	case "sha384":
+		newHash = sha512.New384
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return fmt.Errorf("unsupported CA fingerprint algorithm %s", parts[1])
+	}
+
+	expected, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("decode CA fingerprint: %w", err)
+	}
+
+	for _, ca := range cas {
+		h := newHash()
+		h.Write(ca.Raw) //nolint:errcheck // hash.Hash never returns an error
+
+		if bytes.Equal(h.Sum(nil), expected) {
+			return nil
+		}
+	}
+
+	return errors.New("no CA certificate of the chain matches the CA fingerprint")
+}
+
+func verifyPolicy(policy string, cert *x509.Certificate) error {
+	parts := strings.Split(policy, ":")
+
+	values := make([]string, len(parts)-1)
+
+	for i, part := range parts[1:] {
+		value, err := url.PathUnescape(part)
+		if err != nil {
+			return fmt.Errorf("decode %s policy value: %w", parts[0], err)
+		}
+
+		values[i] = value
+	}
+
+	switch parts[0] {
+	case "subject":
+		return verifySubjectPolicy(values, cert)
+	case "san":
+		return verifySANPolicy(values, cert)
+	case "eku":
+		return verifyEKUPolicy(values, cert)
+	default:
+		return fmt.Errorf("unsupported policy %s", parts[0])
+	}
+}
+
+func verifySubjectPolicy(values []string, cert *x509.Certificate) error {
+	if len(values) == 0 || len(values)%2 != 0 {
+		return errors.New("subject policy must list key:value pairs")
+	}
+
+	for i := 0; i < len(values); i += 2 {
+		oid, ok := subjectAttributes[values[i]]
+		if !ok {
+			return fmt.Errorf("unsupported subject policy attribute %s", values[i])
+		}
+
+		if !hasSubjectAttribute(cert, oid, values[i+1]) {
+			return fmt.Errorf("subject %s does not match %s=%s", cert.Subject, values[i], values[i+1])
+		}
+	}
+
+	return nil
+}
+
+func hasSubjectAttribute(cert *x509.Certificate, oid asn1.ObjectIdentifier, value string) bool {
+	for _, name := range cert.Subject.Names {
+		if name.Type.Equal(oid) && fmt.Sprint(name.Value) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func verifySANPolicy(values []string, cert *x509.Certificate) error {
+	if len(values) != 2 { //nolint:gomnd
+		return errors.New("san policy must be san:{type}:{value}")
+	}
+
+	var names []string
+
+	switch values[0] {
+	case "email":
+		names = cert.EmailAddresses
+	case "dns":
+		names = cert.DNSNames
+	case "uri":
+		for _, uri := range cert.URIs {
+			names = append(names, uri.String())
+		}
+	default:
+		return fmt.Errorf("unsupported san policy type %s", values[0])
+	}
+
+	for _, name := range names {
+		if name == values[1] {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no %s subject alternative name matches %s", values[0], values[1])
+}
+
+func verifyEKUPolicy(values []string, cert *x509.Certificate) error {
+	if len(values) != 1 {
+		return errors.New("eku policy must be eku:{OID}")
+	}
+
+	var oids []string
+
+	for _, oid := range cert.UnknownExtKeyUsage {
+		oids = append(oids, oid.String())
+	}
+
+	for _, usage := range cert.ExtKeyUsage {
+		if oid, ok := extKeyUsageOIDs[usage]; ok {
+			oids = append(oids, oid)
+		}
+	}
+
+	for _, oid := range oids {
+		if oid == values[0] {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("extended key usage %s not found", values[0])
+}
+
+// extKeyUsageOIDs are the OIDs of the extended key usages known by the crypto/x509 package.
+//nolint:gochecknoglobals
+var extKeyUsageOIDs = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageServerAuth:      "1.3.6.1.5.5.7.3.1",
+	x509.ExtKeyUsageClientAuth:      "1.3.6.1.5.5.7.3.2",
+	x509.ExtKeyUsageCodeSigning:     "1.3.6.1.5.5.7.3.3",
+	x509.ExtKeyUsageEmailProtection: "1.3.6.1.5.5.7.3.4",
+	x509.ExtKeyUsageTimeStamping:    "1.3.6.1.5.5.7.3.8",
+	x509.ExtKeyUsageOCSPSigning:     "1.3.6.1.5.5.7.3.9",
+}
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package x509chain
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyDID(t *testing.T) {
+	root := newTestCA(t, "Root CA", 1, nil)
+	intermediate := newTestCA(t, "Intermediate CA", 2, root)
+	leaf := newTestLeaf(t, 3, intermediate)
+
+	chain := []*x509.Certificate{leaf.cert, intermediate.cert, root.cert}
+
+	fingerprint := sha256.Sum256(root.cert.Raw)
+	prefix := "did:x509:0:sha256:" + base64.RawURLEncoding.EncodeToString(fingerprint[:])
+
+	t.Run("test matching policies", func(t *testing.T) {
+		for _, did := range []string{
+			prefix + "::subject:CN:Issuer",
+			prefix + "::subject:O:Example%20Org:C:DE",
+			prefix + "::san:email:issuer%40example.com",
+			prefix + "::san:dns:issuer.example.com",
+			prefix + "::eku:1.3.6.1.5.5.7.3.3",
+			prefix + "::subject:CN:Issuer::eku:1.3.6.1.5.5.7.3.3",
+		} {
+			require.NoError(t, VerifyDID(did, chain), did)
+		}
+	})
+
+	t.Run("test mismatching policies", func(t *testing.T) {
+		for did, errMsg := range map[string]string{
+			prefix + "::subject:CN:Other":               "subject CN=Issuer,O=Example Org,C=DE does not match CN=Other",
+			prefix + "::subject:CN":                     "subject policy must list key:value pairs",
+			prefix + "::subject:SN:Issuer":              "unsupported subject policy attribute SN",
+			prefix + "::san:email:other%40example.com":  "no email subject alternative name matches other@example.com",
+			prefix + "::san:uri:https%3A%2F%2Fexample": "no uri subject alternative name matches https://example",
+			prefix + "::san:ip:127.0.0.1":               "unsupported san policy type ip",
+			prefix + "::san:dns":                        "san policy must be san:{type}:{value}",
+			prefix + "::eku:1.3.6.1.5.5.7.3.1":          "extended key usage 1.3.6.1.5.5.7.3.1 not found",
+			prefix + "::eku":                            "eku policy must be eku:{OID}",
+			prefix + "::fulcio-issuer:example":          "unsupported policy fulcio-issuer",
+			prefix + "::subject:CN:%zz":                 "decode subject policy value",
+		} {
+			err := VerifyDID(did, chain)
+			require.Error(t, err, did)
+			require.Contains(t, err.Error(), errMsg)
+		}
+	})
+
+	t.Run("test CA fingerprint", func(t *testing.T) {
+		intermediateFingerprint := sha256.Sum256(intermediate.cert.Raw)
+		did := "did:x509:0:sha256:" + base64.RawURLEncoding.EncodeToString(intermediateFingerprint[:]) +
+			"::subject:CN:Issuer"
+		require.NoError(t, VerifyDID(did, chain))
+
+		leafFingerprint := sha256.Sum256(leaf.cert.Raw)
+		did = "did:x509:0:sha256:" + base64.RawURLEncoding.EncodeToString(leafFingerprint[:]) + "::subject:CN:Issuer"
+
+		err := VerifyDID(did, chain)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no CA certificate of the chain matches the CA fingerprint")
+
+		for did, errMsg := range map[string]string{
+			"did:x509:0:md5:abc::subject:CN:Issuer":    "unsupported CA fingerprint algorithm md5",
+			"did:x509:1:sha256:abc::subject:CN:Issuer": "unsupported CA fingerprint 1:sha256:abc",
+			"did:x509:0:sha256:%::subject:CN:Issuer":   "decode CA fingerprint",
+		} {
+			err = VerifyDID(did, chain)
+			require.Error(t, err, did)
+			require.Contains(t, err.Error(), errMsg)
+		}
+	})
+
+	t.Run("test invalid DID", func(t *testing.T) {
+		require.EqualError(t, VerifyDID("did:example:123", chain), "did:example:123 is not a did:x509 DID")
+		require.EqualError(t, VerifyDID(prefix, chain), "did:x509 "+prefix+" has no policy")
+		require.EqualError(t, VerifyDID(prefix+"::subject:CN:Issuer", chain[:1]),
+			"certificate chain has no CA certificate")
+	})
+}
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package x509chain validates the X.509 certificate chains of the issuers identified by an "x5c" JOSE header or by
+// a did:x509 DID, against configurable trust anchors and with pluggable revocation checks (OCSP, CRL).
+package x509chain
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RevocationChecker checks that the certificate, issued by the issuer certificate, is not revoked. It is the hook
+// for OCSP requests or CRL lookups.
+type RevocationChecker func(cert, issuer *x509.Certificate) error
+
+// Option configures the Validator.
+type Option func(v *Validator)
+
+// WithTrustAnchors adds the root certificates the chains must lead to.
+func WithTrustAnchors(anchors ...*x509.Certificate) Option {
+	return func(v *Validator) {
+		for _, anchor := range anchors {
+			v.roots.AddCert(anchor)
+		}
+	}
+}
+
+// WithRevocationChecker sets the revocation check of the certificates of the chains, no revocation check is done
+// by default.
+func WithRevocationChecker(checker RevocationChecker) Option {
+	return func(v *Validator) {
+		v.revocationChecker = checker
+	}
+}
+
+// WithCurrentTime sets the time the certificates must be valid at, the current time by default.
+func WithCurrentTime(now func() time.Time) Option {
+	return func(v *Validator) {
+		v.now = now
+	}
+}
+
+// Validator validates X.509 certificate chains against trust anchors.
+type Validator struct {
+	roots             *x509.CertPool
+	revocationChecker RevocationChecker
+	now               func() time.Time
+}
+
+// New creates a new X.509 certificate chain Validator.
+func New(opts ...Option) *Validator {
+	v := &Validator{
+		roots: x509.NewCertPool(),
+		now:   time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Validate validates the chain, the certificate of the signing key first followed by the certificates issuing
+// it, against the trust anchors and returns the verified chain, ending with a trust anchor.
+func (v *Validator) Validate(chain []*x509.Certificate) ([]*x509.Certificate, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("certificate chain is empty")
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	verifiedChains, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   v.now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify certificate chain: %w", err)
+	}
+
+	verified := verifiedChains[0]
+
+	if v.revocationChecker != nil {
+		for i := 0; i < len(verified)-1; i++ {
+			if errRevoked := v.revocationChecker(verified[i], verified[i+1]); errRevoked != nil {
+				return nil, fmt.Errorf("revocation check of certificate %s: %w", verified[i].Subject, errRevoked)
+			}
+		}
+	}
+
+	return verified, nil
+}
+
+// ParseX5C parses the value of an "x5c" JOSE header: an array of base64 (not base64url) encoded DER certificates.
+func ParseX5C(x5c interface{}) ([]*x509.Certificate, error) {
+	var encoded []string
+
+	switch value := x5c.(type) {
+	case []string:
+		encoded = value
+	case []interface{}:
+		for _, e := range value {
+			s, ok := e.(string)
+			if !ok {
+				return nil, errors.New("x5c certificate is not a string")
+			}
+
+			encoded = append(encoded, s)
+		}
+	default:
+		return nil, errors.New("x5c is not an array")
+	}
+
+	chain := make([]*x509.Certificate, len(encoded))
+
+	for i, e := range encoded {
+		der, err := base64.StdEncoding.DecodeString(e)
+		if err != nil {
+			return nil, fmt.Errorf("decode x5c certificate: %w", err)
+		}
+
+		chain[i], err = x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parse x5c certificate: %w", err)
+		}
+	}
+
+	return chain, nil
+}
+
+// NewCRLChecker creates a RevocationChecker looking up the certificates in the CRLs. The CRL of the issuer must be
+// signed by the issuer and not be expired, the certificates issued by issuers without CRL are not checked.
+func NewCRLChecker(crls ...*pkix.CertificateList) RevocationChecker {
+	return func(cert, issuer *x509.Certificate) error {
+		for _, crl := range crls {
+			if crl.TBSCertList.Issuer.String() != issuer.Subject.ToRDNSequence().String() {
+				continue
+			}
+
+			if err := issuer.CheckCRLSignature(crl); err != nil {
+				return fmt.Errorf("check CRL signature: %w", err)
+			}
+
+			if crl.HasExpired(time.Now()) {
+				return fmt.Errorf("CRL of %s has expired", issuer.Subject)
+			}
+
+			for _, revoked := range crl.TBSCertList.RevokedCertificates {
+				if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+					return fmt.Errorf("serial number %s listed in CRL", cert.SerialNumber)
+				}
+			}
+		}
+
+		return nil
+	}
+}
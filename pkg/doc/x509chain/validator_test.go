@@ -0,0 +1,204 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package x509chain
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  ed25519.PrivateKey
+}
+
+func newTestCert(t *testing.T, template *x509.Certificate, issuer *testCert) *testCert {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour)
+		template.NotAfter = time.Now().Add(time.Hour)
+	}
+
+	parent, signer := template, priv
+	if issuer != nil {
+		parent, signer = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: priv}
+}
+
+func newTestCA(t *testing.T, name string, serial int64, issuer *testCert) *testCert {
+	t.Helper()
+
+	return newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: name},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}, issuer)
+}
+
+func newTestLeaf(t *testing.T, serial int64, issuer *testCert) *testCert {
+	t.Helper()
+
+	return newTestCert(t, &x509.Certificate{
+		SerialNumber:   big.NewInt(serial),
+		Subject:        pkix.Name{CommonName: "Issuer", Organization: []string{"Example Org"}, Country: []string{"DE"}},
+		EmailAddresses: []string{"issuer@example.com"},
+		DNSNames:       []string{"issuer.example.com"},
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+	}, issuer)
+}
+
+func TestValidator_Validate(t *testing.T) {
+	root := newTestCA(t, "Root CA", 1, nil)
+	intermediate := newTestCA(t, "Intermediate CA", 2, root)
+	leaf := newTestLeaf(t, 3, intermediate)
+
+	t.Run("test valid chain", func(t *testing.T) {
+		verified, err := New(WithTrustAnchors(root.cert)).Validate([]*x509.Certificate{leaf.cert, intermediate.cert})
+		require.NoError(t, err)
+		require.Equal(t, []*x509.Certificate{leaf.cert, intermediate.cert, root.cert}, verified)
+	})
+
+	t.Run("test untrusted chain", func(t *testing.T) {
+		other := newTestCA(t, "Other CA", 4, nil)
+
+		_, err := New(WithTrustAnchors(other.cert)).Validate([]*x509.Certificate{leaf.cert, intermediate.cert})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify certificate chain")
+
+		_, err = New().Validate(nil)
+		require.EqualError(t, err, "certificate chain is empty")
+	})
+
+	t.Run("test expired chain", func(t *testing.T) {
+		v := New(WithTrustAnchors(root.cert), WithCurrentTime(func() time.Time { return time.Now().Add(2 * time.Hour) }))
+
+		_, err := v.Validate([]*x509.Certificate{leaf.cert, intermediate.cert})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify certificate chain")
+	})
+
+	t.Run("test revocation checker", func(t *testing.T) {
+		var checked []string
+
+		v := New(WithTrustAnchors(root.cert), WithRevocationChecker(func(cert, issuer *x509.Certificate) error {
+			checked = append(checked, cert.Subject.CommonName+" by "+issuer.Subject.CommonName)
+
+			return nil
+		}))
+
+		_, err := v.Validate([]*x509.Certificate{leaf.cert, intermediate.cert})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Issuer by Intermediate CA", "Intermediate CA by Root CA"}, checked)
+
+		v = New(WithTrustAnchors(root.cert), WithRevocationChecker(func(cert, issuer *x509.Certificate) error {
+			return errors.New("OCSP responder unavailable")
+		}))
+
+		_, err = v.Validate([]*x509.Certificate{leaf.cert, intermediate.cert})
+		require.EqualError(t, err, "revocation check of certificate CN=Issuer,O=Example Org,C=DE: "+
+			"OCSP responder unavailable")
+	})
+}
+
+func TestNewCRLChecker(t *testing.T) {
+	root := newTestCA(t, "Root CA", 1, nil)
+	leaf := newTestLeaf(t, 3, root)
+	other := newTestCA(t, "Other CA", 4, nil)
+
+	newCRL := func(issuer *testCert, nextUpdate time.Time, serials ...int64) *pkix.CertificateList {
+		revoked := make([]pkix.RevokedCertificate, len(serials))
+		for i, serial := range serials {
+			revoked[i] = pkix.RevokedCertificate{SerialNumber: big.NewInt(serial), RevocationTime: time.Now()}
+		}
+
+		der, err := issuer.cert.CreateCRL(rand.Reader, issuer.key, revoked, time.Now(), nextUpdate)
+		require.NoError(t, err)
+
+		crl, err := x509.ParseCRL(der)
+		require.NoError(t, err)
+
+		return crl
+	}
+
+	nextUpdate := time.Now().Add(time.Hour)
+
+	require.NoError(t, NewCRLChecker(newCRL(root, nextUpdate, 5))(leaf.cert, root.cert))
+	require.NoError(t, NewCRLChecker(newCRL(other, nextUpdate, 3))(leaf.cert, root.cert))
+
+	err := NewCRLChecker(newCRL(root, nextUpdate, 3))(leaf.cert, root.cert)
+	require.EqualError(t, err, "serial number 3 listed in CRL")
+
+	err = NewCRLChecker(newCRL(root, time.Now().Add(-time.Minute)))(leaf.cert, root.cert)
+	require.EqualError(t, err, "CRL of CN=Root CA has expired")
+
+	forged := newCRL(root, nextUpdate)
+	forged.SignatureValue.Bytes = make([]byte, ed25519.SignatureSize)
+
+	err = NewCRLChecker(forged)(leaf.cert, root.cert)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "check CRL signature")
+
+	// a revoked intermediate CA fails the chain validation
+	intermediate := newTestCA(t, "Intermediate CA", 2, root)
+	leaf = newTestLeaf(t, 3, intermediate)
+
+	v := New(WithTrustAnchors(root.cert), WithRevocationChecker(NewCRLChecker(newCRL(root, nextUpdate, 2))))
+
+	_, err = v.Validate([]*x509.Certificate{leaf.cert, intermediate.cert})
+	require.EqualError(t, err, "revocation check of certificate CN=Intermediate CA: serial number 2 listed in CRL")
+}
+
+func TestParseX5C(t *testing.T) {
+	root := newTestCA(t, "Root CA", 1, nil)
+	encoded := base64.StdEncoding.EncodeToString(root.cert.Raw)
+
+	chain, err := ParseX5C([]string{encoded})
+	require.NoError(t, err)
+	require.Equal(t, []*x509.Certificate{root.cert}, chain)
+
+	chain, err = ParseX5C([]interface{}{encoded})
+	require.NoError(t, err)
+	require.Equal(t, []*x509.Certificate{root.cert}, chain)
+
+	_, err = ParseX5C(encoded)
+	require.EqualError(t, err, "x5c is not an array")
+
+	_, err = ParseX5C([]interface{}{1})
+	require.EqualError(t, err, "x5c certificate is not a string")
+
+	_, err = ParseX5C([]string{"%"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode x5c certificate")
+
+	_, err = ParseX5C([]string{base64.StdEncoding.EncodeToString([]byte("certificate"))})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parse x5c certificate")
+}
@@ -15,15 +15,34 @@ import (
 // ErrNotFound is returned when a DID resolver does not find the DID.
 var ErrNotFound = errors.New("DID not found")
 
+// ErrOperationNotSupported is returned when the VDR of a DID method does not support the operation.
+var ErrOperationNotSupported = errors.New("operation not supported")
+
 // DIDCommServiceType default DID Communication service endpoint type.
 const DIDCommServiceType = "did-communication"
 
+// Operation is a DID operation of a VDR.
+type Operation string
+
+const (
+	// Read resolves a DID.
+	Read Operation = "read"
+	// Create creates a DID.
+	Create Operation = "create"
+	// Update updates a DID document.
+	Update Operation = "update"
+	// Deactivate deactivates a DID.
+	Deactivate Operation = "deactivate"
+)
+
 // Registry vdr registry.
 type Registry interface {
 	Resolve(did string, opts ...DIDMethodOption) (*did.DocResolution, error)
 	Create(method string, did *did.Doc, opts ...DIDMethodOption) (*did.DocResolution, error)
 	Update(did *did.Doc, opts ...DIDMethodOption) error
 	Deactivate(did string, opts ...DIDMethodOption) error
+	// Supports reports whether the VDR of the DID method supports the operation.
+	Supports(method string, op Operation) bool
 	Close() error
 }
 
@@ -35,6 +54,8 @@ type VDR interface {
 	Accept(method string) bool
 	Update(did *did.Doc, opts ...DIDMethodOption) error
 	Deactivate(did string, opts ...DIDMethodOption) error
+	// Supports reports whether the operation is supported by the VDR, unsupported operations fail.
+	Supports(op Operation) bool
 	Close() error
 }
 
@@ -0,0 +1,414 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package config loads the configuration of an agent from YAML or JSON files and environment variables, and
+// builds the framework options from it.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+// Format is the format of a configuration file.
+type Format string
+
+const (
+	// JSON format.
+	JSON Format = "json"
+	// YAML format.
+	YAML Format = "yaml"
+)
+
+// transport schemes.
+const (
+	HTTPScheme      = "http"
+	WebsocketScheme = "ws"
+)
+
+// storage types.
+const (
+	StorageTypeMem = "mem"
+
+	defaultStorageTimeout = 30
+)
+
+// secret lock types of the KMS.
+const (
+	SecretLockNoop  = "noop"
+	SecretLockLocal = "local"
+)
+
+// environment variables overriding the configuration.
+const (
+	LogLevelEnvKey             = "ARIESD_LOG_LEVEL"
+	DefaultLabelEnvKey         = "ARIESD_DEFAULT_LABEL"
+	AutoAcceptEnvKey           = "ARIESD_AUTO_ACCEPT"
+	WebhookURLEnvKey           = "ARIESD_WEBHOOK_URL"
+	TransportReturnRouteEnvKey = "ARIESD_TRANSPORT_RETURN_ROUTE"
+	DatabaseTypeEnvKey         = "ARIESD_DATABASE_TYPE"
+	DatabasePrefixEnvKey       = "ARIESD_DATABASE_PREFIX"
+	DatabaseTimeoutEnvKey      = "ARIESD_DATABASE_TIMEOUT"
+	InboundHostEnvKey          = "ARIESD_INBOUND_HOST"
+	InboundHostExternalEnvKey  = "ARIESD_INBOUND_HOST_EXTERNAL"
+	OutboundTransportEnvKey    = "ARIESD_OUTBOUND_TRANSPORT"
+	HTTPResolverEnvKey         = "ARIESD_HTTP_RESOLVER"
+	TLSCertFileEnvKey          = "TLS_CERT_FILE"
+	TLSKeyFileEnvKey           = "TLS_KEY_FILE"
+	KMSSecretLockEnvKey        = "ARIESD_KMS_SECRET_LOCK"
+	KMSMasterKeyPathEnvKey     = "ARIESD_KMS_MASTER_KEY_PATH"
+)
+
+// Config is the configuration of an agent.
+type Config struct {
+	LogLevel             string          `json:"logLevel,omitempty" yaml:"logLevel,omitempty"`
+	DefaultLabel         string          `json:"defaultLabel,omitempty" yaml:"defaultLabel,omitempty"`
+	AutoAccept           bool            `json:"autoAccept,omitempty" yaml:"autoAccept,omitempty"`
+	WebhookURLs          []string        `json:"webhookURLs,omitempty" yaml:"webhookURLs,omitempty"`
+	TransportReturnRoute string          `json:"transportReturnRoute,omitempty" yaml:"transportReturnRoute,omitempty"`
+	Storage              StorageConfig   `json:"storage" yaml:"storage"`
+	Inbound              []InboundConfig `json:"inbound,omitempty" yaml:"inbound,omitempty"`
+	Outbound             []string        `json:"outbound,omitempty" yaml:"outbound,omitempty"`
+	TLS                  TLSConfig       `json:"tls" yaml:"tls"`
+	VDRs                 []VDRConfig     `json:"vdrs,omitempty" yaml:"vdrs,omitempty"`
+	KMS                  KMSConfig       `json:"kms" yaml:"kms"`
+}
+
+// StorageConfig is the configuration of the storage, the timeout is the time in seconds to wait until the storage
+// is available.
+type StorageConfig struct {
+	Type    string `json:"type,omitempty" yaml:"type,omitempty"`
+	Prefix  string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Timeout uint64 `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// InboundConfig is the configuration of an inbound transport, the external host defaults to the host.
+type InboundConfig struct {
+	Scheme       string `json:"scheme" yaml:"scheme"`
+	Host         string `json:"host" yaml:"host"`
+	ExternalHost string `json:"externalHost,omitempty" yaml:"externalHost,omitempty"`
+}
+
+// TLSConfig is the TLS configuration of the inbound transports.
+type TLSConfig struct {
+	CertFile string `json:"certFile,omitempty" yaml:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty" yaml:"keyFile,omitempty"`
+}
+
+// VDRConfig is the configuration of an HTTP binding resolver of a DID method.
+type VDRConfig struct {
+	Method string `json:"method" yaml:"method"`
+	URL    string `json:"url" yaml:"url"`
+}
+
+// KMSConfig is the configuration of the KMS secret lock, the local secret lock reads its master key from the
+// master key file.
+type KMSConfig struct {
+	SecretLock    string `json:"secretLock,omitempty" yaml:"secretLock,omitempty"`
+	MasterKeyPath string `json:"masterKeyPath,omitempty" yaml:"masterKeyPath,omitempty"`
+}
+
+// Load reads the configuration file, the format is given by the extension of the file (.json, .yaml or .yml).
+// The environment variables override the values of the file.
+func Load(path string, opts ...Option) (*Config, error) {
+	var format Format
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = JSON
+	case ".yaml", ".yml":
+		format = YAML
+	default:
+		return nil, fmt.Errorf("unsupported config file extension: %s", path)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	return Parse(data, format, opts...)
+}
+
+// Parse parses the configuration, then overrides it with the environment variables, sets the defaults and
+// validates it.
+func Parse(data []byte, format Format, opts ...Option) (*Config, error) {
+	cfg := &Config{}
+
+	var err error
+
+	switch format {
+	case JSON:
+		err = json.Unmarshal(data, cfg)
+	case YAML:
+		err = yaml.Unmarshal(data, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parse %s config: %w", format, err)
+	}
+
+	return cfg, cfg.complete(opts)
+}
+
+// FromEnv builds the configuration from the environment variables only.
+func FromEnv(opts ...Option) (*Config, error) {
+	cfg := &Config{}
+
+	return cfg, cfg.complete(opts)
+}
+
+func (c *Config) complete(opts []Option) error {
+	o := newOptions(opts)
+
+	if err := c.ApplyEnv(o.lookupEnv); err != nil {
+		return err
+	}
+
+	c.SetDefaults()
+
+	return c.Validate(opts...)
+}
+
+// ApplyEnv overrides the configuration with the environment variables set.
+func (c *Config) ApplyEnv(lookup func(key string) (string, bool)) error { // nolint:gocyclo
+	setString(lookup, LogLevelEnvKey, &c.LogLevel)
+	setString(lookup, DefaultLabelEnvKey, &c.DefaultLabel)
+	setString(lookup, TransportReturnRouteEnvKey, &c.TransportReturnRoute)
+	setString(lookup, DatabaseTypeEnvKey, &c.Storage.Type)
+	setString(lookup, DatabasePrefixEnvKey, &c.Storage.Prefix)
+	setString(lookup, TLSCertFileEnvKey, &c.TLS.CertFile)
+	setString(lookup, TLSKeyFileEnvKey, &c.TLS.KeyFile)
+	setString(lookup, KMSSecretLockEnvKey, &c.KMS.SecretLock)
+	setString(lookup, KMSMasterKeyPathEnvKey, &c.KMS.MasterKeyPath)
+	setStrings(lookup, WebhookURLEnvKey, &c.WebhookURLs)
+	setStrings(lookup, OutboundTransportEnvKey, &c.Outbound)
+
+	if v, ok := lookup(AutoAcceptEnvKey); ok && v != "" {
+		autoAccept, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", AutoAcceptEnvKey, err)
+		}
+
+		c.AutoAccept = autoAccept
+	}
+
+	if v, ok := lookup(DatabaseTimeoutEnvKey); ok && v != "" {
+		timeout, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", DatabaseTimeoutEnvKey, err)
+		}
+
+		c.Storage.Timeout = timeout
+	}
+
+	if internals, ok := lookup(InboundHostEnvKey); ok {
+		externals, _ := lookup(InboundHostExternalEnvKey)
+
+		inbound, err := ParseInbound(splitCSV(internals), splitCSV(externals))
+		if err != nil {
+			return err
+		}
+
+		c.Inbound = inbound
+	}
+
+	if v, ok := lookup(HTTPResolverEnvKey); ok {
+		vdrs, err := ParseVDRs(splitCSV(v))
+		if err != nil {
+			return err
+		}
+
+		c.VDRs = vdrs
+	}
+
+	return nil
+}
+
+// SetDefaults sets the default values of the options not configured.
+func (c *Config) SetDefaults() {
+	if c.Storage.Type == "" {
+		c.Storage.Type = StorageTypeMem
+	}
+
+	if c.Storage.Timeout == 0 {
+		c.Storage.Timeout = defaultStorageTimeout
+	}
+
+	if c.KMS.SecretLock == "" {
+		c.KMS.SecretLock = SecretLockNoop
+	}
+
+	for i := range c.Inbound {
+		if c.Inbound[i].ExternalHost == "" {
+			c.Inbound[i].ExternalHost = c.Inbound[i].Host
+		}
+	}
+}
+
+// Validate checks the configuration, the storage types supported are the in-memory storage and the ones given
+// with WithStorageProvider.
+func (c *Config) Validate(opts ...Option) error { // nolint:gocyclo
+	o := newOptions(opts)
+
+	if c.LogLevel != "" {
+		if _, err := log.ParseLevel(c.LogLevel); err != nil {
+			return fmt.Errorf("failed to parse log level '%s' : %w", c.LogLevel, err)
+		}
+	}
+
+	if _, ok := o.storageProviders[c.Storage.Type]; !ok {
+		return fmt.Errorf("database type not set to a valid type: %s", c.Storage.Type)
+	}
+
+	schemes := make(map[string]struct{})
+
+	for _, inbound := range c.Inbound {
+		if inbound.Scheme != HTTPScheme && inbound.Scheme != WebsocketScheme {
+			return fmt.Errorf("inbound transport [%s] not supported", inbound.Scheme)
+		}
+
+		if inbound.Host == "" {
+			return fmt.Errorf("inbound transport [%s] host is mandatory", inbound.Scheme)
+		}
+
+		if _, ok := schemes[inbound.Scheme]; ok {
+			return fmt.Errorf("inbound transport [%s] configured more than once", inbound.Scheme)
+		}
+
+		schemes[inbound.Scheme] = struct{}{}
+	}
+
+	for _, outbound := range c.Outbound {
+		if outbound != HTTPScheme && outbound != WebsocketScheme {
+			return fmt.Errorf("outbound transport [%s] not supported", outbound)
+		}
+	}
+
+	for _, vdr := range c.VDRs {
+		if vdr.URL == "" {
+			return fmt.Errorf("vdr [%s] url is mandatory", vdr.Method)
+		}
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return errors.New("tls cert file and key file must be set together")
+	}
+
+	switch c.KMS.SecretLock {
+	case SecretLockNoop:
+	case SecretLockLocal:
+		if c.KMS.MasterKeyPath == "" {
+			return errors.New("kms master key path is mandatory for the local secret lock")
+		}
+	default:
+		return fmt.Errorf("kms secret lock [%s] not supported", c.KMS.SecretLock)
+	}
+
+	return nil
+}
+
+// SetLogLevel sets the log level of all the modules, if configured.
+func (c *Config) SetLogLevel() error {
+	if c.LogLevel == "" {
+		return nil
+	}
+
+	level, err := log.ParseLevel(c.LogLevel)
+	if err != nil {
+		return fmt.Errorf("failed to parse log level '%s' : %w", c.LogLevel, err)
+	}
+
+	log.SetLevel("", level)
+
+	return nil
+}
+
+// ParseInbound parses the inbound transports given in `scheme@host` format, the external hosts are matched to
+// the hosts by scheme.
+func ParseInbound(internals, externals []string) ([]InboundConfig, error) {
+	externalHosts := make(map[string]string)
+
+	for _, e := range externals {
+		scheme, host, err := splitPair(e)
+		if err != nil {
+			return nil, fmt.Errorf("inbound external host : %w", err)
+		}
+
+		externalHosts[scheme] = host
+	}
+
+	var inbound []InboundConfig
+
+	for _, i := range internals {
+		scheme, host, err := splitPair(i)
+		if err != nil {
+			return nil, fmt.Errorf("inbound internal host : %w", err)
+		}
+
+		inbound = append(inbound, InboundConfig{Scheme: scheme, Host: host, ExternalHost: externalHosts[scheme]})
+	}
+
+	return inbound, nil
+}
+
+// ParseVDRs parses the HTTP binding resolvers given in `method@url` format.
+func ParseVDRs(resolvers []string) ([]VDRConfig, error) {
+	var vdrs []VDRConfig
+
+	for _, r := range resolvers {
+		method, url, err := splitPair(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid http resolver options found")
+		}
+
+		vdrs = append(vdrs, VDRConfig{Method: method, URL: url})
+	}
+
+	return vdrs, nil
+}
+
+func splitPair(s string) (string, string, error) {
+	const numParts = 2
+
+	parts := strings.Split(s, "@")
+	if len(parts) != numParts {
+		return "", "", fmt.Errorf("invalid option %s: use the prefix@value format", s)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func splitCSV(v string) []string {
+	if v == "" {
+		return nil
+	}
+
+	return strings.Split(v, ",")
+}
+
+func setString(lookup func(string) (string, bool), key string, value *string) {
+	if v, ok := lookup(key); ok {
+		*value = v
+	}
+}
+
+func setStrings(lookup func(string) (string, bool), key string, values *[]string) {
+	if v, ok := lookup(key); ok {
+		*values = splitCSV(v)
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const yamlConfig = `
+logLevel: DEBUG
+defaultLabel: agent
+autoAccept: true
+storage:
+  type: mem
+  prefix: test
+inbound:
+  - scheme: http
+    host: localhost:8080
+    externalHost: https://agent.example.com
+  - scheme: ws
+    host: localhost:8081
+outbound:
+  - http
+  - ws
+vdrs:
+  - method: sov
+    url: http://resolver.example.com
+`
+
+const jsonConfig = `{
+  "defaultLabel": "agent",
+  "webhookURLs": ["http://localhost:9000"],
+  "inbound": [{"scheme": "http", "host": "localhost:8080"}],
+  "kms": {"secretLock": "noop"}
+}`
+
+func noEnv(string) (string, bool) {
+	return "", false
+}
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := values[key]
+
+		return v, ok
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		cfg, err := Parse([]byte(yamlConfig), YAML, WithEnvLookup(noEnv))
+		require.NoError(t, err)
+
+		require.Equal(t, &Config{
+			LogLevel:     "DEBUG",
+			DefaultLabel: "agent",
+			AutoAccept:   true,
+			Storage:      StorageConfig{Type: StorageTypeMem, Prefix: "test", Timeout: defaultStorageTimeout},
+			Inbound: []InboundConfig{
+				{Scheme: HTTPScheme, Host: "localhost:8080", ExternalHost: "https://agent.example.com"},
+				{Scheme: WebsocketScheme, Host: "localhost:8081", ExternalHost: "localhost:8081"},
+			},
+			Outbound: []string{HTTPScheme, WebsocketScheme},
+			VDRs:     []VDRConfig{{Method: "sov", URL: "http://resolver.example.com"}},
+			KMS:      KMSConfig{SecretLock: SecretLockNoop},
+		}, cfg)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		cfg, err := Parse([]byte(jsonConfig), JSON, WithEnvLookup(noEnv))
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://localhost:9000"}, cfg.WebhookURLs)
+		require.Equal(t, StorageTypeMem, cfg.Storage.Type)
+		require.Equal(t, "localhost:8080", cfg.Inbound[0].ExternalHost)
+	})
+
+	t.Run("environment variables override the file", func(t *testing.T) {
+		cfg, err := Parse([]byte(yamlConfig), YAML, WithEnvLookup(env(map[string]string{
+			DefaultLabelEnvKey:        "env-agent",
+			AutoAcceptEnvKey:          "false",
+			DatabaseTimeoutEnvKey:     "5",
+			InboundHostEnvKey:         "ws@localhost:9090",
+			InboundHostExternalEnvKey: "ws@wss://agent.example.com",
+			OutboundTransportEnvKey:   "ws",
+			HTTPResolverEnvKey:        "peer@http://peer.example.com",
+		})))
+		require.NoError(t, err)
+
+		require.Equal(t, "env-agent", cfg.DefaultLabel)
+		require.False(t, cfg.AutoAccept)
+		require.Equal(t, uint64(5), cfg.Storage.Timeout)
+		require.Equal(t, []InboundConfig{
+			{Scheme: WebsocketScheme, Host: "localhost:9090", ExternalHost: "wss://agent.example.com"},
+		}, cfg.Inbound)
+		require.Equal(t, []string{WebsocketScheme}, cfg.Outbound)
+		require.Equal(t, []VDRConfig{{Method: "peer", URL: "http://peer.example.com"}}, cfg.VDRs)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := Parse([]byte(jsonConfig), "toml")
+		require.EqualError(t, err, "unsupported config format: toml")
+	})
+
+	t.Run("invalid content", func(t *testing.T) {
+		_, err := Parse([]byte("{"), JSON)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse json config")
+	})
+
+	t.Run("invalid environment variables", func(t *testing.T) {
+		for key, value := range map[string]string{
+			AutoAcceptEnvKey:      "maybe",
+			DatabaseTimeoutEnvKey: "-1",
+			InboundHostEnvKey:     "localhost:8080",
+			HTTPResolverEnvKey:    "http://resolver.example.com",
+		} {
+			_, err := Parse([]byte(jsonConfig), JSON, WithEnvLookup(env(map[string]string{key: value})))
+			require.Error(t, err, key)
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0o600))
+
+		return path
+	}
+
+	t.Run("yaml file", func(t *testing.T) {
+		cfg, err := Load(write("agent.yml", yamlConfig), WithEnvLookup(noEnv))
+		require.NoError(t, err)
+		require.Equal(t, "agent", cfg.DefaultLabel)
+	})
+
+	t.Run("json file", func(t *testing.T) {
+		cfg, err := Load(write("agent.json", jsonConfig), WithEnvLookup(noEnv))
+		require.NoError(t, err)
+		require.Equal(t, "agent", cfg.DefaultLabel)
+	})
+
+	t.Run("unsupported extension", func(t *testing.T) {
+		_, err := Load(write("agent.txt", jsonConfig))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported config file extension")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := Load(filepath.Join(dir, "missing.yaml"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read config file")
+	})
+}
+
+func TestFromEnv(t *testing.T) {
+	cfg, err := FromEnv(WithEnvLookup(env(map[string]string{
+		DatabaseTypeEnvKey: "leveldb",
+		WebhookURLEnvKey:   "http://localhost:9000,http://localhost:9001",
+	})), WithStorageProvider("leveldb", nil))
+	require.NoError(t, err)
+	require.Equal(t, "leveldb", cfg.Storage.Type)
+	require.Equal(t, []string{"http://localhost:9000", "http://localhost:9001"}, cfg.WebhookURLs)
+
+	_, err = FromEnv(WithEnvLookup(env(map[string]string{DatabaseTypeEnvKey: "leveldb"})))
+	require.EqualError(t, err, "database type not set to a valid type: leveldb")
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := &Config{}
+		cfg.SetDefaults()
+
+		return cfg
+	}
+
+	require.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{"log level", func(c *Config) { c.LogLevel = "LOUD" }, "invalid log level"},
+		{"inbound scheme", func(c *Config) {
+			c.Inbound = []InboundConfig{{Scheme: "wss", Host: "localhost"}}
+		}, "inbound transport [wss] not supported"},
+		{"inbound host", func(c *Config) {
+			c.Inbound = []InboundConfig{{Scheme: HTTPScheme}}
+		}, "inbound transport [http] host is mandatory"},
+		{"inbound duplicate", func(c *Config) {
+			c.Inbound = []InboundConfig{{Scheme: HTTPScheme, Host: "a"}, {Scheme: HTTPScheme, Host: "b"}}
+		}, "inbound transport [http] configured more than once"},
+		{"outbound", func(c *Config) { c.Outbound = []string{"wss"} }, "outbound transport [wss] not supported"},
+		{"vdr", func(c *Config) { c.VDRs = []VDRConfig{{Method: "sov"}} }, "vdr [sov] url is mandatory"},
+		{"tls", func(c *Config) { c.TLS.CertFile = "cert.pem" }, "tls cert file and key file must be set together"},
+		{"kms master key", func(c *Config) { c.KMS.SecretLock = SecretLockLocal }, "kms master key path is mandatory"},
+		{"kms secret lock", func(c *Config) { c.KMS.SecretLock = "hsm" }, "kms secret lock [hsm] not supported"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid()
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	require.NoError(t, (&Config{}).SetLogLevel())
+	require.NoError(t, (&Config{LogLevel: "INFO"}).SetLogLevel())
+	require.Error(t, (&Config{LogLevel: "LOUD"}).SetLogLevel())
+}
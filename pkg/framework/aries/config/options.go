@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/ws"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/config")
+
+// StorageProviderFactory creates the storage provider of a storage type, the prefix is the storage prefix of the
+// configuration.
+type StorageProviderFactory func(prefix string) (storage.Provider, error)
+
+// Option configures the loading of the configuration and the creation of the framework options.
+type Option func(opts *options)
+
+type options struct {
+	storageProviders map[string]StorageProviderFactory
+	lookupEnv        func(key string) (string, bool)
+	retryInterval    time.Duration
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		storageProviders: map[string]StorageProviderFactory{
+			StorageTypeMem: func(string) (storage.Provider, error) {
+				return mem.NewProvider(), nil
+			},
+		},
+		lookupEnv:     os.LookupEnv,
+		retryInterval: time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithStorageProvider adds a storage type to the in-memory storage supported by default.
+func WithStorageProvider(storageType string, factory StorageProviderFactory) Option {
+	return func(opts *options) {
+		opts.storageProviders[storageType] = factory
+	}
+}
+
+// WithEnvLookup sets the function looking up the environment variables, os.LookupEnv by default.
+func WithEnvLookup(lookup func(key string) (string, bool)) Option {
+	return func(opts *options) {
+		opts.lookupEnv = lookup
+	}
+}
+
+// FrameworkOptions builds the options of the framework from the configuration.
+func (c *Config) FrameworkOptions(opts ...Option) ([]aries.Option, error) {
+	o := newOptions(opts)
+
+	storeProvider, err := c.storeProvider(o)
+	if err != nil {
+		return nil, err
+	}
+
+	frameworkOpts := []aries.Option{aries.WithStoreProvider(storeProvider)}
+
+	if c.TransportReturnRoute != "" {
+		frameworkOpts = append(frameworkOpts, aries.WithTransportReturnRoute(c.TransportReturnRoute))
+	}
+
+	for _, inbound := range c.Inbound {
+		switch inbound.Scheme {
+		case HTTPScheme:
+			frameworkOpts = append(frameworkOpts, defaults.WithInboundHTTPAddr(inbound.Host, inbound.ExternalHost,
+				c.TLS.CertFile, c.TLS.KeyFile))
+		case WebsocketScheme:
+			frameworkOpts = append(frameworkOpts, defaults.WithInboundWSAddr(inbound.Host, inbound.ExternalHost,
+				c.TLS.CertFile, c.TLS.KeyFile))
+		default:
+			return nil, fmt.Errorf("inbound transport [%s] not supported", inbound.Scheme)
+		}
+	}
+
+	outboundOpts, err := c.outboundTransportOpts()
+	if err != nil {
+		return nil, err
+	}
+
+	frameworkOpts = append(frameworkOpts, outboundOpts...)
+
+	for _, vdr := range c.VDRs {
+		method := vdr.Method
+
+		httpVDR, err := httpbinding.New(vdr.URL,
+			httpbinding.WithAccept(func(m string) bool { return m == method }))
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup http resolver :  %w", err)
+		}
+
+		frameworkOpts = append(frameworkOpts, aries.WithVDR(httpVDR))
+	}
+
+	if c.KMS.SecretLock == SecretLockLocal {
+		secretLock, err := c.localSecretLock()
+		if err != nil {
+			return nil, err
+		}
+
+		frameworkOpts = append(frameworkOpts, aries.WithSecretLock(secretLock))
+	}
+
+	return frameworkOpts, nil
+}
+
+// storeProvider opens the storage, retrying until the storage timeout.
+func (c *Config) storeProvider(o *options) (storage.Provider, error) {
+	factory, ok := o.storageProviders[c.Storage.Type]
+	if !ok {
+		return nil, fmt.Errorf("database type not set to a valid type: %s", c.Storage.Type)
+	}
+
+	var store storage.Provider
+
+	err := backoff.RetryNotify(
+		func() error {
+			var openErr error
+			store, openErr = factory(c.Storage.Prefix)
+
+			return openErr
+		},
+		backoff.WithMaxRetries(backoff.NewConstantBackOff(o.retryInterval), c.Storage.Timeout),
+		func(retryErr error, t time.Duration) {
+			logger.Warnf("failed to connect to storage, will sleep for %s before trying again : %s", t, retryErr)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to storage at %s : %w", c.Storage.Prefix, err)
+	}
+
+	return store, nil
+}
+
+func (c *Config) outboundTransportOpts() ([]aries.Option, error) {
+	var transports []transport.OutboundTransport
+
+	for _, outbound := range c.Outbound {
+		switch outbound {
+		case HTTPScheme:
+			t, err := arieshttp.NewOutbound(arieshttp.WithOutboundHTTPClient(&http.Client{}))
+			if err != nil {
+				return nil, fmt.Errorf("http outbound transport initialization failed: %w", err)
+			}
+
+			transports = append(transports, t)
+		case WebsocketScheme:
+			transports = append(transports, ws.NewOutbound())
+		default:
+			return nil, fmt.Errorf("outbound transport [%s] not supported", outbound)
+		}
+	}
+
+	if len(transports) == 0 {
+		return nil, nil
+	}
+
+	return []aries.Option{aries.WithOutboundTransports(transports...)}, nil
+}
+
+func (c *Config) localSecretLock() (secretlock.Service, error) {
+	masterKey, err := local.MasterKeyFromPath(c.KMS.MasterKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read kms master key: %w", err)
+	}
+
+	secretLock, err := local.NewService(masterKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create local secret lock: %w", err)
+	}
+
+	return secretLock, nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestConfig_FrameworkOptions(t *testing.T) {
+	t.Run("framework created from the options", func(t *testing.T) {
+		cfg, err := Parse([]byte(`
+transportReturnRoute: all
+inbound:
+  - scheme: http
+    host: localhost:26700
+  - scheme: ws
+    host: localhost:26701
+outbound:
+  - http
+  - ws
+vdrs:
+  - method: example
+    url: http://resolver.example.com
+`), YAML, WithEnvLookup(noEnv))
+		require.NoError(t, err)
+
+		opts, err := cfg.FrameworkOptions()
+		require.NoError(t, err)
+
+		framework, err := aries.New(opts...)
+		require.NoError(t, err)
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+		require.Len(t, ctx.InboundTransports(), 2)
+		require.Len(t, ctx.OutboundTransports(), 2)
+		require.Equal(t, "all", ctx.TransportReturnRoute())
+
+		require.NoError(t, framework.Close())
+	})
+
+	t.Run("storage provider of a registered type", func(t *testing.T) {
+		cfg := &Config{Storage: StorageConfig{Type: "custom", Prefix: "prefix"}}
+		cfg.SetDefaults()
+
+		var prefix string
+
+		opts, err := cfg.FrameworkOptions(WithStorageProvider("custom", func(p string) (storage.Provider, error) {
+			prefix = p
+
+			return mem.NewProvider(), nil
+		}))
+		require.NoError(t, err)
+		require.NotEmpty(t, opts)
+		require.Equal(t, "prefix", prefix)
+	})
+
+	t.Run("storage retried until the timeout", func(t *testing.T) {
+		cfg := &Config{Storage: StorageConfig{Type: "custom", Timeout: 2}}
+
+		attempts := 0
+
+		_, err := cfg.FrameworkOptions(WithStorageProvider("custom", func(string) (storage.Provider, error) {
+			attempts++
+
+			return nil, errors.New("unavailable")
+		}), withRetryInterval(time.Millisecond))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to connect to storage")
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("unsupported storage type", func(t *testing.T) {
+		_, err := (&Config{Storage: StorageConfig{Type: "unknown"}}).FrameworkOptions()
+		require.EqualError(t, err, "database type not set to a valid type: unknown")
+	})
+
+	t.Run("unsupported transports", func(t *testing.T) {
+		_, err := (&Config{
+			Storage: StorageConfig{Type: StorageTypeMem},
+			Inbound: []InboundConfig{{Scheme: "wss", Host: "localhost"}},
+		}).FrameworkOptions()
+		require.EqualError(t, err, "inbound transport [wss] not supported")
+
+		_, err = (&Config{
+			Storage:  StorageConfig{Type: StorageTypeMem},
+			Outbound: []string{"wss"},
+		}).FrameworkOptions()
+		require.EqualError(t, err, "outbound transport [wss] not supported")
+	})
+
+	t.Run("invalid resolver url", func(t *testing.T) {
+		_, err := (&Config{
+			Storage: StorageConfig{Type: StorageTypeMem},
+			VDRs:    []VDRConfig{{Method: "example", URL: "h"}},
+		}).FrameworkOptions()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to setup http resolver")
+	})
+
+	t.Run("local secret lock", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "config")
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+
+		masterKeyPath := filepath.Join(dir, "master.key")
+		masterKey := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+		require.NoError(t, ioutil.WriteFile(masterKeyPath, []byte(masterKey), 0o600))
+
+		cfg := &Config{KMS: KMSConfig{SecretLock: SecretLockLocal, MasterKeyPath: masterKeyPath}}
+		cfg.SetDefaults()
+
+		opts, err := cfg.FrameworkOptions()
+		require.NoError(t, err)
+		require.Len(t, opts, 2)
+
+		cfg.KMS.MasterKeyPath = filepath.Join(dir, "missing.key")
+
+		_, err = cfg.FrameworkOptions()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read kms master key")
+	})
+}
+
+func withRetryInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.retryInterval = interval
+	}
+}
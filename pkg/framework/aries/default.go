@@ -12,15 +12,20 @@ import (
 	"net/http"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/signed"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/filetransfer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keyescrow"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/legacyconnection"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	mdissuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
@@ -30,11 +35,14 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
+	docverifiable "github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -67,7 +75,12 @@ func defFrameworkOpts(frameworkOpts *Aries) error {
 	// - Introduce depends on OutOfBand
 	frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators,
 		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(), newOutOfBandSvc(),
-		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc())
+		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc(), newFileTransferSvc(), newKeyEscrowSvc())
+
+	// Interop: the connections protocol of the agents not supporting DID exchange, it depends on Route as well
+	if frameworkOpts.interopMode != nil && frameworkOpts.interopMode.ConnectionsProtocol {
+		frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators, newLegacyConnectionSvc())
+	}
 
 	if frameworkOpts.secretLock == nil && frameworkOpts.kmsCreator == nil {
 		err = createDefSecretLock(frameworkOpts)
@@ -91,6 +104,12 @@ func newExchangeSvc() api.ProtocolSvcCreator {
 	}
 }
 
+func newLegacyConnectionSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return legacyconnection.New(prv)
+	}
+}
+
 func newIntroduceSvc() api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
 		return introduce.New(prv)
@@ -104,8 +123,22 @@ func newIssueCredentialSvc() api.ProtocolSvcCreator {
 			return nil, err
 		}
 
+		middleware := []issuecredential.Middleware{mdissuecredential.SaveCredentials(prv)}
+
+		// records the credential exchange events once the other middleware succeeded
+		if store := credentialHistory(prv); store != nil {
+			middleware = append([]issuecredential.Middleware{mdissuecredential.RecordHistory(store)}, middleware...)
+		}
+
 		// sets default middleware to the service
-		service.Use(mdissuecredential.SaveCredentials(prv))
+		service.Use(middleware...)
+
+		// signs the credentials requested in ld-proof-vc-detail format
+		service.RegisterFormat(issuecredential.LDProofVCDetailFormat, mdissuecredential.NewLDProofVCDetailHandler(prv))
+
+		if p, ok := prv.(interface{ InteropMode() *interop.Mode }); ok {
+			service.Formats().SetInteropMode(p.InteropMode())
+		}
 
 		return service, nil
 	}
@@ -118,18 +151,51 @@ func newPresentProofSvc() api.ProtocolSvcCreator {
 			return nil, err
 		}
 
-		// sets default middleware to the service
-		service.Use(
-			mdpresentproof.SavePresentation(prv),
+		var (
+			spOpts []mdpresentproof.OptSP
+			vpOpts []mdpresentproof.OptVP
+		)
+
+		if p, ok := prv.(interface{ TrustRegistry() trustregistry.Registry }); ok && p.TrustRegistry() != nil {
+			spOpts = append(spOpts, mdpresentproof.WithTrustRegistry(p.TrustRegistry()))
+			vpOpts = append(vpOpts, mdpresentproof.WithCredentialOpts(docverifiable.WithTrustRegistry(p.TrustRegistry())))
+		}
+
+		middleware := []presentproof.Middleware{
+			mdpresentproof.VerifyPresentation(prv, vpOpts...),
+			mdpresentproof.SavePresentation(prv, spOpts...),
 			mdpresentproof.PresentationDefinition(prv,
 				mdpresentproof.WithAddProofFn(mdpresentproof.AddBBSProofFn(prv)),
 			),
-		)
+		}
+
+		// records the credential exchange events once the other middleware succeeded
+		if store := credentialHistory(prv); store != nil {
+			middleware = append([]presentproof.Middleware{mdpresentproof.RecordHistory(store)}, middleware...)
+		}
+
+		// sets default middleware to the service
+		service.Use(middleware...)
+
+		// keeps the records of the protocol instances queried by the controller
+		service.EnableRecords()
+
+		// suggests the stored credentials satisfying the received presentation definitions
+		service.EnableSuggestions(mdpresentproof.SuggestFn(prv))
 
 		return service, nil
 	}
 }
 
+// credentialHistory returns the credential history of the provider, nil if the history is not enabled.
+func credentialHistory(prv api.Provider) *history.Store {
+	if p, ok := prv.(interface{ CredentialHistory() *history.Store }); ok {
+		return p.CredentialHistory()
+	}
+
+	return nil
+}
+
 func newRouteSvc() api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
 		return mediator.New(prv)
@@ -147,6 +213,18 @@ func newMessagePickupSvc() api.ProtocolSvcCreator {
 	}
 }
 
+func newFileTransferSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return filetransfer.New(prv)
+	}
+}
+
+func newKeyEscrowSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return keyescrow.New(prv)
+	}
+}
+
 func newOutOfBandSvc() api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
 		return outofband.New(prv)
@@ -185,6 +263,9 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 			func(provider packer.Provider) (packer.Packer, error) {
 				return anoncrypt.New(provider, jose.A256GCM)
 			},
+			func(provider packer.Provider) (packer.Packer, error) {
+				return signed.New(provider)
+			},
 		}
 	}
 
@@ -206,7 +287,8 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 }
 
 func assignVerifiableStoreIfNeeded(aries *Aries, storeProvider storage.Provider) error {
-	if aries.verifiableStore != nil {
+	// the encrypted verifiable store is created once the KMS is available
+	if aries.verifiableStore != nil || aries.verifiableStoreEncryption {
 		return nil
 	}
 
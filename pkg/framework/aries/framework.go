@@ -7,29 +7,53 @@ SPDX-License-Identifier: Apache-2.0
 package aries
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ack"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dedup"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/l10n"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messenger"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/outbox"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/replay"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/rotation"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/thread"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/timing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/store/audit"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+	"github.com/hyperledger/aries-framework-go/pkg/store/keylink"
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+	spilog "github.com/hyperledger/aries-framework-go/spi/log"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -38,6 +62,8 @@ const (
 	defaultMasterKeyURI = "local-lock://default/master/key/"
 )
 
+var logger = log.New("aries-framework/framework/aries")
+
 // Aries provides access to the context being managed by the framework. The context can be used to create aries clients.
 type Aries struct {
 	storeProvider              storage.Provider
@@ -46,8 +72,17 @@ type Aries struct {
 	services                   []dispatcher.ProtocolService
 	msgSvcProvider             api.MessageServiceProvider
 	outboundDispatcher         dispatcher.Outbound
+	outboundInterceptors       []dispatcher.OutboundInterceptor
+	dedupWindow                time.Duration
+	deduplicator               *dedup.Deduplicator
+	replayTTL                  time.Duration
+	replayGuard                *replay.Guard
+	connectionRecorder         *connection.Recorder
+	didRotator                 *rotation.Rotator
+	rotationEvents             chan rotation.Event
 	messenger                  service.MessengerHandler
 	outboundTransports         []transport.OutboundTransport
+	outboundRegistry           *transport.OutboundRegistry
 	inboundTransports          []transport.InboundTransport
 	kms                        kms.KeyManager
 	kmsCreator                 kms.Creator
@@ -61,9 +96,47 @@ type Aries struct {
 	packers                    []packer.Packer
 	vdrRegistry                vdrapi.Registry
 	vdr                        []vdrapi.VDR
+	vdrCacheOpts               []vdr.CacheOption
+	vdrCache                   bool
+	peerVDR                    *peer.VDR
+	peerDIDGC                  *peer.GarbageCollector
+	peerDIDGCOpts              []peer.GCOption
+	peerDIDGCEnabled           bool
 	verifiableStore            verifiable.Store
+	verifiableStoreEncryption  bool
 	didConnectionStore         did.ConnectionStore
+	trustRegistry              trustregistry.Registry
+	locker                     lock.Locker
+	outboxes                   []*outbox.Outbox
+	outboxOpts                 []outbox.Option
+	outboxEnabled              bool
+	expiryTrackers             []*expiry.Tracker
+	expiryOpts                 []expiry.Option
+	expiryEnabled              bool
+	messageCatalogs            *l10n.Registry
+	keyLinkStore               *keylink.Store
+	auditLog                   *audit.Log
+	auditLogOpts               []audit.Option
+	auditLogEnabled            bool
+	credentialHistory          *history.Store
+	credentialHistoryOpts      []history.Option
+	credentialHistoryEnabled   bool
+	interopMode                *interop.Mode
+	didExchangePublicDID       string
+	publicDID                  string
+	didcommBridge              bool
+	mediaTypeProfiles          []string
+	threadStore                *thread.Store
+	ackTracker                 *ack.Tracker
+	ackTrackerOpts             []ack.Option
+	ackTrackingEnabled         bool
+	timingMonitor              *timing.Monitor
+	settings                   *settings.Store
+	settingsEvents             chan settings.Event
+	startupLogLevel            spilog.Level
 	transportReturnRoute       string
+	randomSource               io.Reader
+	restoreRandomSource        func()
 	id                         string
 }
 
@@ -84,12 +157,22 @@ func New(opts ...Option) (*Aries, error) {
 		}
 	}
 
+	if frameworkOpts.randomSource != nil {
+		restore, err := random.SetSource(frameworkOpts.randomSource)
+		if err != nil {
+			return nil, fmt.Errorf("set random source: %w", err)
+		}
+
+		frameworkOpts.restoreRandomSource = restore
+	}
+
 	// generate a random framework ID
 	frameworkOpts.id = uuid.New().String()
 
 	// get the default framework options
 	err := defFrameworkOpts(frameworkOpts)
 	if err != nil {
+		frameworkOpts.restoreRandom()
 		return nil, fmt.Errorf("default option initialization failed: %w", err)
 	}
 
@@ -99,7 +182,13 @@ func New(opts ...Option) (*Aries, error) {
 	//  on the context. The inbound transports require ctx.InboundMessageHandler(), which in-turn depends on
 	//  protocolServices. At the moment, there is a looping issue among these.
 
-	return initializeServices(frameworkOpts)
+	aries, err := initializeServices(frameworkOpts)
+	if err != nil {
+		frameworkOpts.restoreRandom()
+		return nil, err
+	}
+
+	return aries, nil
 }
 
 func initializeServices(frameworkOpts *Aries) (*Aries, error) {
@@ -109,16 +198,29 @@ func initializeServices(frameworkOpts *Aries) (*Aries, error) {
 		return nil, e
 	}
 
+	// Create verifiable store (must be done after KMS when the store is encrypted)
+	if e := createVerifiableStore(frameworkOpts); e != nil {
+		return nil, e
+	}
+
 	// Create vdr
 	if e := createVDR(frameworkOpts); e != nil {
 		return nil, e
 	}
 
+	// Publish the service endpoint of the agent in the document of its public DID (must be done after the vdr)
+	if e := publishPublicDID(frameworkOpts); e != nil {
+		return nil, e
+	}
+
 	// create packers and packager (must be done after KMS and connection store)
 	if err := createPackersAndPackager(frameworkOpts); err != nil {
 		return nil, err
 	}
 
+	// the outbound transports can be added and removed once the framework is running
+	frameworkOpts.outboundRegistry = transport.NewOutboundRegistry(frameworkOpts.outboundTransports...)
+
 	// Create outbound dispatcher
 	if err := createOutboundDispatcher(frameworkOpts); err != nil {
 		return nil, err
@@ -134,11 +236,66 @@ func initializeServices(frameworkOpts *Aries) (*Aries, error) {
 		return nil, err
 	}
 
+	// Create audit log of the credential operations
+	if err := createAuditLog(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create credential history of the credential exchanges
+	if err := createCredentialHistory(frameworkOpts); err != nil {
+		return nil, err
+	}
+
 	// Load services
 	if err := loadServices(frameworkOpts); err != nil {
 		return nil, err
 	}
 
+	// Create inbound message deduplicator
+	if err := createInboundDeduplicator(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create inbound message replay guard
+	if err := createInboundReplayGuard(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create connection recorder tracking the protocol versions supported by the connections
+	if err := createConnectionRecorder(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create the store of the runtime-tunable parameters, the stored log level overrides the startup one
+	if err := createSettings(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create DID rotation handler applying the DID rotations of the other agents
+	if err := createDIDRotator(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Invalidate the recipient keys cached by the packers on DID rotation (must be done after the DID rotator)
+	startRecipientKeyInvalidation(frameworkOpts)
+
+	// Start the garbage collection of the peer DID documents (must be done after the connection recorder)
+	startPeerDIDGarbageCollector(frameworkOpts)
+
+	// Start the outboxes of the protocol services (must be done after loading the services)
+	startOutboxes(frameworkOpts)
+
+	// Start the expiry trackers of the protocol services (must be done after loading the services)
+	startExpiryTrackers(frameworkOpts)
+
+	// Localize the problem reports of the protocol services (must be done after loading the services)
+	setMessageCatalogs(frameworkOpts)
+
+	// Send again the messages not acknowledged in time (must be done after the outbound dispatcher)
+	if frameworkOpts.ackTracker != nil {
+		frameworkOpts.ackTracker.Start(frameworkOpts.outboundDispatcher)
+	}
+
 	// Start inbound/outbound transports
 	if err := startTransports(frameworkOpts); err != nil {
 		return nil, err
@@ -163,6 +320,36 @@ func WithOutboundTransports(outboundTransports ...transport.OutboundTransport) O
 	}
 }
 
+// WithOutboundInterceptors injects the interceptors of the messages sent by the outbound dispatcher
+// (see dispatcher.OutboundDispatcher.RegisterOutboundInterceptor).
+func WithOutboundInterceptors(interceptors ...dispatcher.OutboundInterceptor) Option {
+	return func(opts *Aries) error {
+		opts.outboundInterceptors = append(opts.outboundInterceptors, interceptors...)
+		return nil
+	}
+}
+
+// WithInboundReplayProtection sets the period the nonces of the inbound protocol messages (e.g. the challenges
+// of the presentations) are remembered for, the messages carrying a nonce already seen within the TTL are rejected
+// (replay.DefaultTTL by default). A negative TTL disables the replay protection.
+func WithInboundReplayProtection(ttl time.Duration) Option {
+	return func(opts *Aries) error {
+		opts.replayTTL = ttl
+		return nil
+	}
+}
+
+// WithInboundDeduplicationWindow sets the period the inbound messages are remembered for, the messages received
+// again from the same sender (to the same recipient for anoncrypt messages) within the window are ignored
+// (dedup.DefaultWindow by default).
+// A negative window disables the deduplication.
+func WithInboundDeduplicationWindow(window time.Duration) Option {
+	return func(opts *Aries) error {
+		opts.dedupWindow = window
+		return nil
+	}
+}
+
 // WithInboundTransport injects an inbound transport to the Aries framework.
 func WithInboundTransport(inboundTransport ...transport.InboundTransport) Option {
 	return func(opts *Aries) error {
@@ -244,6 +431,155 @@ func WithVDR(v vdrapi.VDR) Option {
 	}
 }
 
+// WithVDRCache caches the DID documents resolved by the VDR registry of the Aries framework.
+func WithVDRCache(cacheOpts ...vdr.CacheOption) Option {
+	return func(opts *Aries) error {
+		opts.vdrCache = true
+		opts.vdrCacheOpts = cacheOpts
+
+		return nil
+	}
+}
+
+// WithPeerDIDGarbageCollection periodically removes the peer DID documents which are not referenced by any
+// connection record anymore.
+func WithPeerDIDGarbageCollection(gcOpts ...peer.GCOption) Option {
+	return func(opts *Aries) error {
+		opts.peerDIDGCEnabled = true
+		opts.peerDIDGCOpts = gcOpts
+
+		return nil
+	}
+}
+
+// WithOutbox persists the messages sent by the protocol services supporting it (issue credential and present proof)
+// in the same batch as their state change, the messages not sent because of a failure are sent again by a worker.
+func WithOutbox(outboxOpts ...outbox.Option) Option {
+	return func(opts *Aries) error {
+		opts.outboxEnabled = true
+		opts.outboxOpts = outboxOpts
+
+		return nil
+	}
+}
+
+// WithExpiry abandons the protocol instances of the protocol services supporting it (issue credential and present
+// proof) once the expiration time (`~timing.expires_time`) of the offer or the request they wait a reply to passes,
+// and removes their state. The expired protocol instances are checked by a worker.
+func WithExpiry(expiryOpts ...expiry.Option) Option {
+	return func(opts *Aries) error {
+		opts.expiryEnabled = true
+		opts.expiryOpts = expiryOpts
+
+		return nil
+	}
+}
+
+// WithMessageCatalogs localizes the problem reports sent by the protocol services supporting it (issue credential
+// and present proof): the texts of their codes defined by the message catalogs are added to their description.
+func WithMessageCatalogs(catalogs ...*l10n.Catalog) Option {
+	return func(opts *Aries) error {
+		opts.messageCatalogs = l10n.NewRegistry(catalogs...)
+
+		return nil
+	}
+}
+
+// WithAckTracking tracks the acknowledgements requested by the outbound messages with the `~please_ack` decorator:
+// the delivery status of the messages is recorded (see context.Provider.AckTracker), the messages not acknowledged
+// in time are sent again and expire once the retries are exhausted.
+func WithAckTracking(ackOpts ...ack.Option) Option {
+	return func(opts *Aries) error {
+		opts.ackTrackingEnabled = true
+		opts.ackTrackerOpts = ackOpts
+
+		return nil
+	}
+}
+
+// WithTimingMonitor handles the `~timing` decorator of the messages (Aries RFC 0032): the inbound messages are
+// delayed and expired as requested by their sender, the outbound messages are stamped with the time they are sent
+// and the latencies of the connections are measured (see context.Provider.TimingMonitor).
+func WithTimingMonitor(timingOpts ...timing.Option) Option {
+	return func(opts *Aries) error {
+		opts.timingMonitor = timing.NewMonitor(timingOpts...)
+
+		return nil
+	}
+}
+
+// WithAuditLog records the credential operations (issuance, verification, presentation, storage and deletion)
+// in a hash-chained audit log.
+func WithAuditLog(auditOpts ...audit.Option) Option {
+	return func(opts *Aries) error {
+		opts.auditLogEnabled = true
+		opts.auditLogOpts = auditOpts
+
+		return nil
+	}
+}
+
+// WithCredentialHistory records the events of the credential exchanges (offers, requests, issuances,
+// presentations, verifications and revocations of the credentials) in the credential history.
+func WithCredentialHistory(historyOpts ...history.Option) Option {
+	return func(opts *Aries) error {
+		opts.credentialHistoryEnabled = true
+		opts.credentialHistoryOpts = historyOpts
+
+		return nil
+	}
+}
+
+// WithInteropMode enables the compatibility quirks of the agents which do not follow the latest Aries RFCs,
+// e.g. WithInteropMode(interop.ACAPy()) to connect to ACA-Py and issue credentials with it.
+func WithInteropMode(mode *interop.Mode) Option {
+	return func(opts *Aries) error {
+		opts.interopMode = mode
+		return nil
+	}
+}
+
+// WithDIDExchangePublicDID responds to the did-exchange requests with the public DID rather than with a new peer DID
+// for each connection, unless another public DID is given when the request is accepted.
+func WithDIDExchangePublicDID(publicDID string) Option {
+	return func(opts *Aries) error {
+		opts.didExchangePublicDID = publicDID
+		return nil
+	}
+}
+
+// WithPublicDID makes the agent operate with the public DID (e.g. a did:web or did:ion DID) for all its connections
+// instead of creating a peer DID for each of them: the invitations target the DID, and the did-exchange requests and
+// responses are sent with it. The DIDComm service endpoint of the agent is published in the document of the DID
+// when the VDR of its DID method supports the updates, the documents of the other DIDs must have a DIDComm service.
+func WithPublicDID(publicDID string) Option {
+	return func(opts *Aries) error {
+		opts.publicDID = publicDID
+		return nil
+	}
+}
+
+// WithDIDCommBridge converts the messages between DIDComm v1 and v2 at the dispatcher boundary, so the protocols
+// implemented with DIDComm v1 messages run over the connections negotiated as DIDComm v2 (and vice versa):
+// the inbound v2 messages are converted to v1 and the outbound messages to the version of the media type
+// of their destination.
+func WithDIDCommBridge() Option {
+	return func(opts *Aries) error {
+		opts.didcommBridge = true
+		return nil
+	}
+}
+
+// WithMediaTypeProfiles sets the media type profiles (e.g. transport.MediaTypeProfileDIDCommV2) the agent supports,
+// in its order of preference. The envelope media type of the outbound messages is negotiated from them and
+// the accept list of the DIDComm service of the recipient, the first profile is used if the recipient accepts none.
+func WithMediaTypeProfiles(profiles ...string) Option {
+	return func(opts *Aries) error {
+		opts.mediaTypeProfiles = profiles
+		return nil
+	}
+}
+
 // WithMessageServiceProvider injects a message service provider to the Aries framework.
 // Message service provider returns list of message services which can be used to provide custom handle
 // functionality based on incoming messages type and purpose.
@@ -274,6 +610,16 @@ func WithVerifiableStore(store verifiable.Store) Option {
 	}
 }
 
+// WithVerifiableStoreEncryption encrypts the credentials and presentations of the default verifiable store at rest
+// with a content encryption key managed by the framework's KMS. It has no effect when a verifiable store is injected
+// with WithVerifiableStore.
+func WithVerifiableStoreEncryption() Option {
+	return func(opts *Aries) error {
+		opts.verifiableStoreEncryption = true
+		return nil
+	}
+}
+
 // WithDIDConnectionStore injects a DID connection store.
 func WithDIDConnectionStore(store did.ConnectionStore) Option {
 	return func(opts *Aries) error {
@@ -282,12 +628,41 @@ func WithDIDConnectionStore(store did.ConnectionStore) Option {
 	}
 }
 
+// WithTrustRegistry injects a trust registry consulted when verifying credentials of received presentations.
+func WithTrustRegistry(registry trustregistry.Registry) Option {
+	return func(opts *Aries) error {
+		opts.trustRegistry = registry
+		return nil
+	}
+}
+
+// WithLocker injects a locker shared by the agent instances using the same protocol state storage (e.g. Redis),
+// the protocol services lock the protocol instances while advancing their state.
+func WithLocker(locker lock.Locker) Option {
+	return func(opts *Aries) error {
+		opts.locker = locker
+		return nil
+	}
+}
+
+// WithRandomSource replaces the source of randomness of the key generation and nonce creation (e.g. with
+// random.NewDeterministic for golden-output tests) until the framework is closed. The source is process-wide: New
+// fails while another source is installed, and a deterministic source makes the keys predictable, it must never be
+// used outside of tests.
+func WithRandomSource(r io.Reader) Option {
+	return func(opts *Aries) error {
+		opts.randomSource = r
+		return nil
+	}
+}
+
 // Context provides a handle to the framework context.
 func (a *Aries) Context() (*context.Provider, error) {
 	return context.New(
 		context.WithOutboundDispatcher(a.outboundDispatcher),
 		context.WithMessengerHandler(a.messenger),
-		context.WithOutboundTransports(a.outboundTransports...),
+		context.WithOutboundTransportRegistry(a.outboundRegistry),
+		context.WithInboundTransports(a.inboundTransports...),
 		context.WithProtocolServices(a.services...),
 		context.WithKMS(a.kms),
 		context.WithSecretLock(a.secretLock),
@@ -304,6 +679,24 @@ func (a *Aries) Context() (*context.Provider, error) {
 		context.WithMessageServiceProvider(a.msgSvcProvider),
 		context.WithVerifiableStore(a.verifiableStore),
 		context.WithDIDConnectionStore(a.didConnectionStore),
+		context.WithTrustRegistry(a.trustRegistry),
+		context.WithLocker(a.locker),
+		context.WithKeyLinkStore(a.keyLinkStore),
+		context.WithAuditLog(a.auditLog),
+		context.WithCredentialHistory(a.credentialHistory),
+		context.WithInteropMode(a.interopMode),
+		context.WithDIDExchangePublicDID(a.didExchangePublicDID),
+		context.WithPublicDID(a.publicDID),
+		context.WithDIDCommBridge(a.didcommBridge),
+		context.WithMediaTypeProfiles(a.mediaTypeProfiles),
+		context.WithThreadStore(a.threadStore),
+		context.WithAckTracker(a.ackTracker),
+		context.WithTimingMonitor(a.timingMonitor),
+		context.WithSettings(a.settings),
+		context.WithInboundDeduplicator(a.deduplicator),
+		context.WithInboundReplayGuard(a.replayGuard),
+		context.WithConnectionRecorder(a.connectionRecorder),
+		context.WithDIDRotator(a.didRotator),
 	)
 }
 
@@ -313,31 +706,6 @@ func (a *Aries) Messenger() service.Messenger {
 	return a.messenger
 }
 
-// Close frees resources being maintained by the framework.
-func (a *Aries) Close() error {
-	if a.storeProvider != nil {
-		err := a.storeProvider.Close()
-		if err != nil {
-			return fmt.Errorf("failed to close the store: %w", err)
-		}
-	}
-
-	if a.protocolStateStoreProvider != nil {
-		err := a.protocolStateStoreProvider.Close()
-		if err != nil {
-			return fmt.Errorf("failed to close the store: %w", err)
-		}
-	}
-
-	for _, inbound := range a.inboundTransports {
-		if err := inbound.Stop(); err != nil {
-			return fmt.Errorf("inbound transport close failed: %w", err)
-		}
-	}
-
-	return a.closeVDR()
-}
-
 func (a *Aries) closeVDR() error {
 	if a.vdrRegistry != nil {
 		if err := a.vdrRegistry.Close(); err != nil {
@@ -381,11 +749,21 @@ func createVDR(frameworkOpts *Aries) error {
 		opts = append(opts, vdr.WithVDR(v))
 	}
 
-	p, err := peer.New(ctx.StorageProvider())
+	var peerOpts []peer.Option
+
+	// the documents of the unqualified DIDs and of the connections protocol DIDs are only known from the
+	// connections, they are kept by the peer vdr
+	if mode := frameworkOpts.interopMode; mode != nil && (mode.UnqualifiedDIDs || mode.ConnectionsProtocol) {
+		peerOpts = append(peerOpts, peer.WithAcceptedMethods(interop.SovMethod))
+	}
+
+	p, err := peer.New(ctx.StorageProvider(), peerOpts...)
 	if err != nil {
 		return fmt.Errorf("create new vdr peer failed: %w", err)
 	}
 
+	frameworkOpts.peerVDR = p
+
 	opts = append(opts,
 		vdr.WithVDR(p),
 		vdr.WithDefaultServiceType(vdrapi.DIDCommServiceType),
@@ -395,8 +773,33 @@ func createVDR(frameworkOpts *Aries) error {
 	k := key.New()
 	opts = append(opts, vdr.WithVDR(k))
 
+	frameworkOpts.keyLinkStore, err = keylink.New(ctx)
+	if err != nil {
+		return fmt.Errorf("create key link store failed: %w", err)
+	}
+
+	opts = append(opts, vdr.WithCreateListener(frameworkOpts.keyLinkStore.HandleDIDCreated))
+
 	frameworkOpts.vdrRegistry = vdr.New(opts...)
 
+	if frameworkOpts.vdrCache {
+		frameworkOpts.vdrRegistry = vdr.NewCachingRegistry(frameworkOpts.vdrRegistry, frameworkOpts.vdrCacheOpts...)
+	}
+
+	return nil
+}
+
+func publishPublicDID(frameworkOpts *Aries) error {
+	if frameworkOpts.publicDID == "" {
+		return nil
+	}
+
+	_, err := vdr.PublishDIDCommService(frameworkOpts.vdrRegistry, frameworkOpts.publicDID,
+		serviceEndpoint(frameworkOpts), nil)
+	if err != nil {
+		return fmt.Errorf("publish public DID failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -422,16 +825,43 @@ func createOutboundDispatcher(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithKMS(frameworkOpts.kms),
 		context.WithCrypto(frameworkOpts.crypto),
-		context.WithOutboundTransports(frameworkOpts.outboundTransports...),
+		context.WithOutboundTransportRegistry(frameworkOpts.outboundRegistry),
 		context.WithPackager(frameworkOpts.packager),
 		context.WithTransportReturnRoute(frameworkOpts.transportReturnRoute),
 		context.WithVDRegistry(frameworkOpts.vdrRegistry),
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithDIDCommBridge(frameworkOpts.didcommBridge),
+		context.WithMediaTypeProfiles(frameworkOpts.mediaTypeProfiles),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
 	}
 
-	frameworkOpts.outboundDispatcher = dispatcher.NewOutbound(ctx)
+	frameworkOpts.threadStore, err = thread.NewStore(ctx)
+	if err != nil {
+		return fmt.Errorf("create thread store failed: %w", err)
+	}
+
+	outbound := dispatcher.NewOutbound(ctx)
+	// threads are tracked first, so that the custom interceptors see the messages of tracked threads
+	outbound.RegisterOutboundInterceptor(frameworkOpts.threadStore.OutboundInterceptor(frameworkOpts.serviceName))
+	outbound.RegisterOutboundInterceptor(frameworkOpts.outboundInterceptors...)
+
+	if frameworkOpts.timingMonitor != nil {
+		outbound.RegisterOutboundInterceptor(frameworkOpts.timingMonitor.OutboundInterceptor())
+	}
+
+	// acknowledgements are tracked last, so that the acknowledgements requested by the custom interceptors are tracked
+	if frameworkOpts.ackTrackingEnabled {
+		frameworkOpts.ackTracker, err = ack.New(ctx, frameworkOpts.ackTrackerOpts...)
+		if err != nil {
+			return fmt.Errorf("create ack tracker failed: %w", err)
+		}
+
+		outbound.RegisterOutboundInterceptor(frameworkOpts.ackTracker.OutboundInterceptor())
+	}
+
+	frameworkOpts.outboundDispatcher = outbound
 
 	return nil
 }
@@ -454,15 +884,261 @@ func createDIDConnectionStore(frameworkOpts *Aries) error {
 	return err
 }
 
+func createInboundDeduplicator(frameworkOpts *Aries) error {
+	if frameworkOpts.dedupWindow < 0 {
+		return nil
+	}
+
+	ctx, err := context.New(context.WithStorageProvider(frameworkOpts.storeProvider))
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.deduplicator, err = dedup.New(ctx, frameworkOpts.dedupWindow)
+	if err != nil {
+		return fmt.Errorf("create inbound deduplicator failed: %w", err)
+	}
+
+	return nil
+}
+
+func createInboundReplayGuard(frameworkOpts *Aries) error {
+	if frameworkOpts.replayTTL < 0 {
+		return nil
+	}
+
+	ctx, err := context.New(context.WithStorageProvider(frameworkOpts.storeProvider))
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.replayGuard, err = replay.New(ctx, frameworkOpts.replayTTL)
+	if err != nil {
+		return fmt.Errorf("create inbound replay guard failed: %w", err)
+	}
+
+	return nil
+}
+
+func createConnectionRecorder(frameworkOpts *Aries) error {
+	ctx, err := context.New(
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+	)
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.connectionRecorder, err = connection.NewRecorder(ctx)
+	if err != nil {
+		return fmt.Errorf("create connection recorder failed: %w", err)
+	}
+
+	return nil
+}
+
+func createSettings(frameworkOpts *Aries) error {
+	ctx, err := context.New(context.WithStorageProvider(frameworkOpts.storeProvider))
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.settings, err = settings.New(ctx)
+	if err != nil {
+		return fmt.Errorf("create settings store failed: %w", err)
+	}
+
+	frameworkOpts.startupLogLevel = log.GetLevel("")
+
+	var level string
+
+	if err = frameworkOpts.settings.Decode(settings.LogLevel, &level); err == nil {
+		frameworkOpts.applyLogLevel(level)
+	} else if !errors.Is(err, settings.ErrNotFound) {
+		logger.Warnf("stored log level is not applied: %s", err)
+	}
+
+	frameworkOpts.settingsEvents = make(chan settings.Event)
+	frameworkOpts.settings.RegisterEvent(frameworkOpts.settingsEvents)
+
+	go func(events <-chan settings.Event) {
+		for event := range events {
+			if event.Name != settings.LogLevel {
+				continue
+			}
+
+			var changed string
+
+			if event.Value != nil {
+				if errJSON := json.Unmarshal(event.Value, &changed); errJSON != nil {
+					logger.Warnf("log level is not applied: %s", errJSON)
+
+					continue
+				}
+			}
+
+			frameworkOpts.applyLogLevel(changed)
+		}
+	}(frameworkOpts.settingsEvents)
+
+	return nil
+}
+
+// applyLogLevel sets the default log level, the startup log level is restored if the level is empty.
+func (a *Aries) applyLogLevel(level string) {
+	if level == "" {
+		log.SetLevel("", a.startupLogLevel)
+
+		return
+	}
+
+	l, err := log.ParseLevel(level)
+	if err != nil {
+		logger.Warnf("log level is not applied: %s", err)
+
+		return
+	}
+
+	log.SetLevel("", l)
+}
+
+func createDIDRotator(frameworkOpts *Aries) error {
+	ctx, err := context.New(
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+		context.WithVDRegistry(frameworkOpts.vdrRegistry),
+		context.WithDIDConnectionStore(frameworkOpts.didConnectionStore),
+	)
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.didRotator, err = rotation.New(ctx)
+	if err != nil {
+		return fmt.Errorf("create DID rotator failed: %w", err)
+	}
+
+	return nil
+}
+
+// startRecipientKeyInvalidation purges the recipient keys cached by the packers when the DID of another agent
+// is rotated, as the keys of its prior DID document are no longer valid.
+func startRecipientKeyInvalidation(frameworkOpts *Aries) {
+	var caches []packer.RecipientKeyCache
+
+	for _, p := range append([]packer.Packer{frameworkOpts.primaryPacker}, frameworkOpts.packers...) {
+		if c, ok := p.(packer.RecipientKeyCache); ok {
+			caches = append(caches, c)
+		}
+	}
+
+	if len(caches) == 0 {
+		return
+	}
+
+	frameworkOpts.rotationEvents = make(chan rotation.Event)
+	frameworkOpts.didRotator.RegisterEvent(frameworkOpts.rotationEvents)
+
+	go func(events <-chan rotation.Event) {
+		for range events {
+			for _, c := range caches {
+				c.PurgeRecipientKeys()
+			}
+		}
+	}(frameworkOpts.rotationEvents)
+}
+
+func startPeerDIDGarbageCollector(frameworkOpts *Aries) {
+	if !frameworkOpts.peerDIDGCEnabled {
+		return
+	}
+
+	frameworkOpts.peerDIDGC = peer.NewGarbageCollector(frameworkOpts.peerVDR, frameworkOpts.connectionRecorder,
+		frameworkOpts.peerDIDGCOpts...)
+	frameworkOpts.peerDIDGC.Start()
+}
+
+// outboxService is a protocol service able to persist its outbound messages with its state.
+type outboxService interface {
+	EnableOutbox(opts ...outbox.Option) *outbox.Outbox
+}
+
+func startOutboxes(frameworkOpts *Aries) {
+	if !frameworkOpts.outboxEnabled {
+		return
+	}
+
+	for _, svc := range frameworkOpts.services {
+		s, ok := svc.(outboxService)
+		if !ok {
+			continue
+		}
+
+		o := s.EnableOutbox(frameworkOpts.outboxOpts...)
+		o.Start()
+
+		frameworkOpts.outboxes = append(frameworkOpts.outboxes, o)
+	}
+}
+
+// expiryService is a protocol service able to abandon its expired protocol instances.
+type expiryService interface {
+	EnableExpiry(opts ...expiry.Option) *expiry.Tracker
+}
+
+func startExpiryTrackers(frameworkOpts *Aries) {
+	if !frameworkOpts.expiryEnabled {
+		return
+	}
+
+	for _, svc := range frameworkOpts.services {
+		s, ok := svc.(expiryService)
+		if !ok {
+			continue
+		}
+
+		t := s.EnableExpiry(frameworkOpts.expiryOpts...)
+		t.Start()
+
+		frameworkOpts.expiryTrackers = append(frameworkOpts.expiryTrackers, t)
+	}
+}
+
+// localizedService is a protocol service able to localize its problem reports.
+type localizedService interface {
+	SetMessageCatalogs(catalogs *l10n.Registry)
+}
+
+func setMessageCatalogs(frameworkOpts *Aries) {
+	if frameworkOpts.messageCatalogs == nil {
+		return
+	}
+
+	for _, svc := range frameworkOpts.services {
+		if s, ok := svc.(localizedService); ok {
+			s.SetMessageCatalogs(frameworkOpts.messageCatalogs)
+		}
+	}
+}
+
 func startTransports(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithCrypto(frameworkOpts.crypto),
+		context.WithKMS(frameworkOpts.kms),
 		context.WithPackager(frameworkOpts.packager),
 		context.WithProtocolServices(frameworkOpts.services...),
 		context.WithAriesFrameworkID(frameworkOpts.id),
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 		context.WithMessengerHandler(frameworkOpts.messenger),
 		context.WithDIDConnectionStore(frameworkOpts.didConnectionStore),
+		context.WithThreadStore(frameworkOpts.threadStore),
+		context.WithAckTracker(frameworkOpts.ackTracker),
+		context.WithTimingMonitor(frameworkOpts.timingMonitor),
+		context.WithSettings(frameworkOpts.settings),
+		context.WithInboundDeduplicator(frameworkOpts.deduplicator),
+		context.WithInboundReplayGuard(frameworkOpts.replayGuard),
+		context.WithConnectionRecorder(frameworkOpts.connectionRecorder),
+		context.WithDIDRotator(frameworkOpts.didRotator),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
@@ -485,6 +1161,64 @@ func startTransports(frameworkOpts *Aries) error {
 	return nil
 }
 
+func createVerifiableStore(frameworkOpts *Aries) error {
+	if frameworkOpts.verifiableStore != nil {
+		return nil
+	}
+
+	ctx, err := context.New(context.WithStorageProvider(frameworkOpts.storeProvider))
+	if err != nil {
+		return fmt.Errorf("create context failed: %w", err)
+	}
+
+	frameworkOpts.verifiableStore, err = verifiable.New(ctx,
+		verifiable.WithEncryption(frameworkOpts.kms, frameworkOpts.crypto))
+	if err != nil {
+		return fmt.Errorf("create encrypted verifiable store failed: %w", err)
+	}
+
+	return nil
+}
+
+func createAuditLog(frameworkOpts *Aries) error {
+	if !frameworkOpts.auditLogEnabled {
+		return nil
+	}
+
+	ctx, err := context.New(context.WithStorageProvider(frameworkOpts.storeProvider))
+	if err != nil {
+		return fmt.Errorf("create context failed: %w", err)
+	}
+
+	frameworkOpts.auditLog, err = audit.New(ctx, frameworkOpts.auditLogOpts...)
+	if err != nil {
+		return fmt.Errorf("create audit log failed: %w", err)
+	}
+
+	return nil
+}
+
+func createCredentialHistory(frameworkOpts *Aries) error {
+	if !frameworkOpts.credentialHistoryEnabled {
+		return nil
+	}
+
+	ctx, err := context.New(
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+	)
+	if err != nil {
+		return fmt.Errorf("create context failed: %w", err)
+	}
+
+	frameworkOpts.credentialHistory, err = history.New(ctx, frameworkOpts.credentialHistoryOpts...)
+	if err != nil {
+		return fmt.Errorf("create credential history failed: %w", err)
+	}
+
+	return nil
+}
+
 func loadServices(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithOutboundDispatcher(frameworkOpts.outboundDispatcher),
@@ -499,6 +1233,17 @@ func loadServices(frameworkOpts *Aries) error {
 		context.WithVDRegistry(frameworkOpts.vdrRegistry),
 		context.WithVerifiableStore(frameworkOpts.verifiableStore),
 		context.WithDIDConnectionStore(frameworkOpts.didConnectionStore),
+		context.WithTrustRegistry(frameworkOpts.trustRegistry),
+		context.WithLocker(frameworkOpts.locker),
+		context.WithKeyLinkStore(frameworkOpts.keyLinkStore),
+		context.WithAuditLog(frameworkOpts.auditLog),
+		context.WithCredentialHistory(frameworkOpts.credentialHistory),
+		context.WithInteropMode(frameworkOpts.interopMode),
+		context.WithDIDExchangePublicDID(frameworkOpts.didExchangePublicDID),
+		context.WithPublicDID(frameworkOpts.publicDID),
+		context.WithDIDCommBridge(frameworkOpts.didcommBridge),
+		context.WithMediaTypeProfiles(frameworkOpts.mediaTypeProfiles),
+		context.WithThreadStore(frameworkOpts.threadStore),
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 	)
 	if err != nil {
@@ -587,3 +1332,14 @@ func fetchEndpoint(frameworkOpts *Aries, defaultScheme string) string {
 
 	return defaultEndpoint
 }
+
+// serviceName returns the name of the protocol service accepting the message type (empty if none).
+func (a *Aries) serviceName(msgType string) string {
+	for _, svc := range a.services {
+		if svc.Accept(msgType) {
+			return svc.Name()
+		}
+	}
+
+	return ""
+}
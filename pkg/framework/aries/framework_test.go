@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package aries
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -18,19 +20,31 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/random"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ack"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/l10n"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/outbox"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/legacyconnection"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/timing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	didStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/did"
@@ -44,13 +58,20 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mocklocker "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	locallock "github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/audit"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
+	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	vdrregistry "github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	spilog "github.com/hyperledger/aries-framework-go/spi/log"
 )
 
 //nolint:lll
@@ -140,6 +161,28 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdr - with cache", func(t *testing.T) {
+		aries, err := New(WithVDRCache(vdrregistry.WithCacheTTL(time.Minute)), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		require.IsType(t, &vdrregistry.CachingRegistry{}, aries.vdrRegistry)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.vdrRegistry, ctx.VDRegistry())
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test vdr - with peer DID garbage collection", func(t *testing.T) {
+		aries, err := New(WithPeerDIDGarbageCollection(peer.WithGCInterval(time.Millisecond)),
+			WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotNil(t, aries.peerDIDGC)
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test error create vdr", func(t *testing.T) {
 		_, err := New(
 			WithStoreProvider(&storage.MockStoreProvider{FailNamespace: peer.StoreNamespace}),
@@ -148,6 +191,209 @@ func TestFramework(t *testing.T) {
 		require.Contains(t, err.Error(), "create new vdr peer failed")
 	})
 
+	t.Run("test outbox", func(t *testing.T) {
+		aries, err := New(WithOutbox(outbox.WithRetryInterval(time.Millisecond)),
+			WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.Len(t, aries.outboxes, 2)
+
+		require.NoError(t, aries.Close())
+		require.Empty(t, aries.outboxes)
+	})
+
+	t.Run("test expiry", func(t *testing.T) {
+		aries, err := New(WithExpiry(expiry.WithCheckInterval(time.Millisecond)),
+			WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.Len(t, aries.expiryTrackers, 2)
+
+		require.NoError(t, aries.Close())
+		require.Empty(t, aries.expiryTrackers)
+	})
+
+	t.Run("test message catalogs", func(t *testing.T) {
+		aries, err := New(WithMessageCatalogs(&l10n.Catalog{URI: "https://example.com/catalog"}),
+			WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotNil(t, aries.messageCatalogs)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test audit log", func(t *testing.T) {
+		aries, err := New(WithAuditLog(), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotNil(t, aries.auditLog)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.auditLog, ctx.AuditLog())
+
+		require.NoError(t, aries.Close())
+
+		_, err = New(WithAuditLog(),
+			WithStoreProvider(&storage.MockStoreProvider{FailNamespace: audit.NameSpace}),
+			WithInboundTransport(&mockInboundTransport{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create audit log failed")
+	})
+
+	t.Run("test interop mode", func(t *testing.T) {
+		aries, err := New(WithInteropMode(interop.ACAPy()), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.True(t, aries.peerVDR.Accept(interop.SovMethod))
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, interop.ACAPy(), ctx.InteropMode())
+
+		_, err = ctx.Service(legacyconnection.LegacyConnection)
+		require.NoError(t, err)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test did-exchange public DID", func(t *testing.T) {
+		aries, err := New(WithDIDExchangePublicDID("did:example:public"), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, "did:example:public", ctx.DIDExchangePublicDID())
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test public DID", func(t *testing.T) {
+		doc := mockdiddoc.GetMockDIDDoc(t)
+
+		var updated *did.Doc
+
+		aries, err := New(WithPublicDID(doc.ID), WithInboundTransport(&mockInboundTransport{}),
+			WithVDR(&mockvdr.MockVDR{
+				AcceptValue: true,
+				ReadFunc: func(string, ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+					return &did.DocResolution{DIDDocument: doc}, nil
+				},
+				UpdateFunc: func(didDoc *did.Doc, _ ...vdrapi.DIDMethodOption) error {
+					updated = didDoc
+					return nil
+				},
+			}))
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, updated.ID)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, ctx.PublicDID())
+
+		require.NoError(t, aries.Close())
+
+		_, err = New(WithPublicDID("did:example:unknown"), WithInboundTransport(&mockInboundTransport{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "publish public DID failed")
+	})
+
+	t.Run("test media type profiles", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}),
+			WithMediaTypeProfiles(transport.MediaTypeProfileDIDCommV2, transport.MediaTypeProfileAIP2RFC19))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, []string{transport.MediaTypeProfileDIDCommV2, transport.MediaTypeProfileAIP2RFC19},
+			ctx.MediaTypeProfiles())
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test DIDComm bridge", func(t *testing.T) {
+		aries, err := New(WithDIDCommBridge(), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.True(t, ctx.DIDCommBridge())
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test ack tracking", func(t *testing.T) {
+		aries, err := New(WithAckTracking(ack.WithTimeout(time.Millisecond)), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.NotNil(t, ctx.AckTracker())
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test timing monitor", func(t *testing.T) {
+		aries, err := New(WithTimingMonitor(timing.WithMaxDelay(time.Second)),
+			WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotNil(t, aries.timingMonitor)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.timingMonitor, ctx.TimingMonitor())
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test settings", func(t *testing.T) {
+		startupLevel := log.GetLevel("")
+		storeProvider := mem.NewProvider()
+
+		// the log level stored by a previous run overrides the startup log level
+		storeCtx, err := context.New(context.WithStorageProvider(storeProvider))
+		require.NoError(t, err)
+
+		stored, err := settings.New(storeCtx)
+		require.NoError(t, err)
+		require.NoError(t, stored.Set(settings.LogLevel, json.RawMessage(`"critical"`)))
+
+		aries, err := New(WithStoreProvider(storeProvider), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotNil(t, aries.settings)
+		require.Equal(t, spilog.CRITICAL, log.GetLevel(""))
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.settings, ctx.Settings())
+
+		require.NoError(t, ctx.Settings().Set(settings.LogLevel, json.RawMessage(`"error"`)))
+		require.Eventually(t, func() bool {
+			return log.GetLevel("") == spilog.ERROR
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, ctx.Settings().Remove(settings.LogLevel))
+		require.Eventually(t, func() bool {
+			return log.GetLevel("") == startupLevel
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test credential history", func(t *testing.T) {
+		aries, err := New(WithCredentialHistory(), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotNil(t, aries.credentialHistory)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.credentialHistory, ctx.CredentialHistory())
+
+		require.NoError(t, aries.Close())
+
+		_, err = New(WithCredentialHistory(),
+			WithStoreProvider(&storage.MockStoreProvider{FailNamespace: history.NameSpace}),
+			WithInboundTransport(&mockInboundTransport{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create credential history failed")
+	})
+
 	t.Run("test vdr - close error", func(t *testing.T) {
 		vdr := &mockvdr.MockVDR{CloseErr: fmt.Errorf("close vdr error")}
 		aries, err := New(WithVDR(vdr), WithInboundTransport(&mockInboundTransport{}))
@@ -540,6 +786,82 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, aries.Close())
 	})
 
+	t.Run("test new with outbound interceptors", func(t *testing.T) {
+		intercepted := make(chan *dispatcher.OutboundMessage, 1)
+
+		aries, err := New(WithOutboundInterceptors(func(next dispatcher.OutboundHandler) dispatcher.OutboundHandler {
+			return dispatcher.OutboundHandlerFunc(func(msg *dispatcher.OutboundMessage) error {
+				intercepted <- msg
+
+				return nil
+			})
+		}))
+		require.NoError(t, err)
+		require.Len(t, aries.outboundInterceptors, 1)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		require.NoError(t, ctx.OutboundDispatcher().Send(service.DIDCommMsgMap{
+			"@id":   "1",
+			"@type": didexchange.RequestMsgType,
+		}, "", &service.Destination{}))
+		require.Equal(t, "1", (<-intercepted).Msg.ID())
+
+		// the thread of the message was tracked before the custom interceptors
+		rec, err := ctx.ThreadStore().Get("1")
+		require.NoError(t, err)
+		require.Equal(t, didexchange.DIDExchange, rec.Service)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with inbound deduplication window", func(t *testing.T) {
+		aries, err := New()
+		require.NoError(t, err)
+		require.NotNil(t, aries.deduplicator)
+		require.NoError(t, aries.Close())
+
+		aries, err = New(WithInboundDeduplicationWindow(time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, aries.deduplicator)
+		require.Equal(t, time.Minute, aries.dedupWindow)
+		require.NoError(t, aries.Close())
+
+		aries, err = New(WithInboundDeduplicationWindow(-1))
+		require.NoError(t, err)
+		require.Nil(t, aries.deduplicator)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with inbound replay protection", func(t *testing.T) {
+		aries, err := New()
+		require.NoError(t, err)
+		require.NotNil(t, aries.replayGuard)
+		require.NoError(t, aries.Close())
+
+		aries, err = New(WithInboundReplayProtection(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, aries.replayGuard)
+		require.Equal(t, time.Hour, aries.replayTTL)
+		require.NoError(t, aries.Close())
+
+		aries, err = New(WithInboundReplayProtection(-1))
+		require.NoError(t, err)
+		require.Nil(t, aries.replayGuard)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with DID rotator", func(t *testing.T) {
+		aries, err := New()
+		require.NoError(t, err)
+		require.NotNil(t, aries.didRotator)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.didRotator, ctx.DIDRotator())
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test new with messenger handler", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -603,12 +925,110 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, mockStore, aries.verifiableStore)
 	})
 
+	t.Run("test verifiable store encryption option", func(t *testing.T) {
+		aries, err := New(WithVerifiableStoreEncryption())
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		store, ok := ctx.VerifiableStore().(*verifiable.StoreImplementation)
+		require.True(t, ok)
+
+		keyID, err := store.RotateEncryptionKey()
+		require.NoError(t, err)
+		require.NotEmpty(t, keyID)
+
+		require.NoError(t, aries.Close())
+
+		mockStore := &verifiableStoreMocks.MockStore{}
+		aries, err = New(WithVerifiableStoreEncryption(), WithVerifiableStore(mockStore))
+		require.NoError(t, err)
+		require.Equal(t, mockStore, aries.verifiableStore)
+	})
+
 	t.Run("test DID connection store option", func(t *testing.T) {
 		mockStore := &didStoreMocks.MockConnectionStore{}
 		aries, err := New(WithDIDConnectionStore(mockStore))
 		require.NoError(t, err)
 		require.Equal(t, mockStore, aries.didConnectionStore)
 	})
+
+	t.Run("test trust registry option", func(t *testing.T) {
+		registry := trustregistry.NewHTTPRegistry("https://registry.example.com")
+		aries, err := New(WithTrustRegistry(registry))
+		require.NoError(t, err)
+		require.Equal(t, registry, aries.trustRegistry)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, registry, ctx.TrustRegistry())
+	})
+
+	t.Run("test locker option", func(t *testing.T) {
+		locker := &mocklocker.MockLocker{}
+		aries, err := New(WithLocker(locker))
+		require.NoError(t, err)
+		require.Equal(t, locker, aries.locker)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, locker, ctx.Locker())
+	})
+
+	t.Run("test random source option", func(t *testing.T) {
+		source := &constantReader{value: 42}
+		aries, err := New(WithRandomSource(source))
+		require.NoError(t, err)
+		require.Equal(t, source, aries.randomSource)
+
+		expected := bytes.Repeat([]byte{42}, 32)
+
+		b := make([]byte, 32)
+		_, err = random.Read(b)
+		require.NoError(t, err)
+		require.Equal(t, expected, b)
+
+		require.NoError(t, aries.Close())
+
+		_, err = random.Read(b)
+		require.NoError(t, err)
+		require.NotEqual(t, expected, b)
+	})
+
+	t.Run("test random source option - nested install is refused", func(t *testing.T) {
+		aries, err := New(WithRandomSource(&constantReader{value: 42}))
+		require.NoError(t, err)
+
+		_, err = New(WithRandomSource(&constantReader{value: 7}))
+		require.ErrorIs(t, err, random.ErrSourceInstalled)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test random source option - source restored when New fails", func(t *testing.T) {
+		newMockSvc := func(prv api.Provider) (dispatcher.ProtocolService, error) {
+			return nil, errors.New("error creating the protocol")
+		}
+		_, err := New(WithRandomSource(&constantReader{value: 42}), WithProtocols(newMockSvc))
+		require.Error(t, err)
+
+		restore, err := random.SetSource(&constantReader{value: 7})
+		require.NoError(t, err)
+		restore()
+	})
+}
+
+type constantReader struct {
+	value byte
+}
+
+func (r *constantReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = r.value
+	}
+
+	return len(b), nil
 }
 
 func Test_Packager(t *testing.T) {
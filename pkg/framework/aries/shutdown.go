@@ -0,0 +1,134 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+// Close frees resources being maintained by the framework.
+func (a *Aries) Close() error {
+	return a.Shutdown(context.Background())
+}
+
+// Shutdown gracefully shuts the framework down: the inbound transports stop accepting messages and drain the
+// messages being handled, the websocket connections are closed, the peer DID garbage collection, the outboxes, the
+// expiry trackers and the acknowledgement tracker are stopped, then the stores and the VDR registry are closed.
+// When the context is done before the transports are drained, the stores and the VDR registry are closed anyway
+// and the transport error is returned.
+func (a *Aries) Shutdown(ctx context.Context) error {
+	errTransports := a.shutdownTransports(ctx)
+
+	if a.peerDIDGC != nil {
+		a.peerDIDGC.Stop()
+	}
+
+	for _, o := range a.outboxes {
+		o.Stop()
+	}
+
+	a.outboxes = nil
+
+	for _, t := range a.expiryTrackers {
+		t.Stop()
+	}
+
+	a.expiryTrackers = nil
+
+	if a.ackTracker != nil {
+		a.ackTracker.Stop()
+	}
+
+	if a.settingsEvents != nil {
+		a.settings.UnregisterEvent(a.settingsEvents)
+		close(a.settingsEvents)
+		a.settingsEvents = nil
+	}
+
+	if a.rotationEvents != nil {
+		a.didRotator.UnregisterEvent(a.rotationEvents)
+		close(a.rotationEvents)
+		a.rotationEvents = nil
+	}
+
+	a.restoreRandom()
+
+	if err := a.closeStores(); err != nil {
+		return err
+	}
+
+	if err := a.closeVDR(); err != nil {
+		return err
+	}
+
+	return errTransports
+}
+
+// shutdownTransports shuts all the transports down and returns the first error.
+func (a *Aries) shutdownTransports(ctx context.Context) error {
+	var firstErr error
+
+	for _, inbound := range a.inboundTransports {
+		var err error
+
+		if s, ok := inbound.(transport.Shutdowner); ok {
+			err = s.Shutdown(ctx)
+		} else {
+			err = inbound.Stop()
+		}
+
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("inbound transport close failed: %w", err)
+		}
+	}
+
+	outbounds := a.outboundTransports
+	if a.outboundRegistry != nil {
+		outbounds = a.outboundRegistry.Transports()
+	}
+
+	for _, outbound := range outbounds {
+		s, ok := outbound.(transport.Shutdowner)
+		if !ok {
+			continue
+		}
+
+		if err := s.Shutdown(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("outbound transport close failed: %w", err)
+		}
+	}
+
+	return firstErr
+}
+
+func (a *Aries) closeStores() error {
+	if a.storeProvider != nil {
+		err := a.storeProvider.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close the store: %w", err)
+		}
+	}
+
+	if a.protocolStateStoreProvider != nil {
+		err := a.protocolStateStoreProvider.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close the store: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Aries) restoreRandom() {
+	if a.restoreRandomSource != nil {
+		a.restoreRandomSource()
+		a.restoreRandomSource = nil
+	}
+}
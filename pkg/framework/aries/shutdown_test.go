@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestAries_Shutdown(t *testing.T) {
+	t.Run("transports are shut down with the context", func(t *testing.T) {
+		inbound := &mockGracefulInbound{}
+		outbound := &mockGracefulOutbound{MockOutboundTransport: mockdidcomm.NewMockOutboundTransport("")}
+
+		aries, err := New(WithInboundTransport(inbound, &mockInboundTransport{}), WithOutboundTransports(outbound))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		require.NoError(t, aries.Shutdown(ctx))
+		require.Equal(t, ctx, inbound.ctx)
+		require.Equal(t, ctx, outbound.ctx)
+	})
+
+	t.Run("stores are closed when the transports fail to drain", func(t *testing.T) {
+		store := &closeTrackingProvider{MockStoreProvider: mockstorage.NewMockStoreProvider()}
+
+		aries, err := New(WithStoreProvider(store),
+			WithInboundTransport(&mockGracefulInbound{err: context.DeadlineExceeded}),
+			WithOutboundTransports(&mockGracefulOutbound{
+				MockOutboundTransport: mockdidcomm.NewMockOutboundTransport(""),
+				err:                   errors.New("outbound error"),
+			}))
+		require.NoError(t, err)
+
+		err = aries.Shutdown(context.Background())
+		require.EqualError(t, err, "inbound transport close failed: "+context.DeadlineExceeded.Error())
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.True(t, store.closed)
+	})
+
+	t.Run("outbound transport error", func(t *testing.T) {
+		aries, err := New(WithOutboundTransports(&mockGracefulOutbound{
+			MockOutboundTransport: mockdidcomm.NewMockOutboundTransport(""),
+			err:                   errors.New("outbound error"),
+		}))
+		require.NoError(t, err)
+
+		require.EqualError(t, aries.Shutdown(context.Background()), "outbound transport close failed: outbound error")
+	})
+
+	t.Run("store close error", func(t *testing.T) {
+		aries, err := New(WithStoreProvider(&mockstorage.MockStoreProvider{
+			Store:    &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)},
+			ErrClose: errors.New("close error"),
+		}))
+		require.NoError(t, err)
+
+		require.EqualError(t, aries.Shutdown(context.Background()), "failed to close the store: close error")
+	})
+}
+
+type mockGracefulInbound struct {
+	mockInboundTransport
+	ctx context.Context
+	err error
+}
+
+func (m *mockGracefulInbound) Shutdown(ctx context.Context) error {
+	m.ctx = ctx
+
+	return m.err
+}
+
+type mockGracefulOutbound struct {
+	*mockdidcomm.MockOutboundTransport
+	ctx context.Context
+	err error
+}
+
+func (m *mockGracefulOutbound) Shutdown(ctx context.Context) error {
+	m.ctx = ctx
+
+	return m.err
+}
+
+type closeTrackingProvider struct {
+	*mockstorage.MockStoreProvider
+	closed bool
+}
+
+func (p *closeTrackingProvider) Close() error {
+	p.closed = true
+
+	return p.MockStoreProvider.Close()
+}
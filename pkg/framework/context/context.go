@@ -7,26 +7,50 @@ SPDX-License-Identifier: Apache-2.0
 package context
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ack"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/interop"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dedup"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/legacyconnection"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/replay"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/rotation"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/thread"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/timing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/store/audit"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/history"
+	"github.com/hyperledger/aries-framework-go/pkg/store/keylink"
+	"github.com/hyperledger/aries-framework-go/pkg/store/settings"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
+var logger = log.New("aries-framework/framework/context")
+
+var errNoMessageHandler = errors.New("no message handlers found")
+
 // package context creates a framework Provider context to add optional (non default) framework services and provides
 // simple accessor methods to those same services.
 
@@ -46,10 +70,29 @@ type Provider struct {
 	routerEndpoint             string
 	outboundDispatcher         dispatcher.Outbound
 	messenger                  service.MessengerHandler
-	outboundTransports         []transport.OutboundTransport
+	outboundTransports         *transport.OutboundRegistry
+	inboundTransports          []transport.InboundTransport
 	vdr                        vdrapi.Registry
 	verifiableStore            verifiable.Store
 	didConnectionStore         did.ConnectionStore
+	trustRegistry              trustregistry.Registry
+	locker                     lock.Locker
+	keyLinkStore               *keylink.Store
+	auditLog                   *audit.Log
+	credentialHistory          *history.Store
+	interopMode                *interop.Mode
+	didExchangePublicDID       string
+	publicDID                  string
+	didcommBridge              bool
+	mediaTypeProfiles          []string
+	threadStore                *thread.Store
+	ackTracker                 *ack.Tracker
+	deduplicator               *dedup.Deduplicator
+	replayGuard                *replay.Guard
+	timingMonitor              *timing.Monitor
+	settings                   *settings.Store
+	connectionRecorder         *connection.Recorder
+	didRotator                 *rotation.Rotator
 	transportReturnRoute       string
 	frameworkID                string
 }
@@ -89,9 +132,24 @@ func (p *Provider) OutboundDispatcher() dispatcher.Outbound {
 
 // OutboundTransports returns an outbound transports.
 func (p *Provider) OutboundTransports() []transport.OutboundTransport {
+	if p.outboundTransports == nil {
+		return nil
+	}
+
+	return p.outboundTransports.Transports()
+}
+
+// OutboundTransportRegistry returns the registry of the outbound transports, used to add or remove outbound
+// transports while the framework is running (nil if not defined).
+func (p *Provider) OutboundTransportRegistry() *transport.OutboundRegistry {
 	return p.outboundTransports
 }
 
+// InboundTransports returns the inbound transports of the framework.
+func (p *Provider) InboundTransports() []transport.InboundTransport {
+	return p.inboundTransports
+}
+
 // Service return protocol service.
 func (p *Provider) Service(id string) (interface{}, error) {
 	for _, v := range p.services {
@@ -171,67 +229,265 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 			return err
 		}
 
-		// find the service which accepts the message type
-		for _, svc := range p.services {
-			if svc.Accept(msg.Type()) {
-				var myDID, theirDID string
-
-				switch svc.Name() {
-				// perf: DID exchange doesn't require myDID and theirDID
-				case didexchange.DIDExchange:
-				default:
-					myDID, theirDID, err = p.getDIDs(envelope)
-					if err != nil {
-						return fmt.Errorf("inbound message handler: %w", err)
-					}
-				}
+		// the protocols are implemented with DIDComm v1 messages
+		if p.didcommBridge && dispatcher.IsDIDCommV2(msg) {
+			msg = dispatcher.ToDIDCommV1(msg)
+		}
+
+		sender, recipient := base58.Encode(envelope.FromKey), base58.Encode(envelope.ToKey)
+
+		if p.deduplicator != nil {
+			duplicate, errDup := p.deduplicator.Receive(msg.ID(), sender, recipient)
+			if errDup != nil {
+				return fmt.Errorf("inbound message handler: %w", errDup)
+			}
+
+			if duplicate {
+				logger.Infof("inbound message handler: duplicate message %s is ignored", msg.ID())
+
+				return nil
+			}
+		}
+
+		if err = p.handleReceived(msg, envelope); err != nil {
+			p.forgetInbound(msg, sender, recipient)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// handleReceived handles the inbound message that is not a duplicate. The nonces of the message are reserved
+// while it is handled, they are released if the handling fails.
+func (p *Provider) handleReceived(msg service.DIDCommMsgMap, envelope *transport.Envelope) (err error) {
+	if p.replayGuard != nil {
+		if err = p.replayGuard.Reserve(msg); err != nil {
+			return fmt.Errorf("inbound message handler: %w", err)
+		}
+
+		defer func() {
+			if err != nil {
+				p.releaseNonces(msg)
+			}
+		}()
+	}
+
+	receivedAt := time.Now()
+
+	if p.timingMonitor != nil {
+		if receivedAt, err = p.timingMonitor.HandleInbound(msg); err != nil {
+			return fmt.Errorf("inbound message handler: %w", err)
+		}
+	}
+
+	if err = p.verifyAttachments(msg); err != nil {
+		return fmt.Errorf("inbound message handler: %w", err)
+	}
+
+	acknowledged := p.ackTracker != nil && p.ackTracker.HandleInbound(msg)
+
+	err = p.handleInbound(msg, envelope)
+	// the acknowledgements without a protocol service (e.g. notification/1.0/ack) are handled by the tracker
+	if err != nil && !(acknowledged && errors.Is(err, errNoMessageHandler)) {
+		return err
+	}
+
+	p.trackLatency(msg, envelope, receivedAt)
+
+	return nil
+}
+
+// verifyAttachments verifies the signed attachments of the message (e.g. the did_doc~attach of the did-exchange
+// requests), the messages with an invalid signature are rejected. The unsigned attachments are not verified.
+// The signers are exposed in the message metadata (service.AttachmentSignersMetadataKey), a valid signature
+// doesn't mean the signer is allowed to sign the attachment.
+func (p *Provider) verifyAttachments(msg service.DIDCommMsgMap) error {
+	if p.crypto == nil || p.kms == nil {
+		return nil
+	}
+
+	signers := map[string][]string{}
+
+	for name, value := range msg {
+		if !strings.HasSuffix(name, "~attach") {
+			continue
+		}
+
+		src, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", name, err)
+		}
 
-				_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID,
-					map[string]interface{}{
-						service.DIDCommContextEnvelopeMediaTypeKey: envelope.MediaType,
-					},
-				))
+		var attachments []decorator.Attachment
 
-				return err
+		if err = json.Unmarshal(src, &attachments); err != nil {
+			// a single attachment, e.g. did_doc~attach
+			var attachment decorator.Attachment
+			if err = json.Unmarshal(src, &attachment); err != nil {
+				continue
 			}
+
+			attachments = append(attachments, attachment)
 		}
 
-		// in case of no services are registered for given message type,
-		// find generic inbound services registered for given message header
-		for _, svc := range p.msgSvcProvider.Services() {
-			h := struct {
-				Purpose []string `json:"~purpose"`
-			}{}
-			err = msg.Decode(&h)
+		for i := range attachments {
+			if attachments[i].Data.JWS == nil {
+				continue
+			}
 
+			signer, err := attachments[i].Data.Verify(p.crypto, p.kms)
 			if err != nil {
-				return err
+				return fmt.Errorf("invalid signature of %s: %w", name, err)
 			}
 
-			if svc.Accept(msg.Type(), h.Purpose) {
-				myDID, theirDID, err := p.getDIDs(envelope)
+			signers[name] = append(signers[name], signer)
+		}
+	}
+
+	if metadata := msg.Metadata(); metadata != nil && len(signers) > 0 {
+		metadata[service.AttachmentSignersMetadataKey] = signers
+	}
+
+	return nil
+}
+
+// forgetInbound removes the message that failed from the received ones, so that it is handled again
+// when redelivered.
+func (p *Provider) forgetInbound(msg service.DIDCommMsgMap, sender, recipient string) {
+	if p.deduplicator != nil {
+		if err := p.deduplicator.Forget(msg.ID(), sender, recipient); err != nil {
+			logger.Warnf("inbound message handler: %s", err)
+		}
+	}
+}
+
+// releaseNonces releases the nonces of the message that failed, so that it is handled again when redelivered.
+func (p *Provider) releaseNonces(msg service.DIDCommMsgMap) {
+	if err := p.replayGuard.Forget(msg); err != nil {
+		logger.Warnf("inbound message handler: %s", err)
+	}
+}
+
+// trackLatency updates the latency of the connection the message handled successfully was received on.
+func (p *Provider) trackLatency(msg service.DIDCommMsgMap, envelope *transport.Envelope, receivedAt time.Time) {
+	if p.timingMonitor == nil || p.didConnectionStore == nil || timing.Decode(msg) == nil {
+		return
+	}
+
+	_, theirDID, err := p.getDIDs(envelope)
+	if err != nil {
+		logger.Debugf("latency of message %s is not tracked: %s", msg.ID(), err)
+
+		return
+	}
+
+	p.timingMonitor.TrackInbound(msg, theirDID, receivedAt)
+}
+
+func (p *Provider) handleInbound(msg service.DIDCommMsgMap, envelope *transport.Envelope) error {
+	var err error
+
+	// the rotation must be applied before the sender is looked up, its key belongs to the new DID
+	if p.didRotator != nil {
+		if err = p.didRotator.HandleInboundMessage(msg); err != nil {
+			return fmt.Errorf("inbound message handler: %w", err)
+		}
+	}
+
+	// find the service which accepts the message type
+	for _, svc := range p.services {
+		if svc.Accept(msg.Type()) {
+			var myDID, theirDID, piid string
+
+			switch svc.Name() {
+			// perf: DID exchange and connections don't require myDID and theirDID
+			case didexchange.DIDExchange, legacyconnection.LegacyConnection:
+			default:
+				myDID, theirDID, err = p.getDIDs(envelope)
 				if err != nil {
 					return fmt.Errorf("inbound message handler: %w", err)
 				}
+			}
 
-				return p.tryToHandle(svc, msg, service.NewDIDCommContext(
-					myDID, theirDID,
-					map[string]interface{}{
-						service.DIDCommContextEnvelopeMediaTypeKey: envelope.MediaType,
-					},
-				))
+			piid, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID,
+				map[string]interface{}{
+					service.DIDCommContextEnvelopeMediaTypeKey: envelope.MediaType,
+					service.DIDCommContextRecipientKeyKey:      envelope.ToKey,
+				},
+			))
+			if err == nil {
+				p.trackInbound(msg, envelope.MediaType, svc.Name(), piid, myDID, theirDID)
 			}
+
+			return err
+		}
+	}
+
+	// in case of no services are registered for given message type,
+	// find generic inbound services registered for given message header
+	for _, svc := range p.msgSvcProvider.Services() {
+		h := struct {
+			Purpose []string `json:"~purpose"`
+		}{}
+		err = msg.Decode(&h)
+
+		if err != nil {
+			return err
 		}
 
-		return fmt.Errorf("no message handlers found for the message type: %s", msg.Type())
+		if svc.Accept(msg.Type(), h.Purpose) {
+			myDID, theirDID, err := p.getDIDs(envelope)
+			if err != nil {
+				return fmt.Errorf("inbound message handler: %w", err)
+			}
+
+			err = p.tryToHandle(svc, msg, service.NewDIDCommContext(
+				myDID, theirDID,
+				map[string]interface{}{
+					service.DIDCommContextEnvelopeMediaTypeKey: envelope.MediaType,
+				},
+			))
+			if err == nil {
+				p.trackInbound(msg, envelope.MediaType, svc.Name(), "", myDID, theirDID)
+			}
+
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w for the message type: %s", errNoMessageHandler, msg.Type())
+}
+
+// trackInbound records the thread of the handled message and the protocol versions supported by the sender.
+func (p *Provider) trackInbound(msg service.DIDCommMsgMap, mediaType, svcName, piid, myDID, theirDID string) {
+	if p.threadStore != nil {
+		p.threadStore.TrackInbound(msg, svcName, piid, myDID, theirDID)
+	}
+
+	if p.connectionRecorder == nil || myDID == "" || theirDID == "" {
+		return
+	}
+
+	if err := p.connectionRecorder.TrackInbound(msg.Type(), mediaType, myDID, theirDID); err != nil {
+		logger.Warnf("failed to track protocol versions of the connection: %s", err)
 	}
 }
 
 func (p *Provider) getDIDs(envelope *transport.Envelope) (string, string, error) {
-	myDID, err := p.didConnectionStore.GetDID(base58.Encode(envelope.ToKey))
-	if errors.Is(err, did.ErrNotFound) {
-	} else if err != nil {
-		return "", "", fmt.Errorf("failed to get my did: %w", err)
+	var (
+		myDID string
+		err   error
+	)
+
+	// signed envelopes are not addressed to a recipient key
+	if len(envelope.ToKey) > 0 {
+		myDID, err = p.didConnectionStore.GetDID(base58.Encode(envelope.ToKey))
+		if errors.Is(err, did.ErrNotFound) {
+		} else if err != nil {
+			return "", "", fmt.Errorf("failed to get my did: %w", err)
+		}
 	}
 
 	theirDID, err := p.didConnectionStore.GetDID(base58.Encode(envelope.FromKey))
@@ -288,13 +544,108 @@ func (p *Provider) DIDConnectionStore() did.ConnectionStore {
 	return p.didConnectionStore
 }
 
+// TrustRegistry returns the trust registry consulted when verifying credentials (nil if not defined).
+func (p *Provider) TrustRegistry() trustregistry.Registry {
+	return p.trustRegistry
+}
+
+// Locker returns the locker coordinating the agent instances sharing the protocol state (nil if not defined).
+func (p *Provider) Locker() lock.Locker {
+	return p.locker
+}
+
+// KeyLinkStore returns the store of links between KMS keys and verification methods of the agent's DIDs.
+func (p *Provider) KeyLinkStore() *keylink.Store {
+	return p.keyLinkStore
+}
+
+// AuditLog returns the audit log of the credential operations (nil if auditing is not enabled).
+func (p *Provider) AuditLog() *audit.Log {
+	return p.auditLog
+}
+
+// CredentialHistory returns the history of the credential exchanges (nil if the history is not enabled).
+func (p *Provider) CredentialHistory() *history.Store {
+	return p.credentialHistory
+}
+
+// InteropMode returns the compatibility quirks enabled on the agent (nil if none).
+func (p *Provider) InteropMode() *interop.Mode {
+	return p.interopMode
+}
+
+// DIDExchangePublicDID returns the public DID the did-exchange requests are responded with (an empty string if
+// a new peer DID is created for each connection).
+func (p *Provider) DIDExchangePublicDID() string {
+	return p.didExchangePublicDID
+}
+
+// PublicDID returns the public DID the agent uses for all its connections (an empty string if a peer DID is created
+// for each connection).
+func (p *Provider) PublicDID() string {
+	return p.publicDID
+}
+
+// DIDCommBridge returns true if the DIDComm v1 and v2 messages are converted to the version the protocols
+// (inbound) or the destinations (outbound) use.
+func (p *Provider) DIDCommBridge() bool {
+	return p.didcommBridge
+}
+
+// MediaTypeProfiles returns the media type profiles the agent supports, in its order of preference (nil if not
+// defined, the default profiles are supported then).
+func (p *Provider) MediaTypeProfiles() []string {
+	return p.mediaTypeProfiles
+}
+
+// ThreadStore returns the store of the threads of the messages exchanged by the agent (nil if not defined).
+func (p *Provider) ThreadStore() *thread.Store {
+	return p.threadStore
+}
+
+// AckTracker returns the tracker of the acknowledgements requested by the outbound messages (nil if not defined).
+func (p *Provider) AckTracker() *ack.Tracker {
+	return p.ackTracker
+}
+
+// TimingMonitor returns the connection monitor measuring the latencies of the connections (nil if not defined).
+func (p *Provider) TimingMonitor() *timing.Monitor {
+	return p.timingMonitor
+}
+
+// Settings returns the store of the runtime-tunable parameters of the agent (nil if not defined).
+func (p *Provider) Settings() *settings.Store {
+	return p.settings
+}
+
+// DIDRotator returns the handler of the DID rotations of the other agents (nil if not defined).
+func (p *Provider) DIDRotator() *rotation.Rotator {
+	return p.didRotator
+}
+
 // ProviderOption configures the framework.
 type ProviderOption func(opts *Provider) error
 
 // WithOutboundTransports injects an outbound transports into the context.
 func WithOutboundTransports(transports ...transport.OutboundTransport) ProviderOption {
 	return func(opts *Provider) error {
-		opts.outboundTransports = transports
+		opts.outboundTransports = transport.NewOutboundRegistry(transports...)
+		return nil
+	}
+}
+
+// WithOutboundTransportRegistry injects the registry of the outbound transports into the context.
+func WithOutboundTransportRegistry(registry *transport.OutboundRegistry) ProviderOption {
+	return func(opts *Provider) error {
+		opts.outboundTransports = registry
+		return nil
+	}
+}
+
+// WithInboundTransports injects the inbound transports into the context.
+func WithInboundTransports(transports ...transport.InboundTransport) ProviderOption {
+	return func(opts *Provider) error {
+		opts.inboundTransports = transports
 		return nil
 	}
 }
@@ -448,3 +799,154 @@ func WithDIDConnectionStore(store did.ConnectionStore) ProviderOption {
 		return nil
 	}
 }
+
+// WithKeyLinkStore injects a key link store into the context.
+func WithKeyLinkStore(store *keylink.Store) ProviderOption {
+	return func(opts *Provider) error {
+		opts.keyLinkStore = store
+		return nil
+	}
+}
+
+// WithAuditLog injects an audit log into the context.
+func WithAuditLog(auditLog *audit.Log) ProviderOption {
+	return func(opts *Provider) error {
+		opts.auditLog = auditLog
+		return nil
+	}
+}
+
+// WithCredentialHistory injects a credential history into the context.
+func WithCredentialHistory(credentialHistory *history.Store) ProviderOption {
+	return func(opts *Provider) error {
+		opts.credentialHistory = credentialHistory
+		return nil
+	}
+}
+
+// WithInteropMode injects the compatibility quirks enabled on the agent into the context.
+func WithInteropMode(mode *interop.Mode) ProviderOption {
+	return func(opts *Provider) error {
+		opts.interopMode = mode
+		return nil
+	}
+}
+
+// WithDIDExchangePublicDID injects the public DID the did-exchange requests are responded with into the context.
+func WithDIDExchangePublicDID(publicDID string) ProviderOption {
+	return func(opts *Provider) error {
+		opts.didExchangePublicDID = publicDID
+		return nil
+	}
+}
+
+// WithPublicDID injects the public DID the agent uses for all its connections into the context.
+func WithPublicDID(publicDID string) ProviderOption {
+	return func(opts *Provider) error {
+		opts.publicDID = publicDID
+		return nil
+	}
+}
+
+// WithDIDCommBridge enables the conversion of the DIDComm v2 inbound messages to DIDComm v1 messages,
+// the version the protocols are implemented with.
+func WithDIDCommBridge(enabled bool) ProviderOption {
+	return func(opts *Provider) error {
+		opts.didcommBridge = enabled
+		return nil
+	}
+}
+
+// WithMediaTypeProfiles injects the media type profiles the agent supports into the context.
+func WithMediaTypeProfiles(profiles []string) ProviderOption {
+	return func(opts *Provider) error {
+		opts.mediaTypeProfiles = profiles
+		return nil
+	}
+}
+
+// WithConnectionRecorder injects the connection recorder into the context. The inbound message handler records
+// the DIDComm version and the protocols supported by the other agents of the connections.
+func WithConnectionRecorder(recorder *connection.Recorder) ProviderOption {
+	return func(opts *Provider) error {
+		opts.connectionRecorder = recorder
+		return nil
+	}
+}
+
+// WithDIDRotator injects the DID rotation handler into the context. The inbound message handler applies
+// the DID rotations signaled by the `from_prior` header of the messages.
+func WithDIDRotator(rotator *rotation.Rotator) ProviderOption {
+	return func(opts *Provider) error {
+		opts.didRotator = rotator
+		return nil
+	}
+}
+
+// WithInboundDeduplicator injects the deduplicator of inbound messages into the context.
+// The messages already received from the same sender are ignored by the inbound message handler.
+func WithInboundDeduplicator(deduplicator *dedup.Deduplicator) ProviderOption {
+	return func(opts *Provider) error {
+		opts.deduplicator = deduplicator
+		return nil
+	}
+}
+
+// WithInboundReplayGuard injects the replay guard of inbound messages into the context.
+// The messages carrying nonces already seen are rejected by the inbound message handler.
+func WithInboundReplayGuard(guard *replay.Guard) ProviderOption {
+	return func(opts *Provider) error {
+		opts.replayGuard = guard
+		return nil
+	}
+}
+
+// WithTimingMonitor injects the connection monitor into the context. The inbound messages are delayed and expired
+// as requested by their `~timing` decorator, and the latencies of the connections are measured.
+func WithTimingMonitor(monitor *timing.Monitor) ProviderOption {
+	return func(opts *Provider) error {
+		opts.timingMonitor = monitor
+		return nil
+	}
+}
+
+// WithSettings injects the store of the runtime-tunable parameters of the agent into the context.
+func WithSettings(store *settings.Store) ProviderOption {
+	return func(opts *Provider) error {
+		opts.settings = store
+		return nil
+	}
+}
+
+// WithThreadStore injects a thread store into the context.
+func WithThreadStore(store *thread.Store) ProviderOption {
+	return func(opts *Provider) error {
+		opts.threadStore = store
+		return nil
+	}
+}
+
+// WithAckTracker injects the tracker of the acknowledgements into the context.
+// The inbound acknowledgements update the delivery status of the tracked messages.
+func WithAckTracker(tracker *ack.Tracker) ProviderOption {
+	return func(opts *Provider) error {
+		opts.ackTracker = tracker
+		return nil
+	}
+}
+
+// WithTrustRegistry injects a trust registry into the context.
+func WithTrustRegistry(registry trustregistry.Registry) ProviderOption {
+	return func(opts *Provider) error {
+		opts.trustRegistry = registry
+		return nil
+	}
+}
+
+// WithLocker injects a locker into the context.
+func WithLocker(locker lock.Locker) ProviderOption {
+	return func(opts *Provider) error {
+		opts.locker = locker
+		return nil
+	}
+}
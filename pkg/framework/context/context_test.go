@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package context
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -17,24 +19,44 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ack"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dedup"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/expiry"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/replay"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/rotation"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/thread"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/timing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/trustregistry"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	didStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/did"
 	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
 	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+	mockprotocol "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mocklocker "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mocklock "github.com/hyperledger/aries-framework-go/pkg/mock/secretlock"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/keylink"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -82,6 +104,30 @@ func TestNewProvider(t *testing.T) {
 		require.NotNil(t, prov.DIDConnectionStore())
 	})
 
+	t.Run("test new with key link store", func(t *testing.T) {
+		storeProv, err := New(WithStorageProvider(mockstorage.NewMockStoreProvider()))
+		require.NoError(t, err)
+
+		store, err := keylink.New(storeProv)
+		require.NoError(t, err)
+
+		prov, err := New(WithKeyLinkStore(store))
+		require.NoError(t, err)
+		require.Equal(t, store, prov.KeyLinkStore())
+	})
+
+	t.Run("test new with trust registry", func(t *testing.T) {
+		prov, err := New(WithTrustRegistry(trustregistry.NewHTTPRegistry("https://registry.example.com")))
+		require.NoError(t, err)
+		require.NotNil(t, prov.TrustRegistry())
+	})
+
+	t.Run("test new with locker", func(t *testing.T) {
+		prov, err := New(WithLocker(&mocklocker.MockLocker{}))
+		require.NoError(t, err)
+		require.NotNil(t, prov.Locker())
+	})
+
 	t.Run("test inbound message handlers/dispatchers", func(t *testing.T) {
 		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
 		messengerHandler.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -187,6 +233,144 @@ func TestNewProvider(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("inbound message handler converts DIDComm v2 messages with the bridge", func(t *testing.T) {
+		var handled service.DIDCommMsg
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: didexchange.DIDExchange,
+			AcceptFunc:   func(msgType string) bool { return msgType == "valid-message-type" },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				handled = msg
+				return uuid.New().String(), nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithDIDCommBridge(true))
+		require.NoError(t, err)
+		require.True(t, ctx.DIDCommBridge())
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"id": "5678876542345",
+			"type": "valid-message-type",
+			"thid": "thread-1",
+			"body": {"comment": "hello"}
+		}`), FromKey: []byte("fromKey"), ToKey: []byte("toKey")})
+		require.NoError(t, err)
+		require.Equal(t, "5678876542345", handled.ID())
+
+		thID, err := handled.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, "thread-1", thID)
+	})
+
+	t.Run("inbound message handler verifies the signed attachments", func(t *testing.T) {
+		km, err := localkms.New("local-lock://primary/test/",
+			mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+		require.NoError(t, err)
+
+		c, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		keyID, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		kh, err := km.Get(keyID)
+		require.NoError(t, err)
+
+		data := decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(`{"offer":"offer"}`))}
+		require.NoError(t, data.Sign(c, kh, pubKey))
+
+		handled := 0
+
+		var signers interface{}
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: didexchange.DIDExchange,
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				handled++
+				signers = msg.(service.DIDCommMsgMap).Metadata()[service.AttachmentSignersMetadataKey]
+				return uuid.New().String(), nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()), WithCrypto(c), WithKMS(km))
+		require.NoError(t, err)
+
+		msg := service.DIDCommMsgMap{
+			"@id":           uuid.New().String(),
+			"@type":         "valid-message-type",
+			"offers~attach": []decorator.Attachment{{ID: "offer", Data: data}},
+		}
+
+		src, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: src, FromKey: []byte("fromKey")})
+		require.NoError(t, err)
+		require.Equal(t, 1, handled)
+
+		signer, _ := fingerprint.CreateDIDKey(pubKey)
+		require.Equal(t, map[string][]string{"offers~attach": {signer}}, signers)
+
+		// the attached content is tampered
+		data.Base64 = base64.StdEncoding.EncodeToString([]byte(`{"offer":"other"}`))
+		msg["@id"] = uuid.New().String()
+		msg["offers~attach"] = []decorator.Attachment{{ID: "offer", Data: data}}
+
+		src, err = json.Marshal(msg)
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: src, FromKey: []byte("fromKey")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid signature of offers~attach")
+		require.Equal(t, 1, handled)
+	})
+
+	t.Run("inbound message handler tracks the acknowledgements", func(t *testing.T) {
+		tracker, err := ack.New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+		require.NoError(t, err)
+
+		send := tracker.OutboundInterceptor()(dispatcher.OutboundHandlerFunc(func(*dispatcher.OutboundMessage) error {
+			return nil
+		}))
+
+		require.NoError(t, send.HandleOutbound(&dispatcher.OutboundMessage{
+			Msg: service.DIDCommMsgMap{
+				"@id":         "msg-1",
+				"@type":       "https://didcomm.org/basicmessage/1.0/message",
+				"~please_ack": map[string]interface{}{"on": []interface{}{"RECEIPT"}},
+			},
+			Destination: &service.Destination{ServiceEndpoint: "http://example.com"},
+		}))
+
+		ctx, err := New(WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()), WithAckTracker(tracker))
+		require.NoError(t, err)
+		require.Equal(t, tracker, ctx.AckTracker())
+
+		// the acknowledgement has no protocol service
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "ack-1",
+			"@type": "https://didcomm.org/notification/1.0/ack",
+			"status": "OK",
+			"~thread": {"thid": "msg-1"}
+		}`), FromKey: []byte("fromKey"), ToKey: []byte("toKey")})
+		require.NoError(t, err)
+
+		rec, err := tracker.Status("msg-1")
+		require.NoError(t, err)
+		require.Equal(t, ack.StatusAcknowledged, rec.Status)
+
+		// the message is not acknowledging a tracked message anymore
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "ack-2",
+			"@type": "https://didcomm.org/notification/1.0/ack",
+			"~thread": {"thid": "msg-1"}
+		}`), FromKey: []byte("fromKey"), ToKey: []byte("toKey")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no message handlers found")
+	})
+
 	t.Run("inbound message handler: DID not found is ok", func(t *testing.T) {
 		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
 		messengerHandler.EXPECT().
@@ -218,6 +402,311 @@ func TestNewProvider(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("inbound message handler tracks the thread", func(t *testing.T) {
+		storeProv, err := New(WithStorageProvider(mockstorage.NewMockStoreProvider()))
+		require.NoError(t, err)
+
+		threadStore, err := thread.NewStore(storeProv)
+		require.NoError(t, err)
+
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:example:alice", nil)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return("did:example:bob", nil)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc:   func(msg service.DIDCommMsg) (string, error) { return "piid", nil },
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithDIDConnectionStore(connectionStore),
+			WithThreadStore(threadStore))
+		require.NoError(t, err)
+		require.Equal(t, threadStore, ctx.ThreadStore())
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`{
+			"@id": "msg-1",
+			"@type": "valid-message-type",
+			"~thread": {"thid": "thread-1", "pthid": "parent-1"}
+		}`), FromKey: []byte("fromKey"), ToKey: []byte("toKey")})
+		require.NoError(t, err)
+
+		rec, err := threadStore.Get("thread-1")
+		require.NoError(t, err)
+		require.Equal(t, &thread.Record{
+			ThreadID:       "thread-1",
+			ParentThreadID: "parent-1",
+			Service:        "mockProtocolSvc",
+			PIID:           "piid",
+			MyDID:          "did:example:alice",
+			TheirDID:       "did:example:bob",
+		}, rec)
+	})
+
+	t.Run("inbound message handler tracks the protocols of the connection", func(t *testing.T) {
+		recorder, err := connection.NewRecorder(&mockprotocol.MockProvider{})
+		require.NoError(t, err)
+
+		record := &connection.Record{
+			ConnectionID: "conn-1",
+			State:        connection.StateNameCompleted,
+			MyDID:        "did:example:alice",
+			TheirDID:     "did:example:bob",
+		}
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return(record.MyDID, nil)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return(record.TheirDID, nil)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc:   func(msg service.DIDCommMsg) (string, error) { return "", nil },
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithDIDConnectionStore(connectionStore),
+			WithConnectionRecorder(recorder))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{
+			Message:   []byte(`{"@id": "msg-1", "@type": "https://didcomm.org/present-proof/2.0/presentation"}`),
+			MediaType: transport.MediaTypeV2EncryptedEnvelope,
+			FromKey:   []byte("fromKey"),
+			ToKey:     []byte("toKey"),
+		})
+		require.NoError(t, err)
+
+		rec, err := recorder.GetConnectionRecord(record.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, connection.DIDCommV2, rec.DIDCommVersion)
+		require.Equal(t, []string{"https://didcomm.org/present-proof/2.0"}, rec.Protocols)
+	})
+
+	t.Run("inbound message handler ignores duplicate messages", func(t *testing.T) {
+		storeProv, err := New(WithStorageProvider(mockstorage.NewMockStoreProvider()))
+		require.NoError(t, err)
+
+		deduplicator, err := dedup.New(storeProv, time.Minute)
+		require.NoError(t, err)
+
+		handled := 0
+		fail := true
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: didexchange.DIDExchange,
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				handled++
+
+				if fail {
+					return "", errors.New("handle error")
+				}
+
+				return "", nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithInboundDeduplicator(deduplicator))
+		require.NoError(t, err)
+
+		envelope := &transport.Envelope{
+			Message: []byte(`{"@id": "msg-1", "@type": "valid-message-type"}`),
+			FromKey: []byte("fromKey"),
+		}
+
+		// the message that failed is handled again
+		require.EqualError(t, ctx.InboundMessageHandler()(envelope), "handle error")
+
+		fail = false
+
+		require.NoError(t, ctx.InboundMessageHandler()(envelope))
+		require.NoError(t, ctx.InboundMessageHandler()(envelope))
+		require.Equal(t, 2, handled)
+
+		// the same message ID from another sender is not a duplicate
+		require.NoError(t, ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: envelope.Message,
+			FromKey: []byte("otherKey"),
+		}))
+		require.Equal(t, 3, handled)
+
+		// the anoncrypt messages are told apart by their recipient
+		anoncrypt := &transport.Envelope{Message: envelope.Message, ToKey: []byte("toKey")}
+
+		require.NoError(t, ctx.InboundMessageHandler()(anoncrypt))
+		require.NoError(t, ctx.InboundMessageHandler()(anoncrypt))
+		require.NoError(t, ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: envelope.Message,
+			ToKey:   []byte("otherToKey"),
+		}))
+		require.Equal(t, 5, handled)
+	})
+
+	t.Run("inbound message handler rejects replayed messages", func(t *testing.T) {
+		storeProv, err := New(WithStorageProvider(mockstorage.NewMockStoreProvider()))
+		require.NoError(t, err)
+
+		guard, err := replay.New(storeProv, time.Minute)
+		require.NoError(t, err)
+
+		handled := 0
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: didexchange.DIDExchange,
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				handled++
+
+				return "", nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithInboundReplayGuard(guard))
+		require.NoError(t, err)
+
+		response := `{"@id": "%s", "@type": "%s", "connection~sig": {"signature": "c2lnbmF0dXJl"}}`
+
+		require.NoError(t, ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(fmt.Sprintf(response, "msg-1", didexchange.ResponseMsgType)),
+			FromKey: []byte("fromKey"),
+		}))
+
+		// the response is replayed with another message ID
+		err = ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(fmt.Sprintf(response, "msg-2", didexchange.ResponseMsgType)),
+			FromKey: []byte("fromKey"),
+		})
+		require.True(t, errors.Is(err, replay.ErrReplay))
+		require.Equal(t, 1, handled)
+	})
+
+	t.Run("inbound message handler releases the nonces of the messages that failed", func(t *testing.T) {
+		storeProv, err := New(WithStorageProvider(mockstorage.NewMockStoreProvider()))
+		require.NoError(t, err)
+
+		guard, err := replay.New(storeProv, time.Minute)
+		require.NoError(t, err)
+
+		handled := 0
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: didexchange.DIDExchange,
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				handled++
+
+				if handled == 1 {
+					return "", errors.New("handle error")
+				}
+
+				return "", nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithInboundReplayGuard(guard))
+		require.NoError(t, err)
+
+		response := `{"@id": "%s", "@type": "%s", "connection~sig": {"signature": "c2lnbmF0dXJl"}}`
+
+		require.Error(t, ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(fmt.Sprintf(response, "msg-1", didexchange.ResponseMsgType)),
+			FromKey: []byte("fromKey"),
+		}))
+
+		require.NoError(t, ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(fmt.Sprintf(response, "msg-1", didexchange.ResponseMsgType)),
+			FromKey: []byte("fromKey"),
+		}))
+		require.Equal(t, 2, handled)
+	})
+
+	t.Run("inbound message handler honors the timing decorator", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:example:alice", nil).AnyTimes()
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return("did:example:bob", nil).AnyTimes()
+
+		handled := 0
+		monitor := timing.NewMonitor()
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				handled++
+
+				return "", nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithDIDConnectionStore(connectionStore),
+			WithTimingMonitor(monitor))
+		require.NoError(t, err)
+		require.Equal(t, monitor, ctx.TimingMonitor())
+
+		msg := `{"@id": "%s", "@type": "valid-message-type", "~timing": {"out_time": "%s", "expires_time": "%s"}}`
+		now := time.Now().UTC()
+
+		require.NoError(t, ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(fmt.Sprintf(msg, "msg-1", now.Add(-time.Second).Format(time.RFC3339),
+				now.Add(time.Hour).Format(time.RFC3339))),
+			FromKey: []byte("fromKey"),
+			ToKey:   []byte("toKey"),
+		}))
+		require.Equal(t, 1, handled)
+
+		latency, ok := monitor.Latency("did:example:bob")
+		require.True(t, ok)
+		require.Equal(t, 1, latency.Samples)
+		require.GreaterOrEqual(t, latency.Last, time.Duration(0))
+
+		// the message expired
+		err = ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(fmt.Sprintf(msg, "msg-2", now.Add(-time.Hour).Format(time.RFC3339),
+				now.Add(-time.Minute).Format(time.RFC3339))),
+			FromKey: []byte("fromKey"),
+			ToKey:   []byte("toKey"),
+		})
+		require.True(t, errors.Is(err, expiry.ErrExpired))
+		require.Equal(t, 1, handled)
+	})
+
+	t.Run("inbound message handler: invalid DID rotation", func(t *testing.T) {
+		rotatorProv, err := New(WithStorageProvider(mockstorage.NewMockStoreProvider()),
+			WithProtocolStateStorageProvider(mockstorage.NewMockStoreProvider()),
+			WithVDRegistry(&mockvdr.MockVDRegistry{}),
+			WithDIDConnectionStore(didStoreMocks.NewMockConnectionStore(ctrl)))
+		require.NoError(t, err)
+
+		rotator, err := rotation.New(rotatorProv)
+		require.NoError(t, err)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: didexchange.DIDExchange,
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				return "", errors.New("unexpected call")
+			},
+		}), WithDIDRotator(rotator))
+		require.NoError(t, err)
+		require.Equal(t, rotator, ctx.DIDRotator())
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(`{"@id": "msg-1", "@type": "valid-message-type", "from_prior": "invalid"}`),
+		})
+		require.Contains(t, err.Error(), "inbound message handler: from_prior: parse JWT")
+	})
+
+	t.Run("inbound message handler: deduplicator error", func(t *testing.T) {
+		storeProv, err := New(WithStorageProvider(&mockstorage.MockStoreProvider{
+			Store: &mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}, ErrGet: errors.New("get error")},
+		}))
+		require.NoError(t, err)
+
+		deduplicator, err := dedup.New(storeProv, time.Minute)
+		require.NoError(t, err)
+
+		ctx, err := New(WithInboundDeduplicator(deduplicator))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`{"@id": "msg-1"}`)})
+		require.EqualError(t, err, "inbound message handler: get received message: get error")
+	})
+
 	t.Run("inbound message handler: failed to get my did", func(t *testing.T) {
 		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
 		messengerHandler.EXPECT().
@@ -257,6 +746,42 @@ func TestNewProvider(t *testing.T) {
 		require.Contains(t, err.Error(), "failed to get my did")
 	})
 
+	t.Run("inbound message handler: signed message without recipient key", func(t *testing.T) {
+		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
+		messengerHandler.EXPECT().
+			HandleInbound(gomock.Any(), gomock.Any()).
+			Return(nil).
+			AnyTimes()
+
+		// the recipient key is not looked up
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().
+			GetDID(base58.Encode([]byte("fromKey"))).
+			Return("theirDID", nil)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc:   func(msg service.DIDCommMsg) (string, error) { return uuid.New().String(), nil },
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithMessengerHandler(messengerHandler),
+			WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+		require.NotEmpty(t, ctx)
+
+		inboundHandler := ctx.InboundMessageHandler()
+
+		err = inboundHandler(&transport.Envelope{
+			MediaType: transport.MediaTypeV2SignedMessage,
+			Message: []byte(`
+		{
+			"@frameworkID": "5678876542345",
+			"@type": "valid-message-type"
+		}`), FromKey: []byte("fromKey"),
+		})
+		require.NoError(t, err)
+	})
+
 	t.Run("inbound message handler: failed to get their did", func(t *testing.T) {
 		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
 		messengerHandler.EXPECT().
@@ -555,14 +1080,26 @@ func TestNewProvider(t *testing.T) {
 			&mockdidcomm.MockOutboundTransport{ExpectedResponse: "data1"}))
 		require.NoError(t, err)
 		require.Len(t, prov.OutboundTransports(), 2)
-		r, err := prov.outboundTransports[0].Send([]byte("data"), &service.Destination{ServiceEndpoint: "url"})
+		r, err := prov.OutboundTransports()[0].Send([]byte("data"), &service.Destination{ServiceEndpoint: "url"})
 		require.NoError(t, err)
 		require.Equal(t, "data", r)
-		r, err = prov.outboundTransports[1].Send([]byte("data1"), &service.Destination{ServiceEndpoint: "url"})
+		r, err = prov.OutboundTransports()[1].Send([]byte("data1"), &service.Destination{ServiceEndpoint: "url"})
 		require.NoError(t, err)
 		require.Equal(t, "data1", r)
 	})
 
+	t.Run("test new with outbound transport registry", func(t *testing.T) {
+		registry := transport.NewOutboundRegistry()
+
+		prov, err := New(WithOutboundTransportRegistry(registry))
+		require.NoError(t, err)
+		require.Equal(t, registry, prov.OutboundTransportRegistry())
+		require.Empty(t, prov.OutboundTransports())
+
+		registry.Add(&mockdidcomm.MockOutboundTransport{ExpectedResponse: "data"})
+		require.Len(t, prov.OutboundTransports(), 1)
+	})
+
 	t.Run("test new with transport return route", func(t *testing.T) {
 		transportReturnRoute := "none"
 		prov, err := New(WithTransportReturnRoute(transportReturnRoute))
@@ -0,0 +1,370 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tenant enables one process to host many logical agents (tenants). Each tenant runs its own Aries
+// framework instance whose stores are isolated in the shared storage providers, so are the keys of its KMS.
+// The agents are started on first use and stopped when the tenant is suspended or deleted.
+package tenant
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/tenant")
+
+const (
+	// NameSpace for the tenant store.
+	NameSpace = "tenant"
+
+	tenantTagName = "tenant"
+)
+
+var (
+	// ErrTenantNotFound is returned when the tenant does not exist.
+	ErrTenantNotFound = errors.New("tenant not found")
+	// ErrTenantExists is returned when a tenant is created with the ID of an existing tenant.
+	ErrTenantExists = errors.New("tenant already exists")
+	// ErrTenantSuspended is returned when the agent of a suspended tenant is requested.
+	ErrTenantSuspended = errors.New("tenant is suspended")
+)
+
+// tenant IDs are part of the store names, the underscore is excluded since it separates the prefix of the names.
+// The upper case letters are excluded since storage providers may lower-case the store names: the stores of
+// the tenants "Alice" and "alice" would be the same.
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9-]{1,64}$`)
+
+// Status of the tenant.
+type Status string
+
+const (
+	// StatusActive is the status of the tenants whose agent can be used.
+	StatusActive Status = "active"
+	// StatusSuspended is the status of the tenants whose agent is stopped until they are resumed.
+	StatusSuspended Status = "suspended"
+)
+
+// Tenant is the record of a tenant.
+type Tenant struct {
+	ID        string    `json:"id"`
+	Status    Status    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Opt configures the tenant manager.
+type Opt func(m *Manager)
+
+// WithFrameworkOptions sets the function returning the options of the framework instance of a tenant
+// (e.g. its transports). The storage providers options are overridden by the manager.
+func WithFrameworkOptions(opts func(tenantID string) []aries.Option) Opt {
+	return func(m *Manager) {
+		m.frameworkOpts = opts
+	}
+}
+
+// WithProtocolStateStoreProvider sets the storage provider shared by the tenants for their protocol state
+// (the storage provider of the manager by default).
+func WithProtocolStateStoreProvider(provider storage.Provider) Opt {
+	return func(m *Manager) {
+		m.protocolStateStoreProvider = provider
+	}
+}
+
+type agent struct {
+	framework *aries.Aries
+	ctx       *context.Provider
+}
+
+// Manager manages the lifecycle of the tenants and their agents.
+type Manager struct {
+	storeProvider              storage.Provider
+	protocolStateStoreProvider storage.Provider
+	frameworkOpts              func(tenantID string) []aries.Option
+	store                      storage.Store
+
+	mu     sync.Mutex
+	agents map[string]*agent
+}
+
+// New returns a new tenant manager. The tenants and their stores are saved in the storage provider.
+func New(storeProvider storage.Provider, opts ...Opt) (*Manager, error) {
+	m := &Manager{
+		storeProvider:              storeProvider,
+		protocolStateStoreProvider: storeProvider,
+		frameworkOpts:              func(string) []aries.Option { return nil },
+		agents:                     make(map[string]*agent),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	store, err := storeProvider.OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tenant store: %w", err)
+	}
+
+	err = storeProvider.SetStoreConfig(NameSpace, storage.StoreConfiguration{TagNames: []string{tenantTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	m.store = store
+
+	return m, nil
+}
+
+// Create creates an active tenant. Its agent is started on first use.
+func (m *Manager) Create(tenantID string) (*Tenant, error) {
+	if !tenantIDPattern.MatchString(tenantID) {
+		return nil, fmt.Errorf("invalid tenant ID %q: 1 to 64 lower case letters, digits or hyphens expected", tenantID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := m.get(tenantID)
+	if err == nil {
+		return nil, ErrTenantExists
+	}
+
+	if !errors.Is(err, ErrTenantNotFound) {
+		return nil, err
+	}
+
+	t := &Tenant{ID: tenantID, Status: StatusActive, CreatedAt: time.Now().UTC()}
+
+	if err = m.save(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Get returns the tenant.
+func (m *Manager) Get(tenantID string) (*Tenant, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.get(tenantID)
+}
+
+// List returns all the tenants.
+func (m *Manager) List() ([]*Tenant, error) {
+	iter, err := m.store.Query(tenantTagName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tenants: %w", err)
+	}
+
+	defer func() {
+		if errClose := iter.Close(); errClose != nil {
+			logger.Warnf("failed to close iterator: %s", errClose)
+		}
+	}()
+
+	var tenants []*Tenant
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next tenant: %w", err)
+		}
+
+		if !ok {
+			return tenants, nil
+		}
+
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tenant: %w", err)
+		}
+
+		t := &Tenant{}
+
+		if err = json.Unmarshal(value, t); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tenant: %w", err)
+		}
+
+		tenants = append(tenants, t)
+	}
+}
+
+// Context returns the context of the tenant agent, the agent is started if needed.
+func (m *Manager) Context(tenantID string) (*context.Provider, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if a, ok := m.agents[tenantID]; ok {
+		return a.ctx, nil
+	}
+
+	t, err := m.get(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Status == StatusSuspended {
+		return nil, ErrTenantSuspended
+	}
+
+	a, err := m.start(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.agents[tenantID] = a
+
+	return a.ctx, nil
+}
+
+// Suspend stops the agent of the tenant until the tenant is resumed.
+func (m *Manager) Suspend(tenantID string) error {
+	return m.setStatus(tenantID, StatusSuspended)
+}
+
+// Resume activates the suspended tenant. Its agent is started on first use.
+func (m *Manager) Resume(tenantID string) error {
+	return m.setStatus(tenantID, StatusActive)
+}
+
+// Delete stops the agent of the tenant and deletes the tenant. The stores of the tenant are closed, their data
+// is left to the storage provider (storage providers that keep the data of the closed stores have no API to
+// drop them).
+func (m *Manager) Delete(tenantID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.get(tenantID); err != nil {
+		return err
+	}
+
+	if err := m.stop(tenantID); err != nil {
+		return err
+	}
+
+	if err := m.store.Delete(tenantID); err != nil {
+		return fmt.Errorf("failed to delete tenant %s: %w", tenantID, err)
+	}
+
+	return nil
+}
+
+// Close stops the agents of all the tenants.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for tenantID := range m.agents {
+		if err := m.stop(tenantID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) setStatus(tenantID string, status Status) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, err := m.get(tenantID)
+	if err != nil {
+		return err
+	}
+
+	if t.Status == status {
+		return nil
+	}
+
+	if status == StatusSuspended {
+		if err = m.stop(tenantID); err != nil {
+			return err
+		}
+	}
+
+	t.Status = status
+
+	return m.save(t)
+}
+
+func (m *Manager) start(tenantID string) (*agent, error) {
+	opts := append(m.frameworkOpts(tenantID),
+		aries.WithStoreProvider(newNamespacedProvider(m.storeProvider, tenantID)),
+		aries.WithProtocolStateStoreProvider(newNamespacedProvider(m.protocolStateStoreProvider, tenantID)),
+	)
+
+	framework, err := aries.New(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the agent of tenant %s: %w", tenantID, err)
+	}
+
+	ctx, err := framework.Context()
+	if err != nil {
+		if errClose := framework.Close(); errClose != nil {
+			logger.Warnf("failed to close the agent of tenant %s: %s", tenantID, errClose)
+		}
+
+		return nil, fmt.Errorf("failed to get the context of tenant %s: %w", tenantID, err)
+	}
+
+	return &agent{framework: framework, ctx: ctx}, nil
+}
+
+func (m *Manager) stop(tenantID string) error {
+	a, ok := m.agents[tenantID]
+	if !ok {
+		return nil
+	}
+
+	delete(m.agents, tenantID)
+
+	if err := a.framework.Close(); err != nil {
+		return fmt.Errorf("failed to stop the agent of tenant %s: %w", tenantID, err)
+	}
+
+	return nil
+}
+
+func (m *Manager) get(tenantID string) (*Tenant, error) {
+	value, err := m.store.Get(tenantID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrTenantNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant %s: %w", tenantID, err)
+	}
+
+	t := &Tenant{}
+
+	if err = json.Unmarshal(value, t); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tenant %s: %w", tenantID, err)
+	}
+
+	return t, nil
+}
+
+func (m *Manager) save(t *Tenant) error {
+	value, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tenant %s: %w", t.ID, err)
+	}
+
+	if err = m.store.Put(t.ID, value, storage.Tag{Name: tenantTagName}); err != nil {
+		return fmt.Errorf("failed to save tenant %s: %w", t.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tenant
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")})
+	require.EqualError(t, err, "failed to open tenant store: open error")
+}
+
+func TestManager_Lifecycle(t *testing.T) {
+	m, err := New(mem.NewProvider())
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, m.Close()) }()
+
+	t.Run("create tenants", func(t *testing.T) {
+		alice, err := m.Create("alice")
+		require.NoError(t, err)
+		require.Equal(t, "alice", alice.ID)
+		require.Equal(t, StatusActive, alice.Status)
+		require.False(t, alice.CreatedAt.IsZero())
+
+		_, err = m.Create("bob")
+		require.NoError(t, err)
+
+		_, err = m.Create("alice")
+		require.True(t, errors.Is(err, ErrTenantExists))
+
+		_, err = m.Create("invalid_id")
+		require.Contains(t, err.Error(), "invalid tenant ID")
+
+		// the stores of "Alice" would be the stores of "alice" in the providers lower-casing the store names
+		_, err = m.Create("Alice")
+		require.Contains(t, err.Error(), "invalid tenant ID")
+
+		tenants, err := m.List()
+		require.NoError(t, err)
+		require.Len(t, tenants, 2)
+	})
+
+	t.Run("agents of the tenants are isolated", func(t *testing.T) {
+		aliceCtx, err := m.Context("alice")
+		require.NoError(t, err)
+
+		// the agent is started once
+		ctx, err := m.Context("alice")
+		require.NoError(t, err)
+		require.Equal(t, aliceCtx, ctx)
+
+		bobCtx, err := m.Context("bob")
+		require.NoError(t, err)
+
+		keyID, _, err := aliceCtx.KMS().Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = aliceCtx.KMS().Get(keyID)
+		require.NoError(t, err)
+
+		_, err = bobCtx.KMS().Get(keyID)
+		require.Error(t, err)
+	})
+
+	t.Run("suspend and resume", func(t *testing.T) {
+		require.NoError(t, m.Suspend("alice"))
+		require.NoError(t, m.Suspend("alice"))
+
+		alice, err := m.Get("alice")
+		require.NoError(t, err)
+		require.Equal(t, StatusSuspended, alice.Status)
+
+		_, err = m.Context("alice")
+		require.True(t, errors.Is(err, ErrTenantSuspended))
+
+		require.NoError(t, m.Resume("alice"))
+
+		_, err = m.Context("alice")
+		require.NoError(t, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, m.Delete("bob"))
+
+		_, err := m.Get("bob")
+		require.True(t, errors.Is(err, ErrTenantNotFound))
+
+		_, err = m.Context("bob")
+		require.True(t, errors.Is(err, ErrTenantNotFound))
+
+		require.True(t, errors.Is(m.Delete("bob"), ErrTenantNotFound))
+		require.True(t, errors.Is(m.Suspend("bob"), ErrTenantNotFound))
+	})
+}
+
+func TestManager_Errors(t *testing.T) {
+	t.Run("framework options error", func(t *testing.T) {
+		m, err := New(mem.NewProvider(), WithFrameworkOptions(func(tenantID string) []aries.Option {
+			return []aries.Option{func(*aries.Aries) error { return errors.New("option error") }}
+		}))
+		require.NoError(t, err)
+
+		_, err = m.Create("alice")
+		require.NoError(t, err)
+
+		_, err = m.Context("alice")
+		require.Contains(t, err.Error(), "failed to start the agent of tenant alice")
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		m, err := New(mockstorage.NewCustomMockStoreProvider(store),
+			WithProtocolStateStoreProvider(mem.NewProvider()))
+		require.NoError(t, err)
+
+		store.ErrPut = errors.New("put error")
+		_, err = m.Create("alice")
+		require.EqualError(t, err, "failed to save tenant alice: put error")
+
+		store.ErrGet = errors.New("get error")
+		_, err = m.Create("alice")
+		require.EqualError(t, err, "failed to get tenant alice: get error")
+
+		_, err = m.Context("alice")
+		require.EqualError(t, err, "failed to get tenant alice: get error")
+
+		store.ErrQuery = errors.New("query error")
+		_, err = m.List()
+		require.EqualError(t, err, "failed to query tenants: query error")
+	})
+}
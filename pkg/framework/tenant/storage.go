@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tenant
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// namespacedProvider isolates the stores of a tenant in the shared storage provider by prefixing their names
+// with the tenant ID. Closing it closes the stores of the tenant only.
+type namespacedProvider struct {
+	provider storage.Provider
+	prefix   string
+
+	mu     sync.Mutex
+	stores map[string]storage.Store
+}
+
+func newNamespacedProvider(provider storage.Provider, tenantID string) *namespacedProvider {
+	return &namespacedProvider{
+		provider: provider,
+		prefix:   fmt.Sprintf("tenant_%s_", tenantID),
+		stores:   make(map[string]storage.Store),
+	}
+}
+
+func (p *namespacedProvider) OpenStore(name string) (storage.Store, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	store, err := p.provider.OpenStore(p.prefix + name)
+	if err != nil {
+		return nil, err
+	}
+
+	p.stores[name] = store
+
+	return store, nil
+}
+
+func (p *namespacedProvider) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	return p.provider.SetStoreConfig(p.prefix+name, config)
+}
+
+func (p *namespacedProvider) GetStoreConfig(name string) (storage.StoreConfiguration, error) {
+	return p.provider.GetStoreConfig(p.prefix + name)
+}
+
+func (p *namespacedProvider) GetOpenStores() []storage.Store {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stores := make([]storage.Store, 0, len(p.stores))
+
+	for _, store := range p.stores {
+		stores = append(stores, store)
+	}
+
+	return stores
+}
+
+func (p *namespacedProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for name, store := range p.stores {
+		if err := store.Close(); err != nil {
+			return fmt.Errorf("failed to close store %s: %w", name, err)
+		}
+
+		delete(p.stores, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tenant
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestNamespacedProvider(t *testing.T) {
+	shared := mem.NewProvider()
+
+	alice := newNamespacedProvider(shared, "alice")
+	bob := newNamespacedProvider(shared, "bob")
+
+	aliceStore, err := alice.OpenStore("store")
+	require.NoError(t, err)
+	require.NoError(t, aliceStore.Put("key", []byte("alice")))
+
+	bobStore, err := bob.OpenStore("store")
+	require.NoError(t, err)
+
+	_, err = bobStore.Get("key")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	// the stores are named after the tenant in the shared provider
+	store, err := shared.OpenStore("tenant_alice_store")
+	require.NoError(t, err)
+
+	value, err := store.Get("key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("alice"), value)
+
+	require.NoError(t, alice.SetStoreConfig("store", storage.StoreConfiguration{TagNames: []string{"tag"}}))
+
+	config, err := alice.GetStoreConfig("store")
+	require.NoError(t, err)
+	require.Equal(t, []string{"tag"}, config.TagNames)
+
+	require.Len(t, alice.GetOpenStores(), 1)
+	require.NoError(t, alice.Close())
+	require.Empty(t, alice.GetOpenStores())
+	require.Len(t, bob.GetOpenStores(), 1)
+}
+
+func TestNamespacedProviderErrors(t *testing.T) {
+	p := newNamespacedProvider(&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")}, "alice")
+
+	_, err := p.OpenStore("store")
+	require.EqualError(t, err, "open error")
+
+	p = newNamespacedProvider(&mockstorage.MockStoreProvider{Custom: &failingStore{}}, "alice")
+
+	_, err = p.OpenStore("store")
+	require.NoError(t, err)
+	require.EqualError(t, p.Close(), "failed to close store store: close error")
+}
+
+type failingStore struct {
+	storage.Store
+}
+
+func (s *failingStore) Close() error {
+	return errors.New("close error")
+}
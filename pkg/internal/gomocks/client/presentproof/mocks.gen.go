@@ -73,6 +73,20 @@ func (m *MockProtocolService) EXPECT() *MockProtocolServiceMockRecorder {
 	return m.recorder
 }
 
+// Abandon mocks base method.
+func (m *MockProtocolService) Abandon(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Abandon", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Abandon indicates an expected call of Abandon.
+func (mr *MockProtocolServiceMockRecorder) Abandon(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abandon", reflect.TypeOf((*MockProtocolService)(nil).Abandon), arg0, arg1)
+}
+
 // ActionContinue mocks base method.
 func (m *MockProtocolService) ActionContinue(arg0 string, arg1 presentproof.Opt) error {
 	m.ctrl.T.Helper()
@@ -146,6 +160,36 @@ func (mr *MockProtocolServiceMockRecorder) HandleOutbound(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleOutbound", reflect.TypeOf((*MockProtocolService)(nil).HandleOutbound), arg0, arg1, arg2)
 }
 
+// Record mocks base method.
+func (m *MockProtocolService) Record(arg0 string) (*presentproof.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0)
+	ret0, _ := ret[0].(*presentproof.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockProtocolServiceMockRecorder) Record(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockProtocolService)(nil).Record), arg0)
+}
+
+// Records mocks base method.
+func (m *MockProtocolService) Records(arg0 *presentproof.RecordFilter) ([]presentproof.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Records", arg0)
+	ret0, _ := ret[0].([]presentproof.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Records indicates an expected call of Records.
+func (mr *MockProtocolServiceMockRecorder) Records(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Records", reflect.TypeOf((*MockProtocolService)(nil).Records), arg0)
+}
+
 // RegisterActionEvent mocks base method.
 func (m *MockProtocolService) RegisterActionEvent(arg0 chan<- service.DIDCommAction) error {
 	m.ctrl.T.Helper()
@@ -278,6 +278,20 @@ func (mr *MockMessengerMockRecorder) Send(arg0, arg1, arg2 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMessenger)(nil).Send), arg0, arg1, arg2)
 }
 
+// ReplyToMsgDestination mocks base method.
+func (m *MockMessenger) ReplyToMsgDestination(arg0, arg1 service.DIDCommMsgMap, arg2 string, arg3 *service.Destination) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyToMsgDestination", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplyToMsgDestination indicates an expected call of ReplyToMsgDestination.
+func (mr *MockMessengerMockRecorder) ReplyToMsgDestination(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyToMsgDestination", reflect.TypeOf((*MockMessenger)(nil).ReplyToMsgDestination), arg0, arg1, arg2, arg3)
+}
+
 // SendToDestination mocks base method.
 func (m *MockMessenger) SendToDestination(arg0 service.DIDCommMsgMap, arg1 string, arg2 *service.Destination) error {
 	m.ctrl.T.Helper()
@@ -385,6 +399,20 @@ func (mr *MockMessengerHandlerMockRecorder) Send(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMessengerHandler)(nil).Send), arg0, arg1, arg2)
 }
 
+// ReplyToMsgDestination mocks base method.
+func (m *MockMessengerHandler) ReplyToMsgDestination(arg0, arg1 service.DIDCommMsgMap, arg2 string, arg3 *service.Destination) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyToMsgDestination", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplyToMsgDestination indicates an expected call of ReplyToMsgDestination.
+func (mr *MockMessengerHandlerMockRecorder) ReplyToMsgDestination(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyToMsgDestination", reflect.TypeOf((*MockMessengerHandler)(nil).ReplyToMsgDestination), arg0, arg1, arg2, arg3)
+}
+
 // SendToDestination mocks base method.
 func (m *MockMessengerHandler) SendToDestination(arg0 service.DIDCommMsgMap, arg1 string, arg2 *service.Destination) error {
 	m.ctrl.T.Helper()
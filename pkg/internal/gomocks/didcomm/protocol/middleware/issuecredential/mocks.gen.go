@@ -88,6 +88,20 @@ func (m *MockMetadata) EXPECT() *MockMetadataMockRecorder {
 	return m.recorder
 }
 
+// CredentialAcceptance mocks base method.
+func (m *MockMetadata) CredentialAcceptance() []issuecredential.CredentialAcceptance {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CredentialAcceptance")
+	ret0, _ := ret[0].([]issuecredential.CredentialAcceptance)
+	return ret0
+}
+
+// CredentialAcceptance indicates an expected call of CredentialAcceptance.
+func (mr *MockMetadataMockRecorder) CredentialAcceptance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CredentialAcceptance", reflect.TypeOf((*MockMetadata)(nil).CredentialAcceptance))
+}
+
 // CredentialNames mocks base method.
 func (m *MockMetadata) CredentialNames() []string {
 	m.ctrl.T.Helper()
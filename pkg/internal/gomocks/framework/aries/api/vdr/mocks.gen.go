@@ -108,6 +108,20 @@ func (mr *MockRegistryMockRecorder) Resolve(arg0 interface{}, arg1 ...interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockRegistry)(nil).Resolve), varargs...)
 }
 
+// Supports mocks base method.
+func (m *MockRegistry) Supports(arg0 string, arg1 vdr.Operation) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Supports", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Supports indicates an expected call of Supports.
+func (mr *MockRegistryMockRecorder) Supports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Supports", reflect.TypeOf((*MockRegistry)(nil).Supports), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockRegistry) Update(arg0 *did.Doc, arg1 ...vdr.DIDMethodOption) error {
 	m.ctrl.T.Helper()
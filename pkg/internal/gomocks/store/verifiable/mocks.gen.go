@@ -35,6 +35,54 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddCredentialTags mocks base method.
+func (m *MockStore) AddCredentialTags(arg0 string, arg1 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddCredentialTags", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCredentialTags indicates an expected call of AddCredentialTags.
+func (mr *MockStoreMockRecorder) AddCredentialTags(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCredentialTags", reflect.TypeOf((*MockStore)(nil).AddCredentialTags), varargs...)
+}
+
+// CreateCollection mocks base method.
+func (m *MockStore) CreateCollection(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockStoreMockRecorder) CreateCollection(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockStore)(nil).CreateCollection), arg0)
+}
+
+// GetCollections mocks base method.
+func (m *MockStore) GetCollections() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockStoreMockRecorder) GetCollections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockStore)(nil).GetCollections))
+}
+
 // GetCredential mocks base method.
 func (m *MockStore) GetCredential(arg0 string) (*verifiable.Credential, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +128,21 @@ func (mr *MockStoreMockRecorder) GetCredentials() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockStore)(nil).GetCredentials))
 }
 
+// GetCredentialsByCollection mocks base method.
+func (m *MockStore) GetCredentialsByCollection(arg0 string) ([]*verifiable0.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentialsByCollection", arg0)
+	ret0, _ := ret[0].([]*verifiable0.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialsByCollection indicates an expected call of GetCredentialsByCollection.
+func (mr *MockStoreMockRecorder) GetCredentialsByCollection(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialsByCollection", reflect.TypeOf((*MockStore)(nil).GetCredentialsByCollection), arg0)
+}
+
 // GetPresentation mocks base method.
 func (m *MockStore) GetPresentation(arg0 string) (*verifiable.Presentation, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +188,34 @@ func (mr *MockStoreMockRecorder) GetPresentations() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresentations", reflect.TypeOf((*MockStore)(nil).GetPresentations))
 }
 
+// MoveCredential mocks base method.
+func (m *MockStore) MoveCredential(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCredential", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCredential indicates an expected call of MoveCredential.
+func (mr *MockStoreMockRecorder) MoveCredential(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCredential", reflect.TypeOf((*MockStore)(nil).MoveCredential), arg0, arg1)
+}
+
+// RemoveCollection mocks base method.
+func (m *MockStore) RemoveCollection(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCollection", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCollection indicates an expected call of RemoveCollection.
+func (mr *MockStoreMockRecorder) RemoveCollection(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCollection", reflect.TypeOf((*MockStore)(nil).RemoveCollection), arg0)
+}
+
 // RemoveCredentialByName mocks base method.
 func (m *MockStore) RemoveCredentialByName(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -139,6 +230,25 @@ func (mr *MockStoreMockRecorder) RemoveCredentialByName(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCredentialByName", reflect.TypeOf((*MockStore)(nil).RemoveCredentialByName), arg0)
 }
 
+// RemoveCredentialTags mocks base method.
+func (m *MockStore) RemoveCredentialTags(arg0 string, arg1 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveCredentialTags", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCredentialTags indicates an expected call of RemoveCredentialTags.
+func (mr *MockStoreMockRecorder) RemoveCredentialTags(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCredentialTags", reflect.TypeOf((*MockStore)(nil).RemoveCredentialTags), varargs...)
+}
+
 // RemovePresentationByName mocks base method.
 func (m *MockStore) RemovePresentationByName(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -153,6 +263,25 @@ func (mr *MockStoreMockRecorder) RemovePresentationByName(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePresentationByName", reflect.TypeOf((*MockStore)(nil).RemovePresentationByName), arg0)
 }
 
+// ReplaceCredential mocks base method.
+func (m *MockStore) ReplaceCredential(arg0 string, arg1 *verifiable.Credential, arg2 ...verifiable0.Opt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReplaceCredential", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceCredential indicates an expected call of ReplaceCredential.
+func (mr *MockStoreMockRecorder) ReplaceCredential(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCredential", reflect.TypeOf((*MockStore)(nil).ReplaceCredential), varargs...)
+}
+
 // SaveCredential mocks base method.
 func (m *MockStore) SaveCredential(arg0 string, arg1 *verifiable.Credential, arg2 ...verifiable0.Opt) error {
 	m.ctrl.T.Helper()
@@ -190,3 +319,17 @@ func (mr *MockStoreMockRecorder) SavePresentation(arg0, arg1 interface{}, arg2 .
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePresentation", reflect.TypeOf((*MockStore)(nil).SavePresentation), varargs...)
 }
+
+// UpdateCredentialName mocks base method.
+func (m *MockStore) UpdateCredentialName(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCredentialName", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCredentialName indicates an expected call of UpdateCredentialName.
+func (mr *MockStoreMockRecorder) UpdateCredentialName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCredentialName", reflect.TypeOf((*MockStore)(nil).UpdateCredentialName), arg0, arg1)
+}
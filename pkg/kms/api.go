@@ -9,6 +9,7 @@ package kms
 import (
 	"io"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -68,6 +69,28 @@ type KeyManager interface {
 	ImportPrivateKey(privKey interface{}, kt KeyType, opts ...PrivateKeyOpts) (string, interface{}, error)
 }
 
+// X25519Converter is implemented by KeyManagers able to derive X25519 key agreement keys from Ed25519 keys.
+type X25519Converter interface {
+	// ConvertEd25519ToX25519 derives X25519ECDHKWType key from the Ed25519 key referenced by keyID and stores it.
+	// Returns:
+	//  - keyID of the derived key
+	//  - handle instance (to private key)
+	//  - error if keyID does not reference Ed25519 key or the conversion fails
+	ConvertEd25519ToX25519(keyID string) (string, interface{}, error)
+}
+
+// KeyAgreementKeyCreator is implemented by KeyManagers able to create key agreement keys to be published in DID
+// documents.
+type KeyAgreementKeyCreator interface {
+	// CreateKeyAgreementKey creates a key of NISTP256ECDHKWType, NISTP384ECDHKWType, NISTP521ECDHKWType or
+	// X25519ECDHKWType and exports its public key.
+	// Returns:
+	//  - keyID of the new key
+	//  - public key, its KID is set to keyID
+	//  - error if kt is not a key agreement key type or the creation fails
+	CreateKeyAgreementKey(kt KeyType) (string, *crypto.PublicKey, error)
+}
+
 // Provider for KeyManager builder/constructor.
 type Provider interface {
 	StorageProvider() storage.Provider
@@ -100,6 +123,8 @@ const (
 	ECDSAP384IEEEP1363 = "ECDSAP384IEEEP1363"
 	// ECDSAP521IEEEP1363 key type value.
 	ECDSAP521IEEEP1363 = "ECDSAP521IEEEP1363"
+	// ECDSASecp256k1DER key type value.
+	ECDSASecp256k1DER = "ECDSASecp256k1DER"
 	// ECDSASecp256k1IEEEP1363 key type value.
 	ECDSASecp256k1IEEEP1363 = "ECDSASecp256k1IEEEP1363"
 	// ED25519 key type value.
@@ -148,6 +173,8 @@ const (
 	ECDSAP384TypeIEEEP1363 = KeyType(ECDSAP384IEEEP1363)
 	// ECDSAP521TypeIEEEP1363 key type value.
 	ECDSAP521TypeIEEEP1363 = KeyType(ECDSAP521IEEEP1363)
+	// ECDSASecp256k1TypeDER key type value.
+	ECDSASecp256k1TypeDER = KeyType(ECDSASecp256k1DER)
 	// ECDSASecp256k1TypeIEEEP1363 key type value.
 	ECDSASecp256k1TypeIEEEP1363 = KeyType(ECDSASecp256k1IEEEP1363)
 	// ED25519Type key type value.
@@ -20,6 +20,7 @@ import (
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"golang.org/x/crypto/nacl/box"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/securebytes"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
@@ -60,17 +61,21 @@ func (b *CryptoBox) Easy(payload, nonce, theirPub []byte, myKID string) ([]byte,
 
 	copy(recPubBytes[:], theirPub)
 
-	senderPriv, err := b.km.exportEncPrivKeyBytes(myKID)
+	senderPriv, err := b.km.exportEncPrivKey(myKID)
 	if err != nil {
 		return nil, fmt.Errorf("easy: failed to export sender key: %w, kid: %v", err, myKID)
 	}
 
+	defer senderPriv.Destroy()
+
 	var (
 		priv       [cryptoutil.Curve25519KeySize]byte
 		nonceBytes [cryptoutil.NonceSize]byte
 	)
 
-	copy(priv[:], senderPriv)
+	defer securebytes.Zero(priv[:])
+
+	copy(priv[:], senderPriv.Bytes())
 	copy(nonceBytes[:], nonce)
 
 	ret := box.Seal(nil, payload, &nonceBytes, &recPubBytes, &priv)
@@ -91,17 +96,21 @@ func (b *CryptoBox) EasyOpen(cipherText, nonce, theirPub, myPub []byte) ([]byte,
 		return nil, err
 	}
 
-	senderPriv, err := b.km.exportEncPrivKeyBytes(kid)
+	senderPriv, err := b.km.exportEncPrivKey(kid)
 	if err != nil {
 		return nil, err
 	}
 
+	defer senderPriv.Destroy()
+
 	var (
 		priv       [cryptoutil.Curve25519KeySize]byte
 		nonceBytes [cryptoutil.NonceSize]byte
 	)
 
-	copy(priv[:], senderPriv)
+	defer securebytes.Zero(priv[:])
+
+	copy(priv[:], senderPriv.Bytes())
 	copy(nonceBytes[:], nonce)
 
 	out, success := box.Open(nil, cipherText, &nonceBytes, &sendPubBytes, &priv)
@@ -123,6 +132,8 @@ func (b *CryptoBox) Seal(payload, theirEncPub []byte, randSource io.Reader) ([]b
 		return nil, err
 	}
 
+	defer securebytes.Zero(esk[:])
+
 	var recPubBytes [cryptoutil.Curve25519KeySize]byte
 
 	copy(recPubBytes[:], theirEncPub)
@@ -152,18 +163,22 @@ func (b *CryptoBox) SealOpen(cipherText, myPub []byte) ([]byte, error) {
 		return nil, fmt.Errorf("sealOpen: failed to compute ED25519 kid: %w", err)
 	}
 
-	recipientEncPriv, err := b.km.exportEncPrivKeyBytes(kid)
+	recipientEncPriv, err := b.km.exportEncPrivKey(kid)
 	if err != nil {
 		return nil, fmt.Errorf("sealOpen: failed to exportPriveKeyBytes: %w", err)
 	}
 
+	defer recipientEncPriv.Destroy()
+
 	var (
 		epk  [cryptoutil.Curve25519KeySize]byte
 		priv [cryptoutil.Curve25519KeySize]byte
 	)
 
+	defer securebytes.Zero(priv[:])
+
 	copy(epk[:], cipherText[:cryptoutil.Curve25519KeySize])
-	copy(priv[:], recipientEncPriv)
+	copy(priv[:], recipientEncPriv.Bytes())
 
 	recEncPub, err := cryptoutil.PublicEd25519toCurve25519(myPub)
 	if err != nil {
@@ -183,8 +198,9 @@ func (b *CryptoBox) SealOpen(cipherText, myPub []byte) ([]byte, error) {
 	return out, nil
 }
 
-// exportEncPrivKeyBytes temporary support function for crypto_box to be used with legacyPacker only.
-func (l *LocalKMS) exportEncPrivKeyBytes(id string) ([]byte, error) {
+// exportEncPrivKey temporary support function for crypto_box to be used with legacyPacker only.
+// The caller must destroy the returned key once used.
+func (l *LocalKMS) exportEncPrivKey(id string) (*securebytes.Bytes, error) {
 	kh, err := l.getKeySet(id)
 	if err != nil {
 		return nil, err
@@ -217,7 +233,14 @@ func (l *LocalKMS) exportEncPrivKeyBytes(id string) ([]byte, error) {
 		return nil, err
 	}
 
-	return extractPrivKey(decryptedKS)
+	defer securebytes.Zero(decryptedKS)
+
+	privKey, err := extractPrivKey(decryptedKS)
+	if err != nil {
+		return nil, err
+	}
+
+	return securebytes.New(privKey), nil
 }
 
 func extractPrivKey(marshalledKeySet []byte) ([]byte, error) {
@@ -244,6 +267,10 @@ func extractPrivKey(marshalledKeySet []byte) ([]byte, error) {
 		copy(pkBytes[:ed25519.PublicKeySize], prvKey.KeyValue)
 		copy(pkBytes[ed25519.PublicKeySize:], prvKey.PublicKey.KeyValue)
 
+		securebytes.Zero(prvKey.KeyValue)
+
+		defer securebytes.Zero(pkBytes)
+
 		return cryptoutil.SecretEd25519toCurve25519(pkBytes)
 	}
 
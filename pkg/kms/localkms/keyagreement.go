@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// CreateKeyAgreementKey creates a key agreement key (of NISTP256ECDHKWType, NISTP384ECDHKWType, NISTP521ECDHKWType
+// or X25519ECDHKWType) and exports its public key, to be published as keyAgreement verification method of DID
+// documents (see keyagreement.CreateKeyAgreement).
+// Returns:
+//  - keyID of the new key
+//  - public key, its KID is set to keyID
+//  - error if kt is not a key agreement key type or the creation fails
+func (l *LocalKMS) CreateKeyAgreementKey(kt kms.KeyType) (string, *cryptoapi.PublicKey, error) {
+	switch kt {
+	case kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType, kms.X25519ECDHKWType:
+	default:
+		return "", nil, fmt.Errorf("createKeyAgreementKey: %w: '%s'", errInvalidKeyType, kt)
+	}
+
+	kid, pubKeyBytes, err := l.CreateAndExportPubKeyBytes(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("createKeyAgreementKey: %w", err)
+	}
+
+	pubKey := &cryptoapi.PublicKey{}
+
+	err = json.Unmarshal(pubKeyBytes, pubKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("createKeyAgreementKey: failed to unmarshal public key: %w", err)
+	}
+
+	return kid, pubKey, nil
+}
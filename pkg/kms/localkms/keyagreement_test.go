@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestLocalKMS_CreateKeyAgreementKey(t *testing.T) {
+	kmsService, err := New(testMasterKeyURI, &mockProvider{
+		storage:    mockstorage.NewMockStoreProvider(),
+		secretLock: createMasterKeyAndSecretLock(t),
+	})
+	require.NoError(t, err)
+
+	var _ kms.KeyAgreementKeyCreator = kmsService
+
+	t.Run("success", func(t *testing.T) {
+		for _, kt := range []kms.KeyType{
+			kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType, kms.X25519ECDHKWType,
+		} {
+			kid, pubKey, err := kmsService.CreateKeyAgreementKey(kt)
+			require.NoError(t, err, kt)
+			require.NotEmpty(t, kid)
+			require.Equal(t, kid, pubKey.KID)
+			require.NotEmpty(t, pubKey.X)
+
+			if kt == kms.X25519ECDHKWType {
+				require.Equal(t, "OKP", pubKey.Type)
+				require.Empty(t, pubKey.Y)
+			} else {
+				require.Equal(t, "EC", pubKey.Type)
+				require.NotEmpty(t, pubKey.Y)
+			}
+
+			// the key is stored
+			_, err = kmsService.Get(kid)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("not a key agreement key type", func(t *testing.T) {
+		_, _, err := kmsService.CreateKeyAgreementKey(kms.ED25519Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key type is not supported")
+	})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/tink/go/core/registry"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// KeyTemplate describes a custom key type recognized by LocalKMS, e.g. the keys of a national algorithm suite (SM2,
+// SM9...) implemented as Tink key managers. Once registered, the keys of the type are created by LocalKMS.Create and
+// used by the Tink crypto (pkg/crypto/tinkcrypto) as any built-in key: e.g. crypto.Sign signs with the keys whose
+// primitive is a tink.Signer.
+type KeyTemplate struct {
+	// Template of the keys created by LocalKMS.Create.
+	Template *tinkpb.KeyTemplate
+	// KeyManagers of the private and public keys of the type, registered in the Tink registry. They create the
+	// primitives of the keys.
+	KeyManagers []registry.KeyManager
+	// PublicKeyTypeURL is the Tink type URL of the public keys of the type, the public keys can be exported when
+	// it is set with ExportPublicKey.
+	PublicKeyTypeURL string
+	// ExportPublicKey returns the raw bytes of the public key (e.g. for LocalKMS.ExportPubKeyBytes).
+	ExportPublicKey func(keyData *tinkpb.KeyData) ([]byte, error)
+	// CreateKID creates the KID of the keys from their exported public key. Without it, the keys are stored
+	// with random KIDs, as symmetric keys.
+	CreateKID func(pubKey []byte) (string, error)
+}
+
+// nolint:gochecknoglobals // registry of the custom key types, shared by all LocalKMS instances like Tink's registry
+var keyTemplates = &keyTemplateRegistry{
+	byKeyType:       make(map[kms.KeyType]*KeyTemplate),
+	byPublicTypeURL: make(map[string]*KeyTemplate),
+}
+
+type keyTemplateRegistry struct {
+	mu              sync.RWMutex
+	byKeyType       map[kms.KeyType]*KeyTemplate
+	byPublicTypeURL map[string]*KeyTemplate
+}
+
+// RegisterKeyTemplate registers the custom key type kt, its key managers are registered in the Tink registry.
+// Built-in key types cannot be overridden and a key type can be registered once only.
+func RegisterKeyTemplate(kt kms.KeyType, t *KeyTemplate) error {
+	if kt == "" {
+		return errors.New("registerKeyTemplate: missing key type")
+	}
+
+	if t == nil || t.Template == nil {
+		return fmt.Errorf("registerKeyTemplate: missing template of key type '%s'", kt)
+	}
+
+	if _, err := builtInKeyTemplate(kt); err == nil {
+		return fmt.Errorf("registerKeyTemplate: key type '%s' is built-in", kt)
+	}
+
+	keyTemplates.mu.Lock()
+	defer keyTemplates.mu.Unlock()
+
+	if _, ok := keyTemplates.byKeyType[kt]; ok {
+		return fmt.Errorf("registerKeyTemplate: key type '%s' is already registered", kt)
+	}
+
+	for _, km := range t.KeyManagers {
+		if err := registry.RegisterKeyManager(km); err != nil {
+			return fmt.Errorf("registerKeyTemplate: failed to register key manager of key type '%s': %w", kt, err)
+		}
+	}
+
+	keyTemplates.byKeyType[kt] = t
+
+	if t.PublicKeyTypeURL != "" && t.ExportPublicKey != nil {
+		keyTemplates.byPublicTypeURL[t.PublicKeyTypeURL] = t
+	}
+
+	return nil
+}
+
+func customKeyTemplate(kt kms.KeyType) (*KeyTemplate, bool) {
+	keyTemplates.mu.RLock()
+	defer keyTemplates.mu.RUnlock()
+
+	t, ok := keyTemplates.byKeyType[kt]
+
+	return t, ok
+}
+
+func customPublicKeyExporter(typeURL string) (func(keyData *tinkpb.KeyData) ([]byte, error), bool) {
+	keyTemplates.mu.RLock()
+	defer keyTemplates.mu.RUnlock()
+
+	t, ok := keyTemplates.byPublicTypeURL[typeURL]
+	if !ok {
+		return nil, false
+	}
+
+	return t.ExportPublicKey, true
+}
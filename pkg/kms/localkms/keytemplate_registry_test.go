@@ -0,0 +1,183 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/core/registry"
+	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+const (
+	customKeyType          = kms.KeyType("CUSTOM_ED25519")
+	customSignerTypeURL    = "type.example.com/custom.Ed25519PrivateKey"
+	customVerifierTypeURL  = "type.example.com/custom.Ed25519PublicKey"
+	ed25519VerifierURL     = "type.googleapis.com/google.crypto.tink.Ed25519PublicKey"
+	customNoKIDKeyType     = kms.KeyType("CUSTOM_ED25519_NO_KID")
+	customNoKIDSignerURL   = "type.example.com/custom.NoKID.Ed25519PrivateKey"
+	customNoKIDVerifierURL = "type.example.com/custom.NoKID.Ed25519PublicKey"
+)
+
+func TestRegisterKeyTemplate(t *testing.T) {
+	require.NoError(t, RegisterKeyTemplate(customKeyType, testKeyTemplate(t, customSignerTypeURL,
+		customVerifierTypeURL, true)))
+
+	kmsService, err := New(testMasterKeyURI, &mockProvider{
+		storage:    mockstorage.NewMockStoreProvider(),
+		secretLock: createMasterKeyAndSecretLock(t),
+	})
+	require.NoError(t, err)
+
+	t.Run("create, export and sign with a custom key", func(t *testing.T) {
+		kid, kh, err := kmsService.Create(customKeyType)
+		require.NoError(t, err)
+		require.NotEmpty(t, kid)
+
+		pubKey, err := kmsService.ExportPubKeyBytes(kid)
+		require.NoError(t, err)
+		require.Len(t, pubKey, ed25519.PublicKeySize)
+
+		expectedKID, err := CreateKID(pubKey, kms.ED25519Type)
+		require.NoError(t, err)
+		require.Equal(t, expectedKID, kid)
+
+		c, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		msg := []byte("message")
+
+		sig, err := c.Sign(msg, kh)
+		require.NoError(t, err)
+		require.True(t, ed25519.Verify(pubKey, msg, sig))
+	})
+
+	t.Run("custom key without KID creator", func(t *testing.T) {
+		require.NoError(t, RegisterKeyTemplate(customNoKIDKeyType, testKeyTemplate(t, customNoKIDSignerURL,
+			customNoKIDVerifierURL, false)))
+
+		kid, _, err := kmsService.Create(customNoKIDKeyType)
+		require.NoError(t, err)
+		require.NotEmpty(t, kid)
+
+		_, err = kmsService.ExportPubKeyBytes(kid)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key type not supported for writing raw key bytes")
+	})
+
+	t.Run("invalid registrations", func(t *testing.T) {
+		err := RegisterKeyTemplate("", &KeyTemplate{})
+		require.EqualError(t, err, "registerKeyTemplate: missing key type")
+
+		err = RegisterKeyTemplate("OTHER", &KeyTemplate{})
+		require.EqualError(t, err, "registerKeyTemplate: missing template of key type 'OTHER'")
+
+		err = RegisterKeyTemplate(kms.ED25519Type, &KeyTemplate{Template: &tinkpb.KeyTemplate{}})
+		require.EqualError(t, err, "registerKeyTemplate: key type 'ED25519' is built-in")
+
+		err = RegisterKeyTemplate(customKeyType, &KeyTemplate{Template: &tinkpb.KeyTemplate{}})
+		require.EqualError(t, err, "registerKeyTemplate: key type 'CUSTOM_ED25519' is already registered")
+	})
+
+	t.Run("unregistered key type", func(t *testing.T) {
+		_, _, err := kmsService.Create("UNKNOWN")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key type 'UNKNOWN' unrecognized")
+	})
+}
+
+// testKeyTemplate returns the template of Ed25519 keys under custom type URLs, as a custom key type would.
+func testKeyTemplate(t *testing.T, signerURL, verifierURL string, withKID bool) *KeyTemplate {
+	t.Helper()
+
+	signerKM, err := registry.GetKeyManager(ed25519SignerTypeURL)
+	require.NoError(t, err)
+
+	verifierKM, err := registry.GetKeyManager(ed25519VerifierURL)
+	require.NoError(t, err)
+
+	kt := &KeyTemplate{
+		Template: &tinkpb.KeyTemplate{
+			TypeUrl:          signerURL,
+			OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+		},
+		KeyManagers: []registry.KeyManager{
+			&renamedKeyManager{
+				KeyManager: signerKM,
+				typeURL:    signerURL,
+				pubTypeURL: verifierURL,
+			},
+			&renamedKeyManager{
+				KeyManager: verifierKM,
+				typeURL:    verifierURL,
+			},
+		},
+		PublicKeyTypeURL: verifierURL,
+	}
+
+	if withKID {
+		kt.ExportPublicKey = func(keyData *tinkpb.KeyData) ([]byte, error) {
+			pubKey := new(ed25519pb.Ed25519PublicKey)
+
+			if err := proto.Unmarshal(keyData.Value, pubKey); err != nil {
+				return nil, err
+			}
+
+			return pubKey.KeyValue, nil
+		}
+		kt.CreateKID = func(pubKey []byte) (string, error) {
+			return CreateKID(pubKey, kms.ED25519Type)
+		}
+	}
+
+	return kt
+}
+
+// renamedKeyManager is a Tink key manager serving the keys of another key manager under a different type URL.
+type renamedKeyManager struct {
+	registry.KeyManager
+	typeURL    string
+	pubTypeURL string
+}
+
+func (m *renamedKeyManager) TypeURL() string {
+	return m.typeURL
+}
+
+func (m *renamedKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == m.typeURL
+}
+
+func (m *renamedKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	kd, err := m.KeyManager.NewKeyData(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	kd.TypeUrl = m.typeURL
+
+	return kd, nil
+}
+
+func (m *renamedKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	kd, err := m.KeyManager.(registry.PrivateKeyManager).PublicKeyData(serializedPrivKey)
+	if err != nil {
+		return nil, err
+	}
+
+	kd.TypeUrl = m.pubTypeURL
+
+	return kd, nil
+}
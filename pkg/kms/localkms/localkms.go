@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
@@ -175,8 +176,21 @@ func (l *LocalKMS) Rotate(kt kms.KeyType, keyID string) (string, interface{}, er
 	return newID, updatedKH, nil
 }
 
-// nolint:gocyclo
 func getKeyTemplate(keyType kms.KeyType) (*tinkpb.KeyTemplate, error) {
+	template, err := builtInKeyTemplate(keyType)
+	if err == nil {
+		return template, nil
+	}
+
+	if t, ok := customKeyTemplate(keyType); ok {
+		return t.Template, nil
+	}
+
+	return nil, err
+}
+
+// nolint:gocyclo
+func builtInKeyTemplate(keyType kms.KeyType) (*tinkpb.KeyTemplate, error) {
 	switch keyType {
 	case kms.AES128GCMType:
 		return aead.AES128GCMKeyTemplate(), nil
@@ -202,6 +216,10 @@ func getKeyTemplate(keyType kms.KeyType) (*tinkpb.KeyTemplate, error) {
 		return createECDSAIEEE1363KeyTemplate(commonpb.HashType_SHA384, commonpb.EllipticCurveType_NIST_P384), nil
 	case kms.ECDSAP521TypeIEEEP1363:
 		return createECDSAIEEE1363KeyTemplate(commonpb.HashType_SHA512, commonpb.EllipticCurveType_NIST_P521), nil
+	case kms.ECDSASecp256k1TypeDER:
+		return secp256k1.DERKeyTemplate(), nil
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		return secp256k1.IEEEP1363KeyTemplate(), nil
 	case kms.ED25519Type:
 		return signature.ED25519KeyWithoutPrefixTemplate(), nil
 	case kms.HMACSHA256Tag256Type:
@@ -243,10 +261,20 @@ func (l *LocalKMS) storeKeySet(kh *keyset.Handle, kt kms.KeyType) (string, error
 		err error
 	)
 
-	switch kt {
-	case kms.AES128GCMType, kms.AES256GCMType, kms.AES256GCMNoPrefixType, kms.ChaCha20Poly1305Type,
-		kms.XChaCha20Poly1305Type, kms.HMACSHA256Tag256Type:
+	custom, isCustom := customKeyTemplate(kt)
+
+	switch {
+	case kt == kms.AES128GCMType, kt == kms.AES256GCMType, kt == kms.AES256GCMNoPrefixType,
+		kt == kms.ChaCha20Poly1305Type, kt == kms.XChaCha20Poly1305Type, kt == kms.HMACSHA256Tag256Type:
 		// symmetric keys will have random kid value (generated in the local storeWriter)
+	case isCustom:
+		// custom keys have random kid value unless their type creates it from the public key
+		if custom.CreateKID != nil {
+			kid, err = l.generateCustomKID(kh, custom)
+			if err != nil {
+				return "", fmt.Errorf("storeKeySet: failed to generate kid: %w", err)
+			}
+		}
 	default:
 		// asymmetric keys will use the public key's JWK thumbprint base64URL encoded as kid value
 		kid, err = l.generateKID(kh, kt)
@@ -402,6 +430,15 @@ func (l *LocalKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 	}
 }
 
+func (l *LocalKMS) generateCustomKID(kh *keyset.Handle, t *KeyTemplate) (string, error) {
+	keyBytes, err := l.exportPubKeyBytes(kh)
+	if err != nil {
+		return "", fmt.Errorf("generateKID: failed to export public key: %w", err)
+	}
+
+	return t.CreateKID(keyBytes)
+}
+
 func (l *LocalKMS) generateKID(kh *keyset.Handle, kt kms.KeyType) (string, error) {
 	keyBytes, err := l.exportPubKeyBytes(kh)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/google/tink/go/keyset"
 	"github.com/google/tink/go/subtle/random"
 	"github.com/stretchr/testify/require"
@@ -260,6 +261,8 @@ func TestLocalKMS_Success(t *testing.T) {
 		kms.ECDSAP256TypeIEEEP1363,
 		kms.ECDSAP384TypeIEEEP1363,
 		kms.ECDSAP521TypeIEEEP1363,
+		kms.ECDSASecp256k1TypeDER,
+		kms.ECDSASecp256k1TypeIEEEP1363,
 		kms.ED25519Type,
 		kms.NISTP256ECDHKWType,
 		kms.NISTP384ECDHKWType,
@@ -391,6 +394,16 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) {
 			keyType: kms.ECDSAP521TypeIEEEP1363,
 			curve:   elliptic.P521(),
 		},
+		{
+			tcName:  "import private key using ECDSASecp256k1TypeDER type",
+			keyType: kms.ECDSASecp256k1TypeDER,
+			curve:   btcec.S256(),
+		},
+		{
+			tcName:  "import private key using ECDSASecp256k1TypeIEEEP1363 type",
+			keyType: kms.ECDSASecp256k1TypeIEEEP1363,
+			curve:   btcec.S256(),
+		},
 		{
 			tcName:  "import private key using ED25519Type type",
 			keyType: kms.ED25519Type,
@@ -490,7 +503,8 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) {
 				pubKey, err := x509.MarshalPKIXPublicKey(privKey.Public())
 				require.NoError(t, err)
 				require.EqualValues(t, pubKey, pubKeyBytes)
-			case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363:
+			case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363,
+				kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363:
 				pubKey := elliptic.Marshal(tt.curve, privKey.X, privKey.Y)
 				require.EqualValues(t, pubKey, pubKeyBytes)
 			}
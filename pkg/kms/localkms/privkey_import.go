@@ -12,6 +12,7 @@ import (
 	"crypto/ed25519"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/keyset"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
@@ -28,8 +29,11 @@ const (
 	ecdsaSignerTypeURL   = "type.googleapis.com/google.crypto.tink.EcdsaPrivateKey"
 	ed25519SignerTypeURL = "type.googleapis.com/google.crypto.tink.Ed25519PrivateKey"
 	bbsSignerKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
+
+	secp256k1SignerKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PrivateKey"
 )
 
+// nolint:funlen
 func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
 	var params *ecdsapb.EcdsaParams
@@ -39,6 +43,8 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 		return "", nil, fmt.Errorf("import private EC key failed: %w", err)
 	}
 
+	tURL := ecdsaSignerTypeURL
+
 	switch kt {
 	case kms.ECDSAP256TypeDER:
 		params = &ecdsapb.EcdsaParams{
@@ -76,6 +82,13 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 			Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
 			HashType: commonpb.HashType_SHA512,
 		}
+	case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363:
+		if privKey.Curve != btcec.S256() {
+			return "", nil, fmt.Errorf("import private EC key failed: not a secp256k1 key")
+		}
+
+		tURL = secp256k1SignerKeyTypeURL
+		params = secp256k1Params(secp256k1Encoding(kt))
 	default:
 		return "", nil, fmt.Errorf("import private EC key failed: invalid ECDSA key type")
 	}
@@ -85,7 +98,7 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 		return "", nil, fmt.Errorf("import private EC key failed: %w", err)
 	}
 
-	ks := newKeySet(ecdsaSignerTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
+	ks := newKeySet(tURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
 
 	return l.importKeySet(ks, opts...)
 }
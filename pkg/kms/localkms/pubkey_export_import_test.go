@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -65,6 +66,18 @@ func TestPubKeyExportAndRead(t *testing.T) {
 			keyTemplate: createECDSAIEEE1363KeyTemplate(commonpb.HashType_SHA512, commonpb.EllipticCurveType_NIST_P521),
 			doSign:      true,
 		},
+		{
+			tcName:      "export then read ECDSASecp256k1DER public key",
+			keyType:     kms.ECDSASecp256k1TypeDER,
+			keyTemplate: secp256k1.DERKeyTemplate(),
+			doSign:      true,
+		},
+		{
+			tcName:      "export then read ECDSASecp256k1IEEEP1363 public key",
+			keyType:     kms.ECDSASecp256k1TypeIEEEP1363,
+			keyTemplate: secp256k1.IEEEP1363KeyTemplate(),
+			doSign:      true,
+		},
 		{
 			tcName:      "export then read ED25519 public key",
 			keyType:     kms.ED25519Type,
@@ -12,6 +12,7 @@ import (
 	"crypto/x509"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/insecurecleartextkeyset"
 	"github.com/google/tink/go/keyset"
@@ -142,6 +143,13 @@ func getMarshalledProtoKeyAndKeyURL(pubKey []byte, kt kms.KeyType) ([]byte, stri
 		if err != nil {
 			return nil, "", err
 		}
+	case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363:
+		tURL = secp256k1VerifierKeyTypeURL
+
+		keyValue, err = getMarshalledSecp256k1Key(pubKey, secp256k1Encoding(kt))
+		if err != nil {
+			return nil, "", err
+		}
 	case kms.ED25519Type:
 		tURL = ed25519VerifierTypeURL
 		pubKeyProto := new(ed25519pb.Ed25519PublicKey)
@@ -220,6 +228,33 @@ func getMarshalledECDSAIEEEP1363Key(marshaledPubKey []byte, curveName string, c
 	return getMarshalledECDSAKey(&ecdsa.PublicKey{X: x, Y: y, Curve: curve}, params)
 }
 
+func getMarshalledSecp256k1Key(marshaledPubKey []byte, encoding ecdsapb.EcdsaSignatureEncoding) ([]byte, error) {
+	x, y := elliptic.Unmarshal(btcec.S256(), marshaledPubKey)
+
+	if x == nil || y == nil {
+		return nil, fmt.Errorf("failed to unmarshal public secp256k1 key")
+	}
+
+	return getMarshalledECDSAKey(&ecdsa.PublicKey{X: x, Y: y, Curve: btcec.S256()}, secp256k1Params(encoding))
+}
+
+// secp256k1Params are the params of secp256k1 key protos, their curve is implied by their type URL.
+func secp256k1Params(encoding ecdsapb.EcdsaSignatureEncoding) *ecdsapb.EcdsaParams {
+	return &ecdsapb.EcdsaParams{
+		Curve:    commonpb.EllipticCurveType_UNKNOWN_CURVE,
+		Encoding: encoding,
+		HashType: commonpb.HashType_SHA256,
+	}
+}
+
+func secp256k1Encoding(kt kms.KeyType) ecdsapb.EcdsaSignatureEncoding {
+	if kt == kms.ECDSASecp256k1TypeDER {
+		return ecdsapb.EcdsaSignatureEncoding_DER
+	}
+
+	return ecdsapb.EcdsaSignatureEncoding_IEEE_P1363
+}
+
 func getMarshalledECDSAKey(ecPubKey *ecdsa.PublicKey, params *ecdsapb.EcdsaParams) ([]byte, error) {
 	return proto.Marshal(newProtoECDSAPublicKey(ecPubKey, params))
 }
@@ -14,6 +14,7 @@ import (
 	"io"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
@@ -31,6 +32,7 @@ const (
 	nistPECDHKWPublicKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPublicKey"
 	x25519ECDHKWPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPublicKey"
 	bbsVerifierKeyTypeURL        = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPublicKey"
+	secp256k1VerifierKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PublicKey"
 )
 
 // PubKeyWriter will write the raw bytes of a Tink KeySet's primary public key
@@ -68,7 +70,7 @@ func write(w io.Writer, msg *tinkpb.Keyset) error {
 	for _, key := range ks {
 		if key.KeyId == primaryKID && key.Status == tinkpb.KeyStatusType_ENABLED {
 			switch key.KeyData.TypeUrl {
-			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL:
+			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL, secp256k1VerifierKeyTypeURL:
 				created, err = writePubKey(w, key)
 				if err != nil {
 					return err
@@ -83,7 +85,10 @@ func write(w io.Writer, msg *tinkpb.Keyset) error {
 
 				created = true
 			default:
-				return fmt.Errorf("key type not supported for writing raw key bytes: %s", key.KeyData.TypeUrl)
+				created, err = writeCustomPubKey(w, key)
+				if err != nil {
+					return err
+				}
 			}
 
 			break
@@ -101,7 +106,7 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
 	var marshaledRawPubKey []byte
 
 	// TODO add other key types than the ones below and other than nistPECDHKWPublicKeyTypeURL and
-	// TODO x25519ECDHKWPublicKeyTypeURL(eg: secp256k1 when introduced in KMS).
+	// TODO x25519ECDHKWPublicKeyTypeURL.
 	switch key.KeyData.TypeUrl {
 	case ecdsaVerifierTypeURL:
 		pubKeyProto := new(ecdsapb.EcdsaPublicKey)
@@ -115,6 +120,17 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
 		if err != nil {
 			return false, err
 		}
+	case secp256k1VerifierKeyTypeURL:
+		pubKeyProto := new(ecdsapb.EcdsaPublicKey)
+
+		err := proto.Unmarshal(key.KeyData.Value, pubKeyProto)
+		if err != nil {
+			return false, err
+		}
+
+		// x509 does not support secp256k1, keys of both encodings are exported in their uncompressed form.
+		marshaledRawPubKey = elliptic.Marshal(btcec.S256(),
+			new(big.Int).SetBytes(pubKeyProto.X), new(big.Int).SetBytes(pubKeyProto.Y))
 	case ed25519VerifierTypeURL:
 		pubKeyProto := new(ed25519pb.Ed25519PublicKey)
 
@@ -147,6 +163,25 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
 	return n > 0, nil
 }
 
+func writeCustomPubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
+	exportPublicKey, ok := customPublicKeyExporter(key.KeyData.TypeUrl)
+	if !ok {
+		return false, fmt.Errorf("key type not supported for writing raw key bytes: %s", key.KeyData.TypeUrl)
+	}
+
+	marshaledRawPubKey, err := exportPublicKey(key.KeyData)
+	if err != nil {
+		return false, fmt.Errorf("export public key with keyURL %s: %w", key.KeyData.TypeUrl, err)
+	}
+
+	n, err := w.Write(marshaledRawPubKey)
+	if err != nil {
+		return false, nil //nolint:nilerr
+	}
+
+	return n > 0, nil
+}
+
 func getMarshalledECDSAKeyValueFromProto(pubKeyProto *ecdsapb.EcdsaPublicKey) ([]byte, error) {
 	var (
 		marshaledRawPubKey []byte
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
+	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// ConvertEd25519ToX25519 derives the X25519 key agreement key (of X25519ECDHKWType) from the Ed25519 signing key
+// referenced by keyID and stores it in the KMS. It allows decrypting envelopes packed for the X25519 key derived
+// from the public Ed25519 key of DID documents lacking explicit keyAgreement entries.
+// The key ID of derived key is the JWK thumbprint of the X25519 public key, as for keys created by the KMS.
+// Returns:
+//  - keyID of the derived key
+//  - handle instance (to private X25519 key)
+//  - error if keyID does not reference Ed25519 key or the conversion fails
+func (l *LocalKMS) ConvertEd25519ToX25519(keyID string) (string, interface{}, error) {
+	kh, err := l.getKeySet(keyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("convertEd25519ToX25519: failed to get keyset handle: %w", err)
+	}
+
+	if typeURL := primaryKeyTypeURL(kh); typeURL != ed25519SignerTypeURL {
+		return "", nil, fmt.Errorf("convertEd25519ToX25519: key %s is not Ed25519 key: %s", keyID, typeURL)
+	}
+
+	edPub, err := l.exportPubKeyBytes(kh)
+	if err != nil {
+		return "", nil, fmt.Errorf("convertEd25519ToX25519: %w", err)
+	}
+
+	x25519Pub, err := cryptoutil.PublicEd25519toCurve25519(edPub)
+	if err != nil {
+		return "", nil, fmt.Errorf("convertEd25519ToX25519: failed to convert public key: %w", err)
+	}
+
+	x25519Priv, err := l.exportEncPrivKey(keyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("convertEd25519ToX25519: failed to convert private key: %w", err)
+	}
+
+	defer x25519Priv.Destroy()
+
+	x25519KH, err := newX25519ECDHKWKeysetHandle(x25519Priv.Bytes(), x25519Pub)
+	if err != nil {
+		return "", nil, fmt.Errorf("convertEd25519ToX25519: %w", err)
+	}
+
+	kid, err := l.storeKeySet(x25519KH, kms.X25519ECDHKWType)
+	if err != nil {
+		return "", nil, fmt.Errorf("convertEd25519ToX25519: failed to store keyset: %w", err)
+	}
+
+	return kid, x25519KH, nil
+}
+
+func primaryKeyTypeURL(kh *keyset.Handle) string {
+	info := kh.KeysetInfo()
+
+	for _, k := range info.KeyInfo {
+		if k.KeyId == info.PrimaryKeyId {
+			return k.TypeUrl
+		}
+	}
+
+	return ""
+}
+
+func newX25519ECDHKWKeysetHandle(priv, pub []byte) (*keyset.Handle, error) {
+	template := ecdh.X25519ECDHKWKeyTemplate()
+
+	keyFormat := &ecdhpb.EcdhAeadKeyFormat{}
+
+	err := proto.Unmarshal(template.Value, keyFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal X25519ECDHKW key format: %w", err)
+	}
+
+	mKeyValue, err := proto.Marshal(&ecdhpb.EcdhAeadPrivateKey{
+		KeyValue: priv,
+		PublicKey: &ecdhpb.EcdhAeadPublicKey{
+			Params: keyFormat.Params,
+			X:      pub,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal X25519ECDHKW private key: %w", err)
+	}
+
+	ks := newKeySet(template.TypeUrl, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
+
+	return insecurecleartextkeyset.Read(&keyset.MemReaderWriter{Keyset: ks})
+}
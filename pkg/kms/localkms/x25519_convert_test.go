@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestLocalKMS_ConvertEd25519ToX25519(t *testing.T) {
+	kmsService, err := New(testMasterKeyURI, &mockProvider{
+		storage:    mockstorage.NewMockStoreProvider(),
+		secretLock: createMasterKeyAndSecretLock(t),
+	})
+	require.NoError(t, err)
+
+	var _ kms.X25519Converter = kmsService
+
+	t.Run("success", func(t *testing.T) {
+		edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		edKID, _, err := kmsService.ImportPrivateKey(edPriv, kms.ED25519Type)
+		require.NoError(t, err)
+
+		kid, kh, err := kmsService.ConvertEd25519ToX25519(edKID)
+		require.NoError(t, err)
+		require.NotEmpty(t, kid)
+		require.NotNil(t, kh)
+
+		expectedX, err := cryptoutil.PublicEd25519toCurve25519(edPub)
+		require.NoError(t, err)
+
+		mPubKey, err := kmsService.ExportPubKeyBytes(kid)
+		require.NoError(t, err)
+
+		pubKey := &cryptoapi.PublicKey{}
+		require.NoError(t, json.Unmarshal(mPubKey, pubKey))
+		require.Equal(t, expectedX, pubKey.X)
+		require.Equal(t, kid, pubKey.KID)
+
+		expectedKID, err := CreateKID(mPubKey, kms.X25519ECDHKWType)
+		require.NoError(t, err)
+		require.Equal(t, expectedKID, kid)
+	})
+
+	t.Run("not Ed25519 key", func(t *testing.T) {
+		kid, _, err := kmsService.Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		_, _, err = kmsService.ConvertEd25519ToX25519(kid)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not Ed25519 key")
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		_, _, err := kmsService.ConvertEd25519ToX25519("unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get keyset handle")
+	})
+}
@@ -10,11 +10,12 @@ import "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 
 // MockMessenger mock implementation of messenger.
 type MockMessenger struct {
-	ErrReplyTo           error
-	ReplyToMsgFunc       func(service.DIDCommMsgMap, service.DIDCommMsgMap, string, string) error
-	ErrReplyToNested     error
-	ErrSend              error
-	ErrSendToDestination error
+	ErrReplyTo            error
+	ReplyToMsgFunc        func(service.DIDCommMsgMap, service.DIDCommMsgMap, string, string) error
+	ErrReplyToNested      error
+	ErrSend               error
+	ErrSendToDestination  error
+	ErrReplyToDestination error
 }
 
 // ReplyTo mock messenger reply to.
@@ -62,3 +63,13 @@ func (m *MockMessenger) SendToDestination(msg service.DIDCommMsgMap, sender stri
 
 	return nil
 }
+
+// ReplyToMsgDestination mock messenger ReplyToMsgDestination.
+func (m *MockMessenger) ReplyToMsgDestination(in, out service.DIDCommMsgMap, sender string,
+	destination *service.Destination) error {
+	if m.ErrReplyToDestination != nil {
+		return m.ErrReplyToDestination
+	}
+
+	return nil
+}
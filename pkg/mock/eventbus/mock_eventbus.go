@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/eventbus"
+)
+
+// MockBus is an in-process implementation of eventbus.Bus
+// to be used only for unit tests. The groups are ignored, every subscriber receives the messages.
+type MockBus struct {
+	PublishErr   error
+	SubscribeErr error
+	// Published keeps the published messages, in order.
+	Published []*eventbus.Message
+
+	mu          sync.Mutex
+	subscribers map[string][]chan *eventbus.Message
+}
+
+// Publish publishes the message to the subscribers of its topic.
+func (m *MockBus) Publish(ctx context.Context, msg *eventbus.Message) error {
+	if m.PublishErr != nil {
+		return m.PublishErr
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delivered := *msg
+	delivered.Sequence = uint64(len(m.Published) + 1)
+
+	m.Published = append(m.Published, &delivered)
+
+	for _, ch := range m.subscribers[msg.Topic] {
+		select {
+		case ch <- &delivered:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns the messages published on the topic, the channel is buffered and closed when ctx is done.
+func (m *MockBus) Subscribe(ctx context.Context, topic string,
+	options ...eventbus.SubscribeOption) (<-chan *eventbus.Message, error) {
+	if m.SubscribeErr != nil {
+		return nil, m.SubscribeErr
+	}
+
+	opts := &eventbus.SubscribeOptions{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.subscribers == nil {
+		m.subscribers = make(map[string][]chan *eventbus.Message)
+	}
+
+	ch := make(chan *eventbus.Message, len(m.Published)+100)
+
+	if opts.Replay {
+		for _, msg := range m.Published {
+			if msg.Topic == topic {
+				ch <- msg
+			}
+		}
+	}
+
+	m.subscribers[topic] = append(m.subscribers[topic], ch)
+
+	go func() {
+		<-ctx.Done()
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		subscribers := m.subscribers[topic]
+
+		for i := range subscribers {
+			if subscribers[i] == ch {
+				m.subscribers[topic] = append(subscribers[:i], subscribers[i+1:]...)
+
+				break
+			}
+		}
+
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// Close closes the MockBus.
+func (m *MockBus) Close() error {
+	return nil
+}
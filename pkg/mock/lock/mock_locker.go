@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+// MockLocker is an in-process implementation of lock.Locker
+// to be used only for unit tests.
+type MockLocker struct {
+	LockErr error
+	// Acquired keeps the names of the acquired locks, in order.
+	Acquired []string
+
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// Lock acquires the lock with the given name.
+func (m *MockLocker) Lock(ctx context.Context, name string) (lock.Unlocker, error) {
+	if m.LockErr != nil {
+		return nil, m.LockErr
+	}
+
+	for {
+		m.mu.Lock()
+
+		if m.locks == nil {
+			m.locks = make(map[string]chan struct{})
+		}
+
+		held, ok := m.locks[name]
+		if !ok {
+			released := make(chan struct{})
+			m.locks[name] = released
+			m.Acquired = append(m.Acquired, name)
+			m.mu.Unlock()
+
+			return &mockUnlocker{locker: m, name: name, released: released}, nil
+		}
+
+		m.mu.Unlock()
+
+		select {
+		case <-held:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+type mockUnlocker struct {
+	locker   *MockLocker
+	name     string
+	released chan struct{}
+}
+
+func (u *mockUnlocker) Unlock() error {
+	u.locker.mu.Lock()
+	defer u.locker.mu.Unlock()
+
+	if u.locker.locks[u.name] != u.released {
+		return lock.ErrLockNotHeld
+	}
+
+	delete(u.locker.locks, u.name)
+	close(u.released)
+
+	return nil
+}
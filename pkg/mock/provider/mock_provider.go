@@ -9,6 +9,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
@@ -30,6 +31,7 @@ type Provider struct {
 	OutboundDispatcherValue           dispatcher.Outbound
 	VDRegistryValue                   vdrapi.Registry
 	CryptoValue                       crypto.Crypto
+	InboundTransportsValue            []transport.InboundTransport
 }
 
 // Service return service.
@@ -45,6 +47,11 @@ func (p *Provider) Service(id string) (interface{}, error) {
 	return p.ServiceValue, nil
 }
 
+// InboundTransports returns the inbound transports.
+func (p *Provider) InboundTransports() []transport.InboundTransport {
+	return p.InboundTransportsValue
+}
+
 // KMS returns a kms instance.
 func (p *Provider) KMS() kms.KeyManager {
 	return p.KMSValue
@@ -209,6 +209,12 @@ func (s *MockStore) Batch(operations []storage.Operation) error {
 	defer s.lock.Unlock()
 
 	for _, op := range operations {
+		if op.Value == nil {
+			delete(s.Store, op.Key)
+
+			continue
+		}
+
 		s.Store[op.Key] = DBEntry{
 			Value: op.Value,
 			Tags:  op.Tags,
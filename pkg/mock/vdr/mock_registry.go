@@ -26,6 +26,7 @@ type MockVDRegistry struct {
 	ResolveErr     error
 	ResolveValue   *did.Doc
 	ResolveFunc    func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
+	SupportsFunc   func(method string, op vdrapi.Operation) bool
 }
 
 // Create mock implementation of create DID.
@@ -82,6 +83,15 @@ func (m *MockVDRegistry) Deactivate(didID string, opts ...vdrapi.DIDMethodOption
 	return nil
 }
 
+// Supports reports whether the operation is supported, all operations are supported by default.
+func (m *MockVDRegistry) Supports(method string, op vdrapi.Operation) bool {
+	if m.SupportsFunc != nil {
+		return m.SupportsFunc(method, op)
+	}
+
+	return true
+}
+
 // Close frees resources being maintained by vdr.
 func (m *MockVDRegistry) Close() error {
 	return nil
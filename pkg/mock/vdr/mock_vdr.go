@@ -20,6 +20,7 @@ type MockVDR struct {
 	CreateFunc     func(did *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
 	UpdateFunc     func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error
 	DeactivateFunc func(did string, opts ...vdrapi.DIDMethodOption) error
+	SupportsFunc   func(op vdrapi.Operation) bool
 	CloseErr       error
 }
 
@@ -64,6 +65,15 @@ func (m *MockVDR) Accept(method string) bool {
 	return m.AcceptValue
 }
 
+// Supports reports whether the operation is supported, all operations are supported by default.
+func (m *MockVDR) Supports(op vdrapi.Operation) bool {
+	if m.SupportsFunc != nil {
+		return m.SupportsFunc(op)
+	}
+
+	return true
+}
+
 // Close frees resources being maintained by vdr.
 func (m *MockVDR) Close() error {
 	return m.CloseErr
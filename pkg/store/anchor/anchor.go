@@ -0,0 +1,370 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package anchor anchors the hashes of the credentials to a ledger or a witness service. The hashes are batched into
+// a Merkle tree whose root only is anchored by a Witness, every credential then gets an inclusion proof of its hash
+// in the anchored tree, proving that the credential existed at the time of the anchor.
+package anchor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for anchor store.
+	NameSpace = "anchor"
+
+	pendingKeyPrefix = "anchorpending_"
+	pendingTagName   = "anchorpending"
+	proofKeyPrefix   = "anchorproof_"
+
+	defaultBatchSize = 100
+)
+
+var logger = log.New("aries-framework/store/anchor")
+
+var (
+	// ErrNotFound is returned when the credential hash was never added to the anchor store.
+	ErrNotFound = errors.New("credential hash not found")
+	// ErrNotAnchored is returned when the credential hash is waiting for the anchoring of its batch.
+	ErrNotAnchored = errors.New("credential hash not anchored yet")
+)
+
+// Proof is the inclusion proof of a credential hash in an anchored Merkle tree.
+type Proof struct {
+	// CredentialHash is the hex encoded SHA-256 hash of the credential.
+	CredentialHash string `json:"credentialHash"`
+	// Index of the hash in the leaves of the tree.
+	Index int `json:"index"`
+	// TreeSize is the number of leaves of the tree.
+	TreeSize int `json:"treeSize"`
+	// Path is the hex encoded audit path from the leaf to the root.
+	Path []string `json:"path,omitempty"`
+	// Root is the hex encoded root of the tree.
+	Root string `json:"root"`
+	// AnchorRef is the reference of the anchor of the root returned by the witness.
+	AnchorRef string `json:"anchorRef"`
+	// AnchoredAt is the time the root was anchored at.
+	AnchoredAt time.Time `json:"anchoredAt"`
+}
+
+// pending is a credential hash waiting for the anchoring of its batch.
+type pending struct {
+	Hash  string    `json:"hash"`
+	Added time.Time `json:"added"`
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Option configures the anchor store.
+type Option func(s *Store)
+
+// WithBatchSize sets the number of credential hashes anchored together, 100 by default. A batch is anchored as soon
+// as it is full, Anchor anchors the hashes of an incomplete batch.
+func WithBatchSize(size int) Option {
+	return func(s *Store) {
+		s.batchSize = size
+	}
+}
+
+// WithClock sets the clock of the anchoring times, the current time by default.
+func WithClock(now func() time.Time) Option {
+	return func(s *Store) {
+		s.now = now
+	}
+}
+
+// Store batches the credential hashes, anchors the roots of their Merkle trees by the witness and keeps the inclusion
+// proofs of the hashes.
+type Store struct {
+	store     storage.Store
+	witness   Witness
+	batchSize int
+	now       func() time.Time
+	mutex     sync.Mutex
+}
+
+// New returns a new anchor store anchoring by the witness.
+func New(ctx provider, witness Witness, opts ...Option) (*Store, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open anchor store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace, storage.StoreConfiguration{TagNames: []string{pendingTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	s := &Store{store: store, witness: witness, batchSize: defaultBatchSize, now: time.Now}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// Add adds the hash of the credential to the current batch and returns it. The batch is anchored when it is full.
+func (s *Store) Add(credential []byte) (string, error) {
+	hash := Hash(credential)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, err := s.proof(hash)
+	if !errors.Is(err, ErrNotFound) {
+		// the hash is already either anchored or pending
+		return hash, nil
+	}
+
+	pendingBytes, err := json.Marshal(&pending{Hash: hash, Added: s.now().UTC()})
+	if err != nil {
+		return "", fmt.Errorf("marshal pending hash: %w", err)
+	}
+
+	err = s.store.Put(pendingKeyPrefix+hash, pendingBytes, storage.Tag{Name: pendingTagName})
+	if err != nil {
+		return "", fmt.Errorf("store pending hash: %w", err)
+	}
+
+	hashes, err := s.pending()
+	if err != nil {
+		return "", err
+	}
+
+	if len(hashes) >= s.batchSize {
+		err = s.anchor(hashes)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hash, nil
+}
+
+// Anchor anchors the pending credential hashes, if any.
+func (s *Store) Anchor() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	hashes, err := s.pending()
+	if err != nil {
+		return err
+	}
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	return s.anchor(hashes)
+}
+
+// Proof returns the inclusion proof of the credential hash, ErrNotAnchored if its batch isn't anchored yet.
+func (s *Store) Proof(hash string) (*Proof, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.proof(hash)
+}
+
+func (s *Store) proof(hash string) (*Proof, error) {
+	proofBytes, err := s.store.Get(proofKeyPrefix + hash)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		_, err = s.store.Get(pendingKeyPrefix + hash)
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrNotFound
+		} else if err != nil {
+			return nil, fmt.Errorf("get pending hash: %w", err)
+		}
+
+		return nil, ErrNotAnchored
+	} else if err != nil {
+		return nil, fmt.Errorf("get inclusion proof: %w", err)
+	}
+
+	var proof Proof
+
+	err = json.Unmarshal(proofBytes, &proof)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal inclusion proof: %w", err)
+	}
+
+	return &proof, nil
+}
+
+// anchor anchors the root of the tree of the hashes and replaces the pending hashes by their inclusion proofs.
+func (s *Store) anchor(hashes []string) error {
+	leaves := make([][]byte, len(hashes))
+
+	for i, hash := range hashes {
+		leaf, err := hex.DecodeString(hash)
+		if err != nil {
+			return fmt.Errorf("decode pending hash: %w", err)
+		}
+
+		leaves[i] = leaf
+	}
+
+	root := merkleRoot(leaves)
+
+	ref, err := s.witness.Anchor(root)
+	if err != nil {
+		return fmt.Errorf("anchor merkle root: %w", err)
+	}
+
+	anchoredAt := s.now().UTC()
+	operations := make([]storage.Operation, 0, 2*len(hashes))
+
+	for i, hash := range hashes {
+		proof := &Proof{
+			CredentialHash: hash,
+			Index:          i,
+			TreeSize:       len(leaves),
+			Root:           hex.EncodeToString(root),
+			AnchorRef:      ref,
+			AnchoredAt:     anchoredAt,
+		}
+
+		for _, node := range inclusionPath(leaves, i) {
+			proof.Path = append(proof.Path, hex.EncodeToString(node))
+		}
+
+		proofBytes, err := json.Marshal(proof)
+		if err != nil {
+			return fmt.Errorf("marshal inclusion proof: %w", err)
+		}
+
+		operations = append(operations,
+			storage.Operation{Key: proofKeyPrefix + hash, Value: proofBytes},
+			storage.Operation{Key: pendingKeyPrefix + hash})
+	}
+
+	err = s.store.Batch(operations)
+	if err != nil {
+		return fmt.Errorf("store inclusion proofs of anchor %s: %w", ref, err)
+	}
+
+	logger.Debugf("anchored %d credential hashes under %s", len(hashes), ref)
+
+	return nil
+}
+
+// pending returns the pending hashes, in the order they were added.
+func (s *Store) pending() ([]string, error) {
+	iter, err := s.store.Query(pendingTagName)
+	if err != nil {
+		return nil, fmt.Errorf("query pending hashes: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var entries []*pending
+
+	for {
+		ok, errNext := iter.Next()
+		if errNext != nil {
+			return nil, fmt.Errorf("iterate pending hashes: %w", errNext)
+		}
+
+		if !ok {
+			break
+		}
+
+		value, errValue := iter.Value()
+		if errValue != nil {
+			return nil, fmt.Errorf("get pending hash: %w", errValue)
+		}
+
+		var entry pending
+
+		errValue = json.Unmarshal(value, &entry)
+		if errValue != nil {
+			return nil, fmt.Errorf("unmarshal pending hash: %w", errValue)
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Added.Equal(entries[j].Added) {
+			return entries[i].Hash < entries[j].Hash
+		}
+
+		return entries[i].Added.Before(entries[j].Added)
+	})
+
+	hashes := make([]string, len(entries))
+
+	for i, entry := range entries {
+		hashes[i] = entry.Hash
+	}
+
+	return hashes, nil
+}
+
+// Hash returns the hex encoded SHA-256 hash of the credential, as anchored.
+func Hash(credential []byte) string {
+	hash := sha256.Sum256(credential)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// Verify verifies that the proof is the inclusion proof of the credential in a tree whose root was anchored by the
+// witness and returns the time of the anchor.
+func Verify(credential []byte, proof *Proof, witness Witness) (time.Time, error) {
+	if Hash(credential) != proof.CredentialHash {
+		return time.Time{}, errors.New("proof is not a proof of the credential")
+	}
+
+	leaf, err := hex.DecodeString(proof.CredentialHash)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("decode credential hash: %w", err)
+	}
+
+	root, err := hex.DecodeString(proof.Root)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("decode merkle root: %w", err)
+	}
+
+	path := make([][]byte, len(proof.Path))
+
+	for i, node := range proof.Path {
+		path[i], err = hex.DecodeString(node)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("decode inclusion path: %w", err)
+		}
+	}
+
+	err = verifyInclusion(leaf, proof.Index, proof.TreeSize, path, root)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("verify inclusion: %w", err)
+	}
+
+	anchoredRoot, anchoredAt, err := witness.Resolve(proof.AnchorRef)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("resolve anchor %s: %w", proof.AnchorRef, err)
+	}
+
+	if !bytes.Equal(anchoredRoot, root) {
+		return time.Time{}, fmt.Errorf("anchor %s does not anchor the merkle root", proof.AnchorRef)
+	}
+
+	return anchoredAt, nil
+}
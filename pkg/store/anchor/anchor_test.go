@@ -0,0 +1,345 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anchor
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+var anchorTime = time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+// mockWitness anchors the roots in memory, as a ledger would.
+type mockWitness struct {
+	roots     map[string][]byte
+	anchorErr error
+	mutex     sync.Mutex
+}
+
+func newMockWitness() *mockWitness {
+	return &mockWitness{roots: map[string][]byte{}}
+}
+
+func (w *mockWitness) Anchor(root []byte) (string, error) {
+	if w.anchorErr != nil {
+		return "", w.anchorErr
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	ref := fmt.Sprintf("tx-%d", len(w.roots)+1)
+	w.roots[ref] = root
+
+	return ref, nil
+}
+
+func (w *mockWitness) Resolve(ref string) ([]byte, time.Time, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	root, ok := w.roots[ref]
+	if !ok {
+		return nil, time.Time{}, errors.New("unknown anchor")
+	}
+
+	return root, anchorTime, nil
+}
+
+func newTestStore(t *testing.T, witness Witness, storeProvider *mockstore.MockStoreProvider, opts ...Option) *Store {
+	t.Helper()
+
+	s, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider}, witness,
+		append([]Option{WithClock(func() time.Time { return anchorTime })}, opts...)...)
+	require.NoError(t, err)
+
+	return s
+}
+
+func testCredential(i int) []byte {
+	return []byte(fmt.Sprintf(`{"id":"http://example.edu/credentials/%d"}`, i))
+}
+
+func TestNew(t *testing.T) {
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}}, newMockWitness())
+		require.EqualError(t, err, "failed to open anchor store: open error")
+	})
+}
+
+func TestStore(t *testing.T) {
+	t.Run("anchor full batches", func(t *testing.T) {
+		witness := newMockWitness()
+		s := newTestStore(t, witness, mockstore.NewMockStoreProvider(), WithBatchSize(3))
+
+		hashes := make([]string, 7)
+
+		for i := range hashes {
+			hash, err := s.Add(testCredential(i))
+			require.NoError(t, err)
+			require.Equal(t, Hash(testCredential(i)), hash)
+
+			hashes[i] = hash
+		}
+
+		// the two full batches are anchored, the last hash is pending
+		require.Len(t, witness.roots, 2)
+
+		_, err := s.Proof(hashes[6])
+		require.True(t, errors.Is(err, ErrNotAnchored))
+
+		_, err = s.Proof(Hash([]byte("unknown")))
+		require.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, s.Anchor())
+		require.Len(t, witness.roots, 3)
+
+		// nothing to anchor
+		require.NoError(t, s.Anchor())
+		require.Len(t, witness.roots, 3)
+
+		for i, hash := range hashes {
+			proof, err := s.Proof(hash)
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("tx-%d", i/3+1), proof.AnchorRef)
+			require.Equal(t, anchorTime, proof.AnchoredAt)
+
+			anchoredAt, err := Verify(testCredential(i), proof, witness)
+			require.NoError(t, err)
+			require.Equal(t, anchorTime, anchoredAt)
+		}
+	})
+
+	t.Run("add credential twice", func(t *testing.T) {
+		witness := newMockWitness()
+		s := newTestStore(t, witness, mockstore.NewMockStoreProvider(), WithBatchSize(2))
+
+		_, err := s.Add(testCredential(1))
+		require.NoError(t, err)
+
+		_, err = s.Add(testCredential(1))
+		require.NoError(t, err)
+		require.Empty(t, witness.roots)
+
+		_, err = s.Add(testCredential(2))
+		require.NoError(t, err)
+		require.Len(t, witness.roots, 1)
+
+		_, err = s.Add(testCredential(2))
+		require.NoError(t, err)
+		require.Len(t, witness.roots, 1)
+	})
+
+	t.Run("anchor error", func(t *testing.T) {
+		witness := newMockWitness()
+		witness.anchorErr = errors.New("ledger error")
+
+		s := newTestStore(t, witness, mockstore.NewMockStoreProvider(), WithBatchSize(1))
+
+		_, err := s.Add(testCredential(1))
+		require.EqualError(t, err, "anchor merkle root: ledger error")
+
+		// the hash stays pending until the anchoring succeeds
+		_, err = s.Proof(Hash(testCredential(1)))
+		require.True(t, errors.Is(err, ErrNotAnchored))
+
+		witness.anchorErr = nil
+
+		require.NoError(t, s.Anchor())
+
+		_, err = s.Proof(Hash(testCredential(1)))
+		require.NoError(t, err)
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+		s := newTestStore(t, newMockWitness(), storeProvider, WithBatchSize(1))
+
+		storeProvider.Store.ErrPut = errors.New("put error")
+
+		_, err := s.Add(testCredential(1))
+		require.EqualError(t, err, "store pending hash: put error")
+
+		storeProvider.Store.ErrPut = nil
+		storeProvider.Store.ErrBatch = errors.New("batch error")
+
+		_, err = s.Add(testCredential(1))
+		require.EqualError(t, err, "store inclusion proofs of anchor tx-1: batch error")
+
+		storeProvider.Store.ErrBatch = nil
+		storeProvider.Store.ErrQuery = errors.New("query error")
+
+		require.EqualError(t, s.Anchor(), "query pending hashes: query error")
+
+		storeProvider.Store.ErrQuery = nil
+		storeProvider.Store.ErrGet = errors.New("get error")
+
+		_, err = s.Proof(Hash(testCredential(1)))
+		require.EqualError(t, err, "get inclusion proof: get error")
+	})
+}
+
+func TestVerify(t *testing.T) {
+	witness := newMockWitness()
+	s := newTestStore(t, witness, mockstore.NewMockStoreProvider())
+
+	for i := 0; i < 5; i++ {
+		_, err := s.Add(testCredential(i))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, s.Anchor())
+
+	proof, err := s.Proof(Hash(testCredential(2)))
+	require.NoError(t, err)
+
+	t.Run("other credential", func(t *testing.T) {
+		_, err := Verify(testCredential(3), proof, witness)
+		require.EqualError(t, err, "proof is not a proof of the credential")
+	})
+
+	t.Run("altered proof", func(t *testing.T) {
+		altered := *proof
+		altered.Index = 3
+
+		_, err := Verify(testCredential(2), &altered, witness)
+		require.EqualError(t, err, "verify inclusion: inclusion path does not lead to the root")
+
+		altered = *proof
+		altered.Path = []string{"!"}
+
+		_, err = Verify(testCredential(2), &altered, witness)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode inclusion path")
+
+		altered = *proof
+		altered.Root = "!"
+
+		_, err = Verify(testCredential(2), &altered, witness)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode merkle root")
+	})
+
+	t.Run("root not anchored", func(t *testing.T) {
+		altered := *proof
+		altered.AnchorRef = "tx-2"
+
+		_, err := Verify(testCredential(2), &altered, witness)
+		require.EqualError(t, err, "resolve anchor tx-2: unknown anchor")
+
+		otherWitness := newMockWitness()
+		otherWitness.roots["tx-1"] = []byte("other root")
+
+		_, err = Verify(testCredential(2), proof, otherWitness)
+		require.EqualError(t, err, "anchor tx-1 does not anchor the merkle root")
+	})
+}
+
+func TestHTTPWitness(t *testing.T) {
+	roots := map[string]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var record anchorRecord
+
+			if err := json.NewDecoder(r.Body).Decode(&record); err != nil || record.Root == "" {
+				http.Error(w, "invalid anchor request", http.StatusBadRequest)
+
+				return
+			}
+
+			ref := fmt.Sprintf("%d", len(roots)+1)
+			roots[ref] = record.Root
+
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(&anchorRecord{Ref: ref, AnchoredAt: anchorTime}) //nolint:errcheck
+
+			return
+		}
+
+		root, ok := roots[strings.TrimPrefix(r.URL.Path, "/anchors/")]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(&anchorRecord{Root: root, AnchoredAt: anchorTime}) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	witness := NewHTTPWitness(server.URL+"/anchors/", nil)
+
+	root, err := hex.DecodeString(Hash([]byte("root")))
+	require.NoError(t, err)
+
+	ref, err := witness.Anchor(root)
+	require.NoError(t, err)
+	require.Equal(t, "1", ref)
+
+	anchoredRoot, anchoredAt, err := witness.Resolve(ref)
+	require.NoError(t, err)
+	require.Equal(t, root, anchoredRoot)
+	require.Equal(t, anchorTime, anchoredAt)
+
+	t.Run("unknown anchor", func(t *testing.T) {
+		_, _, err := witness.Resolve("2")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read anchor: witness replied with status 404 Not Found")
+	})
+
+	t.Run("invalid anchored root", func(t *testing.T) {
+		roots["2"] = "!"
+
+		_, _, err := witness.Resolve("2")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode anchored root")
+	})
+
+	t.Run("witness errors", func(t *testing.T) {
+		badServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"root":"` + base64.RawURLEncoding.EncodeToString(root) + `"}`)) //nolint:errcheck
+		}))
+		defer badServer.Close()
+
+		_, err := NewHTTPWitness(badServer.URL, http.DefaultClient).Anchor(root)
+		require.EqualError(t, err, "anchor root: witness returned no anchor reference")
+
+		_, err = NewHTTPWitness("http://[::1]:0", nil).Anchor(root)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "post anchor request")
+
+		_, _, err = NewHTTPWitness("http://[::1]:0", nil).Resolve(ref)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get anchor")
+
+		notJSONServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("not JSON")) //nolint:errcheck
+		}))
+		defer notJSONServer.Close()
+
+		_, err = NewHTTPWitness(notJSONServer.URL, nil).Anchor(root)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal witness response")
+	})
+}
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anchor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+)
+
+// The Merkle trees of the batches are the RFC 6962 (Certificate Transparency) trees: the leaf and node hashes are
+// prefixed differently, so that a node can't be presented as a leaf.
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+func leafHash(leaf []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix}) //nolint:errcheck // hash.Hash never returns an error
+	h.Write(leaf)               //nolint:errcheck // hash.Hash never returns an error
+
+	return h.Sum(nil)
+}
+
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{nodePrefix}) //nolint:errcheck // hash.Hash never returns an error
+	h.Write(left)               //nolint:errcheck // hash.Hash never returns an error
+	h.Write(right)              //nolint:errcheck // hash.Hash never returns an error
+
+	return h.Sum(nil)
+}
+
+// split returns the largest power of two smaller than n (n > 1).
+func split(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+
+	return k
+}
+
+// merkleRoot returns the root of the Merkle tree of the leaves (RFC 6962 section 2.1).
+func merkleRoot(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		return sha256.New().Sum(nil)
+	case 1:
+		return leafHash(leaves[0])
+	}
+
+	k := split(len(leaves))
+
+	return nodeHash(merkleRoot(leaves[:k]), merkleRoot(leaves[k:]))
+}
+
+// inclusionPath returns the audit path of the leaf of the given index (RFC 6962 section 2.1.1).
+func inclusionPath(leaves [][]byte, index int) [][]byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+
+	k := split(len(leaves))
+
+	if index < k {
+		return append(inclusionPath(leaves[:k], index), merkleRoot(leaves[k:]))
+	}
+
+	return append(inclusionPath(leaves[k:], index-k), merkleRoot(leaves[:k]))
+}
+
+// verifyInclusion verifies that the leaf of the given index is included in the tree of the given size and root
+// (RFC 9162 section 2.1.3.2).
+func verifyInclusion(leaf []byte, index, size int, path [][]byte, root []byte) error {
+	if index < 0 || index >= size {
+		return errors.New("leaf index is out of the tree")
+	}
+
+	fn, sn := index, size-1
+	hash := leafHash(leaf)
+
+	for _, p := range path {
+		if sn == 0 {
+			return errors.New("inclusion path is too long")
+		}
+
+		if fn&1 == 1 || fn == sn {
+			hash = nodeHash(p, hash)
+
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			hash = nodeHash(hash, p)
+		}
+
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 {
+		return errors.New("inclusion path is too short")
+	}
+
+	if !bytes.Equal(hash, root) {
+		return errors.New("inclusion path does not lead to the root")
+	}
+
+	return nil
+}
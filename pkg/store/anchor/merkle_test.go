@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anchor
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+
+	for i := range leaves {
+		leaves[i] = []byte(fmt.Sprintf("leaf %d", i))
+	}
+
+	return leaves
+}
+
+func TestMerkleRoot(t *testing.T) {
+	// empty tree hash of RFC 6962
+	require.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		hex.EncodeToString(merkleRoot(nil)))
+
+	leaves := testLeaves(3)
+
+	require.Equal(t, leafHash(leaves[0]), merkleRoot(leaves[:1]))
+	require.Equal(t, nodeHash(nodeHash(leafHash(leaves[0]), leafHash(leaves[1])), leafHash(leaves[2])),
+		merkleRoot(leaves))
+
+	// a node can't be presented as a leaf
+	require.NotEqual(t, merkleRoot(leaves[:2]), leafHash(append(leafHash(leaves[0]), leafHash(leaves[1])...)))
+}
+
+func TestInclusion(t *testing.T) {
+	for size := 1; size <= 17; size++ {
+		leaves := testLeaves(size)
+		root := merkleRoot(leaves)
+
+		for index := range leaves {
+			path := inclusionPath(leaves, index)
+
+			require.NoError(t, verifyInclusion(leaves[index], index, size, path, root),
+				"leaf %d of %d", index, size)
+
+			require.EqualError(t, verifyInclusion([]byte("other leaf"), index, size, path, root),
+				"inclusion path does not lead to the root")
+
+			if size > 1 {
+				require.Error(t, verifyInclusion(leaves[index], (index+1)%size, size, path, root))
+				require.EqualError(t, verifyInclusion(leaves[index], index, size, path[1:], root),
+					"inclusion path is too short")
+			}
+
+			require.EqualError(t, verifyInclusion(leaves[index], index, size, append(path, root), root),
+				"inclusion path is too long")
+		}
+	}
+
+	require.EqualError(t, verifyInclusion([]byte("leaf"), 1, 1, nil, nil), "leaf index is out of the tree")
+	require.EqualError(t, verifyInclusion([]byte("leaf"), -1, 1, nil, nil), "leaf index is out of the tree")
+}
@@ -0,0 +1,128 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anchor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Witness anchors the Merkle roots of the batches of credential hashes outside of the agent, e.g. into a ledger by
+// a blockchain adapter or to a witness service by the HTTPWitness.
+type Witness interface {
+	// Anchor anchors the root and returns the reference of the anchor, e.g. the ID of the ledger transaction.
+	Anchor(root []byte) (string, error)
+	// Resolve returns the root anchored under the reference and the time it was anchored at.
+	Resolve(ref string) ([]byte, time.Time, error)
+}
+
+const maxWitnessResponseSize = 1 << 16
+
+// anchorRecord is the JSON representation of the anchors by the HTTP witnesses.
+type anchorRecord struct {
+	Ref        string    `json:"ref,omitempty"`
+	Root       string    `json:"root"`
+	AnchoredAt time.Time `json:"anchoredAt,omitempty"`
+}
+
+// HTTPWitness is the client of a witness service anchoring the roots over HTTP: the roots are posted to the
+// witness URL ({"root": "<base64url root>"}) which replies with the reference and the time of the anchor
+// ({"ref": "...", "anchoredAt": "..."}), the anchors are resolved at the witness URL followed by "/" and their
+// reference.
+type HTTPWitness struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewHTTPWitness returns the client of the witness service at the given URL.
+func NewHTTPWitness(witnessURL string, httpClient *http.Client) *HTTPWitness {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &HTTPWitness{url: strings.TrimSuffix(witnessURL, "/"), httpClient: httpClient}
+}
+
+// Anchor posts the root to the witness and returns the reference of the anchor.
+func (w *HTTPWitness) Anchor(root []byte) (string, error) {
+	reqBytes, err := json.Marshal(&anchorRecord{Root: base64.RawURLEncoding.EncodeToString(root)})
+	if err != nil {
+		return "", fmt.Errorf("marshal anchor request: %w", err)
+	}
+
+	resp, err := w.httpClient.Post(w.url, "application/json", bytes.NewReader(reqBytes)) //nolint:noctx
+	if err != nil {
+		return "", fmt.Errorf("post anchor request: %w", err)
+	}
+
+	var record anchorRecord
+
+	err = readRecord(resp, &record)
+	if err != nil {
+		return "", fmt.Errorf("anchor root: %w", err)
+	}
+
+	if record.Ref == "" {
+		return "", fmt.Errorf("anchor root: witness returned no anchor reference")
+	}
+
+	return record.Ref, nil
+}
+
+// Resolve gets the anchor from the witness and returns its root and time.
+func (w *HTTPWitness) Resolve(ref string) ([]byte, time.Time, error) {
+	resp, err := w.httpClient.Get(w.url + "/" + url.PathEscape(ref)) //nolint:noctx
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("get anchor: %w", err)
+	}
+
+	var record anchorRecord
+
+	err = readRecord(resp, &record)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("read anchor: %w", err)
+	}
+
+	root, err := base64.RawURLEncoding.DecodeString(record.Root)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("decode anchored root: %w", err)
+	}
+
+	return root, record.AnchoredAt, nil
+}
+
+func readRecord(resp *http.Response, record *anchorRecord) error {
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			logger.Warnf("failed to close witness response body: %s", e)
+		}
+	}()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxWitnessResponseSize))
+	if err != nil {
+		return fmt.Errorf("read witness response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("witness replied with status %s: %s", resp.Status, body)
+	}
+
+	err = json.Unmarshal(body, record)
+	if err != nil {
+		return fmt.Errorf("unmarshal witness response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+// BundleType is the "typ" header of the signed audit log bundles.
+const BundleType = "audit-bundle+jws"
+
+// Bundle is an exported range of consecutive audit log entries.
+type Bundle struct {
+	// Created is the export time of the bundle.
+	Created time.Time `json:"created"`
+	// Entries of the bundle, in sequence order.
+	Entries []*Entry `json:"entries"`
+}
+
+// Export exports the consecutive entries with sequence numbers in [from, to] (0 for unbounded) as a bundle signed
+// by the signer and returns its JWS compact serialization.
+func (l *Log) Export(signer jose.Signer, from, to uint64) (string, error) {
+	all, err := l.entries()
+	if err != nil {
+		return "", err
+	}
+
+	bundle := &Bundle{Created: l.now().UTC(), Entries: []*Entry{}}
+
+	for _, entry := range all {
+		if entry.Sequence >= from && (to == 0 || entry.Sequence <= to) {
+			bundle.Entries = append(bundle.Entries, entry)
+		}
+	}
+
+	err = VerifyEntries(bundle.Entries, "")
+	if err != nil {
+		return "", fmt.Errorf("export audit log: %w", err)
+	}
+
+	payload, err := json.Marshal(bundle)
+	if err != nil {
+		return "", fmt.Errorf("marshal audit log bundle: %w", err)
+	}
+
+	jws, err := jose.NewJWS(jose.Headers{jose.HeaderType: BundleType}, nil, payload, signer)
+	if err != nil {
+		return "", fmt.Errorf("sign audit log bundle: %w", err)
+	}
+
+	return jws.SerializeCompact(false)
+}
+
+// ParseBundle verifies the signature of the audit log bundle (JWS compact serialization) and the integrity of its
+// entries, and returns the bundle.
+func ParseBundle(bundleJWS string, verifier jose.SignatureVerifier) (*Bundle, error) {
+	jws, err := jose.ParseJWS(bundleJWS, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("parse audit log bundle: %w", err)
+	}
+
+	if typ, _ := jws.ProtectedHeaders.Type(); typ != BundleType {
+		return nil, errors.New("not an audit log bundle")
+	}
+
+	var bundle Bundle
+
+	err = json.Unmarshal(jws.Payload, &bundle)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal audit log bundle: %w", err)
+	}
+
+	err = VerifyEntries(bundle.Entries, "")
+	if err != nil {
+		return nil, fmt.Errorf("verify audit log bundle: %w", err)
+	}
+
+	return &bundle, nil
+}
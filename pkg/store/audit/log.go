@@ -0,0 +1,351 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package audit provides an append-only audit log of the credential operations (issuance, verification,
+// presentation, storage and deletion). Every entry is chained to the previous one by its SHA-256 hash, so that
+// removed, reordered or altered entries are detected, and the log can be exported as a signed (JWS) log bundle.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for audit log store.
+	NameSpace = "auditlog"
+
+	entryKeyPattern = "auditentry_%020d"
+	entryTagName    = "auditentry"
+	headKey         = "audithead"
+)
+
+var logger = log.New("aries-framework/store/audit")
+
+// EventType is the type of the audited credential operation.
+type EventType string
+
+const (
+	// EventIssued is the event of a credential issuance (signature).
+	EventIssued EventType = "issued"
+	// EventVerified is the event of a credential verification.
+	EventVerified EventType = "verified"
+	// EventPresented is the event of a presentation generation.
+	EventPresented EventType = "presented"
+	// EventStored is the event of a credential or presentation storage.
+	EventStored EventType = "stored"
+	// EventUpdated is the event of a change of the name, tags or collection of a stored credential.
+	EventUpdated EventType = "updated"
+	// EventDeleted is the event of a credential or presentation deletion.
+	EventDeleted EventType = "deleted"
+)
+
+// Event is an audited credential operation.
+type Event struct {
+	// Type of the operation.
+	Type EventType `json:"type"`
+	// ActorDID is the DID of the party performing the operation, if known.
+	ActorDID string `json:"actorDID,omitempty"`
+	// ObjectID is the ID (or name) of the credential or presentation.
+	ObjectID string `json:"objectID,omitempty"`
+	// Details of the operation (e.g. issuer, verification result).
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Entry is an entry of the audit log.
+type Entry struct {
+	Event
+
+	// Sequence number of the entry, starting at 1.
+	Sequence uint64 `json:"sequence"`
+	// Timestamp of the entry.
+	Timestamp time.Time `json:"timestamp"`
+	// PreviousHash is the hash of the previous entry, empty for the first entry.
+	PreviousHash string `json:"previousHash,omitempty"`
+	// Hash is the hex encoded SHA-256 hash of the previous hash and the entry (without hash).
+	Hash string `json:"hash"`
+}
+
+// head is the last entry of the log.
+type head struct {
+	Sequence uint64 `json:"sequence"`
+	Hash     string `json:"hash"`
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Option configures the audit log.
+type Option func(l *Log)
+
+// WithClock sets the clock of the entry timestamps, the current time by default.
+func WithClock(now func() time.Time) Option {
+	return func(l *Log) {
+		l.now = now
+	}
+}
+
+// Log is the append-only hash-chained audit log.
+type Log struct {
+	store storage.Store
+	now   func() time.Time
+	mutex sync.Mutex
+}
+
+// New returns a new audit log.
+func New(ctx provider, opts ...Option) (*Log, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace, storage.StoreConfiguration{TagNames: []string{entryTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	l := &Log{store: store, now: time.Now}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l, nil
+}
+
+// Append appends the event to the log and returns its entry.
+func (l *Log) Append(event *Event) (*Entry, error) {
+	if event.Type == "" {
+		return nil, errors.New("event type is mandatory")
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	last, err := l.head()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{
+		Event:        *event,
+		Sequence:     last.Sequence + 1,
+		Timestamp:    l.now().UTC(),
+		PreviousHash: last.Hash,
+	}
+
+	entry.Hash, err = computeHash(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	headBytes, err := json.Marshal(&head{Sequence: entry.Sequence, Hash: entry.Hash})
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit log head: %w", err)
+	}
+
+	err = l.store.Batch([]storage.Operation{
+		{Key: entryKey(entry.Sequence), Value: entryBytes, Tags: []storage.Tag{{Name: entryTagName}}},
+		{Key: headKey, Value: headBytes},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("append audit entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Filter selects audit log entries, the zero value selects all entries.
+type Filter struct {
+	Type     EventType `json:"type,omitempty"`
+	ActorDID string    `json:"actorDID,omitempty"`
+	ObjectID string    `json:"objectID,omitempty"`
+	From     time.Time `json:"from,omitempty"`
+	To       time.Time `json:"to,omitempty"`
+}
+
+func (f *Filter) matches(entry *Entry) bool {
+	if f == nil {
+		return true
+	}
+
+	return (f.Type == "" || entry.Type == f.Type) &&
+		(f.ActorDID == "" || entry.ActorDID == f.ActorDID) &&
+		(f.ObjectID == "" || entry.ObjectID == f.ObjectID) &&
+		(f.From.IsZero() || !entry.Timestamp.Before(f.From)) &&
+		(f.To.IsZero() || entry.Timestamp.Before(f.To))
+}
+
+// Query returns the entries selected by the filter, in sequence order.
+func (l *Log) Query(filter *Filter) ([]*Entry, error) {
+	entries, err := l.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*Entry
+
+	for _, entry := range entries {
+		if filter.matches(entry) {
+			selected = append(selected, entry)
+		}
+	}
+
+	return selected, nil
+}
+
+// Verify verifies the integrity of the log: the hashes of the entries, their chaining and the last entry.
+func (l *Log) Verify() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	entries, err := l.entries()
+	if err != nil {
+		return err
+	}
+
+	last, err := l.head()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) > 0 && (entries[0].Sequence != 1 || entries[0].PreviousHash != "") {
+		return errors.New("audit entry 1 is missing")
+	}
+
+	err = VerifyEntries(entries, "")
+	if err != nil {
+		return err
+	}
+
+	if uint64(len(entries)) != last.Sequence || (len(entries) > 0 && entries[len(entries)-1].Hash != last.Hash) {
+		return errors.New("audit log was truncated")
+	}
+
+	return nil
+}
+
+// VerifyEntries verifies that the entries are consecutive, chained to the previous hash (if any, the chaining of the
+// first entry is not checked otherwise) and not altered.
+func VerifyEntries(entries []*Entry, previousHash string) error {
+	for i, entry := range entries {
+		if i > 0 && entry.Sequence != entries[i-1].Sequence+1 {
+			return fmt.Errorf("audit entry %d is missing", entries[i-1].Sequence+1)
+		}
+
+		if (i > 0 || previousHash != "") && entry.PreviousHash != previousHash {
+			return fmt.Errorf("audit entry %d is not chained to the previous entry", entry.Sequence)
+		}
+
+		hash, err := computeHash(entry)
+		if err != nil {
+			return err
+		}
+
+		if hash != entry.Hash {
+			return fmt.Errorf("audit entry %d was altered", entry.Sequence)
+		}
+
+		previousHash = entry.Hash
+	}
+
+	return nil
+}
+
+func (l *Log) head() (*head, error) {
+	headBytes, err := l.store.Get(headKey)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &head{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get audit log head: %w", err)
+	}
+
+	var h head
+
+	err = json.Unmarshal(headBytes, &h)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal audit log head: %w", err)
+	}
+
+	return &h, nil
+}
+
+func (l *Log) entries() ([]*Entry, error) {
+	iter, err := l.store.Query(entryTagName)
+	if err != nil {
+		return nil, fmt.Errorf("query audit log: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var entries []*Entry
+
+	for {
+		ok, errNext := iter.Next()
+		if errNext != nil {
+			return nil, fmt.Errorf("iterate audit log: %w", errNext)
+		}
+
+		if !ok {
+			break
+		}
+
+		value, errValue := iter.Value()
+		if errValue != nil {
+			return nil, fmt.Errorf("get audit entry: %w", errValue)
+		}
+
+		var entry Entry
+
+		errValue = json.Unmarshal(value, &entry)
+		if errValue != nil {
+			return nil, fmt.Errorf("unmarshal audit entry: %w", errValue)
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Sequence < entries[j].Sequence
+	})
+
+	return entries, nil
+}
+
+func computeHash(entry *Entry) (string, error) {
+	unhashed := *entry
+	unhashed.Hash = ""
+
+	entryBytes, err := json.Marshal(&unhashed)
+	if err != nil {
+		return "", fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(entry.PreviousHash)) //nolint:errcheck // hash.Hash never returns an error
+	h.Write(entryBytes)                 //nolint:errcheck // hash.Hash never returns an error
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func entryKey(sequence uint64) string {
+	return fmt.Sprintf(entryKeyPattern, sequence)
+}
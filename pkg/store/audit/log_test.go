@@ -0,0 +1,292 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+const (
+	issuerDID   = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+	verifierDID = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+)
+
+type ed25519Signer struct {
+	privKey ed25519.PrivateKey
+}
+
+func (s *ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519Signer) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA", jose.HeaderKeyID: "key-1"}
+}
+
+func ed25519Verifier(pubKey ed25519.PublicKey) jose.SignatureVerifier {
+	return jose.SignatureVerifierFunc(func(_ jose.Headers, _, signingInput, signature []byte) error {
+		if !ed25519.Verify(pubKey, signingInput, signature) {
+			return errors.New("signature doesn't match")
+		}
+
+		return nil
+	})
+}
+
+func newTestLog(t *testing.T, storeProvider *mockstore.MockStoreProvider) *Log {
+	t.Helper()
+
+	now := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	l, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider}, WithClock(func() time.Time {
+		now = now.Add(time.Minute)
+
+		return now
+	}))
+	require.NoError(t, err)
+
+	return l
+}
+
+func appendTestEvents(t *testing.T, l *Log) {
+	t.Helper()
+
+	for _, event := range []*Event{
+		{Type: EventIssued, ActorDID: issuerDID, ObjectID: "urn:uuid:vc-1"},
+		{Type: EventStored, ActorDID: verifierDID, ObjectID: "urn:uuid:vc-1"},
+		{Type: EventVerified, ObjectID: "urn:uuid:vc-1", Details: map[string]string{"result": "valid"}},
+		{Type: EventDeleted, ActorDID: verifierDID, ObjectID: "urn:uuid:vc-1"},
+	} {
+		_, err := l.Append(event)
+		require.NoError(t, err)
+	}
+}
+
+func TestLog(t *testing.T) {
+	t.Run("test append and query", func(t *testing.T) {
+		l := newTestLog(t, mockstore.NewMockStoreProvider())
+
+		entries, err := l.Query(nil)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+		require.NoError(t, l.Verify())
+
+		appendTestEvents(t, l)
+
+		entries, err = l.Query(nil)
+		require.NoError(t, err)
+		require.Len(t, entries, 4)
+
+		for i, entry := range entries {
+			require.Equal(t, uint64(i+1), entry.Sequence)
+			require.NotEmpty(t, entry.Hash)
+
+			if i > 0 {
+				require.Equal(t, entries[i-1].Hash, entry.PreviousHash)
+			}
+		}
+
+		require.Empty(t, entries[0].PreviousHash)
+		require.Equal(t, "valid", entries[2].Details["result"])
+
+		entries, err = l.Query(&Filter{ActorDID: verifierDID})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, EventStored, entries[0].Type)
+		require.Equal(t, EventDeleted, entries[1].Type)
+
+		entries, err = l.Query(&Filter{Type: EventVerified, ObjectID: "urn:uuid:vc-1"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		entries, err = l.Query(&Filter{
+			From: time.Date(2021, time.March, 1, 0, 2, 0, 0, time.UTC),
+			To:   time.Date(2021, time.March, 1, 0, 4, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, uint64(2), entries[0].Sequence)
+
+		require.NoError(t, l.Verify())
+
+		_, err = l.Append(&Event{})
+		require.EqualError(t, err, "event type is mandatory")
+	})
+
+	t.Run("test log is reopened", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+		appendTestEvents(t, newTestLog(t, storeProvider))
+
+		l := newTestLog(t, storeProvider)
+
+		entry, err := l.Append(&Event{Type: EventPresented, ActorDID: verifierDID})
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), entry.Sequence)
+		require.NoError(t, l.Verify())
+	})
+
+	t.Run("test tampering is detected", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+		l := newTestLog(t, storeProvider)
+		appendTestEvents(t, l)
+
+		store := storeProvider.Store.Store
+		original := store[entryKey(2)]
+
+		var entry Entry
+		require.NoError(t, json.Unmarshal(original.Value, &entry))
+
+		entry.ActorDID = issuerDID
+		altered, err := json.Marshal(&entry)
+		require.NoError(t, err)
+
+		store[entryKey(2)] = mockstore.DBEntry{Value: altered, Tags: original.Tags}
+		require.EqualError(t, l.Verify(), "audit entry 2 was altered")
+
+		// a re-hashed entry breaks the chaining of the next entry
+		entry.Hash, err = computeHash(&entry)
+		require.NoError(t, err)
+
+		altered, err = json.Marshal(&entry)
+		require.NoError(t, err)
+
+		store[entryKey(2)] = mockstore.DBEntry{Value: altered, Tags: original.Tags}
+		require.EqualError(t, l.Verify(), "audit entry 3 is not chained to the previous entry")
+
+		store[entryKey(2)] = original
+		require.NoError(t, l.Verify())
+
+		delete(store, entryKey(3))
+		require.EqualError(t, l.Verify(), "audit entry 3 is missing")
+
+		delete(store, entryKey(4))
+		require.EqualError(t, l.Verify(), "audit log was truncated")
+
+		delete(store, entryKey(1))
+		require.EqualError(t, l.Verify(), "audit entry 1 is missing")
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}})
+		require.EqualError(t, err, "failed to open audit log store: open error")
+
+		l := newTestLog(t, mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+			Store:  make(map[string]mockstore.DBEntry),
+			ErrGet: errors.New("get error"),
+		}))
+
+		_, err = l.Append(&Event{Type: EventIssued})
+		require.EqualError(t, err, "get audit log head: get error")
+
+		l = newTestLog(t, mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+			Store:    make(map[string]mockstore.DBEntry),
+			ErrBatch: errors.New("batch error"),
+		}))
+
+		_, err = l.Append(&Event{Type: EventIssued})
+		require.EqualError(t, err, "append audit entry: batch error")
+
+		l = newTestLog(t, mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+			Store:    make(map[string]mockstore.DBEntry),
+			ErrQuery: errors.New("query error"),
+		}))
+
+		_, err = l.Query(nil)
+		require.EqualError(t, err, "query audit log: query error")
+		require.EqualError(t, l.Verify(), "query audit log: query error")
+	})
+}
+
+func TestBundle(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	l := newTestLog(t, mockstore.NewMockStoreProvider())
+	appendTestEvents(t, l)
+
+	t.Run("test export and parse", func(t *testing.T) {
+		bundleJWS, err := l.Export(&ed25519Signer{privKey: privKey}, 0, 0)
+		require.NoError(t, err)
+
+		bundle, err := ParseBundle(bundleJWS, ed25519Verifier(pubKey))
+		require.NoError(t, err)
+		require.Len(t, bundle.Entries, 4)
+		require.False(t, bundle.Created.IsZero())
+
+		bundleJWS, err = l.Export(&ed25519Signer{privKey: privKey}, 2, 3)
+		require.NoError(t, err)
+
+		bundle, err = ParseBundle(bundleJWS, ed25519Verifier(pubKey))
+		require.NoError(t, err)
+		require.Len(t, bundle.Entries, 2)
+		require.Equal(t, uint64(2), bundle.Entries[0].Sequence)
+		require.Equal(t, EventVerified, bundle.Entries[1].Type)
+	})
+
+	t.Run("test invalid bundles", func(t *testing.T) {
+		bundleJWS, err := l.Export(&ed25519Signer{privKey: privKey}, 0, 0)
+		require.NoError(t, err)
+
+		otherPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = ParseBundle(bundleJWS, ed25519Verifier(otherPubKey))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse audit log bundle")
+
+		// a bundle with altered entries signed by a (malicious) exporter
+		bundle, err := ParseBundle(bundleJWS, ed25519Verifier(pubKey))
+		require.NoError(t, err)
+
+		bundle.Entries[1].ObjectID = "urn:uuid:vc-2"
+
+		payload, err := json.Marshal(bundle)
+		require.NoError(t, err)
+
+		jws, err := jose.NewJWS(jose.Headers{jose.HeaderType: BundleType}, nil, payload, &ed25519Signer{privKey: privKey})
+		require.NoError(t, err)
+
+		altered, err := jws.SerializeCompact(false)
+		require.NoError(t, err)
+
+		_, err = ParseBundle(altered, ed25519Verifier(pubKey))
+		require.EqualError(t, err, "verify audit log bundle: audit entry 2 was altered")
+
+		jws, err = jose.NewJWS(jose.Headers{jose.HeaderType: "JWT"}, nil, []byte("{}"), &ed25519Signer{privKey: privKey})
+		require.NoError(t, err)
+
+		other, err := jws.SerializeCompact(false)
+		require.NoError(t, err)
+
+		_, err = ParseBundle(other, ed25519Verifier(pubKey))
+		require.EqualError(t, err, "not an audit log bundle")
+
+		jws, err = jose.NewJWS(jose.Headers{jose.HeaderType: BundleType}, nil, []byte("[]"), &ed25519Signer{privKey: privKey})
+		require.NoError(t, err)
+
+		other, err = jws.SerializeCompact(false)
+		require.NoError(t, err)
+
+		_, err = ParseBundle(other, ed25519Verifier(pubKey))
+		require.Error(t, err)
+		require.True(t, strings.HasPrefix(err.Error(), "unmarshal audit log bundle"))
+	})
+}
@@ -0,0 +1,293 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package challenge manages the single-use challenges issued by verifiers to be signed into the proofs of
+// presentations (see verifiable.WithPresChallengeConsumer), which prevents the replay of presentations
+// across presentation requests.
+//
+// The storage providers have no conditional delete: a challenge is consumed at most once within the agent
+// instance, and across the agent instances sharing the challenge store only if they share a locker
+// (see aries.WithLocker).
+package challenge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for challenge store.
+	NameSpace = "challenge"
+
+	challengeTagName = "challenge"
+	defaultExpiry    = 5 * time.Minute
+	lockTimeout      = time.Minute
+)
+
+var logger = log.New("aries-framework/store/challenge")
+
+var (
+	// ErrChallengeNotFound is returned when the challenge was not issued or was already consumed.
+	ErrChallengeNotFound = errors.New("challenge is unknown or already used")
+
+	// ErrChallengeExpired is returned when the challenge is consumed after its expiry.
+	ErrChallengeExpired = errors.New("challenge is expired")
+
+	// ErrDomainMismatch is returned when the challenge is consumed for another domain than the one it was issued for.
+	ErrDomainMismatch = errors.New("challenge was issued for another domain")
+)
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// lockerProvider is implemented by the providers of the agent instances sharing their stores
+// (e.g. aries.Context() with aries.WithLocker).
+type lockerProvider interface {
+	Locker() lock.Locker
+}
+
+// Challenge is a single-use challenge issued by the verifier.
+type Challenge struct {
+	// Value of the challenge, to be set as the challenge of the presentation proof.
+	Value string `json:"challenge"`
+	// Domain of the verifier, to be set as the domain of the presentation proof.
+	Domain string `json:"domain,omitempty"`
+	// Expires is the time after which the challenge cannot be consumed.
+	Expires time.Time `json:"expires"`
+}
+
+// Manager issues and consumes single-use challenges.
+type Manager struct {
+	store  storage.Store
+	expiry time.Duration
+	now    func() time.Time
+	lock   sync.Mutex
+	locker lock.Locker
+}
+
+// Option configures the challenge manager.
+type Option func(m *Manager)
+
+// WithExpiry sets the lifetime of the issued challenges, 5 minutes by default.
+func WithExpiry(expiry time.Duration) Option {
+	return func(m *Manager) {
+		m.expiry = expiry
+	}
+}
+
+// WithClock sets the clock of the challenge manager.
+func WithClock(now func() time.Time) Option {
+	return func(m *Manager) {
+		m.now = now
+	}
+}
+
+// New returns a new challenge manager.
+func New(ctx provider, opts ...Option) (*Manager, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open challenge store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace,
+		storage.StoreConfiguration{TagNames: []string{challengeTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	m := &Manager{store: store, expiry: defaultExpiry, now: time.Now}
+
+	if lp, ok := ctx.(lockerProvider); ok {
+		m.locker = lp.Locker()
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m, nil
+}
+
+// Issue issues a new challenge for the domain of the verifier (optional).
+func (m *Manager) Issue(domain string) (*Challenge, error) {
+	c := &Challenge{
+		Value:   uuid.New().String(),
+		Domain:  domain,
+		Expires: m.now().Add(m.expiry).UTC(),
+	}
+
+	challengeBytes, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("marshal challenge: %w", err)
+	}
+
+	err = m.store.Put(c.Value, challengeBytes, storage.Tag{Name: challengeTagName})
+	if err != nil {
+		return nil, fmt.Errorf("save challenge: %w", err)
+	}
+
+	return c, nil
+}
+
+// Check validates the challenge issued for the domain without consuming it.
+func (m *Manager) Check(challenge, domain string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	challengeBytes, err := m.get(challenge)
+	if err != nil {
+		return err
+	}
+
+	return m.validate(challengeBytes, domain)
+}
+
+// Consume validates the challenge issued for the domain and consumes it, so that it cannot be used again.
+// The challenge is also locked with the locker of the provider if any, so that the agent instances sharing
+// the challenge store do not consume it concurrently.
+func (m *Manager) Consume(challenge, domain string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	unlock, err := m.lockChallenge(challenge)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	challengeBytes, err := m.get(challenge)
+	if err != nil {
+		return err
+	}
+
+	err = m.store.Delete(challenge)
+	if err != nil {
+		return fmt.Errorf("delete challenge: %w", err)
+	}
+
+	return m.validate(challengeBytes, domain)
+}
+
+func (m *Manager) lockChallenge(challenge string) (func(), error) {
+	if m.locker == nil {
+		return func() {}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+
+	unlocker, err := m.locker.Lock(ctx, NameSpace+":"+challenge)
+	if err != nil {
+		return nil, fmt.Errorf("lock challenge: %w", err)
+	}
+
+	return func() {
+		if errUnlock := unlocker.Unlock(); errUnlock != nil {
+			logger.Warnf("failed to unlock challenge: %s", errUnlock)
+		}
+	}, nil
+}
+
+func (m *Manager) get(challenge string) ([]byte, error) {
+	challengeBytes, err := m.store.Get(challenge)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrChallengeNotFound
+		}
+
+		return nil, fmt.Errorf("get challenge: %w", err)
+	}
+
+	return challengeBytes, nil
+}
+
+func (m *Manager) validate(challengeBytes []byte, domain string) error {
+	var c Challenge
+
+	err := json.Unmarshal(challengeBytes, &c)
+	if err != nil {
+		return fmt.Errorf("unmarshal challenge: %w", err)
+	}
+
+	if m.now().After(c.Expires) {
+		return ErrChallengeExpired
+	}
+
+	if c.Domain != "" && c.Domain != domain {
+		return ErrDomainMismatch
+	}
+
+	return nil
+}
+
+// PurgeExpired deletes the expired challenges which were never consumed.
+func (m *Manager) PurgeExpired() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	iter, err := m.store.Query(challengeTagName)
+	if err != nil {
+		return fmt.Errorf("query challenges: %w", err)
+	}
+
+	defer func() {
+		errClose := iter.Close()
+		if errClose != nil {
+			logger.Warnf("failed to close iterator: %s", errClose)
+		}
+	}()
+
+	var expired []string
+
+	more, err := iter.Next()
+	if err != nil {
+		return fmt.Errorf("query challenges: %w", err)
+	}
+
+	for more {
+		value, errValue := iter.Value()
+		if errValue != nil {
+			return fmt.Errorf("query challenges: %w", errValue)
+		}
+
+		var c Challenge
+
+		if json.Unmarshal(value, &c) != nil || m.now().After(c.Expires) {
+			key, errKey := iter.Key()
+			if errKey != nil {
+				return fmt.Errorf("query challenges: %w", errKey)
+			}
+
+			expired = append(expired, key)
+		}
+
+		more, err = iter.Next()
+		if err != nil {
+			return fmt.Errorf("query challenges: %w", err)
+		}
+	}
+
+	for _, key := range expired {
+		err = m.store.Delete(key)
+		if err != nil {
+			return fmt.Errorf("delete challenge: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package challenge
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mocklocker "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+const verifierDomain = "verifier.example.com"
+
+func newTestManager(t *testing.T, storeProvider *mockstore.MockStoreProvider, now *time.Time) *Manager {
+	t.Helper()
+
+	m, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider}, WithExpiry(time.Minute),
+		WithClock(func() time.Time {
+			return *now
+		}))
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestManager(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("test issue and consume", func(t *testing.T) {
+		m := newTestManager(t, mockstore.NewMockStoreProvider(), &now)
+
+		c, err := m.Issue(verifierDomain)
+		require.NoError(t, err)
+		require.NotEmpty(t, c.Value)
+		require.Equal(t, verifierDomain, c.Domain)
+		require.Equal(t, now.Add(time.Minute), c.Expires)
+
+		other, err := m.Issue("")
+		require.NoError(t, err)
+		require.NotEqual(t, c.Value, other.Value)
+
+		require.NoError(t, m.Consume(c.Value, verifierDomain))
+		require.ErrorIs(t, m.Consume(c.Value, verifierDomain), ErrChallengeNotFound)
+
+		// the challenge issued without domain is accepted for any domain
+		require.NoError(t, m.Consume(other.Value, "other.example.com"))
+
+		require.ErrorIs(t, m.Consume("unknown", verifierDomain), ErrChallengeNotFound)
+	})
+
+	t.Run("test check", func(t *testing.T) {
+		clock := now
+		m := newTestManager(t, mockstore.NewMockStoreProvider(), &clock)
+
+		c, err := m.Issue(verifierDomain)
+		require.NoError(t, err)
+
+		require.NoError(t, m.Check(c.Value, verifierDomain))
+		require.ErrorIs(t, m.Check(c.Value, "other.example.com"), ErrDomainMismatch)
+		require.ErrorIs(t, m.Check("unknown", verifierDomain), ErrChallengeNotFound)
+
+		// the checked challenge is not consumed
+		require.NoError(t, m.Consume(c.Value, verifierDomain))
+		require.ErrorIs(t, m.Check(c.Value, verifierDomain), ErrChallengeNotFound)
+
+		c, err = m.Issue(verifierDomain)
+		require.NoError(t, err)
+
+		clock = clock.Add(2 * time.Minute)
+
+		require.ErrorIs(t, m.Check(c.Value, verifierDomain), ErrChallengeExpired)
+	})
+
+	t.Run("test invalid challenges are consumed", func(t *testing.T) {
+		clock := now
+		m := newTestManager(t, mockstore.NewMockStoreProvider(), &clock)
+
+		c, err := m.Issue(verifierDomain)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, m.Consume(c.Value, "other.example.com"), ErrDomainMismatch)
+		require.ErrorIs(t, m.Consume(c.Value, verifierDomain), ErrChallengeNotFound)
+
+		c, err = m.Issue(verifierDomain)
+		require.NoError(t, err)
+
+		clock = clock.Add(2 * time.Minute)
+
+		require.ErrorIs(t, m.Consume(c.Value, verifierDomain), ErrChallengeExpired)
+		require.ErrorIs(t, m.Consume(c.Value, verifierDomain), ErrChallengeNotFound)
+	})
+
+	t.Run("test purge expired", func(t *testing.T) {
+		clock := now
+		storeProvider := mockstore.NewMockStoreProvider()
+		m := newTestManager(t, storeProvider, &clock)
+
+		expired, err := m.Issue(verifierDomain)
+		require.NoError(t, err)
+
+		clock = clock.Add(2 * time.Minute)
+
+		valid, err := m.Issue(verifierDomain)
+		require.NoError(t, err)
+
+		require.NoError(t, m.PurgeExpired())
+		require.Len(t, storeProvider.Store.Store, 1)
+
+		require.ErrorIs(t, m.Consume(expired.Value, verifierDomain), ErrChallengeNotFound)
+		require.NoError(t, m.Consume(valid.Value, verifierDomain))
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}})
+		require.EqualError(t, err, "failed to open challenge store: open error")
+
+		m := newTestManager(t, mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+			Store:    make(map[string]mockstore.DBEntry),
+			ErrPut:   errors.New("put error"),
+			ErrGet:   errors.New("get error"),
+			ErrQuery: errors.New("query error"),
+		}), &now)
+
+		_, err = m.Issue(verifierDomain)
+		require.EqualError(t, err, "save challenge: put error")
+
+		err = m.Consume("challenge", verifierDomain)
+		require.EqualError(t, err, "get challenge: get error")
+
+		err = m.Check("challenge", verifierDomain)
+		require.EqualError(t, err, "get challenge: get error")
+
+		err = m.PurgeExpired()
+		require.EqualError(t, err, "query challenges: query error")
+	})
+
+	t.Run("test shared locker", func(t *testing.T) {
+		locker := &mocklocker.MockLocker{}
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		m, err := New(&mockLockerProvider{
+			Provider: &mockprovider.Provider{StorageProviderValue: storeProvider},
+			locker:   locker,
+		})
+		require.NoError(t, err)
+
+		c, err := m.Issue(verifierDomain)
+		require.NoError(t, err)
+
+		require.NoError(t, m.Consume(c.Value, verifierDomain))
+		require.Equal(t, []string{NameSpace + ":" + c.Value}, locker.Acquired)
+
+		locker.LockErr = errors.New("lock error")
+
+		c, err = m.Issue(verifierDomain)
+		require.NoError(t, err)
+
+		require.EqualError(t, m.Consume(c.Value, verifierDomain), "lock challenge: lock error")
+		require.NoError(t, m.Check(c.Value, verifierDomain))
+	})
+}
+
+type mockLockerProvider struct {
+	*mockprovider.Provider
+	locker lock.Locker
+}
+
+func (p *mockLockerProvider) Locker() lock.Locker {
+	return p.locker
+}
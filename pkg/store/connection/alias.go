@@ -0,0 +1,49 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"fmt"
+	"strings"
+)
+
+const invAliasKeyPrefix = "invalias"
+
+// SaveInvitationAlias saves the alias given by the user to the connections created for the invitation.
+func (c *Recorder) SaveInvitationAlias(invitationID, alias string) error {
+	if invitationID == "" {
+		return fmt.Errorf(errMsgInvalidKey)
+	}
+
+	if err := c.store.Put(getInvitationAliasKeyPrefix()(invitationID), []byte(alias)); err != nil {
+		return fmt.Errorf("save invitation alias: %w", err)
+	}
+
+	return nil
+}
+
+// GetInvitationAlias returns the alias of the connections created for the invitation
+// (storage.ErrDataNotFound if none).
+func (c *Lookup) GetInvitationAlias(invitationID string) (string, error) {
+	if invitationID == "" {
+		return "", fmt.Errorf(errMsgInvalidKey)
+	}
+
+	alias, err := c.store.Get(getInvitationAliasKeyPrefix()(invitationID))
+	if err != nil {
+		return "", fmt.Errorf("get invitation alias: %w", err)
+	}
+
+	return string(alias), nil
+}
+
+// getInvitationAliasKeyPrefix key prefix for saving invitation aliases.
+func getInvitationAliasKeyPrefix() KeyPrefix {
+	return func(key ...string) string {
+		return fmt.Sprintf(keyPattern, invAliasKeyPrefix, strings.Join(key, keySeparator))
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestRecorder_InvitationAlias(t *testing.T) {
+	t.Run("save and get invitation alias", func(t *testing.T) {
+		recorder, err := NewRecorder(&protocol.MockProvider{})
+		require.NoError(t, err)
+
+		_, err = recorder.GetInvitationAlias("inv1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, recorder.SaveInvitationAlias("inv1", "Bob"))
+
+		alias, err := recorder.GetInvitationAlias("inv1")
+		require.NoError(t, err)
+		require.Equal(t, "Bob", alias)
+	})
+
+	t.Run("invalid invitation ID", func(t *testing.T) {
+		recorder, err := NewRecorder(&protocol.MockProvider{})
+		require.NoError(t, err)
+
+		require.EqualError(t, recorder.SaveInvitationAlias("", "Bob"), errMsgInvalidKey)
+
+		_, err = recorder.GetInvitationAlias("")
+		require.EqualError(t, err, errMsgInvalidKey)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		recorder, err := NewRecorder(&protocol.MockProvider{
+			StoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+				Store:  make(map[string]mockstorage.DBEntry),
+				ErrPut: errors.New("put error"),
+			}),
+		})
+		require.NoError(t, err)
+
+		err = recorder.SaveInvitationAlias("inv1", "Bob")
+		require.Contains(t, err.Error(), "put error")
+	})
+}
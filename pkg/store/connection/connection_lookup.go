@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -59,6 +60,24 @@ type Record struct {
 	Implicit        bool
 	Namespace       string
 	MediaTypes      []string
+	// DIDCommVersion is the DIDComm version (DIDCommV1 or DIDCommV2) of the messages received from the other agent.
+	DIDCommVersion string `json:",omitempty"`
+	// MediaTypeProfile is the media type profile (e.g. didcomm/v2) negotiated from the accept list of the other agent.
+	MediaTypeProfile string `json:",omitempty"`
+	// Protocols are the protocol identifiers (e.g. https://didcomm.org/present-proof/2.0) supported by the other agent.
+	Protocols []string `json:",omitempty"`
+	// Metadata is the application metadata of the connection (see Recorder.SaveMetadata).
+	Metadata *Metadata `json:",omitempty"`
+	// Alias is the name given to the connection by the user (see Recorder.SaveInvitationAlias).
+	Alias string `json:",omitempty"`
+	// GoalCode and Goal are the goal of the out-of-band invitation of the connection.
+	GoalCode string `json:",omitempty"`
+	Goal     string `json:",omitempty"`
+	// InvitationKeys are the recipient keys of the invitation of the connection.
+	InvitationKeys []string `json:",omitempty"`
+	// CreatedAt and UpdatedAt are set by Recorder.SaveConnectionRecord.
+	CreatedAt time.Time `json:",omitempty"`
+	UpdatedAt time.Time `json:",omitempty"`
 }
 
 // NewLookup returns new connection lookup instance.
@@ -110,6 +129,10 @@ func (c *Lookup) GetConnectionRecord(connectionID string) (*Record, error) {
 		}
 	}
 
+	if err = c.attachMetadata(&rec); err != nil {
+		return nil, err
+	}
+
 	return &rec, nil
 }
 
@@ -130,6 +153,10 @@ func (c *Lookup) QueryConnectionRecords() ([]*Record, error) {
 			"from the protocol state store: %w", err)
 	}
 
+	if err = c.attachMetadata(allRecords...); err != nil {
+		return nil, err
+	}
+
 	return allRecords, nil
 }
 
@@ -168,6 +195,10 @@ func (c *Lookup) GetConnectionRecordAtState(connectionID, stateID string) (*Reco
 		return nil, fmt.Errorf("faild to get connection record by state : %s, cause : %w", stateID, err)
 	}
 
+	if err = c.attachMetadata(&rec); err != nil {
+		return nil, err
+	}
+
 	return &rec, nil
 }
 
@@ -185,6 +216,10 @@ func (c *Lookup) GetConnectionRecordByNSThreadID(nsThreadID string) (*Record, er
 		return nil, fmt.Errorf("faild to get connection record by NS thread ID : %s, cause : %w", nsThreadID, err)
 	}
 
+	if err = c.attachMetadata(&rec); err != nil {
+		return nil, err
+	}
+
 	return &rec, nil
 }
 
@@ -14,6 +14,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -58,7 +59,16 @@ func (c *Recorder) SaveInvitation(id string, invitation interface{}) error {
 }
 
 // SaveConnectionRecord saves given connection records in underlying store.
+// It sets the creation time of the new records and the update time of the records.
 func (c *Recorder) SaveConnectionRecord(record *Record) error {
+	now := time.Now().UTC()
+
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = now
+	}
+
+	record.UpdatedAt = now
+
 	if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
 		record, c.protocolStateStore, storage.Tag{
 			Name:  getConnectionKeyPrefix()(""),
@@ -178,6 +188,10 @@ func (c *Recorder) RemoveConnection(connectionID string) error {
 		return fmt.Errorf("unable to delete connection record with namespace mappings: %w", err)
 	}
 
+	if err = c.removeMetadata(connectionID); err != nil {
+		return fmt.Errorf("unable to delete connection metadata: connectionid=%s err=%w", connectionID, err)
+	}
+
 	return nil
 }
 
@@ -601,6 +601,29 @@ func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 	})
 }
 
+func TestConnectionRecorder_SaveConnectionRecordTimes(t *testing.T) {
+	recorder, err := NewRecorder(&protocol.MockProvider{})
+	require.NoError(t, err)
+
+	record := &Record{
+		ThreadID:     threadIDValue,
+		ConnectionID: uuid.New().String(), State: stateNameInvited, Namespace: TheirNSPrefix,
+	}
+	require.NoError(t, recorder.SaveConnectionRecord(record))
+	require.False(t, record.CreatedAt.IsZero())
+	require.Equal(t, record.CreatedAt, record.UpdatedAt)
+
+	createdAt := record.CreatedAt
+
+	record.State = StateNameCompleted
+	require.NoError(t, recorder.SaveConnectionRecord(record))
+
+	recordFound, err := recorder.GetConnectionRecord(record.ConnectionID)
+	require.NoError(t, err)
+	require.Equal(t, createdAt, recordFound.CreatedAt)
+	require.False(t, recordFound.UpdatedAt.Before(createdAt))
+}
+
 func TestConnectionRecorder_ConnectionRecordMappings(t *testing.T) {
 	t.Run("get connection record by namespace threadID in my namespace", func(t *testing.T) {
 		recorder, err := NewRecorder(&protocol.MockProvider{})
@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const metadataKeyPrefix = "connmeta"
+
+// Metadata is the application metadata of the connection. It is not exchanged with the other agent.
+type Metadata struct {
+	// DisplayName of the other agent, chosen by the user.
+	DisplayName string `json:"displayName,omitempty"`
+	// AvatarURL is the URL of the image shown for the connection.
+	AvatarURL string `json:"avatarURL,omitempty"`
+	// TrustLevel given by the user to the other agent.
+	TrustLevel string `json:"trustLevel,omitempty"`
+	// Tags of the connection.
+	Tags []string `json:"tags,omitempty"`
+	// Properties are arbitrary application data.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SaveMetadata sets the application metadata of the connection, the previous metadata is replaced.
+func (c *Recorder) SaveMetadata(connectionID string, metadata *Metadata) error {
+	if _, err := c.GetConnectionRecord(connectionID); err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	if err := marshalAndSave(getMetadataKeyPrefix()(connectionID), metadata, c.store); err != nil {
+		return fmt.Errorf("save connection metadata: %w", err)
+	}
+
+	return nil
+}
+
+// GetMetadata returns the application metadata of the connection (storage.ErrDataNotFound if none).
+func (c *Lookup) GetMetadata(connectionID string) (*Metadata, error) {
+	var metadata Metadata
+
+	if err := getAndUnmarshal(getMetadataKeyPrefix()(connectionID), &metadata, c.store); err != nil {
+		return nil, fmt.Errorf("get connection metadata: %w", err)
+	}
+
+	return &metadata, nil
+}
+
+// removeMetadata removes the application metadata of the connection, if any.
+func (c *Recorder) removeMetadata(connectionID string) error {
+	err := c.store.Delete(getMetadataKeyPrefix()(connectionID))
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// attachMetadata sets the application metadata of the records (the metadata is stored apart from the records
+// so that it is not overwritten by the protocols updating the records).
+func (c *Lookup) attachMetadata(records ...*Record) error {
+	for _, rec := range records {
+		metadata, err := c.GetMetadata(rec.ConnectionID)
+		if errors.Is(err, storage.ErrDataNotFound) {
+			rec.Metadata = nil
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		rec.Metadata = metadata
+	}
+
+	return nil
+}
+
+// getMetadataKeyPrefix key prefix for saving connection metadata.
+func getMetadataKeyPrefix() KeyPrefix {
+	return func(key ...string) string {
+		return fmt.Sprintf(keyPattern, metadataKeyPrefix, strings.Join(key, keySeparator))
+	}
+}
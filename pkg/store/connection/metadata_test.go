@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestRecorder_Metadata(t *testing.T) {
+	recorder, err := NewRecorder(&protocol.MockProvider{})
+	require.NoError(t, err)
+
+	record := &Record{
+		ConnectionID: "conn1",
+		ThreadID:     "thid1",
+		State:        StateNameCompleted,
+		Namespace:    MyNSPrefix,
+	}
+	require.NoError(t, recorder.SaveConnectionRecordWithMappings(record))
+
+	metadata := &Metadata{DisplayName: "Bob", TrustLevel: "low", Tags: []string{"work"}}
+
+	t.Run("save and get metadata", func(t *testing.T) {
+		_, err = recorder.GetMetadata("conn1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, recorder.SaveMetadata("conn1", metadata))
+
+		result, err := recorder.GetMetadata("conn1")
+		require.NoError(t, err)
+		require.Equal(t, metadata, result)
+	})
+
+	t.Run("metadata is returned by the connection queries", func(t *testing.T) {
+		rec, err := recorder.GetConnectionRecord("conn1")
+		require.NoError(t, err)
+		require.Equal(t, metadata, rec.Metadata)
+
+		records, err := recorder.QueryConnectionRecords()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, metadata, records[0].Metadata)
+
+		nsThID, err := CreateNamespaceKey(MyNSPrefix, "thid1")
+		require.NoError(t, err)
+
+		rec, err = recorder.GetConnectionRecordByNSThreadID(nsThID)
+		require.NoError(t, err)
+		require.Equal(t, metadata, rec.Metadata)
+	})
+
+	t.Run("metadata survives the connection record updates", func(t *testing.T) {
+		rec, err := recorder.GetConnectionRecord("conn1")
+		require.NoError(t, err)
+
+		rec.MyDID = "did:example:123"
+		require.NoError(t, recorder.SaveConnectionRecord(rec))
+
+		rec, err = recorder.GetConnectionRecord("conn1")
+		require.NoError(t, err)
+		require.Equal(t, metadata, rec.Metadata)
+	})
+
+	t.Run("metadata of unknown connection", func(t *testing.T) {
+		err = recorder.SaveMetadata("unknown", metadata)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("metadata is removed with the connection", func(t *testing.T) {
+		require.NoError(t, recorder.RemoveConnection("conn1"))
+
+		_, err = recorder.GetMetadata("conn1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
+
+func TestRecorder_MetadataErrors(t *testing.T) {
+	store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+	recorder, err := NewRecorder(&protocol.MockProvider{
+		StoreProvider: mockstorage.NewCustomMockStoreProvider(store),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&Record{
+		ConnectionID: "conn1",
+		ThreadID:     "thid1",
+		State:        StateNameCompleted,
+	}))
+
+	t.Run("save error", func(t *testing.T) {
+		store.ErrPut = errors.New("put error")
+		defer func() { store.ErrPut = nil }()
+
+		err = recorder.SaveMetadata("conn1", &Metadata{})
+		require.EqualError(t, err, "save connection metadata: put error")
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		store.ErrDelete = errors.New("delete error")
+		defer func() { store.ErrDelete = nil }()
+
+		err = recorder.RemoveConnection("conn1")
+		require.Contains(t, err.Error(), "delete error")
+	})
+}
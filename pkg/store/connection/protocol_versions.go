@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// DIDCommV1 is the DIDComm version of the messages packed as per Aries RFC 0019.
+	DIDCommV1 = "v1"
+	// DIDCommV2 is the DIDComm version of the messages packed as per the DIF DIDComm spec.
+	DIDCommV2 = "v2"
+)
+
+// ErrNoCommonVersion is returned when the agents do not support any common version of the protocol.
+var ErrNoCommonVersion = errors.New("no mutually supported protocol version")
+
+// DIDCommVersionFor returns the DIDComm version of the envelope media type (empty if unknown).
+func DIDCommVersionFor(mediaType string) string {
+	switch {
+	case mediaType == transport.MediaTypeV1EncryptedEnvelope, mediaType == transport.MediaTypeV1PlaintextPayload:
+		return DIDCommV1
+	case strings.HasPrefix(mediaType, transport.MediaTypeV2EncryptedEnvelope),
+		mediaType == transport.MediaTypeV2SignedMessage:
+		return DIDCommV2
+	}
+
+	return ""
+}
+
+// ProtocolURI returns the protocol identifier of the message type,
+// e.g. https://didcomm.org/present-proof/2.0 for https://didcomm.org/present-proof/2.0/request-presentation.
+func ProtocolURI(msgType string) string {
+	i := strings.LastIndex(msgType, "/")
+	if i < 0 {
+		return ""
+	}
+
+	return msgType[:i]
+}
+
+// SaveSupportedProtocols adds the DIDComm version and the protocols supported by the other agent (e.g. disclosed
+// by discover-features or learned from the received messages) to the connection record.
+// The record is saved only if it has changed.
+func (c *Recorder) SaveSupportedProtocols(connectionID, didcommVersion string, protocols ...string) error {
+	rec, err := c.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	changed := false
+
+	if didcommVersion != "" && rec.DIDCommVersion != didcommVersion {
+		rec.DIDCommVersion = didcommVersion
+		changed = true
+	}
+
+	for _, protocol := range protocols {
+		if protocol != "" && !contains(rec.Protocols, protocol) {
+			rec.Protocols = append(rec.Protocols, protocol)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return c.SaveConnectionRecord(rec)
+}
+
+// TrackInbound records the DIDComm version of the envelope and the protocol of the message received
+// from the other agent of the connection. Messages received outside of a connection are ignored.
+func (c *Recorder) TrackInbound(msgType, mediaType, myDID, theirDID string) error {
+	connectionID, err := c.GetConnectionIDByDIDs(myDID, theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return c.SaveSupportedProtocols(connectionID, DIDCommVersionFor(mediaType), ProtocolURI(msgType))
+}
+
+// SelectProtocolVersion returns the highest version among the protocol identifiers supported by the agent (ours)
+// that is also supported by the other agent of the connection. Protocol identifiers are compared as per Aries RFC
+// 0003: with the same major version, the lower minor version is used. If the other agent's support of the protocol
+// is unknown, the highest version supported by the agent is returned.
+func (c *Lookup) SelectProtocolVersion(connectionID string, ours ...string) (string, error) {
+	rec, err := c.GetConnectionRecord(connectionID)
+	if err != nil {
+		return "", fmt.Errorf("get connection record: %w", err)
+	}
+
+	return SelectProtocolVersion(rec.Protocols, ours...)
+}
+
+// SelectProtocolVersion returns the highest mutually supported version of the protocol (see Lookup).
+func SelectProtocolVersion(theirs []string, ours ...string) (string, error) {
+	var (
+		selected *piuri
+		known    bool
+	)
+
+	for _, o := range ours {
+		our, err := parsePIURI(o)
+		if err != nil {
+			return "", err
+		}
+
+		for _, t := range theirs {
+			their, err := parsePIURI(t)
+			if err != nil || their.family != our.family {
+				continue
+			}
+
+			known = true
+
+			if their.major != our.major {
+				continue
+			}
+
+			candidate := our
+			if their.minor < our.minor {
+				candidate = their
+			}
+
+			if selected == nil || candidate.higherThan(selected) {
+				selected = candidate
+			}
+		}
+	}
+
+	if selected != nil {
+		return selected.String(), nil
+	}
+
+	if known {
+		return "", ErrNoCommonVersion
+	}
+
+	// the support of the other agent is unknown
+	for _, o := range ours {
+		our, _ := parsePIURI(o) // nolint: errcheck
+		if selected == nil || our.higherThan(selected) {
+			selected = our
+		}
+	}
+
+	if selected == nil {
+		return "", errors.New("no protocol versions")
+	}
+
+	return selected.String(), nil
+}
+
+// piuri is the protocol identifier, e.g. https://didcomm.org/present-proof/2.0.
+type piuri struct {
+	family string
+	major  int
+	minor  int
+}
+
+func parsePIURI(id string) (*piuri, error) {
+	i := strings.LastIndex(id, "/")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid protocol identifier: %s", id)
+	}
+
+	version := strings.SplitN(id[i+1:], ".", 2)
+	if len(version) != 2 { // nolint: gomnd
+		return nil, fmt.Errorf("invalid protocol version: %s", id)
+	}
+
+	major, err := strconv.Atoi(version[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid protocol major version: %s", id)
+	}
+
+	minor, err := strconv.Atoi(version[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid protocol minor version: %s", id)
+	}
+
+	return &piuri{family: id[:i], major: major, minor: minor}, nil
+}
+
+func (p *piuri) higherThan(other *piuri) bool {
+	if p.major != other.major {
+		return p.major > other.major
+	}
+
+	return p.minor > other.minor
+}
+
+func (p *piuri) String() string {
+	return fmt.Sprintf("%s/%d.%d", p.family, p.major, p.minor)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	presentProofV20 = "https://didcomm.org/present-proof/2.0"
+	presentProofV21 = "https://didcomm.org/present-proof/2.1"
+	presentProofV30 = "https://didcomm.org/present-proof/3.0"
+)
+
+func TestDIDCommVersionFor(t *testing.T) {
+	require.Equal(t, DIDCommV1, DIDCommVersionFor(transport.MediaTypeV1EncryptedEnvelope))
+	require.Equal(t, DIDCommV1, DIDCommVersionFor(transport.MediaTypeV1PlaintextPayload))
+	require.Equal(t, DIDCommV2, DIDCommVersionFor(transport.MediaTypeV2EncryptedEnvelope))
+	require.Equal(t, DIDCommV2, DIDCommVersionFor(transport.MediaTypeV2EncryptedEnvelopeV1PlaintextPayload))
+	require.Equal(t, DIDCommV2, DIDCommVersionFor(transport.MediaTypeV2SignedMessage))
+	require.Empty(t, DIDCommVersionFor("application/json"))
+}
+
+func TestProtocolURI(t *testing.T) {
+	require.Equal(t, presentProofV20, ProtocolURI(presentProofV20+"/request-presentation"))
+	require.Empty(t, ProtocolURI("unknown"))
+}
+
+func TestSelectProtocolVersion(t *testing.T) {
+	t.Run("highest mutually supported version", func(t *testing.T) {
+		v, err := SelectProtocolVersion([]string{presentProofV20, presentProofV21}, presentProofV20, presentProofV21)
+		require.NoError(t, err)
+		require.Equal(t, presentProofV21, v)
+
+		v, err = SelectProtocolVersion([]string{presentProofV20, presentProofV30}, presentProofV21)
+		require.NoError(t, err)
+		require.Equal(t, presentProofV20, v)
+	})
+
+	t.Run("unknown support of the other agent", func(t *testing.T) {
+		v, err := SelectProtocolVersion([]string{"https://didcomm.org/issue-credential/2.0"},
+			presentProofV20, presentProofV30, presentProofV21)
+		require.NoError(t, err)
+		require.Equal(t, presentProofV30, v)
+	})
+
+	t.Run("no common version", func(t *testing.T) {
+		_, err := SelectProtocolVersion([]string{presentProofV30}, presentProofV20, presentProofV21)
+		require.True(t, errors.Is(err, ErrNoCommonVersion))
+	})
+
+	t.Run("invalid protocol identifiers", func(t *testing.T) {
+		_, err := SelectProtocolVersion(nil)
+		require.EqualError(t, err, "no protocol versions")
+
+		_, err = SelectProtocolVersion(nil, "present-proof")
+		require.EqualError(t, err, "invalid protocol identifier: present-proof")
+
+		_, err = SelectProtocolVersion(nil, "https://didcomm.org/present-proof/2")
+		require.EqualError(t, err, "invalid protocol version: https://didcomm.org/present-proof/2")
+
+		_, err = SelectProtocolVersion(nil, "https://didcomm.org/present-proof/a.0")
+		require.EqualError(t, err, "invalid protocol major version: https://didcomm.org/present-proof/a.0")
+
+		_, err = SelectProtocolVersion(nil, "https://didcomm.org/present-proof/2.a")
+		require.EqualError(t, err, "invalid protocol minor version: https://didcomm.org/present-proof/2.a")
+	})
+}
+
+func TestRecorder_TrackInbound(t *testing.T) {
+	recorder, err := NewRecorder(&protocol.MockProvider{})
+	require.NoError(t, err)
+
+	record := &Record{
+		ConnectionID: uuid.New().String(),
+		State:        StateNameCompleted,
+		MyDID:        "did:example:alice",
+		TheirDID:     "did:example:bob",
+	}
+	require.NoError(t, recorder.SaveConnectionRecord(record))
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, recorder.TrackInbound(presentProofV20+"/request-presentation",
+			transport.MediaTypeV2EncryptedEnvelope, record.MyDID, record.TheirDID))
+		require.NoError(t, recorder.TrackInbound(presentProofV20+"/presentation",
+			transport.MediaTypeV2EncryptedEnvelope, record.MyDID, record.TheirDID))
+
+		rec, err := recorder.GetConnectionRecord(record.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, DIDCommV2, rec.DIDCommVersion)
+		require.Equal(t, []string{presentProofV20}, rec.Protocols)
+
+		require.NoError(t, recorder.SaveSupportedProtocols(record.ConnectionID, "", presentProofV21))
+
+		v, err := recorder.SelectProtocolVersion(record.ConnectionID, presentProofV21, presentProofV30)
+		require.NoError(t, err)
+		require.Equal(t, presentProofV21, v)
+	})
+
+	t.Run("message received outside of a connection", func(t *testing.T) {
+		require.NoError(t, recorder.TrackInbound(presentProofV20+"/request-presentation",
+			transport.MediaTypeV2EncryptedEnvelope, "did:example:carol", record.TheirDID))
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		err := recorder.SaveSupportedProtocols("unknown", DIDCommV1)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = recorder.SelectProtocolVersion("unknown", presentProofV20)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
@@ -0,0 +1,258 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package history records the events of the credential exchanges (offer, request, issuance, presentation,
+// verification and revocation of the credentials) to query the timeline of a credential or of a connection.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for credential history store.
+	NameSpace = "credentialhistory"
+
+	eventKeyPattern = "historyevent_%s"
+	eventTagName    = "historyevent"
+)
+
+var logger = log.New("aries-framework/store/history")
+
+// EventType is the type of the credential exchange event.
+type EventType string
+
+const (
+	// EventOffered is the event of a credential offer, sent or received.
+	EventOffered EventType = "offered"
+	// EventRequested is the event of a credential or presentation request, sent or received.
+	EventRequested EventType = "requested"
+	// EventIssued is the event of a credential issuance, the credentials were sent or received.
+	EventIssued EventType = "issued"
+	// EventPresented is the event of a presentation sent to a verifier.
+	EventPresented EventType = "presented"
+	// EventVerified is the event of a presentation received and verified.
+	EventVerified EventType = "verified"
+	// EventRevoked is the event of a stored credential found revoked by its issuer.
+	EventRevoked EventType = "revoked"
+)
+
+// Event is an event of a credential exchange.
+type Event struct {
+	// ID of the event, generated when the event is recorded.
+	ID string `json:"id"`
+	// Type of the event.
+	Type EventType `json:"type"`
+	// Timestamp of the event, set when the event is recorded.
+	Timestamp time.Time `json:"timestamp"`
+	// Protocol of the exchange (e.g. issue-credential), empty for the events outside of an exchange.
+	Protocol string `json:"protocol,omitempty"`
+	// PIID is the ID of the protocol instance of the exchange.
+	PIID string `json:"piid,omitempty"`
+	// ConnectionID is the ID of the connection of the exchange, resolved from the DIDs if not set.
+	ConnectionID string `json:"connectionID,omitempty"`
+	// MyDID is the DID of the agent in the exchange.
+	MyDID string `json:"myDID,omitempty"`
+	// TheirDID is the DID of the other agent in the exchange.
+	TheirDID string `json:"theirDID,omitempty"`
+	// CredentialIDs are the IDs of the credentials of the event, if known.
+	CredentialIDs []string `json:"credentialIDs,omitempty"`
+	// Details of the event (e.g. the names of the stored credentials).
+	Details map[string]string `json:"details,omitempty"`
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Option configures the history store.
+type Option func(s *Store)
+
+// WithClock sets the clock of the event timestamps, the current time by default.
+func WithClock(now func() time.Time) Option {
+	return func(s *Store) {
+		s.now = now
+	}
+}
+
+// Store is the store of the credential exchange events.
+type Store struct {
+	store       storage.Store
+	connections *connection.Lookup
+	now         func() time.Time
+}
+
+// New returns a new credential history store.
+func New(ctx provider, opts ...Option) (*Store, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open credential history store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace, storage.StoreConfiguration{TagNames: []string{eventTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	connections, err := connection.NewLookup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection lookup: %w", err)
+	}
+
+	s := &Store{store: store, connections: connections, now: time.Now}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// Record records the event. Its ID and timestamp are set, and its connection is resolved from the DIDs
+// of the exchange (the connectionless exchanges have no connection).
+func (s *Store) Record(event *Event) error {
+	if event.Type == "" {
+		return errors.New("event type is mandatory")
+	}
+
+	event.ID = uuid.New().String()
+	event.Timestamp = s.now().UTC()
+
+	if event.ConnectionID == "" && event.MyDID != "" && event.TheirDID != "" {
+		connectionID, err := s.connections.GetConnectionIDByDIDs(event.MyDID, event.TheirDID)
+		if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+			return fmt.Errorf("get connection of the event: %w", err)
+		}
+
+		event.ConnectionID = connectionID
+	}
+
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal credential history event: %w", err)
+	}
+
+	err = s.store.Put(fmt.Sprintf(eventKeyPattern, event.ID), eventBytes, storage.Tag{Name: eventTagName})
+	if err != nil {
+		return fmt.Errorf("record credential history event: %w", err)
+	}
+
+	return nil
+}
+
+// Filter selects the events of a timeline, the zero value selects all events.
+type Filter struct {
+	// ConnectionID selects the events of the exchanges over the connection.
+	ConnectionID string `json:"connectionID,omitempty"`
+	// CredentialID selects the events of the credential and the events of the exchanges it was part of
+	// (e.g. the offer preceding its issuance).
+	CredentialID string `json:"credentialID,omitempty"`
+	// Type selects the events of the type.
+	Type EventType `json:"type,omitempty"`
+}
+
+// Timeline returns the events selected by the filter, in chronological order.
+func (s *Store) Timeline(filter *Filter) ([]*Event, error) {
+	events, err := s.events()
+	if err != nil {
+		return nil, err
+	}
+
+	if filter == nil {
+		filter = &Filter{}
+	}
+
+	// the exchanges of the credential
+	piids := map[string]struct{}{}
+
+	if filter.CredentialID != "" {
+		for _, event := range events {
+			if event.PIID != "" && contains(event.CredentialIDs, filter.CredentialID) {
+				piids[event.PIID] = struct{}{}
+			}
+		}
+	}
+
+	var timeline []*Event
+
+	for _, event := range events {
+		if filter.CredentialID != "" && !contains(event.CredentialIDs, filter.CredentialID) {
+			if _, ok := piids[event.PIID]; !ok {
+				continue
+			}
+		}
+
+		if (filter.ConnectionID == "" || event.ConnectionID == filter.ConnectionID) &&
+			(filter.Type == "" || event.Type == filter.Type) {
+			timeline = append(timeline, event)
+		}
+	}
+
+	return timeline, nil
+}
+
+func (s *Store) events() ([]*Event, error) {
+	iter, err := s.store.Query(eventTagName)
+	if err != nil {
+		return nil, fmt.Errorf("query credential history: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var events []*Event
+
+	for {
+		ok, errNext := iter.Next()
+		if errNext != nil {
+			return nil, fmt.Errorf("iterate credential history: %w", errNext)
+		}
+
+		if !ok {
+			break
+		}
+
+		value, errValue := iter.Value()
+		if errValue != nil {
+			return nil, fmt.Errorf("get credential history event: %w", errValue)
+		}
+
+		var event Event
+
+		errValue = json.Unmarshal(value, &event)
+		if errValue != nil {
+			return nil, fmt.Errorf("unmarshal credential history event: %w", errValue)
+		}
+
+		events = append(events, &event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+
+	return events, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,217 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	myDID      = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+	theirDID   = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+	otherDID   = "did:example:c276e12ec21ebfeb1f712ebc6f1"
+	vcID       = "http://example.edu/credentials/1872"
+	connID     = "conn-1"
+	issuePIID  = "issue-1"
+	presentPID = "present-1"
+)
+
+func newTestProvider(storeProvider *mockstore.MockStoreProvider) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              storeProvider,
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+	}
+}
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	prov := newTestProvider(mockstore.NewMockStoreProvider())
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        connection.StateNameCompleted,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+	}))
+
+	now := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := New(prov, WithClock(func() time.Time {
+		now = now.Add(time.Minute)
+
+		return now
+	}))
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestNew(t *testing.T) {
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(newTestProvider(&mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")}))
+		require.EqualError(t, err, "failed to open credential history store: open error")
+	})
+
+	t.Run("connection lookup error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: &mockstore.MockStoreProvider{FailNamespace: connection.Namespace},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create connection lookup")
+	})
+}
+
+func TestStore_Record(t *testing.T) {
+	s := newTestStore(t)
+
+	t.Run("connection is resolved from the DIDs", func(t *testing.T) {
+		event := &Event{Type: EventOffered, PIID: issuePIID, MyDID: myDID, TheirDID: theirDID}
+		require.NoError(t, s.Record(event))
+		require.NotEmpty(t, event.ID)
+		require.Equal(t, time.Date(2021, time.March, 1, 0, 1, 0, 0, time.UTC), event.Timestamp)
+		require.Equal(t, connID, event.ConnectionID)
+	})
+
+	t.Run("connectionless exchange", func(t *testing.T) {
+		event := &Event{Type: EventVerified, MyDID: myDID, TheirDID: otherDID}
+		require.NoError(t, s.Record(event))
+		require.Empty(t, event.ConnectionID)
+	})
+
+	t.Run("event type is mandatory", func(t *testing.T) {
+		require.EqualError(t, s.Record(&Event{}), "event type is mandatory")
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		errStore, err := New(newTestProvider(storeProvider))
+		require.NoError(t, err)
+
+		storeProvider.Store.ErrPut = errors.New("put error")
+
+		err = errStore.Record(&Event{Type: EventRevoked})
+		require.EqualError(t, err, "record credential history event: put error")
+	})
+}
+
+func TestStore_Timeline(t *testing.T) {
+	s := newTestStore(t)
+
+	for _, event := range []*Event{
+		{Type: EventOffered, Protocol: "issue-credential", PIID: issuePIID, MyDID: myDID, TheirDID: theirDID},
+		{Type: EventRequested, Protocol: "issue-credential", PIID: issuePIID, MyDID: myDID, TheirDID: theirDID},
+		{
+			Type: EventIssued, Protocol: "issue-credential", PIID: issuePIID, MyDID: myDID, TheirDID: theirDID,
+			CredentialIDs: []string{vcID},
+		},
+		{Type: EventRequested, Protocol: "present-proof", PIID: presentPID, MyDID: myDID, TheirDID: otherDID},
+		{
+			Type: EventPresented, Protocol: "present-proof", PIID: presentPID, MyDID: myDID, TheirDID: otherDID,
+			CredentialIDs: []string{vcID},
+		},
+		{Type: EventRevoked, CredentialIDs: []string{vcID}},
+		{Type: EventOffered, Protocol: "issue-credential", PIID: "issue-2", MyDID: myDID, TheirDID: theirDID},
+	} {
+		require.NoError(t, s.Record(event))
+	}
+
+	types := func(events []*Event) []EventType {
+		var result []EventType
+
+		for _, event := range events {
+			result = append(result, event.Type)
+		}
+
+		return result
+	}
+
+	t.Run("all events", func(t *testing.T) {
+		events, err := s.Timeline(nil)
+		require.NoError(t, err)
+		require.Len(t, events, 7)
+
+		for i := 1; i < len(events); i++ {
+			require.True(t, events[i-1].Timestamp.Before(events[i].Timestamp))
+		}
+	})
+
+	t.Run("timeline of the credential", func(t *testing.T) {
+		events, err := s.Timeline(&Filter{CredentialID: vcID})
+		require.NoError(t, err)
+		require.Equal(t, []EventType{
+			EventOffered, EventRequested, EventIssued, EventRequested, EventPresented, EventRevoked,
+		}, types(events))
+	})
+
+	t.Run("timeline of the connection", func(t *testing.T) {
+		events, err := s.Timeline(&Filter{ConnectionID: connID})
+		require.NoError(t, err)
+		require.Equal(t, []EventType{EventOffered, EventRequested, EventIssued, EventOffered}, types(events))
+	})
+
+	t.Run("events of a type", func(t *testing.T) {
+		events, err := s.Timeline(&Filter{ConnectionID: connID, CredentialID: vcID, Type: EventOffered})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, issuePIID, events[0].PIID)
+	})
+
+	t.Run("unknown credential", func(t *testing.T) {
+		events, err := s.Timeline(&Filter{CredentialID: "http://example.edu/credentials/unknown"})
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+}
+
+func TestStore_TimelineErrors(t *testing.T) {
+	storeProvider := mockstore.NewMockStoreProvider()
+
+	s, err := New(newTestProvider(storeProvider))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Record(&Event{Type: EventRevoked}))
+
+	t.Run("query error", func(t *testing.T) {
+		storeProvider.Store.ErrQuery = errors.New("query error")
+		defer func() { storeProvider.Store.ErrQuery = nil }()
+
+		_, err = s.Timeline(nil)
+		require.EqualError(t, err, "query credential history: query error")
+	})
+
+	t.Run("iterator error", func(t *testing.T) {
+		storeProvider.Store.ErrNext = errors.New("next error")
+		defer func() { storeProvider.Store.ErrNext = nil }()
+
+		_, err = s.Timeline(nil)
+		require.EqualError(t, err, "iterate credential history: next error")
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		require.NoError(t, storeProvider.Store.Put("historyevent_invalid", []byte("{"),
+			storage.Tag{Name: eventTagName}))
+
+		_, err = s.Timeline(nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal credential history event")
+	})
+}
@@ -0,0 +1,187 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package keylink stores links between KMS key IDs and the IDs of DID verification methods
+// using those keys, so that the signing key of a verification method can be found without assuming
+// that the key ID is equal to the fragment of the verification method ID (which is not the case
+// for did:key and did:peer documents).
+package keylink
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did/didurl"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmssigner"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for key link store.
+	NameSpace = "keylink"
+
+	vmKeyPrefix = "vmkey_"
+	kidTagName  = "kid"
+)
+
+var logger = log.New("aries-framework/store/keylink")
+
+// ErrLinkNotFound is returned when the verification method is not linked to any KMS key.
+var ErrLinkNotFound = errors.New("verification method is not linked to KMS key")
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Store stores links between KMS key IDs and verification method IDs.
+type Store struct {
+	store storage.Store
+}
+
+// New returns a new key link store.
+func New(ctx provider) (*Store, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open key link store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace, storage.StoreConfiguration{TagNames: []string{kidTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	return &Store{store: store}, nil
+}
+
+// SaveLink links the KMS key ID to the verification method ID (absolute DID URL).
+func (s *Store) SaveLink(kid, vmID string) error {
+	if kid == "" || vmID == "" {
+		return errors.New("key ID and verification method ID are mandatory")
+	}
+
+	err := s.store.Put(vmKeyPrefix+vmID, []byte(kid), storage.Tag{Name: kidTagName, Value: kid})
+	if err != nil {
+		return fmt.Errorf("save key link: %w", err)
+	}
+
+	return nil
+}
+
+// LinkDID links all verification methods of DID Doc (including those embedded into verification relationships)
+// to KMS key IDs derived from their public keys. Verification methods of unsupported types are skipped.
+func (s *Store) LinkDID(doc *did.Doc) error {
+	for _, verifications := range doc.VerificationMethods() {
+		for i := range verifications {
+			vm := &verifications[i].VerificationMethod
+
+			kid, err := kmssigner.KID(vm)
+			if err != nil {
+				logger.Debugf("verification method %s is not linked to KMS key: %s", vm.ID, err)
+
+				continue
+			}
+
+			vmID, err := absoluteID(doc.ID, vm.ID)
+			if err != nil {
+				return err
+			}
+
+			if err = s.SaveLink(kid, vmID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// HandleDIDCreated links verification methods of the created DID Doc. Errors are logged.
+// It is intended to be registered as the listener of DID creation in VDR registry.
+func (s *Store) HandleDIDCreated(doc *did.Doc) {
+	if err := s.LinkDID(doc); err != nil {
+		logger.Warnf("failed to link verification methods of DID %s to KMS keys: %s", doc.ID, err)
+	}
+}
+
+// LookupSigningKey returns the KMS key ID linked to the verification method.
+func (s *Store) LookupSigningKey(verificationMethodID string) (string, error) {
+	kid, err := s.store.Get(vmKeyPrefix + verificationMethodID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return "", fmt.Errorf("%w: %s", ErrLinkNotFound, verificationMethodID)
+		}
+
+		return "", fmt.Errorf("get key link: %w", err)
+	}
+
+	return string(kid), nil
+}
+
+// LookupVerificationMethods returns the IDs of verification methods linked to the KMS key.
+func (s *Store) LookupVerificationMethods(kid string) ([]string, error) {
+	itr, err := s.store.Query(kidTagName + ":" + kid)
+	if err != nil {
+		return nil, fmt.Errorf("query key links: %w", err)
+	}
+
+	defer func() {
+		if errClose := itr.Close(); errClose != nil {
+			logger.Errorf("failed to close iterator: %s", errClose.Error())
+		}
+	}()
+
+	var vmIDs []string
+
+	more, err := itr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("iterate key links: %w", err)
+	}
+
+	for more {
+		key, err := itr.Key()
+		if err != nil {
+			return nil, fmt.Errorf("get key link key: %w", err)
+		}
+
+		vmIDs = append(vmIDs, key[len(vmKeyPrefix):])
+
+		more, err = itr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("iterate key links: %w", err)
+		}
+	}
+
+	return vmIDs, nil
+}
+
+// RemoveLink removes the link of the verification method.
+func (s *Store) RemoveLink(verificationMethodID string) error {
+	if err := s.store.Delete(vmKeyPrefix + verificationMethodID); err != nil {
+		return fmt.Errorf("remove key link: %w", err)
+	}
+
+	return nil
+}
+
+func absoluteID(didID, vmID string) (string, error) {
+	u, err := didurl.Parse(vmID)
+	if err != nil {
+		return "", fmt.Errorf("parse verification method ID: %w", err)
+	}
+
+	if !u.IsRelative() {
+		return vmID, nil
+	}
+
+	u, err = u.ResolveReference(didID)
+	if err != nil {
+		return "", fmt.Errorf("resolve verification method ID: %w", err)
+	}
+
+	return u.String(), nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keylink
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmssigner"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const didID = "did:peer:1zQmfFyDtwvpY1B6xU5RM3HBWzRM6zC3xHzfVCCouhxzFdee"
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		store, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+		require.NoError(t, err)
+		require.NotNil(t, store)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}})
+		require.EqualError(t, err, "failed to open key link store: open error")
+	})
+
+}
+
+func TestStore_LinkDID(t *testing.T) {
+	store, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+	require.NoError(t, err)
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	authPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	vm := did.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", didID, pubKey)
+	authVM := did.NewVerificationMethodFromBytes(didID+"#auth", "Ed25519VerificationKey2018", didID, authPubKey)
+	unsupportedVM := did.NewVerificationMethodFromBytes("#x25519", "X25519KeyAgreementKey2019", didID, []byte{1})
+
+	doc := &did.Doc{
+		ID:                 didID,
+		VerificationMethod: []did.VerificationMethod{*vm, *unsupportedVM},
+		Authentication:     []did.Verification{*did.NewEmbeddedVerification(authVM, did.Authentication)},
+	}
+
+	store.HandleDIDCreated(doc)
+
+	kid, err := kmssigner.KID(vm)
+	require.NoError(t, err)
+
+	linked, err := store.LookupSigningKey(didID + "#key-1")
+	require.NoError(t, err)
+	require.Equal(t, kid, linked)
+
+	authKID, err := kmssigner.KID(authVM)
+	require.NoError(t, err)
+
+	linked, err = store.LookupSigningKey(didID + "#auth")
+	require.NoError(t, err)
+	require.Equal(t, authKID, linked)
+
+	_, err = store.LookupSigningKey(didID + "#x25519")
+	require.ErrorIs(t, err, ErrLinkNotFound)
+
+	vmIDs, err := store.LookupVerificationMethods(kid)
+	require.NoError(t, err)
+	require.Equal(t, []string{didID + "#key-1"}, vmIDs)
+
+	require.NoError(t, store.RemoveLink(didID+"#key-1"))
+
+	_, err = store.LookupSigningKey(didID + "#key-1")
+	require.ErrorIs(t, err, ErrLinkNotFound)
+
+	require.Error(t, store.LinkDID(&did.Doc{ID: "invalid", VerificationMethod: []did.VerificationMethod{*vm}}))
+}
+
+func TestStore_SaveLink(t *testing.T) {
+	t.Run("missing values", func(t *testing.T) {
+		store, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+		require.NoError(t, err)
+
+		require.EqualError(t, store.SaveLink("", didID+"#key-1"),
+			"key ID and verification method ID are mandatory")
+	})
+
+	t.Run("storage errors", func(t *testing.T) {
+		mockStore := &mockstorage.MockStore{
+			Store:    map[string]mockstorage.DBEntry{},
+			ErrPut:   errors.New("put error"),
+			ErrGet:   errors.New("get error"),
+			ErrQuery: errors.New("query error"),
+		}
+
+		store, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{Store: mockStore},
+		})
+		require.NoError(t, err)
+
+		require.EqualError(t, store.SaveLink("kid", didID+"#key-1"), "save key link: put error")
+
+		_, err = store.LookupSigningKey(didID + "#key-1")
+		require.EqualError(t, err, "get key link: get error")
+
+		_, err = store.LookupVerificationMethods("kid")
+		require.EqualError(t, err, "query key links: query error")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		store, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+		require.NoError(t, err)
+
+		_, err = store.LookupSigningKey(didID + "#key-1")
+		require.ErrorIs(t, err, ErrLinkNotFound)
+		require.False(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
@@ -0,0 +1,345 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package settings persists the runtime-tunable parameters of the agent (auto-accept flag, webhook URLs,
+// log level, mediator connections). The settings survive restarts and override the static startup options of
+// the components consuming them, which are notified of the changes.
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for settings store.
+	NameSpace = "settings"
+
+	// AutoAccept is the setting (boolean) accepting the DID exchange requests automatically.
+	AutoAccept = "auto_accept"
+	// WebhookURLs is the setting (array of URLs) of the webhooks notified of all the topics.
+	WebhookURLs = "webhook_urls"
+	// LogLevel is the setting (string, e.g. "DEBUG") of the default log level of the modules.
+	LogLevel = "log_level"
+	// MediatorConnections is the setting (array of connection IDs) of the router connections used by default
+	// for the invitations and the DID exchanges.
+	MediatorConnections = "mediator_connections"
+
+	settingKeyPrefix = "setting_"
+	settingTagName   = "setting"
+)
+
+var logger = log.New("aries-framework/store/settings")
+
+// ErrNotFound is returned when the setting is not set.
+var ErrNotFound = errors.New("setting not found")
+
+// Setting is a runtime-tunable parameter of the agent.
+type Setting struct {
+	// Name of the setting.
+	Name string `json:"name"`
+	// Value of the setting (JSON).
+	Value json.RawMessage `json:"value"`
+	// UpdatedAt is the time the setting was last set.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Event is sent when a setting is set or removed.
+type Event struct {
+	Name string
+	// Value is the new value of the setting, nil if the setting was removed.
+	Value json.RawMessage
+}
+
+// Validator validates the value of a setting.
+type Validator func(value json.RawMessage) error
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Option configures the settings store.
+type Option func(s *Store)
+
+// WithValidator sets the validator of the setting with the given name, replacing the default one if any.
+// The settings without validator accept any JSON value.
+func WithValidator(name string, validator Validator) Option {
+	return func(s *Store) {
+		s.validators[name] = validator
+	}
+}
+
+// Store is the store of the settings of the agent.
+type Store struct {
+	store      storage.Store
+	validators map[string]Validator
+	now        func() time.Time
+
+	mu     sync.RWMutex
+	events []chan<- Event
+}
+
+// New returns a new settings store.
+func New(ctx provider, opts ...Option) (*Store, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open settings store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace, storage.StoreConfiguration{TagNames: []string{settingTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	s := &Store{
+		store: store,
+		validators: map[string]Validator{
+			AutoAccept:          validateBool,
+			WebhookURLs:         validateURLs,
+			LogLevel:            validateLogLevel,
+			MediatorConnections: validateStrings,
+		},
+		now: time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// RegisterEvent registers a channel to receive the events of the changed settings.
+func (s *Store) RegisterEvent(ch chan<- Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, ch)
+}
+
+// UnregisterEvent unregisters the channel from the events of the changed settings.
+func (s *Store) UnregisterEvent(ch chan<- Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.events {
+		if s.events[i] == ch {
+			s.events = append(s.events[:i], s.events[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Set sets the value (JSON) of the setting and sends the event.
+func (s *Store) Set(name string, value json.RawMessage) error {
+	if name == "" {
+		return errors.New("setting name is mandatory")
+	}
+
+	if !json.Valid(value) {
+		return fmt.Errorf("invalid value of setting %s: not a JSON value", name)
+	}
+
+	if validate, ok := s.validators[name]; ok {
+		if err := validate(value); err != nil {
+			return fmt.Errorf("invalid value of setting %s: %w", name, err)
+		}
+	}
+
+	src, err := json.Marshal(&Setting{Name: name, Value: value, UpdatedAt: s.now().UTC()})
+	if err != nil {
+		return fmt.Errorf("marshal setting: %w", err)
+	}
+
+	if err = s.store.Put(settingKeyPrefix+name, src, storage.Tag{Name: settingTagName}); err != nil {
+		return fmt.Errorf("save setting: %w", err)
+	}
+
+	s.notify(Event{Name: name, Value: value})
+
+	return nil
+}
+
+// Get returns the setting with the given name, ErrNotFound if it is not set.
+func (s *Store) Get(name string) (*Setting, error) {
+	src, err := s.store.Get(settingKeyPrefix + name)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get setting: %w", err)
+	}
+
+	setting := &Setting{}
+	if err = json.Unmarshal(src, setting); err != nil {
+		return nil, fmt.Errorf("unmarshal setting: %w", err)
+	}
+
+	return setting, nil
+}
+
+// Decode decodes the value of the setting with the given name into v, ErrNotFound is returned if it is not set.
+func (s *Store) Decode(name string, v interface{}) error {
+	setting, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(setting.Value, v); err != nil {
+		return fmt.Errorf("decode setting %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Bool returns the boolean value of the setting, or the default value if it is not set (or cannot be read).
+func (s *Store) Bool(name string, defaultValue bool) bool {
+	value := defaultValue
+
+	if err := s.Decode(name, &value); err != nil {
+		s.logDecodeError(err)
+
+		return defaultValue
+	}
+
+	return value
+}
+
+// Strings returns the values of the setting, or the default values if it is not set (or cannot be read).
+func (s *Store) Strings(name string, defaultValues []string) []string {
+	var values []string
+
+	if err := s.Decode(name, &values); err != nil {
+		s.logDecodeError(err)
+
+		return defaultValues
+	}
+
+	return values
+}
+
+// All returns the settings sorted by name.
+func (s *Store) All() ([]*Setting, error) {
+	iter, err := s.store.Query(settingTagName)
+	if err != nil {
+		return nil, fmt.Errorf("query settings: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var settings []*Setting
+
+	more, err := iter.Next()
+
+	for ; err == nil && more; more, err = iter.Next() {
+		src, errValue := iter.Value()
+		if errValue != nil {
+			return nil, fmt.Errorf("get setting: %w", errValue)
+		}
+
+		setting := &Setting{}
+		if errValue = json.Unmarshal(src, setting); errValue != nil {
+			return nil, fmt.Errorf("unmarshal setting: %w", errValue)
+		}
+
+		settings = append(settings, setting)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("iterate settings: %w", err)
+	}
+
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Name < settings[j].Name
+	})
+
+	return settings, nil
+}
+
+// Remove removes the setting, the static startup option applies again. The event is sent with a nil value.
+func (s *Store) Remove(name string) error {
+	if _, err := s.Get(name); err != nil {
+		return err
+	}
+
+	if err := s.store.Delete(settingKeyPrefix + name); err != nil {
+		return fmt.Errorf("delete setting: %w", err)
+	}
+
+	s.notify(Event{Name: name})
+
+	return nil
+}
+
+func (s *Store) notify(event Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, ch := range s.events {
+		ch <- event
+	}
+}
+
+func (s *Store) logDecodeError(err error) {
+	if !errors.Is(err, ErrNotFound) {
+		logger.Warnf("default value is used: %s", err)
+	}
+}
+
+func validateBool(value json.RawMessage) error {
+	var b bool
+
+	return json.Unmarshal(value, &b)
+}
+
+func validateStrings(value json.RawMessage) error {
+	var values []string
+
+	return json.Unmarshal(value, &values)
+}
+
+func validateURLs(value json.RawMessage) error {
+	var urls []string
+
+	if err := json.Unmarshal(value, &urls); err != nil {
+		return err
+	}
+
+	for _, u := range urls {
+		parsed, err := url.ParseRequestURI(u)
+		if err != nil {
+			return err
+		}
+
+		if parsed.Scheme != "http" && parsed.Scheme != "https" {
+			return fmt.Errorf("unsupported scheme of URL %s", u)
+		}
+	}
+
+	return nil
+}
+
+func validateLogLevel(value json.RawMessage) error {
+	var level string
+
+	if err := json.Unmarshal(value, &level); err != nil {
+		return err
+	}
+
+	_, err := log.ParseLevel(level)
+
+	return err
+}
@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func newStore(t *testing.T, opts ...Option) *Store {
+	t.Helper()
+
+	store, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, opts...)
+	require.NoError(t, err)
+
+	return store
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&mockprovider.Provider{
+		StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+	})
+	require.EqualError(t, err, "failed to open settings store: open error")
+}
+
+func TestStore(t *testing.T) {
+	t.Run("set, get and remove", func(t *testing.T) {
+		store := newStore(t)
+
+		events := make(chan Event, 2)
+		store.RegisterEvent(events)
+
+		require.NoError(t, store.Set(AutoAccept, json.RawMessage(`true`)))
+		require.True(t, store.Bool(AutoAccept, false))
+
+		setting, err := store.Get(AutoAccept)
+		require.NoError(t, err)
+		require.Equal(t, AutoAccept, setting.Name)
+		require.JSONEq(t, `true`, string(setting.Value))
+		require.False(t, setting.UpdatedAt.IsZero())
+
+		require.Equal(t, Event{Name: AutoAccept, Value: json.RawMessage(`true`)}, <-events)
+
+		require.NoError(t, store.Remove(AutoAccept))
+		require.Equal(t, Event{Name: AutoAccept}, <-events)
+
+		_, err = store.Get(AutoAccept)
+		require.True(t, errors.Is(err, ErrNotFound))
+		require.False(t, store.Bool(AutoAccept, false))
+
+		require.True(t, errors.Is(store.Remove(AutoAccept), ErrNotFound))
+
+		store.UnregisterEvent(events)
+		require.NoError(t, store.Set(AutoAccept, json.RawMessage(`false`)))
+		require.Empty(t, events)
+	})
+
+	t.Run("all settings", func(t *testing.T) {
+		store := newStore(t)
+
+		all, err := store.All()
+		require.NoError(t, err)
+		require.Empty(t, all)
+
+		require.NoError(t, store.Set(WebhookURLs, json.RawMessage(`["http://example.com/webhook"]`)))
+		require.NoError(t, store.Set(LogLevel, json.RawMessage(`"debug"`)))
+		require.NoError(t, store.Set("custom", json.RawMessage(`{"any":"value"}`)))
+
+		all, err = store.All()
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		require.Equal(t, "custom", all[0].Name)
+		require.Equal(t, LogLevel, all[1].Name)
+		require.Equal(t, WebhookURLs, all[2].Name)
+
+		require.Equal(t, []string{"http://example.com/webhook"}, store.Strings(WebhookURLs, nil))
+		require.Equal(t, []string{"default"}, store.Strings(MediatorConnections, []string{"default"}))
+		require.Equal(t, []string{"default"}, store.Strings(LogLevel, []string{"default"}))
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		store := newStore(t, WithValidator("positive", func(value json.RawMessage) error {
+			var n int
+			if err := json.Unmarshal(value, &n); err != nil || n <= 0 {
+				return errors.New("not a positive number")
+			}
+
+			return nil
+		}))
+
+		for name, value := range map[string]string{
+			AutoAccept:          `"yes"`,
+			WebhookURLs:         `["ftp://example.com"]`,
+			LogLevel:            `"verbose"`,
+			MediatorConnections: `"conn-1"`,
+			"positive":          `-1`,
+			"custom":            `{`,
+		} {
+			err := store.Set(name, json.RawMessage(value))
+			require.Error(t, err, name)
+			require.Contains(t, err.Error(), "invalid value of setting "+name)
+		}
+
+		require.EqualError(t, store.Set("", json.RawMessage(`true`)), "setting name is mandatory")
+		require.NoError(t, store.Set("positive", json.RawMessage(`1`)))
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		store, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider})
+		require.NoError(t, err)
+
+		storeProvider.Store.ErrPut = errors.New("put error")
+		require.EqualError(t, store.Set(AutoAccept, json.RawMessage(`true`)), "save setting: put error")
+
+		storeProvider.Store.ErrGet = errors.New("get error")
+		_, err = store.Get(AutoAccept)
+		require.EqualError(t, err, "get setting: get error")
+		require.True(t, store.Bool(AutoAccept, true))
+
+		storeProvider.Store.ErrQuery = errors.New("query error")
+		_, err = store.All()
+		require.EqualError(t, err, "query settings: query error")
+	})
+}
@@ -0,0 +1,312 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// encryptionKeyIDKey is the key of the ID of the current content encryption key.
+	encryptionKeyIDKey = "vcencryption_currentkey"
+	// encryptionVersion is the version of the encrypted payloads, it tells them apart from the plaintext ones.
+	encryptionVersion = 1
+)
+
+// StoreOpt represents option function of the vc store.
+type StoreOpt func(s *StoreImplementation)
+
+// WithEncryption encrypts the credential and presentation payloads at rest with a content encryption key (AES-256-GCM)
+// managed by the key manager. The records (name, context, type, DIDs and subject ID) are kept in plaintext so that
+// they can be listed. Payloads saved before encryption was enabled are encrypted when the store is first created with
+// encryption, plaintext payloads are rejected from then on.
+func WithEncryption(keyManager kms.KeyManager, cryptoSvc crypto.Crypto) StoreOpt {
+	return func(s *StoreImplementation) {
+		s.encrypter = &payloadEncrypter{keyManager: keyManager, crypto: cryptoSvc}
+	}
+}
+
+// encryptedPayload is the stored form of an encrypted credential or presentation.
+type encryptedPayload struct {
+	Version    int    `json:"vcencryption"`
+	KeyID      string `json:"kid"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type payloadEncrypter struct {
+	keyManager kms.KeyManager
+	crypto     crypto.Crypto
+
+	mutex sync.RWMutex
+	keyID string
+}
+
+// rotate creates a new content encryption key used to encrypt the payloads from now on. The payloads encrypted with
+// the previous keys are re-encrypted lazily, when read.
+func (e *payloadEncrypter) rotate(store storage.Store) (string, error) {
+	keyID, err := e.createKey()
+	if err != nil {
+		return "", err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	err = saveKeyID(store, keyID)
+	if err != nil {
+		return "", err
+	}
+
+	e.keyID = keyID
+
+	return keyID, nil
+}
+
+func (e *payloadEncrypter) createKey() (string, error) {
+	keyID, _, err := e.keyManager.Create(kms.AES256GCMType)
+	if err != nil {
+		return "", fmt.Errorf("create content encryption key: %w", err)
+	}
+
+	return keyID, nil
+}
+
+func saveKeyID(store storage.Store, keyID string) error {
+	err := store.Put(encryptionKeyIDKey, []byte(keyID))
+	if err != nil {
+		return fmt.Errorf("save content encryption key ID: %w", err)
+	}
+
+	return nil
+}
+
+func (e *payloadEncrypter) currentKeyID() string {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.keyID
+}
+
+// encrypt encrypts the payload stored by the given key, the key being the additional authenticated data.
+func (e *payloadEncrypter) encrypt(key string, payload []byte) ([]byte, error) {
+	keyID := e.currentKeyID()
+
+	kh, err := e.keyManager.Get(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get content encryption key: %w", err)
+	}
+
+	ciphertext, nonce, err := e.crypto.Encrypt(payload, []byte(key), kh)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt payload: %w", err)
+	}
+
+	return json.Marshal(&encryptedPayload{
+		Version: encryptionVersion, KeyID: keyID, Nonce: nonce, Ciphertext: ciphertext,
+	})
+}
+
+// decrypt decrypts the payload stored by the given key and tells whether it must be re-encrypted, being encrypted
+// with a previous content encryption key. Plaintext payloads are rejected.
+func (e *payloadEncrypter) decrypt(key string, stored []byte) ([]byte, bool, error) {
+	encrypted, ok := parseEncryptedPayload(stored)
+	if !ok {
+		return nil, false, errors.New("payload is not encrypted")
+	}
+
+	kh, err := e.keyManager.Get(encrypted.KeyID)
+	if err != nil {
+		return nil, false, fmt.Errorf("get content encryption key: %w", err)
+	}
+
+	payload, err := e.crypto.Decrypt(encrypted.Ciphertext, []byte(key), encrypted.Nonce, kh)
+	if err != nil {
+		return nil, false, fmt.Errorf("decrypt payload: %w", err)
+	}
+
+	return payload, encrypted.KeyID != e.currentKeyID(), nil
+}
+
+func parseEncryptedPayload(stored []byte) (*encryptedPayload, bool) {
+	var encrypted encryptedPayload
+
+	if err := json.Unmarshal(stored, &encrypted); err != nil {
+		return nil, false
+	}
+
+	return &encrypted, encrypted.Version == encryptionVersion && encrypted.KeyID != "" && len(encrypted.Ciphertext) > 0
+}
+
+// initEncryption loads the ID of the current content encryption key. When the store is first created with encryption,
+// a key is created and the payloads saved in plaintext until then are encrypted with it.
+func (s *StoreImplementation) initEncryption() error {
+	keyID, err := s.store.Get(encryptionKeyIDKey)
+	if err == nil {
+		s.encrypter.keyID = string(keyID)
+
+		return nil
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get content encryption key ID: %w", err)
+	}
+
+	s.encrypter.keyID, err = s.encrypter.createKey()
+	if err != nil {
+		return err
+	}
+
+	// the key ID is saved once all the payloads are encrypted, so that an interrupted encryption is resumed
+	err = s.encryptPlaintextPayloads()
+	if err != nil {
+		return fmt.Errorf("encrypt plaintext payloads: %w", err)
+	}
+
+	return saveKeyID(s.store, s.encrypter.keyID)
+}
+
+// encryptPlaintextPayloads encrypts the payloads of the credentials and presentations saved in plaintext.
+func (s *StoreImplementation) encryptPlaintextPayloads() error {
+	for _, searchKey := range []string{credentialNameKey, presentationNameKey} {
+		records, err := s.getAllRecords(searchKey)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			if err = s.encryptPlaintextPayload(record.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *StoreImplementation) encryptPlaintextPayload(key string) error {
+	stored, err := s.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := parseEncryptedPayload(stored); ok {
+		return nil
+	}
+
+	sealed, err := s.encrypter.encrypt(key, stored)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Put(key, sealed)
+}
+
+// RotateEncryptionKey creates a new content encryption key for the payloads saved from now on and returns its ID.
+// The payloads encrypted with the previous keys are re-encrypted with the new key when read, the previous keys must
+// therefore be kept in the key manager.
+func (s *StoreImplementation) RotateEncryptionKey() (string, error) {
+	if s.encrypter == nil {
+		return "", errors.New("store encryption is not enabled")
+	}
+
+	s.payloadMutex.Lock()
+	defer s.payloadMutex.Unlock()
+
+	return s.encrypter.rotate(s.store)
+}
+
+// putPayload stores the payload by the given key, encrypted if encryption is enabled.
+func (s *StoreImplementation) putPayload(key string, payload []byte) error {
+	s.payloadMutex.Lock()
+	defer s.payloadMutex.Unlock()
+
+	sealed, err := s.sealPayload(key, payload)
+	if err != nil {
+		return fmt.Errorf("seal payload: %w", err)
+	}
+
+	return s.store.Put(key, sealed)
+}
+
+// sealPayload returns the payload to be stored by the given key, encrypted if encryption is enabled.
+func (s *StoreImplementation) sealPayload(key string, payload []byte) ([]byte, error) {
+	if s.encrypter == nil {
+		return payload, nil
+	}
+
+	return s.encrypter.encrypt(key, payload)
+}
+
+// getPayload gets the payload stored by the given key, decrypting it if needed. Payloads encrypted with a previous
+// content encryption key are re-encrypted with the current key.
+func (s *StoreImplementation) getPayload(key string) ([]byte, error) {
+	stored, err := s.store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.encrypter == nil {
+		if _, ok := parseEncryptedPayload(stored); ok {
+			return nil, errors.New("payload is encrypted but store encryption is not enabled")
+		}
+
+		return stored, nil
+	}
+
+	payload, reencrypt, err := s.encrypter.decrypt(key, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	if reencrypt {
+		if errSeal := s.reencryptPayload(key, stored, payload); errSeal != nil {
+			logger.Warnf("failed to re-encrypt payload %s: %s", key, errSeal.Error())
+		}
+	}
+
+	return payload, nil
+}
+
+// reencryptPayload re-encrypts the payload with the current content encryption key, unless the stored payload was
+// replaced or deleted since it was read.
+func (s *StoreImplementation) reencryptPayload(key string, stored, payload []byte) error {
+	s.payloadMutex.Lock()
+	defer s.payloadMutex.Unlock()
+
+	current, err := s.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(current, stored) {
+		return nil
+	}
+
+	sealed, err := s.encrypter.encrypt(key, payload)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Put(key, sealed)
+}
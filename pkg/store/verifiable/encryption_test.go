@@ -0,0 +1,250 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func newEncryptionOpt(t *testing.T) StoreOpt {
+	t.Helper()
+
+	keyManager, err := localkms.New("local-lock://test/key-uri/",
+		mockkms.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	return WithEncryption(keyManager, cryptoSvc)
+}
+
+func TestStoreEncryption(t *testing.T) {
+	vc := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType},
+		ID:      "http://example.edu/credentials/1872",
+		Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issued:  util.NewTime(time.Now()),
+	}
+
+	t.Run("test credentials and presentations are encrypted at rest", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		s, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider}, newEncryptionOpt(t))
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveCredential(sampleCredentialName, vc))
+
+		stored := storeProvider.Store.Store[vc.ID].Value
+		require.NotContains(t, string(stored), "did:example:ebfeb1f712ebc6f1c276e12ec21")
+
+		var encrypted encryptedPayload
+		require.NoError(t, json.Unmarshal(stored, &encrypted))
+		require.NotEmpty(t, encrypted.KeyID)
+
+		got, err := s.GetCredential(vc.ID)
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, got.ID)
+
+		vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+		require.NoError(t, err)
+
+		vp.ID = "urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5"
+
+		require.NoError(t, s.SavePresentation(samplePresentationName, vp))
+		require.NotContains(t, string(storeProvider.Store.Store[vp.ID].Value), vc.ID)
+
+		gotVP, err := s.GetPresentation(vp.ID)
+		require.NoError(t, err)
+		require.Equal(t, vp.ID, gotVP.ID)
+
+		// replaced credentials are encrypted too
+		require.NoError(t, s.ReplaceCredential(sampleCredentialName, vc))
+		require.NotContains(t, string(storeProvider.Store.Store[vc.ID].Value), "did:example:ebfeb1f712ebc6f1c276e12ec21")
+
+		// a payload moved under another key fails the authentication
+		storeProvider.Store.Store["other"] = storeProvider.Store.Store[vc.ID]
+
+		_, err = s.GetCredential("other")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decrypt payload")
+
+		// the encrypted payloads can't be read without encryption
+		plain, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider})
+		require.NoError(t, err)
+
+		_, err = plain.GetCredential(vc.ID)
+		require.EqualError(t, err, "failed to get vc: payload is encrypted but store encryption is not enabled")
+	})
+
+	t.Run("test key rotation and lazy re-encryption", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+		encryption := newEncryptionOpt(t)
+
+		s, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider}, encryption)
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveCredential(sampleCredentialName, vc))
+
+		firstKeyID := s.encrypter.currentKeyID()
+
+		keyID, err := s.RotateEncryptionKey()
+		require.NoError(t, err)
+		require.NotEqual(t, firstKeyID, keyID)
+
+		// the current key ID is kept across store instances
+		reopened, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider}, encryption)
+		require.NoError(t, err)
+		require.Equal(t, keyID, reopened.encrypter.currentKeyID())
+
+		var encrypted encryptedPayload
+		require.NoError(t, json.Unmarshal(storeProvider.Store.Store[vc.ID].Value, &encrypted))
+		require.Equal(t, firstKeyID, encrypted.KeyID)
+
+		_, err = reopened.GetCredential(vc.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, json.Unmarshal(storeProvider.Store.Store[vc.ID].Value, &encrypted))
+		require.Equal(t, keyID, encrypted.KeyID)
+
+		plain, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()})
+		require.NoError(t, err)
+
+		_, err = plain.RotateEncryptionKey()
+		require.EqualError(t, err, "store encryption is not enabled")
+	})
+
+	t.Run("test plaintext payloads are encrypted when encryption is enabled", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		plain, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider})
+		require.NoError(t, err)
+
+		require.NoError(t, plain.SaveCredential(sampleCredentialName, vc))
+		require.Contains(t, string(storeProvider.Store.Store[vc.ID].Value), "did:example:ebfeb1f712ebc6f1c276e12ec21")
+
+		vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+		require.NoError(t, err)
+
+		vp.ID = "urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5"
+
+		require.NoError(t, plain.SavePresentation(samplePresentationName, vp))
+
+		s, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider}, newEncryptionOpt(t))
+		require.NoError(t, err)
+
+		for _, id := range []string{vc.ID, vp.ID} {
+			_, ok := parseEncryptedPayload(storeProvider.Store.Store[id].Value)
+			require.True(t, ok, id)
+		}
+
+		_, err = s.GetCredential(vc.ID)
+		require.NoError(t, err)
+
+		_, err = s.GetPresentation(vp.ID)
+		require.NoError(t, err)
+
+		// the plaintext payloads are rejected once encryption is enabled
+		require.NoError(t, storeProvider.Store.Put(vc.ID, []byte(`{"id":"http://example.edu/credentials/1872"}`)))
+
+		_, err = s.GetCredential(vc.ID)
+		require.EqualError(t, err, "failed to get vc: payload is not encrypted")
+	})
+
+	t.Run("test payloads saved meanwhile are not re-encrypted", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		s, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider}, newEncryptionOpt(t))
+		require.NoError(t, err)
+
+		read := []byte(`{"id":"http://example.edu/credentials/1872"}`)
+
+		require.NoError(t, s.putPayload(vc.ID, []byte(`{"id":"http://example.edu/credentials/1873"}`)))
+		saved := storeProvider.Store.Store[vc.ID].Value
+
+		require.NoError(t, s.reencryptPayload(vc.ID, read, read))
+		require.Equal(t, saved, storeProvider.Store.Store[vc.ID].Value)
+
+		require.NoError(t, s.remove(vc.ID, credentialNameDataKey(sampleCredentialName)))
+		require.NoError(t, s.reencryptPayload(vc.ID, saved, read))
+		require.NotContains(t, storeProvider.Store.Store, vc.ID)
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewCustomMockStoreProvider(
+			&mockstore.MockStore{Store: make(map[string]mockstore.DBEntry), ErrGet: errors.New("get error")},
+		)}, newEncryptionOpt(t))
+		require.EqualError(t, err, "failed to initialize store encryption: get content encryption key ID: get error")
+
+		_, err = New(&mockprovider.Provider{StorageProviderValue: mockstore.NewCustomMockStoreProvider(
+			&mockstore.MockStore{Store: make(map[string]mockstore.DBEntry), ErrPut: errors.New("put error")},
+		)}, newEncryptionOpt(t))
+		require.EqualError(t, err, "failed to initialize store encryption: save content encryption key ID: put error")
+
+		_, err = New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()},
+			WithEncryption(&mockkms.KeyManager{CreateKeyErr: errors.New("create error")}, nil))
+		require.EqualError(t, err, "failed to initialize store encryption: create content encryption key: create error")
+
+		_, err = New(&mockprovider.Provider{StorageProviderValue: mockstore.NewCustomMockStoreProvider(
+			&mockstore.MockStore{Store: make(map[string]mockstore.DBEntry), ErrQuery: errors.New("query error")},
+		)}, newEncryptionOpt(t))
+		require.EqualError(t, err,
+			"failed to initialize store encryption: encrypt plaintext payloads: failed to query store: query error")
+
+		keyManager := &mockkms.KeyManager{CreateKeyID: "key-1"}
+
+		s, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()},
+			WithEncryption(keyManager, nil))
+		require.NoError(t, err)
+
+		keyManager.GetKeyErr = errors.New("get key error")
+
+		err = s.SaveCredential(sampleCredentialName, vc)
+		require.EqualError(t, err, "failed to put vc: seal payload: get content encryption key: get key error")
+
+		err = s.SavePresentation(samplePresentationName, &verifiable.Presentation{Context: []string{verifiable.ContextURI}})
+		require.EqualError(t, err, "failed to put vp: seal payload: get content encryption key: get key error")
+
+		s.store = &mockstore.MockStore{Store: map[string]mockstore.DBEntry{
+			vc.ID: {Value: []byte(`{"vcencryption":1,"kid":"key-1","ciphertext":"AQ=="}`)},
+		}}
+
+		_, err = s.GetCredential(vc.ID)
+		require.EqualError(t, err, "failed to get vc: get content encryption key: get key error")
+	})
+}
+
+func TestParseEncryptedPayload(t *testing.T) {
+	for stored, ok := range map[string]bool{
+		`{"vcencryption":1,"kid":"key-1","nonce":"AQ==","ciphertext":"AQ=="}`: true,
+		`{"kid":"key-1","nonce":"AQ==","ciphertext":"AQ=="}`:                  false,
+		`{"vcencryption":2,"kid":"key-1","nonce":"AQ==","ciphertext":"AQ=="}`: false,
+		`{"vcencryption":1,"kid":"key-1"}`:                                    false,
+		`{"id":"http://example.edu/credentials/1872"}`:                        false,
+		`"did:example:ebfeb1f712ebc6f1c276e12ec21"`:                           false,
+		`{"@context":["https://www.w3.org/2018/credentials/v1"]`:              false,
+	} {
+		_, parsed := parseEncryptedPayload([]byte(stored))
+		require.Equal(t, ok, parsed, stored)
+	}
+}
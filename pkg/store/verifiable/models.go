@@ -17,4 +17,13 @@ type Record struct {
 	// of issuing a credential or presentation.
 	MyDID    string `json:"my_did,omitempty"`
 	TheirDID string `json:"their_did,omitempty"`
+	// Tags are the labels given to the credential to organize the wallet.
+	Tags []string `json:"tags,omitempty"`
+	// Collection is the name of the collection the credential is grouped in, if any.
+	Collection string `json:"collection,omitempty"`
+}
+
+// Collection is a named collection grouping verifiable credentials.
+type Collection struct {
+	Name string `json:"name"`
 }
@@ -10,6 +10,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 
@@ -27,6 +30,11 @@ const (
 	presentationNameKey            = "vpname_"
 	credentialNameDataKeyPattern   = credentialNameKey + "%s"
 	presentationNameDataKeyPattern = presentationNameKey + "%s"
+
+	collectionKey            = "vccollection_"
+	collectionDataKeyPattern = collectionKey + "%s"
+	// credentialCollectionTag tags the records of the credentials with the name of their collection.
+	credentialCollectionTag = "vcincollection"
 )
 
 var logger = log.New("aries-framework/store/verifiable")
@@ -65,11 +73,24 @@ type Store interface {
 	GetPresentations() ([]*Record, error)
 	RemoveCredentialByName(name string) error
 	RemovePresentationByName(name string) error
+	ReplaceCredential(name string, vc *verifiable.Credential, opts ...Opt) error
+	UpdateCredentialName(name, newName string) error
+	AddCredentialTags(name string, tags ...string) error
+	RemoveCredentialTags(name string, tags ...string) error
+	CreateCollection(name string) error
+	GetCollections() ([]string, error)
+	RemoveCollection(name string) error
+	MoveCredential(name, collection string) error
+	GetCredentialsByCollection(collection string) ([]*Record, error)
 }
 
 // StoreImplementation stores vc.
 type StoreImplementation struct {
-	store storage.Store
+	store     storage.Store
+	encrypter *payloadEncrypter
+	// payloadMutex serializes the writes of the payloads with their re-encryption when read, so that a payload saved
+	// meanwhile is not overwritten by the re-encrypted previous one.
+	payloadMutex sync.Mutex
 }
 
 type provider interface {
@@ -77,19 +98,34 @@ type provider interface {
 }
 
 // New returns a new vc store.
-func New(ctx provider) (*StoreImplementation, error) {
+func New(ctx provider, opts ...StoreOpt) (*StoreImplementation, error) {
 	store, err := ctx.StorageProvider().OpenStore(NameSpace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open vc store: %w", err)
 	}
 
 	err = ctx.StorageProvider().SetStoreConfig(NameSpace,
-		storage.StoreConfiguration{TagNames: []string{credentialNameKey, presentationNameKey}})
+		storage.StoreConfiguration{TagNames: []string{
+			credentialNameKey, presentationNameKey, collectionKey, credentialCollectionTag,
+		}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store configuration: %w", err)
 	}
 
-	return &StoreImplementation{store: store}, nil
+	s := &StoreImplementation{store: store}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.encrypter != nil {
+		err = s.initEncryption()
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize store encryption: %w", err)
+		}
+	}
+
+	return s, nil
 }
 
 // SaveCredential saves a verifiable credential.
@@ -107,7 +143,7 @@ func (s *StoreImplementation) SaveCredential(name string, vc *verifiable.Credent
 		return errors.New("credential name already exists")
 	}
 
-	vcBytes, err := vc.MarshalJSON()
+	vcBytes, err := vc.MarshalOriginal()
 	if err != nil {
 		return fmt.Errorf("failed to marshal vc: %w", err)
 	}
@@ -118,7 +154,7 @@ func (s *StoreImplementation) SaveCredential(name string, vc *verifiable.Credent
 		id = uuid.New().String()
 	}
 
-	if e := s.store.Put(id, vcBytes); e != nil {
+	if e := s.putPayload(id, vcBytes); e != nil {
 		return fmt.Errorf("failed to put vc: %w", e)
 	}
 
@@ -189,7 +225,7 @@ func (s *StoreImplementation) SavePresentation(name string, vp *verifiable.Prese
 		return fmt.Errorf("failed to marshal record: %w", err)
 	}
 
-	if err := s.store.Put(id, vpBytes); err != nil {
+	if err := s.putPayload(id, vpBytes); err != nil {
 		return fmt.Errorf("failed to put vp: %w", err)
 	}
 
@@ -198,12 +234,12 @@ func (s *StoreImplementation) SavePresentation(name string, vp *verifiable.Prese
 
 // GetCredential retrieves a verifiable credential based on ID.
 func (s *StoreImplementation) GetCredential(id string) (*verifiable.Credential, error) {
-	vcBytes, err := s.store.Get(id)
+	vcBytes, err := s.getPayload(id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vc: %w", err)
 	}
 
-	vc, err := verifiable.ParseCredential(vcBytes, verifiable.WithDisabledProofCheck())
+	vc, err := verifiable.ParseCredential(vcBytes, verifiable.WithDisabledProofCheck(), verifiable.WithOriginalJWT())
 	if err != nil {
 		return nil, fmt.Errorf("new credential failed: %w", err)
 	}
@@ -213,7 +249,7 @@ func (s *StoreImplementation) GetCredential(id string) (*verifiable.Credential,
 
 // GetPresentation retrieves a verifiable presentation based on ID.
 func (s *StoreImplementation) GetPresentation(id string) (*verifiable.Presentation, error) {
-	vpBytes, err := s.store.Get(id)
+	vpBytes, err := s.getPayload(id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vc: %w", err)
 	}
@@ -311,8 +347,399 @@ func (s *StoreImplementation) RemovePresentationByName(name string) error {
 	return nil
 }
 
+// ReplaceCredential atomically replaces the verifiable credential saved under the given name (e.g. by its refreshed
+// version). MyDID and TheirDID of the existing record are kept unless provided in options.
+func (s *StoreImplementation) ReplaceCredential(name string, vc *verifiable.Credential, opts ...Opt) error {
+	if name == "" {
+		return errors.New("credential name is mandatory")
+	}
+
+	recordBytes, err := s.store.Get(credentialNameDataKey(name))
+	if err != nil {
+		return fmt.Errorf("fetch credential record based on name : %w", err)
+	}
+
+	var existing Record
+
+	err = json.Unmarshal(recordBytes, &existing)
+	if err != nil {
+		return fmt.Errorf("failed unmarshal record : %w", err)
+	}
+
+	vcBytes, err := vc.MarshalOriginal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal vc: %w", err)
+	}
+
+	id := vc.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	s.payloadMutex.Lock()
+	defer s.payloadMutex.Unlock()
+
+	vcBytes, err = s.sealPayload(id, vcBytes)
+	if err != nil {
+		return fmt.Errorf("failed to seal vc: %w", err)
+	}
+
+	o := &options{MyDID: existing.MyDID, TheirDID: existing.TheirDID}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	recordBytes, err = json.Marshal(&Record{
+		ID:         id,
+		Name:       name,
+		Context:    vc.Context,
+		Type:       vc.Types,
+		MyDID:      o.MyDID,
+		TheirDID:   o.TheirDID,
+		SubjectID:  getVCSubjectID(vc),
+		Tags:       existing.Tags,
+		Collection: existing.Collection,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	ops := []storage.Operation{
+		{Key: id, Value: vcBytes},
+		{Key: credentialNameDataKey(name), Value: recordBytes, Tags: credentialRecordTags(&existing)},
+	}
+
+	if existing.ID != id {
+		// the old credential is deleted within the same batch (nil value).
+		ops = append(ops, storage.Operation{Key: existing.ID})
+	}
+
+	if err = s.store.Batch(ops); err != nil {
+		return fmt.Errorf("failed to replace vc: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateCredentialName renames the verifiable credential saved under the given name, the credential and the other
+// fields of its record are kept.
+func (s *StoreImplementation) UpdateCredentialName(name, newName string) error {
+	if name == "" || newName == "" {
+		return errors.New("credential name is mandatory")
+	}
+
+	record, err := s.getCredentialRecord(name)
+	if err != nil {
+		return err
+	}
+
+	if name == newName {
+		return nil
+	}
+
+	id, err := s.GetCredentialIDByName(newName)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get credential id using name : %w", err)
+	}
+
+	if id != "" {
+		return errors.New("credential name already exists")
+	}
+
+	record.Name = newName
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	err = s.store.Batch([]storage.Operation{
+		{Key: credentialNameDataKey(newName), Value: recordBytes, Tags: credentialRecordTags(record)},
+		{Key: credentialNameDataKey(name)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rename vc: %w", err)
+	}
+
+	return nil
+}
+
+// AddCredentialTags adds the tags to the record of the verifiable credential saved under the given name, the tags
+// already present are ignored.
+func (s *StoreImplementation) AddCredentialTags(name string, tags ...string) error {
+	return s.updateCredentialTags(name, func(existing []string) []string {
+		for _, tag := range tags {
+			if tag != "" && !contains(existing, tag) {
+				existing = append(existing, tag)
+			}
+		}
+
+		return existing
+	})
+}
+
+// RemoveCredentialTags removes the tags from the record of the verifiable credential saved under the given name, the
+// tags not present are ignored.
+func (s *StoreImplementation) RemoveCredentialTags(name string, tags ...string) error {
+	return s.updateCredentialTags(name, func(existing []string) []string {
+		var kept []string
+
+		for _, tag := range existing {
+			if !contains(tags, tag) {
+				kept = append(kept, tag)
+			}
+		}
+
+		return kept
+	})
+}
+
+func (s *StoreImplementation) updateCredentialTags(name string, update func([]string) []string) error {
+	if name == "" {
+		return errors.New("credential name is mandatory")
+	}
+
+	record, err := s.getCredentialRecord(name)
+	if err != nil {
+		return err
+	}
+
+	record.Tags = update(record.Tags)
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	err = s.store.Put(credentialNameDataKey(name), recordBytes, credentialRecordTags(record)...)
+	if err != nil {
+		return fmt.Errorf("failed to update vc record: %w", err)
+	}
+
+	return nil
+}
+
+// CreateCollection creates a named collection to group the verifiable credentials in.
+func (s *StoreImplementation) CreateCollection(name string) error {
+	err := validateCollectionName(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.store.Get(collectionDataKey(name))
+	if err == nil {
+		return errors.New("collection already exists")
+	} else if !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get collection : %w", err)
+	}
+
+	collectionBytes, err := json.Marshal(&Collection{Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to marshal collection: %w", err)
+	}
+
+	err = s.store.Put(collectionDataKey(name), collectionBytes, storage.Tag{Name: collectionKey})
+	if err != nil {
+		return fmt.Errorf("failed to put collection: %w", err)
+	}
+
+	return nil
+}
+
+// GetCollections returns the names of the collections, in alphabetical order.
+func (s *StoreImplementation) GetCollections() ([]string, error) {
+	itr, err := s.store.Query(collectionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query store: %w", err)
+	}
+
+	defer storage.Close(itr, logger)
+
+	var names []string
+
+	for {
+		more, errNext := itr.Next()
+		if errNext != nil {
+			return nil, fmt.Errorf("failed to get next set of data from iterator: %w", errNext)
+		}
+
+		if !more {
+			break
+		}
+
+		value, errValue := itr.Value()
+		if errValue != nil {
+			return nil, fmt.Errorf("failed to get value from iterator: %w", errValue)
+		}
+
+		var collection Collection
+
+		errValue = json.Unmarshal(value, &collection)
+		if errValue != nil {
+			return nil, fmt.Errorf("failed to unmarshal collection : %w", errValue)
+		}
+
+		names = append(names, collection.Name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// RemoveCollection removes the collection, its verifiable credentials are kept out of any collection.
+func (s *StoreImplementation) RemoveCollection(name string) error {
+	if name == "" {
+		return errors.New("collection name is mandatory")
+	}
+
+	err := s.getCollection(name)
+	if err != nil {
+		return err
+	}
+
+	records, err := s.GetCredentialsByCollection(name)
+	if err != nil {
+		return err
+	}
+
+	ops := make([]storage.Operation, 0, len(records)+1)
+
+	for _, record := range records {
+		record.Collection = ""
+
+		recordBytes, errMarshal := json.Marshal(record)
+		if errMarshal != nil {
+			return fmt.Errorf("failed to marshal record: %w", errMarshal)
+		}
+
+		ops = append(ops, storage.Operation{
+			Key: credentialNameDataKey(record.Name), Value: recordBytes, Tags: credentialRecordTags(record),
+		})
+	}
+
+	// the collection is deleted within the same batch (nil value).
+	ops = append(ops, storage.Operation{Key: collectionDataKey(name)})
+
+	if err = s.store.Batch(ops); err != nil {
+		return fmt.Errorf("failed to remove collection: %w", err)
+	}
+
+	return nil
+}
+
+// MoveCredential moves the verifiable credential saved under the given name to the collection, or out of any
+// collection if the collection is empty.
+func (s *StoreImplementation) MoveCredential(name, collection string) error {
+	if name == "" {
+		return errors.New("credential name is mandatory")
+	}
+
+	if collection != "" {
+		err := s.getCollection(collection)
+		if err != nil {
+			return err
+		}
+	}
+
+	record, err := s.getCredentialRecord(name)
+	if err != nil {
+		return err
+	}
+
+	record.Collection = collection
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	err = s.store.Put(credentialNameDataKey(name), recordBytes, credentialRecordTags(record)...)
+	if err != nil {
+		return fmt.Errorf("failed to update vc record: %w", err)
+	}
+
+	return nil
+}
+
+// GetCredentialsByCollection retrieves the records of the verifiable credentials of the collection.
+func (s *StoreImplementation) GetCredentialsByCollection(collection string) ([]*Record, error) {
+	err := validateCollectionName(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getAllRecords(credentialCollectionTag + ":" + collection)
+}
+
+func (s *StoreImplementation) getCollection(name string) error {
+	_, err := s.store.Get(collectionDataKey(name))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("collection %s not found", name)
+	} else if err != nil {
+		return fmt.Errorf("get collection : %w", err)
+	}
+
+	return nil
+}
+
+func validateCollectionName(name string) error {
+	if name == "" {
+		return errors.New("collection name is mandatory")
+	}
+
+	// the collection names are the values of the tags of the credential records
+	if strings.Contains(name, ":") {
+		return errors.New("collection name must not contain ':'")
+	}
+
+	return nil
+}
+
+// credentialRecordTags returns the storage tags of the credential record, its collection is tagged so that the
+// credentials can be queried by collection.
+func credentialRecordTags(record *Record) []storage.Tag {
+	tags := []storage.Tag{{Name: credentialNameKey}}
+
+	if record.Collection != "" {
+		tags = append(tags, storage.Tag{Name: credentialCollectionTag, Value: record.Collection})
+	}
+
+	return tags
+}
+
+func (s *StoreImplementation) getCredentialRecord(name string) (*Record, error) {
+	recordBytes, err := s.store.Get(credentialNameDataKey(name))
+	if err != nil {
+		return nil, fmt.Errorf("fetch credential record based on name : %w", err)
+	}
+
+	var r Record
+
+	err = json.Unmarshal(recordBytes, &r)
+	if err != nil {
+		return nil, fmt.Errorf("failed unmarshal record : %w", err)
+	}
+
+	return &r, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *StoreImplementation) remove(id, recordKey string) error {
+	s.payloadMutex.Lock()
 	err := s.store.Delete(id)
+	s.payloadMutex.Unlock()
+
 	if err != nil {
 		return fmt.Errorf("unable to delete from store : %w", err)
 	}
@@ -384,3 +811,7 @@ func credentialNameDataKey(name string) string {
 func presentationNameDataKey(name string) string {
 	return fmt.Sprintf(presentationNameDataKeyPattern, name)
 }
+
+func collectionDataKey(name string) string {
+	return fmt.Sprintf(collectionDataKeyPattern, name)
+}
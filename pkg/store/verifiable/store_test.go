@@ -8,15 +8,19 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
@@ -358,6 +362,33 @@ func TestGetVC(t *testing.T) {
 		require.NotEmpty(t, vc)
 	})
 
+	t.Run("test success - vc decoded from JWT", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		udVC, err := verifiable.ParseCredential([]byte(udCredential))
+		require.NoError(t, err)
+
+		claims, err := udVC.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWT, err := claims.MarshalJWS(verifiable.EdDSA, &stubSigner{}, "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1")
+		require.NoError(t, err)
+
+		jwtVC, err := verifiable.ParseCredential([]byte(vcJWT), verifiable.WithDisabledProofCheck(),
+			verifiable.WithOriginalJWT())
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, jwtVC))
+
+		// the original JWT is stored
+		vc, err := s.GetCredential("http://example.edu/credentials/1872")
+		require.NoError(t, err)
+		original, err := vc.MarshalOriginal()
+		require.NoError(t, err)
+		require.Equal(t, vcJWT, string(original))
+	})
+
 	t.Run("test error from store get", func(t *testing.T) {
 		s, err := New(&mockprovider.Provider{
 			StorageProviderValue: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
@@ -386,6 +417,12 @@ func TestGetVC(t *testing.T) {
 	})
 }
 
+type stubSigner struct{}
+
+func (s *stubSigner) Sign([]byte) ([]byte, error) {
+	return []byte("signature"), nil
+}
+
 func TestGetCredentialIDBasedOnName(t *testing.T) {
 	t.Run("test get credential based on name - success", func(t *testing.T) {
 		rbytes, err := json.Marshal(&Record{
@@ -813,6 +850,405 @@ func TestRemoveVC(t *testing.T) {
 	})
 }
 
+func TestReplaceVC(t *testing.T) {
+	t.Run("test replace vc - success", func(t *testing.T) {
+		const (
+			MyDID    = "MyDID"
+			TheirDID = "TheirDID"
+		)
+
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"},
+			WithMyDID(MyDID), WithTheirDID(TheirDID)))
+
+		udVC := &verifiable.Credential{
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			ID:      "http://example.edu/credentials/1872",
+			Types:   []string{"VerifiableCredential"},
+			Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+			Issued:  util.NewTime(time.Now()),
+		}
+		require.NoError(t, s.ReplaceCredential(sampleCredentialName, udVC))
+
+		id, err := s.GetCredentialIDByName(sampleCredentialName)
+		require.NoError(t, err)
+		require.Equal(t, udVC.ID, id)
+
+		vc, err := s.GetCredential(id)
+		require.NoError(t, err)
+		require.Equal(t, udVC.ID, vc.ID)
+
+		_, err = s.GetCredential("vc1")
+		require.Error(t, err)
+
+		records, err := s.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, MyDID, records[0].MyDID)
+		require.Equal(t, TheirDID, records[0].TheirDID)
+		require.Equal(t, udVC.Types, records[0].Type)
+
+		require.NoError(t, s.ReplaceCredential(sampleCredentialName, udVC, WithTheirDID("NewTheirDID")))
+
+		records, err = s.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, MyDID, records[0].MyDID)
+		require.Equal(t, "NewTheirDID", records[0].TheirDID)
+	})
+
+	t.Run("test replace vc - empty name", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		err = s.ReplaceCredential("", &verifiable.Credential{ID: "vc1"})
+		require.EqualError(t, err, "credential name is mandatory")
+	})
+
+	t.Run("test replace vc - credential not found", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		err = s.ReplaceCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "fetch credential record based on name")
+	})
+
+	t.Run("test replace vc - error from store batch", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store:    make(map[string]mockstore.DBEntry),
+				ErrBatch: fmt.Errorf("error batch"),
+			}),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"}))
+
+		err = s.ReplaceCredential(sampleCredentialName, &verifiable.Credential{ID: "vc2"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error batch")
+
+		id, err := s.GetCredentialIDByName(sampleCredentialName)
+		require.NoError(t, err)
+		require.Equal(t, "vc1", id)
+	})
+}
+
+func TestUpdateCredentialName(t *testing.T) {
+	t.Run("test rename vc - success", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"},
+			WithMyDID("MyDID")))
+		require.NoError(t, s.AddCredentialTags(sampleCredentialName, "work"))
+
+		require.NoError(t, s.UpdateCredentialName(sampleCredentialName, "newName"))
+
+		_, err = s.GetCredentialIDByName(sampleCredentialName)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		id, err := s.GetCredentialIDByName("newName")
+		require.NoError(t, err)
+		require.Equal(t, "vc1", id)
+
+		records, err := s.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, "newName", records[0].Name)
+		require.Equal(t, "MyDID", records[0].MyDID)
+		require.Equal(t, []string{"work"}, records[0].Tags)
+
+		require.NoError(t, s.UpdateCredentialName("newName", "newName"))
+	})
+
+	t.Run("test rename vc - empty name", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		require.EqualError(t, s.UpdateCredentialName("", "newName"), "credential name is mandatory")
+		require.EqualError(t, s.UpdateCredentialName(sampleCredentialName, ""), "credential name is mandatory")
+	})
+
+	t.Run("test rename vc - credential not found", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		err = s.UpdateCredentialName(sampleCredentialName, "newName")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "fetch credential record based on name")
+	})
+
+	t.Run("test rename vc - name already exists", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"}))
+		require.NoError(t, s.SaveCredential("newName", &verifiable.Credential{ID: "vc2"}))
+
+		require.EqualError(t, s.UpdateCredentialName(sampleCredentialName, "newName"),
+			"credential name already exists")
+	})
+
+	t.Run("test rename vc - error from store batch", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store:    make(map[string]mockstore.DBEntry),
+				ErrBatch: fmt.Errorf("error batch"),
+			}),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"}))
+
+		err = s.UpdateCredentialName(sampleCredentialName, "newName")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error batch")
+
+		id, err := s.GetCredentialIDByName(sampleCredentialName)
+		require.NoError(t, err)
+		require.Equal(t, "vc1", id)
+	})
+}
+
+func TestCredentialTags(t *testing.T) {
+	t.Run("test add and remove tags - success", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"}))
+
+		require.NoError(t, s.AddCredentialTags(sampleCredentialName, "work", "travel"))
+		require.NoError(t, s.AddCredentialTags(sampleCredentialName, "travel", "", "health"))
+
+		records, err := s.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, []string{"work", "travel", "health"}, records[0].Tags)
+
+		require.NoError(t, s.RemoveCredentialTags(sampleCredentialName, "travel", "unknown"))
+
+		records, err = s.GetCredentials()
+		require.NoError(t, err)
+		require.Equal(t, []string{"work", "health"}, records[0].Tags)
+
+		// tags are kept when the credential is replaced
+		require.NoError(t, s.ReplaceCredential(sampleCredentialName, &verifiable.Credential{ID: "vc2"}))
+
+		records, err = s.GetCredentials()
+		require.NoError(t, err)
+		require.Equal(t, []string{"work", "health"}, records[0].Tags)
+
+		require.NoError(t, s.RemoveCredentialTags(sampleCredentialName, "work", "health"))
+
+		records, err = s.GetCredentials()
+		require.NoError(t, err)
+		require.Empty(t, records[0].Tags)
+	})
+
+	t.Run("test tags - empty name", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		require.EqualError(t, s.AddCredentialTags("", "work"), "credential name is mandatory")
+		require.EqualError(t, s.RemoveCredentialTags("", "work"), "credential name is mandatory")
+	})
+
+	t.Run("test tags - credential not found", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		err = s.AddCredentialTags(sampleCredentialName, "work")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "fetch credential record based on name")
+	})
+
+	t.Run("test tags - error from store put", func(t *testing.T) {
+		store := &mockstore.MockStore{Store: make(map[string]mockstore.DBEntry)}
+
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(store),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"}))
+
+		store.ErrPut = fmt.Errorf("error put")
+
+		err = s.AddCredentialTags(sampleCredentialName, "work")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error put")
+	})
+}
+
+func TestCredentialCollections(t *testing.T) {
+	t.Run("test collections - success", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential("vc1", &verifiable.Credential{ID: "vc1"}))
+		require.NoError(t, s.SaveCredential("vc2", &verifiable.Credential{ID: "vc2"}))
+		require.NoError(t, s.SaveCredential("vc3", &verifiable.Credential{ID: "vc3"}))
+
+		collections, err := s.GetCollections()
+		require.NoError(t, err)
+		require.Empty(t, collections)
+
+		require.NoError(t, s.CreateCollection("work"))
+		require.NoError(t, s.CreateCollection("travel"))
+
+		collections, err = s.GetCollections()
+		require.NoError(t, err)
+		require.Equal(t, []string{"travel", "work"}, collections)
+
+		require.NoError(t, s.MoveCredential("vc1", "work"))
+		require.NoError(t, s.MoveCredential("vc2", "work"))
+		require.NoError(t, s.MoveCredential("vc3", "travel"))
+
+		records, err := s.GetCredentialsByCollection("work")
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		for _, r := range records {
+			require.Equal(t, "work", r.Collection)
+		}
+
+		// the collection is kept when the credential is renamed, tagged or replaced
+		require.NoError(t, s.UpdateCredentialName("vc3", "renamed"))
+		require.NoError(t, s.AddCredentialTags("renamed", "flight"))
+		require.NoError(t, s.ReplaceCredential("renamed", &verifiable.Credential{ID: "vc4"}))
+
+		records, err = s.GetCredentialsByCollection("travel")
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, "renamed", records[0].Name)
+		require.Equal(t, "vc4", records[0].ID)
+
+		// moved to another collection, then out of any collection
+		require.NoError(t, s.MoveCredential("vc2", "travel"))
+
+		records, err = s.GetCredentialsByCollection("work")
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+
+		require.NoError(t, s.MoveCredential("vc1", ""))
+
+		records, err = s.GetCredentialsByCollection("work")
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		// the credentials of the removed collection are kept
+		require.NoError(t, s.RemoveCollection("travel"))
+
+		collections, err = s.GetCollections()
+		require.NoError(t, err)
+		require.Equal(t, []string{"work"}, collections)
+
+		records, err = s.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+
+		for _, r := range records {
+			require.Empty(t, r.Collection)
+		}
+	})
+
+	t.Run("test collections - validation errors", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"}))
+
+		require.EqualError(t, s.CreateCollection(""), "collection name is mandatory")
+		require.EqualError(t, s.CreateCollection("a:b"), "collection name must not contain ':'")
+
+		require.NoError(t, s.CreateCollection("work"))
+		require.EqualError(t, s.CreateCollection("work"), "collection already exists")
+
+		require.EqualError(t, s.MoveCredential("", "work"), "credential name is mandatory")
+		require.EqualError(t, s.MoveCredential(sampleCredentialName, "unknown"), "collection unknown not found")
+
+		err = s.MoveCredential("unknown", "work")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "fetch credential record based on name")
+
+		require.EqualError(t, s.RemoveCollection(""), "collection name is mandatory")
+		require.EqualError(t, s.RemoveCollection("unknown"), "collection unknown not found")
+
+		_, err = s.GetCredentialsByCollection("")
+		require.EqualError(t, err, "collection name is mandatory")
+	})
+
+	t.Run("test collections - store errors", func(t *testing.T) {
+		store := &mockstore.MockStore{Store: make(map[string]mockstore.DBEntry)}
+
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(store),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"}))
+		require.NoError(t, s.CreateCollection("work"))
+
+		store.ErrPut = fmt.Errorf("error put")
+
+		err = s.CreateCollection("travel")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error put")
+
+		err = s.MoveCredential(sampleCredentialName, "work")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error put")
+
+		store.ErrPut = nil
+		store.ErrBatch = fmt.Errorf("error batch")
+
+		err = s.RemoveCollection("work")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error batch")
+
+		store.ErrBatch = nil
+		store.ErrQuery = fmt.Errorf("error query")
+
+		_, err = s.GetCollections()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error query")
+
+		err = s.RemoveCollection("work")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error query")
+
+		store.ErrQuery = nil
+		store.ErrGet = fmt.Errorf("error get")
+
+		err = s.CreateCollection("travel")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error get")
+
+		err = s.MoveCredential(sampleCredentialName, "work")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error get")
+	})
+}
+
 func TestRemoveVP(t *testing.T) {
 	t.Run("test remove vp - success", func(t *testing.T) {
 		s, err := New(&mockprovider.Provider{
@@ -0,0 +1,235 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bluele/gcache"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	// DefaultCacheTTL is the default time a resolved DID document is served from the cache.
+	DefaultCacheTTL = 5 * time.Minute
+	// DefaultCacheMaxEntries is the default maximum number of DID documents in the cache.
+	DefaultCacheMaxEntries = 1000
+)
+
+var logger = log.New("aries-framework/vdr")
+
+// CacheOption configures the CachingRegistry.
+type CacheOption func(c *CachingRegistry)
+
+// WithCacheTTL sets the time a resolved DID document is served from the cache, DefaultCacheTTL by default.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(c *CachingRegistry) {
+		c.ttl = ttl
+	}
+}
+
+// WithMethodCacheTTL overrides the cache TTL of the DIDs of the given method, a TTL of 0 disables the caching of
+// the method (e.g. did:peer DIDs which are resolved from the local store anyway).
+func WithMethodCacheTTL(method string, ttl time.Duration) CacheOption {
+	return func(c *CachingRegistry) {
+		c.methodTTLs[method] = ttl
+	}
+}
+
+// WithCacheMaxEntries sets the maximum number of DID documents in the cache, the least recently used are evicted
+// first. DefaultCacheMaxEntries by default.
+func WithCacheMaxEntries(maxEntries int) CacheOption {
+	return func(c *CachingRegistry) {
+		c.maxEntries = maxEntries
+	}
+}
+
+// WithStaleWhileRevalidate sets for how long after its TTL an expired DID document is still returned from the
+// cache while it is resolved again in the background. Disabled by default.
+func WithStaleWhileRevalidate(window time.Duration) CacheOption {
+	return func(c *CachingRegistry) {
+		c.staleWhileRevalidate = window
+	}
+}
+
+// CachingRegistry is a vdrapi.Registry decorator caching the resolved DID documents. The DID documents updated
+// or deactivated through the registry are invalidated, the others can be invalidated explicitly.
+type CachingRegistry struct {
+	vdrapi.Registry
+	cache                gcache.Cache
+	ttl                  time.Duration
+	methodTTLs           map[string]time.Duration
+	maxEntries           int
+	staleWhileRevalidate time.Duration
+	now                  func() time.Time
+
+	mu         sync.Mutex
+	refreshing map[string]struct{}
+}
+
+type cacheEntry struct {
+	docResolution *diddoc.DocResolution
+	resolvedAt    time.Time
+}
+
+// NewCachingRegistry decorates the registry with a DID document cache.
+func NewCachingRegistry(registry vdrapi.Registry, opts ...CacheOption) *CachingRegistry {
+	c := &CachingRegistry{
+		Registry:   registry,
+		ttl:        DefaultCacheTTL,
+		methodTTLs: make(map[string]time.Duration),
+		maxEntries: DefaultCacheMaxEntries,
+		now:        time.Now,
+		refreshing: make(map[string]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.cache = gcache.New(c.maxEntries).LRU().Build()
+
+	return c
+}
+
+// Resolve did document, from the cache if it was resolved within the TTL of its method. The resolutions with
+// options are not cached as the options may change the result.
+func (c *CachingRegistry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	didMethod, err := GetDidMethod(did)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := c.methodTTL(didMethod)
+	if ttl <= 0 || len(opts) > 0 {
+		return c.Registry.Resolve(did, opts...)
+	}
+
+	if value, errGet := c.cache.Get(did); errGet == nil {
+		entry := value.(*cacheEntry)
+
+		age := c.now().Sub(entry.resolvedAt)
+
+		if age < ttl {
+			return entry.docResolution, nil
+		}
+
+		if age < ttl+c.staleWhileRevalidate {
+			c.revalidate(did)
+
+			return entry.docResolution, nil
+		}
+	}
+
+	return c.resolve(did)
+}
+
+// Update did document and invalidate its cached resolution.
+func (c *CachingRegistry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	defer c.Invalidate(didDoc.ID)
+
+	return c.Registry.Update(didDoc, opts...)
+}
+
+// Deactivate did document and invalidate its cached resolution.
+func (c *CachingRegistry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
+	defer c.Invalidate(did)
+
+	return c.Registry.Deactivate(did, opts...)
+}
+
+// Invalidate removes the cached resolution of the DID.
+func (c *CachingRegistry) Invalidate(did string) {
+	c.cache.Remove(did)
+}
+
+// Purge removes all the cached resolutions.
+func (c *CachingRegistry) Purge() {
+	c.cache.Purge()
+}
+
+// AddVDR registers a VDR in the decorated registry, if it supports the registration of VDRs.
+func (c *CachingRegistry) AddVDR(method vdrapi.VDR) {
+	if r, ok := c.Registry.(interface{ AddVDR(vdrapi.VDR) }); ok {
+		r.AddVDR(method)
+
+		// the new VDR takes precedence over the cached resolutions
+		c.Purge()
+	}
+}
+
+// RemoveVDR unregisters the VDRs of the DID method from the decorated registry, if it supports the registration
+// of VDRs.
+func (c *CachingRegistry) RemoveVDR(didMethod string) error {
+	r, ok := c.Registry.(interface{ RemoveVDR(string) error })
+	if !ok {
+		return fmt.Errorf("did method %s not supported for vdr", didMethod)
+	}
+
+	defer c.Purge()
+
+	return r.RemoveVDR(didMethod)
+}
+
+func (c *CachingRegistry) methodTTL(didMethod string) time.Duration {
+	if ttl, ok := c.methodTTLs[didMethod]; ok {
+		return ttl
+	}
+
+	return c.ttl
+}
+
+func (c *CachingRegistry) resolve(did string) (*diddoc.DocResolution, error) {
+	docResolution, err := c.Registry.Resolve(did)
+	if err != nil {
+		if errors.Is(err, vdrapi.ErrNotFound) {
+			c.Invalidate(did)
+		}
+
+		return nil, err
+	}
+
+	err = c.cache.Set(did, &cacheEntry{docResolution: docResolution, resolvedAt: c.now()})
+	if err != nil {
+		logger.Warnf("failed to cache the resolution of %s: %v", did, err)
+	}
+
+	return docResolution, nil
+}
+
+// revalidate resolves the DID in the background, unless it is already being resolved.
+func (c *CachingRegistry) revalidate(did string) {
+	c.mu.Lock()
+
+	if _, ok := c.refreshing[did]; ok {
+		c.mu.Unlock()
+
+		return
+	}
+
+	c.refreshing[did] = struct{}{}
+
+	c.mu.Unlock()
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.refreshing, did)
+			c.mu.Unlock()
+		}()
+
+		if _, err := c.resolve(did); err != nil {
+			logger.Warnf("failed to revalidate the cached resolution of %s: %v", did, err)
+		}
+	}()
+}
@@ -0,0 +1,283 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+type countingVDR struct {
+	mockvdr.MockVDR
+	mu    sync.Mutex
+	reads int
+	err   error
+}
+
+func newCountingVDR() *countingVDR {
+	v := &countingVDR{}
+	v.AcceptValue = true
+	v.ReadFunc = func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+
+		v.reads++
+
+		if v.err != nil {
+			return nil, v.err
+		}
+
+		return &did.DocResolution{DIDDocument: &did.Doc{ID: fmt.Sprintf("%s#%d", didID, v.reads)}}, nil
+	}
+
+	return v
+}
+
+func (v *countingVDR) readCount() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.reads
+}
+
+func (v *countingVDR) setErr(err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.err = err
+}
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newCachingRegistry(v vdrapi.VDR, opts ...CacheOption) (*CachingRegistry, *clock) {
+	c := &clock{now: time.Now()}
+
+	r := NewCachingRegistry(New(WithVDR(v)), opts...)
+	r.now = func() time.Time { return c.now }
+
+	return r, c
+}
+
+func TestCachingRegistry_Resolve(t *testing.T) {
+	const didID = "did:example:123"
+
+	t.Run("test cached within ttl", func(t *testing.T) {
+		v := newCountingVDR()
+		r, c := newCachingRegistry(v, WithCacheTTL(time.Minute))
+
+		docResolution, err := r.Resolve(didID)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#1", docResolution.DIDDocument.ID)
+
+		c.advance(30 * time.Second)
+
+		docResolution, err = r.Resolve(didID)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#1", docResolution.DIDDocument.ID)
+		require.Equal(t, 1, v.readCount())
+
+		c.advance(time.Minute)
+
+		docResolution, err = r.Resolve(didID)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#2", docResolution.DIDDocument.ID)
+	})
+
+	t.Run("test method ttl", func(t *testing.T) {
+		v := newCountingVDR()
+		r, c := newCachingRegistry(v, WithCacheTTL(time.Minute), WithMethodCacheTTL("example", time.Hour),
+			WithMethodCacheTTL("peer", 0))
+
+		_, err := r.Resolve(didID)
+		require.NoError(t, err)
+
+		c.advance(30 * time.Minute)
+
+		_, err = r.Resolve(didID)
+		require.NoError(t, err)
+		require.Equal(t, 1, v.readCount())
+
+		// caching disabled for did:peer
+		_, err = r.Resolve("did:peer:123")
+		require.NoError(t, err)
+		_, err = r.Resolve("did:peer:123")
+		require.NoError(t, err)
+		require.Equal(t, 3, v.readCount())
+	})
+
+	t.Run("test resolution with options not cached", func(t *testing.T) {
+		v := newCountingVDR()
+		r, _ := newCachingRegistry(v)
+
+		_, err := r.Resolve(didID, vdrapi.WithOption("k1", "v1"))
+		require.NoError(t, err)
+		_, err = r.Resolve(didID, vdrapi.WithOption("k1", "v1"))
+		require.NoError(t, err)
+		require.Equal(t, 2, v.readCount())
+	})
+
+	t.Run("test max entries", func(t *testing.T) {
+		v := newCountingVDR()
+		r, _ := newCachingRegistry(v, WithCacheMaxEntries(1))
+
+		_, err := r.Resolve("did:example:1")
+		require.NoError(t, err)
+		_, err = r.Resolve("did:example:2")
+		require.NoError(t, err)
+		_, err = r.Resolve("did:example:1")
+		require.NoError(t, err)
+		require.Equal(t, 3, v.readCount())
+	})
+
+	t.Run("test stale while revalidate", func(t *testing.T) {
+		v := newCountingVDR()
+		r, c := newCachingRegistry(v, WithCacheTTL(time.Minute), WithStaleWhileRevalidate(time.Minute))
+
+		_, err := r.Resolve(didID)
+		require.NoError(t, err)
+
+		c.advance(90 * time.Second)
+
+		// the stale document is returned while it is resolved in the background
+		docResolution, err := r.Resolve(didID)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#1", docResolution.DIDDocument.ID)
+
+		require.Eventually(t, func() bool {
+			docResolution, err = r.Resolve(didID)
+
+			return err == nil && docResolution.DIDDocument.ID == didID+"#2"
+		}, time.Second, 10*time.Millisecond)
+
+		// past the stale window the document is resolved again
+		c.advance(3 * time.Minute)
+
+		docResolution, err = r.Resolve(didID)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#3", docResolution.DIDDocument.ID)
+	})
+
+	t.Run("test stale document kept when revalidation fails", func(t *testing.T) {
+		v := newCountingVDR()
+		r, c := newCachingRegistry(v, WithCacheTTL(time.Minute), WithStaleWhileRevalidate(time.Minute))
+
+		_, err := r.Resolve(didID)
+		require.NoError(t, err)
+
+		v.setErr(errors.New("resolver unavailable"))
+		c.advance(90 * time.Second)
+
+		docResolution, err := r.Resolve(didID)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#1", docResolution.DIDDocument.ID)
+
+		require.Eventually(t, func() bool { return v.readCount() == 2 }, time.Second, 10*time.Millisecond)
+
+		docResolution, err = r.Resolve(didID)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#1", docResolution.DIDDocument.ID)
+	})
+
+	t.Run("test errors not cached", func(t *testing.T) {
+		v := newCountingVDR()
+		v.setErr(vdrapi.ErrNotFound)
+
+		r, _ := newCachingRegistry(v)
+
+		_, err := r.Resolve(didID)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+
+		v.setErr(nil)
+
+		_, err = r.Resolve(didID)
+		require.NoError(t, err)
+		require.Equal(t, 2, v.readCount())
+
+		_, err = r.Resolve("invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wrong format did input")
+	})
+}
+
+func TestCachingRegistry_Invalidate(t *testing.T) {
+	const didID = "did:example:123"
+
+	v := newCountingVDR()
+	r, _ := newCachingRegistry(v)
+
+	_, err := r.Resolve(didID)
+	require.NoError(t, err)
+
+	r.Invalidate(didID)
+
+	_, err = r.Resolve(didID)
+	require.NoError(t, err)
+	require.Equal(t, 2, v.readCount())
+
+	require.NoError(t, r.Update(&did.Doc{ID: didID}))
+
+	_, err = r.Resolve(didID)
+	require.NoError(t, err)
+	require.Equal(t, 3, v.readCount())
+
+	require.NoError(t, r.Deactivate(didID))
+
+	_, err = r.Resolve(didID)
+	require.NoError(t, err)
+	require.Equal(t, 4, v.readCount())
+
+	r.Purge()
+
+	_, err = r.Resolve(didID)
+	require.NoError(t, err)
+	require.Equal(t, 5, v.readCount())
+}
+
+func TestCachingRegistry_AddRemoveVDR(t *testing.T) {
+	const didID = "did:example:123"
+
+	v := newCountingVDR()
+	r, _ := newCachingRegistry(v)
+
+	_, err := r.Resolve(didID)
+	require.NoError(t, err)
+
+	added := newCountingVDR()
+	r.AddVDR(added)
+
+	_, err = r.Resolve(didID)
+	require.NoError(t, err)
+	require.Equal(t, 1, added.readCount())
+
+	require.NoError(t, r.RemoveVDR("example"))
+
+	_, err = r.Resolve(didID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "did method example not supported for vdr")
+
+	r = NewCachingRegistry(&mockvdr.MockVDRegistry{})
+	r.AddVDR(added)
+
+	err = r.RemoveVDR("example")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "did method example not supported for vdr")
+}
@@ -0,0 +1,156 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cheqd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	didResolutionMediaType = `application/ld+json;profile="https://w3id.org/did-resolution"`
+	maxResponseSize        = 10 << 20
+
+	mainnet = "mainnet"
+	testnet = "testnet"
+)
+
+// ErrResourceNotFound is returned when the DID-Linked Resource is not found.
+var ErrResourceNotFound = errors.New("resource not found")
+
+var logger = log.New("aries-framework/pkg/vdr/cheqd")
+
+// Resource is a DID-Linked Resource of a did:cheqd DID.
+type Resource struct {
+	// DIDURL identifies the resource, eg: did:cheqd:mainnet:{id}/resources/{resourceID} or
+	// did:cheqd:mainnet:{id}?resourceName={name}&resourceType={type}.
+	DIDURL string
+	// MediaType is the media type of the content (eg: application/json).
+	MediaType string
+	// Content of the resource.
+	Content []byte
+}
+
+// Read resolves a did:cheqd did.
+func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if err := validateDID(didID); err != nil {
+		return nil, fmt.Errorf("error resolving did:cheqd did --> %w", err)
+	}
+
+	data, _, err := v.get(didID, didResolutionMediaType)
+	if errors.Is(err, ErrResourceNotFound) {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:cheqd did --> %w", err)
+	}
+
+	docResolution, err := did.ParseDocumentResolution(data)
+	if err == nil {
+		return docResolution, nil
+	}
+
+	if !errors.Is(err, did.ErrDIDDocumentNotExist) {
+		return nil, fmt.Errorf("error resolving did:cheqd did --> error parsing did resolution --> %w", err)
+	}
+
+	doc, err := did.ParseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:cheqd did --> error parsing did doc --> %w", err)
+	}
+
+	return &did.DocResolution{DIDDocument: doc}, nil
+}
+
+// ResolveResource resolves the DID-Linked Resource identified by the DID URL, either by its ID
+// (did:cheqd:mainnet:{id}/resources/{resourceID}) or by its name and type
+// (did:cheqd:mainnet:{id}?resourceName={name}&resourceType={type}). It returns ErrResourceNotFound if the
+// resource does not exist.
+func (v *VDR) ResolveResource(didURL string) (*Resource, error) {
+	// the fragment is resolved by the client
+	didURL = strings.SplitN(didURL, "#", 2)[0]
+
+	i := strings.IndexAny(didURL, "/?")
+	if i < 0 {
+		return nil, fmt.Errorf("resolve resource: %s is not a resource DID URL", didURL)
+	}
+
+	if err := validateDID(didURL[:i]); err != nil {
+		return nil, fmt.Errorf("resolve resource: %w", err)
+	}
+
+	content, mediaType, err := v.get(didURL, "*/*")
+	if err != nil {
+		return nil, fmt.Errorf("resolve resource %s: %w", didURL, err)
+	}
+
+	return &Resource{DIDURL: didURL, MediaType: mediaType, Content: content}, nil
+}
+
+// get returns the content and the media type of GET {resolverURL}/{didURL}.
+func (v *VDR) get(didURL, accept string) ([]byte, string, error) {
+	req, err := http.NewRequest(http.MethodGet, v.resolverURL+"/"+didURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Accept", accept)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("http request unsuccessful --> %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading http response body --> %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, "", ErrResourceNotFound
+	default:
+		return nil, "", fmt.Errorf("resolver returned status code [%d]: %s", resp.StatusCode, body)
+	}
+
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// validateDID checks that the DID is a did:cheqd DID of the mainnet or testnet namespace.
+func validateDID(didID string) error {
+	parsedDID, err := did.Parse(didID)
+	if err != nil {
+		return err
+	}
+
+	parts := strings.Split(parsedDID.MethodSpecificID, ":")
+
+	if parsedDID.Method != namespace || len(parts) != 2 || (parts[0] != mainnet && parts[0] != testnet) {
+		return fmt.Errorf("invalid did:cheqd identifier: %s", didID)
+	}
+
+	return nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cheqd
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	validDID   = "did:cheqd:mainnet:zF7rhDBfUt9d1gJPjx7s1JXfUY7oVWkY"
+	resourceID = "9ba3922e-d5f5-4f53-b265-fc0d4e988c77"
+
+	validDoc = `{
+  		"@context": ["https://w3id.org/did/v1"],
+  		"id": "did:cheqd:mainnet:zF7rhDBfUt9d1gJPjx7s1JXfUY7oVWkY"
+	}`
+
+	validResolution = `{
+		"@context": "https://w3id.org/did-resolution/v1",
+		"didResolutionMetadata": {"contentType": "application/did+ld+json"},
+		"didDocument": ` + validDoc + `,
+		"didDocumentMetadata": {"deactivated": false}
+	}`
+
+	statusList = `{"encodedList": "H4sIAAAAAAAAA-3BMQEAAADCoPVPbQwfoAAAAAAAAAAAAAAAAAAAAIC3AYbSVKsAQAAA"}`
+)
+
+type mockHTTPClient struct {
+	err error
+}
+
+func (c *mockHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, c.err
+}
+
+func newResolver(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/identifiers/" + validDID:
+			require.Equal(t, didResolutionMediaType, r.Header.Get("Accept"))
+
+			_, err := w.Write([]byte(validResolution))
+			require.NoError(t, err)
+		case "/identifiers/did:cheqd:testnet:document":
+			_, err := w.Write([]byte(validDoc))
+			require.NoError(t, err)
+		case "/identifiers/did:cheqd:testnet:invalid":
+			_, err := w.Write([]byte(`{`))
+			require.NoError(t, err)
+		case "/identifiers/did:cheqd:testnet:nodoc":
+			_, err := w.Write([]byte(`{"didDocument": {}}`))
+			require.NoError(t, err)
+		case "/identifiers/did:cheqd:testnet:error":
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		case "/identifiers/" + validDID + "/resources/" + resourceID,
+			"/identifiers/" + validDID + "?resourceName=status&resourceType=StatusList2021Revocation":
+			w.Header().Set("Content-Type", "application/json")
+
+			_, err := w.Write([]byte(statusList))
+			require.NoError(t, err)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestVDR(t *testing.T) {
+	v := New()
+	require.Equal(t, ResolverURL, v.resolverURL)
+	require.True(t, v.Accept("cheqd"))
+	require.False(t, v.Accept("web"))
+	require.True(t, v.Supports(vdrapi.Read))
+	require.False(t, v.Supports(vdrapi.Create))
+
+	_, err := v.Create(nil)
+	require.EqualError(t, err, "not supported")
+	require.EqualError(t, v.Update(nil), "not supported")
+	require.EqualError(t, v.Deactivate(validDID), "not supported")
+	require.NoError(t, v.Close())
+}
+
+func TestVDR_Read(t *testing.T) {
+	s := newResolver(t)
+	defer s.Close()
+
+	v := New(WithResolverURL(s.URL+"/identifiers/"), WithHTTPClient(s.Client()))
+
+	t.Run("test resolve did success", func(t *testing.T) {
+		docResolution, err := v.Read(validDID)
+		require.NoError(t, err)
+		require.Equal(t, validDID, docResolution.DIDDocument.ID)
+		require.NotNil(t, docResolution.DocumentMetadata)
+	})
+
+	t.Run("test resolve did document", func(t *testing.T) {
+		docResolution, err := v.Read("did:cheqd:testnet:document")
+		require.NoError(t, err)
+		require.Equal(t, validDID, docResolution.DIDDocument.ID)
+	})
+
+	t.Run("test invalid did", func(t *testing.T) {
+		for _, didID := range []string{"did:cheqd", "did:cheqd:123", "did:cheqd:devnet:123", "did:web:mainnet:123"} {
+			_, err := v.Read(didID)
+			require.Error(t, err, didID)
+			require.Contains(t, err.Error(), "error resolving did:cheqd did", didID)
+		}
+	})
+
+	t.Run("test not found", func(t *testing.T) {
+		_, err := v.Read("did:cheqd:testnet:unknown")
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("test resolver errors", func(t *testing.T) {
+		_, err := v.Read("did:cheqd:testnet:error")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolver returned status code [500]")
+
+		_, err = v.Read("did:cheqd:testnet:invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error parsing did resolution")
+
+		_, err = v.Read("did:cheqd:testnet:nodoc")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error parsing did resolution")
+	})
+
+	t.Run("test request error", func(t *testing.T) {
+		_, err := New(WithHTTPClient(&mockHTTPClient{err: errors.New("connection refused")})).Read(validDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "http request unsuccessful")
+	})
+}
+
+func TestVDR_ResolveResource(t *testing.T) {
+	s := newResolver(t)
+	defer s.Close()
+
+	v := New(WithResolverURL(s.URL+"/identifiers"), WithHTTPClient(s.Client()))
+
+	t.Run("test resolve resource by id", func(t *testing.T) {
+		resource, err := v.ResolveResource(validDID + "/resources/" + resourceID + "#encodedList")
+		require.NoError(t, err)
+		require.Equal(t, validDID+"/resources/"+resourceID, resource.DIDURL)
+		require.Equal(t, "application/json", resource.MediaType)
+		require.JSONEq(t, statusList, string(resource.Content))
+	})
+
+	t.Run("test resolve resource by name and type", func(t *testing.T) {
+		resource, err := v.ResolveResource(validDID + "?resourceName=status&resourceType=StatusList2021Revocation")
+		require.NoError(t, err)
+		require.JSONEq(t, statusList, string(resource.Content))
+	})
+
+	t.Run("test resource not found", func(t *testing.T) {
+		_, err := v.ResolveResource(validDID + "/resources/unknown")
+		require.True(t, errors.Is(err, ErrResourceNotFound))
+	})
+
+	t.Run("test invalid did url", func(t *testing.T) {
+		_, err := v.ResolveResource(validDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not a resource DID URL")
+
+		_, err = v.ResolveResource("did:web:example.com/resources/" + resourceID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did:cheqd identifier")
+	})
+}
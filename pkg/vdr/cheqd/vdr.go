@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package cheqd implements the resolution of did:cheqd DIDs and of their DID-Linked Resources (e.g. status lists,
+// schemas) through the cheqd DID resolver API.
+package cheqd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	namespace = "cheqd"
+
+	// ResolverURL is the default URL of the identifiers of the cheqd DID resolver.
+	ResolverURL = "https://resolver.cheqd.net/1.0/identifiers"
+)
+
+// HTTPClient performs the requests to the cheqd DID resolver.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Option configures the did:cheqd VDR.
+type Option func(v *VDR)
+
+// WithResolverURL sets the URL of the identifiers of the cheqd DID resolver, ResolverURL by default.
+func WithResolverURL(url string) Option {
+	return func(v *VDR) {
+		v.resolverURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for the cheqd DID resolver requests.
+func WithHTTPClient(client HTTPClient) Option {
+	return func(v *VDR) {
+		v.client = client
+	}
+}
+
+// VDR implements the VDR interface for did:cheqd, the DID documents are read only.
+type VDR struct {
+	resolverURL string
+	client      HTTPClient
+}
+
+// New creates a new did:cheqd VDR.
+func New(opts ...Option) *VDR {
+	v := &VDR{
+		resolverURL: ResolverURL,
+		client:      &http.Client{},
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Accept method of the VDR interface.
+func (v *VDR) Accept(method string) bool {
+	return method == namespace
+}
+
+// Supports reports whether the operation is supported, the did:cheqd DIDs can only be read.
+func (v *VDR) Supports(op vdrapi.Operation) bool {
+	return op == vdrapi.Read
+}
+
+// Create did doc, not supported: did:cheqd DIDs are written to the cheqd network by its own tooling.
+func (v *VDR) Create(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+// Update did doc.
+func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Deactivate did doc.
+func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Close method of the VDR interface.
+func (v *VDR) Close() error {
+	return nil
+}
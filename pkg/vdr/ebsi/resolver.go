@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ebsi
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	// legal entity identifiers are base58-btc multibase encoded.
+	multibaseBase58BTC = "z"
+
+	didLDJSON       = "application/did+ld+json"
+	maxResponseSize = 1 << 20
+)
+
+var logger = log.New("aries-framework/pkg/vdr/ebsi")
+
+// Read resolves a did:ebsi did from the EBSI DID registry.
+func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	parsedDID, err := did.Parse(didID)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:ebsi did --> %w", err)
+	}
+
+	if parsedDID.Method != namespace || !strings.HasPrefix(parsedDID.MethodSpecificID, multibaseBase58BTC) {
+		return nil, fmt.Errorf("error resolving did:ebsi did --> invalid did:ebsi identifier: %s", didID)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, v.registryURL+"/"+url.PathEscape(didID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:ebsi did --> create request: %w", err)
+	}
+
+	req.Header.Set("Accept", didLDJSON)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:ebsi did --> http request unsuccessful --> %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:ebsi did --> error reading http response body --> %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, vdrapi.ErrNotFound
+	default:
+		return nil, fmt.Errorf("error resolving did:ebsi did --> registry returned status code [%d]: %s",
+			resp.StatusCode, body)
+	}
+
+	doc, err := did.ParseDocument(body)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:ebsi did --> error parsing did doc --> %w", err)
+	}
+
+	return &did.DocResolution{DIDDocument: doc}, nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
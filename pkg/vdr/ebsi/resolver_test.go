@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ebsi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	validDID = "did:ebsi:zZeKyEJfUTGwajhNyNX928z"
+
+	validDoc = `{
+  		"@context": ["https://w3id.org/did/v1"],
+  		"id": "did:ebsi:zZeKyEJfUTGwajhNyNX928z"
+	}`
+)
+
+type mockHTTPClient struct {
+	err error
+}
+
+func (c *mockHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, c.err
+}
+
+func TestVDR(t *testing.T) {
+	v := New()
+	require.Equal(t, DIDRegistryURL, v.registryURL)
+	require.True(t, v.Accept("ebsi"))
+	require.False(t, v.Accept("web"))
+	require.True(t, v.Supports(vdrapi.Read))
+	require.False(t, v.Supports(vdrapi.Create))
+
+	_, err := v.Create(nil)
+	require.EqualError(t, err, "not supported")
+	require.EqualError(t, v.Update(nil), "not supported")
+	require.EqualError(t, v.Deactivate(validDID), "not supported")
+	require.NoError(t, v.Close())
+}
+
+func TestVDR_Read(t *testing.T) {
+	t.Run("test resolve did success", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/identifiers/"+validDID, r.URL.Path)
+			require.Equal(t, didLDJSON, r.Header.Get("Accept"))
+
+			_, err := w.Write([]byte(validDoc))
+			require.NoError(t, err)
+		}))
+		defer s.Close()
+
+		v := New(WithRegistryURL(s.URL+"/identifiers/"), WithHTTPClient(s.Client()))
+
+		docResolution, err := v.Read(validDID)
+		require.NoError(t, err)
+		require.Equal(t, validDID, docResolution.DIDDocument.ID)
+	})
+
+	t.Run("test invalid did", func(t *testing.T) {
+		v := New()
+
+		_, err := v.Read("did:ebsi")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error resolving did:ebsi did")
+
+		_, err = v.Read("did:ebsi:123")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did:ebsi identifier")
+
+		_, err = v.Read("did:web:zZeKyEJfUTGwajhNyNX928z")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did:ebsi identifier")
+	})
+
+	t.Run("test not found", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(http.NotFound))
+		defer s.Close()
+
+		v := New(WithRegistryURL(s.URL))
+
+		_, err := v.Read(validDID)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("test registry error", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer s.Close()
+
+		v := New(WithRegistryURL(s.URL))
+
+		_, err := v.Read(validDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "registry returned status code [503]")
+	})
+
+	t.Run("test invalid did doc", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{}`))
+			require.NoError(t, err)
+		}))
+		defer s.Close()
+
+		v := New(WithRegistryURL(s.URL))
+
+		_, err := v.Read(validDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error parsing did doc")
+	})
+
+	t.Run("test request error", func(t *testing.T) {
+		v := New(WithHTTPClient(&mockHTTPClient{err: errors.New("connection refused")}))
+
+		_, err := v.Read(validDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "http request unsuccessful")
+	})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ebsi implements the resolution of did:ebsi DIDs (legal entities of the European Blockchain Services
+// Infrastructure) through the DID registry API of EBSI.
+package ebsi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	namespace = "ebsi"
+
+	// DIDRegistryURL is the default URL of the identifiers of the EBSI DID registry (pilot network).
+	DIDRegistryURL = "https://api-pilot.ebsi.eu/did-registry/v4/identifiers"
+)
+
+// HTTPClient performs the requests to the EBSI DID registry.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Option configures the did:ebsi VDR.
+type Option func(v *VDR)
+
+// WithRegistryURL sets the URL of the identifiers of the EBSI DID registry, DIDRegistryURL by default.
+func WithRegistryURL(url string) Option {
+	return func(v *VDR) {
+		v.registryURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for the EBSI DID registry requests.
+func WithHTTPClient(client HTTPClient) Option {
+	return func(v *VDR) {
+		v.client = client
+	}
+}
+
+// VDR implements the VDR interface for did:ebsi, the DID documents are read only.
+type VDR struct {
+	registryURL string
+	client      HTTPClient
+}
+
+// New creates a new did:ebsi VDR.
+func New(opts ...Option) *VDR {
+	v := &VDR{
+		registryURL: DIDRegistryURL,
+		client:      &http.Client{},
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Accept method of the VDR interface.
+func (v *VDR) Accept(method string) bool {
+	return method == namespace
+}
+
+// Supports reports whether the operation is supported, the did:ebsi DIDs can only be read.
+func (v *VDR) Supports(op vdrapi.Operation) bool {
+	return op == vdrapi.Read
+}
+
+// Create did doc, not supported: did:ebsi DIDs are registered through the EBSI onboarding.
+func (v *VDR) Create(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+// Update did doc.
+func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Deactivate did doc.
+func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Close method of the VDR interface.
+func (v *VDR) Close() error {
+	return nil
+}
@@ -23,6 +23,8 @@ const (
 	X25519PubKeyMultiCodec = 0xec
 	// ED25519PubKeyMultiCodec for Ed25519 public key in multicodec table.
 	ED25519PubKeyMultiCodec = 0xed
+	// Secp256k1PubKeyMultiCodec for secp256k1 public key (compressed) in multicodec table.
+	Secp256k1PubKeyMultiCodec = 0xe7
 	// BLS12381g2PubKeyMultiCodec for BLS12-381 G2 public key in multicodec table.
 	BLS12381g2PubKeyMultiCodec = 0xeb
 	// BLS12381g1g2PubKeyMultiCodec for BLS12-381 G1G2 public key in multicodec table.
@@ -112,6 +114,7 @@ func PubKeyFromFingerprint(fingerprint string) ([]byte, uint64, error) {
 // note: for NIST P ECDSA keys, the raw value does not have the compression point.
 //	In order to use elliptic.Unmarshal() with the raw value, the uncompressed point ([]byte{4}) must be prepended.
 //	see https://github.com/golang/go/blob/master/src/crypto/elliptic/elliptic.go#L319.
+// For secp256k1 keys, the raw value is the compressed key (see btcec.ParsePubKey()).
 func PubKeyFromDIDKey(didKey string) ([]byte, error) {
 	id, err := did.Parse(didKey)
 	if err != nil {
@@ -132,7 +135,7 @@ func PubKeyFromDIDKey(didKey string) ([]byte, error) {
 
 	switch code {
 	case X25519PubKeyMultiCodec, ED25519PubKeyMultiCodec, BLS12381g2PubKeyMultiCodec, BLS12381g1g2PubKeyMultiCodec,
-		P256PubKeyMultiCodec, P384PubKeyMultiCodec, P521PubKeyMultiCodec:
+		P256PubKeyMultiCodec, P384PubKeyMultiCodec, P521PubKeyMultiCodec, Secp256k1PubKeyMultiCodec:
 		break
 	default:
 		return nil, fmt.Errorf("unsupported key multicodec code [0x%x]", code)
@@ -251,3 +251,11 @@ func TestDIDResolver_Accept(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, resolver.accept("example"))
 }
+
+func TestDIDResolver_Supports(t *testing.T) {
+	resolver, err := New("localhost:8080")
+	require.NoError(t, err)
+	require.True(t, resolver.Supports(vdrapi.Read))
+	require.False(t, resolver.Supports(vdrapi.Create))
+	require.False(t, resolver.Supports(vdrapi.Update))
+}
@@ -56,6 +56,11 @@ func (v *VDR) Accept(method string) bool {
 	return v.accept(method)
 }
 
+// Supports reports whether the operation is supported, the universal resolver only reads DIDs.
+func (v *VDR) Supports(op vdrapi.Operation) bool {
+	return op == vdrapi.Read
+}
+
 // Create did doc.
 func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
 	return nil, fmt.Errorf("build not supported in http binding vdr")
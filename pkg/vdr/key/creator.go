@@ -11,9 +11,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec"
+
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did/keyagreement"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
-	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
@@ -24,6 +26,9 @@ const (
 	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
 	bls12381G2Key2020          = "Bls12381G2Key2020"
 	jsonWebKey2020             = "JsonWebKey2020"
+	ecdsaSecp256k1Key2019      = "EcdsaSecp256k1VerificationKey2019"
+
+	secp256k1Crv = "secp256k1"
 )
 
 // Create new DID document for didDoc.
@@ -54,12 +59,17 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocR
 		return nil, err
 	}
 
-	didKey, keyID = fingerprint.CreateDIDKeyByCode(keyCode, didDoc.VerificationMethod[0].Value)
+	fpValue, err := fingerprintValue(keyCode, didDoc.VerificationMethod[0].Value)
+	if err != nil {
+		return nil, err
+	}
+
+	didKey, keyID = fingerprint.CreateDIDKeyByCode(keyCode, fpValue)
 	publicKey = did.NewVerificationMethodFromBytes(keyID, didDoc.VerificationMethod[0].Type, didKey,
 		didDoc.VerificationMethod[0].Value)
 
 	if didDoc.VerificationMethod[0].Type == ed25519VerificationKey2018 {
-		keyAgr, err = keyAgreementFromEd25519(didKey, didDoc.VerificationMethod[0].Value)
+		keyAgr, err = keyagreement.DeriveVerificationMethod(publicKey)
 		if err != nil {
 			return nil, err
 		}
@@ -87,6 +97,8 @@ func getKeyCode(keyType kms.KeyType, verificationMethod *did.VerificationMethod)
 		keyCode = fingerprint.ED25519PubKeyMultiCodec
 	case bls12381G2Key2020:
 		keyCode = fingerprint.BLS12381g2PubKeyMultiCodec
+	case ecdsaSecp256k1Key2019:
+		keyCode = fingerprint.Secp256k1PubKeyMultiCodec
 	case jsonWebKey2020:
 		if keyType == "" {
 			return fetchECKeyCodeFromVerMethod(verificationMethod)
@@ -99,6 +111,8 @@ func getKeyCode(keyType kms.KeyType, verificationMethod *did.VerificationMethod)
 			keyCode = fingerprint.P384PubKeyMultiCodec
 		case kms.ECDSAP521TypeDER, kms.ECDSAP521TypeIEEEP1363:
 			keyCode = fingerprint.P521PubKeyMultiCodec
+		case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363:
+			keyCode = fingerprint.Secp256k1PubKeyMultiCodec
 		default:
 			return 0, errors.New("invalid jsonWebKey2020 key type")
 		}
@@ -110,6 +124,10 @@ func getKeyCode(keyType kms.KeyType, verificationMethod *did.VerificationMethod)
 }
 
 func fetchECKeyCodeFromVerMethod(method *did.VerificationMethod) (uint64, error) {
+	if jwk := method.JSONWebKey(); jwk != nil && jwk.Crv == secp256k1Crv {
+		return fingerprint.Secp256k1PubKeyMultiCodec, nil
+	}
+
 	ecdsaCodesByKeyLen := map[int]uint64{
 		64:  fingerprint.P256PubKeyMultiCodec,
 		96:  fingerprint.P384PubKeyMultiCodec,
@@ -119,6 +137,21 @@ func fetchECKeyCodeFromVerMethod(method *did.VerificationMethod) (uint64, error)
 	return ecdsaCodesByKeyLen[len(method.Value)], nil
 }
 
+// fingerprintValue returns the value of the key in its did:key fingerprint: secp256k1 keys are fingerprinted in their
+// compressed form.
+func fingerprintValue(keyCode uint64, value []byte) ([]byte, error) {
+	if keyCode != fingerprint.Secp256k1PubKeyMultiCodec {
+		return value, nil
+	}
+
+	pubKey, err := btcec.ParsePubKey(value, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid secp256k1 public key: %w", err)
+	}
+
+	return pubKey.SerializeCompressed(), nil
+}
+
 func createDoc(pubKey, keyAgreement *did.VerificationMethod, didKey string) *did.Doc {
 	// Created/Updated time
 	t := time.Now()
@@ -142,16 +175,3 @@ func createDoc(pubKey, keyAgreement *did.VerificationMethod, didKey string) *did
 		Updated:              &t,
 	}
 }
-
-func keyAgreementFromEd25519(didKey string, ed25519PubKey []byte) (*did.VerificationMethod, error) {
-	curve25519PubKey, err := cryptoutil.PublicEd25519toCurve25519(ed25519PubKey)
-	if err != nil {
-		return nil, err
-	}
-
-	fp := fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, curve25519PubKey)
-	keyID := fmt.Sprintf("%s#%s", didKey, fp)
-	pubKey := did.NewVerificationMethodFromBytes(keyID, x25519KeyAgreementKey2019, didKey, curve25519PubKey)
-
-	return pubKey, nil
-}
@@ -93,6 +93,30 @@ func TestBuild(t *testing.T) {
 		assertP256Doc(t, docResolution.DIDDocument)
 	})
 
+	t.Run("build with secp256k1 key type", func(t *testing.T) {
+		// uncompressed secp256k1 key, fingerprinted in its compressed form.
+		const pubKeyBase58Secp256k1 = "QBL1VZCdC6hs61fqgwdoNjwh93UBcTg7imvRF9qShKfHR4DQ4RjKyqupL4f2mKduhzF5SzWzDbxKgGwnTsN2zSkg" //nolint:lll
+
+		v := New()
+
+		pubKey := did.VerificationMethod{
+			Type:  ecdsaSecp256k1Key2019,
+			Value: base58.Decode(pubKeyBase58Secp256k1),
+		}
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}})
+		require.NoError(t, err)
+		require.NotNil(t, docResolution.DIDDocument)
+
+		assertSecp256k1Doc(t, docResolution.DIDDocument)
+
+		pubKey.Value = []byte("invalid key")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid secp256k1 public key")
+	})
+
 	t.Run("build with NIST P-384 key type", func(t *testing.T) {
 		v := New()
 
@@ -174,6 +198,18 @@ func assertP256Doc(t *testing.T, doc *did.Doc) {
 		"", "", "")
 }
 
+func assertSecp256k1Doc(t *testing.T, doc *did.Doc) {
+	// did key from https://w3c-ccg.github.io/did-method-key/#secp256k1
+	const (
+		didKey       = "did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme"
+		didKeyID     = "did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme#zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme" //nolint:lll
+		pubKeyBase58 = "QBL1VZCdC6hs61fqgwdoNjwh93UBcTg7imvRF9qShKfHR4DQ4RjKyqupL4f2mKduhzF5SzWzDbxKgGwnTsN2zSkg"
+	)
+
+	assertDualBase58Doc(t, doc, didKey, didKeyID, ecdsaSecp256k1Key2019, pubKeyBase58,
+		"", "", "")
+}
+
 func assertP384Doc(t *testing.T, doc *did.Doc) {
 	// did key from  https://w3c-ccg.github.io/did-method-key/#example-8
 	const (
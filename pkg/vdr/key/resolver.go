@@ -10,7 +10,10 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/btcsuite/btcd/btcec"
+
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did/keyagreement"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
@@ -47,6 +50,8 @@ func createDIDDocFromPubKey(kid string, code uint64, pubKeyBytes []byte) (*did.D
 		return createBase58DIDDoc(kid, bls12381G2Key2020, pubKeyBytes)
 	case fingerprint.P256PubKeyMultiCodec, fingerprint.P384PubKeyMultiCodec, fingerprint.P521PubKeyMultiCodec:
 		return createBase58DIDDoc(kid, jsonWebKey2020, pubKeyBytes)
+	case fingerprint.Secp256k1PubKeyMultiCodec:
+		return createSecp256k1DIDDoc(kid, pubKeyBytes)
 	}
 
 	return nil, fmt.Errorf("unsupported key multicodec code [0x%x]", code)
@@ -63,6 +68,23 @@ func createBase58DIDDoc(kid, keyType string, pubKeyBytes []byte) (*did.Doc, erro
 	return didDoc, nil
 }
 
+func createSecp256k1DIDDoc(kid string, pubKeyBytes []byte) (*did.Doc, error) {
+	didKey := fmt.Sprintf("did:key:%s", kid)
+
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("pub:key vdr Read: invalid secp256k1 key: %w", err)
+	}
+
+	// the verification method holds the uncompressed key, as expected by the secp256k1 signature verifiers.
+	// secp256k1 keys are signing keys only, the DID document has no keyAgreement.
+	keyID := fmt.Sprintf("%s#%s", didKey, kid)
+	publicKey := did.NewVerificationMethodFromBytes(keyID, ecdsaSecp256k1Key2019, didKey,
+		pubKey.SerializeUncompressed())
+
+	return createDoc(publicKey, nil, didKey), nil
+}
+
 func createEd25519DIDDoc(kid string, pubKeyBytes []byte) (*did.Doc, error) {
 	didKey := fmt.Sprintf("did:key:%s", kid)
 
@@ -70,14 +92,14 @@ func createEd25519DIDDoc(kid string, pubKeyBytes []byte) (*did.Doc, error) {
 	// it can be added and read here if needed. Below TODO is a reminder for this)
 	// TODO find a way to get the Encryption key as in creator.go
 	// for now keeping original ed25519 to X25519 key conversion as keyAgreement.
-	keyAgr, err := keyAgreementFromEd25519(didKey, pubKeyBytes)
+	keyID := fmt.Sprintf("%s#%s", didKey, kid)
+	publicKey := did.NewVerificationMethodFromBytes(keyID, ed25519VerificationKey2018, didKey, pubKeyBytes)
+
+	keyAgr, err := keyagreement.DeriveVerificationMethod(publicKey)
 	if err != nil {
 		return nil, fmt.Errorf("pub:key vdr Read: failed to fetch KeyAgreement: %w", err)
 	}
 
-	keyID := fmt.Sprintf("%s#%s", didKey, kid)
-	publicKey := did.NewVerificationMethodFromBytes(keyID, ed25519VerificationKey2018, didKey, pubKeyBytes)
-
 	didDoc := createDoc(publicKey, keyAgr, didKey)
 
 	return didDoc, nil
@@ -185,3 +185,16 @@ func TestRead521(t *testing.T) {
 		assertBase58Doc(t, docResolution.DIDDocument, k2, k2KID, jsonWebKey2020, k2Base58)
 	})
 }
+
+func TestReadSecp256k1(t *testing.T) {
+	v := New()
+
+	t.Run("resolve secp256k1 did:key without key agreement", func(t *testing.T) {
+		docResolution, err := v.Read("did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme")
+		require.NoError(t, err)
+		require.NotNil(t, docResolution.DIDDocument)
+		require.Empty(t, docResolution.DIDDocument.KeyAgreement)
+
+		assertSecp256k1Doc(t, docResolution.DIDDocument)
+	})
+}
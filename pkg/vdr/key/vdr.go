@@ -35,6 +35,11 @@ func (v *VDR) Accept(method string) bool {
 	return method == DIDMethod
 }
 
+// Supports reports whether the operation is supported, the did:key DIDs can be created and read.
+func (v *VDR) Supports(op vdrapi.Operation) bool {
+	return op == vdrapi.Read || op == vdrapi.Create
+}
+
 // Close frees resources being maintained by VDR.
 func (v *VDR) Close() error {
 	return nil
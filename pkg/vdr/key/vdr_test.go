@@ -34,6 +34,14 @@ func TestAccept(t *testing.T) {
 	})
 }
 
+func TestSupports(t *testing.T) {
+	v := New()
+	require.True(t, v.Supports(vdr.Read))
+	require.True(t, v.Supports(vdr.Create))
+	require.False(t, v.Supports(vdr.Update))
+	require.False(t, v.Supports(vdr.Deactivate))
+}
+
 func TestUpdate(t *testing.T) {
 	t.Run("test update", func(t *testing.T) {
 		v := New()
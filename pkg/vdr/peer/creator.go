@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did/keyagreement"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
@@ -93,7 +94,7 @@ func build(didDoc *did.Doc,
 			didDoc.Service[i].Type = v
 		}
 
-		if didDoc.Service[i].ServiceEndpoint == "" && docOpts.Values[DefaultServiceEndpoint] != nil {
+		if didDoc.Service[i].EndpointURI() == "" && docOpts.Values[DefaultServiceEndpoint] != nil {
 			v, ok := docOpts.Values[DefaultServiceEndpoint].(string)
 			if !ok {
 				return nil, fmt.Errorf("defaultServiceEndpoint not string")
@@ -124,14 +125,33 @@ func build(didDoc *did.Doc,
 		Relationship:       did.Authentication,
 	}}
 
-	didDoc, err := NewDoc(
-		[]did.VerificationMethod{publicKey},
+	docOptions := []did.DocOption{
 		did.WithService(service),
 		did.WithCreatedTime(t),
 		did.WithUpdatedTime(t),
 		did.WithAuthentication(authentication),
 		did.WithAssertion(assertion),
-	)
+	}
+
+	if docOpts.Values[DeriveKeyAgreement] != nil {
+		derive, ok := docOpts.Values[DeriveKeyAgreement].(bool)
+		if !ok {
+			return nil, fmt.Errorf("deriveKeyAgreement not boolean")
+		}
+
+		if derive {
+			keyAgreement, err := keyagreement.DeriveVerificationMethod(&publicKey)
+			if err != nil {
+				return nil, fmt.Errorf("derive key agreement: %w", err)
+			}
+
+			docOptions = append(docOptions, did.WithKeyAgreement([]did.Verification{
+				*did.NewEmbeddedVerification(keyAgreement, did.KeyAgreement),
+			}))
+		}
+	}
+
+	didDoc, err := NewDoc([]did.VerificationMethod{publicKey}, docOptions...)
 	if err != nil {
 		return nil, err
 	}
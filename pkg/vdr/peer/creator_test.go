@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
@@ -59,6 +60,21 @@ func TestDIDCreator(t *testing.T) {
 		require.Equal(t, routingKeys, docResolution.DIDDocument.Service[0].RoutingKeys)
 	})
 
+	t.Run("test derive key agreement", func(t *testing.T) {
+		c, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		docResolution, err := c.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdrapi.WithOption(DeriveKeyAgreement, true))
+		require.NoError(t, err)
+		require.Len(t, docResolution.DIDDocument.KeyAgreement, 1)
+		require.Equal(t, "X25519KeyAgreementKey2019", docResolution.DIDDocument.KeyAgreement[0].VerificationMethod.Type)
+
+		_, err = c.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdrapi.WithOption(DeriveKeyAgreement, "true"))
+		require.EqualError(t, err, "create peer DID : deriveKeyAgreement not boolean")
+	})
+
 	t.Run("test accept", func(t *testing.T) {
 		c, err := New(&storage.MockStoreProvider{})
 		require.NoError(t, err)
@@ -70,6 +86,16 @@ func TestDIDCreator(t *testing.T) {
 		accepted = c.Accept("peer")
 		require.True(t, accepted)
 	})
+
+	t.Run("test supports", func(t *testing.T) {
+		c, err := New(&storage.MockStoreProvider{})
+		require.NoError(t, err)
+
+		require.True(t, c.Supports(vdrapi.Read))
+		require.True(t, c.Supports(vdrapi.Create))
+		require.False(t, c.Supports(vdrapi.Update))
+		require.False(t, c.Supports(vdrapi.Deactivate))
+	})
 }
 
 func TestBuild(t *testing.T) {
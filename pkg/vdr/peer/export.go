@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Record is an exported peer DID document along with the history of its changes, used for the migration of the
+// peer DIDs from one agent to another.
+type Record struct {
+	ID     string          `json:"id"`
+	Deltas json.RawMessage `json:"deltas"`
+}
+
+// Export returns the stored peer DID documents, sorted by DID.
+func (v *VDR) Export() ([]Record, error) {
+	docs, err := v.list()
+	if err != nil {
+		return nil, fmt.Errorf("export peer DIDs: %w", err)
+	}
+
+	records := make([]Record, 0, len(docs))
+
+	for id, deltas := range docs {
+		val, errMarshal := json.Marshal(deltas)
+		if errMarshal != nil {
+			return nil, fmt.Errorf("export peer DIDs: JSON marshalling of document deltas failed: %w", errMarshal)
+		}
+
+		records = append(records, Record{ID: id, Deltas: val})
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+
+	return records, nil
+}
+
+// Import stores the exported peer DID documents, replacing the documents already stored with the same DIDs. The
+// records are validated before any of them is stored.
+func (v *VDR) Import(records []Record) error {
+	operations := make([]storage.Operation, 0, len(records))
+
+	for _, record := range records {
+		if err := validateRecord(record); err != nil {
+			return fmt.Errorf("import peer DID %s: %w", record.ID, err)
+		}
+
+		operations = append(operations, storage.Operation{
+			Key:   record.ID,
+			Value: record.Deltas,
+			Tags:  []storage.Tag{{Name: didTagName}},
+		})
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	if err := v.store.Batch(operations); err != nil {
+		return fmt.Errorf("import peer DIDs: %w", err)
+	}
+
+	return nil
+}
+
+// validateRecord checks that the genesis document of the record is the DID document of the record DID.
+func validateRecord(record Record) error {
+	if record.ID == "" {
+		return errors.New("ID is mandatory")
+	}
+
+	var deltas []docDelta
+
+	if err := json.Unmarshal(record.Deltas, &deltas); err != nil {
+		return fmt.Errorf("JSON unmarshalling of document deltas failed: %w", err)
+	}
+
+	if len(deltas) == 0 {
+		return errors.New("document deltas are mandatory")
+	}
+
+	doc, err := base64.URLEncoding.DecodeString(deltas[0].Change)
+	if err != nil {
+		return fmt.Errorf("decoding of document delta failed: %w", err)
+	}
+
+	document, err := did.ParseDocument(doc)
+	if err != nil {
+		return fmt.Errorf("document ParseDocument() failed: %w", err)
+	}
+
+	if document.ID != record.ID {
+		return fmt.Errorf("document ID %s does not match", document.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVDR_ExportImport(t *testing.T) {
+	t.Run("test export import", func(t *testing.T) {
+		source, _ := newStoreWithDIDs(t, "did:peer:2", "did:peer:1")
+
+		records, err := source.Export()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, "did:peer:1", records[0].ID)
+		require.Equal(t, "did:peer:2", records[1].ID)
+
+		// records survive a JSON round trip
+		data, err := json.Marshal(records)
+		require.NoError(t, err)
+
+		var imported []Record
+		require.NoError(t, json.Unmarshal(data, &imported))
+
+		target, _ := newStoreWithDIDs(t)
+		require.NoError(t, target.Import(imported))
+
+		doc, err := target.Get("did:peer:1")
+		require.NoError(t, err)
+		require.Equal(t, "did:peer:1", doc.ID)
+
+		// imported docs are listed
+		records, err = target.Export()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		require.NoError(t, target.Import(nil))
+	})
+
+	t.Run("test invalid records", func(t *testing.T) {
+		v, _ := newStoreWithDIDs(t, "did:peer:1")
+
+		records, err := v.Export()
+		require.NoError(t, err)
+
+		otherDoc := base64.URLEncoding.EncodeToString([]byte(`{"@context":["https://w3id.org/did/v1"],"id":"did:peer:2"}`))
+
+		for _, tc := range []struct {
+			record Record
+			err    string
+		}{
+			{Record{Deltas: records[0].Deltas}, "ID is mandatory"},
+			{Record{ID: "did:peer:1", Deltas: []byte(`{`)}, "JSON unmarshalling of document deltas failed"},
+			{Record{ID: "did:peer:1", Deltas: []byte(`[]`)}, "document deltas are mandatory"},
+			{Record{ID: "did:peer:1", Deltas: []byte(`[{"change":"%"}]`)}, "decoding of document delta failed"},
+			{Record{ID: "did:peer:1", Deltas: []byte(`[{"change":"e30="}]`)}, "document ParseDocument() failed"},
+			{Record{ID: "did:peer:1", Deltas: []byte(`[{"change":"` + otherDoc + `"}]`)}, "does not match"},
+		} {
+			err = v.Import([]Record{records[0], tc.record})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		}
+	})
+
+	t.Run("test store errors", func(t *testing.T) {
+		v, prov := newStoreWithDIDs(t, "did:peer:1")
+
+		records, err := v.Export()
+		require.NoError(t, err)
+
+		prov.Store.ErrBatch = errors.New("batch error")
+
+		err = v.Import(records)
+		require.EqualError(t, err, "import peer DIDs: batch error")
+
+		prov.Store.ErrQuery = errors.New("query error")
+
+		_, err = v.Export()
+		require.EqualError(t, err, "export peer DIDs: query peer DID store: query error")
+	})
+}
@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	// DefaultGCInterval is the default interval between two garbage collections of the peer DID documents.
+	DefaultGCInterval = time.Hour
+	// DefaultGCGracePeriod is the default age under which an unreferenced peer DID document is kept, as it may
+	// belong to a DID exchange which has not created its connection record yet.
+	DefaultGCGracePeriod = 24 * time.Hour
+)
+
+var logger = log.New("aries-framework/vdr/peer")
+
+// ConnectionLookup queries the connection records referencing the peer DIDs.
+type ConnectionLookup interface {
+	QueryConnectionRecords() ([]*connection.Record, error)
+}
+
+// References counts the connection records referencing each DID, as their own DID or as the DID of the other
+// agent.
+func References(lookup ConnectionLookup) (map[string]int, error) {
+	records, err := lookup.QueryConnectionRecords()
+	if err != nil {
+		return nil, fmt.Errorf("query connection records: %w", err)
+	}
+
+	refs := make(map[string]int)
+
+	for _, record := range records {
+		if record.MyDID != "" {
+			refs[record.MyDID]++
+		}
+
+		if record.TheirDID != "" {
+			refs[record.TheirDID]++
+		}
+	}
+
+	return refs, nil
+}
+
+// GCOption configures the GarbageCollector.
+type GCOption func(gc *GarbageCollector)
+
+// WithGCInterval sets the interval between two garbage collections, DefaultGCInterval by default.
+func WithGCInterval(interval time.Duration) GCOption {
+	return func(gc *GarbageCollector) {
+		gc.interval = interval
+	}
+}
+
+// WithGCGracePeriod sets the age under which an unreferenced peer DID document is kept, DefaultGCGracePeriod by
+// default.
+func WithGCGracePeriod(gracePeriod time.Duration) GCOption {
+	return func(gc *GarbageCollector) {
+		gc.gracePeriod = gracePeriod
+	}
+}
+
+// GarbageCollector removes the peer DID documents which are not referenced by any connection record anymore.
+type GarbageCollector struct {
+	vdr         *VDR
+	lookup      ConnectionLookup
+	interval    time.Duration
+	gracePeriod time.Duration
+	now         func() time.Time
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewGarbageCollector creates a garbage collector of the peer DID documents stored by the VDR.
+func NewGarbageCollector(v *VDR, lookup ConnectionLookup, opts ...GCOption) *GarbageCollector {
+	gc := &GarbageCollector{
+		vdr:         v,
+		lookup:      lookup,
+		interval:    DefaultGCInterval,
+		gracePeriod: DefaultGCGracePeriod,
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(gc)
+	}
+
+	return gc
+}
+
+// Collect removes the orphaned peer DID documents older than the grace period and returns their DIDs.
+func (gc *GarbageCollector) Collect() ([]string, error) {
+	docs, err := gc.vdr.list()
+	if err != nil {
+		return nil, fmt.Errorf("list peer DIDs: %w", err)
+	}
+
+	refs, err := References(gc.lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+
+	for id, deltas := range docs {
+		if refs[id] > 0 || len(deltas) == 0 || gc.now().Sub(deltas[0].ModifiedAt) < gc.gracePeriod {
+			continue
+		}
+
+		if err = gc.vdr.Delete(id); err != nil {
+			return removed, fmt.Errorf("delete peer DID %s: %w", id, err)
+		}
+
+		removed = append(removed, id)
+	}
+
+	return removed, nil
+}
+
+// Start collects the orphaned peer DID documents at every interval until Stop is called.
+func (gc *GarbageCollector) Start() {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	if gc.stop != nil {
+		return
+	}
+
+	gc.stop = make(chan struct{})
+	gc.done = make(chan struct{})
+
+	go gc.run(gc.stop, gc.done)
+}
+
+// Stop stops the periodic garbage collection and waits for the garbage collection in progress to complete.
+func (gc *GarbageCollector) Stop() {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	if gc.stop == nil {
+		return
+	}
+
+	close(gc.stop)
+	<-gc.done
+
+	gc.stop = nil
+	gc.done = nil
+}
+
+func (gc *GarbageCollector) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(gc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			removed, err := gc.Collect()
+			if err != nil {
+				logger.Errorf("peer DID garbage collection failed: %v", err)
+			}
+
+			if len(removed) > 0 {
+				logger.Infof("removed %d orphaned peer DID documents", len(removed))
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+type mockConnectionLookup struct {
+	records []*connection.Record
+	err     error
+}
+
+func (m *mockConnectionLookup) QueryConnectionRecords() ([]*connection.Record, error) {
+	return m.records, m.err
+}
+
+func newStoreWithDIDs(t *testing.T, ids ...string) (*VDR, *storage.MockStoreProvider) {
+	t.Helper()
+
+	prov := storage.NewMockStoreProvider()
+
+	v, err := New(prov)
+	require.NoError(t, err)
+
+	for _, id := range ids {
+		require.NoError(t, v.storeDID(&did.Doc{Context: []string{"https://w3id.org/did/v1"}, ID: id}, nil))
+	}
+
+	return v, prov
+}
+
+func TestReferences(t *testing.T) {
+	refs, err := References(&mockConnectionLookup{records: []*connection.Record{
+		{MyDID: "did:peer:1", TheirDID: "did:peer:2"},
+		{MyDID: "did:peer:1", TheirDID: "did:peer:3"},
+		{MyDID: "did:peer:4"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"did:peer:1": 2, "did:peer:2": 1, "did:peer:3": 1, "did:peer:4": 1}, refs)
+
+	_, err = References(&mockConnectionLookup{err: errors.New("query error")})
+	require.EqualError(t, err, "query connection records: query error")
+}
+
+func TestGarbageCollector_Collect(t *testing.T) {
+	t.Run("test orphaned docs removed", func(t *testing.T) {
+		v, _ := newStoreWithDIDs(t, "did:peer:1", "did:peer:2", "did:peer:3")
+
+		gc := NewGarbageCollector(v, &mockConnectionLookup{records: []*connection.Record{
+			{MyDID: "did:peer:1", TheirDID: "did:peer:2"},
+		}})
+		gc.now = func() time.Time { return time.Now().Add(DefaultGCGracePeriod) }
+
+		removed, err := gc.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:peer:3"}, removed)
+
+		_, err = v.Get("did:peer:3")
+		require.Error(t, err)
+
+		_, err = v.Get("did:peer:1")
+		require.NoError(t, err)
+	})
+
+	t.Run("test docs within grace period kept", func(t *testing.T) {
+		v, _ := newStoreWithDIDs(t, "did:peer:1")
+
+		gc := NewGarbageCollector(v, &mockConnectionLookup{}, WithGCGracePeriod(time.Hour))
+
+		removed, err := gc.Collect()
+		require.NoError(t, err)
+		require.Empty(t, removed)
+
+		gc.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+		removed, err = gc.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:peer:1"}, removed)
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		v, prov := newStoreWithDIDs(t, "did:peer:1")
+
+		gc := NewGarbageCollector(v, &mockConnectionLookup{err: errors.New("query error")}, WithGCGracePeriod(0))
+
+		_, err := gc.Collect()
+		require.EqualError(t, err, "query connection records: query error")
+
+		gc = NewGarbageCollector(v, &mockConnectionLookup{}, WithGCGracePeriod(0))
+
+		prov.Store.ErrDelete = errors.New("delete error")
+
+		_, err = gc.Collect()
+		require.EqualError(t, err, "delete peer DID did:peer:1: delete error")
+
+		prov.Store.ErrQuery = errors.New("query error")
+
+		_, err = gc.Collect()
+		require.EqualError(t, err, "list peer DIDs: query peer DID store: query error")
+	})
+}
+
+func TestGarbageCollector_StartStop(t *testing.T) {
+	v, _ := newStoreWithDIDs(t, "did:peer:1")
+
+	gc := NewGarbageCollector(v, &mockConnectionLookup{}, WithGCInterval(10*time.Millisecond), WithGCGracePeriod(0))
+
+	// stopping a collector which is not started is a no-op
+	gc.Stop()
+
+	gc.Start()
+	gc.Start()
+
+	require.Eventually(t, func() bool {
+		_, err := v.Get("did:peer:1")
+
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	gc.Stop()
+	gc.Stop()
+}
@@ -18,6 +18,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
+// didTagName tags the peer DID documents, so that they can be listed.
+const didTagName = "peerDID"
+
 // modifiedBy key/signature used to update the DID Document.
 type modifiedBy struct {
 	Key string `json:"key,omitempty"`
@@ -57,7 +60,66 @@ func (v *VDR) storeDID(doc *did.Doc, by *[]modifiedBy) error { //nolint: unparam
 		return fmt.Errorf("JSON marshalling of document deltas failed: %w", err)
 	}
 
-	return v.store.Put(doc.ID, val)
+	return v.store.Put(doc.ID, val, storage.Tag{Name: didTagName})
+}
+
+// Delete removes the Peer DID Document.
+func (v *VDR) Delete(id string) error {
+	if id == "" {
+		return errors.New("ID is mandatory")
+	}
+
+	return v.store.Delete(id)
+}
+
+// list returns the deltas of the stored Peer DID Documents by DID. The documents stored before they were tagged
+// are not listed.
+func (v *VDR) list() (map[string][]docDelta, error) {
+	iter, err := v.store.Query(didTagName)
+	if err != nil {
+		return nil, fmt.Errorf("query peer DID store: %w", err)
+	}
+
+	defer func() {
+		if errClose := iter.Close(); errClose != nil {
+			logger.Warnf("failed to close peer DID store iterator: %v", errClose)
+		}
+	}()
+
+	docs := make(map[string][]docDelta)
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("iterate peer DID store: %w", err)
+	}
+
+	for more {
+		key, errKey := iter.Key()
+		if errKey != nil {
+			return nil, fmt.Errorf("get peer DID: %w", errKey)
+		}
+
+		val, errValue := iter.Value()
+		if errValue != nil {
+			return nil, fmt.Errorf("get peer DID document deltas: %w", errValue)
+		}
+
+		var deltas []docDelta
+
+		err = json.Unmarshal(val, &deltas)
+		if err != nil {
+			return nil, fmt.Errorf("JSON unmarshalling of document deltas of %s failed: %w", key, err)
+		}
+
+		docs[key] = deltas
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("iterate peer DID store: %w", err)
+		}
+	}
+
+	return docs, nil
 }
 
 // Get returns Peer DID Document.
@@ -21,21 +21,46 @@ const (
 	DefaultServiceType = "defaultServiceType"
 	// DefaultServiceEndpoint default service endpoint.
 	DefaultServiceEndpoint = "defaultServiceEndpoint"
+	// DeriveKeyAgreement option to derive X25519 keyAgreement from the Ed25519 public key of created DID doc.
+	DeriveKeyAgreement = "deriveKeyAgreement"
 )
 
 // VDR implements building new peer dids.
 type VDR struct {
-	store storage.Store
+	store           storage.Store
+	acceptedMethods []string
+}
+
+// Option configures the peer vdr.
+type Option func(v *VDR)
+
+// WithAcceptedMethods makes the peer vdr accept the DIDs of other methods too, e.g. the did:sov DIDs
+// of the connections with the agents using unqualified DIDs, whose documents are only known from the connections.
+func WithAcceptedMethods(methods ...string) Option {
+	return func(v *VDR) {
+		v.acceptedMethods = append(v.acceptedMethods, methods...)
+	}
 }
 
 // New return new instance of peer vdr.
-func New(s storage.Provider) (*VDR, error) {
+func New(s storage.Provider, opts ...Option) (*VDR, error) {
 	didDBStore, err := s.OpenStore(StoreNamespace)
 	if err != nil {
 		return nil, fmt.Errorf("open store : %w", err)
 	}
 
-	return &VDR{store: didDBStore}, nil
+	err = s.SetStoreConfig(StoreNamespace, storage.StoreConfiguration{TagNames: []string{didTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("set store config : %w", err)
+	}
+
+	v := &VDR{store: didDBStore}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v, nil
 }
 
 // Update did doc.
@@ -50,5 +75,20 @@ func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
 
 // Accept did method.
 func (v *VDR) Accept(method string) bool {
-	return method == DIDMethod
+	if method == DIDMethod {
+		return true
+	}
+
+	for _, m := range v.acceptedMethods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Supports reports whether the operation is supported, the did:peer DIDs can be created and read.
+func (v *VDR) Supports(op vdrapi.Operation) bool {
+	return op == vdrapi.Read || op == vdrapi.Create
 }
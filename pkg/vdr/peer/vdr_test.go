@@ -35,3 +35,22 @@ func TestDeactivate(t *testing.T) {
 		require.Contains(t, err.Error(), "not supported")
 	})
 }
+
+func TestAccept(t *testing.T) {
+	t.Run("peer method", func(t *testing.T) {
+		v, err := New(&storage.MockStoreProvider{})
+		require.NoError(t, err)
+
+		require.True(t, v.Accept(DIDMethod))
+		require.False(t, v.Accept("sov"))
+	})
+
+	t.Run("accepted methods", func(t *testing.T) {
+		v, err := New(&storage.MockStoreProvider{}, WithAcceptedMethods("sov"))
+		require.NoError(t, err)
+
+		require.True(t, v.Accept(DIDMethod))
+		require.True(t, v.Accept("sov"))
+		require.False(t, v.Accept("key"))
+	})
+}
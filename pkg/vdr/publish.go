@@ -0,0 +1,128 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"fmt"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	didCommServiceIDFragment   = "#didcomm"
+)
+
+// ErrNoRecipientKey is returned by PublishDIDCommService when the DID document has no Ed25519 authentication key
+// to receive the DIDComm messages with.
+var ErrNoRecipientKey = errors.New("no ed25519 authentication key in the DID document")
+
+// PublishDIDCommService publishes the DIDComm service endpoint (and the routing keys) of the agent in the document
+// of the DID through the VDR of its DID method, unless the document already has a DIDComm service with the endpoint.
+// The recipient keys of the DIDComm service of the document are kept, the did:keys of its Ed25519 authentication
+// keys are used if it has none. It returns true if the document was updated.
+// The documents of the DID methods whose VDR does not support the updates (e.g. did:web documents served by a web
+// server) are expected to be published with a DIDComm service already, vdrapi.ErrOperationNotSupported is returned
+// if they have none.
+func PublishDIDCommService(registry vdrapi.Registry, did, endpoint string, routingKeys []string) (bool, error) {
+	docResolution, err := registry.Resolve(did)
+	if err != nil {
+		return false, fmt.Errorf("resolve %s: %w", did, err)
+	}
+
+	doc := docResolution.DIDDocument
+
+	current, found := diddoc.LookupService(doc, vdrapi.DIDCommServiceType)
+	if found && current.EndpointURI() == endpoint && equalKeys(current.EndpointRoutingKeys(), routingKeys) {
+		return false, nil
+	}
+
+	method, err := GetDidMethod(did)
+	if err != nil {
+		return false, err
+	}
+
+	if !registry.Supports(method, vdrapi.Update) {
+		if found {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("publish the DIDComm service of %s: %w", did, vdrapi.ErrOperationNotSupported)
+	}
+
+	svc := diddoc.Service{
+		ID:              did + didCommServiceIDFragment,
+		Type:            vdrapi.DIDCommServiceType,
+		ServiceEndpoint: endpoint,
+		RoutingKeys:     routingKeys,
+	}
+
+	if found {
+		svc.ID = current.ID
+		svc.RecipientKeys = current.RecipientKeys
+		svc.Accept = current.Accept
+	} else {
+		svc.RecipientKeys, err = authenticationDIDKeys(doc)
+		if err != nil {
+			return false, fmt.Errorf("publish the DIDComm service of %s: %w", did, err)
+		}
+	}
+
+	services := []diddoc.Service{svc}
+
+	for i := range doc.Service {
+		if doc.Service[i].Type != vdrapi.DIDCommServiceType {
+			services = append(services, doc.Service[i])
+		}
+	}
+
+	doc.Service = services
+
+	err = registry.Update(doc)
+	if err != nil {
+		return false, fmt.Errorf("publish the DIDComm service of %s: %w", did, err)
+	}
+
+	return true, nil
+}
+
+// authenticationDIDKeys returns the did:keys of the Ed25519 authentication keys of the document.
+func authenticationDIDKeys(doc *diddoc.Doc) ([]string, error) {
+	var didKeys []string
+
+	for _, auth := range doc.Authentication {
+		if auth.VerificationMethod.Type != ed25519VerificationKey2018 {
+			continue
+		}
+
+		didKey, _ := fingerprint.CreateDIDKey(auth.VerificationMethod.Value)
+		didKeys = append(didKeys, didKey)
+	}
+
+	if len(didKeys) == 0 {
+		return nil, ErrNoRecipientKey
+	}
+
+	return didKeys, nil
+}
+
+func equalKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,165 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const publicDID = "did:web:agent.example.com"
+
+func publicDoc(t *testing.T, services ...did.Service) *did.Doc {
+	t.Helper()
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	vm := did.VerificationMethod{
+		ID:         publicDID + "#key-1",
+		Type:       "Ed25519VerificationKey2018",
+		Controller: publicDID,
+		Value:      pubKey,
+	}
+
+	return &did.Doc{
+		ID:                 publicDID,
+		VerificationMethod: []did.VerificationMethod{vm},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(&vm, did.Authentication)},
+		Service:            services,
+	}
+}
+
+func TestPublishDIDCommService(t *testing.T) {
+	t.Run("publishes the service", func(t *testing.T) {
+		doc := publicDoc(t, did.Service{ID: publicDID + "#hub", Type: "hub", ServiceEndpoint: "https://hub.example.com"})
+
+		var updated *did.Doc
+
+		registry := &mockvdr.MockVDRegistry{
+			ResolveValue: doc,
+			UpdateFunc: func(didDoc *did.Doc, _ ...vdrapi.DIDMethodOption) error {
+				updated = didDoc
+				return nil
+			},
+		}
+
+		published, err := PublishDIDCommService(registry, publicDID, "https://agent.example.com", []string{"routing"})
+		require.NoError(t, err)
+		require.True(t, published)
+		require.Len(t, updated.Service, 2)
+
+		didKey, _ := fingerprint.CreateDIDKey(doc.VerificationMethod[0].Value)
+
+		svc, ok := did.LookupService(updated, vdrapi.DIDCommServiceType)
+		require.True(t, ok)
+		require.Equal(t, publicDID+"#didcomm", svc.ID)
+		require.Equal(t, "https://agent.example.com", svc.ServiceEndpoint)
+		require.Equal(t, []string{didKey}, svc.RecipientKeys)
+		require.Equal(t, []string{"routing"}, svc.RoutingKeys)
+	})
+
+	t.Run("replaces the endpoint of the service", func(t *testing.T) {
+		doc := publicDoc(t, did.Service{
+			ID:              publicDID + "#agent",
+			Type:            vdrapi.DIDCommServiceType,
+			ServiceEndpoint: "https://old.example.com",
+			RecipientKeys:   []string{"did:key:recipient"},
+		})
+
+		var updated *did.Doc
+
+		registry := &mockvdr.MockVDRegistry{
+			ResolveValue: doc,
+			UpdateFunc: func(didDoc *did.Doc, _ ...vdrapi.DIDMethodOption) error {
+				updated = didDoc
+				return nil
+			},
+		}
+
+		published, err := PublishDIDCommService(registry, publicDID, "https://agent.example.com", nil)
+		require.NoError(t, err)
+		require.True(t, published)
+		require.Equal(t, []did.Service{{
+			ID:              publicDID + "#agent",
+			Type:            vdrapi.DIDCommServiceType,
+			ServiceEndpoint: "https://agent.example.com",
+			RecipientKeys:   []string{"did:key:recipient"},
+		}}, updated.Service)
+	})
+
+	t.Run("service already published", func(t *testing.T) {
+		registry := &mockvdr.MockVDRegistry{
+			ResolveValue: publicDoc(t, did.Service{
+				Type:            vdrapi.DIDCommServiceType,
+				ServiceEndpoint: "https://agent.example.com",
+			}),
+			SupportsFunc: func(string, vdrapi.Operation) bool { return false },
+		}
+
+		published, err := PublishDIDCommService(registry, publicDID, "https://agent.example.com", nil)
+		require.NoError(t, err)
+		require.False(t, published)
+	})
+
+	t.Run("service published with another endpoint and updates not supported", func(t *testing.T) {
+		registry := &mockvdr.MockVDRegistry{
+			ResolveValue: publicDoc(t, did.Service{
+				Type:            vdrapi.DIDCommServiceType,
+				ServiceEndpoint: "https://proxy.example.com",
+			}),
+			SupportsFunc: func(string, vdrapi.Operation) bool { return false },
+		}
+
+		published, err := PublishDIDCommService(registry, publicDID, "https://agent.example.com", nil)
+		require.NoError(t, err)
+		require.False(t, published)
+	})
+
+	t.Run("service not published and updates not supported", func(t *testing.T) {
+		registry := &mockvdr.MockVDRegistry{
+			ResolveValue: publicDoc(t),
+			SupportsFunc: func(string, vdrapi.Operation) bool { return false },
+		}
+
+		_, err := PublishDIDCommService(registry, publicDID, "https://agent.example.com", nil)
+		require.True(t, errors.Is(err, vdrapi.ErrOperationNotSupported))
+	})
+
+	t.Run("no recipient key", func(t *testing.T) {
+		registry := &mockvdr.MockVDRegistry{ResolveValue: &did.Doc{ID: publicDID}}
+
+		_, err := PublishDIDCommService(registry, publicDID, "https://agent.example.com", nil)
+		require.True(t, errors.Is(err, ErrNoRecipientKey))
+	})
+
+	t.Run("resolve error", func(t *testing.T) {
+		_, err := PublishDIDCommService(&mockvdr.MockVDRegistry{}, publicDID, "https://agent.example.com", nil)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		registry := &mockvdr.MockVDRegistry{
+			ResolveValue: publicDoc(t),
+			UpdateFunc: func(*did.Doc, ...vdrapi.DIDMethodOption) error {
+				return errors.New("update error")
+			},
+		}
+
+		_, err := PublishDIDCommService(registry, publicDID, "https://agent.example.com", nil)
+		require.EqualError(t, err, "publish the DIDComm service of did:web:agent.example.com: update error")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -21,9 +22,12 @@ type Option func(opts *Registry)
 
 // Registry vdr registry.
 type Registry struct {
+	// vdr is copied on write, so that VDRs can be added and removed while DIDs are being resolved
 	vdr                []vdrapi.VDR
+	mu                 sync.RWMutex
 	defServiceEndpoint string
 	defServiceType     string
+	createListeners    []func(didDoc *diddoc.Doc)
 }
 
 // New return new instance of vdr.
@@ -46,7 +50,7 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 	}
 
 	// resolve did method
-	method, err := r.resolveVDR(didMethod)
+	method, err := r.resolveVDRFor(didMethod, vdrapi.Read)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +76,7 @@ func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) er
 	}
 
 	// resolve did method
-	method, err := r.resolveVDR(didMethod)
+	method, err := r.resolveVDRFor(didMethod, vdrapi.Update)
 	if err != nil {
 		return err
 	}
@@ -88,7 +92,7 @@ func (r *Registry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error
 	}
 
 	// resolve did method
-	method, err := r.resolveVDR(didMethod)
+	method, err := r.resolveVDRFor(didMethod, vdrapi.Deactivate)
 	if err != nil {
 		return err
 	}
@@ -107,7 +111,7 @@ func (r *Registry) Create(didMethod string, did *diddoc.Doc,
 		opt(docOpts)
 	}
 
-	method, err := r.resolveVDR(didMethod)
+	method, err := r.resolveVDRFor(didMethod, vdrapi.Create)
 	if err != nil {
 		return nil, err
 	}
@@ -117,9 +121,25 @@ func (r *Registry) Create(didMethod string, did *diddoc.Doc,
 		return nil, err
 	}
 
+	if didDocResolution != nil && didDocResolution.DIDDocument != nil {
+		for _, listener := range r.createListeners {
+			listener(didDocResolution.DIDDocument)
+		}
+	}
+
 	return didDocResolution, nil
 }
 
+// Supports reports whether the VDR of the did method supports the operation.
+func (r *Registry) Supports(didMethod string, op vdrapi.Operation) bool {
+	method, err := r.resolveVDR(didMethod)
+	if err != nil {
+		return false
+	}
+
+	return method.Supports(op)
+}
+
 // applyDefaultDocOpts applies default creator options to doc options.
 func (r *Registry) applyDefaultDocOpts(docOpts *vdrapi.DIDMethodOpts,
 	opts ...vdrapi.DIDMethodOption) []vdrapi.DIDMethodOption {
@@ -134,9 +154,54 @@ func (r *Registry) applyDefaultDocOpts(docOpts *vdrapi.DIDMethodOpts,
 	return opts
 }
 
+// AddVDR registers a VDR while the registry is in use, the VDR takes precedence over the VDRs already registered
+// for the DID methods it accepts.
+func (r *Registry) AddVDR(method vdrapi.VDR) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	vdrs := make([]vdrapi.VDR, 0, len(r.vdr)+1)
+	vdrs = append(vdrs, method)
+
+	r.vdr = append(vdrs, r.vdr...)
+}
+
+// RemoveVDR unregisters and closes the VDRs accepting the given DID method.
+func (r *Registry) RemoveVDR(didMethod string) error {
+	r.mu.Lock()
+
+	var kept, removed []vdrapi.VDR
+
+	for _, v := range r.vdr {
+		if v.Accept(didMethod) {
+			removed = append(removed, v)
+
+			continue
+		}
+
+		kept = append(kept, v)
+	}
+
+	r.vdr = kept
+
+	r.mu.Unlock()
+
+	if len(removed) == 0 {
+		return fmt.Errorf("did method %s not supported for vdr", didMethod)
+	}
+
+	for _, v := range removed {
+		if err := v.Close(); err != nil {
+			return fmt.Errorf("close vdr: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // Close frees resources being maintained by vdr.
 func (r *Registry) Close() error {
-	for _, v := range r.vdr {
+	for _, v := range r.vdrs() {
 		if err := v.Close(); err != nil {
 			return fmt.Errorf("close vdr: %w", err)
 		}
@@ -145,8 +210,15 @@ func (r *Registry) Close() error {
 	return nil
 }
 
+func (r *Registry) vdrs() []vdrapi.VDR {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.vdr
+}
+
 func (r *Registry) resolveVDR(method string) (vdrapi.VDR, error) {
-	for _, v := range r.vdr {
+	for _, v := range r.vdrs() {
 		if v.Accept(method) {
 			return v, nil
 		}
@@ -155,6 +227,19 @@ func (r *Registry) resolveVDR(method string) (vdrapi.VDR, error) {
 	return nil, fmt.Errorf("did method %s not supported for vdr", method)
 }
 
+func (r *Registry) resolveVDRFor(method string, op vdrapi.Operation) (vdrapi.VDR, error) {
+	v, err := r.resolveVDR(method)
+	if err != nil {
+		return nil, err
+	}
+
+	if !v.Supports(op) {
+		return nil, fmt.Errorf("did method %s: %s: %w", method, op, vdrapi.ErrOperationNotSupported)
+	}
+
+	return v, nil
+}
+
 // WithVDR adds did method implementation for store.
 func WithVDR(method vdrapi.VDR) Option {
 	return func(opts *Registry) {
@@ -176,6 +261,13 @@ func WithDefaultServiceEndpoint(serviceEndpoint string) Option {
 	}
 }
 
+// WithCreateListener adds the listener notified of DID documents created by the registry.
+func WithCreateListener(listener func(didDoc *diddoc.Doc)) Option {
+	return func(opts *Registry) {
+		opts.createListeners = append(opts.createListeners, listener)
+	}
+}
+
 // GetDidMethod get did method.
 func GetDidMethod(didID string) (string, error) {
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/20 Validate that the input DID conforms to
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package vdr
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -45,6 +46,47 @@ func TestRegistry_Close(t *testing.T) {
 	})
 }
 
+func TestRegistry_AddVDR(t *testing.T) {
+	registry := New(WithVDR(&mockvdr.MockVDR{AcceptValue: true, ReadFunc: func(didID string,
+		opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+		return nil, fmt.Errorf("read error")
+	}}))
+
+	registry.AddVDR(&mockvdr.MockVDR{AcceptValue: true, ReadFunc: func(didID string,
+		opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+		return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+	}})
+
+	d, err := registry.Resolve("did:example:123")
+	require.NoError(t, err)
+	require.Equal(t, "did:example:123", d.DIDDocument.ID)
+}
+
+func TestRegistry_RemoveVDR(t *testing.T) {
+	t.Run("test success", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{AcceptValue: true}))
+		require.NoError(t, registry.RemoveVDR("example"))
+
+		_, err := registry.Resolve("did:example:123")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did method example not supported for vdr")
+	})
+
+	t.Run("test did method not supported", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{AcceptValue: false}))
+		err := registry.RemoveVDR("example")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did method example not supported for vdr")
+	})
+
+	t.Run("test close error", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{AcceptValue: true, CloseErr: fmt.Errorf("close error")}))
+		err := registry.RemoveVDR("example")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "close error")
+	})
+}
+
 func TestRegistry_Resolve(t *testing.T) {
 	t.Run("test invalid did input", func(t *testing.T) {
 		registry := New()
@@ -176,6 +218,17 @@ func TestRegistry_Deactivate(t *testing.T) {
 		require.Contains(t, err.Error(), "did method id not supported for vdr")
 	})
 
+	t.Run("test operation not supported", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, SupportsFunc: func(op vdrapi.Operation) bool {
+				return op != vdrapi.Deactivate
+			},
+		}))
+		err := registry.Deactivate("1:id:123")
+		require.True(t, errors.Is(err, vdrapi.ErrOperationNotSupported))
+		require.Contains(t, err.Error(), "did method id: deactivate")
+	})
+
 	t.Run("test error from deactivate did", func(t *testing.T) {
 		registry := New(WithVDR(&mockvdr.MockVDR{
 			AcceptValue: true, DeactivateFunc: func(didID string, opts ...vdrapi.DIDMethodOption) error {
@@ -212,6 +265,28 @@ func TestRegistry_Deactivate(t *testing.T) {
 	})
 }
 
+func TestRegistry_Supports(t *testing.T) {
+	readOnly := &mockvdr.MockVDR{
+		AcceptValue: true, SupportsFunc: func(op vdrapi.Operation) bool {
+			return op == vdrapi.Read
+		},
+	}
+
+	registry := New(WithVDR(readOnly))
+	require.True(t, registry.Supports("id", vdrapi.Read))
+	require.False(t, registry.Supports("id", vdrapi.Create))
+	require.False(t, registry.Supports("id", vdrapi.Update))
+
+	_, err := registry.Create("id", &did.Doc{})
+	require.True(t, errors.Is(err, vdrapi.ErrOperationNotSupported))
+
+	err = registry.Update(&did.Doc{ID: "did:id:123"})
+	require.True(t, errors.Is(err, vdrapi.ErrOperationNotSupported))
+
+	registry = New(WithVDR(&mockvdr.MockVDR{AcceptValue: false}))
+	require.False(t, registry.Supports("id", vdrapi.Read))
+}
+
 func TestRegistry_Create(t *testing.T) {
 	t.Run("test did method not supported", func(t *testing.T) {
 		registry := New(WithVDR(&mockvdr.MockVDR{AcceptValue: false}))
@@ -244,6 +319,22 @@ func TestRegistry_Create(t *testing.T) {
 		_, err := registry.Create("id", &did.Doc{VerificationMethod: []did.VerificationMethod{{ID: "key1"}}})
 		require.NoError(t, err)
 	})
+	t.Run("test create listener is notified", func(t *testing.T) {
+		var created []string
+
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true,
+			CreateFunc: func(didDoc *did.Doc,
+				opts ...vdrapi.DIDMethodOption) (doc *did.DocResolution, e error) {
+				return &did.DocResolution{DIDDocument: &did.Doc{ID: "did:id:123"}}, nil
+			},
+		}), WithCreateListener(func(didDoc *did.Doc) {
+			created = append(created, didDoc.ID)
+		}))
+		_, err := registry.Create("id", &did.Doc{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:id:123"}, created)
+	})
 	t.Run("test error from build doc", func(t *testing.T) {
 		registry := New(WithVDR(&mockvdr.MockVDR{
 			AcceptValue: true,
@@ -30,6 +30,11 @@ func (v *VDR) Accept(method string) bool {
 	return method == namespace
 }
 
+// Supports reports whether the operation is supported, the did:web DIDs can only be read.
+func (v *VDR) Supports(op vdrapi.Operation) bool {
+	return op == vdrapi.Read
+}
+
 // Update did doc.
 func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
 	return fmt.Errorf("not supported")
@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 func TestVDRMethods(t *testing.T) {
@@ -17,6 +19,8 @@ func TestVDRMethods(t *testing.T) {
 		v := New()
 		ok := v.Accept("web")
 		require.True(t, ok)
+		require.True(t, v.Supports(vdrapi.Read))
+		require.False(t, v.Supports(vdrapi.Create))
 		err := v.Close()
 		require.Nil(t, err)
 	})
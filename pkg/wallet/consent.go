@@ -0,0 +1,207 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// ConsentReceiptType is the type of the consent receipt credentials created by the wallet when presenting.
+	ConsentReceiptType = "ConsentReceiptCredential"
+
+	// consentReceiptKey is the key prefix and tag of the stored consent receipts.
+	consentReceiptKey = "consentreceipt"
+
+	// consentReceiptVocab is the vocabulary of the consent receipt terms.
+	consentReceiptVocab = "https://w3id.org/aries/consent-receipt#"
+)
+
+// SharedCredential is a credential shared in a presentation, as recorded in a consent receipt.
+type SharedCredential struct {
+	ID     string   `json:"id,omitempty"`
+	Types  []string `json:"type,omitempty"`
+	Issuer string   `json:"issuer,omitempty"`
+}
+
+// newConsentReceipt creates the (unsigned) consent receipt of the presentation shared by the holder
+// with the requester.
+func newConsentReceipt(vp *verifiable.Presentation, requester string, opts *ProofOptions) *verifiable.Credential {
+	now := time.Now().UTC()
+
+	var shared []SharedCredential
+
+	for _, vc := range presentedCredentials(vp) {
+		shared = append(shared, SharedCredential{ID: vc.ID, Types: vc.Types, Issuer: vc.Issuer.ID})
+	}
+
+	if requester == "" {
+		requester = opts.Domain
+	}
+
+	claims := map[string]interface{}{
+		"requester":         requester,
+		"sharedAt":          now.Format(time.RFC3339),
+		"sharedCredentials": shared,
+	}
+
+	for name, value := range map[string]string{
+		"presentationID": vp.ID,
+		"challenge":      opts.Challenge,
+		"domain":         opts.Domain,
+	} {
+		if value != "" {
+			claims[name] = value
+		}
+	}
+
+	return &verifiable.Credential{
+		Context:       []string{verifiable.ContextURI},
+		CustomContext: []interface{}{map[string]interface{}{"@vocab": consentReceiptVocab}},
+		ID:            "urn:uuid:" + uuid.New().String(),
+		Types:         []string{verifiable.VCType, ConsentReceiptType},
+		Issuer:        verifiable.Issuer{ID: opts.Controller},
+		Issued:        util.NewTime(now),
+		Subject:       verifiable.Subject{ID: opts.Controller, CustomFields: claims},
+	}
+}
+
+// presentedCredentials returns the credentials of the presentation, parsing the raw credentials.
+func presentedCredentials(vp *verifiable.Presentation) []*verifiable.Credential {
+	var credentials []*verifiable.Credential
+
+	for _, cred := range vp.Credentials() {
+		switch vc := cred.(type) {
+		case *verifiable.Credential:
+			credentials = append(credentials, vc)
+		default:
+			raw, err := json.Marshal(vc)
+			if err != nil {
+				continue
+			}
+
+			parsed, err := verifiable.ParseCredential(raw, verifiable.WithDisabledProofCheck())
+			if err != nil {
+				continue
+			}
+
+			credentials = append(credentials, parsed)
+		}
+	}
+
+	return credentials
+}
+
+// saveConsentReceipt signs the consent receipt by the holder and saves it.
+func (c *Wallet) saveConsentReceipt(authToken string, receipt *verifiable.Credential, opts *ProofOptions) error {
+	receiptOpts := *opts
+	receiptOpts.Domain = ""
+	receiptOpts.Challenge = ""
+
+	err := c.addLinkedDataProof(authToken, receipt, &receiptOpts, did.Authentication)
+	if err != nil {
+		return fmt.Errorf("failed to sign consent receipt: %w", err)
+	}
+
+	receiptBytes, err := receipt.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal consent receipt: %w", err)
+	}
+
+	return c.contents.store.Put(getContentKeyPrefix(consentReceiptKey, receipt.ID), receiptBytes,
+		storage.Tag{Name: consentReceiptKey})
+}
+
+// ConsentReceipts returns the consent receipts created when presenting credentials, oldest first.
+func (c *Wallet) ConsentReceipts() ([]json.RawMessage, error) {
+	all, err := c.contents.GetAll(consentReceiptKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consent receipts: %w", err)
+	}
+
+	type issuedReceipt struct {
+		raw    json.RawMessage
+		Issued string `json:"issuanceDate"`
+	}
+
+	sorted := make([]*issuedReceipt, 0, len(all))
+
+	for _, raw := range all {
+		r := &issuedReceipt{raw: raw}
+
+		if errUnmarshal := json.Unmarshal(raw, r); errUnmarshal != nil {
+			return nil, fmt.Errorf("failed to read consent receipt: %w", errUnmarshal)
+		}
+
+		sorted = append(sorted, r)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Issued < sorted[j].Issued
+	})
+
+	receipts := make([]json.RawMessage, len(sorted))
+	for i, r := range sorted {
+		receipts[i] = r.raw
+	}
+
+	return receipts, nil
+}
+
+// ExportConsentReceipts exports the consent receipts in a presentation signed by the holder, to be handed over
+// to an auditor or a data protection authority.
+//
+//	Args:
+//		- auth token for unlocking kms.
+//		- proof options of the presentation.
+//
+func (c *Wallet) ExportConsentReceipts(authToken string, proofOptions *ProofOptions) (*verifiable.Presentation, error) {
+	receipts, err := c.ConsentReceipts()
+	if err != nil {
+		return nil, err
+	}
+
+	purpose := did.Authentication
+
+	err = c.validateProofOption(proofOptions, purpose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare proof: %w", err)
+	}
+
+	credentials := make([]*verifiable.Credential, len(receipts))
+
+	for i, receipt := range receipts {
+		credentials[i], err = verifiable.ParseCredential(receipt, verifiable.WithDisabledProofCheck())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse consent receipt: %w", err)
+		}
+	}
+
+	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(credentials...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create presentation: %w", err)
+	}
+
+	vp.Holder = proofOptions.Controller
+
+	err = c.addLinkedDataProof(authToken, vp, proofOptions, purpose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign consent receipts: %w", err)
+	}
+
+	return vp, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	sampleVerifierDID = "did:example:verifier"
+	sampleConsentVCID = "urn:uuid:6bd3c2d3-9a3d-4bd4-a1c3-3e47e5d8a1f0"
+	sampleConsentVC   = `{
+      "@context": ["https://www.w3.org/2018/credentials/v1"],
+      "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+      "id": "urn:uuid:6bd3c2d3-9a3d-4bd4-a1c3-3e47e5d8a1f0",
+      "issuanceDate": "2010-01-01T19:23:24Z",
+      "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+      "type": ["VerifiableCredential"]
+    }`
+)
+
+func newConsentTestWallet(t *testing.T) (*Wallet, string) {
+	t.Helper()
+
+	mockctx := newMockProvider()
+	mockctx.VDRegistryValue = &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			if strings.HasPrefix(didID, "did:key:") {
+				return key.New().Read(didID)
+			}
+
+			return nil, errors.New("did not found")
+		},
+	}
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx.CryptoValue = cryptoSvc
+
+	require.NoError(t, CreateProfile(sampleUserID, mockctx, WithPassphrase(samplePassPhrase)))
+
+	walletInstance, err := New(sampleUserID, mockctx)
+	require.NoError(t, err)
+
+	authToken, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { walletInstance.Close() })
+
+	kmgr, err := keyManager().getKeyManger(authToken)
+	require.NoError(t, err)
+
+	// nolint: errcheck, gosec
+	kmgr.ImportPrivateKey(ed25519.PrivateKey(base58.Decode(pkBase58)), kms.ED25519, kms.WithKeyID(kid))
+
+	return walletInstance, authToken
+}
+
+func TestWallet_ConsentReceipts(t *testing.T) {
+	t.Run("test consent receipt created on prove", func(t *testing.T) {
+		walletInstance, authToken := newConsentTestWallet(t)
+
+		require.NoError(t, walletInstance.Add(authToken, Credential, []byte(sampleConsentVC)))
+
+		receipts, err := walletInstance.ConsentReceipts()
+		require.NoError(t, err)
+		require.Empty(t, receipts)
+
+		vp, err := walletInstance.Prove(authToken, &ProofOptions{
+			Controller: didKey,
+			Challenge:  "c0ae1c8e-c7e7-469f-b252-86e6a0e7387e",
+			Domain:     "example.com",
+		}, WithStoredCredentialsToPresent(sampleConsentVCID), WithRequester(sampleVerifierDID))
+		require.NoError(t, err)
+		require.Len(t, vp.Proofs, 1)
+
+		_, err = walletInstance.Prove(authToken, &ProofOptions{Controller: didKey, Domain: "other.example.com"},
+			WithStoredCredentialsToPresent(sampleConsentVCID))
+		require.NoError(t, err)
+
+		receipts, err = walletInstance.ConsentReceipts()
+		require.NoError(t, err)
+		require.Len(t, receipts, 2)
+
+		for _, raw := range receipts {
+			// receipts are signed by the holder
+			verified, errVerify := walletInstance.Verify(WithRawCredentialToVerify(raw))
+			require.NoError(t, errVerify)
+			require.True(t, verified)
+		}
+
+		var requesters []string
+
+		for _, raw := range receipts {
+			receipt, errParse := verifiable.ParseCredential(raw, verifiable.WithDisabledProofCheck())
+			require.NoError(t, errParse)
+			require.Equal(t, []string{verifiable.VCType, ConsentReceiptType}, receipt.Types)
+			require.Equal(t, didKey, receipt.Issuer.ID)
+
+			subjects, ok := receipt.Subject.([]verifiable.Subject)
+			require.True(t, ok)
+			require.Len(t, subjects, 1)
+			require.Equal(t, didKey, subjects[0].ID)
+			require.NotEmpty(t, subjects[0].CustomFields["sharedAt"])
+
+			shared, ok := subjects[0].CustomFields["sharedCredentials"].([]interface{})
+			require.True(t, ok)
+			require.Len(t, shared, 1)
+			require.Equal(t, sampleConsentVCID, shared[0].(map[string]interface{})["id"])
+
+			requesters = append(requesters, subjects[0].CustomFields["requester"].(string))
+
+			if subjects[0].CustomFields["requester"] == sampleVerifierDID {
+				require.Equal(t, "c0ae1c8e-c7e7-469f-b252-86e6a0e7387e", subjects[0].CustomFields["challenge"])
+				require.Equal(t, "example.com", subjects[0].CustomFields["domain"])
+			}
+		}
+
+		// the proof domain is recorded when the requester is not known
+		require.ElementsMatch(t, []string{sampleVerifierDID, "other.example.com"}, requesters)
+
+		// receipts are not wallet credentials
+		credentials, err := walletInstance.GetAll(Credential)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+	})
+
+	t.Run("test export consent receipts", func(t *testing.T) {
+		walletInstance, authToken := newConsentTestWallet(t)
+
+		require.NoError(t, walletInstance.Add(authToken, Credential, []byte(sampleConsentVC)))
+
+		_, err := walletInstance.Prove(authToken, &ProofOptions{Controller: didKey},
+			WithStoredCredentialsToPresent(sampleConsentVCID), WithRequester(sampleVerifierDID))
+		require.NoError(t, err)
+
+		vp, err := walletInstance.ExportConsentReceipts(authToken, &ProofOptions{Controller: didKey})
+		require.NoError(t, err)
+		require.Equal(t, didKey, vp.Holder)
+		require.Len(t, vp.Credentials(), 1)
+		require.Len(t, vp.Proofs, 1)
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		verified, err := walletInstance.Verify(WithRawPresentationToVerify(vpBytes))
+		require.NoError(t, err)
+		require.True(t, verified)
+
+		_, err = walletInstance.ExportConsentReceipts(authToken, &ProofOptions{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to prepare proof")
+	})
+
+	t.Run("test consent receipt errors", func(t *testing.T) {
+		walletInstance, authToken := newConsentTestWallet(t)
+
+		require.NoError(t, walletInstance.Add(authToken, Credential, []byte(sampleConsentVC)))
+
+		_, err := walletInstance.Prove(sampleFakeTkn, &ProofOptions{Controller: didKey},
+			WithStoredCredentialsToPresent(sampleConsentVCID))
+		require.Error(t, err)
+
+		receipts, err := walletInstance.ConsentReceipts()
+		require.NoError(t, err)
+		require.Empty(t, receipts)
+
+		require.NoError(t, walletInstance.contents.store.Put(getContentKeyPrefix(consentReceiptKey, "invalid"),
+			[]byte("{"), storage.Tag{Name: consentReceiptKey}))
+
+		_, err = walletInstance.ConsentReceipts()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read consent receipt")
+
+		_, err = walletInstance.ExportConsentReceipts(authToken, &ProofOptions{Controller: didKey})
+		require.Error(t, err)
+	})
+}
@@ -91,6 +91,7 @@ func newContentStore(p storage.Provider, pr *profile) (*contentStore, error) {
 
 	err = p.SetStoreConfig(pr.ID, storage.StoreConfiguration{TagNames: []string{
 		Collection.Name(), Credential.Name(), Connection.Name(), DIDResolutionResponse.Name(), Connection.Name(), Key.Name(),
+		consentReceiptKey,
 	}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store config for user '%s' : %w", pr.User, err)
@@ -184,6 +185,8 @@ func (cs *contentStore) GetAll(ct ContentType) (map[string]json.RawMessage, erro
 		return nil, err
 	}
 
+	defer storage.Close(iter, nil)
+
 	result := make(map[string]json.RawMessage)
 
 	for {
@@ -186,7 +186,7 @@ func TestContentStores(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, contentStore)
 		require.EqualValues(t, sp.config.TagNames,
-			[]string{"collection", "credential", "connection", "didResolutionResponse", "connection", "key"})
+			[]string{"collection", "credential", "connection", "didResolutionResponse", "connection", "key", "consentreceipt"})
 	})
 
 	t.Run("create new content store - failure", func(t *testing.T) {
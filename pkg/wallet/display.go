@@ -0,0 +1,192 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// displayMetadataKey is the key prefix of the display metadata of the wallet credentials.
+const displayMetadataKey = "displaymetadata"
+
+// Logo is the logo of an issuer or a credential.
+type Logo struct {
+	URL     string `json:"url,omitempty"`
+	AltText string `json:"alt_text,omitempty"`
+}
+
+// LocalizedDisplay is the display of an issuer, a credential or a claim for a locale.
+// https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-credential-issuer-metadata-p
+type LocalizedDisplay struct {
+	Name            string `json:"name,omitempty"`
+	Locale          string `json:"locale,omitempty"`
+	Logo            *Logo  `json:"logo,omitempty"`
+	Description     string `json:"description,omitempty"`
+	BackgroundColor string `json:"background_color,omitempty"`
+	TextColor       string `json:"text_color,omitempty"`
+}
+
+// DisplayMetadata is the branding of a credential provided by its issuer (OpenID4VCI credential issuer metadata,
+// Credential Manifest output descriptors), stored along with the credential in the wallet.
+type DisplayMetadata struct {
+	// Issuer displays of the issuer, one per locale.
+	Issuer []LocalizedDisplay `json:"issuer,omitempty"`
+	// Credential displays of the credential, one per locale.
+	Credential []LocalizedDisplay `json:"credential,omitempty"`
+	// Claims displays of the credential subject claims by claim name, one per locale.
+	Claims map[string][]LocalizedDisplay `json:"claims,omitempty"`
+}
+
+// ResolvedClaim is a credential subject claim ready to be rendered.
+type ResolvedClaim struct {
+	Name   string      `json:"name"`
+	Label  string      `json:"label"`
+	Value  interface{} `json:"value"`
+	Locale string      `json:"locale,omitempty"`
+}
+
+// ResolvedDisplay is the display of a credential ready to be rendered by wallet UIs.
+type ResolvedDisplay struct {
+	Issuer     *LocalizedDisplay `json:"issuer"`
+	Credential *LocalizedDisplay `json:"credential"`
+	Claims     []ResolvedClaim   `json:"claims,omitempty"`
+}
+
+// saveDisplayMetadata saves the display metadata of the credential with given ID.
+func (cs *contentStore) saveDisplayMetadata(credentialID string, metadata *DisplayMetadata) error {
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal display metadata: %w", err)
+	}
+
+	return cs.store.Put(getContentKeyPrefix(displayMetadataKey, credentialID), metadataBytes)
+}
+
+// getDisplayMetadata returns the display metadata of the credential with given ID, nil if none was stored.
+func (cs *contentStore) getDisplayMetadata(credentialID string) (*DisplayMetadata, error) {
+	metadataBytes, err := cs.store.Get(getContentKeyPrefix(displayMetadataKey, credentialID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var metadata DisplayMetadata
+
+	err = json.Unmarshal(metadataBytes, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read display metadata: %w", err)
+	}
+
+	return &metadata, nil
+}
+
+// removeDisplayMetadata removes the display metadata of the credential with given ID, if any.
+func (cs *contentStore) removeDisplayMetadata(credentialID string) error {
+	err := cs.store.Delete(getContentKeyPrefix(displayMetadataKey, credentialID))
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// resolveDisplay resolves the display of the credential for the locale from its display metadata. Missing displays
+// fall back to the issuer name, the credential type and the claim names.
+func resolveDisplay(vc *verifiable.Credential, metadata *DisplayMetadata, locale string) *ResolvedDisplay {
+	if metadata == nil {
+		metadata = &DisplayMetadata{}
+	}
+
+	resolved := &ResolvedDisplay{
+		Issuer:     selectLocalizedDisplay(metadata.Issuer, locale),
+		Credential: selectLocalizedDisplay(metadata.Credential, locale),
+	}
+
+	if resolved.Issuer == nil {
+		resolved.Issuer = &LocalizedDisplay{Name: vc.Issuer.ID}
+
+		if name, ok := vc.Issuer.CustomFields["name"].(string); ok {
+			resolved.Issuer.Name = name
+		}
+	}
+
+	if resolved.Credential == nil {
+		resolved.Credential = &LocalizedDisplay{}
+
+		if len(vc.Types) > 0 {
+			resolved.Credential.Name = vc.Types[len(vc.Types)-1]
+		}
+	}
+
+	subjects, ok := vc.Subject.([]verifiable.Subject)
+	if !ok {
+		return resolved
+	}
+
+	for _, subject := range subjects {
+		for name, value := range subject.CustomFields {
+			claim := ResolvedClaim{Name: name, Label: name, Value: value}
+
+			if display := selectLocalizedDisplay(metadata.Claims[name], locale); display != nil {
+				claim.Label = display.Name
+				claim.Locale = display.Locale
+			}
+
+			resolved.Claims = append(resolved.Claims, claim)
+		}
+	}
+
+	sort.SliceStable(resolved.Claims, func(i, j int) bool {
+		return resolved.Claims[i].Name < resolved.Claims[j].Name
+	})
+
+	return resolved
+}
+
+// selectLocalizedDisplay selects the display matching the locale, then the display of the same language,
+// then the display without locale and finally the first display.
+func selectLocalizedDisplay(displays []LocalizedDisplay, locale string) *LocalizedDisplay {
+	if len(displays) == 0 {
+		return nil
+	}
+
+	language := strings.SplitN(locale, "-", 2)[0] //nolint:gomnd
+
+	var sameLanguage, noLocale *LocalizedDisplay
+
+	for i := range displays {
+		display := &displays[i]
+
+		switch {
+		case locale != "" && strings.EqualFold(display.Locale, locale):
+			return display
+		case language != "" && sameLanguage == nil &&
+			strings.EqualFold(strings.SplitN(display.Locale, "-", 2)[0], language): //nolint:gomnd
+			sameLanguage = display
+		case display.Locale == "" && noLocale == nil:
+			noLocale = display
+		}
+	}
+
+	if sameLanguage != nil {
+		return sameLanguage
+	}
+
+	if noLocale != nil {
+		return noLocale
+	}
+
+	return &displays[0]
+}
@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	sampleDisplayVCID = "http://example.edu/credentials/1872"
+	sampleDisplayVC   = `{
+      "@context": ["https://www.w3.org/2018/credentials/v1"],
+      "credentialSubject": {
+        "degree": {
+          "type": "BachelorDegree",
+          "university": "MIT"
+        },
+        "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+        "name": "Jayden Doe",
+        "spouse": "did:example:c276e12ec21ebfeb1f712ebc6f1"
+      },
+      "id": "http://example.edu/credentials/1872",
+      "issuanceDate": "2010-01-01T19:23:24Z",
+      "issuer": {
+        "id": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+        "name": "Example University"
+      },
+      "type": ["VerifiableCredential", "UniversityDegreeCredential"]
+    }`
+)
+
+func sampleDisplayMetadata() *DisplayMetadata {
+	return &DisplayMetadata{
+		Issuer: []LocalizedDisplay{
+			{Name: "Example University", Locale: "en-US", Logo: &Logo{URL: "https://example.edu/logo.png"}},
+			{Name: "Université Exemple", Locale: "fr-FR"},
+		},
+		Credential: []LocalizedDisplay{
+			{Name: "University Degree", Locale: "en-US", BackgroundColor: "#12107c", TextColor: "#FFFFFF"},
+			{Name: "Diplôme universitaire", Locale: "fr-FR", BackgroundColor: "#12107c", TextColor: "#FFFFFF"},
+		},
+		Claims: map[string][]LocalizedDisplay{
+			"name":   {{Name: "Full Name", Locale: "en-US"}, {Name: "Nom complet", Locale: "fr-FR"}},
+			"degree": {{Name: "Degree"}},
+		},
+	}
+}
+
+func TestWallet_ResolveDisplay(t *testing.T) {
+	mockctx := newMockProvider()
+	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
+	require.NoError(t, err)
+
+	walletInstance, err := New(sampleUserID, mockctx)
+	require.NotEmpty(t, walletInstance)
+	require.NoError(t, err)
+
+	t.Run("test resolve display from stored metadata", func(t *testing.T) {
+		require.NoError(t, walletInstance.Add(sampleFakeTkn, Credential, []byte(sampleDisplayVC),
+			WithDisplayMetadata(sampleDisplayMetadata())))
+
+		display, err := walletInstance.ResolveDisplay(sampleDisplayVCID, WithDisplayLocale("fr-CA"))
+		require.NoError(t, err)
+		require.Equal(t, "Université Exemple", display.Issuer.Name)
+		require.Equal(t, "Diplôme universitaire", display.Credential.Name)
+		require.Equal(t, "#12107c", display.Credential.BackgroundColor)
+		require.Len(t, display.Claims, 3)
+		require.Equal(t, ResolvedClaim{
+			Name:   "degree",
+			Label:  "Degree",
+			Value:  map[string]interface{}{"type": "BachelorDegree", "university": "MIT"},
+			Locale: "",
+		}, display.Claims[0])
+		require.Equal(t, ResolvedClaim{Name: "name", Label: "Nom complet", Value: "Jayden Doe", Locale: "fr-FR"},
+			display.Claims[1])
+		require.Equal(t, ResolvedClaim{Name: "spouse", Label: "spouse",
+			Value: "did:example:c276e12ec21ebfeb1f712ebc6f1"}, display.Claims[2])
+
+		display, err = walletInstance.ResolveDisplay(sampleDisplayVCID)
+		require.NoError(t, err)
+		require.Equal(t, "Example University", display.Issuer.Name)
+		require.Equal(t, "https://example.edu/logo.png", display.Issuer.Logo.URL)
+		require.Equal(t, "University Degree", display.Credential.Name)
+
+		// display metadata is removed along with the credential
+		require.NoError(t, walletInstance.Remove(Credential, sampleDisplayVCID))
+
+		metadata, err := walletInstance.contents.getDisplayMetadata(sampleDisplayVCID)
+		require.NoError(t, err)
+		require.Nil(t, metadata)
+
+		_, err = walletInstance.ResolveDisplay(sampleDisplayVCID)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("test resolve display without metadata", func(t *testing.T) {
+		require.NoError(t, walletInstance.Add(sampleFakeTkn, Credential, []byte(sampleDisplayVC)))
+
+		defer func() {
+			require.NoError(t, walletInstance.Remove(Credential, sampleDisplayVCID))
+		}()
+
+		display, err := walletInstance.ResolveDisplay(sampleDisplayVCID, WithDisplayLocale("en-US"))
+		require.NoError(t, err)
+		require.Equal(t, &LocalizedDisplay{Name: "Example University"}, display.Issuer)
+		require.Equal(t, &LocalizedDisplay{Name: "UniversityDegreeCredential"}, display.Credential)
+		require.Len(t, display.Claims, 3)
+		require.Equal(t, "name", display.Claims[1].Label)
+	})
+
+	t.Run("test add display metadata failures", func(t *testing.T) {
+		err := walletInstance.Add(sampleFakeTkn, Metadata, []byte(sampleContentValid),
+			WithDisplayMetadata(sampleDisplayMetadata()))
+		require.EqualError(t, err, "display metadata not supported for content type 'metadata'")
+
+		err = walletInstance.Add(sampleFakeTkn, Credential, []byte("{"), WithDisplayMetadata(sampleDisplayMetadata()))
+		require.Error(t, err)
+	})
+
+	t.Run("test resolve display failures", func(t *testing.T) {
+		require.NoError(t, walletInstance.contents.store.Put(
+			getContentKeyPrefix(Credential, "invalid"), []byte(`{"id":"invalid"}`)))
+
+		_, err := walletInstance.ResolveDisplay("invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse credential")
+
+		require.NoError(t, walletInstance.Add(sampleFakeTkn, Credential, []byte(sampleDisplayVC)))
+		require.NoError(t, walletInstance.contents.store.Put(
+			getContentKeyPrefix(displayMetadataKey, sampleDisplayVCID), []byte("{")))
+
+		_, err = walletInstance.ResolveDisplay(sampleDisplayVCID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read display metadata")
+	})
+}
+
+func TestSelectLocalizedDisplay(t *testing.T) {
+	displays := []LocalizedDisplay{
+		{Name: "English (US)", Locale: "en-US"},
+		{Name: "Default"},
+		{Name: "German", Locale: "de"},
+	}
+
+	require.Nil(t, selectLocalizedDisplay(nil, "en-US"))
+	require.Equal(t, "English (US)", selectLocalizedDisplay(displays, "en-us").Name)
+	require.Equal(t, "English (US)", selectLocalizedDisplay(displays, "en-GB").Name)
+	require.Equal(t, "German", selectLocalizedDisplay(displays, "de-AT").Name)
+	require.Equal(t, "Default", selectLocalizedDisplay(displays, "fr-FR").Name)
+	require.Equal(t, "Default", selectLocalizedDisplay(displays, "").Name)
+	require.Equal(t, "English (US)", selectLocalizedDisplay(displays[:1], "fr-FR").Name)
+}
@@ -110,6 +110,8 @@ type proveOpts struct {
 	credentials []*verifiable.Credential
 	// presentation to be supplied to wallet to prove.
 	presentation *verifiable.Presentation
+	// party requesting the presentation, recorded in the consent receipt.
+	requester string
 }
 
 // ProveOptions options for proving credential to present from wallet.
@@ -145,6 +147,14 @@ func WithPresentation(presentation *verifiable.Presentation) ProveOptions {
 	}
 }
 
+// WithRequester option for providing the party (ex: verifier DID) requesting the presentation, recorded in the
+// consent receipt of the presentation. The proof domain is recorded by default.
+func WithRequester(requester string) ProveOptions {
+	return func(opts *proveOpts) {
+		opts.requester = requester
+	}
+}
+
 // verifyOpts contains options for verifying credentials.
 type verifyOpts struct {
 	// ID of the credential to be verified from wallet.
@@ -212,3 +222,37 @@ func FromCredential(cred *verifiable.Credential) CredentialToDerive {
 		opts.credential = cred
 	}
 }
+
+// addContentOpts contains options for adding contents to wallet.
+type addContentOpts struct {
+	// display metadata of the credential.
+	displayMetadata *DisplayMetadata
+}
+
+// AddContentOptions is option for adding contents to wallet.
+type AddContentOptions func(opts *addContentOpts)
+
+// WithDisplayMetadata option for storing issuer branding (logo, colors, localized labels) along with the credential
+// being added, to be resolved by 'ResolveDisplay()'. Only supported for credential contents.
+func WithDisplayMetadata(metadata *DisplayMetadata) AddContentOptions {
+	return func(opts *addContentOpts) {
+		opts.displayMetadata = metadata
+	}
+}
+
+// resolveDisplayOpts contains options for resolving credential display.
+type resolveDisplayOpts struct {
+	// preferred locale of the display.
+	locale string
+}
+
+// ResolveDisplayOptions is option for resolving credential display from wallet.
+type ResolveDisplayOptions func(opts *resolveDisplayOpts)
+
+// WithDisplayLocale option for providing preferred locale (ex: 'en-US') of the resolved display.
+// By default, display without locale or the first display available will be used.
+func WithDisplayLocale(locale string) ResolveDisplayOptions {
+	return func(opts *resolveDisplayOpts) {
+		opts.locale = locale
+	}
+}
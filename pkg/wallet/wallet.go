@@ -244,9 +244,37 @@ func (c *Wallet) Import(auth string, contents json.RawMessage) error {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
+// Issuer branding of a credential can be stored along with the credential by using 'WithDisplayMetadata()' option.
+//
 // TODO: (#2433) support for correlation between wallet contents (ex: credentials to a profile/collection).
-func (c *Wallet) Add(authToken string, contentType ContentType, content json.RawMessage) error {
-	return c.contents.Save(authToken, contentType, content)
+func (c *Wallet) Add(authToken string, contentType ContentType, content json.RawMessage,
+	options ...AddContentOptions) error {
+	opts := &addContentOpts{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	if opts.displayMetadata != nil && contentType != Credential {
+		return fmt.Errorf("display metadata not supported for content type '%s'", contentType)
+	}
+
+	err := c.contents.Save(authToken, contentType, content)
+	if err != nil || opts.displayMetadata == nil {
+		return err
+	}
+
+	credentialID, err := getContentID(content)
+	if err != nil {
+		return err
+	}
+
+	err = c.contents.saveDisplayMetadata(credentialID, opts.displayMetadata)
+	if err != nil {
+		return fmt.Errorf("failed to save display metadata: %w", err)
+	}
+
+	return nil
 }
 
 // Remove removes wallet content by content ID.
@@ -259,7 +287,12 @@ func (c *Wallet) Add(authToken string, contentType ContentType, content json.Raw
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //
 func (c *Wallet) Remove(contentType ContentType, contentID string) error {
-	return c.contents.Remove(contentType, contentID)
+	err := c.contents.Remove(contentType, contentID)
+	if err != nil || contentType != Credential {
+		return err
+	}
+
+	return c.contents.removeDisplayMetadata(contentID)
 }
 
 // Get fetches a wallet content by content ID.
@@ -348,8 +381,16 @@ func (c *Wallet) Issue(authToken string, credential json.RawMessage,
 //		raw credential or a presentation).
 //		- proof options
 //
+// A consent receipt credential recording the requester, the shared credentials, the proof challenge and domain
+// is signed by the holder and stored along with the wallet contents, see 'ConsentReceipts()'.
 func (c *Wallet) Prove(authToken string, proofOptions *ProofOptions, credentials ...ProveOptions) (*verifiable.Presentation, error) { //nolint: lll
-	presentation, err := c.resolveOptionsToPresent(credentials...)
+	opts := &proveOpts{}
+
+	for _, opt := range credentials {
+		opt(opts)
+	}
+
+	presentation, err := c.resolveOptionsToPresent(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve credentials from request: %w", err)
 	}
@@ -368,6 +409,11 @@ func (c *Wallet) Prove(authToken string, proofOptions *ProofOptions, credentials
 		return nil, fmt.Errorf("failed to prove credentials: %w", err)
 	}
 
+	err = c.saveConsentReceipt(authToken, newConsentReceipt(presentation, opts.requester, proofOptions), proofOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save consent receipt: %w", err)
+	}
+
 	return presentation, nil
 }
 
@@ -399,6 +445,39 @@ func (c *Wallet) Verify(options VerificationOption) (bool, error) {
 	}
 }
 
+// ResolveDisplay resolves display data of the stored credential, ready to be rendered by wallet UIs, from the issuer
+// branding stored along with the credential. Credentials without branding are displayed by their issuer name,
+// type and claim names.
+//
+//	Args:
+//		- ID of the stored credential.
+//		- resolve display options (ex: preferred locale).
+//
+func (c *Wallet) ResolveDisplay(credentialID string, options ...ResolveDisplayOptions) (*ResolvedDisplay, error) {
+	opts := &resolveDisplayOpts{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	raw, err := c.contents.Get(Credential, credentialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+
+	vc, err := verifiable.ParseCredential(raw, verifiable.WithDisabledProofCheck())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credential: %w", err)
+	}
+
+	metadata, err := c.contents.getDisplayMetadata(credentialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get display metadata: %w", err)
+	}
+
+	return resolveDisplay(vc, metadata, opts.locale), nil
+}
+
 // Derive derives a credential and returns response credential.
 //
 //	Args:
@@ -423,15 +502,9 @@ func (c *Wallet) Derive(credential CredentialToDerive,
 	return derived, nil
 }
 
-func (c *Wallet) resolveOptionsToPresent(credentials ...ProveOptions) (*verifiable.Presentation, error) {
+func (c *Wallet) resolveOptionsToPresent(opts *proveOpts) (*verifiable.Presentation, error) {
 	var allCredentials []*verifiable.Credential
 
-	opts := &proveOpts{}
-
-	for _, opt := range credentials {
-		opt(opts)
-	}
-
 	for _, id := range opts.storedCredentials {
 		raw, err := c.contents.Get(Credential, id)
 		if err != nil {
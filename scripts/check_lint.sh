@@ -43,6 +43,15 @@ echo "done linting component/storage/edv"
 echo "linting component/storage/leveldb.."
 ${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/storage/leveldb ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
 echo "done linting component/storage/leveldb"
+echo "linting component/storage/redis.."
+${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/storage/redis ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
+echo "done linting component/storage/redis"
+echo "linting component/eventbus/kafka.."
+${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/eventbus/kafka ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
+echo "done linting component/eventbus/kafka"
+echo "linting component/eventbus/nats.."
+${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/eventbus/nats ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
+echo "done linting component/eventbus/nats"
 echo "linting component/storage/indexeddb.."
 ${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -e GOOS=js -e GOARCH=wasm -v $(pwd):/opt/workspace -w /opt/workspace/component/storage/indexeddb ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
 echo "done linting component/storage/indexeddb"
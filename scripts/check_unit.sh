@@ -76,6 +76,19 @@ PKGS=$(go list github.com/hyperledger/aries-framework-go/component/storage/level
 $GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m
 amend_coverage_file
 
+# Running storage/redis unit tests
+cd ../redis/
+PKGS=$(go list github.com/hyperledger/aries-framework-go/component/storage/redis/... 2> /dev/null)
+$GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m
+amend_coverage_file
+
+# Running eventbus/nats unit tests
+cd ../../eventbus/nats/
+PKGS=$(go list github.com/hyperledger/aries-framework-go/component/eventbus/nats/... 2> /dev/null)
+$GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m
+amend_coverage_file
+cd ../../storage/redis/
+
 if [ "$SKIP_DOCKER" = true ]; then
     echo "Skipping edv unit tests"
 else
@@ -103,5 +116,27 @@ docker kill AriesEDVStorageTest >/dev/null
 remove_docker_containers
 fi
 
+if [ "$SKIP_DOCKER" = true ]; then
+    echo "Skipping eventbus/kafka unit tests"
+else
+  # Running eventbus/kafka unit tests
+  cd "$ROOT"
+
+  . "$ROOT"/scripts/start_eventbus_test_docker_images.sh
+
+  cd component/eventbus/kafka
+  PKGS=$(go list github.com/hyperledger/aries-framework-go/component/eventbus/kafka/... 2> /dev/null)
+  GO_TEST_EXIT_CODE=0
+  $GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m  || GO_TEST_EXIT_CODE=$?
+
+  docker kill AriesKafkaEventBusTest >/dev/null
+  docker rm AriesKafkaEventBusTest >/dev/null
+
+  if [ $GO_TEST_EXIT_CODE -ne 0 ]; then
+    exit $GO_TEST_EXIT_CODE
+  fi
+
+amend_coverage_file
+fi
 
 cd "$ROOT" || exit
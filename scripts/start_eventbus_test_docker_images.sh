@@ -0,0 +1,21 @@
+#!/bin/bash
+#
+# Copyright SecureKey Technologies Inc. All Rights Reserved.
+#
+# SPDX-License-Identifier: Apache-2.0
+#
+
+# docker rm returns 1 if the container isn't found. This is OK and expected, so we suppress it.
+docker kill AriesKafkaEventBusTest >/dev/null 2>&1 || true
+docker rm AriesKafkaEventBusTest >/dev/null 2>&1 || true
+
+# Redpanda is a Kafka API compatible broker running in a single container.
+docker run -p 9092:9092 -d --name AriesKafkaEventBusTest docker.vectorized.io/vectorized/redpanda:v21.7.6 redpanda start --overprovisioned --smp 1 --memory 512M --reserve-memory 0M --node-id 0 --check=false --kafka-addr 0.0.0.0:9092 --advertise-kafka-addr localhost:9092 >/dev/null
+
+# wait for the broker to accept the connections
+for i in $(seq 1 30); do
+  if docker exec AriesKafkaEventBusTest rpk cluster info >/dev/null 2>&1; then
+    break
+  fi
+  sleep 1
+done
@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventbus
+
+import (
+	"context"
+)
+
+// Message is a message published on a Bus.
+type Message struct {
+	// ID identifies the message, the implementations may use it to drop the messages published twice.
+	ID string
+	// Topic is the topic the message is published on.
+	Topic string
+	// Key is the ordering key of the message (e.g. the protocol instance ID): the messages with the same key are
+	// delivered in the order they were published.
+	Key string
+	// Payload is the content of the message.
+	Payload []byte
+	// Sequence is the position of the message in the broker log, set on the delivered messages. The sequences are
+	// implementation specific (e.g. a Kafka offset is relative to its partition).
+	Sequence uint64
+}
+
+// SubscribeOptions represents the options of a Subscribe call.
+type SubscribeOptions struct {
+	// Group is the consumer group of the subscription, the messages are delivered to one subscriber of the group.
+	Group string
+	// Replay delivers the messages retained by the broker before the subscription.
+	Replay bool
+}
+
+// SubscribeOption represents an option of a Subscribe call.
+type SubscribeOption func(opts *SubscribeOptions)
+
+// WithGroup sets the consumer group of the subscription: the messages are delivered to one of the subscribers of the
+// group, and the position of the group is kept by the broker across the subscriptions.
+func WithGroup(group string) SubscribeOption {
+	return func(opts *SubscribeOptions) {
+		opts.Group = group
+	}
+}
+
+// WithReplay delivers the messages retained by the broker, starting from the oldest one, before the new messages.
+// For a group already known by the broker, the delivery resumes from the position of the group.
+func WithReplay() SubscribeOption {
+	return func(opts *SubscribeOptions) {
+		opts.Replay = true
+	}
+}
+
+// Bus delivers the events of the agent (e.g. the actions and state changes of the protocols) through a message
+// broker to the backend systems consuming them.
+type Bus interface {
+	// Publish publishes the message on its topic. The message is retained by the broker when Publish returns.
+	Publish(ctx context.Context, msg *Message) error
+
+	// Subscribe returns the messages published on the topic. The channel is closed when ctx is done.
+	// A message is acknowledged to the broker once it has been received from the channel, the messages not
+	// acknowledged are delivered again to the group.
+	Subscribe(ctx context.Context, topic string, options ...SubscribeOption) (<-chan *Message, error)
+
+	// Close releases the resources of the Bus, the connections owned by the caller are not closed.
+	Close() error
+}
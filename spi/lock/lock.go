@@ -0,0 +1,32 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lock
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLockNotHeld is returned by Unlock when the lock is no longer held, e.g. it expired.
+var ErrLockNotHeld = errors.New("lock not held")
+
+// Locker provides named locks shared by the agent instances using the same storage, e.g. the replicas of an agent
+// running behind a load balancer. The protocol services hold the lock of a protocol instance while they load,
+// advance and save its state.
+type Locker interface {
+	// Lock acquires the lock with the given name, waiting until it is released by its current holder.
+	// If ctx is done before the lock is acquired, then an error wrapping the context error will be returned.
+	// Implementations should expire the locks of crashed holders.
+	Lock(ctx context.Context, name string) (Unlocker, error)
+}
+
+// Unlocker releases an acquired lock.
+type Unlocker interface {
+	// Unlock releases the lock. If the lock is no longer held by the caller, then an error wrapping
+	// ErrLockNotHeld will be returned.
+	Unlock() error
+}
@@ -0,0 +1,332 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/client/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/client/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	protocolissuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	protocolpresentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+)
+
+var logger = log.New("aries-framework/test/bench")
+
+const (
+	// stateDIDExchangeCompleted is the final state of the DID exchange protocol.
+	stateDIDExchangeCompleted = "completed"
+	// stateDone is the final state of the issue credential and present proof protocols.
+	stateDone = "done"
+	// eventsBufferSize is the size of the buffers of the action and state channels of an agent.
+	eventsBufferSize = 100
+)
+
+// the credential issued and the presentation presented by the flows, unsigned: the flows measure the protocols
+// rather than the signatures, but the default middleware saves them.
+const (
+	benchCredential = `{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"id": "http://example.edu/credentials/1872",
+		"type": ["VerifiableCredential"],
+		"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+	}`
+	benchPresentation = `{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"type": ["VerifiablePresentation"]
+	}`
+)
+
+// Agent is an in-memory agent, with its protocol clients.
+type Agent struct {
+	Label           string
+	Framework       *aries.Aries
+	DIDExchange     *didexchange.Client
+	IssueCredential *issuecredential.Client
+	PresentProof    *presentproof.Client
+
+	completions *tracker
+	actions     []chan service.DIDCommAction
+	states      []chan service.StateMsg
+	wg          sync.WaitGroup
+}
+
+// Pair is a pair of in-memory agents: Alice invites, issues and verifies, Bob accepts, holds and proves.
+// The agents accept all the protocol actions automatically.
+type Pair struct {
+	Alice *Agent
+	Bob   *Agent
+
+	completions *tracker
+	conn        *connection
+}
+
+// NewPair starts a new pair of agents on the network, their endpoints are named after the given name.
+func NewPair(network *Network, name string) (*Pair, error) {
+	completions := newTracker()
+
+	alice, err := newAgent(network, name+"-alice", completions)
+	if err != nil {
+		return nil, fmt.Errorf("create alice: %w", err)
+	}
+
+	bob, err := newAgent(network, name+"-bob", completions)
+	if err != nil {
+		alice.close() // nolint:errcheck,gosec // the creation error is reported
+
+		return nil, fmt.Errorf("create bob: %w", err)
+	}
+
+	return &Pair{Alice: alice, Bob: bob, completions: completions}, nil
+}
+
+// Close stops the agents of the pair.
+func (p *Pair) Close() error {
+	errAlice := p.Alice.close()
+	errBob := p.Bob.close()
+
+	if errAlice != nil {
+		return errAlice
+	}
+
+	return errBob
+}
+
+func newAgent(network *Network, label string, completions *tracker) (*Agent, error) {
+	framework, err := aries.New(
+		aries.WithStoreProvider(mem.NewProvider()),
+		aries.WithProtocolStateStoreProvider(mem.NewProvider()),
+		aries.WithInboundTransport(network.NewInbound(label)),
+		aries.WithOutboundTransports(network.NewOutbound()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create framework: %w", err)
+	}
+
+	a := &Agent{Label: label, Framework: framework, completions: completions}
+
+	if err = a.createClients(); err != nil {
+		a.close() // nolint:errcheck,gosec // the creation error is reported
+
+		return nil, err
+	}
+
+	if err = a.registerEvents(); err != nil {
+		a.close() // nolint:errcheck,gosec // the creation error is reported
+
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *Agent) createClients() error {
+	ctx, err := a.Framework.Context()
+	if err != nil {
+		return fmt.Errorf("create context: %w", err)
+	}
+
+	if a.DIDExchange, err = didexchange.New(ctx); err != nil {
+		return fmt.Errorf("create didexchange client: %w", err)
+	}
+
+	if a.IssueCredential, err = issuecredential.New(ctx); err != nil {
+		return fmt.Errorf("create issuecredential client: %w", err)
+	}
+
+	if a.PresentProof, err = presentproof.New(ctx); err != nil {
+		return fmt.Errorf("create presentproof client: %w", err)
+	}
+
+	return nil
+}
+
+func (a *Agent) registerEvents() error {
+	for _, event := range []service.Event{a.DIDExchange, a.IssueCredential, a.PresentProof} {
+		actions := make(chan service.DIDCommAction, eventsBufferSize)
+		if err := event.RegisterActionEvent(actions); err != nil {
+			return fmt.Errorf("register action event: %w", err)
+		}
+
+		states := make(chan service.StateMsg, eventsBufferSize)
+		if err := event.RegisterMsgEvent(states); err != nil {
+			return fmt.Errorf("register msg event: %w", err)
+		}
+
+		a.actions = append(a.actions, actions)
+		a.states = append(a.states, states)
+
+		a.wg.Add(2) // nolint:gomnd // the actions and states goroutines
+
+		go a.handleActions(actions)
+		go a.handleStates(states)
+	}
+
+	return nil
+}
+
+// handleActions accepts all the actions: the agent plays the role of the protocol its message asks for.
+func (a *Agent) handleActions(actions <-chan service.DIDCommAction) {
+	defer a.wg.Done()
+
+	for action := range actions {
+		var (
+			piID string
+			err  error
+		)
+
+		if action.Properties != nil {
+			piID, _ = action.Properties.All()["piid"].(string) // empty for DID exchange
+		}
+
+		switch action.Message.Type() {
+		case protocolissuecredential.OfferCredentialMsgType:
+			err = a.IssueCredential.AcceptOffer(piID)
+		case protocolissuecredential.RequestCredentialMsgType:
+			err = a.IssueCredential.AcceptRequest(piID, &issuecredential.IssueCredential{
+				CredentialsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{
+					JSON: json.RawMessage(benchCredential),
+				}}},
+			})
+		case protocolissuecredential.IssueCredentialMsgType:
+			err = a.IssueCredential.AcceptCredential(piID)
+		case protocolpresentproof.RequestPresentationMsgType:
+			err = a.PresentProof.AcceptRequestPresentation(piID, &presentproof.Presentation{
+				PresentationsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{
+					JSON: json.RawMessage(benchPresentation),
+				}}},
+			}, nil)
+		case protocolpresentproof.PresentationMsgType:
+			err = a.PresentProof.AcceptPresentation(piID)
+		default:
+			action.Continue(&service.Empty{})
+		}
+
+		if err != nil {
+			logger.Errorf("%s failed to accept %s: %s", a.Label, action.Message.Type(), err)
+		}
+	}
+}
+
+// handleStates reports the protocol instances reaching their final state.
+func (a *Agent) handleStates(states <-chan service.StateMsg) {
+	defer a.wg.Done()
+
+	for st := range states {
+		if st.Type != service.PostState || st.Properties == nil {
+			continue
+		}
+
+		props := st.Properties.All()
+
+		switch st.StateID {
+		case stateDIDExchangeCompleted:
+			if id, ok := props["invitationID"].(string); ok {
+				a.completions.done(id)
+			}
+		case stateDone:
+			if id, ok := props["piid"].(string); ok {
+				a.completions.done(id)
+			}
+		}
+	}
+}
+
+func (a *Agent) close() error {
+	for i, event := range []service.Event{a.DIDExchange, a.IssueCredential, a.PresentProof} {
+		if i >= len(a.actions) {
+			break
+		}
+
+		// nolint:errcheck // the channels were registered
+		event.UnregisterActionEvent(a.actions[i])
+		// nolint:errcheck // the channels were registered
+		event.UnregisterMsgEvent(a.states[i])
+
+		close(a.actions[i])
+		close(a.states[i])
+	}
+
+	a.wg.Wait()
+
+	return a.Framework.Close()
+}
+
+// tracker counts the agents which completed a protocol instance, identified by its invitation or thread ID.
+// Completions reported before the wait started are kept.
+type tracker struct {
+	mu      sync.Mutex
+	entries map[string]*completion
+}
+
+type completion struct {
+	count int
+	want  int
+	ch    chan struct{}
+}
+
+func newTracker() *tracker {
+	return &tracker{entries: make(map[string]*completion)}
+}
+
+func (t *tracker) entry(id string) *completion {
+	e, ok := t.entries[id]
+	if !ok {
+		e = &completion{ch: make(chan struct{})}
+		t.entries[id] = e
+	}
+
+	return e
+}
+
+// done records that an agent completed the protocol instance.
+func (t *tracker) done(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e := t.entry(id)
+	e.count++
+
+	if e.want > 0 && e.count == e.want {
+		close(e.ch)
+		delete(t.entries, id)
+	}
+}
+
+// wait returns a channel closed once the given number of agents completed the protocol instance.
+func (t *tracker) wait(id string, agents int) <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e := t.entry(id)
+	e.want = agents
+
+	if e.count >= agents {
+		close(e.ch)
+		delete(t.entries, id)
+	}
+
+	return e.ch
+}
+
+// forget drops the protocol instance, when the wait is abandoned.
+func (t *tracker) forget(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.entries, id)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	load        = flag.Bool("load", false, "run the load test")
+	flows       = flag.String("flows", "didexchange,issuecredential,presentproof", "flows of the load test")
+	pairs       = flag.Int("pairs", 1, "agent pairs of the load test")
+	concurrency = flag.Int("concurrency", 1, "concurrency of the load test")
+	iterations  = flag.Int("iterations", defaultIterations, "iterations of each flow of the load test")
+	out         = flag.String("out", "", "file the load test results are written to (stdout by default)")
+)
+
+func TestRun(t *testing.T) {
+	for name, flow := range Flows() {
+		flow := flow
+
+		t.Run(name, func(t *testing.T) {
+			result, err := Run(flow, Config{Pairs: 2, Concurrency: 4, Iterations: 8, Timeout: 10 * time.Second})
+			require.NoError(t, err)
+			require.Empty(t, result.FirstError)
+			require.Equal(t, name, result.Flow)
+			require.Equal(t, 8, result.Iterations)
+			require.Zero(t, result.Errors)
+			require.Positive(t, result.Throughput)
+			require.Positive(t, result.Latency.Min)
+			require.LessOrEqual(t, result.Latency.Min, result.Latency.P50)
+			require.LessOrEqual(t, result.Latency.P50, result.Latency.P99)
+			require.LessOrEqual(t, result.Latency.P99, result.Latency.Max)
+		})
+	}
+
+	t.Run("flow is mandatory", func(t *testing.T) {
+		_, err := Run(nil, Config{})
+		require.EqualError(t, err, "flow is mandatory")
+	})
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteJSON(&buf, &Result{Flow: "didexchange", Iterations: 1, Latency: Latency{P50: 1.5}}))
+
+	var results []map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+	require.Len(t, results, 1)
+	require.Equal(t, "didexchange", results[0]["flow"])
+	require.Equal(t, 1.5, results[0]["latency"].(map[string]interface{})["p50_ms"])
+
+	buf.Reset()
+	require.NoError(t, WriteJSON(&buf))
+	require.Equal(t, "[]\n", buf.String())
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	require.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	require.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	require.Equal(t, time.Millisecond, percentile(latencies[:1], 50))
+
+	stats := latencyStats([]time.Duration{3 * time.Millisecond, time.Millisecond, 2 * time.Millisecond})
+	require.Equal(t, Latency{Min: 1, Mean: 2, P50: 2, P90: 3, P99: 3, Max: 3}, stats)
+	require.Equal(t, Latency{}, latencyStats(nil))
+}
+
+func TestTracker(t *testing.T) {
+	tr := newTracker()
+
+	tr.done("early")
+
+	completed := tr.wait("early", 2)
+
+	select {
+	case <-completed:
+		require.Fail(t, "completed by a single agent")
+	default:
+	}
+
+	tr.done("early")
+	<-completed
+
+	tr.done("done")
+	tr.done("done")
+	<-tr.wait("done", 2)
+
+	tr.done("forgotten")
+	tr.forget("forgotten")
+	require.Empty(t, tr.entries)
+}
+
+func TestLoad(t *testing.T) {
+	if !*load {
+		t.Skip("load test is run with -load")
+	}
+
+	var results []*Result
+
+	for _, name := range strings.Split(*flows, ",") {
+		flow, ok := Flows()[strings.TrimSpace(name)]
+		require.True(t, ok, "unknown flow %s", name)
+
+		result, err := Run(flow, Config{Pairs: *pairs, Concurrency: *concurrency, Iterations: *iterations})
+		require.NoError(t, err)
+
+		results = append(results, result)
+	}
+
+	w := os.Stdout
+
+	if *out != "" {
+		f, err := os.Create(*out)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, f.Close())
+		}()
+
+		w = f
+	}
+
+	require.NoError(t, WriteJSON(w, results...))
+}
+
+func BenchmarkDIDExchange(b *testing.B) {
+	benchmarkFlow(b, DIDExchange())
+}
+
+func BenchmarkIssueCredential(b *testing.B) {
+	benchmarkFlow(b, IssueCredential())
+}
+
+func BenchmarkPresentProof(b *testing.B) {
+	benchmarkFlow(b, PresentProof())
+}
+
+func benchmarkFlow(b *testing.B, flow Flow) {
+	b.Helper()
+
+	pair, err := NewPair(NewNetwork(), flow.Name())
+	require.NoError(b, err)
+
+	defer func() {
+		require.NoError(b, pair.Close())
+	}()
+
+	require.NoError(b, flow.Setup(pair))
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := flow.Run(pair, defaultTimeout); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
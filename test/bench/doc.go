@@ -0,0 +1,24 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bench is the benchmark and load-test harness of the framework. It starts pairs of agents connected by
+// an in-memory transport, so that no network I/O is measured, and runs end-to-end protocol flows (DID exchange,
+// credential issuance, proof presentation) on them with a configurable concurrency.
+//
+// The results are reported in JSON, so that the CI can compare the runs and catch the performance regressions:
+//
+//	result, err := bench.Run(bench.IssueCredential(), bench.Config{Concurrency: 10, Iterations: 1000})
+//	if err != nil {
+//		return err
+//	}
+//
+//	return bench.WriteJSON(os.Stdout, result)
+//
+// The load test of the package runs the flows with the given settings and writes the results to a file:
+//
+//	go test ./test/bench -run TestLoad -args -load -flows=didexchange,issuecredential -concurrency=10 \
+//		-iterations=1000 -out=bench.json
+package bench
@@ -0,0 +1,196 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bench
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
+)
+
+const (
+	// pairAgents is the number of agents completing each protocol instance of a pair.
+	pairAgents = 2
+	// setupTimeout is the timeout of the connection of the agents during the setup.
+	setupTimeout = time.Minute
+)
+
+// Flow is an end-to-end protocol flow measured by the harness.
+type Flow interface {
+	// Name of the flow, as reported in the results.
+	Name() string
+	// Setup prepares the pair before the flow is run, e.g. connects the agents.
+	Setup(pair *Pair) error
+	// Run runs the flow once, it returns once both agents completed it or the timeout expired.
+	Run(pair *Pair, timeout time.Duration) error
+}
+
+// DIDExchange returns the flow connecting the agents of the pair with the DID exchange protocol.
+func DIDExchange() Flow {
+	return &didExchangeFlow{}
+}
+
+// IssueCredential returns the flow of Alice issuing a credential to Bob, over a connection established during
+// the setup.
+func IssueCredential() Flow {
+	return &issueCredentialFlow{}
+}
+
+// PresentProof returns the flow of Alice requesting a presentation to Bob, over a connection established during
+// the setup.
+func PresentProof() Flow {
+	return &presentProofFlow{}
+}
+
+// Flows returns all the flows of the harness, by name.
+func Flows() map[string]Flow {
+	flows := make(map[string]Flow)
+
+	for _, f := range []Flow{DIDExchange(), IssueCredential(), PresentProof()} {
+		flows[f.Name()] = f
+	}
+
+	return flows
+}
+
+type didExchangeFlow struct{}
+
+func (f *didExchangeFlow) Name() string {
+	return "didexchange"
+}
+
+func (f *didExchangeFlow) Setup(*Pair) error {
+	return nil
+}
+
+func (f *didExchangeFlow) Run(pair *Pair, timeout time.Duration) error {
+	_, err := connect(pair, timeout)
+
+	return err
+}
+
+// connection holds the DIDs of the agents of a pair connected with each other.
+type connection struct {
+	aliceDID string
+	bobDID   string
+}
+
+// connect connects the agents of the pair: Alice invites Bob.
+func connect(pair *Pair, timeout time.Duration) (*connection, error) {
+	invitation, err := pair.Alice.DIDExchange.CreateInvitation(pair.Alice.Label)
+	if err != nil {
+		return nil, fmt.Errorf("create invitation: %w", err)
+	}
+
+	completed := pair.completions.wait(invitation.ID, pairAgents)
+
+	connectionID, err := pair.Bob.DIDExchange.HandleInvitation(invitation)
+	if err != nil {
+		pair.completions.forget(invitation.ID)
+
+		return nil, fmt.Errorf("handle invitation: %w", err)
+	}
+
+	if err = await(pair, invitation.ID, completed, timeout); err != nil {
+		return nil, fmt.Errorf("did exchange: %w", err)
+	}
+
+	conn, err := pair.Bob.DIDExchange.GetConnection(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	return &connection{aliceDID: conn.TheirDID, bobDID: conn.MyDID}, nil
+}
+
+// setupConnection connects the agents of the pair once, the connection is shared by the flows run on the pair.
+func setupConnection(pair *Pair) error {
+	if pair.conn != nil {
+		return nil
+	}
+
+	conn, err := connect(pair, setupTimeout)
+	if err != nil {
+		return err
+	}
+
+	pair.conn = conn
+
+	return nil
+}
+
+type issueCredentialFlow struct{}
+
+func (f *issueCredentialFlow) Name() string {
+	return "issuecredential"
+}
+
+func (f *issueCredentialFlow) Setup(pair *Pair) error {
+	return setupConnection(pair)
+}
+
+func (f *issueCredentialFlow) Run(pair *Pair, timeout time.Duration) error {
+	conn := pair.conn
+	if conn == nil {
+		return fmt.Errorf("%s: agents are not connected", f.Name())
+	}
+
+	piID, err := pair.Alice.IssueCredential.SendOffer(&issuecredential.OfferCredential{},
+		conn.aliceDID, conn.bobDID)
+	if err != nil {
+		return fmt.Errorf("send offer: %w", err)
+	}
+
+	// the instance may complete before the wait starts, the tracker keeps the early completions
+	if err = await(pair, piID, pair.completions.wait(piID, pairAgents), timeout); err != nil {
+		return fmt.Errorf("issue credential: %w", err)
+	}
+
+	return nil
+}
+
+type presentProofFlow struct{}
+
+func (f *presentProofFlow) Name() string {
+	return "presentproof"
+}
+
+func (f *presentProofFlow) Setup(pair *Pair) error {
+	return setupConnection(pair)
+}
+
+func (f *presentProofFlow) Run(pair *Pair, timeout time.Duration) error {
+	conn := pair.conn
+	if conn == nil {
+		return fmt.Errorf("%s: agents are not connected", f.Name())
+	}
+
+	piID, err := pair.Alice.PresentProof.SendRequestPresentation(&presentproof.RequestPresentation{},
+		conn.aliceDID, conn.bobDID)
+	if err != nil {
+		return fmt.Errorf("send request presentation: %w", err)
+	}
+
+	if err = await(pair, piID, pair.completions.wait(piID, pairAgents), timeout); err != nil {
+		return fmt.Errorf("present proof: %w", err)
+	}
+
+	return nil
+}
+
+func await(pair *Pair, id string, completed <-chan struct{}, timeout time.Duration) error {
+	select {
+	case <-completed:
+		return nil
+	case <-time.After(timeout):
+		pair.completions.forget(id)
+
+		return fmt.Errorf("%s not completed after %s", id, timeout)
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultIterations = 100
+	defaultTimeout    = 30 * time.Second
+)
+
+// Config of a run of the harness.
+type Config struct {
+	// Pairs is the number of agent pairs the flow is run on (1 by default).
+	Pairs int
+	// Concurrency is the number of flows run at the same time, spread across the pairs (1 by default).
+	Concurrency int
+	// Iterations is the total number of flows run (100 by default).
+	Iterations int
+	// Timeout of a single flow (30s by default).
+	Timeout time.Duration
+}
+
+func (c *Config) withDefaults() Config {
+	cfg := *c
+
+	if cfg.Pairs <= 0 {
+		cfg.Pairs = 1
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+
+	if cfg.Iterations <= 0 {
+		cfg.Iterations = defaultIterations
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return cfg
+}
+
+// Result of a run of the harness, the durations are in milliseconds.
+type Result struct {
+	Flow        string  `json:"flow"`
+	Pairs       int     `json:"pairs"`
+	Concurrency int     `json:"concurrency"`
+	Iterations  int     `json:"iterations"`
+	Errors      int     `json:"errors"`
+	DurationMs  float64 `json:"duration_ms"`
+	Throughput  float64 `json:"throughput_per_sec"`
+	Latency     Latency `json:"latency"`
+	// FirstError is the first error of the failed flows, if any.
+	FirstError string `json:"first_error,omitempty"`
+}
+
+// Latency statistics of the successful flows, in milliseconds.
+type Latency struct {
+	Min  float64 `json:"min_ms"`
+	Mean float64 `json:"mean_ms"`
+	P50  float64 `json:"p50_ms"`
+	P90  float64 `json:"p90_ms"`
+	P99  float64 `json:"p99_ms"`
+	Max  float64 `json:"max_ms"`
+}
+
+// Run starts the agent pairs on an in-memory network, sets them up for the flow, then runs the flow the configured
+// number of times and returns the measured throughput and latencies.
+func Run(flow Flow, config Config) (*Result, error) {
+	if flow == nil {
+		return nil, errors.New("flow is mandatory")
+	}
+
+	cfg := config.withDefaults()
+	network := NewNetwork()
+
+	pairs := make([]*Pair, 0, cfg.Pairs)
+
+	defer func() {
+		for _, p := range pairs {
+			if err := p.Close(); err != nil {
+				logger.Warnf("failed to close agent pair: %s", err)
+			}
+		}
+	}()
+
+	for i := 0; i < cfg.Pairs; i++ {
+		pair, err := NewPair(network, fmt.Sprintf("%s-%d", flow.Name(), i))
+		if err != nil {
+			return nil, fmt.Errorf("start agent pair: %w", err)
+		}
+
+		pairs = append(pairs, pair)
+
+		if err = flow.Setup(pair); err != nil {
+			return nil, fmt.Errorf("setup %s: %w", flow.Name(), err)
+		}
+	}
+
+	return run(flow, pairs, cfg), nil
+}
+
+func run(flow Flow, pairs []*Pair, cfg Config) *Result {
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		latencies = make([]time.Duration, 0, cfg.Iterations)
+		failures  int
+		firstErr  error
+	)
+
+	iterations := make(chan int)
+
+	start := time.Now()
+
+	for w := 0; w < cfg.Concurrency; w++ {
+		wg.Add(1)
+
+		go func(pair *Pair) {
+			defer wg.Done()
+
+			for range iterations {
+				begin := time.Now()
+				err := flow.Run(pair, cfg.Timeout)
+				elapsed := time.Since(begin)
+
+				mu.Lock()
+				if err != nil {
+					failures++
+
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					latencies = append(latencies, elapsed)
+				}
+				mu.Unlock()
+			}
+		}(pairs[w%len(pairs)])
+	}
+
+	for i := 0; i < cfg.Iterations; i++ {
+		iterations <- i
+	}
+
+	close(iterations)
+	wg.Wait()
+
+	duration := time.Since(start)
+
+	result := &Result{
+		Flow:        flow.Name(),
+		Pairs:       len(pairs),
+		Concurrency: cfg.Concurrency,
+		Iterations:  cfg.Iterations,
+		Errors:      failures,
+		DurationMs:  ms(duration),
+		Latency:     latencyStats(latencies),
+	}
+
+	if duration > 0 {
+		result.Throughput = float64(len(latencies)) / duration.Seconds()
+	}
+
+	if firstErr != nil {
+		result.FirstError = firstErr.Error()
+	}
+
+	return result
+}
+
+func latencyStats(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	var total time.Duration
+
+	for _, l := range latencies {
+		total += l
+	}
+
+	return Latency{
+		Min:  ms(latencies[0]),
+		Mean: ms(total / time.Duration(len(latencies))),
+		P50:  ms(percentile(latencies, 50)), // nolint:gomnd // 50th percentile
+		P90:  ms(percentile(latencies, 90)), // nolint:gomnd // 90th percentile
+		P99:  ms(percentile(latencies, 99)), // nolint:gomnd // 99th percentile
+		Max:  ms(latencies[len(latencies)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100 // nolint:gomnd // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// WriteJSON writes the results as a JSON array, for the comparison of the runs by the CI.
+func WriteJSON(w io.Writer, results ...*Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if results == nil {
+		results = []*Result{}
+	}
+
+	if err := enc.Encode(results); err != nil {
+		return fmt.Errorf("write results: %w", err)
+	}
+
+	return nil
+}
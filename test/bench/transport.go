@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+// endpointScheme is the scheme of the in-memory transport endpoints.
+const endpointScheme = "mem://"
+
+// Network connects the in-memory transports of the agents: the messages sent to an endpoint are delivered to
+// the inbound transport registered for it, without any network I/O so that only the framework is measured.
+type Network struct {
+	mu       sync.RWMutex
+	inbounds map[string]transport.Provider
+}
+
+// NewNetwork returns a new in-memory network.
+func NewNetwork() *Network {
+	return &Network{inbounds: make(map[string]transport.Provider)}
+}
+
+// NewInbound returns a new inbound transport listening on the given endpoint name.
+func (n *Network) NewInbound(name string) *Inbound {
+	return &Inbound{network: n, endpoint: endpointScheme + name}
+}
+
+// NewOutbound returns a new outbound transport sending to the inbound transports of the network.
+func (n *Network) NewOutbound() *Outbound {
+	return &Outbound{network: n}
+}
+
+func (n *Network) register(endpoint string, prov transport.Provider) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.inbounds[endpoint]; ok {
+		return fmt.Errorf("endpoint %s is already in use", endpoint)
+	}
+
+	n.inbounds[endpoint] = prov
+
+	return nil
+}
+
+func (n *Network) unregister(endpoint string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.inbounds, endpoint)
+}
+
+// deliver unpacks the message with the packager of the receiving agent then hands it to its inbound handler,
+// as the HTTP inbound transport does for a POST request.
+func (n *Network) deliver(data []byte, endpoint string) error {
+	n.mu.RLock()
+	prov, ok := n.inbounds[endpoint]
+	n.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("no inbound transport for endpoint %s", endpoint)
+	}
+
+	envelope, err := prov.Packager().UnpackMessage(data)
+	if err != nil {
+		return fmt.Errorf("failed to unpack msg: %w", err)
+	}
+
+	return prov.InboundMessageHandler()(envelope)
+}
+
+// Inbound is the in-memory inbound transport.
+type Inbound struct {
+	network  *Network
+	endpoint string
+}
+
+// Start registers the inbound transport in the network.
+func (i *Inbound) Start(prov transport.Provider) error {
+	if prov == nil || prov.InboundMessageHandler() == nil {
+		return errors.New("creation of inbound handler failed")
+	}
+
+	return i.network.register(i.endpoint, prov)
+}
+
+// Stop unregisters the inbound transport from the network.
+func (i *Inbound) Stop() error {
+	i.network.unregister(i.endpoint)
+
+	return nil
+}
+
+// Endpoint returns the endpoint of the inbound transport.
+func (i *Inbound) Endpoint() string {
+	return i.endpoint
+}
+
+// Outbound is the in-memory outbound transport.
+type Outbound struct {
+	network *Network
+}
+
+// Start the outbound transport.
+func (o *Outbound) Start(transport.Provider) error {
+	return nil
+}
+
+// Send delivers the data to the inbound transport of the destination, synchronously.
+func (o *Outbound) Send(data []byte, destination *service.Destination) (string, error) {
+	if destination == nil || destination.ServiceEndpoint == "" {
+		return "", errors.New("destination service endpoint is mandatory")
+	}
+
+	if err := o.network.deliver(data, destination.ServiceEndpoint); err != nil {
+		return "", fmt.Errorf("in-memory send to %s: %w", destination.ServiceEndpoint, err)
+	}
+
+	return "", nil
+}
+
+// AcceptRecipient returns false: the in-memory transport keeps no connections.
+func (o *Outbound) AcceptRecipient([]string) bool {
+	return false
+}
+
+// Accept returns true for the in-memory endpoints.
+func (o *Outbound) Accept(url string) bool {
+	return strings.HasPrefix(url, endpointScheme)
+}
@@ -0,0 +1,212 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const concurrentWorkers = 10
+
+// TestStoreIterator tests the common behaviour of the iterators returned by Store Query: every result is returned
+// once and an exhausted iterator keeps returning false without error.
+func TestStoreIterator(t *testing.T, provider spi.Provider) {
+	storeName := randomStoreName()
+
+	store, err := provider.OpenStore(storeName)
+	require.NoError(t, err)
+
+	err = provider.SetStoreConfig(storeName, spi.StoreConfiguration{TagNames: []string{"tagName1", "tagName2"}})
+	require.NoError(t, err)
+
+	putData(t, store, []string{"key1", "key2", "key3"},
+		[][]byte{[]byte("value1"), []byte("value2"), []byte("value3")},
+		[][]spi.Tag{{{Name: "tagName1"}}, {{Name: "tagName1"}}, {{Name: "tagName1"}}})
+
+	t.Run("Every result is returned once", func(t *testing.T) {
+		itr, err := store.Query("tagName1", spi.WithPageSize(2))
+		require.NoError(t, err)
+
+		received := make(map[string]int)
+
+		more, err := itr.Next()
+		require.NoError(t, err)
+
+		for more {
+			key, err := itr.Key()
+			require.NoError(t, err)
+
+			received[key]++
+
+			more, err = itr.Next()
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, map[string]int{"key1": 1, "key2": 1, "key3": 1}, received)
+
+		more, err = itr.Next()
+		require.NoError(t, err)
+		require.False(t, more, "exhausted iterator returned more results")
+
+		require.NoError(t, itr.Close())
+	})
+	t.Run("No results", func(t *testing.T) {
+		itr, err := store.Query("tagName2")
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			more, err := itr.Next()
+			require.NoError(t, err)
+			require.False(t, more)
+		}
+
+		require.NoError(t, itr.Close())
+	})
+}
+
+// TestStoreConcurrency tests that Stores can be used from multiple goroutines, as the framework's services share
+// their Stores: the data put concurrently is stored without loss.
+func TestStoreConcurrency(t *testing.T, provider spi.Provider) { //nolint:funlen // Test file
+	storeName := randomStoreName()
+
+	store, err := provider.OpenStore(storeName)
+	require.NoError(t, err)
+
+	err = provider.SetStoreConfig(storeName, spi.StoreConfiguration{TagNames: []string{"worker"}})
+	require.NoError(t, err)
+
+	const keysPerWorker = 10
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, concurrentWorkers)
+
+	for w := 0; w < concurrentWorkers; w++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			errs <- doConcurrentWork(provider, storeName, worker, keysPerWorker)
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, store.Flush())
+
+	for w := 0; w < concurrentWorkers; w++ {
+		itr, err := store.Query(fmt.Sprintf("worker:%d", w))
+		require.NoError(t, err)
+
+		count := 0
+
+		more, err := itr.Next()
+		require.NoError(t, err)
+
+		for more {
+			count++
+
+			more, err = itr.Next()
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, itr.Close())
+
+		// the first key of each worker is deleted, the others are put by Put and Batch
+		require.Equal(t, 2*keysPerWorker-1, count, "unexpected number of values of worker %d", w)
+	}
+}
+
+// doConcurrentWork opens the store and puts, gets, queries and deletes data of worker in it.
+func doConcurrentWork(provider spi.Provider, storeName string, worker, keys int) error {
+	store, err := provider.OpenStore(storeName)
+	if err != nil {
+		return fmt.Errorf("open store: %w", err)
+	}
+
+	tag := spi.Tag{Name: "worker", Value: fmt.Sprint(worker)}
+
+	operations := make([]spi.Operation, keys)
+
+	for i := 0; i < keys; i++ {
+		key := fmt.Sprintf("worker%d-put%d", worker, i)
+
+		if err = store.Put(key, []byte(key), tag); err != nil {
+			return fmt.Errorf("put %s: %w", key, err)
+		}
+
+		value, errGet := store.Get(key)
+		if errGet != nil && !errors.Is(errGet, spi.ErrDataNotFound) { // a batching Store may not have flushed yet
+			return fmt.Errorf("get %s: %w", key, errGet)
+		}
+
+		if errGet == nil && string(value) != key {
+			return fmt.Errorf("get %s: unexpected value %s", key, value)
+		}
+
+		batchKey := fmt.Sprintf("worker%d-batch%d", worker, i)
+		operations[i] = spi.Operation{Key: batchKey, Value: []byte(batchKey), Tags: []spi.Tag{tag}}
+	}
+
+	if err = store.Batch(operations); err != nil {
+		return fmt.Errorf("batch: %w", err)
+	}
+
+	if err = store.Delete(fmt.Sprintf("worker%d-put0", worker)); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	itr, err := store.Query(tag.Name + ":" + tag.Value)
+	if err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	for more, errNext := itr.Next(); more; more, errNext = itr.Next() {
+		if errNext != nil {
+			return fmt.Errorf("iterator next: %w", errNext)
+		}
+	}
+
+	return itr.Close()
+}
+
+// TestStoreBatchAtomicity tests that a failing Batch call does not perform any of its operations.
+// The Store interface does not require atomic batches, so this test is not part of TestAll: it should be run
+// by implementations documenting this guarantee.
+func TestStoreBatchAtomicity(t *testing.T, provider spi.Provider) {
+	store, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	err = store.Put("key1", []byte("value1"))
+	require.NoError(t, err)
+
+	err = store.Batch([]spi.Operation{
+		{Key: "key1", Value: []byte("value1_new")},
+		{Key: "key2", Value: []byte("value2")},
+		{Key: "", Value: []byte("value3")},
+	})
+	require.Error(t, err)
+
+	value, err := store.Get("key1")
+	require.NoError(t, err)
+	require.Equal(t, "value1", string(value))
+
+	_, err = store.Get("key2")
+	require.True(t, errors.Is(err, spi.ErrDataNotFound), "got unexpected error or no error")
+}
@@ -4,6 +4,9 @@ SPDX-License-Identifier: Apache-2.0
 */
 
 // Package storage contains common tests for storage provider implementations.
+//
+// The tests are the conformance suite of the spi/storage interfaces: authors of custom storage providers run TestAll
+// (and TestStoreBatchAtomicity if their stores guarantee atomic batches) against their implementation.
 package storage
 
 import (
@@ -45,6 +48,12 @@ func TestAll(t *testing.T, provider spi.Provider) {
 		t.Run("Batch", func(t *testing.T) {
 			TestStoreBatch(t, provider)
 		})
+		t.Run("Iterator", func(t *testing.T) {
+			TestStoreIterator(t, provider)
+		})
+		t.Run("Concurrency", func(t *testing.T) {
+			TestStoreConcurrency(t, provider)
+		})
 		t.Run("Flush", func(t *testing.T) {
 			TestStoreFlush(t, provider)
 		})